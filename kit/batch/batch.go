@@ -0,0 +1,419 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	stdsync "sync"
+	"time"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+var (
+	// ErrClosed 表示批处理器已经关闭。
+	ErrClosed = errors.New("kit/batch: 批处理器已关闭")
+
+	// sizerType 是 Sizer 接口的类型，用于检查元素类型能否计算大小。
+	sizerType = reflect.TypeFor[Sizer]()
+)
+
+type (
+	// FlushFunc 定义了提交一批元素的函数。
+	// 返回错误时，如果开启了重试，同一批元素会被再次提交，因此提交操作应当是幂等的。
+	//
+	// 参数：
+	//   - ctx：上下文，在提交超时或批处理器强制关闭时取消。
+	//   - items：本批元素，按加入的顺序排列，提交函数可以持有该切片。
+	//
+	// 返回值：
+	//   - error：提交失败时返回错误。
+	FlushFunc[T any] func(ctx context.Context, items []T) error
+
+	// Sizer 定义了能够计算自身大小的元素，用于 WithMaxBytes。
+	Sizer interface {
+		// Size 返回元素的字节数。
+		Size() int
+	}
+
+	// Batcher 将元素按数量、字节数与等待时间分批，交给提交函数处理。
+	// 提交在单独的协程中按顺序进行，同一时间只有一批在提交；提交跟不上时 Add 阻塞，以此向调用方施加背压。
+	// 所有方法都是并发安全的。
+	Batcher[T any] struct {
+		// flush 是提交函数。
+		flush FlushFunc[T]
+		// opts 是批处理器的配置。
+		opts *options
+		// size 计算元素的字节数，未设置 WithMaxBytes 时为 nil。
+		size func(T) int
+		// ctx 是提交使用的根上下文，强制关闭时取消。
+		ctx context.Context
+		// cancel 取消 ctx。
+		cancel context.CancelFunc
+		// kick 通知提交协程有新的批次或计时需要更新。
+		kick chan struct{}
+		// done 在提交协程退出时关闭。
+		done chan struct{}
+
+		// mu 保护以下字段。
+		mu stdsync.Mutex
+		// items 是正在积累的元素。
+		items []T
+		// bytes 是 items 的字节数。
+		bytes int
+		// deadline 是 items 必须提交的时间。
+		deadline time.Time
+		// pending 是已经分好、等待提交的批次。
+		pending [][]T
+		// cut 是已经分出的批次数量。
+		cut uint64
+		// delivered 是已经提交完成的批次数量，无论成功与否。
+		delivered uint64
+		// closed 表示批处理器是否已经关闭。
+		closed bool
+		// writable 在等待提交的批次减少或批处理器关闭时关闭并替换，用于唤醒阻塞的 Add。
+		writable chan struct{}
+		// flushed 在每一批提交完成时关闭并替换，用于唤醒等待的 Flush。
+		flushed chan struct{}
+	}
+)
+
+// New 创建一个批处理器，并启动提交协程。
+// 不再使用时需要调用 Close，否则提交协程不会退出。
+//
+// 参数：
+//   - flush：提交函数。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Batcher[T]：批处理器实例。
+//
+// 示例：
+//
+//	b := batch.New(func(ctx context.Context, rows []Row) error {
+//	    return db.BulkInsert(ctx, rows)
+//	}, batch.WithMaxItems(500), batch.WithMaxLatency(time.Second), batch.WithRetry())
+//	defer b.Close(context.Background())
+//
+//	if err := b.Add(ctx, row); nil != err {
+//	    return err
+//	}
+func New[T any](flush FlushFunc[T], opts ...Option) *Batcher[T] {
+	o := newOptions(opts...)
+	// 重试默认使用批处理器的时钟，调用方传入的退避配置可以覆盖。
+	o.backoff = append([]retry.BackoffOption{retry.WithClock(o.clock)}, o.backoff...)
+
+	b := &Batcher[T]{
+		flush:    flush,
+		opts:     o,
+		kick:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		writable: make(chan struct{}),
+		flushed:  make(chan struct{}),
+	}
+	if o.maxBytes > 0 {
+		b.size = sizeFunc[T]()
+		if nil == b.size {
+			panic(fmt.Sprintf("kit/batch: 类型 %s 未实现 Sizer，不能使用 WithMaxBytes", reflect.TypeFor[T]()))
+		}
+	}
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+
+	go b.run()
+
+	return b
+}
+
+// Add 加入一个元素。
+// 元素加入后达到数量或字节数的限制时立即分为一批；等待提交的批次超过 WithMaxPending 的限制时阻塞，
+// 直到有批次提交完成、批处理器关闭或上下文被取消。
+//
+// 参数：
+//   - ctx：上下文，用于取消等待。
+//   - item：要加入的元素。
+//
+// 返回值：
+//   - error：批处理器已关闭时返回 ErrClosed，上下文被取消时返回上下文的错误。
+func (b *Batcher[T]) Add(ctx context.Context, item T) error {
+	n := 0
+	if nil != b.size {
+		n = b.size(item)
+	}
+
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return ErrClosed
+		}
+
+		// 加入之后超过字节数限制时，先将已有的元素分为一批。
+		before := len(b.items) > 0 && b.opts.maxBytes > 0 && b.bytes+n > b.opts.maxBytes
+		count, bytes := len(b.items)+1, b.bytes+n
+		if before {
+			count, bytes = 1, n
+		}
+		after := count >= b.opts.maxItems || (b.opts.maxBytes > 0 && bytes >= b.opts.maxBytes)
+
+		cuts := 0
+		if before {
+			cuts++
+		}
+		if after {
+			cuts++
+		}
+		// 没有等待提交的批次时总是允许分批，避免一次需要分出两批时永远等待。
+		if cuts > 0 && len(b.pending) > 0 && len(b.pending)+cuts > b.opts.maxPending {
+			writable := b.writable
+			b.mu.Unlock()
+
+			select {
+			case <-writable:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+
+		if before {
+			b.cutLocked()
+		}
+		first := 0 == len(b.items)
+		if first {
+			b.deadline = b.opts.clock.Now().Add(b.opts.maxLatency)
+		}
+		b.items = append(b.items, item)
+		b.bytes += n
+		if after {
+			b.cutLocked()
+		}
+		b.mu.Unlock()
+
+		if first || cuts > 0 {
+			b.wake()
+		}
+		return nil
+	}
+}
+
+// Flush 立即将已经加入的元素分为一批，并等待此前分出的所有批次提交完成。
+// 提交失败不会通过 Flush 返回，而是交给 WithErrorHandler 设置的处理函数。
+//
+// 参数：
+//   - ctx：上下文，用于取消等待；取消等待不会取消提交。
+//
+// 返回值：
+//   - error：上下文被取消时返回上下文的错误。
+func (b *Batcher[T]) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	if len(b.items) > 0 {
+		b.cutLocked()
+	}
+	target := b.cut
+	b.mu.Unlock()
+	b.wake()
+
+	for {
+		b.mu.Lock()
+		if b.delivered >= target {
+			b.mu.Unlock()
+			return nil
+		}
+		flushed := b.flushed
+		b.mu.Unlock()
+
+		select {
+		case <-flushed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Close 关闭批处理器，提交剩余的全部元素后返回。
+// 关闭之后 Add 返回 ErrClosed，阻塞中的 Add 被唤醒。上下文在提交完成前被取消时，
+// 正在进行的提交被取消，尚未提交的批次被放弃并交给错误处理函数。重复调用时等待首次关闭完成。
+//
+// 参数：
+//   - ctx：上下文，用于限制等待提交的时间。
+//
+// 返回值：
+//   - error：上下文被取消时返回上下文的错误。
+func (b *Batcher[T]) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		if len(b.items) > 0 {
+			b.cutLocked()
+		}
+		b.signalWritable()
+	}
+	b.mu.Unlock()
+	b.wake()
+
+	select {
+	case <-b.done:
+		b.cancel()
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// Len 返回已经加入但尚未开始提交的元素数量。
+//
+// 返回值：
+//   - int：元素数量。
+func (b *Batcher[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := len(b.items)
+	for _, items := range b.pending {
+		n += len(items)
+	}
+	return n
+}
+
+// run 是提交协程，按顺序提交分好的批次，并在等待时间到期时将正在积累的元素分为一批。
+func (b *Batcher[T]) run() {
+	defer close(b.done)
+
+	for {
+		b.mu.Lock()
+		if 0 == len(b.pending) && len(b.items) > 0 && !b.opts.clock.Now().Before(b.deadline) {
+			b.cutLocked()
+		}
+		if 0 == len(b.pending) {
+			// 关闭时剩余的元素已经分为一批，没有等待提交的批次即可退出。
+			if b.closed {
+				b.mu.Unlock()
+				return
+			}
+
+			var timer kittime.Timer
+			var timerC <-chan time.Time
+			if len(b.items) > 0 {
+				timer = b.opts.clock.NewTimer(b.deadline.Sub(b.opts.clock.Now()))
+				timerC = timer.C()
+			}
+			b.mu.Unlock()
+
+			select {
+			case <-b.kick:
+			case <-timerC:
+			}
+			if nil != timer {
+				timer.Stop()
+			}
+			continue
+		}
+
+		items := b.pending[0]
+		// 清除引用，避免已经提交的元素无法被回收。
+		b.pending[0] = nil
+		b.pending = b.pending[1:]
+		b.signalWritable()
+		b.mu.Unlock()
+
+		b.deliver(items)
+
+		b.mu.Lock()
+		b.delivered++
+		b.signalFlushed()
+		b.mu.Unlock()
+	}
+}
+
+// deliver 提交一批元素，按配置重试，最终失败时交给错误处理函数。
+func (b *Batcher[T]) deliver(items []T) {
+	ctx, cancel := context.WithTimeout(b.ctx, b.opts.flushTimeout)
+	defer cancel()
+
+	err := ctx.Err()
+	if nil == err {
+		if b.opts.retry {
+			var last error
+			err = retry.RetryWithContext(ctx, func(ctx context.Context) error {
+				last = b.call(ctx, items)
+				return last
+			}, b.opts.backoff...)
+			// 因超时放弃重试时，报告最后一次提交的错误。
+			if nil != err && nil != last {
+				err = last
+			}
+		} else {
+			err = b.call(ctx, items)
+		}
+	}
+	if nil != err {
+		b.opts.onError(fmt.Errorf("kit/batch: 提交 %d 个元素失败：%w", len(items), err))
+	}
+}
+
+// call 调用提交函数，将 panic 转换为错误，避免提交协程退出。
+func (b *Batcher[T]) call(ctx context.Context, items []T) (err error) {
+	defer func() {
+		if r := recover(); nil != r {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return b.flush(ctx, items)
+}
+
+// cutLocked 将正在积累的元素分为一批，调用方需要持有锁。
+func (b *Batcher[T]) cutLocked() {
+	b.pending = append(b.pending, b.items)
+	b.items = nil
+	b.bytes = 0
+	b.cut++
+}
+
+// wake 通知提交协程，不会阻塞。
+func (b *Batcher[T]) wake() {
+	select {
+	case b.kick <- struct{}{}:
+	default:
+	}
+}
+
+// signalWritable 唤醒等待分批的 Add，调用方需要持有锁。
+func (b *Batcher[T]) signalWritable() {
+	close(b.writable)
+	b.writable = make(chan struct{})
+}
+
+// signalFlushed 唤醒等待提交完成的 Flush，调用方需要持有锁。
+func (b *Batcher[T]) signalFlushed() {
+	close(b.flushed)
+	b.flushed = make(chan struct{})
+}
+
+// sizeFunc 返回计算元素字节数的函数，元素类型不支持时返回 nil。
+func sizeFunc[T any]() func(T) int {
+	t := reflect.TypeFor[T]()
+	switch {
+	case t.Implements(sizerType):
+		return func(v T) int {
+			if s, ok := any(v).(Sizer); ok {
+				return s.Size()
+			}
+			return 0
+		}
+	case t == reflect.TypeFor[[]byte]():
+		return func(v T) int {
+			return len(any(v).([]byte))
+		}
+	case t == reflect.TypeFor[string]():
+		return func(v T) int {
+			return len(any(v).(string))
+		}
+	}
+	return nil
+}
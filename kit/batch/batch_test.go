@@ -0,0 +1,333 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+var (
+	// errBoom 是测试使用的提交错误。
+	errBoom = errors.New("boom")
+)
+
+type (
+	// recorder 记录提交的批次。
+	recorder[T any] struct {
+		// batches 接收每次提交的批次。
+		batches chan []T
+	}
+
+	// event 是实现了 Sizer 的测试元素。
+	event struct {
+		// body 是事件的内容。
+		body string
+	}
+)
+
+// Size 返回事件内容的长度。
+func (e event) Size() int {
+	return len(e.body)
+}
+
+// newRecorder 创建 recorder。
+func newRecorder[T any]() *recorder[T] {
+	return &recorder[T]{batches: make(chan []T, 16)}
+}
+
+// flush 是提交函数。
+func (r *recorder[T]) flush(_ context.Context, items []T) error {
+	r.batches <- items
+	return nil
+}
+
+// expect 断言依次提交了 want 中的批次，并且之后没有更多的提交。
+func (r *recorder[T]) expect(t *testing.T, want ...[]T) {
+	t.Helper()
+	for i, items := range want {
+		select {
+		case got := <-r.batches:
+			assert.Equal(t, items, got)
+		case <-time.After(time.Second):
+			t.Fatalf("等待第 %d 批提交超时", i+1)
+		}
+	}
+	select {
+	case got := <-r.batches:
+		t.Fatalf("多余的提交：%v", got)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+// errorRecorder 返回记录错误的处理函数与接收错误的通道。
+func errorRecorder() (func(err error), chan error) {
+	errs := make(chan error, 16)
+	return func(err error) { errs <- err }, errs
+}
+
+// TestBatcher_MaxItems 测试达到最大元素数量时提交，关闭时提交剩余的元素。
+func TestBatcher_MaxItems(t *testing.T) {
+	r := newRecorder[int]()
+	b := New(r.flush, WithMaxItems(3), WithMaxPending(4))
+
+	for i := 1; i <= 7; i++ {
+		require.NoError(t, b.Add(context.Background(), i))
+	}
+	r.expect(t, []int{1, 2, 3}, []int{4, 5, 6})
+	assert.Equal(t, 1, b.Len())
+
+	require.NoError(t, b.Close(context.Background()))
+	r.expect(t, []int{7})
+	assert.Equal(t, 0, b.Len())
+}
+
+// TestBatcher_MaxBytes 测试达到最大字节数时提交。
+func TestBatcher_MaxBytes(t *testing.T) {
+	r := newRecorder[string]()
+	b := New(r.flush, WithMaxBytes(10), WithMaxPending(4))
+
+	for _, s := range []string{"aaaa", "bbbb", "cc", "dddddd", "eeeeee", "ffffffffffff", "g"} {
+		require.NoError(t, b.Add(context.Background(), s))
+	}
+	r.expect(t,
+		[]string{"aaaa", "bbbb", "cc"},
+		[]string{"dddddd"},
+		[]string{"eeeeee"},
+		[]string{"ffffffffffff"},
+	)
+
+	require.NoError(t, b.Close(context.Background()))
+	r.expect(t, []string{"g"})
+}
+
+// TestBatcher_Sizer 测试通过 Sizer 计算元素大小，以及不支持的类型。
+func TestBatcher_Sizer(t *testing.T) {
+	r := newRecorder[event]()
+	b := New(r.flush, WithMaxBytes(5))
+	require.NoError(t, b.Add(context.Background(), event{body: "abc"}))
+	require.NoError(t, b.Add(context.Background(), event{body: "de"}))
+	r.expect(t, []event{{body: "abc"}, {body: "de"}})
+	require.NoError(t, b.Close(context.Background()))
+
+	rb := newRecorder[[]byte]()
+	bb := New(rb.flush, WithMaxBytes(2))
+	require.NoError(t, bb.Add(context.Background(), []byte("xy")))
+	rb.expect(t, [][]byte{[]byte("xy")})
+	require.NoError(t, bb.Close(context.Background()))
+
+	// 接口类型的零值无法计算大小，按 0 处理。
+	assert.Equal(t, 0, sizeFunc[Sizer]()(nil))
+
+	assert.PanicsWithValue(t, "kit/batch: 类型 int 未实现 Sizer，不能使用 WithMaxBytes", func() {
+		New(newRecorder[int]().flush, WithMaxBytes(1))
+	})
+}
+
+// TestBatcher_MaxLatency 测试等待时间到期时提交。
+func TestBatcher_MaxLatency(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	r := newRecorder[int]()
+	b := New(r.flush, WithMaxLatency(time.Second), WithClock(clock))
+	defer func() { _ = b.Close(context.Background()) }()
+
+	require.NoError(t, b.Add(context.Background(), 1))
+	clock.BlockUntil(1)
+	clock.Advance(500 * time.Millisecond)
+	r.expect(t)
+
+	// 之后加入的元素不会推迟第一个元素的提交时间。
+	require.NoError(t, b.Add(context.Background(), 2))
+	clock.BlockUntil(1)
+	clock.Advance(500 * time.Millisecond)
+	r.expect(t, []int{1, 2})
+
+	require.NoError(t, b.Add(context.Background(), 3))
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	r.expect(t, []int{3})
+}
+
+// TestBatcher_Flush 测试立即提交并等待提交完成。
+func TestBatcher_Flush(t *testing.T) {
+	r := newRecorder[int]()
+	b := New(r.flush)
+	defer func() { _ = b.Close(context.Background()) }()
+
+	require.NoError(t, b.Flush(context.Background()))
+
+	require.NoError(t, b.Add(context.Background(), 1))
+	require.NoError(t, b.Add(context.Background(), 2))
+	require.NoError(t, b.Flush(context.Background()))
+	assert.Equal(t, 0, b.Len())
+	r.expect(t, []int{1, 2})
+}
+
+// TestBatcher_Backpressure 测试提交跟不上时 Add 阻塞。
+func TestBatcher_Backpressure(t *testing.T) {
+	started := make(chan int, 16)
+	release := make(chan struct{})
+	b := New(func(_ context.Context, items []int) error {
+		started <- items[0]
+		<-release
+		return nil
+	}, WithMaxItems(1), WithMaxPending(1))
+
+	require.NoError(t, b.Add(context.Background(), 1))
+	assert.Equal(t, 1, <-started)
+	// 第一批正在提交，第二批等待提交，第三批需要等待。
+	require.NoError(t, b.Add(context.Background(), 2))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.Add(ctx, 3), context.DeadlineExceeded)
+
+	// Flush 在提交完成前被取消。
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.Flush(ctx), context.DeadlineExceeded)
+
+	added := make(chan error, 1)
+	go func() { added <- b.Add(context.Background(), 3) }()
+	release <- struct{}{}
+	assert.Equal(t, 2, <-started)
+	require.NoError(t, <-added)
+
+	close(release)
+	require.NoError(t, b.Close(context.Background()))
+	assert.Equal(t, 3, <-started)
+}
+
+// TestBatcher_Retry 测试提交失败时重试。
+func TestBatcher_Retry(t *testing.T) {
+	var attempts atomic.Int32
+	r := newRecorder[int]()
+	onError, errs := errorRecorder()
+	b := New(func(ctx context.Context, items []int) error {
+		if attempts.Add(1) < 3 {
+			return errBoom
+		}
+		return r.flush(ctx, items)
+	}, WithRetry(retry.WithClock(kittime.NewRealClock()), retry.WithMin(time.Millisecond), retry.WithMax(time.Millisecond)), WithErrorHandler(onError))
+
+	require.NoError(t, b.Add(context.Background(), 1))
+	require.NoError(t, b.Close(context.Background()))
+	r.expect(t, []int{1})
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.Empty(t, errs)
+}
+
+// TestBatcher_RetryTimeout 测试重试超时时报告最后一次提交的错误。
+func TestBatcher_RetryTimeout(t *testing.T) {
+	onError, errs := errorRecorder()
+	b := New(func(context.Context, []int) error {
+		return errBoom
+	}, WithRetry(retry.WithMin(time.Millisecond), retry.WithMax(time.Millisecond)), WithFlushTimeout(20*time.Millisecond), WithErrorHandler(onError))
+
+	require.NoError(t, b.Add(context.Background(), 1))
+	require.NoError(t, b.Close(context.Background()))
+	err := <-errs
+	assert.ErrorIs(t, err, errBoom)
+	assert.EqualError(t, err, "kit/batch: 提交 1 个元素失败：boom")
+}
+
+// TestBatcher_Error 测试提交失败与 panic 交给错误处理函数，之后的批次继续提交。
+func TestBatcher_Error(t *testing.T) {
+	onError, errs := errorRecorder()
+	r := newRecorder[int]()
+	b := New(func(ctx context.Context, items []int) error {
+		switch items[0] {
+		case 1:
+			return errBoom
+		case 2:
+			panic("oops")
+		}
+		return r.flush(ctx, items)
+	}, WithMaxItems(1), WithMaxPending(4), WithErrorHandler(onError))
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, b.Add(context.Background(), i))
+	}
+	require.NoError(t, b.Close(context.Background()))
+
+	assert.ErrorIs(t, <-errs, errBoom)
+	assert.EqualError(t, <-errs, "kit/batch: 提交 1 个元素失败：panic: oops")
+	r.expect(t, []int{3})
+}
+
+// TestBatcher_Close 测试关闭之后的行为。
+func TestBatcher_Close(t *testing.T) {
+	r := newRecorder[int]()
+	b := New(r.flush)
+
+	require.NoError(t, b.Close(context.Background()))
+	require.NoError(t, b.Close(context.Background()))
+	assert.ErrorIs(t, b.Add(context.Background(), 1), ErrClosed)
+	r.expect(t)
+}
+
+// TestBatcher_CloseTimeout 测试关闭超时时取消正在进行的提交，并放弃剩余的批次。
+func TestBatcher_CloseTimeout(t *testing.T) {
+	started := make(chan struct{})
+	onError, errs := errorRecorder()
+	b := New(func(ctx context.Context, items []int) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}, WithMaxItems(1), WithMaxPending(1), WithErrorHandler(onError))
+
+	require.NoError(t, b.Add(context.Background(), 1))
+	<-started
+	require.NoError(t, b.Add(context.Background(), 2))
+
+	// 阻塞中的 Add 在关闭时被唤醒。
+	added := make(chan error, 1)
+	go func() { added <- b.Add(context.Background(), 3) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.Close(ctx), context.DeadlineExceeded)
+	assert.ErrorIs(t, <-added, ErrClosed)
+
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	err := <-errs
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualError(t, err, "kit/batch: 提交 1 个元素失败：context canceled")
+	require.NoError(t, b.Close(context.Background()))
+}
+
+// TestNewOptions 测试配置选项的默认值与非法参数。
+func TestNewOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, maxItemsDefault, o.maxItems)
+	assert.Equal(t, maxBytesDefault, o.maxBytes)
+	assert.Equal(t, maxLatencyDefault, o.maxLatency)
+	assert.Equal(t, maxPendingDefault, o.maxPending)
+	assert.Equal(t, flushTimeoutDefault, o.flushTimeout)
+	assert.False(t, o.retry)
+	assert.NotNil(t, o.onError)
+	assert.Equal(t, clockDefault, o.clock)
+
+	o = newOptions(WithMaxItems(0), WithMaxBytes(-1), WithMaxLatency(0), WithMaxPending(0),
+		WithFlushTimeout(-1), WithErrorHandler(nil), WithClock(nil))
+	assert.Equal(t, maxItemsDefault, o.maxItems)
+	assert.Equal(t, maxBytesDefault, o.maxBytes)
+	assert.Equal(t, maxLatencyDefault, o.maxLatency)
+	assert.Equal(t, maxPendingDefault, o.maxPending)
+	assert.Equal(t, flushTimeoutDefault, o.flushTimeout)
+	assert.NotNil(t, o.onError)
+	assert.Equal(t, clockDefault, o.clock)
+
+	// 默认的错误处理函数记录日志，不会 panic。
+	assert.NotPanics(t, func() { errorHandlerDefault(errBoom) })
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package batch 提供了按数量、字节数与等待时间分批提交的批处理器，用于批量写入数据库、批量发送日志等场景。
+
+主要功能：
+
+  - 分批：达到最大元素数量（WithMaxItems）、最大字节数（WithMaxBytes）或最长等待时间（WithMaxLatency）时提交一批
+  - 背压：提交在单独的协程中按顺序进行，等待提交的批次超过 WithMaxPending 的限制时 Add 阻塞
+  - 重试：WithRetry 开启后通过 kit/runtime/retry 重试失败的提交，WithFlushTimeout 限制每一批的总耗时
+  - 排空：Flush 立即提交并等待完成，Close 提交剩余的全部元素后返回
+
+提交最终失败的批次交给 WithErrorHandler 设置的处理函数，默认记录错误日志。
+
+基本使用：
+
+	b := batch.New(func(ctx context.Context, rows []Row) error {
+	    return db.BulkInsert(ctx, rows)
+	}, batch.WithMaxItems(500), batch.WithMaxLatency(time.Second), batch.WithRetry())
+
+	for row := range rows {
+	    if err := b.Add(ctx, row); nil != err {
+	        return err
+	    }
+	}
+
+	// 退出前提交剩余的元素。
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = b.Close(shutdownCtx)
+*/
+package batch
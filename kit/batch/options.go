@@ -0,0 +1,212 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package batch
+
+import (
+	"time"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 以下为批处理器的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// maxItemsDefault 为一批中默认的最大元素数量。
+	maxItemsDefault = 100
+	// maxBytesDefault 为一批中默认的最大字节数，0 表示不限制。
+	maxBytesDefault = 0
+	// maxLatencyDefault 为元素默认的最长等待时间。
+	maxLatencyDefault = time.Second
+	// maxPendingDefault 为默认允许等待提交的批次数量。
+	maxPendingDefault = 1
+	// flushTimeoutDefault 为提交一批的默认超时时间，包含重试的时间。
+	flushTimeoutDefault = 30 * time.Second
+	// clockDefault 为默认使用的时钟。
+	clockDefault = kittime.NewRealClock()
+	// errorHandlerDefault 为默认的错误处理函数，记录错误日志。
+	errorHandlerDefault = func(err error) {
+		kitlog.Error(err)
+	}
+)
+
+type (
+	// Option 定义了批处理器的配置选项。
+	Option func(*options)
+
+	// options 包含批处理器的配置。
+	options struct {
+		// maxItems 是一批中的最大元素数量。
+		maxItems int
+		// maxBytes 是一批中的最大字节数，0 表示不限制。
+		maxBytes int
+		// maxLatency 是元素从加入到提交的最长等待时间。
+		maxLatency time.Duration
+		// maxPending 是允许等待提交的批次数量，超过时 Add 阻塞。
+		maxPending int
+		// flushTimeout 是提交一批的超时时间，包含重试的时间。
+		flushTimeout time.Duration
+		// retry 表示提交失败时是否重试。
+		retry bool
+		// backoff 是重试的退避配置。
+		backoff []retry.BackoffOption
+		// onError 是提交最终失败时的处理函数。
+		onError func(err error)
+		// clock 是计时使用的时钟。
+		clock kittime.Clock
+	}
+)
+
+// WithMaxItems 设置一批中的最大元素数量，达到时立即提交。
+//
+// 参数：
+//   - maxItems：最大元素数量，默认为 100，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxItems(maxItems int) Option {
+	return func(o *options) {
+		o.maxItems = maxItems
+	}
+}
+
+// WithMaxBytes 设置一批中的最大字节数，达到时立即提交。
+// 元素的大小由 Sizer 接口计算，[]byte 与 string 按长度计算；其他类型的元素设置该选项时 New 会 panic。
+// 单个元素超过 maxBytes 时单独成为一批。
+//
+// 参数：
+//   - maxBytes：最大字节数，默认为 0 表示不限制，小于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxBytes(maxBytes int) Option {
+	return func(o *options) {
+		o.maxBytes = maxBytes
+	}
+}
+
+// WithMaxLatency 设置元素从加入到提交的最长等待时间。
+// 一批中的第一个元素加入后开始计时，到期时即使未达到数量与字节数的限制也会提交。
+//
+// 参数：
+//   - maxLatency：最长等待时间，默认为 1 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxLatency(maxLatency time.Duration) Option {
+	return func(o *options) {
+		o.maxLatency = maxLatency
+	}
+}
+
+// WithMaxPending 设置允许等待提交的批次数量。
+// 提交速度跟不上加入速度时，等待提交的批次超过该数量后 Add 阻塞，以此向调用方施加背压。
+//
+// 参数：
+//   - maxPending：批次数量，默认为 1，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxPending(maxPending int) Option {
+	return func(o *options) {
+		o.maxPending = maxPending
+	}
+}
+
+// WithFlushTimeout 设置提交一批的超时时间，包含重试的时间。
+//
+// 参数：
+//   - timeout：超时时间，默认为 30 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithFlushTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.flushTimeout = timeout
+	}
+}
+
+// WithRetry 开启提交失败时的重试，重试由 kit/runtime/retry 完成，直到成功或达到 WithFlushTimeout 设置的超时时间。
+//
+// 参数：
+//   - opts：重试的退避配置，参见 kit/runtime/retry 的 BackoffOption。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithRetry(opts ...retry.BackoffOption) Option {
+	return func(o *options) {
+		o.retry = true
+		o.backoff = opts
+	}
+}
+
+// WithErrorHandler 设置提交最终失败时的处理函数。
+// 失败的批次不会再次提交，需要保留时应在处理函数或提交函数中自行处理。
+//
+// 参数：
+//   - fn：错误处理函数，默认记录错误日志，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithErrorHandler(fn func(err error)) Option {
+	return func(o *options) {
+		o.onError = fn
+	}
+}
+
+// WithClock 设置计时使用的时钟。
+// 测试时可以注入 kit/time 的 FakeClock，无需真实等待即可验证按时间提交的行为。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		maxItems:     maxItemsDefault,
+		maxBytes:     maxBytesDefault,
+		maxLatency:   maxLatencyDefault,
+		maxPending:   maxPendingDefault,
+		flushTimeout: flushTimeoutDefault,
+		onError:      errorHandlerDefault,
+		clock:        clockDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.maxItems < 1 {
+		o.maxItems = maxItemsDefault
+	}
+	if o.maxBytes < 0 {
+		o.maxBytes = maxBytesDefault
+	}
+	if o.maxLatency <= 0 {
+		o.maxLatency = maxLatencyDefault
+	}
+	if o.maxPending < 1 {
+		o.maxPending = maxPendingDefault
+	}
+	if o.flushTimeout <= 0 {
+		o.flushTimeout = flushTimeoutDefault
+	}
+	if nil == o.onError {
+		o.onError = errorHandlerDefault
+	}
+	if nil == o.clock {
+		o.clock = clockDefault
+	}
+
+	return o
+}
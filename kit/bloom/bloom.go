@@ -0,0 +1,335 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/cespare/xxhash/v2"
+
+	kitstrings "github.com/fsyyft-go/monorepo/kit/strings"
+)
+
+const (
+	// wordBits 是每个字的位数。
+	wordBits = 64
+	// maxHashes 是哈希函数数量的上限，误判率极低时限制计算量。
+	maxHashes = 32
+	// headerSize 是序列化数据头部的字节数：魔数与版本 4 字节、哈希函数数量 4 字节、位数 8 字节。
+	headerSize = 16
+)
+
+var (
+	// magic 是序列化数据的魔数与版本。
+	magic = [4]byte{'K', 'B', 'F', 1}
+)
+
+var (
+	// ErrIncompatible 表示两个过滤器的位数或哈希函数数量不同，不能合并。
+	ErrIncompatible = errors.New("kit/bloom: 过滤器的参数不同")
+	// ErrInvalidData 表示序列化数据无效。
+	ErrInvalidData = errors.New("kit/bloom: 无效的序列化数据")
+)
+
+type (
+	// Filter 是布隆过滤器，用很小的空间判断元素是否可能在集合中。
+	// Contains 返回 false 时元素一定没有被添加过；返回 true 时元素可能被添加过，误判率由创建时的参数决定。
+	// 元素不能被删除。通过 WithConcurrent 开启后所有方法都是并发安全的，UnmarshalBinary 除外。
+	// 请使用 New 创建，或在零值上调用 UnmarshalBinary 恢复。
+	Filter struct {
+		// words 是位数组。
+		words []uint64
+		// m 是位数，为 wordBits 的整数倍。
+		m uint64
+		// k 是哈希函数的数量。
+		k int
+		// concurrent 表示是否使用原子操作读写位数组。
+		concurrent bool
+	}
+)
+
+// Estimate 计算容纳 n 个元素且误判率为 p 时需要的位数与哈希函数数量。
+//
+// 参数：
+//   - n：元素数量，小于 1 时按 1 计算。
+//   - p：误判率，应在 (0, 1) 之间，否则按 0.01 计算。
+//
+// 返回值：
+//   - uint64：位数，为 64 的整数倍，占用的内存为该值除以 8 字节。
+//   - int：哈希函数数量，在 1 到 32 之间。
+//
+// 示例：
+//
+//	bits, hashes := bloom.Estimate(1_000_000, 0.001)
+//	fmt.Println(bits/8, hashes) // 约 1.8MB、10 个哈希函数
+func Estimate(n int, p float64) (uint64, int) {
+	if n < 1 {
+		n = 1
+	}
+	if !(p > 0 && p < 1) {
+		p = falsePositiveRateDefault
+	}
+
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	m = (m + wordBits - 1) / wordBits * wordBits
+	if 0 == m {
+		m = wordBits
+	}
+	k := int(math.Round(float64(m) / float64(n) * math.Ln2))
+	k = max(1, min(k, maxHashes))
+	return m, k
+}
+
+// New 创建一个布隆过滤器，大小由 WithExpected 与 WithFalsePositiveRate 决定。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Filter：布隆过滤器。
+//
+// 示例：
+//
+//	seen := bloom.New(bloom.WithExpected(1_000_000), bloom.WithFalsePositiveRate(0.001))
+//	if seen.AddString(messageID) {
+//	    // 可能是重复的消息。
+//	}
+func New(opts ...Option) *Filter {
+	o := newOptions(opts...)
+	m, k := Estimate(o.expected, o.falsePositiveRate)
+	return &Filter{
+		words:      make([]uint64, m/wordBits),
+		m:          m,
+		k:          k,
+		concurrent: o.concurrent,
+	}
+}
+
+// Add 添加一个元素。
+//
+// 参数：
+//   - data：元素，不会被保存。
+//
+// 返回值：
+//   - bool：添加前元素是否可能已经存在，即 Contains 在添加前的结果；用于去重时可以省去一次 Contains。
+func (f *Filter) Add(data []byte) bool {
+	h1, h2 := hashes(data)
+	present := true
+	for i := 0; i < f.k; i++ {
+		w, mask := f.location(h1, h2, i)
+		if f.concurrent {
+			if 0 == atomic.OrUint64(&f.words[w], mask)&mask {
+				present = false
+			}
+		} else if 0 == f.words[w]&mask {
+			f.words[w] |= mask
+			present = false
+		}
+	}
+	return present
+}
+
+// AddString 添加一个字符串元素，与 Add 相同，但不复制字符串。
+//
+// 参数：
+//   - s：元素。
+//
+// 返回值：
+//   - bool：添加前元素是否可能已经存在。
+func (f *Filter) AddString(s string) bool {
+	return f.Add(kitstrings.ToBytes(s))
+}
+
+// Contains 判断元素是否可能存在。
+//
+// 参数：
+//   - data：元素。
+//
+// 返回值：
+//   - bool：返回 false 时元素一定不存在；返回 true 时元素可能存在。
+func (f *Filter) Contains(data []byte) bool {
+	h1, h2 := hashes(data)
+	for i := 0; i < f.k; i++ {
+		w, mask := f.location(h1, h2, i)
+		if 0 == f.load(w)&mask {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsString 判断字符串元素是否可能存在，与 Contains 相同，但不复制字符串。
+//
+// 参数：
+//   - s：元素。
+//
+// 返回值：
+//   - bool：返回 false 时元素一定不存在；返回 true 时元素可能存在。
+func (f *Filter) ContainsString(s string) bool {
+	return f.Contains(kitstrings.ToBytes(s))
+}
+
+// Len 根据已设置的位数估算添加过的不同元素数量，需要遍历位数组。
+//
+// 返回值：
+//   - int：估算的元素数量。
+func (f *Filter) Len() int {
+	set := f.setBits()
+	if set >= f.m {
+		return int(f.m)
+	}
+	n := -float64(f.m) / float64(f.k) * math.Log(1-float64(set)/float64(f.m))
+	return int(math.Round(n))
+}
+
+// FalsePositiveRate 根据已设置的位数估算当前的误判率，需要遍历位数组。
+// 元素数量超过 WithExpected 后误判率会超过创建时设置的值，可以据此决定是否重建过滤器。
+//
+// 返回值：
+//   - float64：估算的误判率。
+func (f *Filter) FalsePositiveRate() float64 {
+	return math.Pow(float64(f.setBits())/float64(f.m), float64(f.k))
+}
+
+// Bits 返回位数，占用的内存为该值除以 8 字节。
+//
+// 返回值：
+//   - uint64：位数。
+func (f *Filter) Bits() uint64 {
+	return f.m
+}
+
+// Hashes 返回哈希函数的数量。
+//
+// 返回值：
+//   - int：哈希函数的数量。
+func (f *Filter) Hashes() int {
+	return f.k
+}
+
+// Reset 清空过滤器。
+func (f *Filter) Reset() {
+	for i := range f.words {
+		if f.concurrent {
+			atomic.StoreUint64(&f.words[i], 0)
+		} else {
+			f.words[i] = 0
+		}
+	}
+}
+
+// Merge 将 other 中的元素合并到当前过滤器，合并后的过滤器包含两者的全部元素。
+//
+// 参数：
+//   - other：另一个过滤器，位数与哈希函数数量必须与当前过滤器相同。
+//
+// 返回值：
+//   - error：参数不同时返回 ErrIncompatible。
+func (f *Filter) Merge(other *Filter) error {
+	if f.m != other.m || f.k != other.k {
+		return fmt.Errorf("%w：%d 位 %d 个哈希函数与 %d 位 %d 个哈希函数", ErrIncompatible, f.m, f.k, other.m, other.k)
+	}
+	for i := range f.words {
+		v := other.load(i)
+		if f.concurrent {
+			atomic.OrUint64(&f.words[i], v)
+		} else {
+			f.words[i] |= v
+		}
+	}
+	return nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler 接口，将过滤器序列化为字节切片。
+// 序列化数据与平台无关，可以保存到文件或缓存中，之后通过 UnmarshalBinary 恢复。
+//
+// 返回值：
+//   - []byte：序列化数据，长度为位数除以 8 再加 16 字节。
+//   - error：总是返回 nil。
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, headerSize+len(f.words)*8)
+	copy(data, magic[:])
+	binary.LittleEndian.PutUint32(data[4:], uint32(f.k))
+	binary.LittleEndian.PutUint64(data[8:], f.m)
+	for i := range f.words {
+		binary.LittleEndian.PutUint64(data[headerSize+i*8:], f.load(i))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler 接口，从 MarshalBinary 的结果恢复过滤器。
+// 恢复后过滤器的位数与哈希函数数量与序列化时相同，是否支持并发访问保持不变。不能与其他方法并发调用。
+//
+// 参数：
+//   - data：序列化数据，不会被保存。
+//
+// 返回值：
+//   - error：数据无效时返回包装了 ErrInvalidData 的错误，此时过滤器不变。
+//
+// 示例：
+//
+//	var f bloom.Filter
+//	if err := f.UnmarshalBinary(data); nil != err {
+//	    return err
+//	}
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize || [4]byte(data[:4]) != magic {
+		return fmt.Errorf("%w：缺少头部", ErrInvalidData)
+	}
+	k := binary.LittleEndian.Uint32(data[4:])
+	m := binary.LittleEndian.Uint64(data[8:])
+	if k < 1 || k > maxHashes || 0 == m || 0 != m%wordBits {
+		return fmt.Errorf("%w：%d 位 %d 个哈希函数", ErrInvalidData, m, k)
+	}
+	if uint64(len(data)-headerSize) != m/8 {
+		return fmt.Errorf("%w：%d 位需要 %d 字节，实际为 %d 字节", ErrInvalidData, m, m/8, len(data)-headerSize)
+	}
+
+	words := make([]uint64, m/wordBits)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[headerSize+i*8:])
+	}
+	f.words, f.m, f.k = words, m, int(k)
+	return nil
+}
+
+// location 返回第 i 个哈希函数对应的字下标与位掩码。
+func (f *Filter) location(h1, h2 uint64, i int) (int, uint64) {
+	pos := (h1 + uint64(i)*h2) % f.m
+	return int(pos / wordBits), 1 << (pos % wordBits)
+}
+
+// load 读取第 i 个字。
+func (f *Filter) load(i int) uint64 {
+	if f.concurrent {
+		return atomic.LoadUint64(&f.words[i])
+	}
+	return f.words[i]
+}
+
+// setBits 返回已设置的位数。
+func (f *Filter) setBits() uint64 {
+	var n int
+	for i := range f.words {
+		n += bits.OnesCount64(f.load(i))
+	}
+	return uint64(n)
+}
+
+// hashes 计算元素的两个哈希值，k 个哈希函数由 h1 + i*h2 组合得到（Kirsch-Mitzenmacher）。
+func hashes(data []byte) (uint64, uint64) {
+	h1 := xxhash.Sum64(data)
+	// 使用 splitmix64 的混合函数从 h1 派生 h2，并保证 h2 为奇数，避免步长与位数有公因子 2。
+	h2 := h1
+	h2 = (h2 ^ (h2 >> 30)) * 0xbf58476d1ce4e5b9
+	h2 = (h2 ^ (h2 >> 27)) * 0x94d049bb133111eb
+	h2 ^= h2 >> 31
+	return h1, h2 | 1
+}
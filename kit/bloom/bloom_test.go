@@ -0,0 +1,216 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package bloom
+
+import (
+	"math"
+	"strconv"
+	stdsync "sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEstimate 测试位数与哈希函数数量的计算。
+func TestEstimate(t *testing.T) {
+	m, k := Estimate(1_000_000, 0.01)
+	assert.Equal(t, uint64(9585088), m)
+	assert.Equal(t, 7, k)
+	assert.Zero(t, m%wordBits)
+
+	m, k = Estimate(0, 0.5)
+	assert.Equal(t, uint64(wordBits), m)
+	assert.Equal(t, maxHashes, k)
+
+	// 非法的误判率按默认值计算。
+	m, k = Estimate(1000, math.NaN())
+	m2, k2 := Estimate(1000, falsePositiveRateDefault)
+	assert.Equal(t, m2, m)
+	assert.Equal(t, k2, k)
+
+	// 哈希函数数量的上限。
+	_, k = Estimate(10, 1e-30)
+	assert.Equal(t, maxHashes, k)
+}
+
+// TestFilter 测试添加、判断与误判率。
+func TestFilter(t *testing.T) {
+	f := New(WithExpected(10000), WithFalsePositiveRate(0.01))
+	assert.Equal(t, 0, f.Len())
+	assert.Zero(t, f.FalsePositiveRate())
+
+	// 添加过程中同样存在误判。
+	present := 0
+	for i := 0; i < 10000; i++ {
+		if f.AddString("key-" + strconv.Itoa(i)) {
+			present++
+		}
+	}
+	assert.Less(t, present, 100)
+	for i := 0; i < 10000; i++ {
+		require.True(t, f.ContainsString("key-"+strconv.Itoa(i)))
+	}
+	assert.True(t, f.Add([]byte("key-0")))
+	assert.True(t, f.Contains([]byte("key-1")))
+
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if f.ContainsString("other-" + strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, 200)
+	assert.InDelta(t, 0.01, f.FalsePositiveRate(), 0.005)
+	assert.InDelta(t, 10000, f.Len(), 300)
+
+	f.Reset()
+	assert.False(t, f.ContainsString("key-0"))
+	assert.Equal(t, 0, f.Len())
+}
+
+// TestFilterFull 测试全部位都被设置时的估算。
+func TestFilterFull(t *testing.T) {
+	f := New(WithExpected(1), WithFalsePositiveRate(0.5))
+	for i := 0; i < 1000; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	assert.Equal(t, int(f.Bits()), f.Len())
+	assert.Equal(t, 1.0, f.FalsePositiveRate())
+}
+
+// TestConcurrent 测试并发添加与判断。
+func TestConcurrent(t *testing.T) {
+	f := New(WithExpected(8000), WithConcurrent(true))
+
+	var wg stdsync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa(g*1000 + i)
+				f.AddString(key)
+				assert.True(t, f.ContainsString(key))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 8000; i++ {
+		require.True(t, f.ContainsString(strconv.Itoa(i)))
+	}
+
+	other := New(WithExpected(8000))
+	other.AddString("merged")
+	require.NoError(t, f.Merge(other))
+	assert.True(t, f.ContainsString("merged"))
+
+	data, err := f.MarshalBinary()
+	require.NoError(t, err)
+	restored := New(WithExpected(1), WithConcurrent(true))
+	require.NoError(t, restored.UnmarshalBinary(data))
+	assert.True(t, restored.ContainsString("merged"))
+	assert.True(t, restored.concurrent)
+
+	f.Reset()
+	assert.False(t, f.ContainsString("merged"))
+}
+
+// TestMerge 测试合并过滤器。
+func TestMerge(t *testing.T) {
+	a := New(WithExpected(1000))
+	b := New(WithExpected(1000))
+	a.AddString("a")
+	b.AddString("b")
+
+	require.NoError(t, a.Merge(b))
+	assert.True(t, a.ContainsString("a"))
+	assert.True(t, a.ContainsString("b"))
+	assert.False(t, b.ContainsString("a"))
+
+	err := a.Merge(New(WithExpected(2000)))
+	assert.ErrorIs(t, err, ErrIncompatible)
+}
+
+// TestMarshalBinary 测试序列化与恢复。
+func TestMarshalBinary(t *testing.T) {
+	f := New(WithExpected(1000), WithFalsePositiveRate(0.001))
+	for i := 0; i < 1000; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	data, err := f.MarshalBinary()
+	require.NoError(t, err)
+	assert.Len(t, data, headerSize+int(f.Bits()/8))
+
+	var restored Filter
+	require.NoError(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, f.Bits(), restored.Bits())
+	assert.Equal(t, f.Hashes(), restored.Hashes())
+	assert.False(t, restored.concurrent)
+	for i := 0; i < 1000; i++ {
+		require.True(t, restored.ContainsString(strconv.Itoa(i)))
+	}
+	restored.AddString("new")
+	assert.False(t, f.ContainsString("new"))
+
+	// 无效的数据不修改过滤器。
+	bad := func(mutate func([]byte) []byte) error {
+		return restored.UnmarshalBinary(mutate(append([]byte(nil), data...)))
+	}
+	for name, mutate := range map[string]func([]byte) []byte{
+		"short":     func(b []byte) []byte { return b[:headerSize-1] },
+		"magic":     func(b []byte) []byte { b[0] = 'X'; return b },
+		"version":   func(b []byte) []byte { b[3] = 2; return b },
+		"no hashes": func(b []byte) []byte { b[4], b[5], b[6], b[7] = 0, 0, 0, 0; return b },
+		"hashes":    func(b []byte) []byte { b[4] = maxHashes + 1; return b },
+		"bits":      func(b []byte) []byte { b[8]++; return b },
+		"length":    func(b []byte) []byte { return b[:len(b)-8] },
+	} {
+		assert.ErrorIs(t, bad(mutate), ErrInvalidData, name)
+	}
+	assert.True(t, restored.ContainsString("new"))
+	assert.Equal(t, f.Bits(), restored.Bits())
+}
+
+// TestOptions 测试配置选项与默认值。
+func TestOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, expectedDefault, o.expected)
+	assert.Equal(t, falsePositiveRateDefault, o.falsePositiveRate)
+	assert.Equal(t, concurrentDefault, o.concurrent)
+
+	o = newOptions(WithExpected(5), WithFalsePositiveRate(0.1), WithConcurrent(true))
+	assert.Equal(t, 5, o.expected)
+	assert.Equal(t, 0.1, o.falsePositiveRate)
+	assert.True(t, o.concurrent)
+
+	o = newOptions(WithExpected(0), WithFalsePositiveRate(1))
+	assert.Equal(t, expectedDefault, o.expected)
+	assert.Equal(t, falsePositiveRateDefault, o.falsePositiveRate)
+	o = newOptions(WithFalsePositiveRate(math.NaN()))
+	assert.Equal(t, falsePositiveRateDefault, o.falsePositiveRate)
+}
+
+// BenchmarkAdd 测试添加的性能。
+func BenchmarkAdd(b *testing.B) {
+	f := New(WithExpected(b.N + 1))
+	key := []byte("benchmark-key")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Add(key)
+	}
+}
+
+// BenchmarkContains 测试判断的性能。
+func BenchmarkContains(b *testing.B) {
+	f := New(WithExpected(1000))
+	key := []byte("benchmark-key")
+	f.Add(key)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Contains(key)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package bloom 提供了布隆过滤器，一种节省空间的概率集合。
+
+布隆过滤器判断元素“一定不存在”或“可能存在”：Contains 返回 false 时元素一定没有被添加过，
+返回 true 时有一定概率误判。过滤器的大小由预计的元素数量与期望的误判率决定，
+例如 100 万个元素、1% 的误判率只需要约 1.2MB，与元素本身的大小无关。
+
+基本使用：
+
+	seen := bloom.New(bloom.WithExpected(1_000_000), bloom.WithFalsePositiveRate(0.01))
+
+	// 去重：Add 返回添加前元素是否可能已经存在。
+	if seen.AddString(messageID) {
+	    return // 可能是重复的消息
+	}
+
+作为缓存的前置过滤：
+
+把全部存在的键加入过滤器，查询缓存与数据库之前先判断，一定不存在的键直接返回，避免缓存穿透。
+
+	if !exists.ContainsString(userID) {
+	    return nil, ErrNotFound
+	}
+	return users.GetOrLoad(ctx, userID, loadUser)
+
+并发与序列化：
+
+过滤器默认不是并发安全的，WithConcurrent(true) 开启后使用原子操作读写，不需要加锁。
+MarshalBinary 与 UnmarshalBinary 可以把过滤器保存到文件或缓存中，在进程重启或多个实例之间共享；
+位数与哈希函数数量相同的过滤器可以通过 Merge 合并。
+*/
+package bloom
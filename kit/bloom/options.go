@@ -0,0 +1,94 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package bloom
+
+// 以下为布隆过滤器的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// expectedDefault 为默认的预计元素数量。
+	expectedDefault = 10000
+	// falsePositiveRateDefault 为默认的误判率。
+	falsePositiveRateDefault = 0.01
+	// concurrentDefault 为默认是否支持并发访问。
+	concurrentDefault = false
+)
+
+type (
+	// Option 定义了布隆过滤器的配置选项。
+	Option func(*options)
+
+	// options 包含布隆过滤器的配置。
+	options struct {
+		// expected 是预计添加的元素数量。
+		expected int
+		// falsePositiveRate 是添加了 expected 个元素时期望的误判率。
+		falsePositiveRate float64
+		// concurrent 表示是否支持并发访问。
+		concurrent bool
+	}
+)
+
+// WithExpected 设置预计添加的元素数量，与 WithFalsePositiveRate 一起决定过滤器的大小。
+// 实际添加的元素超过该数量时过滤器仍然可用，但误判率会升高。
+//
+// 参数：
+//   - n：预计元素数量，默认为 10000，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithExpected(n int) Option {
+	return func(o *options) {
+		o.expected = n
+	}
+}
+
+// WithFalsePositiveRate 设置添加了预计数量的元素时期望的误判率。
+//
+// 参数：
+//   - p：误判率，默认为 0.01，不在 (0, 1) 之间时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithFalsePositiveRate(p float64) Option {
+	return func(o *options) {
+		o.falsePositiveRate = p
+	}
+}
+
+// WithConcurrent 设置是否支持并发访问。
+// 支持并发访问时使用原子操作读写，不需要加锁；只在单个协程中使用时可以关闭以获得更好的性能。
+//
+// 参数：
+//   - concurrent：是否支持并发访问，默认为 false。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithConcurrent(concurrent bool) Option {
+	return func(o *options) {
+		o.concurrent = concurrent
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		expected:          expectedDefault,
+		falsePositiveRate: falsePositiveRateDefault,
+		concurrent:        concurrentDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.expected < 1 {
+		o.expected = expectedDefault
+	}
+	// 使用取反的比较，使 NaN 也被替换为默认值。
+	if !(o.falsePositiveRate > 0 && o.falsePositiveRate < 1) {
+		o.falsePositiveRate = falsePositiveRateDefault
+	}
+
+	return o
+}
@@ -0,0 +1,285 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	stdsync "sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// StateClosed 表示熔断关闭，请求正常通过。
+	StateClosed State = iota
+	// StateHalfOpen 表示熔断半开，只允许有限的探测请求通过。
+	StateHalfOpen
+	// StateOpen 表示熔断打开，请求被直接拒绝。
+	StateOpen
+)
+
+var (
+	// ErrOpen 表示熔断打开或半开状态下探测请求已满，请求被拒绝。
+	ErrOpen = errors.New("kit/breaker: 熔断已打开")
+)
+
+type (
+	// State 表示熔断器的状态。
+	State int
+
+	// Breaker 定义了熔断器的接口，重试等组件通过该接口接入熔断。
+	Breaker interface {
+		// Allow 判断是否允许一次请求。
+		// 允许时返回记录请求结果的函数，调用方必须在请求结束后以请求的错误调用该函数。
+		//
+		// 返回值：
+		//   - func(err error)：记录请求结果的函数，参数为 nil 表示请求成功。
+		//   - error：请求被拒绝时返回 ErrOpen。
+		Allow() (func(err error), error)
+	}
+
+	// CircuitBreaker 是基于连续失败次数与滑动窗口失败率的熔断器。
+	// 关闭状态下任一策略触发即打开熔断；打开一段时间后转为半开，放行有限的探测请求，
+	// 探测请求全部成功时关闭熔断，任一失败时重新打开。
+	// CircuitBreaker 实现了 Breaker 接口，所有方法都是并发安全的。
+	CircuitBreaker struct {
+		// o 是熔断器的配置。
+		o *options
+		// mu 保护以下字段。
+		mu stdsync.Mutex
+		// state 是当前状态。
+		state State
+		// generation 在每次状态变化时递增，用于忽略状态变化之前开始的请求的结果。
+		generation uint64
+		// consecutive 是关闭状态下的连续失败次数。
+		consecutive int
+		// window 是关闭状态下统计失败率的滑动窗口。
+		window *window
+		// openedAt 是熔断打开的时间。
+		openedAt time.Time
+		// probes 是半开状态下已经放行的探测请求数。
+		probes int
+		// probeSuccesses 是半开状态下成功的探测请求数。
+		probeSuccesses int
+
+		// stateGauge 记录当前状态，未启用指标时为 nil。
+		stateGauge prometheus.Gauge
+		// successes 记录成功的请求数，未启用指标时为 nil。
+		successes prometheus.Counter
+		// failures 记录失败的请求数，未启用指标时为 nil。
+		failures prometheus.Counter
+		// rejected 记录被拒绝的请求数，未启用指标时为 nil。
+		rejected prometheus.Counter
+	}
+)
+
+// String 返回状态的名称。
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// New 创建一个处于关闭状态的熔断器。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *CircuitBreaker：熔断器实例。
+//
+// 示例：
+//
+//	b := breaker.New(breaker.WithName("user-service"), breaker.WithFailureRate(0.5, 20))
+//	err := b.Execute(func() error {
+//	    return client.Call(ctx)
+//	})
+func New(opts ...Option) *CircuitBreaker {
+	o := newOptions(opts...)
+	b := &CircuitBreaker{
+		o:      o,
+		state:  StateClosed,
+		window: newWindow(o.window, o.clock.Now()),
+	}
+	if o.metrics {
+		b.stateGauge = MetricState.WithLabelValues(o.name)
+		b.successes = MetricRequests.WithLabelValues(o.name, "success")
+		b.failures = MetricRequests.WithLabelValues(o.name, "failure")
+		b.rejected = MetricRequests.WithLabelValues(o.name, "rejected")
+		b.stateGauge.Set(float64(StateClosed))
+	}
+	return b
+}
+
+// Allow 判断是否允许一次请求，实现 Breaker 接口。
+// 返回的函数可以重复调用，只有第一次调用生效。
+//
+// 返回值：
+//   - func(err error)：记录请求结果的函数。
+//   - error：请求被拒绝时返回 ErrOpen。
+func (b *CircuitBreaker) Allow() (func(err error), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh(b.o.clock.Now())
+	switch b.state {
+	case StateOpen:
+		b.reject()
+		return nil, ErrOpen
+	case StateHalfOpen:
+		if b.probes >= b.o.halfOpenRequests {
+			b.reject()
+			return nil, ErrOpen
+		}
+		b.probes++
+	}
+
+	generation := b.generation
+	var once stdsync.Once
+	return func(err error) {
+		once.Do(func() {
+			b.record(generation, err)
+		})
+	}, nil
+}
+
+// Execute 在熔断器允许时执行 fn 并记录结果。fn 发生 panic 时计为失败并继续 panic。
+//
+// 参数：
+//   - fn：要执行的请求。
+//
+// 返回值：
+//   - error：请求被拒绝时返回 ErrOpen，否则返回 fn 的错误。
+func (b *CircuitBreaker) Execute(fn func() error) error {
+	done, err := b.Allow()
+	if nil != err {
+		return err
+	}
+	defer func() {
+		if r := recover(); nil != r {
+			done(fmt.Errorf("kit/breaker: panic：%v", r))
+			panic(r)
+		}
+	}()
+
+	err = fn()
+	done(err)
+	return err
+}
+
+// State 返回熔断器的当前状态。
+//
+// 返回值：
+//   - State：当前状态。
+func (b *CircuitBreaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh(b.o.clock.Now())
+	return b.state
+}
+
+// record 记录请求结果，调用方不能持有锁。
+func (b *CircuitBreaker) record(generation uint64, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	failure := b.o.isFailure(err)
+	if nil != b.successes {
+		if failure {
+			b.failures.Inc()
+		} else {
+			b.successes.Inc()
+		}
+	}
+
+	now := b.o.clock.Now()
+	b.refresh(now)
+	// 请求开始之后状态已经变化，结果不再代表当前状态下的下游情况。
+	if generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case StateClosed:
+		b.window.add(now, failure)
+		if !failure {
+			b.consecutive = 0
+			return
+		}
+		b.consecutive++
+		if b.shouldTrip(now) {
+			b.setState(StateOpen, now)
+		}
+	case StateHalfOpen:
+		if failure {
+			b.setState(StateOpen, now)
+			return
+		}
+		b.probeSuccesses++
+		if b.probeSuccesses >= b.o.halfOpenRequests {
+			b.setState(StateClosed, now)
+		}
+	}
+}
+
+// shouldTrip 判断关闭状态下是否应当打开熔断，调用方需要持有锁。
+func (b *CircuitBreaker) shouldTrip(now time.Time) bool {
+	if b.o.consecutiveFailures > 0 && b.consecutive >= b.o.consecutiveFailures {
+		return true
+	}
+	if b.o.failureRate > 0 {
+		total, failures := b.window.counts(now)
+		if total >= b.o.minRequests && float64(failures)/float64(total) >= b.o.failureRate {
+			return true
+		}
+	}
+	return false
+}
+
+// refresh 在熔断打开的时间超过等待时间时转为半开，调用方需要持有锁。
+func (b *CircuitBreaker) refresh(now time.Time) {
+	if StateOpen == b.state && now.Sub(b.openedAt) >= b.o.openTimeout {
+		b.setState(StateHalfOpen, now)
+	}
+}
+
+// setState 切换状态并清空计数，调用方需要持有锁。
+func (b *CircuitBreaker) setState(to State, now time.Time) {
+	from := b.state
+	b.state = to
+	b.generation++
+	b.consecutive = 0
+	b.window.reset(now)
+	b.probes = 0
+	b.probeSuccesses = 0
+	if StateOpen == to {
+		b.openedAt = now
+	}
+
+	if nil != b.stateGauge {
+		b.stateGauge.Set(float64(to))
+	}
+	b.o.getLogger().WithField("name", b.o.name).WithField("from", from.String()).WithField("to", to.String()).
+		Warn("circuit breaker state changed")
+	if nil != b.o.onStateChange {
+		b.o.onStateChange(b.o.name, from, to)
+	}
+}
+
+// reject 记录一次被拒绝的请求，调用方需要持有锁。
+func (b *CircuitBreaker) reject() {
+	if nil != b.rejected {
+		b.rejected.Inc()
+	}
+}
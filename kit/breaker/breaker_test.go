@@ -0,0 +1,225 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package breaker
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+var (
+	// errDown 是测试使用的下游错误。
+	errDown = errors.New("down")
+)
+
+type (
+	// transition 是一次状态变化。
+	transition struct {
+		from, to State
+	}
+)
+
+// fail 执行一次失败的请求。
+func fail(b *CircuitBreaker) error {
+	return b.Execute(func() error { return errDown })
+}
+
+// succeed 执行一次成功的请求。
+func succeed(b *CircuitBreaker) error {
+	return b.Execute(func() error { return nil })
+}
+
+// TestCircuitBreaker_Consecutive 测试连续失败触发熔断，打开期间拒绝请求，半开后探测成功关闭熔断。
+func TestCircuitBreaker_Consecutive(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Unix(0, 0))
+	var transitions []transition
+	b := New(
+		WithConsecutiveFailures(3),
+		WithOpenTimeout(time.Second),
+		WithClock(clock),
+		WithMetrics(false),
+		WithOnStateChange(func(_ string, from, to State) {
+			transitions = append(transitions, transition{from: from, to: to})
+		}),
+	)
+
+	// 成功的请求重置连续失败次数。
+	assert.ErrorIs(t, fail(b), errDown)
+	assert.ErrorIs(t, fail(b), errDown)
+	require.NoError(t, succeed(b))
+	assert.ErrorIs(t, fail(b), errDown)
+	assert.ErrorIs(t, fail(b), errDown)
+	assert.Equal(t, StateClosed, b.State())
+	assert.ErrorIs(t, fail(b), errDown)
+	assert.Equal(t, StateOpen, b.State())
+	assert.ErrorIs(t, succeed(b), ErrOpen)
+
+	clock.Advance(time.Second)
+	assert.Equal(t, StateHalfOpen, b.State())
+	require.NoError(t, succeed(b))
+	assert.Equal(t, StateClosed, b.State())
+
+	assert.Equal(t, []transition{
+		{from: StateClosed, to: StateOpen},
+		{from: StateOpen, to: StateHalfOpen},
+		{from: StateHalfOpen, to: StateClosed},
+	}, transitions)
+}
+
+// TestCircuitBreaker_HalfOpen 测试半开状态只放行有限的探测请求，探测失败时重新打开。
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Unix(0, 0))
+	b := New(WithConsecutiveFailures(1), WithOpenTimeout(time.Second), WithHalfOpenRequests(2), WithClock(clock), WithMetrics(false))
+
+	assert.ErrorIs(t, fail(b), errDown)
+	clock.Advance(time.Second)
+
+	done1, err := b.Allow()
+	require.NoError(t, err)
+	done2, err := b.Allow()
+	require.NoError(t, err)
+	_, err = b.Allow()
+	assert.ErrorIs(t, err, ErrOpen)
+
+	done1(nil)
+	assert.Equal(t, StateHalfOpen, b.State())
+	done2(errDown)
+	assert.Equal(t, StateOpen, b.State())
+
+	// 两个探测请求都成功时关闭。
+	clock.Advance(time.Second)
+	require.NoError(t, succeed(b))
+	assert.Equal(t, StateHalfOpen, b.State())
+	require.NoError(t, succeed(b))
+	assert.Equal(t, StateClosed, b.State())
+}
+
+// TestCircuitBreaker_FailureRate 测试滑动窗口内失败率达到阈值时打开熔断，窗口滑过后旧的请求不再计入。
+func TestCircuitBreaker_FailureRate(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Unix(0, 0))
+	b := New(WithConsecutiveFailures(0), WithFailureRate(0.5, 4), WithWindow(10*time.Second), WithClock(clock), WithMetrics(false))
+
+	// 请求数不足时不熔断。
+	assert.ErrorIs(t, fail(b), errDown)
+	assert.ErrorIs(t, fail(b), errDown)
+	assert.ErrorIs(t, fail(b), errDown)
+	assert.Equal(t, StateClosed, b.State())
+
+	// 旧的失败滑出窗口后失败率下降。
+	clock.Advance(10 * time.Second)
+	require.NoError(t, succeed(b))
+	require.NoError(t, succeed(b))
+	require.NoError(t, succeed(b))
+	assert.ErrorIs(t, fail(b), errDown)
+	assert.Equal(t, StateClosed, b.State())
+
+	clock.Advance(3 * time.Second)
+	assert.ErrorIs(t, fail(b), errDown)
+	assert.Equal(t, StateClosed, b.State())
+	assert.ErrorIs(t, fail(b), errDown)
+	assert.Equal(t, StateOpen, b.State())
+}
+
+// TestCircuitBreaker_Generation 测试状态变化之前开始的请求的结果被忽略，记录函数只生效一次。
+func TestCircuitBreaker_Generation(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Unix(0, 0))
+	b := New(WithConsecutiveFailures(1), WithOpenTimeout(time.Second), WithClock(clock), WithMetrics(false))
+
+	slow, err := b.Allow()
+	require.NoError(t, err)
+	assert.ErrorIs(t, fail(b), errDown)
+	clock.Advance(time.Second)
+	assert.Equal(t, StateHalfOpen, b.State())
+
+	// 熔断打开之前开始的请求失败，不影响半开状态。
+	slow(errDown)
+	assert.Equal(t, StateHalfOpen, b.State())
+
+	done, err := b.Allow()
+	require.NoError(t, err)
+	done(nil)
+	done(errDown)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+// TestCircuitBreaker_IsFailure 测试上下文取消与自定义的非失败错误不计为失败。
+func TestCircuitBreaker_IsFailure(t *testing.T) {
+	b := New(WithConsecutiveFailures(1), WithMetrics(false))
+	assert.ErrorIs(t, b.Execute(func() error { return context.Canceled }), context.Canceled)
+	assert.Equal(t, StateClosed, b.State())
+
+	errInvalid := errors.New("invalid argument")
+	b = New(WithConsecutiveFailures(1), WithMetrics(false), WithIsFailure(func(err error) bool {
+		return nil != err && !errors.Is(err, errInvalid)
+	}))
+	assert.ErrorIs(t, b.Execute(func() error { return errInvalid }), errInvalid)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+// TestCircuitBreaker_Panic 测试请求 panic 时计为失败并继续 panic。
+func TestCircuitBreaker_Panic(t *testing.T) {
+	b := New(WithConsecutiveFailures(1), WithMetrics(false))
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = b.Execute(func() error { panic("boom") })
+	})
+	assert.Equal(t, StateOpen, b.State())
+}
+
+// TestCircuitBreaker_Metrics 测试状态与请求数指标。
+func TestCircuitBreaker_Metrics(t *testing.T) {
+	name := t.Name() + strconv.FormatInt(time.Now().UnixNano(), 10)
+	b := New(WithName(name), WithConsecutiveFailures(1))
+	var _ Breaker = b
+
+	assert.Equal(t, float64(StateClosed), testutil.ToFloat64(MetricState.WithLabelValues(name)))
+	require.NoError(t, succeed(b))
+	assert.ErrorIs(t, fail(b), errDown)
+	assert.ErrorIs(t, succeed(b), ErrOpen)
+
+	assert.Equal(t, float64(StateOpen), testutil.ToFloat64(MetricState.WithLabelValues(name)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricRequests.WithLabelValues(name, "success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricRequests.WithLabelValues(name, "failure")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricRequests.WithLabelValues(name, "rejected")))
+}
+
+// TestState_String 测试状态的名称。
+func TestState_String(t *testing.T) {
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "half-open", StateHalfOpen.String())
+	assert.Equal(t, "open", StateOpen.String())
+	assert.Equal(t, "State(9)", State(9).String())
+}
+
+// TestNewOptions 测试非法的参数使用默认值。
+func TestNewOptions(t *testing.T) {
+	o := newOptions(
+		WithConsecutiveFailures(-1),
+		WithFailureRate(2, 0),
+		WithWindow(0),
+		WithOpenTimeout(0),
+		WithHalfOpenRequests(0),
+		WithIsFailure(nil),
+		WithClock(nil),
+		WithLogger(nil),
+	)
+	assert.Equal(t, consecutiveFailuresDefault, o.consecutiveFailures)
+	assert.Equal(t, failureRateDefault, o.failureRate)
+	assert.Equal(t, minRequestsDefault, o.minRequests)
+	assert.Equal(t, windowDefault, o.window)
+	assert.Equal(t, openTimeoutDefault, o.openTimeout)
+	assert.Equal(t, halfOpenRequestsDefault, o.halfOpenRequests)
+	assert.NotNil(t, o.isFailure)
+	assert.NotNil(t, o.clock)
+	assert.NotNil(t, o.getLogger())
+}
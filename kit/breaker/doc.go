@@ -0,0 +1,30 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package breaker 提供了熔断器，在下游持续故障时快速失败，避免故障扩散并给下游恢复的时间。
+
+主要功能：
+
+  - 三种状态：关闭时请求正常通过，打开时请求被直接拒绝，半开时只放行有限的探测请求
+  - 熔断策略：连续失败次数与滑动窗口内的失败率，任一策略触发即打开熔断
+  - 恢复：打开一段时间后转为半开，探测请求全部成功时关闭熔断，任一失败时重新打开
+  - 可观测：状态变化回调、日志，以及状态与请求数的 Prometheus 指标
+  - 接口：Breaker 接口供重试等组件接入熔断
+
+基本使用：
+
+	b := breaker.New(
+	    breaker.WithName("user-service"),
+	    breaker.WithFailureRate(0.5, 20),
+	)
+
+	err := b.Execute(func() error {
+	    return client.GetUser(ctx, id)
+	})
+	if errors.Is(err, breaker.ErrOpen) {
+	    // 熔断打开，返回降级结果。
+	}
+*/
+package breaker
@@ -0,0 +1,47 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package breaker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 定义熔断器指标相关的常量。
+const (
+	// namespace 定义 prometheus 指标的命名空间。
+	namespace = "kit_breaker"
+)
+
+var (
+	// MetricState 用于记录熔断器的当前状态，0 表示关闭，1 表示半开，2 表示打开。
+	// 该指标包含以下标签：
+	// - name: 熔断器的名称。
+	MetricState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "state",
+		Help:      "circuit breaker's state, 0 closed, 1 half-open, 2 open.",
+	}, []string{"name"})
+
+	// MetricRequests 用于记录熔断器处理的请求数。
+	// 该指标包含以下标签：
+	// - name: 熔断器的名称。
+	// - result: 处理结果，success 表示成功，failure 表示失败，rejected 表示被熔断拒绝。
+	MetricRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "circuit breaker's requests total.",
+	}, []string{"name", "result"})
+)
+
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricState,
+		MetricRequests,
+	}
+}
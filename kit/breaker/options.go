@@ -0,0 +1,269 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package breaker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 以下为熔断器的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// consecutiveFailuresDefault 为触发熔断的连续失败次数。
+	consecutiveFailuresDefault = 5
+	// failureRateDefault 为触发熔断的失败率，0 表示不按失败率熔断。
+	failureRateDefault = float64(0)
+	// minRequestsDefault 为按失败率熔断时统计窗口内的最少请求数。
+	minRequestsDefault = 20
+	// windowDefault 为统计失败率的滑动窗口大小。
+	windowDefault = 10 * time.Second
+	// openTimeoutDefault 为熔断打开后转为半开的等待时间。
+	openTimeoutDefault = 30 * time.Second
+	// halfOpenRequestsDefault 为半开状态下允许通过的探测请求数。
+	halfOpenRequestsDefault = 1
+	// isFailureDefault 为判断请求是否失败的函数，上下文取消不计为失败。
+	isFailureDefault = func(err error) bool {
+		return nil != err && !errors.Is(err, context.Canceled)
+	}
+	// metricsDefault 为是否默认记录指标。
+	metricsDefault = true
+	// clockDefault 为读取当前时间使用的时钟。
+	clockDefault = kittime.NewRealClock()
+)
+
+type (
+	// Option 定义了熔断器的配置选项。
+	Option func(*options)
+
+	// options 包含熔断器的配置。
+	options struct {
+		// name 是熔断器的名称，用于指标标签与日志。
+		name string
+		// consecutiveFailures 是触发熔断的连续失败次数，0 表示不按连续失败次数熔断。
+		consecutiveFailures int
+		// failureRate 是触发熔断的失败率，0 表示不按失败率熔断。
+		failureRate float64
+		// minRequests 是按失败率熔断时统计窗口内的最少请求数。
+		minRequests int
+		// window 是统计失败率的滑动窗口大小。
+		window time.Duration
+		// openTimeout 是熔断打开后转为半开的等待时间。
+		openTimeout time.Duration
+		// halfOpenRequests 是半开状态下允许通过的探测请求数。
+		halfOpenRequests int
+		// isFailure 判断请求是否失败。
+		isFailure func(err error) bool
+		// onStateChange 是状态变化时的回调函数。
+		onStateChange func(name string, from, to State)
+		// logger 是记录状态变化使用的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+		// metrics 表示是否记录指标。
+		metrics bool
+		// clock 是读取当前时间使用的时钟。
+		clock kittime.Clock
+	}
+)
+
+// WithName 设置熔断器的名称，用于区分不同熔断器的指标与日志。
+//
+// 参数：
+//   - name：熔断器的名称，通常为下游服务的名称，默认为空。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithConsecutiveFailures 设置连续失败多少次后打开熔断。
+//
+// 参数：
+//   - n：连续失败次数，默认为 5，0 表示不按连续失败次数熔断，小于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithConsecutiveFailures(n int) Option {
+	return func(o *options) {
+		o.consecutiveFailures = n
+	}
+}
+
+// WithFailureRate 设置滑动窗口内失败率达到多少时打开熔断。
+//
+// 参数：
+//   - rate：失败率，取值范围为 (0, 1]，默认为 0 表示不按失败率熔断，超出范围时使用默认值。
+//   - minRequests：窗口内的最少请求数，请求数不足时不按失败率熔断，默认为 20，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithFailureRate(rate float64, minRequests int) Option {
+	return func(o *options) {
+		o.failureRate = rate
+		o.minRequests = minRequests
+	}
+}
+
+// WithWindow 设置统计失败率的滑动窗口大小。
+//
+// 参数：
+//   - window：窗口大小，默认为 10 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithWindow(window time.Duration) Option {
+	return func(o *options) {
+		o.window = window
+	}
+}
+
+// WithOpenTimeout 设置熔断打开后多久转为半开状态。
+//
+// 参数：
+//   - timeout：等待时间，默认为 30 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithOpenTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.openTimeout = timeout
+	}
+}
+
+// WithHalfOpenRequests 设置半开状态下允许通过的探测请求数，探测请求全部成功后关闭熔断。
+//
+// 参数：
+//   - n：探测请求数，默认为 1，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithHalfOpenRequests(n int) Option {
+	return func(o *options) {
+		o.halfOpenRequests = n
+	}
+}
+
+// WithIsFailure 设置判断请求是否失败的函数。
+// 例如参数错误等调用方的错误不代表下游故障，不应计为失败。
+//
+// 参数：
+//   - isFailure：判断函数，默认将 context.Canceled 以外的非 nil 错误计为失败，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithIsFailure(isFailure func(err error) bool) Option {
+	return func(o *options) {
+		o.isFailure = isFailure
+	}
+}
+
+// WithOnStateChange 设置状态变化时的回调函数。
+// 回调函数在持有熔断器内部锁时同步调用，不应执行耗时操作或调用同一熔断器的方法。
+//
+// 参数：
+//   - fn：回调函数，参数为熔断器的名称、原状态与新状态。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithOnStateChange(fn func(name string, from, to State)) Option {
+	return func(o *options) {
+		o.onStateChange = fn
+	}
+}
+
+// WithLogger 设置记录状态变化使用的日志实例。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithMetrics 设置是否记录指标。
+//
+// 参数：
+//   - metrics：是否记录指标，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMetrics(metrics bool) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// WithClock 设置读取当前时间使用的时钟。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		consecutiveFailures: consecutiveFailuresDefault,
+		failureRate:         failureRateDefault,
+		minRequests:         minRequestsDefault,
+		window:              windowDefault,
+		openTimeout:         openTimeoutDefault,
+		halfOpenRequests:    halfOpenRequestsDefault,
+		isFailure:           isFailureDefault,
+		metrics:             metricsDefault,
+		clock:               clockDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.consecutiveFailures < 0 {
+		o.consecutiveFailures = consecutiveFailuresDefault
+	}
+	if o.failureRate < 0 || o.failureRate > 1 {
+		o.failureRate = failureRateDefault
+	}
+	if o.minRequests < 1 {
+		o.minRequests = minRequestsDefault
+	}
+	if o.window <= 0 {
+		o.window = windowDefault
+	}
+	if o.openTimeout <= 0 {
+		o.openTimeout = openTimeoutDefault
+	}
+	if o.halfOpenRequests < 1 {
+		o.halfOpenRequests = halfOpenRequestsDefault
+	}
+	if nil == o.isFailure {
+		o.isFailure = isFailureDefault
+	}
+	o.clock = kittime.OrReal(o.clock)
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
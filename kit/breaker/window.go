@@ -0,0 +1,89 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package breaker
+
+import (
+	"time"
+)
+
+const (
+	// windowBuckets 是滑动窗口划分的桶数，窗口每次滑动一个桶的宽度。
+	windowBuckets = 10
+)
+
+type (
+	// window 是按桶滑动的请求计数窗口，调用方负责加锁。
+	window struct {
+		// width 是每个桶覆盖的时长。
+		width time.Duration
+		// buckets 是环形排列的桶。
+		buckets [windowBuckets]bucket
+		// cur 是当前桶的位置。
+		cur int
+		// start 是当前桶的开始时间。
+		start time.Time
+	}
+
+	// bucket 记录一个桶内的请求数。
+	bucket struct {
+		// total 是请求总数。
+		total int
+		// failures 是失败的请求数。
+		failures int
+	}
+)
+
+// newWindow 创建大小为 size 的滑动窗口。
+func newWindow(size time.Duration, now time.Time) *window {
+	width := size / windowBuckets
+	if width <= 0 {
+		width = 1
+	}
+	return &window{width: width, start: now}
+}
+
+// add 记录一次请求。
+func (w *window) add(now time.Time, failure bool) {
+	w.advance(now)
+	w.buckets[w.cur].total++
+	if failure {
+		w.buckets[w.cur].failures++
+	}
+}
+
+// counts 返回窗口内的请求总数与失败数。
+func (w *window) counts(now time.Time) (total, failures int) {
+	w.advance(now)
+	for _, b := range w.buckets {
+		total += b.total
+		failures += b.failures
+	}
+	return total, failures
+}
+
+// reset 清空窗口。
+func (w *window) reset(now time.Time) {
+	w.buckets = [windowBuckets]bucket{}
+	w.cur = 0
+	w.start = now
+}
+
+// advance 将窗口滑动到 now 所在的桶，清空滑出窗口的桶。
+func (w *window) advance(now time.Time) {
+	elapsed := now.Sub(w.start)
+	if elapsed < w.width {
+		return
+	}
+	n := int(elapsed / w.width)
+	if n >= windowBuckets {
+		w.reset(now)
+		return
+	}
+	for range n {
+		w.cur = (w.cur + 1) % windowBuckets
+		w.buckets[w.cur] = bucket{}
+	}
+	w.start = w.start.Add(time.Duration(n) * w.width)
+}
@@ -0,0 +1,586 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// EvictReason 定义了缓存项被移除的原因。
+type EvictReason int
+
+const (
+	// EvictReasonExpired 表示缓存项已过期。
+	EvictReasonExpired EvictReason = iota
+	// EvictReasonCapacity 表示缓存已满，最久未使用的缓存项被淘汰。
+	EvictReasonCapacity
+	// EvictReasonDeleted 表示缓存项被 Delete 或 Clear 显式删除。
+	EvictReasonDeleted
+	// EvictReasonReplaced 表示缓存项被新的值覆盖。
+	EvictReasonReplaced
+)
+
+// 以下为缓存的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// maxEntriesDefault 为缓存的最大条目数，0 表示不限制。
+	maxEntriesDefault = 0
+	// ttlDefault 为缓存项的默认过期时间，0 表示永不过期。
+	ttlDefault = time.Duration(0)
+	// cleanupIntervalDefault 为后台清理过期缓存项的时间间隔，0 表示默认不启动后台清理。
+	cleanupIntervalDefault = time.Duration(0)
+	// loadAttemptsDefault 为 GetOrLoad 加载失败时的最大尝试次数，1 表示不重试。
+	loadAttemptsDefault = 1
+	// metricsDefault 为是否默认记录指标。
+	metricsDefault = true
+	// clockDefault 为读取当前时间与定时清理使用的时钟。
+	clockDefault = kittime.NewRealClock()
+)
+
+type (
+	// Cache 是基于泛型的内存缓存，支持按条目设置过期时间与按最近最少使用（LRU）淘汰。
+	// 过期的缓存项在读取时即视为不存在。只有通过 WithCleanupInterval 启用后台清理时才会定期清理，此时不再使用时需要调用 Close 停止后台协程；
+	// 没有启用时，过期的缓存项在被访问时移除，或者通过 DeleteExpired 清理。
+	// Cache 的所有方法都是并发安全的。
+	Cache[K comparable, V any] struct {
+		// mu 保护 items、lru 与 onEvict。
+		mu sync.Mutex
+		// items 是键到 LRU 链表节点的映射。
+		items map[K]*list.Element
+		// lru 是按最近使用时间排列的链表，表头为最近使用的缓存项。
+		lru *list.List
+		// onEvict 是缓存项被移除时的回调函数。
+		onEvict func(key K, value V, reason EvictReason)
+		// maxEntries 是最大条目数，0 表示不限制。
+		maxEntries int
+		// ttl 是缓存项的默认过期时间，0 表示永不过期。
+		ttl time.Duration
+		// staleWindow 是缓存项过期后仍可由 GetOrLoad 返回旧值的时间。
+		staleWindow time.Duration
+		// loadAttempts 是 GetOrLoad 加载失败时的最大尝试次数。
+		loadAttempts int
+		// loadBackoff 是 GetOrLoad 重试加载时的退避配置。
+		loadBackoff []retry.BackoffOption
+		// callMu 保护 calls。
+		callMu sync.Mutex
+		// calls 是正在进行的加载，同一个键同时只有一个加载。
+		calls map[K]*call[V]
+		// clock 是读取当前时间、等待重试与定时清理使用的时钟。
+		clock kittime.Clock
+		// closed 在 Close 时关闭，通知后台协程退出。
+		closed chan struct{}
+		// closeOnce 保证 Close 只执行一次。
+		closeOnce sync.Once
+		// hits 是命中次数的指标，未开启指标时为 nil。
+		hits prometheus.Counter
+		// misses 是未命中次数的指标，未开启指标时为 nil。
+		misses prometheus.Counter
+		// evictions 是各原因移除的缓存项数量的指标，未开启指标时为 nil。
+		evictions *prometheus.CounterVec
+		// entries 是当前条目数的指标，未开启指标时为 nil。
+		entries prometheus.Gauge
+		// stales 是返回旧值次数的指标，未开启指标时为 nil。
+		stales prometheus.Counter
+		// loadSuccesses 是加载成功次数的指标，未开启指标时为 nil。
+		loadSuccesses prometheus.Counter
+		// loadFailures 是加载失败次数的指标，未开启指标时为 nil。
+		loadFailures prometheus.Counter
+	}
+
+	// entry 是缓存项。
+	entry[K comparable, V any] struct {
+		// key 是缓存项的键。
+		key K
+		// value 是缓存项的值。
+		value V
+		// staleAt 是缓存项不再新鲜的时间，零值表示永不过期。
+		staleAt time.Time
+		// expireAt 是缓存项被移除的时间，等于 staleAt 加上允许返回旧值的时间，零值表示永不过期。
+		expireAt time.Time
+	}
+
+	// evicted 是被移除的缓存项，用于在释放锁之后调用回调函数。
+	evicted[K comparable, V any] struct {
+		// key 是缓存项的键。
+		key K
+		// value 是缓存项的值。
+		value V
+		// reason 是移除的原因。
+		reason EvictReason
+	}
+
+	// Option 定义了缓存的配置选项。
+	Option func(*options)
+
+	// options 包含缓存的配置。
+	options struct {
+		// maxEntries 是最大条目数。
+		maxEntries int
+		// ttl 是缓存项的默认过期时间。
+		ttl time.Duration
+		// cleanupInterval 是后台清理过期缓存项的时间间隔。
+		cleanupInterval time.Duration
+		// staleWindow 是缓存项过期后仍可由 GetOrLoad 返回旧值的时间。
+		staleWindow time.Duration
+		// loadAttempts 是 GetOrLoad 加载失败时的最大尝试次数。
+		loadAttempts int
+		// loadBackoff 是 GetOrLoad 重试加载时的退避配置。
+		loadBackoff []retry.BackoffOption
+		// name 是缓存的名称。
+		name string
+		// metrics 表示是否记录指标。
+		metrics bool
+		// clock 是读取当前时间、等待重试与定时清理使用的时钟。
+		clock kittime.Clock
+	}
+)
+
+// String 返回移除原因的名称，同时用作指标的 reason 标签。
+//
+// 返回值：
+//   - string：移除原因的名称。
+func (r EvictReason) String() string {
+	switch r {
+	case EvictReasonExpired:
+		return "expired"
+	case EvictReasonCapacity:
+		return "capacity"
+	case EvictReasonDeleted:
+		return "deleted"
+	case EvictReasonReplaced:
+		return "replaced"
+	default:
+		return "unknown"
+	}
+}
+
+// WithMaxEntries 设置缓存的最大条目数，超过时淘汰最久未使用的缓存项。
+//
+// 参数：
+//   - maxEntries：最大条目数，0 表示不限制（默认）。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxEntries(maxEntries int) Option {
+	return func(o *options) {
+		o.maxEntries = maxEntries
+	}
+}
+
+// WithTTL 设置缓存项的默认过期时间，Set 使用该过期时间。
+//
+// 参数：
+//   - ttl：过期时间，0 表示永不过期（默认）。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithCleanupInterval 启用后台清理过期缓存项，并设置清理的时间间隔。
+// 后台清理由一个协程定期执行，启用后不再使用缓存时必须调用 Close，否则该协程与缓存都无法被回收。
+//
+// 参数：
+//   - interval：清理间隔，默认为 0；0 或负数表示不启动后台清理，过期缓存项只在访问时移除。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCleanupInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.cleanupInterval = interval
+	}
+}
+
+// WithStaleWhileRevalidate 设置缓存项过期后仍可由 GetOrLoad 返回旧值的时间。
+// 在该时间内，GetOrLoad 立即返回旧值，同时在后台重新加载；Get 与 Keys 仍将其视为不存在。
+//
+// 参数：
+//   - window：允许返回旧值的时间，0 表示不返回旧值（默认）。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithStaleWhileRevalidate(window time.Duration) Option {
+	return func(o *options) {
+		o.staleWindow = window
+	}
+}
+
+// WithLoadRetry 设置 GetOrLoad 加载失败时的重试策略，重试间隔由 kit/runtime/retry 的退避算法计算。
+//
+// 参数：
+//   - attempts：最大尝试次数（包含第一次），默认为 1，即不重试。
+//   - opts：退避配置，例如 retry.WithMin、retry.WithMax。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLoadRetry(attempts int, opts ...retry.BackoffOption) Option {
+	return func(o *options) {
+		o.loadAttempts = attempts
+		o.loadBackoff = opts
+	}
+}
+
+// WithName 设置缓存的名称，作为指标的 name 标签。
+//
+// 参数：
+//   - name：缓存名称。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithMetrics 设置是否记录指标。
+//
+// 参数：
+//   - metrics：是否记录指标，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMetrics(metrics bool) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// WithClock 设置读取当前时间、等待重试与定时清理使用的时钟。
+// 测试时可以注入 kit/time 的 FakeClock，推进时钟即可使缓存项过期。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// New 创建缓存。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Cache[K, V]：缓存实例，通过 WithCleanupInterval 启用后台清理时，不再使用时必须调用 Close。
+//
+// 示例：
+//
+//	c := cache.New[string, *User](
+//	    cache.WithMaxEntries(10000),
+//	    cache.WithTTL(5*time.Minute),
+//	    cache.WithCleanupInterval(time.Minute),
+//	    cache.WithName("user"),
+//	)
+//	defer c.Close()
+//
+//	c.Set("u:1", user)
+//	if u, ok := c.Get("u:1"); ok {
+//	    // 命中。
+//	}
+func New[K comparable, V any](opts ...Option) *Cache[K, V] {
+	o := &options{
+		maxEntries:      maxEntriesDefault,
+		ttl:             ttlDefault,
+		cleanupInterval: cleanupIntervalDefault,
+		loadAttempts:    loadAttemptsDefault,
+		metrics:         metricsDefault,
+		clock:           clockDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.staleWindow < 0 {
+		o.staleWindow = 0
+	}
+	if o.loadAttempts < 1 {
+		o.loadAttempts = loadAttemptsDefault
+	}
+	o.clock = kittime.OrReal(o.clock)
+
+	c := &Cache[K, V]{
+		items:        make(map[K]*list.Element),
+		lru:          list.New(),
+		maxEntries:   o.maxEntries,
+		ttl:          o.ttl,
+		staleWindow:  o.staleWindow,
+		loadAttempts: o.loadAttempts,
+		loadBackoff:  o.loadBackoff,
+		calls:        make(map[K]*call[V]),
+		clock:        o.clock,
+		closed:       make(chan struct{}),
+	}
+	if o.metrics {
+		c.hits = MetricRequests.WithLabelValues(o.name, "hit")
+		c.misses = MetricRequests.WithLabelValues(o.name, "miss")
+		c.evictions = MetricEvictions.MustCurryWith(prometheus.Labels{"name": o.name})
+		c.entries = MetricEntries.WithLabelValues(o.name)
+		c.stales = MetricRequests.WithLabelValues(o.name, "stale")
+		c.loadSuccesses = MetricLoads.WithLabelValues(o.name, "success")
+		c.loadFailures = MetricLoads.WithLabelValues(o.name, "failure")
+	}
+	if o.cleanupInterval > 0 {
+		go c.janitor(o.cleanupInterval)
+	}
+	return c
+}
+
+// OnEvict 设置缓存项被移除时的回调函数，包括过期、容量淘汰、显式删除与被覆盖。
+// 回调函数在释放锁之后同步调用，可以安全地访问缓存。
+//
+// 参数：
+//   - fn：回调函数，为 nil 时取消回调。
+func (c *Cache[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvict = fn
+}
+
+// Get 获取缓存项的值，并将其标记为最近使用。
+//
+// 参数：
+//   - key：缓存项的键。
+//
+// 返回值：
+//   - V：缓存项的值，不存在或已过期时返回零值。
+//   - bool：缓存项存在且未过期时返回 true。
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	var removed []evicted[K, V]
+	var value V
+	ok := false
+	if el, exists := c.items[key]; exists {
+		e := el.Value.(*entry[K, V])
+		now := c.clock.Now()
+		switch {
+		case c.expired(e, now):
+			removed = append(removed, c.remove(el, EvictReasonExpired))
+		case c.stale(e, now):
+			// 过期但仍可由 GetOrLoad 返回旧值，暂不移除。
+		default:
+			c.lru.MoveToFront(el)
+			value, ok = e.value, true
+		}
+	}
+	onEvict := c.changed()
+	c.mu.Unlock()
+
+	c.notify(removed, onEvict)
+	c.recordGet(ok)
+	return value, ok
+}
+
+// Set 使用默认过期时间设置缓存项。
+//
+// 参数：
+//   - key：缓存项的键。
+//   - value：缓存项的值。
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL 使用指定的过期时间设置缓存项，已存在的缓存项会被覆盖。
+// 缓存已满时淘汰最久未使用的缓存项。
+//
+// 参数：
+//   - key：缓存项的键。
+//   - value：缓存项的值。
+//   - ttl：过期时间，0 或负数表示永不过期。
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	var staleAt, expireAt time.Time
+	if ttl > 0 {
+		staleAt = c.clock.Now().Add(ttl)
+		expireAt = staleAt.Add(c.staleWindow)
+	}
+
+	c.mu.Lock()
+	var removed []evicted[K, V]
+	if el, exists := c.items[key]; exists {
+		e := el.Value.(*entry[K, V])
+		removed = append(removed, evicted[K, V]{key: key, value: e.value, reason: EvictReasonReplaced})
+		e.value = value
+		e.staleAt = staleAt
+		e.expireAt = expireAt
+		c.lru.MoveToFront(el)
+	} else {
+		c.items[key] = c.lru.PushFront(&entry[K, V]{key: key, value: value, staleAt: staleAt, expireAt: expireAt})
+		for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+			removed = append(removed, c.remove(c.lru.Back(), EvictReasonCapacity))
+		}
+	}
+	onEvict := c.changed()
+	c.mu.Unlock()
+
+	c.notify(removed, onEvict)
+}
+
+// Delete 删除缓存项。
+//
+// 参数：
+//   - key：缓存项的键。
+//
+// 返回值：
+//   - bool：缓存项存在时返回 true。
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	el, exists := c.items[key]
+	var removed []evicted[K, V]
+	if exists {
+		removed = append(removed, c.remove(el, EvictReasonDeleted))
+	}
+	onEvict := c.changed()
+	c.mu.Unlock()
+
+	c.notify(removed, onEvict)
+	return exists
+}
+
+// Clear 删除全部缓存项。
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	removed := make([]evicted[K, V], 0, c.lru.Len())
+	for el := c.lru.Back(); nil != el; el = c.lru.Back() {
+		removed = append(removed, c.remove(el, EvictReasonDeleted))
+	}
+	onEvict := c.changed()
+	c.mu.Unlock()
+
+	c.notify(removed, onEvict)
+}
+
+// Len 返回缓存项的数量，可能包含已过期但尚未清理的缓存项。
+//
+// 返回值：
+//   - int：缓存项的数量。
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Keys 返回未过期的缓存项的键，按最近使用时间从近到远排列。
+//
+// 返回值：
+//   - []K：键列表。
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	keys := make([]K, 0, c.lru.Len())
+	for el := c.lru.Front(); nil != el; el = el.Next() {
+		e := el.Value.(*entry[K, V])
+		if !c.stale(e, now) {
+			keys = append(keys, e.key)
+		}
+	}
+	return keys
+}
+
+// DeleteExpired 立即清理全部过期的缓存项。
+// 通过 WithCleanupInterval 启用后台清理时，后台协程会定期调用该方法；没有启用时，过期的缓存项只在被访问时移除，
+// 可以按需调用该方法释放其余过期缓存项占用的内存。
+func (c *Cache[K, V]) DeleteExpired() {
+	c.mu.Lock()
+	now := c.clock.Now()
+	var removed []evicted[K, V]
+	for el := c.lru.Back(); nil != el; {
+		prev := el.Prev()
+		if c.expired(el.Value.(*entry[K, V]), now) {
+			removed = append(removed, c.remove(el, EvictReasonExpired))
+		}
+		el = prev
+	}
+	onEvict := c.changed()
+	c.mu.Unlock()
+
+	c.notify(removed, onEvict)
+}
+
+// Close 停止后台清理协程，多次调用是安全的。缓存在关闭后仍然可以使用。
+// 通过 WithCleanupInterval 启用后台清理时必须调用 Close，否则清理协程会一直运行并持有缓存；
+// 没有启用时调用 Close 只会停止 GetOrLoad 的加载重试。
+func (c *Cache[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+	})
+}
+
+// janitor 定期清理过期的缓存项。
+func (c *Cache[K, V]) janitor(interval time.Duration) {
+	ticker := c.clock.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+			c.DeleteExpired()
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+// stale 判断缓存项在 now 时是否已经不再新鲜，不再新鲜的缓存项对 Get 不可见。
+func (c *Cache[K, V]) stale(e *entry[K, V], now time.Time) bool {
+	return !e.staleAt.IsZero() && !now.Before(e.staleAt)
+}
+
+// expired 判断缓存项在 now 时是否已经超过允许返回旧值的时间，需要被移除。
+func (c *Cache[K, V]) expired(e *entry[K, V], now time.Time) bool {
+	return !e.expireAt.IsZero() && !now.Before(e.expireAt)
+}
+
+// remove 移除缓存项并返回移除记录，调用方需要持有锁。
+func (c *Cache[K, V]) remove(el *list.Element, reason EvictReason) evicted[K, V] {
+	e := c.lru.Remove(el).(*entry[K, V])
+	delete(c.items, e.key)
+	return evicted[K, V]{key: e.key, value: e.value, reason: reason}
+}
+
+// changed 在缓存项数量可能变化后更新指标，并返回当前的回调函数，调用方需要持有锁。
+func (c *Cache[K, V]) changed() func(key K, value V, reason EvictReason) {
+	if nil != c.entries {
+		c.entries.Set(float64(c.lru.Len()))
+	}
+	return c.onEvict
+}
+
+// notify 在释放锁之后记录移除指标并调用移除回调函数。
+func (c *Cache[K, V]) notify(removed []evicted[K, V], onEvict func(key K, value V, reason EvictReason)) {
+	for _, r := range removed {
+		if nil != c.evictions {
+			c.evictions.WithLabelValues(r.reason.String()).Inc()
+		}
+		if nil != onEvict {
+			onEvict(r.key, r.value, r.reason)
+		}
+	}
+}
+
+// recordGet 记录命中与未命中的指标。
+func (c *Cache[K, V]) recordGet(hit bool) {
+	if nil == c.hits {
+		return
+	}
+	if hit {
+		c.hits.Inc()
+	} else {
+		c.misses.Inc()
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// evictRecord 记录一次移除回调。
+type evictRecord struct {
+	key    string
+	value  int
+	reason EvictReason
+}
+
+// TestCache_Basic 测试基本的读写与删除。
+func TestCache_Basic(t *testing.T) {
+	c := New[string, int](WithMetrics(false))
+	defer c.Close()
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, []string{"a", "b"}, c.Keys(), "最近读取的键排在前面")
+
+	c.Set("a", 10)
+	v, _ = c.Get("a")
+	assert.Equal(t, 10, v)
+
+	assert.True(t, c.Delete("a"))
+	assert.False(t, c.Delete("a"))
+	assert.Equal(t, 1, c.Len())
+
+	c.Clear()
+	assert.Equal(t, 0, c.Len())
+}
+
+// TestCache_TTL 测试过期时间。
+func TestCache_TTL(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Unix(0, 0))
+	c := New[string, int](WithTTL(time.Minute), WithCleanupInterval(0), WithMetrics(false), WithClock(clock))
+	defer c.Close()
+
+	var records []evictRecord
+	c.OnEvict(func(key string, value int, reason EvictReason) {
+		records = append(records, evictRecord{key, value, reason})
+	})
+
+	c.Set("default", 1)
+	c.SetWithTTL("short", 2, time.Second)
+	c.SetWithTTL("forever", 3, 0)
+
+	clock.Advance(time.Second)
+	_, ok := c.Get("short")
+	assert.False(t, ok, "到达过期时间即视为过期")
+	assert.Equal(t, []evictRecord{{"short", 2, EvictReasonExpired}}, records)
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, []string{"forever"}, c.Keys())
+	assert.Equal(t, 2, c.Len(), "过期但未清理的缓存项仍计入数量")
+
+	c.DeleteExpired()
+	assert.Equal(t, 1, c.Len())
+	assert.Equal(t, evictRecord{"default", 1, EvictReasonExpired}, records[1])
+
+	v, ok := c.Get("forever")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+}
+
+// TestCache_LRU 测试按最近最少使用淘汰。
+func TestCache_LRU(t *testing.T) {
+	c := New[string, int](WithMaxEntries(2), WithMetrics(false))
+	defer c.Close()
+
+	var records []evictRecord
+	c.OnEvict(func(key string, value int, reason EvictReason) {
+		records = append(records, evictRecord{key, value, reason})
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Set("c", 3)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok, "最久未使用的 b 被淘汰")
+	assert.Equal(t, []string{"c", "a"}, c.Keys())
+
+	c.Set("a", 4)
+	c.Delete("c")
+	assert.Equal(t, []evictRecord{
+		{"b", 2, EvictReasonCapacity},
+		{"a", 1, EvictReasonReplaced},
+		{"c", 3, EvictReasonDeleted},
+	}, records)
+}
+
+// TestCache_Janitor 测试后台清理。
+func TestCache_Janitor(t *testing.T) {
+	c := New[string, int](WithTTL(10*time.Millisecond), WithCleanupInterval(5*time.Millisecond), WithMetrics(false))
+	defer c.Close()
+
+	c.Set("a", 1)
+	require.Eventually(t, func() bool { return 0 == c.Len() }, time.Second, 5*time.Millisecond)
+
+	c.Close()
+	c.Close()
+}
+
+// TestCache_JanitorOptIn 测试后台清理默认不启动，通过 WithCleanupInterval 启用并由 Close 停止。
+func TestCache_JanitorOptIn(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Unix(0, 0))
+
+	c := New[string, int](WithTTL(time.Minute), WithClock(clock), WithMetrics(false))
+	assert.Never(t, func() bool { return 0 != clock.Waiters() }, 50*time.Millisecond, 5*time.Millisecond, "默认不启动后台清理")
+	c.Close()
+
+	c = New[string, int](WithTTL(time.Minute), WithCleanupInterval(time.Second), WithClock(clock), WithMetrics(false))
+	clock.BlockUntil(1)
+	c.Close()
+	require.Eventually(t, func() bool { return 0 == clock.Waiters() }, time.Second, 5*time.Millisecond, "Close 后停止后台清理")
+}
+
+// TestCache_CallbackReentrant 测试回调函数中可以访问缓存。
+func TestCache_CallbackReentrant(t *testing.T) {
+	c := New[string, int](WithMaxEntries(1), WithMetrics(false))
+	defer c.Close()
+
+	var lens []int
+	c.OnEvict(func(key string, value int, reason EvictReason) {
+		_, ok := c.Get(key)
+		assert.False(t, ok, "回调时缓存项已被移除")
+		lens = append(lens, c.Len())
+	})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Delete("b")
+	assert.Equal(t, []int{1, 0}, lens)
+}
+
+// TestCache_Metrics 测试指标记录。
+func TestCache_Metrics(t *testing.T) {
+	name := "test-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	c := New[string, int](WithName(name), WithMaxEntries(1))
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Get("a")
+	c.Get("b")
+	c.Get("b")
+	c.Set("b", 2)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricRequests.WithLabelValues(name, "hit")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricRequests.WithLabelValues(name, "miss")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricEvictions.WithLabelValues(name, "capacity")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricEntries.WithLabelValues(name)))
+}
+
+// TestCache_Concurrent 测试并发读写。
+func TestCache_Concurrent(t *testing.T) {
+	c := New[int, int](WithMaxEntries(100), WithTTL(time.Millisecond), WithCleanupInterval(time.Millisecond))
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				c.Set(j%200, i)
+				c.Get((j + i) % 200)
+				if 0 == j%100 {
+					c.Keys()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, c.Len(), 100)
+}
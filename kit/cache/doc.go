@@ -0,0 +1,50 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package cache 提供了基于泛型的内存缓存。
+
+主要功能：
+
+  - 类型安全：Cache[K, V] 通过泛型约束键与值的类型，读取时不需要类型断言
+  - 过期时间：WithTTL 设置默认过期时间，SetWithTTL 为单个缓存项设置过期时间
+  - LRU 淘汰：WithMaxEntries 限制条目数，超过时淘汰最久未使用的缓存项
+  - 后台清理：WithCleanupInterval 启用定期清理过期的缓存项，默认不启动；启用后不再使用时必须调用 Close 停止
+  - 加载缓存：GetOrLoad 合并同一个键的并发加载，支持过期后返回旧值并在后台刷新，以及加载失败重试
+  - 移除回调：OnEvict 在缓存项过期、被淘汰、被删除或被覆盖时回调
+  - 指标：记录命中、未命中、移除次数与当前条目数的 Prometheus 指标
+
+基本使用：
+
+	c := cache.New[string, *User](
+	    cache.WithMaxEntries(10000),
+	    cache.WithTTL(5*time.Minute),
+	    cache.WithCleanupInterval(time.Minute),
+	    cache.WithName("user"),
+	)
+	defer c.Close()
+
+	c.Set("u:1", user)
+	if u, ok := c.Get("u:1"); ok {
+	    // 命中。
+	}
+
+加载缓存：
+
+	users := cache.New[int64, *User](
+	    cache.WithTTL(5*time.Minute),
+	    cache.WithStaleWhileRevalidate(time.Minute),
+	    cache.WithLoadRetry(3),
+	)
+	u, err := users.GetOrLoad(ctx, id, func(ctx context.Context, id int64) (*User, error) {
+	    return db.FindUser(ctx, id)
+	})
+
+移除回调：
+
+	c.OnEvict(func(key string, u *User, reason cache.EvictReason) {
+	    logger.Debugf("缓存项 %s 被移除：%s", key, reason)
+	})
+*/
+package cache
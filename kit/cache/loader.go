@@ -0,0 +1,195 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+var (
+	// ErrLoaderPanic 表示加载函数发生了 panic，GetOrLoad 返回的错误会包装该错误。
+	ErrLoaderPanic = errors.New("缓存加载函数发生 panic")
+)
+
+type (
+	// Loader 定义了缓存未命中时加载值的函数。
+	//
+	// 参数：
+	//   - ctx：上下文，不会随调用方的上下文取消。
+	//   - key：缓存项的键。
+	//
+	// 返回值：
+	//   - V：加载到的值。
+	//   - error：加载失败时返回错误，失败的结果不会被缓存。
+	Loader[K comparable, V any] func(ctx context.Context, key K) (V, error)
+
+	// call 是一次正在进行的加载，同一个键的并发调用共享同一次加载的结果。
+	call[V any] struct {
+		// done 在加载完成后关闭。
+		done chan struct{}
+		// val 是加载到的值，done 关闭后可读。
+		val V
+		// err 是加载的错误，done 关闭后可读。
+		err error
+	}
+
+	// lookupState 是 GetOrLoad 查找缓存项的结果。
+	lookupState int
+)
+
+const (
+	// lookupMiss 表示缓存项不存在或已过期。
+	lookupMiss lookupState = iota
+	// lookupFresh 表示缓存项存在且未过期。
+	lookupFresh
+	// lookupStale 表示缓存项已过期，但仍在允许返回旧值的时间内。
+	lookupStale
+)
+
+// GetOrLoad 获取缓存项的值，未命中时调用 loader 加载并写入缓存。
+// 同一个键的并发调用只会执行一次加载，其余调用等待并共享加载结果。
+// 开启 WithStaleWhileRevalidate 时，过期不久的缓存项会被立即返回，同时在后台重新加载。
+// 加载失败时按 WithLoadRetry 的配置重试，最终失败的结果不会被缓存。
+//
+// 加载在独立的协程中执行，使用不随 ctx 取消的上下文；调用方因 ctx 取消而提前返回时，加载仍会继续并写入缓存。
+//
+// 参数：
+//   - ctx：上下文，用于取消等待。
+//   - key：缓存项的键。
+//   - loader：加载函数。
+//
+// 返回值：
+//   - V：缓存项的值。
+//   - error：加载失败或 ctx 被取消时返回错误；loader 发生 panic 时返回包装了 ErrLoaderPanic 的错误。
+//
+// 示例：
+//
+//	u, err := c.GetOrLoad(ctx, userID, func(ctx context.Context, id string) (*User, error) {
+//	    return repo.FindUser(ctx, id)
+//	})
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, loader Loader[K, V]) (V, error) {
+	value, state := c.lookup(key)
+	switch state {
+	case lookupFresh:
+		c.recordGet(true)
+		return value, nil
+	case lookupStale:
+		if nil != c.stales {
+			c.stales.Inc()
+		}
+		c.load(ctx, key, loader)
+		return value, nil
+	}
+
+	c.recordGet(false)
+	cl := c.load(ctx, key, loader)
+	select {
+	case <-cl.done:
+		return cl.val, cl.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// lookup 查找缓存项并区分未过期、可返回旧值与不存在三种情况，已超过旧值时间的缓存项会被移除。
+func (c *Cache[K, V]) lookup(key K) (V, lookupState) {
+	var (
+		value   V
+		state   = lookupMiss
+		removed []evicted[K, V]
+	)
+
+	c.mu.Lock()
+	if el, found := c.items[key]; found {
+		e := el.Value.(*entry[K, V])
+		now := c.clock.Now()
+		switch {
+		case c.expired(e, now):
+			removed = append(removed, c.remove(el, EvictReasonExpired))
+		case c.stale(e, now):
+			c.lru.MoveToFront(el)
+			value, state = e.value, lookupStale
+		default:
+			c.lru.MoveToFront(el)
+			value, state = e.value, lookupFresh
+		}
+	}
+	onEvict := c.changed()
+	c.mu.Unlock()
+
+	c.notify(removed, onEvict)
+	return value, state
+}
+
+// load 返回键上正在进行的加载，没有时启动新的加载。
+func (c *Cache[K, V]) load(ctx context.Context, key K, loader Loader[K, V]) *call[V] {
+	c.callMu.Lock()
+	defer c.callMu.Unlock()
+	if cl, ok := c.calls[key]; ok {
+		return cl
+	}
+
+	cl := &call[V]{done: make(chan struct{})}
+	c.calls[key] = cl
+	go c.doLoad(context.WithoutCancel(ctx), key, loader, cl)
+	return cl
+}
+
+// doLoad 执行加载并按配置重试，成功时写入缓存，完成后唤醒全部等待的调用方。
+func (c *Cache[K, V]) doLoad(ctx context.Context, key K, loader Loader[K, V], cl *call[V]) {
+	defer func() {
+		c.callMu.Lock()
+		delete(c.calls, key)
+		c.callMu.Unlock()
+		close(cl.done)
+	}()
+
+	backoff := retry.NewBackoff(c.loadBackoff...)
+	for attempt := 1; ; attempt++ {
+		cl.val, cl.err = callLoader(ctx, key, loader)
+		if nil == cl.err || attempt >= c.loadAttempts || !c.sleep(backoff.Duration()) {
+			break
+		}
+	}
+
+	if nil != cl.err {
+		if nil != c.loadFailures {
+			c.loadFailures.Inc()
+		}
+		return
+	}
+	c.Set(key, cl.val)
+	if nil != c.loadSuccesses {
+		c.loadSuccesses.Inc()
+	}
+}
+
+// sleep 等待重试间隔，缓存被关闭时提前返回 false。
+func (c *Cache[K, V]) sleep(d time.Duration) bool {
+	timer := c.clock.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return true
+	case <-c.closed:
+		return false
+	}
+}
+
+// callLoader 调用加载函数，并将 panic 转换为错误。
+func callLoader[K comparable, V any](ctx context.Context, key K, loader Loader[K, V]) (value V, err error) {
+	defer func() {
+		if r := recover(); nil != r {
+			err = fmt.Errorf("%w：%v", ErrLoaderPanic, r)
+		}
+	}()
+	return loader(ctx, key)
+}
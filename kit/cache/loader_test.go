@@ -0,0 +1,267 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// TestCache_GetOrLoad 测试未命中时加载并写入缓存。
+func TestCache_GetOrLoad(t *testing.T) {
+	c := New[string, int](WithMetrics(false))
+	defer c.Close()
+
+	var calls int32
+	loader := func(_ context.Context, key string) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return len(key), nil
+	}
+
+	v, err := c.GetOrLoad(context.Background(), "abc", loader)
+	require.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	v, err = c.GetOrLoad(context.Background(), "abc", loader)
+	require.NoError(t, err)
+	assert.Equal(t, 3, v)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "命中时不再加载")
+
+	v, ok := c.Get("abc")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+}
+
+// TestCache_GetOrLoadSingleflight 测试同一个键的并发调用只加载一次。
+func TestCache_GetOrLoadSingleflight(t *testing.T) {
+	c := New[string, int](WithMetrics(false))
+	defer c.Close()
+
+	var calls int32
+	release := make(chan struct{})
+	loader := func(_ context.Context, _ string) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 42, nil
+	}
+
+	const n = 50
+	var wg sync.WaitGroup
+	results := make([]int, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := c.GetOrLoad(context.Background(), "k", loader)
+			assert.NoError(t, err)
+			results[i] = v
+		}(i)
+	}
+
+	// 等待所有调用方进入等待后再完成加载。
+	require.Eventually(t, func() bool { return 1 == atomic.LoadInt32(&calls) }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, v := range results {
+		assert.Equal(t, 42, v)
+	}
+}
+
+// TestCache_GetOrLoadError 测试加载失败的结果不会被缓存。
+func TestCache_GetOrLoadError(t *testing.T) {
+	c := New[string, int](WithMetrics(false))
+	defer c.Close()
+
+	errLoad := errors.New("load failed")
+	var calls int32
+	loader := func(_ context.Context, _ string) (int, error) {
+		if 1 == atomic.AddInt32(&calls, 1) {
+			return 0, errLoad
+		}
+		return 7, nil
+	}
+
+	_, err := c.GetOrLoad(context.Background(), "k", loader)
+	assert.ErrorIs(t, err, errLoad)
+	assert.Equal(t, 0, c.Len())
+
+	v, err := c.GetOrLoad(context.Background(), "k", loader)
+	require.NoError(t, err)
+	assert.Equal(t, 7, v)
+}
+
+// TestCache_GetOrLoadPanic 测试加载函数的 panic 被转换为错误。
+func TestCache_GetOrLoadPanic(t *testing.T) {
+	c := New[string, int](WithMetrics(false))
+	defer c.Close()
+
+	_, err := c.GetOrLoad(context.Background(), "k", func(_ context.Context, _ string) (int, error) {
+		panic("boom")
+	})
+	assert.ErrorIs(t, err, ErrLoaderPanic)
+	assert.Contains(t, err.Error(), "boom")
+}
+
+// TestCache_GetOrLoadRetry 测试加载失败时按配置重试。
+func TestCache_GetOrLoadRetry(t *testing.T) {
+	errLoad := errors.New("load failed")
+	tests := []struct {
+		name     string
+		attempts int
+		failures int32
+		wantErr  bool
+		wantCall int32
+	}{
+		{name: "不重试", attempts: 1, failures: 1, wantErr: true, wantCall: 1},
+		{name: "重试后成功", attempts: 3, failures: 2, wantErr: false, wantCall: 3},
+		{name: "重试次数用尽", attempts: 3, failures: 5, wantErr: true, wantCall: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New[string, int](
+				WithMetrics(false),
+				WithLoadRetry(tt.attempts, retry.WithMin(time.Millisecond), retry.WithMax(2*time.Millisecond)),
+			)
+			defer c.Close()
+
+			var calls int32
+			v, err := c.GetOrLoad(context.Background(), "k", func(_ context.Context, _ string) (int, error) {
+				if atomic.AddInt32(&calls, 1) <= tt.failures {
+					return 0, errLoad
+				}
+				return 1, nil
+			})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errLoad)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 1, v)
+			}
+			assert.Equal(t, tt.wantCall, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+// TestCache_GetOrLoadContext 测试调用方的上下文取消后提前返回，加载仍然完成并写入缓存。
+func TestCache_GetOrLoadContext(t *testing.T) {
+	c := New[string, int](WithMetrics(false))
+	defer c.Close()
+
+	release := make(chan struct{})
+	loaded := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_, err := c.GetOrLoad(ctx, "k", func(ctx context.Context, _ string) (int, error) {
+			<-release
+			loaded <- ctx.Err()
+			return 5, nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	}()
+
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	require.NoError(t, <-loaded, "加载使用的上下文不随调用方取消")
+
+	require.Eventually(t, func() bool {
+		v, ok := c.Get("k")
+		return ok && 5 == v
+	}, time.Second, time.Millisecond)
+}
+
+// TestCache_StaleWhileRevalidate 测试过期后返回旧值并在后台重新加载。
+func TestCache_StaleWhileRevalidate(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Unix(1000, 0))
+	c := New[string, int](
+		WithMetrics(false),
+		WithTTL(time.Minute),
+		WithStaleWhileRevalidate(30*time.Second),
+		WithClock(clock),
+	)
+	defer c.Close()
+
+	var version int32
+	release := make(chan struct{}, 1)
+	loader := func(_ context.Context, _ string) (int, error) {
+		if atomic.AddInt32(&version, 1) > 1 {
+			<-release
+		}
+		return int(atomic.LoadInt32(&version)), nil
+	}
+
+	v, err := c.GetOrLoad(context.Background(), "k", loader)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	// 过期但在允许返回旧值的时间内：立即返回旧值，Get 视为不存在。
+	clock.Advance(time.Minute + 10*time.Second)
+	v, err = c.GetOrLoad(context.Background(), "k", loader)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, err = c.GetOrLoad(context.Background(), "k", loader)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v, "后台加载完成前继续返回旧值")
+	_, ok := c.Get("k")
+	assert.False(t, ok)
+	assert.Empty(t, c.Keys())
+	assert.Equal(t, 1, c.Len())
+
+	release <- struct{}{}
+	require.Eventually(t, func() bool {
+		v, ok := c.Get("k")
+		return ok && 2 == v
+	}, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool {
+		c.callMu.Lock()
+		defer c.callMu.Unlock()
+		return 0 == len(c.calls)
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&version), "后台只重新加载一次")
+
+	// 超过允许返回旧值的时间：同步加载。
+	clock.Advance(2 * time.Minute)
+	release <- struct{}{}
+	v, err = c.GetOrLoad(context.Background(), "k", loader)
+	require.NoError(t, err)
+	assert.Equal(t, 3, v)
+}
+
+// TestCache_LoadMetrics 测试加载相关的指标。
+func TestCache_LoadMetrics(t *testing.T) {
+	name := t.Name() + strconv.FormatInt(time.Now().UnixNano(), 10)
+	c := New[string, int](WithName(name))
+	defer c.Close()
+
+	_, _ = c.GetOrLoad(context.Background(), "a", func(_ context.Context, _ string) (int, error) {
+		return 1, nil
+	})
+	_, _ = c.GetOrLoad(context.Background(), "a", func(_ context.Context, _ string) (int, error) {
+		return 1, nil
+	})
+	_, _ = c.GetOrLoad(context.Background(), "b", func(_ context.Context, _ string) (int, error) {
+		return 0, errors.New("load failed")
+	})
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricLoads.WithLabelValues(name, "success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricLoads.WithLabelValues(name, "failure")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricRequests.WithLabelValues(name, "hit")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricRequests.WithLabelValues(name, "miss")))
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 定义缓存指标相关的常量。
+const (
+	// namespace 定义 prometheus 指标的命名空间。
+	namespace = "kit_cache"
+)
+
+var (
+	// MetricRequests 用于记录缓存的读取次数。
+	// 该指标包含以下标签：
+	// - name: 缓存的名称。
+	// - result: 读取结果，hit 表示命中，miss 表示未命中，stale 表示 GetOrLoad 返回了旧值。
+	MetricRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "cache's requests total.",
+	}, []string{"name", "result"})
+
+	// MetricEvictions 用于记录被移除的缓存项数量。
+	// 该指标包含以下标签：
+	// - name: 缓存的名称。
+	// - reason: 移除原因，包括 expired、capacity、deleted 与 replaced。
+	MetricEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "evictions_total",
+		Help:      "cache's evictions total.",
+	}, []string{"name", "reason"})
+
+	// MetricEntries 用于记录缓存当前的条目数。
+	// 该指标包含以下标签：
+	// - name: 缓存的名称。
+	MetricEntries = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "entries",
+		Help:      "cache's current entries.",
+	}, []string{"name"})
+
+	// MetricLoads 用于记录 GetOrLoad 调用加载函数的结果，合并的并发加载只记录一次。
+	// 该指标包含以下标签：
+	// - name: 缓存的名称。
+	// - result: 加载结果，success 表示成功，failure 表示重试后仍失败。
+	MetricLoads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "loads_total",
+		Help:      "cache's loads total.",
+	}, []string{"name", "result"})
+)
+
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricRequests,
+		MetricEvictions,
+		MetricEntries,
+		MetricLoads,
+	}
+}
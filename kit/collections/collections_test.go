@@ -0,0 +1,122 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package collections
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// user 是测试使用的元素。
+	user struct {
+		// id 是用户 ID。
+		id int
+		// name 是用户名。
+		name string
+	}
+)
+
+// TestMap 测试转换切片中的元素。
+func TestMap(t *testing.T) {
+	assert.Equal(t, []string{"1", "2", "3"}, Map([]int{1, 2, 3}, strconv.Itoa))
+	assert.Nil(t, Map(nil, strconv.Itoa))
+	assert.Equal(t, []string{}, Map([]int{}, strconv.Itoa))
+}
+
+// TestFilter 测试过滤切片中的元素。
+func TestFilter(t *testing.T) {
+	even := func(v int) bool { return 0 == v%2 }
+	s := []int{1, 2, 3, 4}
+	assert.Equal(t, []int{2, 4}, Filter(s, even))
+	assert.Equal(t, []int{1, 2, 3, 4}, s)
+	assert.Nil(t, Filter([]int{1, 3}, even))
+}
+
+// TestReduce 测试累积切片中的元素。
+func TestReduce(t *testing.T) {
+	sum := func(acc, v int) int { return acc + v }
+	assert.Equal(t, 10, Reduce([]int{1, 2, 3, 4}, 0, sum))
+	assert.Equal(t, 5, Reduce(nil, 5, sum))
+	assert.Equal(t, "abc", Reduce([]string{"a", "b", "c"}, "", func(acc, v string) string { return acc + v }))
+}
+
+// TestFind 测试查找满足条件的元素。
+func TestFind(t *testing.T) {
+	users := []user{{1, "a"}, {2, "b"}, {3, "b"}}
+	u, ok := Find(users, func(u user) bool { return "b" == u.name })
+	assert.True(t, ok)
+	assert.Equal(t, 2, u.id)
+
+	u, ok = Find(users, func(u user) bool { return "c" == u.name })
+	assert.False(t, ok)
+	assert.Equal(t, user{}, u)
+}
+
+// TestChunk 测试将切片分块。
+func TestChunk(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	chunks := Chunk(s, 2)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, chunks)
+	assert.Equal(t, [][]int{{1, 2, 3, 4, 5}}, Chunk(s, 10))
+	assert.Nil(t, Chunk([]int{}, 2))
+
+	// 对块执行 append 不会覆盖其他块。
+	_ = append(chunks[0], 100)
+	assert.Equal(t, []int{3, 4}, chunks[1])
+
+	assert.PanicsWithValue(t, "kit/collections: Chunk 的 size 必须大于 0", func() {
+		Chunk(s, 0)
+	})
+}
+
+// TestDedupe 测试去除重复元素。
+func TestDedupe(t *testing.T) {
+	s := []int{3, 1, 3, 2, 1}
+	assert.Equal(t, []int{3, 1, 2}, Dedupe(s))
+	assert.Equal(t, []int{3, 1, 3, 2, 1}, s)
+	assert.Nil(t, Dedupe[int](nil))
+
+	users := []user{{1, "a"}, {2, "b"}, {1, "c"}}
+	assert.Equal(t, []user{{1, "a"}, {2, "b"}}, DedupeBy(users, func(u user) int { return u.id }))
+}
+
+// TestGroupBy 测试将元素分组。
+func TestGroupBy(t *testing.T) {
+	users := []user{{1, "a"}, {2, "b"}, {3, "a"}}
+	assert.Equal(t, map[string][]user{
+		"a": {{1, "a"}, {3, "a"}},
+		"b": {{2, "b"}},
+	}, GroupBy(users, func(u user) string { return u.name }))
+	assert.Empty(t, GroupBy(nil, func(u user) string { return u.name }))
+}
+
+// TestPartition 测试按条件将元素分为两组。
+func TestPartition(t *testing.T) {
+	even, odd := Partition([]int{1, 2, 3, 4, 5}, func(v int) bool { return 0 == v%2 })
+	assert.Equal(t, []int{2, 4}, even)
+	assert.Equal(t, []int{1, 3, 5}, odd)
+}
+
+// TestKeysValues 测试获取 map 的键与值。
+func TestKeysValues(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, Keys(m))
+	assert.Equal(t, []string{"a", "b", "c"}, SortedKeys(m))
+	assert.ElementsMatch(t, []int{1, 2, 3}, Values(m))
+	assert.Empty(t, Keys(map[string]int(nil)))
+	assert.Empty(t, Values(map[string]int(nil)))
+}
+
+// TestKeyBy 测试将切片转换为 map。
+func TestKeyBy(t *testing.T) {
+	users := []user{{1, "a"}, {2, "b"}, {1, "c"}}
+	assert.Equal(t, map[int]user{
+		1: {1, "c"},
+		2: {2, "b"},
+	}, KeyBy(users, func(u user) int { return u.id }))
+}
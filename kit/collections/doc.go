@@ -0,0 +1,34 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package collections 提供了基于泛型的切片、map 与集合工具，用于替代各服务中重复引入的同类第三方库。
+
+主要功能：
+
+  - 切片：Map、Filter、Reduce、Find、Chunk、Dedupe、DedupeBy、GroupBy、Partition，均不修改原切片
+  - map：Keys、Values、SortedKeys，以及将切片转换为 map 的 KeyBy
+  - 集合：Set 提供添加、删除、判断以及并集、交集、差集等运算
+  - 有序 map：OrderedMap 按插入顺序遍历，支持 range-over-func 迭代
+
+标准库 slices 与 maps 已经提供的功能（排序、查找下标、比较等）不再重复提供，请直接使用标准库。
+Set 与 OrderedMap 不是并发安全的。
+
+基本使用：
+
+	ids := collections.Map(users, func(u *User) int64 { return u.ID })
+	active := collections.Filter(users, func(u *User) bool { return u.Active })
+
+	for _, batch := range collections.Chunk(collections.Dedupe(ids), 500) {
+	    if err := repo.Load(ctx, batch); nil != err {
+	        return err
+	    }
+	}
+
+	roles := collections.NewSet("admin", "editor")
+	if roles.Contains(role) {
+	    // ...
+	}
+*/
+package collections
@@ -0,0 +1,73 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package collections
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Keys 返回 map 的全部键，顺序不确定。
+//
+// 参数：
+//   - m：map。
+//
+// 返回值：
+//   - []K：全部键，m 为空时返回空切片。
+func Keys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// SortedKeys 返回 map 的全部键，按升序排列，适用于需要稳定输出的场景。
+//
+// 参数：
+//   - m：map。
+//
+// 返回值：
+//   - []K：按升序排列的全部键。
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+// Values 返回 map 的全部值，顺序不确定。
+//
+// 参数：
+//   - m：map。
+//
+// 返回值：
+//   - []V：全部值，m 为空时返回空切片。
+func Values[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// KeyBy 以 key 函数的返回值为键，将切片转换为 map；键重复时保留最后一个元素。
+//
+// 参数：
+//   - s：原切片。
+//   - key：计算元素的键。
+//
+// 返回值：
+//   - map[K]T：键到元素的映射。
+//
+// 示例：
+//
+//	byID := collections.KeyBy(users, func(u *User) int64 { return u.ID })
+func KeyBy[T any, K comparable](s []T, key func(T) K) map[K]T {
+	m := make(map[K]T, len(s))
+	for _, v := range s {
+		m[key(v)] = v
+	}
+	return m
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package collections
+
+import (
+	"iter"
+)
+
+type (
+	// OrderedMap 是按插入顺序遍历的 map。
+	// 更新已经存在的键不会改变其位置；删除后重新插入的键排在最后。
+	// OrderedMap 不是并发安全的，请使用 NewOrderedMap 创建。
+	OrderedMap[K comparable, V any] struct {
+		// entries 是键到链表节点的映射。
+		entries map[K]*orderedEntry[K, V]
+		// root 是双向循环链表的哨兵节点，root.next 为最早插入的节点。
+		root orderedEntry[K, V]
+	}
+
+	// orderedEntry 是 OrderedMap 的链表节点。
+	orderedEntry[K comparable, V any] struct {
+		// key 是键。
+		key K
+		// value 是值。
+		value V
+		// prev 是前一个节点。
+		prev *orderedEntry[K, V]
+		// next 是后一个节点，节点被删除后保留，供正在进行的遍历继续前进。
+		next *orderedEntry[K, V]
+		// deleted 表示节点是否已经被删除。
+		deleted bool
+	}
+)
+
+// NewOrderedMap 创建一个按插入顺序遍历的 map。
+//
+// 返回值：
+//   - *OrderedMap[K, V]：OrderedMap 实例。
+//
+// 示例：
+//
+//	m := collections.NewOrderedMap[string, int]()
+//	m.Set("b", 2)
+//	m.Set("a", 1)
+//	for k, v := range m.All() {
+//	    fmt.Println(k, v) // b 2、a 1
+//	}
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	m := &OrderedMap[K, V]{entries: make(map[K]*orderedEntry[K, V])}
+	m.root.prev = &m.root
+	m.root.next = &m.root
+	return m
+}
+
+// Set 设置键的值，新的键排在最后，已经存在的键保持原位置。
+//
+// 参数：
+//   - key：键。
+//   - value：值。
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if e, ok := m.entries[key]; ok {
+		e.value = value
+		return
+	}
+	e := &orderedEntry[K, V]{key: key, value: value, prev: m.root.prev, next: &m.root}
+	m.root.prev.next = e
+	m.root.prev = e
+	m.entries[key] = e
+}
+
+// Get 获取键的值。
+//
+// 参数：
+//   - key：键。
+//
+// 返回值：
+//   - V：键的值，不存在时为零值。
+//   - bool：键存在时返回 true。
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, ok := m.entries[key]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has 判断键是否存在。
+//
+// 参数：
+//   - key：键。
+//
+// 返回值：
+//   - bool：键存在时返回 true。
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Delete 删除键。
+//
+// 参数：
+//   - key：键。
+//
+// 返回值：
+//   - bool：键存在并被删除时返回 true。
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	// 保留 next，正在遍历到该节点的迭代器可以继续前进。
+	e.prev = nil
+	e.deleted = true
+	delete(m.entries, key)
+	return true
+}
+
+// Len 返回键的数量。
+//
+// 返回值：
+//   - int：键的数量。
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Keys 返回全部键，按插入顺序排列。
+//
+// 返回值：
+//   - []K：全部键。
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.entries))
+	for e := m.root.next; e != &m.root; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// Values 返回全部值，按插入顺序排列。
+//
+// 返回值：
+//   - []V：全部值。
+func (m *OrderedMap[K, V]) Values() []V {
+	values := make([]V, 0, len(m.entries))
+	for e := m.root.next; e != &m.root; e = e.next {
+		values = append(values, e.value)
+	}
+	return values
+}
+
+// All 返回按插入顺序遍历全部键值对的迭代器。
+// 遍历过程中可以删除任意的键，被删除且尚未遍历到的键不会被遍历；遍历过程中新插入的键通常会被遍历到。
+//
+// 返回值：
+//   - iter.Seq2[K, V]：键值对迭代器。
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.root.next; e != &m.root; e = e.next {
+			if e.deleted {
+				continue
+			}
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collect 按遍历顺序收集 OrderedMap 的键。
+func collect[K comparable, V any](m *OrderedMap[K, V]) []K {
+	var keys []K
+	for k := range m.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// TestOrderedMap 测试按插入顺序遍历。
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	// 更新已经存在的键不改变位置。
+	m.Set("c", 30)
+
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, []string{"c", "a", "b"}, m.Keys())
+	assert.Equal(t, []int{30, 1, 2}, m.Values())
+
+	v, ok := m.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 30, v)
+	_, ok = m.Get("x")
+	assert.False(t, ok)
+	assert.True(t, m.Has("a"))
+
+	// 删除后重新插入的键排在最后。
+	assert.True(t, m.Delete("c"))
+	assert.False(t, m.Delete("c"))
+	assert.False(t, m.Has("c"))
+	m.Set("c", 3)
+	assert.Equal(t, []string{"a", "b", "c"}, collect(m))
+
+	// 提前结束遍历。
+	var first string
+	for k := range m.All() {
+		first = k
+		break
+	}
+	assert.Equal(t, "a", first)
+
+	empty := NewOrderedMap[int, int]()
+	assert.Empty(t, empty.Keys())
+	assert.Empty(t, collect(empty))
+}
+
+// TestOrderedMap_DeleteWhileIterating 测试遍历过程中删除键。
+func TestOrderedMap_DeleteWhileIterating(t *testing.T) {
+	m := NewOrderedMap[int, string]()
+	for i := 1; i <= 5; i++ {
+		m.Set(i, "")
+	}
+
+	var visited []int
+	for k := range m.All() {
+		visited = append(visited, k)
+		switch k {
+		case 1:
+			// 删除当前的键。
+			m.Delete(1)
+		case 2:
+			// 删除之后的两个相邻的键。
+			m.Delete(3)
+			m.Delete(4)
+		}
+	}
+	assert.Equal(t, []int{1, 2, 5}, visited)
+	assert.Equal(t, []int{2, 5}, m.Keys())
+}
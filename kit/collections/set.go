@@ -0,0 +1,182 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package collections
+
+import (
+	"iter"
+)
+
+type (
+	// Set 是基于 map 的集合。
+	// Set 不是并发安全的；零值为 nil 集合，可以读取但不能添加元素，请使用 NewSet 创建。
+	Set[T comparable] map[T]struct{}
+)
+
+// NewSet 创建一个集合，并添加给定的元素。
+//
+// 参数：
+//   - items：初始元素。
+//
+// 返回值：
+//   - Set[T]：集合实例。
+//
+// 示例：
+//
+//	allowed := collections.NewSet("read", "write")
+//	if allowed.Contains(action) {
+//	    // ...
+//	}
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	s.Add(items...)
+	return s
+}
+
+// Add 添加元素，已经存在的元素不受影响。
+//
+// 参数：
+//   - items：要添加的元素。
+func (s Set[T]) Add(items ...T) {
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+}
+
+// Remove 删除元素，不存在的元素被忽略。
+//
+// 参数：
+//   - items：要删除的元素。
+func (s Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(s, item)
+	}
+}
+
+// Contains 判断元素是否存在。
+//
+// 参数：
+//   - item：要判断的元素。
+//
+// 返回值：
+//   - bool：元素存在时返回 true。
+func (s Set[T]) Contains(item T) bool {
+	_, ok := s[item]
+	return ok
+}
+
+// Len 返回元素数量。
+//
+// 返回值：
+//   - int：元素数量。
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Items 返回全部元素，顺序不确定。
+//
+// 返回值：
+//   - []T：全部元素。
+func (s Set[T]) Items() []T {
+	return Keys(s)
+}
+
+// All 返回遍历全部元素的迭代器，顺序不确定。
+//
+// 返回值：
+//   - iter.Seq[T]：元素迭代器。
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range s {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Clone 返回集合的副本。
+//
+// 返回值：
+//   - Set[T]：集合的副本。
+func (s Set[T]) Clone() Set[T] {
+	c := make(Set[T], len(s))
+	for item := range s {
+		c[item] = struct{}{}
+	}
+	return c
+}
+
+// Union 返回两个集合的并集，不修改原集合。
+//
+// 参数：
+//   - other：另一个集合。
+//
+// 返回值：
+//   - Set[T]：并集。
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	u := s.Clone()
+	for item := range other {
+		u[item] = struct{}{}
+	}
+	return u
+}
+
+// Intersect 返回两个集合的交集，不修改原集合。
+//
+// 参数：
+//   - other：另一个集合。
+//
+// 返回值：
+//   - Set[T]：交集。
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	// 遍历较小的集合。
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	i := make(Set[T])
+	for item := range small {
+		if large.Contains(item) {
+			i[item] = struct{}{}
+		}
+	}
+	return i
+}
+
+// Difference 返回在当前集合中、但不在 other 中的元素，不修改原集合。
+//
+// 参数：
+//   - other：另一个集合。
+//
+// 返回值：
+//   - Set[T]：差集。
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	d := make(Set[T])
+	for item := range s {
+		if !other.Contains(item) {
+			d[item] = struct{}{}
+		}
+	}
+	return d
+}
+
+// Equal 判断两个集合是否包含相同的元素。
+//
+// 参数：
+//   - other：另一个集合。
+//
+// 返回值：
+//   - bool：元素相同时返回 true。
+func (s Set[T]) Equal(other Set[T]) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for item := range s {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSet 测试集合的基本操作。
+func TestSet(t *testing.T) {
+	s := NewSet(1, 2, 2, 3)
+	assert.Equal(t, 3, s.Len())
+	assert.True(t, s.Contains(2))
+	assert.False(t, s.Contains(4))
+
+	s.Add(4, 5)
+	s.Remove(1, 9)
+	assert.ElementsMatch(t, []int{2, 3, 4, 5}, s.Items())
+
+	var items []int
+	for item := range s.All() {
+		items = append(items, item)
+	}
+	assert.ElementsMatch(t, []int{2, 3, 4, 5}, items)
+
+	// 提前结束遍历。
+	n := 0
+	for range s.All() {
+		n++
+		break
+	}
+	assert.Equal(t, 1, n)
+
+	c := s.Clone()
+	c.Add(6)
+	assert.False(t, s.Contains(6))
+
+	// nil 集合可以读取。
+	var empty Set[int]
+	assert.False(t, empty.Contains(1))
+	assert.Equal(t, 0, empty.Len())
+}
+
+// TestSet_Operations 测试集合的并集、交集、差集与相等。
+func TestSet_Operations(t *testing.T) {
+	a := NewSet(1, 2, 3)
+	b := NewSet(2, 3, 4, 5)
+
+	assert.True(t, NewSet(1, 2, 3, 4, 5).Equal(a.Union(b)))
+	assert.True(t, NewSet(2, 3).Equal(a.Intersect(b)))
+	assert.True(t, NewSet(2, 3).Equal(b.Intersect(a)))
+	assert.True(t, NewSet(1).Equal(a.Difference(b)))
+	assert.True(t, NewSet(4, 5).Equal(b.Difference(a)))
+
+	// 原集合不受影响。
+	assert.True(t, NewSet(1, 2, 3).Equal(a))
+
+	assert.False(t, a.Equal(b))
+	assert.False(t, a.Equal(NewSet(1, 2, 4)))
+	assert.True(t, NewSet[int]().Equal(nil))
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package collections
+
+// Map 将切片中的每个元素转换为另一种类型。
+//
+// 参数：
+//   - s：原切片。
+//   - fn：转换函数。
+//
+// 返回值：
+//   - []U：转换后的切片，与原切片等长，s 为 nil 时返回 nil。
+//
+// 示例：
+//
+//	ids := collections.Map(users, func(u *User) int64 { return u.ID })
+func Map[T, U any](s []T, fn func(T) U) []U {
+	if nil == s {
+		return nil
+	}
+	result := make([]U, len(s))
+	for i, v := range s {
+		result[i] = fn(v)
+	}
+	return result
+}
+
+// Filter 返回满足条件的元素组成的新切片，不修改原切片。
+//
+// 参数：
+//   - s：原切片。
+//   - fn：判断函数，返回 true 的元素被保留。
+//
+// 返回值：
+//   - []T：满足条件的元素，按原顺序排列，没有满足条件的元素时返回 nil。
+func Filter[T any](s []T, fn func(T) bool) []T {
+	var result []T
+	for _, v := range s {
+		if fn(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Reduce 从初始值开始依次将元素累积为一个值。
+//
+// 参数：
+//   - s：原切片。
+//   - init：初始值。
+//   - fn：累积函数，acc 为此前的累积结果。
+//
+// 返回值：
+//   - U：累积结果，s 为空时返回 init。
+//
+// 示例：
+//
+//	total := collections.Reduce(items, 0, func(acc int, it Item) int { return acc + it.Price })
+func Reduce[T, U any](s []T, init U, fn func(acc U, v T) U) U {
+	acc := init
+	for _, v := range s {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// Find 返回第一个满足条件的元素。
+//
+// 参数：
+//   - s：原切片。
+//   - fn：判断函数。
+//
+// 返回值：
+//   - T：第一个满足条件的元素，不存在时为零值。
+//   - bool：存在满足条件的元素时返回 true。
+func Find[T any](s []T, fn func(T) bool) (T, bool) {
+	for _, v := range s {
+		if fn(v) {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Chunk 将切片按固定大小分块，最后一块可能不足 size 个元素。
+// 各块与原切片共享底层数组，但容量被限制为自身长度，对块执行 append 不会覆盖其他块。
+//
+// 参数：
+//   - s：原切片。
+//   - size：每块的元素数量，小于 1 时 panic。
+//
+// 返回值：
+//   - [][]T：分块后的切片，s 为空时返回 nil。
+//
+// 示例：
+//
+//	for _, ids := range collections.Chunk(allIDs, 500) {
+//	    if err := db.DeleteByIDs(ctx, ids); nil != err {
+//	        return err
+//	    }
+//	}
+func Chunk[T any](s []T, size int) [][]T {
+	if size < 1 {
+		panic("kit/collections: Chunk 的 size 必须大于 0")
+	}
+	if 0 == len(s) {
+		return nil
+	}
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for i := 0; i < len(s); i += size {
+		end := min(i+size, len(s))
+		chunks = append(chunks, s[i:end:end])
+	}
+	return chunks
+}
+
+// Dedupe 返回去除重复元素后的新切片，保留每个元素第一次出现的位置，不修改原切片。
+//
+// 参数：
+//   - s：原切片。
+//
+// 返回值：
+//   - []T：去重后的切片，s 为 nil 时返回 nil。
+func Dedupe[T comparable](s []T) []T {
+	return DedupeBy(s, func(v T) T { return v })
+}
+
+// DedupeBy 按 key 函数的返回值去除重复元素，保留每个 key 第一次出现的元素，不修改原切片。
+//
+// 参数：
+//   - s：原切片。
+//   - key：计算元素的去重依据。
+//
+// 返回值：
+//   - []T：去重后的切片，s 为 nil 时返回 nil。
+//
+// 示例：
+//
+//	users = collections.DedupeBy(users, func(u *User) int64 { return u.ID })
+func DedupeBy[T any, K comparable](s []T, key func(T) K) []T {
+	if nil == s {
+		return nil
+	}
+	seen := make(map[K]struct{}, len(s))
+	result := make([]T, 0, len(s))
+	for _, v := range s {
+		k := key(v)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		result = append(result, v)
+	}
+	return result
+}
+
+// GroupBy 按 key 函数的返回值将元素分组，组内元素保持原顺序。
+//
+// 参数：
+//   - s：原切片。
+//   - key：计算元素所属的组。
+//
+// 返回值：
+//   - map[K][]T：各组的元素。
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Partition 将元素按条件分为两组，组内元素保持原顺序。
+//
+// 参数：
+//   - s：原切片。
+//   - fn：判断函数。
+//
+// 返回值：
+//   - []T：满足条件的元素。
+//   - []T：不满足条件的元素。
+func Partition[T any](s []T, fn func(T) bool) ([]T, []T) {
+	var matched, rest []T
+	for _, v := range s {
+		if fn(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return matched, rest
+}
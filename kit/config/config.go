@@ -0,0 +1,459 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+)
+
+const (
+	// keyDelimiter 是配置键的层级分隔符，例如 "server.port"。
+	keyDelimiter = "."
+)
+
+type (
+	// Config 定义了只读的配置访问接口。
+	// 配置键不区分大小写，层级之间以 "." 分隔；取值时会进行宽松的类型转换，例如字符串 "8080" 可以通过 GetInt 读取。
+	// Config 的所有方法都是并发安全的。
+	Config interface {
+		// Get 获取配置项的原始值。
+		//
+		// 参数：
+		//   - key：配置键，例如 "server.port"。
+		//
+		// 返回值：
+		//   - interface{}：配置值，不存在时返回 nil。
+		Get(key string) interface{}
+
+		// IsSet 判断配置项是否存在。
+		//
+		// 参数：
+		//   - key：配置键。
+		//
+		// 返回值：
+		//   - bool：存在时返回 true。
+		IsSet(key string) bool
+
+		// GetString 获取字符串类型的配置值，不存在或无法转换时返回空字符串。
+		GetString(key string) string
+
+		// GetInt 获取整数类型的配置值，不存在或无法转换时返回 0。
+		GetInt(key string) int
+
+		// GetInt64 获取 int64 类型的配置值，不存在或无法转换时返回 0。
+		GetInt64(key string) int64
+
+		// GetFloat64 获取浮点数类型的配置值，不存在或无法转换时返回 0。
+		GetFloat64(key string) float64
+
+		// GetBool 获取布尔类型的配置值，不存在或无法转换时返回 false。
+		GetBool(key string) bool
+
+		// GetDuration 获取时长类型的配置值，支持 "1s"、"500ms" 等格式，不存在或无法转换时返回 0。
+		GetDuration(key string) time.Duration
+
+		// GetStringSlice 获取字符串切片类型的配置值，字符串值按逗号分隔，不存在或无法转换时返回 nil。
+		GetStringSlice(key string) []string
+
+		// GetStringMap 获取子配置的全部键值，不存在或不是子配置时返回 nil。
+		GetStringMap(key string) map[string]interface{}
+
+		// AllSettings 返回全部配置的副本。
+		//
+		// 返回值：
+		//   - map[string]interface{}：按层级嵌套的配置。
+		AllSettings() map[string]interface{}
+
+		// Redacted 返回全部配置的副本，通过密钥占位符解析的配置值替换为 "******"，用于输出到日志。
+		// 配置的 String 与 MarshalJSON 方法输出的也是该副本。
+		//
+		// 返回值：
+		//   - map[string]interface{}：按层级嵌套的配置。
+		Redacted() map[string]interface{}
+
+		// Unmarshal 将全部配置解析到结构体。
+		// 结构体字段通过 config 标签指定配置键，未指定时按字段名不区分大小写匹配。
+		//
+		// 参数：
+		//   - v：解析目标，必须是指向结构体的指针。
+		//
+		// 返回值：
+		//   - error：解析失败时返回错误。
+		Unmarshal(v interface{}) error
+
+		// UnmarshalKey 将指定子配置解析到 v。
+		//
+		// 参数：
+		//   - key：配置键。
+		//   - v：解析目标，必须是指针。
+		//
+		// 返回值：
+		//   - error：解析失败时返回错误。
+		UnmarshalKey(key string, v interface{}) error
+	}
+
+	// Validator 定义了配置结构体的校验接口。
+	// Load 在解析完成后会调用实现了该接口的结构体（包括嵌套的结构体）的 Validate 方法。
+	Validator interface {
+		// Validate 校验配置是否合法。
+		//
+		// 返回值：
+		//   - error：配置不合法时返回错误。
+		Validate() error
+	}
+
+	// Option 定义了配置加载的选项。
+	Option func(*options)
+
+	// options 包含配置加载的选项。
+	options struct {
+		// defaults 是代码中指定的默认值，优先级最低。
+		defaults map[string]interface{}
+		// files 是按顺序加载的配置文件，后加载的覆盖先加载的。
+		files []fileSource
+		// envEnabled 表示是否从环境变量读取配置。
+		envEnabled bool
+		// envPrefix 是环境变量的前缀，例如 "APP" 对应 APP_SERVER_PORT。
+		envPrefix string
+		// flags 是命令行参数集合，显式设置的参数优先级最高。
+		flags *flag.FlagSet
+		// keys 是除配置来源外额外已知的配置键，用于匹配环境变量，由 Load 根据结构体生成。
+		keys []string
+		// reloadDebounce 是文件变化后重新加载配置前的等待时间，仅对 Watch 生效。
+		reloadDebounce time.Duration
+		// onReloadError 是自动重新加载失败时的处理函数，仅对 Watch 生效。
+		onReloadError func(err error)
+		// secretsEnabled 表示是否解析配置值中的密钥占位符。
+		secretsEnabled bool
+		// resolvers 是按协议注册的密钥解析函数。
+		resolvers map[string]SecretResolver
+		// fsys 是读取配置文件与密钥文件使用的文件系统，为 nil 时读取磁盘。
+		fsys fs.FS
+	}
+
+	// fileSource 描述一个配置文件来源。
+	fileSource struct {
+		// path 是文件路径。
+		path string
+		// optional 表示文件不存在时是否忽略。
+		optional bool
+	}
+
+	// config 是 Config 的默认实现，持有一份不可变的配置快照。
+	config struct {
+		// settings 是合并后的配置，键均为小写。
+		settings map[string]interface{}
+		// secrets 是通过密钥占位符解析的配置键，输出配置时替换为 "******"。
+		secrets map[string]struct{}
+	}
+)
+
+// WithDefaults 设置默认值，优先级低于配置文件、环境变量与命令行参数。
+// 键可以使用 "." 表示层级，例如 {"server.port": 8080}，也可以直接传入嵌套的映射。
+//
+// 参数：
+//   - defaults：默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithDefaults(defaults map[string]interface{}) Option {
+	return func(o *options) {
+		for k, v := range defaults {
+			o.defaults[k] = v
+		}
+	}
+}
+
+// WithFile 添加一个必须存在的配置文件，格式由扩展名决定，支持 .yaml、.yml、.json 与 .toml。
+// 多次调用时按顺序加载，后加载的文件覆盖先加载的文件中的同名配置。
+//
+// 参数：
+//   - path：配置文件路径。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithFile(path string) Option {
+	return func(o *options) {
+		o.files = append(o.files, fileSource{path: path})
+	}
+}
+
+// WithOptionalFile 添加一个可选的配置文件，文件不存在时忽略，常用于本地覆盖配置。
+//
+// 参数：
+//   - path：配置文件路径。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithOptionalFile(path string) Option {
+	return func(o *options) {
+		o.files = append(o.files, fileSource{path: path, optional: true})
+	}
+}
+
+// WithEnv 从环境变量读取配置，优先级高于配置文件。
+// 配置键 "server.port" 对应的环境变量为 <PREFIX>_SERVER_PORT，键中的 "." 与 "-" 均替换为 "_"。
+//
+// 参数：
+//   - prefix：环境变量前缀，为空时不添加前缀。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithEnv(prefix string) Option {
+	return func(o *options) {
+		o.envEnabled = true
+		o.envPrefix = prefix
+	}
+}
+
+// WithFlags 从命令行参数读取配置，参数名即配置键，例如 -server.port=8080。
+// 显式设置的参数优先级最高；未设置的参数以其默认值作为配置的默认值。
+// fs 需要在加载配置前完成解析。
+//
+// 参数：
+//   - fs：已解析的命令行参数集合，通常为 flag.CommandLine。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithFlags(fs *flag.FlagSet) Option {
+	return func(o *options) {
+		o.flags = fs
+	}
+}
+
+// WithFS 设置读取配置文件与 file 协议的密钥文件使用的文件系统，测试时可以注入 kit/testing 的 MemFS。
+// 路径原样传给 fsys，使用 os.DirFS 等遵守 io/fs 路径规则的实现时只能使用相对路径。
+// Watch 只能监听磁盘上的文件变化，注入文件系统后不会自动重新加载，可以调用 Reload 手动重新加载。
+//
+// 参数：
+//   - fsys：文件系统，为 nil 时读取磁盘。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithFS(fsys fs.FS) Option {
+	return func(o *options) {
+		o.fsys = fsys
+	}
+}
+
+// New 按照默认值、配置文件、环境变量、命令行参数的优先级从低到高加载并合并配置。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - Config：合并后的配置。
+//   - error：加载失败时返回错误。
+//
+// 示例：
+//
+//	cfg, err := config.New(
+//	    config.WithFile("conf/app.yaml"),
+//	    config.WithOptionalFile("conf/app.local.yaml"),
+//	    config.WithEnv("APP"),
+//	    config.WithFlags(flag.CommandLine),
+//	)
+//	port := cfg.GetInt("server.port")
+func New(opts ...Option) (Config, error) {
+	o := newOptions(opts...)
+
+	return o.load()
+}
+
+// Load 加载配置并解析到结构体 v，然后进行校验。
+// 结构体字段的 default 标签提供默认值（优先级低于 WithDefaults）；结构体中的全部配置键都可以通过环境变量设置；
+// 解析完成后按 validate 标签校验字段（规则参见 kit/validator），错误中的字段路径为配置键，
+// 然后调用实现了 Validator 接口的结构体的 Validate 方法。
+//
+// 参数：
+//   - v：解析目标，必须是指向结构体的指针。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - Config：合并后的配置。
+//   - error：加载、解析或校验失败时返回错误。
+//
+// 示例：
+//
+//	type AppConfig struct {
+//	    Server struct {
+//	        Port    int           `config:"port" default:"8080" validate:"min=1,max=65535"`
+//	        Timeout time.Duration `config:"timeout" default:"5s"`
+//	    } `config:"server"`
+//	}
+//
+//	var cfg AppConfig
+//	_, err := config.Load(&cfg, config.WithFile("app.yaml"), config.WithEnv("APP"))
+func Load(v interface{}, opts ...Option) (Config, error) {
+	o := newOptions(opts...)
+
+	tagDefaults, keys, err := structDefaults(v)
+	if nil != err {
+		return nil, err
+	}
+	// 结构体标签中的默认值优先级低于 WithDefaults。
+	for k, dv := range o.defaults {
+		tagDefaults[k] = dv
+	}
+	o.defaults = tagDefaults
+	o.keys = append(o.keys, keys...)
+
+	cfg, err := o.load()
+	if nil != err {
+		return nil, err
+	}
+
+	if err := cfg.Unmarshal(v); nil != err {
+		return nil, err
+	}
+	if err := validate(v); nil != err {
+		return nil, fmt.Errorf("配置校验失败：%w", err)
+	}
+
+	return cfg, nil
+}
+
+// newOptions 创建并应用配置选项。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		defaults: make(map[string]interface{}),
+	}
+	o.resolvers = map[string]SecretResolver{
+		SchemeEnv:  resolveEnv,
+		SchemeFile: o.resolveFile,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Get 获取配置项的原始值。
+func (c *config) Get(key string) interface{} {
+	v, _ := lookup(c.settings, key)
+	return v
+}
+
+// IsSet 判断配置项是否存在。
+func (c *config) IsSet(key string) bool {
+	_, ok := lookup(c.settings, key)
+	return ok
+}
+
+// GetString 获取字符串类型的配置值。
+func (c *config) GetString(key string) string {
+	var s string
+	c.getAs(key, &s)
+	return s
+}
+
+// GetInt 获取整数类型的配置值。
+func (c *config) GetInt(key string) int {
+	var i int
+	c.getAs(key, &i)
+	return i
+}
+
+// GetInt64 获取 int64 类型的配置值。
+func (c *config) GetInt64(key string) int64 {
+	var i int64
+	c.getAs(key, &i)
+	return i
+}
+
+// GetFloat64 获取浮点数类型的配置值。
+func (c *config) GetFloat64(key string) float64 {
+	var f float64
+	c.getAs(key, &f)
+	return f
+}
+
+// GetBool 获取布尔类型的配置值。
+func (c *config) GetBool(key string) bool {
+	var b bool
+	c.getAs(key, &b)
+	return b
+}
+
+// GetDuration 获取时长类型的配置值。
+func (c *config) GetDuration(key string) time.Duration {
+	var d time.Duration
+	c.getAs(key, &d)
+	return d
+}
+
+// GetStringSlice 获取字符串切片类型的配置值。
+func (c *config) GetStringSlice(key string) []string {
+	var s []string
+	c.getAs(key, &s)
+	return s
+}
+
+// GetStringMap 获取子配置的全部键值。
+func (c *config) GetStringMap(key string) map[string]interface{} {
+	v, ok := lookup(c.settings, key)
+	if !ok {
+		return nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return deepCopy(m)
+}
+
+// AllSettings 返回全部配置的副本。
+func (c *config) AllSettings() map[string]interface{} {
+	return deepCopy(c.settings)
+}
+
+// Unmarshal 将全部配置解析到结构体。
+func (c *config) Unmarshal(v interface{}) error {
+	if err := decode(c.settings, v); nil != err {
+		return fmt.Errorf("解析配置失败：%w", err)
+	}
+	return nil
+}
+
+// UnmarshalKey 将指定子配置解析到 v。
+func (c *config) UnmarshalKey(key string, v interface{}) error {
+	raw, ok := lookup(c.settings, key)
+	if !ok {
+		return fmt.Errorf("配置项 %s 不存在", key)
+	}
+	if err := decode(raw, v); nil != err {
+		return fmt.Errorf("解析配置项 %s 失败：%w", key, err)
+	}
+	return nil
+}
+
+// getAs 将配置值宽松地转换到 out，不存在或转换失败时保持零值。
+func (c *config) getAs(key string, out interface{}) {
+	v, ok := lookup(c.settings, key)
+	if !ok {
+		return
+	}
+	_ = decode(v, out)
+}
+
+// lookup 按层级查找配置键。
+func lookup(settings map[string]interface{}, key string) (interface{}, bool) {
+	var cur interface{} = settings
+	for _, part := range strings.Split(strings.ToLower(key), keyDelimiter) {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitvalidator "github.com/fsyyft-go/monorepo/kit/validator"
+)
+
+type testConfig struct {
+	Server struct {
+		Host    string        `config:"host" default:"localhost"`
+		Port    int           `config:"port" default:"80"`
+		Timeout time.Duration `config:"timeout" default:"1s"`
+	} `config:"server"`
+	Log struct {
+		Level   string   `config:"level" default:"info"`
+		Outputs []string `config:"outputs"`
+	} `config:"log"`
+	MaxConns int `config:"max-conns" default:"100"`
+	Ignored  int `config:"-"`
+}
+
+func (c *testConfig) Validate() error {
+	if c.Server.Port <= 0 {
+		return errors.New("server.port 必须大于 0")
+	}
+	return nil
+}
+
+// TestNew_Files 测试配置文件的加载与覆盖顺序。
+func TestNew_Files(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		port  int
+		level string
+		host  string
+	}{
+		{"YAML", []string{"app.yaml"}, 8080, "info", "0.0.0.0"},
+		{"JSON 覆盖 YAML", []string{"app.yaml", "app.json"}, 9090, "debug", "0.0.0.0"},
+		{"TOML 覆盖 YAML", []string{"app.yaml", "app.toml"}, 7070, "warn", "127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			for _, f := range tt.files {
+				opts = append(opts, WithFile(filepath.Join("testdata", f)))
+			}
+			cfg, err := New(opts...)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.port, cfg.GetInt("server.port"))
+			assert.Equal(t, tt.level, cfg.GetString("LOG.Level"))
+			assert.Equal(t, tt.host, cfg.GetString("server.host"))
+			assert.Equal(t, 3*time.Second, cfg.GetDuration("server.timeout"))
+			assert.Equal(t, []string{"stdout", "file"}, cfg.GetStringSlice("log.outputs"))
+		})
+	}
+}
+
+// TestNew_Errors 测试配置文件错误的处理。
+func TestNew_Errors(t *testing.T) {
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "broken.yaml")
+	require.NoError(t, os.WriteFile(broken, []byte("server: [\n"), 0644))
+	unknown := filepath.Join(dir, "app.ini")
+	require.NoError(t, os.WriteFile(unknown, []byte("a=b"), 0644))
+
+	_, err := New(WithFile(filepath.Join(dir, "missing.yaml")))
+	assert.ErrorContains(t, err, "missing.yaml")
+
+	_, err = New(WithFile(broken))
+	assert.ErrorContains(t, err, "broken.yaml")
+
+	_, err = New(WithFile(unknown))
+	assert.ErrorContains(t, err, "不支持的配置文件格式")
+
+	cfg, err := New(WithOptionalFile(filepath.Join(dir, "missing.yaml")))
+	require.NoError(t, err)
+	assert.Empty(t, cfg.AllSettings())
+}
+
+// TestNew_FS 测试从注入的文件系统读取配置文件与密钥文件，注入后 Watch 只能手动重新加载。
+func TestNew_FS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"conf/app.yaml":  {Data: []byte("server:\n  port: 8080\n  key: file://secrets/key\n")},
+		"secrets/key":    {Data: []byte("k1\n")},
+		"conf/base.json": {Data: []byte(`{"server": {"host": "example.com"}}`)},
+	}
+
+	cfg, err := New(
+		WithFS(fsys),
+		WithFile("conf/base.json"),
+		WithFile("conf/app.yaml"),
+		WithOptionalFile("conf/missing.yaml"),
+		WithSecrets(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", cfg.GetString("server.host"))
+	assert.Equal(t, 8080, cfg.GetInt("server.port"))
+	assert.Equal(t, "k1", cfg.GetString("server.key"))
+
+	_, err = New(WithFS(fsys), WithFile("conf/missing.yaml"))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	w, err := Watch(WithFS(fsys), WithFile("conf/app.yaml"))
+	require.NoError(t, err)
+	defer w.Close() // nolint: errcheck
+	fsys["conf/app.yaml"] = &fstest.MapFile{Data: []byte("server:\n  port: 9090\n")}
+	require.NoError(t, w.Reload())
+	assert.Equal(t, 9090, w.GetInt("server.port"))
+}
+
+// TestNew_Precedence 测试默认值、文件、环境变量与命令行参数的优先级。
+func TestNew_Precedence(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("server.port", 1, "")
+	fs.String("log.level", "error", "")
+	fs.Bool("debug", false, "")
+	require.NoError(t, fs.Parse([]string{"-server.port=6060"}))
+
+	t.Setenv("APP_SERVER_PORT", "5050")
+	t.Setenv("APP_SERVER_HOST", "env-host")
+	t.Setenv("APP_CACHE_SIZE", "64")
+
+	cfg, err := New(
+		WithDefaults(map[string]interface{}{"server.host": "default-host", "cache.size": 32, "name": "app"}),
+		WithFile(filepath.Join("testdata", "app.yaml")),
+		WithEnv("app"),
+		WithFlags(fs),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, 6060, cfg.GetInt("server.port"), "显式设置的命令行参数优先级最高")
+	assert.Equal(t, "env-host", cfg.GetString("server.host"), "环境变量覆盖配置文件")
+	assert.Equal(t, 64, cfg.GetInt("cache.size"), "环境变量覆盖默认值")
+	assert.Equal(t, "info", cfg.GetString("log.level"), "配置文件覆盖命令行参数的默认值")
+	assert.Equal(t, "app", cfg.GetString("name"))
+	assert.False(t, cfg.GetBool("debug"))
+	assert.True(t, cfg.IsSet("debug"))
+	assert.False(t, cfg.IsSet("server.port.value"))
+	assert.Nil(t, cfg.Get("missing"))
+}
+
+// TestConfig_Getters 测试类型化的取值方法。
+func TestConfig_Getters(t *testing.T) {
+	cfg, err := New(WithDefaults(map[string]interface{}{
+		"int":      "42",
+		"float":    "1.5",
+		"bool":     "true",
+		"duration": "250ms",
+		"slice":    "a,b",
+		"nested":   map[string]interface{}{"Key": "v"},
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, 42, cfg.GetInt("int"))
+	assert.Equal(t, int64(42), cfg.GetInt64("int"))
+	assert.Equal(t, 1.5, cfg.GetFloat64("float"))
+	assert.True(t, cfg.GetBool("bool"))
+	assert.Equal(t, 250*time.Millisecond, cfg.GetDuration("duration"))
+	assert.Equal(t, []string{"a", "b"}, cfg.GetStringSlice("slice"))
+	assert.Equal(t, map[string]interface{}{"key": "v"}, cfg.GetStringMap("nested"))
+	assert.Nil(t, cfg.GetStringMap("int"))
+	assert.Equal(t, 0, cfg.GetInt("bool.missing"))
+
+	// AllSettings 返回副本，修改不影响配置。
+	cfg.AllSettings()["nested"].(map[string]interface{})["key"] = "changed"
+	assert.Equal(t, "v", cfg.GetString("nested.key"))
+}
+
+// TestLoad 测试结构体默认值、环境变量与校验。
+func TestLoad(t *testing.T) {
+	t.Setenv("APP_MAX_CONNS", "500")
+	t.Setenv("APP_SERVER_TIMEOUT", "10s")
+
+	var c testConfig
+	cfg, err := Load(&c, WithFile(filepath.Join("testdata", "app.json")), WithEnv("APP"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "localhost", c.Server.Host, "结构体默认值")
+	assert.Equal(t, 9090, c.Server.Port, "配置文件覆盖结构体默认值")
+	assert.Equal(t, 10*time.Second, c.Server.Timeout, "环境变量匹配结构体中的配置键")
+	assert.Equal(t, "debug", c.Log.Level)
+	assert.Equal(t, 500, c.MaxConns)
+	assert.Equal(t, 500, cfg.GetInt("max-conns"))
+
+	var server struct {
+		Port int
+	}
+	require.NoError(t, cfg.UnmarshalKey("server", &server))
+	assert.Equal(t, 9090, server.Port)
+	assert.Error(t, cfg.UnmarshalKey("missing", &server))
+}
+
+// TestLoad_Errors 测试 Load 的错误处理。
+func TestLoad_Errors(t *testing.T) {
+	var c testConfig
+	_, err := Load(&c, WithDefaults(map[string]interface{}{"server.port": 0}))
+	assert.ErrorContains(t, err, "配置校验失败")
+
+	_, err = Load(c)
+	assert.ErrorContains(t, err, "指向结构体的指针")
+
+	_, err = Load(&c, WithDefaults(map[string]interface{}{"server.port": "abc"}))
+	assert.ErrorContains(t, err, "解析配置失败")
+}
+
+// TestLoad_ValidateTag 测试 validate 标签的校验与字段路径。
+func TestLoad_ValidateTag(t *testing.T) {
+	type embedded struct {
+		Name string `config:"name" validate:"required"`
+	}
+	type tagConfig struct {
+		embedded `config:",squash"`
+		Server   struct {
+			Port    int           `config:"port" default:"80" validate:"min=1,max=65535"`
+			Timeout time.Duration `config:"timeout" default:"1s" validate:"min=100ms"`
+		} `config:"server"`
+		MaxConns int `config:"max-conns" validate:"min=1"`
+	}
+
+	var c tagConfig
+	_, err := Load(&c, WithDefaults(map[string]interface{}{"name": "app", "max-conns": 10}))
+	require.NoError(t, err)
+
+	var invalid tagConfig
+	_, err = Load(&invalid, WithDefaults(map[string]interface{}{"server.port": 70000, "server.timeout": "10ms"}))
+	require.ErrorContains(t, err, "配置校验失败")
+	var fields []string
+	for _, fe := range kitvalidator.FieldErrors(err) {
+		fields = append(fields, fe.Field)
+	}
+	assert.Equal(t, []string{"name", "server.port", "server.timeout", "max-conns"}, fields)
+}
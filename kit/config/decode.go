@@ -0,0 +1,128 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+
+	kitvalidator "github.com/fsyyft-go/monorepo/kit/validator"
+)
+
+const (
+	// tagName 是指定配置键的结构体标签。
+	tagName = "config"
+	// defaultTagName 是指定默认值的结构体标签。
+	defaultTagName = "default"
+)
+
+var (
+	// textUnmarshalerType 是 encoding.TextUnmarshaler 的反射类型，实现了该接口的结构体视为单个配置值。
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// decode 将配置值宽松地解析到 out，支持字符串到数字、布尔、时长以及逗号分隔的切片的转换。
+func decode(input, out interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           out,
+		TagName:          tagName,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+			mapstructure.TextUnmarshallerHookFunc(),
+		),
+	})
+	if nil != err {
+		return err
+	}
+	return decoder.Decode(input)
+}
+
+// validate 按 validate 标签校验配置结构体，字段路径与配置键一致。
+func validate(v interface{}) error {
+	return kitvalidator.Struct(v, kitvalidator.WithFieldName(func(field reflect.StructField) string {
+		name, squash, _ := fieldKey(field)
+		if squash {
+			return ""
+		}
+		return name
+	}))
+}
+
+// structDefaults 遍历结构体，返回 default 标签指定的默认值与全部配置键。
+func structDefaults(v interface{}) (map[string]interface{}, []string, error) {
+	t := reflect.TypeOf(v)
+	if nil == t || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("配置解析目标必须是指向结构体的指针，实际为 %T", v)
+	}
+
+	defaults := make(map[string]interface{})
+	var keys []string
+	walkStruct(t.Elem(), "", defaults, &keys)
+	return defaults, keys, nil
+}
+
+// walkStruct 递归遍历结构体字段，收集默认值与配置键。
+func walkStruct(t reflect.Type, prefix string, defaults map[string]interface{}, keys *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, squash, skip := fieldKey(field)
+		if skip {
+			continue
+		}
+		key := name
+		if "" != prefix {
+			key = prefix + keyDelimiter + name
+		}
+
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && !reflect.PointerTo(ft).Implements(textUnmarshalerType) {
+			if squash {
+				walkStruct(ft, prefix, defaults, keys)
+			} else {
+				walkStruct(ft, key, defaults, keys)
+			}
+			continue
+		}
+
+		*keys = append(*keys, key)
+		if dv, ok := field.Tag.Lookup(defaultTagName); ok {
+			defaults[key] = dv
+		}
+	}
+}
+
+// fieldKey 解析字段的配置键，返回键名、是否展开到上一级以及是否跳过。
+func fieldKey(field reflect.StructField) (string, bool, bool) {
+	tag := field.Tag.Get(tagName)
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if "-" == name {
+		return "", false, true
+	}
+
+	squash := false
+	for _, opt := range parts[1:] {
+		if "squash" == opt {
+			squash = true
+		}
+	}
+	if "" == name {
+		name = field.Name
+	}
+	return strings.ToLower(name), squash, false
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package config 提供了分层的配置加载功能。
+
+配置来源与优先级（从低到高）：
+
+  - 默认值：结构体字段的 default 标签与 WithDefaults 指定的值
+  - 配置文件：WithFile、WithOptionalFile 按顺序加载，支持 YAML、JSON 与 TOML
+  - 环境变量：WithEnv 指定前缀，配置键 server.port 对应 <PREFIX>_SERVER_PORT
+  - 命令行参数：WithFlags 指定参数集合，参数名即配置键，只有显式设置的参数会覆盖其他来源
+
+配置键不区分大小写，层级之间以 "." 分隔。
+
+基本使用：
+
+	cfg, err := config.New(
+	    config.WithFile("conf/app.yaml"),
+	    config.WithEnv("APP"),
+	)
+	if nil != err {
+	    panic(err)
+	}
+	port := cfg.GetInt("server.port")
+	timeout := cfg.GetDuration("server.timeout")
+
+解析到结构体：
+
+Load 加载配置并解析到结构体，字段通过 config 标签指定配置键、通过 default 标签指定默认值；
+结构体实现了 Validator 接口时，解析完成后会调用其 Validate 方法。
+
+	type AppConfig struct {
+	    Server struct {
+	        Port    int           `config:"port" default:"8080"`
+	        Timeout time.Duration `config:"timeout" default:"5s"`
+	    } `config:"server"`
+	}
+
+	var c AppConfig
+	if _, err := config.Load(&c, config.WithFile("conf/app.yaml"), config.WithEnv("APP")); nil != err {
+	    panic(err)
+	}
+
+类型转换：
+
+取值方法与结构体解析都会进行宽松的类型转换，例如环境变量中的字符串 "8080" 可以解析为整数，
+"5s" 可以解析为 time.Duration，"a,b" 可以解析为 []string。
+
+热更新：
+
+Watch 加载配置并监听配置文件的变化，文件变化后重新加载全部来源，配置内容发生变化时原子替换快照并通知订阅者；
+重新加载失败时保留原有配置。
+
+	w, err := config.Watch(config.WithFile("conf/app.yaml"))
+	if nil != err {
+	    panic(err)
+	}
+	defer w.Close()
+
+	w.OnChange(func(old, new config.Config) {
+	    if level, err := log.ParseLevel(new.GetString("log.level")); nil == err {
+	        logger.SetLevel(level)
+	    }
+	})
+
+	// 连续读取多个相关配置项时先获取快照。
+	snapshot := w.Snapshot()
+
+密钥占位符：
+
+WithSecrets 启用 env://NAME 与 file:///path 两种密钥占位符，WithSecretResolver 注册自定义协议，
+密钥在加载时注入，配置的 Redacted、String 与 MarshalJSON 输出中密钥被替换为 "******"。
+
+	cfg, err := config.New(
+	    config.WithFile("conf/app.yaml"), // database.password: env://DB_PASSWORD
+	    config.WithSecrets(),
+	)
+	logger.WithField("config", cfg).Info("配置加载完成")
+*/
+package config
@@ -0,0 +1,23 @@
+module github.com/fsyyft-go/monorepo/kit/config
+
+go 1.25
+
+require (
+	github.com/BurntSushi/toml v1.5.0
+	github.com/fsnotify/fsnotify v1.9.0
+	github.com/fsyyft-go/monorepo/kit/validator v0.1.0
+	github.com/go-viper/mapstructure/v2 v2.4.0
+	github.com/stretchr/testify v1.11.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/fsyyft-go/monorepo/kit/errors v0.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+)
+
+replace github.com/fsyyft-go/monorepo/kit/validator => ../validator
+
+replace github.com/fsyyft-go/monorepo/kit/errors => ../errors
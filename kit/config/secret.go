@@ -0,0 +1,203 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SchemeEnv 是从环境变量读取密钥的占位符协议，例如 env://DB_PASSWORD。
+	SchemeEnv = "env"
+	// SchemeFile 是从文件读取密钥的占位符协议，例如 file:///run/secrets/db_password。
+	SchemeFile = "file"
+
+	// schemeSeparator 是占位符中协议与引用之间的分隔符。
+	schemeSeparator = "://"
+	// redactedValue 是输出配置时替换密钥的值。
+	redactedValue = "******"
+)
+
+type (
+	// SecretResolver 解析密钥占位符中协议之后的引用，返回密钥的值。
+	// 例如占位符 vault://secret/db#password 对应的引用为 secret/db#password。
+	SecretResolver func(ref string) (string, error)
+)
+
+// WithSecrets 启用密钥占位符解析，内置 env:// 与 file:// 两种协议。
+// 启用后，任意来源中形如 env://NAME 或 file:///path 的字符串配置值都会在加载时被替换为密钥的值，
+// 这些配置项在 Redacted、String 与 MarshalJSON 的输出中会被替换为 "******"。
+// file:// 读取的文件内容会去掉末尾的换行符；引用的环境变量或文件不存在时加载失败。
+//
+// 返回值：
+//   - Option：配置选项函数。
+//
+// 示例：
+//
+//	// app.yaml：
+//	//   db:
+//	//     password: env://DB_PASSWORD
+//	//     tls-key: file:///run/secrets/db_tls_key
+//	cfg, err := config.New(config.WithFile("app.yaml"), config.WithSecrets())
+func WithSecrets() Option {
+	return func(o *options) {
+		o.secretsEnabled = true
+	}
+}
+
+// WithSecretResolver 注册自定义协议的密钥解析函数，并启用密钥占位符解析，可以多次使用注册多个协议。
+// 注册 env 或 file 协议时覆盖内置的解析方式。
+//
+// 参数：
+//   - scheme：协议名称，不区分大小写，例如 "vault"。
+//   - fn：解析函数，为 nil 时忽略。
+//
+// 返回值：
+//   - Option：配置选项函数。
+//
+// 示例：
+//
+//	cfg, err := config.New(
+//	    config.WithFile("app.yaml"),
+//	    config.WithSecretResolver("vault", func(ref string) (string, error) {
+//	        return vaultClient.Read(ref)
+//	    }),
+//	)
+func WithSecretResolver(scheme string, fn SecretResolver) Option {
+	return func(o *options) {
+		if nil == fn {
+			return
+		}
+		o.secretsEnabled = true
+		o.resolvers[strings.ToLower(scheme)] = fn
+	}
+}
+
+// resolveEnv 从环境变量读取密钥。
+func resolveEnv(name string) (string, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("环境变量 %s 不存在", name)
+	}
+	return v, nil
+}
+
+// resolveFile 从文件读取密钥，去掉末尾的换行符。
+func (o *options) resolveFile(path string) (string, error) {
+	data, err := o.read(path)
+	if nil != err {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// resolveSecrets 将配置中的密钥占位符替换为密钥的值，返回被替换的配置键。
+func (o *options) resolveSecrets(settings map[string]interface{}) (map[string]struct{}, error) {
+	if !o.secretsEnabled {
+		return nil, nil
+	}
+	secrets := make(map[string]struct{})
+	if err := o.resolveMap(settings, "", secrets); nil != err {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+// resolveMap 递归替换映射中的密钥占位符。
+func (o *options) resolveMap(m map[string]interface{}, prefix string, secrets map[string]struct{}) error {
+	for k, v := range m {
+		key := k
+		if "" != prefix {
+			key = prefix + keyDelimiter + k
+		}
+		resolved, secret, err := o.resolveValue(v, key, secrets)
+		if nil != err {
+			return err
+		}
+		if secret {
+			m[k] = resolved
+			secrets[key] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// resolveValue 替换单个配置值中的密钥占位符，返回替换后的值以及该值本身是否为密钥。
+// 切片中包含密钥时整个切片视为密钥。
+func (o *options) resolveValue(v interface{}, key string, secrets map[string]struct{}) (interface{}, bool, error) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return val, false, o.resolveMap(val, key, secrets)
+	case []interface{}:
+		secret := false
+		for i, item := range val {
+			resolved, ok, err := o.resolveValue(item, key+keyDelimiter+strconv.Itoa(i), secrets)
+			if nil != err {
+				return nil, false, err
+			}
+			if ok {
+				val[i] = resolved
+				secret = true
+			}
+		}
+		return val, secret, nil
+	case string:
+		scheme, ref, ok := strings.Cut(val, schemeSeparator)
+		if !ok {
+			return val, false, nil
+		}
+		fn, ok := o.resolvers[strings.ToLower(scheme)]
+		if !ok {
+			return val, false, nil
+		}
+		secret, err := fn(ref)
+		if nil != err {
+			return nil, false, fmt.Errorf("解析配置项 %s 的密钥 %s 失败：%w", key, scheme+schemeSeparator, err)
+		}
+		return secret, true, nil
+	default:
+		return val, false, nil
+	}
+}
+
+// Redacted 返回全部配置的副本，密钥替换为 "******"。
+func (c *config) Redacted() map[string]interface{} {
+	out := deepCopy(c.settings)
+	for key := range c.secrets {
+		redact(out, strings.Split(key, keyDelimiter))
+	}
+	return out
+}
+
+// redact 将 parts 指向的配置值替换为 "******"，路径经过切片时替换整个切片。
+func redact(m map[string]interface{}, parts []string) {
+	v, ok := m[parts[0]]
+	if !ok {
+		return
+	}
+	if sub, isMap := v.(map[string]interface{}); isMap && len(parts) > 1 {
+		redact(sub, parts[1:])
+		return
+	}
+	m[parts[0]] = redactedValue
+}
+
+// String 实现 fmt.Stringer 接口，返回密钥已替换为 "******" 的全部配置，便于输出到日志。
+func (c *config) String() string {
+	data, err := json.Marshal(c.Redacted())
+	if nil != err {
+		return fmt.Sprintf("%v", c.Redacted())
+	}
+	return string(data)
+}
+
+// MarshalJSON 实现 json.Marshaler 接口，输出密钥已替换为 "******" 的全部配置。
+func (c *config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Redacted())
+}
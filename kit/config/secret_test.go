@@ -0,0 +1,141 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSecrets 测试 env://、file:// 与自定义协议的密钥占位符在加载时被替换，输出配置时被脱敏。
+func TestSecrets(t *testing.T) {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(keyPath, []byte("key-data\n"), 0600))
+	t.Setenv("TEST_DB_PASSWORD", "p@ss")
+
+	var refs []string
+	cfg, err := New(
+		WithDefaults(map[string]interface{}{
+			"db.host":      "localhost",
+			"db.password":  "env://TEST_DB_PASSWORD",
+			"db.tls-key":   "file://" + keyPath,
+			"api.token":    "Vault://secret/api#token",
+			"api.tokens":   []interface{}{"plain", "vault://secret/api#backup"},
+			"api.homepage": "https://example.com",
+		}),
+		WithSecretResolver("vault", func(ref string) (string, error) {
+			refs = append(refs, ref)
+			return "v-" + ref, nil
+		}),
+		WithSecretResolver("nil", nil),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "p@ss", cfg.GetString("db.password"))
+	assert.Equal(t, "key-data", cfg.GetString("db.tls-key"))
+	assert.Equal(t, "v-secret/api#token", cfg.GetString("api.token"))
+	assert.Equal(t, []string{"plain", "v-secret/api#backup"}, cfg.GetStringSlice("api.tokens"))
+	assert.Equal(t, "https://example.com", cfg.GetString("api.homepage"), "未注册的协议保持原值")
+	assert.ElementsMatch(t, []string{"secret/api#token", "secret/api#backup"}, refs)
+
+	redacted := cfg.Redacted()
+	assert.Equal(t, map[string]interface{}{
+		"db": map[string]interface{}{
+			"host":     "localhost",
+			"password": redactedValue,
+			"tls-key":  redactedValue,
+		},
+		"api": map[string]interface{}{
+			"token":    redactedValue,
+			"tokens":   redactedValue,
+			"homepage": "https://example.com",
+		},
+	}, redacted)
+	assert.Equal(t, "p@ss", cfg.GetString("db.password"), "脱敏不影响原配置")
+
+	for _, out := range []string{fmt.Sprint(cfg), fmt.Sprintf("%v", cfg)} {
+		assert.NotContains(t, out, "p@ss")
+		assert.Contains(t, out, redactedValue)
+	}
+	data, err := json.Marshal(map[string]interface{}{"config": cfg})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "key-data")
+	assert.Contains(t, string(data), `"host":"localhost"`)
+}
+
+// TestSecrets_Disabled 测试未启用时占位符保持原值。
+func TestSecrets_Disabled(t *testing.T) {
+	t.Setenv("TEST_DB_PASSWORD", "p@ss")
+	cfg, err := New(WithDefaults(map[string]interface{}{"db.password": "env://TEST_DB_PASSWORD"}))
+	require.NoError(t, err)
+	assert.Equal(t, "env://TEST_DB_PASSWORD", cfg.GetString("db.password"))
+	assert.Equal(t, cfg.AllSettings(), cfg.Redacted())
+}
+
+// TestSecrets_Error 测试密钥无法解析时加载失败，错误包含配置键。
+func TestSecrets_Error(t *testing.T) {
+	errDenied := errors.New("permission denied")
+	tests := []struct {
+		name  string
+		value interface{}
+		opts  []Option
+		want  string
+	}{
+		{"环境变量不存在", "env://TEST_NOT_EXISTS", []Option{WithSecrets()}, "环境变量 TEST_NOT_EXISTS 不存在"},
+		{"文件不存在", "file:///not/exists", []Option{WithSecrets()}, "/not/exists"},
+		{"自定义协议失败", []interface{}{"vault://a"}, []Option{WithSecretResolver("vault", func(string) (string, error) {
+			return "", errDenied
+		})}, "permission denied"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithDefaults(map[string]interface{}{"db.password": tt.value})}, tt.opts...)
+			_, err := New(opts...)
+			require.Error(t, err)
+			assert.True(t, strings.HasPrefix(err.Error(), "解析配置项 db.password"), err.Error())
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+// TestSecrets_Load 测试 Load 解析到结构体的是密钥的值。
+func TestSecrets_Load(t *testing.T) {
+	t.Setenv("TEST_DB_PASSWORD", "p@ss")
+	var c struct {
+		DB struct {
+			Password string `config:"password" default:"env://TEST_DB_PASSWORD"`
+		} `config:"db"`
+	}
+	cfg, err := Load(&c, WithSecrets())
+	require.NoError(t, err)
+	assert.Equal(t, "p@ss", c.DB.Password)
+	assert.Equal(t, `{"db":{"password":"******"}}`, fmt.Sprint(cfg))
+}
+
+// TestSecrets_Watcher 测试重新加载时重新解析密钥，Watcher 的输出同样被脱敏。
+func TestSecrets_Watcher(t *testing.T) {
+	t.Setenv("TEST_DB_PASSWORD", "old")
+	w, err := Watch(WithDefaults(map[string]interface{}{"db.password": "env://TEST_DB_PASSWORD"}), WithSecrets())
+	require.NoError(t, err)
+	defer w.Close() // nolint: errcheck
+
+	t.Setenv("TEST_DB_PASSWORD", "new")
+	require.NoError(t, w.Reload())
+	assert.Equal(t, "new", w.GetString("db.password"))
+	assert.Equal(t, map[string]interface{}{"db": map[string]interface{}{"password": redactedValue}}, w.Redacted())
+	assert.Equal(t, `{"db":{"password":"******"}}`, w.String())
+	data, err := json.Marshal(w)
+	require.NoError(t, err)
+	assert.Equal(t, `{"db":{"password":"******"}}`, string(data))
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// envKeyReplacer 将配置键转换为环境变量名。
+	envKeyReplacer = strings.NewReplacer(keyDelimiter, "_", "-", "_")
+)
+
+// load 按优先级从低到高依次合并默认值、配置文件、环境变量与命令行参数，然后解析密钥占位符。
+func (o *options) load() (*config, error) {
+	settings := make(map[string]interface{})
+
+	// 默认值。
+	for k, v := range o.defaults {
+		setPath(settings, k, normalize(v))
+	}
+	// 命令行参数的默认值同样作为默认值。
+	if nil != o.flags {
+		o.flags.VisitAll(func(f *flag.Flag) {
+			setPath(settings, f.Name, flagValue(f))
+		})
+	}
+
+	// 配置文件。
+	for _, f := range o.files {
+		data, err := o.readFile(f)
+		if nil != err {
+			return nil, err
+		}
+		merge(settings, data)
+	}
+
+	// 环境变量，只匹配已知的配置键。
+	if o.envEnabled {
+		keys := append(leafKeys(settings, ""), o.keys...)
+		for _, key := range keys {
+			if v, ok := os.LookupEnv(o.envName(key)); ok {
+				setPath(settings, key, v)
+			}
+		}
+	}
+
+	// 显式设置的命令行参数。
+	if nil != o.flags {
+		o.flags.Visit(func(f *flag.Flag) {
+			setPath(settings, f.Name, flagValue(f))
+		})
+	}
+
+	secrets, err := o.resolveSecrets(settings)
+	if nil != err {
+		return nil, err
+	}
+
+	return &config{settings: settings, secrets: secrets}, nil
+}
+
+// envName 返回配置键对应的环境变量名。
+func (o *options) envName(key string) string {
+	name := strings.ToUpper(envKeyReplacer.Replace(key))
+	if "" == o.envPrefix {
+		return name
+	}
+	return strings.ToUpper(o.envPrefix) + "_" + name
+}
+
+// flagValue 返回命令行参数的值，实现了 flag.Getter 的参数返回其类型化的值。
+func flagValue(f *flag.Flag) interface{} {
+	if getter, ok := f.Value.(flag.Getter); ok {
+		return getter.Get()
+	}
+	return f.Value.String()
+}
+
+// readFile 读取并解析配置文件。
+func (o *options) readFile(f fileSource) (map[string]interface{}, error) {
+	data, err := o.read(f.path)
+	if nil != err {
+		if f.optional && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("读取配置文件 %s 失败：%w", f.path, err)
+	}
+
+	settings, err := parse(filepath.Ext(f.path), data)
+	if nil != err {
+		return nil, fmt.Errorf("解析配置文件 %s 失败：%w", f.path, err)
+	}
+	return settings, nil
+}
+
+// read 从注入的文件系统或磁盘读取文件。
+func (o *options) read(path string) ([]byte, error) {
+	if nil == o.fsys {
+		return os.ReadFile(path) // nolint:gosec
+	}
+	return fs.ReadFile(o.fsys, path)
+}
+
+// parse 按扩展名解析配置内容。
+func parse(ext string, data []byte) (map[string]interface{}, error) {
+	raw := make(map[string]interface{})
+
+	var err error
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("不支持的配置文件格式：%q", ext)
+	}
+	if nil != err {
+		return nil, err
+	}
+
+	settings, _ := normalize(raw).(map[string]interface{})
+	return settings, nil
+}
+
+// normalize 将值中的映射统一转换为键为小写字符串的 map[string]interface{}。
+func normalize(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[strings.ToLower(k)] = normalize(item)
+		}
+		return m
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[strings.ToLower(fmt.Sprint(k))] = normalize(item)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(val))
+		for i, item := range val {
+			s[i] = normalize(item)
+		}
+		return s
+	case []map[string]interface{}:
+		// TOML 的表数组会被解析为 []map[string]interface{}。
+		s := make([]interface{}, len(val))
+		for i, item := range val {
+			s[i] = normalize(item)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// setPath 按层级设置配置值，中间层级不存在或不是映射时会被创建或覆盖。
+func setPath(settings map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(strings.ToLower(key), keyDelimiter)
+	cur := settings
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := cur[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			cur[part] = next
+		}
+		cur = next
+	}
+
+	last := parts[len(parts)-1]
+	if m, ok := value.(map[string]interface{}); ok {
+		// 映射值与已有的子配置合并，而不是整体替换。
+		if existing, ok := cur[last].(map[string]interface{}); ok {
+			merge(existing, m)
+			return
+		}
+	}
+	cur[last] = value
+}
+
+// merge 将 src 深度合并到 dst，同名的非映射值以 src 为准。
+func merge(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if sm, ok := v.(map[string]interface{}); ok {
+			if dm, ok := dst[k].(map[string]interface{}); ok {
+				merge(dm, sm)
+				continue
+			}
+			dst[k] = deepCopy(sm)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// leafKeys 返回所有非映射配置值的完整键，按字典序排列。
+func leafKeys(settings map[string]interface{}, prefix string) []string {
+	var keys []string
+	for k, v := range settings {
+		full := k
+		if "" != prefix {
+			full = prefix + keyDelimiter + k
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			keys = append(keys, leafKeys(m, full)...)
+			continue
+		}
+		keys = append(keys, full)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// deepCopy 深度复制配置映射。
+func deepCopy(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			out[k] = deepCopy(val)
+		case []interface{}:
+			s := make([]interface{}, len(val))
+			copy(s, val)
+			out[k] = s
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
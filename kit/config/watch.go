@@ -0,0 +1,312 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+var (
+	// 确保 Watcher 实现了 Config 接口。
+	_ Config = (*Watcher)(nil)
+
+	// reloadDebounceDefault 定义了文件变化后重新加载配置前的默认等待时间。
+	// 编辑器保存文件时通常会产生多个连续事件，等待一段时间可以将其合并为一次重新加载。
+	reloadDebounceDefault = 100 * time.Millisecond
+)
+
+type (
+	// Watcher 是支持热更新的配置。
+	// Watcher 实现了 Config 接口，每次取值都读取最新的配置快照；配置文件变化时自动重新加载，
+	// 并在配置内容发生变化时通知通过 OnChange 注册的订阅者。重新加载失败时保留原有配置。
+	Watcher struct {
+		// opts 是加载配置的选项，重新加载时复用。
+		opts *options
+		// current 是当前的配置快照。
+		current atomic.Pointer[config]
+		// reloadMu 保证重新加载与通知按顺序执行。
+		reloadMu sync.Mutex
+		// subMu 保护 subscribers。
+		subMu sync.RWMutex
+		// subscribers 是配置变化的订阅者。
+		subscribers []func(old, new Config)
+		// fsw 是文件系统监听器。
+		fsw *fsnotify.Watcher
+		// done 在 Close 时关闭，通知监听协程退出。
+		done chan struct{}
+		// wg 等待监听协程退出。
+		wg sync.WaitGroup
+		// closeOnce 保证 Close 只执行一次。
+		closeOnce sync.Once
+	}
+)
+
+// WithReloadDebounce 设置文件变化后重新加载配置前的等待时间，仅对 Watch 生效。
+//
+// 参数：
+//   - d：等待时间，默认为 100 毫秒。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithReloadDebounce(d time.Duration) Option {
+	return func(o *options) {
+		o.reloadDebounce = d
+	}
+}
+
+// WithReloadErrorHandler 设置自动重新加载失败时的处理函数，仅对 Watch 生效。
+// 重新加载失败时原有配置保持不变；未设置时错误被忽略。
+//
+// 参数：
+//   - fn：错误处理函数。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithReloadErrorHandler(fn func(err error)) Option {
+	return func(o *options) {
+		o.onReloadError = fn
+	}
+}
+
+// Watch 加载配置并监听配置文件的变化。
+// 监听的是配置文件所在的目录，因此文件被删除后重建、通过重命名原子替换，以及 Kubernetes ConfigMap 的符号链接切换都能被感知。
+//
+// 参数：
+//   - opts：配置选项，与 New 相同。
+//
+// 返回值：
+//   - *Watcher：支持热更新的配置，不再使用时需要调用 Close。
+//   - error：首次加载或创建监听失败时返回错误。
+//
+// 示例：
+//
+//	w, err := config.Watch(config.WithFile("conf/app.yaml"))
+//	if nil != err {
+//	    panic(err)
+//	}
+//	defer w.Close()
+//
+//	w.OnChange(func(old, new config.Config) {
+//	    if level, err := log.ParseLevel(new.GetString("log.level")); nil == err {
+//	        logger.SetLevel(level)
+//	    }
+//	})
+func Watch(opts ...Option) (*Watcher, error) {
+	o := newOptions(opts...)
+
+	cfg, err := o.load()
+	if nil != err {
+		return nil, err
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if nil != err {
+		return nil, fmt.Errorf("创建配置文件监听失败：%w", err)
+	}
+	dirs := make(map[string]struct{})
+	for _, f := range o.files {
+		// 注入的文件系统无法监听，只能手动重新加载。
+		if nil != o.fsys {
+			break
+		}
+		dir := filepath.Dir(f.path)
+		if _, ok := dirs[dir]; ok {
+			continue
+		}
+		dirs[dir] = struct{}{}
+		if err := fsw.Add(dir); nil != err {
+			_ = fsw.Close()
+			return nil, fmt.Errorf("监听配置目录 %s 失败：%w", dir, err)
+		}
+	}
+
+	w := &Watcher{
+		opts: o,
+		fsw:  fsw,
+		done: make(chan struct{}),
+	}
+	w.current.Store(cfg)
+
+	w.wg.Add(1)
+	go w.watch()
+
+	return w, nil
+}
+
+// Snapshot 返回当前配置的快照，快照不会随之后的重新加载而改变。
+// 需要连续读取多个相关配置项时应先获取快照，保证读取到的配置来自同一版本。
+//
+// 返回值：
+//   - Config：当前配置的快照。
+func (w *Watcher) Snapshot() Config {
+	return w.current.Load()
+}
+
+// OnChange 注册配置变化的订阅者。
+// 每次重新加载后配置内容发生变化时，订阅者按注册顺序被同步调用，old 与 new 分别为变化前后的快照。
+// 订阅者不应长时间阻塞，否则会推迟后续的重新加载。
+//
+// 参数：
+//   - fn：订阅函数。
+func (w *Watcher) OnChange(fn func(old, new Config)) {
+	w.subMu.Lock()
+	defer w.subMu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Reload 立即重新加载配置，内容发生变化时通知订阅者。
+//
+// 返回值：
+//   - error：加载失败时返回错误，此时原有配置保持不变。
+func (w *Watcher) Reload() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	next, err := w.opts.load()
+	if nil != err {
+		return err
+	}
+
+	old := w.current.Load()
+	if reflect.DeepEqual(old.settings, next.settings) {
+		return nil
+	}
+	w.current.Store(next)
+
+	w.subMu.RLock()
+	subscribers := append([]func(old, new Config){}, w.subscribers...)
+	w.subMu.RUnlock()
+	for _, fn := range subscribers {
+		fn(old, next)
+	}
+
+	return nil
+}
+
+// Close 停止监听配置文件。
+//
+// 返回值：
+//   - error：关闭监听失败时返回错误。
+func (w *Watcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		err = w.fsw.Close()
+		w.wg.Wait()
+	})
+	return err
+}
+
+// watch 处理文件系统事件，合并短时间内的连续事件后重新加载配置。
+func (w *Watcher) watch() {
+	defer w.wg.Done()
+
+	debounce := w.opts.reloadDebounce
+	if debounce <= 0 {
+		debounce = reloadDebounceDefault
+	}
+
+	var timer *time.Timer
+	var timerC <-chan time.Time
+	defer func() {
+		if nil != timer {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case _, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			// 目录中的任何变化都可能影响配置文件（例如符号链接切换），统一触发重新加载，由内容比较决定是否通知。
+			if nil == timer {
+				timer = time.NewTimer(debounce)
+			} else {
+				timer.Reset(debounce)
+			}
+			timerC = timer.C
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			w.reportError(fmt.Errorf("监听配置文件失败：%w", err))
+		case <-timerC:
+			timerC = nil
+			if err := w.Reload(); nil != err {
+				w.reportError(err)
+			}
+		}
+	}
+}
+
+// reportError 将自动重新加载过程中的错误交给错误处理函数。
+func (w *Watcher) reportError(err error) {
+	if nil != w.opts.onReloadError {
+		w.opts.onReloadError(err)
+	}
+}
+
+// Get 获取配置项的原始值。
+func (w *Watcher) Get(key string) interface{} { return w.current.Load().Get(key) }
+
+// IsSet 判断配置项是否存在。
+func (w *Watcher) IsSet(key string) bool { return w.current.Load().IsSet(key) }
+
+// GetString 获取字符串类型的配置值。
+func (w *Watcher) GetString(key string) string { return w.current.Load().GetString(key) }
+
+// GetInt 获取整数类型的配置值。
+func (w *Watcher) GetInt(key string) int { return w.current.Load().GetInt(key) }
+
+// GetInt64 获取 int64 类型的配置值。
+func (w *Watcher) GetInt64(key string) int64 { return w.current.Load().GetInt64(key) }
+
+// GetFloat64 获取浮点数类型的配置值。
+func (w *Watcher) GetFloat64(key string) float64 { return w.current.Load().GetFloat64(key) }
+
+// GetBool 获取布尔类型的配置值。
+func (w *Watcher) GetBool(key string) bool { return w.current.Load().GetBool(key) }
+
+// GetDuration 获取时长类型的配置值。
+func (w *Watcher) GetDuration(key string) time.Duration { return w.current.Load().GetDuration(key) }
+
+// GetStringSlice 获取字符串切片类型的配置值。
+func (w *Watcher) GetStringSlice(key string) []string { return w.current.Load().GetStringSlice(key) }
+
+// GetStringMap 获取子配置的全部键值。
+func (w *Watcher) GetStringMap(key string) map[string]interface{} {
+	return w.current.Load().GetStringMap(key)
+}
+
+// AllSettings 返回全部配置的副本。
+func (w *Watcher) AllSettings() map[string]interface{} { return w.current.Load().AllSettings() }
+
+// Redacted 返回全部配置的副本，密钥替换为 "******"。
+func (w *Watcher) Redacted() map[string]interface{} { return w.current.Load().Redacted() }
+
+// String 实现 fmt.Stringer 接口，返回密钥已替换为 "******" 的全部配置。
+func (w *Watcher) String() string { return w.current.Load().String() }
+
+// MarshalJSON 实现 json.Marshaler 接口，输出密钥已替换为 "******" 的全部配置。
+func (w *Watcher) MarshalJSON() ([]byte, error) { return w.current.Load().MarshalJSON() }
+
+// Unmarshal 将全部配置解析到结构体。
+func (w *Watcher) Unmarshal(v interface{}) error { return w.current.Load().Unmarshal(v) }
+
+// UnmarshalKey 将指定子配置解析到 v。
+func (w *Watcher) UnmarshalKey(key string, v interface{}) error {
+	return w.current.Load().UnmarshalKey(key, v)
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWatch_FileChange 测试配置文件变化后自动重新加载并通知订阅者。
+func TestWatch_FileChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: info\n"), 0644))
+
+	var errMu sync.Mutex
+	var reloadErrs []error
+	w, err := Watch(
+		WithFile(path),
+		WithReloadDebounce(10*time.Millisecond),
+		WithReloadErrorHandler(func(err error) {
+			errMu.Lock()
+			defer errMu.Unlock()
+			reloadErrs = append(reloadErrs, err)
+		}),
+	)
+	require.NoError(t, err)
+	defer w.Close() // nolint: errcheck
+
+	snapshot := w.Snapshot()
+	changes := make(chan [2]string, 10)
+	w.OnChange(func(old, new Config) {
+		changes <- [2]string{old.GetString("log.level"), new.GetString("log.level")}
+	})
+	assert.Equal(t, "info", w.GetString("log.level"))
+
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: debug\n"), 0644))
+	select {
+	case c := <-changes:
+		assert.Equal(t, [2]string{"info", "debug"}, c)
+	case <-time.After(5 * time.Second):
+		t.Fatal("未收到配置变化通知")
+	}
+	assert.Equal(t, "debug", w.GetString("log.level"))
+	assert.Equal(t, "info", snapshot.GetString("log.level"), "快照不随重新加载改变")
+
+	// 格式错误的文件不影响当前配置，错误交给处理函数。
+	require.NoError(t, os.WriteFile(path, []byte("log: [\n"), 0644))
+	require.Eventually(t, func() bool {
+		errMu.Lock()
+		defer errMu.Unlock()
+		return len(reloadErrs) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "debug", w.GetString("log.level"))
+
+	// 通过重命名原子替换文件。
+	tmp := filepath.Join(dir, "app.yaml.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("log:\n  level: warn\n"), 0644))
+	require.NoError(t, os.Rename(tmp, path))
+	select {
+	case c := <-changes:
+		assert.Equal(t, [2]string{"debug", "warn"}, c)
+	case <-time.After(5 * time.Second):
+		t.Fatal("未收到配置变化通知")
+	}
+}
+
+// TestWatcher_Reload 测试手动重新加载与内容未变化时不通知。
+func TestWatcher_Reload(t *testing.T) {
+	w, err := Watch(WithEnv("KIT_CONFIG_TEST"), WithDefaults(map[string]interface{}{"size": 1}))
+	require.NoError(t, err)
+	defer w.Close() // nolint: errcheck
+
+	var calls int
+	w.OnChange(func(old, new Config) { calls++ })
+
+	require.NoError(t, w.Reload())
+	assert.Equal(t, 0, calls, "内容未变化时不通知")
+
+	t.Setenv("KIT_CONFIG_TEST_SIZE", "2")
+	require.NoError(t, w.Reload())
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 2, w.GetInt("size"))
+
+	var c struct{ Size int }
+	require.NoError(t, w.Unmarshal(&c))
+	assert.Equal(t, 2, c.Size)
+
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close(), "重复关闭不报错")
+}
+
+// TestWatch_Errors 测试 Watch 的错误处理。
+func TestWatch_Errors(t *testing.T) {
+	_, err := Watch(WithFile(filepath.Join(t.TempDir(), "missing.yaml")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	// versionAES256GCM 是 AES-256-GCM 密文的版本号。
+	versionAES256GCM byte = 1
+	// headerSize 是密文头的长度：1 字节版本号与 4 字节密钥标识。
+	headerSize = 1 + 4
+	// nonceSize 是 GCM 随机数的长度。
+	nonceSize = 12
+	// tagSize 是 GCM 认证标签的长度。
+	tagSize = 16
+)
+
+type (
+	// Key 是带标识的对称密钥。
+	Key struct {
+		// ID 是密钥标识，写入密文头，解密时据此选择密钥。
+		ID uint32
+		// Secret 是 KeySize 字节的密钥，可以通过 GenerateKey 生成。
+		Secret []byte
+	}
+
+	// Keyring 是用于 AES-256-GCM 加密的密钥环，支持密钥轮换。
+	// 加密总是使用主密钥；解密根据密文头中的密钥标识选择密钥，因此轮换后仍然可以解密旧密钥加密的数据。
+	// Keyring 创建后不可修改，可以被多个协程并发使用；轮换密钥时创建新的 Keyring 替换旧的。
+	//
+	// 密文的格式为：版本号（1 字节）| 密钥标识（4 字节，大端序）| 随机数（12 字节）| 密文与认证标签，
+	// 密文头同时作为附加数据参与认证，篡改任何部分都会导致解密失败。
+	Keyring struct {
+		// primary 是加密使用的主密钥标识。
+		primary uint32
+		// aeads 是密钥标识到 AEAD 的映射。
+		aeads map[uint32]cipher.AEAD
+	}
+)
+
+// NewKeyring 创建密钥环。
+//
+// 参数：
+//   - primary：加密使用的主密钥。
+//   - previous：只用于解密的旧密钥，轮换期间保留，直到旧数据全部重新加密。
+//
+// 返回值：
+//   - *Keyring：创建的密钥环。
+//   - error：密钥长度不是 KeySize 或者密钥标识重复时返回包装 ErrInvalidKey 的错误。
+//
+// 示例：
+//
+//	// 轮换：新密钥 2 作为主密钥，保留旧密钥 1 用于解密。
+//	keyring, err := crypto.NewKeyring(crypto.Key{ID: 2, Secret: newKey}, crypto.Key{ID: 1, Secret: oldKey})
+func NewKeyring(primary Key, previous ...Key) (*Keyring, error) {
+	k := &Keyring{
+		primary: primary.ID,
+		aeads:   make(map[uint32]cipher.AEAD, 1+len(previous)),
+	}
+	for _, key := range append([]Key{primary}, previous...) {
+		if KeySize != len(key.Secret) {
+			return nil, fmt.Errorf("%w：密钥 %d 的长度为 %d 字节，应为 %d 字节", ErrInvalidKey, key.ID, len(key.Secret), KeySize)
+		}
+		if _, ok := k.aeads[key.ID]; ok {
+			return nil, fmt.Errorf("%w：密钥标识 %d 重复", ErrInvalidKey, key.ID)
+		}
+		block, err := aes.NewCipher(key.Secret)
+		if nil != err {
+			return nil, fmt.Errorf("%w：%v", ErrInvalidKey, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if nil != err {
+			return nil, fmt.Errorf("%w：%v", ErrInvalidKey, err)
+		}
+		k.aeads[key.ID] = aead
+	}
+	return k, nil
+}
+
+// Encrypt 使用主密钥加密数据，每次加密使用新的随机数，相同的明文每次得到不同的密文。
+// 随机数为 96 位，同一个密钥加密的消息数量应远小于 2^32 条，超过时应轮换密钥。
+//
+// 参数：
+//   - plaintext：要加密的数据。
+//   - additionalData：参与认证但不加密的附加数据，例如记录的主键，解密时必须相同，可以为 nil。
+//     绑定附加数据可以防止密文被复制到其他记录中使用。
+//
+// 返回值：
+//   - []byte：带版本号与密钥标识的密文，比明文长 33 字节。
+//
+// 示例：
+//
+//	sealed := keyring.Encrypt([]byte(user.Phone), []byte(user.ID))
+func (k *Keyring) Encrypt(plaintext, additionalData []byte) []byte {
+	out := make([]byte, headerSize+nonceSize, headerSize+nonceSize+len(plaintext)+tagSize)
+	out[0] = versionAES256GCM
+	binary.BigEndian.PutUint32(out[1:headerSize], k.primary)
+	nonce := RandomBytes(nonceSize)
+	copy(out[headerSize:], nonce)
+
+	return k.aeads[k.primary].Seal(out, nonce, plaintext, associatedData(out[:headerSize], additionalData))
+}
+
+// Decrypt 解密 Encrypt 生成的密文。
+//
+// 参数：
+//   - ciphertext：密文。
+//   - additionalData：加密时使用的附加数据。
+//
+// 返回值：
+//   - []byte：解密得到的数据。
+//   - error：密钥不在密钥环中时返回包装 ErrUnknownKey 的错误；格式错误、被篡改或附加数据不同时返回 ErrInvalidCiphertext。
+func (k *Keyring) Decrypt(ciphertext, additionalData []byte) ([]byte, error) {
+	id, err := KeyID(ciphertext)
+	if nil != err {
+		return nil, err
+	}
+	aead, ok := k.aeads[id]
+	if !ok {
+		return nil, fmt.Errorf("%w：密钥标识 %d", ErrUnknownKey, id)
+	}
+
+	header, nonce, sealed := ciphertext[:headerSize], ciphertext[headerSize:headerSize+nonceSize], ciphertext[headerSize+nonceSize:]
+	plaintext, err := aead.Open(nil, nonce, sealed, associatedData(header, additionalData))
+	if nil != err {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
+
+// NeedsRotation 返回密文是否由主密钥以外的密钥加密，用于在读取时将旧数据重新加密。
+//
+// 参数：
+//   - ciphertext：密文。
+//
+// 返回值：
+//   - bool：密文格式错误或者不是由主密钥加密时返回 true。
+//
+// 示例：
+//
+//	plaintext, err := keyring.Decrypt(row.Secret, ad)
+//	if nil == err && keyring.NeedsRotation(row.Secret) {
+//	    row.Secret = keyring.Encrypt(plaintext, ad)
+//	}
+func (k *Keyring) NeedsRotation(ciphertext []byte) bool {
+	id, err := KeyID(ciphertext)
+	return nil != err || k.primary != id
+}
+
+// KeyID 返回密文头中的密钥标识。
+//
+// 参数：
+//   - ciphertext：密文。
+//
+// 返回值：
+//   - uint32：密钥标识。
+//   - error：密文过短或版本号不受支持时返回 ErrInvalidCiphertext。
+func KeyID(ciphertext []byte) (uint32, error) {
+	if len(ciphertext) < headerSize+nonceSize+tagSize || versionAES256GCM != ciphertext[0] {
+		return 0, ErrInvalidCiphertext
+	}
+	return binary.BigEndian.Uint32(ciphertext[1:headerSize]), nil
+}
+
+// associatedData 返回参与认证的附加数据：密文头与调用方提供的附加数据。
+func associatedData(header, additionalData []byte) []byte {
+	ad := make([]byte, 0, len(header)+len(additionalData))
+	return append(append(ad, header...), additionalData...)
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package crypto
+
+import (
+	"crypto/subtle"
+)
+
+// Equal 以常数时间比较两个字节切片，用于比较令牌、签名等秘密值，避免时序攻击。
+// 比较所需的时间只取决于长度，长度不同时直接返回 false，因此会泄露长度是否相同。
+//
+// 参数：
+//   - a、b：要比较的字节切片。
+//
+// 返回值：
+//   - bool：内容相同时返回 true。
+func Equal(a, b []byte) bool {
+	return 1 == subtle.ConstantTimeCompare(a, b)
+}
+
+// EqualString 以常数时间比较两个字符串，语义与 Equal 相同。
+//
+// 参数：
+//   - a、b：要比较的字符串。
+//
+// 返回值：
+//   - bool：内容相同时返回 true。
+//
+// 示例：
+//
+//	if !crypto.EqualString(r.Header.Get("X-Api-Key"), apiKey) {
+//	    http.Error(w, "unauthorized", http.StatusUnauthorized)
+//	    return
+//	}
+func EqualString(a, b string) bool {
+	return Equal([]byte(a), []byte(b))
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package crypto
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestKeyring 测试加密、解密与密钥轮换。
+func TestKeyring(t *testing.T) {
+	oldKey, newKey := GenerateKey(), GenerateKey()
+	old, err := NewKeyring(Key{ID: 1, Secret: oldKey})
+	require.NoError(t, err)
+
+	sealed := old.Encrypt([]byte("secret"), []byte("user:1"))
+	assert.Len(t, sealed, len("secret")+headerSize+nonceSize+tagSize)
+	assert.NotEqual(t, sealed, old.Encrypt([]byte("secret"), []byte("user:1")), "每次使用新的随机数")
+
+	plaintext, err := old.Decrypt(sealed, []byte("user:1"))
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+
+	// 轮换后旧密文仍然可以解密，新密文使用新密钥。
+	rotated, err := NewKeyring(Key{ID: 2, Secret: newKey}, Key{ID: 1, Secret: oldKey})
+	require.NoError(t, err)
+	plaintext, err = rotated.Decrypt(sealed, []byte("user:1"))
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+	assert.True(t, rotated.NeedsRotation(sealed))
+
+	resealed := rotated.Encrypt(plaintext, []byte("user:1"))
+	assert.False(t, rotated.NeedsRotation(resealed))
+	id, err := KeyID(resealed)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), id)
+
+	_, err = old.Decrypt(resealed, []byte("user:1"))
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	empty := rotated.Encrypt(nil, nil)
+	plaintext, err = rotated.Decrypt(empty, nil)
+	require.NoError(t, err)
+	assert.Empty(t, plaintext)
+}
+
+// TestKeyring_Tamper 测试篡改密文与附加数据时解密失败。
+func TestKeyring_Tamper(t *testing.T) {
+	keyring, err := NewKeyring(Key{ID: 7, Secret: GenerateKey()}, Key{ID: 8, Secret: GenerateKey()})
+	require.NoError(t, err)
+	sealed := keyring.Encrypt([]byte("payload"), []byte("ad"))
+
+	_, err = keyring.Decrypt(sealed, []byte("other"))
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	for i := range sealed {
+		tampered := bytes.Clone(sealed)
+		tampered[i] ^= 0x01
+		_, err = keyring.Decrypt(tampered, []byte("ad"))
+		assert.Error(t, err, "第 %d 字节", i)
+	}
+
+	_, err = keyring.Decrypt(sealed[:headerSize+nonceSize+tagSize-1], []byte("ad"))
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+	assert.True(t, keyring.NeedsRotation(nil))
+}
+
+// TestNewKeyring_Errors 测试非法的密钥。
+func TestNewKeyring_Errors(t *testing.T) {
+	_, err := NewKeyring(Key{ID: 1, Secret: make([]byte, 16)})
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	key := GenerateKey()
+	_, err = NewKeyring(Key{ID: 1, Secret: key}, Key{ID: 1, Secret: key})
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+// TestSign 测试 HMAC 签名与校验。
+func TestSign(t *testing.T) {
+	key := GenerateKey()
+	sig, err := Sign(key, []byte("message"))
+	require.NoError(t, err)
+	assert.Len(t, sig, 32)
+
+	assert.True(t, Verify(key, []byte("message"), sig))
+	assert.False(t, Verify(key, []byte("message!"), sig))
+	assert.False(t, Verify(GenerateKey(), []byte("message"), sig))
+	assert.False(t, Verify(key, []byte("message"), sig[:31]))
+
+	_, err = Sign([]byte("short"), nil)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	assert.False(t, Verify([]byte("short"), nil, nil))
+}
+
+// TestEqual 测试常数时间比较。
+func TestEqual(t *testing.T) {
+	assert.True(t, Equal([]byte("abc"), []byte("abc")))
+	assert.False(t, Equal([]byte("abc"), []byte("abd")))
+	assert.False(t, Equal([]byte("abc"), []byte("ab")))
+	assert.True(t, EqualString("", ""))
+	assert.False(t, EqualString("token", "Token"))
+
+	assert.Len(t, RandomBytes(7), 7)
+	assert.NotEqual(t, GenerateKey(), GenerateKey())
+}
+
+// TestPassword 测试密码哈希与校验。
+func TestPassword(t *testing.T) {
+	fast := []Option{WithMemory(64), WithIterations(1), WithParallelism(1)}
+
+	encoded := HashPassword("correct horse", fast...)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$"), encoded)
+	assert.NotEqual(t, encoded, HashPassword("correct horse", fast...), "每次使用新的盐")
+
+	assert.NoError(t, VerifyPassword(encoded, "correct horse"))
+	assert.ErrorIs(t, VerifyPassword(encoded, "wrong horse"), ErrPasswordMismatch)
+
+	assert.False(t, NeedsRehash(encoded, fast...))
+	assert.True(t, NeedsRehash(encoded, WithMemory(128), WithIterations(1), WithParallelism(1)))
+	assert.True(t, NeedsRehash(encoded))
+	assert.True(t, NeedsRehash("plain"))
+}
+
+// TestPassword_InvalidHash 测试格式错误的密码哈希。
+func TestPassword_InvalidHash(t *testing.T) {
+	for _, encoded := range []string{
+		"",
+		"$2a$10$bcrypt",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=64,t=0,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=x,t=1,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=64,t=1,p=1$!!$aGFzaA",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA$",
+		"$argon2id$v=19$m=4294967295,t=1,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=64,t=4294967295,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=64,t=1,p=1$" + strings.Repeat("A", 88) + "$aGFzaA",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA$" + strings.Repeat("A", 88),
+	} {
+		assert.ErrorIs(t, VerifyPassword(encoded, "x"), ErrInvalidHash, encoded)
+	}
+}
+
+// TestNewOptions 测试非法或超出上限的参数使用默认值。
+func TestNewOptions(t *testing.T) {
+	o := newOptions(WithMemory(1), WithIterations(0), WithParallelism(0))
+	assert.Equal(t, options{memory: memoryDefault, iterations: iterationsDefault, parallelism: parallelismDefault}, *o)
+
+	o = newOptions(WithMemory(memoryMax+1), WithIterations(iterationsMax+1), WithParallelism(1))
+	assert.Equal(t, options{memory: memoryDefault, iterations: iterationsDefault, parallelism: 1}, *o)
+}
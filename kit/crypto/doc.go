@@ -0,0 +1,39 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package crypto 提供了默认安全的加密工具，调用方不需要选择算法、模式与参数。
+
+主要功能：
+
+  - 对称加密：Keyring 使用 AES-256-GCM 加密，密文头带版本号与密钥标识，支持不停机的密钥轮换
+  - 消息签名：Sign、Verify 使用 HMAC-SHA256，校验以常数时间比较
+  - 常数时间比较：Equal、EqualString 用于比较令牌、API 密钥等秘密值
+  - 密码哈希：HashPassword、VerifyPassword 使用 argon2id，哈希为 PHC 字符串格式，NeedsRehash 支持参数升级
+  - 随机数：RandomBytes、GenerateKey 基于 crypto/rand
+
+API 的设计避免常见的误用：随机数由 Encrypt 生成而不是由调用方传入，密钥长度不足时返回错误而不是静默补齐，
+解密失败时不区分原因，密码哈希中记录全部参数。
+
+基本使用：
+
+	keyring, err := crypto.NewKeyring(crypto.Key{ID: 1, Secret: key})
+	if nil != err {
+	    return err
+	}
+	sealed := keyring.Encrypt([]byte(user.Phone), []byte(user.ID))
+	phone, err := keyring.Decrypt(sealed, []byte(user.ID))
+
+	user.PasswordHash = crypto.HashPassword(password)
+	err = crypto.VerifyPassword(user.PasswordHash, password)
+
+由于包名与标准库 crypto 相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "crypto"
+
+	    kitcrypto "github.com/fsyyft-go/monorepo/kit/crypto"
+	)
+*/
+package crypto
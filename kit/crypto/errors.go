@@ -0,0 +1,23 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package crypto
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidKey 表示密钥的长度不符合要求或密钥标识重复。
+	ErrInvalidKey = errors.New("kit/crypto: 密钥不合法")
+	// ErrUnknownKey 表示密文使用的密钥不在密钥环中，通常是轮换时过早移除了旧密钥。
+	ErrUnknownKey = errors.New("kit/crypto: 密文使用的密钥不存在")
+	// ErrInvalidCiphertext 表示密文格式错误、版本不受支持，或者密文、附加数据被篡改。
+	// 出于安全考虑不区分具体原因。
+	ErrInvalidCiphertext = errors.New("kit/crypto: 密文不合法")
+	// ErrInvalidHash 表示密码哈希的格式错误。
+	ErrInvalidHash = errors.New("kit/crypto: 密码哈希格式错误")
+	// ErrPasswordMismatch 表示密码与哈希不匹配。
+	ErrPasswordMismatch = errors.New("kit/crypto: 密码不匹配")
+)
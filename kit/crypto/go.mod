@@ -0,0 +1,15 @@
+module github.com/fsyyft-go/monorepo/kit/crypto
+
+go 1.25
+
+require (
+	github.com/stretchr/testify v1.11.1
+	golang.org/x/crypto v0.41.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
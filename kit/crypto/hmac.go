@@ -0,0 +1,54 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Sign 使用 HMAC-SHA256 计算消息的签名，用于回调、Webhook、签名 URL 等场景中验证消息的来源与完整性。
+//
+// 参数：
+//   - key：签名密钥，长度不能小于 KeySize，可以通过 GenerateKey 生成。
+//   - message：要签名的消息。
+//
+// 返回值：
+//   - []byte：32 字节的签名。
+//   - error：密钥过短时返回包装 ErrInvalidKey 的错误。
+//
+// 示例：
+//
+//	sig, err := crypto.Sign(secret, body)
+//	if nil != err {
+//	    return err
+//	}
+//	req.Header.Set("X-Signature", hex.EncodeToString(sig))
+func Sign(key, message []byte) ([]byte, error) {
+	if len(key) < KeySize {
+		return nil, fmt.Errorf("%w：HMAC 密钥长度为 %d 字节，不能小于 %d 字节", ErrInvalidKey, len(key), KeySize)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message)
+	return mac.Sum(nil), nil
+}
+
+// Verify 以常数时间校验消息的 HMAC-SHA256 签名。
+//
+// 参数：
+//   - key：签名密钥。
+//   - message：收到的消息。
+//   - signature：收到的签名。
+//
+// 返回值：
+//   - bool：签名正确时返回 true；密钥过短时总是返回 false。
+func Verify(key, message, signature []byte) bool {
+	expected, err := Sign(key, message)
+	if nil != err {
+		return false
+	}
+	return hmac.Equal(expected, signature)
+}
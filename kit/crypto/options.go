@@ -0,0 +1,109 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package crypto
+
+// 以下为密码哈希的默认参数配置，取自 RFC 9106 推荐的第二种参数。
+// 可通过 Option 机制覆盖。
+var (
+	// memoryDefault 为 argon2id 使用的内存，单位为 KiB。
+	memoryDefault uint32 = 64 * 1024
+	// iterationsDefault 为 argon2id 的迭代次数。
+	iterationsDefault uint32 = 3
+	// parallelismDefault 为 argon2id 的并行度。
+	parallelismDefault uint8 = 4
+	// saltSizeDefault 为盐的长度，单位为字节。
+	saltSizeDefault = 16
+	// hashSizeDefault 为哈希的长度，单位为字节。
+	hashSizeDefault uint32 = 32
+)
+
+// 以下为密码哈希参数的上限，VerifyPassword 拒绝超出上限的哈希，避免被篡改的哈希耗尽内存或 CPU。
+const (
+	// memoryMax 为 argon2id 使用的内存上限，单位为 KiB，即 RFC 9106 第一种推荐参数使用的 2 GiB。
+	memoryMax uint32 = 2 * 1024 * 1024
+	// iterationsMax 为 argon2id 的迭代次数上限。
+	iterationsMax uint32 = 32
+	// saltSizeMax 为盐的长度上限，单位为字节。
+	saltSizeMax = 64
+	// hashSizeMax 为哈希的长度上限，单位为字节。
+	hashSizeMax = 64
+)
+
+type (
+	// Option 定义了密码哈希的配置选项。
+	Option func(*options)
+
+	// options 包含密码哈希的配置。
+	options struct {
+		// memory 是使用的内存，单位为 KiB。
+		memory uint32
+		// iterations 是迭代次数。
+		iterations uint32
+		// parallelism 是并行度。
+		parallelism uint8
+	}
+)
+
+// WithMemory 设置 argon2id 使用的内存。
+//
+// 参数：
+//   - kib：内存大小，单位为 KiB，默认为 65536（64 MiB），小于 8 倍并行度或大于 2097152（2 GiB）时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMemory(kib uint32) Option {
+	return func(o *options) {
+		o.memory = kib
+	}
+}
+
+// WithIterations 设置 argon2id 的迭代次数。
+//
+// 参数：
+//   - iterations：迭代次数，默认为 3，为 0 或大于 32 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithIterations(iterations uint32) Option {
+	return func(o *options) {
+		o.iterations = iterations
+	}
+}
+
+// WithParallelism 设置 argon2id 的并行度。
+//
+// 参数：
+//   - parallelism：并行度，默认为 4，为 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithParallelism(parallelism uint8) Option {
+	return func(o *options) {
+		o.parallelism = parallelism
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		memory:      memoryDefault,
+		iterations:  iterationsDefault,
+		parallelism: parallelismDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if 0 == o.iterations || o.iterations > iterationsMax {
+		o.iterations = iterationsDefault
+	}
+	if 0 == o.parallelism {
+		o.parallelism = parallelismDefault
+	}
+	if o.memory < 8*uint32(o.parallelism) || o.memory > memoryMax {
+		o.memory = memoryDefault
+	}
+	return o
+}
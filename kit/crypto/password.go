@@ -0,0 +1,133 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package crypto
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// hashPrefix 是 argon2id 哈希的 PHC 字符串前缀。
+	hashPrefix = "$argon2id$"
+)
+
+type (
+	// passwordHash 是解析后的密码哈希。
+	passwordHash struct {
+		// params 是计算哈希使用的参数。
+		params options
+		// salt 是盐。
+		salt []byte
+		// hash 是哈希值。
+		hash []byte
+	}
+)
+
+// HashPassword 使用 argon2id 计算密码的哈希，每次使用新的随机盐。
+// 结果为 PHC 字符串格式，包含算法版本与参数，例如 $argon2id$v=19$m=65536,t=3,p=4$<盐>$<哈希>，
+// 调整参数后旧的哈希仍然可以校验，可以通过 NeedsRehash 判断是否需要重新计算。
+//
+// 参数：
+//   - password：密码。
+//   - opts：配置选项，参见 WithMemory、WithIterations 与 WithParallelism。
+//
+// 返回值：
+//   - string：密码哈希，可以直接存入数据库。
+//
+// 示例：
+//
+//	user.PasswordHash = crypto.HashPassword(form.Password)
+func HashPassword(password string, opts ...Option) string {
+	o := newOptions(opts...)
+	salt := RandomBytes(saltSizeDefault)
+	hash := argon2.IDKey([]byte(password), salt, o.iterations, o.memory, o.parallelism, hashSizeDefault)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", hashPrefix, argon2.Version, o.memory, o.iterations, o.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(hash))
+}
+
+// VerifyPassword 以常数时间校验密码与哈希是否匹配，使用哈希中记录的参数计算。
+//
+// 参数：
+//   - encoded：HashPassword 返回的密码哈希。
+//   - password：要校验的密码。
+//
+// 返回值：
+//   - error：匹配时返回 nil；不匹配时返回 ErrPasswordMismatch；哈希格式错误或参数超出上限时返回包装 ErrInvalidHash 的错误。
+//
+// 示例：
+//
+//	if err := crypto.VerifyPassword(user.PasswordHash, form.Password); nil != err {
+//	    return ErrLoginFailed
+//	}
+func VerifyPassword(encoded, password string) error {
+	h, err := parseHash(encoded)
+	if nil != err {
+		return err
+	}
+	hash := argon2.IDKey([]byte(password), h.salt, h.params.iterations, h.params.memory, h.params.parallelism, uint32(len(h.hash)))
+	if 1 != subtle.ConstantTimeCompare(hash, h.hash) {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// NeedsRehash 返回密码哈希的参数是否与当前配置不同，用于在登录成功后以新参数重新计算哈希。
+//
+// 参数：
+//   - encoded：密码哈希。
+//   - opts：当前的配置选项。
+//
+// 返回值：
+//   - bool：参数不同或者哈希格式错误时返回 true。
+//
+// 示例：
+//
+//	if nil == crypto.VerifyPassword(user.PasswordHash, form.Password) && crypto.NeedsRehash(user.PasswordHash) {
+//	    user.PasswordHash = crypto.HashPassword(form.Password)
+//	}
+func NeedsRehash(encoded string, opts ...Option) bool {
+	h, err := parseHash(encoded)
+	if nil != err {
+		return true
+	}
+	return *newOptions(opts...) != h.params || saltSizeDefault != len(h.salt) || hashSizeDefault != uint32(len(h.hash))
+}
+
+// parseHash 解析 PHC 字符串格式的密码哈希，参数、盐与哈希值的长度超出上限时视为格式错误。
+func parseHash(encoded string) (*passwordHash, error) {
+	if !strings.HasPrefix(encoded, hashPrefix) {
+		return nil, fmt.Errorf("%w：不是 argon2id 哈希", ErrInvalidHash)
+	}
+	parts := strings.Split(encoded[len(hashPrefix):], "$")
+	if 4 != len(parts) {
+		return nil, fmt.Errorf("%w：字段数量不正确", ErrInvalidHash)
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &version); nil != err || argon2.Version != version {
+		return nil, fmt.Errorf("%w：不支持的版本 %q", ErrInvalidHash, parts[0])
+	}
+	h := &passwordHash{}
+	if _, err := fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &h.params.memory, &h.params.iterations, &h.params.parallelism); nil != err ||
+		0 == h.params.iterations || 0 == h.params.parallelism ||
+		h.params.memory > memoryMax || h.params.iterations > iterationsMax {
+		return nil, fmt.Errorf("%w：参数 %q 不合法", ErrInvalidHash, parts[1])
+	}
+
+	var err error
+	if h.salt, err = base64.RawStdEncoding.DecodeString(parts[2]); nil != err || 0 == len(h.salt) || len(h.salt) > saltSizeMax {
+		return nil, fmt.Errorf("%w：盐不合法", ErrInvalidHash)
+	}
+	if h.hash, err = base64.RawStdEncoding.DecodeString(parts[3]); nil != err || 0 == len(h.hash) || len(h.hash) > hashSizeMax {
+		return nil, fmt.Errorf("%w：哈希值不合法", ErrInvalidHash)
+	}
+	return h, nil
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package crypto
+
+import (
+	"crypto/rand"
+)
+
+const (
+	// KeySize 是 GenerateKey 生成的密钥长度，也是 AES-256-GCM 密钥与 HMAC 密钥要求的长度。
+	KeySize = 32
+)
+
+// RandomBytes 返回 n 个密码学安全的随机字节。
+//
+// 参数：
+//   - n：字节数。
+//
+// 返回值：
+//   - []byte：随机字节。
+func RandomBytes(n int) []byte {
+	b := make([]byte, n)
+	// crypto/rand.Read 不会返回错误，无法读取系统随机源时直接终止进程。
+	_, _ = rand.Read(b)
+	return b
+}
+
+// GenerateKey 生成 KeySize 字节的随机密钥，可用于 Keyring 与 Sign。
+//
+// 返回值：
+//   - []byte：随机密钥。
+func GenerateKey() []byte {
+	return RandomBytes(KeySize)
+}
@@ -0,0 +1,362 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	kiterrors "github.com/fsyyft-go/monorepo/kit/errors"
+)
+
+var (
+	// ErrInvalidTask 表示任务的名称为空或任务函数为 nil。
+	ErrInvalidTask = errors.New("kit/dag: 无效的任务")
+	// ErrDuplicateTask 表示任务的名称重复。
+	ErrDuplicateTask = errors.New("kit/dag: 任务名称重复")
+	// ErrUnknownDependency 表示任务依赖的任务不存在。
+	ErrUnknownDependency = errors.New("kit/dag: 依赖的任务不存在")
+	// ErrCycle 表示任务之间存在循环依赖。
+	ErrCycle = errors.New("kit/dag: 存在循环依赖")
+	// ErrSkipped 表示任务因依赖的任务未成功或其他任务失败而被跳过。
+	ErrSkipped = errors.New("kit/dag: 任务被跳过")
+)
+
+type (
+	// TaskFunc 定义了任务函数。
+	//
+	// 参数：
+	//   - ctx：上下文，在任务超时、FailFast 策略下其他任务失败或 Run 的上下文被取消时取消。
+	//
+	// 返回值：
+	//   - error：任务失败时返回错误。
+	TaskFunc func(ctx context.Context) error
+
+	// task 是声明的任务。
+	task struct {
+		// name 是任务的名称。
+		name string
+		// fn 是任务函数。
+		fn TaskFunc
+		// deps 是被依赖的任务名称。
+		deps []string
+		// timeout 是任务的超时时间，小于 0 时使用任务图的设置。
+		timeout time.Duration
+	}
+
+	// Graph 是由任务及其依赖关系组成的有向无环图。
+	// 声明任务的 Add 不是并发安全的，应在执行前完成；声明完成后 Run 可以并发、多次调用。
+	Graph struct {
+		// opts 是任务图的配置。
+		opts *options
+		// tasks 是声明的任务，按声明的顺序排列。
+		tasks []*task
+		// index 是任务名称到 tasks 下标的映射。
+		index map[string]int
+	}
+
+	// execution 是一次执行的状态，只在 Run 的协程中访问。
+	execution struct {
+		// g 是执行的任务图。
+		g *Graph
+		// ctx 是任务的根上下文，FailFast 策略下任务失败时取消。
+		ctx context.Context
+		// cancel 取消 ctx。
+		cancel context.CancelFunc
+		// parent 是 Run 传入的上下文。
+		parent context.Context
+		// results 是各任务的执行结果。
+		results []Result
+		// children 是依赖于各任务的任务下标。
+		children [][]int
+		// waiting 是各任务尚未完成的依赖数量。
+		waiting []int
+		// ready 是依赖已经全部完成、等待执行的任务下标。
+		ready []int
+		// done 接收执行完成的任务下标。
+		done chan int
+		// running 是正在执行的任务数量。
+		running int
+		// finished 是已经完成的任务数量，包括被跳过的任务。
+		finished int
+		// failed 表示是否有任务失败。
+		failed bool
+	}
+)
+
+// New 创建一个空的任务图。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Graph：任务图实例。
+//
+// 示例：
+//
+//	g := dag.New(dag.WithTimeout(time.Minute))
+//	_ = g.Add("db", connectDB)
+//	_ = g.Add("cache", connectCache)
+//	_ = g.Add("migrate", migrate, dag.DependsOn("db"))
+//	_ = g.Add("server", startServer, dag.DependsOn("migrate", "cache"))
+//
+//	report, err := g.Run(ctx)
+func New(opts ...Option) *Graph {
+	return &Graph{
+		opts:  newOptions(opts...),
+		index: make(map[string]int),
+	}
+}
+
+// Add 声明一个任务。
+//
+// 参数：
+//   - name：任务的名称，在任务图中唯一。
+//   - fn：任务函数。
+//   - opts：任务配置选项，支持 DependsOn 与 WithTaskTimeout。
+//
+// 返回值：
+//   - error：名称为空或 fn 为 nil 时返回 ErrInvalidTask，名称重复时返回 ErrDuplicateTask。
+func (g *Graph) Add(name string, fn TaskFunc, opts ...TaskOption) error {
+	if "" == name || nil == fn {
+		return fmt.Errorf("%w：%q", ErrInvalidTask, name)
+	}
+	if _, ok := g.index[name]; ok {
+		return fmt.Errorf("%w：%s", ErrDuplicateTask, name)
+	}
+
+	t := &task{name: name, fn: fn, timeout: -1}
+	for _, opt := range opts {
+		opt(t)
+	}
+	g.index[name] = len(g.tasks)
+	g.tasks = append(g.tasks, t)
+	return nil
+}
+
+// Validate 检查依赖的任务是否都存在，以及是否存在循环依赖。Run 在执行前会进行同样的检查。
+//
+// 返回值：
+//   - error：依赖的任务不存在时返回 ErrUnknownDependency，存在循环依赖时返回包含循环路径的 ErrCycle。
+func (g *Graph) Validate() error {
+	for _, t := range g.tasks {
+		for _, dep := range t.deps {
+			if _, ok := g.index[dep]; !ok {
+				return fmt.Errorf("%w：任务 %s 依赖的 %s", ErrUnknownDependency, t.name, dep)
+			}
+		}
+	}
+
+	// 深度优先搜索，state 为 0 表示未访问，1 表示在当前路径上，2 表示已经完成。
+	state := make([]int, len(g.tasks))
+	var path []string
+	var visit func(i int) error
+	visit = func(i int) error {
+		state[i] = 1
+		path = append(path, g.tasks[i].name)
+		for _, dep := range g.tasks[i].deps {
+			j := g.index[dep]
+			switch state[j] {
+			case 0:
+				if err := visit(j); nil != err {
+					return err
+				}
+			case 1:
+				// 从路径中第一次出现 dep 的位置截取出循环。
+				start := 0
+				for k, name := range path {
+					if dep == name {
+						start = k
+						break
+					}
+				}
+				cycle := append(append([]string{}, path[start:]...), dep)
+				return fmt.Errorf("%w：%s", ErrCycle, strings.Join(cycle, " -> "))
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = 2
+		return nil
+	}
+	for i := range g.tasks {
+		if 0 == state[i] {
+			if err := visit(i); nil != err {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Run 并发执行任务图，每个任务在依赖的任务全部成功后开始执行。
+// 任务失败时按 WithFailurePolicy 设置的策略处理；ctx 被取消时，正在执行的任务的上下文被取消，尚未开始的任务被跳过。
+// Run 在所有开始执行的任务返回后才返回，任务应当响应上下文的取消。
+//
+// 参数：
+//   - ctx：上下文，用于取消执行。
+//
+// 返回值：
+//   - *Report：执行结果报告，任务图无效时为 nil。
+//   - error：任务图无效时返回 Validate 的错误；有任务失败时返回包含各任务错误的 *kiterrors.MultiError，
+//     ctx 被取消时其中包含 ctx 的错误；所有任务都成功时返回 nil。
+func (g *Graph) Run(ctx context.Context) (*Report, error) {
+	if err := g.Validate(); nil != err {
+		return nil, err
+	}
+
+	start := time.Now()
+	e := g.newExecution(ctx)
+	defer e.cancel()
+
+	for e.finished < len(g.tasks) {
+		e.schedule()
+		if e.finished == len(g.tasks) {
+			break
+		}
+		i := <-e.done
+		e.running--
+		e.finish(i)
+	}
+
+	report := &Report{Results: e.results, Duration: time.Since(start)}
+
+	var errs kiterrors.MultiError
+	for _, result := range e.results {
+		if StatusFailed == result.Status {
+			errs.Append(fmt.Errorf("kit/dag: 任务 %s 失败：%w", result.Name, result.Err))
+		}
+	}
+	if err := ctx.Err(); nil != err && !report.Succeeded() {
+		errs.Append(err)
+	}
+	return report, errs.ErrorOrNil()
+}
+
+// newExecution 创建一次执行的状态。
+func (g *Graph) newExecution(ctx context.Context) *execution {
+	n := len(g.tasks)
+	e := &execution{
+		g:        g,
+		parent:   ctx,
+		results:  make([]Result, n),
+		children: make([][]int, n),
+		waiting:  make([]int, n),
+		// 容量足以容纳所有任务，任务协程发送时不会阻塞。
+		done: make(chan int, n),
+	}
+	e.ctx, e.cancel = context.WithCancel(ctx)
+
+	for i, t := range g.tasks {
+		e.results[i].Name = t.name
+		e.waiting[i] = len(t.deps)
+		for _, dep := range t.deps {
+			j := g.index[dep]
+			e.children[j] = append(e.children[j], i)
+		}
+		if 0 == len(t.deps) {
+			e.ready = append(e.ready, i)
+		}
+	}
+	return e
+}
+
+// schedule 启动等待执行的任务，直到没有等待的任务或达到并发上限；应被跳过的任务直接完成。
+func (e *execution) schedule() {
+	concurrency := e.g.opts.concurrency
+	for 0 < len(e.ready) {
+		i := e.ready[0]
+		if err := e.skipReason(i); nil != err {
+			e.ready = e.ready[1:]
+			e.results[i].Status = StatusSkipped
+			e.results[i].Err = err
+			e.finish(i)
+			continue
+		}
+		if 0 < concurrency && e.running >= concurrency {
+			return
+		}
+		e.ready = e.ready[1:]
+		e.start(i)
+	}
+}
+
+// skipReason 返回任务应被跳过的原因，应当执行时返回 nil。
+func (e *execution) skipReason(i int) error {
+	if err := e.parent.Err(); nil != err {
+		return err
+	}
+	if e.failed && FailFast == e.g.opts.failurePolicy {
+		return fmt.Errorf("%w：其他任务失败", ErrSkipped)
+	}
+	for _, dep := range e.g.tasks[i].deps {
+		if StatusSucceeded != e.results[e.g.index[dep]].Status {
+			return fmt.Errorf("%w：依赖的任务 %s 未成功", ErrSkipped, dep)
+		}
+	}
+	return nil
+}
+
+// start 在协程池中执行任务，提交失败时任务失败。
+func (e *execution) start(i int) {
+	t := e.g.tasks[i]
+	timeout := t.timeout
+	if timeout < 0 {
+		timeout = e.g.opts.timeout
+	}
+
+	e.running++
+	result := &e.results[i]
+	err := e.g.opts.submit(func() {
+		ctx, cancel := e.ctx, context.CancelFunc(func() {})
+		if timeout > 0 {
+			ctx, cancel = context.WithTimeout(e.ctx, timeout)
+		}
+		defer cancel()
+
+		result.Start = time.Now()
+		result.Err = call(ctx, t.fn)
+		result.Duration = time.Since(result.Start)
+		if nil != result.Err {
+			result.Status = StatusFailed
+		}
+		e.done <- i
+	})
+	if nil != err {
+		result.Status = StatusFailed
+		result.Err = fmt.Errorf("kit/dag: 启动任务失败：%w", err)
+		e.running--
+		e.finish(i)
+	}
+}
+
+// finish 记录任务完成，并将依赖已经全部完成的任务加入等待执行的队列。
+func (e *execution) finish(i int) {
+	e.finished++
+	if StatusFailed == e.results[i].Status {
+		e.failed = true
+		if FailFast == e.g.opts.failurePolicy {
+			e.cancel()
+		}
+	}
+	for _, child := range e.children[i] {
+		e.waiting[child]--
+		if 0 == e.waiting[child] {
+			e.ready = append(e.ready, child)
+		}
+	}
+}
+
+// call 调用任务函数，将 panic 转换为错误。
+func call(ctx context.Context, fn TaskFunc) (err error) {
+	defer func() {
+		if r := recover(); nil != r {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,351 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dag
+
+import (
+	"context"
+	"errors"
+	stdsync "sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+var (
+	// errBoom 是测试使用的任务错误。
+	errBoom = errors.New("boom")
+)
+
+type (
+	// tracer 记录任务的执行顺序。
+	tracer struct {
+		// mu 保护 order。
+		mu stdsync.Mutex
+		// order 是任务开始执行的顺序。
+		order []string
+	}
+)
+
+// task 返回记录执行顺序后返回 err 的任务函数。
+func (tr *tracer) task(name string, err error) TaskFunc {
+	return func(context.Context) error {
+		tr.mu.Lock()
+		tr.order = append(tr.order, name)
+		tr.mu.Unlock()
+		return err
+	}
+}
+
+// index 返回任务在执行顺序中的位置，未执行时返回 -1。
+func (tr *tracer) index(name string) int {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	for i, n := range tr.order {
+		if name == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// statuses 返回报告中各任务的状态。
+func statuses(r *Report) map[string]Status {
+	m := make(map[string]Status)
+	for _, result := range r.Results {
+		m[result.Name] = result.Status
+	}
+	return m
+}
+
+// TestGraph_Run 测试任务按依赖关系执行。
+func TestGraph_Run(t *testing.T) {
+	tr := &tracer{}
+	g := New()
+	require.NoError(t, g.Add("d", tr.task("d", nil), DependsOn("b", "c")))
+	require.NoError(t, g.Add("b", tr.task("b", nil), DependsOn("a")))
+	require.NoError(t, g.Add("c", tr.task("c", nil), DependsOn("a")))
+	require.NoError(t, g.Add("a", tr.task("a", nil)))
+	require.NoError(t, g.Add("e", tr.task("e", nil)))
+
+	report, err := g.Run(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.Succeeded())
+	assert.Less(t, tr.index("a"), tr.index("b"))
+	assert.Less(t, tr.index("a"), tr.index("c"))
+	assert.Less(t, tr.index("b"), tr.index("d"))
+	assert.Less(t, tr.index("c"), tr.index("d"))
+	assert.NotEqual(t, -1, tr.index("e"))
+
+	// 结果按声明的顺序排列。
+	names := make([]string, 0, len(report.Results))
+	for _, result := range report.Results {
+		names = append(names, result.Name)
+		assert.False(t, result.Start.IsZero())
+	}
+	assert.Equal(t, []string{"d", "b", "c", "a", "e"}, names)
+
+	result, ok := report.Result("a")
+	assert.True(t, ok)
+	assert.Equal(t, StatusSucceeded, result.Status)
+	_, ok = report.Result("missing")
+	assert.False(t, ok)
+
+	// 任务图可以重复执行。
+	report, err = New().Run(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, report.Results)
+}
+
+// TestGraph_Concurrency 测试没有依赖关系的任务并发执行，并受并发上限的限制。
+func TestGraph_Concurrency(t *testing.T) {
+	// 两个任务互相等待，只有并发执行才能完成。
+	var wg stdsync.WaitGroup
+	wg.Add(2)
+	barrier := func(context.Context) error {
+		wg.Done()
+		wg.Wait()
+		return nil
+	}
+	g := New()
+	require.NoError(t, g.Add("a", barrier))
+	require.NoError(t, g.Add("b", barrier))
+	_, err := g.Run(context.Background())
+	require.NoError(t, err)
+
+	var running, peak atomic.Int32
+	limited := func(context.Context) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	}
+	g = New(WithConcurrency(2))
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		require.NoError(t, g.Add(name, limited))
+	}
+	_, err = g.Run(context.Background())
+	require.NoError(t, err)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+// TestGraph_FailFast 测试 FailFast 策略下任务失败时取消其他任务。
+func TestGraph_FailFast(t *testing.T) {
+	started := make(chan struct{})
+	tr := &tracer{}
+	g := New()
+	require.NoError(t, g.Add("slow", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	require.NoError(t, g.Add("bad", func(context.Context) error {
+		<-started
+		return errBoom
+	}))
+	require.NoError(t, g.Add("after", tr.task("after", nil), DependsOn("bad")))
+
+	report, err := g.Run(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errBoom)
+	assert.ErrorContains(t, err, "kit/dag: 任务 bad 失败：boom")
+	assert.Equal(t, map[string]Status{
+		"slow":  StatusFailed,
+		"bad":   StatusFailed,
+		"after": StatusSkipped,
+	}, statuses(report))
+	result, _ := report.Result("slow")
+	assert.ErrorIs(t, result.Err, context.Canceled)
+	assert.Equal(t, -1, tr.index("after"))
+}
+
+// TestGraph_Continue 测试 Continue 策略下只跳过依赖于失败任务的任务。
+func TestGraph_Continue(t *testing.T) {
+	tr := &tracer{}
+	g := New(WithFailurePolicy(Continue), WithConcurrency(1))
+	require.NoError(t, g.Add("bad", tr.task("bad", errBoom)))
+	require.NoError(t, g.Add("ok", tr.task("ok", nil)))
+	require.NoError(t, g.Add("child", tr.task("child", nil), DependsOn("bad", "ok")))
+	require.NoError(t, g.Add("grandchild", tr.task("grandchild", nil), DependsOn("child")))
+	require.NoError(t, g.Add("sibling", tr.task("sibling", nil), DependsOn("ok")))
+
+	report, err := g.Run(context.Background())
+	assert.ErrorIs(t, err, errBoom)
+	assert.Equal(t, map[string]Status{
+		"bad":        StatusFailed,
+		"ok":         StatusSucceeded,
+		"child":      StatusSkipped,
+		"grandchild": StatusSkipped,
+		"sibling":    StatusSucceeded,
+	}, statuses(report))
+
+	result, _ := report.Result("child")
+	assert.ErrorIs(t, result.Err, ErrSkipped)
+	assert.EqualError(t, result.Err, "kit/dag: 任务被跳过：依赖的任务 bad 未成功")
+	result, _ = report.Result("grandchild")
+	assert.EqualError(t, result.Err, "kit/dag: 任务被跳过：依赖的任务 child 未成功")
+	assert.Len(t, report.Filter(StatusSkipped), 2)
+}
+
+// TestGraph_FailFastSkip 测试 FailFast 策略下任务失败后尚未开始的任务被跳过。
+func TestGraph_FailFastSkip(t *testing.T) {
+	tr := &tracer{}
+	g := New(WithConcurrency(1))
+	require.NoError(t, g.Add("bad", tr.task("bad", errBoom)))
+	require.NoError(t, g.Add("ok", tr.task("ok", nil)))
+
+	report, err := g.Run(context.Background())
+	assert.ErrorIs(t, err, errBoom)
+	result, _ := report.Result("ok")
+	assert.Equal(t, StatusSkipped, result.Status)
+	assert.EqualError(t, result.Err, "kit/dag: 任务被跳过：其他任务失败")
+}
+
+// TestGraph_Timeout 测试任务的超时时间。
+func TestGraph_Timeout(t *testing.T) {
+	wait := func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return nil
+		}
+	}
+	g := New(WithTimeout(10*time.Millisecond), WithFailurePolicy(Continue))
+	require.NoError(t, g.Add("timeout", wait))
+	require.NoError(t, g.Add("unlimited", wait, WithTaskTimeout(0)))
+
+	report, err := g.Run(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, map[string]Status{
+		"timeout":   StatusFailed,
+		"unlimited": StatusSucceeded,
+	}, statuses(report))
+}
+
+// TestGraph_Cancel 测试 Run 的上下文被取消。
+func TestGraph_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tr := &tracer{}
+	g := New()
+	require.NoError(t, g.Add("first", func(ctx context.Context) error {
+		cancel()
+		return nil
+	}))
+	require.NoError(t, g.Add("second", tr.task("second", nil), DependsOn("first")))
+
+	report, err := g.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	result, _ := report.Result("second")
+	assert.Equal(t, StatusSkipped, result.Status)
+	assert.ErrorIs(t, result.Err, context.Canceled)
+	assert.Equal(t, -1, tr.index("second"))
+}
+
+// TestGraph_Panic 测试任务 panic 时转换为错误。
+func TestGraph_Panic(t *testing.T) {
+	g := New()
+	require.NoError(t, g.Add("panic", func(context.Context) error {
+		panic("oops")
+	}))
+
+	report, err := g.Run(context.Background())
+	assert.EqualError(t, err, "kit/dag: 任务 panic 失败：panic: oops")
+	assert.Len(t, report.Filter(StatusFailed), 1)
+}
+
+// TestGraph_Pool 测试提交到协程池失败时任务失败。
+func TestGraph_Pool(t *testing.T) {
+	pool, release, err := goroutine.NewGoroutinePool(goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	release()
+
+	g := New(WithPool(pool))
+	require.NoError(t, g.Add("a", func(context.Context) error { return nil }))
+	require.NoError(t, g.Add("b", func(context.Context) error { return nil }, DependsOn("a")))
+
+	report, err := g.Run(context.Background())
+	assert.ErrorContains(t, err, "kit/dag: 任务 a 失败：kit/dag: 启动任务失败：")
+	assert.Equal(t, map[string]Status{
+		"a": StatusFailed,
+		"b": StatusSkipped,
+	}, statuses(report))
+}
+
+// TestGraph_Add 测试声明无效的任务。
+func TestGraph_Add(t *testing.T) {
+	fn := func(context.Context) error { return nil }
+	g := New()
+	assert.ErrorIs(t, g.Add("", fn), ErrInvalidTask)
+	assert.ErrorIs(t, g.Add("a", nil), ErrInvalidTask)
+	require.NoError(t, g.Add("a", fn))
+	assert.EqualError(t, g.Add("a", fn), "kit/dag: 任务名称重复：a")
+}
+
+// TestGraph_Validate 测试检查依赖关系。
+func TestGraph_Validate(t *testing.T) {
+	fn := func(context.Context) error { return nil }
+
+	g := New()
+	require.NoError(t, g.Add("a", fn, DependsOn("missing")))
+	assert.EqualError(t, g.Validate(), "kit/dag: 依赖的任务不存在：任务 a 依赖的 missing")
+	report, err := g.Run(context.Background())
+	assert.ErrorIs(t, err, ErrUnknownDependency)
+	assert.Nil(t, report)
+
+	g = New()
+	require.NoError(t, g.Add("root", fn))
+	require.NoError(t, g.Add("a", fn, DependsOn("root", "c")))
+	require.NoError(t, g.Add("b", fn, DependsOn("a")))
+	require.NoError(t, g.Add("c", fn, DependsOn("b")))
+	assert.EqualError(t, g.Validate(), "kit/dag: 存在循环依赖：a -> c -> b -> a")
+
+	g = New()
+	require.NoError(t, g.Add("self", fn, DependsOn("self")))
+	assert.ErrorIs(t, g.Validate(), ErrCycle)
+	assert.EqualError(t, g.Validate(), "kit/dag: 存在循环依赖：self -> self")
+}
+
+// TestReport_String 测试报告的文本形式。
+func TestReport_String(t *testing.T) {
+	r := &Report{Results: []Result{
+		{Name: "db", Status: StatusSucceeded, Duration: 12 * time.Millisecond},
+		{Name: "migrate", Status: StatusFailed, Err: errBoom, Duration: 3 * time.Millisecond},
+		{Name: "seed", Status: StatusSkipped, Err: errors.New("skipped")},
+	}}
+	assert.Equal(t, "db      succeeded 12ms\n"+
+		"migrate failed    3ms boom\n"+
+		"seed    skipped    skipped\n", r.String())
+	assert.False(t, r.Succeeded())
+
+	assert.Equal(t, "unknown", Status(-1).String())
+	assert.Equal(t, "fail-fast", FailFast.String())
+	assert.Equal(t, "continue", Continue.String())
+	assert.Equal(t, "unknown", FailurePolicy(-1).String())
+}
+
+// TestNewOptions 测试配置选项的默认值与非法参数。
+func TestNewOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, failurePolicyDefault, o.failurePolicy)
+	assert.Equal(t, timeoutDefault, o.timeout)
+	assert.Equal(t, concurrencyDefault, o.concurrency)
+	assert.Nil(t, o.pool)
+
+	o = newOptions(WithFailurePolicy(FailurePolicy(9)), WithTimeout(-1), WithConcurrency(-1))
+	assert.Equal(t, failurePolicyDefault, o.failurePolicy)
+	assert.Equal(t, timeoutDefault, o.timeout)
+	assert.Equal(t, concurrencyDefault, o.concurrency)
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package dag 提供了任务依赖图的声明与并发执行，用于构建、数据迁移与服务启动等需要按依赖顺序编排的场景。
+
+主要功能：
+
+  - 声明：Add 声明任务，DependsOn 声明依赖关系，Validate 检查缺失的依赖与循环依赖
+  - 执行：Run 在 kit/runtime/goroutine 的协程池中并发执行依赖已经满足的任务，WithConcurrency 限制并发数量
+  - 超时：WithTimeout 设置任务默认的超时时间，WithTaskTimeout 为单个任务单独设置
+  - 失败策略：FailFast 在任务失败时取消其他任务，Continue 只跳过依赖于失败任务的任务
+  - 报告：Report 记录每个任务的状态、错误与耗时
+
+基本使用：
+
+	g := dag.New(dag.WithTimeout(time.Minute))
+	_ = g.Add("db", connectDB)
+	_ = g.Add("cache", connectCache)
+	_ = g.Add("migrate", migrate, dag.DependsOn("db"))
+	_ = g.Add("server", startServer, dag.DependsOn("migrate", "cache"))
+
+	report, err := g.Run(ctx)
+	if nil != err {
+	    log.Error(report)
+	    return err
+	}
+*/
+package dag
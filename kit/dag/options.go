@@ -0,0 +1,179 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dag
+
+import (
+	"time"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+const (
+	// FailFast 表示任一任务失败时取消正在执行的任务，并跳过尚未开始的任务。
+	FailFast FailurePolicy = iota
+	// Continue 表示任务失败时继续执行不依赖于它的任务，只跳过直接或间接依赖于失败任务的任务。
+	Continue
+)
+
+// 以下为任务图的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// failurePolicyDefault 为默认的失败策略。
+	failurePolicyDefault = FailFast
+	// timeoutDefault 为任务默认的超时时间，0 表示不限制。
+	timeoutDefault = time.Duration(0)
+	// concurrencyDefault 为默认同时执行的任务数量上限，0 表示不限制。
+	concurrencyDefault = 0
+)
+
+type (
+	// FailurePolicy 定义了任务失败时的处理策略。
+	FailurePolicy int
+
+	// Option 定义了任务图的配置选项。
+	Option func(*options)
+
+	// options 包含任务图的配置。
+	options struct {
+		// pool 是执行任务的协程池，为 nil 时使用 kit/runtime/goroutine 的默认协程池。
+		pool goroutine.GoroutinePool
+		// failurePolicy 是任务失败时的处理策略。
+		failurePolicy FailurePolicy
+		// timeout 是任务默认的超时时间，0 表示不限制。
+		timeout time.Duration
+		// concurrency 是同时执行的任务数量上限，0 表示不限制。
+		concurrency int
+	}
+
+	// TaskOption 定义了单个任务的配置选项。
+	TaskOption func(*task)
+)
+
+// WithPool 设置执行任务的协程池。
+// 每个任务在执行期间占用协程池中的一个协程。
+//
+// 参数：
+//   - pool：协程池，默认为 kit/runtime/goroutine 的默认协程池。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPool(pool goroutine.GoroutinePool) Option {
+	return func(o *options) {
+		o.pool = pool
+	}
+}
+
+// WithFailurePolicy 设置任务失败时的处理策略。
+//
+// 参数：
+//   - policy：失败策略，默认为 FailFast，非法的值使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithFailurePolicy(policy FailurePolicy) Option {
+	return func(o *options) {
+		o.failurePolicy = policy
+	}
+}
+
+// WithTimeout 设置任务默认的超时时间，可以被 WithTaskTimeout 覆盖。
+// 超时后任务的上下文被取消，任务应当尽快返回。
+//
+// 参数：
+//   - timeout：超时时间，默认为 0 表示不限制，小于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithConcurrency 设置同时执行的任务数量上限。
+//
+// 参数：
+//   - concurrency：任务数量上限，默认为 0 表示只受协程池的限制，小于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithConcurrency(concurrency int) Option {
+	return func(o *options) {
+		o.concurrency = concurrency
+	}
+}
+
+// DependsOn 声明任务依赖的其他任务，被依赖的任务全部成功后该任务才会执行。
+// 被依赖的任务可以在之后声明，执行前统一检查是否存在。
+//
+// 参数：
+//   - names：被依赖的任务名称。
+//
+// 返回值：
+//   - TaskOption：任务配置选项函数。
+func DependsOn(names ...string) TaskOption {
+	return func(t *task) {
+		t.deps = append(t.deps, names...)
+	}
+}
+
+// WithTaskTimeout 设置任务的超时时间，覆盖任务图的 WithTimeout。
+//
+// 参数：
+//   - timeout：超时时间，0 表示不限制，小于 0 时使用任务图的设置。
+//
+// 返回值：
+//   - TaskOption：任务配置选项函数。
+func WithTaskTimeout(timeout time.Duration) TaskOption {
+	return func(t *task) {
+		t.timeout = timeout
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		failurePolicy: failurePolicyDefault,
+		timeout:       timeoutDefault,
+		concurrency:   concurrencyDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if FailFast != o.failurePolicy && Continue != o.failurePolicy {
+		o.failurePolicy = failurePolicyDefault
+	}
+	if o.timeout < 0 {
+		o.timeout = timeoutDefault
+	}
+	if o.concurrency < 0 {
+		o.concurrency = concurrencyDefault
+	}
+
+	return o
+}
+
+// submit 将任务提交到协程池。
+func (o *options) submit(task func()) error {
+	if nil != o.pool {
+		return o.pool.Submit(task)
+	}
+	return goroutine.Submit(task)
+}
+
+// String 返回失败策略的名称。
+//
+// 返回值：
+//   - string：失败策略的名称。
+func (p FailurePolicy) String() string {
+	switch p {
+	case FailFast:
+		return "fail-fast"
+	case Continue:
+		return "continue"
+	}
+	return "unknown"
+}
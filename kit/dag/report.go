@@ -0,0 +1,142 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dag
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// StatusSucceeded 表示任务执行成功。
+	StatusSucceeded Status = iota
+	// StatusFailed 表示任务返回了错误或发生了 panic。
+	StatusFailed
+	// StatusSkipped 表示任务没有执行，原因记录在 Result.Err 中。
+	StatusSkipped
+)
+
+type (
+	// Status 定义了任务的最终状态。
+	Status int
+
+	// Result 是单个任务的执行结果。
+	Result struct {
+		// Name 是任务的名称。
+		Name string
+		// Status 是任务的最终状态。
+		Status Status
+		// Err 是任务失败或被跳过的原因，成功时为 nil。
+		Err error
+		// Start 是任务开始执行的时间，被跳过的任务为零值。
+		Start time.Time
+		// Duration 是任务执行的耗时，被跳过的任务为 0。
+		Duration time.Duration
+	}
+
+	// Report 是一次执行的结果报告。
+	Report struct {
+		// Results 是各任务的执行结果，按声明的顺序排列。
+		Results []Result
+		// Duration 是整个执行的耗时。
+		Duration time.Duration
+	}
+)
+
+// String 返回任务状态的名称。
+//
+// 返回值：
+//   - string：任务状态的名称。
+func (s Status) String() string {
+	switch s {
+	case StatusSucceeded:
+		return "succeeded"
+	case StatusFailed:
+		return "failed"
+	case StatusSkipped:
+		return "skipped"
+	}
+	return "unknown"
+}
+
+// Result 返回指定任务的执行结果。
+//
+// 参数：
+//   - name：任务的名称。
+//
+// 返回值：
+//   - Result：任务的执行结果。
+//   - bool：任务存在时返回 true。
+func (r *Report) Result(name string) (Result, bool) {
+	for _, result := range r.Results {
+		if name == result.Name {
+			return result, true
+		}
+	}
+	return Result{}, false
+}
+
+// Succeeded 返回是否所有任务都执行成功。
+//
+// 返回值：
+//   - bool：所有任务都执行成功时返回 true。
+func (r *Report) Succeeded() bool {
+	for _, result := range r.Results {
+		if StatusSucceeded != result.Status {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter 返回指定状态的任务的执行结果，按声明的顺序排列。
+//
+// 参数：
+//   - status：任务状态。
+//
+// 返回值：
+//   - []Result：指定状态的任务的执行结果。
+func (r *Report) Filter(status Status) []Result {
+	var results []Result
+	for _, result := range r.Results {
+		if status == result.Status {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// String 返回报告的文本形式，每个任务一行，便于记录日志。
+//
+// 返回值：
+//   - string：报告的文本形式。
+//
+// 示例：
+//
+//	db      succeeded 12ms
+//	migrate failed    3ms connection refused
+//	seed    skipped    kit/dag: 任务被跳过：依赖的任务 migrate 未成功
+func (r *Report) String() string {
+	width := 0
+	for _, result := range r.Results {
+		width = max(width, len(result.Name))
+	}
+
+	var b strings.Builder
+	for _, result := range r.Results {
+		duration := ""
+		if StatusSkipped != result.Status {
+			duration = result.Duration.Round(time.Millisecond).String()
+		}
+		line := fmt.Sprintf("%-*s %-9s %s", width, result.Name, result.Status, duration)
+		if nil != result.Err {
+			line = fmt.Sprintf("%s %v", line, result.Err)
+		}
+		b.WriteString(strings.TrimRight(line, " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
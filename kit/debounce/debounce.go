@@ -0,0 +1,340 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package debounce
+
+import (
+	"context"
+	stdsync "sync"
+	"time"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+type (
+	// Debouncer 合并短时间内的连续调用，由 Debounce 或 Throttle 创建。
+	//
+	// 一次“连续调用”从第一次 Call 开始，到最后一次 Call 之后保持 wait 时长的安静为止。
+	// 等待期间只占用一个定时器，到期时才把函数提交到协程池执行。
+	// 同一个 Debouncer 的函数不会并发执行：执行期间到期的调用在本次执行结束后立即执行一次。
+	// Debouncer 的方法可以被多个协程并发调用。
+	Debouncer struct {
+		// fn 是被包装的函数。
+		fn func(ctx context.Context)
+		// opts 是防抖与节流的配置。
+		opts *options
+
+		// mu 保护以下字段。
+		mu stdsync.Mutex
+		// gen 是当前连续调用的代数，Cancel 与连续调用结束时递增，使已触发的旧定时器不再生效。
+		gen uint64
+		// timer 是当前连续调用的定时器，到期时检查各个到期时间，不处于连续调用中时为 nil。
+		timer kittime.Timer
+		// calls 是调用的序号，用于识别上下文被取消的是否为最后一次调用。
+		calls uint64
+		// stopWatch 注销对最后一次调用的上下文的监听，没有监听时为 nil。
+		stopWatch func() bool
+		// active 表示是否处于连续调用中。
+		active bool
+		// pending 表示是否有等待执行的调用。
+		pending bool
+		// ctx 是最后一次调用的上下文，执行时传给函数。
+		ctx context.Context
+		// deadline 是保持安静的截止时间，到期时连续调用结束。
+		deadline time.Time
+		// maxDeadline 是最迟的执行时间，为零值时不限制。
+		maxDeadline time.Time
+		// lastInvoke 是最后一次执行的时间。
+		lastInvoke time.Time
+		// running 表示函数是否正在执行。
+		running bool
+		// rerun 表示执行结束后是否需要再执行一次。
+		rerun bool
+		// rerunCtx 是再次执行时使用的上下文。
+		rerunCtx context.Context
+	}
+)
+
+// Debounce 创建防抖的函数：连续调用时推迟执行，直到最后一次调用之后保持 wait 时长的安静才执行一次。
+// 适用于配置文件变化、窗口大小调整等短时间内连续触发、只关心最终状态的事件。
+//
+// 参数：
+//   - fn：被包装的函数，参数为触发执行的那次调用的上下文。
+//   - wait：需要保持安静的时长。
+//   - opts：配置选项，参见 WithLeading、WithTrailing、WithMaxWait、WithPool 与 WithClock。
+//
+// 返回值：
+//   - *Debouncer：通过 Call 调用函数。
+//
+// 示例：
+//
+//	reload := debounce.Debounce(func(ctx context.Context) {
+//	    if err := watcher.Reload(); nil != err {
+//	        logger.Error(err)
+//	    }
+//	}, 200*time.Millisecond)
+//	defer reload.Cancel()
+//
+//	for event := range events {
+//	    reload.Call(ctx)
+//	}
+func Debounce(fn func(ctx context.Context), wait time.Duration, opts ...Option) *Debouncer {
+	return newDebouncer(fn, newOptions(wait, false, opts...))
+}
+
+// Throttle 创建节流的函数：连续调用时最多每隔 interval 执行一次。
+// 默认在连续调用开始时立即执行，之后每隔 interval 执行一次期间最后到达的调用，适用于进度上报、指标刷新等需要限制频率的场景。
+//
+// 参数：
+//   - fn：被包装的函数。
+//   - interval：两次执行之间的最短间隔。
+//   - opts：配置选项，WithMaxWait 对 Throttle 不生效。
+//
+// 返回值：
+//   - *Debouncer：通过 Call 调用函数。
+func Throttle(fn func(ctx context.Context), interval time.Duration, opts ...Option) *Debouncer {
+	o := newOptions(interval, true, opts...)
+	o.maxWait = o.wait
+	return newDebouncer(fn, o)
+}
+
+// newDebouncer 创建 Debouncer。
+func newDebouncer(fn func(ctx context.Context), o *options) *Debouncer {
+	return &Debouncer{
+		fn:   fn,
+		opts: o,
+	}
+}
+
+// Call 调用函数，根据配置立即执行、推迟执行或与其他调用合并。
+// 推迟执行时使用最后一次调用的上下文，上下文在执行前被取消时放弃本次执行；
+// 最后一次调用的上下文被取消时与 Cancel 相同，放弃等待中的调用并结束当前的连续调用。
+//
+// 参数：
+//   - ctx：调用的上下文。
+func (d *Debouncer) Call(ctx context.Context) {
+	d.mu.Lock()
+	now := d.opts.clock.Now()
+	d.ctx = ctx
+	d.deadline = now.Add(d.opts.wait)
+	d.watch(ctx)
+
+	if d.active {
+		d.pending = d.opts.trailing
+		d.mu.Unlock()
+		return
+	}
+
+	d.active = true
+	d.gen++
+	gen := d.gen
+	d.pending = d.opts.trailing
+	invoke := false
+	if d.opts.leading {
+		if 0 == d.opts.maxWait || d.lastInvoke.IsZero() || now.Sub(d.lastInvoke) >= d.opts.maxWait {
+			// 立即执行，之后的调用才需要在结束时执行。
+			invoke = true
+			d.pending = false
+			d.lastInvoke = now
+		}
+	}
+	if 0 != d.opts.maxWait {
+		if invoke || d.lastInvoke.IsZero() {
+			d.maxDeadline = now.Add(d.opts.maxWait)
+		} else {
+			// 节流时距离上一次执行不足间隔，等到间隔结束再执行。
+			d.maxDeadline = d.lastInvoke.Add(d.opts.maxWait)
+		}
+	}
+	due, _ := d.due()
+	d.timer = d.opts.clock.AfterFunc(due.Sub(now), func() { d.fire(gen) })
+	d.mu.Unlock()
+
+	if invoke {
+		d.invoke(ctx)
+	}
+}
+
+// Flush 立即执行等待中的调用，不影响之后的调用。
+//
+// 返回值：
+//   - bool：没有等待中的调用时返回 false。
+func (d *Debouncer) Flush() bool {
+	d.mu.Lock()
+	if !d.pending {
+		d.mu.Unlock()
+		return false
+	}
+	d.pending = false
+	d.lastInvoke = d.opts.clock.Now()
+	ctx := d.ctx
+	d.mu.Unlock()
+
+	d.invoke(ctx)
+	return true
+}
+
+// Cancel 放弃等待中的调用并结束当前的连续调用，正在执行的函数不受影响。
+// 之后的 Call 开始新的连续调用，Debouncer 可以继续使用。
+//
+// 返回值：
+//   - bool：有等待中的调用被放弃时返回 true。
+func (d *Debouncer) Cancel() bool {
+	d.mu.Lock()
+	pending := d.pending
+	d.end()
+	d.rerun = false
+	d.rerunCtx = nil
+	d.mu.Unlock()
+
+	return pending
+}
+
+// Pending 返回是否有等待执行的调用。
+//
+// 返回值：
+//   - bool：有等待执行的调用时返回 true。
+func (d *Debouncer) Pending() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.pending
+}
+
+// fire 在定时器到期时执行等待中的调用；连续调用尚未结束时重置定时器等待下一个到期时间。
+func (d *Debouncer) fire(gen uint64) {
+	d.mu.Lock()
+	if gen != d.gen {
+		d.mu.Unlock()
+		return
+	}
+
+	now := d.opts.clock.Now()
+	due, quiet := d.due()
+	if now.Before(due) {
+		// 等待期间的调用推迟了安静的截止时间。
+		d.timer.Reset(due.Sub(now))
+		d.mu.Unlock()
+		return
+	}
+
+	var ctx context.Context
+	if d.pending {
+		ctx = d.ctx
+		d.pending = false
+		d.lastInvoke = now
+	}
+	if quiet {
+		d.end()
+	} else {
+		d.maxDeadline = now.Add(d.opts.maxWait)
+		due, _ = d.due()
+		d.timer.Reset(due.Sub(now))
+	}
+	d.mu.Unlock()
+
+	if nil != ctx {
+		d.invoke(ctx)
+	}
+}
+
+// due 返回最近的到期时间，以及到期时连续调用是否结束，调用方需持有 mu。
+func (d *Debouncer) due() (time.Time, bool) {
+	if !d.maxDeadline.IsZero() && d.maxDeadline.Before(d.deadline) {
+		return d.maxDeadline, false
+	}
+	return d.deadline, true
+}
+
+// watch 监听最后一次调用的上下文，取消时结束当前的连续调用，同时注销对上一次调用的监听，调用方需持有 mu。
+func (d *Debouncer) watch(ctx context.Context) {
+	if nil != d.stopWatch {
+		d.stopWatch()
+	}
+	d.calls++
+	call := d.calls
+	d.stopWatch = context.AfterFunc(ctx, func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if call == d.calls && d.active {
+			d.end()
+		}
+	})
+}
+
+// end 结束当前的连续调用，停止定时器并注销对上下文的监听，调用方需持有 mu。
+func (d *Debouncer) end() {
+	d.gen++
+	d.active = false
+	d.pending = false
+	d.ctx = nil
+	d.maxDeadline = time.Time{}
+	if nil != d.timer {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if nil != d.stopWatch {
+		d.stopWatch()
+		d.stopWatch = nil
+	}
+}
+
+// invoke 在协程池中执行函数；函数正在执行时，在本次执行结束后再执行一次。
+func (d *Debouncer) invoke(ctx context.Context) {
+	d.mu.Lock()
+	if d.running {
+		d.rerun = true
+		d.rerunCtx = ctx
+		d.mu.Unlock()
+		return
+	}
+	d.running = true
+	d.mu.Unlock()
+
+	d.submit(func() { d.run(ctx) })
+}
+
+// run 执行函数，直到没有需要再次执行的调用。
+func (d *Debouncer) run(ctx context.Context) {
+	defer func() {
+		if r := recover(); nil != r {
+			d.mu.Lock()
+			d.running = false
+			d.rerun = false
+			d.rerunCtx = nil
+			d.mu.Unlock()
+			panic(r)
+		}
+	}()
+
+	for {
+		if nil == ctx.Err() {
+			d.fn(ctx)
+		}
+
+		d.mu.Lock()
+		if !d.rerun {
+			d.running = false
+			d.mu.Unlock()
+			return
+		}
+		ctx = d.rerunCtx
+		d.rerun = false
+		d.rerunCtx = nil
+		d.mu.Unlock()
+	}
+}
+
+// submit 将任务提交到协程池，提交失败时（例如协程池已关闭）在新的协程中执行，保证调用不会丢失。
+func (d *Debouncer) submit(task func()) {
+	var err error
+	if nil != d.opts.pool {
+		err = d.opts.pool.Submit(task)
+	} else {
+		err = goroutine.Submit(task)
+	}
+	if nil != err {
+		go task()
+	}
+}
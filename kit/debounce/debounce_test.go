@@ -0,0 +1,314 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package debounce
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+type (
+	// ctxKey 是测试在上下文中保存调用序号的键。
+	ctxKey struct{}
+
+	// recorder 记录函数的执行。
+	recorder struct {
+		// calls 接收每次执行时上下文中的调用序号。
+		calls chan int
+	}
+)
+
+// newRecorder 创建 recorder。
+func newRecorder() *recorder {
+	return &recorder{calls: make(chan int, 16)}
+}
+
+// fn 是被包装的函数。
+func (r *recorder) fn(ctx context.Context) {
+	n, _ := ctx.Value(ctxKey{}).(int)
+	r.calls <- n
+}
+
+// expect 断言依次执行了 want 中的调用，并且之后没有更多的执行。
+func (r *recorder) expect(t *testing.T, want ...int) {
+	t.Helper()
+	for _, n := range want {
+		select {
+		case got := <-r.calls:
+			assert.Equal(t, n, got)
+		case <-time.After(time.Second):
+			t.Fatalf("等待第 %d 次调用的执行超时", n)
+		}
+	}
+	select {
+	case got := <-r.calls:
+		t.Fatalf("多余的执行：%d", got)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+// call 以带序号的上下文调用 d。
+func call(d *Debouncer, n int) {
+	d.Call(context.WithValue(context.Background(), ctxKey{}, n))
+}
+
+// advance 等待 Debouncer 开始计时后推进时钟。
+func advance(clock *kittime.FakeClock, d time.Duration) {
+	clock.BlockUntil(1)
+	clock.Advance(d)
+}
+
+// TestDebounce 测试连续调用合并为最后一次。
+func TestDebounce(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	r := newRecorder()
+	d := Debounce(r.fn, 100*time.Millisecond, WithClock(clock))
+
+	call(d, 1)
+	advance(clock, 50*time.Millisecond)
+	call(d, 2)
+	advance(clock, 50*time.Millisecond)
+	call(d, 3)
+	assert.True(t, d.Pending())
+	advance(clock, 50*time.Millisecond)
+	r.expect(t)
+	advance(clock, 50*time.Millisecond)
+	r.expect(t, 3)
+	assert.False(t, d.Pending())
+
+	// 新的连续调用。
+	call(d, 4)
+	advance(clock, 100*time.Millisecond)
+	r.expect(t, 4)
+}
+
+// TestDebounce_Leading 测试在连续调用开始时立即执行。
+func TestDebounce_Leading(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	r := newRecorder()
+	d := Debounce(r.fn, 100*time.Millisecond, WithClock(clock), WithLeading(true), WithTrailing(false))
+
+	call(d, 1)
+	r.expect(t, 1)
+	call(d, 2)
+	advance(clock, 100*time.Millisecond)
+	r.expect(t)
+
+	call(d, 3)
+	r.expect(t, 3)
+
+	// leading 与 trailing 同时开启时，只调用一次不会在结束时再执行。
+	both := Debounce(r.fn, 100*time.Millisecond, WithClock(clock), WithLeading(true))
+	call(both, 5)
+	r.expect(t, 5)
+	advance(clock, 200*time.Millisecond)
+	r.expect(t)
+}
+
+// TestDebounce_MaxWait 测试持续调用时不会被无限推迟。
+func TestDebounce_MaxWait(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	r := newRecorder()
+	d := Debounce(r.fn, 100*time.Millisecond, WithClock(clock), WithMaxWait(250*time.Millisecond))
+
+	for i := 1; i <= 3; i++ {
+		call(d, i)
+		advance(clock, 90*time.Millisecond)
+	}
+	// 第三次推进跨过了 250ms 的最长等待。
+	r.expect(t, 3)
+	advance(clock, 100*time.Millisecond)
+	r.expect(t)
+}
+
+// TestThrottle 测试节流的执行频率。
+func TestThrottle(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	r := newRecorder()
+	d := Throttle(r.fn, 100*time.Millisecond, WithClock(clock), WithMaxWait(time.Hour))
+
+	call(d, 1)
+	r.expect(t, 1)
+	call(d, 2)
+	call(d, 3)
+	advance(clock, 100*time.Millisecond)
+	r.expect(t, 3)
+
+	// 距离上一次执行不足间隔，等到间隔结束再执行。
+	clock.Advance(10 * time.Millisecond)
+	call(d, 4)
+	r.expect(t)
+	advance(clock, 90*time.Millisecond)
+	r.expect(t, 4)
+
+	// 关闭 leading 时第一次调用也在间隔结束时执行。
+	trailing := Throttle(r.fn, 100*time.Millisecond, WithClock(clock), WithLeading(false))
+	call(trailing, 5)
+	r.expect(t)
+	clock.BlockUntil(2)
+	clock.Advance(100 * time.Millisecond)
+	r.expect(t, 5)
+}
+
+// TestDebouncer_FlushCancel 测试立即执行与取消。
+func TestDebouncer_FlushCancel(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	r := newRecorder()
+	d := Debounce(r.fn, time.Minute, WithClock(clock))
+
+	assert.False(t, d.Flush())
+	assert.False(t, d.Cancel())
+
+	call(d, 1)
+	assert.True(t, d.Flush())
+	r.expect(t, 1)
+	assert.False(t, d.Pending())
+
+	call(d, 2)
+	assert.True(t, d.Cancel())
+	assert.Zero(t, clock.Waiters(), "Cancel 停止定时器")
+	clock.Advance(time.Minute)
+	r.expect(t)
+
+	call(d, 3)
+	advance(clock, time.Minute)
+	r.expect(t, 3)
+}
+
+// TestDebouncer_Context 测试最后一次调用的上下文取消后放弃执行并停止定时器。
+func TestDebouncer_Context(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	r := newRecorder()
+	d := Debounce(r.fn, time.Second, WithClock(clock))
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, 1))
+	d.Call(ctx)
+	require.Equal(t, 1, clock.Waiters())
+	cancel()
+	assert.Eventually(t, func() bool {
+		return !d.Pending() && 0 == clock.Waiters()
+	}, time.Second, time.Millisecond)
+	clock.Advance(time.Second)
+	r.expect(t)
+
+	// 被之后的调用取代的上下文取消时不影响等待中的调用。
+	ctx, cancel = context.WithCancel(context.Background())
+	d.Call(ctx)
+	call(d, 2)
+	cancel()
+	advance(clock, time.Second)
+	r.expect(t, 2)
+}
+
+// TestDebouncer_Timer 测试等待期间不占用协程池的协程。
+func TestDebouncer_Timer(t *testing.T) {
+	pool, release, err := goroutine.NewGoroutinePool(goroutine.WithSize(1), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer release()
+
+	clock := kittime.NewFakeClock(time.Time{})
+	r := newRecorder()
+	d := Debounce(r.fn, time.Second, WithClock(clock), WithPool(pool))
+	call(d, 1)
+	assert.Equal(t, 1, clock.Waiters())
+	assert.Zero(t, pool.Running())
+
+	advance(clock, time.Second)
+	r.expect(t, 1)
+	assert.Zero(t, clock.Waiters())
+}
+
+// TestDebouncer_Serial 测试函数不会并发执行。
+func TestDebouncer_Serial(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	var running, maxRunning atomic.Int32
+	release := make(chan struct{})
+	done := make(chan struct{}, 4)
+	d := Debounce(func(context.Context) {
+		n := running.Add(1)
+		if n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		<-release
+		running.Add(-1)
+		done <- struct{}{}
+	}, time.Second, WithClock(clock))
+
+	d.Call(context.Background())
+	require.True(t, d.Flush())
+	d.Call(context.Background())
+	require.True(t, d.Flush())
+	d.Call(context.Background())
+	require.True(t, d.Flush())
+
+	close(release)
+	<-done
+	<-done
+	select {
+	case <-done:
+		t.Fatal("执行期间到期的多次调用应合并为一次")
+	case <-time.After(20 * time.Millisecond):
+	}
+	assert.Equal(t, int32(1), maxRunning.Load())
+}
+
+// TestDebouncer_Pool 测试使用指定的协程池以及协程池不可用时的处理。
+func TestDebouncer_Pool(t *testing.T) {
+	pool, release, err := goroutine.NewGoroutinePool(goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	release()
+
+	clock := kittime.NewFakeClock(time.Time{})
+	r := newRecorder()
+	d := Debounce(r.fn, time.Second, WithClock(clock), WithPool(pool), WithLeading(true))
+	call(d, 1)
+	r.expect(t, 1)
+	call(d, 2)
+	advance(clock, time.Second)
+	r.expect(t, 2)
+}
+
+// TestDebouncer_Panic 测试函数 panic 后仍然可以继续使用。
+func TestDebouncer_Panic(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	var calls atomic.Int32
+	d := Debounce(func(context.Context) {
+		if 1 == calls.Add(1) {
+			panic("boom")
+		}
+	}, time.Second, WithClock(clock))
+
+	d.Call(context.Background())
+	d.Flush()
+	assert.Eventually(t, func() bool {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return 1 == calls.Load() && !d.running
+	}, time.Second, time.Millisecond)
+
+	d.Call(context.Background())
+	d.Flush()
+	assert.Eventually(t, func() bool { return 2 == calls.Load() }, time.Second, time.Millisecond)
+}
+
+// TestNewOptions 测试非法参数使用默认值。
+func TestNewOptions(t *testing.T) {
+	o := newOptions(-1, false, WithMaxWait(-1), WithTrailing(false), WithClock(nil))
+	assert.Zero(t, o.wait)
+	assert.Zero(t, o.maxWait)
+	assert.True(t, o.trailing)
+	assert.Equal(t, clockDefault, o.clock)
+
+	o = newOptions(time.Second, true, WithMaxWait(time.Millisecond))
+	assert.Equal(t, time.Second, o.maxWait)
+}
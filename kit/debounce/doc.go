@@ -0,0 +1,29 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package debounce 提供了防抖与节流的函数包装，用于合并短时间内连续触发的事件。
+
+主要功能：
+
+  - 防抖：Debounce 在最后一次调用之后保持安静一段时间才执行，适用于配置重新加载等只关心最终状态的事件
+  - 节流：Throttle 最多每隔一段时间执行一次，适用于进度上报、指标刷新等需要限制频率的事件
+  - 执行时机：WithLeading、WithTrailing 控制在连续调用开始时还是结束后执行，WithMaxWait 限制防抖的最长推迟时间
+  - 控制：Flush 立即执行等待中的调用，Cancel 放弃等待中的调用
+  - 上下文：执行时使用触发执行的那次调用的上下文，上下文已取消时放弃执行
+
+函数在 kit/runtime/goroutine 的协程池中执行，同一个 Debouncer 的函数不会并发执行；计时使用 kit/time 的时钟，
+测试中可以通过 WithClock 传入 FakeClock。
+
+基本使用：
+
+	reload := debounce.Debounce(func(ctx context.Context) {
+	    reloadConfig(ctx)
+	}, 200*time.Millisecond)
+
+	for range events {
+	    reload.Call(ctx)
+	}
+*/
+package debounce
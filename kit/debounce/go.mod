@@ -0,0 +1,65 @@
+module github.com/fsyyft-go/monorepo/kit/debounce
+
+go 1.25
+
+require (
+	github.com/fsyyft-go/monorepo/kit/runtime v0.1.0
+	github.com/fsyyft-go/monorepo/kit/time v0.1.0
+	github.com/stretchr/testify v1.11.1
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/fsyyft-go/monorepo/kit/encoding v0.1.0 // indirect
+	github.com/fsyyft-go/monorepo/kit/fs v0.1.0 // indirect
+	github.com/fsyyft-go/monorepo/kit/id v0.1.0 // indirect
+	github.com/fsyyft-go/monorepo/kit/json v0.1.0 // indirect
+	github.com/fsyyft-go/monorepo/kit/log v0.1.0 // indirect
+	github.com/fsyyft-go/monorepo/kit/pool v0.1.0 // indirect
+	github.com/fsyyft-go/monorepo/kit/runtime/retry v0.1.0 // indirect
+	github.com/fsyyft-go/monorepo/kit/strings v0.1.0 // indirect
+	github.com/fsyyft-go/monorepo/kit/sync v0.1.0 // indirect
+	github.com/lestrrat-go/file-rotatelogs v2.4.0+incompatible // indirect
+	github.com/lestrrat-go/strftime v1.1.1 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/panjf2000/ants/v2 v2.11.3 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_golang v1.23.2 // indirect
+	github.com/prometheus/client_model v0.6.2 // indirect
+	github.com/prometheus/common v0.66.1 // indirect
+	github.com/prometheus/procfs v0.16.1 // indirect
+	github.com/sirupsen/logrus v1.9.3 // indirect
+	go.opentelemetry.io/otel v1.37.0 // indirect
+	go.opentelemetry.io/otel/trace v1.37.0 // indirect
+	go.opentelemetry.io/proto/otlp v1.7.0 // indirect
+	go.yaml.in/yaml/v2 v2.4.2 // indirect
+	golang.org/x/sync v0.13.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+	google.golang.org/protobuf v1.36.8 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/fsyyft-go/monorepo/kit/runtime => ../runtime
+
+replace github.com/fsyyft-go/monorepo/kit/time => ../time
+
+replace github.com/fsyyft-go/monorepo/kit/log => ../log
+
+replace github.com/fsyyft-go/monorepo/kit/sync => ../sync
+
+replace github.com/fsyyft-go/monorepo/kit/strings => ../strings
+
+replace github.com/fsyyft-go/monorepo/kit/json => ../json
+
+replace github.com/fsyyft-go/monorepo/kit/fs => ../fs
+
+replace github.com/fsyyft-go/monorepo/kit/id => ../id
+
+replace github.com/fsyyft-go/monorepo/kit/runtime/retry => ../runtime/retry
+
+replace github.com/fsyyft-go/monorepo/kit/pool => ../pool
+
+replace github.com/fsyyft-go/monorepo/kit/encoding => ../encoding
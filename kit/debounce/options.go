@@ -0,0 +1,137 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package debounce
+
+import (
+	"time"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 以下为防抖与节流的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// clockDefault 为默认使用的时钟。
+	clockDefault = kittime.NewRealClock()
+)
+
+type (
+	// Option 定义了防抖与节流的配置选项。
+	Option func(*options)
+
+	// options 包含防抖与节流的配置。
+	options struct {
+		// wait 是最后一次调用之后需要保持安静的时长。
+		wait time.Duration
+		// maxWait 是一次连续调用中两次执行之间的最长间隔，为 0 时不限制。
+		maxWait time.Duration
+		// leading 表示是否在连续调用开始时立即执行。
+		leading bool
+		// trailing 表示是否在连续调用结束后执行。
+		trailing bool
+		// pool 是执行函数的协程池，为 nil 时使用 kit/runtime/goroutine 的默认协程池。
+		pool goroutine.GoroutinePool
+		// clock 是计时使用的时钟。
+		clock kittime.Clock
+	}
+)
+
+// WithLeading 设置是否在连续调用开始时立即执行。
+//
+// 参数：
+//   - leading：是否立即执行，Debounce 默认为 false，Throttle 默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLeading(leading bool) Option {
+	return func(o *options) {
+		o.leading = leading
+	}
+}
+
+// WithTrailing 设置是否在连续调用结束后执行。
+// 与 WithLeading 同时为 false 时没有意义，此时 trailing 按 true 处理。
+//
+// 参数：
+//   - trailing：是否在结束后执行，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTrailing(trailing bool) Option {
+	return func(o *options) {
+		o.trailing = trailing
+	}
+}
+
+// WithMaxWait 设置一次连续调用中两次执行之间的最长间隔，仅对 Debounce 生效。
+// 调用持续不断时，防抖的函数至少每隔 maxWait 执行一次，不会被无限推迟。
+//
+// 参数：
+//   - maxWait：最长间隔，默认为 0 表示不限制，小于 wait 时按 wait 处理。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxWait(maxWait time.Duration) Option {
+	return func(o *options) {
+		o.maxWait = maxWait
+	}
+}
+
+// WithPool 设置执行函数与等待计时的协程池。
+//
+// 参数：
+//   - pool：协程池，默认为 kit/runtime/goroutine 的默认协程池。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPool(pool goroutine.GoroutinePool) Option {
+	return func(o *options) {
+		o.pool = pool
+	}
+}
+
+// WithClock 设置计时使用的时钟，测试中可以传入 kit/time 的 FakeClock。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(wait time.Duration, leading bool, opts ...Option) *options {
+	o := &options{
+		wait:     wait,
+		leading:  leading,
+		trailing: true,
+		clock:    clockDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.wait < 0 {
+		o.wait = 0
+	}
+	if o.maxWait < 0 {
+		o.maxWait = 0
+	}
+	if 0 != o.maxWait && o.maxWait < o.wait {
+		o.maxWait = o.wait
+	}
+	if !o.leading && !o.trailing {
+		o.trailing = true
+	}
+	if nil == o.clock {
+		o.clock = clockDefault
+	}
+	return o
+}
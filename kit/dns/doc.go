@@ -0,0 +1,31 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package dns 提供了带有缓存的域名解析器 Resolver，用于减少 HTTP 与 gRPC 客户端的域名查询。
+
+Resolver 的查询方法与 net.Resolver 一致，结果按记录的 TTL 缓存，域名不存在的结果按较短的时间缓存，
+同一个域名的并发查询只向上游查询一次：
+
+	r := dns.New(dns.WithName("internal"))
+	defer r.Close()
+
+	addrs, err := r.LookupNetIP(ctx, "ip4", "user.internal")
+
+上游默认为系统的解析器（SystemUpstream），它无法提供记录的 TTL，结果按 WithDefaultTTL 缓存；
+使用 ServerUpstream 直接向 DNS 服务器查询时，结果按记录的 TTL 缓存：
+
+	r := dns.New(dns.WithUpstream(dns.ServerUpstream("10.0.0.2", "10.0.0.3")))
+
+DialContext 解析域名后建立连接，可以直接用于 http.Transport、kit/http 的 WithDialContext
+与 grpc.WithContextDialer：
+
+	client := kithttp.NewClient(kithttp.WithDialContext(r.DialContext))
+
+	conn, err := grpc.NewClient("passthrough:///user.internal:9090",
+	    grpc.WithContextDialer(r.ContextDialer()),
+	    grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+*/
+package dns
@@ -0,0 +1,49 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dns
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 定义解析器指标相关的常量。
+const (
+	// namespace 定义 prometheus 指标的命名空间。
+	namespace = "kit_dns"
+)
+
+var (
+	// MetricLookups 用于记录解析器的查询次数，IP 字面量不经过缓存，不被记录。
+	// 该指标包含以下标签：
+	// - name: 解析器的名称。
+	// - result: 查询结果，hit 表示命中缓存，negative_hit 表示命中否定缓存，miss 表示未命中。
+	MetricLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "lookups_total",
+		Help:      "dns resolver's lookups total.",
+	}, []string{"name", "result"})
+
+	// MetricUpstreamDuration 用于记录向上游查询的耗时，单位为秒，合并的并发查询只记录一次。
+	// 该指标包含以下标签：
+	// - name: 解析器的名称。
+	// - result: 查询结果，success 表示成功，not_found 表示域名不存在或没有地址，error 表示查询失败。
+	MetricUpstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_duration_seconds",
+		Help:      "dns resolver's upstream lookup duration in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"name", "result"})
+)
+
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricLookups,
+		MetricUpstreamDuration,
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dns
+
+import (
+	"net"
+	"time"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 以下为解析器的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// nameDefault 为解析器的默认名称，用于指标标签。
+	nameDefault = "default"
+	// metricsDefault 为是否默认记录指标。
+	metricsDefault = true
+	// defaultTTLDefault 为上游没有提供 TTL 时结果的有效期。
+	defaultTTLDefault = 30 * time.Second
+	// minTTLDefault 为结果的最短有效期，避免 TTL 为 0 的记录导致每次都查询上游。
+	minTTLDefault = time.Second
+	// maxTTLDefault 为结果的最长有效期，避免地址变化后长时间使用旧地址。
+	maxTTLDefault = 5 * time.Minute
+	// negativeTTLDefault 为域名不存在或没有地址时结果的有效期。
+	negativeTTLDefault = 5 * time.Second
+	// maxEntriesDefault 为缓存的最大域名数。
+	maxEntriesDefault = 10000
+	// lookupTimeoutDefault 为一次上游查询的超时时间。
+	lookupTimeoutDefault = 5 * time.Second
+	// dialTimeoutDefault 为 DialContext 连接一个地址的超时时间。
+	dialTimeoutDefault = 5 * time.Second
+	// cleanupIntervalDefault 为缓存后台清理过期结果的时间间隔。
+	cleanupIntervalDefault = time.Minute
+	// clockDefault 为计算有效期与查询耗时使用的时钟。
+	clockDefault = kittime.NewRealClock()
+)
+
+type (
+	// Option 定义了解析器的配置选项。
+	Option func(*options)
+
+	// options 包含解析器的配置。
+	options struct {
+		// name 是解析器的名称，用于指标标签。
+		name string
+		// metrics 表示是否记录指标。
+		metrics bool
+		// upstream 是查询域名的上游。
+		upstream Upstream
+		// defaultTTL 是上游没有提供 TTL 时结果的有效期。
+		defaultTTL time.Duration
+		// minTTL 是结果的最短有效期。
+		minTTL time.Duration
+		// maxTTL 是结果的最长有效期。
+		maxTTL time.Duration
+		// negativeTTL 是域名不存在时结果的有效期，0 表示不缓存。
+		negativeTTL time.Duration
+		// maxEntries 是缓存的最大域名数。
+		maxEntries int
+		// lookupTimeout 是一次上游查询的超时时间。
+		lookupTimeout time.Duration
+		// dialer 是 DialContext 建立连接使用的 Dialer。
+		dialer *net.Dialer
+		// clock 是计算有效期与查询耗时使用的时钟。
+		clock kittime.Clock
+	}
+)
+
+// WithName 设置解析器的名称，用于区分不同解析器的指标。
+//
+// 参数：
+//   - name：解析器名称，默认为 default。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithMetrics 设置是否记录指标。
+//
+// 参数：
+//   - metrics：是否记录指标，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMetrics(metrics bool) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// WithUpstream 设置查询域名的上游。
+//
+// 参数：
+//   - upstream：上游，默认为 SystemUpstream(nil)；需要按记录的 TTL 缓存时使用 ServerUpstream。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithUpstream(upstream Upstream) Option {
+	return func(o *options) {
+		o.upstream = upstream
+	}
+}
+
+// WithDefaultTTL 设置上游没有提供 TTL 时结果的有效期，例如使用 SystemUpstream 时。
+//
+// 参数：
+//   - ttl：有效期，默认为 30 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithDefaultTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.defaultTTL = ttl
+	}
+}
+
+// WithTTLRange 设置结果有效期的范围，上游的 TTL 超出范围时取边界值。
+//
+// 参数：
+//   - minTTL：最短有效期，默认为 1 秒，小于等于 0 时使用默认值。
+//   - maxTTL：最长有效期，默认为 5 分钟，小于 minTTL 时使用 minTTL。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTTLRange(minTTL, maxTTL time.Duration) Option {
+	return func(o *options) {
+		o.minTTL = minTTL
+		o.maxTTL = maxTTL
+	}
+}
+
+// WithNegativeTTL 设置域名不存在或没有地址时结果的有效期，查询失败的结果总是不被缓存。
+//
+// 参数：
+//   - ttl：有效期，默认为 5 秒，0 表示不缓存，小于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.negativeTTL = ttl
+	}
+}
+
+// WithMaxEntries 设置缓存的最大域名数，超过时淘汰最久未使用的域名。
+//
+// 参数：
+//   - n：最大域名数，默认为 10000，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxEntries(n int) Option {
+	return func(o *options) {
+		o.maxEntries = n
+	}
+}
+
+// WithLookupTimeout 设置一次上游查询的超时时间。
+// 查询不随调用方的上下文取消，调用方提前返回时查询仍会完成并写入缓存。
+//
+// 参数：
+//   - timeout：超时时间，默认为 5 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLookupTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.lookupTimeout = timeout
+	}
+}
+
+// WithDialer 设置 DialContext 建立连接使用的 Dialer。
+//
+// 参数：
+//   - dialer：Dialer，默认连接超时为 5 秒、TCP keep-alive 为 30 秒，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithDialer(dialer *net.Dialer) Option {
+	return func(o *options) {
+		o.dialer = dialer
+	}
+}
+
+// WithClock 设置计算有效期与查询耗时使用的时钟。
+// 测试时可以注入 kit/time 的 FakeClock，推进时钟即可使结果过期。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		name:          nameDefault,
+		metrics:       metricsDefault,
+		defaultTTL:    defaultTTLDefault,
+		minTTL:        minTTLDefault,
+		maxTTL:        maxTTLDefault,
+		negativeTTL:   negativeTTLDefault,
+		maxEntries:    maxEntriesDefault,
+		lookupTimeout: lookupTimeoutDefault,
+		clock:         clockDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if "" == o.name {
+		o.name = nameDefault
+	}
+	if nil == o.upstream {
+		o.upstream = SystemUpstream(nil)
+	}
+	if o.defaultTTL <= 0 {
+		o.defaultTTL = defaultTTLDefault
+	}
+	if o.minTTL <= 0 {
+		o.minTTL = minTTLDefault
+	}
+	if o.maxTTL < o.minTTL {
+		o.maxTTL = o.minTTL
+	}
+	if o.negativeTTL < 0 {
+		o.negativeTTL = negativeTTLDefault
+	}
+	if o.maxEntries <= 0 {
+		o.maxEntries = maxEntriesDefault
+	}
+	if o.lookupTimeout <= 0 {
+		o.lookupTimeout = lookupTimeoutDefault
+	}
+	if nil == o.dialer {
+		o.dialer = &net.Dialer{Timeout: dialTimeoutDefault, KeepAlive: 30 * time.Second}
+	}
+	o.clock = kittime.OrReal(o.clock)
+
+	return o
+}
+
+// clampTTL 返回结果的有效期，上游没有提供时使用默认值，并限制在范围之内。
+func (o *options) clampTTL(ttl time.Duration) time.Duration {
+	if ttl < 0 {
+		ttl = o.defaultTTL
+	}
+	return min(max(ttl, o.minTTL), o.maxTTL)
+}
@@ -0,0 +1,346 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/fsyyft-go/monorepo/kit/cache"
+)
+
+type (
+	// Resolver 是带有缓存的域名解析器，可以代替 net.Resolver 为 HTTP 与 gRPC 客户端解析域名。
+	// 结果按记录的 TTL 缓存，域名不存在的结果按 WithNegativeTTL 缓存，同一个域名的并发查询只向上游查询一次。
+	// Resolver 的所有方法都是并发安全的，不再使用时需要调用 Close。
+	Resolver struct {
+		// o 是解析器的配置。
+		o *options
+		// cache 是域名到查询结果的缓存。
+		cache *cache.Cache[string, *result]
+		// callMu 保护 calls。
+		callMu sync.Mutex
+		// calls 是正在进行的上游查询，同一个域名同时只有一个查询。
+		calls map[string]*call
+
+		// hits 是命中缓存的计数器，未开启指标时为 nil。
+		hits prometheus.Counter
+		// negativeHits 是命中否定缓存的计数器，未开启指标时为 nil。
+		negativeHits prometheus.Counter
+		// misses 是未命中缓存的计数器，未开启指标时为 nil。
+		misses prometheus.Counter
+		// upstreamDuration 是上游查询耗时的直方图，未开启指标时为 nil。
+		upstreamDuration prometheus.ObserverVec
+	}
+
+	// result 是一个域名的查询结果。
+	result struct {
+		// addrs 是域名的地址。
+		addrs []netip.Addr
+		// err 是查询的错误，只有包装了 ErrNotFound 的错误会被缓存。
+		err error
+	}
+
+	// call 是一次正在进行的上游查询，同一个域名的并发查询共享其结果。
+	call struct {
+		// done 在查询完成后关闭。
+		done chan struct{}
+		// res 是查询的结果，done 关闭后可读。
+		res *result
+	}
+)
+
+// New 创建带有缓存的域名解析器。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Resolver：解析器实例，不再使用时需要调用 Close。
+//
+// 示例：
+//
+//	r := dns.New(
+//	    dns.WithName("internal"),
+//	    dns.WithUpstream(dns.ServerUpstream("10.0.0.2")),
+//	)
+//	defer r.Close()
+//
+//	client := kithttp.NewClient(kithttp.WithDialContext(r.DialContext))
+func New(opts ...Option) *Resolver {
+	o := newOptions(opts...)
+
+	r := &Resolver{
+		o: o,
+		cache: cache.New[string, *result](
+			cache.WithMaxEntries(o.maxEntries),
+			cache.WithCleanupInterval(cleanupIntervalDefault),
+			cache.WithClock(o.clock),
+			cache.WithMetrics(false),
+		),
+		calls: make(map[string]*call),
+	}
+	if o.metrics {
+		r.hits = MetricLookups.WithLabelValues(o.name, "hit")
+		r.negativeHits = MetricLookups.WithLabelValues(o.name, "negative_hit")
+		r.misses = MetricLookups.WithLabelValues(o.name, "miss")
+		r.upstreamDuration = MetricUpstreamDuration.MustCurryWith(prometheus.Labels{"name": o.name})
+	}
+	return r
+}
+
+// LookupNetIP 返回域名的地址，与 net.Resolver.LookupNetIP 一致。IP 字面量直接返回，不经过缓存。
+//
+// 参数：
+//   - ctx：上下文，取消时停止等待，正在进行的上游查询不受影响。
+//   - network：地址类型，ip 表示全部地址，ip4 与 ip6 分别只返回 IPv4 与 IPv6 地址。
+//   - host：域名或 IP 字面量。
+//
+// 返回值：
+//   - []netip.Addr：域名的地址，调用方可以修改。
+//   - error：域名不存在或没有指定类型的地址时返回包装了 ErrNotFound 的错误。
+func (r *Resolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	if "ip" != network && "ip4" != network && "ip6" != network {
+		return nil, fmt.Errorf("kit/dns: 不支持的地址类型 %q", network)
+	}
+
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); nil == err {
+		addrs = []netip.Addr{addr.Unmap()}
+	} else {
+		res, err := r.lookup(ctx, normalize(host))
+		if nil != err {
+			return nil, err
+		}
+		addrs = slices.Clone(res)
+	}
+
+	switch network {
+	case "ip4":
+		addrs = slices.DeleteFunc(addrs, func(addr netip.Addr) bool { return !addr.Is4() })
+	case "ip6":
+		addrs = slices.DeleteFunc(addrs, func(addr netip.Addr) bool { return !addr.Is6() })
+	}
+	if 0 == len(addrs) {
+		return nil, fmt.Errorf("%w：%q", ErrNotFound, host)
+	}
+	return addrs, nil
+}
+
+// LookupIPAddr 返回域名的地址，与 net.Resolver.LookupIPAddr 一致。
+//
+// 参数：
+//   - ctx：上下文。
+//   - host：域名或 IP 字面量。
+//
+// 返回值：
+//   - []net.IPAddr：域名的地址。
+//   - error：同 LookupNetIP。
+func (r *Resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	addrs, err := r.LookupNetIP(ctx, "ip", host)
+	if nil != err {
+		return nil, err
+	}
+	ips := make([]net.IPAddr, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, net.IPAddr{IP: addr.AsSlice(), Zone: addr.Zone()})
+	}
+	return ips, nil
+}
+
+// LookupHost 返回域名的地址的文本形式，与 net.Resolver.LookupHost 一致。
+//
+// 参数：
+//   - ctx：上下文。
+//   - host：域名或 IP 字面量。
+//
+// 返回值：
+//   - []string：域名的地址。
+//   - error：同 LookupNetIP。
+func (r *Resolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addrs, err := r.LookupNetIP(ctx, "ip", host)
+	if nil != err {
+		return nil, err
+	}
+	hosts := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		hosts = append(hosts, addr.String())
+	}
+	return hosts, nil
+}
+
+// DialContext 解析地址中的域名并建立连接，签名与 net.Dialer.DialContext 一致，
+// 可以用作 http.Transport.DialContext。按顺序尝试域名的每个地址，直到连接成功。
+//
+// 参数：
+//   - ctx：上下文，用于解析与建立连接。
+//   - network：网络类型，例如 tcp、tcp4、tcp6 与 udp。
+//   - address：目标地址，格式为 host:port。
+//
+// 返回值：
+//   - net.Conn：建立的连接。
+//   - error：解析失败或全部地址都连接失败时返回错误。
+//
+// 示例：
+//
+//	transport := &http.Transport{DialContext: r.DialContext}
+func (r *Resolver) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if nil != err {
+		return nil, err
+	}
+
+	ipNetwork := "ip"
+	switch {
+	case strings.HasSuffix(network, "4"):
+		ipNetwork = "ip4"
+	case strings.HasSuffix(network, "6"):
+		ipNetwork = "ip6"
+	}
+	addrs, err := r.LookupNetIP(ctx, ipNetwork, host)
+	if nil != err {
+		return nil, err
+	}
+
+	var errs []error
+	for _, addr := range addrs {
+		conn, err := r.o.dialer.DialContext(ctx, network, net.JoinHostPort(addr.String(), port))
+		if nil == err {
+			return conn, nil
+		}
+		errs = append(errs, err)
+		if nil != ctx.Err() {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// ContextDialer 返回只建立 TCP 连接的 DialContext，签名与 grpc.WithContextDialer 的参数一致。
+//
+// 返回值：
+//   - func(context.Context, string) (net.Conn, error)：建立 TCP 连接的函数。
+//
+// 示例：
+//
+//	conn, err := grpc.NewClient("passthrough:///user.internal:9090",
+//	    grpc.WithContextDialer(r.ContextDialer()),
+//	    grpc.WithTransportCredentials(insecure.NewCredentials()),
+//	)
+func (r *Resolver) ContextDialer() func(context.Context, string) (net.Conn, error) {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		return r.DialContext(ctx, "tcp", address)
+	}
+}
+
+// Forget 删除域名的缓存结果，下一次查询时重新向上游查询。
+//
+// 参数：
+//   - host：域名。
+func (r *Resolver) Forget(host string) {
+	r.cache.Delete(normalize(host))
+}
+
+// Close 停止缓存的后台清理，之后仍可以查询，但过期的结果只在查询时移除。
+func (r *Resolver) Close() {
+	r.cache.Close()
+}
+
+// lookup 返回缓存的结果，未命中时向上游查询。
+func (r *Resolver) lookup(ctx context.Context, host string) ([]netip.Addr, error) {
+	if "" == host {
+		return nil, fmt.Errorf("%w：%q", ErrNotFound, host)
+	}
+
+	if res, ok := r.cache.Get(host); ok {
+		if nil != res.err {
+			inc(r.negativeHits)
+		} else {
+			inc(r.hits)
+		}
+		return res.addrs, res.err
+	}
+
+	inc(r.misses)
+	cl := r.load(ctx, host)
+	select {
+	case <-cl.done:
+		return cl.res.addrs, cl.res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// load 返回域名上正在进行的查询，没有时启动新的查询。
+func (r *Resolver) load(ctx context.Context, host string) *call {
+	r.callMu.Lock()
+	defer r.callMu.Unlock()
+	if cl, ok := r.calls[host]; ok {
+		return cl
+	}
+
+	cl := &call{done: make(chan struct{})}
+	r.calls[host] = cl
+	go r.doLoad(context.WithoutCancel(ctx), host, cl)
+	return cl
+}
+
+// doLoad 向上游查询并按结果写入缓存，完成后唤醒全部等待的调用方。
+func (r *Resolver) doLoad(ctx context.Context, host string, cl *call) {
+	defer func() {
+		r.callMu.Lock()
+		delete(r.calls, host)
+		r.callMu.Unlock()
+		close(cl.done)
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, r.o.lookupTimeout)
+	defer cancel()
+	start := r.o.clock.Now()
+	ans, err := r.o.upstream.Lookup(ctx, host)
+	duration := r.o.clock.Since(start)
+	if nil == err && 0 == len(ans.Addrs) {
+		err = fmt.Errorf("%w：%q", ErrNotFound, host)
+	}
+
+	label := "success"
+	switch {
+	case nil == err:
+		cl.res = &result{addrs: ans.Addrs}
+		r.cache.SetWithTTL(host, cl.res, r.o.clampTTL(ans.TTL))
+	case errors.Is(err, ErrNotFound):
+		label = "not_found"
+		cl.res = &result{err: err}
+		if r.o.negativeTTL > 0 {
+			r.cache.SetWithTTL(host, cl.res, r.o.negativeTTL)
+		}
+	default:
+		label = "error"
+		cl.res = &result{err: fmt.Errorf("kit/dns: 解析 %s 失败：%w", host, err)}
+	}
+	if nil != r.upstreamDuration {
+		r.upstreamDuration.WithLabelValues(label).Observe(duration.Seconds())
+	}
+}
+
+// normalize 将域名转换为小写并去掉结尾的点，作为缓存的键。
+func normalize(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+// inc 在计数器不为 nil 时加一。
+func inc(c prometheus.Counter) {
+	if nil != c {
+		c.Inc()
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+type (
+	// fakeUpstream 按域名返回预设结果的上游，记录查询次数。
+	fakeUpstream struct {
+		mu      sync.Mutex
+		answers map[string]Answer
+		errs    map[string]error
+		calls   map[string]int
+		// block 不为 nil 时，查询等待其关闭后返回。
+		block chan struct{}
+	}
+)
+
+// newFakeUpstream 创建没有预设结果的上游。
+func newFakeUpstream() *fakeUpstream {
+	return &fakeUpstream{
+		answers: make(map[string]Answer),
+		errs:    make(map[string]error),
+		calls:   make(map[string]int),
+	}
+}
+
+// Lookup 返回预设的结果，没有预设时返回 ErrNotFound。
+func (u *fakeUpstream) Lookup(ctx context.Context, host string) (Answer, error) {
+	u.mu.Lock()
+	u.calls[host]++
+	block := u.block
+	u.mu.Unlock()
+	if nil != block {
+		select {
+		case <-block:
+		case <-ctx.Done():
+			return Answer{}, ctx.Err()
+		}
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err, ok := u.errs[host]; ok {
+		return Answer{}, err
+	}
+	if ans, ok := u.answers[host]; ok {
+		return Answer{Addrs: append([]netip.Addr(nil), ans.Addrs...), TTL: ans.TTL}, nil
+	}
+	return Answer{}, ErrNotFound
+}
+
+// set 设置域名的结果。
+func (u *fakeUpstream) set(host string, ttl time.Duration, addrs ...string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	ans := Answer{TTL: ttl}
+	for _, addr := range addrs {
+		ans.Addrs = append(ans.Addrs, netip.MustParseAddr(addr))
+	}
+	u.answers[host] = ans
+	delete(u.errs, host)
+}
+
+// fail 设置域名的错误。
+func (u *fakeUpstream) fail(host string, err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.errs[host] = err
+}
+
+// count 返回域名的查询次数。
+func (u *fakeUpstream) count(host string) int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.calls[host]
+}
+
+// newTestResolver 创建使用假上游与假时钟的解析器。
+func newTestResolver(t *testing.T, opts ...Option) (*Resolver, *fakeUpstream, *kittime.FakeClock) {
+	upstream := newFakeUpstream()
+	clock := kittime.NewFakeClock(time.Unix(0, 0))
+	r := New(append([]Option{WithUpstream(upstream), WithClock(clock), WithMetrics(false)}, opts...)...)
+	t.Cleanup(r.Close)
+	return r, upstream, clock
+}
+
+// TestResolver_TTL 测试按记录的 TTL 缓存以及 TTL 的范围。
+func TestResolver_TTL(t *testing.T) {
+	r, upstream, clock := newTestResolver(t)
+	ctx := context.Background()
+
+	upstream.set("a.test", 10*time.Second, "10.0.0.1", "::1")
+	for range 3 {
+		addrs, err := r.LookupNetIP(ctx, "ip", "a.test")
+		require.NoError(t, err)
+		assert.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("::1")}, addrs)
+	}
+	assert.Equal(t, 1, upstream.count("a.test"))
+
+	clock.Advance(9 * time.Second)
+	_, _ = r.LookupNetIP(ctx, "ip", "a.test")
+	assert.Equal(t, 1, upstream.count("a.test"))
+	clock.Advance(time.Second)
+	_, _ = r.LookupNetIP(ctx, "ip", "a.test")
+	assert.Equal(t, 2, upstream.count("a.test"))
+
+	// TTL 为 0 时使用最短有效期，没有 TTL 时使用默认有效期，超过最长有效期时取最长有效期。
+	upstream.set("zero.test", 0, "10.0.0.2")
+	upstream.set("unknown.test", -1, "10.0.0.3")
+	upstream.set("long.test", time.Hour, "10.0.0.4")
+	for _, host := range []string{"zero.test", "unknown.test", "long.test"} {
+		_, err := r.LookupNetIP(ctx, "ip", host)
+		require.NoError(t, err)
+	}
+	clock.Advance(time.Second)
+	for _, host := range []string{"zero.test", "unknown.test", "long.test"} {
+		_, _ = r.LookupNetIP(ctx, "ip", host)
+	}
+	assert.Equal(t, 2, upstream.count("zero.test"))
+	assert.Equal(t, 1, upstream.count("unknown.test"))
+	clock.Advance(30 * time.Second)
+	_, _ = r.LookupNetIP(ctx, "ip", "unknown.test")
+	assert.Equal(t, 2, upstream.count("unknown.test"))
+	clock.Advance(5 * time.Minute)
+	_, _ = r.LookupNetIP(ctx, "ip", "long.test")
+	assert.Equal(t, 2, upstream.count("long.test"))
+
+	// Forget 删除缓存结果，键不区分大小写与结尾的点。
+	_, _ = r.LookupNetIP(ctx, "ip", "A.TEST.")
+	assert.Equal(t, 3, upstream.count("a.test"))
+	r.Forget("a.test.")
+	_, _ = r.LookupNetIP(ctx, "ip", "a.test")
+	assert.Equal(t, 4, upstream.count("a.test"))
+}
+
+// TestResolver_Negative 测试否定缓存与查询失败不被缓存。
+func TestResolver_Negative(t *testing.T) {
+	r, upstream, clock := newTestResolver(t)
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := r.LookupNetIP(ctx, "ip", "missing.test")
+		assert.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, 1, upstream.count("missing.test"))
+	clock.Advance(5 * time.Second)
+	_, err := r.LookupNetIP(ctx, "ip", "missing.test")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 2, upstream.count("missing.test"))
+
+	// 没有地址视为不存在。
+	upstream.set("empty.test", time.Minute)
+	_, err = r.LookupNetIP(ctx, "ip", "empty.test")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// 查询失败不被缓存。
+	failure := errors.New("timeout")
+	upstream.fail("flaky.test", failure)
+	for range 2 {
+		_, err = r.LookupNetIP(ctx, "ip", "flaky.test")
+		assert.ErrorIs(t, err, failure)
+		assert.NotErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, 2, upstream.count("flaky.test"))
+
+	// 关闭否定缓存。
+	r, upstream, _ = newTestResolver(t, WithNegativeTTL(0))
+	for range 2 {
+		_, err = r.LookupNetIP(ctx, "ip", "missing.test")
+		assert.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, 2, upstream.count("missing.test"))
+}
+
+// TestResolver_Singleflight 测试同一个域名的并发查询只向上游查询一次。
+func TestResolver_Singleflight(t *testing.T) {
+	r, upstream, _ := newTestResolver(t)
+	upstream.set("a.test", time.Minute, "10.0.0.1")
+	upstream.block = make(chan struct{})
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := r.LookupNetIP(context.Background(), "ip", "a.test")
+			errs <- err
+		}()
+	}
+
+	// 调用方取消时停止等待，查询仍在进行。
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err := r.LookupNetIP(ctx, "ip", "a.test")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(upstream.block)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, upstream.count("a.test"))
+}
+
+// TestResolver_Lookup 测试地址类型、IP 字面量与各查询方法。
+func TestResolver_Lookup(t *testing.T) {
+	r, upstream, _ := newTestResolver(t)
+	ctx := context.Background()
+	upstream.set("a.test", time.Minute, "10.0.0.1", "fd00::1")
+
+	addrs, err := r.LookupNetIP(ctx, "ip4", "a.test")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.1")}, addrs)
+	addrs, err = r.LookupNetIP(ctx, "ip6", "a.test")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("fd00::1")}, addrs)
+
+	// 修改返回值不影响缓存。
+	addrs[0] = netip.MustParseAddr("fd00::2")
+	hosts, err := r.LookupHost(ctx, "a.test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "fd00::1"}, hosts)
+
+	ips, err := r.LookupIPAddr(ctx, "a.test")
+	require.NoError(t, err)
+	require.Len(t, ips, 2)
+	assert.Equal(t, "10.0.0.1", ips[0].IP.String())
+
+	// IP 字面量不经过上游。
+	addrs, err = r.LookupNetIP(ctx, "ip", "::ffff:10.0.0.9")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.9")}, addrs)
+	_, err = r.LookupNetIP(ctx, "ip6", "10.0.0.9")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = r.LookupNetIP(ctx, "tcp", "a.test")
+	assert.Error(t, err)
+	_, err = r.LookupNetIP(ctx, "ip", "")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = r.LookupHost(ctx, "missing.test")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = r.LookupIPAddr(ctx, "missing.test")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 1, upstream.count("a.test"))
+}
+
+// TestResolver_DialContext 测试解析域名后建立连接。
+func TestResolver_DialContext(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if nil != err {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+
+	r, upstream, _ := newTestResolver(t)
+	upstream.set("svc.test", time.Minute, "fd00::1", "127.0.0.1")
+	ctx := context.Background()
+
+	// tcp4 只尝试 IPv4 地址。
+	conn, err := r.DialContext(ctx, "tcp4", net.JoinHostPort("svc.test", port))
+	require.NoError(t, err)
+	assert.Equal(t, ln.Addr().String(), conn.RemoteAddr().String())
+	_ = conn.Close()
+
+	conn, err = r.ContextDialer()(ctx, net.JoinHostPort("127.0.0.1", port))
+	require.NoError(t, err)
+	_ = conn.Close()
+
+	_, err = r.DialContext(ctx, "tcp", "svc.test")
+	assert.Error(t, err)
+	_, err = r.DialContext(ctx, "tcp", "missing.test:80")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// 全部地址都连接失败。
+	_ = ln.Close()
+	_, err = r.DialContext(ctx, "tcp6", net.JoinHostPort("::1", port))
+	assert.Error(t, err)
+	_, err = r.DialContext(ctx, "tcp4", net.JoinHostPort("svc.test", port))
+	assert.Error(t, err)
+}
+
+// TestResolver_Metrics 测试指标。
+func TestResolver_Metrics(t *testing.T) {
+	name := t.Name()
+	r, upstream, _ := newTestResolver(t, WithName(name), WithMetrics(true))
+	ctx := context.Background()
+	upstream.set("a.test", time.Minute, "10.0.0.1")
+	upstream.fail("flaky.test", errors.New("timeout"))
+
+	_, _ = r.LookupNetIP(ctx, "ip", "a.test")
+	_, _ = r.LookupNetIP(ctx, "ip", "a.test")
+	_, _ = r.LookupNetIP(ctx, "ip", "missing.test")
+	_, _ = r.LookupNetIP(ctx, "ip", "missing.test")
+	_, _ = r.LookupNetIP(ctx, "ip", "flaky.test")
+	_, _ = r.LookupNetIP(ctx, "ip", "10.0.0.1")
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricLookups.WithLabelValues(name, "hit")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricLookups.WithLabelValues(name, "negative_hit")))
+	assert.Equal(t, float64(3), testutil.ToFloat64(MetricLookups.WithLabelValues(name, "miss")))
+	for _, label := range []string{"success", "not_found", "error"} {
+		h, err := MetricUpstreamDuration.GetMetricWithLabelValues(name, label)
+		require.NoError(t, err)
+		assert.Equal(t, 1, testutil.CollectAndCount(h.(prometheus.Histogram)))
+	}
+}
+
+// TestOptions 测试配置选项与默认值。
+func TestOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, nameDefault, o.name)
+	assert.Equal(t, defaultTTLDefault, o.defaultTTL)
+	assert.Equal(t, minTTLDefault, o.minTTL)
+	assert.Equal(t, maxTTLDefault, o.maxTTL)
+	assert.Equal(t, negativeTTLDefault, o.negativeTTL)
+	assert.Equal(t, maxEntriesDefault, o.maxEntries)
+	assert.Equal(t, lookupTimeoutDefault, o.lookupTimeout)
+	assert.Equal(t, dialTimeoutDefault, o.dialer.Timeout)
+	assert.IsType(t, &systemUpstream{}, o.upstream)
+
+	o = newOptions(
+		WithName(""),
+		WithDefaultTTL(-1),
+		WithTTLRange(0, -1),
+		WithNegativeTTL(-1),
+		WithMaxEntries(0),
+		WithLookupTimeout(0),
+		WithDialer(nil),
+		WithClock(nil),
+	)
+	assert.Equal(t, nameDefault, o.name)
+	assert.Equal(t, defaultTTLDefault, o.defaultTTL)
+	assert.Equal(t, minTTLDefault, o.minTTL)
+	assert.Equal(t, minTTLDefault, o.maxTTL)
+	assert.Equal(t, negativeTTLDefault, o.negativeTTL)
+	assert.Equal(t, maxEntriesDefault, o.maxEntries)
+	assert.Equal(t, lookupTimeoutDefault, o.lookupTimeout)
+	assert.NotNil(t, o.dialer)
+	assert.NotNil(t, o.clock)
+
+	o = newOptions(WithTTLRange(time.Second, time.Minute), WithDefaultTTL(10*time.Second))
+	assert.Equal(t, 10*time.Second, o.clampTTL(-1))
+	assert.Equal(t, time.Second, o.clampTTL(0))
+	assert.Equal(t, time.Minute, o.clampTTL(time.Hour))
+}
@@ -0,0 +1,359 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// udpSize 是通过 EDNS0 声明的 UDP 响应的最大字节数，避免响应在传输中被分片。
+	udpSize = 1232
+	// defaultPort 是 DNS 服务器的默认端口。
+	defaultPort = "53"
+)
+
+var (
+	// ErrNotFound 表示域名不存在或没有地址，该结果按 WithNegativeTTL 缓存。
+	ErrNotFound = errors.New("kit/dns: 域名不存在或没有地址")
+)
+
+type (
+	// Answer 是上游返回的查询结果。
+	Answer struct {
+		// Addrs 是域名的地址。
+		Addrs []netip.Addr
+		// TTL 是结果的有效期，小于 0 时表示上游没有提供，由解析器使用 WithDefaultTTL 设置的值。
+		TTL time.Duration
+	}
+
+	// Upstream 定义了解析器查询域名的上游。
+	Upstream interface {
+		// Lookup 查询域名的 IPv4 与 IPv6 地址。
+		//
+		// 参数：
+		//   - ctx：查询的上下文，超时由解析器的 WithLookupTimeout 控制。
+		//   - host：域名，已转换为小写且不以点结尾。
+		//
+		// 返回值：
+		//   - Answer：查询结果。
+		//   - error：域名不存在或没有地址时返回包装了 ErrNotFound 的错误，其余错误不被缓存。
+		Lookup(ctx context.Context, host string) (Answer, error)
+	}
+
+	// systemUpstream 通过 net.Resolver 查询，使用系统的配置与 hosts 文件，但无法得到记录的 TTL。
+	systemUpstream struct {
+		// r 是实际查询的解析器。
+		r *net.Resolver
+	}
+
+	// serverUpstream 直接向 DNS 服务器发送查询，使用记录的 TTL。
+	serverUpstream struct {
+		// servers 是按顺序尝试的服务器地址，包含端口。
+		servers []string
+		// dialer 是连接服务器使用的 Dialer。
+		dialer net.Dialer
+	}
+
+	// exchangeResult 是一种记录类型的查询结果。
+	exchangeResult struct {
+		// addrs 是查询到的地址。
+		addrs []netip.Addr
+		// ttl 是应答中所有记录的最小 TTL。
+		ttl time.Duration
+		// err 是查询的错误。
+		err error
+	}
+)
+
+// SystemUpstream 返回通过 net.Resolver 查询的上游，使用系统的 DNS 配置与 hosts 文件。
+// net.Resolver 不提供记录的 TTL，结果按 WithDefaultTTL 缓存。
+//
+// 参数：
+//   - r：实际查询的解析器，为 nil 时使用 net.DefaultResolver。
+//
+// 返回值：
+//   - Upstream：上游实例。
+func SystemUpstream(r *net.Resolver) Upstream {
+	if nil == r {
+		r = net.DefaultResolver
+	}
+	return &systemUpstream{r: r}
+}
+
+// Lookup 通过 net.Resolver 查询域名的地址。
+func (u *systemUpstream) Lookup(ctx context.Context, host string) (Answer, error) {
+	addrs, err := u.r.LookupNetIP(ctx, "ip", host)
+	if nil != err {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return Answer{}, fmt.Errorf("%w：%w", ErrNotFound, err)
+		}
+		return Answer{}, err
+	}
+	for i, addr := range addrs {
+		addrs[i] = addr.Unmap()
+	}
+	return Answer{Addrs: addrs, TTL: -1}, nil
+}
+
+// ServerUpstream 返回直接向 DNS 服务器查询的上游，结果按记录的 TTL 缓存。
+// 通过 UDP 查询，响应被截断时改用 TCP；服务器无响应或返回错误时按顺序尝试下一个服务器。
+// 不读取 hosts 文件，因此 localhost 等只在 hosts 文件中配置的域名无法解析。
+//
+// 参数：
+//   - servers：DNS 服务器的地址，例如 "10.0.0.2" 或 "10.0.0.2:53"，省略端口时为 53。
+//
+// 返回值：
+//   - Upstream：上游实例。
+//
+// 示例：
+//
+//	r := dns.New(dns.WithUpstream(dns.ServerUpstream("10.0.0.2", "10.0.0.3")))
+func ServerUpstream(servers ...string) Upstream {
+	u := &serverUpstream{}
+	for _, server := range servers {
+		server = strings.TrimSpace(server)
+		if "" == server {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(server); nil != err {
+			server = net.JoinHostPort(strings.Trim(server, "[]"), defaultPort)
+		}
+		u.servers = append(u.servers, server)
+	}
+	return u
+}
+
+// Lookup 同时查询 A 与 AAAA 记录，IPv4 地址在前。
+func (u *serverUpstream) Lookup(ctx context.Context, host string) (Answer, error) {
+	if 0 == len(u.servers) {
+		return Answer{}, errors.New("kit/dns: 没有配置 DNS 服务器")
+	}
+	name, err := dnsmessage.NewName(host + ".")
+	if nil != err {
+		return Answer{}, fmt.Errorf("%w：%q", ErrNotFound, host)
+	}
+
+	a, aaaa := make(chan exchangeResult, 1), make(chan exchangeResult, 1)
+	go func() { a <- u.query(ctx, name, dnsmessage.TypeA) }()
+	go func() { aaaa <- u.query(ctx, name, dnsmessage.TypeAAAA) }()
+	results := []exchangeResult{<-a, <-aaaa}
+
+	var (
+		ans     Answer
+		errs    []error
+		found   bool
+		minTTL  time.Duration
+		missing int
+	)
+	for _, res := range results {
+		switch {
+		case nil == res.err:
+			ans.Addrs = append(ans.Addrs, res.addrs...)
+			if !found || res.ttl < minTTL {
+				minTTL = res.ttl
+			}
+			found = true
+		case errors.Is(res.err, ErrNotFound):
+			missing++
+		default:
+			errs = append(errs, res.err)
+		}
+	}
+	if found {
+		ans.TTL = minTTL
+		return ans, nil
+	}
+	if len(results) == missing {
+		return Answer{}, fmt.Errorf("%w：%q", ErrNotFound, host)
+	}
+	return Answer{}, errors.Join(errs...)
+}
+
+// query 按顺序向服务器查询一种记录，直到得到确定的结果。
+func (u *serverUpstream) query(ctx context.Context, name dnsmessage.Name, qtype dnsmessage.Type) exchangeResult {
+	var errs []error
+	for _, server := range u.servers {
+		res := u.exchange(ctx, server, name, qtype)
+		if nil == res.err || errors.Is(res.err, ErrNotFound) {
+			return res
+		}
+		errs = append(errs, res.err)
+		if nil != ctx.Err() {
+			break
+		}
+	}
+	return exchangeResult{err: errors.Join(errs...)}
+}
+
+// exchange 向一个服务器查询一种记录，UDP 响应被截断时改用 TCP。
+func (u *serverUpstream) exchange(ctx context.Context, server string, name dnsmessage.Name, qtype dnsmessage.Type) exchangeResult {
+	id := uint16(rand.Uint32())
+	query, err := buildQuery(id, name, qtype)
+	if nil != err {
+		return exchangeResult{err: err}
+	}
+
+	resp, err := u.roundTrip(ctx, "udp", server, query)
+	if nil == err {
+		var h dnsmessage.Header
+		if h, err = peekHeader(resp); nil == err && h.Truncated {
+			resp, err = u.roundTrip(ctx, "tcp", server, query)
+		}
+	}
+	if nil != err {
+		return exchangeResult{err: fmt.Errorf("kit/dns: 查询 %s 失败：%w", server, err)}
+	}
+
+	res := parseResponse(resp, id, qtype)
+	if nil != res.err && !errors.Is(res.err, ErrNotFound) {
+		res.err = fmt.Errorf("kit/dns: 查询 %s 失败：%w", server, res.err)
+	}
+	return res
+}
+
+// roundTrip 发送查询并读取响应，TCP 时消息带有两个字节的长度前缀。
+func (u *serverUpstream) roundTrip(ctx context.Context, network, server string, query []byte) ([]byte, error) {
+	conn, err := u.dialer.DialContext(ctx, network, server)
+	if nil != err {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if "udp" == network {
+		if _, err := conn.Write(query); nil != err {
+			return nil, err
+		}
+		buf := make([]byte, udpSize)
+		n, err := conn.Read(buf)
+		if nil != err {
+			return nil, err
+		}
+		return buf[:n], nil
+	}
+
+	msg := make([]byte, 2+len(query))
+	binary.BigEndian.PutUint16(msg, uint16(len(query)))
+	copy(msg[2:], query)
+	if _, err := conn.Write(msg); nil != err {
+		return nil, err
+	}
+	var size [2]byte
+	if _, err := io.ReadFull(conn, size[:]); nil != err {
+		return nil, err
+	}
+	buf := make([]byte, binary.BigEndian.Uint16(size[:]))
+	if _, err := io.ReadFull(conn, buf); nil != err {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// buildQuery 构造启用递归与 EDNS0 的查询消息。
+func buildQuery(id uint16, name dnsmessage.Name, qtype dnsmessage.Type) ([]byte, error) {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	b.EnableCompression()
+	if err := b.StartQuestions(); nil != err {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: name, Type: qtype, Class: dnsmessage.ClassINET}); nil != err {
+		return nil, err
+	}
+	if err := b.StartAdditionals(); nil != err {
+		return nil, err
+	}
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(udpSize, dnsmessage.RCodeSuccess, false); nil != err {
+		return nil, err
+	}
+	if err := b.OPTResource(opt, dnsmessage.OPTResource{}); nil != err {
+		return nil, err
+	}
+	return b.Finish()
+}
+
+// peekHeader 解析响应的消息头。
+func peekHeader(resp []byte) (dnsmessage.Header, error) {
+	var p dnsmessage.Parser
+	return p.Start(resp)
+}
+
+// parseResponse 解析响应中请求类型的地址与最小 TTL。
+func parseResponse(resp []byte, id uint16, qtype dnsmessage.Type) exchangeResult {
+	var p dnsmessage.Parser
+	h, err := p.Start(resp)
+	if nil != err {
+		return exchangeResult{err: err}
+	}
+	if !h.Response || id != h.ID {
+		return exchangeResult{err: errors.New("响应与查询不匹配")}
+	}
+	switch h.RCode {
+	case dnsmessage.RCodeSuccess:
+	case dnsmessage.RCodeNameError:
+		return exchangeResult{err: ErrNotFound}
+	default:
+		return exchangeResult{err: fmt.Errorf("服务器返回 %s", h.RCode)}
+	}
+	if err := p.SkipAllQuestions(); nil != err {
+		return exchangeResult{err: err}
+	}
+
+	var res exchangeResult
+	for i := 0; ; i++ {
+		rh, err := p.AnswerHeader()
+		if errors.Is(err, dnsmessage.ErrSectionDone) {
+			break
+		}
+		if nil != err {
+			return exchangeResult{err: err}
+		}
+		ttl := time.Duration(rh.TTL) * time.Second
+		if 0 == i || ttl < res.ttl {
+			res.ttl = ttl
+		}
+		switch {
+		case dnsmessage.TypeA == rh.Type && dnsmessage.TypeA == qtype:
+			r, err := p.AResource()
+			if nil != err {
+				return exchangeResult{err: err}
+			}
+			res.addrs = append(res.addrs, netip.AddrFrom4(r.A))
+		case dnsmessage.TypeAAAA == rh.Type && dnsmessage.TypeAAAA == qtype:
+			r, err := p.AAAAResource()
+			if nil != err {
+				return exchangeResult{err: err}
+			}
+			res.addrs = append(res.addrs, netip.AddrFrom16(r.AAAA).Unmap())
+		default:
+			if err := p.SkipAnswer(); nil != err {
+				return exchangeResult{err: err}
+			}
+		}
+	}
+	if 0 == len(res.addrs) {
+		return exchangeResult{err: ErrNotFound}
+	}
+	return res
+}
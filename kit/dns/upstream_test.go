@@ -0,0 +1,299 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type (
+	// fakeServer 是同时监听 UDP 与 TCP 的 DNS 服务器，按域名返回预设的应答。
+	fakeServer struct {
+		// addr 是 UDP 与 TCP 共同的监听地址。
+		addr string
+		udp  net.PacketConn
+		tcp  net.Listener
+	}
+)
+
+// newFakeServer 在本机的随机端口上启动 DNS 服务器，测试结束时关闭。
+func newFakeServer(t *testing.T) *fakeServer {
+	udp, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	tcp, err := net.Listen("tcp4", udp.LocalAddr().String())
+	if nil != err {
+		_ = udp.Close()
+		t.Skipf("无法在同一端口监听 TCP：%v", err)
+	}
+	s := &fakeServer{addr: udp.LocalAddr().String(), udp: udp, tcp: tcp}
+	t.Cleanup(func() {
+		_ = udp.Close()
+		_ = tcp.Close()
+	})
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, from, err := udp.ReadFrom(buf)
+			if nil != err {
+				return
+			}
+			if resp := s.answer(buf[:n], true); nil != resp {
+				_, _ = udp.WriteTo(resp, from)
+			}
+		}
+	}()
+	go func() {
+		for {
+			conn, err := tcp.Accept()
+			if nil != err {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				var size [2]byte
+				if _, err := io.ReadFull(conn, size[:]); nil != err {
+					return
+				}
+				query := make([]byte, binary.BigEndian.Uint16(size[:]))
+				if _, err := io.ReadFull(conn, query); nil != err {
+					return
+				}
+				resp := s.answer(query, false)
+				msg := binary.BigEndian.AppendUint16(nil, uint16(len(resp)))
+				_, _ = conn.Write(append(msg, resp...))
+			}()
+		}
+	}()
+	return s
+}
+
+// answer 构造查询的应答：
+//   - a.test：A 10.0.0.1（TTL 60）与 AAAA ::1（TTL 30）。
+//   - alias.test：CNAME 到 a.test（TTL 20），以及 a.test 的 A 记录。
+//   - v4.test：只有 A 10.0.0.2，AAAA 没有记录。
+//   - big.test：UDP 时返回截断的应答，TCP 时返回 A 10.0.0.3。
+//   - fail.test：返回 SERVFAIL。
+//   - 其余域名返回 NXDOMAIN。
+func (s *fakeServer) answer(query []byte, udp bool) []byte {
+	var p dnsmessage.Parser
+	h, err := p.Start(query)
+	if nil != err {
+		return nil
+	}
+	q, err := p.Question()
+	if nil != err {
+		return nil
+	}
+
+	rh := dnsmessage.Header{ID: h.ID, Response: true, RecursionDesired: h.RecursionDesired, RecursionAvailable: true}
+	type record struct {
+		name string
+		ttl  uint32
+		body dnsmessage.ResourceBody
+	}
+	var records []record
+	a := func(ip string) dnsmessage.ResourceBody {
+		return &dnsmessage.AResource{A: netip.MustParseAddr(ip).As4()}
+	}
+	switch q.Name.String() {
+	case "a.test.":
+		switch q.Type {
+		case dnsmessage.TypeA:
+			records = append(records, record{"a.test.", 60, a("10.0.0.1")})
+		case dnsmessage.TypeAAAA:
+			records = append(records, record{"a.test.", 30, &dnsmessage.AAAAResource{AAAA: netip.MustParseAddr("::1").As16()}})
+		}
+	case "alias.test.":
+		records = append(records, record{"alias.test.", 20, &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("a.test.")}})
+		if dnsmessage.TypeA == q.Type {
+			records = append(records, record{"a.test.", 60, a("10.0.0.1")})
+		}
+	case "v4.test.":
+		if dnsmessage.TypeA == q.Type {
+			records = append(records, record{"v4.test.", 60, a("10.0.0.2")})
+		}
+	case "big.test.":
+		if udp {
+			rh.Truncated = true
+		} else if dnsmessage.TypeA == q.Type {
+			records = append(records, record{"big.test.", 60, a("10.0.0.3")})
+		}
+	case "fail.test.":
+		rh.RCode = dnsmessage.RCodeServerFailure
+	default:
+		rh.RCode = dnsmessage.RCodeNameError
+	}
+
+	b := dnsmessage.NewBuilder(nil, rh)
+	_ = b.StartQuestions()
+	_ = b.Question(q)
+	_ = b.StartAnswers()
+	for _, r := range records {
+		hdr := dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(r.name), Class: dnsmessage.ClassINET, TTL: r.ttl}
+		switch body := r.body.(type) {
+		case *dnsmessage.AResource:
+			_ = b.AResource(hdr, *body)
+		case *dnsmessage.AAAAResource:
+			_ = b.AAAAResource(hdr, *body)
+		case *dnsmessage.CNAMEResource:
+			_ = b.CNAMEResource(hdr, *body)
+		}
+	}
+	resp, _ := b.Finish()
+	return resp
+}
+
+// TestServerUpstream 测试直接向 DNS 服务器查询。
+func TestServerUpstream(t *testing.T) {
+	s := newFakeServer(t)
+	u := ServerUpstream(s.addr)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ans, err := u.Lookup(ctx, "a.test")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("::1")}, ans.Addrs)
+	assert.Equal(t, 30*time.Second, ans.TTL)
+
+	// CNAME 的 TTL 参与计算最小值。
+	ans, err = u.Lookup(ctx, "alias.test")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.1")}, ans.Addrs)
+	assert.Equal(t, 20*time.Second, ans.TTL)
+
+	ans, err = u.Lookup(ctx, "v4.test")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.2")}, ans.Addrs)
+
+	// UDP 应答被截断时改用 TCP。
+	ans, err = u.Lookup(ctx, "big.test")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.3")}, ans.Addrs)
+
+	_, err = u.Lookup(ctx, "missing.test")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = u.Lookup(ctx, "fail.test")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotFound)
+
+	// 域名过长。
+	long := make([]byte, 300)
+	for i := range long {
+		long[i] = 'a'
+	}
+	_, err = u.Lookup(ctx, string(long))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// TestServerUpstream_Failover 测试服务器无响应时尝试下一个服务器。
+func TestServerUpstream_Failover(t *testing.T) {
+	s := newFakeServer(t)
+
+	// 占用一个端口后关闭，向其发送的 UDP 查询会收到端口不可达。
+	closed, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	dead := closed.LocalAddr().String()
+	_ = closed.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ans, err := ServerUpstream(dead, s.addr).Lookup(ctx, "a.test")
+	require.NoError(t, err)
+	assert.Len(t, ans.Addrs, 2)
+
+	_, err = ServerUpstream(dead).Lookup(ctx, "a.test")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotFound)
+
+	_, err = ServerUpstream().Lookup(ctx, "a.test")
+	assert.Error(t, err)
+
+	// 上下文结束时不再尝试。
+	done, cancelDone := context.WithCancel(context.Background())
+	cancelDone()
+	_, err = ServerUpstream(s.addr, s.addr).Lookup(done, "a.test")
+	assert.Error(t, err)
+}
+
+// TestServerUpstream_Servers 测试服务器地址的默认端口。
+func TestServerUpstream_Servers(t *testing.T) {
+	u := ServerUpstream("10.0.0.2", " ", "[::1]", "10.0.0.3:5353").(*serverUpstream)
+	assert.Equal(t, []string{"10.0.0.2:53", "[::1]:53", "10.0.0.3:5353"}, u.servers)
+}
+
+// TestParseResponse 测试无法解析或不匹配的应答。
+func TestParseResponse(t *testing.T) {
+	name := dnsmessage.MustNewName("a.test.")
+	query, err := buildQuery(1, name, dnsmessage.TypeA)
+	require.NoError(t, err)
+
+	// 查询本身不是应答。
+	res := parseResponse(query, 1, dnsmessage.TypeA)
+	assert.Error(t, res.err)
+
+	s := &fakeServer{}
+	resp := s.answer(query, false)
+	res = parseResponse(resp, 2, dnsmessage.TypeA)
+	assert.Error(t, res.err)
+	res = parseResponse(resp, 1, dnsmessage.TypeA)
+	require.NoError(t, res.err)
+	assert.Equal(t, time.Minute, res.ttl)
+
+	res = parseResponse(resp[:len(resp)-2], 1, dnsmessage.TypeA)
+	assert.Error(t, res.err)
+	res = parseResponse(nil, 1, dnsmessage.TypeA)
+	assert.Error(t, res.err)
+}
+
+// TestSystemUpstream 测试通过 net.Resolver 查询。
+func TestSystemUpstream(t *testing.T) {
+	s := newFakeServer(t)
+	var d net.Dialer
+	u := SystemUpstream(&net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return d.DialContext(ctx, network, s.addr)
+		},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ans, err := u.Lookup(ctx, "a.test")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("::1")}, ans.Addrs)
+	assert.Less(t, ans.TTL, time.Duration(0))
+
+	_, err = u.Lookup(ctx, "missing.test")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = u.Lookup(ctx, "fail.test")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotFound)
+
+	assert.Same(t, net.DefaultResolver, SystemUpstream(nil).(*systemUpstream).r)
+}
+
+// TestResolver_ServerUpstream 测试解析器使用服务器的 TTL。
+func TestResolver_ServerUpstream(t *testing.T) {
+	s := newFakeServer(t)
+	r := New(WithUpstream(ServerUpstream(s.addr)), WithMetrics(false))
+	defer r.Close()
+
+	hosts, err := r.LookupHost(context.Background(), "alias.test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, hosts)
+}
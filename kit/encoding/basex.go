@@ -0,0 +1,299 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package encoding
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+const (
+	// alphabetBase58 是 Bitcoin 使用的 Base58 字母表，去掉了容易混淆的 0、O、I、l。
+	alphabetBase58 = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// alphabetBase62 是 Base62 字母表，按 ASCII 顺序排列。
+	alphabetBase62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+var (
+	// Base58 是使用 Bitcoin 字母表的 Base58 编码，编码结果不包含容易混淆的字符，适合需要人工抄写的场景。
+	Base58 = mustNewEncoding(alphabetBase58)
+	// Base62 是使用数字与大小写字母的 Base62 编码，比 Base58 略短。
+	Base62 = mustNewEncoding(alphabetBase62)
+)
+
+type (
+	// Encoding 是以字母表的长度为基数的进制编码，把字节序列视为一个大端序的大整数，转换为该进制的数字串。
+	// 与 Base64 不同，编码结果没有填充字符，只包含字母表中的字符，Base58 与 Base62 的结果可以直接用于 URL、文件名与缓存键。
+	//
+	// 字节序列开头的每个 0 字节编码为一个字母表的第一个字符，解码时还原，因此编码与解码是一一对应的。
+	// 编码需要对整个输入做多次除法，耗时与输入长度的平方成正比，适用于标识、令牌等短数据。
+	//
+	// Encoding 是不可变的，可以在多个协程中并发使用。
+	Encoding struct {
+		// alphabet 是字母表，下标为字符代表的数值。
+		alphabet string
+		// index 是字符到数值的映射，不在字母表中的字符为 -1。
+		index [256]int16
+		// base 是进制，等于字母表的长度。
+		base uint32
+		// encodeFactor 是每个字节编码后的最大字符数。
+		encodeFactor float64
+		// decodeFactor 是每个字符解码后的最大字节数。
+		decodeFactor float64
+	}
+)
+
+// NewEncoding 使用指定的字母表创建进制编码。
+//
+// 参数：
+//   - alphabet：字母表，长度在 2 到 256 之间且不包含重复的字节，第一个字符代表 0。
+//
+// 返回值：
+//   - *Encoding：进制编码。
+//   - error：字母表不合法时返回 ErrInvalidAlphabet。
+//
+// 示例：
+//
+//	// 只包含小写字母与数字的 Base36 编码。
+//	base36, err := encoding.NewEncoding("0123456789abcdefghijklmnopqrstuvwxyz")
+func NewEncoding(alphabet string) (*Encoding, error) {
+	if len(alphabet) < 2 || len(alphabet) > 256 {
+		return nil, fmt.Errorf("%w：长度为 %d", ErrInvalidAlphabet, len(alphabet))
+	}
+
+	e := &Encoding{
+		alphabet: alphabet,
+		base:     uint32(len(alphabet)),
+	}
+	for i := range e.index {
+		e.index[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		if -1 != e.index[alphabet[i]] {
+			return nil, fmt.Errorf("%w：字符 %q 重复", ErrInvalidAlphabet, alphabet[i])
+		}
+		e.index[alphabet[i]] = int16(i)
+	}
+	e.encodeFactor = math.Log(256) / math.Log(float64(e.base))
+	e.decodeFactor = math.Log(float64(e.base)) / math.Log(256)
+	return e, nil
+}
+
+// mustNewEncoding 创建进制编码，字母表不合法时 panic，用于初始化包级变量。
+func mustNewEncoding(alphabet string) *Encoding {
+	e, err := NewEncoding(alphabet)
+	if nil != err {
+		panic(err)
+	}
+	return e
+}
+
+// EncodeToString 编码字节序列。
+//
+// 参数：
+//   - src：待编码的字节序列。
+//
+// 返回值：
+//   - string：编码结果，src 为空时返回空字符串。
+//
+// 示例：
+//
+//	token := encoding.Base58.EncodeToString(crypto.RandomBytes(16))
+func (e *Encoding) EncodeToString(src []byte) string {
+	return string(e.AppendEncode(nil, src))
+}
+
+// AppendEncode 编码字节序列并追加到 dst。
+//
+// 参数：
+//   - dst：目标缓冲区，可以为 nil。
+//   - src：待编码的字节序列。
+//
+// 返回值：
+//   - []byte：追加了编码结果的 dst。
+func (e *Encoding) AppendEncode(dst, src []byte) []byte {
+	zeros := 0
+	for zeros < len(src) && 0 == src[zeros] {
+		zeros++
+	}
+
+	// digits 保存大端序的各位数字，high 之前的位置尚未使用。
+	size := int(math.Ceil(float64(len(src)-zeros)*e.encodeFactor)) + 1
+	digits := make([]byte, size)
+	high := size - 1
+	for _, b := range src[zeros:] {
+		carry := uint32(b)
+		j := size - 1
+		for ; j > high || 0 != carry; j-- {
+			carry += uint32(digits[j]) << 8
+			digits[j] = byte(carry % e.base)
+			carry /= e.base
+		}
+		high = j
+	}
+
+	start := high + 1
+	for start < size && 0 == digits[start] {
+		start++
+	}
+	dst = slices.Grow(dst, zeros+size-start)
+	for i := 0; i < zeros; i++ {
+		dst = append(dst, e.alphabet[0])
+	}
+	for _, d := range digits[start:] {
+		dst = append(dst, e.alphabet[d])
+	}
+	return dst
+}
+
+// DecodeString 解码 EncodeToString 的结果。
+//
+// 参数：
+//   - s：编码。
+//
+// 返回值：
+//   - []byte：解码得到的字节序列，s 为空时返回空切片。
+//   - error：包含字母表以外的字符时返回 ErrInvalidCharacter。
+func (e *Encoding) DecodeString(s string) ([]byte, error) {
+	return e.appendDecode(nil, s)
+}
+
+// AppendDecode 解码 src 并追加到 dst。
+//
+// 参数：
+//   - dst：目标缓冲区，可以为 nil。
+//   - src：编码。
+//
+// 返回值：
+//   - []byte：追加了解码结果的 dst，出错时为原来的 dst。
+//   - error：包含字母表以外的字符时返回 ErrInvalidCharacter。
+func (e *Encoding) AppendDecode(dst, src []byte) ([]byte, error) {
+	return e.appendDecode(dst, string(src))
+}
+
+// appendDecode 解码 s 并追加到 dst。
+func (e *Encoding) appendDecode(dst []byte, s string) ([]byte, error) {
+	zero := e.alphabet[0]
+	zeros := 0
+	for zeros < len(s) && zero == s[zeros] {
+		zeros++
+	}
+
+	// bytes 保存大端序的解码结果，high 之前的位置尚未使用。
+	size := int(math.Ceil(float64(len(s)-zeros)*e.decodeFactor)) + 1
+	bytes := make([]byte, size)
+	high := size - 1
+	for i := zeros; i < len(s); i++ {
+		v := e.index[s[i]]
+		if v < 0 {
+			return dst, fmt.Errorf("%w：位置 %d 的字符 %q", ErrInvalidCharacter, i, s[i])
+		}
+		carry := uint32(v)
+		j := size - 1
+		for ; j > high || 0 != carry; j-- {
+			carry += uint32(bytes[j]) * e.base
+			bytes[j] = byte(carry)
+			carry >>= 8
+		}
+		high = j
+	}
+
+	start := high + 1
+	for start < size && 0 == bytes[start] {
+		start++
+	}
+	dst = slices.Grow(dst, zeros+size-start)
+	for i := 0; i < zeros; i++ {
+		dst = append(dst, 0)
+	}
+	return append(dst, bytes[start:]...), nil
+}
+
+// EncodeUint64 编码无符号整数，0 编码为字母表的第一个字符。
+// 编码结果不补齐长度，需要按字典序排序时使用 AppendUint64 并指定宽度。
+//
+// 参数：
+//   - v：待编码的整数。
+//
+// 返回值：
+//   - string：编码结果。
+//
+// 示例：
+//
+//	encoding.Base62.EncodeUint64(1234567890) // "1LY7VK"
+func (e *Encoding) EncodeUint64(v uint64) string {
+	return string(e.AppendUint64(nil, v, 0))
+}
+
+// AppendUint64 编码无符号整数并追加到 dst，不足 width 位时在前面补字母表的第一个字符。
+// 字母表按 ASCII 顺序排列（例如 Base62）时，宽度相同的编码结果的字典序与数值的大小顺序一致。
+//
+// 参数：
+//   - dst：目标缓冲区，可以为 nil。
+//   - v：待编码的整数。
+//   - width：最小宽度，小于等于 0 时不补齐。
+//
+// 返回值：
+//   - []byte：追加了编码结果的 dst。
+//
+// 示例：
+//
+//	// 11 位 Base62 足以表示任意 uint64，补齐后可以按字符串排序。
+//	key := encoding.Base62.AppendUint64(buf, userID, 11)
+func (e *Encoding) AppendUint64(dst []byte, v uint64, width int) []byte {
+	var buf [64]byte
+	i := len(buf)
+	base := uint64(e.base)
+	for {
+		i--
+		buf[i] = e.alphabet[v%base]
+		v /= base
+		if 0 == v {
+			break
+		}
+	}
+	for n := len(buf) - i; n < width; n++ {
+		dst = append(dst, e.alphabet[0])
+	}
+	return append(dst, buf[i:]...)
+}
+
+// DecodeUint64 解码 EncodeUint64 或 AppendUint64 的结果。
+//
+// 参数：
+//   - s：编码，可以带有补齐的前导字符。
+//
+// 返回值：
+//   - uint64：解码得到的整数。
+//   - error：s 为空或包含字母表以外的字符时返回 ErrInvalidCharacter，超出 uint64 的范围时返回 ErrOverflow。
+func (e *Encoding) DecodeUint64(s string) (uint64, error) {
+	if "" == s {
+		return 0, fmt.Errorf("%w：编码为空", ErrInvalidCharacter)
+	}
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		d := e.index[s[i]]
+		if d < 0 {
+			return 0, fmt.Errorf("%w：位置 %d 的字符 %q", ErrInvalidCharacter, i, s[i])
+		}
+		hi, lo := bits.Mul64(v, uint64(e.base))
+		lo, carry := bits.Add64(lo, uint64(d), 0)
+		if 0 != hi || 0 != carry {
+			return 0, fmt.Errorf("%w：%q", ErrOverflow, s)
+		}
+		v = lo
+	}
+	return v, nil
+}
+
+// Alphabet 返回字母表。
+//
+// 返回值：
+//   - string：字母表。
+func (e *Encoding) Alphabet() string {
+	return e.alphabet
+}
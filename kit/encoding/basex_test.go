@@ -0,0 +1,215 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package encoding
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBase58_Vectors 测试 Base58 与 Bitcoin 实现的测试向量一致。
+func TestBase58_Vectors(t *testing.T) {
+	tests := []struct {
+		hex     string
+		encoded string
+	}{
+		{"", ""},
+		{"61", "2g"},
+		{"626262", "a3gV"},
+		{"636363", "aPEr"},
+		{"73696d706c792061206c6f6e6720737472696e67", "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+		{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+		{"516b6fcd0f", "ABnLTmg"},
+		{"bf4f89001e670274dd", "3SEo3LWLoPntC"},
+		{"572e4794", "3EFU7m"},
+		{"ecac89cad93923c02321", "EJDM8drfXA6uyA"},
+		{"10c8511e", "Rt5zm"},
+		{"00000000000000000000", "1111111111"},
+	}
+	for _, tt := range tests {
+		src, err := hex.DecodeString(tt.hex)
+		require.NoError(t, err)
+		assert.Equal(t, tt.encoded, Base58.EncodeToString(src), tt.hex)
+
+		decoded, err := Base58.DecodeString(tt.encoded)
+		require.NoError(t, err)
+		assert.Equal(t, tt.hex, hex.EncodeToString(decoded), tt.encoded)
+	}
+}
+
+// TestEncoding_MatchesBigInt 测试随机数据的编码与 math/big 的进制转换一致，并且可以还原。
+func TestEncoding_MatchesBigInt(t *testing.T) {
+	base36, err := NewEncoding("0123456789abcdefghijklmnopqrstuvwxyz")
+	require.NoError(t, err)
+	binaryEnc, err := NewEncoding("01")
+	require.NoError(t, err)
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, e := range []*Encoding{Base58, Base62, base36, binaryEnc} {
+		for i := 0; i < 200; i++ {
+			src := make([]byte, r.IntN(40))
+			for j := range src {
+				src[j] = byte(r.UintN(256))
+			}
+			// 开头放入若干 0 字节。
+			if 0 == i%4 && len(src) > 2 {
+				src[0], src[1] = 0, 0
+			}
+
+			encoded := e.EncodeToString(src)
+			assert.Equal(t, expectedEncode(e, src), encoded)
+
+			decoded, err := e.DecodeString(encoded)
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(src, decoded), "%x != %x", src, decoded)
+		}
+	}
+}
+
+// expectedEncode 使用 math/big 计算编码结果。
+func expectedEncode(e *Encoding, src []byte) string {
+	var sb strings.Builder
+	for _, b := range src {
+		if 0 != b {
+			break
+		}
+		sb.WriteByte(e.alphabet[0])
+	}
+	n := new(big.Int).SetBytes(src)
+	if 0 == n.Sign() {
+		return sb.String()
+	}
+	base := big.NewInt(int64(e.base))
+	var digits []byte
+	for mod := new(big.Int); n.Sign() > 0; {
+		n.DivMod(n, base, mod)
+		digits = append(digits, e.alphabet[mod.Int64()])
+	}
+	for i := len(digits) - 1; i >= 0; i-- {
+		sb.WriteByte(digits[i])
+	}
+	return sb.String()
+}
+
+// TestEncoding_Append 测试追加到已有的缓冲区。
+func TestEncoding_Append(t *testing.T) {
+	dst := Base62.AppendEncode([]byte("key:"), []byte{0, 0xff})
+	assert.Equal(t, "key:047", string(dst))
+
+	dst, err := Base62.AppendDecode([]byte{1}, []byte("047"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 0, 0xff}, dst)
+
+	dst, err = Base62.AppendDecode([]byte{1}, []byte("04-"))
+	assert.ErrorIs(t, err, ErrInvalidCharacter)
+	assert.ErrorContains(t, err, "位置 2")
+	assert.Equal(t, []byte{1}, dst)
+}
+
+// TestEncoding_DecodeInvalid 测试解码字母表以外的字符。
+func TestEncoding_DecodeInvalid(t *testing.T) {
+	for _, s := range []string{"0", "O", "I", "l", "abc+", "é"} {
+		_, err := Base58.DecodeString(s)
+		assert.ErrorIs(t, err, ErrInvalidCharacter, s)
+	}
+}
+
+// TestEncoding_Uint64 测试整数的编码、补齐与溢出。
+func TestEncoding_Uint64(t *testing.T) {
+	assert.Equal(t, "0", Base62.EncodeUint64(0))
+	assert.Equal(t, "1LY7VK", Base62.EncodeUint64(1234567890))
+	assert.Equal(t, "LygHa16AHYF", Base62.EncodeUint64(math.MaxUint64))
+	assert.Equal(t, "0000001LY7VK", string(Base62.AppendUint64(nil, 1234567890, 12)))
+	assert.Equal(t, "id:1LY7VK", string(Base62.AppendUint64([]byte("id:"), 1234567890, 3)))
+
+	for _, v := range []uint64{0, 1, 61, 62, 1 << 32, math.MaxUint64} {
+		for _, e := range []*Encoding{Base58, Base62} {
+			got, err := e.DecodeUint64(e.EncodeUint64(v))
+			require.NoError(t, err)
+			assert.Equal(t, v, got)
+		}
+	}
+
+	v, err := Base62.DecodeUint64("0000001LY7VK")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1234567890), v)
+
+	_, err = Base62.DecodeUint64("LygHa16AHYG")
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = Base62.DecodeUint64("100000000000")
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = Base62.DecodeUint64("")
+	assert.ErrorIs(t, err, ErrInvalidCharacter)
+	_, err = Base62.DecodeUint64("12_3")
+	assert.ErrorIs(t, err, ErrInvalidCharacter)
+}
+
+// TestEncoding_Uint64Order 测试 Base62 补齐到相同宽度后，编码的字典序与数值的大小顺序一致。
+func TestEncoding_Uint64Order(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	values := make([]uint64, 500)
+	encoded := make([]string, len(values))
+	for i := range values {
+		values[i] = r.Uint64() >> r.UintN(64)
+		encoded[i] = string(Base62.AppendUint64(nil, values[i], 11))
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	sort.Strings(encoded)
+	for i, s := range encoded {
+		v, err := Base62.DecodeUint64(s)
+		require.NoError(t, err)
+		assert.Equal(t, values[i], v)
+	}
+}
+
+// TestNewEncoding 测试不合法的字母表。
+func TestNewEncoding(t *testing.T) {
+	_, err := NewEncoding("a")
+	assert.ErrorIs(t, err, ErrInvalidAlphabet)
+	_, err = NewEncoding(strings.Repeat("ab", 129))
+	assert.ErrorIs(t, err, ErrInvalidAlphabet)
+	_, err = NewEncoding("abca")
+	assert.ErrorIs(t, err, ErrInvalidAlphabet)
+	assert.ErrorContains(t, err, `'a'`)
+
+	all := make([]byte, 256)
+	for i := range all {
+		all[i] = byte(i)
+	}
+	e, err := NewEncoding(string(all))
+	require.NoError(t, err)
+	assert.Equal(t, string(all), e.Alphabet())
+	// 基数为 256 时编码结果与原数据相同。
+	assert.Equal(t, "\x00\x01\xff", e.EncodeToString([]byte{0, 1, 0xff}))
+
+	assert.Panics(t, func() { mustNewEncoding("") })
+}
+
+// BenchmarkBase58_Encode 测试编码 16 字节数据的性能。
+func BenchmarkBase58_Encode(b *testing.B) {
+	src := bytes.Repeat([]byte{0xa5}, 16)
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = Base58.EncodeToString(src)
+	}
+}
+
+// BenchmarkBase58_Decode 测试解码 16 字节数据的编码的性能。
+func BenchmarkBase58_Decode(b *testing.B) {
+	s := Base58.EncodeToString(bytes.Repeat([]byte{0xa5}, 16))
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = Base58.DecodeString(s)
+	}
+}
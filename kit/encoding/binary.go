@@ -0,0 +1,311 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package encoding
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+type (
+	// Writer 把整数、字节序列与字符串紧凑地编码到一个缓冲区中，用于生成缓存键、短标识等二进制表示。
+	//
+	// 编码规则：
+	//   - 变长整数使用与 encoding/binary 相同的 varint 格式，小的数值只占用一个字节
+	//   - 定长整数使用大端序，编码结果按字节比较的顺序与数值的大小顺序一致
+	//   - 字节序列与字符串在内容之前写入 varint 编码的长度，拼接多个字段时不会产生歧义
+	//
+	// Writer 的零值可以直接使用。Writer 不是并发安全的。
+	Writer struct {
+		// buf 是编码结果。
+		buf []byte
+	}
+
+	// Reader 按写入的顺序读取 Writer 编码的数据。
+	//
+	// 读取失败后，Reader 记录第一个错误，之后的读取都返回零值，调用方在读取完全部字段后检查一次 Err 即可。
+	// Reader 不是并发安全的。
+	Reader struct {
+		// buf 是待读取的数据。
+		buf []byte
+		// off 是下一次读取的位置。
+		off int
+		// err 是第一次读取失败的错误。
+		err error
+	}
+)
+
+// NewWriter 创建在 buf 之后追加数据的 Writer。
+//
+// 参数：
+//   - buf：初始缓冲区，可以为 nil，也可以传入复用的缓冲区 buf[:0]。
+//
+// 返回值：
+//   - *Writer：编码器。
+//
+// 示例：
+//
+//	w := encoding.NewWriter(make([]byte, 0, 64))
+//	w.WriteString("user")
+//	w.WriteUvarint(tenantID)
+//	w.WriteUint64(userID)
+//	key := encoding.Base62.EncodeToString(w.Bytes())
+func NewWriter(buf []byte) *Writer {
+	return &Writer{buf: buf}
+}
+
+// WriteUvarint 写入变长编码的无符号整数，占用 1 到 10 字节。
+//
+// 参数：
+//   - v：整数。
+func (w *Writer) WriteUvarint(v uint64) {
+	w.buf = binary.AppendUvarint(w.buf, v)
+}
+
+// WriteVarint 写入 zigzag 变长编码的有符号整数，绝对值小的负数同样只占用很少的字节。
+//
+// 参数：
+//   - v：整数。
+func (w *Writer) WriteVarint(v int64) {
+	w.buf = binary.AppendVarint(w.buf, v)
+}
+
+// WriteUint8 写入一个字节。
+//
+// 参数：
+//   - v：整数。
+func (w *Writer) WriteUint8(v uint8) {
+	w.buf = append(w.buf, v)
+}
+
+// WriteUint16 写入大端序的 16 位无符号整数。
+//
+// 参数：
+//   - v：整数。
+func (w *Writer) WriteUint16(v uint16) {
+	w.buf = binary.BigEndian.AppendUint16(w.buf, v)
+}
+
+// WriteUint32 写入大端序的 32 位无符号整数。
+//
+// 参数：
+//   - v：整数。
+func (w *Writer) WriteUint32(v uint32) {
+	w.buf = binary.BigEndian.AppendUint32(w.buf, v)
+}
+
+// WriteUint64 写入大端序的 64 位无符号整数。
+//
+// 参数：
+//   - v：整数。
+func (w *Writer) WriteUint64(v uint64) {
+	w.buf = binary.BigEndian.AppendUint64(w.buf, v)
+}
+
+// WriteBytes 写入 varint 编码的长度与字节序列。
+//
+// 参数：
+//   - b：字节序列。
+func (w *Writer) WriteBytes(b []byte) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+// WriteString 写入 varint 编码的长度与字符串。
+//
+// 参数：
+//   - s：字符串。
+func (w *Writer) WriteString(s string) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// Bytes 返回编码结果，与 Writer 共享底层内存，之后的写入可能修改其内容。
+//
+// 返回值：
+//   - []byte：编码结果。
+func (w *Writer) Bytes() []byte {
+	return w.buf
+}
+
+// Len 返回编码结果的字节数。
+//
+// 返回值：
+//   - int：字节数。
+func (w *Writer) Len() int {
+	return len(w.buf)
+}
+
+// Reset 清空编码结果并保留缓冲区，用于复用 Writer。
+func (w *Writer) Reset() {
+	w.buf = w.buf[:0]
+}
+
+// NewReader 创建读取 buf 的 Reader。
+//
+// 参数：
+//   - buf：Writer 编码的数据，读取期间不能修改。
+//
+// 返回值：
+//   - *Reader：解码器。
+//
+// 示例：
+//
+//	r := encoding.NewReader(data)
+//	prefix := r.ReadString()
+//	tenantID := r.ReadUvarint()
+//	userID := r.ReadUint64()
+//	if err := r.Err(); nil != err {
+//	    return err
+//	}
+func NewReader(buf []byte) *Reader {
+	return &Reader{buf: buf}
+}
+
+// ReadUvarint 读取变长编码的无符号整数。
+//
+// 返回值：
+//   - uint64：整数，读取失败时为 0。
+func (r *Reader) ReadUvarint() uint64 {
+	if nil != r.err {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf[r.off:])
+	if n <= 0 {
+		r.varintFailed(n)
+		return 0
+	}
+	r.off += n
+	return v
+}
+
+// ReadVarint 读取 zigzag 变长编码的有符号整数。
+//
+// 返回值：
+//   - int64：整数，读取失败时为 0。
+func (r *Reader) ReadVarint() int64 {
+	if nil != r.err {
+		return 0
+	}
+	v, n := binary.Varint(r.buf[r.off:])
+	if n <= 0 {
+		r.varintFailed(n)
+		return 0
+	}
+	r.off += n
+	return v
+}
+
+// ReadUint8 读取一个字节。
+//
+// 返回值：
+//   - uint8：整数，读取失败时为 0。
+func (r *Reader) ReadUint8() uint8 {
+	b := r.next(1)
+	if nil == b {
+		return 0
+	}
+	return b[0]
+}
+
+// ReadUint16 读取大端序的 16 位无符号整数。
+//
+// 返回值：
+//   - uint16：整数，读取失败时为 0。
+func (r *Reader) ReadUint16() uint16 {
+	b := r.next(2)
+	if nil == b {
+		return 0
+	}
+	return binary.BigEndian.Uint16(b)
+}
+
+// ReadUint32 读取大端序的 32 位无符号整数。
+//
+// 返回值：
+//   - uint32：整数，读取失败时为 0。
+func (r *Reader) ReadUint32() uint32 {
+	b := r.next(4)
+	if nil == b {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+// ReadUint64 读取大端序的 64 位无符号整数。
+//
+// 返回值：
+//   - uint64：整数，读取失败时为 0。
+func (r *Reader) ReadUint64() uint64 {
+	b := r.next(8)
+	if nil == b {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+// ReadBytes 读取 WriteBytes 写入的字节序列。返回的切片与 Reader 的数据共享底层内存，需要保留时自行复制。
+//
+// 返回值：
+//   - []byte：字节序列，读取失败时为 nil。
+func (r *Reader) ReadBytes() []byte {
+	n := r.ReadUvarint()
+	if nil != r.err {
+		return nil
+	}
+	if n > math.MaxInt {
+		r.err = fmt.Errorf("%w：长度 %d", ErrOverflow, n)
+		return nil
+	}
+	return r.next(int(n))
+}
+
+// ReadString 读取 WriteString 写入的字符串。
+//
+// 返回值：
+//   - string：字符串，读取失败时为空字符串。
+func (r *Reader) ReadString() string {
+	return string(r.ReadBytes())
+}
+
+// Remaining 返回尚未读取的字节数。
+//
+// 返回值：
+//   - int：字节数。
+func (r *Reader) Remaining() int {
+	return len(r.buf) - r.off
+}
+
+// Err 返回第一次读取失败的错误。
+//
+// 返回值：
+//   - error：数据不完整时返回 ErrTruncated，varint 超出 64 位时返回 ErrOverflow，没有失败时返回 nil。
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// next 读取 n 个字节，数据不足时记录错误并返回 nil。
+func (r *Reader) next(n int) []byte {
+	if nil != r.err {
+		return nil
+	}
+	if n > len(r.buf)-r.off {
+		r.err = fmt.Errorf("%w：位置 %d 需要 %d 字节，剩余 %d 字节", ErrTruncated, r.off, n, len(r.buf)-r.off)
+		return nil
+	}
+	b := r.buf[r.off : r.off+n : r.off+n]
+	r.off += n
+	return b
+}
+
+// varintFailed 根据 binary.Uvarint 与 binary.Varint 返回的 n 记录错误。
+func (r *Reader) varintFailed(n int) {
+	if 0 == n {
+		r.err = fmt.Errorf("%w：位置 %d 的 varint", ErrTruncated, r.off)
+		return
+	}
+	r.err = fmt.Errorf("%w：位置 %d 的 varint", ErrOverflow, r.off)
+}
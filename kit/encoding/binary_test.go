@@ -0,0 +1,130 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package encoding
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriterReader 测试按写入的顺序读取全部类型的字段。
+func TestWriterReader(t *testing.T) {
+	var w Writer
+	w.WriteUvarint(300)
+	w.WriteVarint(-2)
+	w.WriteUint8(7)
+	w.WriteUint16(0x0102)
+	w.WriteUint32(0x01020304)
+	w.WriteUint64(math.MaxUint64)
+	w.WriteBytes([]byte{0xde, 0xad})
+	w.WriteString("用户")
+	w.WriteString("")
+
+	assert.Equal(t, 2+1+1+2+4+8+3+7+1, w.Len())
+
+	r := NewReader(w.Bytes())
+	assert.Equal(t, uint64(300), r.ReadUvarint())
+	assert.Equal(t, int64(-2), r.ReadVarint())
+	assert.Equal(t, uint8(7), r.ReadUint8())
+	assert.Equal(t, uint16(0x0102), r.ReadUint16())
+	assert.Equal(t, uint32(0x01020304), r.ReadUint32())
+	assert.Equal(t, uint64(math.MaxUint64), r.ReadUint64())
+	assert.Equal(t, []byte{0xde, 0xad}, r.ReadBytes())
+	assert.Equal(t, "用户", r.ReadString())
+	assert.Equal(t, "", r.ReadString())
+	assert.Equal(t, 0, r.Remaining())
+	assert.NoError(t, r.Err())
+}
+
+// TestWriter_Prefix 测试带长度前缀的字段拼接后不会产生歧义，定长整数保持大小顺序。
+func TestWriter_Prefix(t *testing.T) {
+	key := func(a, b string) []byte {
+		w := NewWriter(nil)
+		w.WriteString(a)
+		w.WriteString(b)
+		return w.Bytes()
+	}
+	assert.NotEqual(t, key("ab", "c"), key("a", "bc"))
+
+	fixed := func(v uint64) []byte {
+		w := NewWriter(nil)
+		w.WriteUint64(v)
+		return w.Bytes()
+	}
+	assert.Equal(t, -1, bytes.Compare(fixed(255), fixed(256)))
+}
+
+// TestWriter_Reuse 测试复用缓冲区。
+func TestWriter_Reuse(t *testing.T) {
+	buf := make([]byte, 0, 32)
+	w := NewWriter(buf)
+	w.WriteUint32(1)
+	w.Reset()
+	assert.Equal(t, 0, w.Len())
+	w.WriteUint8(9)
+	assert.Equal(t, []byte{9}, w.Bytes())
+	assert.Equal(t, byte(9), buf[:1][0], "应复用传入的缓冲区")
+}
+
+// TestReader_Errors 测试数据不完整与溢出时记录第一个错误，之后的读取返回零值。
+func TestReader_Errors(t *testing.T) {
+	r := NewReader([]byte{0x01, 0x02, 0x03})
+	assert.Equal(t, uint16(0x0102), r.ReadUint16())
+	assert.Equal(t, uint32(0), r.ReadUint32())
+	assert.ErrorIs(t, r.Err(), ErrTruncated)
+	assert.ErrorContains(t, r.Err(), "位置 2 需要 4 字节，剩余 1 字节")
+	// 出错后不再读取。
+	assert.Equal(t, uint8(0), r.ReadUint8())
+	assert.Equal(t, uint64(0), r.ReadUvarint())
+	assert.Equal(t, int64(0), r.ReadVarint())
+	assert.Nil(t, r.ReadBytes())
+	assert.Equal(t, 1, r.Remaining())
+
+	r = NewReader([]byte{0x80})
+	assert.Equal(t, uint64(0), r.ReadUvarint())
+	assert.ErrorIs(t, r.Err(), ErrTruncated)
+
+	r = NewReader(bytes.Repeat([]byte{0xff}, 11))
+	assert.Equal(t, int64(0), r.ReadVarint())
+	assert.ErrorIs(t, r.Err(), ErrOverflow)
+
+	// 长度超过剩余的数据。
+	r = NewReader([]byte{0x05, 'a', 'b'})
+	assert.Equal(t, "", r.ReadString())
+	assert.ErrorIs(t, r.Err(), ErrTruncated)
+
+	// 长度超过 int 的范围。
+	w := NewWriter(nil)
+	w.WriteUvarint(math.MaxUint64)
+	r = NewReader(w.Bytes())
+	assert.Nil(t, r.ReadBytes())
+	assert.ErrorIs(t, r.Err(), ErrOverflow)
+}
+
+// TestWriter_CacheKey 测试生成缓存键的典型用法。
+func TestWriter_CacheKey(t *testing.T) {
+	build := func(tenant uint64, user string) string {
+		w := NewWriter(make([]byte, 0, 32))
+		w.WriteString("profile")
+		w.WriteUvarint(tenant)
+		w.WriteString(user)
+		return Base62.EncodeToString(w.Bytes())
+	}
+	key := build(42, "alice")
+	assert.Equal(t, key, build(42, "alice"))
+	assert.NotEqual(t, key, build(42, "bob"))
+
+	raw, err := Base62.DecodeString(key)
+	require.NoError(t, err)
+	r := NewReader(raw)
+	assert.Equal(t, "profile", r.ReadString())
+	assert.Equal(t, uint64(42), r.ReadUvarint())
+	assert.Equal(t, "alice", r.ReadString())
+	assert.NoError(t, r.Err())
+}
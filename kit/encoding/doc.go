@@ -0,0 +1,35 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package encoding 提供了 URL 安全的进制编码与紧凑的二进制编码，用于生成短标识、令牌与缓存键。
+
+主要功能：
+
+  - 进制编码：Base58、Base62 以及 NewEncoding 创建的任意字母表的进制编码，结果只包含字母与数字，保留开头的 0 字节
+  - 整数编码：EncodeUint64、AppendUint64 把整数编码为最短的进制字符串，可以补齐为固定宽度以保持字典序
+  - 二进制编码：Writer 与 Reader 读写 varint、大端序定长整数以及带长度前缀的字节序列与字符串
+
+基本使用：
+
+	token := encoding.Base58.EncodeToString(raw)
+	raw, err := encoding.Base58.DecodeString(token)
+
+	short := encoding.Base62.EncodeUint64(id)
+
+	w := encoding.NewWriter(nil)
+	w.WriteString(prefix)
+	w.WriteUvarint(tenantID)
+	w.WriteUint64(userID)
+	key := encoding.Base62.EncodeToString(w.Bytes())
+
+由于包名与标准库 encoding 相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "encoding"
+
+	    kitencoding "github.com/fsyyft-go/monorepo/kit/encoding"
+	)
+*/
+package encoding
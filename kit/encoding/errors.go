@@ -0,0 +1,20 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package encoding
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidAlphabet 表示字母表的长度不在 2 到 256 之间，或者包含重复的字符。
+	ErrInvalidAlphabet = errors.New("kit/encoding: 字母表不合法")
+	// ErrInvalidCharacter 表示编码中包含字母表以外的字符。
+	ErrInvalidCharacter = errors.New("kit/encoding: 编码包含不合法的字符")
+	// ErrOverflow 表示解码的数值超出了目标类型的范围。
+	ErrOverflow = errors.New("kit/encoding: 数值溢出")
+	// ErrTruncated 表示二进制数据在读取完一个完整的值之前结束。
+	ErrTruncated = errors.New("kit/encoding: 数据不完整")
+)
@@ -0,0 +1,42 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package env 提供了类型化的环境变量读取功能，省去 os.Getenv 之后的 strconv 样板代码。
+
+主要功能：
+
+  - 泛型读取：Get 未设置或无法解析时返回默认值，Lookup 无法解析时返回错误，Required 未设置时返回 ErrNotSet
+  - 类型支持：字符串、布尔（额外支持 yes、no、on、off）、整数、浮点数、time.Duration、Size、
+    实现了 encoding.TextUnmarshaler 的类型以及以逗号分隔的切片
+  - 大小：Size 解析 "64KB"、"1.5GiB" 等格式，按 1024 进制计算，也可以作为 kit/config 配置结构体的字段类型
+  - 结构体加载：Load 按 env、default 标签加载结构体，一次返回全部缺失或错误的环境变量
+
+kit/testing 使用该包读取随机数种子与快照更新开关。
+
+基本使用：
+
+	port := env.Get("PORT", 8080)
+	timeout := env.Get("TIMEOUT", 5*time.Second)
+
+	dsn, err := env.Required[string]("DATABASE_URL")
+	if nil != err {
+	    return err
+	}
+
+按结构体加载：
+
+	type Config struct {
+	    Port    int      `env:"PORT" default:"8080"`
+	    DSN     string   `env:"DATABASE_URL,required"`
+	    MaxBody env.Size `env:"MAX_BODY" default:"4MB"`
+	    Peers   []string `env:"PEERS"`
+	}
+
+	var c Config
+	if err := env.Load(&c, env.WithPrefix("APP_")); nil != err {
+	    return err
+	}
+*/
+package env
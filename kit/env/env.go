@@ -0,0 +1,116 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrNotSet 表示必需的环境变量未设置或为空。
+	ErrNotSet = errors.New("kit/env: 环境变量未设置")
+	// ErrUnsupportedType 表示目标类型无法从环境变量解析，属于编程错误。
+	ErrUnsupportedType = errors.New("kit/env: 不支持的类型")
+)
+
+// Get 读取环境变量并解析为 T，未设置、为空或无法解析时返回默认值。
+// 适用于有合理默认值、取值错误不应阻止启动的配置；需要发现取值错误时使用 Lookup。
+//
+// T 支持字符串、布尔、整数、无符号整数、浮点数、time.Duration、Size、实现了 encoding.TextUnmarshaler 的类型，
+// 以及元素为以上类型的切片。布尔值额外支持 yes、no、on、off；切片以逗号分隔。
+// 值两端的空白会被去除，只包含空白的值视为未设置。
+//
+// 参数：
+//   - key：环境变量名称。
+//   - def：默认值。
+//   - opts：配置选项，参见 WithPrefix、WithLookup 与 WithSeparator。
+//
+// 返回值：
+//   - T：解析得到的值或默认值。
+//
+// 示例：
+//
+//	port := env.Get("PORT", 8080)
+//	timeout := env.Get("TIMEOUT", 5*time.Second)
+//	maxBody := env.Get("MAX_BODY", 4*env.MB)
+//	peers := env.Get("PEERS", []string{"127.0.0.1:7000"})
+//
+// T 不受支持时 panic。
+func Get[T any](key string, def T, opts ...Option) T {
+	v, err := Lookup(key, def, opts...)
+	if nil != err {
+		if errors.Is(err, ErrUnsupportedType) {
+			panic(err)
+		}
+		return def
+	}
+	return v
+}
+
+// Lookup 读取环境变量并解析为 T，未设置或为空时返回默认值，无法解析时返回错误。
+//
+// 参数：
+//   - key：环境变量名称。
+//   - def：默认值。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - T：解析得到的值或默认值。
+//   - error：无法解析时返回包含变量名称的错误，T 不受支持时返回包装 ErrUnsupportedType 的错误。
+func Lookup[T any](key string, def T, opts ...Option) (T, error) {
+	o := newOptions(opts...)
+	var v T
+	if err := lookup(o, key, reflect.ValueOf(&v).Elem()); nil != err {
+		if errors.Is(err, ErrNotSet) {
+			return def, nil
+		}
+		return def, err
+	}
+	return v, nil
+}
+
+// Required 读取必需的环境变量并解析为 T。
+//
+// 参数：
+//   - key：环境变量名称。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - T：解析得到的值。
+//   - error：未设置或为空时返回包装 ErrNotSet 的错误，无法解析时返回包含变量名称的错误。
+//
+// 示例：
+//
+//	dsn, err := env.Required[string]("DATABASE_URL")
+//	if nil != err {
+//	    return err
+//	}
+func Required[T any](key string, opts ...Option) (T, error) {
+	o := newOptions(opts...)
+	var v T
+	if err := lookup(o, key, reflect.ValueOf(&v).Elem()); nil != err {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// lookup 读取环境变量并解析到 v，未设置时返回包装 ErrNotSet 的错误。
+func lookup(o *options, key string, v reflect.Value) error {
+	name := o.prefix + key
+	if !supported(v.Type()) {
+		return fmt.Errorf("%w：环境变量 %s 的类型为 %s", ErrUnsupportedType, name, v.Type())
+	}
+	raw, ok := o.get(key)
+	if !ok {
+		return fmt.Errorf("%w：%s", ErrNotSet, name)
+	}
+	if err := parseValue(raw, v, o.separator); nil != err {
+		return fmt.Errorf("kit/env: 环境变量 %s 的值 %q 无法解析为 %s：%w", name, raw, v.Type(), err)
+	}
+	return nil
+}
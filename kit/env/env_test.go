@@ -0,0 +1,192 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package env
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kiterrors "github.com/fsyyft-go/monorepo/kit/errors"
+)
+
+// mapLookup 返回从 map 读取环境变量的 Option。
+func mapLookup(vars map[string]string) Option {
+	return WithLookup(func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	})
+}
+
+// TestGet 测试各类型的解析与默认值。
+func TestGet(t *testing.T) {
+	vars := mapLookup(map[string]string{
+		"STR":      " hello ",
+		"BLANK":    "  ",
+		"INT":      "42",
+		"INT8":     "300",
+		"UINT":     "7",
+		"FLOAT":    "1.5",
+		"BOOL":     "Yes",
+		"BOOL_OFF": "off",
+		"DUR":      "1m30s",
+		"SIZE":     "1.5 KiB",
+		"ADDR":     "10.0.0.1",
+		"LIST":     "a, b,,c ",
+		"INTS":     "1;2;3",
+		"BAD":      "x",
+	})
+
+	assert.Equal(t, "hello", Get("STR", "", vars))
+	assert.Equal(t, "def", Get("BLANK", "def", vars), "只包含空白视为未设置")
+	assert.Equal(t, "def", Get("MISSING", "def", vars))
+	assert.Equal(t, 42, Get("INT", 0, vars))
+	assert.Equal(t, int8(1), Get("INT8", int8(1), vars), "溢出时使用默认值")
+	assert.Equal(t, uint(7), Get("UINT", uint(0), vars))
+	assert.Equal(t, 1.5, Get("FLOAT", 0.0, vars))
+	assert.True(t, Get("BOOL", false, vars))
+	assert.False(t, Get("BOOL_OFF", true, vars))
+	assert.Equal(t, 90*time.Second, Get("DUR", time.Second, vars))
+	assert.Equal(t, Size(1536), Get("SIZE", MB, vars))
+	assert.Equal(t, netip.MustParseAddr("10.0.0.1"), Get("ADDR", netip.Addr{}, vars))
+	assert.Equal(t, []string{"a", "b", "c"}, Get("LIST", []string(nil), vars))
+	assert.Equal(t, []int{1, 2, 3}, Get("INTS", []int(nil), vars, WithSeparator(";")))
+	assert.Equal(t, 5, Get("BAD", 5, vars))
+	assert.Equal(t, false, Get("BAD", false, vars))
+	assert.Equal(t, time.Second, Get("BAD", time.Second, vars))
+
+	assert.Panics(t, func() { Get("INT", map[string]int{}, vars) })
+	assert.Panics(t, func() { Get("INT", [][]string{}, vars) })
+}
+
+// TestLookup 测试取值错误的返回。
+func TestLookup(t *testing.T) {
+	vars := mapLookup(map[string]string{"APP_PORT": "80", "APP_BAD": "x", "APP_LIST": "1,x"})
+
+	port, err := Lookup("PORT", 0, vars, WithPrefix("APP_"))
+	require.NoError(t, err)
+	assert.Equal(t, 80, port)
+
+	port, err = Lookup("MISSING", 8080, vars, WithPrefix("APP_"))
+	require.NoError(t, err)
+	assert.Equal(t, 8080, port)
+
+	port, err = Lookup("BAD", 8080, vars, WithPrefix("APP_"))
+	assert.EqualError(t, err, `kit/env: 环境变量 APP_BAD 的值 "x" 无法解析为 int：strconv.ParseInt: parsing "x": invalid syntax`)
+	assert.Equal(t, 8080, port)
+
+	_, err = Lookup("LIST", []uint{}, vars, WithPrefix("APP_"))
+	assert.ErrorContains(t, err, `元素 "x"`)
+
+	_, err = Lookup("PORT", struct{}{}, vars)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+// TestRequired 测试必需的环境变量。
+func TestRequired(t *testing.T) {
+	t.Setenv("KIT_ENV_TEST_DSN", "postgres://db")
+
+	dsn, err := Required[string]("KIT_ENV_TEST_DSN")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://db", dsn)
+
+	_, err = Required[string]("KIT_ENV_TEST_MISSING", WithLookup(nil))
+	assert.ErrorIs(t, err, ErrNotSet)
+	assert.ErrorContains(t, err, "KIT_ENV_TEST_MISSING")
+
+	n, err := Required[int]("KIT_ENV_TEST_DSN")
+	assert.Error(t, err)
+	assert.Zero(t, n)
+}
+
+// TestLoad 测试按结构体标签加载。
+func TestLoad(t *testing.T) {
+	type cache struct {
+		Size Size          `env:"SIZE" default:"64MB"`
+		TTL  time.Duration `env:"TTL" default:"1m"`
+	}
+	type config struct {
+		Port   int      `env:"PORT" default:"8080"`
+		Host   string   `env:"HOST"`
+		DSN    string   `env:"DATABASE_URL,required"`
+		Debug  bool     `env:"DEBUG"`
+		Peers  []string `env:"PEERS"`
+		Cache  cache    `env:"CACHE_"`
+		Inline struct {
+			Level string `env:"LOG_LEVEL" default:"info"`
+		}
+		Pointer *cache `env:"PTR_"`
+		Ignored string `env:"-"`
+		NoTag   string
+		secret  string `env:"SECRET"`
+	}
+
+	vars := mapLookup(map[string]string{
+		"APP_DATABASE_URL": "postgres://db",
+		"APP_DEBUG":        "true",
+		"APP_PEERS":        "a,b",
+		"APP_CACHE_TTL":    "5m",
+		"APP_PTR_SIZE":     "1G",
+		"APP_LOG_LEVEL":    "debug",
+		"APP_SECRET":       "s",
+		"APP_NoTag":        "x",
+	})
+
+	c := config{Host: "preset"}
+	require.NoError(t, Load(&c, vars, WithPrefix("APP_")))
+	assert.Equal(t, 8080, c.Port)
+	assert.Equal(t, "preset", c.Host, "未设置且没有默认值时保留原值")
+	assert.Equal(t, "postgres://db", c.DSN)
+	assert.True(t, c.Debug)
+	assert.Equal(t, []string{"a", "b"}, c.Peers)
+	assert.Equal(t, cache{Size: 64 * MB, TTL: 5 * time.Minute}, c.Cache)
+	assert.Equal(t, "debug", c.Inline.Level)
+	require.NotNil(t, c.Pointer)
+	assert.Equal(t, GB, c.Pointer.Size)
+	assert.Empty(t, c.NoTag)
+	assert.Empty(t, c.secret)
+}
+
+// TestLoad_Errors 测试加载的错误处理。
+func TestLoad_Errors(t *testing.T) {
+	type config struct {
+		Port int    `env:"PORT"`
+		DSN  string `env:"DATABASE_URL,required"`
+		Key  string `env:"KEY,required"`
+	}
+	vars := mapLookup(map[string]string{"PORT": "http"})
+
+	var c config
+	err := Load(&c, vars)
+	require.Error(t, err)
+	errs := err.(*kiterrors.MultiError).Errors()
+	require.Len(t, errs, 3, "一次返回全部错误")
+	assert.ErrorContains(t, errs[0], "PORT")
+	assert.ErrorIs(t, errs[1], ErrNotSet)
+	assert.ErrorContains(t, errs[2], "KEY")
+
+	assert.ErrorIs(t, Load(c), ErrUnsupportedType)
+	assert.ErrorIs(t, Load((*config)(nil)), ErrUnsupportedType)
+
+	var unsupported struct {
+		M map[string]string `env:"M"`
+	}
+	assert.ErrorIs(t, Load(&unsupported, vars), ErrUnsupportedType)
+
+	var badDefault struct {
+		N int `env:"N" default:"x"`
+	}
+	assert.ErrorContains(t, Load(&badDefault, vars), "默认值")
+
+	var nestedBad struct {
+		Inner struct {
+			M map[string]string `env:"M"`
+		}
+	}
+	assert.ErrorIs(t, Load(&nestedBad, vars), ErrUnsupportedType)
+}
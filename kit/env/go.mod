@@ -0,0 +1,16 @@
+module github.com/fsyyft-go/monorepo/kit/env
+
+go 1.25
+
+require (
+	github.com/fsyyft-go/monorepo/kit/errors v0.1.0
+	github.com/stretchr/testify v1.11.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/fsyyft-go/monorepo/kit/errors => ../errors
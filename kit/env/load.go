@@ -0,0 +1,132 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	kiterrors "github.com/fsyyft-go/monorepo/kit/errors"
+)
+
+const (
+	// tagName 是指定环境变量名称的结构体标签。
+	tagName = "env"
+	// tagDefault 是指定默认值的结构体标签。
+	tagDefault = "default"
+	// optionRequired 表示环境变量必须设置。
+	optionRequired = "required"
+)
+
+// Load 按结构体标签从环境变量加载配置到结构体 v。
+//
+// 字段通过 env 标签指定环境变量名称，default 标签指定未设置时的默认值，名称之后加上 ",required" 表示必须设置：
+//
+//   - 环境变量已设置时解析到字段，支持的类型与 Get 相同
+//   - 未设置时使用 default 标签的值；没有 default 标签时保持字段原有的值，因此可以先填充默认配置再调用 Load
+//   - 没有 env 标签的嵌套结构体（包括指向结构体的指针）使用相同的前缀递归加载；
+//     带 env 标签的嵌套结构体以标签值作为追加的前缀，例如 `env:"DB_"`
+//   - 没有 env 标签的其他字段与 env 标签为 "-" 的字段被忽略
+//
+// 全部字段处理完成后一并返回错误，可以一次看到全部缺失或错误的环境变量。
+//
+// 参数：
+//   - v：加载目标，必须是指向结构体的指针。
+//   - opts：配置选项，参见 WithPrefix、WithLookup 与 WithSeparator。
+//
+// 返回值：
+//   - error：必需的变量未设置时错误包装 ErrNotSet，取值无法解析时错误包含变量名称，多个错误合并为 kiterrors.MultiError；
+//     v 或字段的类型不受支持时返回包装 ErrUnsupportedType 的错误，default 标签无法解析时直接返回错误。
+//
+// 示例：
+//
+//	type Config struct {
+//	    Port    int           `env:"PORT" default:"8080"`
+//	    Timeout time.Duration `env:"TIMEOUT" default:"5s"`
+//	    DSN     string        `env:"DATABASE_URL,required"`
+//	    Cache   struct {
+//	        Size env.Size `env:"SIZE" default:"64MB"`
+//	    } `env:"CACHE_"`
+//	}
+//
+//	var c Config
+//	if err := env.Load(&c, env.WithPrefix("APP_")); nil != err {
+//	    return err
+//	}
+func Load(v interface{}, opts ...Option) error {
+	rv := reflect.ValueOf(v)
+	if reflect.Ptr != rv.Kind() || rv.IsNil() || reflect.Struct != rv.Elem().Kind() {
+		return fmt.Errorf("%w：加载目标必须是指向结构体的指针，实际为 %T", ErrUnsupportedType, v)
+	}
+
+	var errs kiterrors.MultiError
+	if err := loadStruct(newOptions(opts...), rv.Elem(), &errs); nil != err {
+		return err
+	}
+	return errs.ErrorOrNil()
+}
+
+// loadStruct 加载结构体的字段，取值错误收集到 errs 中，类型错误直接返回。
+func loadStruct(o *options, v reflect.Value, errs *kiterrors.MultiError) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, hasTag := field.Tag.Lookup(tagName)
+		name, option, _ := strings.Cut(tag, ",")
+		if "-" == name {
+			continue
+		}
+
+		fv := v.Field(i)
+		if isNested(field.Type) {
+			if reflect.Ptr == fv.Kind() {
+				if fv.IsNil() {
+					fv.Set(reflect.New(field.Type.Elem()))
+				}
+				fv = fv.Elem()
+			}
+			nested := *o
+			nested.prefix = o.prefix + name
+			if err := loadStruct(&nested, fv, errs); nil != err {
+				return err
+			}
+			continue
+		}
+		if !hasTag || "" == name {
+			continue
+		}
+
+		err := lookup(o, name, fv)
+		switch {
+		case nil == err:
+		case errors.Is(err, ErrUnsupportedType):
+			return fmt.Errorf("%w（字段 %s）", err, field.Name)
+		case !errors.Is(err, ErrNotSet):
+			errs.Append(err)
+		case optionRequired == option:
+			errs.Append(err)
+		default:
+			if def, ok := field.Tag.Lookup(tagDefault); ok {
+				if err := parseValue(def, fv, o.separator); nil != err {
+					return fmt.Errorf("kit/env: 字段 %s 的默认值 %q 无法解析：%w", field.Name, def, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isNested 返回字段是否为需要递归加载的结构体或指向结构体的指针。
+func isNested(t reflect.Type) bool {
+	if reflect.Ptr == t.Kind() {
+		t = t.Elem()
+	}
+	return reflect.Struct == t.Kind() && !supported(t)
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package env
+
+import (
+	"os"
+	"strings"
+)
+
+// 以下为环境变量读取的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// lookupDefault 为读取环境变量的默认函数。
+	lookupDefault = os.LookupEnv
+	// separatorDefault 为切片元素的默认分隔符。
+	separatorDefault = ","
+)
+
+type (
+	// Option 定义了环境变量读取的配置选项。
+	Option func(*options)
+
+	// options 包含环境变量读取的配置。
+	options struct {
+		// prefix 是环境变量名称的前缀。
+		prefix string
+		// lookup 读取环境变量，返回值与是否设置。
+		lookup func(key string) (string, bool)
+		// separator 是切片元素的分隔符。
+		separator string
+	}
+)
+
+// WithPrefix 设置环境变量名称的前缀，读取时在名称之前加上前缀，例如前缀 "APP_" 与名称 "PORT" 读取 APP_PORT。
+//
+// 参数：
+//   - prefix：名称前缀，默认为空。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithLookup 设置读取环境变量的函数，用于从其他来源读取或在测试中注入。
+//
+// 参数：
+//   - lookup：读取函数，默认为 os.LookupEnv，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLookup(lookup func(key string) (string, bool)) Option {
+	return func(o *options) {
+		o.lookup = lookup
+	}
+}
+
+// WithSeparator 设置切片元素的分隔符。
+//
+// 参数：
+//   - separator：分隔符，默认为 ","，为空时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithSeparator(separator string) Option {
+	return func(o *options) {
+		o.separator = separator
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		lookup:    lookupDefault,
+		separator: separatorDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if nil == o.lookup {
+		o.lookup = lookupDefault
+	}
+	if "" == o.separator {
+		o.separator = separatorDefault
+	}
+	return o
+}
+
+// get 读取带前缀的环境变量，未设置或去除空白后为空时返回 false。
+func (o *options) get(key string) (string, bool) {
+	raw, ok := o.lookup(o.prefix + key)
+	if !ok {
+		return "", false
+	}
+	raw = strings.TrimSpace(raw)
+	return raw, "" != raw
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package env
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// durationType 是 time.Duration 的反射类型，按 time.ParseDuration 的格式解析。
+	durationType = reflect.TypeOf(time.Duration(0))
+	// textUnmarshalerType 是 encoding.TextUnmarshaler 的反射类型，实现了该接口的类型按其自身的格式解析。
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// parseValue 将字符串解析到 v，v 必须可以设置。
+// 支持字符串、布尔、整数、无符号整数、浮点数、time.Duration、实现了 encoding.TextUnmarshaler 的类型，
+// 以及元素为以上类型的切片，切片按 separator 分隔，元素两端的空白会被去除，空元素会被忽略。
+func parseValue(raw string, v reflect.Value, separator string) error {
+	if reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+	if durationType == v.Type() {
+		d, err := time.ParseDuration(raw)
+		if nil != err {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := parseBool(raw)
+		if nil != err {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if nil != err {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if nil != err {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if nil != err {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		parts := strings.Split(raw, separator)
+		slice := reflect.MakeSlice(v.Type(), 0, len(parts))
+		for _, part := range parts {
+			part = strings.TrimSpace(part)
+			if "" == part {
+				continue
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := parseValue(part, elem, separator); nil != err {
+				return fmt.Errorf("元素 %q：%w", part, err)
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("%w：%s", ErrUnsupportedType, v.Type())
+	}
+	return nil
+}
+
+// parseBool 解析布尔值，在 strconv.ParseBool 的基础上支持不区分大小写的 yes、no、on、off。
+func parseBool(raw string) (bool, error) {
+	switch strings.ToLower(raw) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off":
+		return false, nil
+	default:
+		return false, errors.New("不是合法的布尔值")
+	}
+}
+
+// supported 返回类型是否可以由 parseValue 解析。
+func supported(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return reflect.Slice != t.Elem().Kind() && supported(t.Elem())
+	default:
+		return false
+	}
+}
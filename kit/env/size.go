@@ -0,0 +1,132 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package env
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// B 是 1 字节。
+	B Size = 1
+	// KB 是 1024 字节。
+	KB = 1024 * B
+	// MB 是 1024 KB。
+	MB = 1024 * KB
+	// GB 是 1024 MB。
+	GB = 1024 * MB
+	// TB 是 1024 GB。
+	TB = 1024 * GB
+)
+
+var (
+	// sizeUnits 是按从大到小排列的单位，用于格式化。
+	sizeUnits = []struct {
+		name string
+		size Size
+	}{
+		{"TB", TB},
+		{"GB", GB},
+		{"MB", MB},
+		{"KB", KB},
+	}
+)
+
+type (
+	// Size 表示以字节为单位的大小，例如缓冲区、文件或请求体的大小上限。
+	// Size 实现了 encoding.TextUnmarshaler，可以直接作为 kit/config 配置结构体的字段类型。
+	Size int64
+)
+
+// ParseSize 解析大小，格式为数字加可选的单位，单位不区分大小写，数字与单位之间可以有空格。
+// 支持的单位为 B、K/KB/KiB、M/MB/MiB、G/GB/GiB、T/TB/TiB，均按 1024 进制计算；没有单位时为字节。
+//
+// 参数：
+//   - s：要解析的字符串，例如 "512"、"64KB"、"1.5 GiB"。
+//
+// 返回值：
+//   - Size：解析得到的大小。
+//   - error：格式错误、为负数或超出 int64 范围时返回错误。
+func ParseSize(s string) (Size, error) {
+	s = strings.TrimSpace(s)
+	end := len(s)
+	for end > 0 && ('a' <= s[end-1] && s[end-1] <= 'z' || 'A' <= s[end-1] && s[end-1] <= 'Z') {
+		end--
+	}
+	number, unit := strings.TrimSpace(s[:end]), strings.ToUpper(s[end:])
+
+	var multiplier Size
+	switch strings.TrimSuffix(strings.TrimSuffix(unit, "IB"), "B") {
+	case "":
+		if "IB" == unit {
+			return 0, sizeError(s, "的单位无法识别")
+		}
+		multiplier = B
+	case "K":
+		multiplier = KB
+	case "M":
+		multiplier = MB
+	case "G":
+		multiplier = GB
+	case "T":
+		multiplier = TB
+	default:
+		return 0, sizeError(s, "的单位无法识别")
+	}
+
+	if n, err := strconv.ParseInt(number, 10, 64); nil == err {
+		if n < 0 || n > math.MaxInt64/int64(multiplier) {
+			return 0, sizeError(s, "超出范围")
+		}
+		return Size(n) * multiplier, nil
+	}
+	f, err := strconv.ParseFloat(number, 64)
+	if nil != err {
+		return 0, sizeError(s, "格式错误")
+	}
+	f *= float64(multiplier)
+	if f < 0 || f >= math.MaxInt64 || math.IsNaN(f) {
+		return 0, sizeError(s, "超出范围")
+	}
+	return Size(f), nil
+}
+
+// sizeError 返回大小无法解析的错误。
+func sizeError(s, reason string) error {
+	return fmt.Errorf("kit/env: 大小 %q %s", s, reason)
+}
+
+// Bytes 返回字节数。
+func (s Size) Bytes() int64 {
+	return int64(s)
+}
+
+// String 以能整除的最大单位格式化大小，例如 "64MB"、"1536KB"、"100B"。
+func (s Size) String() string {
+	for _, unit := range sizeUnits {
+		if 0 != s && 0 == s%unit.size {
+			return strconv.FormatInt(int64(s/unit.size), 10) + unit.name
+		}
+	}
+	return strconv.FormatInt(int64(s), 10) + "B"
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，按 ParseSize 的格式解析。
+func (s *Size) UnmarshalText(text []byte) error {
+	size, err := ParseSize(string(text))
+	if nil != err {
+		return err
+	}
+	*s = size
+	return nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler，格式与 String 相同。
+func (s Size) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseSize 测试大小的解析。
+func TestParseSize(t *testing.T) {
+	cases := map[string]Size{
+		"0":       0,
+		"512":     512,
+		"512b":    512,
+		"64K":     64 * KB,
+		"64KB":    64 * KB,
+		"64kib":   64 * KB,
+		"10 MB":   10 * MB,
+		"1.5GiB":  GB + 512*MB,
+		"2T":      2 * TB,
+		" 0.5KB ": 512,
+	}
+	for s, want := range cases {
+		got, err := ParseSize(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, want, got, s)
+	}
+
+	for _, s := range []string{"", "KB", "1iB", "1PB", "1KBB", "-1", "-1.5MB", "x1", "9223372036854775807K", "1e30T"} {
+		_, err := ParseSize(s)
+		assert.Error(t, err, s)
+	}
+}
+
+// TestSizeText 测试大小的格式化与文本编解码。
+func TestSizeText(t *testing.T) {
+	assert.Equal(t, "0B", Size(0).String())
+	assert.Equal(t, "100B", Size(100).String())
+	assert.Equal(t, "1536KB", (MB + 512*KB).String())
+	assert.Equal(t, "64MB", (64 * MB).String())
+	assert.Equal(t, "2TB", (2 * TB).String())
+	assert.Equal(t, int64(1024), KB.Bytes())
+
+	var s Size
+	require.NoError(t, s.UnmarshalText([]byte("4GB")))
+	assert.Equal(t, 4*GB, s)
+	assert.Error(t, s.UnmarshalText([]byte("4XB")))
+
+	text, err := s.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "4GB", string(text))
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+import (
+	"strconv"
+)
+
+// Code 定义了错误码。
+// 预定义的错误码与 gRPC 的状态码一一对应，便于在不同传输协议之间转换；业务可以在 CodeUnauthenticated 之后扩展自定义错误码。
+type Code uint32
+
+const (
+	// CodeOK 表示没有错误。
+	CodeOK Code = iota
+	// CodeCanceled 表示操作被调用方取消。
+	CodeCanceled
+	// CodeUnknown 表示未知错误，没有设置错误码的错误均视为该错误码。
+	CodeUnknown
+	// CodeInvalidArgument 表示参数不合法。
+	CodeInvalidArgument
+	// CodeDeadlineExceeded 表示操作超时。
+	CodeDeadlineExceeded
+	// CodeNotFound 表示请求的资源不存在。
+	CodeNotFound
+	// CodeAlreadyExists 表示要创建的资源已经存在。
+	CodeAlreadyExists
+	// CodePermissionDenied 表示没有执行操作的权限。
+	CodePermissionDenied
+	// CodeResourceExhausted 表示资源耗尽，例如超出配额或限流。
+	CodeResourceExhausted
+	// CodeFailedPrecondition 表示系统状态不满足操作的前置条件。
+	CodeFailedPrecondition
+	// CodeAborted 表示操作因并发冲突等原因中止。
+	CodeAborted
+	// CodeOutOfRange 表示操作超出了有效范围。
+	CodeOutOfRange
+	// CodeUnimplemented 表示操作未实现或不支持。
+	CodeUnimplemented
+	// CodeInternal 表示内部错误。
+	CodeInternal
+	// CodeUnavailable 表示服务暂时不可用，通常可以重试。
+	CodeUnavailable
+	// CodeDataLoss 表示不可恢复的数据丢失或损坏。
+	CodeDataLoss
+	// CodeUnauthenticated 表示请求没有有效的身份认证信息。
+	CodeUnauthenticated
+)
+
+var (
+	// codeNames 是预定义错误码的名称。
+	codeNames = map[Code]string{
+		CodeOK:                 "OK",
+		CodeCanceled:           "Canceled",
+		CodeUnknown:            "Unknown",
+		CodeInvalidArgument:    "InvalidArgument",
+		CodeDeadlineExceeded:   "DeadlineExceeded",
+		CodeNotFound:           "NotFound",
+		CodeAlreadyExists:      "AlreadyExists",
+		CodePermissionDenied:   "PermissionDenied",
+		CodeResourceExhausted:  "ResourceExhausted",
+		CodeFailedPrecondition: "FailedPrecondition",
+		CodeAborted:            "Aborted",
+		CodeOutOfRange:         "OutOfRange",
+		CodeUnimplemented:      "Unimplemented",
+		CodeInternal:           "Internal",
+		CodeUnavailable:        "Unavailable",
+		CodeDataLoss:           "DataLoss",
+		CodeUnauthenticated:    "Unauthenticated",
+	}
+)
+
+// String 返回错误码的名称，自定义错误码返回 "Code(<数值>)"。
+//
+// 返回值：
+//   - string：错误码的名称。
+func (c Code) String() string {
+	if name, ok := codeNames[c]; ok {
+		return name
+	}
+	return "Code(" + strconv.FormatUint(uint64(c), 10) + ")"
+}
+
+// ParseCode 根据名称返回预定义的错误码，名称与 String 的返回值一致。
+//
+// 参数：
+//   - name：错误码的名称，例如 "NotFound"。
+//
+// 返回值：
+//   - Code：对应的错误码。
+//   - bool：名称是预定义错误码时返回 true。
+func ParseCode(name string) (Code, bool) {
+	for code, n := range codeNames {
+		if n == name {
+			return code, true
+		}
+	}
+	return CodeUnknown, false
+}
+
+// WithCode 为错误设置错误码。
+// 错误链中已有错误码时，外层设置的错误码优先。
+//
+// 参数：
+//   - err：原始错误，为 nil 时返回 nil。
+//   - code：错误码。
+//
+// 返回值：
+//   - error：带错误码的错误，错误信息与原始错误相同。
+//
+// 示例：
+//
+//	if nil == user {
+//	    return errors.WithCode(errors.New("用户不存在"), errors.CodeNotFound)
+//	}
+func WithCode(err error, code Code) error {
+	if nil == err {
+		return nil
+	}
+	return &Error{cause: err, code: code, hasCode: true}
+}
+
+// CodeOf 返回错误链中最外层的错误码。
+// 实现了 Code() Code 方法的错误同样可以提供错误码。
+//
+// 参数：
+//   - err：要检查的错误。
+//
+// 返回值：
+//   - Code：err 为 nil 时返回 CodeOK；错误链中没有错误码时返回 CodeUnknown。
+func CodeOf(err error) Code {
+	if nil == err {
+		return CodeOK
+	}
+	for e := err; nil != e; e = Unwrap(e) {
+		if ke, ok := e.(*Error); ok {
+			if ke.hasCode {
+				return ke.code
+			}
+			continue
+		}
+		if coder, ok := e.(interface{ Code() Code }); ok {
+			return coder.Code()
+		}
+	}
+	return CodeUnknown
+}
+
+// IsCode 判断错误的错误码是否为 code。
+//
+// 参数：
+//   - err：要检查的错误。
+//   - code：期望的错误码。
+//
+// 返回值：
+//   - bool：错误码相同时返回 true。
+func IsCode(err error, code Code) bool {
+	return CodeOf(err) == code
+}
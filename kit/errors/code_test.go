@@ -0,0 +1,50 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// customCodeError 是实现了 Code 方法的自定义错误。
+type customCodeError struct{}
+
+func (customCodeError) Error() string { return "自定义错误" }
+func (customCodeError) Code() Code    { return CodeUnavailable }
+
+// TestCode_String 测试错误码名称。
+func TestCode_String(t *testing.T) {
+	assert.Equal(t, "OK", CodeOK.String())
+	assert.Equal(t, "NotFound", CodeNotFound.String())
+	assert.Equal(t, "Unauthenticated", CodeUnauthenticated.String())
+	assert.Equal(t, "Code(1000)", Code(1000).String())
+}
+
+// TestCodeOf 测试获取错误码。
+func TestCodeOf(t *testing.T) {
+	assert.Equal(t, CodeOK, CodeOf(nil))
+	assert.Equal(t, CodeUnknown, CodeOf(io.EOF))
+	assert.Nil(t, WithCode(nil, CodeInternal))
+
+	err := WithCode(io.EOF, CodeNotFound)
+	assert.Equal(t, io.EOF.Error(), err.Error())
+	assert.Equal(t, CodeNotFound, CodeOf(err))
+	assert.True(t, IsCode(err, CodeNotFound))
+	assert.True(t, Is(err, io.EOF))
+
+	// 外层的错误码优先。
+	err = WithCode(Wrap(err, "查询失败"), CodeInternal)
+	assert.Equal(t, CodeInternal, CodeOf(err))
+	assert.Equal(t, CodeInternal, err.(*Error).Code())
+
+	// 没有设置错误码的包装层不影响内层的错误码。
+	assert.Equal(t, CodeNotFound, CodeOf(Wrap(WithCode(io.EOF, CodeNotFound), "外层")))
+
+	// 自定义错误通过 Code 方法提供错误码。
+	assert.Equal(t, CodeUnavailable, CodeOf(Wrap(customCodeError{}, "调用失败")))
+}
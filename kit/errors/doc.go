@@ -0,0 +1,56 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package errors 提供了携带错误码、调用堆栈与元数据的错误处理功能。
+
+主要功能：
+
+  - 创建错误：New、Errorf 创建携带调用堆栈的错误
+  - 包装错误：Wrap、Wrapf 为错误附加上下文信息，错误链中没有调用堆栈时在包装处捕获
+  - 错误码：WithCode 设置错误码，CodeOf、IsCode 读取错误码，预定义的错误码与 gRPC 状态码一一对应
+  - 元数据：WithField、WithFields 附加排查问题所需的上下文，FieldsOf 读取
+  - 日志集成：LogFields 将错误展开为结构化日志字段
+  - 传输协议映射：HTTPStatus、CodeFromHTTPStatus 在错误码与 HTTP 状态码之间转换，错误码的数值与 gRPC 状态码相同
+  - 多错误：MultiError 并发安全地收集多个错误，Append 合并错误
+
+本包创建的错误与标准库完全兼容，Is、As、Unwrap 等同于标准库的同名函数，
+可以与 fmt.Errorf 的 %w 混合使用。
+
+基本使用：
+
+	var ErrUserNotFound = errors.WithCode(errors.New("用户不存在"), errors.CodeNotFound)
+
+	func GetUser(id int64) (*User, error) {
+	    user, err := db.Find(id)
+	    if nil != err {
+	        return nil, errors.WithField(errors.Wrap(err, "查询用户失败"), "user_id", id)
+	    }
+	    if nil == user {
+	        return nil, ErrUserNotFound
+	    }
+	    return user, nil
+	}
+
+	if _, err := GetUser(42); errors.IsCode(err, errors.CodeNotFound) {
+	    // 处理用户不存在。
+	}
+
+记录日志：
+
+	logger.WithFields(errors.LogFields(err)).Error("处理请求失败")
+
+收集多个错误：
+
+	var merr errors.MultiError
+	for _, task := range tasks {
+	    merr.Append(task.Run())
+	}
+	return merr.ErrorOrNil()
+
+格式化输出：
+
+%v 与 %s 只输出错误信息，%+v 额外输出错误码、元数据与调用堆栈。
+*/
+package errors
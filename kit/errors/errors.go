@@ -0,0 +1,202 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// messageSeparator 是包装错误时附加信息与原始错误信息之间的分隔符。
+	messageSeparator = "："
+)
+
+type (
+	// Error 是携带错误码、调用堆栈与元数据的错误。
+	// Error 由 New、Wrap、WithCode、WithFields 等函数创建，每次包装都会产生新的 Error，原有错误不会被修改。
+	Error struct {
+		// msg 是本层附加的错误信息，为空时直接使用 cause 的错误信息。
+		msg string
+		// cause 是被包装的错误。
+		cause error
+		// code 是本层设置的错误码。
+		code Code
+		// hasCode 表示本层是否设置了错误码。
+		hasCode bool
+		// fields 是本层附加的元数据。
+		fields map[string]interface{}
+		// stack 是创建错误时捕获的调用堆栈。
+		stack *stack
+	}
+)
+
+// New 创建一个携带调用堆栈的错误。
+//
+// 参数：
+//   - msg：错误信息。
+//
+// 返回值：
+//   - error：新创建的错误。
+//
+// 示例：
+//
+//	var ErrNotFound = errors.New("记录不存在")
+func New(msg string) error {
+	return &Error{msg: msg, stack: callers(0)}
+}
+
+// Errorf 按格式创建一个携带调用堆栈的错误，支持 %w 包装其他错误。
+// 被包装的错误已经携带调用堆栈时，不再重复捕获。
+//
+// 参数：
+//   - format：格式字符串。
+//   - args：格式参数。
+//
+// 返回值：
+//   - error：新创建的错误。
+func Errorf(format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	e := &Error{cause: err}
+	if nil == stackOf(Unwrap(err)) {
+		e.stack = callers(0)
+	}
+	return e
+}
+
+// Wrap 为错误附加上下文信息，错误信息的格式为 "<msg>：<原始错误信息>"。
+// 错误链中没有调用堆栈时，在此处捕获。
+//
+// 参数：
+//   - err：原始错误，为 nil 时返回 nil。
+//   - msg：附加的上下文信息。
+//
+// 返回值：
+//   - error：包装后的错误，可以通过 Is、As 与 Unwrap 获取原始错误。
+//
+// 示例：
+//
+//	if err := db.Query(ctx, sql); nil != err {
+//	    return errors.Wrap(err, "查询用户失败")
+//	}
+func Wrap(err error, msg string) error {
+	if nil == err {
+		return nil
+	}
+	e := &Error{msg: msg, cause: err}
+	if nil == stackOf(err) {
+		e.stack = callers(0)
+	}
+	return e
+}
+
+// Wrapf 与 Wrap 相同，附加的上下文信息按格式生成。
+//
+// 参数：
+//   - err：原始错误，为 nil 时返回 nil。
+//   - format：格式字符串。
+//   - args：格式参数。
+//
+// 返回值：
+//   - error：包装后的错误。
+func Wrapf(err error, format string, args ...interface{}) error {
+	if nil == err {
+		return nil
+	}
+	e := &Error{msg: fmt.Sprintf(format, args...), cause: err}
+	if nil == stackOf(err) {
+		e.stack = callers(0)
+	}
+	return e
+}
+
+// Is 报告错误链中是否有与 target 相等的错误，等同于标准库的 errors.Is。
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+// As 在错误链中查找第一个可以赋值给 target 的错误，等同于标准库的 errors.As。
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
+
+// Unwrap 返回被 err 包装的错误，等同于标准库的 errors.Unwrap。
+func Unwrap(err error) error {
+	return stderrors.Unwrap(err)
+}
+
+// Error 返回错误信息。
+//
+// 返回值：
+//   - string：错误信息，包含被包装错误的信息。
+func (e *Error) Error() string {
+	switch {
+	case nil == e.cause:
+		return e.msg
+	case "" == e.msg:
+		return e.cause.Error()
+	default:
+		return e.msg + messageSeparator + e.cause.Error()
+	}
+}
+
+// Unwrap 返回被包装的错误。
+//
+// 返回值：
+//   - error：被包装的错误，没有时返回 nil。
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Code 返回错误链中最外层的错误码。
+//
+// 返回值：
+//   - Code：错误码，没有设置时返回 CodeUnknown。
+func (e *Error) Code() Code {
+	return CodeOf(e)
+}
+
+// Fields 返回错误链中全部的元数据。
+//
+// 返回值：
+//   - map[string]interface{}：元数据的副本，同名的键以外层为准。
+func (e *Error) Fields() map[string]interface{} {
+	return FieldsOf(e)
+}
+
+// StackTrace 返回错误链中最内层捕获的调用堆栈。
+//
+// 返回值：
+//   - string：格式化后的调用堆栈，每个调用帧占两行。
+func (e *Error) StackTrace() string {
+	return StackOf(e)
+}
+
+// Format 实现了 fmt.Formatter 接口。
+// %s 与 %v 输出错误信息，%q 输出带引号的错误信息，%+v 额外输出错误码、元数据与调用堆栈。
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, e.Error())
+			if code := CodeOf(e); CodeUnknown != code {
+				_, _ = fmt.Fprintf(s, "\ncode: %s", code)
+			}
+			if fields := FieldsOf(e); len(fields) > 0 {
+				_, _ = fmt.Fprintf(s, "\nfields: %v", fields)
+			}
+			if st := StackOf(e); "" != st {
+				_, _ = io.WriteString(s, "\n"+st)
+			}
+			return
+		}
+		_, _ = io.WriteString(s, e.Error())
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Error())
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNew 测试创建错误。
+func TestNew(t *testing.T) {
+	err := New("记录不存在")
+	assert.Equal(t, "记录不存在", err.Error())
+	assert.Contains(t, StackOf(err), "TestNew")
+	assert.Equal(t, CodeUnknown, CodeOf(err))
+}
+
+// TestWrap 测试包装错误。
+func TestWrap(t *testing.T) {
+	assert.Nil(t, Wrap(nil, "忽略"))
+	assert.Nil(t, Wrapf(nil, "忽略 %d", 1))
+
+	err := Wrap(io.EOF, "读取配置失败")
+	assert.Equal(t, "读取配置失败："+io.EOF.Error(), err.Error())
+	assert.True(t, Is(err, io.EOF))
+	assert.Equal(t, io.EOF, Unwrap(err))
+	assert.NotEmpty(t, StackOf(err), "原始错误没有堆栈时在包装处捕获")
+
+	err = Wrapf(err, "加载 %s", "app.yaml")
+	assert.Equal(t, "加载 app.yaml：读取配置失败："+io.EOF.Error(), err.Error())
+	assert.True(t, Is(err, io.EOF))
+
+	var ke *Error
+	require.True(t, As(err, &ke))
+	assert.Nil(t, ke.stack, "错误链中已有堆栈时不重复捕获")
+}
+
+// TestErrorf 测试按格式创建错误。
+func TestErrorf(t *testing.T) {
+	err := Errorf("用户 %d 不存在", 42)
+	assert.Equal(t, "用户 42 不存在", err.Error())
+	assert.NotEmpty(t, StackOf(err))
+
+	inner := WithCode(New("超时"), CodeDeadlineExceeded)
+	err = Errorf("调用下游失败：%w", inner)
+	assert.Equal(t, "调用下游失败：超时", err.Error())
+	assert.True(t, Is(err, inner))
+	assert.Equal(t, CodeDeadlineExceeded, CodeOf(err))
+
+	var ke *Error
+	require.True(t, As(err, &ke))
+	assert.Nil(t, ke.stack, "被包装的错误已有堆栈时不重复捕获")
+}
+
+// TestError_Format 测试格式化输出。
+func TestError_Format(t *testing.T) {
+	err := WithField(WithCode(New("记录不存在"), CodeNotFound), "id", 7)
+
+	assert.Equal(t, "记录不存在", fmt.Sprintf("%s", err))
+	assert.Equal(t, "记录不存在", fmt.Sprintf("%v", err))
+	assert.Equal(t, `"记录不存在"`, fmt.Sprintf("%q", err))
+
+	detail := fmt.Sprintf("%+v", err)
+	assert.Contains(t, detail, "记录不存在")
+	assert.Contains(t, detail, "code: NotFound")
+	assert.Contains(t, detail, "fields: map[id:7]")
+	assert.Contains(t, detail, "TestError_Format")
+}
+
+// TestError_StdCompatible 测试与标准库错误的互操作。
+func TestError_StdCompatible(t *testing.T) {
+	sentinel := New("哨兵错误")
+	err := fmt.Errorf("外层：%w", Wrap(sentinel, "中间层"))
+	assert.True(t, stderrors.Is(err, sentinel))
+	assert.Equal(t, "外层：中间层：哨兵错误", err.Error())
+	assert.Contains(t, StackOf(err), "TestError_StdCompatible")
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+const (
+	// LogFieldError 是 LogFields 中错误信息的字段名。
+	LogFieldError = "error"
+	// LogFieldCode 是 LogFields 中错误码的字段名。
+	LogFieldCode = "error_code"
+	// LogFieldStack 是 LogFields 中调用堆栈的字段名。
+	LogFieldStack = "error_stack"
+)
+
+// WithFields 为错误附加元数据，例如请求参数、资源标识等排查问题所需的上下文。
+// 错误链中已有同名的元数据时，外层附加的值优先。
+//
+// 参数：
+//   - err：原始错误，为 nil 时返回 nil。
+//   - fields：元数据。
+//
+// 返回值：
+//   - error：附加了元数据的错误，错误信息与原始错误相同。
+//
+// 示例：
+//
+//	return errors.WithFields(err, map[string]interface{}{
+//	    "user_id": userID,
+//	    "order_id": orderID,
+//	})
+func WithFields(err error, fields map[string]interface{}) error {
+	if nil == err {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+	return &Error{cause: err, fields: copied}
+}
+
+// WithField 为错误附加一项元数据。
+//
+// 参数：
+//   - err：原始错误，为 nil 时返回 nil。
+//   - key：元数据的键。
+//   - value：元数据的值。
+//
+// 返回值：
+//   - error：附加了元数据的错误。
+func WithField(err error, key string, value interface{}) error {
+	if nil == err {
+		return nil
+	}
+	return &Error{cause: err, fields: map[string]interface{}{key: value}}
+}
+
+// FieldsOf 返回错误链中全部的元数据。
+//
+// 参数：
+//   - err：要检查的错误。
+//
+// 返回值：
+//   - map[string]interface{}：元数据的副本，同名的键以外层为准；没有元数据时返回空映射。
+func FieldsOf(err error) map[string]interface{} {
+	var chain []*Error
+	for e := err; nil != e; e = Unwrap(e) {
+		if ke, ok := e.(*Error); ok && len(ke.fields) > 0 {
+			chain = append(chain, ke)
+		}
+	}
+
+	fields := make(map[string]interface{})
+	// 从内层到外层依次覆盖。
+	for i := len(chain) - 1; i >= 0; i-- {
+		for k, v := range chain[i].fields {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// LogFields 返回适合写入结构化日志的字段，包含错误信息、错误码、调用堆栈与全部元数据。
+// 元数据与固定字段同名时，以固定字段为准。
+//
+// 参数：
+//   - err：要记录的错误。
+//
+// 返回值：
+//   - map[string]interface{}：日志字段，err 为 nil 时返回 nil。
+//
+// 示例：
+//
+//	logger.WithFields(errors.LogFields(err)).Error("处理请求失败")
+func LogFields(err error) map[string]interface{} {
+	if nil == err {
+		return nil
+	}
+
+	fields := FieldsOf(err)
+	fields[LogFieldError] = err.Error()
+	fields[LogFieldCode] = CodeOf(err).String()
+	if st := StackOf(err); "" != st {
+		fields[LogFieldStack] = st
+	}
+	return fields
+}
+
+// LogFields 返回适合写入结构化日志的字段。
+// kit/log 等日志组件通过该方法识别并展开错误中的结构化信息。
+//
+// 返回值：
+//   - map[string]interface{}：日志字段。
+func (e *Error) LogFields() map[string]interface{} {
+	return LogFields(e)
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFieldsOf 测试附加与获取元数据。
+func TestFieldsOf(t *testing.T) {
+	assert.Nil(t, WithFields(nil, map[string]interface{}{"a": 1}))
+	assert.Nil(t, WithField(nil, "a", 1))
+	assert.Empty(t, FieldsOf(io.EOF))
+
+	src := map[string]interface{}{"user_id": 1, "order_id": 2}
+	err := WithFields(io.EOF, src)
+	src["user_id"] = 100
+	assert.Equal(t, map[string]interface{}{"user_id": 1, "order_id": 2}, FieldsOf(err), "元数据在附加时复制")
+	assert.Equal(t, io.EOF.Error(), err.Error())
+
+	// 外层的同名元数据优先。
+	err = WithField(Wrap(err, "下单失败"), "user_id", 3)
+	assert.Equal(t, map[string]interface{}{"user_id": 3, "order_id": 2}, err.(*Error).Fields())
+}
+
+// TestLogFields 测试生成日志字段。
+func TestLogFields(t *testing.T) {
+	assert.Nil(t, LogFields(nil))
+
+	fields := LogFields(io.EOF)
+	assert.Equal(t, map[string]interface{}{
+		LogFieldError: io.EOF.Error(),
+		LogFieldCode:  "Unknown",
+	}, fields)
+
+	err := WithField(WithCode(New("记录不存在"), CodeNotFound), "id", 7)
+	fields = err.(*Error).LogFields()
+	assert.Equal(t, "记录不存在", fields[LogFieldError])
+	assert.Equal(t, "NotFound", fields[LogFieldCode])
+	assert.Equal(t, 7, fields["id"])
+	assert.Contains(t, fields[LogFieldStack], "TestLogFields")
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// MultiError 是并发安全的多错误收集器。
+	// 常用于收集并发任务、批量提交或多次重试中各自产生的错误，全部完成后通过 ErrorOrNil 统一返回。
+	// MultiError 的零值即可使用。
+	MultiError struct {
+		// mu 保护 errs。
+		mu sync.RWMutex
+		// errs 是收集到的错误，按添加顺序排列。
+		errs []error
+	}
+)
+
+// Append 将错误添加到 err 中并返回合并后的错误，nil 错误会被忽略。
+// err 为 *MultiError 时直接向其中添加；否则创建新的 *MultiError。
+//
+// 参数：
+//   - err：已有的错误，可以为 nil。
+//   - errs：要添加的错误。
+//
+// 返回值：
+//   - error：合并后的错误，全部为 nil 时返回 nil。
+//
+// 示例：
+//
+//	var err error
+//	for _, f := range files {
+//	    err = errors.Append(err, os.Remove(f))
+//	}
+//	return err
+func Append(err error, errs ...error) error {
+	m, ok := err.(*MultiError)
+	if !ok {
+		m = &MultiError{}
+		m.Append(err)
+	}
+	m.Append(errs...)
+	if 0 == m.Len() {
+		return nil
+	}
+	return m
+}
+
+// Append 添加错误，nil 错误会被忽略，*MultiError 会被展开。
+//
+// 参数：
+//   - errs：要添加的错误。
+func (m *MultiError) Append(errs ...error) {
+	var flat []error
+	for _, err := range errs {
+		switch e := err.(type) {
+		case nil:
+		case *MultiError:
+			flat = append(flat, e.Errors()...)
+		default:
+			flat = append(flat, err)
+		}
+	}
+	if 0 == len(flat) {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errs = append(m.errs, flat...)
+}
+
+// Errors 返回收集到的全部错误。
+//
+// 返回值：
+//   - []error：错误列表的副本，按添加顺序排列。
+func (m *MultiError) Errors() []error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]error(nil), m.errs...)
+}
+
+// Len 返回收集到的错误数量。
+//
+// 返回值：
+//   - int：错误数量。
+func (m *MultiError) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.errs)
+}
+
+// ErrorOrNil 在没有收集到错误时返回 nil，否则返回当前错误的快照。
+// 返回快照而不是 m 本身，避免之后的 Append 修改已经返回的错误。
+//
+// 返回值：
+//   - error：没有错误时返回 nil，否则返回 *MultiError。
+//
+// 示例：
+//
+//	var merr errors.MultiError
+//	var wg sync.WaitGroup
+//	for _, task := range tasks {
+//	    wg.Add(1)
+//	    go func() {
+//	        defer wg.Done()
+//	        merr.Append(task.Run())
+//	    }()
+//	}
+//	wg.Wait()
+//	return merr.ErrorOrNil()
+func (m *MultiError) ErrorOrNil() error {
+	if nil == m {
+		return nil
+	}
+	errs := m.Errors()
+	if 0 == len(errs) {
+		return nil
+	}
+	return &MultiError{errs: errs}
+}
+
+// Error 返回错误信息。
+// 只有一个错误时返回该错误的信息；有多个错误时返回 "发生了 N 个错误：" 及逐行列出的各错误信息。
+//
+// 返回值：
+//   - string：错误信息。
+func (m *MultiError) Error() string {
+	errs := m.Errors()
+	switch len(errs) {
+	case 0:
+		return "没有错误"
+	case 1:
+		return errs[0].Error()
+	}
+
+	var b strings.Builder
+	b.WriteString("发生了 ")
+	b.WriteString(strconv.Itoa(len(errs)))
+	b.WriteString(" 个错误：")
+	for _, err := range errs {
+		b.WriteString("\n\t* ")
+		b.WriteString(strings.ReplaceAll(err.Error(), "\n", "\n\t  "))
+	}
+	return b.String()
+}
+
+// Unwrap 返回收集到的全部错误，标准库的 errors.Is 与 errors.As 会逐个检查。
+//
+// 返回值：
+//   - []error：错误列表的副本。
+func (m *MultiError) Unwrap() []error {
+	return m.Errors()
+}
+
+// Format 实现了 fmt.Formatter 接口，%+v 对每个错误使用 %+v 输出详细信息。
+func (m *MultiError) Format(s fmt.State, verb rune) {
+	if 'v' != verb || !s.Flag('+') {
+		if 'q' == verb {
+			_, _ = fmt.Fprintf(s, "%q", m.Error())
+			return
+		}
+		_, _ = io.WriteString(s, m.Error())
+		return
+	}
+
+	errs := m.Errors()
+	_, _ = fmt.Fprintf(s, "发生了 %d 个错误：", len(errs))
+	for i, err := range errs {
+		detail := strings.ReplaceAll(fmt.Sprintf("%+v", err), "\n", "\n\t  ")
+		_, _ = fmt.Fprintf(s, "\n\t[%d] %s", i+1, detail)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMultiError_Append 测试添加错误与展开。
+func TestMultiError_Append(t *testing.T) {
+	var m MultiError
+	assert.Nil(t, m.ErrorOrNil())
+	var nilMulti *MultiError
+	assert.Nil(t, nilMulti.ErrorOrNil())
+
+	m.Append(nil, io.EOF, nil)
+	assert.Equal(t, 1, m.Len())
+	assert.Equal(t, io.EOF.Error(), m.Error(), "只有一个错误时直接返回其信息")
+
+	inner := &MultiError{}
+	inner.Append(io.ErrUnexpectedEOF, io.ErrClosedPipe)
+	m.Append(inner)
+	assert.Equal(t, []error{io.EOF, io.ErrUnexpectedEOF, io.ErrClosedPipe}, m.Errors(), "嵌套的 MultiError 被展开")
+
+	err := m.ErrorOrNil()
+	require.Error(t, err)
+	m.Append(io.ErrShortWrite)
+	assert.Equal(t, 3, err.(*MultiError).Len(), "ErrorOrNil 返回快照")
+
+	assert.True(t, Is(err, io.ErrClosedPipe))
+	assert.False(t, Is(err, io.ErrShortWrite))
+	var ke *Error
+	m.Append(WithCode(New("不可用"), CodeUnavailable))
+	assert.True(t, As(&m, &ke))
+	assert.Equal(t, CodeUnavailable, ke.Code())
+}
+
+// TestAppend 测试包级的 Append 函数。
+func TestAppend(t *testing.T) {
+	assert.Nil(t, Append(nil))
+	assert.Nil(t, Append(nil, nil, nil))
+
+	var err error
+	err = Append(err, io.EOF)
+	err = Append(err, nil, io.ErrUnexpectedEOF)
+	require.IsType(t, &MultiError{}, err)
+	assert.Equal(t, []error{io.EOF, io.ErrUnexpectedEOF}, err.(*MultiError).Errors())
+
+	err = Append(io.EOF, io.ErrClosedPipe)
+	assert.Equal(t, 2, err.(*MultiError).Len())
+}
+
+// TestMultiError_Format 测试格式化输出。
+func TestMultiError_Format(t *testing.T) {
+	var m MultiError
+	assert.Equal(t, "没有错误", m.Error())
+
+	m.Append(io.EOF, fmt.Errorf("第一行\n第二行"))
+	want := "发生了 2 个错误：\n\t* EOF\n\t* 第一行\n\t  第二行"
+	assert.Equal(t, want, m.Error())
+	assert.Equal(t, want, fmt.Sprintf("%v", &m))
+	assert.Equal(t, fmt.Sprintf("%q", want), fmt.Sprintf("%q", &m))
+
+	m.Append(WithCode(New("超时"), CodeDeadlineExceeded))
+	detail := fmt.Sprintf("%+v", &m)
+	assert.Contains(t, detail, "发生了 3 个错误：\n\t[1] EOF")
+	assert.Contains(t, detail, "[3] 超时\n\t  code: DeadlineExceeded")
+	assert.Contains(t, detail, "TestMultiError_Format")
+}
+
+// TestMultiError_Concurrent 测试并发添加错误。
+func TestMultiError_Concurrent(t *testing.T) {
+	var m MultiError
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if 0 == i%2 {
+				m.Append(Errorf("任务 %d 失败", i))
+			} else {
+				m.Append(nil)
+			}
+			_ = m.Error()
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 50, m.Len())
+}
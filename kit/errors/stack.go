@@ -0,0 +1,88 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	// stackMaxDepth 是捕获调用堆栈的最大深度。
+	stackMaxDepth = 32
+)
+
+type (
+	// stack 是捕获的调用堆栈，保存各调用帧的程序计数器。
+	stack []uintptr
+)
+
+// callers 捕获调用堆栈。
+// skip 为 0 时从调用 callers 的函数的调用方开始，即跳过 New、Wrap 等创建错误的函数本身。
+func callers(skip int) *stack {
+	pcs := make([]uintptr, stackMaxDepth)
+	// 跳过 runtime.Callers、callers 以及创建错误的函数。
+	n := runtime.Callers(3+skip, pcs)
+	st := stack(pcs[:n])
+	return &st
+}
+
+// String 将调用堆栈格式化为 "函数名\n\t文件:行号" 的形式，调用帧之间以换行分隔。
+func (s *stack) String() string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(*s)
+	for {
+		frame, more := frames.Next()
+		if "" != frame.Function {
+			if b.Len() > 0 {
+				b.WriteByte('\n')
+			}
+			b.WriteString(frame.Function)
+			b.WriteString("\n\t")
+			b.WriteString(frame.File)
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// stackOf 返回错误链中最内层捕获的调用堆栈，没有时返回 nil。
+// 最内层的调用堆栈最接近错误发生的位置。
+func stackOf(err error) *stack {
+	var st *stack
+	for e := err; nil != e; e = Unwrap(e) {
+		if ke, ok := e.(*Error); ok && nil != ke.stack {
+			st = ke.stack
+		}
+	}
+	return st
+}
+
+// StackOf 返回错误链中最内层捕获的调用堆栈。
+//
+// 参数：
+//   - err：要检查的错误。
+//
+// 返回值：
+//   - string：格式化后的调用堆栈，错误链中没有调用堆栈时返回空字符串。
+//
+// 示例：
+//
+//	if err := run(); nil != err {
+//	    fmt.Fprintln(os.Stderr, err)
+//	    fmt.Fprintln(os.Stderr, errors.StackOf(err))
+//	}
+func StackOf(err error) string {
+	st := stackOf(err)
+	if nil == st {
+		return ""
+	}
+	return st.String()
+}
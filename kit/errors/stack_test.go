@@ -0,0 +1,34 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newNested 在另一个函数中创建错误，用于验证堆栈的起点。
+func newNested() error {
+	return New("内层错误")
+}
+
+// TestStackOf 测试获取调用堆栈。
+func TestStackOf(t *testing.T) {
+	assert.Equal(t, "", StackOf(nil))
+	assert.Equal(t, "", StackOf(io.EOF))
+
+	err := Wrap(newNested(), "外层")
+	st := StackOf(err)
+	lines := strings.Split(st, "\n")
+	assert.True(t, strings.HasSuffix(lines[0], ".newNested"), "堆栈从创建错误的函数开始：%s", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "\t"))
+	assert.Contains(t, lines[1], "stack_test.go:")
+	assert.Contains(t, st, "TestStackOf")
+	assert.NotContains(t, st, "errors.New")
+	assert.Equal(t, st, err.(*Error).StackTrace())
+}
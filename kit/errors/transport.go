@@ -0,0 +1,136 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+import (
+	"context"
+	stdhttp "net/http"
+)
+
+const (
+	// StatusClientClosedRequest 是调用方取消请求时使用的 HTTP 状态码，沿用 nginx 的约定，net/http 中没有定义。
+	StatusClientClosedRequest = 499
+)
+
+var (
+	// httpStatuses 是预定义错误码对应的 HTTP 状态码，与 grpc-gateway 的映射一致。
+	httpStatuses = map[Code]int{
+		CodeOK:                 stdhttp.StatusOK,
+		CodeCanceled:           StatusClientClosedRequest,
+		CodeUnknown:            stdhttp.StatusInternalServerError,
+		CodeInvalidArgument:    stdhttp.StatusBadRequest,
+		CodeDeadlineExceeded:   stdhttp.StatusGatewayTimeout,
+		CodeNotFound:           stdhttp.StatusNotFound,
+		CodeAlreadyExists:      stdhttp.StatusConflict,
+		CodePermissionDenied:   stdhttp.StatusForbidden,
+		CodeResourceExhausted:  stdhttp.StatusTooManyRequests,
+		CodeFailedPrecondition: stdhttp.StatusBadRequest,
+		CodeAborted:            stdhttp.StatusConflict,
+		CodeOutOfRange:         stdhttp.StatusBadRequest,
+		CodeUnimplemented:      stdhttp.StatusNotImplemented,
+		CodeInternal:           stdhttp.StatusInternalServerError,
+		CodeUnavailable:        stdhttp.StatusServiceUnavailable,
+		CodeDataLoss:           stdhttp.StatusInternalServerError,
+		CodeUnauthenticated:    stdhttp.StatusUnauthorized,
+	}
+
+	// httpCodes 是 HTTP 状态码对应的错误码，多个错误码对应同一个状态码时取最常见的含义。
+	httpCodes = map[int]Code{
+		stdhttp.StatusBadRequest:                   CodeInvalidArgument,
+		stdhttp.StatusUnauthorized:                 CodeUnauthenticated,
+		stdhttp.StatusForbidden:                    CodePermissionDenied,
+		stdhttp.StatusNotFound:                     CodeNotFound,
+		stdhttp.StatusMethodNotAllowed:             CodeUnimplemented,
+		stdhttp.StatusRequestTimeout:               CodeDeadlineExceeded,
+		stdhttp.StatusConflict:                     CodeAlreadyExists,
+		stdhttp.StatusPreconditionFailed:           CodeFailedPrecondition,
+		stdhttp.StatusRequestEntityTooLarge:        CodeInvalidArgument,
+		stdhttp.StatusRequestedRangeNotSatisfiable: CodeOutOfRange,
+		stdhttp.StatusTooManyRequests:              CodeResourceExhausted,
+		StatusClientClosedRequest:                  CodeCanceled,
+		stdhttp.StatusNotImplemented:               CodeUnimplemented,
+		stdhttp.StatusBadGateway:                   CodeUnavailable,
+		stdhttp.StatusServiceUnavailable:           CodeUnavailable,
+		stdhttp.StatusGatewayTimeout:               CodeDeadlineExceeded,
+	}
+)
+
+// HTTPStatus 返回错误码对应的 HTTP 状态码，映射与 grpc-gateway 一致。
+// 自定义错误码返回 500。
+//
+// 参数：
+//   - code：错误码。
+//
+// 返回值：
+//   - int：HTTP 状态码。
+func HTTPStatus(code Code) int {
+	if status, ok := httpStatuses[code]; ok {
+		return status
+	}
+	return stdhttp.StatusInternalServerError
+}
+
+// HTTPStatusOf 返回错误对应的 HTTP 状态码，错误码由 TransportCodeOf 确定。
+//
+// 参数：
+//   - err：要转换的错误。
+//
+// 返回值：
+//   - int：HTTP 状态码，err 为 nil 时返回 200。
+//
+// 示例：
+//
+//	w.WriteHeader(errors.HTTPStatusOf(err))
+func HTTPStatusOf(err error) int {
+	return HTTPStatus(TransportCodeOf(err))
+}
+
+// CodeFromHTTPStatus 返回 HTTP 状态码对应的错误码。
+// 2xx 与 3xx 返回 CodeOK；未列出的 4xx 返回 CodeFailedPrecondition，未列出的 5xx 返回 CodeInternal，其余返回 CodeUnknown。
+// 多个错误码对应同一个状态码，转换后不一定得到原来的错误码，需要精确的错误码时应在响应体中携带错误码的名称。
+//
+// 参数：
+//   - status：HTTP 状态码。
+//
+// 返回值：
+//   - Code：错误码。
+func CodeFromHTTPStatus(status int) Code {
+	if code, ok := httpCodes[status]; ok {
+		return code
+	}
+	switch {
+	case status >= 200 && status < 400:
+		return CodeOK
+	case status >= 400 && status < 500:
+		return CodeFailedPrecondition
+	case status >= 500 && status < 600:
+		return CodeInternal
+	default:
+		return CodeUnknown
+	}
+}
+
+// TransportCodeOf 返回错误在跨进程传输时使用的错误码。
+// 与 CodeOf 相同，但错误链中没有错误码时，context.Canceled 与 context.DeadlineExceeded 分别转换为 CodeCanceled 与 CodeDeadlineExceeded。
+//
+// 参数：
+//   - err：要转换的错误。
+//
+// 返回值：
+//   - Code：错误码。
+func TransportCodeOf(err error) Code {
+	code := CodeOf(err)
+	if CodeUnknown != code {
+		return code
+	}
+	switch {
+	case Is(err, context.Canceled):
+		return CodeCanceled
+	case Is(err, context.DeadlineExceeded):
+		return CodeDeadlineExceeded
+	default:
+		return code
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	stdhttp "net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseCode 测试按名称解析错误码。
+func TestParseCode(t *testing.T) {
+	for code := range codeNames {
+		parsed, ok := ParseCode(code.String())
+		assert.True(t, ok)
+		assert.Equal(t, code, parsed)
+	}
+
+	code, ok := ParseCode("Code(1000)")
+	assert.False(t, ok)
+	assert.Equal(t, CodeUnknown, code)
+}
+
+// TestHTTPStatus 测试错误码与 HTTP 状态码之间的转换。
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		code   Code
+		status int
+	}{
+		{CodeOK, stdhttp.StatusOK},
+		{CodeCanceled, StatusClientClosedRequest},
+		{CodeInvalidArgument, stdhttp.StatusBadRequest},
+		{CodeNotFound, stdhttp.StatusNotFound},
+		{CodeAlreadyExists, stdhttp.StatusConflict},
+		{CodePermissionDenied, stdhttp.StatusForbidden},
+		{CodeResourceExhausted, stdhttp.StatusTooManyRequests},
+		{CodeUnimplemented, stdhttp.StatusNotImplemented},
+		{CodeUnavailable, stdhttp.StatusServiceUnavailable},
+		{CodeDeadlineExceeded, stdhttp.StatusGatewayTimeout},
+		{CodeUnauthenticated, stdhttp.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			assert.Equal(t, tt.status, HTTPStatus(tt.code))
+			assert.Equal(t, tt.code, CodeFromHTTPStatus(tt.status))
+		})
+	}
+
+	// 多个错误码对应同一个状态码。
+	assert.Equal(t, stdhttp.StatusBadRequest, HTTPStatus(CodeFailedPrecondition))
+	assert.Equal(t, stdhttp.StatusInternalServerError, HTTPStatus(CodeDataLoss))
+	assert.Equal(t, stdhttp.StatusInternalServerError, HTTPStatus(Code(1000)))
+
+	// 未列出的状态码按范围转换。
+	assert.Equal(t, CodeOK, CodeFromHTTPStatus(stdhttp.StatusNoContent))
+	assert.Equal(t, CodeFailedPrecondition, CodeFromHTTPStatus(stdhttp.StatusTeapot))
+	assert.Equal(t, CodeInternal, CodeFromHTTPStatus(stdhttp.StatusInternalServerError))
+	assert.Equal(t, CodeUnknown, CodeFromHTTPStatus(0))
+}
+
+// TestHTTPStatusOf 测试错误转换为 HTTP 状态码，上下文错误使用对应的错误码。
+func TestHTTPStatusOf(t *testing.T) {
+	assert.Equal(t, stdhttp.StatusOK, HTTPStatusOf(nil))
+	assert.Equal(t, stdhttp.StatusInternalServerError, HTTPStatusOf(io.EOF))
+	assert.Equal(t, stdhttp.StatusNotFound, HTTPStatusOf(WithCode(io.EOF, CodeNotFound)))
+	assert.Equal(t, stdhttp.StatusGatewayTimeout, HTTPStatusOf(fmt.Errorf("查询：%w", context.DeadlineExceeded)))
+	assert.Equal(t, StatusClientClosedRequest, HTTPStatusOf(Wrap(context.Canceled, "查询")))
+
+	// 显式设置的错误码优先于上下文错误。
+	assert.Equal(t, CodeUnavailable, TransportCodeOf(WithCode(context.Canceled, CodeUnavailable)))
+}
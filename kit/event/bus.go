@@ -0,0 +1,264 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	stdsync "sync"
+)
+
+var (
+	// ErrClosed 表示事件总线已经停止，不再接受新的事件。
+	ErrClosed = errors.New("kit/event: 事件总线已停止")
+)
+
+type (
+	// Topic 是携带事件类型的主题。
+	// 名称相同但事件类型不同的主题互不相通。
+	Topic[T any] struct {
+		// name 是主题的名称。
+		name string
+	}
+
+	// Handler 是订阅者处理事件的函数。
+	Handler[T any] func(ctx context.Context, event T) error
+
+	// Bus 是进程内的事件总线，用于解耦服务内部的模块。
+	// Bus 实现了 kit/runtime 的 Runner 接口，Stop 等待异步投递中的事件处理完成。
+	Bus struct {
+		// o 是事件总线的配置。
+		o *options
+		// mu 保护以下字段。
+		mu stdsync.RWMutex
+		// subs 是各个主题的订阅者，按订阅顺序排列。
+		subs map[topicKey][]*subscription
+		// nextID 是下一个订阅者的编号。
+		nextID uint64
+		// closed 表示事件总线是否已经停止。
+		closed bool
+		// inflight 记录异步投递中的事件数量。
+		inflight stdsync.WaitGroup
+	}
+
+	// topicKey 以主题名称与事件类型区分主题。
+	topicKey struct {
+		// name 是主题的名称。
+		name string
+		// typ 是事件的类型。
+		typ reflect.Type
+	}
+
+	// subscription 是一个订阅者。
+	subscription struct {
+		// id 是订阅者的编号，用于取消订阅。
+		id uint64
+		// handle 是去除类型参数后的处理函数。
+		handle func(ctx context.Context, event any) error
+	}
+)
+
+// NewTopic 创建一个主题。
+//
+// 参数：
+//   - name：主题的名称，建议使用 "模块.事件" 的形式，例如 "order.created"。
+//
+// 返回值：
+//   - Topic[T]：事件类型为 T 的主题。
+//
+// 示例：
+//
+//	var OrderCreated = event.NewTopic[OrderCreatedEvent]("order.created")
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{name: name}
+}
+
+// Name 返回主题的名称。
+func (t Topic[T]) Name() string {
+	return t.name
+}
+
+// key 返回主题在事件总线中的键。
+func (t Topic[T]) key() topicKey {
+	return topicKey{name: t.name, typ: reflect.TypeFor[T]()}
+}
+
+// New 创建一个事件总线。
+//
+// 参数：
+//   - opts：配置选项，支持 WithPool、WithErrorHandler 与 WithLogger。
+//
+// 返回值：
+//   - *Bus：事件总线。
+func New(opts ...Option) *Bus {
+	return &Bus{
+		o:    newOptions(opts...),
+		subs: make(map[topicKey][]*subscription),
+	}
+}
+
+// Subscribe 订阅主题，返回取消订阅的函数。
+// 取消订阅后不再收到新的事件，已经开始异步投递的事件仍会处理。
+//
+// 参数：
+//   - b：事件总线。
+//   - topic：订阅的主题。
+//   - handler：处理事件的函数。
+//
+// 返回值：
+//   - func()：取消订阅的函数，可以重复调用。
+func Subscribe[T any](b *Bus, topic Topic[T], handler Handler[T]) func() {
+	key := topic.key()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	id := b.nextID
+	b.subs[key] = append(b.subs[key], &subscription{
+		id: id,
+		handle: func(ctx context.Context, event any) error {
+			return handler(ctx, event.(T))
+		},
+	})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		subs := b.subs[key]
+		for i, sub := range subs {
+			if id == sub.id {
+				// 复制而不是原地修改，正在投递的事件持有的切片不受影响。
+				b.subs[key] = append(subs[:i:i], subs[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Publish 在调用方的协程中依次将事件投递给主题的所有订阅者。
+// 某个订阅者返回错误或发生 panic 时继续投递给其他订阅者，所有错误合并后返回。
+//
+// 参数：
+//   - ctx：传递给订阅者的上下文。
+//   - b：事件总线。
+//   - topic：事件的主题。
+//   - event：事件。
+//
+// 返回值：
+//   - error：事件总线已停止时返回 ErrClosed，否则返回订阅者错误的合并结果。
+func Publish[T any](ctx context.Context, b *Bus, topic Topic[T], event T) error {
+	subs, err := b.subscribers(topic.key())
+	if nil != err {
+		return err
+	}
+
+	var errs []error
+	for _, sub := range subs {
+		if err := deliver(ctx, topic.name, sub, event); nil != err {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PublishAsync 通过协程池将事件异步投递给主题的所有订阅者，每个订阅者使用一个独立的任务。
+// 订阅者的错误交给 WithErrorHandler 设置的函数处理。
+// 订阅者收到的上下文保留 ctx 中的值，但不会随 ctx 取消。
+//
+// 参数：
+//   - ctx：传递给订阅者的上下文。
+//   - b：事件总线。
+//   - topic：事件的主题。
+//   - event：事件，由所有订阅者并发读取，不应被修改。
+//
+// 返回值：
+//   - error：事件总线已停止时返回 ErrClosed，提交任务失败时返回合并后的错误。
+func PublishAsync[T any](ctx context.Context, b *Bus, topic Topic[T], event T) error {
+	ctx = context.WithoutCancel(ctx)
+
+	// 在持有锁时登记投递数量，保证 Stop 等待时不会遗漏已经接受的事件。
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	subs := b.subs[topic.key()]
+	b.inflight.Add(len(subs))
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		err := b.o.submit(func() {
+			defer b.inflight.Done()
+			if err := deliver(ctx, topic.name, sub, event); nil != err {
+				b.o.handleError(ctx, topic.name, err)
+			}
+		})
+		if nil != err {
+			b.inflight.Done()
+			errs = append(errs, fmt.Errorf("kit/event: 投递主题 %s 的事件失败：%w", topic.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Start 实现 Runner 接口，事件总线创建后即可使用，该方法不做任何处理。
+//
+// 参数：
+//   - ctx：未使用。
+//
+// 返回值：
+//   - error：始终返回 nil。
+func (b *Bus) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop 停止接受新的事件，并等待异步投递中的事件处理完成。
+//
+// 参数：
+//   - ctx：等待的截止时间。
+//
+// 返回值：
+//   - error：截止时间到达时返回上下文的错误。
+func (b *Bus) Stop(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		b.inflight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// subscribers 返回主题当前的订阅者。
+func (b *Bus) subscribers(key topicKey) ([]*subscription, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	return b.subs[key], nil
+}
+
+// deliver 将事件投递给订阅者，并将 panic 转换为错误。
+func deliver(ctx context.Context, topic string, sub *subscription, event any) (err error) {
+	defer func() {
+		if r := recover(); nil != r {
+			err = fmt.Errorf("kit/event: 处理主题 %s 的事件发生 panic：%v", topic, r)
+		}
+	}()
+	return sub.handle(ctx, event)
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdsync "sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+type (
+	// orderCreated 是测试使用的事件。
+	orderCreated struct {
+		ID string
+	}
+
+	// errorLogger 是只记录 Error 日志的 kitlog.Logger 实现。
+	errorLogger struct {
+		kitlog.Logger
+		mu     *stdsync.Mutex
+		lines  *[]string
+		fields map[string]interface{}
+	}
+)
+
+// newErrorLogger 创建一个新的 errorLogger。
+func newErrorLogger() *errorLogger {
+	return &errorLogger{mu: &stdsync.Mutex{}, lines: &[]string{}, fields: map[string]interface{}{}}
+}
+
+func (l *errorLogger) WithField(key string, value interface{}) kitlog.Logger {
+	fields := map[string]interface{}{key: value}
+	for k, v := range l.fields {
+		fields[k] = v
+	}
+	return &errorLogger{mu: l.mu, lines: l.lines, fields: fields}
+}
+
+func (l *errorLogger) Error(args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	*l.lines = append(*l.lines, fmt.Sprint(args...)+" "+fmt.Sprint(l.fields))
+}
+
+// Lines 返回已记录的日志。
+func (l *errorLogger) Lines() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), *l.lines...)
+}
+
+// TestPublish 测试同步投递按订阅顺序执行，错误与 panic 合并后返回。
+func TestPublish(t *testing.T) {
+	bus := New()
+	topic := NewTopic[orderCreated]("order.created")
+	assert.Equal(t, "order.created", topic.Name())
+
+	var got []string
+	Subscribe(bus, topic, func(_ context.Context, e orderCreated) error {
+		got = append(got, "a:"+e.ID)
+		return errors.New("a failed")
+	})
+	Subscribe(bus, topic, func(context.Context, orderCreated) error { panic("boom") })
+	Subscribe(bus, topic, func(_ context.Context, e orderCreated) error {
+		got = append(got, "c:"+e.ID)
+		return nil
+	})
+
+	err := Publish(context.Background(), bus, topic, orderCreated{ID: "1"})
+	assert.Equal(t, []string{"a:1", "c:1"}, got)
+	assert.ErrorContains(t, err, "a failed")
+	assert.ErrorContains(t, err, "kit/event: 处理主题 order.created 的事件发生 panic：boom")
+
+	// 没有订阅者时不返回错误。
+	assert.NoError(t, Publish(context.Background(), bus, NewTopic[int]("none"), 1))
+}
+
+// TestSubscribe 测试名称相同但类型不同的主题互不相通，以及取消订阅。
+func TestSubscribe(t *testing.T) {
+	bus := New()
+	typed := NewTopic[orderCreated]("order")
+	untyped := NewTopic[string]("order")
+
+	var orders, strings int
+	unsubscribe := Subscribe(bus, typed, func(context.Context, orderCreated) error {
+		orders++
+		return nil
+	})
+	Subscribe(bus, untyped, func(context.Context, string) error {
+		strings++
+		return nil
+	})
+
+	require.NoError(t, Publish(context.Background(), bus, typed, orderCreated{}))
+	require.NoError(t, Publish(context.Background(), bus, untyped, "x"))
+	assert.Equal(t, 1, orders)
+	assert.Equal(t, 1, strings)
+
+	unsubscribe()
+	unsubscribe()
+	require.NoError(t, Publish(context.Background(), bus, typed, orderCreated{}))
+	assert.Equal(t, 1, orders)
+}
+
+// TestPublishAsync 测试异步投递的错误交给错误处理函数，且上下文不随发布方取消。
+func TestPublishAsync(t *testing.T) {
+	type failure struct {
+		topic string
+		err   error
+	}
+	failures := make(chan failure, 2)
+	bus := New(WithErrorHandler(func(_ context.Context, topic string, err error) {
+		failures <- failure{topic: topic, err: err}
+	}))
+	topic := NewTopic[orderCreated]("order.created")
+
+	type key struct{}
+	values := make(chan interface{}, 1)
+	Subscribe(bus, topic, func(ctx context.Context, e orderCreated) error {
+		values <- ctx.Value(key{})
+		return ctx.Err()
+	})
+	Subscribe(bus, topic, func(_ context.Context, e orderCreated) error {
+		return errors.New("failed " + e.ID)
+	})
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "v"))
+	cancel()
+	require.NoError(t, PublishAsync(ctx, bus, topic, orderCreated{ID: "1"}))
+	require.NoError(t, bus.Stop(context.Background()))
+
+	assert.Equal(t, "v", <-values)
+	require.Len(t, failures, 1)
+	f := <-failures
+	assert.Equal(t, "order.created", f.topic)
+	assert.EqualError(t, f.err, "failed 1")
+}
+
+// TestPublishAsync_Logger 测试未设置错误处理函数时记录日志。
+func TestPublishAsync_Logger(t *testing.T) {
+	logger := newErrorLogger()
+	bus := New(WithLogger(logger))
+	topic := NewTopic[int]("count")
+	Subscribe(bus, topic, func(context.Context, int) error { return errors.New("bad") })
+
+	require.NoError(t, PublishAsync(context.Background(), bus, topic, 1))
+	require.NoError(t, bus.Stop(context.Background()))
+	require.Len(t, logger.Lines(), 1)
+	assert.Contains(t, logger.Lines()[0], "event handler failed")
+	assert.Contains(t, logger.Lines()[0], "topic:count")
+	assert.NotNil(t, newOptions().getLogger())
+}
+
+// TestPublishAsync_Pool 测试使用自定义的协程池，提交失败时返回错误。
+func TestPublishAsync_Pool(t *testing.T) {
+	pool, release, err := goroutine.NewGoroutinePool(goroutine.WithSize(1), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	bus := New(WithPool(pool))
+	topic := NewTopic[int]("count")
+	done := make(chan int, 1)
+	Subscribe(bus, topic, func(_ context.Context, n int) error {
+		done <- n
+		return nil
+	})
+
+	require.NoError(t, PublishAsync(context.Background(), bus, topic, 1))
+	assert.Equal(t, 1, <-done)
+
+	release()
+	err = PublishAsync(context.Background(), bus, topic, 2)
+	assert.ErrorContains(t, err, "kit/event: 投递主题 count 的事件失败")
+	assert.NoError(t, bus.Stop(context.Background()))
+}
+
+// TestBus_Stop 测试停止时等待异步投递完成，停止后不再接受新的事件。
+func TestBus_Stop(t *testing.T) {
+	bus := New()
+	require.NoError(t, bus.Start(context.Background()))
+	topic := NewTopic[int]("count")
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	Subscribe(bus, topic, func(context.Context, int) error {
+		close(entered)
+		<-release
+		return nil
+	})
+
+	require.NoError(t, PublishAsync(context.Background(), bus, topic, 1))
+	<-entered
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bus.Stop(ctx), context.DeadlineExceeded)
+
+	assert.ErrorIs(t, Publish(context.Background(), bus, topic, 2), ErrClosed)
+	assert.ErrorIs(t, PublishAsync(context.Background(), bus, topic, 2), ErrClosed)
+
+	close(release)
+	assert.NoError(t, bus.Stop(context.Background()))
+}
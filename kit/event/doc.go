@@ -0,0 +1,29 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package event 提供了基于泛型的进程内事件总线，用于解耦服务内部的模块。
+
+主要功能：
+
+  - 类型化主题：Topic[T] 携带事件类型，订阅者与发布方在编译期检查事件类型
+  - 同步投递：Publish 在调用方的协程中依次投递，返回所有订阅者错误的合并结果
+  - 异步投递：PublishAsync 通过 kit/runtime/goroutine 的协程池投递，错误交给错误处理函数
+  - 错误隔离：订阅者的错误与 panic 不影响其他订阅者
+  - 优雅停止：Bus 实现了 kit/runtime 的 Runner 接口，Stop 等待异步投递中的事件处理完成
+
+基本使用：
+
+	var OrderCreated = event.NewTopic[OrderCreatedEvent]("order.created")
+
+	bus := event.New()
+	event.Subscribe(bus, OrderCreated, func(ctx context.Context, e OrderCreatedEvent) error {
+	    return mailer.SendReceipt(ctx, e.OrderID)
+	})
+
+	if err := event.PublishAsync(ctx, bus, OrderCreated, OrderCreatedEvent{OrderID: "1"}); nil != err {
+	    return err
+	}
+*/
+package event
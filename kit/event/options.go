@@ -0,0 +1,105 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package event
+
+import (
+	"context"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+type (
+	// Option 定义了事件总线的配置选项。
+	Option func(*options)
+
+	// ErrorHandler 处理异步投递时订阅者返回的错误。
+	// 参数 topic 是事件的主题名称，err 是订阅者返回的错误或 panic 转换成的错误。
+	ErrorHandler func(ctx context.Context, topic string, err error)
+
+	// options 包含事件总线的配置。
+	options struct {
+		// pool 是异步投递使用的协程池，为 nil 时使用 kit/runtime/goroutine 的默认协程池。
+		pool goroutine.GoroutinePool
+		// errorHandler 是处理异步投递错误的函数，为 nil 时记录日志。
+		errorHandler ErrorHandler
+		// logger 是记录异步投递错误使用的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+	}
+)
+
+// WithPool 设置异步投递使用的协程池。
+//
+// 参数：
+//   - pool：协程池，默认为 kit/runtime/goroutine 的默认协程池。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPool(pool goroutine.GoroutinePool) Option {
+	return func(o *options) {
+		o.pool = pool
+	}
+}
+
+// WithErrorHandler 设置处理异步投递错误的函数。
+// 同步投递的错误由 Publish 直接返回，不经过该函数。
+//
+// 参数：
+//   - errorHandler：错误处理函数，默认以 Error 级别记录日志。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithErrorHandler(errorHandler ErrorHandler) Option {
+	return func(o *options) {
+		o.errorHandler = errorHandler
+	}
+}
+
+// WithLogger 设置记录异步投递错误使用的日志实例，设置了 WithErrorHandler 时不生效。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions 创建并应用配置选项。
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
+
+// submit 将任务提交到协程池。
+func (o *options) submit(task func()) error {
+	if nil != o.pool {
+		return o.pool.Submit(task)
+	}
+	return goroutine.Submit(task)
+}
+
+// handleError 处理异步投递的错误。
+func (o *options) handleError(ctx context.Context, topic string, err error) {
+	if nil != o.errorHandler {
+		o.errorHandler(ctx, topic, err)
+		return
+	}
+	o.getLogger().WithField("topic", topic).WithField("error", err.Error()).Error("event handler failed")
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package exec 提供了对 os/exec 的封装，统一处理超时、输出收集、环境变量与重试。
+
+每次执行都返回结构化的 Result，包含退出码、标准输出、标准错误与耗时；失败时的错误保留了
+os/exec 的原始错误，可以通过 errors.As 得到 *os/exec.ExitError。
+
+基本使用：
+
+	res, err := exec.Run(ctx, "git", []string{"rev-parse", "HEAD"},
+	    exec.WithDir(repo),
+	    exec.WithTimeout(10*time.Second),
+	)
+	if nil != err {
+	    return fmt.Errorf("读取版本失败：%w，%s", err, res.Stderr)
+	}
+	commit := strings.TrimSpace(string(res.Stdout))
+
+需要重试时，重试之间的等待由 kit/runtime/retry 的 Backoff 计算，输出可以逐行写入 kit/log：
+
+	cmd := exec.New("rsync", []string{"-a", src, dst},
+	    exec.WithEnv("LANG", "C"),
+	    exec.WithRetry(3, retry.WithMin(time.Second), retry.WithJitter(true)),
+	    exec.WithLogOutput(true),
+	)
+	res, err := cmd.Run(ctx)
+
+由于包名与 os/exec 相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "os/exec"
+
+	    kitexec "github.com/fsyyft-go/monorepo/kit/exec"
+	)
+*/
+package exec
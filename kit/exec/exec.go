@@ -0,0 +1,203 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdexec "os/exec"
+	"strings"
+	"time"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+const (
+	// waitDelay 是进程被终止后等待输出管道关闭的最长时间，避免孙进程持有管道时一直等待。
+	waitDelay = time.Second
+)
+
+type (
+	// Command 是一个待执行的命令，可以多次执行，并发执行时每次都启动新的进程。
+	Command struct {
+		// name 是程序名或路径。
+		name string
+		// args 是命令行参数。
+		args []string
+		// o 是执行的配置。
+		o *options
+	}
+
+	// Result 是命令执行的结果。重试时为最后一次执行的结果。
+	Result struct {
+		// ExitCode 是进程的退出码，进程未能启动或被信号终止时为 -1。
+		ExitCode int
+		// Stdout 是标准输出，合并输出时包含标准错误。
+		Stdout []byte
+		// Stderr 是标准错误，合并输出时为空。
+		Stderr []byte
+		// Truncated 表示输出是否超过 WithMaxOutput 而被截断。
+		Truncated bool
+		// Duration 是执行的耗时。
+		Duration time.Duration
+		// Attempts 是已经执行的次数。
+		Attempts int
+	}
+)
+
+// New 创建一个待执行的命令。
+//
+// 参数：
+//   - name：程序名或路径，不包含路径分隔符时在 PATH 中查找。
+//   - args：命令行参数，不经过 shell 解析。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Command：命令实例。
+//
+// 示例：
+//
+//	cmd := exec.New("git", []string{"fetch", "origin"},
+//	    exec.WithDir(repo),
+//	    exec.WithTimeout(time.Minute),
+//	    exec.WithRetry(3, retry.WithMin(time.Second)),
+//	)
+//	res, err := cmd.Run(ctx)
+func New(name string, args []string, opts ...Option) *Command {
+	return &Command{
+		name: name,
+		args: append([]string(nil), args...),
+		o:    newOptions(opts...),
+	}
+}
+
+// Run 创建并执行命令，同 New(name, args, opts...).Run(ctx)。
+//
+// 参数：
+//   - ctx：执行的上下文，结束时终止进程并停止重试。
+//   - name：程序名或路径。
+//   - args：命令行参数。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Result：执行结果，总是不为 nil。
+//   - error：执行失败的错误。
+func Run(ctx context.Context, name string, args []string, opts ...Option) (*Result, error) {
+	return New(name, args, opts...).Run(ctx)
+}
+
+// String 返回命令行，参数包含空白或引号时加上引号，仅用于日志与错误信息。
+//
+// 返回值：
+//   - string：命令行。
+func (c *Command) String() string {
+	var b strings.Builder
+	b.WriteString(c.name)
+	for _, arg := range c.args {
+		b.WriteByte(' ')
+		if "" == arg || strings.ContainsAny(arg, " \t\n\"'") {
+			fmt.Fprintf(&b, "%q", arg)
+		} else {
+			b.WriteString(arg)
+		}
+	}
+	return b.String()
+}
+
+// Run 执行命令，失败时按 WithRetry 与 WithRetryIf 重试。
+//
+// 参数：
+//   - ctx：执行的上下文，结束时终止进程并停止重试。
+//
+// 返回值：
+//   - *Result：最后一次执行的结果，总是不为 nil。
+//   - error：执行失败的错误。退出码不为 0 时可以通过 errors.As 得到 *os/exec.ExitError，
+//     超时时 errors.Is(err, context.DeadlineExceeded) 为 true，命令不存在时 errors.Is(err, os/exec.ErrNotFound) 为 true。
+func (c *Command) Run(ctx context.Context) (*Result, error) {
+	var res *Result
+	var runErr error
+	attempts := 0
+	err := retry.RetryWithContext(ctx, func(ctx context.Context) error {
+		attempts++
+		res, runErr = c.runOnce(ctx)
+		res.Attempts = attempts
+		if nil == runErr || attempts >= c.o.attempts || nil != ctx.Err() || !c.o.retryIf(res, runErr) {
+			return nil
+		}
+		c.o.getLogger().WithFields(map[string]interface{}{
+			"cmd":     c.String(),
+			"attempt": attempts,
+		}).Warn("kit/exec: 执行失败，等待重试：", runErr)
+		return runErr
+	}, c.o.backoff...)
+
+	if nil == res {
+		// 第一次执行前 ctx 已经结束。
+		return &Result{ExitCode: -1}, fmt.Errorf("kit/exec: 执行 %s 失败：%w", c, err)
+	}
+	if nil != err {
+		// 等待重试时 ctx 结束，返回最后一次执行的错误与 ctx 的错误。
+		return res, errors.Join(runErr, err)
+	}
+	return res, runErr
+}
+
+// runOnce 执行一次命令。
+func (c *Command) runOnce(ctx context.Context) (*Result, error) {
+	if c.o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.o.timeout)
+		defer cancel()
+	}
+
+	cmd := stdexec.CommandContext(ctx, c.name, c.args...)
+	cmd.Dir = c.o.dir
+	cmd.Env = c.o.environ()
+	cmd.Stdin = c.o.stdin
+	cmd.WaitDelay = waitDelay
+
+	logger := c.o.getLogger().WithField("cmd", c.String())
+	stdout := newOutput(c.o.maxOutput, c.o.logOutput, logger.WithField("stream", "stdout").Info)
+	cmd.Stdout = stdout
+	var stderr *output
+	if c.o.combined {
+		cmd.Stderr = stdout
+	} else {
+		stderr = newOutput(c.o.maxOutput, c.o.logOutput, logger.WithField("stream", "stderr").Warn)
+		cmd.Stderr = stderr
+	}
+
+	start := time.Now()
+	err := cmd.Run()
+	res := &Result{
+		ExitCode: -1,
+		Duration: time.Since(start),
+	}
+	stdout.flush()
+	res.Stdout, res.Truncated = stdout.bytes(), stdout.truncated
+	if nil != stderr {
+		stderr.flush()
+		res.Stderr = stderr.bytes()
+		res.Truncated = res.Truncated || stderr.truncated
+	}
+	if nil != cmd.ProcessState {
+		res.ExitCode = cmd.ProcessState.ExitCode()
+	}
+
+	if nil == err {
+		return res, nil
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return res, fmt.Errorf("kit/exec: 执行 %s 超时：%w", c, context.DeadlineExceeded)
+	}
+	if nil != ctx.Err() {
+		return res, fmt.Errorf("kit/exec: 执行 %s 被取消：%w", c, ctx.Err())
+	}
+	if 0 < res.ExitCode {
+		return res, fmt.Errorf("kit/exec: 执行 %s 失败，退出码 %d：%w", c, res.ExitCode, err)
+	}
+	return res, fmt.Errorf("kit/exec: 执行 %s 失败：%w", c, err)
+}
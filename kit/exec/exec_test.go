@@ -0,0 +1,267 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	stdexec "os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+// TestHelperProcess 是测试中被执行的子进程，不是真正的测试。
+func TestHelperProcess(t *testing.T) {
+	if "1" != os.Getenv("GO_EXEC_HELPER") {
+		return
+	}
+	args := os.Args
+	for 0 < len(args) && "--" != args[0] {
+		args = args[1:]
+	}
+	args = args[1:]
+
+	switch args[0] {
+	case "echo":
+		fmt.Println(strings.Join(args[1:], " "))
+	case "both":
+		fmt.Fprint(os.Stdout, "out1\n")
+		fmt.Fprint(os.Stderr, "err1\n")
+		fmt.Fprint(os.Stdout, "out2")
+	case "exit":
+		fmt.Fprint(os.Stderr, "failed")
+		code, _ := strconv.Atoi(args[1])
+		os.Exit(code)
+	case "sleep":
+		d, _ := time.ParseDuration(args[1])
+		time.Sleep(d)
+	case "env":
+		fmt.Print(os.Getenv(args[1]))
+	case "cat":
+		_, _ = io.Copy(os.Stdout, os.Stdin)
+	case "pwd":
+		dir, _ := os.Getwd()
+		fmt.Print(dir)
+	case "flaky":
+		// 第 n 次执行之前都失败。
+		data, _ := os.ReadFile(args[1])
+		count, _ := strconv.Atoi(string(data))
+		count++
+		_ = os.WriteFile(args[1], []byte(strconv.Itoa(count)), 0o644)
+		n, _ := strconv.Atoi(args[2])
+		if count < n {
+			os.Exit(1)
+		}
+	}
+	os.Exit(0)
+}
+
+// helper 返回执行 TestHelperProcess 的命令。
+func helper(args []string, opts ...Option) *Command {
+	opts = append([]Option{WithEnv("GO_EXEC_HELPER", "1")}, opts...)
+	return New(os.Args[0], append([]string{"-test.run=^TestHelperProcess$", "--"}, args...), opts...)
+}
+
+// TestRun 测试执行成功与输出。
+func TestRun(t *testing.T) {
+	res, err := helper([]string{"both"}).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.ExitCode)
+	assert.Equal(t, "out1\nout2", string(res.Stdout))
+	assert.Equal(t, "err1\n", string(res.Stderr))
+	assert.False(t, res.Truncated)
+	assert.Equal(t, 1, res.Attempts)
+	assert.Greater(t, res.Duration, time.Duration(0))
+
+	res, err = helper([]string{"both"}, WithCombinedOutput(true)).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "out1\nerr1\nout2", string(res.Stdout))
+	assert.Nil(t, res.Stderr)
+
+	res, err = helper([]string{"echo", "hello", "world"}, WithMaxOutput(5)).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(res.Stdout))
+	assert.True(t, res.Truncated)
+
+	res, err = helper([]string{"cat"}, WithStdin(strings.NewReader("input"))).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "input", string(res.Stdout))
+
+	dir := t.TempDir()
+	res, err = helper([]string{"pwd"}, WithDir(dir)).Run(context.Background())
+	require.NoError(t, err)
+	want, _ := filepath.EvalSymlinks(dir)
+	got, _ := filepath.EvalSymlinks(string(res.Stdout))
+	assert.Equal(t, want, got)
+
+	res, err = Run(context.Background(), os.Args[0], []string{"-test.run=^TestHelperProcess$", "--", "echo", "x"},
+		WithEnv("GO_EXEC_HELPER", "1"))
+	require.NoError(t, err)
+	assert.Equal(t, "x\n", string(res.Stdout))
+}
+
+// TestRunError 测试退出码、超时、取消与命令不存在。
+func TestRunError(t *testing.T) {
+	res, err := helper([]string{"exit", "3"}).Run(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 3, res.ExitCode)
+	assert.Equal(t, "failed", string(res.Stderr))
+	var exitErr *stdexec.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Contains(t, err.Error(), "退出码 3")
+
+	res, err = helper([]string{"sleep", "10s"}, WithTimeout(50*time.Millisecond)).Run(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, -1, res.ExitCode)
+	assert.Less(t, res.Duration, 5*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err = helper([]string{"sleep", "10s"}).Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	res, err = Run(context.Background(), "kit-exec-command-not-found", nil, WithRetry(3))
+	assert.ErrorIs(t, err, stdexec.ErrNotFound)
+	assert.Equal(t, -1, res.ExitCode)
+	// 命令不存在时默认不重试。
+	assert.Equal(t, 1, res.Attempts)
+
+	res, err = helper(nil).Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, -1, res.ExitCode)
+	assert.Zero(t, res.Attempts)
+}
+
+// TestRetry 测试失败后重试。
+func TestRetry(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "counter")
+	logger := logtest.NewRecorder()
+	res, err := helper([]string{"flaky", counter, "3"},
+		WithRetry(5, retry.WithMin(time.Millisecond), retry.WithMax(time.Millisecond)),
+		WithLogger(logger),
+	).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Attempts)
+	assert.Len(t, logger.Messages(), 2)
+
+	// 达到最多执行次数。
+	require.NoError(t, os.Remove(counter))
+	res, err = helper([]string{"flaky", counter, "10"},
+		WithRetry(2, retry.WithMin(time.Millisecond)),
+		WithLogger(logger),
+	).Run(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 2, res.Attempts)
+	assert.Equal(t, 1, res.ExitCode)
+
+	// WithRetryIf 决定不重试。
+	res, err = helper([]string{"exit", "2"},
+		WithRetry(3, retry.WithMin(time.Millisecond)),
+		WithRetryIf(func(res *Result, _ error) bool { return 2 != res.ExitCode }),
+		WithLogger(logger),
+	).Run(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 1, res.Attempts)
+
+	// 等待重试时 ctx 结束。
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	res, err = helper([]string{"exit", "1"},
+		WithRetry(3, retry.WithMin(time.Hour)),
+		WithLogger(logger),
+	).Run(ctx)
+	var exitErr *stdexec.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, res.Attempts)
+}
+
+// TestEnv 测试环境变量。
+func TestEnv(t *testing.T) {
+	t.Setenv("KIT_EXEC_INHERITED", "inherited")
+
+	res, err := helper([]string{"env", "KIT_EXEC_INHERITED"}).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "inherited", string(res.Stdout))
+
+	res, err = helper([]string{"env", "KIT_EXEC_INHERITED"}, WithEnv("KIT_EXEC_INHERITED", "a"), WithEnv("KIT_EXEC_INHERITED", "b")).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "b", string(res.Stdout))
+
+	res, err = helper([]string{"env", "KIT_EXEC_INHERITED"}, WithInheritEnv(false)).Run(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, res.Stdout)
+
+	o := newOptions(WithInheritEnv(false), WithEnvMap(map[string]string{"A": "1"}), WithEnv("A", "2"))
+	assert.Equal(t, []string{"A=2"}, o.environ())
+	assert.Equal(t, []string{}, newOptions(WithInheritEnv(false)).environ())
+	assert.Nil(t, newOptions().environ())
+}
+
+// TestLogOutput 测试将输出逐行写入日志。
+func TestLogOutput(t *testing.T) {
+	logger := logtest.NewRecorder()
+	_, err := helper([]string{"both"}, WithLogOutput(true), WithLogger(logger)).Run(context.Background())
+	require.NoError(t, err)
+	messages := logger.Messages()
+	assert.ElementsMatch(t, []string{"info out1", "warn err1", "info out2"}, messages)
+
+	logger = logtest.NewRecorder()
+	_, err = helper([]string{"both"}, WithLogger(logger)).Run(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, logger.Messages())
+}
+
+// TestOutput 测试输出的收集与按行写入日志。
+func TestOutput(t *testing.T) {
+	var lines []string
+	o := newOutput(4, true, func(args ...interface{}) {
+		lines = append(lines, fmt.Sprint(args...))
+	})
+	_, _ = o.Write([]byte("ab"))
+	_, _ = o.Write([]byte("c\r\nde\nf"))
+	o.flush()
+	o.flush()
+	assert.Equal(t, []string{"abc", "de", "f"}, lines)
+	assert.Equal(t, "abc\r", string(o.bytes()))
+	assert.True(t, o.truncated)
+
+	assert.Nil(t, newOutput(0, false, nil).bytes())
+}
+
+// TestString 测试命令行的显示。
+func TestString(t *testing.T) {
+	assert.Equal(t, `git commit -m "fix bug" ""`, New("git", []string{"commit", "-m", "fix bug", ""}).String())
+	assert.Equal(t, "ls", New("ls", nil).String())
+}
+
+// TestOptions 测试配置选项与默认值。
+func TestOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, inheritEnvDefault, o.inheritEnv)
+	assert.Equal(t, maxOutputDefault, o.maxOutput)
+	assert.Equal(t, attemptsDefault, o.attempts)
+	assert.NotNil(t, o.retryIf)
+	assert.Equal(t, kitlog.GetLogger(), o.getLogger())
+
+	o = newOptions(WithMaxOutput(-1), WithRetry(0), WithRetryIf(nil))
+	assert.Equal(t, maxOutputDefault, o.maxOutput)
+	assert.Equal(t, attemptsDefault, o.attempts)
+	assert.True(t, o.retryIf(nil, errors.New("failed")))
+	assert.False(t, o.retryIf(nil, stdexec.ErrNotFound))
+}
@@ -0,0 +1,312 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package exec
+
+import (
+	"errors"
+	"io"
+	"os"
+	stdexec "os/exec"
+	"strings"
+	"time"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+// 以下为命令执行的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// inheritEnvDefault 为默认是否继承当前进程的环境变量。
+	inheritEnvDefault = true
+	// maxOutputDefault 为默认每个输出保留的最大字节数。
+	maxOutputDefault = 4 << 20
+	// attemptsDefault 为默认的最多执行次数。
+	attemptsDefault = 1
+)
+
+type (
+	// Option 定义了命令执行的配置选项。
+	Option func(*options)
+
+	// RetryIf 判断一次失败的执行是否需要重试。
+	//
+	// 参数：
+	//   - res：本次执行的结果。
+	//   - err：本次执行的错误，不为 nil。
+	//
+	// 返回值：
+	//   - bool：是否需要重试。
+	RetryIf func(res *Result, err error) bool
+
+	// options 包含命令执行的配置。
+	options struct {
+		// dir 是命令的工作目录，为空时使用当前进程的工作目录。
+		dir string
+		// inheritEnv 表示是否继承当前进程的环境变量。
+		inheritEnv bool
+		// env 是额外设置的环境变量，后设置的同名变量覆盖先设置的。
+		env []envVar
+		// stdin 是命令的标准输入。
+		stdin io.Reader
+		// timeout 是每次执行的超时时间，小于等于 0 时不限制。
+		timeout time.Duration
+		// combined 表示是否将标准输出与标准错误合并到 Result.Stdout。
+		combined bool
+		// maxOutput 是每个输出保留的最大字节数。
+		maxOutput int
+		// logOutput 表示是否将输出逐行写入日志。
+		logOutput bool
+		// attempts 是最多执行的次数。
+		attempts int
+		// backoff 是重试之间等待的配置。
+		backoff []retry.BackoffOption
+		// retryIf 判断失败的执行是否需要重试。
+		retryIf RetryIf
+		// logger 是记录输出与重试的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+	}
+
+	// envVar 是一个环境变量。
+	envVar struct {
+		// key 是变量名。
+		key string
+		// value 是变量值。
+		value string
+	}
+)
+
+// WithDir 设置命令的工作目录。
+//
+// 参数：
+//   - dir：工作目录，默认为当前进程的工作目录。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithDir(dir string) Option {
+	return func(o *options) {
+		o.dir = dir
+	}
+}
+
+// WithEnv 设置一个环境变量，可以多次使用，后设置的同名变量覆盖先设置的以及继承的变量。
+//
+// 参数：
+//   - key：变量名。
+//   - value：变量值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithEnv(key, value string) Option {
+	return func(o *options) {
+		o.env = append(o.env, envVar{key: key, value: value})
+	}
+}
+
+// WithEnvMap 设置多个环境变量，同 WithEnv。
+//
+// 参数：
+//   - env：变量名与变量值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithEnvMap(env map[string]string) Option {
+	return func(o *options) {
+		for k, v := range env {
+			o.env = append(o.env, envVar{key: k, value: v})
+		}
+	}
+}
+
+// WithInheritEnv 设置是否继承当前进程的环境变量。
+//
+// 参数：
+//   - inherit：是否继承，默认为 true；为 false 时命令只能看到 WithEnv 设置的变量。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithInheritEnv(inherit bool) Option {
+	return func(o *options) {
+		o.inheritEnv = inherit
+	}
+}
+
+// WithStdin 设置命令的标准输入。重试时同一个 Reader 被再次使用，需要可重复读取时应传入新的 Reader 或不使用重试。
+//
+// 参数：
+//   - r：标准输入，默认为空。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithStdin(r io.Reader) Option {
+	return func(o *options) {
+		o.stdin = r
+	}
+}
+
+// WithTimeout 设置每次执行的超时时间，超时后进程被终止。
+//
+// 参数：
+//   - timeout：超时时间，默认不限制，小于等于 0 时不限制。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithCombinedOutput 设置是否将标准输出与标准错误按写入顺序合并到 Result.Stdout，合并时 Result.Stderr 为空。
+//
+// 参数：
+//   - combined：是否合并，默认为 false。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCombinedOutput(combined bool) Option {
+	return func(o *options) {
+		o.combined = combined
+	}
+}
+
+// WithMaxOutput 设置每个输出保留的最大字节数，超过的部分被丢弃并设置 Result.Truncated，不影响写入日志。
+//
+// 参数：
+//   - n：最大字节数，默认为 4MiB，小于 0 时使用默认值，为 0 时不保留输出。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxOutput(n int) Option {
+	return func(o *options) {
+		o.maxOutput = n
+	}
+}
+
+// WithLogOutput 设置是否将输出逐行写入日志，标准输出为 Info 级别，标准错误为 Warn 级别。
+//
+// 参数：
+//   - logOutput：是否写入日志，默认为 false。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogOutput(logOutput bool) Option {
+	return func(o *options) {
+		o.logOutput = logOutput
+	}
+}
+
+// WithRetry 设置失败时重试，重试之间的等待由 kit/runtime/retry 的 Backoff 计算。
+//
+// 参数：
+//   - attempts：最多执行的次数，默认为 1 即不重试，小于 1 时使用默认值。
+//   - opts：Backoff 的配置选项，例如 retry.WithMin 与 retry.WithMax。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithRetry(attempts int, opts ...retry.BackoffOption) Option {
+	return func(o *options) {
+		o.attempts = attempts
+		o.backoff = opts
+	}
+}
+
+// WithRetryIf 设置判断失败的执行是否需要重试的函数。
+//
+// 参数：
+//   - fn：判断函数，默认除命令不存在外都重试，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithRetryIf(fn RetryIf) Option {
+	return func(o *options) {
+		o.retryIf = fn
+	}
+}
+
+// WithLogger 设置记录输出与重试的日志实例。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		inheritEnv: inheritEnvDefault,
+		maxOutput:  maxOutputDefault,
+		attempts:   attemptsDefault,
+		retryIf:    retryIfDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.maxOutput < 0 {
+		o.maxOutput = maxOutputDefault
+	}
+	if o.attempts < 1 {
+		o.attempts = attemptsDefault
+	}
+	if nil == o.retryIf {
+		o.retryIf = retryIfDefault
+	}
+
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
+
+// environ 返回命令的环境变量，不继承且没有额外的变量时返回空切片而不是 nil，避免 os/exec 继承当前进程的环境变量。
+func (o *options) environ() []string {
+	var base []string
+	if o.inheritEnv {
+		if 0 == len(o.env) {
+			return nil
+		}
+		base = os.Environ()
+	}
+
+	env := make([]string, 0, len(base)+len(o.env))
+	index := make(map[string]int, len(base)+len(o.env))
+	add := func(key, kv string) {
+		if i, ok := index[key]; ok {
+			env[i] = kv
+			return
+		}
+		index[key] = len(env)
+		env = append(env, kv)
+	}
+	for _, kv := range base {
+		key, _, _ := strings.Cut(kv, "=")
+		if "" == key {
+			// Windows 上以 = 开头的变量（例如 =C:）没有变量名，原样保留。
+			key = kv
+		}
+		add(key, kv)
+	}
+	for _, v := range o.env {
+		add(v.key, v.key+"="+v.value)
+	}
+	return env
+}
+
+// retryIfDefault 除命令不存在外都重试。
+func retryIfDefault(_ *Result, err error) bool {
+	return !errors.Is(err, stdexec.ErrNotFound)
+}
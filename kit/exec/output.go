@@ -0,0 +1,75 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package exec
+
+import (
+	"bytes"
+)
+
+type (
+	// output 收集命令的一个输出，并可选地逐行写入日志。
+	// 标准输出与标准错误使用同一个 output 时，os/exec 保证同一时间只有一个协程调用 Write。
+	output struct {
+		// buf 是保留的输出。
+		buf bytes.Buffer
+		// limit 是保留的最大字节数。
+		limit int
+		// truncated 表示是否有输出被丢弃。
+		truncated bool
+		// log 写入一行日志，为 nil 时不写入日志。
+		log func(args ...interface{})
+		// line 是尚未写入日志的不完整的行。
+		line []byte
+	}
+)
+
+// newOutput 创建一个输出，logOutput 为 false 时不写入日志。
+func newOutput(limit int, logOutput bool, log func(args ...interface{})) *output {
+	o := &output{limit: limit}
+	if logOutput {
+		o.log = log
+	}
+	return o
+}
+
+// Write 保留不超过 limit 的输出，并将完整的行写入日志。
+func (o *output) Write(p []byte) (int, error) {
+	if remaining := o.limit - o.buf.Len(); len(p) > remaining {
+		o.buf.Write(p[:remaining])
+		o.truncated = true
+	} else {
+		o.buf.Write(p)
+	}
+
+	if nil != o.log {
+		o.line = append(o.line, p...)
+		for {
+			i := bytes.IndexByte(o.line, '\n')
+			if i < 0 {
+				break
+			}
+			o.log(string(bytes.TrimSuffix(o.line[:i], []byte{'\r'})))
+			o.line = o.line[i+1:]
+		}
+		o.line = append([]byte(nil), o.line...)
+	}
+	return len(p), nil
+}
+
+// flush 将最后一行不完整的输出写入日志。
+func (o *output) flush() {
+	if nil != o.log && 0 < len(o.line) {
+		o.log(string(o.line))
+		o.line = nil
+	}
+}
+
+// bytes 返回保留的输出，没有输出时返回 nil。
+func (o *output) bytes() []byte {
+	if 0 == o.buf.Len() {
+		return nil
+	}
+	return o.buf.Bytes()
+}
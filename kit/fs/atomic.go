@@ -0,0 +1,109 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package fs
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	// maxTempAttempts 是临时文件重名时重试的最大次数。
+	maxTempAttempts = 100
+)
+
+// WriteFileAtomic 原子地将 data 写入 path。
+// 数据先写入同一目录下的临时文件并落盘，再通过重命名替换目标文件，
+// 读取方只会看到旧文件或完整的新文件，进程在写入过程中崩溃也不会留下半截的文件。
+//
+// 参数：
+//   - path：目标文件的路径，所在目录必须已经存在。
+//   - data：要写入的内容。
+//   - perm：目标文件的权限。
+//
+// 返回值：
+//   - error：写入失败的原因，失败时目标文件保持不变，临时文件被删除。
+//
+// 示例：
+//
+//	data, err := yaml.Marshal(cfg)
+//	if nil != err {
+//	    return err
+//	}
+//	return fs.WriteFileAtomic("conf/app.yaml", data, 0644)
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return WriteFileAtomicFS(OS, path, data, perm)
+}
+
+// WriteFileAtomicFS 与 WriteFileAtomic 相同，在 fsys 中写入文件。
+// 目录只有在 fsys 直接读写磁盘时才会同步。
+//
+// 参数：
+//   - fsys：文件系统，为 nil 时使用 OS。
+//   - path：目标文件的路径，所在目录必须已经存在。
+//   - data：要写入的内容。
+//   - perm：目标文件的权限。
+//
+// 返回值：
+//   - error：写入失败的原因，失败时目标文件保持不变，临时文件被删除。
+func WriteFileAtomicFS(fsys FS, path string, data []byte, perm os.FileMode) (err error) {
+	fsys = OrOS(fsys)
+	dir, base := filepath.Split(path)
+	if "" == dir {
+		dir = "."
+	}
+	// 临时文件以点开头，避免被按扩展名扫描目录的程序读到。
+	f, tmp, err := createTemp(fsys, dir, "."+base+".tmp-")
+	if nil != err {
+		return fmt.Errorf("kit/fs: 创建临时文件失败：%w", err)
+	}
+	defer func() {
+		if nil != err {
+			_ = f.Close()
+			_ = fsys.Remove(tmp)
+		}
+	}()
+
+	if _, err = f.Write(data); nil != err {
+		return fmt.Errorf("kit/fs: 写入临时文件失败：%w", err)
+	}
+	if err = fsys.Chmod(tmp, perm); nil != err {
+		return fmt.Errorf("kit/fs: 设置文件权限失败：%w", err)
+	}
+	if err = f.Sync(); nil != err {
+		return fmt.Errorf("kit/fs: 同步临时文件失败：%w", err)
+	}
+	if err = f.Close(); nil != err {
+		return fmt.Errorf("kit/fs: 关闭临时文件失败：%w", err)
+	}
+	if err = fsys.Rename(tmp, path); nil != err {
+		return fmt.Errorf("kit/fs: 替换文件 %s 失败：%w", path, err)
+	}
+	if !IsOS(fsys) {
+		return nil
+	}
+	// 同步目录，确保重命名本身也已落盘。
+	if err := syncDir(dir); nil != err {
+		return fmt.Errorf("kit/fs: 同步目录 %s 失败：%w", dir, err)
+	}
+	return nil
+}
+
+// createTemp 在 dir 中以 prefix 加随机后缀为名独占地创建文件，返回文件与其路径。
+func createTemp(fsys FS, dir, prefix string) (File, string, error) {
+	for i := 0; ; i++ {
+		name := filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10))
+		f, err := fsys.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+		if errors.Is(err, iofs.ErrExist) && i < maxTempAttempts {
+			continue
+		}
+		return f, name, err
+	}
+}
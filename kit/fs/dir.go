@@ -0,0 +1,122 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package fs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var (
+	// ErrNotDir 表示路径已经存在但不是目录。
+	ErrNotDir = errors.New("kit/fs: 路径已存在且不是目录")
+)
+
+// EnsureDir 确保目录存在，不存在时连同上级目录一起创建。
+//
+// 参数：
+//   - dir：目录的路径。
+//   - perm：新建目录的权限，已经存在的目录不会被修改。
+//
+// 返回值：
+//   - error：创建失败的原因，路径已经存在但不是目录时包装 ErrNotDir。
+func EnsureDir(dir string, perm os.FileMode) error {
+	return EnsureDirFS(OS, dir, perm)
+}
+
+// EnsureDirFS 与 EnsureDir 相同，在 fsys 中创建目录。
+//
+// 参数：
+//   - fsys：文件系统，为 nil 时使用 OS。
+//   - dir：目录的路径。
+//   - perm：新建目录的权限，已经存在的目录不会被修改。
+//
+// 返回值：
+//   - error：创建失败的原因，路径已经存在但不是目录时包装 ErrNotDir。
+func EnsureDirFS(fsys FS, dir string, perm os.FileMode) error {
+	fsys = OrOS(fsys)
+	info, err := fsys.Stat(dir)
+	if nil == err {
+		if !info.IsDir() {
+			return fmt.Errorf("%w：%s", ErrNotDir, dir)
+		}
+		return nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("kit/fs: 检查目录 %s 失败：%w", dir, err)
+	}
+	if err := fsys.MkdirAll(dir, perm); nil != err {
+		return fmt.Errorf("kit/fs: 创建目录 %s 失败：%w", dir, err)
+	}
+	return nil
+}
+
+// EnsureFileDir 确保文件所在的目录存在，常用于创建日志、配置等文件之前。
+//
+// 参数：
+//   - path：文件的路径。
+//   - perm：新建目录的权限。
+//
+// 返回值：
+//   - error：创建失败的原因，参见 EnsureDir。
+func EnsureFileDir(path string, perm os.FileMode) error {
+	return EnsureDirFS(OS, filepath.Dir(path), perm)
+}
+
+// EnsureFileDirFS 与 EnsureFileDir 相同，在 fsys 中创建文件所在的目录。
+//
+// 参数：
+//   - fsys：文件系统，为 nil 时使用 OS。
+//   - path：文件的路径。
+//   - perm：新建目录的权限。
+//
+// 返回值：
+//   - error：创建失败的原因，参见 EnsureDir。
+func EnsureFileDirFS(fsys FS, path string, perm os.FileMode) error {
+	return EnsureDirFS(fsys, filepath.Dir(path), perm)
+}
+
+// OpenAppend 以追加方式打开文件，文件或所在目录不存在时自动创建。
+//
+// 参数：
+//   - path：文件的路径。
+//   - filePerm：新建文件的权限。
+//   - dirPerm：新建目录的权限。
+//
+// 返回值：
+//   - *os.File：只写、追加方式打开的文件。
+//   - error：打开失败的原因。
+func OpenAppend(path string, filePerm, dirPerm os.FileMode) (*os.File, error) {
+	f, err := OpenAppendFS(OS, path, filePerm, dirPerm)
+	if nil != err {
+		return nil, err
+	}
+	return f.(*os.File), nil
+}
+
+// OpenAppendFS 与 OpenAppend 相同，在 fsys 中打开文件。
+//
+// 参数：
+//   - fsys：文件系统，为 nil 时使用 OS。
+//   - path：文件的路径。
+//   - filePerm：新建文件的权限。
+//   - dirPerm：新建目录的权限。
+//
+// 返回值：
+//   - File：只写、追加方式打开的文件。
+//   - error：打开失败的原因。
+func OpenAppendFS(fsys FS, path string, filePerm, dirPerm os.FileMode) (File, error) {
+	fsys = OrOS(fsys)
+	if err := EnsureFileDirFS(fsys, path, dirPerm); nil != err {
+		return nil, err
+	}
+	f, err := fsys.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, filePerm)
+	if nil != err {
+		return nil, fmt.Errorf("kit/fs: 打开文件 %s 失败：%w", path, err)
+	}
+	return f, nil
+}
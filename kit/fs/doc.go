@@ -0,0 +1,42 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package fs 提供了文件系统的常用操作，替代散落在各个组件中的 os 调用。
+
+主要功能：
+
+  - 原子写入：WriteFileAtomic 通过临时文件与重命名替换目标文件，读取方不会看到半截的内容
+  - 文件锁：TryLock、Lock 基于锁文件在多个进程之间互斥，在类 Unix 系统上使用 flock，在 Windows 上使用 LockFileEx
+  - 目录：EnsureDir、EnsureFileDir 确保目录存在，OpenAppend 以追加方式打开文件并在需要时创建所在目录
+  - 文件系统：FS 是兼容 io/fs.FS 的可写文件系统，OS 直接读写磁盘，以 FS 结尾的函数在传入的文件系统中操作
+
+kit/log 使用 OpenAppendFS 与 EnsureFileDirFS 创建日志文件，通过 WithFS 注入文件系统时不需要读写真实的磁盘，
+测试时可以注入 kit/testing 的 MemFS。
+
+基本使用：
+
+	lock, err := fs.TryLock("/var/run/app.lock")
+	if errors.Is(err, fs.ErrLocked) {
+	    return errors.New("另一个实例正在运行")
+	}
+	if nil != err {
+	    return err
+	}
+	defer lock.Unlock()
+
+	if err := fs.EnsureDir("conf", 0755); nil != err {
+	    return err
+	}
+	return fs.WriteFileAtomic("conf/app.yaml", data, 0644)
+
+由于包名与标准库 io/fs 相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "io/fs"
+
+	    kitfs "github.com/fsyyft-go/monorepo/kit/fs"
+	)
+*/
+package fs
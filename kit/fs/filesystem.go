@@ -0,0 +1,131 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package fs
+
+import (
+	"io"
+	iofs "io/fs"
+	"os"
+)
+
+var (
+	// OS 是直接读写磁盘的文件系统，路径的含义与 os 包相同。
+	OS FS = osFS{}
+)
+
+type (
+	// File 是可写的文件，*os.File 实现了该接口。
+	File interface {
+		iofs.File
+		io.Writer
+
+		// Sync 将写入的内容落盘。
+		//
+		// 返回值：
+		//   - error：同步失败的原因。
+		Sync() error
+	}
+
+	// FS 是可写的文件系统，兼容 io/fs.FS，供日志、配置等组件注入，测试时可以替换为 kit/testing 的 MemFS，
+	// 不需要读写真实的磁盘。
+	// 路径使用操作系统的格式，可以是绝对路径，也可以是相对路径；Open 与 Stat 同样接受这种路径，
+	// 需要严格遵守 io/fs 的路径规则时使用 io/fs.Sub 包装。
+	FS interface {
+		iofs.StatFS
+
+		// OpenFile 按 os.OpenFile 的语义打开文件。
+		//
+		// 参数：
+		//   - name：文件的路径。
+		//   - flag：os.O_RDONLY、os.O_CREATE 等打开方式的组合。
+		//   - perm：新建文件的权限。
+		//
+		// 返回值：
+		//   - File：打开的文件。
+		//   - error：打开失败的原因，可以通过 errors.Is 与 io/fs.ErrNotExist 等比较。
+		OpenFile(name string, flag int, perm os.FileMode) (File, error)
+
+		// MkdirAll 创建目录，连同不存在的上级目录一起创建，目录已经存在时直接返回 nil。
+		MkdirAll(path string, perm os.FileMode) error
+
+		// Rename 将 oldpath 重命名为 newpath，newpath 是已经存在的文件时替换该文件。
+		Rename(oldpath, newpath string) error
+
+		// Remove 删除文件或空目录。
+		Remove(name string) error
+
+		// Chmod 修改文件的权限。
+		Chmod(name string, mode os.FileMode) error
+	}
+
+	// osFS 是 OS 的实现。
+	osFS struct{}
+)
+
+// OrOS 在 fsys 为 nil 时返回 OS，用于处理组件的可选文件系统参数。
+//
+// 参数：
+//   - fsys：注入的文件系统。
+//
+// 返回值：
+//   - FS：fsys 不为 nil 时原样返回，否则返回 OS。
+func OrOS(fsys FS) FS {
+	if nil == fsys {
+		return OS
+	}
+	return fsys
+}
+
+// IsOS 判断 fsys 是否直接读写磁盘，为 nil 时同样视为磁盘。
+// 只能作用于磁盘的功能（例如按时间滚动日志文件、监听文件变化）据此判断是否可用。
+//
+// 参数：
+//   - fsys：注入的文件系统。
+//
+// 返回值：
+//   - bool：fsys 为 nil 或 OS 时返回 true。
+func IsOS(fsys FS) bool {
+	return nil == fsys || OS == fsys
+}
+
+// Open 实现了 io/fs.FS 接口。
+func (osFS) Open(name string) (iofs.File, error) {
+	return os.Open(name) // nolint:gosec
+}
+
+// Stat 实现了 io/fs.StatFS 接口。
+func (osFS) Stat(name string) (iofs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// OpenFile 实现了 FS 接口。
+func (osFS) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	f, err := os.OpenFile(name, flag, perm) // nolint:gosec
+	if nil != err {
+		// 避免返回包含 nil 指针的接口。
+		return nil, err
+	}
+	return f, nil
+}
+
+// MkdirAll 实现了 FS 接口。
+func (osFS) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// Rename 实现了 FS 接口。
+func (osFS) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+// Remove 实现了 FS 接口。
+func (osFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// Chmod 实现了 FS 接口。
+func (osFS) Chmod(name string, mode os.FileMode) error {
+	return os.Chmod(name, mode)
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build !unix && !windows
+
+package fs
+
+import (
+	"errors"
+	"os"
+)
+
+// lockFile 在不支持文件锁的平台上返回 errors.ErrUnsupported。
+func lockFile(_ *os.File) error {
+	return errors.ErrUnsupported
+}
+
+// unlockFile 在不支持文件锁的平台上返回 errors.ErrUnsupported。
+func unlockFile(_ *os.File) error {
+	return errors.ErrUnsupported
+}
+
+// syncDir 在其他平台上不同步目录。
+func syncDir(_ string) error {
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package fs
+
+import (
+	"context"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteFileAtomic 测试原子写入文件。
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.yaml")
+
+	require.NoError(t, WriteFileAtomic(path, []byte("v1"), 0600))
+	require.NoError(t, WriteFileAtomic(path, []byte("v2"), 0640))
+
+	data, err := os.ReadFile(path) // nolint:gosec
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(data))
+
+	if "windows" != runtime.GOOS {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "不应残留临时文件")
+
+	assert.Error(t, WriteFileAtomic(filepath.Join(dir, "missing", "a"), nil, 0600))
+	// 目标是目录时重命名失败，临时文件应被删除。
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "x"), nil, 0600))
+	assert.Error(t, WriteFileAtomic(filepath.Join(dir, "sub"), []byte("x"), 0600))
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+// TestWriteFileAtomicRelative 测试写入当前目录下的文件。
+func TestWriteFileAtomicRelative(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, WriteFileAtomic("a.txt", []byte("x"), 0600))
+	data, err := os.ReadFile("a.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "x", string(data))
+}
+
+// TestEnsureDir 测试目录的创建与检查。
+func TestEnsureDir(t *testing.T) {
+	dir := t.TempDir()
+
+	nested := filepath.Join(dir, "a", "b")
+	require.NoError(t, EnsureDir(nested, 0750))
+	require.NoError(t, EnsureDir(nested, 0750))
+	info, err := os.Stat(nested)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	require.NoError(t, EnsureFileDir(filepath.Join(dir, "c", "app.log"), 0750))
+	assert.DirExists(t, filepath.Join(dir, "c"))
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	assert.ErrorIs(t, EnsureDir(file, 0750), ErrNotDir)
+	assert.Error(t, EnsureDir(filepath.Join(file, "sub"), 0750))
+	assert.Error(t, EnsureFileDir(filepath.Join(file, "sub", "x.log"), 0750))
+}
+
+// TestOpenAppend 测试以追加方式打开文件。
+func TestOpenAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+
+	for _, line := range []string{"a\n", "b\n"} {
+		f, err := OpenAppend(path, 0600, 0750)
+		require.NoError(t, err)
+		_, err = f.WriteString(line)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+
+	data, err := os.ReadFile(path) // nolint:gosec
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\n", string(data))
+
+	_, err = OpenAppend(filepath.Dir(path), 0600, 0750)
+	assert.Error(t, err)
+	_, err = OpenAppend(filepath.Join(path, "x"), 0600, 0750)
+	assert.ErrorIs(t, err, ErrNotDir)
+}
+
+// TestTryLock 测试锁的互斥与释放。
+func TestTryLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.lock")
+
+	lock, err := TryLock(path)
+	require.NoError(t, err)
+	assert.Equal(t, path, lock.Path())
+
+	_, err = TryLock(path)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	require.NoError(t, lock.Unlock())
+	require.NoError(t, lock.Unlock())
+
+	again, err := TryLock(path)
+	require.NoError(t, err)
+	require.NoError(t, again.Unlock())
+
+	_, err = TryLock(filepath.Join(path+"-missing", "x.lock"))
+	assert.Error(t, err)
+}
+
+// TestLock 测试等待获取锁与取消等待。
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.lock")
+	held, err := TryLock(path)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*lockRetryInterval)
+	defer cancel()
+	_, err = Lock(ctx, path)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	go func() {
+		time.Sleep(lockRetryInterval)
+		_ = held.Unlock()
+	}()
+	lock, err := Lock(context.Background(), path)
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock())
+}
+
+// TestOS 测试 OrOS、IsOS 与磁盘文件系统的辅助函数。
+func TestOS(t *testing.T) {
+	assert.True(t, IsOS(nil))
+	assert.True(t, IsOS(OS))
+	assert.Equal(t, OS, OrOS(nil))
+
+	_, err := OS.OpenFile(filepath.Join(t.TempDir(), "missing", "a"), os.O_RDONLY, 0)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	f, err := OpenAppendFS(nil, path, 0644, 0755)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, WriteFileAtomicFS(nil, path, []byte("y"), 0644))
+	data, err := iofs.ReadFile(OS, path)
+	require.NoError(t, err)
+	assert.Equal(t, "y", string(data))
+}
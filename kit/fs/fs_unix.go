@@ -0,0 +1,46 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build unix
+
+package fs
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// lockFile 以非阻塞方式获取文件上的排他锁。
+func lockFile(f *os.File) error {
+	for {
+		err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+		if errors.Is(err, unix.EINTR) {
+			continue
+		}
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			return ErrLocked
+		}
+		return err
+	}
+}
+
+// unlockFile 释放文件上的锁。
+func unlockFile(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_UN)
+}
+
+// syncDir 同步目录，使目录项的变更落盘。
+func syncDir(dir string) error {
+	d, err := os.Open(dir) // nolint:gosec
+	if nil != err {
+		return err
+	}
+	err = d.Sync()
+	if closeErr := d.Close(); nil == err {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package fs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	stdsync "sync"
+	"time"
+)
+
+const (
+	// lockRetryInterval 是 Lock 重试获取锁的间隔。
+	lockRetryInterval = 50 * time.Millisecond
+	// lockFilePerm 是新建锁文件的权限。
+	lockFilePerm = 0644
+)
+
+var (
+	// ErrLocked 表示锁已被其他进程或其他 FileLock 持有。
+	ErrLocked = errors.New("kit/fs: 文件已被锁定")
+)
+
+type (
+	// FileLock 是基于锁文件的建议锁，用于在多个进程之间互斥，例如防止同一个任务被重复启动。
+	// 在类 Unix 系统上基于 flock，在 Windows 上基于 LockFileEx。
+	// 建议锁只约束同样加锁的程序，不阻止其他程序读写锁文件。
+	FileLock struct {
+		// mu 保护 file。
+		mu stdsync.Mutex
+		// path 是锁文件的路径。
+		path string
+		// file 是持有锁的文件，解锁后为 nil。
+		file *os.File
+	}
+)
+
+// TryLock 尝试获取锁文件上的排他锁，不等待。
+// 锁文件不存在时自动创建，解锁后保留，删除锁文件会使之后的加锁与仍持有锁的进程互不可见。
+//
+// 参数：
+//   - path：锁文件的路径，所在目录必须已经存在。
+//
+// 返回值：
+//   - *FileLock：持有的锁，使用完毕后调用 Unlock 释放。
+//   - error：获取失败的原因，锁已被持有时返回 ErrLocked。
+//
+// 示例：
+//
+//	lock, err := fs.TryLock("/var/run/app.lock")
+//	if errors.Is(err, fs.ErrLocked) {
+//	    return errors.New("另一个实例正在运行")
+//	}
+//	if nil != err {
+//	    return err
+//	}
+//	defer lock.Unlock()
+func TryLock(path string) (*FileLock, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, lockFilePerm) // nolint:gosec
+	if nil != err {
+		return nil, fmt.Errorf("kit/fs: 打开锁文件 %s 失败：%w", path, err)
+	}
+	if err := lockFile(f); nil != err {
+		_ = f.Close()
+		if errors.Is(err, ErrLocked) {
+			return nil, ErrLocked
+		}
+		return nil, fmt.Errorf("kit/fs: 锁定文件 %s 失败：%w", path, err)
+	}
+	return &FileLock{path: path, file: f}, nil
+}
+
+// Lock 获取锁文件上的排他锁，锁被持有时等待，直到获取成功或上下文取消。
+//
+// 参数：
+//   - ctx：上下文，取消时停止等待。
+//   - path：锁文件的路径，所在目录必须已经存在。
+//
+// 返回值：
+//   - *FileLock：持有的锁，使用完毕后调用 Unlock 释放。
+//   - error：获取失败的原因，上下文取消时返回上下文的错误。
+func Lock(ctx context.Context, path string) (*FileLock, error) {
+	ticker := time.NewTicker(lockRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		lock, err := TryLock(path)
+		if !errors.Is(err, ErrLocked) {
+			return lock, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Path 返回锁文件的路径。
+func (l *FileLock) Path() string {
+	return l.path
+}
+
+// Unlock 释放锁并关闭锁文件，重复调用时直接返回 nil。
+//
+// 返回值：
+//   - error：释放失败的原因。
+func (l *FileLock) Unlock() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if nil == l.file {
+		return nil
+	}
+	f := l.file
+	l.file = nil
+
+	// 关闭文件同样会释放锁，显式解锁只是为了在出错时能够报告。
+	err := unlockFile(f)
+	if closeErr := f.Close(); nil == err {
+		err = closeErr
+	}
+	if nil != err {
+		return fmt.Errorf("kit/fs: 释放锁文件 %s 失败：%w", l.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	stdsync "sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	kitid "github.com/fsyyft-go/monorepo/kit/id"
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+var (
+	// errRetry 通知重试循环继续下一次尝试。
+	errRetry = errors.New("kit/grpc: retry")
+)
+
+type (
+	// attemptContextKey 是尝试次数在上下文中的键。
+	attemptContextKey struct{}
+
+	// observedClientStream 在流结束时调用 done，用于记录客户端流式调用的日志与指标。
+	observedClientStream struct {
+		grpc.ClientStream
+		// once 保证 done 只被调用一次。
+		once stdsync.Once
+		// done 在流结束时调用，参数为流的错误，正常结束时为 nil。
+		done func(err error)
+	}
+)
+
+// DefaultDialOptions 返回客户端的默认拦截器组合。
+// 一元调用依次经过错误转换、重试、指标与日志，重试的每次尝试都会分别记录日志与指标；流式调用只转换错误并记录指标与日志，不重试。
+// 调用返回的状态错误按 FromStatus 转换为 kit/errors 的错误，仍然可以通过 status.Code 取得状态码。
+//
+// 参数：
+//   - opts：配置选项，支持 WithName、WithLogger、WithClock、WithMetrics、WithMaxAttempts、WithBackoff 与 WithRetryCodes。
+//
+// 返回值：
+//   - []grpc.DialOption：包含一元与流式拦截器链的客户端选项。
+//
+// 示例：
+//
+//	conn, err := grpc.NewClient(target, append(kitgrpc.DefaultDialOptions(kitgrpc.WithName("user")),
+//	    grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+func DefaultDialOptions(opts ...Option) []grpc.DialOption {
+	o := newOptions(opts...)
+
+	var (
+		unary  = []grpc.UnaryClientInterceptor{UnaryClientErrors()}
+		stream = []grpc.StreamClientInterceptor{StreamClientErrors()}
+	)
+	if o.maxAttempts > 1 {
+		unary = append(unary, UnaryClientRetry(opts...))
+	}
+	if o.metrics {
+		unary = append(unary, UnaryClientMetrics(opts...))
+		stream = append(stream, StreamClientMetrics(opts...))
+	}
+	unary = append(unary, UnaryClientLogging(opts...))
+	stream = append(stream, StreamClientLogging(opts...))
+
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unary...),
+		grpc.WithChainStreamInterceptor(stream...),
+	}
+}
+
+// UnaryClientRetry 返回按退避策略重试一元调用的拦截器。
+// 状态码属于 WithRetryCodes 设置的范围时重试，最后一次尝试的错误原样返回；
+// 重试等待期间上下文结束时返回上下文对应的状态错误。
+//
+// 参数：
+//   - opts：配置选项，支持 WithName、WithMetrics、WithMaxAttempts、WithBackoff 与 WithRetryCodes。
+//
+// 返回值：
+//   - grpc.UnaryClientInterceptor：重试的拦截器。
+func UnaryClientRetry(opts ...Option) grpc.UnaryClientInterceptor {
+	o := newOptions(opts...)
+
+	return func(ctx context.Context, fullMethod string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		if o.maxAttempts <= 1 {
+			return invoker(ctx, fullMethod, req, reply, cc, callOpts...)
+		}
+
+		var (
+			err     error
+			attempt int
+		)
+		retryErr := retry.RetryWithContext(ctx, func(ctx context.Context) error {
+			attempt++
+			if attempt > 1 {
+				ctx = context.WithValue(ctx, attemptContextKey{}, attempt)
+				if o.metrics {
+					service, method := splitMethod(fullMethod)
+					MetricClientRetries.WithLabelValues(o.name, service, method).Inc()
+				}
+			}
+
+			err = invoker(ctx, fullMethod, req, reply, cc, callOpts...)
+			if nil == err || attempt >= o.maxAttempts || !o.retryable(status.Code(err)) {
+				return nil
+			}
+			return errRetry
+		}, o.backoff...)
+		if nil != retryErr {
+			return status.FromContextError(retryErr).Err()
+		}
+		return err
+	}
+}
+
+// UnaryClientLogging 返回记录一元调用日志的拦截器。
+// 每次尝试记录一条日志，包含客户端名称、方法、目标地址、尝试次数、状态码、耗时与请求 ID；
+// 成功时以调试级别记录，失败时以警告级别记录并附带错误信息。
+//
+// 参数：
+//   - opts：配置选项，支持 WithName、WithLogger 与 WithClock。
+//
+// 返回值：
+//   - grpc.UnaryClientInterceptor：记录日志的拦截器。
+func UnaryClientLogging(opts ...Option) grpc.UnaryClientInterceptor {
+	o := newOptions(opts...)
+
+	return func(ctx context.Context, fullMethod string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		start := o.clock.Now()
+		err := invoker(ctx, fullMethod, req, reply, cc, callOpts...)
+		logClientCall(ctx, o, fullMethod, cc.Target(), start, err)
+		return err
+	}
+}
+
+// StreamClientLogging 返回记录流式调用日志的拦截器。
+// 日志在流结束时记录，即 RecvMsg 返回错误或 io.EOF 时；调用方没有读取到流结束时不会记录。
+//
+// 参数：
+//   - opts：配置选项，支持 WithName、WithLogger 与 WithClock。
+//
+// 返回值：
+//   - grpc.StreamClientInterceptor：记录日志的拦截器。
+func StreamClientLogging(opts ...Option) grpc.StreamClientInterceptor {
+	o := newOptions(opts...)
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, fullMethod string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := o.clock.Now()
+		return observeClientStream(ctx, desc, cc, fullMethod, streamer, callOpts, func(err error) {
+			logClientCall(ctx, o, fullMethod, cc.Target(), start, err)
+		})
+	}
+}
+
+// logClientCall 记录客户端一次调用的日志。
+func logClientCall(ctx context.Context, o *options, fullMethod, target string, start time.Time, err error) {
+	fields := map[string]interface{}{
+		"client":      o.name,
+		"method":      fullMethod,
+		"target":      target,
+		"attempt":     attemptOf(ctx),
+		"code":        status.Code(err).String(),
+		"duration_ms": o.clock.Since(start).Milliseconds(),
+	}
+	if requestID, ok := kitid.FromContext(ctx); ok {
+		fields[kitid.LogFieldRequestID] = requestID
+	}
+
+	logger := o.getLogger()
+	if nil != err {
+		fields["error"] = status.Convert(err).Message()
+		logger.WithFields(fields).Warn("grpc client request failed")
+		return
+	}
+	logger.WithFields(fields).Debug("grpc client request")
+}
+
+// UnaryClientMetrics 返回记录一元调用指标的拦截器，指标为 MetricClientHandlingDuration。
+//
+// 参数：
+//   - opts：配置选项，支持 WithName 与 WithClock。
+//
+// 返回值：
+//   - grpc.UnaryClientInterceptor：记录指标的拦截器。
+func UnaryClientMetrics(opts ...Option) grpc.UnaryClientInterceptor {
+	o := newOptions(opts...)
+
+	return func(ctx context.Context, fullMethod string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		start := o.clock.Now()
+		err := invoker(ctx, fullMethod, req, reply, cc, callOpts...)
+		observeClientCall(o, fullMethod, start, err)
+		return err
+	}
+}
+
+// StreamClientMetrics 返回记录流式调用指标的拦截器，耗时为流建立到结束的持续时间。
+//
+// 参数：
+//   - opts：配置选项，支持 WithName 与 WithClock。
+//
+// 返回值：
+//   - grpc.StreamClientInterceptor：记录指标的拦截器。
+func StreamClientMetrics(opts ...Option) grpc.StreamClientInterceptor {
+	o := newOptions(opts...)
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, fullMethod string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := o.clock.Now()
+		return observeClientStream(ctx, desc, cc, fullMethod, streamer, callOpts, func(err error) {
+			observeClientCall(o, fullMethod, start, err)
+		})
+	}
+}
+
+// observeClientCall 记录客户端一次调用的指标。
+func observeClientCall(o *options, fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	MetricClientHandlingDuration.WithLabelValues(o.name, service, method, status.Code(err).String()).
+		Observe(o.clock.Since(start).Seconds())
+}
+
+// observeClientStream 建立流，并在流结束时调用 done；建立失败时立即调用 done。
+func observeClientStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, fullMethod string,
+	streamer grpc.Streamer, callOpts []grpc.CallOption, done func(err error)) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, fullMethod, callOpts...)
+	if nil != err {
+		done(err)
+		return nil, err
+	}
+	return &observedClientStream{ClientStream: stream, done: done}, nil
+}
+
+// RecvMsg 接收消息，流结束时调用 done。
+func (s *observedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if nil != err {
+		s.once.Do(func() {
+			if errors.Is(err, io.EOF) {
+				s.done(nil)
+			} else {
+				s.done(err)
+			}
+		})
+	}
+	return err
+}
+
+// attemptOf 返回上下文中的尝试次数，未设置时为第一次尝试。
+func attemptOf(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptContextKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
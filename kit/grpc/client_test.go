@@ -0,0 +1,187 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	kitid "github.com/fsyyft-go/monorepo/kit/id"
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+// flakyHealth 返回前 failures 次 Check 返回 code、之后成功的健康检查服务，以及 Check 的调用次数。
+func flakyHealth(failures int32, code codes.Code) (*healthServer, *atomic.Int32) {
+	var calls atomic.Int32
+	return &healthServer{
+		check: func(context.Context) error {
+			if calls.Add(1) <= failures {
+				return status.Error(code, "flaky")
+			}
+			return nil
+		},
+	}, &calls
+}
+
+// fastRetry 返回测试中使用的快速退避策略。
+func fastRetry() Option {
+	return WithBackoff(retry.WithMin(time.Millisecond), retry.WithMax(time.Millisecond), retry.WithJitter(false))
+}
+
+// TestUnaryClientRetry 测试 Unavailable 被重试，并记录每次尝试的日志与指标。
+func TestUnaryClientRetry(t *testing.T) {
+	name := uniqueName(t)
+	logger := logtest.NewRecorder()
+	hs, calls := flakyHealth(2, codes.Unavailable)
+	client := newHealthClient(t, hs, nil, DefaultDialOptions(WithName(name), WithLogger(logger), fastRetry()))
+
+	_, err := client.Check(kitid.NewContext(context.Background(), "req-3"), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+
+	entries := logger.Entries()
+	require.Len(t, entries, 3)
+	for i, entry := range entries[:2] {
+		assert.Equal(t, kitlog.WarnLevel, entry.Level)
+		assert.Equal(t, "grpc client request failed", entry.Message)
+		assert.Equal(t, i+1, entry.Fields["attempt"])
+		assert.Equal(t, "Unavailable", entry.Fields["code"])
+		assert.Equal(t, "flaky", entry.Fields["error"])
+	}
+	assert.Equal(t, kitlog.DebugLevel, entries[2].Level)
+	assert.Equal(t, 3, entries[2].Fields["attempt"])
+	assert.Equal(t, name, entries[2].Fields["client"])
+	assert.Equal(t, "/grpc.health.v1.Health/Check", entries[2].Fields["method"])
+	assert.Equal(t, "passthrough:///bufnet", entries[2].Fields["target"])
+	assert.Equal(t, "req-3", entries[2].Fields[kitid.LogFieldRequestID])
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricClientRetries.WithLabelValues(name, "grpc.health.v1.Health", "Check")))
+	assert.Equal(t, uint64(2), sampleCount(t, MetricClientHandlingDuration.WithLabelValues(name, "grpc.health.v1.Health", "Check", "Unavailable")))
+	assert.Equal(t, uint64(1), sampleCount(t, MetricClientHandlingDuration.WithLabelValues(name, "grpc.health.v1.Health", "Check", "OK")))
+}
+
+// TestUnaryClientRetry_Exhausted 测试重试次数用尽时返回最后一次的错误。
+func TestUnaryClientRetry_Exhausted(t *testing.T) {
+	hs, calls := flakyHealth(10, codes.Unavailable)
+	client := newHealthClient(t, hs, nil, DefaultDialOptions(WithMaxAttempts(2), WithMetrics(false), WithLogger(logtest.NewRecorder()), fastRetry()))
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+// TestUnaryClientRetry_Codes 测试默认只重试 Unavailable，WithRetryCodes 可以替换需要重试的状态码。
+func TestUnaryClientRetry_Codes(t *testing.T) {
+	hs, calls := flakyHealth(1, codes.Aborted)
+	client := newHealthClient(t, hs, nil, DefaultDialOptions(WithMetrics(false), WithLogger(logtest.NewRecorder()), fastRetry()))
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	assert.Equal(t, int32(1), calls.Load())
+
+	hs, calls = flakyHealth(1, codes.Aborted)
+	client = newHealthClient(t, hs, nil, DefaultDialOptions(WithMetrics(false), WithLogger(logtest.NewRecorder()), fastRetry(),
+		WithRetryCodes(codes.Aborted)))
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+// TestUnaryClientRetry_Canceled 测试重试等待期间上下文结束时返回上下文对应的状态错误。
+func TestUnaryClientRetry_Canceled(t *testing.T) {
+	hs, _ := flakyHealth(10, codes.Unavailable)
+	client := newHealthClient(t, hs, nil, DefaultDialOptions(WithMetrics(false), WithLogger(logtest.NewRecorder()),
+		WithBackoff(retry.WithMin(time.Hour), retry.WithMax(time.Hour))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+// TestDefaultDialOptions_NoRetry 测试最大尝试次数为 1 时不重试。
+func TestDefaultDialOptions_NoRetry(t *testing.T) {
+	hs, calls := flakyHealth(1, codes.Unavailable)
+	client := newHealthClient(t, hs, nil, DefaultDialOptions(WithMaxAttempts(1), WithMetrics(false), WithLogger(logtest.NewRecorder())))
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(1), calls.Load())
+
+	// 直接使用的重试拦截器同样遵守最大尝试次数。
+	interceptor := UnaryClientRetry(WithMaxAttempts(1))
+	var invoked int
+	err = interceptor(context.Background(), "/pkg.Svc/Get", nil, nil, nil, func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		invoked++
+		return status.Error(codes.Unavailable, "busy")
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, invoked)
+}
+
+// TestStreamClient 测试流式调用在流结束时记录日志与指标。
+func TestStreamClient(t *testing.T) {
+	name := uniqueName(t)
+	logger := logtest.NewRecorder()
+	var fail atomic.Bool
+	hs := &healthServer{
+		watch: func(stream grpc_health_v1.Health_WatchServer) error {
+			if fail.Load() {
+				return status.Error(codes.PermissionDenied, "denied")
+			}
+			for i := 0; i < 2; i++ {
+				if err := stream.Send(&grpc_health_v1.HealthCheckResponse{}); nil != err {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	client := newHealthClient(t, hs, nil, DefaultDialOptions(WithName(name), WithLogger(logger)))
+
+	stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	received := 0
+	for {
+		_, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		received++
+	}
+	assert.Equal(t, 2, received)
+	// 流结束后再次读取不会重复记录。
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+
+	fail.Store(true)
+	stream, err = client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	entries := logger.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, kitlog.DebugLevel, entries[0].Level)
+	assert.Equal(t, "OK", entries[0].Fields["code"])
+	assert.Equal(t, kitlog.WarnLevel, entries[1].Level)
+	assert.Equal(t, "PermissionDenied", entries[1].Fields["code"])
+	assert.Equal(t, uint64(1), sampleCount(t, MetricClientHandlingDuration.WithLabelValues(name, "grpc.health.v1.Health", "Watch", "OK")))
+	assert.Equal(t, uint64(1), sampleCount(t, MetricClientHandlingDuration.WithLabelValues(name, "grpc.health.v1.Health", "Watch", "PermissionDenied")))
+}
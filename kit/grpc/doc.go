@@ -0,0 +1,39 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package grpc 提供了 gRPC 服务端与客户端的拦截器，接入 kit/log、kit/runtime/retry 与 Prometheus，
+使各个 gRPC 服务具有一致的日志、指标与重试行为。
+
+主要功能：
+
+  - 默认组合：DefaultServerOptions 与 DefaultDialOptions 一次调用得到完整的拦截器链
+  - 异常恢复：UnaryServerRecovery、StreamServerRecovery 捕获处理函数的 panic，记录堆栈并返回 Internal
+  - 调用日志：服务端与客户端的日志拦截器记录方法、状态码、耗时与请求 ID
+  - 调用指标：服务端与客户端的指标拦截器按服务、方法与状态码记录耗时的直方图
+  - 客户端重试：UnaryClientRetry 按 kit/runtime/retry 的退避策略重试 Unavailable 等状态码
+  - 错误转换：ToStatus、FromStatus 在 kit/errors 的错误与 gRPC 状态之间转换，错误码与元数据跨服务保留
+
+服务端：
+
+	srv := grpc.NewServer(kitgrpc.DefaultServerOptions(kitgrpc.WithLogger(logger))...)
+
+客户端：
+
+	opts := kitgrpc.DefaultDialOptions(
+	    kitgrpc.WithName("user"),
+	    kitgrpc.WithMaxAttempts(3),
+	)
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient("dns:///user-service:9090", opts...)
+
+由于包名与 google.golang.org/grpc 相同，使用时建议指定别名：
+
+	import (
+	    "google.golang.org/grpc"
+
+	    kitgrpc "github.com/fsyyft-go/monorepo/kit/grpc"
+	)
+*/
+package grpc
@@ -0,0 +1,72 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package grpc
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 定义 gRPC 指标相关的常量。
+const (
+	// namespace 定义 prometheus 指标的命名空间。
+	namespace = "kit_grpc"
+	// subsystemServer 定义服务端指标的子系统名称。
+	subsystemServer = "server"
+	// subsystemClient 定义客户端指标的子系统名称。
+	subsystemClient = "client"
+)
+
+var (
+	// MetricServerHandlingDuration 用于记录服务端处理每次调用的耗时，单位为秒。
+	// 该指标包含以下标签：
+	// - service: 服务的全名，例如 helloworld.Greeter。
+	// - method: 方法名。
+	// - code: 状态码，例如 OK、Unavailable。
+	MetricServerHandlingDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystemServer,
+		Name:      "handling_seconds",
+		Help:      "grpc server's handling duration in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "code"})
+
+	// MetricClientHandlingDuration 用于记录客户端每次调用的耗时，单位为秒，重试的每次尝试分别记录。
+	// 该指标包含以下标签：
+	// - name: 客户端的名称。
+	// - service: 服务的全名。
+	// - method: 方法名。
+	// - code: 状态码。
+	MetricClientHandlingDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystemClient,
+		Name:      "handling_seconds",
+		Help:      "grpc client's handling duration in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"name", "service", "method", "code"})
+
+	// MetricClientRetries 用于记录客户端的重试次数。
+	// 该指标包含以下标签：
+	// - name: 客户端的名称。
+	// - service: 服务的全名。
+	// - method: 方法名。
+	MetricClientRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystemClient,
+		Name:      "retries_total",
+		Help:      "grpc client's retries total.",
+	}, []string{"name", "service", "method"})
+)
+
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricServerHandlingDuration,
+		MetricClientHandlingDuration,
+		MetricClientRetries,
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package grpc
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 以下为拦截器的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// clockDefault 为计算调用耗时使用的时钟。
+	clockDefault = kittime.NewRealClock()
+	// nameDefault 为客户端的默认名称，用于指标标签。
+	nameDefault = "default"
+	// metricsDefault 为 DefaultServerOptions 与 DefaultDialOptions 是否默认包含指标拦截器。
+	metricsDefault = true
+	// maxAttemptsDefault 为一次调用的最大尝试次数，包括第一次调用。
+	maxAttemptsDefault = 3
+	// backoffDefault 为重试之间的退避策略，等待时间从 100 毫秒开始增长，最长 2 秒，并启用抖动。
+	backoffDefault = []retry.BackoffOption{
+		retry.WithMin(100 * time.Millisecond),
+		retry.WithMax(2 * time.Second),
+		retry.WithJitter(true),
+	}
+	// retryCodesDefault 为默认重试的状态码。
+	// 只有 Unavailable 表示服务端没有处理请求，对非幂等的方法重试也是安全的。
+	retryCodesDefault = []codes.Code{codes.Unavailable}
+)
+
+type (
+	// Option 定义了拦截器的配置选项。
+	// 部分选项只对客户端生效，在选项的说明中注明。
+	Option func(*options)
+
+	// options 包含拦截器的配置。
+	options struct {
+		// logger 是记录日志使用的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+		// clock 是计算调用耗时使用的时钟。
+		clock kittime.Clock
+		// metrics 表示是否记录指标。
+		metrics bool
+
+		// name 是客户端的名称，用于指标标签与日志。
+		name string
+		// maxAttempts 是一次调用的最大尝试次数。
+		maxAttempts int
+		// backoff 是重试之间的退避策略。
+		backoff []retry.BackoffOption
+		// retryCodes 是需要重试的状态码。
+		retryCodes []codes.Code
+	}
+)
+
+// WithLogger 设置记录日志使用的日志实例。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例，在每次记录时获取，因此可以晚于拦截器创建初始化。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithClock 设置计算调用耗时使用的时钟。
+// 测试时可以注入 kit/time 的 FakeClock，得到确定的耗时。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithMetrics 设置 DefaultServerOptions 与 DefaultDialOptions 是否包含指标拦截器，以及重试拦截器是否记录重试次数。
+//
+// 参数：
+//   - metrics：是否包含指标拦截器，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMetrics(metrics bool) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// WithName 设置客户端的名称，用于区分不同客户端的指标与日志，仅对客户端生效。
+//
+// 参数：
+//   - name：客户端名称，默认为 default。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithMaxAttempts 设置一次调用的最大尝试次数，包括第一次调用，仅对客户端生效。
+//
+// 参数：
+//   - n：最大尝试次数，默认为 3，1 表示不重试。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxAttempts(n int) Option {
+	return func(o *options) {
+		o.maxAttempts = n
+	}
+}
+
+// WithBackoff 设置重试之间的退避策略，选项追加在默认策略之后，仅对客户端生效。
+//
+// 参数：
+//   - opts：kit/runtime/retry 的退避选项，默认等待时间从 100 毫秒开始增长，最长 2 秒，并启用抖动。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithBackoff(opts ...retry.BackoffOption) Option {
+	return func(o *options) {
+		o.backoff = append(o.backoff, opts...)
+	}
+}
+
+// WithRetryCodes 设置需要重试的状态码，替换默认值，仅对客户端生效。
+// 除 Unavailable 以外的状态码，服务端可能已经处理了请求，只应当对幂等的方法使用。
+//
+// 参数：
+//   - retryCodes：需要重试的状态码，默认为 Unavailable。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithRetryCodes(retryCodes ...codes.Code) Option {
+	return func(o *options) {
+		o.retryCodes = retryCodes
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		clock:       clockDefault,
+		metrics:     metricsDefault,
+		name:        nameDefault,
+		maxAttempts: maxAttemptsDefault,
+		backoff:     append([]retry.BackoffOption(nil), backoffDefault...),
+		retryCodes:  retryCodesDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	o.clock = kittime.OrReal(o.clock)
+	if "" == o.name {
+		o.name = nameDefault
+	}
+	if o.maxAttempts <= 0 {
+		o.maxAttempts = maxAttemptsDefault
+	}
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
+
+// retryable 判断状态码是否需要重试。
+func (o *options) retryable(code codes.Code) bool {
+	for _, c := range o.retryCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package grpc
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	kitid "github.com/fsyyft-go/monorepo/kit/id"
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+)
+
+var (
+	// metadataRequestID 是请求 ID 在 gRPC 元数据中的键，gRPC 元数据的键均为小写。
+	metadataRequestID = strings.ToLower(kitid.HeaderRequestID)
+)
+
+// DefaultServerOptions 返回服务端的默认拦截器组合，依次为指标、日志、错误转换与异常恢复。
+// 异常恢复位于最内层，panic 转换为 Internal 错误后仍会被记录日志与指标；
+// 处理函数返回的 kit/errors 错误按 ToStatus 转换为 gRPC 状态，日志与指标使用转换后的状态码。
+//
+// 参数：
+//   - opts：配置选项，支持 WithLogger、WithClock 与 WithMetrics。
+//
+// 返回值：
+//   - []grpc.ServerOption：包含一元与流式拦截器链的服务端选项。
+//
+// 示例：
+//
+//	srv := grpc.NewServer(kitgrpc.DefaultServerOptions(kitgrpc.WithLogger(logger))...)
+func DefaultServerOptions(opts ...Option) []grpc.ServerOption {
+	o := newOptions(opts...)
+
+	var (
+		unary  []grpc.UnaryServerInterceptor
+		stream []grpc.StreamServerInterceptor
+	)
+	if o.metrics {
+		unary = append(unary, UnaryServerMetrics(opts...))
+		stream = append(stream, StreamServerMetrics(opts...))
+	}
+	unary = append(unary, UnaryServerLogging(opts...), UnaryServerErrors(), UnaryServerRecovery(opts...))
+	stream = append(stream, StreamServerLogging(opts...), StreamServerErrors(), StreamServerRecovery(opts...))
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+// UnaryServerRecovery 返回捕获一元调用处理函数 panic 的拦截器。
+// panic 的值与堆栈通过 kit/log 以错误级别记录，调用返回 Internal 错误。
+//
+// 参数：
+//   - opts：配置选项，支持 WithLogger。
+//
+// 返回值：
+//   - grpc.UnaryServerInterceptor：恢复 panic 的拦截器。
+func UnaryServerRecovery(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts...)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); nil != p {
+				err = recoverPanic(ctx, o, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerRecovery 返回捕获流式调用处理函数 panic 的拦截器。
+// panic 的值与堆栈通过 kit/log 以错误级别记录，调用返回 Internal 错误。
+//
+// 参数：
+//   - opts：配置选项，支持 WithLogger。
+//
+// 返回值：
+//   - grpc.StreamServerInterceptor：恢复 panic 的拦截器。
+func StreamServerRecovery(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts...)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); nil != p {
+				err = recoverPanic(ss.Context(), o, info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recoverPanic 记录 panic 的日志，并返回对应的 Internal 错误。
+func recoverPanic(ctx context.Context, o *options, fullMethod string, p interface{}) error {
+	fields := map[string]interface{}{
+		"method": fullMethod,
+		"panic":  p,
+		"stack":  string(debug.Stack()),
+	}
+	if requestID := requestIDOf(ctx); "" != requestID {
+		fields[kitid.LogFieldRequestID] = requestID
+	}
+	o.getLogger().WithFields(fields).Error("grpc handler panic")
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+// UnaryServerLogging 返回记录一元调用日志的拦截器。
+// 每次调用完成后记录一条日志，包含方法、状态码、耗时、客户端地址与请求 ID，失败时记录错误信息。
+// 日志级别由状态码决定：服务端的错误以错误级别记录，需要关注的错误以警告级别记录，其余以信息级别记录。
+//
+// 参数：
+//   - opts：配置选项，支持 WithLogger 与 WithClock。
+//
+// 返回值：
+//   - grpc.UnaryServerInterceptor：记录日志的拦截器。
+func UnaryServerLogging(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts...)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := o.clock.Now()
+		resp, err := handler(ctx, req)
+		logServerCall(ctx, o, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerLogging 返回记录流式调用日志的拦截器，日志在流结束时记录，内容与 UnaryServerLogging 相同。
+//
+// 参数：
+//   - opts：配置选项，支持 WithLogger 与 WithClock。
+//
+// 返回值：
+//   - grpc.StreamServerInterceptor：记录日志的拦截器。
+func StreamServerLogging(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts...)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := o.clock.Now()
+		err := handler(srv, ss)
+		logServerCall(ss.Context(), o, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// logServerCall 记录服务端一次调用的日志。
+func logServerCall(ctx context.Context, o *options, fullMethod string, start time.Time, err error) {
+	code := status.Code(err)
+	fields := map[string]interface{}{
+		"method":      fullMethod,
+		"code":        code.String(),
+		"duration_ms": o.clock.Since(start).Milliseconds(),
+	}
+	if p, ok := peer.FromContext(ctx); ok && nil != p.Addr {
+		fields["peer"] = p.Addr.String()
+	}
+	if requestID := requestIDOf(ctx); "" != requestID {
+		fields[kitid.LogFieldRequestID] = requestID
+	}
+	if nil != err {
+		fields["error"] = status.Convert(err).Message()
+	}
+
+	logger := o.getLogger().WithFields(fields)
+	switch serverLevel(code) {
+	case kitlog.ErrorLevel:
+		logger.Error("grpc request")
+	case kitlog.WarnLevel:
+		logger.Warn("grpc request")
+	default:
+		logger.Info("grpc request")
+	}
+}
+
+// UnaryServerMetrics 返回记录一元调用指标的拦截器，指标为 MetricServerHandlingDuration。
+//
+// 参数：
+//   - opts：配置选项，支持 WithClock。
+//
+// 返回值：
+//   - grpc.UnaryServerInterceptor：记录指标的拦截器。
+func UnaryServerMetrics(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts...)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := o.clock.Now()
+		resp, err := handler(ctx, req)
+		observeServerCall(o, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerMetrics 返回记录流式调用指标的拦截器，耗时为整个流的持续时间。
+//
+// 参数：
+//   - opts：配置选项，支持 WithClock。
+//
+// 返回值：
+//   - grpc.StreamServerInterceptor：记录指标的拦截器。
+func StreamServerMetrics(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts...)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := o.clock.Now()
+		err := handler(srv, ss)
+		observeServerCall(o, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// observeServerCall 记录服务端一次调用的指标。
+func observeServerCall(o *options, fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	MetricServerHandlingDuration.WithLabelValues(service, method, status.Code(err).String()).
+		Observe(o.clock.Since(start).Seconds())
+}
+
+// serverLevel 返回服务端调用以状态码区分的日志级别。
+// 服务端的错误为错误级别；超时、限流、前置条件不满足等需要关注的错误为警告级别；
+// 成功以及由调用方引起的错误为信息级别。
+func serverLevel(code codes.Code) kitlog.Level {
+	switch code {
+	case codes.Unknown, codes.Unimplemented, codes.Internal, codes.DataLoss:
+		return kitlog.ErrorLevel
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted, codes.FailedPrecondition,
+		codes.Aborted, codes.OutOfRange, codes.Unavailable:
+		return kitlog.WarnLevel
+	default:
+		return kitlog.InfoLevel
+	}
+}
+
+// splitMethod 将 /package.Service/Method 形式的完整方法名拆分为服务名与方法名。
+func splitMethod(fullMethod string) (string, string) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "unknown", name
+}
+
+// requestIDOf 返回调用的请求 ID，优先使用上下文中的值，其次使用传入的元数据。
+func requestIDOf(ctx context.Context) string {
+	if requestID, ok := kitid.FromContext(ctx); ok {
+		return requestID
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(metadataRequestID); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
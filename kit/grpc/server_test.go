@@ -0,0 +1,270 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+)
+
+type (
+	// fakeServerStream 是只提供上下文的 grpc.ServerStream，用于直接调用流式拦截器。
+	fakeServerStream struct {
+		grpc.ServerStream
+		ctx context.Context
+	}
+
+	// healthServer 是行为可控的健康检查服务，用于端到端地测试拦截器。
+	healthServer struct {
+		grpc_health_v1.UnimplementedHealthServer
+		// check 处理 Check 调用。
+		check func(ctx context.Context) error
+		// watch 处理 Watch 调用。
+		watch func(stream grpc_health_v1.Health_WatchServer) error
+	}
+)
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *healthServer) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if err := s.check(ctx); nil != err {
+		return nil, err
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *healthServer) Watch(_ *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	return s.watch(stream)
+}
+
+// newHealthClient 启动使用指定服务端选项的健康检查服务，返回使用指定客户端选项连接到该服务的客户端。
+func newHealthClient(t *testing.T, hs *healthServer, serverOpts []grpc.ServerOption, dialOpts []grpc.DialOption) grpc_health_v1.HealthClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(serverOpts...)
+	grpc_health_v1.RegisterHealthServer(srv, hs)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	dialOpts = append(dialOpts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOpts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+// uniqueName 返回测试唯一的名称，避免不同测试的指标互相影响。
+func uniqueName(t *testing.T) string {
+	return t.Name() + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+// sampleCount 返回直方图指标的样本数。
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	t.Helper()
+	m := &dto.Metric{}
+	require.NoError(t, observer.(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+// TestUnaryServerRecovery 测试一元调用的 panic 被转换为 Internal 错误并记录日志。
+func TestUnaryServerRecovery(t *testing.T) {
+	logger := logtest.NewRecorder()
+	interceptor := UnaryServerRecovery(WithLogger(logger))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Boom"}
+
+	resp, err := interceptor(ctx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	entries := logger.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, kitlog.ErrorLevel, entries[0].Level)
+	assert.Equal(t, "grpc handler panic", entries[0].Message)
+	assert.Equal(t, "/pkg.Svc/Boom", entries[0].Fields["method"])
+	assert.Equal(t, "boom", entries[0].Fields["panic"])
+	assert.NotEmpty(t, entries[0].Fields["stack"])
+	assert.Equal(t, "req-1", entries[0].Fields["request_id"])
+
+	// 没有 panic 时原样返回。
+	resp, err = interceptor(ctx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+	assert.Len(t, logger.Entries(), 1)
+}
+
+// TestStreamServerRecovery 测试流式调用的 panic 被转换为 Internal 错误。
+func TestStreamServerRecovery(t *testing.T) {
+	logger := logtest.NewRecorder()
+	interceptor := StreamServerRecovery(WithLogger(logger))
+	ss := &fakeServerStream{ctx: context.Background()}
+
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/pkg.Svc/Stream"}, func(interface{}, grpc.ServerStream) error {
+		panic(errors.New("boom"))
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	require.Len(t, logger.Entries(), 1)
+	assert.Equal(t, "/pkg.Svc/Stream", logger.Entries()[0].Fields["method"])
+}
+
+// TestUnaryServerLogging 测试一元调用的日志字段与按状态码区分的日志级别。
+func TestUnaryServerLogging(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		level kitlog.Level
+	}{
+		{name: "ok", err: nil, level: kitlog.InfoLevel},
+		{name: "not found", err: status.Error(codes.NotFound, "missing"), level: kitlog.InfoLevel},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "busy"), level: kitlog.WarnLevel},
+		{name: "internal", err: status.Error(codes.Internal, "bad"), level: kitlog.ErrorLevel},
+		{name: "plain error", err: errors.New("plain"), level: kitlog.ErrorLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logtest.NewRecorder()
+			interceptor := UnaryServerLogging(WithLogger(logger))
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234}})
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}, func(context.Context, interface{}) (interface{}, error) {
+				return nil, tt.err
+			})
+			assert.Equal(t, tt.err, err)
+
+			entries := logger.Entries()
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.level, entries[0].Level)
+			assert.Equal(t, "grpc request", entries[0].Message)
+			assert.Equal(t, "/pkg.Svc/Get", entries[0].Fields["method"])
+			assert.Equal(t, status.Code(tt.err).String(), entries[0].Fields["code"])
+			assert.Equal(t, "127.0.0.1:1234", entries[0].Fields["peer"])
+			assert.Contains(t, entries[0].Fields, "duration_ms")
+			if nil == tt.err {
+				assert.NotContains(t, entries[0].Fields, "error")
+			} else {
+				assert.Equal(t, status.Convert(tt.err).Message(), entries[0].Fields["error"])
+			}
+		})
+	}
+}
+
+// TestStreamServerLogging 测试流式调用在流结束时记录日志。
+func TestStreamServerLogging(t *testing.T) {
+	logger := logtest.NewRecorder()
+	interceptor := StreamServerLogging(WithLogger(logger))
+	ss := &fakeServerStream{ctx: context.Background()}
+
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/pkg.Svc/Stream"}, func(interface{}, grpc.ServerStream) error {
+		return status.Error(codes.DeadlineExceeded, "slow")
+	})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	entries := logger.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, kitlog.WarnLevel, entries[0].Level)
+	assert.Equal(t, "DeadlineExceeded", entries[0].Fields["code"])
+	assert.NotContains(t, entries[0].Fields, "peer")
+}
+
+// TestServerMetrics 测试服务端指标按服务、方法与状态码记录。
+func TestServerMetrics(t *testing.T) {
+	service := "pkg." + uniqueName(t)
+
+	_, _ = UnaryServerMetrics()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/" + service + "/Get"},
+		func(context.Context, interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "missing")
+		})
+	_ = StreamServerMetrics()(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/" + service + "/Watch"},
+		func(interface{}, grpc.ServerStream) error {
+			return nil
+		})
+
+	assert.Equal(t, uint64(1), sampleCount(t, MetricServerHandlingDuration.WithLabelValues(service, "Get", "NotFound")))
+	assert.Equal(t, uint64(1), sampleCount(t, MetricServerHandlingDuration.WithLabelValues(service, "Watch", "OK")))
+}
+
+// TestDefaultServerOptions 测试默认的服务端拦截器组合：panic 被恢复，并被记录日志与指标。
+func TestDefaultServerOptions(t *testing.T) {
+	logger := logtest.NewRecorder()
+	hs := &healthServer{
+		check: func(context.Context) error {
+			panic("boom")
+		},
+		watch: func(grpc_health_v1.Health_WatchServer) error {
+			panic("boom")
+		},
+	}
+	client := newHealthClient(t, hs, DefaultServerOptions(WithLogger(logger)), nil)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-2")
+	_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// 客户端收到响应时服务端的日志可能尚未写入。
+	require.Eventually(t, func() bool {
+		return 4 == len(logger.Entries())
+	}, time.Second, 5*time.Millisecond)
+	entries := logger.Entries()
+	assert.Equal(t, "grpc handler panic", entries[0].Message)
+	assert.Equal(t, "grpc request", entries[1].Message)
+	assert.Equal(t, kitlog.ErrorLevel, entries[1].Level)
+	assert.Equal(t, "Internal", entries[1].Fields["code"])
+	assert.Equal(t, "req-2", entries[1].Fields["request_id"])
+	assert.Equal(t, "/grpc.health.v1.Health/Watch", entries[3].Fields["method"])
+	assert.NotZero(t, sampleCount(t, MetricServerHandlingDuration.WithLabelValues("grpc.health.v1.Health", "Check", "Internal")))
+
+	assert.Len(t, DefaultServerOptions(WithMetrics(false)), 2)
+}
+
+// TestSplitMethod 测试完整方法名的拆分。
+func TestSplitMethod(t *testing.T) {
+	service, method := splitMethod("/pkg.Svc/Get")
+	assert.Equal(t, "pkg.Svc", service)
+	assert.Equal(t, "Get", method)
+
+	service, method = splitMethod("Get")
+	assert.Equal(t, "unknown", service)
+	assert.Equal(t, "Get", method)
+}
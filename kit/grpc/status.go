@@ -0,0 +1,196 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	kiterrors "github.com/fsyyft-go/monorepo/kit/errors"
+)
+
+const (
+	// ErrorInfoDomain 是携带 kit/errors 元数据的 errdetails.ErrorInfo 的 Domain。
+	ErrorInfoDomain = "kit/errors"
+)
+
+type (
+	// statusError 是由 gRPC 状态转换而来的错误。
+	// 错误信息为状态的描述，GRPCStatus 返回原始状态，再次返回给上游时状态的详情不会丢失。
+	statusError struct {
+		// st 是原始状态。
+		st *status.Status
+	}
+
+	// errorClientStream 将 RecvMsg 与 SendMsg 返回的状态错误转换为 kit/errors 的错误。
+	errorClientStream struct {
+		grpc.ClientStream
+	}
+)
+
+// Error 返回状态的描述。
+func (e *statusError) Error() string {
+	return e.st.Message()
+}
+
+// GRPCStatus 返回原始状态，供 status.FromError 与 status.Code 使用。
+func (e *statusError) GRPCStatus() *status.Status {
+	return e.st
+}
+
+// Code 返回状态码对应的 kit/errors 错误码，供 kiterrors.CodeOf 使用。
+func (e *statusError) Code() kiterrors.Code {
+	return kiterrors.Code(e.st.Code())
+}
+
+// ToStatus 将错误转换为 gRPC 状态。
+// 错误码由 kiterrors.TransportCodeOf 确定，两者的数值一一对应；状态的描述为错误信息；
+// 错误的元数据以 errdetails.ErrorInfo 的 Metadata 作为详情携带，值通过 fmt.Sprint 转换为字符串。
+// 错误链中已经包含错误码相同的 gRPC 状态时直接使用该状态，保留其全部详情。
+//
+// 参数：
+//   - err：要转换的错误。
+//
+// 返回值：
+//   - *status.Status：gRPC 状态，err 为 nil 时状态码为 OK。
+//
+// 示例：
+//
+//	return nil, kitgrpc.ToStatus(err).Err()
+func ToStatus(err error) *status.Status {
+	if nil == err {
+		return status.New(codes.OK, "")
+	}
+
+	code := kiterrors.TransportCodeOf(err)
+	if st, ok := status.FromError(err); ok && (kiterrors.CodeUnknown == code || codes.Code(code) == st.Code()) {
+		return st
+	}
+
+	st := status.New(codes.Code(code), err.Error())
+	if fields := kiterrors.FieldsOf(err); len(fields) > 0 {
+		info := &errdetails.ErrorInfo{
+			Reason:   code.String(),
+			Domain:   ErrorInfoDomain,
+			Metadata: make(map[string]string, len(fields)),
+		}
+		for k, v := range fields {
+			info.Metadata[k] = fmt.Sprint(v)
+		}
+		if withDetails, errDetails := st.WithDetails(info); nil == errDetails {
+			st = withDetails
+		}
+	}
+	return st
+}
+
+// FromStatus 将 gRPC 状态转换为 kit/errors 的错误。
+// 错误码与状态码一一对应，错误信息为状态的描述，Domain 为 ErrorInfoDomain 的 ErrorInfo 详情转换为错误的元数据。
+// 返回的错误仍然可以通过 status.FromError 取得原始状态。
+//
+// 参数：
+//   - st：gRPC 状态。
+//
+// 返回值：
+//   - error：转换后的错误，st 为 nil 或状态码为 OK 时返回 nil。
+//
+// 示例：
+//
+//	if kiterrors.IsCode(kitgrpc.FromStatus(status.Convert(err)), kiterrors.CodeNotFound) {
+//	    // 处理资源不存在。
+//	}
+func FromStatus(st *status.Status) error {
+	if nil == st || codes.OK == st.Code() {
+		return nil
+	}
+
+	fields := make(map[string]interface{})
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && ErrorInfoDomain == info.GetDomain() {
+			for k, v := range info.GetMetadata() {
+				fields[k] = v
+			}
+		}
+	}
+	return kiterrors.WithFields(&statusError{st: st}, fields)
+}
+
+// UnaryServerErrors 返回将处理函数的错误转换为 gRPC 状态的拦截器，转换规则见 ToStatus。
+//
+// 返回值：
+//   - grpc.UnaryServerInterceptor：转换错误的拦截器。
+func UnaryServerErrors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, toStatusError(err)
+	}
+}
+
+// StreamServerErrors 返回将流式处理函数的错误转换为 gRPC 状态的拦截器，转换规则见 ToStatus。
+//
+// 返回值：
+//   - grpc.StreamServerInterceptor：转换错误的拦截器。
+func StreamServerErrors() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return toStatusError(handler(srv, ss))
+	}
+}
+
+// UnaryClientErrors 返回将调用的状态错误转换为 kit/errors 错误的拦截器，转换规则见 FromStatus。
+//
+// 返回值：
+//   - grpc.UnaryClientInterceptor：转换错误的拦截器。
+func UnaryClientErrors() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, fullMethod string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		return fromStatusError(invoker(ctx, fullMethod, req, reply, cc, callOpts...))
+	}
+}
+
+// StreamClientErrors 返回将流式调用的状态错误转换为 kit/errors 错误的拦截器，流正常结束时的 io.EOF 原样返回。
+//
+// 返回值：
+//   - grpc.StreamClientInterceptor：转换错误的拦截器。
+func StreamClientErrors() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, fullMethod string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, fullMethod, callOpts...)
+		if nil != err {
+			return nil, fromStatusError(err)
+		}
+		return &errorClientStream{ClientStream: stream}, nil
+	}
+}
+
+// SendMsg 发送消息并转换状态错误。
+func (s *errorClientStream) SendMsg(m interface{}) error {
+	return fromStatusError(s.ClientStream.SendMsg(m))
+}
+
+// RecvMsg 接收消息并转换状态错误。
+func (s *errorClientStream) RecvMsg(m interface{}) error {
+	return fromStatusError(s.ClientStream.RecvMsg(m))
+}
+
+// toStatusError 将错误转换为 gRPC 状态错误，err 为 nil 时返回 nil。
+func toStatusError(err error) error {
+	if nil == err {
+		return nil
+	}
+	return ToStatus(err).Err()
+}
+
+// fromStatusError 将 gRPC 状态错误转换为 kit/errors 的错误，nil 与 io.EOF 原样返回。
+func fromStatusError(err error) error {
+	if nil == err || errors.Is(err, io.EOF) {
+		return err
+	}
+	return FromStatus(status.Convert(err))
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package grpc
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	kiterrors "github.com/fsyyft-go/monorepo/kit/errors"
+
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+)
+
+// TestToStatus 测试错误转换为 gRPC 状态。
+func TestToStatus(t *testing.T) {
+	assert.Equal(t, codes.OK, ToStatus(nil).Code())
+	assert.Equal(t, codes.Unknown, ToStatus(io.EOF).Code())
+	assert.Equal(t, codes.DeadlineExceeded, ToStatus(context.DeadlineExceeded).Code())
+
+	err := kiterrors.WithField(kiterrors.WithCode(kiterrors.New("用户不存在"), kiterrors.CodeNotFound), "user_id", 42)
+	st := ToStatus(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "用户不存在", st.Message())
+	require.Len(t, st.Details(), 1)
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.Equal(t, ErrorInfoDomain, info.GetDomain())
+	assert.Equal(t, "NotFound", info.GetReason())
+	assert.Equal(t, map[string]string{"user_id": "42"}, info.GetMetadata())
+
+	// 错误链中已有的 gRPC 状态保留其详情。
+	origin, detailsErr := status.New(codes.InvalidArgument, "参数错误").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "name"}},
+	})
+	require.NoError(t, detailsErr)
+	st = ToStatus(kiterrors.Wrap(origin.Err(), "校验"))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Len(t, st.Details(), 1)
+
+	// 外层设置了不同的错误码时以外层为准。
+	st = ToStatus(kiterrors.WithCode(origin.Err(), kiterrors.CodeInternal))
+	assert.Equal(t, codes.Internal, st.Code())
+}
+
+// TestFromStatus 测试 gRPC 状态转换为 kit/errors 的错误。
+func TestFromStatus(t *testing.T) {
+	assert.NoError(t, FromStatus(nil))
+	assert.NoError(t, FromStatus(status.New(codes.OK, "")))
+
+	origin := ToStatus(kiterrors.WithField(kiterrors.WithCode(kiterrors.New("余额不足"), kiterrors.CodeFailedPrecondition), "balance", 3))
+	err := FromStatus(origin)
+	assert.EqualError(t, err, "余额不足")
+	assert.Equal(t, kiterrors.CodeFailedPrecondition, kiterrors.CodeOf(err))
+	assert.Equal(t, map[string]interface{}{"balance": "3"}, kiterrors.FieldsOf(err))
+
+	// 转换后的错误仍然携带原始状态，再次转换时详情不会丢失。
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, origin.Proto().GetDetails(), ToStatus(err).Proto().GetDetails())
+}
+
+// TestErrorInterceptors 测试服务端与客户端的错误转换拦截器端到端地保留错误码与元数据。
+func TestErrorInterceptors(t *testing.T) {
+	hs := &healthServer{
+		check: func(context.Context) error {
+			return kiterrors.WithField(kiterrors.WithCode(kiterrors.New("配额已用尽"), kiterrors.CodeResourceExhausted), "quota", "daily")
+		},
+		watch: func(grpc_health_v1.Health_WatchServer) error {
+			return kiterrors.WithCode(kiterrors.New("没有权限"), kiterrors.CodePermissionDenied)
+		},
+	}
+	client := newHealthClient(t, hs,
+		[]grpc.ServerOption{grpc.UnaryInterceptor(UnaryServerErrors()), grpc.StreamInterceptor(StreamServerErrors())},
+		[]grpc.DialOption{grpc.WithUnaryInterceptor(UnaryClientErrors()), grpc.WithStreamInterceptor(StreamClientErrors())},
+	)
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.EqualError(t, err, "配额已用尽")
+	assert.True(t, kiterrors.IsCode(err, kiterrors.CodeResourceExhausted))
+	assert.Equal(t, map[string]interface{}{"quota": "daily"}, kiterrors.FieldsOf(err))
+
+	stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.True(t, kiterrors.IsCode(err, kiterrors.CodePermissionDenied))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+// TestDefaultServerOptions_Errors 测试默认拦截器组合的日志使用转换后的状态码。
+func TestDefaultServerOptions_Errors(t *testing.T) {
+	logger := logtest.NewRecorder()
+	hs := &healthServer{
+		check: func(context.Context) error {
+			return kiterrors.WithCode(kiterrors.New("用户不存在"), kiterrors.CodeNotFound)
+		},
+	}
+	client := newHealthClient(t, hs, DefaultServerOptions(WithLogger(logger), WithMetrics(false)), DefaultDialOptions(WithLogger(logtest.NewRecorder()), WithMetrics(false)))
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.True(t, kiterrors.IsCode(err, kiterrors.CodeNotFound))
+
+	require.Eventually(t, func() bool {
+		return 1 == len(logger.Entries())
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "NotFound", logger.Entries()[0].Fields["code"])
+}
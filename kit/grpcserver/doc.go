@@ -0,0 +1,36 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package grpcserver 提供了实现 kit/runtime Runner 接口的 gRPC 服务 Server。
+
+Server 预置了 kit/grpc 的拦截器组合（指标、日志与异常恢复）、grpc.health.v1 健康检查服务与反射服务，
+业务只需要通过 WithRegister 注册自己的服务实现：
+
+	srv, err := grpcserver.New(
+	    grpcserver.WithAddr(":9090"),
+	    grpcserver.WithRegister(func(s grpc.ServiceRegistrar) {
+	        pb.RegisterUserServer(s, userService)
+	    }),
+	)
+	if nil != err {
+	    return err
+	}
+	if err := srv.Start(ctx); nil != err {
+	    return err
+	}
+	defer srv.Stop(shutdownCtx)
+
+Start 监听成功后将健康状态置为 SERVING；Stop 先将健康状态置为 NOT_SERVING，再等待正在处理的调用完成，
+截止时间到达时强制关闭剩余连接。
+
+使用 kit/tls 的 Provider 启用 TLS，证书重新加载后新的连接立即使用新证书：
+
+	srv, err := grpcserver.New(grpcserver.WithTLS(provider))
+
+Ready 在监听成功后被关闭，Addr 返回实际监听的地址，Check 可以注册为 kit/health 的就绪检查：
+
+	h.AddReadiness("grpc", srv)
+*/
+package grpcserver
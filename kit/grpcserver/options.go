@@ -0,0 +1,230 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package grpcserver
+
+import (
+	"net"
+
+	"google.golang.org/grpc"
+
+	kitgrpc "github.com/fsyyft-go/monorepo/kit/grpc"
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	kittls "github.com/fsyyft-go/monorepo/kit/tls"
+)
+
+// 以下为 gRPC 服务的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// nameDefault 为服务的默认名称，用于日志。
+	nameDefault = "grpc"
+	// addrDefault 为默认的监听地址。
+	addrDefault = ":9090"
+	// healthDefault 为是否默认注册健康检查服务。
+	healthDefault = true
+	// reflectionDefault 为是否默认注册反射服务。
+	reflectionDefault = true
+)
+
+type (
+	// Option 定义了 gRPC 服务的配置选项。
+	Option func(*options)
+
+	// options 包含 gRPC 服务的配置。
+	options struct {
+		// name 是服务的名称，用于日志。
+		name string
+		// addr 是监听地址。
+		addr string
+		// listener 是已经创建的监听器，设置后 addr 不再生效。
+		listener net.Listener
+		// tls 提供 TLS 证书，为 nil 时不启用 TLS。
+		tls *kittls.Provider
+		// health 表示是否注册健康检查服务。
+		health bool
+		// reflection 表示是否注册反射服务。
+		reflection bool
+		// registers 是创建服务时依次调用的注册函数。
+		registers []func(grpc.ServiceRegistrar)
+		// grpcOptions 是传给 kit/grpc 拦截器组合的配置选项。
+		grpcOptions []kitgrpc.Option
+		// serverOptions 是追加的 gRPC 服务端选项，位于 kit/grpc 的拦截器组合之后。
+		serverOptions []grpc.ServerOption
+		// logger 是记录服务启停与拦截器日志的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+	}
+)
+
+// WithName 设置服务的名称，用于区分同一进程中多个服务的日志。
+//
+// 参数：
+//   - name：服务名称，默认为 grpc。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithAddr 设置监听地址。
+//
+// 参数：
+//   - addr：监听地址，默认为 :9090，端口为 0 时由系统分配，可以通过 Addr 获取实际地址。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithAddr(addr string) Option {
+	return func(o *options) {
+		o.addr = addr
+	}
+}
+
+// WithListener 使用已经创建的监听器，例如 kit/net 的 GracefulListener。
+// 设置后 WithAddr 不再生效，Stop 时监听器被关闭。
+//
+// 参数：
+//   - listener：监听器。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithListener(listener net.Listener) Option {
+	return func(o *options) {
+		o.listener = listener
+	}
+}
+
+// WithTLS 启用 TLS，证书由 kit/tls 的 Provider 提供，Provider 重新加载证书后新的连接立即使用新证书。
+//
+// 参数：
+//   - provider：证书的 Provider，必须设置了证书，默认不启用 TLS。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTLS(provider *kittls.Provider) Option {
+	return func(o *options) {
+		o.tls = provider
+	}
+}
+
+// WithHealth 设置是否注册 grpc.health.v1 健康检查服务。
+//
+// 参数：
+//   - enabled：是否注册，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithHealth(enabled bool) Option {
+	return func(o *options) {
+		o.health = enabled
+	}
+}
+
+// WithReflection 设置是否注册 gRPC 反射服务，供 grpcurl 等工具查询服务定义。
+//
+// 参数：
+//   - enabled：是否注册，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithReflection(enabled bool) Option {
+	return func(o *options) {
+		o.reflection = enabled
+	}
+}
+
+// WithRegister 追加创建服务时调用的注册函数，在其中注册业务的服务实现，可以多次使用。
+//
+// 参数：
+//   - register：注册函数，例如 func(s grpc.ServiceRegistrar) { pb.RegisterUserServer(s, impl) }。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithRegister(register func(grpc.ServiceRegistrar)) Option {
+	return func(o *options) {
+		if nil != register {
+			o.registers = append(o.registers, register)
+		}
+	}
+}
+
+// WithGRPCOptions 追加传给 kit/grpc 拦截器组合的配置选项，例如 kitgrpc.WithMetrics。
+//
+// 参数：
+//   - opts：kit/grpc 的配置选项。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithGRPCOptions(opts ...kitgrpc.Option) Option {
+	return func(o *options) {
+		o.grpcOptions = append(o.grpcOptions, opts...)
+	}
+}
+
+// WithServerOptions 追加 gRPC 服务端选项，例如 keepalive 参数或业务的拦截器。
+// 追加的拦截器位于 kit/grpc 的拦截器组合之内。
+//
+// 参数：
+//   - opts：gRPC 服务端选项。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithServerOptions(opts ...grpc.ServerOption) Option {
+	return func(o *options) {
+		o.serverOptions = append(o.serverOptions, opts...)
+	}
+}
+
+// WithLogger 设置记录服务启停的日志实例，同时用于 kit/grpc 的拦截器。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		name:       nameDefault,
+		addr:       addrDefault,
+		health:     healthDefault,
+		reflection: reflectionDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if "" == o.name {
+		o.name = nameDefault
+	}
+	if "" == o.addr {
+		o.addr = addrDefault
+	}
+
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
+
+// interceptorOptions 返回传给 kit/grpc 拦截器组合的配置选项，设置了日志实例时传给拦截器。
+func (o *options) interceptorOptions() []kitgrpc.Option {
+	var opts []kitgrpc.Option
+	if nil != o.logger {
+		opts = append(opts, kitgrpc.WithLogger(o.logger))
+	}
+	return append(opts, o.grpcOptions...)
+}
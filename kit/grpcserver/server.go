@@ -0,0 +1,291 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	stdsync "sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	kitgrpc "github.com/fsyyft-go/monorepo/kit/grpc"
+)
+
+var (
+	// ErrStarted 表示服务已经启动过，服务只能启动一次。
+	ErrStarted = errors.New("kit/grpcserver: 服务已经启动")
+	// ErrNotServing 表示服务尚未启动、已经停止或接受连接失败，由 Check 返回。
+	ErrNotServing = errors.New("kit/grpcserver: 服务未在运行")
+)
+
+type (
+	// Server 是预置了 kit/grpc 拦截器组合、健康检查与反射服务的 gRPC 服务。
+	// Server 实现了 kit/runtime 的 Runner 接口：Start 监听地址后在后台处理调用并立即返回，
+	// Stop 将健康状态置为 NOT_SERVING，停止接受新连接并等待正在处理的调用完成，截止时间到达时强制关闭剩余连接。
+	// Server 同时实现了 grpc.ServiceRegistrar，业务的服务实现可以在 Start 之前直接注册到 Server。
+	Server struct {
+		// o 是服务的配置。
+		o *options
+		// srv 是底层的 grpc.Server。
+		srv *grpc.Server
+		// health 是健康检查服务，未启用时为 nil。
+		health *health.Server
+		// ready 在监听成功后被关闭。
+		ready chan struct{}
+		// done 在后台的处理协程退出后被关闭。
+		done chan struct{}
+
+		// mu 保护以下字段。
+		mu stdsync.Mutex
+		// ln 是接受连接的监听器，Start 之前为 nil。
+		ln net.Listener
+		// started 表示 Start 已经成功。
+		started bool
+		// stopped 表示 Stop 已经被调用。
+		stopped bool
+		// err 是处理协程意外退出时的错误。
+		err error
+	}
+)
+
+// New 创建 gRPC 服务，并依次注册健康检查服务、反射服务与 WithRegister 的注册函数。
+// kit/grpc 的拦截器组合（指标、日志与异常恢复）位于最外层，WithServerOptions 追加的拦截器位于其内。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Server：新的服务。
+//   - error：获取 TLS 配置失败时返回错误。
+//
+// 示例：
+//
+//	srv, err := grpcserver.New(
+//	    grpcserver.WithAddr(":9090"),
+//	    grpcserver.WithRegister(func(s grpc.ServiceRegistrar) {
+//	        pb.RegisterUserServer(s, userService)
+//	    }),
+//	)
+//	if nil != err {
+//	    return err
+//	}
+//	if err := srv.Start(ctx); nil != err {
+//	    return err
+//	}
+//	defer srv.Stop(shutdownCtx)
+func New(opts ...Option) (*Server, error) {
+	o := newOptions(opts...)
+
+	serverOptions := kitgrpc.DefaultServerOptions(o.interceptorOptions()...)
+	if nil != o.tls {
+		cfg, err := o.tls.ServerConfig()
+		if nil != err {
+			return nil, fmt.Errorf("kit/grpcserver: 获取 TLS 配置失败：%w", err)
+		}
+		// credentials.NewTLS 只在自己复制的配置上设置 NextProtos，而 Provider 的 GetConfigForClient 复制的是 cfg，
+		// 需要直接在 cfg 上设置，否则设置了 CA 时无法协商 h2，客户端会拒绝连接。
+		cfg.NextProtos = []string{"h2"}
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(cfg)))
+	}
+	serverOptions = append(serverOptions, o.serverOptions...)
+
+	s := &Server{
+		o:     o,
+		srv:   grpc.NewServer(serverOptions...),
+		ready: make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	if o.health {
+		s.health = health.NewServer()
+		// 启动之前所有服务均为 NOT_SERVING，Start 成功后置为 SERVING。
+		s.health.Shutdown()
+		healthpb.RegisterHealthServer(s.srv, s.health)
+	}
+	if o.reflection {
+		reflection.Register(s.srv)
+	}
+	for _, register := range o.registers {
+		register(s)
+	}
+	return s, nil
+}
+
+// RegisterService 注册服务实现，实现了 grpc.ServiceRegistrar，必须在 Start 之前调用。
+//
+// 参数：
+//   - desc：服务描述，由 protoc-gen-go-grpc 生成。
+//   - impl：服务实现。
+func (s *Server) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	s.srv.RegisterService(desc, impl)
+}
+
+// GRPCServer 返回底层的 grpc.Server，用于调用 Server 没有包装的方法。
+//
+// 返回值：
+//   - *grpc.Server：底层的 gRPC 服务。
+func (s *Server) GRPCServer() *grpc.Server {
+	return s.srv
+}
+
+// Health 返回健康检查服务，可以用于单独设置某个服务的状态。
+//
+// 返回值：
+//   - *health.Server：健康检查服务，WithHealth(false) 时返回 nil。
+func (s *Server) Health() *health.Server {
+	return s.health
+}
+
+// Start 监听地址后在后台协程中处理调用并立即返回，监听失败时返回错误。
+// 监听成功后，健康检查服务中整体与每个已注册的服务的状态被置为 SERVING。
+// ctx 被取消时不会停止服务，停止服务需要调用 Stop。
+//
+// 参数：
+//   - ctx：提供监听操作的取消信号。
+//
+// 返回值：
+//   - error：服务已经启动过时返回 ErrStarted，监听失败时返回对应的错误。
+func (s *Server) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started || s.stopped {
+		return ErrStarted
+	}
+
+	ln := s.o.listener
+	if nil == ln {
+		var lc net.ListenConfig
+		var err error
+		if ln, err = lc.Listen(ctx, "tcp", s.o.addr); nil != err {
+			return fmt.Errorf("kit/grpcserver: 监听 %s 失败：%w", s.o.addr, err)
+		}
+	}
+	s.ln = ln
+	s.started = true
+
+	logger := s.o.getLogger().WithFields(map[string]interface{}{
+		"name": s.o.name,
+		"addr": ln.Addr().String(),
+		"tls":  nil != s.o.tls,
+	})
+	go func() {
+		defer close(s.done)
+		err := s.srv.Serve(ln)
+		if nil == err || errors.Is(err, grpc.ErrServerStopped) {
+			return
+		}
+		s.mu.Lock()
+		stopped := s.stopped
+		if !stopped {
+			s.err = err
+		}
+		s.mu.Unlock()
+		if !stopped {
+			logger.Error("kit/grpcserver: 处理调用失败：", err)
+		}
+	}()
+
+	if nil != s.health {
+		s.health.Resume()
+		for service := range s.srv.GetServiceInfo() {
+			s.health.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+		}
+	}
+	close(s.ready)
+	logger.Info("kit/grpcserver: 开始监听")
+	return nil
+}
+
+// Stop 将健康状态置为 NOT_SERVING，停止接受新连接，并等待正在处理的调用完成。
+// ctx 结束时强制关闭剩余连接并返回 ctx 的错误；服务未启动时直接返回 nil。
+//
+// 参数：
+//   - ctx：提供停止操作的截止时间。
+//
+// 返回值：
+//   - error：所有调用在截止时间前完成时返回 nil，否则返回 ctx 的错误。
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopped = true
+	started := s.started
+	s.mu.Unlock()
+	if !started {
+		return nil
+	}
+
+	if nil != s.health {
+		s.health.Shutdown()
+	}
+
+	graceful := make(chan struct{})
+	go func() {
+		defer close(graceful)
+		s.srv.GracefulStop()
+	}()
+
+	var err error
+	select {
+	case <-graceful:
+	case <-ctx.Done():
+		s.srv.Stop()
+		<-graceful
+		err = ctx.Err()
+	}
+	<-s.done
+
+	s.o.getLogger().WithField("name", s.o.name).Info("kit/grpcserver: 已停止")
+	return err
+}
+
+// Ready 返回在服务监听成功后被关闭的通道，可以用于等待 Start 在其他协程中完成。
+//
+// 返回值：
+//   - <-chan struct{}：监听成功后被关闭的通道。
+func (s *Server) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Addr 返回服务实际监听的地址，WithAddr 的端口为 0 时可以用于获取系统分配的端口。
+//
+// 返回值：
+//   - net.Addr：监听的地址，服务未启动时返回 nil。
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if nil == s.ln {
+		return nil
+	}
+	return s.ln.Addr()
+}
+
+// Check 检查服务是否正在处理调用，实现了 kit/health 的 Checker 接口，可以注册为就绪检查。
+//
+// 参数：
+//   - ctx：检查的上下文，未使用。
+//
+// 返回值：
+//   - error：服务正在处理调用时返回 nil，否则返回包装了 ErrNotServing 的错误。
+//
+// 示例：
+//
+//	h.AddReadiness("grpc", srv)
+func (s *Server) Check(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case nil != s.err:
+		return fmt.Errorf("%w：%w", ErrNotServing, s.err)
+	case !s.started || s.stopped:
+		return ErrNotServing
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package grpcserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+
+	kitgrpc "github.com/fsyyft-go/monorepo/kit/grpc"
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+	kittls "github.com/fsyyft-go/monorepo/kit/tls"
+)
+
+// testService 是测试服务的名称，复用健康检查服务的消息类型。
+const testService = "kit.test.Echo"
+
+type (
+	// echoServer 是测试服务的实现，按请求中的 service 字段决定行为：
+	// panic 时 panic，block 时等待 release 被关闭或调用被取消，其余返回 SERVING。
+	echoServer struct {
+		healthpb.UnimplementedHealthServer
+		// entered 在 block 调用开始时接收一个值。
+		entered chan struct{}
+		// release 被关闭时 block 调用返回。
+		release chan struct{}
+	}
+)
+
+// newEchoServer 创建一个新的 echoServer。
+func newEchoServer() *echoServer {
+	return &echoServer{entered: make(chan struct{}, 1), release: make(chan struct{})}
+}
+
+func (s *echoServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	switch req.GetService() {
+	case "panic":
+		panic("boom")
+	case "block":
+		s.entered <- struct{}{}
+		select {
+		case <-s.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// registerEcho 返回将 echo 注册为测试服务的注册函数。
+func registerEcho(echo *echoServer) func(grpc.ServiceRegistrar) {
+	return func(s grpc.ServiceRegistrar) {
+		desc := healthpb.Health_ServiceDesc
+		desc.ServiceName = testService
+		desc.Streams = nil
+		s.RegisterService(&desc, echo)
+	}
+}
+
+// startServer 在本机的随机端口上创建并启动服务，测试结束时停止。
+func startServer(t *testing.T, opts ...Option) *Server {
+	t.Helper()
+	opts = append([]Option{WithAddr("127.0.0.1:0"), WithGRPCOptions(kitgrpc.WithMetrics(false))}, opts...)
+	s, err := New(opts...)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(context.Background()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = s.Stop(ctx)
+	})
+	return s
+}
+
+// dial 连接到服务，测试结束时关闭连接。
+func dial(t *testing.T, addr string, opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	if 0 == len(opts) {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	conn, err := grpc.NewClient("passthrough:///"+addr, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+// echo 调用测试服务。
+func echo(ctx context.Context, conn *grpc.ClientConn, service string) error {
+	return conn.Invoke(ctx, "/"+testService+"/Check", &healthpb.HealthCheckRequest{Service: service}, &healthpb.HealthCheckResponse{})
+}
+
+// TestServer 测试启动服务、处理调用与停止服务。
+func TestServer(t *testing.T) {
+	logger := logtest.NewRecorder()
+	var intercepted []string
+	s, err := New(
+		WithAddr("127.0.0.1:0"),
+		WithName("api"),
+		WithLogger(logger),
+		WithGRPCOptions(kitgrpc.WithMetrics(false)),
+		WithRegister(registerEcho(newEchoServer())),
+		WithRegister(nil),
+		WithServerOptions(grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			intercepted = append(intercepted, info.FullMethod)
+			return handler(ctx, req)
+		})),
+	)
+	require.NoError(t, err)
+	assert.NotNil(t, s.GRPCServer())
+	require.NotNil(t, s.Health())
+
+	assert.Nil(t, s.Addr())
+	assert.ErrorIs(t, s.Check(context.Background()), ErrNotServing)
+	select {
+	case <-s.Ready():
+		t.Fatal("启动前不应就绪")
+	default:
+	}
+
+	require.NoError(t, s.Start(context.Background()))
+	<-s.Ready()
+	require.NotNil(t, s.Addr())
+	assert.NoError(t, s.Check(context.Background()))
+	assert.True(t, logger.Contains("开始监听"))
+	assert.True(t, logger.Contains("api"))
+	assert.ErrorIs(t, s.Start(context.Background()), ErrStarted)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn := dial(t, s.Addr().String())
+
+	// 整体与每个已注册的服务均为 SERVING。
+	hc := healthpb.NewHealthClient(conn)
+	for _, service := range []string{"", testService} {
+		resp, err := hc.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus(), service)
+	}
+
+	// 拦截器组合记录日志，追加的拦截器对每次调用生效：两次健康检查与一次测试调用。
+	require.NoError(t, echo(ctx, conn, ""))
+	assert.True(t, logger.Contains("grpc request"))
+	assert.Len(t, intercepted, 3)
+
+	// 异常恢复将 panic 转为 Internal。
+	assert.Equal(t, codes.Internal, status.Code(echo(ctx, conn, "panic")))
+	assert.True(t, logger.Contains("grpc handler panic"))
+
+	// 反射服务列出已注册的服务。
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	reply, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, service := range reply.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, testService)
+	assert.Contains(t, services, healthpb.Health_ServiceDesc.ServiceName)
+	require.NoError(t, stream.CloseSend())
+
+	require.NoError(t, s.Stop(context.Background()))
+	assert.ErrorIs(t, s.Check(context.Background()), ErrNotServing)
+	assert.True(t, logger.Contains("已停止"))
+	assert.ErrorIs(t, s.Start(context.Background()), ErrStarted)
+	assert.Equal(t, codes.Unavailable, status.Code(echo(ctx, conn, "")))
+}
+
+// TestServer_Stop 测试停止服务时等待正在处理的调用，截止时间到达时强制关闭连接。
+func TestServer_Stop(t *testing.T) {
+	t.Run("graceful", func(t *testing.T) {
+		e := newEchoServer()
+		s := startServer(t, WithRegister(registerEcho(e)), WithLogger(logtest.NewRecorder()))
+		conn := dial(t, s.Addr().String())
+
+		result := make(chan error, 1)
+		go func() {
+			result <- echo(context.Background(), conn, "block")
+		}()
+		<-e.entered
+
+		stopped := make(chan error, 1)
+		go func() {
+			stopped <- s.Stop(context.Background())
+		}()
+
+		// 停止期间健康状态为 NOT_SERVING。
+		require.Eventually(t, func() bool {
+			resp, err := s.Health().Check(context.Background(), &healthpb.HealthCheckRequest{})
+			return nil == err && healthpb.HealthCheckResponse_NOT_SERVING == resp.GetStatus()
+		}, time.Second, 10*time.Millisecond)
+		select {
+		case <-stopped:
+			t.Fatal("调用完成前不应停止")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(e.release)
+		assert.NoError(t, <-stopped)
+		assert.NoError(t, <-result)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		e := newEchoServer()
+		s := startServer(t, WithRegister(registerEcho(e)), WithLogger(logtest.NewRecorder()))
+		conn := dial(t, s.Addr().String())
+
+		result := make(chan error, 1)
+		go func() {
+			result <- echo(context.Background(), conn, "block")
+		}()
+		<-e.entered
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, s.Stop(ctx), context.DeadlineExceeded)
+		assert.Error(t, <-result)
+	})
+
+	t.Run("not started", func(t *testing.T) {
+		s, err := New()
+		require.NoError(t, err)
+		assert.NoError(t, s.Stop(context.Background()))
+	})
+}
+
+// TestServer_Listener 测试使用已经创建的监听器与监听失败。
+func TestServer_Listener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := startServer(t, WithListener(ln), WithRegister(registerEcho(newEchoServer())), WithLogger(logtest.NewRecorder()))
+	assert.Equal(t, ln.Addr(), s.Addr())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, echo(ctx, dial(t, ln.Addr().String()), ""))
+
+	// 地址已被占用。
+	other, err := New(WithAddr(ln.Addr().String()), WithLogger(logtest.NewRecorder()))
+	require.NoError(t, err)
+	err = other.Start(context.Background())
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrStarted)
+}
+
+// TestServer_ServeError 测试处理协程意外退出时 Check 返回错误。
+func TestServer_ServeError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	logger := logtest.NewRecorder()
+	s := startServer(t, WithListener(ln), WithLogger(logger))
+
+	// 在服务之外关闭监听器，接受连接失败。
+	_ = ln.Close()
+	assert.Eventually(t, func() bool {
+		return nil != s.Check(context.Background())
+	}, time.Second, 10*time.Millisecond)
+	assert.ErrorIs(t, s.Check(context.Background()), ErrNotServing)
+	assert.True(t, logger.Contains("处理调用失败"))
+}
+
+// TestServer_TLS 测试通过 kit/tls 提供证书。
+func TestServer_TLS(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile, caFile := ca.writeFiles(t, t.TempDir())
+	provider, err := kittls.New(kittls.WithCertificate(certFile, keyFile), kittls.WithCA(caFile))
+	require.NoError(t, err)
+
+	s := startServer(t, WithTLS(provider), WithRegister(registerEcho(newEchoServer())), WithLogger(logtest.NewRecorder()))
+	_, port, err := net.SplitHostPort(s.Addr().String())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn := dial(t, "localhost:"+port, grpc.WithTransportCredentials(credentials.NewTLS(provider.ClientConfig())))
+	assert.NoError(t, echo(ctx, conn, ""))
+
+	// 未设置证书时创建失败。
+	empty, err := kittls.New()
+	require.NoError(t, err)
+	_, err = New(WithTLS(empty))
+	assert.ErrorIs(t, err, kittls.ErrNoCertificate)
+}
+
+// TestServer_Disabled 测试不注册健康检查与反射服务。
+func TestServer_Disabled(t *testing.T) {
+	s := startServer(t, WithHealth(false), WithReflection(false), WithLogger(logtest.NewRecorder()))
+	assert.Nil(t, s.Health())
+	assert.Empty(t, s.GRPCServer().GetServiceInfo())
+}
+
+// TestNewOptions 测试配置选项的默认值与非法值。
+func TestNewOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, nameDefault, o.name)
+	assert.Equal(t, addrDefault, o.addr)
+	assert.True(t, o.health)
+	assert.True(t, o.reflection)
+	assert.Same(t, kitlog.GetLogger(), o.getLogger())
+	assert.Empty(t, o.interceptorOptions())
+
+	o = newOptions(WithName(""), WithAddr(""))
+	assert.Equal(t, nameDefault, o.name)
+	assert.Equal(t, addrDefault, o.addr)
+
+	logger := logtest.NewRecorder()
+	o = newOptions(WithLogger(logger), WithGRPCOptions(kitgrpc.WithMetrics(false)))
+	assert.Same(t, logger, o.getLogger())
+	assert.Len(t, o.interceptorOptions(), 2)
+}
+
+type (
+	// testCA 是测试使用的自签名 CA。
+	testCA struct {
+		cert *x509.Certificate
+		key  *ecdsa.PrivateKey
+		pem  []byte
+	}
+)
+
+// newTestCA 创建测试使用的自签名 CA。
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kit test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// writeFiles 签发 localhost 的证书，并在 dir 中写入证书、私钥与 CA 文件，返回它们的路径。
+func (ca *testCA) writeFiles(t *testing.T, dir string) (string, string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.WriteFile(caFile, ca.pem, 0o600))
+	return certFile, keyFile, caFile
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package hash 提供了一致性哈希环，用于在多个缓存、队列等后端之间做客户端分片。
+
+主要功能：
+
+  - 虚拟节点：每个成员在环上有多个虚拟节点，键的分布均匀
+  - 权重：虚拟节点数量与权重成正比，容量不同的后端分到相应比例的键
+  - 泛型成员：成员以名称标识并关联任意类型的值，Pick 直接返回后端的客户端
+  - 多副本：PickN 按环上的顺序返回多个不同的成员，用于多副本写入或故障后备
+  - 变更统计：Add、Remove 返回键空间中归属发生变化的比例，Shares 返回各成员所占的比例
+
+读取不加锁，成员变更时重建整个环后原子替换。
+
+基本使用：
+
+	ring := hash.NewRing[*redis.Client]()
+	ring.Add("10.0.0.1:6379", client1, 1)
+	ring.Add("10.0.0.2:6379", client2, 2)
+
+	client, ok := ring.Pick("user:42")
+
+由于包名与标准库 hash 相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "hash"
+
+	    kithash "github.com/fsyyft-go/monorepo/kit/hash"
+	)
+*/
+package hash
@@ -0,0 +1,77 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package hash
+
+import (
+	"github.com/cespare/xxhash/v2"
+)
+
+// 以下为一致性哈希环的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// replicasDefault 为权重为 1 的成员在环上的虚拟节点数量。
+	replicasDefault = 160
+	// hashFuncDefault 为默认的哈希函数。
+	hashFuncDefault = xxhash.Sum64
+)
+
+type (
+	// Option 定义了一致性哈希环的配置选项。
+	Option func(*options)
+
+	// options 包含一致性哈希环的配置。
+	options struct {
+		// replicas 是权重为 1 的成员的虚拟节点数量。
+		replicas int
+		// hashFunc 计算键与虚拟节点的哈希值。
+		hashFunc func(data []byte) uint64
+	}
+)
+
+// WithReplicas 设置权重为 1 的成员在环上的虚拟节点数量，成员的虚拟节点数量为该值乘以权重。
+// 虚拟节点越多，键的分布越均匀，内存占用与成员变更的开销也越大。
+//
+// 参数：
+//   - replicas：虚拟节点数量，默认为 160，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithReplicas(replicas int) Option {
+	return func(o *options) {
+		o.replicas = replicas
+	}
+}
+
+// WithHashFunc 设置哈希函数，同一组客户端必须使用相同的哈希函数才能得到相同的分片结果。
+//
+// 参数：
+//   - fn：哈希函数，默认为 xxhash 的 Sum64，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithHashFunc(fn func(data []byte) uint64) Option {
+	return func(o *options) {
+		o.hashFunc = fn
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		replicas: replicasDefault,
+		hashFunc: hashFuncDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.replicas < 1 {
+		o.replicas = replicasDefault
+	}
+	if nil == o.hashFunc {
+		o.hashFunc = hashFuncDefault
+	}
+	return o
+}
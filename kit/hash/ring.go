@@ -0,0 +1,302 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package hash
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	stdsync "sync"
+	"sync/atomic"
+
+	kitstrings "github.com/fsyyft-go/monorepo/kit/strings"
+)
+
+var (
+	// ErrInvalidWeight 表示成员的权重小于 1。
+	ErrInvalidWeight = errors.New("kit/hash: 成员权重必须大于 0")
+)
+
+type (
+	// Ring 是带虚拟节点与权重的一致性哈希环，用于在多个缓存、队列等后端之间做客户端分片。
+	// 成员以名称标识，名称决定虚拟节点在环上的位置，因此使用相同成员名称、权重与配置的客户端得到相同的分片结果；
+	// 成员变更时只有约 1/N 的键改变归属。
+	//
+	// Pick 等读取方法不加锁，可以被多个协程高并发调用；Add、Remove 重建整个环后原子替换，适用于成员变更不频繁的场景。
+	// T 是成员关联的值，例如后端的客户端。
+	Ring[T any] struct {
+		// opts 是哈希环的配置。
+		opts *options
+		// mu 串行化成员变更。
+		mu stdsync.Mutex
+		// state 是当前的环，变更时整体替换。
+		state atomic.Pointer[ringState[T]]
+	}
+
+	// Stats 描述一次成员变更的结果。
+	Stats struct {
+		// Members 是变更后的成员数量。
+		Members int
+		// VirtualNodes 是变更后环上的虚拟节点数量。
+		VirtualNodes int
+		// Moved 是键空间中归属发生变化的比例，取值为 0 到 1，可以据此估算需要迁移或失效的缓存。
+		Moved float64
+	}
+
+	// ringState 是不可变的环。
+	ringState[T any] struct {
+		// points 是按哈希值排序的虚拟节点。
+		points []point
+		// members 是按名称排序的成员。
+		members []member[T]
+	}
+
+	// member 是环上的成员。
+	member[T any] struct {
+		// name 是成员名称。
+		name string
+		// value 是成员关联的值。
+		value T
+		// weight 是成员的权重。
+		weight int
+	}
+
+	// point 是环上的虚拟节点。
+	point struct {
+		// hash 是虚拟节点的哈希值。
+		hash uint64
+		// member 是成员在 ringState.members 中的下标。
+		member int
+	}
+)
+
+// NewRing 创建空的一致性哈希环。
+//
+// 参数：
+//   - opts：配置选项，参见 WithReplicas 与 WithHashFunc。
+//
+// 返回值：
+//   - *Ring[T]：创建的哈希环。
+//
+// 示例：
+//
+//	ring := hash.NewRing[*redis.Client]()
+//	ring.Add("cache-1:6379", client1, 1)
+//	ring.Add("cache-2:6379", client2, 2)
+//
+//	client, ok := ring.Pick("user:42")
+func NewRing[T any](opts ...Option) *Ring[T] {
+	r := &Ring[T]{opts: newOptions(opts...)}
+	r.state.Store(&ringState[T]{})
+	return r
+}
+
+// Add 添加成员，已存在的同名成员会被替换，可以用于调整权重或替换关联的值。
+//
+// 参数：
+//   - name：成员名称，通常为后端地址，决定虚拟节点在环上的位置。
+//   - value：成员关联的值，由 Pick 返回。
+//   - weight：成员的权重，虚拟节点数量与权重成正比，分到的键也与权重成正比。
+//
+// 返回值：
+//   - Stats：变更的结果。
+//   - error：权重小于 1 时返回包装 ErrInvalidWeight 的错误。
+func (r *Ring[T]) Add(name string, value T, weight int) (Stats, error) {
+	if weight < 1 {
+		return Stats{}, fmt.Errorf("%w：成员 %s 的权重为 %d", ErrInvalidWeight, name, weight)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old := r.state.Load()
+	members := slices.DeleteFunc(slices.Clone(old.members), func(m member[T]) bool { return name == m.name })
+	members = append(members, member[T]{name: name, value: value, weight: weight})
+	return r.replace(old, members), nil
+}
+
+// Remove 移除成员。
+//
+// 参数：
+//   - name：成员名称。
+//
+// 返回值：
+//   - Stats：变更的结果。
+//   - bool：成员不存在时返回 false。
+func (r *Ring[T]) Remove(name string) (Stats, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old := r.state.Load()
+	members := slices.DeleteFunc(slices.Clone(old.members), func(m member[T]) bool { return name == m.name })
+	if len(members) == len(old.members) {
+		return Stats{Members: len(old.members), VirtualNodes: len(old.points)}, false
+	}
+	return r.replace(old, members), true
+}
+
+// Pick 返回键所属的成员关联的值。
+//
+// 参数：
+//   - key：键，例如缓存键或消息的分区键。
+//
+// 返回值：
+//   - T：成员关联的值。
+//   - bool：环为空时返回 false。
+func (r *Ring[T]) Pick(key string) (T, bool) {
+	s := r.state.Load()
+	if 0 == len(s.points) {
+		var zero T
+		return zero, false
+	}
+	return s.members[s.points[s.search(r.hash(key))].member].value, true
+}
+
+// PickN 返回键所属的前 n 个不同成员关联的值，按环上的顺序排列，用于多副本写入或故障时的后备选择。
+//
+// 参数：
+//   - key：键。
+//   - n：成员数量，超过成员总数时返回全部成员。
+//
+// 返回值：
+//   - []T：成员关联的值，第一个与 Pick 的结果相同；环为空或 n 小于 1 时返回 nil。
+func (r *Ring[T]) PickN(key string, n int) []T {
+	s := r.state.Load()
+	n = min(n, len(s.members))
+	if n < 1 {
+		return nil
+	}
+
+	values := make([]T, 0, n)
+	seen := make([]bool, len(s.members))
+	for i, start := 0, s.search(r.hash(key)); len(values) < n; i++ {
+		p := s.points[(start+i)%len(s.points)]
+		if !seen[p.member] {
+			seen[p.member] = true
+			values = append(values, s.members[p.member].value)
+		}
+	}
+	return values
+}
+
+// Members 返回按名称排序的成员名称。
+//
+// 返回值：
+//   - []string：成员名称。
+func (r *Ring[T]) Members() []string {
+	s := r.state.Load()
+	names := make([]string, len(s.members))
+	for i, m := range s.members {
+		names[i] = m.name
+	}
+	return names
+}
+
+// Len 返回成员数量。
+//
+// 返回值：
+//   - int：成员数量。
+func (r *Ring[T]) Len() int {
+	return len(r.state.Load().members)
+}
+
+// Shares 返回每个成员在键空间中所占的比例，用于检查分布是否与权重相符。
+//
+// 返回值：
+//   - map[string]float64：成员名称到比例的映射，比例之和为 1；环为空时返回空映射。
+func (r *Ring[T]) Shares() map[string]float64 {
+	s := r.state.Load()
+	shares := make(map[string]float64, len(s.members))
+	for i, p := range s.points {
+		prev := s.points[(i+len(s.points)-1)%len(s.points)]
+		shares[s.members[p.member].name] += arcFraction(prev.hash, p.hash, len(s.points))
+	}
+	return shares
+}
+
+// hash 计算键的哈希值，键不会被复制。
+func (r *Ring[T]) hash(key string) uint64 {
+	return r.opts.hashFunc(kitstrings.ToBytes(key))
+}
+
+// replace 以新的成员重建环并替换当前的环，返回变更的结果。
+func (r *Ring[T]) replace(old *ringState[T], members []member[T]) Stats {
+	slices.SortFunc(members, func(a, b member[T]) int { return cmp.Compare(a.name, b.name) })
+
+	s := &ringState[T]{members: members}
+	buf := make([]byte, 0, 64)
+	for i, m := range members {
+		for j := 0; j < r.opts.replicas*m.weight; j++ {
+			buf = strconv.AppendInt(append(append(buf[:0], m.name...), '#'), int64(j), 10)
+			s.points = append(s.points, point{hash: r.opts.hashFunc(buf), member: i})
+		}
+	}
+	// 哈希值相同时按成员名称排序，保证不同客户端的结果一致。
+	slices.SortFunc(s.points, func(a, b point) int {
+		return cmp.Or(cmp.Compare(a.hash, b.hash), cmp.Compare(a.member, b.member))
+	})
+
+	r.state.Store(s)
+	return Stats{Members: len(s.members), VirtualNodes: len(s.points), Moved: moved(old, s)}
+}
+
+// search 返回哈希值所属的虚拟节点的下标：顺时针方向的第一个虚拟节点。
+func (s *ringState[T]) search(h uint64) int {
+	i, _ := slices.BinarySearchFunc(s.points, h, func(p point, h uint64) int { return cmp.Compare(p.hash, h) })
+	if i == len(s.points) {
+		return 0
+	}
+	return i
+}
+
+// owner 返回哈希值所属的成员名称，环为空时返回 false。
+func (s *ringState[T]) owner(h uint64) (string, bool) {
+	if 0 == len(s.points) {
+		return "", false
+	}
+	return s.members[s.points[s.search(h)].member].name, true
+}
+
+// moved 返回从 a 变为 b 时键空间中归属发生变化的比例。
+// 两个环的虚拟节点把键空间分成若干段，每一段在两个环中各自只属于一个成员，逐段比较即可。
+func moved[T any](a, b *ringState[T]) float64 {
+	if 0 == len(a.points) || 0 == len(b.points) {
+		if len(a.points) == len(b.points) {
+			return 0
+		}
+		return 1
+	}
+
+	bounds := make([]uint64, 0, len(a.points)+len(b.points))
+	for _, p := range a.points {
+		bounds = append(bounds, p.hash)
+	}
+	for _, p := range b.points {
+		bounds = append(bounds, p.hash)
+	}
+	slices.Sort(bounds)
+	bounds = slices.Compact(bounds)
+
+	var total float64
+	for i, h := range bounds {
+		ownerA, okA := a.owner(h)
+		ownerB, okB := b.owner(h)
+		if okA != okB || ownerA != ownerB {
+			total += arcFraction(bounds[(i+len(bounds)-1)%len(bounds)], h, len(bounds))
+		}
+	}
+	return min(total, 1)
+}
+
+// arcFraction 返回环上从 from（不含）到 to（含）的弧占整个键空间的比例，环上只有一个点时为整个键空间。
+func arcFraction(from, to uint64, points int) float64 {
+	if 1 == points {
+		return 1
+	}
+	return float64(to-from) / (math.MaxUint64 + 1.0)
+}
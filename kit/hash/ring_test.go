@@ -0,0 +1,175 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package hash
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countKeys 返回 n 个键在各成员上的分布。
+func countKeys(r *Ring[string], n int) map[string]int {
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		v, _ := r.Pick("key-" + strconv.Itoa(i))
+		counts[v]++
+	}
+	return counts
+}
+
+// TestRing 测试成员的添加、移除与键的分布。
+func TestRing(t *testing.T) {
+	r := NewRing[string]()
+	_, ok := r.Pick("k")
+	assert.False(t, ok)
+	assert.Nil(t, r.PickN("k", 2))
+	assert.Empty(t, r.Shares())
+
+	stats, err := r.Add("a", "A", 1)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Members: 1, VirtualNodes: 160, Moved: 1}, stats)
+	v, ok := r.Pick("k")
+	assert.True(t, ok)
+	assert.Equal(t, "A", v)
+
+	_, err = r.Add("b", "B", 1)
+	require.NoError(t, err)
+	stats, err = r.Add("c", "C", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Members)
+	assert.Equal(t, 480, stats.VirtualNodes)
+	assert.InDelta(t, 1.0/3, stats.Moved, 0.08, "新成员分走约 1/3 的键")
+	assert.Equal(t, []string{"a", "b", "c"}, r.Members())
+	assert.Equal(t, 3, r.Len())
+
+	counts := countKeys(r, 30000)
+	for _, name := range []string{"A", "B", "C"} {
+		assert.InDelta(t, 10000, counts[name], 1500, name)
+	}
+
+	// 移除成员时只有该成员的键改变归属。
+	before := make(map[string]string)
+	for i := 0; i < 3000; i++ {
+		key := "key-" + strconv.Itoa(i)
+		before[key], _ = r.Pick(key)
+	}
+	stats, ok = r.Remove("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, stats.Members)
+	for key, owner := range before {
+		now, _ := r.Pick(key)
+		if "B" != owner {
+			assert.Equal(t, owner, now, key)
+		} else {
+			assert.NotEqual(t, "B", now, key)
+		}
+	}
+
+	stats, ok = r.Remove("missing")
+	assert.False(t, ok)
+	assert.Zero(t, stats.Moved)
+
+	stats, _ = r.Remove("a")
+	stats, _ = r.Remove("c")
+	assert.Equal(t, Stats{Moved: 1}, stats)
+}
+
+// TestRing_Weight 测试权重与成员替换。
+func TestRing_Weight(t *testing.T) {
+	r := NewRing[string](WithReplicas(200))
+	_, _ = r.Add("small", "S", 1)
+	stats, err := r.Add("large", "L", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 800, stats.VirtualNodes)
+
+	shares := r.Shares()
+	assert.InDelta(t, 1.0, shares["small"]+shares["large"], 1e-9)
+	assert.InDelta(t, 0.75, shares["large"], 0.05)
+	counts := countKeys(r, 20000)
+	assert.InDelta(t, 15000, counts["L"], 1500)
+
+	// 调整权重只移动新增虚拟节点覆盖的键。
+	stats, err = r.Add("small", "S2", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Members)
+	assert.InDelta(t, 0.25, stats.Moved, 0.06)
+	v, _ := r.Pick("key-1")
+	assert.Contains(t, []string{"S2", "L"}, v, "关联的值被替换")
+
+	_, err = r.Add("zero", "Z", 0)
+	assert.ErrorIs(t, err, ErrInvalidWeight)
+}
+
+// TestRing_PickN 测试多副本的选择。
+func TestRing_PickN(t *testing.T) {
+	r := NewRing[string]()
+	for _, name := range []string{"a", "b", "c"} {
+		_, _ = r.Add(name, name, 1)
+	}
+
+	for i := 0; i < 100; i++ {
+		key := "key-" + strconv.Itoa(i)
+		first, _ := r.Pick(key)
+		two := r.PickN(key, 2)
+		require.Len(t, two, 2)
+		assert.Equal(t, first, two[0])
+		assert.NotEqual(t, two[0], two[1])
+		assert.ElementsMatch(t, []string{"a", "b", "c"}, r.PickN(key, 5))
+	}
+	assert.Nil(t, r.PickN("k", 0))
+}
+
+// TestRing_Deterministic 测试相同配置的环得到相同的结果。
+func TestRing_Deterministic(t *testing.T) {
+	build := func(order []string) *Ring[string] {
+		r := NewRing[string](WithReplicas(0), WithHashFunc(nil))
+		for _, name := range order {
+			_, _ = r.Add(name, name, 1)
+		}
+		return r
+	}
+	r1, r2 := build([]string{"a", "b", "c"}), build([]string{"c", "a", "b"})
+	for i := 0; i < 1000; i++ {
+		key := "key-" + strconv.Itoa(i)
+		v1, _ := r1.Pick(key)
+		v2, _ := r2.Pick(key)
+		assert.Equal(t, v1, v2, key)
+	}
+
+	// 哈希值全部相同时按成员名称决定归属。
+	constant := NewRing[string](WithReplicas(1), WithHashFunc(func([]byte) uint64 { return 42 }))
+	_, _ = constant.Add("b", "b", 1)
+	_, _ = constant.Add("a", "a", 1)
+	v, _ := constant.Pick("k")
+	assert.Equal(t, "a", v)
+}
+
+// TestRing_Concurrent 测试并发的读取与变更。
+func TestRing_Concurrent(t *testing.T) {
+	r := NewRing[int](WithReplicas(10))
+	_, _ = r.Add("0", 0, 1)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_, _ = r.Add(strconv.Itoa(i), i, 1)
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				_, ok := r.Pick(strconv.Itoa(j))
+				assert.True(t, ok)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 5, r.Len())
+}
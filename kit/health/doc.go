@@ -0,0 +1,29 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package health 提供了存活检查与就绪检查的聚合器，以及输出 JSON 结果的 /healthz 与 /readyz 处理函数。
+
+主要功能：
+
+  - 检查聚合：Health 分别注册存活检查与就绪检查，并发执行并按注册顺序汇总结果
+  - 超时保护：每项检查单独计算超时时间，超时或发生 panic 的检查视为失败
+  - 就绪门：Gate 由组件在启动完成后打开、开始退出时关闭，使负载均衡先于组件停止摘除流量
+  - HTTP 处理函数：所有检查通过时返回 200，否则返回 503；默认只输出总体状态，携带 verbose 查询参数时输出每项检查的详情
+
+基本使用：
+
+	h := health.New(health.WithTimeout(2 * time.Second))
+	h.AddReadiness("db", health.CheckFunc(func(ctx context.Context) error {
+	    return db.PingContext(ctx)
+	}))
+	gate := h.Gate("http")
+
+	mux := http.NewServeMux()
+	h.Register(mux)
+
+	// 组件启动完成后打开就绪门。
+	gate.Open()
+*/
+package health
@@ -0,0 +1,70 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+var (
+	// ErrGateClosed 表示就绪门处于关闭状态。
+	ErrGateClosed = errors.New("kit/health: 就绪门未打开")
+)
+
+type (
+	// Gate 是由组件显式控制的就绪检查。
+	// 门创建时处于关闭状态，组件完成启动后调用 Open，开始退出时调用 Close，
+	// 使负载均衡在组件真正停止之前摘除流量。
+	Gate struct {
+		// open 表示门是否打开。
+		open atomic.Bool
+	}
+)
+
+// Gate 创建一个就绪门并注册为就绪检查。
+//
+// 参数：
+//   - name：检查的名称，通常为控制该门的组件名称。
+//
+// 返回值：
+//   - *Gate：处于关闭状态的就绪门。
+//
+// 示例：
+//
+//	gate := h.Gate("http")
+//	if err := server.Start(ctx); nil != err {
+//	    return err
+//	}
+//	gate.Open()
+func (h *Health) Gate(name string) *Gate {
+	g := &Gate{}
+	h.AddReadiness(name, g)
+	return g
+}
+
+// Open 打开就绪门，对应的就绪检查开始通过。
+func (g *Gate) Open() {
+	g.open.Store(true)
+}
+
+// Close 关闭就绪门，对应的就绪检查开始失败。
+func (g *Gate) Close() {
+	g.open.Store(false)
+}
+
+// IsOpen 返回就绪门是否打开。
+func (g *Gate) IsOpen() bool {
+	return g.open.Load()
+}
+
+// Check 在就绪门关闭时返回 ErrGateClosed。
+func (g *Gate) Check(_ context.Context) error {
+	if !g.IsOpen() {
+		return ErrGateClosed
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// PathLiveness 是存活检查处理函数的默认路径。
+	PathLiveness = "/healthz"
+	// PathReadiness 是就绪检查处理函数的默认路径。
+	PathReadiness = "/readyz"
+
+	// queryVerbose 是要求输出检查详情的查询参数。
+	queryVerbose = "verbose"
+)
+
+type (
+	// response 是处理函数输出的 JSON 结构。
+	response struct {
+		// Status 是总体状态。
+		Status Status `json:"status"`
+		// Checks 是各项检查的详情，仅在详细模式下输出。
+		Checks []checkResponse `json:"checks,omitempty"`
+	}
+
+	// checkResponse 是单项检查的 JSON 结构。
+	checkResponse struct {
+		// Name 是检查的名称。
+		Name string `json:"name"`
+		// Status 是检查的状态。
+		Status Status `json:"status"`
+		// Error 是检查失败的原因。
+		Error string `json:"error,omitempty"`
+		// DurationMs 是检查的耗时，单位为毫秒。
+		DurationMs float64 `json:"duration_ms"`
+	}
+)
+
+// LivenessHandler 返回执行存活检查的处理函数。
+// 所有检查通过时返回 200，否则返回 503。
+//
+// 返回值：
+//   - http.Handler：存活检查处理函数。
+func (h *Health) LivenessHandler() http.Handler {
+	return h.handler(h.CheckLiveness)
+}
+
+// ReadinessHandler 返回执行就绪检查的处理函数。
+// 所有检查通过时返回 200，否则返回 503。
+//
+// 返回值：
+//   - http.Handler：就绪检查处理函数。
+func (h *Health) ReadinessHandler() http.Handler {
+	return h.handler(h.CheckReadiness)
+}
+
+// Register 将存活检查与就绪检查处理函数分别注册到 PathLiveness 与 PathReadiness。
+//
+// 参数：
+//   - mux：注册处理函数的路由。
+func (h *Health) Register(mux *http.ServeMux) {
+	mux.Handle(PathLiveness, h.LivenessHandler())
+	mux.Handle(PathReadiness, h.ReadinessHandler())
+}
+
+// handler 执行检查并以 JSON 输出结果。
+// 请求携带 verbose 查询参数时输出每项检查的详情，verbose=false 时只输出总体状态。
+func (h *Health) handler(run func(ctx context.Context) Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := run(r.Context())
+
+		resp := response{Status: report.Status}
+		if h.verbose(r) {
+			resp.Checks = make([]checkResponse, 0, len(report.Results))
+			for _, result := range report.Results {
+				c := checkResponse{
+					Name:       result.Name,
+					Status:     result.Status,
+					DurationMs: float64(result.Duration.Microseconds()) / 1000,
+				}
+				if nil != result.Err {
+					c.Error = result.Err.Error()
+				}
+				resp.Checks = append(resp.Checks, c)
+			}
+		}
+
+		code := http.StatusOK
+		if !report.OK() {
+			code = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// verbose 返回请求是否需要输出检查详情。
+func (h *Health) verbose(r *http.Request) bool {
+	query := r.URL.Query()
+	if !query.Has(queryVerbose) {
+		return h.opts.verbose
+	}
+	value := query.Get(queryVerbose)
+	if "" == value {
+		return true
+	}
+	verbose, err := strconv.ParseBool(value)
+	if nil != err {
+		return true
+	}
+	return verbose
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// serve 向路由发送请求并解析响应。
+func serve(t *testing.T, handler http.Handler, target string) (int, map[string]interface{}) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	body := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return rec.Code, body
+}
+
+// TestHandler 测试处理函数的状态码与简洁、详细两种输出模式。
+func TestHandler(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Unix(0, 0))
+	h := New(WithClock(clock))
+	h.AddLiveness("self", CheckFunc(func(context.Context) error { return nil }))
+	h.AddReadiness("db", CheckFunc(func(context.Context) error {
+		clock.Advance(1500 * time.Microsecond)
+		return errors.New("connection refused")
+	}))
+	mux := http.NewServeMux()
+	h.Register(mux)
+
+	code, body := serve(t, mux, PathLiveness)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]interface{}{"status": "ok"}, body)
+
+	code, body = serve(t, mux, PathReadiness)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, map[string]interface{}{"status": "fail"}, body)
+
+	code, body = serve(t, mux, PathReadiness+"?verbose")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, map[string]interface{}{
+		"status": "fail",
+		"checks": []interface{}{
+			map[string]interface{}{
+				"name":        "db",
+				"status":      "fail",
+				"error":       "connection refused",
+				"duration_ms": 1.5,
+			},
+		},
+	}, body)
+
+	_, body = serve(t, mux, PathLiveness+"?verbose=1")
+	assert.Len(t, body["checks"], 1)
+	_, body = serve(t, mux, PathLiveness+"?verbose=x")
+	assert.Len(t, body["checks"], 1)
+}
+
+// TestHandler_Verbose 测试默认输出详情时可以通过查询参数关闭。
+func TestHandler_Verbose(t *testing.T) {
+	h := New(WithVerbose(true))
+	h.AddLiveness("self", CheckFunc(func(context.Context) error { return nil }))
+
+	_, body := serve(t, h.LivenessHandler(), PathLiveness)
+	assert.Len(t, body["checks"], 1)
+
+	_, body = serve(t, h.LivenessHandler(), PathLiveness+"?verbose=false")
+	assert.NotContains(t, body, "checks")
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// StatusOK 表示检查通过。
+	StatusOK Status = "ok"
+	// StatusFail 表示检查失败。
+	StatusFail Status = "fail"
+)
+
+type (
+	// Status 表示检查的状态。
+	Status string
+
+	// Checker 定义了单项健康检查。
+	Checker interface {
+		// Check 执行检查，返回 nil 表示检查通过。
+		// 上下文在超时时间到达时取消，实现应当及时返回。
+		Check(ctx context.Context) error
+	}
+
+	// CheckFunc 将普通函数适配为 Checker。
+	CheckFunc func(ctx context.Context) error
+
+	// Result 表示单项检查的结果。
+	Result struct {
+		// Name 是检查的名称。
+		Name string
+		// Status 是检查的状态。
+		Status Status
+		// Err 是检查失败的原因，检查通过时为 nil。
+		Err error
+		// Duration 是检查的耗时。
+		Duration time.Duration
+	}
+
+	// Report 表示一组检查的汇总结果。
+	Report struct {
+		// Status 是总体状态，所有检查通过时为 StatusOK。
+		Status Status
+		// Results 是各项检查的结果，按注册顺序排列。
+		Results []Result
+	}
+
+	// Health 聚合存活检查与就绪检查。
+	// 存活检查失败表示进程需要重启，就绪检查失败表示进程暂时不能接收流量。
+	Health struct {
+		// opts 是健康检查的配置。
+		opts *options
+		// mu 保护检查列表。
+		mu sync.RWMutex
+		// liveness 是注册的存活检查。
+		liveness []check
+		// readiness 是注册的就绪检查。
+		readiness []check
+	}
+
+	// check 是带有名称的检查。
+	check struct {
+		// name 是检查的名称。
+		name string
+		// checker 是检查的实现。
+		checker Checker
+	}
+)
+
+// Check 调用函数本身。
+func (f CheckFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// OK 返回所有检查是否都已通过。
+func (r Report) OK() bool {
+	return StatusOK == r.Status
+}
+
+// New 创建一个健康检查聚合器。
+//
+// 参数：
+//   - opts：配置选项，参见 WithTimeout、WithVerbose 与 WithClock。
+//
+// 返回值：
+//   - *Health：健康检查聚合器。
+func New(opts ...Option) *Health {
+	return &Health{
+		opts: newOptions(opts...),
+	}
+}
+
+// AddLiveness 注册一项存活检查。
+// 存活检查应只检查进程自身的状态，不应依赖外部服务，避免外部服务故障导致进程被反复重启。
+//
+// 参数：
+//   - name：检查的名称，出现在检查结果中。
+//   - checker：检查的实现。
+func (h *Health) AddLiveness(name string, checker Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.liveness = append(h.liveness, check{name: name, checker: checker})
+}
+
+// AddReadiness 注册一项就绪检查。
+// 就绪检查可以检查数据库等依赖的外部服务，失败时进程被暂时移出负载均衡。
+//
+// 参数：
+//   - name：检查的名称，出现在检查结果中。
+//   - checker：检查的实现。
+func (h *Health) AddReadiness(name string, checker Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.readiness = append(h.readiness, check{name: name, checker: checker})
+}
+
+// CheckLiveness 并发执行所有存活检查并汇总结果。
+//
+// 参数：
+//   - ctx：上下文，取消时未完成的检查视为失败。
+//
+// 返回值：
+//   - Report：汇总结果，没有注册任何检查时状态为 StatusOK。
+func (h *Health) CheckLiveness(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := h.liveness
+	h.mu.RUnlock()
+	return h.run(ctx, checks)
+}
+
+// CheckReadiness 并发执行所有就绪检查并汇总结果。
+//
+// 参数：
+//   - ctx：上下文，取消时未完成的检查视为失败。
+//
+// 返回值：
+//   - Report：汇总结果，没有注册任何检查时状态为 StatusOK。
+func (h *Health) CheckReadiness(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := h.readiness
+	h.mu.RUnlock()
+	return h.run(ctx, checks)
+}
+
+// run 并发执行检查，每项检查单独计算超时时间。
+func (h *Health) run(ctx context.Context, checks []check) Report {
+	report := Report{
+		Status:  StatusOK,
+		Results: make([]Result, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Results[i] = h.runOne(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Results {
+		if StatusOK != result.Status {
+			report.Status = StatusFail
+			break
+		}
+	}
+	return report
+}
+
+// runOne 执行单项检查。检查在超时后仍未返回时不再等待，检查协程在返回后自行退出。
+func (h *Health) runOne(ctx context.Context, c check) Result {
+	ctx, cancel := context.WithTimeout(ctx, h.opts.timeout)
+	defer cancel()
+
+	start := h.opts.clock.Now()
+	// 使用带缓冲的通道，超时后检查协程仍然可以写入并退出。
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); nil != r {
+				done <- fmt.Errorf("kit/health: 检查 %s 发生 panic：%v", c.name, r)
+			}
+		}()
+		done <- c.checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("kit/health: 检查 %s 未完成：%w", c.name, ctx.Err())
+	}
+
+	result := Result{
+		Name:     c.name,
+		Status:   StatusOK,
+		Err:      err,
+		Duration: h.opts.clock.Since(start),
+	}
+	if nil != err {
+		result.Status = StatusFail
+	}
+	return result
+}
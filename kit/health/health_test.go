@@ -0,0 +1,102 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHealth_Check 测试检查结果按注册顺序汇总，任一检查失败时总体状态为失败。
+func TestHealth_Check(t *testing.T) {
+	h := New()
+	report := h.CheckLiveness(context.Background())
+	assert.True(t, report.OK())
+	assert.Empty(t, report.Results)
+
+	h.AddLiveness("self", CheckFunc(func(context.Context) error { return nil }))
+	h.AddReadiness("db", CheckFunc(func(context.Context) error { return nil }))
+	h.AddReadiness("cache", CheckFunc(func(context.Context) error { return errors.New("down") }))
+
+	report = h.CheckLiveness(context.Background())
+	assert.True(t, report.OK())
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, "self", report.Results[0].Name)
+
+	report = h.CheckReadiness(context.Background())
+	assert.False(t, report.OK())
+	assert.Equal(t, StatusFail, report.Status)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, "db", report.Results[0].Name)
+	assert.Equal(t, StatusOK, report.Results[0].Status)
+	assert.NoError(t, report.Results[0].Err)
+	assert.Equal(t, "cache", report.Results[1].Name)
+	assert.Equal(t, StatusFail, report.Results[1].Status)
+	assert.EqualError(t, report.Results[1].Err, "down")
+}
+
+// TestHealth_Timeout 测试超时的检查视为失败，且不等待检查返回。
+func TestHealth_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	h := New(WithTimeout(20 * time.Millisecond))
+	h.AddReadiness("slow", CheckFunc(func(context.Context) error {
+		<-release
+		return nil
+	}))
+
+	start := time.Now()
+	report := h.CheckReadiness(context.Background())
+	assert.Less(t, time.Since(start), time.Second)
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, StatusFail, report.Results[0].Status)
+	assert.ErrorIs(t, report.Results[0].Err, context.DeadlineExceeded)
+	assert.GreaterOrEqual(t, report.Results[0].Duration, 20*time.Millisecond)
+}
+
+// TestHealth_Panic 测试发生 panic 的检查视为失败。
+func TestHealth_Panic(t *testing.T) {
+	h := New()
+	h.AddLiveness("panic", CheckFunc(func(context.Context) error { panic("boom") }))
+
+	report := h.CheckLiveness(context.Background())
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, StatusFail, report.Results[0].Status)
+	assert.ErrorContains(t, report.Results[0].Err, "boom")
+}
+
+// TestGate 测试就绪门的打开与关闭。
+func TestGate(t *testing.T) {
+	h := New()
+	gate := h.Gate("http")
+	assert.False(t, gate.IsOpen())
+
+	report := h.CheckReadiness(context.Background())
+	assert.False(t, report.OK())
+	assert.ErrorIs(t, report.Results[0].Err, ErrGateClosed)
+
+	gate.Open()
+	assert.True(t, h.CheckReadiness(context.Background()).OK())
+
+	gate.Close()
+	assert.False(t, h.CheckReadiness(context.Background()).OK())
+	// 就绪门不影响存活检查。
+	assert.True(t, h.CheckLiveness(context.Background()).OK())
+}
+
+// TestNewOptions 测试非法的参数使用默认值。
+func TestNewOptions(t *testing.T) {
+	o := newOptions(WithTimeout(0), WithClock(nil))
+	assert.Equal(t, timeoutDefault, o.timeout)
+	assert.NotNil(t, o.clock)
+	assert.False(t, o.verbose)
+	assert.True(t, newOptions(WithVerbose(true)).verbose)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package health
+
+import (
+	"time"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 以下为健康检查的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// timeoutDefault 为单个检查的默认超时时间。
+	timeoutDefault = 3 * time.Second
+	// verboseDefault 为处理函数是否默认输出每个检查的详情。
+	verboseDefault = false
+	// clockDefault 为计算检查耗时使用的时钟。
+	clockDefault = kittime.NewRealClock()
+)
+
+type (
+	// Option 定义了健康检查的配置选项。
+	Option func(*options)
+
+	// options 包含健康检查的配置。
+	options struct {
+		// timeout 是单个检查的超时时间。
+		timeout time.Duration
+		// verbose 表示处理函数是否默认输出每个检查的详情。
+		verbose bool
+		// clock 是计算检查耗时使用的时钟。
+		clock kittime.Clock
+	}
+)
+
+// WithTimeout 设置单个检查的超时时间，超时的检查视为失败。
+//
+// 参数：
+//   - timeout：超时时间，默认为 3 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithVerbose 设置处理函数是否默认输出每个检查的详情。
+// 默认只输出总体状态，请求携带 verbose 查询参数时输出详情。
+//
+// 参数：
+//   - verbose：是否默认输出详情，默认为 false。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithVerbose(verbose bool) Option {
+	return func(o *options) {
+		o.verbose = verbose
+	}
+}
+
+// WithClock 设置计算检查耗时使用的时钟。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		timeout: timeoutDefault,
+		verbose: verboseDefault,
+		clock:   clockDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.timeout <= 0 {
+		o.timeout = timeoutDefault
+	}
+	o.clock = kittime.OrReal(o.clock)
+	return o
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	stdhttp "net/http"
+
+	kitid "github.com/fsyyft-go/monorepo/kit/id"
+	kitip "github.com/fsyyft-go/monorepo/kit/ip"
+)
+
+// AccessLog 返回记录访问日志的中间件。
+// 每个请求处理完成后记录一条日志，包含方法、路径、状态码、响应字节数、耗时、客户端地址与 User-Agent，
+// 请求携带请求 ID 时一并记录，经过 RealIP 时记录解析出的客户端地址 client_ip。状态码为 5xx 时以错误级别记录，其余以信息级别记录。
+//
+// 参数：
+//   - opts：配置选项，支持 WithLogger 与 WithClock。
+//
+// 返回值：
+//   - Middleware：记录访问日志的中间件。
+//
+// 示例：
+//
+//	handler := http.AccessLog(http.WithLogger(logger))(mux)
+func AccessLog(opts ...Option) Middleware {
+	o := newOptions(opts...)
+
+	return func(next stdhttp.Handler) stdhttp.Handler {
+		return stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			start := o.clock.Now()
+			rr := recordResponse(w)
+			next.ServeHTTP(rr, r)
+
+			status := rr.status
+			// 处理函数没有写入任何内容时，net/http 会返回 200。
+			if 0 == status {
+				status = stdhttp.StatusOK
+			}
+			fields := map[string]interface{}{
+				"method":      r.Method,
+				"path":        r.URL.Path,
+				"status":      status,
+				"bytes":       rr.bytes,
+				"duration_ms": o.clock.Since(start).Milliseconds(),
+				"remote_addr": r.RemoteAddr,
+				"user_agent":  r.UserAgent(),
+			}
+			if requestID := requestIDOf(r); "" != requestID {
+				fields[kitid.LogFieldRequestID] = requestID
+			}
+			if addr, ok := kitip.FromContext(r.Context()); ok {
+				fields["client_ip"] = addr.String()
+			}
+
+			logger := o.getLogger().WithFields(fields)
+			if status >= stdhttp.StatusInternalServerError {
+				logger.Error("http access")
+			} else {
+				logger.Info("http access")
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitid "github.com/fsyyft-go/monorepo/kit/id"
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// TestAccessLog 测试访问日志记录的字段。
+func TestAccessLog(t *testing.T) {
+	logger := logtest.NewRecorder()
+	clock := kittime.NewFakeClock(time.Time{})
+	handler := AccessLog(WithLogger(logger), WithClock(clock))(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		clock.Advance(25 * time.Millisecond)
+		w.WriteHeader(stdhttp.StatusCreated)
+		_, _ = w.Write([]byte("hello"))
+	}))
+
+	r := httptest.NewRequest(stdhttp.MethodPut, "/items/1", nil)
+	r.Header.Set("User-Agent", "kit-test")
+	r = r.WithContext(kitid.NewContext(r.Context(), "req-2"))
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	entries := logger.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, kitlog.InfoLevel, entries[0].Level)
+	assert.Equal(t, map[string]interface{}{
+		"method":                stdhttp.MethodPut,
+		"path":                  "/items/1",
+		"status":                stdhttp.StatusCreated,
+		"bytes":                 int64(5),
+		"duration_ms":           int64(25),
+		"remote_addr":           r.RemoteAddr,
+		"user_agent":            "kit-test",
+		kitid.LogFieldRequestID: "req-2",
+	}, entries[0].Fields)
+}
+
+// TestAccessLog_Status 测试未写入时状态码记为 200，5xx 以错误级别记录。
+func TestAccessLog_Status(t *testing.T) {
+	logger := logtest.NewRecorder()
+	mw := AccessLog(WithLogger(logger))
+
+	mw(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {})).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(stdhttp.MethodGet, "/", nil))
+	mw(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		w.WriteHeader(stdhttp.StatusBadGateway)
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(stdhttp.MethodGet, "/", nil))
+
+	entries := logger.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, stdhttp.StatusOK, entries[0].Fields["status"])
+	assert.NotContains(t, entries[0].Fields, kitid.LogFieldRequestID)
+	assert.Equal(t, kitlog.ErrorLevel, entries[1].Level)
+	assert.Equal(t, stdhttp.StatusBadGateway, entries[1].Fields["status"])
+}
+
+// TestAccessLog_Recovery 测试与 Recovery 组合时访问日志记录 500。
+func TestAccessLog_Recovery(t *testing.T) {
+	logger := logtest.NewRecorder()
+	handler := Chain(AccessLog(WithLogger(logger)), Recovery(WithLogger(logger)))(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		panic("boom")
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(stdhttp.MethodGet, "/", nil))
+	entries := logger.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "http handler panic", entries[0].Message)
+	assert.Equal(t, stdhttp.StatusInternalServerError, entries[1].Fields["status"])
+}
+
+// TestAccessLog_Flush 测试包装后的 ResponseWriter 仍然支持 Flush 与 ResponseController。
+func TestAccessLog_Flush(t *testing.T) {
+	handler := AccessLog(WithLogger(logtest.NewRecorder()))(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		w.(stdhttp.Flusher).Flush()
+		assert.NoError(t, stdhttp.NewResponseController(w).Flush())
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(stdhttp.MethodGet, "/", nil))
+	assert.True(t, w.Flushed)
+}
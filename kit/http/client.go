@@ -0,0 +1,290 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	stdhttp "net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kitid "github.com/fsyyft-go/monorepo/kit/id"
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+const (
+	// routeUnknown 是未通过 RouteContext 设置路由时使用的指标标签。
+	routeUnknown = "unknown"
+	// codeError 是请求失败、没有响应时使用的状态码标签。
+	codeError = "error"
+	// drainLimit 是重试前读取并丢弃的响应体的最大字节数，读取完的连接可以被复用。
+	drainLimit = 4 << 10
+)
+
+var (
+	// errRetry 通知重试循环继续下一次尝试。
+	errRetry = errors.New("kit/http: retry")
+)
+
+type (
+	// routeContextKey 是路由在上下文中的键。
+	routeContextKey struct{}
+	// attemptContextKey 是尝试次数在上下文中的键。
+	attemptContextKey struct{}
+
+	// instrumentedTransport 记录每次尝试的日志与指标。
+	instrumentedTransport struct {
+		// next 是实际发送请求的 RoundTripper。
+		next stdhttp.RoundTripper
+		// o 是客户端的配置。
+		o *options
+	}
+
+	// retryTransport 按退避策略重试可以安全重放的请求。
+	retryTransport struct {
+		// next 是实际发送请求的 RoundTripper。
+		next stdhttp.RoundTripper
+		// o 是客户端的配置。
+		o *options
+	}
+)
+
+// NewClient 创建带有超时、连接池调优、重试、日志与指标的 HTTP 客户端。
+// 请求依次经过重试、日志与指标，最后由底层的 http.Transport 发送；
+// 重试的每次尝试都会分别记录日志与指标。
+//
+// 参数：
+//   - opts：配置选项，支持 WithName、WithMetrics、WithLogger、WithClock、WithTimeout、WithDialTimeout、WithDialContext、
+//     WithMaxIdleConnsPerHost、WithMaxConnsPerHost、WithMaxAttempts、WithBackoff 与 WithTransport。
+//
+// 返回值：
+//   - *http.Client：新的客户端。
+//
+// 示例：
+//
+//	client := http.NewClient(
+//	    http.WithName("payment"),
+//	    http.WithTimeout(10*time.Second),
+//	)
+//	req, _ := stdhttp.NewRequestWithContext(http.RouteContext(ctx, "/orders/{id}"), stdhttp.MethodGet, url, nil)
+//	resp, err := client.Do(req)
+func NewClient(opts ...Option) *stdhttp.Client {
+	o := newOptions(opts...)
+
+	c := &stdhttp.Client{Transport: newTransport(o)}
+	if o.timeout > 0 {
+		c.Timeout = o.timeout
+	}
+	return c
+}
+
+// NewTransport 创建带有重试、日志与指标的 RoundTripper，用于需要自行构造 http.Client 的场景。
+// WithTimeout 对 RoundTripper 不生效，总超时时间由调用方的 http.Client 或请求上下文控制。
+//
+// 参数：
+//   - opts：配置选项，与 NewClient 相同。
+//
+// 返回值：
+//   - http.RoundTripper：新的 RoundTripper。
+func NewTransport(opts ...Option) stdhttp.RoundTripper {
+	return newTransport(newOptions(opts...))
+}
+
+// RouteContext 返回携带路由的上下文，路由用作指标的 route 标签。
+// 路由应当是模板而不是实际路径，例如 /users/{id}，避免标签的取值过多。
+//
+// 参数：
+//   - ctx：父上下文。
+//   - route：路由模板。
+//
+// 返回值：
+//   - context.Context：携带路由的上下文。
+func RouteContext(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeContextKey{}, route)
+}
+
+// routeOf 返回上下文中的路由，未设置时返回 unknown。
+func routeOf(ctx context.Context) string {
+	if route, ok := ctx.Value(routeContextKey{}).(string); ok && "" != route {
+		return route
+	}
+	return routeUnknown
+}
+
+// attemptOf 返回上下文中的尝试次数，未设置时为第一次尝试。
+func attemptOf(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptContextKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+// newTransport 按配置组装 RoundTripper。
+func newTransport(o *options) stdhttp.RoundTripper {
+	base := o.transport
+	if nil == base {
+		base = newBaseTransport(o)
+	}
+
+	var rt stdhttp.RoundTripper = &instrumentedTransport{next: base, o: o}
+	if o.maxAttempts > 1 {
+		rt = &retryTransport{next: rt, o: o}
+	}
+	return rt
+}
+
+// newBaseTransport 按连接池与建立连接的参数创建 http.Transport，其余参数与 http.DefaultTransport 一致。
+func newBaseTransport(o *options) *stdhttp.Transport {
+	dial := o.dialContext
+	if nil == dial {
+		dialer := &net.Dialer{
+			Timeout:   o.dialTimeout,
+			KeepAlive: 30 * time.Second,
+		}
+		dial = dialer.DialContext
+	}
+	return &stdhttp.Transport{
+		Proxy:                 stdhttp.ProxyFromEnvironment,
+		DialContext:           dial,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   o.maxIdleConnsPerHost,
+		MaxConnsPerHost:       o.maxConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   o.dialTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// RoundTrip 发送请求，并记录这次尝试的日志与指标。
+func (t *instrumentedTransport) RoundTrip(req *stdhttp.Request) (*stdhttp.Response, error) {
+	start := t.o.clock.Now()
+	resp, err := t.next.RoundTrip(req)
+	duration := t.o.clock.Since(start)
+
+	code := codeError
+	if nil == err {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	if t.o.metrics {
+		MetricRequestDuration.With(prometheus.Labels{
+			"name":   t.o.name,
+			"host":   req.URL.Host,
+			"route":  routeOf(req.Context()),
+			"method": req.Method,
+			"code":   code,
+		}).Observe(duration.Seconds())
+	}
+
+	fields := map[string]interface{}{
+		"client":      t.o.name,
+		"method":      req.Method,
+		"host":        req.URL.Host,
+		"path":        req.URL.Path,
+		"attempt":     attemptOf(req.Context()),
+		"duration_ms": duration.Milliseconds(),
+	}
+	if requestID, ok := kitid.FromContext(req.Context()); ok {
+		fields[kitid.LogFieldRequestID] = requestID
+	}
+	logger := t.o.getLogger()
+	switch {
+	case nil != err:
+		fields["error"] = err.Error()
+		logger.WithFields(fields).Warn("http client request failed")
+	case resp.StatusCode >= stdhttp.StatusInternalServerError:
+		fields["status"] = resp.StatusCode
+		logger.WithFields(fields).Warn("http client request")
+	default:
+		fields["status"] = resp.StatusCode
+		logger.WithFields(fields).Debug("http client request")
+	}
+	return resp, err
+}
+
+// RoundTrip 发送请求，失败且可以重试时按退避策略重试。
+// 最后一次尝试的响应或错误原样返回；重试等待期间上下文结束时返回上下文的错误。
+func (t *retryTransport) RoundTrip(req *stdhttp.Request) (*stdhttp.Response, error) {
+	if !replayable(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	var (
+		resp    *stdhttp.Response
+		err     error
+		attempt int
+	)
+	retryErr := retry.RetryWithContext(req.Context(), func(ctx context.Context) error {
+		attempt++
+		r := req
+		if attempt > 1 {
+			r = req.Clone(context.WithValue(ctx, attemptContextKey{}, attempt))
+			if nil != req.GetBody {
+				body, bodyErr := req.GetBody()
+				if nil != bodyErr {
+					resp, err = nil, bodyErr
+					return nil
+				}
+				r.Body = body
+			}
+			if t.o.metrics {
+				MetricRetries.WithLabelValues(t.o.name, req.URL.Host).Inc()
+			}
+		}
+
+		resp, err = t.next.RoundTrip(r)
+		if attempt >= t.o.maxAttempts || !retryable(resp, err) {
+			return nil
+		}
+		if nil != resp {
+			// 读取剩余的响应体，使连接可以被复用。
+			_, _ = io.CopyN(io.Discard, resp.Body, drainLimit)
+			_ = resp.Body.Close()
+		}
+		return errRetry
+	}, t.o.backoff...)
+	if nil != retryErr {
+		return nil, retryErr
+	}
+	return resp, err
+}
+
+// replayable 判断请求是否可以安全地重新发送。
+// 与 net/http 的规则一致：请求体可以重新获取，并且方法是幂等的或者携带了幂等键。
+func replayable(req *stdhttp.Request) bool {
+	if nil != req.Body && stdhttp.NoBody != req.Body && nil == req.GetBody {
+		return false
+	}
+	switch req.Method {
+	case "", stdhttp.MethodGet, stdhttp.MethodHead, stdhttp.MethodOptions, stdhttp.MethodTrace,
+		stdhttp.MethodPut, stdhttp.MethodDelete:
+		return true
+	}
+	if _, ok := req.Header["Idempotency-Key"]; ok {
+		return true
+	}
+	if _, ok := req.Header["X-Idempotency-Key"]; ok {
+		return true
+	}
+	return false
+}
+
+// retryable 判断一次尝试的结果是否值得重试：网络错误、429 以及网关类的 5xx 错误。
+func retryable(resp *stdhttp.Response, err error) bool {
+	if nil != err {
+		return true
+	}
+	switch resp.StatusCode {
+	case stdhttp.StatusTooManyRequests, stdhttp.StatusBadGateway,
+		stdhttp.StatusServiceUnavailable, stdhttp.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
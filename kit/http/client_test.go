@@ -0,0 +1,249 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"io"
+	"net"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitid "github.com/fsyyft-go/monorepo/kit/id"
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+// uniqueName 返回测试唯一的客户端名称，避免不同测试的指标互相影响。
+func uniqueName(t *testing.T) string {
+	return t.Name() + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+// sampleCount 返回直方图指标的样本数。
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	t.Helper()
+	m := &dto.Metric{}
+	require.NoError(t, observer.(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+// flakyServer 启动一个前 failures 次请求返回 status、之后返回 200 的服务。
+func flakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	srv := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(append([]byte("ok:"), body...))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+// fastRetry 返回测试中使用的快速退避策略。
+func fastRetry() Option {
+	return WithBackoff(retry.WithMin(time.Millisecond), retry.WithMax(time.Millisecond), retry.WithJitter(false))
+}
+
+// TestNewClient_Defaults 测试默认参数与非法参数的处理。
+func TestNewClient_Defaults(t *testing.T) {
+	c := NewClient()
+	assert.Equal(t, timeoutDefault, c.Timeout)
+	rt, ok := c.Transport.(*retryTransport)
+	require.True(t, ok)
+	it, ok := rt.next.(*instrumentedTransport)
+	require.True(t, ok)
+	base, ok := it.next.(*stdhttp.Transport)
+	require.True(t, ok)
+	assert.Equal(t, maxIdleConnsPerHostDefault, base.MaxIdleConnsPerHost)
+	assert.Equal(t, dialTimeoutDefault, base.TLSHandshakeTimeout)
+
+	c = NewClient(WithTimeout(-1), WithMaxAttempts(1), WithName(""), WithDialTimeout(-1),
+		WithMaxIdleConnsPerHost(-1), WithMaxConnsPerHost(-1), WithTransport(stdhttp.DefaultTransport))
+	assert.Zero(t, c.Timeout)
+	it, ok = c.Transport.(*instrumentedTransport)
+	require.True(t, ok)
+	assert.Equal(t, stdhttp.DefaultTransport, it.next)
+	assert.Equal(t, nameDefault, it.o.name)
+	assert.Equal(t, dialTimeoutDefault, it.o.dialTimeout)
+	assert.Equal(t, maxIdleConnsPerHostDefault, it.o.maxIdleConnsPerHost)
+	assert.Zero(t, it.o.maxConnsPerHost)
+
+	assert.IsType(t, &retryTransport{}, NewTransport(WithMaxAttempts(0)))
+}
+
+// TestClient_DialContext 测试 WithDialContext 设置的函数用于建立连接。
+func TestClient_DialContext(t *testing.T) {
+	srv := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, _ *stdhttp.Request) {
+		w.WriteHeader(stdhttp.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var dialed []string
+	var d net.Dialer
+	c := NewClient(WithLogger(logtest.NewRecorder()), WithMetrics(false), WithDialContext(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		return d.DialContext(ctx, network, srv.Listener.Addr().String())
+	}))
+
+	resp, err := c.Get("http://backend.test/")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, stdhttp.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, []string{"backend.test:80"}, dialed)
+}
+
+// TestClient_Retry 测试可重试的状态码被重试，并记录每次尝试的指标。
+func TestClient_Retry(t *testing.T) {
+	name := uniqueName(t)
+	srv, requests := flakyServer(t, 2, stdhttp.StatusServiceUnavailable)
+	c := NewClient(WithName(name), WithLogger(logtest.NewRecorder()), fastRetry())
+
+	req, err := stdhttp.NewRequestWithContext(RouteContext(context.Background(), "/items/{id}"), stdhttp.MethodGet, srv.URL+"/items/1", nil)
+	require.NoError(t, err)
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	assert.Equal(t, stdhttp.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), requests.Load())
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricRetries.WithLabelValues(name, host)))
+	assert.Equal(t, uint64(2), sampleCount(t, MetricRequestDuration.WithLabelValues(name, host, "/items/{id}", stdhttp.MethodGet, "503")))
+	assert.Equal(t, uint64(1), sampleCount(t, MetricRequestDuration.WithLabelValues(name, host, "/items/{id}", stdhttp.MethodGet, "200")))
+}
+
+// TestClient_RetryExhausted 测试重试次数用尽时返回最后一次的响应。
+func TestClient_RetryExhausted(t *testing.T) {
+	srv, requests := flakyServer(t, 10, stdhttp.StatusBadGateway)
+	c := NewClient(WithMaxAttempts(2), WithMetrics(false), WithLogger(logtest.NewRecorder()), fastRetry())
+
+	resp, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, stdhttp.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+// TestClient_RetryBody 测试带请求体的幂等请求在重试时重新发送请求体。
+func TestClient_RetryBody(t *testing.T) {
+	srv, requests := flakyServer(t, 1, stdhttp.StatusTooManyRequests)
+	c := NewClient(WithMetrics(false), WithLogger(logtest.NewRecorder()), fastRetry())
+
+	req, err := stdhttp.NewRequest(stdhttp.MethodPut, srv.URL, strings.NewReader("payload"))
+	require.NoError(t, err)
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, "ok:payload", string(body))
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+// TestClient_NotReplayable 测试非幂等请求不重试，携带幂等键时重试。
+func TestClient_NotReplayable(t *testing.T) {
+	srv, requests := flakyServer(t, 1, stdhttp.StatusServiceUnavailable)
+	c := NewClient(WithMetrics(false), WithLogger(logtest.NewRecorder()), fastRetry())
+
+	resp, err := c.Post(srv.URL, "text/plain", strings.NewReader("a"))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, stdhttp.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+
+	requests.Store(0)
+	req, err := stdhttp.NewRequest(stdhttp.MethodPost, srv.URL, strings.NewReader("b"))
+	require.NoError(t, err)
+	req.Header.Set("Idempotency-Key", "k1")
+	resp, err = c.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, stdhttp.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// 请求体无法重新获取时不重试。
+	requests.Store(0)
+	req, err = stdhttp.NewRequest(stdhttp.MethodPut, srv.URL, io.NopCloser(strings.NewReader("c")))
+	require.NoError(t, err)
+	resp, err = c.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, stdhttp.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+// TestClient_RetryCanceled 测试重试等待期间上下文结束时返回上下文的错误。
+func TestClient_RetryCanceled(t *testing.T) {
+	srv, _ := flakyServer(t, 10, stdhttp.StatusServiceUnavailable)
+	c := NewClient(WithMetrics(false), WithLogger(logtest.NewRecorder()), WithBackoff(retry.WithMin(time.Hour), retry.WithMax(time.Hour)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := stdhttp.NewRequestWithContext(ctx, stdhttp.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, err = c.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestClient_Logging 测试每次尝试都记录日志，失败时以警告级别记录。
+func TestClient_Logging(t *testing.T) {
+	srv, _ := flakyServer(t, 1, stdhttp.StatusServiceUnavailable)
+	logger := logtest.NewRecorder()
+	c := NewClient(WithName("logging"), WithMetrics(false), WithLogger(logger), fastRetry())
+
+	req, err := stdhttp.NewRequestWithContext(kitid.NewContext(context.Background(), "req-3"), stdhttp.MethodGet, srv.URL+"/a", nil)
+	require.NoError(t, err)
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	entries := logger.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, kitlog.WarnLevel, entries[0].Level)
+	assert.Equal(t, 1, entries[0].Fields["attempt"])
+	assert.Equal(t, stdhttp.StatusServiceUnavailable, entries[0].Fields["status"])
+	assert.Equal(t, kitlog.DebugLevel, entries[1].Level)
+	assert.Equal(t, 2, entries[1].Fields["attempt"])
+	assert.Equal(t, "logging", entries[1].Fields["client"])
+	assert.Equal(t, "/a", entries[1].Fields["path"])
+	assert.Equal(t, "req-3", entries[1].Fields[kitid.LogFieldRequestID])
+}
+
+// TestClient_NetworkError 测试网络错误被重试，并以错误的原因记录日志。
+func TestClient_NetworkError(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	name := uniqueName(t)
+	logger := logtest.NewRecorder()
+	c := NewClient(WithName(name), WithLogger(logger), WithMaxAttempts(2), fastRetry())
+	_, err = c.Get("http://" + addr)
+	require.Error(t, err)
+
+	entries := logger.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "http client request failed", entries[1].Message)
+	assert.NotEmpty(t, entries[1].Fields["error"])
+	assert.Equal(t, uint64(2), sampleCount(t, MetricRequestDuration.WithLabelValues(name, addr, routeUnknown, stdhttp.MethodGet, codeError)))
+}
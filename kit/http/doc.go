@@ -0,0 +1,56 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package http 提供了可组合的 net/http 中间件与带有重试、日志和指标的客户端，避免每个服务重复编写相同的处理逻辑。
+
+主要功能：
+
+  - 中间件组合：Chain 将多个中间件按从外到内的顺序组合为一个
+  - 异常恢复：Recovery 捕获处理函数的 panic，通过 kit/log 记录堆栈并返回 500
+  - 访问日志：AccessLog 记录方法、路径、状态码、响应字节数、耗时与请求 ID
+  - 超时控制：Timeout 限制处理时间，超时后取消请求上下文并返回 503
+  - 请求体限制：MaxBodySize 拒绝或截断超过上限的请求体
+  - 错误响应：WriteError 按 kit/errors 的错误码写入 JSON 错误响应，ErrorFromResponse 在客户端还原错误
+  - 客户端：NewClient 创建带有超时、连接池调优、kit/runtime/retry 重试、请求日志与 Prometheus 指标的 http.Client
+
+基本使用：
+
+	handler := http.Chain(
+	    http.Recovery(),
+	    http.AccessLog(),
+	    http.Timeout(5*time.Second),
+	    http.MaxBodySize(1<<20),
+	)(mux)
+	_ = stdhttp.ListenAndServe(":8080", handler)
+
+指定日志实例：
+
+	logger, _ := log.NewLogger(log.WithLogType(log.LogTypeLogrus))
+	handler := http.Chain(
+	    http.Recovery(http.WithLogger(logger)),
+	    http.AccessLog(http.WithLogger(logger)),
+	)(mux)
+
+创建客户端：
+
+	client := http.NewClient(
+	    http.WithName("payment"),
+	    http.WithTimeout(10*time.Second),
+	    http.WithMaxAttempts(3),
+	)
+	// 路由模板作为指标的 route 标签。
+	ctx = http.RouteContext(ctx, "/orders/{id}")
+	req, _ := stdhttp.NewRequestWithContext(ctx, stdhttp.MethodGet, "https://pay.example.com/orders/1", nil)
+	resp, err := client.Do(req)
+
+由于包名与标准库相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "net/http"
+
+	    kithttp "github.com/fsyyft-go/monorepo/kit/http"
+	)
+*/
+package http
@@ -0,0 +1,128 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"encoding/json"
+	"io"
+	stdhttp "net/http"
+	"strconv"
+
+	kiterrors "github.com/fsyyft-go/monorepo/kit/errors"
+)
+
+const (
+	// errorBodyLimit 是 ErrorFromResponse 读取响应体的最大字节数。
+	errorBodyLimit = 64 << 10
+)
+
+var (
+	// errInternal 是处理函数 panic 时返回给调用方的错误，不包含 panic 的内容。
+	errInternal = kiterrors.WithCode(kiterrors.New("internal error"), kiterrors.CodeInternal)
+	// errBodyTooLarge 是请求体超过限制时返回给调用方的错误。
+	errBodyTooLarge = kiterrors.WithCode(kiterrors.New("request body too large"), kiterrors.CodeInvalidArgument)
+)
+
+type (
+	// ErrorBody 是 WriteError 写入的 JSON 响应体。
+	ErrorBody struct {
+		// Code 是错误码的名称，例如 "NotFound"，用于还原 HTTP 状态码无法区分的错误码。
+		Code string `json:"code"`
+		// Message 是错误信息。
+		Message string `json:"message"`
+		// Details 是错误的元数据。
+		Details map[string]interface{} `json:"details,omitempty"`
+	}
+)
+
+// WriteError 将错误写入 HTTP 响应。
+// 状态码由 kiterrors.HTTPStatusOf 确定；响应体为 ErrorBody 的 JSON，包含错误码的名称、错误信息与元数据。
+// 错误信息会原样返回给调用方，内部错误应在写入前替换为不含敏感信息的错误。
+//
+// 参数：
+//   - w：HTTP 响应。
+//   - err：要写入的错误，为 nil 时不写入任何内容。
+//
+// 示例：
+//
+//	user, err := svc.GetUser(r.Context(), id)
+//	if nil != err {
+//	    http.WriteError(w, err)
+//	    return
+//	}
+func WriteError(w stdhttp.ResponseWriter, err error) {
+	if nil == err {
+		return
+	}
+	writeError(w, err, kiterrors.HTTPStatusOf(err))
+}
+
+// writeError 以指定的状态码写入错误，用于错误码对应的状态码不够具体的场景，例如 413。
+func writeError(w stdhttp.ResponseWriter, err error, status int) {
+	body := ErrorBody{
+		Code:    kiterrors.TransportCodeOf(err).String(),
+		Message: err.Error(),
+		Details: kiterrors.FieldsOf(err),
+	}
+	data, errMarshal := json.Marshal(body)
+	if nil != errMarshal {
+		// 元数据无法序列化时只返回错误码与错误信息。
+		body.Details = nil
+		data, _ = json.Marshal(body)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(data, '\n'))
+}
+
+// ErrorFromResponse 将失败的 HTTP 响应转换为 kit/errors 的错误，是 WriteError 的逆操作。
+// 响应体为 ErrorBody 时使用其中的错误码、错误信息与元数据；否则错误码由 kiterrors.CodeFromHTTPStatus 确定，
+// 错误信息为状态码的描述。该函数会读取响应体，但不会关闭响应体。
+//
+// 参数：
+//   - resp：HTTP 响应。
+//
+// 返回值：
+//   - error：转换后的错误，状态码小于 400 时返回 nil。
+//
+// 示例：
+//
+//	resp, err := client.Do(req)
+//	if nil != err {
+//	    return err
+//	}
+//	defer resp.Body.Close()
+//	if err := http.ErrorFromResponse(resp); nil != err {
+//	    return err
+//	}
+func ErrorFromResponse(resp *stdhttp.Response) error {
+	if resp.StatusCode < stdhttp.StatusBadRequest {
+		return nil
+	}
+
+	code := kiterrors.CodeFromHTTPStatus(resp.StatusCode)
+	message := strconv.Itoa(resp.StatusCode) + " " + stdhttp.StatusText(resp.StatusCode)
+	var details map[string]interface{}
+
+	var body ErrorBody
+	if nil != resp.Body {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+		if nil == json.Unmarshal(data, &body) && "" != body.Code {
+			if parsed, ok := kiterrors.ParseCode(body.Code); ok {
+				code = parsed
+			}
+			message = body.Message
+			details = body.Details
+		}
+	}
+
+	err := kiterrors.WithCode(kiterrors.New(message), code)
+	if len(details) > 0 {
+		err = kiterrors.WithFields(err, details)
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"encoding/json"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kiterrors "github.com/fsyyft-go/monorepo/kit/errors"
+)
+
+// TestWriteError 测试错误写入响应的状态码与响应体，并通过 ErrorFromResponse 还原。
+func TestWriteError(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteError(w, nil)
+	assert.Equal(t, stdhttp.StatusOK, w.Code)
+	assert.Zero(t, w.Body.Len())
+
+	// FailedPrecondition 与 InvalidArgument 的状态码相同，响应体中的错误码用于区分两者。
+	err := kiterrors.WithField(kiterrors.WithCode(kiterrors.New("余额不足"), kiterrors.CodeFailedPrecondition), "balance", 3)
+	w = httptest.NewRecorder()
+	WriteError(w, err)
+	assert.Equal(t, stdhttp.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	var body ErrorBody
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, ErrorBody{Code: "FailedPrecondition", Message: "余额不足", Details: map[string]interface{}{"balance": float64(3)}}, body)
+
+	restored := ErrorFromResponse(w.Result())
+	assert.EqualError(t, restored, "余额不足")
+	assert.Equal(t, kiterrors.CodeFailedPrecondition, kiterrors.CodeOf(restored))
+	assert.Equal(t, map[string]interface{}{"balance": float64(3)}, kiterrors.FieldsOf(restored))
+}
+
+// TestWriteError_UnsupportedDetails 测试元数据无法序列化时只写入错误码与错误信息。
+func TestWriteError_UnsupportedDetails(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteError(w, kiterrors.WithField(kiterrors.New("失败"), "callback", func() {}))
+	assert.Equal(t, stdhttp.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"code":"Unknown","message":"失败"}`, w.Body.String())
+}
+
+// TestErrorFromResponse 测试其他格式的失败响应按状态码转换。
+func TestErrorFromResponse(t *testing.T) {
+	assert.NoError(t, ErrorFromResponse(&stdhttp.Response{StatusCode: stdhttp.StatusNoContent}))
+
+	w := httptest.NewRecorder()
+	stdhttp.Error(w, "not here", stdhttp.StatusNotFound)
+	err := ErrorFromResponse(w.Result())
+	assert.EqualError(t, err, "404 Not Found")
+	assert.True(t, kiterrors.IsCode(err, kiterrors.CodeNotFound))
+
+	// 无法识别的错误码名称按状态码转换，仍然使用响应体中的错误信息。
+	resp := &stdhttp.Response{
+		StatusCode: stdhttp.StatusServiceUnavailable,
+		Body:       stdhttp.NoBody,
+	}
+	assert.True(t, kiterrors.IsCode(ErrorFromResponse(resp), kiterrors.CodeUnavailable))
+	w = httptest.NewRecorder()
+	w.WriteHeader(stdhttp.StatusTooManyRequests)
+	_, _ = w.Body.WriteString(`{"code":"Quota","message":"稍后再试"}`)
+	err = ErrorFromResponse(w.Result())
+	assert.EqualError(t, err, "稍后再试")
+	assert.True(t, kiterrors.IsCode(err, kiterrors.CodeResourceExhausted))
+	assert.Empty(t, kiterrors.FieldsOf(err))
+}
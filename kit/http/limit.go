@@ -0,0 +1,64 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	stdhttp "net/http"
+	"time"
+)
+
+// Timeout 返回限制请求处理时间的中间件。
+// 基于 http.TimeoutHandler 实现：处理函数的请求上下文在 d 之后被取消，
+// 尚未写入的响应被丢弃并返回 503，之后处理函数的写入返回 http.ErrHandlerTimeout。
+// 由于响应会被缓冲，该中间件不支持 Flush 与 Hijack，流式接口不应使用。
+//
+// 参数：
+//   - d：处理时间的上限，小于等于 0 时不限制。
+//
+// 返回值：
+//   - Middleware：限制处理时间的中间件。
+//
+// 示例：
+//
+//	handler := http.Timeout(5 * time.Second)(mux)
+func Timeout(d time.Duration) Middleware {
+	return func(next stdhttp.Handler) stdhttp.Handler {
+		if d <= 0 {
+			return next
+		}
+		return stdhttp.TimeoutHandler(next, d, stdhttp.StatusText(stdhttp.StatusServiceUnavailable))
+	}
+}
+
+// MaxBodySize 返回限制请求体大小的中间件。
+// Content-Length 超过 n 的请求直接返回 413；未声明长度的请求体在读取超过 n 字节时，
+// 读取方法返回 *http.MaxBytesError，并通知服务端在响应后关闭连接。
+//
+// 参数：
+//   - n：请求体的最大字节数，小于等于 0 时不限制。
+//
+// 返回值：
+//   - Middleware：限制请求体大小的中间件。
+//
+// 示例：
+//
+//	handler := http.MaxBodySize(1 << 20)(mux) // 1 MiB
+func MaxBodySize(n int64) Middleware {
+	return func(next stdhttp.Handler) stdhttp.Handler {
+		if n <= 0 {
+			return next
+		}
+		return stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			if r.ContentLength > n {
+				writeError(w, errBodyTooLarge, stdhttp.StatusRequestEntityTooLarge)
+				return
+			}
+			if nil != r.Body {
+				r.Body = stdhttp.MaxBytesReader(w, r.Body, n)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
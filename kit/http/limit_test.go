@@ -0,0 +1,77 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kiterrors "github.com/fsyyft-go/monorepo/kit/errors"
+)
+
+// TestTimeout 测试处理超时时返回 503 并取消请求上下文。
+func TestTimeout(t *testing.T) {
+	canceled := make(chan error, 1)
+	handler := Timeout(20 * time.Millisecond)(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		<-r.Context().Done()
+		canceled <- r.Context().Err()
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(stdhttp.MethodGet, "/", nil))
+	assert.Equal(t, stdhttp.StatusServiceUnavailable, w.Code)
+	assert.ErrorIs(t, <-canceled, context.DeadlineExceeded)
+}
+
+// TestTimeout_Disabled 测试上限小于等于 0 时不包装处理函数。
+func TestTimeout_Disabled(t *testing.T) {
+	h := stdhttp.NotFoundHandler()
+	handler := Timeout(0)(h)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(stdhttp.MethodGet, "/", nil))
+	assert.Equal(t, stdhttp.StatusNotFound, w.Code)
+}
+
+// TestMaxBodySize 测试请求体大小的限制。
+func TestMaxBodySize(t *testing.T) {
+	var readErr error
+	handler := MaxBodySize(4)(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		_, readErr = io.ReadAll(r.Body)
+	}))
+
+	// 声明的长度超过上限时直接拒绝。
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(stdhttp.MethodPost, "/", strings.NewReader("hello")))
+	assert.Equal(t, stdhttp.StatusRequestEntityTooLarge, w.Code)
+	assert.True(t, kiterrors.IsCode(ErrorFromResponse(w.Result()), kiterrors.CodeInvalidArgument))
+
+	// 未声明长度时在读取超过上限时返回错误。
+	r := httptest.NewRequest(stdhttp.MethodPost, "/", io.NopCloser(strings.NewReader("hello")))
+	r.ContentLength = -1
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	var maxErr *stdhttp.MaxBytesError
+	require.ErrorAs(t, readErr, &maxErr)
+	assert.Equal(t, int64(4), maxErr.Limit)
+
+	// 未超过上限时正常读取。
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(stdhttp.MethodPost, "/", strings.NewReader("hey")))
+	assert.NoError(t, readErr)
+
+	// 上限小于等于 0 时不限制。
+	unlimited := MaxBodySize(0)(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		_, readErr = io.ReadAll(r.Body)
+	}))
+	unlimited.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(stdhttp.MethodPost, "/", strings.NewReader("hello")))
+	assert.NoError(t, readErr)
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 定义 HTTP 客户端指标相关的常量。
+const (
+	// namespace 定义 prometheus 指标的命名空间。
+	namespace = "kit_http"
+	// subsystem 定义 prometheus 指标的子系统名称。
+	subsystem = "client"
+)
+
+var (
+	// MetricRequestDuration 用于记录客户端每次请求的耗时，单位为秒，重试的每次尝试分别记录。
+	// 该指标包含以下标签：
+	// - name: 客户端的名称。
+	// - host: 请求的目标主机。
+	// - route: 请求的路由，通过 RouteContext 设置，未设置时为 unknown。
+	// - method: 请求方法。
+	// - code: 响应状态码，请求失败时为 error。
+	MetricRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "request_duration_seconds",
+		Help:      "http client's request duration in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"name", "host", "route", "method", "code"})
+
+	// MetricRetries 用于记录客户端的重试次数。
+	// 该指标包含以下标签：
+	// - name: 客户端的名称。
+	// - host: 请求的目标主机。
+	MetricRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "retries_total",
+		Help:      "http client's retries total.",
+	}, []string{"name", "host"})
+)
+
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricRequestDuration,
+		MetricRetries,
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	stdhttp "net/http"
+)
+
+type (
+	// Middleware 包装一个 http.Handler，在请求前后执行额外的处理。
+	Middleware func(next stdhttp.Handler) stdhttp.Handler
+)
+
+// Chain 将多个中间件组合为一个中间件。
+// 第一个中间件位于最外层，最先接收请求、最后处理响应；nil 中间件会被忽略。
+//
+// 参数：
+//   - middlewares：要组合的中间件，按从外到内的顺序排列。
+//
+// 返回值：
+//   - Middleware：组合后的中间件。
+//
+// 示例：
+//
+//	handler := http.Chain(
+//	    http.Recovery(),
+//	    http.AccessLog(),
+//	    http.Timeout(5*time.Second),
+//	    http.MaxBodySize(1<<20),
+//	)(mux)
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next stdhttp.Handler) stdhttp.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			if nil != middlewares[i] {
+				next = middlewares[i](next)
+			}
+		}
+		return next
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tag 返回在响应体中追加 name 的中间件，用于验证执行顺序。
+func tag(name string) Middleware {
+	return func(next stdhttp.Handler) stdhttp.Handler {
+		return stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			_, _ = w.Write([]byte(name + ">"))
+			next.ServeHTTP(w, r)
+			_, _ = w.Write([]byte("<" + name))
+		})
+	}
+}
+
+// TestChain 测试中间件按从外到内的顺序执行，并忽略 nil 中间件。
+func TestChain(t *testing.T) {
+	handler := Chain(tag("a"), nil, tag("b"), tag("c"))(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		_, _ = w.Write([]byte("h"))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(stdhttp.MethodGet, "/", nil))
+	assert.Equal(t, "a>b>c>h<c<b<a", w.Body.String())
+}
+
+// TestChain_Empty 测试没有中间件时直接返回原处理函数。
+func TestChain_Empty(t *testing.T) {
+	handler := Chain()(stdhttp.NotFoundHandler())
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(stdhttp.MethodGet, "/", nil))
+	assert.Equal(t, stdhttp.StatusNotFound, w.Code)
+	assert.True(t, strings.HasPrefix(w.Body.String(), "404"))
+}
@@ -0,0 +1,282 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"net"
+	stdhttp "net/http"
+	"time"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 以下为中间件与客户端的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// clockDefault 为计算请求耗时使用的时钟。
+	clockDefault = kittime.NewRealClock()
+	// nameDefault 为客户端的默认名称，用于指标标签。
+	nameDefault = "default"
+	// metricsDefault 为客户端是否默认记录指标。
+	metricsDefault = true
+	// timeoutDefault 为客户端一次请求（包括重试与读取响应体）的总超时时间。
+	timeoutDefault = 30 * time.Second
+	// dialTimeoutDefault 为建立连接的超时时间。
+	dialTimeoutDefault = 5 * time.Second
+	// maxIdleConnsPerHostDefault 为每个主机保留的最大空闲连接数。
+	maxIdleConnsPerHostDefault = 16
+	// maxAttemptsDefault 为一次请求的最大尝试次数，包括第一次请求。
+	maxAttemptsDefault = 3
+	// backoffDefault 为重试之间的退避策略，等待时间从 100 毫秒开始增长，最长 2 秒，并启用抖动。
+	backoffDefault = []retry.BackoffOption{
+		retry.WithMin(100 * time.Millisecond),
+		retry.WithMax(2 * time.Second),
+		retry.WithJitter(true),
+	}
+)
+
+type (
+	// Option 定义了中间件与客户端的配置选项。
+	// 部分选项只对客户端生效，在选项的说明中注明。
+	Option func(*options)
+
+	// options 包含中间件与客户端的配置。
+	options struct {
+		// logger 是记录日志使用的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+		// clock 是计算请求耗时使用的时钟。
+		clock kittime.Clock
+
+		// name 是客户端的名称，用于指标标签。
+		name string
+		// metrics 表示客户端是否记录指标。
+		metrics bool
+		// timeout 是客户端一次请求的总超时时间。
+		timeout time.Duration
+		// dialTimeout 是建立连接的超时时间。
+		dialTimeout time.Duration
+		// dialContext 是建立连接的函数，为 nil 时使用按 dialTimeout 创建的 net.Dialer。
+		dialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+		// maxIdleConnsPerHost 是每个主机保留的最大空闲连接数。
+		maxIdleConnsPerHost int
+		// maxConnsPerHost 是每个主机的最大连接数，0 表示不限制。
+		maxConnsPerHost int
+		// maxAttempts 是一次请求的最大尝试次数。
+		maxAttempts int
+		// backoff 是重试之间的退避策略。
+		backoff []retry.BackoffOption
+		// transport 是底层的 RoundTripper，为 nil 时使用按连接池参数创建的 http.Transport。
+		transport stdhttp.RoundTripper
+	}
+)
+
+// WithLogger 设置记录日志使用的日志实例。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例，在每次记录时获取，因此可以晚于中间件创建初始化。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithClock 设置计算请求耗时使用的时钟。
+// 测试时可以注入 kit/time 的 FakeClock，得到确定的耗时。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithName 设置客户端的名称，用于区分不同客户端的指标与日志，仅对客户端生效。
+//
+// 参数：
+//   - name：客户端名称，默认为 default。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithMetrics 设置客户端是否记录指标，仅对客户端生效。
+//
+// 参数：
+//   - metrics：是否记录指标，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMetrics(metrics bool) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// WithTimeout 设置客户端一次请求的总超时时间，包括所有重试与读取响应体，仅对客户端生效。
+//
+// 参数：
+//   - timeout：总超时时间，默认为 30 秒，小于 0 时不限制。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithDialTimeout 设置建立连接与 TLS 握手的超时时间，仅对客户端生效。
+//
+// 参数：
+//   - timeout：超时时间，默认为 5 秒。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.dialTimeout = timeout
+	}
+}
+
+// WithDialContext 设置建立连接的函数，仅对客户端生效，例如使用 kit/dns 的 Resolver.DialContext 缓存域名解析。
+// 设置后 WithDialTimeout 只对 TLS 握手生效，连接的超时时间由该函数决定。
+//
+// 参数：
+//   - dial：建立连接的函数，默认使用按 WithDialTimeout 创建的 net.Dialer。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) Option {
+	return func(o *options) {
+		o.dialContext = dial
+	}
+}
+
+// WithMaxIdleConnsPerHost 设置每个主机保留的最大空闲连接数，仅对客户端生效。
+// 标准库的默认值为 2，对同一下游的并发请求较多时会频繁建立连接。
+//
+// 参数：
+//   - n：最大空闲连接数，默认为 16。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(o *options) {
+		o.maxIdleConnsPerHost = n
+	}
+}
+
+// WithMaxConnsPerHost 设置每个主机的最大连接数，超过时请求等待空闲连接，仅对客户端生效。
+//
+// 参数：
+//   - n：最大连接数，默认为 0，表示不限制。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxConnsPerHost(n int) Option {
+	return func(o *options) {
+		o.maxConnsPerHost = n
+	}
+}
+
+// WithMaxAttempts 设置一次请求的最大尝试次数，包括第一次请求，仅对客户端生效。
+//
+// 参数：
+//   - n：最大尝试次数，默认为 3，1 表示不重试。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxAttempts(n int) Option {
+	return func(o *options) {
+		o.maxAttempts = n
+	}
+}
+
+// WithBackoff 设置重试之间的退避策略，选项追加在默认策略之后，仅对客户端生效。
+//
+// 参数：
+//   - opts：kit/runtime/retry 的退避选项，默认等待时间从 100 毫秒开始增长，最长 2 秒，并启用抖动。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithBackoff(opts ...retry.BackoffOption) Option {
+	return func(o *options) {
+		o.backoff = append(o.backoff, opts...)
+	}
+}
+
+// WithTransport 设置底层的 RoundTripper，仅对客户端生效。
+// 设置后连接池相关的选项不再生效，重试、日志与指标仍然包装在其外层。
+//
+// 参数：
+//   - transport：底层的 RoundTripper，默认按连接池参数创建 http.Transport。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTransport(transport stdhttp.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		clock:               clockDefault,
+		name:                nameDefault,
+		metrics:             metricsDefault,
+		timeout:             timeoutDefault,
+		dialTimeout:         dialTimeoutDefault,
+		maxIdleConnsPerHost: maxIdleConnsPerHostDefault,
+		maxAttempts:         maxAttemptsDefault,
+		backoff:             append([]retry.BackoffOption(nil), backoffDefault...),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	o.clock = kittime.OrReal(o.clock)
+	if "" == o.name {
+		o.name = nameDefault
+	}
+	if 0 == o.timeout {
+		o.timeout = timeoutDefault
+	}
+	if o.dialTimeout <= 0 {
+		o.dialTimeout = dialTimeoutDefault
+	}
+	if o.maxIdleConnsPerHost <= 0 {
+		o.maxIdleConnsPerHost = maxIdleConnsPerHostDefault
+	}
+	if o.maxConnsPerHost < 0 {
+		o.maxConnsPerHost = 0
+	}
+	if o.maxAttempts <= 0 {
+		o.maxAttempts = maxAttemptsDefault
+	}
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	stdhttp "net/http"
+
+	kitip "github.com/fsyyft-go/monorepo/kit/ip"
+)
+
+// RealIP 返回解析客户端真实地址的中间件。
+// 解析结果通过 kit/ip 的 NewContext 写入请求上下文，下游通过 kit/ip 的 FromContext 读取，
+// AccessLog 位于该中间件之内时额外记录 client_ip 字段。
+//
+// 参数：
+//   - resolver：客户端地址解析器，为 nil 时使用不信任任何代理的解析器，即总是使用直接连接的地址。
+//
+// 返回值：
+//   - Middleware：解析客户端地址的中间件。
+//
+// 示例：
+//
+//	resolver := ip.NewResolver(ip.WithTrustedProxies(ip.LocalNetworks()))
+//	handler := http.Chain(
+//	    http.RealIP(resolver),
+//	    http.AccessLog(),
+//	)(mux)
+func RealIP(resolver *kitip.Resolver) Middleware {
+	if nil == resolver {
+		resolver = kitip.NewResolver()
+	}
+	return func(next stdhttp.Handler) stdhttp.Handler {
+		return stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			if addr := resolver.ClientIP(r); addr.IsValid() {
+				r = r.WithContext(kitip.NewContext(r.Context(), addr))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitip "github.com/fsyyft-go/monorepo/kit/ip"
+
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+)
+
+// TestRealIP 测试解析客户端地址并写入上下文与访问日志。
+func TestRealIP(t *testing.T) {
+	logger := logtest.NewRecorder()
+	resolver := kitip.NewResolver(kitip.WithTrustedProxies(kitip.LocalNetworks()))
+	var got string
+	handler := Chain(RealIP(resolver), AccessLog(WithLogger(logger)))(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		addr, ok := kitip.FromContext(r.Context())
+		require.True(t, ok)
+		got = addr.String()
+	}))
+
+	r := httptest.NewRequest(stdhttp.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.2")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, "203.0.113.7", got)
+
+	entries := logger.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "203.0.113.7", entries[0].Fields["client_ip"])
+	assert.Equal(t, "10.0.0.1:1234", entries[0].Fields["remote_addr"])
+}
+
+// TestRealIP_Default 测试未指定解析器时使用直接连接的地址，无法解析时不写入上下文。
+func TestRealIP_Default(t *testing.T) {
+	var addr string
+	var ok bool
+	handler := RealIP(nil)(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		a, found := kitip.FromContext(r.Context())
+		addr, ok = a.String(), found
+	}))
+
+	r := httptest.NewRequest(stdhttp.MethodGet, "/", nil)
+	r.RemoteAddr = "127.0.0.1:1234"
+	r.Header.Set("X-Forwarded-For", "203.0.113.7")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	assert.True(t, ok)
+	assert.Equal(t, "127.0.0.1", addr)
+
+	r.RemoteAddr = "@"
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	assert.False(t, ok)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	stdhttp "net/http"
+	"runtime/debug"
+
+	kitid "github.com/fsyyft-go/monorepo/kit/id"
+)
+
+// Recovery 返回捕获处理函数 panic 的中间件。
+// panic 的值与堆栈通过 kit/log 以错误级别记录；尚未写入响应头时通过 WriteError 返回 500。
+// 与 net/http 一致，http.ErrAbortHandler 会被继续抛出，用于中止响应而不记录日志。
+//
+// 参数：
+//   - opts：配置选项，支持 WithLogger。
+//
+// 返回值：
+//   - Middleware：恢复 panic 的中间件。
+//
+// 示例：
+//
+//	handler := http.Recovery(http.WithLogger(logger))(mux)
+func Recovery(opts ...Option) Middleware {
+	o := newOptions(opts...)
+
+	return func(next stdhttp.Handler) stdhttp.Handler {
+		return stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			rr := recordResponse(w)
+			defer func() {
+				p := recover()
+				if nil == p {
+					return
+				}
+				if p == stdhttp.ErrAbortHandler { // nolint: errorlint
+					panic(p)
+				}
+
+				fields := map[string]interface{}{
+					"method": r.Method,
+					"path":   r.URL.Path,
+					"panic":  p,
+					"stack":  string(debug.Stack()),
+				}
+				if requestID := requestIDOf(r); "" != requestID {
+					fields[kitid.LogFieldRequestID] = requestID
+				}
+				o.getLogger().WithFields(fields).Error("http handler panic")
+
+				if 0 == rr.status {
+					WriteError(rr, errInternal)
+				}
+			}()
+			next.ServeHTTP(rr, r)
+		})
+	}
+}
+
+// requestIDOf 返回请求的请求 ID，优先使用上下文中的值，其次使用请求头。
+func requestIDOf(r *stdhttp.Request) string {
+	if requestID, ok := kitid.FromContext(r.Context()); ok {
+		return requestID
+	}
+	return r.Header.Get(kitid.HeaderRequestID)
+}
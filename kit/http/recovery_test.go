@@ -0,0 +1,83 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitid "github.com/fsyyft-go/monorepo/kit/id"
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+)
+
+// TestRecovery 测试 panic 被捕获、记录堆栈并返回 500。
+func TestRecovery(t *testing.T) {
+	logger := logtest.NewRecorder()
+	handler := Recovery(WithLogger(logger))(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		panic("boom")
+	}))
+
+	r := httptest.NewRequest(stdhttp.MethodPost, "/orders", nil)
+	r.Header.Set(kitid.HeaderRequestID, "req-1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, stdhttp.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"code":"Internal","message":"internal error"}`, w.Body.String())
+	entries := logger.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, kitlog.ErrorLevel, entries[0].Level)
+	assert.Equal(t, "boom", entries[0].Fields["panic"])
+	assert.Equal(t, "/orders", entries[0].Fields["path"])
+	assert.Equal(t, "req-1", entries[0].Fields[kitid.LogFieldRequestID])
+	assert.Contains(t, entries[0].Fields["stack"], "recovery_test.go")
+}
+
+// TestRecovery_HeaderWritten 测试已写入响应头时不再覆盖状态码。
+func TestRecovery_HeaderWritten(t *testing.T) {
+	logger := logtest.NewRecorder()
+	handler := Recovery(WithLogger(logger))(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		w.WriteHeader(stdhttp.StatusAccepted)
+		panic("late")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(stdhttp.MethodGet, "/", nil))
+	assert.Equal(t, stdhttp.StatusAccepted, w.Code)
+	assert.Len(t, logger.Entries(), 1)
+}
+
+// TestRecovery_AbortHandler 测试 http.ErrAbortHandler 被继续抛出。
+func TestRecovery_AbortHandler(t *testing.T) {
+	logger := logtest.NewRecorder()
+	handler := Recovery(WithLogger(logger))(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		panic(stdhttp.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, stdhttp.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(stdhttp.MethodGet, "/", nil))
+	})
+	assert.Empty(t, logger.Entries())
+}
+
+// TestRecovery_Timeout 测试 Timeout 中间件内部的 panic 同样被捕获。
+func TestRecovery_Timeout(t *testing.T) {
+	logger := logtest.NewRecorder()
+	handler := Chain(Recovery(WithLogger(logger)), Timeout(time.Second))(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		panic("inner")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(stdhttp.MethodGet, "/", nil))
+	assert.Equal(t, stdhttp.StatusInternalServerError, w.Code)
+	require.Len(t, logger.Entries(), 1)
+	assert.Equal(t, "inner", logger.Entries()[0].Fields["panic"])
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	stdhttp "net/http"
+)
+
+type (
+	// responseRecorder 记录响应的状态码与写入的字节数。
+	// 多个中间件共享同一个 responseRecorder，避免重复包装。
+	responseRecorder struct {
+		stdhttp.ResponseWriter
+
+		// status 是响应的状态码，未调用 WriteHeader 时为 0。
+		status int
+		// bytes 是已写入响应体的字节数。
+		bytes int64
+	}
+)
+
+// recordResponse 返回记录响应的 ResponseWriter，w 已经是 responseRecorder 时直接返回。
+func recordResponse(w stdhttp.ResponseWriter) *responseRecorder {
+	if rr, ok := w.(*responseRecorder); ok {
+		return rr
+	}
+	return &responseRecorder{ResponseWriter: w}
+}
+
+// WriteHeader 记录状态码并写入响应头。
+func (r *responseRecorder) WriteHeader(status int) {
+	// 1xx 为信息性响应，之后仍可以写入最终的状态码。
+	if 0 == r.status && status >= stdhttp.StatusOK {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write 写入响应体并记录字节数，未写入响应头时状态码记为 200。
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if 0 == r.status {
+		r.status = stdhttp.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Flush 将缓冲的数据发送给客户端，底层 ResponseWriter 不支持时不执行任何操作。
+func (r *responseRecorder) Flush() {
+	if 0 == r.status {
+		r.status = stdhttp.StatusOK
+	}
+	if f, ok := r.ResponseWriter.(stdhttp.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap 返回被包装的 ResponseWriter，供 http.ResponseController 使用。
+func (r *responseRecorder) Unwrap() stdhttp.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,29 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package httpserver 提供了实现 kit/runtime Runner 接口的 HTTP 服务 Server。
+
+Server 预置了请求头读取超时、空闲超时等服务端超时配置，以及 kit/http 的 Recovery 与 AccessLog 中间件；
+Start 在监听成功后返回，Stop 优雅地停止服务：
+
+	srv := httpserver.New(mux,
+	    httpserver.WithAddr(":8080"),
+	    httpserver.WithWriteTimeout(10*time.Second),
+	)
+	if err := srv.Start(ctx); nil != err {
+	    return err
+	}
+	defer srv.Stop(shutdownCtx)
+
+使用 kit/tls 的 Provider 启用 TLS，证书重新加载后新的连接立即使用新证书：
+
+	provider, err := kittls.Watch(kittls.WithCertificate("tls.crt", "tls.key"))
+	srv := httpserver.New(mux, httpserver.WithTLS(provider))
+
+Ready 在监听成功后被关闭，Addr 返回实际监听的地址，Check 可以注册为 kit/health 的就绪检查：
+
+	h.AddReadiness("http", srv)
+*/
+package httpserver
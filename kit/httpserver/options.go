@@ -0,0 +1,333 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package httpserver
+
+import (
+	"net"
+	"time"
+
+	kithttp "github.com/fsyyft-go/monorepo/kit/http"
+	kitip "github.com/fsyyft-go/monorepo/kit/ip"
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	kittls "github.com/fsyyft-go/monorepo/kit/tls"
+)
+
+// 以下为 HTTP 服务的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// nameDefault 为服务的默认名称，用于日志。
+	nameDefault = "http"
+	// addrDefault 为默认的监听地址。
+	addrDefault = ":8080"
+	// readHeaderTimeoutDefault 为读取请求头的超时时间，避免慢速攻击占用连接。
+	readHeaderTimeoutDefault = 5 * time.Second
+	// readTimeoutDefault 为读取整个请求的超时时间，0 表示不限制。
+	readTimeoutDefault = time.Duration(0)
+	// writeTimeoutDefault 为写入响应的超时时间，0 表示不限制。
+	writeTimeoutDefault = time.Duration(0)
+	// idleTimeoutDefault 为 keep-alive 连接的空闲超时时间。
+	idleTimeoutDefault = 2 * time.Minute
+	// maxHeaderBytesDefault 为请求头的最大字节数。
+	maxHeaderBytesDefault = 1 << 20
+	// recoveryDefault 为是否默认启用 Recovery 中间件。
+	recoveryDefault = true
+	// accessLogDefault 为是否默认启用 AccessLog 中间件。
+	accessLogDefault = true
+)
+
+type (
+	// Option 定义了 HTTP 服务的配置选项。
+	Option func(*options)
+
+	// options 包含 HTTP 服务的配置。
+	options struct {
+		// name 是服务的名称，用于日志。
+		name string
+		// addr 是监听地址。
+		addr string
+		// listener 是已经创建的监听器，设置后 addr 不再生效。
+		listener net.Listener
+		// readHeaderTimeout 是读取请求头的超时时间。
+		readHeaderTimeout time.Duration
+		// readTimeout 是读取整个请求的超时时间。
+		readTimeout time.Duration
+		// writeTimeout 是写入响应的超时时间。
+		writeTimeout time.Duration
+		// idleTimeout 是 keep-alive 连接的空闲超时时间。
+		idleTimeout time.Duration
+		// maxHeaderBytes 是请求头的最大字节数。
+		maxHeaderBytes int
+		// tls 提供 TLS 证书，为 nil 时不启用 TLS。
+		tls *kittls.Provider
+		// recovery 表示是否启用 Recovery 中间件。
+		recovery bool
+		// accessLog 表示是否启用 AccessLog 中间件。
+		accessLog bool
+		// realIP 是解析客户端真实地址的解析器，为 nil 时不启用 RealIP 中间件。
+		realIP *kitip.Resolver
+		// middlewares 是内层的中间件，位于预置的中间件之后。
+		middlewares []kithttp.Middleware
+		// httpOptions 是传给预置中间件的配置选项。
+		httpOptions []kithttp.Option
+		// logger 是记录服务启停与中间件日志的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+	}
+)
+
+// WithName 设置服务的名称，用于区分同一进程中多个服务的日志。
+//
+// 参数：
+//   - name：服务名称，默认为 http。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithAddr 设置监听地址。
+//
+// 参数：
+//   - addr：监听地址，默认为 :8080，端口为 0 时由系统分配，可以通过 Addr 获取实际地址。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithAddr(addr string) Option {
+	return func(o *options) {
+		o.addr = addr
+	}
+}
+
+// WithListener 使用已经创建的监听器，例如由进程管理器传入的监听器或 kit/net 的 GracefulListener。
+// 设置后 WithAddr 不再生效，Stop 时监听器被关闭。
+//
+// 参数：
+//   - listener：监听器。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithListener(listener net.Listener) Option {
+	return func(o *options) {
+		o.listener = listener
+	}
+}
+
+// WithReadHeaderTimeout 设置读取请求头的超时时间。
+//
+// 参数：
+//   - timeout：超时时间，默认为 5 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithReadHeaderTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.readHeaderTimeout = timeout
+	}
+}
+
+// WithReadTimeout 设置读取整个请求（包括请求体）的超时时间。
+//
+// 参数：
+//   - timeout：超时时间，默认为 0，表示不限制。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithReadTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.readTimeout = timeout
+	}
+}
+
+// WithWriteTimeout 设置写入响应的超时时间，从读取完请求头开始计算。
+// 流式接口（SSE、长轮询）应保持默认值，或改为在处理函数中使用 http.ResponseController 单独设置。
+//
+// 参数：
+//   - timeout：超时时间，默认为 0，表示不限制。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.writeTimeout = timeout
+	}
+}
+
+// WithIdleTimeout 设置 keep-alive 连接的空闲超时时间。
+//
+// 参数：
+//   - timeout：超时时间，默认为 2 分钟，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.idleTimeout = timeout
+	}
+}
+
+// WithMaxHeaderBytes 设置请求头的最大字节数。
+//
+// 参数：
+//   - n：最大字节数，默认为 1MiB，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxHeaderBytes(n int) Option {
+	return func(o *options) {
+		o.maxHeaderBytes = n
+	}
+}
+
+// WithTLS 启用 TLS，证书由 kit/tls 的 Provider 提供，Provider 重新加载证书后新的连接立即使用新证书。
+//
+// 参数：
+//   - provider：证书的 Provider，必须设置了证书，默认不启用 TLS。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTLS(provider *kittls.Provider) Option {
+	return func(o *options) {
+		o.tls = provider
+	}
+}
+
+// WithRecovery 设置是否启用 kit/http 的 Recovery 中间件。
+//
+// 参数：
+//   - enabled：是否启用，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithRecovery(enabled bool) Option {
+	return func(o *options) {
+		o.recovery = enabled
+	}
+}
+
+// WithAccessLog 设置是否启用 kit/http 的 AccessLog 中间件。
+//
+// 参数：
+//   - enabled：是否启用，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithAccessLog(enabled bool) Option {
+	return func(o *options) {
+		o.accessLog = enabled
+	}
+}
+
+// WithRealIP 启用 kit/http 的 RealIP 中间件，它位于 AccessLog 之外，访问日志因此记录 client_ip。
+//
+// 参数：
+//   - resolver：解析客户端真实地址的解析器，默认不启用。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithRealIP(resolver *kitip.Resolver) Option {
+	return func(o *options) {
+		o.realIP = resolver
+	}
+}
+
+// WithMiddleware 追加内层的中间件，位于预置的中间件之后、处理函数之前，可以多次使用。
+//
+// 参数：
+//   - middlewares：中间件，按顺序执行。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMiddleware(middlewares ...kithttp.Middleware) Option {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// WithHTTPOptions 追加传给预置中间件的 kit/http 配置选项，例如 kithttp.WithClock。
+//
+// 参数：
+//   - opts：kit/http 的配置选项。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithHTTPOptions(opts ...kithttp.Option) Option {
+	return func(o *options) {
+		o.httpOptions = append(o.httpOptions, opts...)
+	}
+}
+
+// WithLogger 设置记录服务启停的日志实例，同时用于预置的中间件。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		name:              nameDefault,
+		addr:              addrDefault,
+		readHeaderTimeout: readHeaderTimeoutDefault,
+		readTimeout:       readTimeoutDefault,
+		writeTimeout:      writeTimeoutDefault,
+		idleTimeout:       idleTimeoutDefault,
+		maxHeaderBytes:    maxHeaderBytesDefault,
+		recovery:          recoveryDefault,
+		accessLog:         accessLogDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if "" == o.name {
+		o.name = nameDefault
+	}
+	if "" == o.addr {
+		o.addr = addrDefault
+	}
+	if o.readHeaderTimeout <= 0 {
+		o.readHeaderTimeout = readHeaderTimeoutDefault
+	}
+	if o.readTimeout < 0 {
+		o.readTimeout = readTimeoutDefault
+	}
+	if o.writeTimeout < 0 {
+		o.writeTimeout = writeTimeoutDefault
+	}
+	if o.idleTimeout <= 0 {
+		o.idleTimeout = idleTimeoutDefault
+	}
+	if o.maxHeaderBytes <= 0 {
+		o.maxHeaderBytes = maxHeaderBytesDefault
+	}
+
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
+
+// middlewareOptions 返回传给预置中间件的配置选项，设置了日志实例时传给中间件。
+func (o *options) middlewareOptions() []kithttp.Option {
+	var opts []kithttp.Option
+	if nil != o.logger {
+		opts = append(opts, kithttp.WithLogger(o.logger))
+	}
+	return append(opts, o.httpOptions...)
+}
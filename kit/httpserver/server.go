@@ -0,0 +1,242 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	stdsync "sync"
+
+	kithttp "github.com/fsyyft-go/monorepo/kit/http"
+)
+
+var (
+	// ErrStarted 表示服务已经启动过，服务只能启动一次。
+	ErrStarted = errors.New("kit/httpserver: 服务已经启动")
+	// ErrNotServing 表示服务尚未启动、已经停止或接受连接失败，由 Check 返回。
+	ErrNotServing = errors.New("kit/httpserver: 服务未在运行")
+)
+
+type (
+	// Server 是预置了超时、访问日志与 Recovery 中间件的 HTTP 服务。
+	// Server 实现了 kit/runtime 的 Runner 接口：Start 监听地址后在后台处理请求并立即返回，
+	// Stop 停止接受新连接并等待正在处理的请求完成，截止时间到达时强制关闭剩余连接。
+	Server struct {
+		// o 是服务的配置。
+		o *options
+		// handler 是包装了中间件的处理函数。
+		handler http.Handler
+		// ready 在监听成功后被关闭。
+		ready chan struct{}
+		// done 在后台的处理协程退出后被关闭。
+		done chan struct{}
+
+		// mu 保护以下字段。
+		mu stdsync.Mutex
+		// srv 是底层的 http.Server，Start 之前为 nil。
+		srv *http.Server
+		// ln 是接受连接的监听器，Start 之前为 nil。
+		ln net.Listener
+		// stopped 表示 Stop 已经被调用。
+		stopped bool
+		// err 是处理协程意外退出时的错误。
+		err error
+	}
+)
+
+// New 创建处理 handler 的 HTTP 服务。
+// 预置的中间件从外到内依次为 Recovery、RealIP、AccessLog 与 WithMiddleware 追加的中间件。
+//
+// 参数：
+//   - handler：处理请求的函数，为 nil 时使用 http.DefaultServeMux。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Server：新的服务。
+//
+// 示例：
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("GET /hello", hello)
+//	srv := httpserver.New(mux, httpserver.WithAddr(":8080"))
+//	if err := srv.Start(ctx); nil != err {
+//	    return err
+//	}
+//	defer srv.Stop(shutdownCtx)
+func New(handler http.Handler, opts ...Option) *Server {
+	o := newOptions(opts...)
+	if nil == handler {
+		handler = http.DefaultServeMux
+	}
+
+	var middlewares []kithttp.Middleware
+	if o.recovery {
+		middlewares = append(middlewares, kithttp.Recovery(o.middlewareOptions()...))
+	}
+	if nil != o.realIP {
+		middlewares = append(middlewares, kithttp.RealIP(o.realIP))
+	}
+	if o.accessLog {
+		middlewares = append(middlewares, kithttp.AccessLog(o.middlewareOptions()...))
+	}
+	middlewares = append(middlewares, o.middlewares...)
+
+	return &Server{
+		o:       o,
+		handler: kithttp.Chain(middlewares...)(handler),
+		ready:   make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start 监听地址后在后台协程中处理请求并立即返回，监听失败时返回错误。
+// ctx 被取消时不会停止服务，停止服务需要调用 Stop。
+//
+// 参数：
+//   - ctx：提供监听操作的取消信号，其中的值会传给每个请求的上下文。
+//
+// 返回值：
+//   - error：服务已经启动过时返回 ErrStarted，获取证书或监听失败时返回对应的错误。
+func (s *Server) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if nil != s.srv || s.stopped {
+		return ErrStarted
+	}
+
+	srv := &http.Server{
+		Handler:           s.handler,
+		ReadHeaderTimeout: s.o.readHeaderTimeout,
+		ReadTimeout:       s.o.readTimeout,
+		WriteTimeout:      s.o.writeTimeout,
+		IdleTimeout:       s.o.idleTimeout,
+		MaxHeaderBytes:    s.o.maxHeaderBytes,
+		BaseContext: func(net.Listener) context.Context {
+			return context.WithoutCancel(ctx)
+		},
+	}
+	if nil != s.o.tls {
+		cfg, err := s.o.tls.ServerConfig()
+		if nil != err {
+			return fmt.Errorf("kit/httpserver: 获取 TLS 配置失败：%w", err)
+		}
+		// ServeTLS 只在自己复制的配置上设置 NextProtos，而 Provider 的 GetConfigForClient 复制的是 cfg，
+		// 需要直接在 cfg 上设置，否则设置了 CA 时无法协商 HTTP/2。
+		cfg.NextProtos = []string{"h2", "http/1.1"}
+		srv.TLSConfig = cfg
+	}
+
+	ln := s.o.listener
+	if nil == ln {
+		var lc net.ListenConfig
+		var err error
+		if ln, err = lc.Listen(ctx, "tcp", s.o.addr); nil != err {
+			return fmt.Errorf("kit/httpserver: 监听 %s 失败：%w", s.o.addr, err)
+		}
+	}
+	s.srv = srv
+	s.ln = ln
+
+	logger := s.o.getLogger().WithFields(map[string]interface{}{
+		"name": s.o.name,
+		"addr": ln.Addr().String(),
+		"tls":  nil != srv.TLSConfig,
+	})
+	go func() {
+		defer close(s.done)
+		var err error
+		if nil != srv.TLSConfig {
+			err = srv.ServeTLS(ln, "", "")
+		} else {
+			err = srv.Serve(ln)
+		}
+		if errors.Is(err, http.ErrServerClosed) {
+			return
+		}
+		logger.Error("kit/httpserver: 处理请求失败：", err)
+		s.mu.Lock()
+		s.err = err
+		s.mu.Unlock()
+	}()
+
+	close(s.ready)
+	logger.Info("kit/httpserver: 开始监听")
+	return nil
+}
+
+// Stop 停止接受新连接，关闭空闲连接，并等待正在处理的请求完成。
+// ctx 结束时强制关闭剩余连接并返回 ctx 的错误；服务未启动时直接返回 nil。
+//
+// 参数：
+//   - ctx：提供停止操作的截止时间。
+//
+// 返回值：
+//   - error：所有请求在截止时间前完成时返回 nil，否则返回 ctx 的错误。
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopped = true
+	srv := s.srv
+	s.mu.Unlock()
+	if nil == srv {
+		return nil
+	}
+
+	err := srv.Shutdown(ctx)
+	if nil != err {
+		_ = srv.Close()
+	}
+	<-s.done
+
+	s.o.getLogger().WithField("name", s.o.name).Info("kit/httpserver: 已停止")
+	return err
+}
+
+// Ready 返回在服务监听成功后被关闭的通道，可以用于等待 Start 在其他协程中完成。
+//
+// 返回值：
+//   - <-chan struct{}：监听成功后被关闭的通道。
+func (s *Server) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Addr 返回服务实际监听的地址，WithAddr 的端口为 0 时可以用于获取系统分配的端口。
+//
+// 返回值：
+//   - net.Addr：监听的地址，服务未启动时返回 nil。
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if nil == s.ln {
+		return nil
+	}
+	return s.ln.Addr()
+}
+
+// Check 检查服务是否正在处理请求，实现了 kit/health 的 Checker 接口，可以注册为就绪检查。
+//
+// 参数：
+//   - ctx：检查的上下文，未使用。
+//
+// 返回值：
+//   - error：服务正在处理请求时返回 nil，否则返回包装了 ErrNotServing 的错误。
+//
+// 示例：
+//
+//	h.AddReadiness("http", srv)
+func (s *Server) Check(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case nil != s.err:
+		return fmt.Errorf("%w：%w", ErrNotServing, s.err)
+	case nil == s.srv || s.stopped:
+		return ErrNotServing
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,376 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package httpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kithttp "github.com/fsyyft-go/monorepo/kit/http"
+	kitip "github.com/fsyyft-go/monorepo/kit/ip"
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+	kittls "github.com/fsyyft-go/monorepo/kit/tls"
+)
+
+// startServer 在本机的随机端口上创建并启动服务，测试结束时停止。
+func startServer(t *testing.T, handler http.Handler, opts ...Option) *Server {
+	t.Helper()
+	opts = append([]Option{WithAddr("127.0.0.1:0")}, opts...)
+	s := New(handler, opts...)
+	require.NoError(t, s.Start(context.Background()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = s.Stop(ctx)
+	})
+	return s
+}
+
+// get 请求服务的 path，返回状态码与响应体。
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+// TestServer 测试启动服务、处理请求与停止服务。
+func TestServer(t *testing.T) {
+	logger := logtest.NewRecorder()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "hello")
+	})
+	mux.HandleFunc("/panic", func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	})
+	s := New(mux, WithAddr("127.0.0.1:0"), WithName("api"), WithLogger(logger))
+
+	assert.Nil(t, s.Addr())
+	assert.ErrorIs(t, s.Check(context.Background()), ErrNotServing)
+	select {
+	case <-s.Ready():
+		t.Fatal("启动前不应就绪")
+	default:
+	}
+
+	require.NoError(t, s.Start(context.Background()))
+	<-s.Ready()
+	require.NotNil(t, s.Addr())
+	assert.NoError(t, s.Check(context.Background()))
+	assert.True(t, logger.Contains("开始监听"))
+	assert.True(t, logger.Contains("api"))
+	assert.ErrorIs(t, s.Start(context.Background()), ErrStarted)
+
+	base := "http://" + s.Addr().String()
+	status, body := get(t, http.DefaultClient, base+"/hello")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "hello", body)
+	assert.True(t, logger.Contains("http access"))
+
+	// Recovery 中间件将 panic 转为 500。
+	status, _ = get(t, http.DefaultClient, base+"/panic")
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.True(t, logger.Contains("http handler panic"))
+
+	require.NoError(t, s.Stop(context.Background()))
+	assert.ErrorIs(t, s.Check(context.Background()), ErrNotServing)
+	assert.True(t, logger.Contains("已停止"))
+	assert.ErrorIs(t, s.Start(context.Background()), ErrStarted)
+	_, err := http.Get(base + "/hello")
+	assert.Error(t, err)
+}
+
+// TestServer_Stop 测试停止服务时等待正在处理的请求，截止时间到达时强制关闭连接。
+func TestServer_Stop(t *testing.T) {
+	t.Run("graceful", func(t *testing.T) {
+		entered := make(chan struct{})
+		release := make(chan struct{})
+		s := startServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(entered)
+			<-release
+			_, _ = io.WriteString(w, "done")
+		}), WithLogger(logtest.NewRecorder()))
+
+		result := make(chan string, 1)
+		go func() {
+			resp, err := http.Get("http://" + s.Addr().String())
+			if nil != err {
+				result <- err.Error()
+				return
+			}
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			body, _ := io.ReadAll(resp.Body)
+			result <- string(body)
+		}()
+		<-entered
+
+		stopped := make(chan error, 1)
+		go func() {
+			stopped <- s.Stop(context.Background())
+		}()
+		select {
+		case <-stopped:
+			t.Fatal("请求完成前不应停止")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+		assert.NoError(t, <-stopped)
+		assert.Equal(t, "done", <-result)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		entered := make(chan struct{})
+		s := startServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(entered)
+			<-r.Context().Done()
+		}), WithLogger(logtest.NewRecorder()))
+
+		go func() {
+			resp, err := http.Get("http://" + s.Addr().String())
+			if nil == err {
+				_ = resp.Body.Close()
+			}
+		}()
+		<-entered
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, s.Stop(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("not started", func(t *testing.T) {
+		assert.NoError(t, New(nil).Stop(context.Background()))
+	})
+}
+
+// TestServer_Middleware 测试预置中间件的开关与顺序。
+func TestServer_Middleware(t *testing.T) {
+	logger := logtest.NewRecorder()
+	var order []string
+	mark := func(name string) kithttp.Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	resolver := kitip.NewResolver(kitip.WithTrustedProxies(kitip.MustParseSet("127.0.0.1/32")))
+	s := startServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}), WithLogger(logger), WithRealIP(resolver), WithMiddleware(mark("a")), WithMiddleware(mark("b")))
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+s.Addr().String(), nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, []string{"a", "b"}, order)
+	assert.True(t, logger.Contains("203.0.113.7"))
+
+	// 关闭预置中间件后不记录访问日志。
+	quiet := logtest.NewRecorder()
+	s = startServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}), WithLogger(quiet), WithAccessLog(false), WithRecovery(false))
+	status, _ := get(t, http.DefaultClient, "http://"+s.Addr().String())
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.False(t, quiet.Contains("http access"))
+}
+
+// TestServer_Listener 测试使用已经创建的监听器与监听失败。
+func TestServer_Listener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := startServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}), WithListener(ln), WithLogger(logtest.NewRecorder()))
+	assert.Equal(t, ln.Addr(), s.Addr())
+	status, body := get(t, http.DefaultClient, "http://"+ln.Addr().String())
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", body)
+
+	// 地址已被占用。
+	err = New(nil, WithAddr(ln.Addr().String()), WithLogger(logtest.NewRecorder())).Start(context.Background())
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrStarted)
+}
+
+// TestServer_ServeError 测试处理协程意外退出时 Check 返回错误。
+func TestServer_ServeError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	logger := logtest.NewRecorder()
+	s := startServer(t, nil, WithListener(ln), WithLogger(logger))
+
+	// 在服务之外关闭监听器，接受连接失败。
+	_ = ln.Close()
+	assert.Eventually(t, func() bool {
+		return nil != s.Check(context.Background())
+	}, time.Second, 10*time.Millisecond)
+	assert.ErrorIs(t, s.Check(context.Background()), ErrNotServing)
+	assert.True(t, logger.Contains("处理请求失败"))
+}
+
+// TestServer_TLS 测试通过 kit/tls 提供证书。
+func TestServer_TLS(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile, caFile := ca.writeFiles(t, t.TempDir())
+	provider, err := kittls.New(kittls.WithCertificate(certFile, keyFile), kittls.WithCA(caFile))
+	require.NoError(t, err)
+
+	s := startServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto)
+	}), WithTLS(provider), WithLogger(logtest.NewRecorder()))
+
+	_, port, err := net.SplitHostPort(s.Addr().String())
+	require.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   provider.ClientConfig(),
+		ForceAttemptHTTP2: true,
+	}}
+	status, body := get(t, client, "https://localhost:"+port)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "HTTP/2.0", body)
+
+	// 未设置证书时启动失败。
+	empty, err := kittls.New()
+	require.NoError(t, err)
+	err = New(nil, WithAddr("127.0.0.1:0"), WithTLS(empty)).Start(context.Background())
+	assert.ErrorIs(t, err, kittls.ErrNoCertificate)
+}
+
+// TestNewOptions 测试配置选项的默认值与非法值。
+func TestNewOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, nameDefault, o.name)
+	assert.Equal(t, addrDefault, o.addr)
+	assert.Equal(t, readHeaderTimeoutDefault, o.readHeaderTimeout)
+	assert.Equal(t, idleTimeoutDefault, o.idleTimeout)
+	assert.Equal(t, maxHeaderBytesDefault, o.maxHeaderBytes)
+	assert.True(t, o.recovery)
+	assert.True(t, o.accessLog)
+	assert.Same(t, kitlog.GetLogger(), o.getLogger())
+	assert.Empty(t, o.middlewareOptions())
+
+	o = newOptions(
+		WithName(""),
+		WithAddr(""),
+		WithReadHeaderTimeout(0),
+		WithReadTimeout(-1),
+		WithWriteTimeout(-1),
+		WithIdleTimeout(0),
+		WithMaxHeaderBytes(0),
+	)
+	assert.Equal(t, nameDefault, o.name)
+	assert.Equal(t, addrDefault, o.addr)
+	assert.Equal(t, readHeaderTimeoutDefault, o.readHeaderTimeout)
+	assert.Equal(t, readTimeoutDefault, o.readTimeout)
+	assert.Equal(t, writeTimeoutDefault, o.writeTimeout)
+	assert.Equal(t, idleTimeoutDefault, o.idleTimeout)
+	assert.Equal(t, maxHeaderBytesDefault, o.maxHeaderBytes)
+
+	logger := logtest.NewRecorder()
+	o = newOptions(
+		WithReadTimeout(time.Second),
+		WithWriteTimeout(2*time.Second),
+		WithLogger(logger),
+		WithHTTPOptions(kithttp.WithName("api")),
+	)
+	assert.Equal(t, time.Second, o.readTimeout)
+	assert.Equal(t, 2*time.Second, o.writeTimeout)
+	assert.Same(t, logger, o.getLogger())
+	assert.Len(t, o.middlewareOptions(), 2)
+}
+
+type (
+	// testCA 是测试使用的自签名 CA。
+	testCA struct {
+		cert *x509.Certificate
+		key  *ecdsa.PrivateKey
+		pem  []byte
+	}
+)
+
+// newTestCA 创建测试使用的自签名 CA。
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kit test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// writeFiles 签发 localhost 的证书，并在 dir 中写入证书、私钥与 CA 文件，返回它们的路径。
+func (ca *testCA) writeFiles(t *testing.T, dir string) (string, string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.WriteFile(caFile, ca.pem, 0o600))
+	return certFile, keyFile, caFile
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package id 提供了统一的唯一标识生成与编码，所有组件共享同一种请求标识格式。
+
+主要功能：
+
+  - UUIDv7：按 RFC 9562 生成按时间排序的 UUID，同一进程内严格递增，适合作为数据库主键
+  - 短编码：Short 是 26 位、URL 安全、保持顺序的 Crockford Base32 编码；Compact 是 22 位、只包含数字与字母、保持顺序的 Base62 编码
+  - 解析：Parse 支持标准格式、32 位十六进制、Short 与 Compact 编码
+  - 请求标识：RequestID 生成请求标识，NewContext、FromContext 与 EnsureContext 在 context 中传递请求标识
+
+基本使用：
+
+	u := id.NewV7()
+	fmt.Println(u)         // 0190a6e4-7c5b-7d3e-8f4a-3b2c1d0e9f8a
+	fmt.Println(u.Short()) // 01j2ke8z2vfmz8yjhv5gegx7wa
+
+	parsed, err := id.Parse("01j2ke8z2vfmz8yjhv5gegx7wa")
+	if nil != err {
+	    return err
+	}
+	fmt.Println(parsed == u) // true
+
+请求标识：
+
+	reqID := r.Header.Get(id.HeaderRequestID)
+	if "" == reqID {
+	    reqID = id.RequestID()
+	}
+	ctx := id.NewContext(r.Context(), reqID)
+
+	// 在下游读取请求标识，写入日志。
+	if reqID, ok := id.FromContext(ctx); ok {
+	    logger.WithField(id.LogFieldRequestID, reqID).Info("处理请求")
+	}
+*/
+package id
@@ -0,0 +1,79 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package id
+
+import (
+	"context"
+)
+
+const (
+	// HeaderRequestID 是传递请求标识的 HTTP 头。
+	HeaderRequestID = "X-Request-ID"
+	// LogFieldRequestID 是日志中请求标识的字段名。
+	LogFieldRequestID = "request_id"
+)
+
+type (
+	// requestIDKey 是请求标识在 context 中的键。
+	requestIDKey struct{}
+)
+
+// RequestID 生成新的请求标识。
+// 请求标识是 UUIDv7 的 Short 编码，26 位、URL 安全、按生成时间排序；HTTP 中间件、gRPC 拦截器与日志使用同一格式。
+//
+// 返回值：
+//   - string：新的请求标识。
+//
+// 示例：
+//
+//	reqID := r.Header.Get(id.HeaderRequestID)
+//	if "" == reqID {
+//	    reqID = id.RequestID()
+//	}
+//	ctx := id.NewContext(r.Context(), reqID)
+func RequestID() string {
+	return NewV7().Short()
+}
+
+// NewContext 返回携带请求标识的 context。
+//
+// 参数：
+//   - ctx：父 context。
+//   - requestID：请求标识。
+//
+// 返回值：
+//   - context.Context：携带请求标识的 context。
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// FromContext 返回 context 中的请求标识。
+//
+// 参数：
+//   - ctx：context。
+//
+// 返回值：
+//   - string：请求标识。
+//   - bool：context 中有请求标识时返回 true。
+func FromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok && "" != requestID
+}
+
+// EnsureContext 返回携带请求标识的 context，context 中没有请求标识时生成新的请求标识。
+//
+// 参数：
+//   - ctx：父 context。
+//
+// 返回值：
+//   - context.Context：携带请求标识的 context。
+//   - string：请求标识。
+func EnsureContext(ctx context.Context) (context.Context, string) {
+	if requestID, ok := FromContext(ctx); ok {
+		return ctx, requestID
+	}
+	requestID := RequestID()
+	return NewContext(ctx, requestID), requestID
+}
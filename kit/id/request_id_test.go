@@ -0,0 +1,47 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package id
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRequestID 测试请求标识的格式。
+func TestRequestID(t *testing.T) {
+	a, b := RequestID(), RequestID()
+	assert.Len(t, a, shortLen)
+	assert.NotEqual(t, a, b)
+
+	u, err := Parse(a)
+	require.NoError(t, err)
+	assert.Equal(t, 7, u.Version())
+}
+
+// TestRequestID_Context 测试在 context 中传递请求标识。
+func TestRequestID_Context(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	_, ok = FromContext(NewContext(context.Background(), ""))
+	assert.False(t, ok, "空的请求标识视为不存在")
+
+	ctx := NewContext(context.Background(), "req-1")
+	got, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "req-1", got)
+
+	ensured, got := EnsureContext(ctx)
+	assert.Equal(t, ctx, ensured, "已有请求标识时不生成新的")
+	assert.Equal(t, "req-1", got)
+
+	ensured, got = EnsureContext(context.Background())
+	assert.Len(t, got, shortLen)
+	fromCtx, _ := FromContext(ensured)
+	assert.Equal(t, got, fromCtx)
+}
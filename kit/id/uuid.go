@@ -0,0 +1,298 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	kitencoding "github.com/fsyyft-go/monorepo/kit/encoding"
+)
+
+const (
+	// crockford 是 Crockford Base32 的字母表，按 ASCII 顺序排列，编码结果的字典序与 UUID 的字节序一致。
+	crockford = "0123456789abcdefghjkmnpqrstvwxyz"
+	// shortLen 是 Short 编码的长度。
+	shortLen = 26
+	// compactLen 是 Compact 编码的长度，UUID 的高、低 64 位各占 compactHalfLen 位。
+	compactLen = 2 * compactHalfLen
+	// compactHalfLen 是 11 位 Base62，足以表示任意 uint64。
+	compactHalfLen = 11
+	// maxSeq 是同一毫秒内序号的最大值，序号占用 UUIDv7 的 12 位 rand_a。
+	maxSeq = 0xfff
+)
+
+var (
+	// ErrInvalidUUID 表示字符串不是合法的 UUID。
+	ErrInvalidUUID = errors.New("不是合法的 UUID")
+
+	// Nil 是所有位都为 0 的 UUID。
+	Nil UUID
+
+	// generatorDefault 是默认的 UUIDv7 生成器。
+	generatorDefault = newGenerator(time.Now, rand.Reader)
+
+	// crockfordIndex 是 Crockford Base32 字符到值的映射，不合法的字符为 0xff。
+	crockfordIndex = func() [256]byte {
+		var idx [256]byte
+		for i := range idx {
+			idx[i] = 0xff
+		}
+		for i := 0; i < len(crockford); i++ {
+			idx[crockford[i]] = byte(i)
+			idx[strings.ToUpper(crockford[i : i+1])[0]] = byte(i)
+		}
+		return idx
+	}()
+)
+
+type (
+	// UUID 是 RFC 9562 定义的 128 位通用唯一标识符。
+	UUID [16]byte
+
+	// generator 生成单调递增的 UUIDv7。
+	generator struct {
+		// mu 保护 lastMs 与 seq。
+		mu sync.Mutex
+		// lastMs 是上一次生成使用的毫秒时间戳。
+		lastMs int64
+		// seq 是同一毫秒内的序号。
+		seq uint16
+		// now 返回当前时间。
+		now func() time.Time
+		// rand 是随机数来源。
+		rand io.Reader
+	}
+)
+
+// NewV7 生成按时间排序的 UUIDv7。
+// 前 48 位是毫秒级 Unix 时间戳，同一进程内生成的 UUID 严格递增，适合作为数据库主键与请求标识。
+//
+// 返回值：
+//   - UUID：新生成的 UUID。
+//
+// 示例：
+//
+//	u := id.NewV7()
+//	fmt.Println(u)         // 0190a6e4-7c5b-7d3e-8f4a-3b2c1d0e9f8a
+//	fmt.Println(u.Short()) // 01j2ke8z2vfmz8yjhv5gegx7wa
+func NewV7() UUID {
+	return generatorDefault.next()
+}
+
+// newGenerator 创建 UUIDv7 生成器。
+func newGenerator(now func() time.Time, r io.Reader) *generator {
+	return &generator{now: now, rand: r}
+}
+
+// next 生成下一个 UUIDv7。
+// 同一毫秒内使用递增的序号；序号用尽或时钟回拨时沿用上一次的时间戳继续递增，保证单调。
+func (g *generator) next() UUID {
+	var u UUID
+	if _, err := io.ReadFull(g.rand, u[6:]); nil != err {
+		panic("kit/id: 读取随机数失败：" + err.Error())
+	}
+
+	g.mu.Lock()
+	ms := g.now().UnixMilli()
+	if ms > g.lastMs {
+		g.lastMs = ms
+		// 新的毫秒使用随机的起始序号，最高位置 0 为同一毫秒内的递增预留空间。
+		g.seq = binary.BigEndian.Uint16(u[6:8]) & (maxSeq >> 1)
+	} else {
+		g.seq++
+		if g.seq > maxSeq {
+			g.lastMs++
+			g.seq = 0
+		}
+	}
+	ms, seq := g.lastMs, g.seq
+	g.mu.Unlock()
+
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+	u[6] = 0x70 | byte(seq>>8)
+	u[7] = byte(seq)
+	u[8] = 0x80 | u[8]&0x3f
+	return u
+}
+
+// Parse 解析 UUID，支持标准格式（36 位，带连字符）、32 位十六进制、Short（26 位）与 Compact（22 位）编码。
+//
+// 参数：
+//   - s：要解析的字符串。
+//
+// 返回值：
+//   - UUID：解析得到的 UUID。
+//   - error：格式不合法时返回 ErrInvalidUUID。
+func Parse(s string) (UUID, error) {
+	var u UUID
+	switch len(s) {
+	case 36:
+		if '-' != s[8] || '-' != s[13] || '-' != s[18] || '-' != s[23] {
+			return Nil, ErrInvalidUUID
+		}
+		s = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+		fallthrough
+	case 32:
+		if _, err := hex.Decode(u[:], []byte(s)); nil != err {
+			return Nil, ErrInvalidUUID
+		}
+	case shortLen:
+		return decodeShort(s)
+	case compactLen:
+		hi, err := kitencoding.Base62.DecodeUint64(s[:compactHalfLen])
+		if nil != err {
+			return Nil, ErrInvalidUUID
+		}
+		lo, err := kitencoding.Base62.DecodeUint64(s[compactHalfLen:])
+		if nil != err {
+			return Nil, ErrInvalidUUID
+		}
+		binary.BigEndian.PutUint64(u[0:8], hi)
+		binary.BigEndian.PutUint64(u[8:16], lo)
+	default:
+		return Nil, ErrInvalidUUID
+	}
+	return u, nil
+}
+
+// MustParse 解析 UUID，格式不合法时引发 panic，用于解析常量。
+//
+// 参数：
+//   - s：要解析的字符串。
+//
+// 返回值：
+//   - UUID：解析得到的 UUID。
+func MustParse(s string) UUID {
+	u, err := Parse(s)
+	if nil != err {
+		panic("kit/id: " + err.Error() + "：" + s)
+	}
+	return u
+}
+
+// String 返回标准格式，例如 0190a6e4-7c5b-7d3e-8f4a-3b2c1d0e9f8a。
+//
+// 返回值：
+//   - string：36 位带连字符的小写十六进制字符串。
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// Short 返回 26 位的 Crockford Base32 编码。
+// 编码只包含小写字母与数字，可以安全地用于 URL、文件名与日志；编码结果的字典序与 UUID 的先后顺序一致。
+//
+// 返回值：
+//   - string：26 位编码。
+func (u UUID) Short() string {
+	var buf [shortLen]byte
+	// 128 位按 5 位一组编码，最前面补 2 位 0，共 130 位。
+	hi := binary.BigEndian.Uint64(u[0:8])
+	lo := binary.BigEndian.Uint64(u[8:16])
+	for i := shortLen - 1; i >= 0; i-- {
+		buf[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
+
+// Compact 返回 22 位的 Base62 编码，是最短的文本表示，只包含数字与字母，但区分大小写。
+// 高、低 64 位分别使用 kit/encoding 的 Base62 编码为补齐的 11 位，编码结果的字典序与 UUID 的先后顺序一致。
+//
+// 返回值：
+//   - string：22 位编码。
+func (u UUID) Compact() string {
+	buf := make([]byte, 0, compactLen)
+	buf = kitencoding.Base62.AppendUint64(buf, binary.BigEndian.Uint64(u[0:8]), compactHalfLen)
+	buf = kitencoding.Base62.AppendUint64(buf, binary.BigEndian.Uint64(u[8:16]), compactHalfLen)
+	return string(buf)
+}
+
+// Version 返回 UUID 的版本号，UUIDv7 返回 7。
+//
+// 返回值：
+//   - int：版本号。
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Time 返回 UUIDv7 中记录的生成时间，精确到毫秒。
+//
+// 返回值：
+//   - time.Time：生成时间，不是 UUIDv7 时返回零值。
+func (u UUID) Time() time.Time {
+	if 7 != u.Version() {
+		return time.Time{}
+	}
+	ms := int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 | int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+	return time.UnixMilli(ms)
+}
+
+// IsZero 判断是否为 Nil。
+//
+// 返回值：
+//   - bool：所有位都为 0 时返回 true。
+func (u UUID) IsZero() bool {
+	return Nil == u
+}
+
+// MarshalText 实现了 encoding.TextMarshaler 接口，使用标准格式。
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText 实现了 encoding.TextUnmarshaler 接口，支持 Parse 接受的全部格式。
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if nil != err {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// decodeShort 解析 Short 编码。
+func decodeShort(s string) (UUID, error) {
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := crockfordIndex[s[i]]
+		if 0xff == v {
+			return Nil, ErrInvalidUUID
+		}
+		// 第一个字符只能包含最高 3 位中的有效数据，超出 128 位时不合法。
+		if hi>>59 != 0 {
+			return Nil, ErrInvalidUUID
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+
+	var u UUID
+	binary.BigEndian.PutUint64(u[0:8], hi)
+	binary.BigEndian.PutUint64(u[8:16], lo)
+	return u, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package id
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewV7 测试 UUIDv7 的版本、变体与时间。
+func TestNewV7(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	u := NewV7()
+	after := time.Now()
+
+	assert.Equal(t, 7, u.Version())
+	assert.Equal(t, byte(0x80), u[8]&0xc0, "变体为 RFC 9562")
+	assert.False(t, u.IsZero())
+	assert.False(t, u.Time().Before(before))
+	assert.False(t, u.Time().After(after))
+}
+
+// TestNewV7_Monotonic 测试同一毫秒内与时钟回拨时仍然严格递增。
+func TestNewV7_Monotonic(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	g := newGenerator(func() time.Time { return now }, rand.Reader)
+
+	prev := g.next()
+	// 超过序号上限，时间戳借位递增。
+	for i := 0; i < 2*maxSeq; i++ {
+		if 1000 == i {
+			now = now.Add(-time.Second)
+		}
+		u := g.next()
+		require.Equal(t, 1, bytes.Compare(u[:], prev[:]), "第 %d 个 UUID 应大于前一个", i)
+		require.Equal(t, 1, bytes.Compare([]byte(u.Short()), []byte(prev.Short())), "Short 编码保持顺序")
+		prev = u
+	}
+}
+
+// TestNewV7_Concurrent 测试并发生成不重复。
+func TestNewV7_Concurrent(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		seen = make(map[UUID]struct{})
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := make([]UUID, 0, 1000)
+			for j := 0; j < 1000; j++ {
+				local = append(local, NewV7())
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, u := range local {
+				seen[u] = struct{}{}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 8000)
+}
+
+// TestParse 测试各种格式的解析。
+func TestParse(t *testing.T) {
+	u := NewV7()
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "标准格式", input: u.String()},
+		{name: "大写标准格式", input: strings.ToUpper(u.String())},
+		{name: "十六进制", input: strings.ReplaceAll(u.String(), "-", "")},
+		{name: "Short", input: u.Short()},
+		{name: "大写 Short", input: strings.ToUpper(u.Short())},
+		{name: "Compact", input: u.Compact()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, u, parsed)
+		})
+	}
+}
+
+// TestParse_Invalid 测试不合法的输入。
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"not-a-uuid",
+		"0190a6e4x7c5b-7d3e-8f4a-3b2c1d0e9f8a",
+		"0190a6e4-7c5b-7d3e-8f4a-3b2c1d0e9f8g",
+		"8zzzzzzzzzzzzzzzzzzzzzzzzz",
+		"01j2ke8z2v7mz8z2hv5gehx7wu",
+		"!!!!!!!!!!!!!!!!!!!!!!",
+		"AZCm5HxbfT6PSjssHQ6f-g",
+		"zzzzzzzzzzz00000000000",
+	} {
+		_, err := Parse(input)
+		assert.ErrorIs(t, err, ErrInvalidUUID, input)
+	}
+	assert.Panics(t, func() { MustParse("bad") })
+}
+
+// TestUUID_Encoding 测试已知值的编码。
+func TestUUID_Encoding(t *testing.T) {
+	u := MustParse("0190a6e4-7c5b-7d3e-8f4a-3b2c1d0e9f8a")
+	assert.Equal(t, "0190a6e4-7c5b-7d3e-8f4a-3b2c1d0e9f8a", u.String())
+	assert.Len(t, u.Short(), shortLen)
+	assert.Equal(t, "08KVEY4X114CIjCkY0v5b8", u.Compact())
+	assert.Equal(t, time.UnixMilli(0x0190a6e47c5b), u.Time())
+
+	allOnes := MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+	assert.Equal(t, "7zzzzzzzzzzzzzzzzzzzzzzzzz", allOnes.Short())
+	assert.Equal(t, "00000000000000000000000000", Nil.Short())
+	assert.Equal(t, "LygHa16AHYFLygHa16AHYF", allOnes.Compact())
+	assert.Equal(t, "0000000000000000000000", Nil.Compact())
+	assert.True(t, Nil.Time().IsZero(), "不是 UUIDv7 时返回零值")
+}
+
+// TestUUID_JSON 测试文本序列化。
+func TestUUID_JSON(t *testing.T) {
+	type payload struct {
+		ID UUID `json:"id"`
+	}
+	in := payload{ID: NewV7()}
+	data, err := json.Marshal(in)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), in.ID.String())
+
+	var out payload
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, in, out)
+	assert.Error(t, json.Unmarshal([]byte(`{"id":"bad"}`), &out))
+}
+
+// TestUUID_SortByTime 测试 Short 与 Compact 编码按生成时间排序。
+func TestUUID_SortByTime(t *testing.T) {
+	var shorts, compacts []string
+	for i := 0; i < 100; i++ {
+		u := NewV7()
+		shorts = append(shorts, u.Short())
+		compacts = append(compacts, u.Compact())
+	}
+	assert.True(t, sort.StringsAreSorted(shorts))
+	assert.True(t, sort.StringsAreSorted(compacts))
+}
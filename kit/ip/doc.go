@@ -0,0 +1,28 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package ip 提供了地址解析、分类、CIDR 集合与客户端真实地址解析的工具。
+
+地址统一使用 net/netip 的 netip.Addr 表示。Parse 比 netip.ParseAddr 更宽松，
+可以直接解析请求头与 http.Request.RemoteAddr 中带端口、方括号或区域的地址：
+
+	addr, err := ip.Parse("[::ffff:10.0.0.1]:8080") // 10.0.0.1
+	ip.Classify(addr)                                // ip.ClassPrivate
+
+Set 是由 CIDR、单个地址与地址范围组成的不可变集合，适合保存白名单与可信代理：
+
+	trusted := ip.MustParseSet("10.0.0.0/8", "192.168.1.10", "172.16.0.1-172.16.0.9")
+	trusted.ContainsString("10.1.2.3") // true
+
+Resolver 根据可信代理解析客户端的真实地址。只有直接连接的地址属于可信代理时才读取
+X-Forwarded-For 等请求头，避免客户端伪造：
+
+	resolver := ip.NewResolver(ip.WithTrustedProxies(ip.LocalNetworks()))
+	client := resolver.ClientIP(r)
+
+kit/http 的 RealIP 中间件使用 Resolver 解析客户端地址，并通过 NewContext 写入请求上下文，
+之后可以通过 FromContext 读取。
+*/
+package ip
@@ -0,0 +1,170 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ip
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+const (
+	// ClassInvalid 表示无效的地址。
+	ClassInvalid Class = "invalid"
+	// ClassUnspecified 表示未指定地址，例如 0.0.0.0 与 ::。
+	ClassUnspecified Class = "unspecified"
+	// ClassLoopback 表示环回地址，例如 127.0.0.1 与 ::1。
+	ClassLoopback Class = "loopback"
+	// ClassPrivate 表示私有地址，即 RFC 1918 与 RFC 4193 定义的地址。
+	ClassPrivate Class = "private"
+	// ClassShared 表示运营商级 NAT 使用的共享地址 100.64.0.0/10。
+	ClassShared Class = "shared"
+	// ClassLinkLocal 表示链路本地地址，例如 169.254.0.0/16 与 fe80::/10。
+	ClassLinkLocal Class = "link_local"
+	// ClassMulticast 表示组播地址。
+	ClassMulticast Class = "multicast"
+	// ClassReserved 表示文档、测试或保留用途的地址，例如 192.0.2.0/24 与 2001:db8::/32。
+	ClassReserved Class = "reserved"
+	// ClassPublic 表示公网地址。
+	ClassPublic Class = "public"
+)
+
+var (
+	// ErrInvalid 表示无法解析的地址或地址范围。
+	ErrInvalid = errors.New("kit/ip: 无效的地址")
+
+	// sharedSet 是共享地址。
+	sharedSet = MustParseSet("100.64.0.0/10")
+	// reservedSet 是文档、测试与保留用途的地址。
+	reservedSet = MustParseSet(
+		"0.0.0.0/8",
+		"192.0.0.0/24",
+		"192.0.2.0/24",
+		"198.18.0.0/15",
+		"198.51.100.0/24",
+		"203.0.113.0/24",
+		"240.0.0.0/4",
+		"100::/64",
+		"2001:db8::/32",
+	)
+)
+
+type (
+	// Class 是地址的分类。
+	Class string
+)
+
+// String 返回分类的名称。
+func (c Class) String() string {
+	return string(c)
+}
+
+// Parse 解析地址，比 netip.ParseAddr 更宽松，适用于请求头与配置中的地址。
+// 忽略首尾空白与引号、端口、IPv6 的方括号与区域，IPv4 映射的 IPv6 地址转换为 IPv4 地址。
+//
+// 参数：
+//   - s：地址，例如 "10.0.0.1"、"10.0.0.1:8080"、"[::1]:8080" 与 "::ffff:10.0.0.1"。
+//
+// 返回值：
+//   - netip.Addr：解析后的地址。
+//   - error：无法解析时返回包装了 ErrInvalid 的错误。
+func Parse(s string) (netip.Addr, error) {
+	raw := s
+	s = strings.Trim(strings.TrimSpace(s), `"`)
+	addr, err := netip.ParseAddr(s)
+	if nil != err {
+		if ap, apErr := netip.ParseAddrPort(s); nil == apErr {
+			addr, err = ap.Addr(), nil
+		} else if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+			addr, err = netip.ParseAddr(s[1 : len(s)-1])
+		}
+	}
+	if nil != err {
+		return netip.Addr{}, fmt.Errorf("%w：%q", ErrInvalid, raw)
+	}
+	return normalize(addr), nil
+}
+
+// Contains 返回地址是否属于 CIDR，任一参数无法解析时返回 false。
+//
+// 参数：
+//   - cidr：CIDR，例如 "10.0.0.0/8"，也可以是单个地址。
+//   - s：地址，解析规则同 Parse。
+//
+// 返回值：
+//   - bool：地址是否属于 CIDR。
+func Contains(cidr, s string) bool {
+	set, err := ParseSet(cidr)
+	if nil != err {
+		return false
+	}
+	return set.ContainsString(s)
+}
+
+// Classify 返回地址的分类。
+//
+// 参数：
+//   - addr：地址，IPv4 映射的 IPv6 地址按 IPv4 地址分类。
+//
+// 返回值：
+//   - Class：地址的分类。
+//
+// 示例：
+//
+//	ip.Classify(netip.MustParseAddr("10.1.2.3")) // ClassPrivate
+//	ip.Classify(netip.MustParseAddr("8.8.8.8"))  // ClassPublic
+func Classify(addr netip.Addr) Class {
+	if !addr.IsValid() {
+		return ClassInvalid
+	}
+	addr = normalize(addr)
+	switch {
+	case addr.IsUnspecified():
+		return ClassUnspecified
+	case addr.IsLoopback():
+		return ClassLoopback
+	case addr.IsMulticast():
+		return ClassMulticast
+	case addr.IsLinkLocalUnicast():
+		return ClassLinkLocal
+	case addr.IsPrivate():
+		return ClassPrivate
+	case sharedSet.Contains(addr):
+		return ClassShared
+	case reservedSet.Contains(addr) || !addr.IsGlobalUnicast():
+		return ClassReserved
+	default:
+		return ClassPublic
+	}
+}
+
+// IsPrivate 返回地址是否为私有地址，即 10.0.0.0/8、172.16.0.0/12、192.168.0.0/16 与 fc00::/7。
+// 环回地址与链路本地地址不是私有地址。
+//
+// 参数：
+//   - addr：地址。
+//
+// 返回值：
+//   - bool：是否为私有地址。
+func IsPrivate(addr netip.Addr) bool {
+	return ClassPrivate == Classify(addr)
+}
+
+// IsPublic 返回地址是否为公网地址。
+//
+// 参数：
+//   - addr：地址。
+//
+// 返回值：
+//   - bool：是否为公网地址。
+func IsPublic(addr netip.Addr) bool {
+	return ClassPublic == Classify(addr)
+}
+
+// normalize 去掉区域，并将 IPv4 映射的 IPv6 地址转换为 IPv4 地址。
+func normalize(addr netip.Addr) netip.Addr {
+	return addr.WithZone("").Unmap()
+}
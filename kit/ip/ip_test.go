@@ -0,0 +1,86 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ip
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParse 测试宽松的地址解析。
+func TestParse(t *testing.T) {
+	for input, want := range map[string]string{
+		"10.0.0.1":            "10.0.0.1",
+		" 10.0.0.1 ":          "10.0.0.1",
+		`"10.0.0.1"`:          "10.0.0.1",
+		"10.0.0.1:8080":       "10.0.0.1",
+		"::1":                 "::1",
+		"[::1]":               "::1",
+		"[::1]:8080":          "::1",
+		"::ffff:10.0.0.1":     "10.0.0.1",
+		"fe80::1%eth0":        "fe80::1",
+		"[fe80::1%eth0]:8080": "fe80::1",
+	} {
+		addr, err := Parse(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, addr.String(), input)
+	}
+
+	for _, input := range []string{"", "unknown", "10.0.0", "10.0.0.1:port", "[10.0.0.1"} {
+		_, err := Parse(input)
+		assert.ErrorIs(t, err, ErrInvalid, input)
+	}
+}
+
+// TestContains 测试 CIDR 包含关系。
+func TestContains(t *testing.T) {
+	assert.True(t, Contains("10.0.0.0/8", "10.1.2.3"))
+	assert.True(t, Contains("10.0.0.0/8", "::ffff:10.1.2.3"))
+	assert.False(t, Contains("10.0.0.0/8", "11.0.0.1"))
+	assert.True(t, Contains("2001:db8::/32", "2001:db8::1"))
+	assert.True(t, Contains("192.168.1.1", "192.168.1.1"))
+	assert.False(t, Contains("invalid", "10.0.0.1"))
+	assert.False(t, Contains("10.0.0.0/8", "invalid"))
+}
+
+// TestClassify 测试地址分类。
+func TestClassify(t *testing.T) {
+	for input, want := range map[string]Class{
+		"0.0.0.0":         ClassUnspecified,
+		"::":              ClassUnspecified,
+		"127.0.0.1":       ClassLoopback,
+		"::1":             ClassLoopback,
+		"10.1.2.3":        ClassPrivate,
+		"172.16.0.1":      ClassPrivate,
+		"192.168.1.1":     ClassPrivate,
+		"fd00::1":         ClassPrivate,
+		"::ffff:10.0.0.1": ClassPrivate,
+		"100.64.0.1":      ClassShared,
+		"169.254.1.1":     ClassLinkLocal,
+		"fe80::1":         ClassLinkLocal,
+		"224.0.0.1":       ClassMulticast,
+		"ff02::1":         ClassMulticast,
+		"192.0.2.1":       ClassReserved,
+		"198.18.0.1":      ClassReserved,
+		"240.0.0.1":       ClassReserved,
+		"255.255.255.255": ClassReserved,
+		"2001:db8::1":     ClassReserved,
+		"8.8.8.8":         ClassPublic,
+		"2606:4700::1111": ClassPublic,
+	} {
+		addr := netip.MustParseAddr(input)
+		assert.Equal(t, want, Classify(addr), input)
+	}
+	assert.Equal(t, ClassInvalid, Classify(netip.Addr{}))
+	assert.Equal(t, "public", ClassPublic.String())
+
+	assert.True(t, IsPrivate(netip.MustParseAddr("10.0.0.1")))
+	assert.False(t, IsPrivate(netip.MustParseAddr("127.0.0.1")))
+	assert.True(t, IsPublic(netip.MustParseAddr("1.1.1.1")))
+	assert.False(t, IsPublic(netip.MustParseAddr("100.64.0.1")))
+}
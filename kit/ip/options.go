@@ -0,0 +1,68 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ip
+
+// 以下为解析客户端地址的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// headersDefault 为默认读取客户端地址的请求头。
+	headersDefault = []string{"X-Forwarded-For", "X-Real-Ip"}
+)
+
+type (
+	// Option 定义了解析客户端地址的配置选项。
+	Option func(*options)
+
+	// options 包含解析客户端地址的配置。
+	options struct {
+		// trusted 是可信代理的地址集合。
+		trusted *Set
+		// headers 是读取客户端地址的请求头，按顺序尝试。
+		headers []string
+	}
+)
+
+// WithTrustedProxies 设置可信代理的地址集合。
+// 只有直接连接的地址属于可信代理时才读取请求头，请求头中属于可信代理的地址被跳过。
+//
+// 参数：
+//   - trusted：可信代理的地址集合，例如 LocalNetworks()，默认为空，即不信任任何请求头。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTrustedProxies(trusted *Set) Option {
+	return func(o *options) {
+		o.trusted = trusted
+	}
+}
+
+// WithHeaders 设置读取客户端地址的请求头，按顺序尝试，每个请求头可以是逗号分隔的地址列表。
+//
+// 参数：
+//   - headers：请求头，默认为 X-Forwarded-For 与 X-Real-Ip，为空时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithHeaders(headers ...string) Option {
+	return func(o *options) {
+		o.headers = headers
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		headers: headersDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if 0 == len(o.headers) {
+		o.headers = headersDefault
+	}
+
+	return o
+}
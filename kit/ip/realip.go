@@ -0,0 +1,129 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ip
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+type (
+	// Resolver 根据直接连接的地址与代理添加的请求头解析客户端的真实地址。
+	// 创建后不可修改，可以被多个协程并发使用。
+	Resolver struct {
+		// o 是解析的配置。
+		o *options
+	}
+
+	// clientIPKey 是 context 中客户端地址的键。
+	clientIPKey struct{}
+)
+
+// NewResolver 创建客户端地址解析器。
+//
+// 参数：
+//   - opts：配置选项，未设置可信代理时总是返回直接连接的地址。
+//
+// 返回值：
+//   - *Resolver：解析器实例。
+//
+// 示例：
+//
+//	resolver := ip.NewResolver(ip.WithTrustedProxies(ip.LocalNetworks()))
+//	client := resolver.ClientIP(r)
+func NewResolver(opts ...Option) *Resolver {
+	return &Resolver{o: newOptions(opts...)}
+}
+
+// ClientIP 返回 HTTP 请求的客户端地址，同 ClientIPFrom(r.RemoteAddr, r.Header)。
+//
+// 参数：
+//   - req：HTTP 请求。
+//
+// 返回值：
+//   - netip.Addr：客户端地址，无法解析时为零值。
+func (r *Resolver) ClientIP(req *http.Request) netip.Addr {
+	return r.ClientIPFrom(req.RemoteAddr, req.Header)
+}
+
+// ClientIPFrom 返回客户端地址。
+// 直接连接的地址不属于可信代理时返回该地址，请求头被忽略，避免客户端伪造。
+// 否则按顺序读取请求头，从右向左跳过属于可信代理的地址，第一个不可信的地址即为客户端地址；
+// 全部属于可信代理时返回最左边的地址；遇到无法解析的地址时尝试下一个请求头。
+// 所有请求头都没有结果时返回直接连接的地址。
+//
+// 参数：
+//   - remoteAddr：直接连接的地址，例如 http.Request.RemoteAddr。
+//   - header：请求头。
+//
+// 返回值：
+//   - netip.Addr：客户端地址，无法解析时为零值。
+func (r *Resolver) ClientIPFrom(remoteAddr string, header http.Header) netip.Addr {
+	remote, err := Parse(remoteAddr)
+	if nil != err {
+		return netip.Addr{}
+	}
+	if !r.o.trusted.Contains(remote) {
+		return remote
+	}
+
+	for _, name := range r.o.headers {
+		if addr, ok := r.fromHeader(header.Values(name)); ok {
+			return addr
+		}
+	}
+	return remote
+}
+
+// fromHeader 从请求头的值中解析客户端地址。
+func (r *Resolver) fromHeader(values []string) (netip.Addr, bool) {
+	var hops []string
+	for _, v := range values {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+
+	var last netip.Addr
+	for i := len(hops) - 1; i >= 0; i-- {
+		if "" == strings.TrimSpace(hops[i]) {
+			continue
+		}
+		addr, err := Parse(hops[i])
+		if nil != err {
+			return netip.Addr{}, false
+		}
+		if !r.o.trusted.Contains(addr) {
+			return addr, true
+		}
+		last = addr
+	}
+	return last, last.IsValid()
+}
+
+// NewContext 返回携带客户端地址的 context。
+//
+// 参数：
+//   - ctx：父 context。
+//   - addr：客户端地址。
+//
+// 返回值：
+//   - context.Context：携带客户端地址的 context。
+func NewContext(ctx context.Context, addr netip.Addr) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, addr)
+}
+
+// FromContext 返回 context 中的客户端地址。
+//
+// 参数：
+//   - ctx：context。
+//
+// 返回值：
+//   - netip.Addr：客户端地址。
+//   - bool：context 中有有效的客户端地址时返回 true。
+func FromContext(ctx context.Context) (netip.Addr, bool) {
+	addr, ok := ctx.Value(clientIPKey{}).(netip.Addr)
+	return addr, ok && addr.IsValid()
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResolver 测试从请求头解析客户端地址。
+func TestResolver(t *testing.T) {
+	r := NewResolver(WithTrustedProxies(MustParseSet("10.0.0.0/8")))
+
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string][]string
+		want    string
+	}{
+		{"直接连接", "1.2.3.4:5678", nil, "1.2.3.4"},
+		{"不可信的连接忽略请求头", "1.2.3.4:5678", map[string][]string{"X-Forwarded-For": {"5.6.7.8"}}, "1.2.3.4"},
+		{"可信代理", "10.0.0.1:80", map[string][]string{"X-Forwarded-For": {"5.6.7.8"}}, "5.6.7.8"},
+		{"跳过可信代理", "10.0.0.1:80", map[string][]string{"X-Forwarded-For": {"9.9.9.9, 5.6.7.8, 10.0.0.2"}}, "5.6.7.8"},
+		{"多个请求头", "10.0.0.1:80", map[string][]string{"X-Forwarded-For": {"5.6.7.8", "10.0.0.3"}}, "5.6.7.8"},
+		{"全部可信", "10.0.0.1:80", map[string][]string{"X-Forwarded-For": {"10.0.0.5, 10.0.0.2"}}, "10.0.0.5"},
+		{"无效地址时尝试下一个请求头", "10.0.0.1:80", map[string][]string{"X-Forwarded-For": {"unknown"}, "X-Real-Ip": {"5.6.7.8"}}, "5.6.7.8"},
+		{"空的元素被跳过", "10.0.0.1:80", map[string][]string{"X-Forwarded-For": {"5.6.7.8, "}}, "5.6.7.8"},
+		{"没有请求头", "10.0.0.1:80", nil, "10.0.0.1"},
+		{"IPv6", "[::ffff:10.0.0.1]:80", map[string][]string{"X-Forwarded-For": {"[2001:db8::1]:1234"}}, "2001:db8::1"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tt.remote
+		for k, vs := range tt.headers {
+			for _, v := range vs {
+				req.Header.Add(k, v)
+			}
+		}
+		assert.Equal(t, tt.want, r.ClientIP(req).String(), tt.name)
+	}
+
+	assert.False(t, r.ClientIPFrom("pipe", nil).IsValid())
+}
+
+// TestResolverHeaders 测试自定义请求头与默认配置。
+func TestResolverHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Forwarded-For", "5.6.7.8")
+	header.Set("Cf-Connecting-Ip", "9.9.9.9")
+
+	r := NewResolver(WithTrustedProxies(LocalNetworks()), WithHeaders("Cf-Connecting-Ip"))
+	assert.Equal(t, "9.9.9.9", r.ClientIPFrom("127.0.0.1:80", header).String())
+
+	// 默认不信任任何代理。
+	assert.Equal(t, "127.0.0.1", NewResolver().ClientIPFrom("127.0.0.1:80", header).String())
+
+	o := newOptions(WithHeaders())
+	assert.Equal(t, headersDefault, o.headers)
+	assert.Nil(t, o.trusted)
+}
+
+// TestContext 测试在 context 中传递客户端地址。
+func TestContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	addr := netip.MustParseAddr("1.2.3.4")
+	got, ok := FromContext(NewContext(context.Background(), addr))
+	assert.True(t, ok)
+	assert.Equal(t, addr, got)
+
+	_, ok = FromContext(NewContext(context.Background(), netip.Addr{}))
+	assert.False(t, ok)
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ip
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+type (
+	// Set 是地址的集合，由 CIDR、单个地址与地址范围组成。
+	// 创建后不可修改，可以被多个协程并发使用；查找的时间复杂度为 O(log n)。
+	Set struct {
+		// ranges 是按起始地址排序、互不重叠且不相邻的地址范围。
+		ranges []addrRange
+	}
+
+	// addrRange 是一段连续的地址，包含 from 与 to。
+	addrRange struct {
+		// from 是起始地址。
+		from netip.Addr
+		// to 是结束地址。
+		to netip.Addr
+	}
+)
+
+// ParseSet 解析地址集合。
+//
+// 参数：
+//   - entries：集合的元素，支持 CIDR（"10.0.0.0/8"）、单个地址（"192.168.1.1"）
+//     与地址范围（"10.0.0.1-10.0.0.9"），首尾空白被忽略，空字符串被跳过。
+//
+// 返回值：
+//   - *Set：地址集合，相邻与重叠的范围被合并。
+//   - error：元素无法解析时返回包装了 ErrInvalid 的错误。
+//
+// 示例：
+//
+//	trusted, err := ip.ParseSet("10.0.0.0/8", "172.16.0.0/12", "fd00::/8")
+//	if nil != err {
+//	    return err
+//	}
+//	trusted.ContainsString("10.1.2.3") // true
+func ParseSet(entries ...string) (*Set, error) {
+	ranges := make([]addrRange, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if "" == entry {
+			continue
+		}
+		r, err := parseRange(entry)
+		if nil != err {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return &Set{ranges: merge(ranges)}, nil
+}
+
+// MustParseSet 解析地址集合，无法解析时 panic，适用于常量配置。
+//
+// 参数：
+//   - entries：集合的元素，同 ParseSet。
+//
+// 返回值：
+//   - *Set：地址集合。
+func MustParseSet(entries ...string) *Set {
+	s, err := ParseSet(entries...)
+	if nil != err {
+		panic(err)
+	}
+	return s
+}
+
+// LocalNetworks 返回环回地址与私有地址组成的集合，适合作为部署在内网的反向代理的可信地址。
+//
+// 返回值：
+//   - *Set：127.0.0.0/8、10.0.0.0/8、172.16.0.0/12、192.168.0.0/16、::1 与 fc00::/7。
+func LocalNetworks() *Set {
+	return MustParseSet("127.0.0.0/8", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "::1", "fc00::/7")
+}
+
+// Contains 返回地址是否属于集合。集合为 nil 时返回 false。
+//
+// 参数：
+//   - addr：地址，IPv4 映射的 IPv6 地址按 IPv4 地址查找。
+//
+// 返回值：
+//   - bool：地址是否属于集合。
+func (s *Set) Contains(addr netip.Addr) bool {
+	if nil == s || !addr.IsValid() {
+		return false
+	}
+	addr = normalize(addr)
+	i, _ := slices.BinarySearchFunc(s.ranges, addr, func(r addrRange, addr netip.Addr) int {
+		return r.to.Compare(addr)
+	})
+	return i < len(s.ranges) && s.ranges[i].from.Compare(addr) <= 0
+}
+
+// ContainsString 返回地址是否属于集合，地址无法解析时返回 false。
+//
+// 参数：
+//   - str：地址，解析规则同 Parse。
+//
+// 返回值：
+//   - bool：地址是否属于集合。
+func (s *Set) ContainsString(str string) bool {
+	addr, err := Parse(str)
+	if nil != err {
+		return false
+	}
+	return s.Contains(addr)
+}
+
+// Len 返回集合中合并后的地址范围数量。
+//
+// 返回值：
+//   - int：地址范围数量。
+func (s *Set) Len() int {
+	if nil == s {
+		return 0
+	}
+	return len(s.ranges)
+}
+
+// String 返回集合的文本形式，可以表示为 CIDR 的范围输出为 CIDR，其余输出为地址范围。
+//
+// 返回值：
+//   - string：以逗号分隔的元素。
+func (s *Set) String() string {
+	if nil == s {
+		return ""
+	}
+	parts := make([]string, 0, len(s.ranges))
+	for _, r := range s.ranges {
+		parts = append(parts, r.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// String 返回范围的文本形式。
+func (r addrRange) String() string {
+	if r.from == r.to {
+		return r.from.String()
+	}
+	for bits := 0; bits <= r.from.BitLen(); bits++ {
+		p := netip.PrefixFrom(r.from, bits)
+		if p.Masked().Addr() == r.from && lastAddr(p) == r.to {
+			return p.String()
+		}
+	}
+	return r.from.String() + "-" + r.to.String()
+}
+
+// parseRange 解析集合的一个元素。
+func parseRange(entry string) (addrRange, error) {
+	if from, to, ok := strings.Cut(entry, "-"); ok {
+		a, errFrom := netip.ParseAddr(strings.TrimSpace(from))
+		b, errTo := netip.ParseAddr(strings.TrimSpace(to))
+		if nil != errFrom || nil != errTo {
+			return addrRange{}, fmt.Errorf("%w：%q", ErrInvalid, entry)
+		}
+		a, b = normalize(a), normalize(b)
+		if a.Is4() != b.Is4() || b.Less(a) {
+			return addrRange{}, fmt.Errorf("%w：%q", ErrInvalid, entry)
+		}
+		return addrRange{from: a, to: b}, nil
+	}
+
+	if strings.Contains(entry, "/") {
+		p, err := netip.ParsePrefix(entry)
+		if nil != err {
+			return addrRange{}, fmt.Errorf("%w：%q", ErrInvalid, entry)
+		}
+		if p.Addr().Is4In6() && p.Bits() >= 96 {
+			p = netip.PrefixFrom(p.Addr().Unmap(), p.Bits()-96)
+		}
+		p = p.Masked()
+		return addrRange{from: p.Addr(), to: lastAddr(p)}, nil
+	}
+
+	addr, err := netip.ParseAddr(entry)
+	if nil != err {
+		return addrRange{}, fmt.Errorf("%w：%q", ErrInvalid, entry)
+	}
+	addr = normalize(addr)
+	return addrRange{from: addr, to: addr}, nil
+}
+
+// lastAddr 返回前缀中的最后一个地址。
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Masked().Addr().AsSlice()
+	for i := p.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// merge 将范围排序并合并重叠与相邻的范围。
+func merge(ranges []addrRange) []addrRange {
+	slices.SortFunc(ranges, func(a, b addrRange) int {
+		return a.from.Compare(b.from)
+	})
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); 0 < n {
+			last := &merged[n-1]
+			next := last.to.Next()
+			if r.from.Compare(last.to) <= 0 || (next.IsValid() && next == r.from) {
+				if last.to.Less(r.to) {
+					last.to = r.to
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	return slices.Clip(merged)
+}
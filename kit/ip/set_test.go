@@ -0,0 +1,85 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ip
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSet 测试地址集合的查找。
+func TestSet(t *testing.T) {
+	s, err := ParseSet("10.0.0.0/8", " 192.168.1.1 ", "", "172.16.0.10-172.16.0.20", "2001:db8::/32", "::ffff:100.64.0.0/106")
+	require.NoError(t, err)
+	assert.Equal(t, 5, s.Len())
+
+	for input, want := range map[string]bool{
+		"10.0.0.0":         true,
+		"10.255.255.255":   true,
+		"11.0.0.0":         false,
+		"9.255.255.255":    false,
+		"192.168.1.1":      true,
+		"192.168.1.2":      false,
+		"172.16.0.9":       false,
+		"172.16.0.10":      true,
+		"172.16.0.20":      true,
+		"172.16.0.21":      false,
+		"100.64.0.1":       true,
+		"::ffff:10.1.1.1":  true,
+		"2001:db8:ffff::1": true,
+		"2001:db9::1":      false,
+		"::a00:1":          false,
+		"invalid":          false,
+	} {
+		assert.Equal(t, want, s.ContainsString(input), input)
+	}
+	assert.False(t, s.Contains(netip.Addr{}))
+
+	var empty *Set
+	assert.False(t, empty.Contains(netip.MustParseAddr("10.0.0.1")))
+	assert.Zero(t, empty.Len())
+	assert.Empty(t, empty.String())
+}
+
+// TestSetMerge 测试重叠与相邻的范围被合并。
+func TestSetMerge(t *testing.T) {
+	s := MustParseSet("10.0.0.0/25", "10.0.0.128/25", "10.0.0.5", "10.0.2.0-10.0.2.3", "10.0.2.4-10.0.2.7", "255.255.255.255", "::/128")
+	assert.Equal(t, "10.0.0.0/24,10.0.2.0/29,255.255.255.255,::", s.String())
+	assert.Equal(t, 4, s.Len())
+	// 10.0.0.255 与 10.0.1.0 相邻。
+	assert.Equal(t, "10.0.0.0-10.0.1.4", MustParseSet("10.0.0.0/24", "10.0.1.0-10.0.1.4").String())
+
+	s = MustParseSet("10.0.0.1-10.0.0.6", "10.0.0.3-10.0.0.4", "10.0.0.0/8")
+	assert.Equal(t, "10.0.0.0/8", s.String())
+	s = MustParseSet("10.0.0.1-10.0.0.6")
+	assert.Equal(t, "10.0.0.1-10.0.0.6", s.String())
+	// 前缀中的主机位被忽略。
+	assert.Equal(t, "10.0.0.0/8", MustParseSet("10.1.2.3/8").String())
+}
+
+// TestParseSetError 测试无法解析的元素。
+func TestParseSetError(t *testing.T) {
+	for _, entry := range []string{"10.0.0.0/33", "invalid", "10.0.0.9-10.0.0.1", "10.0.0.1-::1", "10.0.0.1-x", "x/8"} {
+		_, err := ParseSet(entry)
+		assert.ErrorIs(t, err, ErrInvalid, entry)
+	}
+	assert.Panics(t, func() {
+		MustParseSet("invalid")
+	})
+}
+
+// TestLocalNetworks 测试本地网络集合。
+func TestLocalNetworks(t *testing.T) {
+	s := LocalNetworks()
+	for _, input := range []string{"127.0.0.1", "10.0.0.1", "172.31.255.255", "192.168.0.1", "::1", "fd12::1"} {
+		assert.True(t, s.ContainsString(input), input)
+	}
+	for _, input := range []string{"8.8.8.8", "172.32.0.1", "2001:db8::1"} {
+		assert.False(t, s.ContainsString(input), input)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package json
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	// ErrTrailingData 表示 JSON 值之后存在多余的数据。
+	ErrTrailingData = errors.New("kit/json: JSON 值之后存在多余的数据")
+)
+
+type (
+	// Number 是 encoding/json 的 Number，开启 WithUseNumber 时 interface{} 中的数字解码为该类型。
+	Number = stdjson.Number
+)
+
+// Unmarshal 将 data 解码到 v。
+// 默认忽略未知字段，数字解码到 interface{} 时保留为 Number；data 中只能包含一个 JSON 值。
+//
+// 参数：
+//   - data：JSON 数据。
+//   - v：解码的目标，必须是非 nil 的指针。
+//   - opts：配置选项，参见 WithDisallowUnknownFields 与 WithUseNumber。
+//
+// 返回值：
+//   - error：解码失败的原因，data 中存在多余数据时返回 ErrTrailingData。
+func Unmarshal(data []byte, v interface{}, opts ...Option) error {
+	dec := newDecoder(bytes.NewReader(data), opts...)
+	if err := dec.Decode(v); nil != err {
+		return fmt.Errorf("kit/json: 解码失败：%w", err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return ErrTrailingData
+	}
+	return nil
+}
+
+// Decode 从 r 中读取一个 JSON 值并解码到 v，适用于请求体等流式输入。
+// 与 Unmarshal 不同，Decode 不检查之后的数据，r 中可以包含多个连续的 JSON 值。
+//
+// 参数：
+//   - r：输入。
+//   - v：解码的目标，必须是非 nil 的指针。
+//   - opts：配置选项，参见 WithDisallowUnknownFields 与 WithUseNumber。
+//
+// 返回值：
+//   - error：解码失败的原因，r 中没有数据时包装 io.EOF。
+func Decode(r io.Reader, v interface{}, opts ...Option) error {
+	if err := newDecoder(r, opts...).Decode(v); nil != err {
+		return fmt.Errorf("kit/json: 解码失败：%w", err)
+	}
+	return nil
+}
+
+// newDecoder 按配置创建解码器。
+func newDecoder(r io.Reader, opts ...Option) *stdjson.Decoder {
+	o := newOptions(opts...)
+	dec := stdjson.NewDecoder(r)
+	if o.disallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if o.useNumber {
+		dec.UseNumber()
+	}
+	return dec
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package json 提供了低分配的 JSON 编码工具与宽松的解码工具，底层基于 encoding/json。
+
+主要功能：
+
+  - 流式编码：Encode 在复用的缓冲区中完成编码后一次性写入，Append 将编码结果追加到字节切片，均不转义 HTML 字符
+  - 字段编码：AppendFields 将 map[string]interface{} 按字段名排序编码为 JSON 对象，常见类型不经过反射，供日志后端使用
+  - 宽松解码：Unmarshal、Decode 默认忽略未知字段，数字解码到 interface{} 时保留为 Number，避免大整数丢失精度
+  - 数字类型：Int64、Float64 同时接受数字与字符串两种写法
+
+kit/log 的 JSONFormatter 使用 AppendFields 编码日志字段。
+
+基本使用：
+
+	// 编码响应。
+	if err := json.Encode(w, resp); nil != err {
+	    return err
+	}
+
+	// 解码请求，拒绝未知字段。
+	var req CreateUserRequest
+	if err := json.Decode(r.Body, &req, json.WithDisallowUnknownFields(true)); nil != err {
+	    return err
+	}
+
+	// 对接把 ID 编码为字符串的上游。
+	type Order struct {
+	    ID    json.Int64   `json:"id"`
+	    Price json.Float64 `json:"price"`
+	}
+
+由于包名与标准库 encoding/json 相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "encoding/json"
+
+	    kitjson "github.com/fsyyft-go/monorepo/kit/json"
+	)
+*/
+package json
@@ -0,0 +1,73 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package json
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+
+	kitstrings "github.com/fsyyft-go/monorepo/kit/strings"
+)
+
+// Append 将 v 的 JSON 编码追加到 dst，末尾不带换行符。
+// 编码规则与 encoding/json 相同，但不转义 HTML 字符 <、> 与 &。
+//
+// 参数：
+//   - dst：追加的目标。
+//   - v：要编码的值。
+//
+// 返回值：
+//   - []byte：追加之后的切片，编码失败时为原样的 dst。
+//   - error：编码失败的原因。
+func Append(dst []byte, v interface{}) ([]byte, error) {
+	buf := kitstrings.GetBuffer()
+	defer kitstrings.PutBuffer(buf)
+
+	if err := encodeTo(buf, v); nil != err {
+		return dst, err
+	}
+	// Encoder 在末尾写入换行符，追加时去掉。
+	return append(dst, buf.B[:len(buf.B)-1]...), nil
+}
+
+// Encode 将 v 的 JSON 编码与换行符写入 w。
+// 编码先在复用的缓冲区中完成，再一次性写入 w，编码失败时不会向 w 写入不完整的内容。
+//
+// 参数：
+//   - w：写入的目标。
+//   - v：要编码的值。
+//
+// 返回值：
+//   - error：编码或写入失败的原因。
+//
+// 示例：
+//
+//	func handle(w http.ResponseWriter, r *http.Request) {
+//	    w.Header().Set("Content-Type", "application/json")
+//	    if err := json.Encode(w, resp); nil != err {
+//	        logger.WithField("error", err).Error("encode response failed")
+//	    }
+//	}
+func Encode(w io.Writer, v interface{}) error {
+	buf := kitstrings.GetBuffer()
+	defer kitstrings.PutBuffer(buf)
+
+	if err := encodeTo(buf, v); nil != err {
+		return err
+	}
+	_, err := w.Write(buf.B)
+	return err
+}
+
+// encodeTo 将 v 编码到缓冲区，不转义 HTML 字符。
+func encodeTo(buf *kitstrings.Buffer, v interface{}) error {
+	enc := stdjson.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); nil != err {
+		return fmt.Errorf("kit/json: 编码失败：%w", err)
+	}
+	return nil
+}
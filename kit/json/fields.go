@@ -0,0 +1,220 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package json
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	stdsync "sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// hexDigits 是 \u 转义使用的十六进制字符。
+	hexDigits = "0123456789abcdef"
+)
+
+var (
+	// keysPool 是 AppendFields 排序字段名使用的切片池。
+	keysPool = stdsync.Pool{
+		New: func() interface{} {
+			keys := make([]string, 0, 16)
+			return &keys
+		},
+	}
+)
+
+// AppendFields 将字段映射编码为 JSON 对象追加到 dst，字段按字段名的字典序输出。
+// 用于日志后端编码结构化字段，常见类型不经过反射，也不分配内存。
+//
+// 参数：
+//   - dst：追加的目标。
+//   - fields：字段映射，为 nil 时输出 {}。
+//
+// 返回值：
+//   - []byte：追加之后的切片。
+//
+// 示例：
+//
+//	buf := strings.GetBuffer()
+//	defer strings.PutBuffer(buf)
+//	buf.B = json.AppendFields(buf.B, map[string]interface{}{"user_id": 42, "msg": "login"})
+//	// {"msg":"login","user_id":42}
+func AppendFields(dst []byte, fields map[string]interface{}) []byte {
+	keysPtr := keysPool.Get().(*[]string)
+	keys := (*keysPtr)[:0]
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	dst = append(dst, '{')
+	for i, k := range keys {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = AppendString(dst, k)
+		dst = append(dst, ':')
+		dst = AppendValue(dst, fields[k])
+	}
+	dst = append(dst, '}')
+
+	// 清空引用，避免池中的切片持有字段名。
+	clear(keys)
+	*keysPtr = keys[:0]
+	keysPool.Put(keysPtr)
+	return dst
+}
+
+// AppendValue 将 v 的 JSON 编码追加到 dst。
+// 字符串、整数、浮点数、布尔值、time.Time 与 time.Duration 直接编码，结果与 encoding/json 相同；
+// error 编码为 Error() 返回的字符串，与 logrus 的 JSON 格式化器一致；其他类型交给 encoding/json。
+// 无法编码的值，例如 NaN、通道或 MarshalJSON 返回错误的值，编码为 fmt.Sprint 的字符串，
+// 保证日志不会因为个别字段而丢失。
+//
+// 参数：
+//   - dst：追加的目标。
+//   - v：要编码的值。
+//
+// 返回值：
+//   - []byte：追加之后的切片。
+func AppendValue(dst []byte, v interface{}) []byte {
+	switch x := v.(type) {
+	case nil:
+		return append(dst, "null"...)
+	case string:
+		return AppendString(dst, x)
+	case bool:
+		return strconv.AppendBool(dst, x)
+	case int:
+		return strconv.AppendInt(dst, int64(x), 10)
+	case int8:
+		return strconv.AppendInt(dst, int64(x), 10)
+	case int16:
+		return strconv.AppendInt(dst, int64(x), 10)
+	case int32:
+		return strconv.AppendInt(dst, int64(x), 10)
+	case int64:
+		return strconv.AppendInt(dst, x, 10)
+	case uint:
+		return strconv.AppendUint(dst, uint64(x), 10)
+	case uint8:
+		return strconv.AppendUint(dst, uint64(x), 10)
+	case uint16:
+		return strconv.AppendUint(dst, uint64(x), 10)
+	case uint32:
+		return strconv.AppendUint(dst, uint64(x), 10)
+	case uint64:
+		return strconv.AppendUint(dst, x, 10)
+	case float32:
+		return appendFloat(dst, float64(x), 32)
+	case float64:
+		return appendFloat(dst, x, 64)
+	case time.Duration:
+		return strconv.AppendInt(dst, int64(x), 10)
+	case time.Time:
+		dst = append(dst, '"')
+		dst = x.AppendFormat(dst, time.RFC3339Nano)
+		return append(dst, '"')
+	case error:
+		return AppendString(dst, x.Error())
+	}
+
+	out, err := Append(dst, v)
+	if nil != err {
+		return AppendString(dst, fmt.Sprint(v))
+	}
+	return out
+}
+
+// AppendString 将 s 编码为 JSON 字符串追加到 dst。
+// 转义规则与 encoding/json 相同，但不转义 HTML 字符；非法的 UTF-8 字节替换为 U+FFFD。
+//
+// 参数：
+//   - dst：追加的目标。
+//   - s：要编码的字符串。
+//
+// 返回值：
+//   - []byte：追加之后的切片。
+func AppendString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && '"' != b && '\\' != b {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if utf8.RuneError == r && 1 == size {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 与 U+2029 在 JavaScript 中是换行符，与 encoding/json 一样进行转义。
+		if '\u2028' == r || '\u2029' == r {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+// appendFloat 按 encoding/json 的规则追加浮点数，NaN 与无穷大编码为字符串。
+func appendFloat(dst []byte, f float64, bits int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return AppendString(dst, strconv.FormatFloat(f, 'g', -1, bits))
+	}
+
+	// 与 encoding/json 一致：绝对值过小或过大时使用科学计数法，其他情况使用小数形式。
+	format := byte('f')
+	if abs := math.Abs(f); 0 != abs {
+		if 64 == bits && (abs < 1e-6 || abs >= 1e21) || 32 == bits && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if 'e' == format {
+		// 将 1e-07 整理为 1e-7。
+		n := len(dst)
+		if n >= 4 && 'e' == dst[n-4] && '-' == dst[n-3] && '0' == dst[n-2] {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst
+}
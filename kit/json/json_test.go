@@ -0,0 +1,223 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package json
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// badMarshaler 是 MarshalJSON 总是失败的类型。
+	badMarshaler struct{}
+
+	// failWriter 是写入总是失败的 io.Writer。
+	failWriter struct{}
+)
+
+func (badMarshaler) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("bad")
+}
+
+func (badMarshaler) String() string {
+	return "bad marshaler"
+}
+
+func (failWriter) Write([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+// stdEncode 使用 encoding/json 编码 v，不转义 HTML 字符，作为对照结果。
+func stdEncode(t *testing.T, v interface{}) string {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := stdjson.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	require.NoError(t, enc.Encode(v))
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// TestAppendString 测试字符串的转义与 encoding/json 一致。
+func TestAppendString(t *testing.T) {
+	cases := []string{
+		"",
+		"hello",
+		`quote " backslash \ slash /`,
+		"control \b\f\n\r\t\x00\x1f",
+		"html <a href=\"x\">&</a>",
+		"中文与 emoji 🚀",
+		"line \u2028 paragraph \u2029",
+		"invalid \xff\xfe utf8",
+	}
+	for _, s := range cases {
+		assert.Equal(t, stdEncode(t, s), string(AppendString(nil, s)), "%q", s)
+	}
+}
+
+// TestAppendValue 测试常见类型的编码与 encoding/json 一致。
+func TestAppendValue(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 6, time.FixedZone("CST", 8*3600))
+	cases := []interface{}{
+		nil, "s", true, false,
+		int(-1), int8(-8), int16(-16), int32(-32), int64(math.MinInt64),
+		uint(1), uint8(8), uint16(16), uint32(32), uint64(math.MaxUint64),
+		float32(1.5), float32(1e-7), float32(3.4e38),
+		float64(0), float64(-2.25), float64(1e-7), float64(1e21), float64(123456789.125), float64(1e20),
+		1500 * time.Millisecond, now,
+		[]int{1, 2}, map[string]int{"b": 2, "a": 1}, struct {
+			Name string `json:"name"`
+		}{Name: "<x>"},
+		[]byte("raw"),
+	}
+	for _, v := range cases {
+		assert.Equal(t, stdEncode(t, v), string(AppendValue(nil, v)), "%#v", v)
+	}
+
+	assert.Equal(t, `"boom"`, string(AppendValue(nil, errors.New("boom"))))
+	assert.Equal(t, `"NaN"`, string(AppendValue(nil, math.NaN())))
+	assert.Equal(t, `"+Inf"`, string(AppendValue(nil, float32(math.Inf(1)))))
+	assert.Equal(t, `"bad marshaler"`, string(AppendValue(nil, badMarshaler{})))
+	assert.True(t, strings.HasPrefix(string(AppendValue([]byte("x"), make(chan int))), `x"0x`))
+}
+
+// TestAppendFields 测试字段映射按字段名排序编码。
+func TestAppendFields(t *testing.T) {
+	assert.Equal(t, "{}", string(AppendFields(nil, nil)))
+
+	fields := map[string]interface{}{
+		"user_id": 42,
+		"msg":     "login <ok>",
+		"cost":    0.5,
+		"error":   errors.New("denied"),
+		"tags":    []string{"a"},
+	}
+	out := AppendFields([]byte("prefix "), fields)
+	assert.Equal(t, `prefix {"cost":0.5,"error":"denied","msg":"login <ok>","tags":["a"],"user_id":42}`, string(out))
+
+	var decoded map[string]interface{}
+	require.NoError(t, stdjson.Unmarshal(out[len("prefix "):], &decoded))
+	assert.Len(t, decoded, len(fields))
+}
+
+// TestAppendAndEncode 测试通过复用缓冲区编码任意值。
+func TestAppendAndEncode(t *testing.T) {
+	out, err := Append([]byte("x="), map[string]string{"a": "<b>"})
+	require.NoError(t, err)
+	assert.Equal(t, `x={"a":"<b>"}`, string(out))
+
+	out, err = Append([]byte("x="), make(chan int))
+	assert.Error(t, err)
+	assert.Equal(t, "x=", string(out))
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, []int{1, 2}))
+	assert.Equal(t, "[1,2]\n", buf.String())
+
+	buf.Reset()
+	assert.Error(t, Encode(&buf, badMarshaler{}))
+	assert.Zero(t, buf.Len(), "编码失败时不应写入内容")
+
+	assert.ErrorIs(t, Encode(failWriter{}, 1), io.ErrClosedPipe)
+}
+
+// TestUnmarshal 测试宽松解码的默认行为与配置选项。
+func TestUnmarshal(t *testing.T) {
+	type user struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+
+	var u user
+	require.NoError(t, Unmarshal([]byte(`{"id":1,"name":"a","extra":true}`), &u))
+	assert.Equal(t, user{ID: 1, Name: "a"}, u)
+
+	err := Unmarshal([]byte(`{"id":1,"extra":true}`), &u, WithDisallowUnknownFields(true))
+	assert.ErrorContains(t, err, "extra")
+
+	var v map[string]interface{}
+	require.NoError(t, Unmarshal([]byte(`{"big":9007199254740993}`), &v))
+	assert.Equal(t, Number("9007199254740993"), v["big"])
+
+	require.NoError(t, Unmarshal([]byte(`{"big":1.5}`), &v, WithUseNumber(false)))
+	assert.Equal(t, 1.5, v["big"])
+
+	assert.ErrorIs(t, Unmarshal([]byte(`{} {}`), &v), ErrTrailingData)
+	assert.NoError(t, Unmarshal([]byte(" {} \n"), &v))
+	assert.Error(t, Unmarshal([]byte(`{`), &v))
+}
+
+// TestDecode 测试从流中依次解码多个值。
+func TestDecode(t *testing.T) {
+	r := strings.NewReader(`{"a":1} {"a":2}`)
+	var v map[string]interface{}
+	require.NoError(t, Decode(r, &v))
+	assert.Equal(t, Number("1"), v["a"])
+
+	var n map[string]int
+	require.NoError(t, Decode(strings.NewReader(`{"a":2}`), &n, WithUseNumber(false)))
+	assert.Equal(t, 2, n["a"])
+
+	assert.ErrorIs(t, Decode(strings.NewReader(""), &v), io.EOF)
+}
+
+// TestNumberTypes 测试兼容字符串写法的数字类型。
+func TestNumberTypes(t *testing.T) {
+	type payload struct {
+		ID    Int64   `json:"id"`
+		Price Float64 `json:"price"`
+	}
+
+	var p payload
+	require.NoError(t, Unmarshal([]byte(`{"id":"9007199254740993","price":"1.25"}`), &p))
+	assert.Equal(t, payload{ID: 9007199254740993, Price: 1.25}, p)
+
+	require.NoError(t, Unmarshal([]byte(`{"id":7,"price":2}`), &p))
+	assert.Equal(t, payload{ID: 7, Price: 2}, p)
+
+	require.NoError(t, Unmarshal([]byte(`{"id":null,"price":null}`), &p))
+	assert.Equal(t, payload{ID: 7, Price: 2}, p)
+
+	assert.Error(t, Unmarshal([]byte(`{"id":"x"}`), &p))
+	assert.Error(t, Unmarshal([]byte(`{"price":"x"}`), &p))
+
+	out, err := stdjson.Marshal(payload{ID: 3, Price: 0.5})
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":3,"price":0.5}`, string(out))
+
+	_, err = stdjson.Marshal(Float64(math.Inf(-1)))
+	assert.Error(t, err)
+}
+
+// BenchmarkAppendFields 测试编码日志字段的性能。
+func BenchmarkAppendFields(b *testing.B) {
+	fields := map[string]interface{}{
+		"user_id": 42, "path": "/api/users", "cost": 15 * time.Millisecond, "ok": true,
+	}
+	buf := make([]byte, 0, 256)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = AppendFields(buf[:0], fields)
+	}
+}
+
+// BenchmarkMarshal 作为对照，测试 encoding/json 编码同样字段的性能。
+func BenchmarkMarshal(b *testing.B) {
+	fields := map[string]interface{}{
+		"user_id": 42, "path": "/api/users", "cost": 15 * time.Millisecond, "ok": true,
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = stdjson.Marshal(fields)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package json
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+type (
+	// Int64 是兼容数字与字符串两种写法的整数。
+	// 解码时接受 123、"123" 与 null（保持原值），编码时输出数字。
+	// 用于对接把整数编码为字符串的上游，例如为避免 JavaScript 精度丢失而使用字符串的 ID。
+	Int64 int64
+
+	// Float64 是兼容数字与字符串两种写法的浮点数。
+	// 解码时接受 1.5、"1.5" 与 null（保持原值），编码时输出数字。
+	Float64 float64
+)
+
+// UnmarshalJSON 实现了 encoding/json 的 Unmarshaler 接口。
+func (i *Int64) UnmarshalJSON(data []byte) error {
+	s, ok := unquoteNumber(data)
+	if !ok {
+		return nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if nil != err {
+		return fmt.Errorf("kit/json: 无法解析整数 %s：%w", data, err)
+	}
+	*i = Int64(n)
+	return nil
+}
+
+// MarshalJSON 实现了 encoding/json 的 Marshaler 接口。
+func (i Int64) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(i), 10), nil
+}
+
+// UnmarshalJSON 实现了 encoding/json 的 Unmarshaler 接口。
+func (f *Float64) UnmarshalJSON(data []byte) error {
+	s, ok := unquoteNumber(data)
+	if !ok {
+		return nil
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if nil != err {
+		return fmt.Errorf("kit/json: 无法解析浮点数 %s：%w", data, err)
+	}
+	*f = Float64(n)
+	return nil
+}
+
+// MarshalJSON 实现了 encoding/json 的 Marshaler 接口。
+// NaN 与无穷大不是合法的 JSON 数字，编码时返回错误。
+func (f Float64) MarshalJSON() ([]byte, error) {
+	return Append(nil, float64(f))
+}
+
+// unquoteNumber 去掉数字两侧的引号，data 为 null 时返回 false。
+func unquoteNumber(data []byte) (string, bool) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return "", false
+	}
+	if len(data) >= 2 && '"' == data[0] && '"' == data[len(data)-1] {
+		data = data[1 : len(data)-1]
+	}
+	return string(data), true
+}
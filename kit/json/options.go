@@ -0,0 +1,67 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package json
+
+// 以下为解码的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// disallowUnknownFieldsDefault 为是否拒绝目标结构体中不存在的字段，默认忽略，兼容上游新增字段。
+	disallowUnknownFieldsDefault = false
+	// useNumberDefault 为解码到 interface{} 时数字是否保留为 Number，默认保留，避免大整数丢失精度。
+	useNumberDefault = true
+)
+
+type (
+	// Option 定义了解码的配置选项。
+	Option func(*options)
+
+	// options 包含解码的配置。
+	options struct {
+		// disallowUnknownFields 表示是否拒绝目标结构体中不存在的字段。
+		disallowUnknownFields bool
+		// useNumber 表示解码到 interface{} 时数字是否保留为 Number。
+		useNumber bool
+	}
+)
+
+// WithDisallowUnknownFields 设置是否拒绝目标结构体中不存在的字段。
+// 默认忽略未知字段，上游新增字段不会导致解码失败；校验请求体等需要严格匹配的场景可以开启。
+//
+// 参数：
+//   - disallow：是否拒绝未知字段，默认为 false。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithDisallowUnknownFields(disallow bool) Option {
+	return func(o *options) {
+		o.disallowUnknownFields = disallow
+	}
+}
+
+// WithUseNumber 设置解码到 interface{} 时数字是否保留为 Number。
+// 默认保留为 Number，超过 2^53 的整数不会因为转换为 float64 而丢失精度；关闭时与 encoding/json 的默认行为相同。
+//
+// 参数：
+//   - use：是否保留为 Number，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithUseNumber(use bool) Option {
+	return func(o *options) {
+		o.useNumber = use
+	}
+}
+
+// newOptions 创建并应用配置选项。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		disallowUnknownFields: disallowUnknownFieldsDefault,
+		useNumber:             useNumberDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+const (
+	// AsyncDrop 表示缓冲区已满时丢弃新的日志，Write 始终不阻塞，丢弃的条数计入 MetricWriterEntries。
+	AsyncDrop AsyncPolicy = "drop"
+	// AsyncBlock 表示缓冲区已满时 Write 等待后台协程腾出空间，不丢失日志，但输出目标持续缓慢时会阻塞记录日志的调用方。
+	AsyncBlock AsyncPolicy = "block"
+)
+
+// 以下为 AsyncWriter 的默认参数配置。
+var (
+	// asyncBufferSizeDefault 为等待写入的日志条数上限。
+	asyncBufferSizeDefault = 8192
+	// asyncFlushIntervalDefault 为批量缓冲区写入输出目标的间隔。
+	asyncFlushIntervalDefault = time.Second
+	// asyncBatchBytes 为批量缓冲区的字节数，写满时立即写入输出目标。
+	asyncBatchBytes = 64 * 1024
+	// asyncWriterName 为 AsyncWriter 在指标中的名称。
+	asyncWriterName = "async"
+)
+
+type (
+	// AsyncPolicy 定义了异步写入时缓冲区已满的处理策略。
+	AsyncPolicy string
+
+	// AsyncWriter 在后台协程中将日志写入输出目标，实现了 io.Writer。
+	// 每次 Write 复制一条日志放入有界的缓冲区后返回；后台协程将日志合并到批量缓冲区，
+	// 批量缓冲区写满或每隔 flushInterval 时才写入输出目标，使记录日志的调用方不被缓慢的磁盘阻塞。
+	// 缓冲区已满时按 AsyncPolicy 丢弃或等待。写入失败时丢弃批量缓冲区中的日志，错误输出到标准错误。
+	// 所有方法都是并发安全的。
+	AsyncWriter struct {
+		// w 是输出目标。
+		w io.Writer
+		// policy 是缓冲区已满的处理策略。
+		policy AsyncPolicy
+		// flushInterval 是批量缓冲区写入输出目标的间隔。
+		flushInterval time.Duration
+		// clock 是计算写入间隔使用的时钟。
+		clock kittime.Clock
+		// entries 是等待写入的日志。
+		entries chan []byte
+		// syncs 接收 Sync 的请求，写入完成后将结果发送到请求中的通道。
+		syncs chan chan error
+		// done 在后台协程退出时关闭。
+		done chan struct{}
+		// dropped 是丢弃的日志条数的指标。
+		dropped prometheus.Counter
+
+		// mu 保护 closed 与 entries 的关闭。
+		mu sync.RWMutex
+		// closed 表示写入器是否已经关闭。
+		closed bool
+	}
+)
+
+// NewAsyncWriter 创建在后台协程中写入 w 的写入器，并启动后台协程。
+// 不再使用时需要调用 Close，写入缓冲区中剩余的日志；Close 不关闭 w。
+//
+// 参数：
+//   - w：输出目标，只在后台协程中调用，不需要是并发安全的。
+//   - bufferSize：等待写入的日志条数上限，小于等于 0 时使用默认值 8192。
+//   - flushInterval：批量缓冲区写入输出目标的间隔，即日志最长的延迟，小于等于 0 时使用默认值 1 秒。
+//   - policy：缓冲区已满的处理策略，为空时使用 AsyncDrop。
+//
+// 返回值：
+//   - *AsyncWriter：写入器实例。
+//
+// 示例：
+//
+//	w := log.NewAsyncWriter(file, 4096, 500*time.Millisecond, log.AsyncBlock)
+//	defer w.Close()
+func NewAsyncWriter(w io.Writer, bufferSize int, flushInterval time.Duration, policy AsyncPolicy) *AsyncWriter {
+	return newAsyncWriter(w, bufferSize, flushInterval, policy, nil)
+}
+
+// newAsyncWriter 创建使用 clock 计算写入间隔的 AsyncWriter，clock 为 nil 时使用系统时钟。
+func newAsyncWriter(w io.Writer, bufferSize int, flushInterval time.Duration, policy AsyncPolicy, clock kittime.Clock) *AsyncWriter {
+	if bufferSize <= 0 {
+		bufferSize = asyncBufferSizeDefault
+	}
+	if flushInterval <= 0 {
+		flushInterval = asyncFlushIntervalDefault
+	}
+	if "" == policy {
+		policy = AsyncDrop
+	}
+
+	aw := &AsyncWriter{
+		w:             w,
+		policy:        policy,
+		flushInterval: flushInterval,
+		clock:         kittime.OrReal(clock),
+		entries:       make(chan []byte, bufferSize),
+		syncs:         make(chan chan error),
+		done:          make(chan struct{}),
+		dropped:       MetricWriterEntries.WithLabelValues(asyncWriterName, "dropped"),
+	}
+
+	go aw.run()
+
+	return aw
+}
+
+// Write 复制一条日志放入缓冲区后返回，缓冲区已满时按策略丢弃或等待，丢弃时仍然返回 nil。
+//
+// 参数：
+//   - p：一条日志，返回后不再持有。
+//
+// 返回值：
+//   - int：总是返回 len(p)。
+//   - error：写入器已经关闭时返回 ErrWriterClosed。
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	e := bytes.Clone(p)
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return 0, ErrWriterClosed
+	}
+	if AsyncBlock == w.policy {
+		w.entries <- e
+		return len(p), nil
+	}
+	select {
+	case w.entries <- e:
+	default:
+		inc(w.dropped, 1)
+	}
+	return len(p), nil
+}
+
+// Sync 将 Sync 调用之前进入缓冲区的日志写入输出目标，输出目标实现了 Sync() error 时一并调用。
+//
+// 返回值：
+//   - error：写入输出目标或调用其 Sync 失败时返回错误，写入器已经关闭时返回 nil。
+func (w *AsyncWriter) Sync() error {
+	result := make(chan error, 1)
+	select {
+	case w.syncs <- result:
+		return <-result
+	case <-w.done:
+		return nil
+	}
+}
+
+// Close 停止接收日志，写入缓冲区中剩余的日志后返回，重复调用直接返回 nil。Close 不关闭输出目标。
+//
+// 返回值：
+//   - error：总是返回 nil，写入失败时错误输出到标准错误。
+func (w *AsyncWriter) Close() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.entries)
+	}
+	w.mu.Unlock()
+
+	<-w.done
+	return nil
+}
+
+// run 是后台写入协程，将日志合并到批量缓冲区，写满或每隔 flushInterval 写入输出目标，entries 关闭后写入剩余的日志并退出。
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+
+	ticker := w.clock.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	bw := bufio.NewWriterSize(w.w, asyncBatchBytes)
+	write := func(e []byte) {
+		if _, err := bw.Write(e); nil != err {
+			w.reset(bw, err)
+		}
+	}
+	flush := func() error {
+		if err := bw.Flush(); nil != err {
+			w.reset(bw, err)
+			return err
+		}
+		return nil
+	}
+	for {
+		select {
+		case e, ok := <-w.entries:
+			if !ok {
+				_ = flush()
+				return
+			}
+			write(e)
+		case <-ticker.C():
+			_ = flush()
+		case result := <-w.syncs:
+			// 只写入 Sync 调用之前已经进入缓冲区的日志，避免持续写入时 Sync 无法返回。
+			for n := len(w.entries); n > 0; n-- {
+				if e, ok := <-w.entries; ok {
+					write(e)
+				}
+			}
+			err := flush()
+			if nil == err {
+				err = syncOutput(w.w)
+			}
+			result <- err
+		}
+	}
+}
+
+// reset 在写入失败时丢弃批量缓冲区中的日志，使后续的日志可以继续写入，错误输出到标准错误。
+// 不记录到全局日志实例，因为全局日志实例可能正在使用该写入器。
+func (w *AsyncWriter) reset(bw *bufio.Writer, err error) {
+	_, _ = fmt.Fprintf(os.Stderr, "kit/log: 异步写入日志失败，丢弃 %d 字节：%v\n", bw.Buffered(), err)
+	bw.Reset(w.w)
+}
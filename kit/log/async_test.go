@@ -0,0 +1,165 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+type (
+	// lockedBuffer 是可以在写入的同时读取内容的缓冲区，entered 不为 nil 时第一次写入通知 entered 并等待 release。
+	lockedBuffer struct {
+		mu      sync.Mutex
+		buf     bytes.Buffer
+		entered chan struct{}
+		release chan struct{}
+	}
+)
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	if nil != b.entered {
+		close(b.entered)
+		b.entered = nil
+		<-b.release
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// asyncDropped 返回 AsyncWriter 丢弃的日志条数。
+func asyncDropped() float64 {
+	return testutil.ToFloat64(MetricWriterEntries.WithLabelValues(asyncWriterName, "dropped"))
+}
+
+// TestAsyncWriter 测试按间隔批量写入、Sync 与关闭。
+func TestAsyncWriter(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Now())
+	out := &lockedBuffer{}
+	w := newAsyncWriter(out, 16, time.Second, "", clock)
+
+	for _, s := range []string{"a\n", "b\n"} {
+		n, err := w.Write([]byte(s))
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+	}
+	clock.BlockUntil(1)
+	require.Eventually(t, func() bool {
+		clock.Advance(time.Second)
+		return "a\nb\n" == out.String()
+	}, time.Second, time.Millisecond)
+
+	_, _ = w.Write([]byte("c\n"))
+	require.NoError(t, w.Sync())
+	assert.Equal(t, "a\nb\nc\n", out.String())
+
+	_, _ = w.Write([]byte("d\n"))
+	require.NoError(t, w.Close())
+	assert.Equal(t, "a\nb\nc\nd\n", out.String())
+	_, err := w.Write([]byte("e\n"))
+	assert.ErrorIs(t, err, ErrWriterClosed)
+	assert.NoError(t, w.Sync())
+	assert.NoError(t, w.Close())
+}
+
+// TestAsyncWriter_Policy 测试缓冲区已满时 AsyncDrop 丢弃日志并记录指标，AsyncBlock 等待而不丢失日志。
+func TestAsyncWriter_Policy(t *testing.T) {
+	// 超过批量缓冲区的日志直接写入输出目标，使后台协程阻塞在第一次写入。
+	large := append(bytes.Repeat([]byte("x"), asyncBatchBytes), '\n')
+
+	t.Run("drop", func(t *testing.T) {
+		out := &lockedBuffer{entered: make(chan struct{}), release: make(chan struct{})}
+		entered := out.entered
+		w := NewAsyncWriter(out, 1, time.Hour, AsyncDrop)
+		before := asyncDropped()
+
+		_, _ = w.Write(large)
+		<-entered
+		for _, s := range []string{"a\n", "b\n", "c\n"} {
+			n, err := w.Write([]byte(s))
+			require.NoError(t, err)
+			assert.Equal(t, 2, n)
+		}
+		close(out.release)
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, float64(2), asyncDropped()-before)
+		assert.True(t, strings.HasSuffix(out.String(), "x\na\n"))
+	})
+
+	t.Run("block", func(t *testing.T) {
+		out := &lockedBuffer{entered: make(chan struct{}), release: make(chan struct{})}
+		entered := out.entered
+		w := NewAsyncWriter(out, 1, time.Hour, AsyncBlock)
+		before := asyncDropped()
+
+		_, _ = w.Write(large)
+		<-entered
+		_, _ = w.Write([]byte("a\n"))
+		written := make(chan struct{})
+		go func() {
+			defer close(written)
+			_, _ = w.Write([]byte("b\n"))
+		}()
+		select {
+		case <-written:
+			t.Fatal("Write 应当等待缓冲区腾出空间")
+		case <-time.After(20 * time.Millisecond):
+		}
+		close(out.release)
+		<-written
+		require.NoError(t, w.Close())
+
+		assert.Zero(t, asyncDropped()-before)
+		assert.True(t, strings.HasSuffix(out.String(), "x\na\nb\n"))
+	})
+}
+
+// TestNewLogger_Async 测试各个日志实现开启异步写入后，日志在写入输出目标之前不落盘，Sync 后写入文件。
+func TestNewLogger_Async(t *testing.T) {
+	for _, logType := range []LogType{LogTypeStd, LogTypeLogrus, LogTypeSlog} {
+		t.Run(string(logType), func(t *testing.T) {
+			logPath := filepath.Join(t.TempDir(), "app.log")
+			logger, err := NewLogger(
+				WithLogType(logType),
+				WithOutput(logPath),
+				WithEnableRotate(false),
+				WithAsync(16, time.Hour),
+				WithAsyncPolicy(AsyncBlock),
+			)
+			require.NoError(t, err)
+			defer logger.Close() // nolint:errcheck
+
+			logger.WithField("user", "alice").Info("hello")
+			content, err := os.ReadFile(logPath) // nolint:gosec
+			require.NoError(t, err)
+			assert.Empty(t, content)
+
+			require.NoError(t, logger.Sync())
+			content, err = os.ReadFile(logPath) // nolint:gosec
+			require.NoError(t, err)
+			assert.Contains(t, string(content), "hello")
+			assert.Contains(t, string(content), "alice")
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FieldCaller 是调用位置的字段名，值的格式为 "目录/文件:行号:函数名"，例如 "order/service.go:42:order.(*Service).Create"。
+	FieldCaller = "caller"
+)
+
+type (
+	// caller 是记录调用位置的配置，派生的实例复制该配置。
+	caller struct {
+		// enabled 是否记录调用位置。
+		enabled bool
+		// skip 是在日志方法的调用方之上额外跳过的栈帧数。
+		skip int
+	}
+
+	// callerLogger 是可以记录调用位置的日志实现，内置的日志实现都支持。
+	callerLogger interface {
+		Logger
+
+		// withCaller 返回记录调用位置的实例，与原实例共享其他状态。
+		//
+		// 参数：
+		//   - skip：在日志方法的调用方之上额外跳过的栈帧数。
+		withCaller(skip int) Logger
+
+		// withCallerSkip 返回额外跳过 n 个栈帧的实例，没有开启记录调用位置时返回原实例。
+		withCallerSkip(n int) Logger
+	}
+)
+
+// pc 返回调用位置的程序计数器，没有开启时返回 0。
+//
+// 参数：
+//   - depth：从调用 pc 的函数到日志方法的调用方之间的栈帧数，例如 log 由 Info 调用时为 2。
+func (c caller) pc(depth int) uintptr {
+	if !c.enabled {
+		return 0
+	}
+	var pcs [1]uintptr
+	// 跳过 runtime.Callers 与 pc。
+	if 0 == runtime.Callers(depth+c.skip+2, pcs[:]) {
+		return 0
+	}
+	return pcs[0]
+}
+
+// field 返回调用位置的字段值，没有开启时返回空字符串。
+//
+// 参数：
+//   - depth：从调用 field 的函数到日志方法的调用方之间的栈帧数。
+func (c caller) field(depth int) string {
+	if !c.enabled {
+		return ""
+	}
+	return formatCaller(c.pc(depth + 1))
+}
+
+// formatCaller 将程序计数器格式化为 "目录/文件:行号:函数名"，文件只保留最后一级目录，函数名去掉包的导入路径。
+func formatCaller(pc uintptr) string {
+	if 0 == pc {
+		return ""
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	file := frame.File
+	if i := strings.LastIndexByte(file, '/'); i >= 0 {
+		if j := strings.LastIndexByte(file[:i], '/'); j >= 0 {
+			file = file[j+1:]
+		}
+	}
+	function := frame.Function
+	if i := strings.LastIndexByte(function, '/'); i >= 0 {
+		function = function[i+1:]
+	}
+	return file + ":" + strconv.Itoa(frame.Line) + ":" + function
+}
+
+// addCallerSkip 返回额外跳过 n 个栈帧的 logger，用于包装日志方法的函数，例如包级别的 Info；
+// logger 不支持或没有开启记录调用位置时返回 logger 本身。
+func addCallerSkip(logger Logger, n int) Logger {
+	if l, ok := logger.(callerLogger); ok {
+		return l.withCallerSkip(n)
+	}
+	return logger
+}
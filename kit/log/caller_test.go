@@ -0,0 +1,78 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callerAt 返回测试文件中第 line 行的调用位置的前缀，函数名由调用方断言。
+func callerAt(line int) string {
+	return fmt.Sprintf("log/caller_test.go:%d:log.", line)
+}
+
+// logWrapped 是调用方自己封装的日志函数，用于测试 WithCallerSkip。
+func logWrapped(logger Logger, msg string) {
+	logger.Warn(msg)
+}
+
+// TestWithCaller 测试全部日志实现的各个日志方法记录调用方的位置，包级别的函数与派生的实例同样正确。
+func TestWithCaller(t *testing.T) {
+	srv := newOTLPServer(t)
+	for _, logType := range []LogType{LogTypeStd, LogTypeLogrus, LogTypeSlog, LogTypeOTLP} {
+		t.Run(string(logType), func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := NewLogger(WithLogType(logType), WithWriter(&buf), WithOTLP(srv.URL), WithCaller(true))
+			require.NoError(t, err)
+			defer logger.Close() // nolint:errcheck
+
+			var callers []string
+			logger.AddHook(nil, func(e Entry) {
+				callers = append(callers, e.Fields[FieldCaller].(string))
+			})
+			useGlobalLogger(t, logger)
+
+			_, _, line, _ := runtime.Caller(0)
+			logger.Info("a")
+			logger.WithField("user", "alice").Warnf("%s", "b")
+			logger.ErrorContext(context.Background(), "c")
+			Info("d")
+			WithField("user", "bob").Error("e")
+			ErrorContext(context.Background(), "f")
+
+			require.Len(t, callers, 6)
+			for i, c := range callers {
+				assert.Contains(t, c, callerAt(line+1+i)+"TestWithCaller.func1", i)
+			}
+			if LogTypeOTLP != logType {
+				assert.Contains(t, buf.String(), callerAt(line+1))
+			}
+		})
+	}
+}
+
+// TestWithCallerSkip 测试额外跳过封装函数的栈帧，以及没有开启时不记录调用位置。
+func TestWithCallerSkip(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(WithWriter(&buf), WithCaller(true), WithCallerSkip(1))
+	require.NoError(t, err)
+	_, _, line, _ := runtime.Caller(0)
+	logWrapped(logger.WithField("zone", "cn"), "wrapped")
+	assert.Contains(t, buf.String(), fmt.Sprintf("[WARN] [caller=%sTestWithCallerSkip zone=cn] wrapped", callerAt(line+1)))
+
+	buf.Reset()
+	logger, err = NewLogger(WithWriter(&buf), WithCaller(false), WithCallerSkip(1))
+	require.NoError(t, err)
+	logger.WithField("zone", "cn").Info("plain")
+	assert.Contains(t, buf.String(), "[INFO] [zone=cn] plain")
+	assert.Same(t, logger, addCallerSkip(logger, 1))
+}
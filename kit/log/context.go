@@ -0,0 +1,196 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	stdsync "sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+
+	kitid "github.com/fsyyft-go/monorepo/kit/id"
+)
+
+const (
+	// ContextExtractorRequestID 是内置的请求标识提取器的名称，输出 kit/id 保存在 context 中的请求标识。
+	ContextExtractorRequestID = "request_id"
+	// ContextExtractorTrace 是内置的链路提取器的名称，输出 OpenTelemetry 的 trace_id 与 span_id。
+	ContextExtractorTrace = "trace"
+
+	// FieldTraceID 是日志中链路标识的字段名。
+	FieldTraceID = "trace_id"
+	// FieldSpanID 是日志中 span 标识的字段名。
+	FieldSpanID = "span_id"
+)
+
+var (
+	// contextExtractorsMu 串行化提取器的注册。
+	contextExtractorsMu stdsync.Mutex
+	// contextExtractorsCurrent 是当前的提取器列表，注册时整体替换，输出日志时无锁读取。
+	contextExtractorsCurrent atomic.Pointer[[]namedContextExtractor]
+)
+
+type (
+	// ContextExtractor 从 context 中提取需要输出到日志的字段，没有可输出的字段时返回 nil。
+	ContextExtractor func(ctx context.Context) map[string]interface{}
+
+	// loggerKey 是 Logger 在 context 中的键。
+	loggerKey struct{}
+
+	// namedContextExtractor 是带名称的提取器。
+	namedContextExtractor struct {
+		// name 是注册时使用的名称。
+		name string
+		// extractor 是提取器。
+		extractor ContextExtractor
+	}
+)
+
+func init() {
+	contextExtractorsCurrent.Store(&[]namedContextExtractor{})
+	RegisterContextExtractor(ContextExtractorRequestID, extractRequestID)
+	RegisterContextExtractor(ContextExtractorTrace, extractTrace)
+}
+
+// RegisterContextExtractor 注册 context 字段的提取器，DebugContext、InfoContext 等方法输出日志时与 WithContext 派生时依次调用全部提取器，
+// 将返回的字段添加到日志中，使 context 中的链路标识、请求标识与租户标识等无需逐一通过 WithField 添加。
+//
+// 提取器按注册的顺序调用，同名字段以后调用的为准。重复注册同一个名称时原地替换，extractor 为 nil 时取消注册。
+//
+// 内置的提取器：
+//   - ContextExtractorRequestID：kit/id 保存在 context 中的请求标识，字段名为 request_id。
+//   - ContextExtractorTrace：有效的 OpenTelemetry span 的 trace_id 与 span_id。
+//
+// 提取器只在日志级别启用时调用，应当快速且不修改 context。通常在程序初始化时注册，注册是并发安全的。
+//
+// 参数：
+//   - name：提取器的名称。
+//   - extractor：提取器，为 nil 时取消注册。
+//
+// 示例：
+//
+//	log.RegisterContextExtractor("tenant", func(ctx context.Context) map[string]interface{} {
+//	    if tenant, ok := tenantFromContext(ctx); ok {
+//	        return map[string]interface{}{"tenant_id": tenant}
+//	    }
+//	    return nil
+//	})
+func RegisterContextExtractor(name string, extractor ContextExtractor) {
+	contextExtractorsMu.Lock()
+	defer contextExtractorsMu.Unlock()
+
+	old := *contextExtractorsCurrent.Load()
+	next := make([]namedContextExtractor, 0, len(old)+1)
+	replaced := false
+	for _, e := range old {
+		if e.name != name {
+			next = append(next, e)
+			continue
+		}
+		replaced = true
+		if nil != extractor {
+			next = append(next, namedContextExtractor{name: name, extractor: extractor})
+		}
+	}
+	if !replaced && nil != extractor {
+		next = append(next, namedContextExtractor{name: name, extractor: extractor})
+	}
+	contextExtractorsCurrent.Store(&next)
+}
+
+// ContextFields 调用全部注册的提取器，返回 context 中需要输出到日志的字段。
+//
+// 参数：
+//   - ctx：context，为 nil 时返回 nil。
+//
+// 返回值：
+//   - map[string]interface{}：提取的字段，没有字段时返回 nil。
+func ContextFields(ctx context.Context) map[string]interface{} {
+	if nil == ctx {
+		return nil
+	}
+	var fields map[string]interface{}
+	for _, e := range *contextExtractorsCurrent.Load() {
+		extracted := e.extractor(ctx)
+		if 0 == len(extracted) {
+			continue
+		}
+		if nil == fields {
+			fields = make(map[string]interface{}, len(extracted))
+		}
+		for k, v := range extracted {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// extractRequestID 是请求标识的内置提取器。
+func extractRequestID(ctx context.Context) map[string]interface{} {
+	if requestID, ok := kitid.FromContext(ctx); ok {
+		return map[string]interface{}{kitid.LogFieldRequestID: requestID}
+	}
+	return nil
+}
+
+// extractTrace 是链路的内置提取器。
+func extractTrace(ctx context.Context) map[string]interface{} {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return map[string]interface{}{
+		FieldTraceID: sc.TraceID().String(),
+		FieldSpanID:  sc.SpanID().String(),
+	}
+}
+
+// NewContext 返回携带 logger 的 context，下游通过 FromContext 取得该 logger，无需依赖全局日志实例。
+//
+// 参数：
+//   - ctx：父 context。
+//   - logger：请求范围的日志实例，为 nil 时返回 ctx 本身。
+//
+// 返回值：
+//   - context.Context：携带 logger 的 context。
+//
+// 示例：
+//
+//	func middleware(next http.Handler) http.Handler {
+//	    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	        logger := log.GetLogger().WithContext(r.Context()).WithField("path", r.URL.Path)
+//	        next.ServeHTTP(w, r.WithContext(log.NewContext(r.Context(), logger)))
+//	    })
+//	}
+func NewContext(ctx context.Context, logger Logger) context.Context {
+	if nil == logger {
+		return ctx
+	}
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext 返回 NewContext 保存在 ctx 中的日志实例，没有保存时返回全局日志实例。
+//
+// 参数：
+//   - ctx：context，为 nil 时返回全局日志实例。
+//
+// 返回值：
+//   - Logger：日志实例。
+//
+// 示例：
+//
+//	func (s *OrderService) Create(ctx context.Context, order *Order) error {
+//	    log.FromContext(ctx).WithField("order_id", order.ID).Info("创建订单")
+//	    // ...
+//	}
+func FromContext(ctx context.Context) Logger {
+	if nil != ctx {
+		if logger, ok := ctx.Value(loggerKey{}).(Logger); ok {
+			return logger
+		}
+	}
+	return GetLogger()
+}
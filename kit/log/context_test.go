@@ -0,0 +1,200 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	kitid "github.com/fsyyft-go/monorepo/kit/id"
+)
+
+// tenantKey 是测试中租户标识在 context 中的键。
+type tenantKey struct{}
+
+// newTestContext 返回携带请求标识与有效 span 的 context。
+func newTestContext(t *testing.T) context.Context {
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+	return trace.ContextWithSpanContext(kitid.NewContext(context.Background(), "req-1"), sc)
+}
+
+// registerTenantExtractor 注册测试用的租户提取器，测试结束时取消注册。
+func registerTenantExtractor(t *testing.T) {
+	RegisterContextExtractor("tenant", func(ctx context.Context) map[string]interface{} {
+		if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+			return map[string]interface{}{"tenant_id": tenant}
+		}
+		return nil
+	})
+	t.Cleanup(func() {
+		RegisterContextExtractor("tenant", nil)
+	})
+}
+
+// TestContextFields 测试内置提取器与自定义提取器，替换与取消注册。
+func TestContextFields(t *testing.T) {
+	assert.Nil(t, ContextFields(nil)) // nolint:staticcheck
+	assert.Nil(t, ContextFields(context.Background()))
+
+	ctx := newTestContext(t)
+	assert.Equal(t, map[string]interface{}{
+		"request_id": "req-1",
+		FieldTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		FieldSpanID:  "00f067aa0ba902b7",
+	}, ContextFields(ctx))
+
+	registerTenantExtractor(t)
+	ctx = context.WithValue(ctx, tenantKey{}, "acme")
+	assert.Equal(t, "acme", ContextFields(ctx)["tenant_id"])
+
+	// 同名注册原地替换，后注册的提取器覆盖先输出的同名字段。
+	RegisterContextExtractor(ContextExtractorRequestID, func(context.Context) map[string]interface{} {
+		return map[string]interface{}{"request_id": "replaced"}
+	})
+	defer RegisterContextExtractor(ContextExtractorRequestID, extractRequestID)
+	RegisterContextExtractor("override", func(context.Context) map[string]interface{} {
+		return map[string]interface{}{"tenant_id": "override"}
+	})
+	fields := ContextFields(ctx)
+	assert.Equal(t, "replaced", fields["request_id"])
+	assert.Equal(t, "override", fields["tenant_id"])
+
+	RegisterContextExtractor("override", nil)
+	RegisterContextExtractor(ContextExtractorTrace, nil)
+	defer RegisterContextExtractor(ContextExtractorTrace, extractTrace)
+	fields = ContextFields(ctx)
+	assert.Equal(t, "acme", fields["tenant_id"])
+	assert.NotContains(t, fields, FieldTraceID)
+}
+
+// TestLoggers_Context 测试各个日志实现的 Context 方法输出提取的字段，日志级别未启用时不调用提取器。
+func TestLoggers_Context(t *testing.T) {
+	ctx := newTestContext(t)
+	calls := 0
+	RegisterContextExtractor("count", func(context.Context) map[string]interface{} {
+		calls++
+		return nil
+	})
+	defer RegisterContextExtractor("count", nil)
+
+	t.Run("std", func(t *testing.T) {
+		logPath := filepath.Join(t.TempDir(), "std.log")
+		logger, err := NewStdLogger(logPath)
+		require.NoError(t, err)
+
+		calls = 0
+		logger.DebugContext(ctx, "hidden")
+		assert.Zero(t, calls)
+		logger.WithField("user", "alice").InfoContext(ctx, "hello")
+		logger.WarnContext(context.Background(), "plain")
+
+		content, err := os.ReadFile(logPath) // nolint:gosec
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		require.Len(t, lines, 2)
+		assert.True(t, strings.HasSuffix(lines[0],
+			" [INFO] [request_id=req-1 span_id=00f067aa0ba902b7 trace_id=4bf92f3577b34da6a3ce929d0e0e4736 user=alice] hello"), lines[0])
+		assert.True(t, strings.HasSuffix(lines[1], " [WARN] plain"), lines[1])
+	})
+
+	t.Run("logrus", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newTestLogrusLogger(&buf, logrus.InfoLevel)
+
+		calls = 0
+		logger.DebugContext(ctx, "hidden")
+		assert.Zero(t, calls)
+		logger.WithField("user", "alice").ErrorContext(ctx, "hello")
+
+		lines := decodeLines(t, &buf)
+		require.Len(t, lines, 1)
+		assert.Equal(t, "req-1", lines[0]["request_id"])
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", lines[0][FieldTraceID])
+		assert.Equal(t, "alice", lines[0]["user"])
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := NewSlogLogger(WithSlogWriter(&buf))
+		require.NoError(t, err)
+
+		calls = 0
+		logger.DebugContext(ctx, "hidden")
+		assert.Zero(t, calls)
+		logger.WithField("user", "alice").WarnContext(ctx, "hello")
+
+		lines := decodeLines(t, &buf)
+		require.Len(t, lines, 1)
+		assert.Equal(t, "WARN", lines[0]["level"])
+		assert.Equal(t, "req-1", lines[0]["request_id"])
+		assert.Equal(t, "00f067aa0ba902b7", lines[0][FieldSpanID])
+		assert.Equal(t, "alice", lines[0]["user"])
+	})
+}
+
+// TestLoggers_WithContext 测试各个日志实现派生的 Logger 携带提取的字段，没有字段时返回原实例。
+func TestLoggers_WithContext(t *testing.T) {
+	ctx := newTestContext(t)
+
+	var logrusBuf, slogBuf bytes.Buffer
+	slogLogger, err := NewSlogLogger(WithSlogWriter(&slogBuf))
+	require.NoError(t, err)
+	loggers := []struct {
+		logger Logger
+		buf    *bytes.Buffer
+	}{
+		{logger: newTestLogrusLogger(&logrusBuf, logrus.InfoLevel), buf: &logrusBuf},
+		{logger: slogLogger, buf: &slogBuf},
+	}
+	for _, tc := range loggers {
+		assert.Same(t, tc.logger, tc.logger.WithContext(context.Background()))
+
+		tc.logger.WithContext(ctx).WithField("user", "alice").Info("hello")
+		lines := decodeLines(t, tc.buf)
+		require.Len(t, lines, 1)
+		assert.Equal(t, "req-1", lines[0]["request_id"])
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", lines[0][FieldTraceID])
+		assert.Equal(t, "alice", lines[0]["user"])
+	}
+
+	logPath := filepath.Join(t.TempDir(), "std.log")
+	stdLogger, err := NewStdLogger(logPath)
+	require.NoError(t, err)
+	stdLogger.WithContext(kitid.NewContext(context.Background(), "req-2")).Info("hello")
+	content, err := os.ReadFile(logPath) // nolint:gosec
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "[INFO] [request_id=req-2] hello")
+}
+
+// TestNewContext 测试通过 context 传递日志实例，没有保存时返回全局日志实例。
+func TestNewContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogrusLogger(&buf, logrus.InfoLevel).WithField("scope", "request")
+
+	assert.Same(t, GetLogger(), FromContext(context.Background()))
+	assert.Same(t, GetLogger(), FromContext(nil)) // nolint:staticcheck
+	ctx := context.Background()
+	assert.Equal(t, ctx, NewContext(ctx, nil))
+
+	ctx = NewContext(ctx, logger)
+	assert.Same(t, logger, FromContext(ctx))
+	FromContext(context.WithValue(ctx, tenantKey{}, "acme")).Info("downstream")
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "request", lines[0]["scope"])
+}
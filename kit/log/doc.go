@@ -7,7 +7,7 @@ Package log 提供了一个统一的日志接口和多种日志实现。
 
 主要特性：
 
-  - 支持多种日志后端（标准输出、Logrus）
+  - 支持多种日志后端（标准输出、Logrus、log/slog）
   - 提供统一的日志接口
   - 支持结构化日志记录
   - 支持多个日志级别
@@ -15,6 +15,12 @@ Package log 提供了一个统一的日志接口和多种日志实现。
   - 支持函数式配置选项
   - 支持日志文件轮转
   - 支持日志格式化（文本/JSON）
+  - 支持按类型注册字段值的编码器
+  - 支持从 context 中提取链路标识、请求标识等字段
+  - 支持在记录日志时调用钩子，对全部日志后端有效
+  - 支持记录调用位置（文件、行号与函数名）
+  - 支持分批写入 Elasticsearch 或 OpenSearch
+  - 支持通过 OTLP/HTTP 将日志发送到 OpenTelemetry Collector
 
 日志级别：
 
@@ -40,6 +46,8 @@ Package log 提供了一个统一的日志接口和多种日志实现。
 	); err != nil {
 	    panic(err)
 	}
+	// 程序退出前写入缓冲的日志并关闭日志文件
+	defer log.Close()
 
 	// 记录不同级别的日志
 	log.Debug("调试信息")
@@ -67,6 +75,29 @@ Package log 提供了一个统一的日志接口和多种日志实现。
 	    panic(err)
 	}
 
+多个输出目标：
+
+	// 标准输出使用文本格式，文件使用 JSON 格式
+	if err := log.InitLogger(
+	    log.WithLogType(log.LogTypeSlog),
+	    log.WithSinks(
+	        log.Sink{FormatType: log.TextFormat},
+	        log.Sink{Output: "/var/log/app.log", FormatType: log.JSONFormat},
+	    ),
+	); err != nil {
+	    panic(err)
+	}
+
+异步写入：
+
+	// 后台协程批量写入，缓冲区已满时默认丢弃新的日志，WithAsyncPolicy(log.AsyncBlock) 改为等待
+	if err := log.InitLogger(
+	    log.WithOutput("/var/log/app.log"),
+	    log.WithAsync(4096, 500*time.Millisecond),
+	); err != nil {
+	    panic(err)
+	}
+
 独立日志实例：
 
 	// 创建独立的日志实例
@@ -82,6 +113,81 @@ Package log 提供了一个统一的日志接口和多种日志实现。
 	// 使用独立实例记录日志
 	logger.Info("使用独立的日志实例")
 
+slog 后端：
+
+	// 使用标准库 log/slog 输出，也可以通过 NewSlogLogger 与 WithSlogHandler 接入自定义的处理器
+	logger, err := log.NewLogger(log.WithLogType(log.LogTypeSlog), log.WithFormatType(log.TextFormat))
+	slog.SetDefault(logger.(*log.SlogLogger).Slog())
+
+context 字段：
+
+	// InfoContext 等方法输出 context 中的 request_id、trace_id 与 span_id，也可以注册自定义的提取器
+	log.RegisterContextExtractor("tenant", func(ctx context.Context) map[string]interface{} {
+	    return map[string]interface{}{"tenant_id": tenantOf(ctx)}
+	})
+	log.InfoContext(ctx, "创建订单")
+
+	// 中间件派生请求范围的日志实例并保存在 context 中，下游通过 FromContext 取出
+	ctx = log.NewContext(ctx, log.WithContext(ctx).WithField("path", r.URL.Path))
+	log.FromContext(ctx).Info("处理请求")
+
+错误字段：
+
+	// 输出 error、error_type 与错误链中携带的 error_stack，EnableErrorStack 开启后为其他错误捕获调用处的堆栈
+	log.WithError(err).WithField("path", path).Error("加载配置失败")
+
+字段值编码：
+
+	// error、time.Duration 与 fmt.Stringer 使用内置的编码器，也可以按类型注册自定义的编码器
+	log.RegisterFieldEncoder(reflect.TypeFor[Cents](), func(v interface{}) interface{} {
+	    return float64(v.(Cents)) / 100
+	})
+
+动态日志级别：
+
+	// 为模块设置独立的日志实例
+	dbLogger, _ := log.NewLogger(log.WithLevel(log.WarnLevel))
+	log.SetModuleLogger("db", dbLogger)
+
+	// 从配置中心读取 "info,db=debug" 形式的级别配置，变化时更新全局与模块的日志级别
+	w := log.NewLevelWatcher(log.PollLevelSource(fetch, 10*time.Second))
+	_ = w.Start(ctx)
+	defer w.Stop(context.Background())
+
+写入 Elasticsearch：
+
+	// 日志分批通过 _bulk 接口写入按天生成的索引，缓冲区已满时丢弃并计入 MetricWriterEntries
+	w, _ := log.NewElasticsearchWriter("http://localhost:9200", log.WithElasticsearchIndex("app-{2006.01.02}"))
+	defer w.Close()
+	logger, _ := log.NewLogger(log.WithFormatType(log.JSONFormat), log.WithWriter(w))
+	defer logger.Close() // 不关闭 w
+
+发送到日志收集服务：
+
+	// 每条日志以换行符结尾写入 TCP 连接，断线后重新建立连接并重试
+	w, _ := log.NewTCPWriter("logstash:5000")
+	defer w.Close()
+
+发送到 OpenTelemetry Collector：
+
+	// 日志转换为 OTLP 日志记录，ctx 中的 span 写入 TraceId 与 SpanId
+	logger, _ := log.NewLogger(log.WithLogType(log.LogTypeOTLP), log.WithOTLP("http://otel-collector:4318"))
+	defer logger.Close()
+	logger.InfoContext(ctx, "hello")
+
+记录调用位置：
+
+	// 每条日志添加 caller 字段，例如 caller=order/service.go:42:order.(*Service).Create
+	_ = log.InitLogger(log.WithCaller(true))
+	log.Info("hello") // 调用位置是这一行，而不是 log 包内部
+
+错误日志告警：
+
+	// 钩子在记录日志的协程中同步执行，应当尽快返回
+	log.AddHook([]log.Level{log.ErrorLevel, log.FatalLevel}, func(e log.Entry) {
+	    alert(e.Message, e.Fields)
+	})
+
 更多示例请参考 example/log 目录。
 */
 package log
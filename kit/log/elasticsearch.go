@@ -0,0 +1,686 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kitjson "github.com/fsyyft-go/monorepo/kit/json"
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+var (
+	// ErrWriterClosed 表示日志写入器已经关闭。
+	ErrWriterClosed = errors.New("日志写入器已关闭")
+)
+
+// 以下为 ElasticsearchWriter 的默认参数配置。
+// 可通过 ElasticsearchOption 机制覆盖。
+var (
+	// esIndexDefault 为索引名称的模板，按天写入不同的索引。
+	esIndexDefault = "logs-{2006.01.02}"
+	// esBatchSizeDefault 为每个 _bulk 请求最多包含的日志条数。
+	esBatchSizeDefault = 500
+	// esFlushIntervalDefault 为不足一批时提交的间隔。
+	esFlushIntervalDefault = time.Second
+	// esBufferSizeDefault 为等待提交的日志条数上限。
+	esBufferSizeDefault = 10000
+	// esTimeoutDefault 为单个 _bulk 请求的超时时间。
+	esTimeoutDefault = 10 * time.Second
+	// esNameDefault 为写入器的名称。
+	esNameDefault = "elasticsearch"
+	// esBackoffDefault 为提交失败时的重试策略。
+	esBackoffDefault = []retry.BackoffOption{
+		retry.WithMin(100 * time.Millisecond),
+		retry.WithMax(5 * time.Second),
+		retry.WithJitter(true),
+		retry.WithMaxAttempts(4),
+	}
+)
+
+type (
+	// ElasticsearchOption 定义了 ElasticsearchWriter 的配置选项。
+	ElasticsearchOption func(*elasticsearchOptions)
+
+	// elasticsearchOptions 包含 ElasticsearchWriter 的配置。
+	elasticsearchOptions struct {
+		// index 是索引名称的模板。
+		index string
+		// batchSize 是每个 _bulk 请求最多包含的日志条数。
+		batchSize int
+		// flushInterval 是不足一批时提交的间隔。
+		flushInterval time.Duration
+		// bufferSize 是等待提交的日志条数上限。
+		bufferSize int
+		// timeout 是单个 _bulk 请求的超时时间。
+		timeout time.Duration
+		// client 是发送请求使用的 HTTP 客户端。
+		client *http.Client
+		// username 与 password 是 Basic 认证的用户名与密码，username 为空时不使用。
+		username, password string
+		// apiKey 是 ApiKey 认证的凭据，为空时不使用。
+		apiKey string
+		// backoff 是提交失败时的重试策略。
+		backoff []retry.BackoffOption
+		// name 是写入器的名称，用于指标标签。
+		name string
+		// metrics 表示是否记录指标。
+		metrics bool
+		// onError 处理提交失败的错误，为 nil 时输出到标准错误。
+		onError func(err error)
+		// clock 是计算索引日期与提交间隔使用的时钟。
+		clock kittime.Clock
+	}
+
+	// esEntry 是等待提交的一条日志。
+	esEntry struct {
+		// index 是写入的索引。
+		index string
+		// doc 是日志的 JSON 文档。
+		doc []byte
+	}
+
+	// esBulkResponse 是 _bulk 响应中需要的部分。
+	esBulkResponse struct {
+		// Errors 表示是否有文档写入失败。
+		Errors bool `json:"errors"`
+		// Items 按请求的顺序保存每个文档的结果，键为操作类型。
+		Items []map[string]struct {
+			// Status 是文档的状态码。
+			Status int `json:"status"`
+			// Error 是写入失败的原因。
+			Error struct {
+				// Type 是错误的类型。
+				Type string `json:"type"`
+				// Reason 是错误的描述。
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+
+	// ElasticsearchWriter 将日志分批通过 _bulk 接口写入 Elasticsearch 或 OpenSearch，实现了 io.Writer。
+	// 每次 Write 是一条日志：JSON 对象原样作为文档，其他内容作为 message 字段，并加上 @timestamp 字段。
+	// 日志先进入有界的缓冲区，由后台协程按数量与间隔提交，Write 不会阻塞；缓冲区已满时丢弃日志并记录指标。
+	// 返回 429 或 5xx 的请求与文档按退避策略重试，其他失败的文档直接丢弃。
+	// 所有方法都是并发安全的。
+	ElasticsearchWriter struct {
+		// url 是 _bulk 接口的地址。
+		url string
+		// o 是写入器的配置。
+		o *elasticsearchOptions
+		// entries 是等待提交的日志。
+		entries chan esEntry
+		// syncs 接收 Sync 的请求，提交完成后关闭请求中的通道。
+		syncs chan chan struct{}
+		// done 在后台协程退出时关闭。
+		done chan struct{}
+		// sent、failed 与 dropped 是各处理结果的指标，未开启指标时为 nil。
+		sent, failed, dropped prometheus.Counter
+
+		// mu 保护 closed 与 entries 的关闭。
+		mu sync.RWMutex
+		// closed 表示写入器是否已经关闭。
+		closed bool
+	}
+)
+
+// WithElasticsearchIndex 设置索引名称的模板，花括号中的内容是 Go 的时间格式，按写入时的 UTC 时间替换。
+//
+// 参数：
+//   - index：索引名称的模板，默认为 logs-{2006.01.02}，即每天一个索引；不含花括号时总是写入同一个索引。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchIndex(index string) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.index = index
+	}
+}
+
+// WithElasticsearchBatchSize 设置每个 _bulk 请求最多包含的日志条数。
+//
+// 参数：
+//   - n：日志条数，默认为 500，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchBatchSize(n int) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.batchSize = n
+	}
+}
+
+// WithElasticsearchFlushInterval 设置不足一批时提交的间隔。
+//
+// 参数：
+//   - d：提交间隔，默认为 1 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchFlushInterval(d time.Duration) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.flushInterval = d
+	}
+}
+
+// WithElasticsearchBufferSize 设置等待提交的日志条数上限，超过时 Write 丢弃日志。
+//
+// 参数：
+//   - n：日志条数，默认为 10000，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchBufferSize(n int) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.bufferSize = n
+	}
+}
+
+// WithElasticsearchTimeout 设置单个 _bulk 请求的超时时间。
+//
+// 参数：
+//   - d：超时时间，默认为 10 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchTimeout(d time.Duration) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.timeout = d
+	}
+}
+
+// WithElasticsearchHTTPClient 设置发送请求使用的 HTTP 客户端，用于配置 TLS 与代理等。
+//
+// 参数：
+//   - client：HTTP 客户端，默认为 http.DefaultClient。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchHTTPClient(client *http.Client) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.client = client
+	}
+}
+
+// WithElasticsearchBasicAuth 设置 Basic 认证的用户名与密码。
+//
+// 参数：
+//   - username：用户名。
+//   - password：密码。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchBasicAuth(username, password string) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithElasticsearchAPIKey 设置 ApiKey 认证的凭据，即创建 API Key 时返回的 encoded 字段。
+//
+// 参数：
+//   - apiKey：Base64 编码的凭据。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchAPIKey(apiKey string) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithElasticsearchBackoff 设置提交失败时的重试策略，在默认策略之后应用。
+// 默认最多尝试 4 次，等待时间从 100 毫秒开始按指数增长，最长 5 秒，并带有抖动。
+//
+// 参数：
+//   - opts：kit/runtime/retry 的退避选项，例如 retry.WithMaxAttempts。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchBackoff(opts ...retry.BackoffOption) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.backoff = append(o.backoff, opts...)
+	}
+}
+
+// WithElasticsearchName 设置写入器的名称，用于区分多个写入器的指标。
+//
+// 参数：
+//   - name：写入器的名称，默认为 elasticsearch。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchName(name string) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.name = name
+	}
+}
+
+// WithElasticsearchMetrics 设置是否记录 MetricWriterEntries 指标。
+//
+// 参数：
+//   - metrics：是否记录指标，默认为 true。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchMetrics(metrics bool) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.metrics = metrics
+	}
+}
+
+// WithElasticsearchErrorHandler 设置提交失败时的处理函数。
+// 处理函数不应当把错误写回同一个写入器，否则服务端不可用时会不断产生新的日志。
+//
+// 参数：
+//   - fn：错误处理函数，默认输出到标准错误。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchErrorHandler(fn func(err error)) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.onError = fn
+	}
+}
+
+// WithElasticsearchClock 设置计算索引日期与提交间隔使用的时钟，测试时可以注入 kit/time 的 FakeClock。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟。
+//
+// 返回值：
+//   - ElasticsearchOption：配置选项函数。
+func WithElasticsearchClock(clock kittime.Clock) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.clock = clock
+	}
+}
+
+// NewElasticsearchWriter 创建写入 Elasticsearch 或 OpenSearch 的写入器，并启动后台提交协程。
+// 不再使用时需要调用 Close，提交缓冲区中剩余的日志。
+//
+// 参数：
+//   - address：集群的地址，例如 http://localhost:9200，可以包含路径前缀。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *ElasticsearchWriter：写入器实例。
+//   - error：地址或索引名称的模板无效时返回错误。
+//
+// 示例：
+//
+//	w, err := log.NewElasticsearchWriter("https://es.example.com:9200",
+//	    log.WithElasticsearchIndex("order-{2006.01.02}"),
+//	    log.WithElasticsearchAPIKey(os.Getenv("ES_API_KEY")),
+//	)
+//	if nil != err {
+//	    return err
+//	}
+//	defer w.Close()
+func NewElasticsearchWriter(address string, opts ...ElasticsearchOption) (*ElasticsearchWriter, error) {
+	o := &elasticsearchOptions{
+		index:         esIndexDefault,
+		batchSize:     esBatchSizeDefault,
+		flushInterval: esFlushIntervalDefault,
+		bufferSize:    esBufferSizeDefault,
+		timeout:       esTimeoutDefault,
+		name:          esNameDefault,
+		metrics:       true,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.batchSize <= 0 {
+		o.batchSize = esBatchSizeDefault
+	}
+	if o.flushInterval <= 0 {
+		o.flushInterval = esFlushIntervalDefault
+	}
+	if o.bufferSize <= 0 {
+		o.bufferSize = esBufferSizeDefault
+	}
+	if o.timeout <= 0 {
+		o.timeout = esTimeoutDefault
+	}
+	if nil == o.client {
+		o.client = http.DefaultClient
+	}
+	o.clock = kittime.OrReal(o.clock)
+	// 重试默认使用写入器的时钟，调用方传入的退避配置可以覆盖。
+	o.backoff = append(append([]retry.BackoffOption{retry.WithClock(o.clock)}, esBackoffDefault...), o.backoff...)
+
+	u, err := url.Parse(address)
+	if nil != err || ("http" != u.Scheme && "https" != u.Scheme) || "" == u.Host {
+		return nil, fmt.Errorf("无效的 Elasticsearch 地址：%q", address)
+	}
+	if _, err := formatIndex(o.index, o.clock.Now()); nil != err {
+		return nil, err
+	}
+
+	w := &ElasticsearchWriter{
+		url:     strings.TrimRight(address, "/") + "/_bulk",
+		o:       o,
+		entries: make(chan esEntry, o.bufferSize),
+		syncs:   make(chan chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if o.metrics {
+		w.sent = MetricWriterEntries.WithLabelValues(o.name, "sent")
+		w.failed = MetricWriterEntries.WithLabelValues(o.name, "failed")
+		w.dropped = MetricWriterEntries.WithLabelValues(o.name, "dropped")
+	}
+
+	go w.run()
+
+	return w, nil
+}
+
+// Write 将一条日志放入缓冲区，立即返回。缓冲区已满时丢弃日志，仍然返回 nil，避免阻塞记录日志的调用方。
+//
+// 参数：
+//   - p：一条日志，末尾的换行符会被去掉。
+//
+// 返回值：
+//   - int：总是返回 len(p)。
+//   - error：写入器已经关闭时返回 ErrWriterClosed。
+func (w *ElasticsearchWriter) Write(p []byte) (int, error) {
+	now := w.o.clock.Now()
+	// 模板在创建时已经校验过。
+	index, _ := formatIndex(w.o.index, now)
+	e := esEntry{index: index, doc: esDocument(bytes.TrimRight(p, "\r\n"), now)}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return 0, ErrWriterClosed
+	}
+	select {
+	case w.entries <- e:
+	default:
+		inc(w.dropped, 1)
+	}
+	return len(p), nil
+}
+
+// Sync 提交缓冲区中的全部日志，在提交完成（包括重试）后返回。
+//
+// 返回值：
+//   - error：总是返回 nil，提交失败交给错误处理函数。
+func (w *ElasticsearchWriter) Sync() error {
+	done := make(chan struct{})
+	select {
+	case w.syncs <- done:
+		<-done
+	case <-w.done:
+	}
+	return nil
+}
+
+// Close 停止接收日志，提交缓冲区中剩余的日志后返回，重复调用直接返回 nil。
+//
+// 返回值：
+//   - error：总是返回 nil，提交失败交给错误处理函数。
+func (w *ElasticsearchWriter) Close() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.entries)
+	}
+	w.mu.Unlock()
+
+	<-w.done
+	return nil
+}
+
+// run 是后台提交协程，按数量与间隔分批提交日志，entries 关闭后提交剩余的日志并退出。
+func (w *ElasticsearchWriter) run() {
+	defer close(w.done)
+
+	ticker := w.o.clock.NewTicker(w.o.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]esEntry, 0, w.o.batchSize)
+	add := func(e esEntry) {
+		batch = append(batch, e)
+		if len(batch) >= w.o.batchSize {
+			w.flush(batch)
+			batch = batch[:0]
+		}
+	}
+	for {
+		select {
+		case e, ok := <-w.entries:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+			add(e)
+		case <-ticker.C():
+			w.flush(batch)
+			batch = batch[:0]
+		case done := <-w.syncs:
+			// 只提交 Sync 调用之前已经进入缓冲区的日志，避免持续写入时 Sync 无法返回。
+			for n := len(w.entries); n > 0; n-- {
+				if e, ok := <-w.entries; ok {
+					add(e)
+				}
+			}
+			w.flush(batch)
+			batch = batch[:0]
+			close(done)
+		}
+	}
+}
+
+// flush 提交一批日志，可以重试的失败按退避策略重试，重试用尽时丢弃剩余的日志。
+//
+// 参数：
+//   - batch：要提交的日志，返回后不再持有。
+func (w *ElasticsearchWriter) flush(batch []esEntry) {
+	if 0 == len(batch) {
+		return
+	}
+	pending := batch
+	err := retry.RetryWithContext(context.Background(), func(ctx context.Context) error {
+		var err error
+		pending, err = w.send(ctx, pending)
+		return err
+	}, w.o.backoff...)
+	if nil != err {
+		inc(w.failed, len(pending))
+		w.handleError(fmt.Errorf("写入 Elasticsearch 失败，丢弃 %d 条日志：%w", len(pending), err))
+	}
+}
+
+// send 发送一个 _bulk 请求，成功与无法重试的文档计入指标。
+//
+// 参数：
+//   - ctx：请求的上下文。
+//   - entries：要提交的日志。
+//
+// 返回值：
+//   - []esEntry：需要重试的日志。
+//   - error：存在需要重试的日志时返回原因，否则返回 nil。
+func (w *ElasticsearchWriter) send(ctx context.Context, entries []esEntry) ([]esEntry, error) {
+	var body bytes.Buffer
+	for _, e := range entries {
+		// 使用 create 操作，同时兼容普通索引与数据流。
+		b := append(body.AvailableBuffer(), `{"create":{"_index":`...)
+		b = kitjson.AppendString(b, e.index)
+		b = append(b, "}}\n"...)
+		b = append(b, e.doc...)
+		b = append(b, '\n')
+		body.Write(b)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.o.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, &body)
+	if nil != err {
+		return entries, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if "" != w.o.apiKey {
+		req.Header.Set("Authorization", "ApiKey "+w.o.apiKey)
+	} else if "" != w.o.username {
+		req.SetBasicAuth(w.o.username, w.o.password)
+	}
+
+	resp, err := w.o.client.Do(req)
+	if nil != err {
+		return entries, err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+	data, err := io.ReadAll(resp.Body)
+	if nil != err {
+		return entries, err
+	}
+
+	if retryableStatus(resp.StatusCode) {
+		return entries, fmt.Errorf("_bulk 请求返回状态码 %d", resp.StatusCode)
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		inc(w.failed, len(entries))
+		w.handleError(fmt.Errorf("写入 Elasticsearch 被拒绝，丢弃 %d 条日志，状态码 %d：%s", len(entries), resp.StatusCode, truncate(data, 256)))
+		return nil, nil
+	}
+
+	var result esBulkResponse
+	if err := kitjson.Unmarshal(data, &result); nil != err || !result.Errors {
+		// 无法解析的 2xx 响应视为全部成功，重新提交会导致重复的文档。
+		inc(w.sent, len(entries))
+		return nil, nil
+	}
+
+	var (
+		retries  []esEntry
+		failed   int
+		firstErr string
+	)
+	for i, item := range result.Items {
+		if i >= len(entries) {
+			break
+		}
+		for _, r := range item {
+			switch {
+			case retryableStatus(r.Status):
+				retries = append(retries, entries[i])
+			case r.Status >= http.StatusMultipleChoices:
+				failed++
+				if "" == firstErr {
+					firstErr = fmt.Sprintf("%s：%s", r.Error.Type, r.Error.Reason)
+				}
+			}
+		}
+	}
+	inc(w.sent, len(entries)-len(retries)-failed)
+	if failed > 0 {
+		inc(w.failed, failed)
+		w.handleError(fmt.Errorf("写入 Elasticsearch 被拒绝，丢弃 %d 条日志：%s", failed, firstErr))
+	}
+	if len(retries) > 0 {
+		return retries, fmt.Errorf("%d 条日志暂时无法写入 Elasticsearch", len(retries))
+	}
+	return nil, nil
+}
+
+// handleError 将错误交给错误处理函数，未设置时输出到标准错误。
+// 默认不记录到全局日志实例，因为全局日志实例可能正在使用该写入器。
+func (w *ElasticsearchWriter) handleError(err error) {
+	if nil != w.o.onError {
+		w.o.onError(err)
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "kit/log: %v\n", err)
+}
+
+// formatIndex 将索引名称模板中花括号内的时间格式替换为 t 的 UTC 时间。
+//
+// 参数：
+//   - template：索引名称的模板。
+//   - t：日志的时间。
+//
+// 返回值：
+//   - string：索引名称。
+//   - error：花括号不成对时返回错误。
+func formatIndex(template string, t time.Time) (string, error) {
+	if !strings.ContainsAny(template, "{}") {
+		return template, nil
+	}
+	t = t.UTC()
+	var b strings.Builder
+	for rest := template; "" != rest; {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			if strings.ContainsRune(rest, '}') {
+				return "", fmt.Errorf("索引名称模板 %q 无效：花括号不成对", template)
+			}
+			b.WriteString(rest)
+			break
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 || strings.ContainsRune(rest[:open], '}') {
+			return "", fmt.Errorf("索引名称模板 %q 无效：花括号不成对", template)
+		}
+		b.WriteString(rest[:open])
+		b.WriteString(t.Format(rest[open+1 : open+end]))
+		rest = rest[open+end+1:]
+	}
+	return b.String(), nil
+}
+
+// esDocument 返回一条日志对应的文档：JSON 对象原样返回，其他内容包装为带有 @timestamp 与 message 字段的对象。
+//
+// 参数：
+//   - line：一条日志。
+//   - now：写入的时间。
+//
+// 返回值：
+//   - []byte：文档，不与 line 共享内存。
+func esDocument(line []byte, now time.Time) []byte {
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) > 0 && '{' == trimmed[0] && stdjson.Valid(trimmed) {
+		return bytes.Clone(trimmed)
+	}
+	return kitjson.AppendFields(nil, map[string]interface{}{
+		"@timestamp": now.UTC().Format(time.RFC3339Nano),
+		"message":    string(line),
+	})
+}
+
+// retryableStatus 判断状态码是否表示可以重试：429 与 5xx。
+func retryableStatus(code int) bool {
+	return http.StatusTooManyRequests == code || code >= http.StatusInternalServerError
+}
+
+// truncate 返回 data 最多前 n 个字节的字符串，用于错误信息。
+func truncate(data []byte, n int) string {
+	if len(data) > n {
+		return string(data[:n]) + "..."
+	}
+	return string(data)
+}
+
+// inc 在指标开启时增加计数。
+func inc(c prometheus.Counter, n int) {
+	if nil != c && n > 0 {
+		c.Add(float64(n))
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+type (
+	// bulkServer 是记录 _bulk 请求的测试服务，按顺序使用 responses 中的处理函数，用完后总是返回成功。
+	bulkServer struct {
+		*httptest.Server
+
+		mu        sync.Mutex
+		requests  []*http.Request
+		bodies    [][]string
+		responses []func(w http.ResponseWriter, lines []string)
+	}
+)
+
+// newBulkServer 创建测试服务，测试结束时关闭。
+func newBulkServer(t *testing.T, responses ...func(w http.ResponseWriter, lines []string)) *bulkServer {
+	s := &bulkServer{responses: responses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+
+		s.mu.Lock()
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, lines)
+		var respond func(w http.ResponseWriter, lines []string)
+		if len(s.responses) > 0 {
+			respond, s.responses = s.responses[0], s.responses[1:]
+		}
+		s.mu.Unlock()
+
+		if nil != respond {
+			respond(w, lines)
+			return
+		}
+		_, _ = w.Write([]byte(`{"errors":false,"items":[]}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// snapshot 返回收到的请求与请求体的各行。
+func (s *bulkServer) snapshot() ([]*http.Request, [][]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*http.Request(nil), s.requests...), append([][]string(nil), s.bodies...)
+}
+
+// docs 返回全部请求中的文档，不包括操作行。
+func (s *bulkServer) docs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var docs []string
+	for _, lines := range s.bodies {
+		for i := 1; i < len(lines); i += 2 {
+			docs = append(docs, lines[i])
+		}
+	}
+	return docs
+}
+
+// status 返回以固定状态码响应的处理函数。
+func status(code int) func(w http.ResponseWriter, lines []string) {
+	return func(w http.ResponseWriter, _ []string) {
+		w.WriteHeader(code)
+	}
+}
+
+// itemStatus 返回按文档顺序给出每个文档状态码的处理函数。
+func itemStatus(codes ...int) func(w http.ResponseWriter, lines []string) {
+	return func(w http.ResponseWriter, _ []string) {
+		items := make([]map[string]interface{}, 0, len(codes))
+		for _, code := range codes {
+			item := map[string]interface{}{"status": code}
+			if code >= 300 {
+				item["error"] = map[string]string{"type": "mapper_parsing_exception", "reason": "failed to parse"}
+			}
+			items = append(items, map[string]interface{}{"create": item})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": true, "items": items})
+	}
+}
+
+// fastBackoff 使用系统时钟与很短的等待时间重试。
+func fastBackoff(opts ...retry.BackoffOption) ElasticsearchOption {
+	return WithElasticsearchBackoff(append([]retry.BackoffOption{
+		retry.WithClock(kittime.NewRealClock()),
+		retry.WithMin(time.Millisecond),
+		retry.WithMax(time.Millisecond),
+	}, opts...)...)
+}
+
+// entriesSince 返回读取写入器各结果的日志条数的函数，条数从调用 entriesSince 时开始计算，重复运行测试时不受之前的影响。
+func entriesSince(name string) func(result string) float64 {
+	get := func(result string) float64 {
+		return testutil.ToFloat64(MetricWriterEntries.WithLabelValues(name, result))
+	}
+	base := map[string]float64{"sent": get("sent"), "failed": get("failed"), "dropped": get("dropped")}
+	return func(result string) float64 {
+		return get(result) - base[result]
+	}
+}
+
+// TestElasticsearchWriter 测试日志按天写入索引，JSON 日志原样作为文档，其他日志包装为 message 字段。
+func TestElasticsearchWriter(t *testing.T) {
+	entries := entriesSince(t.Name())
+	srv := newBulkServer(t)
+	clock := kittime.NewFakeClock(time.Date(2025, 1, 2, 23, 0, 0, 0, time.UTC))
+	w, err := NewElasticsearchWriter(srv.URL+"/",
+		WithElasticsearchIndex("app-{2006.01.02}"),
+		WithElasticsearchAPIKey("key"),
+		WithElasticsearchName(t.Name()),
+		WithElasticsearchClock(clock),
+	)
+	require.NoError(t, err)
+
+	n, err := w.Write([]byte(`{"level":"info","msg":"hello"}` + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 31, n)
+	clock.Advance(2 * time.Hour)
+	_, _ = w.Write([]byte("2025/01/03 01:00:00 [INFO] plain\n"))
+	require.NoError(t, w.Sync())
+
+	requests, bodies := srv.snapshot()
+	require.Len(t, requests, 1)
+	r := requests[0]
+	assert.Equal(t, "/_bulk", r.URL.Path)
+	assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+	assert.Equal(t, "ApiKey key", r.Header.Get("Authorization"))
+
+	lines := bodies[0]
+	require.Len(t, lines, 4)
+	assert.JSONEq(t, `{"create":{"_index":"app-2025.01.02"}}`, lines[0])
+	assert.Equal(t, `{"level":"info","msg":"hello"}`, lines[1])
+	assert.JSONEq(t, `{"create":{"_index":"app-2025.01.03"}}`, lines[2])
+	assert.JSONEq(t, `{"@timestamp":"2025-01-03T01:00:00Z","message":"2025/01/03 01:00:00 [INFO] plain"}`, lines[3])
+	assert.Equal(t, float64(2), entries("sent"))
+
+	// Close 提交剩余的日志，之后的写入返回 ErrWriterClosed。
+	_, _ = w.Write([]byte("last"))
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+	assert.Len(t, srv.docs(), 3)
+	_, err = w.Write([]byte("after close"))
+	assert.ErrorIs(t, err, ErrWriterClosed)
+	require.NoError(t, w.Sync())
+}
+
+// TestElasticsearchWriter_Batch 测试达到批量大小时立即提交，不足一批时按间隔提交。
+func TestElasticsearchWriter_Batch(t *testing.T) {
+	srv := newBulkServer(t)
+	clock := kittime.NewFakeClock(time.Now())
+	w, err := NewElasticsearchWriter(srv.URL,
+		WithElasticsearchBatchSize(2),
+		WithElasticsearchFlushInterval(time.Minute),
+		WithElasticsearchMetrics(false),
+		WithElasticsearchClock(clock),
+	)
+	require.NoError(t, err)
+	defer w.Close() // nolint:errcheck
+
+	for _, s := range []string{"a", "b", "c"} {
+		_, _ = w.Write([]byte(s))
+	}
+	require.Eventually(t, func() bool { return 2 == len(srv.docs()) }, time.Second, time.Millisecond)
+
+	// 第三条日志可能晚于计时器进入批次，每次检查前都推进时钟。
+	require.Eventually(t, func() bool {
+		clock.Advance(time.Minute)
+		return 3 == len(srv.docs())
+	}, time.Second, time.Millisecond)
+}
+
+// TestElasticsearchWriter_Retry 测试 429 与 5xx 的请求和文档被重试，其他失败的文档被丢弃。
+func TestElasticsearchWriter_Retry(t *testing.T) {
+	entries := entriesSince(t.Name())
+	srv := newBulkServer(t,
+		status(http.StatusTooManyRequests),
+		itemStatus(201, 503, 400),
+	)
+	var errs []error
+	w, err := NewElasticsearchWriter(srv.URL,
+		WithElasticsearchBasicAuth("elastic", "secret"),
+		WithElasticsearchName(t.Name()),
+		WithElasticsearchErrorHandler(func(err error) { errs = append(errs, err) }),
+		fastBackoff(),
+	)
+	require.NoError(t, err)
+
+	for _, s := range []string{"a", "b", "c"} {
+		_, _ = w.Write([]byte(s))
+	}
+	require.NoError(t, w.Close())
+
+	requests, bodies := srv.snapshot()
+	require.Len(t, bodies, 3)
+	assert.Len(t, bodies[1], 6)
+	// 第三次请求只包含第二个文档。
+	require.Len(t, bodies[2], 2)
+	assert.Contains(t, bodies[2][1], `"message":"b"`)
+	user, pass, ok := requests[0].BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "elastic:secret", user+":"+pass)
+
+	assert.Equal(t, float64(2), entries("sent"))
+	assert.Equal(t, float64(1), entries("failed"))
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "mapper_parsing_exception")
+}
+
+// TestElasticsearchWriter_Exhausted 测试重试用尽与无法重试的状态码时丢弃日志并报告错误。
+func TestElasticsearchWriter_Exhausted(t *testing.T) {
+	entries := entriesSince(t.Name())
+	srv := newBulkServer(t,
+		status(http.StatusBadGateway),
+		status(http.StatusBadGateway),
+		status(http.StatusBadRequest),
+	)
+	var errs []error
+	w, err := NewElasticsearchWriter(srv.URL,
+		WithElasticsearchName(t.Name()),
+		WithElasticsearchErrorHandler(func(err error) { errs = append(errs, err) }),
+		fastBackoff(retry.WithMaxAttempts(2)),
+	)
+	require.NoError(t, err)
+
+	_, _ = w.Write([]byte("a"))
+	require.NoError(t, w.Sync())
+	_, _ = w.Write([]byte("b"))
+	require.NoError(t, w.Close())
+
+	requests, _ := srv.snapshot()
+	assert.Len(t, requests, 3)
+	assert.Equal(t, float64(2), entries("failed"))
+	require.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], retry.ErrBudgetExhausted)
+	assert.Contains(t, errs[1].Error(), "状态码 400")
+}
+
+// TestElasticsearchWriter_Drop 测试提交跟不上时 Write 不阻塞，超出缓冲区的日志被丢弃并计入指标。
+func TestElasticsearchWriter_Drop(t *testing.T) {
+	entries := entriesSince(t.Name())
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	srv := newBulkServer(t, func(w http.ResponseWriter, _ []string) {
+		entered <- struct{}{}
+		<-release
+	})
+	w, err := NewElasticsearchWriter(srv.URL,
+		WithElasticsearchBatchSize(1),
+		WithElasticsearchBufferSize(1),
+		WithElasticsearchName(t.Name()),
+	)
+	require.NoError(t, err)
+
+	_, _ = w.Write([]byte("a"))
+	<-entered
+	for _, s := range []string{"b", "c", "d", "e"} {
+		n, err := w.Write([]byte(s))
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	}
+	close(release)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, float64(3), entries("dropped"))
+	assert.Equal(t, float64(2), entries("sent"))
+	assert.Equal(t, 2, len(srv.docs()))
+}
+
+// TestNewElasticsearchWriter_Invalid 测试无效的地址与索引名称模板。
+func TestNewElasticsearchWriter_Invalid(t *testing.T) {
+	for _, address := range []string{"", "localhost:9200", "ftp://es:21", "http://"} {
+		_, err := NewElasticsearchWriter(address)
+		assert.Error(t, err, address)
+	}
+	for _, index := range []string{"logs-{2006", "logs-}2006{", "logs-2006}"} {
+		_, err := NewElasticsearchWriter("http://localhost:9200", WithElasticsearchIndex(index))
+		assert.Error(t, err, index)
+	}
+}
+
+// TestFormatIndex 测试索引名称模板的替换。
+func TestFormatIndex(t *testing.T) {
+	ts := time.Date(2025, 3, 4, 5, 6, 7, 0, time.FixedZone("CST", 8*3600))
+	for template, want := range map[string]string{
+		"logs":                    "logs",
+		"logs-{2006.01.02}":       "logs-2025.03.03",
+		"{2006}-app-{01}":         "2025-app-03",
+		"logs-{2006.01.02.15}-v1": "logs-2025.03.03.21-v1",
+	} {
+		got, err := formatIndex(template, ts)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, template)
+	}
+
+	doc := esDocument([]byte(" {\"a\":1} "), ts)
+	assert.True(t, bytes.Equal([]byte(`{"a":1}`), doc))
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// FieldError 是 WithError 输出错误信息的字段名。
+	FieldError = "error"
+	// FieldErrorType 是 WithError 输出错误类型的字段名。
+	FieldErrorType = "error_type"
+	// FieldErrorStack 是 WithError 输出调用堆栈的字段名。
+	FieldErrorStack = "error_stack"
+
+	// errorStackMaxDepth 是 WithError 捕获的调用堆栈的最大深度。
+	errorStackMaxDepth = 32
+	// logPackagePrefix 是本包函数名的前缀，捕获调用堆栈时跳过本包内的调用帧。
+	logPackagePrefix = "github.com/fsyyft-go/monorepo/kit/log."
+)
+
+var (
+	// errorStackEnabled 表示错误没有携带调用堆栈时，WithError 是否捕获调用处的堆栈。
+	errorStackEnabled atomic.Bool
+)
+
+// EnableErrorStack 设置错误没有携带调用堆栈时，WithError 是否捕获调用处的堆栈，默认不捕获。
+// 错误链中携带调用堆栈（实现了 StackTrace() string，例如 kit/errors 创建的错误）时，始终输出该堆栈。
+// 捕获堆栈有一定的开销，通常只在开发环境或排查问题时开启，设置是并发安全的。
+//
+// 参数：
+//   - enabled：true 表示捕获，false 表示不捕获。
+func EnableErrorStack(enabled bool) {
+	errorStackEnabled.Store(enabled)
+}
+
+// errorFields 返回 WithError 添加的字段：错误信息、错误类型与调用堆栈。
+// 错误类型是错误链最内层的错误的类型，不受 fmt.Errorf、kit/errors.Wrap 等包装层的影响，使错误仍然可以按类型检索。
+func errorFields(err error) map[string]interface{} {
+	fields := map[string]interface{}{
+		FieldError:     err.Error(),
+		FieldErrorType: fmt.Sprintf("%T", unwrapMessage(err)),
+	}
+
+	var st stackTracer
+	if errors.As(err, &st) {
+		if stack := st.StackTrace(); "" != stack {
+			fields[FieldErrorStack] = stack
+			return fields
+		}
+	}
+	if errorStackEnabled.Load() {
+		fields[FieldErrorStack] = callerStack()
+	}
+	return fields
+}
+
+// unwrapMessage 沿错误链跳过全部包装层，返回最内层的错误。
+// 实现了 Unwrap() error 的错误沿被包装的错误继续；实现了 Unwrap() []error 的错误（errors.Join 或包含多个 %w 的 fmt.Errorf）
+// 与 errors.Is 的遍历顺序一致，沿第一个不为 nil 的错误继续，没有时返回该错误本身。
+func unwrapMessage(err error) error {
+	for {
+		var next error
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			next = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, v := range e.Unwrap() {
+				if nil != v {
+					next = v
+					break
+				}
+			}
+		}
+		if nil == next {
+			return err
+		}
+		err = next
+	}
+}
+
+// callerStack 返回调用本包的函数的调用堆栈，格式与 kit/errors 相同："函数名\n\t文件:行号"，调用帧之间以换行分隔。
+func callerStack() string {
+	pcs := make([]uintptr, errorStackMaxDepth)
+	// 跳过 runtime.Callers 与 callerStack。
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var b strings.Builder
+	skipping := true
+	for {
+		frame, more := frames.Next()
+		// 跳过调用堆栈开头本包内的调用帧，本包的测试函数除外。
+		if skipping && strings.HasPrefix(frame.Function, logPackagePrefix) && !strings.HasSuffix(frame.File, "_test.go") {
+			if !more {
+				break
+			}
+			continue
+		}
+		skipping = false
+		if "" != frame.Function {
+			if b.Len() > 0 {
+				b.WriteByte('\n')
+			}
+			b.WriteString(frame.Function)
+			b.WriteString("\n\t")
+			b.WriteString(frame.File)
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWithError 测试错误信息、错误链最内层的错误类型与错误链中携带的调用堆栈。
+func TestWithError(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogrusLogger(&buf, logrus.InfoLevel)
+	assert.Same(t, logger, logger.WithError(nil))
+
+	pathErr := &fs.PathError{Op: "open", Path: "/etc/app.yaml", Err: fs.ErrNotExist}
+	logger.WithError(fmt.Errorf("load config: %w", pathErr)).Error("failed")
+	logger.WithError(fmt.Errorf("wrap: %w", &stackError{msg: "boom"})).Error("failed")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "load config: open /etc/app.yaml: file does not exist", lines[0][FieldError])
+	assert.Equal(t, "*errors.errorString", lines[0][FieldErrorType])
+	assert.NotContains(t, lines[0], FieldErrorStack)
+
+	assert.Equal(t, "wrap: boom", lines[1][FieldError])
+	assert.Equal(t, "*log.stackError", lines[1][FieldErrorType])
+	assert.Equal(t, "main.go:10", lines[1][FieldErrorStack])
+}
+
+// TestUnwrapMessage 测试沿 Unwrap() error 与 Unwrap() []error 找到最内层的错误。
+func TestUnwrapMessage(t *testing.T) {
+	leaf := &stackError{msg: "boom"}
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "leaf", err: leaf, want: leaf},
+		{name: "wrap", err: fmt.Errorf("b: %w", fmt.Errorf("a: %w", leaf)), want: leaf},
+		{name: "path", err: &fs.PathError{Op: "open", Path: "a", Err: fs.ErrNotExist}, want: fs.ErrNotExist},
+		{name: "join", err: errors.Join(nil, fmt.Errorf("a: %w", leaf), fs.ErrClosed), want: leaf},
+		{name: "multi", err: fmt.Errorf("%w, %w", fs.ErrClosed, leaf), want: fs.ErrClosed},
+		{name: "empty", err: emptyJoin{}, want: emptyJoin{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unwrapMessage(tt.err))
+		})
+	}
+}
+
+// emptyJoin 是 Unwrap() []error 不返回任何错误的错误。
+type emptyJoin struct{}
+
+func (emptyJoin) Error() string   { return "empty" }
+func (emptyJoin) Unwrap() []error { return nil }
+
+// TestWithError_CaptureStack 测试开启后捕获调用处的堆栈，堆栈从本包之外的调用方开始。
+func TestWithError_CaptureStack(t *testing.T) {
+	EnableErrorStack(true)
+	defer EnableErrorStack(false)
+
+	var buf bytes.Buffer
+	logger := newTestLogrusLogger(&buf, logrus.InfoLevel)
+	SetLogger(logger)
+	defer SetLogger(nil)
+
+	WithError(fs.ErrClosed).Error("failed")
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	stack := lines[0][FieldErrorStack].(string)
+	assert.True(t, strings.HasPrefix(stack, logPackagePrefix+"TestWithError_CaptureStack\n\t"), stack)
+	assert.NotContains(t, stack, "WithError\n")
+	assert.Contains(t, stack, "error_test.go:")
+}
+
+// TestLoggers_WithError 测试标准库与 slog 日志实现输出错误字段。
+func TestLoggers_WithError(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "std.log")
+	stdLogger, err := NewStdLogger(logPath)
+	require.NoError(t, err)
+	stdLogger.WithError(fs.ErrNotExist).Warn("missing")
+	content, err := os.ReadFile(logPath) // nolint:gosec
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "[WARN] [error=file does not exist error_type=*errors.errorString] missing")
+
+	var buf bytes.Buffer
+	slogLogger, err := NewSlogLogger(WithSlogWriter(&buf))
+	require.NoError(t, err)
+	slogLogger.WithError(&stackError{msg: "boom"}).Error("failed")
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "boom", lines[0][FieldError])
+	assert.Equal(t, "main.go:10", lines[0][FieldErrorStack])
+}
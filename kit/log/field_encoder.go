@@ -0,0 +1,199 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	stdsync "sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// fieldEncodersMu 串行化编码器的注册。
+	fieldEncodersMu stdsync.Mutex
+	// fieldEncodersCurrent 是当前的编码器注册表，注册时整体替换，输出日志时无锁读取。
+	fieldEncodersCurrent atomic.Pointer[fieldEncoders]
+)
+
+type (
+	// FieldEncoder 把字段值转换为输出时使用的值，在交给具体的日志实现序列化之前调用。
+	// 返回的值按日志实现的规则输出：文本格式使用 fmt 的格式化结果，JSON 格式使用 JSON 编码。
+	FieldEncoder func(v interface{}) interface{}
+
+	// fieldEncoders 是不可变的编码器注册表。
+	fieldEncoders struct {
+		// exact 是按具体类型注册的编码器。
+		exact map[reflect.Type]FieldEncoder
+		// ifaces 是按接口类型注册的编码器，按注册的顺序排列，后注册的优先。
+		ifaces []ifaceEncoder
+		// cache 缓存每个具体类型查找到的编码器，没有编码器时缓存 nil。
+		cache stdsync.Map
+	}
+
+	// ifaceEncoder 是按接口类型注册的编码器。
+	ifaceEncoder struct {
+		// typ 是接口类型。
+		typ reflect.Type
+		// encoder 是编码器。
+		encoder FieldEncoder
+	}
+
+	// stackTracer 是可以返回调用堆栈的错误，kit/errors 创建的错误实现了该接口。
+	stackTracer interface {
+		StackTrace() string
+	}
+
+	// errorValue 是带调用堆栈的错误字段的输出值，文本格式只输出错误信息。
+	errorValue struct {
+		// Message 是错误信息。
+		Message string `json:"message"`
+		// Stack 是错误链中捕获的调用堆栈。
+		Stack string `json:"stack"`
+	}
+
+	// durationValue 是时长字段的输出值，文本格式输出可读的字符串。
+	durationValue struct {
+		// Text 是可读的字符串，例如 1.5s。
+		Text string `json:"text"`
+		// Ms 是毫秒数。
+		Ms float64 `json:"ms"`
+	}
+)
+
+func init() {
+	fieldEncodersCurrent.Store(&fieldEncoders{exact: make(map[reflect.Type]FieldEncoder)})
+	// 后注册的接口优先，同时实现 error 与 fmt.Stringer 的值按 error 编码。
+	RegisterFieldEncoder(reflect.TypeFor[fmt.Stringer](), encodeStringer)
+	RegisterFieldEncoder(reflect.TypeFor[error](), encodeError)
+	RegisterFieldEncoder(reflect.TypeFor[time.Duration](), encodeDuration)
+}
+
+// RegisterFieldEncoder 注册字段值的编码器，StdLogger 与 LogrusLogger 在输出日志之前用它转换字段值，
+// 使同一个字段在不同的日志实现中输出一致。
+//
+// typ 为具体类型时只匹配该类型的值；为接口类型时匹配实现了该接口的值。具体类型的编码器优先于接口类型的编码器，
+// 多个接口都匹配时后注册的优先。重复注册同一个类型时替换原有的编码器，encoder 为 nil 时取消注册。
+//
+// 内置的编码器：
+//   - error：带调用堆栈（实现了 StackTrace() string，例如 kit/errors 创建的错误）时，JSON 格式输出 message 与 stack，
+//     文本格式只输出错误信息；否则输出错误信息。
+//   - time.Duration：JSON 格式输出 text（例如 1.5s）与 ms（毫秒数），文本格式输出 text。
+//   - fmt.Stringer：输出 String() 的结果。
+//
+// 编码器在每次输出日志时调用，应当快速且不修改 v；编码器 panic 时输出原始值。
+// 通常在程序初始化时注册，注册是并发安全的。
+//
+// 参数：
+//   - typ：字段值的类型，可以通过 reflect.TypeFor 获取。
+//   - encoder：编码器，为 nil 时取消注册。
+//
+// 示例：
+//
+//	// 金额以分存储，输出时转换为元。
+//	log.RegisterFieldEncoder(reflect.TypeFor[Cents](), func(v interface{}) interface{} {
+//	    return float64(v.(Cents)) / 100
+//	})
+//
+//	// 所有实现了 Redactor 的值输出脱敏后的结果。
+//	log.RegisterFieldEncoder(reflect.TypeFor[Redactor](), func(v interface{}) interface{} {
+//	    return v.(Redactor).Redact()
+//	})
+func RegisterFieldEncoder(typ reflect.Type, encoder FieldEncoder) {
+	fieldEncodersMu.Lock()
+	defer fieldEncodersMu.Unlock()
+
+	old := fieldEncodersCurrent.Load()
+	next := &fieldEncoders{exact: make(map[reflect.Type]FieldEncoder, len(old.exact)+1)}
+	for t, e := range old.exact {
+		next.exact[t] = e
+	}
+	for _, ie := range old.ifaces {
+		if ie.typ != typ {
+			next.ifaces = append(next.ifaces, ie)
+		}
+	}
+
+	switch {
+	case typ.Kind() != reflect.Interface:
+		delete(next.exact, typ)
+		if nil != encoder {
+			next.exact[typ] = encoder
+		}
+	case nil != encoder:
+		next.ifaces = append(next.ifaces, ifaceEncoder{typ: typ, encoder: encoder})
+	}
+	fieldEncodersCurrent.Store(next)
+}
+
+// lookup 返回具体类型 t 的编码器，没有时返回 nil。
+func (r *fieldEncoders) lookup(t reflect.Type) FieldEncoder {
+	if e, ok := r.cache.Load(t); ok {
+		return e.(FieldEncoder)
+	}
+	encoder, ok := r.exact[t]
+	if !ok {
+		for i := len(r.ifaces) - 1; i >= 0; i-- {
+			if t.Implements(r.ifaces[i].typ) {
+				encoder = r.ifaces[i].encoder
+				break
+			}
+		}
+	}
+	r.cache.Store(t, encoder)
+	return encoder
+}
+
+// encodeField 使用注册的编码器转换字段值，没有匹配的编码器时返回原值。
+func encodeField(v interface{}) (out interface{}) {
+	if nil == v {
+		return nil
+	}
+	encoder := fieldEncodersCurrent.Load().lookup(reflect.TypeOf(v))
+	if nil == encoder {
+		return v
+	}
+	defer func() {
+		if r := recover(); nil != r {
+			out = v
+		}
+	}()
+	return encoder(v)
+}
+
+// encodeError 是 error 的内置编码器。
+func encodeError(v interface{}) interface{} {
+	err := v.(error)
+	var st stackTracer
+	if errors.As(err, &st) {
+		if stack := st.StackTrace(); "" != stack {
+			return errorValue{Message: err.Error(), Stack: stack}
+		}
+	}
+	return err.Error()
+}
+
+// encodeDuration 是 time.Duration 的内置编码器。
+func encodeDuration(v interface{}) interface{} {
+	d := v.(time.Duration)
+	return durationValue{Text: d.String(), Ms: float64(d) / float64(time.Millisecond)}
+}
+
+// encodeStringer 是 fmt.Stringer 的内置编码器，由 fmt 处理 nil 指针接收者导致的 panic。
+func encodeStringer(v interface{}) interface{} {
+	return fmt.Sprint(v)
+}
+
+// String 实现了 fmt.Stringer 接口，返回错误信息。
+func (e errorValue) String() string {
+	return e.Message
+}
+
+// String 实现了 fmt.Stringer 接口，返回可读的字符串。
+func (d durationValue) String() string {
+	return d.Text
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// stackError 是带调用堆栈的测试错误。
+	stackError struct {
+		// msg 是错误信息。
+		msg string
+	}
+
+	// cents 是以分为单位的测试金额。
+	cents int64
+
+	// redactor 是需要脱敏输出的测试接口。
+	redactor interface {
+		Redact() string
+	}
+
+	// password 实现了 redactor 与 fmt.Stringer。
+	password string
+
+	// nilStringer 的 String 方法在 nil 接收者上 panic。
+	nilStringer struct {
+		// s 是输出的字符串。
+		s string
+	}
+)
+
+func (e *stackError) Error() string      { return e.msg }
+func (e *stackError) StackTrace() string { return "main.go:10" }
+func (p password) Redact() string        { return "***" }
+func (p password) String() string        { return string(p) }
+func (n *nilStringer) String() string    { return n.s }
+
+// newTestStdLogger 创建输出到 w 的 StdLogger。
+func newTestStdLogger(w *bytes.Buffer) Logger {
+	return &StdLogger{logger: log.New(w, "", 0), fields: map[string]interface{}{}, level: new(atomic.Int32)}
+}
+
+// registerTestEncoder 注册测试使用的编码器，并在测试结束时取消注册。
+func registerTestEncoder(t *testing.T, typ reflect.Type, encoder FieldEncoder) {
+	RegisterFieldEncoder(typ, encoder)
+	t.Cleanup(func() { RegisterFieldEncoder(typ, nil) })
+}
+
+// TestFieldEncoder_BuiltinStd 测试 StdLogger 使用内置编码器输出 error、time.Duration 与 fmt.Stringer。
+func TestFieldEncoder_BuiltinStd(t *testing.T) {
+	var buf bytes.Buffer
+	newTestStdLogger(&buf).WithFields(map[string]interface{}{
+		"a_err":   &stackError{msg: "boom"},
+		"b_plain": errors.New("plain"),
+		"c_dur":   1500 * time.Millisecond,
+		"d_ip":    fmt.Stringer(password("secret")),
+	}).Info("done")
+
+	assert.Equal(t, "[INFO] [a_err=boom b_plain=plain c_dur=1.5s d_ip=secret] done\n", buf.String())
+}
+
+// TestFieldEncoder_BuiltinJSON 测试 JSON 格式输出错误的调用堆栈与时长的毫秒数。
+func TestFieldEncoder_BuiltinJSON(t *testing.T) {
+	var buf bytes.Buffer
+	newTestLogrusLogger(&buf, logrus.InfoLevel).WithFields(map[string]interface{}{
+		"err":   fmt.Errorf("wrap: %w", &stackError{msg: "boom"}),
+		"plain": errors.New("plain"),
+		"dur":   250 * time.Microsecond,
+		"pw":    password("secret"),
+	}).Info("done")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, map[string]interface{}{"message": "wrap: boom", "stack": "main.go:10"}, lines[0]["err"])
+	assert.Equal(t, "plain", lines[0]["plain"])
+	assert.Equal(t, map[string]interface{}{"text": "250µs", "ms": 0.25}, lines[0]["dur"])
+	assert.Equal(t, "secret", lines[0]["pw"])
+}
+
+// TestFieldEncoder_LogrusText 测试 logrus 文本格式与 StdLogger 输出一致的字段值。
+func TestFieldEncoder_LogrusText(t *testing.T) {
+	var buf bytes.Buffer
+	l := logrus.New()
+	l.SetOutput(&buf)
+	l.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true, DisableQuote: true})
+	(&LogrusLogger{logger: logrus.NewEntry(l)}).
+		WithField("err", &stackError{msg: "boom"}).
+		WithField("dur", 2*time.Second).
+		Info("done")
+
+	assert.Equal(t, "level=info msg=done dur=2s err=boom\n", buf.String())
+}
+
+// TestRegisterFieldEncoder 测试具体类型优先于接口类型、后注册的接口优先以及取消注册。
+func TestRegisterFieldEncoder(t *testing.T) {
+	registerTestEncoder(t, reflect.TypeFor[cents](), func(v interface{}) interface{} {
+		return fmt.Sprintf("%.2f", float64(v.(cents))/100)
+	})
+	assert.Equal(t, "12.34", encodeField(cents(1234)))
+
+	// password 同时实现了 fmt.Stringer 与 redactor，后注册的 redactor 优先。
+	assert.Equal(t, "secret", encodeField(password("secret")))
+	registerTestEncoder(t, reflect.TypeFor[redactor](), func(v interface{}) interface{} {
+		return v.(redactor).Redact()
+	})
+	assert.Equal(t, "***", encodeField(password("secret")))
+
+	// 具体类型的编码器优先于接口类型的编码器。
+	registerTestEncoder(t, reflect.TypeFor[password](), func(v interface{}) interface{} {
+		return "exact"
+	})
+	assert.Equal(t, "exact", encodeField(password("secret")))
+
+	RegisterFieldEncoder(reflect.TypeFor[password](), nil)
+	RegisterFieldEncoder(reflect.TypeFor[redactor](), nil)
+	assert.Equal(t, "secret", encodeField(password("secret")))
+
+	// 没有编码器的值原样返回。
+	assert.Equal(t, 42, encodeField(42))
+	assert.Nil(t, encodeField(nil))
+}
+
+// TestFieldEncoder_Panic 测试编码器 panic 时输出原始值，nil 指针的 String 方法由 fmt 处理。
+func TestFieldEncoder_Panic(t *testing.T) {
+	registerTestEncoder(t, reflect.TypeFor[cents](), func(v interface{}) interface{} {
+		panic("bad encoder")
+	})
+	assert.Equal(t, cents(1), encodeField(cents(1)))
+
+	var d *nilStringer
+	assert.Equal(t, "<nil>", encodeField(d))
+}
+
+// BenchmarkEncodeField 测试查找编码器的开销。
+func BenchmarkEncodeField(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = encodeField(i)
+	}
+}
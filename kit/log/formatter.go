@@ -0,0 +1,74 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	kitjson "github.com/fsyyft-go/monorepo/kit/json"
+)
+
+type (
+	// JSONFormatter 是使用 kit/json 编码字段的 Logrus 格式化器。
+	// 输出与 logrus.JSONFormatter 的单行格式兼容：字段按字段名排序，与 time、msg、level 等保留字段冲突的字段加上 "fields." 前缀，
+	// error 类型的字段编码为错误信息。不同之处在于不转义 HTML 字符，常见类型的字段不经过反射。
+	JSONFormatter struct {
+		// TimestampFormat 是时间戳的格式化模板，为空时使用 time.RFC3339。
+		TimestampFormat string
+	}
+)
+
+// Format 实现了 logrus.Formatter 接口。
+//
+// 参数：
+//   - entry：日志条目。
+//
+// 返回值：
+//   - []byte：以换行符结尾的 JSON 日志行。
+//   - error：始终为 nil，无法编码的字段以字符串形式输出。
+func (f *JSONFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	data := make(logrus.Fields, len(entry.Data)+5)
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	prefixFieldClashes(data, logrus.FieldKeyTime, logrus.FieldKeyMsg, logrus.FieldKeyLevel)
+
+	timestampFormat := f.TimestampFormat
+	if "" == timestampFormat {
+		timestampFormat = time.RFC3339
+	}
+	data[logrus.FieldKeyTime] = entry.Time.Format(timestampFormat)
+	data[logrus.FieldKeyMsg] = entry.Message
+	data[logrus.FieldKeyLevel] = entry.Level.String()
+	if entry.HasCaller() {
+		prefixFieldClashes(data, logrus.FieldKeyFunc, logrus.FieldKeyFile)
+		data[logrus.FieldKeyFunc] = entry.Caller.Function
+		data[logrus.FieldKeyFile] = entry.Caller.File + ":" + strconv.Itoa(entry.Caller.Line)
+	}
+
+	b := entry.Buffer
+	if nil == b {
+		b = &bytes.Buffer{}
+	}
+	// 直接追加到缓冲区的空闲空间，容量足够时 Write 不会复制。
+	line := kitjson.AppendFields(b.AvailableBuffer(), data)
+	line = append(line, '\n')
+	_, _ = b.Write(line)
+	return b.Bytes(), nil
+}
+
+// prefixFieldClashes 为与保留字段同名的字段加上 "fields." 前缀，避免被覆盖。
+func prefixFieldClashes(data logrus.Fields, keys ...string) {
+	for _, k := range keys {
+		if v, ok := data[k]; ok {
+			data["fields."+k] = v
+			delete(data, k)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJSONFormatter 测试 JSONFormatter 的输出与 logrus.JSONFormatter 一致。
+func TestJSONFormatter(t *testing.T) {
+	entry := &logrus.Entry{
+		Logger: logrus.New(),
+		Data: logrus.Fields{
+			"user_id": 42,
+			"error":   errors.New("denied"),
+			"msg":     "clash",
+			"file":    "clash",
+		},
+		Time:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   logrus.WarnLevel,
+		Message: "login failed",
+	}
+
+	for _, withCaller := range []bool{false, true} {
+		entry.Logger.ReportCaller = withCaller
+		entry.Caller = nil
+		if withCaller {
+			entry.Caller = &runtime.Frame{Function: "main.main", File: "main.go", Line: 7}
+		}
+
+		want, err := (&logrus.JSONFormatter{}).Format(entry)
+		require.NoError(t, err)
+		got, err := (&JSONFormatter{}).Format(entry)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got))
+	}
+
+	entry.Buffer = bytes.NewBufferString("")
+	got, err := (&JSONFormatter{TimestampFormat: "2006-01-02"}).Format(entry)
+	require.NoError(t, err)
+	assert.Same(t, &entry.Buffer.Bytes()[0], &got[0])
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(got, &decoded))
+	assert.Equal(t, "2025-01-02", decoded["time"])
+}
@@ -5,6 +5,7 @@
 package log
 
 import (
+	"context"
 	"fmt"
 	"sync"
 )
@@ -21,6 +22,14 @@ const (
 	// LogTypeLogrus 表示 Logrus 日志类型。
 	// 使用 Logrus 库实现，提供丰富的日志功能，包括结构化日志、多种输出格式等。
 	LogTypeLogrus LogType = "logrus"
+
+	// LogTypeSlog 表示 slog 日志类型。
+	// 使用标准库的 log/slog 实现，支持文本与 JSON 格式，可以通过 NewSlogLogger 接入自定义的 slog.Handler。
+	LogTypeSlog LogType = "slog"
+
+	// LogTypeOTLP 表示 OpenTelemetry 日志类型。
+	// 将日志转换为 OTLP 日志记录，通过 OTLP/HTTP 分批发送到 Collector，通过 WithOTLP 设置地址与其他配置。
+	LogTypeOTLP LogType = "otlp"
 )
 
 var (
@@ -42,6 +51,8 @@ type (
 //   - 日志级别：InfoLevel
 //   - 输出路径：标准输出
 //
+// 替换全局日志实例时不关闭原实例，原实例可能仍被模块或派生的实例使用；需要释放时先调用 Close。
+//
 // 参数：
 //   - options：可选的配置选项，用于定制日志行为。
 //
@@ -84,14 +95,21 @@ func SetLogger(logger Logger) {
 }
 
 // GetLogger 获取全局日志实例。
-// 如果全局日志实例未设置，则返回一个默认的标准输出日志实例。
+// 如果全局日志实例未设置（包括被 Close 清除后），则创建并设置一个默认的标准输出日志实例，可以与 Close、SetLogger 并发调用。
 //
 // 返回值：
 //   - Logger：返回全局日志实例。
 func GetLogger() Logger {
 	globalLoggerLock.RLock()
-	defer globalLoggerLock.RUnlock()
+	logger := globalLogger
+	globalLoggerLock.RUnlock()
+	if nil != logger {
+		return logger
+	}
 
+	globalLoggerLock.Lock()
+	defer globalLoggerLock.Unlock()
+	// 获取写锁期间其他协程可能已经设置了全局日志实例。
 	if nil == globalLogger {
 		stdLogger, err := NewLogger()
 		if nil != err {
@@ -99,16 +117,21 @@ func GetLogger() Logger {
 		}
 		globalLogger = stdLogger
 	}
-
 	return globalLogger
 }
 
+// callerGlobalLogger 返回记录日志的包级别函数使用的全局日志实例。
+// 开启记录调用位置时额外跳过包级别函数这一层，使调用位置指向包级别函数的调用方，而不是本文件。
+func callerGlobalLogger() Logger {
+	return addCallerSkip(GetLogger(), 1)
+}
+
 // Debug 使用全局日志实例记录调试级别的日志。
 //
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func Debug(args ...interface{}) {
-	GetLogger().Debug(args...)
+	callerGlobalLogger().Debug(args...)
 }
 
 // Debugf 使用全局日志实例记录格式化的调试级别日志。
@@ -117,7 +140,7 @@ func Debug(args ...interface{}) {
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func Debugf(format string, args ...interface{}) {
-	GetLogger().Debugf(format, args...)
+	callerGlobalLogger().Debugf(format, args...)
 }
 
 // Info 使用全局日志实例记录信息级别的日志。
@@ -125,7 +148,7 @@ func Debugf(format string, args ...interface{}) {
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func Info(args ...interface{}) {
-	GetLogger().Info(args...)
+	callerGlobalLogger().Info(args...)
 }
 
 // Infof 使用全局日志实例记录格式化的信息级别日志。
@@ -134,7 +157,7 @@ func Info(args ...interface{}) {
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func Infof(format string, args ...interface{}) {
-	GetLogger().Infof(format, args...)
+	callerGlobalLogger().Infof(format, args...)
 }
 
 // Warn 使用全局日志实例记录警告级别的日志。
@@ -142,7 +165,7 @@ func Infof(format string, args ...interface{}) {
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func Warn(args ...interface{}) {
-	GetLogger().Warn(args...)
+	callerGlobalLogger().Warn(args...)
 }
 
 // Warnf 使用全局日志实例记录格式化的警告级别日志。
@@ -151,7 +174,7 @@ func Warn(args ...interface{}) {
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func Warnf(format string, args ...interface{}) {
-	GetLogger().Warnf(format, args...)
+	callerGlobalLogger().Warnf(format, args...)
 }
 
 // Error 使用全局日志实例记录错误级别的日志。
@@ -159,7 +182,7 @@ func Warnf(format string, args ...interface{}) {
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func Error(args ...interface{}) {
-	GetLogger().Error(args...)
+	callerGlobalLogger().Error(args...)
 }
 
 // Errorf 使用全局日志实例记录格式化的错误级别日志。
@@ -168,7 +191,7 @@ func Error(args ...interface{}) {
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func Errorf(format string, args ...interface{}) {
-	GetLogger().Errorf(format, args...)
+	callerGlobalLogger().Errorf(format, args...)
 }
 
 // Fatal 使用全局日志实例记录致命错误级别的日志。
@@ -177,7 +200,7 @@ func Errorf(format string, args ...interface{}) {
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func Fatal(args ...interface{}) {
-	GetLogger().Fatal(args...)
+	callerGlobalLogger().Fatal(args...)
 }
 
 // Fatalf 使用全局日志实例记录格式化的致命错误级别日志。
@@ -187,7 +210,65 @@ func Fatal(args ...interface{}) {
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func Fatalf(format string, args ...interface{}) {
-	GetLogger().Fatalf(format, args...)
+	callerGlobalLogger().Fatalf(format, args...)
+}
+
+// DebugContext 使用全局日志实例记录调试级别的日志，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func DebugContext(ctx context.Context, args ...interface{}) {
+	callerGlobalLogger().DebugContext(ctx, args...)
+}
+
+// InfoContext 使用全局日志实例记录信息级别的日志，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func InfoContext(ctx context.Context, args ...interface{}) {
+	callerGlobalLogger().InfoContext(ctx, args...)
+}
+
+// WarnContext 使用全局日志实例记录警告级别的日志，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func WarnContext(ctx context.Context, args ...interface{}) {
+	callerGlobalLogger().WarnContext(ctx, args...)
+}
+
+// ErrorContext 使用全局日志实例记录错误级别的日志，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func ErrorContext(ctx context.Context, args ...interface{}) {
+	callerGlobalLogger().ErrorContext(ctx, args...)
+}
+
+// WithContext 使用全局日志实例添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//
+// 返回值：
+//   - Logger：返回一个新的 Logger 实例，包含提取的字段。
+func WithContext(ctx context.Context) Logger {
+	return GetLogger().WithContext(ctx)
+}
+
+// WithError 使用全局日志实例添加错误相关的字段。
+//
+// 参数：
+//   - err：要记录的错误。
+//
+// 返回值：
+//   - Logger：返回一个新的 Logger 实例，包含错误信息、错误类型与调用堆栈，err 为 nil 时返回全局日志实例。
+func WithError(err error) Logger {
+	return GetLogger().WithError(err)
 }
 
 // WithField 使用全局日志实例添加一个结构化字段。
@@ -212,3 +293,46 @@ func WithField(key string, value interface{}) Logger {
 func WithFields(fields map[string]interface{}) Logger {
 	return GetLogger().WithFields(fields)
 }
+
+// AddHook 为全局日志实例添加记录日志时调用的钩子。
+// 钩子添加到当前的全局日志实例，之后通过 SetLogger 或 InitLogger 替换的日志实例需要重新添加。
+//
+// 参数：
+//   - levels：触发钩子的日志级别，为空时对全部级别触发。
+//   - fn：钩子函数，可能被并发调用，为 nil 时不添加。
+func AddHook(levels []Level, fn func(Entry)) {
+	GetLogger().AddHook(levels, fn)
+}
+
+// Sync 将全局日志实例缓冲的日志写入输出目标。
+//
+// 返回值：
+//   - error：写入失败时返回错误。
+func Sync() error {
+	return GetLogger().Sync()
+}
+
+// Close 关闭全局日志实例，写入缓冲的日志并释放打开的资源，通常在程序退出前调用。
+// 关闭后全局日志实例被清除，之后的全局日志输出到使用默认配置新建的日志实例，不会写入已经关闭的文件。
+// 通过 SetModuleLogger 设置的模块日志实例不受影响，需要单独关闭。
+//
+// 返回值：
+//   - error：关闭失败时返回错误，全局日志实例未设置时返回 nil。
+//
+// 示例：
+//
+//	if err := log.InitLogger(log.WithOutput("/var/log/app.log"), log.WithAsync(4096, time.Second)); nil != err {
+//	    panic(err)
+//	}
+//	defer log.Close()
+func Close() error {
+	globalLoggerLock.Lock()
+	logger := globalLogger
+	globalLogger = nil
+	globalLoggerLock.Unlock()
+
+	if nil == logger {
+		return nil
+	}
+	return logger.Close()
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type (
+	// Entry 是交给钩子的一条日志。
+	Entry struct {
+		// Time 是记录日志的时间。
+		Time time.Time
+		// Level 是日志级别。
+		Level Level
+		// Message 是日志内容，不包含日志级别与字段。
+		Message string
+		// Fields 是日志的结构化字段，包括 WithField 等方法添加的字段与从 ctx 中提取的字段，
+		// 字段值已经经过 RegisterFieldEncoder 注册的编码器转换；同一条日志的全部钩子共享该映射，钩子不应修改。
+		Fields map[string]interface{}
+	}
+
+	// hook 是通过 AddHook 添加的一个钩子。
+	hook struct {
+		// levels 是触发钩子的日志级别，第 n 位对应级别 n。
+		levels uint32
+		// fn 是钩子函数。
+		fn func(Entry)
+	}
+
+	// hooks 是日志实例与派生的实例共享的钩子列表。
+	// 添加钩子时复制整个列表，记录日志时无需加锁。
+	hooks struct {
+		// mu 保证并发添加的钩子不会丢失。
+		mu sync.Mutex
+		// list 是当前的钩子列表。
+		list atomic.Pointer[[]hook]
+		// levels 是全部钩子的日志级别的并集，用于在没有钩子时跳过构造 Entry。
+		levels atomic.Uint32
+	}
+
+	// logrusHook 是调用 AddHook 添加的钩子的 Logrus 钩子，在 NewLogrusLogger 中注册到 Logrus 日志实例。
+	logrusHook struct {
+		// hooks 是通过 AddHook 添加的钩子。
+		hooks *hooks
+	}
+
+	// slogHookHandler 在处理器输出日志之后调用通过 AddHook 添加的钩子，
+	// 通过 Slog 方法取得的 *slog.Logger 记录的日志同样会调用钩子。
+	slogHookHandler struct {
+		slog.Handler
+		// hooks 是通过 AddHook 添加的钩子。
+		hooks *hooks
+		// attrs 是通过 WithAttrs 添加的属性，分组中的属性以 "分组.字段名" 作为字段名。
+		attrs []slog.Attr
+		// group 是通过 WithGroup 添加的分组前缀，不为空时以 "." 结尾。
+		group string
+	}
+)
+
+// newHooks 创建一个空的钩子列表。
+func newHooks() *hooks {
+	return &hooks{}
+}
+
+// add 添加一个钩子，levels 为空时对全部日志级别触发，fn 为 nil 时不添加。
+func (h *hooks) add(levels []Level, fn func(Entry)) {
+	if nil == fn {
+		return
+	}
+	var mask uint32
+	for _, level := range levels {
+		if level >= DebugLevel && level <= FatalLevel {
+			mask |= 1 << uint(level)
+		}
+	}
+	if 0 == len(levels) {
+		mask = 1<<uint(FatalLevel+1) - 1
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var list []hook
+	if old := h.list.Load(); nil != old {
+		list = append(list, *old...)
+	}
+	list = append(list, hook{levels: mask, fn: fn})
+	h.list.Store(&list)
+	h.levels.Store(h.levels.Load() | mask)
+}
+
+// enabled 判断是否有钩子在 level 级别触发，h 为 nil 时返回 false。
+func (h *hooks) enabled(level Level) bool {
+	if nil == h || level < DebugLevel || level > FatalLevel {
+		return false
+	}
+	return 0 != h.levels.Load()&(1<<uint(level))
+}
+
+// fire 依次调用在 e.Level 级别触发的钩子。
+func (h *hooks) fire(e Entry) {
+	if !h.enabled(e.Level) {
+		return
+	}
+	for _, hk := range *h.list.Load() {
+		if 0 != hk.levels&(1<<uint(e.Level)) {
+			hk.fn(e)
+		}
+	}
+}
+
+// Levels 实现了 logrus.Hook 接口，作用于全部日志级别，是否调用由添加钩子时的日志级别决定。
+func (h *logrusHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire 实现了 logrus.Hook 接口，将 Logrus 的条目转换为 Entry 交给钩子。
+// 条目中的字段值已经经过注册的编码器转换，不再重复转换。
+func (h *logrusHook) Fire(entry *logrus.Entry) error {
+	level := InfoLevel
+	for l, lLevel := range logrusLevelMap {
+		if lLevel == entry.Level {
+			level = l
+		}
+	}
+	if !h.hooks.enabled(level) {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(entry.Data))
+	for k, v := range entry.Data {
+		if !isFuncValue(v) {
+			fields[k] = v
+		}
+	}
+	h.hooks.fire(Entry{Time: entry.Time, Level: level, Message: entry.Message, Fields: fields})
+	return nil
+}
+
+// Handle 将日志交给处理器输出，随后调用在该级别触发的钩子，返回处理器的错误。
+func (h *slogHookHandler) Handle(ctx context.Context, r slog.Record) error {
+	err := h.Handler.Handle(ctx, r)
+	level := slogToLevel(r.Level)
+	if !h.hooks.enabled(level) {
+		return err
+	}
+	fields := make(map[string]interface{}, len(h.attrs)+r.NumAttrs())
+	for _, a := range h.attrs {
+		addSlogField(fields, "", a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addSlogField(fields, h.group, a)
+		return true
+	})
+	h.hooks.fire(Entry{Time: r.Time, Level: level, Message: r.Message, Fields: fields})
+	return err
+}
+
+// WithAttrs 返回添加了属性的处理器，保留钩子与已经添加的属性。
+func (h *slogHookHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	n := &slogHookHandler{
+		Handler: h.Handler.WithAttrs(attrs),
+		hooks:   h.hooks,
+		attrs:   make([]slog.Attr, 0, len(h.attrs)+len(attrs)),
+		group:   h.group,
+	}
+	n.attrs = append(n.attrs, h.attrs...)
+	for _, a := range attrs {
+		a.Key = h.group + a.Key
+		n.attrs = append(n.attrs, a)
+	}
+	return n
+}
+
+// WithGroup 返回添加了分组的处理器，保留钩子与已经添加的属性。
+func (h *slogHookHandler) WithGroup(name string) slog.Handler {
+	if "" == name {
+		return h
+	}
+	return &slogHookHandler{
+		Handler: h.Handler.WithGroup(name),
+		hooks:   h.hooks,
+		attrs:   h.attrs,
+		group:   h.group + name + ".",
+	}
+}
+
+// addSlogField 将属性转换为字段写入 fields，分组展开为 "分组.字段名"，SlogLogger 添加的字段值使用原始值。
+func addSlogField(fields map[string]interface{}, prefix string, a slog.Attr) {
+	if v, ok := a.Value.Any().(slogFieldValue); ok {
+		if !isFuncValue(v.v) {
+			fields[prefix+a.Key] = encodeField(v.v)
+		}
+		return
+	}
+	a.Value = a.Value.Resolve()
+	if slog.KindGroup == a.Value.Kind() {
+		if "" != a.Key {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			addSlogField(fields, prefix, ga)
+		}
+		return
+	}
+	if "" != a.Key {
+		fields[prefix+a.Key] = a.Value.Any()
+	}
+}
+
+// slogToLevel 将 slog 的日志级别转换为最接近的不高于它的日志级别。
+func slogToLevel(level slog.Level) Level {
+	switch {
+	case level >= SlogLevelFatal:
+		return FatalLevel
+	case level >= slog.LevelError:
+		return ErrorLevel
+	case level >= slog.LevelWarn:
+		return WarnLevel
+	case level >= slog.LevelInfo:
+		return InfoLevel
+	default:
+		return DebugLevel
+	}
+}
+
+// hookFields 返回交给钩子的字段：复制 fields 并经过注册的编码器转换，函数类型的字段被忽略。
+func hookFields(fields map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		if !isFuncValue(v) {
+			m[k] = encodeField(v)
+		}
+	}
+	return m
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// TestAddHook 测试全部日志实现在实际记录日志时按级别调用钩子，派生的实例共享钩子，字段包括从 ctx 中提取的字段。
+func TestAddHook(t *testing.T) {
+	srv := newOTLPServer(t)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1, 2, 3},
+		SpanID:  trace.SpanID{4, 5, 6},
+	}))
+
+	for _, logType := range []LogType{LogTypeStd, LogTypeLogrus, LogTypeSlog, LogTypeOTLP} {
+		t.Run(string(logType), func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := NewLogger(WithLogType(logType), WithWriter(&buf), WithOTLP(srv.URL))
+			require.NoError(t, err)
+			defer logger.Close() // nolint:errcheck
+
+			var (
+				mu      sync.Mutex
+				entries []Entry
+				total   int
+			)
+			logger.AddHook([]Level{WarnLevel, ErrorLevel}, func(e Entry) {
+				mu.Lock()
+				defer mu.Unlock()
+				entries = append(entries, e)
+			})
+			derived := logger.WithField("user", "alice")
+			derived.AddHook(nil, func(Entry) {
+				mu.Lock()
+				defer mu.Unlock()
+				total++
+			})
+			logger.AddHook(nil, nil)
+
+			derived.Debug("hidden")
+			derived.Info("hello")
+			derived.Warnf("slow %dms", 300)
+			derived.ErrorContext(ctx, "boom")
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, 3, total)
+			require.Len(t, entries, 2)
+			assert.Equal(t, WarnLevel, entries[0].Level)
+			assert.Equal(t, "slow 300ms", entries[0].Message)
+			assert.Equal(t, map[string]interface{}{"user": "alice"}, entries[0].Fields)
+			assert.False(t, entries[0].Time.IsZero())
+			assert.Equal(t, ErrorLevel, entries[1].Level)
+			assert.Equal(t, "boom", entries[1].Message)
+			assert.Equal(t, "alice", entries[1].Fields["user"])
+			assert.Equal(t, trace.TraceID{1, 2, 3}.String(), entries[1].Fields[FieldTraceID])
+		})
+	}
+}
+
+// TestStdLogger_HookClock 测试 StdLogger 使用 WithClock 设置的时钟生成钩子的日志时间，派生的实例使用同一个时钟。
+func TestStdLogger_HookClock(t *testing.T) {
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := kittime.NewFakeClock(start)
+	var buf bytes.Buffer
+	logger, err := NewLogger(WithLogType(LogTypeStd), WithWriter(&buf), WithClock(clock))
+	require.NoError(t, err)
+
+	var entries []Entry
+	logger.AddHook(nil, func(e Entry) { entries = append(entries, e) })
+	logger.Info("first")
+	clock.Advance(time.Minute)
+	logger.WithField("user", "alice").Warn("second")
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, start, entries[0].Time)
+	assert.Equal(t, start.Add(time.Minute), entries[1].Time)
+}
+
+// TestSlogLogger_Hook 测试通过 Slog 方法取得的 *slog.Logger 记录的日志同样调用钩子，分组中的字段展开为 "分组.字段名"。
+func TestSlogLogger_Hook(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewSlogLogger(WithSlogWriter(&buf))
+	require.NoError(t, err)
+
+	var entries []Entry
+	logger.AddHook(nil, func(e Entry) { entries = append(entries, e) })
+	sl := logger.WithField("user", "alice").(*SlogLogger).Slog()
+	sl.WithGroup("req").With("id", 1).Error("failed", "code", 500)
+
+	require.Len(t, entries, 1)
+	assert.Equal(t, ErrorLevel, entries[0].Level)
+	assert.Equal(t, "failed", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"user": "alice", "req.id": int64(1), "req.code": int64(500)}, entries[0].Fields)
+}
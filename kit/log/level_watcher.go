@@ -0,0 +1,385 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+var (
+	// ErrLevelWatcherStarted 表示 LevelWatcher 已经启动过，LevelWatcher 只能启动一次。
+	ErrLevelWatcherStarted = errors.New("日志级别监听已经启动")
+)
+
+// 以下为 LevelWatcher 的默认参数配置。
+// 可通过 LevelWatcherOption 机制覆盖。
+var (
+	// levelRetryIntervalDefault 为来源的 Watch 返回错误后重新监听前的等待时间。
+	levelRetryIntervalDefault = 5 * time.Second
+)
+
+type (
+	// LevelSource 定义了日志级别配置的来源，例如 etcd 或 Consul 中的一个键。
+	// 配置的格式为逗号分隔的项：不含等号的项为全局级别，module=level 的项为模块级别，例如 info,db=debug,http=warn。
+	LevelSource interface {
+		// Watch 监听配置，先以当前的值调用 fn，之后每次值变化时再调用 fn，直到 ctx 被取消或监听失败。
+		// 键不存在时应当以空值调用 fn，表示恢复所有级别。
+		//
+		// 参数：
+		//   - ctx：监听的上下文，被取消时 Watch 应当尽快返回。
+		//   - fn：接收配置值的函数，不会被并发调用。
+		//
+		// 返回值：
+		//   - error：监听失败时返回错误，LevelWatcher 等待一段时间后重新调用 Watch。
+		Watch(ctx context.Context, fn func(value []byte)) error
+	}
+
+	// LevelSourceFunc 是函数形式的 LevelSource。
+	LevelSourceFunc func(ctx context.Context, fn func(value []byte)) error
+
+	// pollLevelSource 按固定间隔读取配置的 LevelSource。
+	pollLevelSource struct {
+		// fetch 读取配置的当前值。
+		fetch func(ctx context.Context) ([]byte, error)
+		// interval 是两次读取之间的间隔。
+		interval time.Duration
+		// clock 是等待间隔使用的时钟。
+		clock kittime.Clock
+	}
+
+	// LevelWatcherOption 定义了 LevelWatcher 的配置选项。
+	LevelWatcherOption func(*levelWatcherOptions)
+
+	// levelWatcherOptions 包含 LevelWatcher 的配置。
+	levelWatcherOptions struct {
+		// retryInterval 是 Watch 返回错误后重新监听前的等待时间。
+		retryInterval time.Duration
+		// onError 处理监听失败与配置无效的错误，为 nil 时记录到全局日志实例。
+		onError func(err error)
+		// clock 是等待重新监听使用的时钟。
+		clock kittime.Clock
+	}
+
+	// LevelWatcher 监听 LevelSource 中的配置，并据此更新全局日志实例与通过 SetModuleLogger 设置的模块日志实例的级别。
+	// 配置中不再出现的全局或模块级别恢复为首次被修改前的级别；配置无效时保持当前级别不变。
+	// LevelWatcher 实现了 kit/runtime 的 Runner 接口。
+	LevelWatcher struct {
+		// source 是配置的来源。
+		source LevelSource
+		// o 是监听的配置。
+		o *levelWatcherOptions
+
+		// mu 保护以下字段。
+		mu sync.Mutex
+		// original 保存被修改过的全局（键为空字符串）与模块级别在首次修改前的值。
+		original map[string]Level
+		// cancel 取消监听协程，Start 之前为 nil。
+		cancel context.CancelFunc
+		// done 在监听协程退出后被关闭。
+		done chan struct{}
+	}
+
+	// levelSpec 是解析后的级别配置。
+	levelSpec struct {
+		// global 是全局级别，为 nil 表示配置中没有全局级别。
+		global *Level
+		// modules 是各模块的级别。
+		modules map[string]Level
+	}
+)
+
+// Watch 调用函数本身，实现了 LevelSource 接口。
+func (f LevelSourceFunc) Watch(ctx context.Context, fn func(value []byte)) error {
+	return f(ctx, fn)
+}
+
+// PollLevelSource 返回按固定间隔读取配置的 LevelSource，值变化时才通知 LevelWatcher。
+// 适用于只提供读取接口的配置中心，例如通过 HTTP 读取 Consul KV。
+//
+// 参数：
+//   - fetch：读取配置的当前值，键不存在时应当返回空值与 nil 错误。
+//   - interval：两次读取之间的间隔，小于等于 0 时使用 10 秒。
+//
+// 返回值：
+//   - LevelSource：按间隔读取的来源，读取失败时 Watch 返回该错误。
+func PollLevelSource(fetch func(ctx context.Context) ([]byte, error), interval time.Duration) LevelSource {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	return &pollLevelSource{fetch: fetch, interval: interval, clock: kittime.NewRealClock()}
+}
+
+// Watch 立即读取一次配置，之后按间隔读取，值变化时调用 fn。
+func (s *pollLevelSource) Watch(ctx context.Context, fn func(value []byte)) error {
+	var last []byte
+	for first := true; ; first = false {
+		value, err := s.fetch(ctx)
+		if nil != err {
+			return err
+		}
+		if first || !bytes.Equal(last, value) {
+			last = value
+			fn(value)
+		}
+
+		timer := s.clock.NewTimer(s.interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C():
+		}
+	}
+}
+
+// WithLevelRetryInterval 设置来源的 Watch 返回错误后重新监听前的等待时间。
+//
+// 参数：
+//   - d：等待时间，默认为 5 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - LevelWatcherOption：配置选项函数。
+func WithLevelRetryInterval(d time.Duration) LevelWatcherOption {
+	return func(o *levelWatcherOptions) {
+		o.retryInterval = d
+	}
+}
+
+// WithLevelErrorHandler 设置监听失败与配置无效时的处理函数。
+//
+// 参数：
+//   - fn：错误处理函数，默认以错误级别记录到全局日志实例。
+//
+// 返回值：
+//   - LevelWatcherOption：配置选项函数。
+func WithLevelErrorHandler(fn func(err error)) LevelWatcherOption {
+	return func(o *levelWatcherOptions) {
+		o.onError = fn
+	}
+}
+
+// WithLevelClock 设置等待重新监听使用的时钟，测试时可以注入 kit/time 的 FakeClock。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟。
+//
+// 返回值：
+//   - LevelWatcherOption：配置选项函数。
+func WithLevelClock(clock kittime.Clock) LevelWatcherOption {
+	return func(o *levelWatcherOptions) {
+		o.clock = clock
+	}
+}
+
+// NewLevelWatcher 创建监听 source 并更新日志级别的 LevelWatcher。
+//
+// 参数：
+//   - source：日志级别配置的来源。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *LevelWatcher：新的 LevelWatcher，需要调用 Start 开始监听。
+//
+// 示例：
+//
+//	w := log.NewLevelWatcher(etcdLevelSource{client: cli, key: "/config/app/log_level"})
+//	if err := w.Start(ctx); nil != err {
+//	    return err
+//	}
+//	defer w.Stop(shutdownCtx)
+func NewLevelWatcher(source LevelSource, opts ...LevelWatcherOption) *LevelWatcher {
+	o := &levelWatcherOptions{
+		retryInterval: levelRetryIntervalDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.retryInterval <= 0 {
+		o.retryInterval = levelRetryIntervalDefault
+	}
+	o.clock = kittime.OrReal(o.clock)
+
+	return &LevelWatcher{
+		source:   source,
+		o:        o,
+		original: make(map[string]Level),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start 在后台协程中开始监听后立即返回。
+// ctx 被取消或调用 Stop 时停止监听，已经修改的级别保持不变。
+//
+// 参数：
+//   - ctx：提供生命周期控制和取消信号。
+//
+// 返回值：
+//   - error：LevelWatcher 已经启动过时返回 ErrLevelWatcherStarted。
+func (w *LevelWatcher) Start(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if nil != w.cancel {
+		return ErrLevelWatcherStarted
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	go func() {
+		defer close(w.done)
+		w.run(ctx)
+	}()
+	return nil
+}
+
+// Stop 停止监听，并等待监听协程退出。
+//
+// 参数：
+//   - ctx：提供停止操作的截止时间。
+//
+// 返回值：
+//   - error：监听协程在截止时间前退出时返回 nil，否则返回 ctx 的错误。
+func (w *LevelWatcher) Stop(ctx context.Context) error {
+	w.mu.Lock()
+	cancel := w.cancel
+	w.mu.Unlock()
+	if nil == cancel {
+		return nil
+	}
+
+	cancel()
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 调用来源的 Watch，失败时等待重试间隔后重新监听，直到 ctx 被取消。
+func (w *LevelWatcher) run(ctx context.Context) {
+	for {
+		err := w.source.Watch(ctx, func(value []byte) {
+			if err := w.apply(value); nil != err {
+				w.handleError(err)
+			}
+		})
+		if nil != ctx.Err() {
+			return
+		}
+		if nil != err {
+			w.handleError(fmt.Errorf("监听日志级别失败：%w", err))
+		}
+
+		timer := w.o.clock.NewTimer(w.o.retryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+	}
+}
+
+// handleError 将错误交给错误处理函数，未设置时记录到全局日志实例。
+func (w *LevelWatcher) handleError(err error) {
+	if nil != w.o.onError {
+		w.o.onError(err)
+		return
+	}
+	GetLogger().Error(err)
+}
+
+// apply 解析配置并更新日志级别，配置无效时不修改任何级别。
+// 没有设置日志实例的模块被忽略，在下一次配置变化时重新尝试。
+func (w *LevelWatcher) apply(value []byte) error {
+	spec, err := parseLevelSpec(string(value))
+	if nil != err {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if nil != spec.global {
+		w.setLevel("", GetLogger(), *spec.global)
+	} else {
+		w.restore("", GetLogger())
+	}
+
+	for module, level := range spec.modules {
+		if logger, ok := lookupModuleLogger(module); ok {
+			w.setLevel(module, logger, level)
+		}
+	}
+	for module := range w.original {
+		if _, ok := spec.modules[module]; ok || "" == module {
+			continue
+		}
+		if logger, ok := lookupModuleLogger(module); ok {
+			w.restore(module, logger)
+		} else {
+			delete(w.original, module)
+		}
+	}
+	return nil
+}
+
+// setLevel 设置日志实例的级别，首次修改时记录原有的级别。
+func (w *LevelWatcher) setLevel(key string, logger Logger, level Level) {
+	if _, ok := w.original[key]; !ok {
+		w.original[key] = logger.GetLevel()
+	}
+	logger.SetLevel(level)
+}
+
+// restore 将被修改过的日志实例恢复为首次修改前的级别。
+func (w *LevelWatcher) restore(key string, logger Logger) {
+	if level, ok := w.original[key]; ok {
+		logger.SetLevel(level)
+		delete(w.original, key)
+	}
+}
+
+// parseLevelSpec 解析逗号分隔的级别配置，例如 info,db=debug,http=warn。
+// 空白的项被忽略，空配置表示没有任何级别。
+func parseLevelSpec(s string) (*levelSpec, error) {
+	spec := &levelSpec{modules: make(map[string]Level)}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if "" == item {
+			continue
+		}
+
+		module, value, isModule := strings.Cut(item, "=")
+		level, err := ParseLevel(strings.ToLower(strings.TrimSpace(value)))
+		if !isModule {
+			level, err = ParseLevel(strings.ToLower(item))
+		}
+		if nil != err {
+			return nil, fmt.Errorf("日志级别配置 %q 无效：%w", s, err)
+		}
+
+		if !isModule {
+			if nil != spec.global {
+				return nil, fmt.Errorf("日志级别配置 %q 无效：全局级别重复", s)
+			}
+			spec.global = &level
+			continue
+		}
+		module = strings.TrimSpace(module)
+		if "" == module {
+			return nil, fmt.Errorf("日志级别配置 %q 无效：模块名为空", s)
+		}
+		spec.modules[module] = level
+	}
+	return spec, nil
+}
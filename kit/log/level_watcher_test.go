@@ -0,0 +1,281 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// chanLevelSource 返回从 values 接收配置值的来源，接收到 nil 时 Watch 返回错误。
+func chanLevelSource(values <-chan []byte) LevelSource {
+	return LevelSourceFunc(func(ctx context.Context, fn func(value []byte)) error {
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case value := <-values:
+				if nil == value {
+					return errors.New("connection lost")
+				}
+				fn(value)
+			}
+		}
+	})
+}
+
+// newTestLogger 创建指定级别的日志实例。
+func newTestLogger(t *testing.T, level Level) Logger {
+	t.Helper()
+	logger, err := NewLogger(WithLevel(level))
+	require.NoError(t, err)
+	return logger
+}
+
+// useGlobalLogger 在测试期间替换全局日志实例。
+func useGlobalLogger(t *testing.T, logger Logger) {
+	t.Helper()
+	globalLoggerLock.RLock()
+	previous := globalLogger
+	globalLoggerLock.RUnlock()
+	SetLogger(logger)
+	t.Cleanup(func() {
+		SetLogger(previous)
+	})
+}
+
+// TestLevelWatcher 测试根据配置更新与恢复全局和模块的日志级别。
+func TestLevelWatcher(t *testing.T) {
+	global := newTestLogger(t, InfoLevel)
+	useGlobalLogger(t, global)
+	db := newTestLogger(t, WarnLevel)
+	SetModuleLogger("db", db)
+	defer SetModuleLogger("db", nil)
+
+	var errs []error
+	var errMu sync.Mutex
+	values := make(chan []byte)
+	w := NewLevelWatcher(chanLevelSource(values), WithLevelErrorHandler(func(err error) {
+		errMu.Lock()
+		defer errMu.Unlock()
+		errs = append(errs, err)
+	}))
+	require.NoError(t, w.Start(context.Background()))
+	defer func() {
+		assert.NoError(t, w.Stop(context.Background()))
+	}()
+	assert.ErrorIs(t, w.Start(context.Background()), ErrLevelWatcherStarted)
+
+	// 发送下一个值时，上一个值已经被处理完成。
+	send := func(value string) {
+		values <- []byte(value)
+		values <- []byte(value)
+	}
+
+	send("debug, db = error, cache=debug")
+	assert.Equal(t, DebugLevel, global.GetLevel())
+	assert.Equal(t, ErrorLevel, db.GetLevel())
+
+	send("error,db=debug")
+	assert.Equal(t, ErrorLevel, global.GetLevel())
+	assert.Equal(t, DebugLevel, db.GetLevel())
+
+	// 无效的配置不修改任何级别。
+	send("verbose,db=info")
+	assert.Equal(t, ErrorLevel, global.GetLevel())
+	assert.Equal(t, DebugLevel, db.GetLevel())
+	errMu.Lock()
+	require.NotEmpty(t, errs)
+	assert.Contains(t, errs[0].Error(), "verbose")
+	errMu.Unlock()
+
+	// 配置中不再出现的级别恢复为首次修改前的值。
+	send("db=info")
+	assert.Equal(t, InfoLevel, global.GetLevel())
+	assert.Equal(t, InfoLevel, db.GetLevel())
+
+	send("")
+	assert.Equal(t, InfoLevel, global.GetLevel())
+	assert.Equal(t, WarnLevel, db.GetLevel())
+}
+
+// TestLevelWatcher_Retry 测试来源监听失败后等待重试间隔重新监听。
+func TestLevelWatcher_Retry(t *testing.T) {
+	global := newTestLogger(t, InfoLevel)
+	useGlobalLogger(t, global)
+
+	clock := kittime.NewFakeClock(time.Now())
+	var calls atomic.Int32
+	errs := make(chan error, 10)
+	source := LevelSourceFunc(func(ctx context.Context, fn func(value []byte)) error {
+		if 1 == calls.Add(1) {
+			return errors.New("connection refused")
+		}
+		fn([]byte("warn"))
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	w := NewLevelWatcher(source, WithLevelClock(clock), WithLevelRetryInterval(time.Second), WithLevelErrorHandler(func(err error) {
+		errs <- err
+	}))
+	require.NoError(t, w.Start(context.Background()))
+
+	err := <-errs
+	assert.Contains(t, err.Error(), "connection refused")
+	assert.Equal(t, InfoLevel, global.GetLevel())
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	assert.Eventually(t, func() bool {
+		return WarnLevel == global.GetLevel()
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// 停止后级别保持不变。
+	require.NoError(t, w.Stop(context.Background()))
+	assert.Equal(t, WarnLevel, global.GetLevel())
+}
+
+// TestLevelWatcher_Stop 测试停止时的上下文与未启动时的停止。
+func TestLevelWatcher_Stop(t *testing.T) {
+	assert.NoError(t, NewLevelWatcher(chanLevelSource(nil)).Stop(context.Background()))
+
+	// 来源不响应取消时，Stop 在截止时间到达后返回。
+	release := make(chan struct{})
+	defer close(release)
+	w := NewLevelWatcher(LevelSourceFunc(func(context.Context, func([]byte)) error {
+		<-release
+		return nil
+	}), WithLevelRetryInterval(-1))
+	assert.Equal(t, levelRetryIntervalDefault, w.o.retryInterval)
+	require.NoError(t, w.Start(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, w.Stop(ctx), context.DeadlineExceeded)
+}
+
+// TestLevelWatcher_DefaultErrorHandler 测试未设置错误处理函数时记录到全局日志实例。
+func TestLevelWatcher_DefaultErrorHandler(t *testing.T) {
+	useGlobalLogger(t, newTestLogger(t, FatalLevel))
+	values := make(chan []byte, 1)
+	values <- []byte("db=")
+	w := NewLevelWatcher(chanLevelSource(values))
+	require.NoError(t, w.Start(context.Background()))
+	assert.Eventually(t, func() bool {
+		return 0 == len(values)
+	}, time.Second, time.Millisecond)
+	require.NoError(t, w.Stop(context.Background()))
+}
+
+// TestLevelWatcher_UnknownModule 测试没有设置日志实例的模块被忽略。
+func TestLevelWatcher_UnknownModule(t *testing.T) {
+	global := newTestLogger(t, InfoLevel)
+	useGlobalLogger(t, global)
+	cache := newTestLogger(t, InfoLevel)
+
+	w := NewLevelWatcher(chanLevelSource(nil))
+	require.NoError(t, w.apply([]byte("cache=debug")))
+	assert.Equal(t, InfoLevel, cache.GetLevel())
+
+	// 模块设置日志实例后，下一次配置变化时生效。
+	SetModuleLogger("cache", cache)
+	require.NoError(t, w.apply([]byte("cache=debug,warn")))
+	assert.Equal(t, DebugLevel, cache.GetLevel())
+
+	// 模块的日志实例被删除后，不再尝试恢复。
+	SetModuleLogger("cache", nil)
+	require.NoError(t, w.apply([]byte("warn")))
+	assert.NotContains(t, w.original, "cache")
+	assert.Equal(t, DebugLevel, cache.GetLevel())
+	assert.Same(t, global, GetModuleLogger("cache"))
+}
+
+// TestPollLevelSource 测试按间隔读取配置，值变化时才通知。
+func TestPollLevelSource(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Now())
+	var mu sync.Mutex
+	current := []byte("info")
+	fetchErr := error(nil)
+	source := PollLevelSource(func(context.Context) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return current, fetchErr
+	}, time.Second).(*pollLevelSource)
+	source.clock = clock
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- source.Watch(ctx, func(value []byte) {
+			received <- string(value)
+		})
+	}()
+	assert.Equal(t, "info", <-received)
+
+	// 值不变时不通知。
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	clock.BlockUntil(1)
+	mu.Lock()
+	current = []byte("debug")
+	mu.Unlock()
+	clock.Advance(time.Second)
+	assert.Equal(t, "debug", <-received)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	// 读取失败时返回错误。
+	mu.Lock()
+	fetchErr = errors.New("timeout")
+	mu.Unlock()
+	assert.EqualError(t, source.Watch(context.Background(), func([]byte) {}), "timeout")
+
+	assert.Equal(t, 10*time.Second, PollLevelSource(nil, 0).(*pollLevelSource).interval)
+}
+
+// TestParseLevelSpec 测试解析级别配置。
+func TestParseLevelSpec(t *testing.T) {
+	spec, err := parseLevelSpec(" WARN , db=Debug,,http= error ")
+	require.NoError(t, err)
+	require.NotNil(t, spec.global)
+	assert.Equal(t, WarnLevel, *spec.global)
+	assert.Equal(t, map[string]Level{"db": DebugLevel, "http": ErrorLevel}, spec.modules)
+
+	spec, err = parseLevelSpec("")
+	require.NoError(t, err)
+	assert.Nil(t, spec.global)
+	assert.Empty(t, spec.modules)
+
+	for _, s := range []string{"info,warn", "=debug", "db=verbose", "verbose"} {
+		_, err = parseLevelSpec(s)
+		assert.Error(t, err, s)
+	}
+}
+
+// TestModuleLogger 测试设置与获取模块的日志实例。
+func TestModuleLogger(t *testing.T) {
+	global := newTestLogger(t, InfoLevel)
+	useGlobalLogger(t, global)
+	assert.Same(t, global, GetModuleLogger("http"))
+
+	http := newTestLogger(t, DebugLevel)
+	SetModuleLogger("http", http)
+	assert.Same(t, http, GetModuleLogger("http"))
+
+	SetModuleLogger("http", nil)
+	assert.Same(t, global, GetModuleLogger("http"))
+}
@@ -5,8 +5,13 @@
 package log
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"time"
+
+	kitfs "github.com/fsyyft-go/monorepo/kit/fs"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
 )
 
 const (
@@ -62,7 +67,9 @@ type (
 	// - 提供格式化和非格式化的日志记录方法。
 	// - 支持结构化日志记录。
 	// - 支持日志级别的动态调整。
-	// - 提供上下文信息的添加和管理。
+	// - 提供上下文信息的添加和管理，支持从 context 中提取字段。
+	// - 支持写入缓冲的日志与释放打开的资源。
+	// - 支持在记录日志时调用钩子。
 	Logger interface {
 		// SetLevel 设置日志级别。
 		// 只有大于或等于设置级别的日志才会被记录。
@@ -164,6 +171,57 @@ type (
 		//   - args：格式化参数。
 		Fatalf(format string, args ...interface{})
 
+		// DebugContext 记录调试级别的日志，并添加通过 RegisterContextExtractor 注册的提取器从 ctx 中提取的字段。
+		//
+		// 参数：
+		//   - ctx：携带链路标识、请求标识等信息的上下文。
+		//   - args：要记录的日志内容，支持多个参数。
+		DebugContext(ctx context.Context, args ...interface{})
+
+		// InfoContext 记录信息级别的日志，并添加从 ctx 中提取的字段。
+		//
+		// 参数：
+		//   - ctx：携带链路标识、请求标识等信息的上下文。
+		//   - args：要记录的日志内容，支持多个参数。
+		InfoContext(ctx context.Context, args ...interface{})
+
+		// WarnContext 记录警告级别的日志，并添加从 ctx 中提取的字段。
+		//
+		// 参数：
+		//   - ctx：携带链路标识、请求标识等信息的上下文。
+		//   - args：要记录的日志内容，支持多个参数。
+		WarnContext(ctx context.Context, args ...interface{})
+
+		// ErrorContext 记录错误级别的日志，并添加从 ctx 中提取的字段。
+		//
+		// 参数：
+		//   - ctx：携带链路标识、请求标识等信息的上下文。
+		//   - args：要记录的日志内容，支持多个参数。
+		ErrorContext(ctx context.Context, args ...interface{})
+
+		// WithContext 返回添加了从 ctx 中提取的字段的 Logger，提取规则与 InfoContext 等方法相同。
+		// 返回一个新的 Logger 实例，原实例不会被修改；ctx 中没有可提取的字段时可能返回原实例。
+		// 这个方法用于在请求开始时派生请求范围的 Logger，再通过 NewContext 传递给下游。
+		//
+		// 参数：
+		//   - ctx：携带链路标识、请求标识等信息的上下文。
+		//
+		// 返回值：
+		//   - Logger：新的日志实例。
+		WithContext(ctx context.Context) Logger
+
+		// WithError 添加错误相关的字段到日志上下文。
+		// 添加的字段包括错误信息 error、错误链最内层的错误类型 error_type，以及调用堆栈 error_stack；
+		// 错误链中携带调用堆栈时输出该堆栈，否则只在通过 EnableErrorStack 开启后捕获调用处的堆栈。
+		// 返回一个新的 Logger 实例，原实例不会被修改；err 为 nil 时返回原实例。
+		//
+		// 参数：
+		//   - err：要记录的错误。
+		//
+		// 返回值：
+		//   - Logger：新的日志实例。
+		WithError(err error) Logger
+
 		// WithField 添加一个字段到日志上下文。
 		// 参数 key 是字段名，value 是字段值。
 		// 返回一个新的 Logger 实例，原实例不会被修改。
@@ -188,6 +246,36 @@ type (
 		// 返回值：
 		//   - Logger：新的日志实例。
 		WithFields(fields map[string]interface{}) Logger
+
+		// AddHook 添加记录日志时调用的钩子，用于在错误日志时告警、统计日志条数或转发日志等，对全部日志实现都有效。
+		// 钩子只在日志实际被记录时调用，在记录日志的协程中同步执行，应当尽快返回；Fatal 在退出前调用钩子。
+		// 通过 WithField 等方法派生的实例与原实例共享钩子，在任一实例上添加的钩子对全部实例生效。
+		//
+		// 参数：
+		//   - levels：触发钩子的日志级别，为空时对全部级别触发。
+		//   - fn：钩子函数，可能被并发调用，为 nil 时不添加。
+		//
+		// 示例：
+		//
+		//	logger.AddHook([]log.Level{log.ErrorLevel, log.FatalLevel}, func(e log.Entry) {
+		//		alert(e.Message, e.Fields)
+		//	})
+		AddHook(levels []Level, fn func(Entry))
+
+		// Sync 将缓冲的日志写入输出目标，例如 WithAsync 开启异步写入时缓冲区中的日志。
+		// 输出目标是文件时一并将文件内容写入磁盘；Fatal 在退出前自动调用。
+		//
+		// 返回值：
+		//   - error：写入失败时返回错误。
+		Sync() error
+
+		// Close 写入缓冲的日志，并释放日志实例打开的资源，例如日志文件与异步写入的后台协程。
+		// 通过 WithField 等方法派生的实例共享这些资源，关闭后它们同样不再输出日志；调用方传入的写入器不会被关闭。
+		// 程序退出前应当调用 Close，重复调用是安全的。
+		//
+		// 返回值：
+		//   - error：关闭失败时返回错误。
+		Close() error
 	}
 
 	// LoggerOptions 定义了日志配置选项。
@@ -203,6 +291,8 @@ type (
 		Level Level
 		// Output 指定日志输出路径。
 		Output string
+		// Writer 指定日志的输出目标，设置时忽略 Output，不由日志实例关闭。
+		Writer io.Writer
 		// EnableRotate 是否启用日志滚动。
 		EnableRotate bool
 		// RotateTime 日志滚动时间间隔。
@@ -211,6 +301,38 @@ type (
 		MaxAge time.Duration
 		// FormatType 指定日志输出格式类型。
 		FormatType LoggerFormatType
+		// Clock 日志滚动、异步写入与 StdLogger 钩子使用的时钟，为 nil 时使用系统时钟。
+		Clock kittime.Clock
+		// FS 是创建日志文件使用的文件系统，为 nil 时使用磁盘。
+		FS kitfs.FS
+		// Async 是否在后台协程中异步写入日志。
+		Async bool
+		// AsyncBufferSize 异步写入时等待写入的日志条数上限，小于等于 0 时使用默认值。
+		AsyncBufferSize int
+		// AsyncFlushInterval 异步写入时批量写入输出目标的间隔，小于等于 0 时使用默认值。
+		AsyncFlushInterval time.Duration
+		// AsyncPolicy 异步写入时缓冲区已满的处理策略，为空时使用 AsyncDrop。
+		AsyncPolicy AsyncPolicy
+		// Sinks 同时输出的多个目标，设置时忽略 Output 与 Writer。
+		Sinks []Sink
+		// OTLPEndpoint LogTypeOTLP 发送日志的 Collector 地址，为空时使用环境变量或默认地址。
+		OTLPEndpoint string
+		// OTLPOptions LogTypeOTLP 的其他配置选项。
+		OTLPOptions []OTLPOption
+		// Caller 是否在每条日志中记录调用位置。
+		Caller bool
+		// CallerSkip 记录调用位置时在日志方法的调用方之上额外跳过的栈帧数。
+		CallerSkip int
+	}
+
+	// Sink 定义了日志的一个输出目标，通过 WithSinks 同时输出到多个目标，每个目标可以使用独立的格式。
+	Sink struct {
+		// Output 日志文件的路径，与 Writer 都为空时输出到标准输出。
+		Output string
+		// Writer 日志的输出目标，设置时忽略 Output，不由日志实例关闭。
+		Writer io.Writer
+		// FormatType 该目标的输出格式，为空时使用日志实例的格式；LogTypeStd 与 LogTypeConsole 只输出文本格式，忽略该设置。
+		FormatType LoggerFormatType
 	}
 
 	// Option 定义了日志配置的函数选项。
@@ -305,6 +427,20 @@ func WithOutput(output string) Option {
 	}
 }
 
+// WithWriter 设置日志的输出目标，用于接入网络写入器、测试使用的内存缓冲区与管道等，设置后忽略 WithOutput。
+// 日志实例不关闭 w，也不对其滚动；w 需要支持并发调用，开启异步写入时只在后台协程中调用。
+//
+// 参数：
+//   - w：日志的输出目标，例如 ElasticsearchWriter。
+//
+// 返回值：
+//   - 返回一个配置选项函数，可用于配置日志实例。
+func WithWriter(w io.Writer) Option {
+	return func(opts *LoggerOptions) {
+		opts.Writer = w
+	}
+}
+
 // WithEnableRotate 设置是否启用日志滚动。
 //
 // 参数：
@@ -344,6 +480,129 @@ func WithMaxAge(duration time.Duration) Option {
 	}
 }
 
+// WithClock 设置日志滚动、异步写入与钩子使用的时钟。
+//
+// 参数：
+//   - clock：决定滚动时间点、日志文件名、异步写入间隔与 StdLogger 钩子日志时间的时钟，为 nil 时使用系统时钟，测试时可以注入 kit/time 的 FakeClock。
+//
+// 返回值：
+//   - 返回一个配置选项函数，可用于配置日志实例。
+func WithClock(clock kittime.Clock) Option {
+	return func(opts *LoggerOptions) {
+		opts.Clock = clock
+	}
+}
+
+// WithFS 设置创建日志文件使用的文件系统。
+// 日志滚动只能作用于磁盘，注入其他文件系统时不滚动，日志始终写入 Output 指定的文件。
+//
+// 参数：
+//   - fsys：文件系统，为 nil 时使用磁盘，测试时可以注入 kit/testing 的 MemFS。
+//
+// 返回值：
+//   - 返回一个配置选项函数，可用于配置日志实例。
+func WithFS(fsys kitfs.FS) Option {
+	return func(opts *LoggerOptions) {
+		opts.FS = fsys
+	}
+}
+
+// WithAsync 设置在后台协程中异步写入日志，输出目标由 AsyncWriter 包装。
+// 记录日志只需要将日志放入有界的缓冲区，后台协程批量写入输出目标，避免缓慢的磁盘阻塞请求处理。
+// 缓冲区已满时默认丢弃新的日志，可以通过 WithAsyncPolicy 改为等待；Fatal 在退出前写入缓冲区中的日志。
+//
+// 参数：
+//   - bufferSize：等待写入的日志条数上限，小于等于 0 时使用默认值 8192。
+//   - flushInterval：批量写入输出目标的间隔，即日志最长的延迟，小于等于 0 时使用默认值 1 秒。
+//
+// 返回值：
+//   - 返回一个配置选项函数，可用于配置日志实例。
+func WithAsync(bufferSize int, flushInterval time.Duration) Option {
+	return func(opts *LoggerOptions) {
+		opts.Async = true
+		opts.AsyncBufferSize = bufferSize
+		opts.AsyncFlushInterval = flushInterval
+	}
+}
+
+// WithAsyncPolicy 设置异步写入时缓冲区已满的处理策略，只在通过 WithAsync 开启异步写入时生效。
+//
+// 参数：
+//   - policy：处理策略，可选值包括 AsyncDrop（丢弃，默认）与 AsyncBlock（等待）。
+//
+// 返回值：
+//   - 返回一个配置选项函数，可用于配置日志实例。
+func WithAsyncPolicy(policy AsyncPolicy) Option {
+	return func(opts *LoggerOptions) {
+		opts.AsyncPolicy = policy
+	}
+}
+
+// WithSinks 设置同时输出的多个目标，例如文件、标准输出与自定义的 io.Writer，设置后忽略 WithOutput 与 WithWriter。
+// 每个目标可以使用独立的格式，例如标准输出使用文本格式，文件使用 JSON 格式；
+// 日志文件按日志实例的滚动配置滚动，开启异步写入时每个目标使用独立的 AsyncWriter。
+//
+// 参数：
+//   - sinks：输出目标列表。
+//
+// 返回值：
+//   - 返回一个配置选项函数，可用于配置日志实例。
+func WithSinks(sinks ...Sink) Option {
+	return func(opts *LoggerOptions) {
+		opts.Sinks = append([]Sink(nil), sinks...)
+	}
+}
+
+// WithOTLP 设置 LogTypeOTLP 发送日志的 Collector 地址与其他配置选项。
+// LogTypeOTLP 不写入本地的输出目标，忽略 WithOutput、WithWriter、WithSinks、WithFormatType、日志滚动与异步写入的配置。
+//
+// 参数：
+//   - endpoint：Collector 的地址，例如 http://otel-collector:4318，为空时的规则见 NewOTLPLogger。
+//   - opts：OTLPLogger 的配置选项，例如 WithOTLPServiceName 与 WithOTLPHeaders。
+//
+// 返回值：
+//   - 返回一个配置选项函数，可用于配置日志实例。
+func WithOTLP(endpoint string, opts ...OTLPOption) Option {
+	return func(o *LoggerOptions) {
+		o.OTLPEndpoint = endpoint
+		o.OTLPOptions = append([]OTLPOption(nil), opts...)
+	}
+}
+
+// WithCaller 设置是否在每条日志中记录调用位置，对全部日志实现有效。
+// 调用位置作为 caller 字段输出，格式为 "目录/文件:行号:函数名"；包级别的 Info 等函数记录的是它们的调用方。
+// 获取调用位置需要遍历调用栈，只在日志级别启用时进行，仍会增加每条日志的开销。
+//
+// 参数：
+//   - enabled：是否记录调用位置，true 表示记录。
+//
+// 返回值：
+//   - 返回一个配置选项函数，可用于配置日志实例。
+func WithCaller(enabled bool) Option {
+	return func(opts *LoggerOptions) {
+		opts.Caller = enabled
+	}
+}
+
+// WithCallerSkip 设置记录调用位置时额外跳过的栈帧数，用于调用方自己封装了日志函数的情况，只在通过 WithCaller 开启时生效。
+//
+// 参数：
+//   - skip：在日志方法的调用方之上额外跳过的栈帧数，例如经过一层封装函数时为 1。
+//
+// 返回值：
+//   - 返回一个配置选项函数，可用于配置日志实例。
+//
+// 示例：
+//
+//	// 调用位置指向 logError 的调用方。
+//	logger, _ := log.NewLogger(log.WithCaller(true), log.WithCallerSkip(1))
+//	logError := func(err error) { logger.WithError(err).Error("请求失败") }
+func WithCallerSkip(skip int) Option {
+	return func(opts *LoggerOptions) {
+		opts.CallerSkip = skip
+	}
+}
+
 // NewLogger 创建一个新的日志实例。
 //
 // 参数：
@@ -372,34 +631,68 @@ func NewLogger(options ...Option) (Logger, error) {
 	var logger Logger
 	var err error
 
+	// 开启异步写入时，由 AsyncWriter 包装各日志实现打开的输出目标。
+	var wrap func(io.Writer) io.Writer
+	if opts.Async {
+		wrap = func(w io.Writer) io.Writer {
+			return newAsyncWriter(w, opts.AsyncBufferSize, opts.AsyncFlushInterval, opts.AsyncPolicy, opts.Clock)
+		}
+	}
+
+	sinks := opts.Sinks
+	if 0 == len(sinks) {
+		sinks = []Sink{{Output: opts.Output, Writer: opts.Writer}}
+	}
+
 	switch opts.Type {
 	case LogTypeConsole:
-		logger, err = NewStdLogger("")
+		logger, err = newStdLogger(nil, []Sink{{}}, wrap, opts.Clock)
 	case LogTypeStd:
-		logger, err = NewStdLogger(opts.Output)
+		logger, err = newStdLogger(opts.FS, sinks, wrap, opts.Clock)
 	case LogTypeLogrus:
 		// 使用 WithOutputPath 和其他选项创建 Logrus 日志实例。
 		logrusOpts := []LogrusOption{
 			WithOutputPath(opts.Output),
+			WithLogrusWriter(opts.Writer),
 			WithLogrusLevel(opts.Level),
 			WithLogrusEnableRotate(opts.EnableRotate),
 			WithLogrusRotateTime(opts.RotateTime),
 			WithLogrusMaxAge(opts.MaxAge),
+			WithLogrusClock(opts.Clock),
+			WithLogrusFS(opts.FS),
+			WithLogrusSinks(opts.Sinks...),
+			func(o *LogrusLoggerOptions) {
+				o.wrapWriter = wrap
+			},
 		}
 
 		// 根据格式类型设置格式化器。
-		switch opts.FormatType {
-		case TextFormat:
-			logrusOpts = append(logrusOpts,
-				WithTextFormatter(timestampFormat, fullTimestamp, disableColors),
-			)
-		case JSONFormat:
-			logrusOpts = append(logrusOpts,
-				WithJSONFormatter(timestampFormat, prettyPrint),
-			)
+		if formatter := logrusFormatter(opts.FormatType); nil != formatter {
+			logrusOpts = append(logrusOpts, WithFormatter(formatter))
 		}
 
 		logger, err = NewLogrusLogger(logrusOpts...)
+	case LogTypeSlog:
+		logger, err = NewSlogLogger(
+			WithSlogOutputPath(opts.Output),
+			WithSlogWriter(opts.Writer),
+			WithSlogFormatType(opts.FormatType),
+			WithSlogTimestampFormat(timestampFormat),
+			WithSlogLevel(opts.Level),
+			WithSlogEnableRotate(opts.EnableRotate),
+			WithSlogRotateTime(opts.RotateTime),
+			WithSlogMaxAge(opts.MaxAge),
+			WithSlogClock(opts.Clock),
+			WithSlogFS(opts.FS),
+			WithSlogSinks(opts.Sinks...),
+			func(o *SlogLoggerOptions) {
+				o.wrapWriter = wrap
+			},
+		)
+	case LogTypeOTLP:
+		// 调用方的配置在后，可以覆盖时钟；日志级别随后统一设置。
+		logger, err = NewOTLPLogger(opts.OTLPEndpoint,
+			append([]OTLPOption{WithOTLPLevel(opts.Level), WithOTLPClock(opts.Clock)}, opts.OTLPOptions...)...)
 	default:
 		return nil, fmt.Errorf("不支持的日志类型：%s", opts.Type)
 	}
@@ -410,6 +703,9 @@ func NewLogger(options ...Option) (Logger, error) {
 
 	// 设置日志级别。
 	logger.SetLevel(opts.Level)
+	if opts.Caller {
+		logger = logger.(callerLogger).withCaller(opts.CallerSkip)
+	}
 
 	return logger, nil
 }
@@ -9,8 +9,13 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitfs "github.com/fsyyft-go/monorepo/kit/fs"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
 )
 
 // TestLoggers 测试所有支持的日志实现。
@@ -133,6 +138,11 @@ func TestNewLogger(t *testing.T) {
 	assert.NotNil(t, logger)
 	assert.Equal(t, InfoLevel, logger.GetLevel())
 
+	// 派生的实例共享日志级别。
+	derived := logger.WithField("module", "db")
+	logger.SetLevel(WarnLevel)
+	assert.Equal(t, WarnLevel, derived.GetLevel())
+
 	// 测试自定义配置。
 	logPath := filepath.Join(tmpDir, "custom.log")
 	logger, err = NewLogger(
@@ -249,3 +259,90 @@ func TestWithFieldsAndFormat(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, content)
 }
+
+// TestRotateClock 测试日志滚动使用注入的时钟决定文件名与滚动时间点。
+func TestRotateClock(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "rotate.log")
+	clock := kittime.NewFakeClock(time.Date(2025, 1, 1, 8, 30, 0, 0, time.UTC))
+
+	logger, err := NewLogger(
+		WithLogType(LogTypeLogrus),
+		WithOutput(logPath),
+		WithRotateTime(time.Hour),
+		WithClock(clock),
+	)
+	assert.NoError(t, err)
+
+	logger.Info("滚动前。")
+	clock.Advance(time.Hour)
+	logger.Info("滚动后。")
+
+	for _, name := range []string{"rotate-2025010108.log", "rotate-2025010109.log"} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, name)) // nolint:gosec
+		assert.NoError(t, err, name)
+		assert.Equal(t, 1, strings.Count(string(content), "\n"), "每个文件应只包含一条日志：%s", name)
+	}
+}
+
+// TestStdLoggerFormat 测试标准库日志器的输出格式，字段按字段名排序。
+func TestStdLoggerFormat(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "std.log")
+	logger, err := NewStdLogger(logPath)
+	assert.NoError(t, err)
+
+	logger.Info("plain", 1)
+	logger.WithFields(map[string]interface{}{"b": 2, "a": "x"}).WithField("c", true).Warnf("n=%d", 3)
+	logger.Debug("filtered")
+
+	content, err := os.ReadFile(logPath) // nolint:gosec
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasSuffix(lines[0], " [INFO] plain1"), lines[0])
+	assert.True(t, strings.HasSuffix(lines[1], " [WARN] [a=x b=2 c=true] n=3"), lines[1])
+}
+
+// rootFS 是把全部路径映射到 dir 下的测试文件系统，用于验证日志文件通过注入的文件系统创建。
+type rootFS struct {
+	// FS 是实际读写的磁盘。
+	kitfs.FS
+	// dir 是映射的根目录。
+	dir string
+}
+
+// path 返回 name 映射到磁盘上的路径。
+func (r rootFS) path(name string) string { return filepath.Join(r.dir, name) }
+
+// Stat 查看映射后的路径。
+func (r rootFS) Stat(name string) (os.FileInfo, error) { return r.FS.Stat(r.path(name)) }
+
+// MkdirAll 创建映射后的目录。
+func (r rootFS) MkdirAll(name string, perm os.FileMode) error {
+	return r.FS.MkdirAll(r.path(name), perm)
+}
+
+// OpenFile 打开映射后的文件。
+func (r rootFS) OpenFile(name string, flag int, perm os.FileMode) (kitfs.File, error) {
+	return r.FS.OpenFile(r.path(name), flag, perm)
+}
+
+// TestNewLogger_FS 测试日志文件通过注入的文件系统创建，注入的文件系统不支持滚动。
+func TestNewLogger_FS(t *testing.T) {
+	dir := t.TempDir()
+	fsys := rootFS{FS: kitfs.OS, dir: dir}
+
+	for _, typ := range []LogType{LogTypeStd, LogTypeLogrus, LogTypeSlog} {
+		logger, err := NewLogger(WithLogType(typ), WithOutput("/logs/"+string(typ)+".log"), WithFS(fsys))
+		require.NoError(t, err, typ)
+		logger.Info("hello")
+
+		content, err := os.ReadFile(filepath.Join(dir, "logs", string(typ)+".log")) // nolint:gosec
+		require.NoError(t, err, typ)
+		assert.Contains(t, string(content), "hello", typ)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "logs"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "注入文件系统时不创建滚动的文件")
+}
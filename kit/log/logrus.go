@@ -5,12 +5,17 @@
 package log
 
 import (
+	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
 
 	rotatelogs "github.com/lestrrat-go/file-rotatelogs"
 	"github.com/sirupsen/logrus"
+
+	kitfs "github.com/fsyyft-go/monorepo/kit/fs"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
 )
 
 const (
@@ -32,7 +37,7 @@ var (
 
 	// defaultOptions 定义了默认的 Logrus 日志选项。
 	defaultOptions = LogrusLoggerOptions{
-		Formatter: &logrus.JSONFormatter{
+		Formatter: &JSONFormatter{
 			TimestampFormat: "2006-01-02 15:04:05",
 		},
 		Level:        logrus.InfoLevel,
@@ -51,15 +56,28 @@ type (
 	// - 多种输出格式（文本、JSON）。
 	// - 灵活的日志级别控制。
 	// - 支持同时输出到多个目标。
+	//
+	// WithField 与 WithFields 派生的 Logger 与原 Logger 共享同一个 Logrus 日志实例，新增的字段串联在原有字段之后，
+	// 派生时不复制已有字段；输出日志时才将字段合并到池化的条目中，日志级别未启用时不做任何合并。
 	LogrusLogger struct {
-		// logger 是 Logrus 的日志实例，包含了所有的上下文信息。
+		// logger 是不带字段的 Logrus 基础条目。
 		logger *logrus.Entry
+		// fields 是 WithField 与 WithFields 添加的字段，为 nil 时表示没有字段。
+		fields *fieldChain
+		// output 是输出目标，与派生的实例共享，为 nil 时 Sync 与 Close 不做任何操作。
+		output *output
+		// hooks 是通过 AddHook 添加的钩子，与派生的实例共享。
+		hooks *hooks
+		// caller 是记录调用位置的配置。
+		caller caller
 	}
 
 	// LogrusLoggerOptions 包含了 LogrusLogger 的所有配置选项。
 	LogrusLoggerOptions struct {
 		// OutputPath 输出文件路径。
 		OutputPath string
+		// Writer 日志的输出目标，设置时忽略 OutputPath，不由日志实例关闭。
+		Writer io.Writer
 		// Formatter 日志格式化器。
 		Formatter logrus.Formatter
 		// Level 日志级别。
@@ -74,6 +92,15 @@ type (
 		RotateTime time.Duration
 		// MaxAge 日志保留时间。
 		MaxAge time.Duration
+		// Clock 日志滚动使用的时钟，为 nil 时使用系统时钟。
+		Clock kittime.Clock
+		// FS 是创建日志文件使用的文件系统，为 nil 时使用磁盘。
+		FS kitfs.FS
+		// Sinks 同时输出的多个目标，设置时忽略 OutputPath 与 Writer。
+		Sinks []Sink
+
+		// wrapWriter 不为 nil 时用于包装输出目标，NewLogger 通过它开启异步写入。
+		wrapWriter func(io.Writer) io.Writer
 	}
 
 	// LogrusOption 定义了 LogrusLogger 的配置选项函数类型。
@@ -93,6 +120,19 @@ func WithOutputPath(path string) LogrusOption {
 	}
 }
 
+// WithLogrusWriter 设置日志的输出目标，设置后忽略 WithOutputPath。
+//
+// 参数：
+//   - w：日志的输出目标，不由日志实例关闭，为 nil 时不生效。
+//
+// 返回值：
+//   - LogrusOption：返回一个配置选项函数。
+func WithLogrusWriter(w io.Writer) LogrusOption {
+	return func(o *LogrusLoggerOptions) {
+		o.Writer = w
+	}
+}
+
 // WithFormatter 设置日志格式化器。
 //
 // 参数：
@@ -107,6 +147,7 @@ func WithFormatter(formatter logrus.Formatter) LogrusOption {
 }
 
 // WithJSONFormatter 设置 JSON 格式化器的选项。
+// 单行输出时使用 JSONFormatter，美化输出时使用 logrus.JSONFormatter。
 //
 // 参数：
 //   - timestampFormat：时间戳的格式化模板，例如："2006-01-02 15:04:05"。
@@ -116,6 +157,12 @@ func WithFormatter(formatter logrus.Formatter) LogrusOption {
 //   - LogrusOption：返回一个配置选项函数。
 func WithJSONFormatter(timestampFormat string, prettyPrint bool) LogrusOption {
 	return func(o *LogrusLoggerOptions) {
+		if !prettyPrint {
+			o.Formatter = &JSONFormatter{
+				TimestampFormat: timestampFormat,
+			}
+			return
+		}
 		o.Formatter = &logrus.JSONFormatter{
 			TimestampFormat: timestampFormat,
 			PrettyPrint:     prettyPrint,
@@ -222,6 +269,59 @@ func WithLogrusMaxAge(duration time.Duration) LogrusOption {
 	}
 }
 
+// WithLogrusClock 设置日志滚动使用的时钟。
+//
+// 参数：
+//   - clock：决定滚动时间点与日志文件名的时钟，为 nil 时使用系统时钟，测试时可以注入 kit/time 的 FakeClock。
+//
+// 返回值：
+//   - LogrusOption：返回一个配置选项函数。
+func WithLogrusClock(clock kittime.Clock) LogrusOption {
+	return func(o *LogrusLoggerOptions) {
+		o.Clock = clock
+	}
+}
+
+// WithLogrusFS 设置创建日志文件使用的文件系统。
+// 日志滚动只能作用于磁盘，注入其他文件系统时不滚动，日志始终写入 OutputPath 指定的文件。
+//
+// 参数：
+//   - fsys：文件系统，为 nil 时使用磁盘，测试时可以注入 kit/testing 的 MemFS。
+//
+// 返回值：
+//   - LogrusOption：返回一个配置选项函数。
+func WithLogrusFS(fsys kitfs.FS) LogrusOption {
+	return func(o *LogrusLoggerOptions) {
+		o.FS = fsys
+	}
+}
+
+// WithLogrusSinks 设置同时输出的多个目标，设置后忽略 WithOutputPath 与 WithLogrusWriter。
+// 目标的 FormatType 为空时使用 WithFormatter 等选项设置的格式化器，否则使用与 NewLogger 相同的文本或 JSON 格式化器。
+//
+// 参数：
+//   - sinks：输出目标列表。
+//
+// 返回值：
+//   - LogrusOption：返回一个配置选项函数。
+func WithLogrusSinks(sinks ...Sink) LogrusOption {
+	return func(o *LogrusLoggerOptions) {
+		o.Sinks = append([]Sink(nil), sinks...)
+	}
+}
+
+// logrusFormatter 返回 NewLogger 为 formatType 使用的格式化器，不支持的格式返回 nil。
+func logrusFormatter(formatType LoggerFormatType) logrus.Formatter {
+	var o LogrusLoggerOptions
+	switch formatType {
+	case TextFormat:
+		WithTextFormatter(timestampFormat, fullTimestamp, disableColors)(&o)
+	case JSONFormat:
+		WithJSONFormatter(timestampFormat, prettyPrint)(&o)
+	}
+	return o.Formatter
+}
+
 // NewLogrusLogger 创建一个新的 LogrusLogger 实例。
 //
 // 参数：
@@ -240,51 +340,95 @@ func NewLogrusLogger(opts ...LogrusOption) (Logger, error) {
 	}
 
 	log := logrus.New()
+	open := func(path string) (io.Writer, error) {
+		return openOutputWriter(options.FS, path, options.FileMode, options.DirMode,
+			options.EnableRotate, options.RotateTime, options.MaxAge, options.Clock)
+	}
 
-	// 如果指定了输出目录，配置文件输出。
-	if options.OutputPath != "" {
-		// 确保日志文件所在的目录存在。
-		if err := os.MkdirAll(filepath.Dir(options.OutputPath), options.DirMode); nil != err {
+	out := newOutput(log.Out, false)
+	// 配置日志格式。
+	log.SetFormatter(options.Formatter)
+
+	switch {
+	case 0 != len(options.Sinks):
+		// 每个目标使用独立的格式化器，由钩子格式化并写入，Logrus 自身不再输出。
+		outs, err := openSinks(options.Sinks, open, options.wrapWriter)
+		if nil != err {
 			return nil, err
 		}
-
-		if options.EnableRotate {
-			// 获取文件名和扩展名
-			ext := filepath.Ext(options.OutputPath)
-			base := options.OutputPath[:len(options.OutputPath)-len(ext)]
-
-			// 配置日志滚动
-			writer, err := rotatelogs.New(
-				base+"-%Y%m%d%H"+ext,
-				rotatelogs.WithLinkName(options.OutputPath),
-				rotatelogs.WithRotationTime(options.RotateTime),
-				rotatelogs.WithMaxAge(options.MaxAge),
-			)
-			if nil != err {
-				return nil, err
-			}
-			log.SetOutput(writer)
-		} else {
-			// 打开或创建日志文件。
-			file, err := os.OpenFile(options.OutputPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, options.FileMode)
-			if nil != err {
-				return nil, err
+		hook := &logrusSinkHook{}
+		for i, o := range outs {
+			formatter := logrusFormatter(options.Sinks[i].FormatType)
+			if nil == formatter {
+				formatter = options.Formatter
 			}
-			log.SetOutput(file)
+			hook.sinks = append(hook.sinks, logrusSink{w: o.w, formatter: formatter})
 		}
+		log.AddHook(hook)
+		log.SetOutput(io.Discard)
+		log.SetFormatter(nopFormatter{})
+		out = newMultiOutput(outs)
+	case nil != options.Writer:
+		// 调用方传入的写入器不由日志实例关闭。
+		out = newOutput(options.Writer, false)
+		out.wrap(options.wrapWriter)
+		log.SetOutput(out.w)
+	case options.OutputPath != "":
+		// 如果指定了输出目录，配置文件输出。
+		writer, err := open(options.OutputPath)
+		if nil != err {
+			return nil, err
+		}
+		out = newOutput(writer, true)
+		out.wrap(options.wrapWriter)
+		log.SetOutput(out.w)
+	default:
+		out.wrap(options.wrapWriter)
+		log.SetOutput(out.w)
 	}
 
-	// 配置日志格式。
-	log.SetFormatter(options.Formatter)
-
 	// 设置日志级别。
 	log.SetLevel(options.Level)
+	// 在输出目标的钩子之后注册，同时输出到多个目标时钩子调用前日志已经写入。
+	hooks := newHooks()
+	log.AddHook(&logrusHook{hooks: hooks})
 
 	return &LogrusLogger{
 		logger: logrus.NewEntry(log),
+		output: out,
+		hooks:  hooks,
 	}, nil
 }
 
+// openOutputWriter 打开日志文件，所在目录不存在时一并创建。
+// 启用滚动且 fsys 为磁盘时按 rotateTime 滚动，文件名形如 app-2025010215.log，path 为指向当前文件的链接；
+// 否则以追加模式打开 path。
+func openOutputWriter(fsys kitfs.FS, path string, fileMode, dirMode os.FileMode,
+	enableRotate bool, rotateTime, maxAge time.Duration, clock kittime.Clock) (io.Writer, error) {
+	// 确保日志文件所在的目录存在。
+	if err := kitfs.EnsureFileDirFS(fsys, path, dirMode); nil != err {
+		return nil, err
+	}
+
+	if enableRotate && kitfs.IsOS(fsys) {
+		// 获取文件名和扩展名
+		ext := filepath.Ext(path)
+		base := path[:len(path)-len(ext)]
+
+		// 配置日志滚动
+		return rotatelogs.New(
+			base+"-%Y%m%d%H"+ext,
+			rotatelogs.WithLinkName(path),
+			rotatelogs.WithRotationTime(rotateTime),
+			rotatelogs.WithMaxAge(maxAge),
+			rotatelogs.WithClock(kittime.OrReal(clock)),
+		)
+	}
+
+	// 打开或创建日志文件。
+	return kitfs.OpenAppendFS(fsys, path, fileMode, dirMode)
+}
+
 // SetLevel 实现 Logger 接口的日志级别设置方法。
 //
 // 参数：
@@ -314,7 +458,7 @@ func (l *LogrusLogger) GetLevel() Level {
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func (l *LogrusLogger) Debug(args ...interface{}) {
-	l.logger.Debug(args...)
+	l.log(logrus.DebugLevel, args...)
 }
 
 // Debugf 实现 Logger 接口的格式化调试级别日志记录。
@@ -323,7 +467,7 @@ func (l *LogrusLogger) Debug(args ...interface{}) {
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func (l *LogrusLogger) Debugf(format string, args ...interface{}) {
-	l.logger.Debugf(format, args...)
+	l.logf(logrus.DebugLevel, format, args...)
 }
 
 // Info 实现 Logger 接口的信息级别日志记录。
@@ -331,7 +475,7 @@ func (l *LogrusLogger) Debugf(format string, args ...interface{}) {
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func (l *LogrusLogger) Info(args ...interface{}) {
-	l.logger.Info(args...)
+	l.log(logrus.InfoLevel, args...)
 }
 
 // Infof 实现 Logger 接口的格式化信息级别日志记录。
@@ -340,7 +484,7 @@ func (l *LogrusLogger) Info(args ...interface{}) {
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func (l *LogrusLogger) Infof(format string, args ...interface{}) {
-	l.logger.Infof(format, args...)
+	l.logf(logrus.InfoLevel, format, args...)
 }
 
 // Warn 实现 Logger 接口的警告级别日志记录。
@@ -348,7 +492,7 @@ func (l *LogrusLogger) Infof(format string, args ...interface{}) {
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func (l *LogrusLogger) Warn(args ...interface{}) {
-	l.logger.Warn(args...)
+	l.log(logrus.WarnLevel, args...)
 }
 
 // Warnf 实现 Logger 接口的格式化警告级别日志记录。
@@ -357,7 +501,7 @@ func (l *LogrusLogger) Warn(args ...interface{}) {
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func (l *LogrusLogger) Warnf(format string, args ...interface{}) {
-	l.logger.Warnf(format, args...)
+	l.logf(logrus.WarnLevel, format, args...)
 }
 
 // Error 实现 Logger 接口的错误级别日志记录。
@@ -365,7 +509,7 @@ func (l *LogrusLogger) Warnf(format string, args ...interface{}) {
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func (l *LogrusLogger) Error(args ...interface{}) {
-	l.logger.Error(args...)
+	l.log(logrus.ErrorLevel, args...)
 }
 
 // Errorf 实现 Logger 接口的格式化错误级别日志记录。
@@ -374,7 +518,7 @@ func (l *LogrusLogger) Error(args ...interface{}) {
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func (l *LogrusLogger) Errorf(format string, args ...interface{}) {
-	l.logger.Errorf(format, args...)
+	l.logf(logrus.ErrorLevel, format, args...)
 }
 
 // Fatal 实现 Logger 接口的致命错误级别日志记录。
@@ -383,7 +527,9 @@ func (l *LogrusLogger) Errorf(format string, args ...interface{}) {
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func (l *LogrusLogger) Fatal(args ...interface{}) {
-	l.logger.Fatal(args...)
+	l.log(logrus.FatalLevel, args...)
+	_ = l.Sync()
+	l.logger.Logger.Exit(1)
 }
 
 // Fatalf 实现 Logger 接口的格式化致命错误级别日志记录。
@@ -393,10 +539,96 @@ func (l *LogrusLogger) Fatal(args ...interface{}) {
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func (l *LogrusLogger) Fatalf(format string, args ...interface{}) {
-	l.logger.Fatalf(format, args...)
+	l.logf(logrus.FatalLevel, format, args...)
+	_ = l.Sync()
+	l.logger.Logger.Exit(1)
+}
+
+// DebugContext 实现 Logger 接口的调试级别日志记录，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *LogrusLogger) DebugContext(ctx context.Context, args ...interface{}) {
+	l.withContext(ctx, logrus.DebugLevel).log(logrus.DebugLevel, args...)
+}
+
+// InfoContext 实现 Logger 接口的信息级别日志记录，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *LogrusLogger) InfoContext(ctx context.Context, args ...interface{}) {
+	l.withContext(ctx, logrus.InfoLevel).log(logrus.InfoLevel, args...)
+}
+
+// WarnContext 实现 Logger 接口的警告级别日志记录，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *LogrusLogger) WarnContext(ctx context.Context, args ...interface{}) {
+	l.withContext(ctx, logrus.WarnLevel).log(logrus.WarnLevel, args...)
+}
+
+// ErrorContext 实现 Logger 接口的错误级别日志记录，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *LogrusLogger) ErrorContext(ctx context.Context, args ...interface{}) {
+	l.withContext(ctx, logrus.ErrorLevel).log(logrus.ErrorLevel, args...)
+}
+
+// withContext 返回添加了从 ctx 中提取的字段的实例，日志级别未启用或没有字段时返回 l 本身。
+func (l *LogrusLogger) withContext(ctx context.Context, level logrus.Level) *LogrusLogger {
+	if !l.logger.Logger.IsLevelEnabled(level) {
+		return l
+	}
+	fields := ContextFields(ctx)
+	if 0 == len(fields) {
+		return l
+	}
+	return &LogrusLogger{
+		logger: l.logger,
+		fields: l.fields.withFields(fields),
+		output: l.output,
+		hooks:  l.hooks,
+		caller: l.caller,
+	}
+}
+
+// WithContext 实现 Logger 接口的 context 字段添加方法。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//
+// 返回值：
+//   - Logger：返回一个包含提取的字段的新 Logger 实例，没有可提取的字段时返回原实例。
+func (l *LogrusLogger) WithContext(ctx context.Context) Logger {
+	fields := ContextFields(ctx)
+	if 0 == len(fields) {
+		return l
+	}
+	return l.WithFields(fields)
+}
+
+// WithError 实现 Logger 接口的错误字段添加方法。
+//
+// 参数：
+//   - err：要记录的错误。
+//
+// 返回值：
+//   - Logger：返回一个包含错误信息、错误类型与调用堆栈的新 Logger 实例，err 为 nil 时返回原实例。
+func (l *LogrusLogger) WithError(err error) Logger {
+	if nil == err {
+		return l
+	}
+	return l.WithFields(errorFields(err))
 }
 
 // WithField 实现 Logger 接口的单字段添加方法。
+// 派生时只分配一个字段节点，不复制已有字段；函数类型的字段值无法输出，会被忽略。
 //
 // 参数：
 //   - key：字段名。
@@ -406,11 +638,16 @@ func (l *LogrusLogger) Fatalf(format string, args ...interface{}) {
 //   - Logger：返回一个包含新字段的新 Logger 实例。
 func (l *LogrusLogger) WithField(key string, value interface{}) Logger {
 	return &LogrusLogger{
-		logger: l.logger.WithField(key, value),
+		logger: l.logger,
+		fields: l.fields.withField(key, value),
+		output: l.output,
+		hooks:  l.hooks,
+		caller: l.caller,
 	}
 }
 
 // WithFields 实现 Logger 接口的多字段添加方法。
+// fields 中的字段会被复制，调用之后修改 fields 不影响返回的 Logger；函数类型的字段值无法输出，会被忽略。
 //
 // 参数：
 //   - fields：要添加的字段映射。
@@ -419,6 +656,53 @@ func (l *LogrusLogger) WithField(key string, value interface{}) Logger {
 //   - Logger：返回一个包含新字段的新 Logger 实例。
 func (l *LogrusLogger) WithFields(fields map[string]interface{}) Logger {
 	return &LogrusLogger{
-		logger: l.logger.WithFields(fields),
+		logger: l.logger,
+		fields: l.fields.withFields(fields),
+		output: l.output,
+		hooks:  l.hooks,
+		caller: l.caller,
 	}
 }
+
+// AddHook 实现 Logger 接口的钩子添加方法。
+// 钩子通过 Logrus 的钩子机制调用，与 Logrus 日志实例上的其他钩子一起执行。
+//
+// 参数：
+//   - levels：触发钩子的日志级别，为空时对全部级别触发。
+//   - fn：钩子函数，为 nil 时不添加。
+func (l *LogrusLogger) AddHook(levels []Level, fn func(Entry)) {
+	l.hooks.add(levels, fn)
+}
+
+// withCaller 返回记录调用位置的实例。
+// 调用位置作为 caller 字段输出，不使用 Logrus 的 ReportCaller，后者只能报告本包中的位置。
+func (l *LogrusLogger) withCaller(skip int) Logger {
+	n := *l
+	n.caller = caller{enabled: true, skip: skip}
+	return &n
+}
+
+// withCallerSkip 返回额外跳过 n 个栈帧的实例，没有开启记录调用位置时返回 l 本身。
+func (l *LogrusLogger) withCallerSkip(n int) Logger {
+	if !l.caller.enabled {
+		return l
+	}
+	return l.withCaller(l.caller.skip + n)
+}
+
+// Sync 实现 Logger 接口的缓冲日志写入方法。
+//
+// 返回值：
+//   - error：写入输出目标失败时返回错误，输出到标准输出、标准错误或已经关闭时返回 nil。
+func (l *LogrusLogger) Sync() error {
+	return l.output.sync()
+}
+
+// Close 实现 Logger 接口的资源释放方法，写入缓冲的日志并关闭打开的日志文件与滚动写入器。
+// 通过 WithField 等方法派生的实例共享输出目标，关闭后它们同样不再输出日志。
+//
+// 返回值：
+//   - error：关闭失败时返回错误，重复调用返回第一次关闭的结果。
+func (l *LogrusLogger) Close() error {
+	return l.output.close()
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"reflect"
+
+	"github.com/sirupsen/logrus"
+
+	kitpool "github.com/fsyyft-go/monorepo/kit/pool"
+)
+
+const (
+	// maxChainDepth 是字段链的最大深度，超过时将全部字段合并为一个节点，使输出日志时的遍历开销有上限。
+	maxChainDepth = 16
+	// maxPooledFields 是放回条目池的字段映射的最大字段数，更大的映射直接丢弃，避免池中长期持有大映射。
+	maxPooledFields = 64
+)
+
+var (
+	// entryPool 是输出带字段的日志时使用的条目池，条目的 Data 在放回前清空。
+	// 每条日志都会取出与放回条目，不记录对象池指标，避免在日志的热路径上增加计数。
+	entryPool = kitpool.New(func() *logrus.Entry {
+		return &logrus.Entry{Data: make(logrus.Fields, 8)}
+	}, resetEntry, kitpool.WithMetrics(false))
+)
+
+type (
+	// fieldChain 是 WithField 与 WithFields 添加的字段，按添加的顺序串联成不可变的链。
+	// 派生 Logger 时只创建一个节点，不复制已有的字段；输出日志时才将字段合并到池化的条目中。
+	fieldChain struct {
+		// parent 是先添加的字段，为 nil 时表示链的起点。
+		parent *fieldChain
+		// fields 是本节点添加的字段，同名字段以后添加的为准。
+		fields []field
+		// one 是 WithField 添加的单个字段，fields 指向它，使节点只需要一次内存分配。
+		one [1]field
+		// depth 是从链的起点到本节点的节点数。
+		depth int
+	}
+
+	// field 是一个字段。
+	field struct {
+		// key 是字段名。
+		key string
+		// value 是字段值。
+		value interface{}
+	}
+)
+
+// withField 返回在 c 之后添加一个字段的新链，c 本身不变。
+func (c *fieldChain) withField(key string, value interface{}) *fieldChain {
+	if isFuncValue(value) {
+		return c
+	}
+	n := &fieldChain{parent: c, depth: c.nextDepth()}
+	n.one[0] = field{key: key, value: value}
+	n.fields = n.one[:]
+	return n.compact()
+}
+
+// withFields 返回在 c 之后添加多个字段的新链，c 本身与 fields 均不会被修改。
+func (c *fieldChain) withFields(fields map[string]interface{}) *fieldChain {
+	n := &fieldChain{parent: c, depth: c.nextDepth(), fields: make([]field, 0, len(fields))}
+	for k, v := range fields {
+		if !isFuncValue(v) {
+			n.fields = append(n.fields, field{key: k, value: v})
+		}
+	}
+	if 0 == len(n.fields) {
+		return c
+	}
+	return n.compact()
+}
+
+// nextDepth 返回在 c 之后添加的节点的深度。
+func (c *fieldChain) nextDepth() int {
+	if nil == c {
+		return 1
+	}
+	return c.depth + 1
+}
+
+// compact 在链的深度超过 maxChainDepth 时将全部字段合并为一个节点，否则直接返回 c。
+func (c *fieldChain) compact() *fieldChain {
+	if c.depth <= maxChainDepth {
+		return c
+	}
+	data := make(logrus.Fields, c.depth)
+	c.fill(data)
+	n := &fieldChain{depth: 1, fields: make([]field, 0, len(data))}
+	for k, v := range data {
+		n.fields = append(n.fields, field{key: k, value: v})
+	}
+	return n
+}
+
+// fill 将链上的全部字段按添加的顺序写入 data，同名字段以后添加的为准。
+func (c *fieldChain) fill(data logrus.Fields) {
+	if nil == c {
+		return
+	}
+	c.parent.fill(data)
+	for _, f := range c.fields {
+		data[f.key] = f.value
+	}
+}
+
+// isFuncValue 判断字段值是否为函数，与 logrus 一致，函数类型的字段无法输出，会被忽略。
+func isFuncValue(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	if nil == t {
+		return false
+	}
+	return t.Kind() == reflect.Func || (t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Func)
+}
+
+// getEntry 从条目池中取出一个条目，填入 base 的日志实例与字段链上的全部字段。
+func getEntry(base *logrus.Entry, c *fieldChain) *logrus.Entry {
+	e := entryPool.Get()
+	e.Logger = base.Logger
+	c.fill(e.Data)
+	// 字段链中保存原始值，输出前统一编码，避免重复编码。
+	for k, v := range e.Data {
+		e.Data[k] = encodeField(v)
+	}
+	return e
+}
+
+// putEntry 将条目放回条目池。logrus 输出时会复制条目，放回之后不会再被引用。
+func putEntry(e *logrus.Entry) {
+	entryPool.Put(e)
+}
+
+// resetEntry 在条目放回条目池前清空条目，字段数超过 maxPooledFields 时返回 false 丢弃条目。
+func resetEntry(e *logrus.Entry) bool {
+	if len(e.Data) > maxPooledFields {
+		return false
+	}
+	clear(e.Data)
+	e.Logger = nil
+	return true
+}
+
+// log 输出一条日志，没有字段与调用位置时直接使用基础条目，否则使用池化的条目。
+func (l *LogrusLogger) log(level logrus.Level, args ...interface{}) {
+	if !l.logger.Logger.IsLevelEnabled(level) {
+		return
+	}
+	caller := l.caller.field(2)
+	if nil == l.fields && "" == caller {
+		l.logger.Log(level, args...)
+		return
+	}
+	e := getEntry(l.logger, l.fields)
+	if "" != caller {
+		e.Data[FieldCaller] = caller
+	}
+	e.Log(level, args...)
+	putEntry(e)
+}
+
+// logf 输出一条格式化的日志，没有字段与调用位置时直接使用基础条目，否则使用池化的条目。
+func (l *LogrusLogger) logf(level logrus.Level, format string, args ...interface{}) {
+	if !l.logger.Logger.IsLevelEnabled(level) {
+		return
+	}
+	caller := l.caller.field(2)
+	if nil == l.fields && "" == caller {
+		l.logger.Logf(level, format, args...)
+		return
+	}
+	e := getEntry(l.logger, l.fields)
+	if "" != caller {
+		e.Data[FieldCaller] = caller
+	}
+	e.Logf(level, format, args...)
+	putEntry(e)
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLogrusLogger 创建输出到 w 的 JSON 格式 LogrusLogger。
+func newTestLogrusLogger(w io.Writer, level logrus.Level) *LogrusLogger {
+	l := logrus.New()
+	l.SetOutput(w)
+	l.SetFormatter(&JSONFormatter{})
+	l.SetLevel(level)
+	return &LogrusLogger{logger: logrus.NewEntry(l)}
+}
+
+// decodeLines 将每行 JSON 日志解码为字段映射。
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		m := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &m), line)
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+// TestLogrusLogger_Fields 测试派生 Logger 的字段：后添加的同名字段为准，派生不影响原 Logger 与兄弟 Logger，
+// WithFields 复制传入的映射，函数类型的字段值被忽略。
+func TestLogrusLogger_Fields(t *testing.T) {
+	var buf bytes.Buffer
+	base := newTestLogrusLogger(&buf, logrus.InfoLevel)
+
+	fields := map[string]interface{}{"user": "alice", "fn": func() {}}
+	parent := base.WithField("request_id", "r1").WithFields(fields)
+	fields["user"] = "bob"
+	child := parent.WithField("user", "carol").WithField("cb", func() {})
+	sibling := parent.WithFields(map[string]interface{}{"fn": func() {}})
+
+	base.Info("base")
+	parent.Info("parent")
+	child.Infof("child %d", 1)
+	sibling.Warn("sibling")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 4)
+	assert.NotContains(t, lines[0], "request_id")
+	assert.Equal(t, "r1", lines[1]["request_id"])
+	assert.Equal(t, "alice", lines[1]["user"])
+	assert.NotContains(t, lines[1], "fn")
+	assert.Equal(t, "child 1", lines[2]["msg"])
+	assert.Equal(t, "carol", lines[2]["user"])
+	assert.NotContains(t, lines[2], "cb")
+	assert.Equal(t, "alice", lines[3]["user"])
+	assert.Equal(t, "warning", lines[3]["level"])
+}
+
+// TestLogrusLogger_FieldsCompact 测试字段链超过最大深度时合并，合并前后输出的字段一致。
+func TestLogrusLogger_FieldsCompact(t *testing.T) {
+	var buf bytes.Buffer
+	var l Logger = newTestLogrusLogger(&buf, logrus.InfoLevel)
+	for i := 0; i < 3*maxChainDepth; i++ {
+		l = l.WithField(fmt.Sprintf("k%d", i), i).WithField("last", i)
+	}
+	assert.LessOrEqual(t, l.(*LogrusLogger).fields.depth, maxChainDepth)
+
+	l.Error("compact")
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Len(t, lines[0], 3*maxChainDepth+1+3)
+	assert.Equal(t, float64(3*maxChainDepth-1), lines[0]["last"])
+	assert.Equal(t, float64(0), lines[0]["k0"])
+}
+
+// TestLogrusLogger_DisabledLevel 测试日志级别未启用时不合并字段，也不分配内存。
+func TestLogrusLogger_DisabledLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogrusLogger(&buf, logrus.InfoLevel).WithFields(map[string]interface{}{"a": 1, "b": "x"})
+
+	allocs := testing.AllocsPerRun(100, func() {
+		l.Debug("hidden")
+	})
+	assert.Zero(t, allocs)
+	assert.Zero(t, buf.Len())
+}
+
+// TestLogrusLogger_Concurrent 测试多个协程同时使用派生 Logger 输出，池化的条目之间不会串字段。
+func TestLogrusLogger_Concurrent(t *testing.T) {
+	var buf bytes.Buffer
+	base := newTestLogrusLogger(&syncWriter{w: &buf}, logrus.InfoLevel)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l := base.WithField("worker", i)
+			for j := 0; j < 50; j++ {
+				l.WithField("seq", j).Info("work")
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 8*50)
+	for _, line := range lines {
+		assert.Len(t, line, 5)
+	}
+}
+
+// syncWriter 是并发安全的 io.Writer。
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// BenchmarkLogrusLogger_WithFields 测试每次输出都派生带字段的 Logger 的性能。
+func BenchmarkLogrusLogger_WithFields(b *testing.B) {
+	l := Logger(newTestLogrusLogger(io.Discard, logrus.InfoLevel)).
+		WithFields(map[string]interface{}{"service": "order", "version": "v1.2.3", "region": "cn"})
+
+	b.ReportAllocs()
+	for b.Loop() {
+		l.WithField("request_id", "r-123").WithField("user_id", 42).Info("handled")
+	}
+}
+
+// BenchmarkLogrusEntry_WithFields 作为对照，测试直接使用 logrus.Entry 派生并输出同样字段的性能。
+func BenchmarkLogrusEntry_WithFields(b *testing.B) {
+	e := newTestLogrusLogger(io.Discard, logrus.InfoLevel).logger.
+		WithFields(logrus.Fields{"service": "order", "version": "v1.2.3", "region": "cn"})
+
+	b.ReportAllocs()
+	for b.Loop() {
+		e.WithField("request_id", "r-123").WithField("user_id", 42).Info("handled")
+	}
+}
+
+// BenchmarkLogrusLogger_Derive 测试只派生不输出（例如日志级别未启用）时的性能。
+func BenchmarkLogrusLogger_Derive(b *testing.B) {
+	l := Logger(newTestLogrusLogger(io.Discard, logrus.InfoLevel)).
+		WithFields(map[string]interface{}{"service": "order", "version": "v1.2.3", "region": "cn"})
+
+	b.ReportAllocs()
+	for b.Loop() {
+		l.WithField("request_id", "r-123").WithField("user_id", 42).Debug("hidden")
+	}
+}
+
+// BenchmarkLogrusEntry_Derive 作为对照，测试直接使用 logrus.Entry 只派生不输出时的性能。
+func BenchmarkLogrusEntry_Derive(b *testing.B) {
+	e := newTestLogrusLogger(io.Discard, logrus.InfoLevel).logger.
+		WithFields(logrus.Fields{"service": "order", "version": "v1.2.3", "region": "cn"})
+
+	b.ReportAllocs()
+	for b.Loop() {
+		e.WithField("request_id", "r-123").WithField("user_id", 42).Debug("hidden")
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package logtest 提供在测试中记录并断言日志的 kitlog.Logger 实现。
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+)
+
+type (
+	// Recorder 是把每条日志记录在内存中的 kitlog.Logger 实现，用于断言被测组件输出的日志。
+	// 通过 WithField 等方法派生的实例与原实例共享日志记录、日志级别与钩子。
+	// Fatal 与 Fatalf 只记录日志，不退出进程；Sync 与 Close 不做任何事。Recorder 的所有方法都是并发安全的。
+	Recorder struct {
+		// state 是原实例与派生的实例共享的状态。
+		state *recorderState
+		// fields 是当前实例附加到每条日志的字段。
+		fields map[string]interface{}
+	}
+
+	// recorderState 是 Recorder 与其派生的实例共享的状态。
+	recorderState struct {
+		// mu 保护以下全部字段。
+		mu sync.Mutex
+		// level 是日志级别，低于该级别的日志不被记录。
+		level kitlog.Level
+		// entries 是已经记录的日志。
+		entries []kitlog.Entry
+		// hooks 是通过 AddHook 添加的钩子。
+		hooks []recorderHook
+	}
+
+	// recorderHook 是通过 AddHook 添加的一个钩子。
+	recorderHook struct {
+		// levels 是触发钩子的日志级别，为空时对全部级别触发。
+		levels []kitlog.Level
+		// fn 是钩子函数。
+		fn func(kitlog.Entry)
+	}
+)
+
+var (
+	// 确保 Recorder 实现了 kitlog.Logger 接口。
+	_ kitlog.Logger = (*Recorder)(nil)
+)
+
+// NewRecorder 创建一个日志级别为 DebugLevel 的 Recorder。
+//
+// 返回值：
+//   - *Recorder：没有任何日志记录的实例。
+//
+// 示例：
+//
+//	logger := logtest.NewRecorder()
+//	srv := httpserver.New(handler, httpserver.WithLogger(logger))
+//	// ...
+//	assert.True(t, logger.Contains("开始监听"))
+func NewRecorder() *Recorder {
+	return &Recorder{state: &recorderState{level: kitlog.DebugLevel}}
+}
+
+// Entries 返回按记录顺序排列的全部日志。
+//
+// 返回值：
+//   - []kitlog.Entry：日志的副本，Fields 包含派生实例附加的字段与从 ctx 中提取的字段。
+func (r *Recorder) Entries() []kitlog.Entry {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	return slices.Clone(r.state.entries)
+}
+
+// Messages 返回按记录顺序排列的全部日志，每条日志的格式为 "级别 内容"，例如 "info 开始监听"，不包含字段。
+//
+// 返回值：
+//   - []string：日志的文本。
+func (r *Recorder) Messages() []string {
+	entries := r.Entries()
+	messages := make([]string, 0, len(entries))
+	for _, e := range entries {
+		messages = append(messages, e.Level.String()+" "+e.Message)
+	}
+	return messages
+}
+
+// Contains 检查是否有日志的 "级别 内容" 文本或任意字段的值包含 s。
+//
+// 参数：
+//   - s：要查找的文本。
+//
+// 返回值：
+//   - bool：找到时返回 true。
+func (r *Recorder) Contains(s string) bool {
+	for _, e := range r.Entries() {
+		if strings.Contains(e.Level.String()+" "+e.Message, s) {
+			return true
+		}
+		for _, v := range e.Fields {
+			if strings.Contains(fmt.Sprint(v), s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Reset 清空已经记录的日志，日志级别与钩子保持不变。
+func (r *Recorder) Reset() {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	r.state.entries = nil
+}
+
+// SetLevel 设置日志级别，对原实例与派生的实例都生效。
+func (r *Recorder) SetLevel(level kitlog.Level) {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	r.state.level = level
+}
+
+// GetLevel 返回日志级别。
+func (r *Recorder) GetLevel() kitlog.Level {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	return r.state.level
+}
+
+// Debug 记录调试级别的日志。
+func (r *Recorder) Debug(args ...interface{}) {
+	r.log(kitlog.DebugLevel, fmt.Sprint(args...))
+}
+
+// Debugf 按格式记录调试级别的日志。
+func (r *Recorder) Debugf(format string, args ...interface{}) {
+	r.log(kitlog.DebugLevel, fmt.Sprintf(format, args...))
+}
+
+// Info 记录信息级别的日志。
+func (r *Recorder) Info(args ...interface{}) {
+	r.log(kitlog.InfoLevel, fmt.Sprint(args...))
+}
+
+// Infof 按格式记录信息级别的日志。
+func (r *Recorder) Infof(format string, args ...interface{}) {
+	r.log(kitlog.InfoLevel, fmt.Sprintf(format, args...))
+}
+
+// Warn 记录警告级别的日志。
+func (r *Recorder) Warn(args ...interface{}) {
+	r.log(kitlog.WarnLevel, fmt.Sprint(args...))
+}
+
+// Warnf 按格式记录警告级别的日志。
+func (r *Recorder) Warnf(format string, args ...interface{}) {
+	r.log(kitlog.WarnLevel, fmt.Sprintf(format, args...))
+}
+
+// Error 记录错误级别的日志。
+func (r *Recorder) Error(args ...interface{}) {
+	r.log(kitlog.ErrorLevel, fmt.Sprint(args...))
+}
+
+// Errorf 按格式记录错误级别的日志。
+func (r *Recorder) Errorf(format string, args ...interface{}) {
+	r.log(kitlog.ErrorLevel, fmt.Sprintf(format, args...))
+}
+
+// Fatal 记录致命级别的日志，不退出进程。
+func (r *Recorder) Fatal(args ...interface{}) {
+	r.log(kitlog.FatalLevel, fmt.Sprint(args...))
+}
+
+// Fatalf 按格式记录致命级别的日志，不退出进程。
+func (r *Recorder) Fatalf(format string, args ...interface{}) {
+	r.log(kitlog.FatalLevel, fmt.Sprintf(format, args...))
+}
+
+// DebugContext 记录调试级别的日志，并附加从 ctx 中提取的字段。
+func (r *Recorder) DebugContext(ctx context.Context, args ...interface{}) {
+	r.withContext(ctx).log(kitlog.DebugLevel, fmt.Sprint(args...))
+}
+
+// InfoContext 记录信息级别的日志，并附加从 ctx 中提取的字段。
+func (r *Recorder) InfoContext(ctx context.Context, args ...interface{}) {
+	r.withContext(ctx).log(kitlog.InfoLevel, fmt.Sprint(args...))
+}
+
+// WarnContext 记录警告级别的日志，并附加从 ctx 中提取的字段。
+func (r *Recorder) WarnContext(ctx context.Context, args ...interface{}) {
+	r.withContext(ctx).log(kitlog.WarnLevel, fmt.Sprint(args...))
+}
+
+// ErrorContext 记录错误级别的日志，并附加从 ctx 中提取的字段。
+func (r *Recorder) ErrorContext(ctx context.Context, args ...interface{}) {
+	r.withContext(ctx).log(kitlog.ErrorLevel, fmt.Sprint(args...))
+}
+
+// WithContext 返回附加了从 ctx 中提取的字段的实例。
+func (r *Recorder) WithContext(ctx context.Context) kitlog.Logger {
+	return r.withContext(ctx)
+}
+
+// WithError 返回以 error 字段附加了 err 的实例。
+func (r *Recorder) WithError(err error) kitlog.Logger {
+	return r.with(map[string]interface{}{"error": err})
+}
+
+// WithField 返回附加了一个字段的实例。
+func (r *Recorder) WithField(key string, value interface{}) kitlog.Logger {
+	return r.with(map[string]interface{}{key: value})
+}
+
+// WithFields 返回附加了多个字段的实例。
+func (r *Recorder) WithFields(fields map[string]interface{}) kitlog.Logger {
+	return r.with(fields)
+}
+
+// AddHook 添加记录日志时调用的钩子，levels 为空时对全部级别触发，fn 为 nil 时不添加。
+func (r *Recorder) AddHook(levels []kitlog.Level, fn func(kitlog.Entry)) {
+	if nil == fn {
+		return
+	}
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	r.state.hooks = append(r.state.hooks, recorderHook{levels: slices.Clone(levels), fn: fn})
+}
+
+// Sync 不做任何事，总是返回 nil。
+func (r *Recorder) Sync() error {
+	return nil
+}
+
+// Close 不做任何事，总是返回 nil，关闭后仍然可以记录日志。
+func (r *Recorder) Close() error {
+	return nil
+}
+
+// withContext 返回附加了从 ctx 中提取的字段的实例，没有字段时返回 r 本身。
+func (r *Recorder) withContext(ctx context.Context) *Recorder {
+	fields := kitlog.ContextFields(ctx)
+	if 0 == len(fields) {
+		return r
+	}
+	return r.with(fields)
+}
+
+// with 返回与 r 共享状态、附加了 fields 的实例，fields 中的字段覆盖同名的已有字段。
+func (r *Recorder) with(fields map[string]interface{}) *Recorder {
+	merged := make(map[string]interface{}, len(r.fields)+len(fields))
+	for k, v := range r.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &Recorder{state: r.state, fields: merged}
+}
+
+// log 在级别不低于日志级别时记录一条日志，并在释放锁之后调用匹配的钩子。
+func (r *Recorder) log(level kitlog.Level, message string) {
+	e := kitlog.Entry{Time: time.Now(), Level: level, Message: message, Fields: r.fields}
+
+	r.state.mu.Lock()
+	if level < r.state.level {
+		r.state.mu.Unlock()
+		return
+	}
+	r.state.entries = append(r.state.entries, e)
+	hooks := r.state.hooks
+	r.state.mu.Unlock()
+
+	for _, h := range hooks {
+		if 0 == len(h.levels) || slices.Contains(h.levels, level) {
+			h.fn(e)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package logtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+)
+
+// TestRecorder 测试记录日志、派生实例共享记录以及按文本查找。
+func TestRecorder(t *testing.T) {
+	r := NewRecorder()
+	r.Info("开始监听")
+	r.WithField("addr", "127.0.0.1:8080").Warnf("重试第 %d 次", 2)
+	r.WithError(errors.New("disk full")).Error("写入失败")
+
+	assert.Equal(t, []string{"info 开始监听", "warn 重试第 2 次", "error 写入失败"}, r.Messages())
+	entries := r.Entries()
+	require.Len(t, entries, 3)
+	assert.Nil(t, entries[0].Fields)
+	assert.Equal(t, map[string]interface{}{"addr": "127.0.0.1:8080"}, entries[1].Fields)
+	assert.EqualError(t, entries[2].Fields["error"].(error), "disk full")
+
+	assert.True(t, r.Contains("开始监听"))
+	assert.True(t, r.Contains("warn 重试"))
+	assert.True(t, r.Contains("127.0.0.1"), "字段的值同样参与查找")
+	assert.True(t, r.Contains("disk full"))
+	assert.False(t, r.Contains("不存在"))
+
+	r.Reset()
+	assert.Empty(t, r.Messages())
+}
+
+// TestRecorder_Fields 测试派生实例合并字段且不影响原实例。
+func TestRecorder_Fields(t *testing.T) {
+	r := NewRecorder()
+	a := r.WithFields(map[string]interface{}{"a": 1, "b": 1})
+	a.WithField("b", 2).Info("x")
+	a.Info("y")
+	r.Info("z")
+
+	entries := r.Entries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, map[string]interface{}{"a": 1, "b": 2}, entries[0].Fields)
+	assert.Equal(t, map[string]interface{}{"a": 1, "b": 1}, entries[1].Fields)
+	assert.Nil(t, entries[2].Fields)
+}
+
+// TestRecorder_Context 测试 Context 方法附加从 ctx 中提取的字段。
+func TestRecorder_Context(t *testing.T) {
+	type tenantKey struct{}
+	key := tenantKey{}
+	kitlog.RegisterContextExtractor("logtest", func(ctx context.Context) map[string]interface{} {
+		if v, ok := ctx.Value(key).(string); ok {
+			return map[string]interface{}{"tenant": v}
+		}
+		return nil
+	})
+	t.Cleanup(func() {
+		kitlog.RegisterContextExtractor("logtest", nil)
+	})
+
+	r := NewRecorder()
+	ctx := context.WithValue(context.Background(), key, "acme")
+	r.InfoContext(ctx, "a")
+	r.WithContext(ctx).Debug("b")
+	r.ErrorContext(context.Background(), "c")
+
+	entries := r.Entries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "acme", entries[0].Fields["tenant"])
+	assert.Equal(t, "acme", entries[1].Fields["tenant"])
+	assert.Nil(t, entries[2].Fields)
+}
+
+// TestRecorder_Level 测试低于日志级别的日志不被记录，级别对派生实例同样生效。
+func TestRecorder_Level(t *testing.T) {
+	r := NewRecorder()
+	assert.Equal(t, kitlog.DebugLevel, r.GetLevel())
+
+	child := r.WithField("k", "v")
+	r.SetLevel(kitlog.WarnLevel)
+	child.Info("丢弃")
+	child.Warn("保留")
+	r.Fatal("不退出")
+
+	assert.Equal(t, kitlog.WarnLevel, child.GetLevel())
+	assert.Equal(t, []string{"warn 保留", "fatal 不退出"}, r.Messages())
+	assert.NoError(t, r.Sync())
+	assert.NoError(t, r.Close())
+}
+
+// TestRecorder_Hook 测试钩子按级别触发，并可以在并发记录日志时使用。
+func TestRecorder_Hook(t *testing.T) {
+	r := NewRecorder()
+	var mu sync.Mutex
+	var got []string
+	r.AddHook([]kitlog.Level{kitlog.ErrorLevel}, func(e kitlog.Entry) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, e.Message)
+	})
+	r.AddHook(nil, nil)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Info("info")
+			r.WithField("k", "v").Error("error")
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, r.Entries(), 20)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, got, 10)
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 定义日志指标相关的常量。
+const (
+	// namespace 定义 prometheus 指标的命名空间。
+	namespace = "kit_log"
+)
+
+var (
+	// MetricWriterEntries 用于记录远程日志写入器与 AsyncWriter 处理的日志条数。
+	// 该指标包含以下标签：
+	// - writer: 写入器的名称，例如 elasticsearch、tcp、udp、kafka；AsyncWriter 为 async，只记录 dropped。
+	// - result: 处理结果，sent 表示写入成功，failed 表示被服务端拒绝、重试用尽或超过长度上限，dropped 表示缓冲区已满被丢弃。
+	MetricWriterEntries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "writer",
+		Name:      "entries_total",
+		Help:      "log writer's entries total.",
+	}, []string{"writer", "result"})
+)
+
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricWriterEntries,
+	}
+}
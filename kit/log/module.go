@@ -0,0 +1,61 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"sync"
+)
+
+var (
+	// moduleLoggers 保存按模块名注册的日志实例。
+	moduleLoggers = make(map[string]Logger)
+	// moduleLoggersLock 用于保护 moduleLoggers 的并发访问。
+	moduleLoggersLock sync.RWMutex
+)
+
+// SetModuleLogger 设置模块的日志实例。
+// 每个模块使用独立创建的日志实例时，可以单独调整模块的日志级别，例如通过 LevelWatcher 从配置中心更新。
+//
+// 参数：
+//   - module：模块名，例如 db、http。
+//   - logger：模块的日志实例，为 nil 时删除模块的日志实例。
+//
+// 示例：
+//
+//	dbLogger, _ := log.NewLogger(log.WithLevel(log.WarnLevel))
+//	log.SetModuleLogger("db", dbLogger)
+//	db := kitdb.New(kitdb.WithLogger(log.GetModuleLogger("db")))
+func SetModuleLogger(module string, logger Logger) {
+	moduleLoggersLock.Lock()
+	defer moduleLoggersLock.Unlock()
+	if nil == logger {
+		delete(moduleLoggers, module)
+		return
+	}
+	moduleLoggers[module] = logger
+}
+
+// GetModuleLogger 获取模块的日志实例。
+// 模块没有设置日志实例时返回全局日志实例。
+//
+// 参数：
+//   - module：模块名。
+//
+// 返回值：
+//   - Logger：模块的日志实例。
+func GetModuleLogger(module string) Logger {
+	if logger, ok := lookupModuleLogger(module); ok {
+		return logger
+	}
+	return GetLogger()
+}
+
+// lookupModuleLogger 返回模块设置的日志实例，以及模块是否设置了日志实例。
+func lookupModuleLogger(module string) (Logger, bool) {
+	moduleLoggersLock.RLock()
+	defer moduleLoggersLock.RUnlock()
+	logger, ok := moduleLoggers[module]
+	return logger, ok
+}
@@ -0,0 +1,915 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+const (
+	// otlpScopeName 是日志记录的 InstrumentationScope 名称。
+	otlpScopeName = "github.com/fsyyft-go/monorepo/kit/log"
+	// otlpLogsPath 是 OTLP/HTTP 日志接口的路径。
+	otlpLogsPath = "/v1/logs"
+	// otlpResponseLimit 是读取响应体的字节数上限。
+	otlpResponseLimit = 64 * 1024
+)
+
+// 以下为 OTLPLogger 的默认参数配置。
+var (
+	// otlpEndpointDefault 为未设置地址与环境变量时使用的 Collector 地址。
+	otlpEndpointDefault = "http://localhost:4318"
+	// otlpNameDefault 为发送日志的 ShipperWriter 在指标中的名称。
+	otlpNameDefault = "otlp"
+	// otlpSeverities 为日志级别对应的 OTLP 严重程度。
+	otlpSeverities = map[Level]logspb.SeverityNumber{
+		DebugLevel: logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG,
+		InfoLevel:  logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+		WarnLevel:  logspb.SeverityNumber_SEVERITY_NUMBER_WARN,
+		ErrorLevel: logspb.SeverityNumber_SEVERITY_NUMBER_ERROR,
+		FatalLevel: logspb.SeverityNumber_SEVERITY_NUMBER_FATAL,
+	}
+)
+
+type (
+	// OTLPOption 定义了 OTLPLogger 的配置选项。
+	OTLPOption func(*otlpOptions)
+
+	// otlpOptions 包含 OTLPLogger 的配置。
+	otlpOptions struct {
+		// headers 是每个请求附加的请求头，例如认证信息。
+		headers map[string]string
+		// client 是发送请求使用的 HTTP 客户端。
+		client *http.Client
+		// serviceName 是资源的 service.name 属性，为空时使用环境变量 OTEL_SERVICE_NAME 或进程名。
+		serviceName string
+		// resource 是资源的其他属性。
+		resource map[string]interface{}
+		// level 是初始的日志级别。
+		level Level
+		// clock 是日志时间与发送间隔使用的时钟。
+		clock kittime.Clock
+		// shipper 是发送日志的 ShipperWriter 的配置选项。
+		shipper []ShipperOption
+	}
+
+	// OTLPLogger 实现了 Logger 接口，将日志转换为 OpenTelemetry 的日志记录，通过 OTLP/HTTP 发送到 Collector。
+	// 日志级别转换为 SeverityNumber 与 SeverityText，日志内容作为 Body，结构化字段作为 Attributes；
+	// InfoContext 等方法与 WithContext 将 ctx 中有效的 span 写入 TraceId 与 SpanId。
+	// 日志记录由 ShipperWriter 分批发送，缓冲区已满、重试与指标的行为与 ShipperWriter 相同。
+	OTLPLogger struct {
+		// fields 存储结构化字段信息。
+		fields map[string]interface{}
+		// keys 是按字典序排列的字段名。
+		keys []string
+		// span 是通过 WithContext 添加的 span，无效时不输出 TraceId 与 SpanId。
+		span trace.SpanContext
+		// level 存储当前的日志级别，与通过 WithField 等方法派生的实例共享。
+		level *atomic.Int32
+		// clock 是日志时间使用的时钟。
+		clock kittime.Clock
+		// shipper 发送日志记录，与派生的实例共享。
+		shipper *ShipperWriter
+		// hooks 是通过 AddHook 添加的钩子，与派生的实例共享。
+		hooks *hooks
+		// caller 是记录调用位置的配置。
+		caller caller
+	}
+
+	// otlpTransport 通过 OTLP/HTTP 发送一批日志记录，每条日志是编码后的 LogRecord。
+	otlpTransport struct {
+		// url 是日志接口的地址。
+		url string
+		// headers 是每个请求附加的请求头。
+		headers map[string]string
+		// client 是发送请求使用的 HTTP 客户端。
+		client *http.Client
+		// resource 是编码后的 ResourceLogs 的 resource 字段。
+		resource []byte
+		// scope 是编码后的 ScopeLogs 的 scope 字段。
+		scope []byte
+	}
+)
+
+// WithOTLPHeaders 设置每个请求附加的请求头，例如认证信息。
+//
+// 参数：
+//   - headers：请求头，会被复制。
+//
+// 返回值：
+//   - OTLPOption：配置选项函数。
+func WithOTLPHeaders(headers map[string]string) OTLPOption {
+	return func(o *otlpOptions) {
+		o.headers = make(map[string]string, len(headers))
+		for k, v := range headers {
+			o.headers[k] = v
+		}
+	}
+}
+
+// WithOTLPHTTPClient 设置发送请求使用的 HTTP 客户端，用于配置 TLS 与代理等。
+//
+// 参数：
+//   - client：HTTP 客户端，默认为 http.DefaultClient。
+//
+// 返回值：
+//   - OTLPOption：配置选项函数。
+func WithOTLPHTTPClient(client *http.Client) OTLPOption {
+	return func(o *otlpOptions) {
+		o.client = client
+	}
+}
+
+// WithOTLPServiceName 设置资源的 service.name 属性。
+//
+// 参数：
+//   - name：服务名，默认使用环境变量 OTEL_SERVICE_NAME，未设置时为 unknown_service:进程名。
+//
+// 返回值：
+//   - OTLPOption：配置选项函数。
+func WithOTLPServiceName(name string) OTLPOption {
+	return func(o *otlpOptions) {
+		o.serviceName = name
+	}
+}
+
+// WithOTLPResource 添加资源的属性，例如 deployment.environment 与 service.version。
+//
+// 参数：
+//   - attrs：资源属性，值的转换规则与日志字段相同。
+//
+// 返回值：
+//   - OTLPOption：配置选项函数。
+func WithOTLPResource(attrs map[string]interface{}) OTLPOption {
+	return func(o *otlpOptions) {
+		if nil == o.resource {
+			o.resource = make(map[string]interface{}, len(attrs))
+		}
+		for k, v := range attrs {
+			o.resource[k] = v
+		}
+	}
+}
+
+// WithOTLPLevel 设置初始的日志级别。
+//
+// 参数：
+//   - level：日志级别，默认为 InfoLevel。
+//
+// 返回值：
+//   - OTLPOption：配置选项函数。
+func WithOTLPLevel(level Level) OTLPOption {
+	return func(o *otlpOptions) {
+		o.level = level
+	}
+}
+
+// WithOTLPClock 设置日志时间与发送间隔使用的时钟，测试时可以注入 kit/time 的 FakeClock。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟。
+//
+// 返回值：
+//   - OTLPOption：配置选项函数。
+func WithOTLPClock(clock kittime.Clock) OTLPOption {
+	return func(o *otlpOptions) {
+		o.clock = clock
+	}
+}
+
+// WithOTLPShipperOptions 设置发送日志的 ShipperWriter 的配置选项，例如批量大小、缓冲区、重试策略与超时时间。
+// 指标中写入器的名称默认为 otlp。
+//
+// 参数：
+//   - opts：ShipperWriter 的配置选项，WithShipperDialer 与 WithShipperMaxEntrySize 不起作用。
+//
+// 返回值：
+//   - OTLPOption：配置选项函数。
+func WithOTLPShipperOptions(opts ...ShipperOption) OTLPOption {
+	return func(o *otlpOptions) {
+		o.shipper = append(o.shipper, opts...)
+	}
+}
+
+// NewOTLPLogger 创建通过 OTLP/HTTP 将日志发送到 Collector 的 OTLPLogger，并启动后台发送协程。
+// 不再使用时需要调用 Close，发送缓冲区中剩余的日志。
+//
+// 参数：
+//   - endpoint：Collector 的地址，例如 http://otel-collector:4318，日志发送到该地址的 /v1/logs；
+//     为空时依次使用环境变量 OTEL_EXPORTER_OTLP_LOGS_ENDPOINT（完整的地址）与 OTEL_EXPORTER_OTLP_ENDPOINT，
+//     都未设置时为 http://localhost:4318。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *OTLPLogger：日志实例。
+//   - error：地址无效时返回错误。
+//
+// 示例：
+//
+//	logger, err := log.NewOTLPLogger("http://otel-collector:4318",
+//	    log.WithOTLPServiceName("order"),
+//	    log.WithOTLPHeaders(map[string]string{"Authorization": "Bearer " + token}),
+//	)
+//	if nil != err {
+//	    return err
+//	}
+//	defer logger.Close()
+func NewOTLPLogger(endpoint string, opts ...OTLPOption) (*OTLPLogger, error) {
+	o := &otlpOptions{level: InfoLevel}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if nil == o.client {
+		o.client = http.DefaultClient
+	}
+	o.clock = kittime.OrReal(o.clock)
+
+	u, err := otlpLogsURL(endpoint)
+	if nil != err {
+		return nil, err
+	}
+
+	t := &otlpTransport{url: u, headers: o.headers, client: o.client}
+	t.resource, err = proto.Marshal(otlpResource(o.serviceName, o.resource))
+	if nil != err {
+		return nil, fmt.Errorf("编码 OTLP 资源失败：%w", err)
+	}
+	t.scope, err = proto.Marshal(&commonpb.InstrumentationScope{Name: otlpScopeName})
+	if nil != err {
+		return nil, fmt.Errorf("编码 OTLP 范围失败：%w", err)
+	}
+
+	// 时钟在调用方的配置之前应用，调用方可以单独设置发送间隔使用的时钟。
+	shipperOpts := append([]ShipperOption{WithShipperClock(o.clock)}, o.shipper...)
+	l := &OTLPLogger{
+		fields:  make(map[string]interface{}),
+		level:   new(atomic.Int32),
+		clock:   o.clock,
+		shipper: newShipperWriter(t, newShipperOptions(otlpNameDefault, 0, shipperOpts)),
+		hooks:   newHooks(),
+	}
+	l.level.Store(int32(o.level))
+	return l, nil
+}
+
+// SetLevel 实现 Logger 接口的日志级别设置方法。
+// 通过 WithField 等方法派生的实例共享日志级别，可以在记录日志的同时并发调用。
+//
+// 参数：
+//   - level：要设置的日志级别。
+func (l *OTLPLogger) SetLevel(level Level) {
+	l.level.Store(int32(level))
+}
+
+// GetLevel 实现 Logger 接口的日志级别获取方法。
+//
+// 返回值：
+//   - Level：返回当前日志记录器的日志级别。
+func (l *OTLPLogger) GetLevel() Level {
+	return Level(l.level.Load())
+}
+
+// emit 将一条日志转换为日志记录放入发送缓冲区，日志级别未启用时直接返回。
+//
+// 参数：
+//   - ctx：携带 span 与请求标识等信息的上下文，为 nil 时只使用实例的字段。
+//   - level：日志级别。
+//   - body：日志内容。
+func (l *OTLPLogger) emit(ctx context.Context, level Level, body func() string) {
+	if level < l.GetLevel() {
+		return
+	}
+
+	now, msg := l.clock.Now(), body()
+	r := &logspb.LogRecord{
+		TimeUnixNano:         uint64(now.UnixNano()),
+		ObservedTimeUnixNano: uint64(now.UnixNano()),
+		SeverityNumber:       otlpSeverities[level],
+		SeverityText:         strings.ToUpper(level.String()),
+		Body:                 otlpString(msg),
+	}
+
+	// ContextFields 每次返回新的映射，调用位置可以直接加入其中。
+	extracted := ContextFields(ctx)
+	if caller := l.caller.field(2); "" != caller {
+		if nil == extracted {
+			extracted = make(map[string]interface{}, 1)
+		}
+		extracted[FieldCaller] = caller
+	}
+	fields, keys := l.fields, l.keys
+	if 0 != len(extracted) {
+		fields = make(map[string]interface{}, len(l.fields)+len(extracted))
+		for k, v := range l.fields {
+			fields[k] = v
+		}
+		for k, v := range extracted {
+			fields[k] = v
+		}
+		keys = sortedKeys(fields)
+	}
+
+	span := l.span
+	if nil != ctx {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			span = sc
+		}
+	}
+	if span.IsValid() {
+		traceID, spanID := span.TraceID(), span.SpanID()
+		r.TraceId, r.SpanId = traceID[:], spanID[:]
+		r.Flags = uint32(span.TraceFlags())
+	}
+
+	r.Attributes = make([]*commonpb.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		// 链路标识已经写入 TraceId 与 SpanId，不再重复作为属性。
+		if span.IsValid() && (FieldTraceID == k || FieldSpanID == k) {
+			continue
+		}
+		r.Attributes = append(r.Attributes, &commonpb.KeyValue{Key: validUTF8(k), Value: otlpValue(encodeField(fields[k]))})
+	}
+
+	data, err := proto.Marshal(r)
+	if nil != err {
+		l.shipper.handleError(fmt.Errorf("编码 OTLP 日志记录失败：%w", err))
+		return
+	}
+	// 关闭后的日志被丢弃，与其他日志实现一致。
+	_ = l.shipper.enqueue(data)
+	if l.hooks.enabled(level) {
+		l.hooks.fire(Entry{Time: now, Level: level, Message: msg, Fields: hookFields(fields)})
+	}
+}
+
+// Debug 实现 Logger 接口的调试级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTLPLogger) Debug(args ...interface{}) {
+	l.emit(nil, DebugLevel, func() string { return fmt.Sprint(args...) })
+}
+
+// Debugf 实现 Logger 接口的格式化调试级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *OTLPLogger) Debugf(format string, args ...interface{}) {
+	l.emit(nil, DebugLevel, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Info 实现 Logger 接口的信息级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTLPLogger) Info(args ...interface{}) {
+	l.emit(nil, InfoLevel, func() string { return fmt.Sprint(args...) })
+}
+
+// Infof 实现 Logger 接口的格式化信息级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *OTLPLogger) Infof(format string, args ...interface{}) {
+	l.emit(nil, InfoLevel, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Warn 实现 Logger 接口的警告级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTLPLogger) Warn(args ...interface{}) {
+	l.emit(nil, WarnLevel, func() string { return fmt.Sprint(args...) })
+}
+
+// Warnf 实现 Logger 接口的格式化警告级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *OTLPLogger) Warnf(format string, args ...interface{}) {
+	l.emit(nil, WarnLevel, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Error 实现 Logger 接口的错误级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTLPLogger) Error(args ...interface{}) {
+	l.emit(nil, ErrorLevel, func() string { return fmt.Sprint(args...) })
+}
+
+// Errorf 实现 Logger 接口的格式化错误级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *OTLPLogger) Errorf(format string, args ...interface{}) {
+	l.emit(nil, ErrorLevel, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Fatal 实现 Logger 接口的致命错误级别日志记录。
+// 发送缓冲区中的日志后以状态码 1 退出。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTLPLogger) Fatal(args ...interface{}) {
+	l.emit(nil, FatalLevel, func() string { return fmt.Sprint(args...) })
+	_ = l.Sync()
+	os.Exit(1)
+}
+
+// Fatalf 实现 Logger 接口的格式化致命错误级别日志记录。
+// 发送缓冲区中的日志后以状态码 1 退出。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *OTLPLogger) Fatalf(format string, args ...interface{}) {
+	l.emit(nil, FatalLevel, func() string { return fmt.Sprintf(format, args...) })
+	_ = l.Sync()
+	os.Exit(1)
+}
+
+// DebugContext 实现 Logger 接口的调试级别日志记录，并添加从 ctx 中提取的字段与 span。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTLPLogger) DebugContext(ctx context.Context, args ...interface{}) {
+	l.emit(ctx, DebugLevel, func() string { return fmt.Sprint(args...) })
+}
+
+// InfoContext 实现 Logger 接口的信息级别日志记录，并添加从 ctx 中提取的字段与 span。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTLPLogger) InfoContext(ctx context.Context, args ...interface{}) {
+	l.emit(ctx, InfoLevel, func() string { return fmt.Sprint(args...) })
+}
+
+// WarnContext 实现 Logger 接口的警告级别日志记录，并添加从 ctx 中提取的字段与 span。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTLPLogger) WarnContext(ctx context.Context, args ...interface{}) {
+	l.emit(ctx, WarnLevel, func() string { return fmt.Sprint(args...) })
+}
+
+// ErrorContext 实现 Logger 接口的错误级别日志记录，并添加从 ctx 中提取的字段与 span。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTLPLogger) ErrorContext(ctx context.Context, args ...interface{}) {
+	l.emit(ctx, ErrorLevel, func() string { return fmt.Sprint(args...) })
+}
+
+// WithContext 实现 Logger 接口的 context 字段添加方法，ctx 中有效的 span 写入之后每条日志的 TraceId 与 SpanId。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//
+// 返回值：
+//   - Logger：返回一个包含提取的字段与 span 的新 Logger 实例，没有可提取的字段与 span 时返回原实例。
+func (l *OTLPLogger) WithContext(ctx context.Context) Logger {
+	fields := ContextFields(ctx)
+	var span trace.SpanContext
+	if nil != ctx {
+		span = trace.SpanContextFromContext(ctx)
+	}
+	if 0 == len(fields) && !span.IsValid() {
+		return l
+	}
+	derived := l.derive(fields)
+	if span.IsValid() {
+		derived.span = span
+	}
+	return derived
+}
+
+// WithError 实现 Logger 接口的错误字段添加方法。
+//
+// 参数：
+//   - err：要记录的错误。
+//
+// 返回值：
+//   - Logger：返回一个包含错误信息、错误类型与调用堆栈的新 Logger 实例，err 为 nil 时返回原实例。
+func (l *OTLPLogger) WithError(err error) Logger {
+	if nil == err {
+		return l
+	}
+	return l.derive(errorFields(err))
+}
+
+// WithField 实现 Logger 接口的单字段添加方法。
+//
+// 参数：
+//   - key：字段名。
+//   - value：字段值。
+//
+// 返回值：
+//   - Logger：返回一个包含新字段的新 Logger 实例。
+func (l *OTLPLogger) WithField(key string, value interface{}) Logger {
+	return l.derive(map[string]interface{}{key: value})
+}
+
+// WithFields 实现 Logger 接口的多字段添加方法。
+//
+// 参数：
+//   - fields：要添加的字段映射。
+//
+// 返回值：
+//   - Logger：返回一个包含所有字段的新 Logger 实例。
+func (l *OTLPLogger) WithFields(fields map[string]interface{}) Logger {
+	return l.derive(fields)
+}
+
+// derive 返回添加了 fields 的新实例，与 l 共享日志级别与发送协程。
+func (l *OTLPLogger) derive(fields map[string]interface{}) *OTLPLogger {
+	newFields := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		newFields[k] = v
+	}
+	for k, v := range fields {
+		newFields[k] = v
+	}
+	return &OTLPLogger{
+		fields:  newFields,
+		keys:    sortedKeys(newFields),
+		span:    l.span,
+		level:   l.level,
+		clock:   l.clock,
+		shipper: l.shipper,
+		hooks:   l.hooks,
+		caller:  l.caller,
+	}
+}
+
+// AddHook 实现 Logger 接口的钩子添加方法，钩子在日志记录放入发送缓冲区之后调用。
+//
+// 参数：
+//   - levels：触发钩子的日志级别，为空时对全部级别触发。
+//   - fn：钩子函数，为 nil 时不添加。
+func (l *OTLPLogger) AddHook(levels []Level, fn func(Entry)) {
+	l.hooks.add(levels, fn)
+}
+
+// withCaller 返回记录调用位置的实例。
+func (l *OTLPLogger) withCaller(skip int) Logger {
+	n := *l
+	n.caller = caller{enabled: true, skip: skip}
+	return &n
+}
+
+// withCallerSkip 返回额外跳过 n 个栈帧的实例，没有开启记录调用位置时返回 l 本身。
+func (l *OTLPLogger) withCallerSkip(n int) Logger {
+	if !l.caller.enabled {
+		return l
+	}
+	return l.withCaller(l.caller.skip + n)
+}
+
+// Sync 实现 Logger 接口的缓冲日志写入方法，发送缓冲区中的全部日志（包括重试）后返回。
+//
+// 返回值：
+//   - error：总是返回 nil，发送失败交给 WithShipperErrorHandler 设置的错误处理函数。
+func (l *OTLPLogger) Sync() error {
+	return l.shipper.Sync()
+}
+
+// Close 实现 Logger 接口的资源释放方法，发送缓冲区中剩余的日志并停止后台发送协程。
+// 通过 WithField 等方法派生的实例共享发送协程，关闭后它们同样不再发送日志。
+//
+// 返回值：
+//   - error：总是返回 nil，重复调用是安全的。
+func (l *OTLPLogger) Close() error {
+	return l.shipper.Close()
+}
+
+// Send 将一批编码后的日志记录组装为 ExportLogsServiceRequest 发送到 Collector。
+// 429、502、503 与 504 按退避策略重试，其他失败的状态码与部分成功中被拒绝的日志不再重试。
+func (t *otlpTransport) Send(ctx context.Context, entries [][]byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(t.encode(entries)))
+	if nil != err {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := t.client.Do(req)
+	if nil != err {
+		return 0, err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+	data, err := io.ReadAll(io.LimitReader(resp.Body, otlpResponseLimit))
+	if nil != err {
+		return 0, err
+	}
+
+	switch code := resp.StatusCode; {
+	case code >= http.StatusOK && code < http.StatusMultipleChoices:
+		rejected, message := otlpPartialSuccess(data)
+		if rejected <= 0 {
+			return len(entries), nil
+		}
+		rejected = min(rejected, int64(len(entries)))
+		return len(entries) - int(rejected), fmt.Errorf("%w：%d 条日志记录被 Collector 拒绝：%s", ErrShipperRejected, rejected, message)
+	case otlpRetryableStatus(code):
+		return 0, fmt.Errorf("OTLP 请求返回状态码 %d", code)
+	default:
+		return 0, fmt.Errorf("%w：OTLP 请求返回状态码 %d：%s", ErrShipperRejected, code, truncate(data, 256))
+	}
+}
+
+// Close 不关闭调用方提供的 HTTP 客户端，直接返回 nil。
+func (t *otlpTransport) Close() error {
+	return nil
+}
+
+// encode 将编码后的日志记录组装为 ExportLogsServiceRequest，即只包含一个 ResourceLogs 与一个 ScopeLogs 的请求。
+// 请求与 LogsData 的编码相同，日志记录在记录日志时已经编码，这里只拼接字段，不再编码每条日志记录。
+func (t *otlpTransport) encode(entries [][]byte) []byte {
+	scope := protowire.AppendTag(nil, 1, protowire.BytesType)
+	scope = protowire.AppendBytes(scope, t.scope)
+	for _, e := range entries {
+		scope = protowire.AppendTag(scope, 2, protowire.BytesType)
+		scope = protowire.AppendBytes(scope, e)
+	}
+
+	resource := protowire.AppendTag(nil, 1, protowire.BytesType)
+	resource = protowire.AppendBytes(resource, t.resource)
+	resource = protowire.AppendTag(resource, 2, protowire.BytesType)
+	resource = protowire.AppendBytes(resource, scope)
+
+	request := protowire.AppendTag(nil, 1, protowire.BytesType)
+	return protowire.AppendBytes(request, resource)
+}
+
+// otlpLogsURL 返回日志接口的地址。
+//
+// 参数：
+//   - endpoint：Collector 的地址，为空时使用环境变量或默认地址。
+//
+// 返回值：
+//   - string：日志接口的地址。
+//   - error：地址无效时返回错误。
+func otlpLogsURL(endpoint string) (string, error) {
+	full := false
+	if "" == endpoint {
+		if v := os.Getenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"); "" != v {
+			endpoint, full = v, true
+		} else if v := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); "" != v {
+			endpoint = v
+		} else {
+			endpoint = otlpEndpointDefault
+		}
+	}
+
+	u, err := url.Parse(endpoint)
+	if nil != err || ("http" != u.Scheme && "https" != u.Scheme) || "" == u.Host {
+		return "", fmt.Errorf("无效的 OTLP 地址：%q", endpoint)
+	}
+	if full {
+		return endpoint, nil
+	}
+	return strings.TrimRight(endpoint, "/") + otlpLogsPath, nil
+}
+
+// otlpResource 返回包含 service.name 与 attrs 的资源，属性按名称排序。
+func otlpResource(serviceName string, attrs map[string]interface{}) *resourcepb.Resource {
+	if "" == serviceName {
+		serviceName = os.Getenv("OTEL_SERVICE_NAME")
+	}
+	if "" == serviceName {
+		serviceName = "unknown_service:" + filepath.Base(os.Args[0])
+	}
+	all := map[string]interface{}{"service.name": serviceName}
+	for k, v := range attrs {
+		all[k] = v
+	}
+	r := &resourcepb.Resource{}
+	for _, k := range sortedKeys(all) {
+		r.Attributes = append(r.Attributes, &commonpb.KeyValue{Key: validUTF8(k), Value: otlpValue(encodeField(all[k]))})
+	}
+	return r
+}
+
+// otlpValue 将经过字段编码器转换的值转换为 OTLP 的 AnyValue。
+// 字符串、布尔、整数、浮点数、字节切片、map 与切片转换为对应的类型，超出 int64 的无符号整数转换为字符串；
+// 其他类型按 JSON 编码的结果转换，与 JSON 格式的输出一致，无法 JSON 编码时使用 fmt 的格式化结果。
+//
+// 参数：
+//   - v：字段值。
+//
+// 返回值：
+//   - *commonpb.AnyValue：OTLP 的值，v 为 nil 时为空值。
+func otlpValue(v interface{}) *commonpb.AnyValue {
+	switch v := v.(type) {
+	case nil:
+		return &commonpb.AnyValue{}
+	case string:
+		return otlpString(v)
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+	case int:
+		return otlpInt(int64(v))
+	case int8:
+		return otlpInt(int64(v))
+	case int16:
+		return otlpInt(int64(v))
+	case int32:
+		return otlpInt(int64(v))
+	case int64:
+		return otlpInt(v)
+	case uint:
+		return otlpUint(uint64(v))
+	case uint8:
+		return otlpInt(int64(v))
+	case uint16:
+		return otlpInt(int64(v))
+	case uint32:
+		return otlpInt(int64(v))
+	case uint64:
+		return otlpUint(v)
+	case float32:
+		return otlpDouble(float64(v))
+	case float64:
+		return otlpDouble(v)
+	case []byte:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v}}
+	case time.Time:
+		return otlpString(v.Format(time.RFC3339Nano))
+	case map[string]interface{}:
+		kvs := make([]*commonpb.KeyValue, 0, len(v))
+		for _, k := range sortedKeys(v) {
+			kvs = append(kvs, &commonpb.KeyValue{Key: validUTF8(k), Value: otlpValue(encodeField(v[k]))})
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: kvs}}}
+	case []interface{}:
+		values := make([]*commonpb.AnyValue, 0, len(v))
+		for _, e := range v {
+			values = append(values, otlpValue(encodeField(e)))
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}
+	}
+
+	data, err := stdjson.Marshal(v)
+	if nil != err {
+		return otlpString(fmt.Sprint(v))
+	}
+	d := stdjson.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var decoded interface{}
+	if err := d.Decode(&decoded); nil != err {
+		return otlpString(string(data))
+	}
+	return otlpJSONValue(decoded)
+}
+
+// otlpJSONValue 将 JSON 解码的值转换为 OTLP 的 AnyValue，不再经过字段编码器。
+func otlpJSONValue(v interface{}) *commonpb.AnyValue {
+	switch v := v.(type) {
+	case stdjson.Number:
+		if i, err := v.Int64(); nil == err {
+			return otlpInt(i)
+		}
+		f, _ := v.Float64()
+		return otlpDouble(f)
+	case map[string]interface{}:
+		kvs := make([]*commonpb.KeyValue, 0, len(v))
+		for _, k := range sortedKeys(v) {
+			kvs = append(kvs, &commonpb.KeyValue{Key: validUTF8(k), Value: otlpJSONValue(v[k])})
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: kvs}}}
+	case []interface{}:
+		values := make([]*commonpb.AnyValue, 0, len(v))
+		for _, e := range v {
+			values = append(values, otlpJSONValue(e))
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}
+	default:
+		// 字符串、布尔与 nil。
+		return otlpValue(v)
+	}
+}
+
+// otlpString 返回字符串值，无效的 UTF-8 字节替换为 U+FFFD，否则日志记录无法编码。
+func otlpString(s string) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: validUTF8(s)}}
+}
+
+// otlpInt 返回整数值。
+func otlpInt(i int64) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: i}}
+}
+
+// otlpUint 返回无符号整数值，超出 int64 时返回字符串值。
+func otlpUint(u uint64) *commonpb.AnyValue {
+	if u > math.MaxInt64 {
+		return otlpString(fmt.Sprint(u))
+	}
+	return otlpInt(int64(u))
+}
+
+// otlpDouble 返回浮点数值。
+func otlpDouble(f float64) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: f}}
+}
+
+// validUTF8 将 s 中无效的 UTF-8 字节替换为 U+FFFD。
+func validUTF8(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	return strings.ToValidUTF8(s, "�")
+}
+
+// otlpPartialSuccess 解析 ExportLogsServiceResponse 中部分成功的结果。
+//
+// 参数：
+//   - data：protobuf 编码的响应体，无法解析时视为全部成功。
+//
+// 返回值：
+//   - int64：被拒绝的日志记录条数。
+//   - string：Collector 给出的原因。
+func otlpPartialSuccess(data []byte) (int64, string) {
+	partial := otlpField(data, 1, protowire.BytesType)
+	if nil == partial {
+		return 0, ""
+	}
+	var (
+		rejected int64
+		message  string
+	)
+	if v := otlpField(partial, 1, protowire.VarintType); nil != v {
+		if n, l := protowire.ConsumeVarint(v); l > 0 {
+			rejected = int64(n)
+		}
+	}
+	if v := otlpField(partial, 2, protowire.BytesType); nil != v {
+		message = string(v)
+	}
+	return rejected, message
+}
+
+// otlpField 返回 protobuf 消息中最后一个编号为 num 的字段：BytesType 返回内容，VarintType 返回编码后的值。
+// 没有该字段或消息无法解析时返回 nil。
+func otlpField(data []byte, num protowire.Number, typ protowire.Type) []byte {
+	var found []byte
+	for 0 != len(data) {
+		n, t, l := protowire.ConsumeTag(data)
+		if l < 0 {
+			return nil
+		}
+		data = data[l:]
+		size := protowire.ConsumeFieldValue(n, t, data)
+		if size < 0 {
+			return nil
+		}
+		if num == n && typ == t {
+			found = data[:size]
+			if protowire.BytesType == t {
+				found, _ = protowire.ConsumeBytes(found)
+			}
+		}
+		data = data[size:]
+	}
+	return found
+}
+
+// otlpRetryableStatus 判断状态码是否表示可以重试，OTLP/HTTP 规定 429、502、503 与 504 可以重试。
+func otlpRetryableStatus(code int) bool {
+	return slices.Contains([]int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}, code)
+}
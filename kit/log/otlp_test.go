@@ -0,0 +1,281 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+type (
+	// otlpServer 是记录 OTLP 请求的测试 Collector，按顺序使用 responses 中的处理函数，用完后总是返回成功。
+	otlpServer struct {
+		*httptest.Server
+
+		mu        sync.Mutex
+		requests  []*http.Request
+		bodies    []*logspb.LogsData
+		responses []func(w http.ResponseWriter)
+	}
+)
+
+// newOTLPServer 创建测试 Collector，测试结束时关闭。
+func newOTLPServer(t *testing.T, responses ...func(w http.ResponseWriter)) *otlpServer {
+	s := &otlpServer{responses: responses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		// ExportLogsServiceRequest 与 LogsData 的编码相同。
+		body := &logspb.LogsData{}
+		if err := proto.Unmarshal(data, body); nil != err {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, body)
+		var respond func(w http.ResponseWriter)
+		if len(s.responses) > 0 {
+			respond, s.responses = s.responses[0], s.responses[1:]
+		}
+		s.mu.Unlock()
+
+		if nil != respond {
+			respond(w)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// records 返回全部请求中的日志记录。
+func (s *otlpServer) records() []*logspb.LogRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var records []*logspb.LogRecord
+	for _, body := range s.bodies {
+		for _, rl := range body.ResourceLogs {
+			for _, sl := range rl.ScopeLogs {
+				records = append(records, sl.LogRecords...)
+			}
+		}
+	}
+	return records
+}
+
+// otlpAttrs 将属性转换为 map，便于断言。
+func otlpAttrs(kvs []*commonpb.KeyValue) map[string]*commonpb.AnyValue {
+	attrs := make(map[string]*commonpb.AnyValue, len(kvs))
+	for _, kv := range kvs {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+// partialSuccess 返回响应部分成功的处理函数。
+func partialSuccess(rejected int64, message string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		partial := protowire.AppendTag(nil, 1, protowire.VarintType)
+		partial = protowire.AppendVarint(partial, uint64(rejected))
+		partial = protowire.AppendTag(partial, 2, protowire.BytesType)
+		partial = protowire.AppendString(partial, message)
+		resp := protowire.AppendTag(nil, 1, protowire.BytesType)
+		_, _ = w.Write(protowire.AppendBytes(resp, partial))
+	}
+}
+
+// TestNewLogger_OTLP 测试日志级别、字段、时间与 span 转换为 OTLP 日志记录，并附带资源、范围与请求头。
+func TestNewLogger_OTLP(t *testing.T) {
+	srv := newOTLPServer(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	logger, err := NewLogger(
+		WithLogType(LogTypeOTLP),
+		WithClock(kittime.NewFakeClock(now)),
+		WithOTLP(srv.URL+"/",
+			WithOTLPServiceName("order"),
+			WithOTLPResource(map[string]interface{}{"deployment.environment": "test"}),
+			WithOTLPHeaders(map[string]string{"Authorization": "Bearer token"}),
+		),
+	)
+	require.NoError(t, err)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3},
+		SpanID:     trace.SpanID{4, 5, 6},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	logger.Debug("hidden")
+	logger.WithField("user", "alice").WithError(errors.New("boom")).InfoContext(ctx, "hello ", 42)
+	logger.WithContext(ctx).Warnf("slow %dms", 300)
+	logger.Error("plain")
+	require.NoError(t, logger.Sync())
+
+	srv.mu.Lock()
+	require.Len(t, srv.requests, 1)
+	r, body := srv.requests[0], srv.bodies[0]
+	srv.mu.Unlock()
+	assert.Equal(t, "/v1/logs", r.URL.Path)
+	assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+	require.Len(t, body.ResourceLogs, 1)
+	resource := otlpAttrs(body.ResourceLogs[0].Resource.Attributes)
+	assert.Equal(t, "order", resource["service.name"].GetStringValue())
+	assert.Equal(t, "test", resource["deployment.environment"].GetStringValue())
+	require.Len(t, body.ResourceLogs[0].ScopeLogs, 1)
+	assert.Equal(t, otlpScopeName, body.ResourceLogs[0].ScopeLogs[0].Scope.Name)
+
+	records := srv.records()
+	require.Len(t, records, 3)
+	info := records[0]
+	assert.Equal(t, uint64(now.UnixNano()), info.TimeUnixNano)
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_INFO, info.SeverityNumber)
+	assert.Equal(t, "INFO", info.SeverityText)
+	assert.Equal(t, "hello 42", info.Body.GetStringValue())
+	assert.Equal(t, sc.TraceID().String(), trace.TraceID(info.TraceId).String())
+	assert.Equal(t, sc.SpanID().String(), trace.SpanID(info.SpanId).String())
+	assert.Equal(t, uint32(trace.FlagsSampled), info.Flags)
+	attrs := otlpAttrs(info.Attributes)
+	assert.Equal(t, "alice", attrs["user"].GetStringValue())
+	assert.Equal(t, "boom", attrs[FieldError].GetStringValue())
+	assert.Equal(t, "*errors.errorString", attrs[FieldErrorType].GetStringValue())
+	// 链路标识只写入 TraceId 与 SpanId。
+	assert.NotContains(t, attrs, FieldTraceID)
+	assert.NotContains(t, attrs, FieldSpanID)
+
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_WARN, records[1].SeverityNumber)
+	assert.Equal(t, "slow 300ms", records[1].Body.GetStringValue())
+	assert.Equal(t, info.TraceId, records[1].TraceId)
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_ERROR, records[2].SeverityNumber)
+	assert.Empty(t, records[2].TraceId)
+	assert.Empty(t, records[2].Attributes)
+
+	require.NoError(t, logger.Close())
+	logger.Info("after close")
+	assert.Len(t, srv.records(), 3)
+}
+
+// TestOTLPLogger_Retry 测试 503 被重试，400 与部分成功中被拒绝的日志记录不再重试并报告错误。
+func TestOTLPLogger_Retry(t *testing.T) {
+	entries := entriesSince(t.Name())
+	srv := newOTLPServer(t,
+		func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) },
+		partialSuccess(1, "too old"),
+		func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadRequest) },
+	)
+	var errs []error
+	logger, err := NewOTLPLogger(srv.URL, WithOTLPShipperOptions(
+		WithShipperName(t.Name()),
+		WithShipperErrorHandler(func(err error) { errs = append(errs, err) }),
+		fastShipperBackoff(),
+	))
+	require.NoError(t, err)
+
+	logger.Info("a")
+	logger.Info("b")
+	require.NoError(t, logger.Sync())
+	logger.Info("c")
+	require.NoError(t, logger.Close())
+
+	srv.mu.Lock()
+	assert.Len(t, srv.requests, 3)
+	srv.mu.Unlock()
+	assert.Equal(t, float64(1), entries("sent"))
+	assert.Equal(t, float64(2), entries("failed"))
+	require.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], ErrShipperRejected)
+	assert.Contains(t, errs[0].Error(), "too old")
+	assert.Contains(t, errs[1].Error(), "状态码 400")
+}
+
+// TestOTLPValue 测试字段值转换为 OTLP 的 AnyValue。
+func TestOTLPValue(t *testing.T) {
+	type point struct {
+		X int `json:"x"`
+	}
+	kv := func(key string, v *commonpb.AnyValue) *commonpb.KeyValue {
+		return &commonpb.KeyValue{Key: key, Value: v}
+	}
+	kvlist := func(kvs ...*commonpb.KeyValue) *commonpb.AnyValue {
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: kvs}}}
+	}
+	tests := []struct {
+		name  string
+		value interface{}
+		want  *commonpb.AnyValue
+	}{
+		{"nil", nil, &commonpb.AnyValue{}},
+		{"string", "a", otlpString("a")},
+		{"invalid utf8", "a\xffb", otlpString("a�b")},
+		{"bool", true, &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: true}}},
+		{"int", int32(-3), otlpInt(-3)},
+		{"uint", uint(7), otlpInt(7)},
+		{"uint overflow", uint64(math.MaxUint64), otlpString("18446744073709551615")},
+		{"float", 1.5, otlpDouble(1.5)},
+		{"bytes", []byte{1, 2}, &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: []byte{1, 2}}}},
+		{"time", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), otlpString("2025-01-02T03:04:05Z")},
+		{"map", map[string]interface{}{"b": 1, "a": "x"}, kvlist(kv("a", otlpString("x")), kv("b", otlpInt(1)))},
+		{"slice", []interface{}{"x", 2}, &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{
+			Values: []*commonpb.AnyValue{otlpString("x"), otlpInt(2)},
+		}}}},
+		{"struct", point{X: 3}, kvlist(kv("x", otlpInt(3)))},
+		{"duration", encodeField(1500 * time.Millisecond), kvlist(kv("ms", otlpInt(1500)), kv("text", otlpString("1.5s")))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, proto.Equal(tt.want, otlpValue(tt.value)), "%v", otlpValue(tt.value))
+		})
+	}
+
+	// 无法 JSON 编码的值使用 fmt 的格式化结果。
+	assert.NotEmpty(t, otlpValue(func() {}).GetStringValue())
+}
+
+// TestOTLPLogsURL 测试日志接口地址的解析与环境变量。
+func TestOTLPLogsURL(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+
+	u, err := otlpLogsURL("")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:4318/v1/logs", u)
+	u, err = otlpLogsURL("https://collector:4318/prefix/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://collector:4318/prefix/v1/logs", u)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://base:4318")
+	u, err = otlpLogsURL("")
+	require.NoError(t, err)
+	assert.Equal(t, "http://base:4318/v1/logs", u)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", "http://logs:4318/custom")
+	u, err = otlpLogsURL("")
+	require.NoError(t, err)
+	assert.Equal(t, "http://logs:4318/custom", u)
+
+	for _, endpoint := range []string{"collector:4318", "ftp://collector", "http://"} {
+		_, err := NewOTLPLogger(endpoint)
+		assert.Error(t, err, endpoint)
+	}
+}
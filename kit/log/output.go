@@ -0,0 +1,173 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// output 是日志实例的输出目标，与通过 WithField 等方法派生的实例共享。
+	// 记录日志实例自身打开的资源（日志文件、滚动写入器与 AsyncWriter），Close 时按由外到内的顺序关闭；
+	// 调用方传入的写入器不由日志实例关闭。
+	output struct {
+		// w 是日志写入的目标。
+		w io.Writer
+		// closers 是日志实例打开的资源，按由外到内的顺序排列。
+		closers []io.Closer
+		// children 是同时输出的多个目标，w 依次写入它们，Sync 与 Close 作用于每一个目标。
+		children []*output
+		// once 保证资源只关闭一次。
+		once sync.Once
+		// closed 表示资源是否已经关闭，关闭后 sync 不再调用输出目标。
+		closed atomic.Bool
+		// err 是关闭资源时返回的错误。
+		err error
+	}
+
+	// syncer 是可以将缓冲的日志写入输出目标的写入器，例如 AsyncWriter、ElasticsearchWriter 与 *os.File。
+	syncer interface {
+		Sync() error
+	}
+)
+
+// newOutput 创建写入 w 的输出目标。
+//
+// 参数：
+//   - w：日志写入的目标。
+//   - owned：w 是否由日志实例打开，为 true 且 w 实现了 io.Closer 时，Close 关闭 w。
+//
+// 返回值：
+//   - *output：输出目标。
+func newOutput(w io.Writer, owned bool) *output {
+	o := &output{w: w}
+	if c, ok := w.(io.Closer); ok && owned {
+		o.closers = append(o.closers, c)
+	}
+	return o
+}
+
+// newMultiOutput 创建依次写入 outs 的输出目标，outs 只有一个时直接返回该目标。
+//
+// 参数：
+//   - outs：输出目标列表，不能为空。
+//
+// 返回值：
+//   - *output：输出目标。
+func newMultiOutput(outs []*output) *output {
+	if 1 == len(outs) {
+		return outs[0]
+	}
+	ws := make([]io.Writer, 0, len(outs))
+	for _, o := range outs {
+		ws = append(ws, o.w)
+	}
+	return &output{w: io.MultiWriter(ws...), children: outs}
+}
+
+// openSinks 打开 sinks 对应的输出目标，并使用 wrap 包装每个目标；任意一个目标打开失败时关闭已经打开的目标。
+//
+// 参数：
+//   - sinks：输出目标的配置列表。
+//   - open：打开日志文件的函数。
+//   - wrap：包装函数，为 nil 时不包装。
+//
+// 返回值：
+//   - []*output：与 sinks 一一对应的输出目标。
+//   - error：打开日志文件失败时返回错误。
+func openSinks(sinks []Sink, open func(path string) (io.Writer, error), wrap func(io.Writer) io.Writer) ([]*output, error) {
+	outs := make([]*output, 0, len(sinks))
+	for _, sink := range sinks {
+		var o *output
+		switch {
+		case nil != sink.Writer:
+			o = newOutput(sink.Writer, false)
+		case "" != sink.Output:
+			w, err := open(sink.Output)
+			if nil != err {
+				for _, opened := range outs {
+					_ = opened.close()
+				}
+				return nil, err
+			}
+			o = newOutput(w, true)
+		default:
+			o = newOutput(os.Stdout, false)
+		}
+		o.wrap(wrap)
+		outs = append(outs, o)
+	}
+	return outs, nil
+}
+
+// wrap 使用 fn 包装写入的目标，包装后的写入器实现了 io.Closer 时先于原有的资源关闭。
+//
+// 参数：
+//   - fn：包装函数，为 nil 时不包装。
+func (o *output) wrap(fn func(io.Writer) io.Writer) {
+	if nil == fn {
+		return
+	}
+	o.w = fn(o.w)
+	if c, ok := o.w.(io.Closer); ok {
+		o.closers = append([]io.Closer{c}, o.closers...)
+	}
+}
+
+// sync 将缓冲的日志写入输出目标，o 为 nil 或已经关闭时直接返回 nil。
+func (o *output) sync() error {
+	if nil == o || o.closed.Load() {
+		return nil
+	}
+	if 0 != len(o.children) {
+		errs := make([]error, 0, len(o.children))
+		for _, c := range o.children {
+			errs = append(errs, c.sync())
+		}
+		return errors.Join(errs...)
+	}
+	return syncOutput(o.w)
+}
+
+// close 按由外到内的顺序关闭日志实例打开的资源，重复调用返回第一次关闭的结果，o 为 nil 时直接返回 nil。
+func (o *output) close() error {
+	if nil == o {
+		return nil
+	}
+	o.once.Do(func() {
+		o.closed.Store(true)
+		errs := make([]error, 0, len(o.closers)+len(o.children))
+		for _, c := range o.closers {
+			errs = append(errs, c.Close())
+		}
+		for _, c := range o.children {
+			errs = append(errs, c.close())
+		}
+		o.err = errors.Join(errs...)
+	})
+	return o.err
+}
+
+// syncOutput 在 w 实现了 Sync() error 时调用，将缓冲的日志写入输出目标。
+// 标准输出与标准错误可能是终端或管道，不支持 Sync，直接返回 nil。
+//
+// 参数：
+//   - w：日志的输出目标。
+//
+// 返回值：
+//   - error：Sync 返回的错误，w 没有实现 Sync() error 时返回 nil。
+func syncOutput(w io.Writer) error {
+	if os.Stdout == w || os.Stderr == w {
+		return nil
+	}
+	if s, ok := w.(syncer); ok {
+		return s.Sync()
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// closeRecorder 是记录关闭次数的写入器。
+	closeRecorder struct {
+		bytes.Buffer
+		closed int
+	}
+)
+
+func (w *closeRecorder) Close() error {
+	w.closed++
+	return nil
+}
+
+// TestLoggers_Close 测试各个日志实现关闭打开的日志文件，派生的实例共享输出目标，重复关闭是安全的。
+func TestLoggers_Close(t *testing.T) {
+	for _, logType := range []LogType{LogTypeStd, LogTypeLogrus, LogTypeSlog} {
+		t.Run(string(logType), func(t *testing.T) {
+			logPath := filepath.Join(t.TempDir(), "app.log")
+			logger, err := NewLogger(WithLogType(logType), WithOutput(logPath), WithEnableRotate(false))
+			require.NoError(t, err)
+
+			derived := logger.WithField("user", "alice")
+			derived.Info("before")
+			require.NoError(t, derived.Sync())
+			require.NoError(t, logger.Close())
+			derived.Info("after")
+
+			content, err := os.ReadFile(logPath) // nolint:gosec
+			require.NoError(t, err)
+			assert.Contains(t, string(content), "before")
+			assert.NotContains(t, string(content), "after")
+
+			assert.NoError(t, derived.Close())
+			assert.NoError(t, logger.Sync())
+		})
+	}
+}
+
+// TestLoggers_Close_Async 测试关闭异步写入的日志实例时写入缓冲区中剩余的日志。
+func TestLoggers_Close_Async(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewLogger(WithLogType(LogTypeLogrus), WithOutput(logPath), WithEnableRotate(false),
+		WithAsync(16, time.Hour))
+	require.NoError(t, err)
+
+	logger.Info("buffered")
+	require.NoError(t, logger.Close())
+	content, err := os.ReadFile(logPath) // nolint:gosec
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "buffered")
+}
+
+// TestSlogLogger_Close 测试调用方传入的写入器与自定义的处理器不由日志实例关闭。
+func TestSlogLogger_Close(t *testing.T) {
+	w := &closeRecorder{}
+	logger, err := NewSlogLogger(WithSlogWriter(w))
+	require.NoError(t, err)
+	require.NoError(t, logger.Close())
+	assert.Zero(t, w.closed)
+
+	logger, err = NewSlogLogger(WithSlogHandler(&recordHandler{}))
+	require.NoError(t, err)
+	assert.NoError(t, logger.Sync())
+	assert.NoError(t, logger.Close())
+}
+
+// TestClose 测试关闭全局日志实例后清除全局日志实例。
+func TestClose(t *testing.T) {
+	assert.NoError(t, Close())
+
+	logPath := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, InitLogger(WithOutput(logPath)))
+	logger := GetLogger()
+	Info("hello")
+	require.NoError(t, Sync())
+	require.NoError(t, Close())
+	assert.NotSame(t, logger, GetLogger())
+	SetLogger(nil)
+
+	content, err := os.ReadFile(logPath) // nolint:gosec
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "hello")
+}
+
+// TestClose_Concurrent 测试关闭全局日志实例与记录日志、获取全局日志实例并发执行，需要配合 -race 运行。
+func TestClose_Concurrent(t *testing.T) {
+	defer SetLogger(nil)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				// 默认日志实例为 InfoLevel，Debug 不输出，只获取全局日志实例。
+				Debug("concurrent")
+				_ = GetLogger().GetLevel()
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, InitLogger(WithWriter(io.Discard), WithAsync(16, time.Millisecond)))
+		Info("hello")
+		assert.NoError(t, Close())
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,723 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+var (
+	// ErrShipperRejected 表示日志被服务端拒绝，重试也无法成功。
+	// ShipperTransport 的 Send 返回包装了该错误的错误时，ShipperWriter 不再重试，丢弃尚未发送的日志。
+	ErrShipperRejected = errors.New("日志被服务端拒绝")
+)
+
+// 以下为 ShipperWriter 的默认参数配置。
+// 可通过 ShipperOption 机制覆盖。
+var (
+	// shipperBatchSizeDefault 为每批发送的日志条数上限。
+	shipperBatchSizeDefault = 500
+	// shipperFlushIntervalDefault 为不足一批时发送的间隔。
+	shipperFlushIntervalDefault = time.Second
+	// shipperBufferSizeDefault 为等待发送的日志条数上限。
+	shipperBufferSizeDefault = 10000
+	// shipperTimeoutDefault 为单次发送（包括建立连接）的超时时间。
+	shipperTimeoutDefault = 10 * time.Second
+	// shipperNameDefault 为自定义传输方式的写入器的名称。
+	shipperNameDefault = "shipper"
+	// udpMaxEntrySizeDefault 为 UDP 单个数据报的最大负载，超过的日志无法发送。
+	udpMaxEntrySizeDefault = 65507
+	// shipperBackoffDefault 为发送失败时的重试策略，重试前会重新建立连接。
+	shipperBackoffDefault = []retry.BackoffOption{
+		retry.WithMin(100 * time.Millisecond),
+		retry.WithMax(5 * time.Second),
+		retry.WithJitter(true),
+		retry.WithMaxAttempts(4),
+	}
+)
+
+type (
+	// ShipperTransport 定义了 ShipperWriter 发送一批日志的传输方式，由后台协程串行调用，不需要是并发安全的。
+	ShipperTransport interface {
+		// Send 按顺序发送一批日志，每条日志不含末尾的换行符。
+		//
+		// 参数：
+		//   - ctx：发送的上下文，带有 WithShipperTimeout 设置的超时时间。
+		//   - entries：要发送的日志，返回后不再持有。
+		//
+		// 返回值：
+		//   - int：从头开始已经发送成功的条数，失败时 ShipperWriter 只重试之后的日志。
+		//   - error：发送失败时返回错误，ShipperWriter 按退避策略重试；包装了 ErrShipperRejected 时不重试。
+		Send(ctx context.Context, entries [][]byte) (int, error)
+
+		// Close 释放传输方式持有的连接等资源，在 ShipperWriter 关闭时调用。
+		//
+		// 返回值：
+		//   - error：释放失败时返回错误。
+		Close() error
+	}
+
+	// KafkaProducer 定义了向 Kafka 发送消息的生产者，由调用方使用 Kafka 客户端（例如 franz-go、sarama 或 kafka-go）实现。
+	// kit/log 不依赖任何 Kafka 客户端，分区、压缩、认证与确认级别等都由生产者决定。
+	KafkaProducer interface {
+		// Produce 将 values 作为一批消息同步发送到 topic，全部确认后返回 nil。
+		//
+		// 参数：
+		//   - ctx：发送的上下文。
+		//   - topic：主题。
+		//   - values：消息的内容，每条日志一条消息，返回后不再持有。
+		//
+		// 返回值：
+		//   - error：发送失败时返回错误，整批消息会被重试。
+		Produce(ctx context.Context, topic string, values [][]byte) error
+	}
+
+	// DialFunc 定义了建立网络连接的函数，与 net.Dialer 的 DialContext 方法的签名相同。
+	DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+	// ShipperOption 定义了 ShipperWriter 的配置选项。
+	ShipperOption func(*shipperOptions)
+
+	// shipperOptions 包含 ShipperWriter 的配置。
+	shipperOptions struct {
+		// batchSize 是每批发送的日志条数上限。
+		batchSize int
+		// flushInterval 是不足一批时发送的间隔。
+		flushInterval time.Duration
+		// bufferSize 是等待发送的日志条数上限。
+		bufferSize int
+		// policy 是缓冲区已满的处理策略。
+		policy AsyncPolicy
+		// maxEntrySize 是单条日志的字节数上限，小于等于 0 时不限制。
+		maxEntrySize int
+		// timeout 是单次发送的超时时间。
+		timeout time.Duration
+		// dial 是 TCP 与 UDP 写入器建立连接的函数。
+		dial DialFunc
+		// backoff 是发送失败时的重试策略。
+		backoff []retry.BackoffOption
+		// name 是写入器的名称，用于指标标签。
+		name string
+		// metrics 表示是否记录指标。
+		metrics bool
+		// onError 处理发送失败的错误，为 nil 时输出到标准错误。
+		onError func(err error)
+		// clock 是计算发送间隔使用的时钟。
+		clock kittime.Clock
+	}
+
+	// ShipperWriter 将日志分批通过 ShipperTransport 发送到远程的日志收集服务，实现了 io.Writer。
+	// 每次 Write 是一条日志，末尾的换行符会被去掉，由传输方式决定分隔的方式。
+	// 日志先进入有界的缓冲区，由后台协程按数量与间隔发送；缓冲区已满时按 AsyncPolicy 丢弃或等待，形成背压。
+	// 发送失败时按退避策略重试，TCP 与 UDP 写入器在重试前重新建立连接；重试用尽时丢弃剩余的日志并报告错误。
+	// 日志至少发送一次：连接在发送过程中断开时，服务端可能收到重复的日志。
+	// 所有方法都是并发安全的。
+	ShipperWriter struct {
+		// transport 是发送日志的传输方式。
+		transport ShipperTransport
+		// o 是写入器的配置。
+		o *shipperOptions
+		// entries 是等待发送的日志。
+		entries chan []byte
+		// syncs 接收 Sync 的请求，发送完成后关闭请求中的通道。
+		syncs chan chan struct{}
+		// done 在后台协程退出时关闭。
+		done chan struct{}
+		// closeErr 是关闭传输方式时返回的错误，在 done 关闭之前写入。
+		closeErr error
+		// sent、failed 与 dropped 是各处理结果的指标，未开启指标时为 nil。
+		sent, failed, dropped prometheus.Counter
+
+		// mu 保护 closed 与 entries 的关闭。
+		mu sync.RWMutex
+		// closed 表示写入器是否已经关闭。
+		closed bool
+	}
+
+	// connTransport 通过 TCP 或 UDP 连接发送日志，连接在第一次发送时建立，发送失败时关闭，下一次发送时重新建立。
+	connTransport struct {
+		// network 是网络类型，tcp 或 udp 及其变体。
+		network string
+		// address 是服务端的地址。
+		address string
+		// dial 是建立连接的函数。
+		dial DialFunc
+		// datagram 表示每条日志作为一个数据报发送，否则以换行符分隔写入字节流。
+		datagram bool
+		// conn 是当前的连接，为 nil 时需要重新建立。
+		conn net.Conn
+		// buf 是拼接一批日志的缓冲区，在多次发送之间复用。
+		buf []byte
+	}
+
+	// kafkaTransport 通过 KafkaProducer 将日志发送到 Kafka 的主题。
+	kafkaTransport struct {
+		// topic 是主题。
+		topic string
+		// producer 是调用方提供的生产者，不由写入器关闭。
+		producer KafkaProducer
+	}
+)
+
+// WithShipperBatchSize 设置每批发送的日志条数上限。
+//
+// 参数：
+//   - n：日志条数，默认为 500，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperBatchSize(n int) ShipperOption {
+	return func(o *shipperOptions) {
+		o.batchSize = n
+	}
+}
+
+// WithShipperFlushInterval 设置不足一批时发送的间隔。
+//
+// 参数：
+//   - d：发送间隔，默认为 1 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperFlushInterval(d time.Duration) ShipperOption {
+	return func(o *shipperOptions) {
+		o.flushInterval = d
+	}
+}
+
+// WithShipperBufferSize 设置等待发送的日志条数上限。
+//
+// 参数：
+//   - n：日志条数，默认为 10000，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperBufferSize(n int) ShipperOption {
+	return func(o *shipperOptions) {
+		o.bufferSize = n
+	}
+}
+
+// WithShipperPolicy 设置缓冲区已满的处理策略。
+// AsyncBlock 使 Write 等待后台协程腾出空间，服务端不可用时会阻塞记录日志的调用方，直到重试用尽。
+//
+// 参数：
+//   - policy：处理策略，默认为 AsyncDrop。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperPolicy(policy AsyncPolicy) ShipperOption {
+	return func(o *shipperOptions) {
+		o.policy = policy
+	}
+}
+
+// WithShipperMaxEntrySize 设置单条日志的字节数上限，超过的日志在 Write 时丢弃，计入 failed 并报告错误。
+//
+// 参数：
+//   - n：字节数，UDP 写入器默认为 65507，其他写入器默认不限制；小于等于 0 时不限制。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperMaxEntrySize(n int) ShipperOption {
+	return func(o *shipperOptions) {
+		o.maxEntrySize = n
+	}
+}
+
+// WithShipperTimeout 设置单次发送（包括建立连接）的超时时间。
+//
+// 参数：
+//   - d：超时时间，默认为 10 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperTimeout(d time.Duration) ShipperOption {
+	return func(o *shipperOptions) {
+		o.timeout = d
+	}
+}
+
+// WithShipperDialer 设置 TCP 与 UDP 写入器建立连接的函数，用于配置 TLS 与代理等。
+//
+// 参数：
+//   - dial：建立连接的函数，默认为 net.Dialer 的 DialContext，使用 TLS 时可以传入 tls.Dialer 的 DialContext。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperDialer(dial DialFunc) ShipperOption {
+	return func(o *shipperOptions) {
+		o.dial = dial
+	}
+}
+
+// WithShipperBackoff 设置发送失败时的重试策略，在默认策略之后应用。
+// 默认最多尝试 4 次，等待时间从 100 毫秒开始按指数增长，最长 5 秒，并带有抖动。
+//
+// 参数：
+//   - opts：kit/runtime/retry 的退避选项，例如 retry.WithMaxAttempts。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperBackoff(opts ...retry.BackoffOption) ShipperOption {
+	return func(o *shipperOptions) {
+		o.backoff = append(o.backoff, opts...)
+	}
+}
+
+// WithShipperName 设置写入器的名称，用于区分多个写入器的指标。
+//
+// 参数：
+//   - name：写入器的名称，默认为 tcp、udp、kafka，自定义的传输方式为 shipper。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperName(name string) ShipperOption {
+	return func(o *shipperOptions) {
+		o.name = name
+	}
+}
+
+// WithShipperMetrics 设置是否记录 MetricWriterEntries 指标。
+//
+// 参数：
+//   - metrics：是否记录指标，默认为 true。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperMetrics(metrics bool) ShipperOption {
+	return func(o *shipperOptions) {
+		o.metrics = metrics
+	}
+}
+
+// WithShipperErrorHandler 设置发送失败时的处理函数。
+// 处理函数不应当把错误写回同一个写入器，否则服务端不可用时会不断产生新的日志。
+//
+// 参数：
+//   - fn：错误处理函数，默认输出到标准错误。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperErrorHandler(fn func(err error)) ShipperOption {
+	return func(o *shipperOptions) {
+		o.onError = fn
+	}
+}
+
+// WithShipperClock 设置计算发送间隔使用的时钟，测试时可以注入 kit/time 的 FakeClock。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟。
+//
+// 返回值：
+//   - ShipperOption：配置选项函数。
+func WithShipperClock(clock kittime.Clock) ShipperOption {
+	return func(o *shipperOptions) {
+		o.clock = clock
+	}
+}
+
+// NewShipperWriter 创建通过 transport 发送日志的写入器，并启动后台发送协程。
+// 不再使用时需要调用 Close，发送缓冲区中剩余的日志并关闭 transport。
+//
+// 参数：
+//   - transport：发送日志的传输方式。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *ShipperWriter：写入器实例。
+//   - error：transport 为 nil 时返回错误。
+func NewShipperWriter(transport ShipperTransport, opts ...ShipperOption) (*ShipperWriter, error) {
+	if nil == transport {
+		return nil, errors.New("日志发送的传输方式不能为 nil")
+	}
+	return newShipperWriter(transport, newShipperOptions(shipperNameDefault, 0, opts)), nil
+}
+
+// NewTCPWriter 创建通过 TCP 连接发送日志的写入器，每条日志以换行符结尾，可以对接 Logstash、Vector 与 Fluent Bit 等的 TCP 输入。
+// 连接在第一次发送时建立，断开后在重试时重新建立，服务端不可用时 NewTCPWriter 不返回错误。
+// 服务端关闭连接后，TCP 要在下一次写入时才能发现，此前写入的一批日志可能在没有任何错误的情况下丢失。
+//
+// 参数：
+//   - address：服务端的地址，例如 logstash:5000。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *ShipperWriter：写入器实例。
+//   - error：地址无效时返回错误。
+//
+// 示例：
+//
+//	w, err := log.NewTCPWriter("logstash:5000", log.WithShipperPolicy(log.AsyncBlock))
+//	if nil != err {
+//	    return err
+//	}
+//	defer w.Close()
+func NewTCPWriter(address string, opts ...ShipperOption) (*ShipperWriter, error) {
+	return newConnWriter("tcp", address, false, 0, opts)
+}
+
+// NewUDPWriter 创建通过 UDP 发送日志的写入器，每条日志是一个数据报，超过 65507 字节的日志被丢弃。
+// UDP 不保证送达，服务端不可用时日志可能在没有任何错误的情况下丢失。
+//
+// 参数：
+//   - address：服务端的地址，例如 vector:9000。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *ShipperWriter：写入器实例。
+//   - error：地址无效时返回错误。
+func NewUDPWriter(address string, opts ...ShipperOption) (*ShipperWriter, error) {
+	return newConnWriter("udp", address, true, udpMaxEntrySizeDefault, opts)
+}
+
+// NewKafkaWriter 创建通过 producer 将日志发送到 Kafka 主题的写入器，每条日志是一条消息。
+// 关闭写入器时不关闭 producer。
+//
+// 参数：
+//   - topic：主题。
+//   - producer：调用方提供的生产者。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *ShipperWriter：写入器实例。
+//   - error：主题为空或 producer 为 nil 时返回错误。
+//
+// 示例：
+//
+//	w, err := log.NewKafkaWriter("app-logs", producer, log.WithShipperBatchSize(1000))
+//	if nil != err {
+//	    return err
+//	}
+//	defer w.Close()
+func NewKafkaWriter(topic string, producer KafkaProducer, opts ...ShipperOption) (*ShipperWriter, error) {
+	if "" == topic {
+		return nil, errors.New("kafka 主题不能为空")
+	}
+	if nil == producer {
+		return nil, errors.New("kafka 生产者不能为 nil")
+	}
+	return newShipperWriter(&kafkaTransport{topic: topic, producer: producer}, newShipperOptions("kafka", 0, opts)), nil
+}
+
+// newConnWriter 校验地址后创建通过 TCP 或 UDP 连接发送日志的写入器。
+func newConnWriter(network, address string, datagram bool, maxEntrySize int, opts []ShipperOption) (*ShipperWriter, error) {
+	if host, port, err := net.SplitHostPort(address); nil != err || "" == host || "" == port {
+		return nil, fmt.Errorf("无效的 %s 地址：%q", network, address)
+	}
+	o := newShipperOptions(network, maxEntrySize, opts)
+	return newShipperWriter(&connTransport{network: network, address: address, dial: o.dial, datagram: datagram}, o), nil
+}
+
+// newShipperOptions 使用默认名称与单条日志的字节数上限创建配置，依次应用 opts 后将无效的值替换为默认值。
+func newShipperOptions(name string, maxEntrySize int, opts []ShipperOption) *shipperOptions {
+	o := &shipperOptions{
+		batchSize:     shipperBatchSizeDefault,
+		flushInterval: shipperFlushIntervalDefault,
+		bufferSize:    shipperBufferSizeDefault,
+		policy:        AsyncDrop,
+		maxEntrySize:  maxEntrySize,
+		timeout:       shipperTimeoutDefault,
+		name:          name,
+		metrics:       true,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.batchSize <= 0 {
+		o.batchSize = shipperBatchSizeDefault
+	}
+	if o.flushInterval <= 0 {
+		o.flushInterval = shipperFlushIntervalDefault
+	}
+	if o.bufferSize <= 0 {
+		o.bufferSize = shipperBufferSizeDefault
+	}
+	if o.timeout <= 0 {
+		o.timeout = shipperTimeoutDefault
+	}
+	if "" == o.policy {
+		o.policy = AsyncDrop
+	}
+	if nil == o.dial {
+		o.dial = (&net.Dialer{}).DialContext
+	}
+	o.clock = kittime.OrReal(o.clock)
+	// 重试默认使用写入器的时钟，调用方传入的退避配置可以覆盖。
+	o.backoff = append(append([]retry.BackoffOption{retry.WithClock(o.clock)}, shipperBackoffDefault...), o.backoff...)
+	return o
+}
+
+// newShipperWriter 创建通过 transport 发送日志的写入器，并启动后台发送协程。
+func newShipperWriter(transport ShipperTransport, o *shipperOptions) *ShipperWriter {
+	w := &ShipperWriter{
+		transport: transport,
+		o:         o,
+		entries:   make(chan []byte, o.bufferSize),
+		syncs:     make(chan chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if o.metrics {
+		w.sent = MetricWriterEntries.WithLabelValues(o.name, "sent")
+		w.failed = MetricWriterEntries.WithLabelValues(o.name, "failed")
+		w.dropped = MetricWriterEntries.WithLabelValues(o.name, "dropped")
+	}
+
+	go w.run()
+
+	return w
+}
+
+// Write 复制一条日志放入缓冲区后返回，缓冲区已满时按策略丢弃或等待，丢弃时仍然返回 nil。
+//
+// 参数：
+//   - p：一条日志，末尾的换行符会被去掉，返回后不再持有。
+//
+// 返回值：
+//   - int：总是返回 len(p)。
+//   - error：写入器已经关闭时返回 ErrWriterClosed。
+func (w *ShipperWriter) Write(p []byte) (int, error) {
+	if err := w.enqueue(bytes.Clone(bytes.TrimRight(p, "\r\n"))); nil != err {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// enqueue 将一条日志放入缓冲区，缓冲区已满时按策略丢弃或等待，超过长度上限时丢弃并报告错误。
+//
+// 参数：
+//   - e：一条日志，之后由写入器持有，调用方不能再修改。
+//
+// 返回值：
+//   - error：写入器已经关闭时返回 ErrWriterClosed，丢弃时仍然返回 nil。
+func (w *ShipperWriter) enqueue(e []byte) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return ErrWriterClosed
+	}
+	if w.o.maxEntrySize > 0 && len(e) > w.o.maxEntrySize {
+		inc(w.failed, 1)
+		w.handleError(fmt.Errorf("日志长度 %d 字节超过上限 %d 字节，已丢弃", len(e), w.o.maxEntrySize))
+		return nil
+	}
+	if AsyncBlock == w.o.policy {
+		w.entries <- e
+		return nil
+	}
+	select {
+	case w.entries <- e:
+	default:
+		inc(w.dropped, 1)
+	}
+	return nil
+}
+
+// Sync 发送缓冲区中的全部日志，在发送完成（包括重试）后返回。
+//
+// 返回值：
+//   - error：总是返回 nil，发送失败交给错误处理函数。
+func (w *ShipperWriter) Sync() error {
+	done := make(chan struct{})
+	select {
+	case w.syncs <- done:
+		<-done
+	case <-w.done:
+	}
+	return nil
+}
+
+// Close 停止接收日志，发送缓冲区中剩余的日志并关闭传输方式后返回，重复调用返回第一次关闭的结果。
+//
+// 返回值：
+//   - error：关闭传输方式失败时返回错误，发送失败交给错误处理函数。
+func (w *ShipperWriter) Close() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.entries)
+	}
+	w.mu.Unlock()
+
+	<-w.done
+	return w.closeErr
+}
+
+// run 是后台发送协程，按数量与间隔分批发送日志，entries 关闭后发送剩余的日志，关闭传输方式并退出。
+func (w *ShipperWriter) run() {
+	defer close(w.done)
+
+	ticker := w.o.clock.NewTicker(w.o.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, w.o.batchSize)
+	add := func(e []byte) {
+		batch = append(batch, e)
+		if len(batch) >= w.o.batchSize {
+			w.flush(batch)
+			batch = batch[:0]
+		}
+	}
+	for {
+		select {
+		case e, ok := <-w.entries:
+			if !ok {
+				w.flush(batch)
+				w.closeErr = w.transport.Close()
+				return
+			}
+			add(e)
+		case <-ticker.C():
+			w.flush(batch)
+			batch = batch[:0]
+		case done := <-w.syncs:
+			// 只发送 Sync 调用之前已经进入缓冲区的日志，避免持续写入时 Sync 无法返回。
+			for n := len(w.entries); n > 0; n-- {
+				if e, ok := <-w.entries; ok {
+					add(e)
+				}
+			}
+			w.flush(batch)
+			batch = batch[:0]
+			close(done)
+		}
+	}
+}
+
+// flush 发送一批日志，失败时按退避策略重试尚未发送的部分，重试用尽或被服务端拒绝时丢弃剩余的日志。
+//
+// 参数：
+//   - batch：要发送的日志，返回后不再持有。
+func (w *ShipperWriter) flush(batch [][]byte) {
+	if 0 == len(batch) {
+		return
+	}
+	pending := batch
+	var rejected error
+	err := retry.RetryWithContext(context.Background(), func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, w.o.timeout)
+		defer cancel()
+		n, err := w.transport.Send(ctx, pending)
+		n = min(max(n, 0), len(pending))
+		inc(w.sent, n)
+		pending = pending[n:]
+		switch {
+		case 0 == len(pending):
+			return nil
+		case errors.Is(err, ErrShipperRejected):
+			// 返回 nil 结束重试，错误在重试结束后报告。
+			rejected = err
+			return nil
+		case nil == err:
+			return io.ErrShortWrite
+		}
+		return err
+	}, w.o.backoff...)
+	if nil != rejected {
+		err = rejected
+	}
+	if nil != err {
+		inc(w.failed, len(pending))
+		w.handleError(fmt.Errorf("发送日志到 %s 失败，丢弃 %d 条日志：%w", w.o.name, len(pending), err))
+	}
+}
+
+// handleError 将错误交给错误处理函数，未设置时输出到标准错误。
+// 默认不记录到全局日志实例，因为全局日志实例可能正在使用该写入器。
+func (w *ShipperWriter) handleError(err error) {
+	if nil != w.o.onError {
+		w.o.onError(err)
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "kit/log: %v\n", err)
+}
+
+// Send 通过连接发送一批日志，连接不存在时建立，发送失败时关闭连接，由重试重新建立。
+func (t *connTransport) Send(ctx context.Context, entries [][]byte) (int, error) {
+	if nil == t.conn {
+		conn, err := t.dial(ctx, t.network, t.address)
+		if nil != err {
+			return 0, err
+		}
+		t.conn = conn
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = t.conn.SetWriteDeadline(deadline)
+	}
+
+	if t.datagram {
+		for i, e := range entries {
+			if _, err := t.conn.Write(e); nil != err {
+				t.reset()
+				return i, err
+			}
+		}
+		return len(entries), nil
+	}
+
+	buf := t.buf[:0]
+	for _, e := range entries {
+		buf = append(append(buf, e...), '\n')
+	}
+	t.buf = buf
+	written, err := t.conn.Write(buf)
+	if nil == err {
+		return len(entries), nil
+	}
+	t.reset()
+	// 完整写入的日志不再重试，写入一部分的日志整条重试。
+	n := 0
+	for _, e := range entries {
+		if written < len(e)+1 {
+			break
+		}
+		written -= len(e) + 1
+		n++
+	}
+	return n, err
+}
+
+// Close 关闭当前的连接。
+func (t *connTransport) Close() error {
+	if nil == t.conn {
+		return nil
+	}
+	err := t.conn.Close()
+	t.conn = nil
+	return err
+}
+
+// reset 关闭发送失败的连接，下一次发送时重新建立。
+func (t *connTransport) reset() {
+	_ = t.conn.Close()
+	t.conn = nil
+}
+
+// Send 将一批日志作为消息发送到主题，失败时整批重试。
+func (t *kafkaTransport) Send(ctx context.Context, entries [][]byte) (int, error) {
+	if err := t.producer.Produce(ctx, t.topic, entries); nil != err {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// Close 不关闭调用方提供的生产者，直接返回 nil。
+func (t *kafkaTransport) Close() error {
+	return nil
+}
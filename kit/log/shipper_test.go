@@ -0,0 +1,335 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+type (
+	// recordTransport 是记录每次发送的测试传输方式，按顺序使用 results 中的结果，用完后总是全部发送成功。
+	recordTransport struct {
+		mu      sync.Mutex
+		batches [][]string
+		results []func(entries [][]byte) (int, error)
+		// entered 不为 nil 时第一次发送通知 entered 并等待 release。
+		entered chan struct{}
+		release chan struct{}
+		closed  int
+	}
+
+	// recordProducer 是记录消息的测试 Kafka 生产者，前 fails 次发送返回错误。
+	recordProducer struct {
+		mu     sync.Mutex
+		topics []string
+		values []string
+		fails  int
+	}
+)
+
+func (t *recordTransport) Send(_ context.Context, entries [][]byte) (int, error) {
+	if nil != t.entered {
+		close(t.entered)
+		t.entered = nil
+		<-t.release
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	batch := make([]string, 0, len(entries))
+	for _, e := range entries {
+		batch = append(batch, string(e))
+	}
+	t.batches = append(t.batches, batch)
+	if len(t.results) > 0 {
+		var result func(entries [][]byte) (int, error)
+		result, t.results = t.results[0], t.results[1:]
+		return result(entries)
+	}
+	return len(entries), nil
+}
+
+func (t *recordTransport) Close() error {
+	t.closed++
+	return errors.New("close failed")
+}
+
+// snapshot 返回每次发送的日志。
+func (t *recordTransport) snapshot() [][]string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([][]string(nil), t.batches...)
+}
+
+func (p *recordProducer) Produce(_ context.Context, topic string, values [][]byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fails > 0 {
+		p.fails--
+		return errors.New("broker not available")
+	}
+	for _, v := range values {
+		p.topics = append(p.topics, topic)
+		p.values = append(p.values, string(v))
+	}
+	return nil
+}
+
+// fastShipperBackoff 使用系统时钟与很短的等待时间重试。
+func fastShipperBackoff(opts ...retry.BackoffOption) ShipperOption {
+	return WithShipperBackoff(append([]retry.BackoffOption{
+		retry.WithClock(kittime.NewRealClock()),
+		retry.WithMin(time.Millisecond),
+		retry.WithMax(time.Millisecond),
+	}, opts...)...)
+}
+
+// TestTCPWriter 测试日志以换行符分隔写入 TCP 连接，服务端关闭连接后重新建立连接。
+func TestTCPWriter(t *testing.T) {
+	entries := entriesSince(t.Name())
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close() // nolint:errcheck
+
+	conns := make(chan net.Conn, 2)
+	lines := make(chan string, 8)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if nil != err {
+				return
+			}
+			conns <- conn
+			go func() {
+				s := bufio.NewScanner(conn)
+				for s.Scan() {
+					lines <- s.Text()
+				}
+			}()
+		}
+	}()
+
+	var (
+		mu     sync.Mutex
+		dialed []net.Conn
+	)
+	w, err := NewTCPWriter(ln.Addr().String(),
+		WithShipperName(t.Name()),
+		WithShipperDialer(func(ctx context.Context, network, address string) (net.Conn, error) {
+			conn, err := (&net.Dialer{}).DialContext(ctx, network, address)
+			if nil == err {
+				mu.Lock()
+				dialed = append(dialed, conn)
+				mu.Unlock()
+			}
+			return conn, err
+		}),
+	)
+	require.NoError(t, err)
+
+	_, _ = w.Write([]byte(`{"msg":"a"}` + "\n"))
+	_, _ = w.Write([]byte("b"))
+	require.NoError(t, w.Sync())
+	assert.Equal(t, `{"msg":"a"}`, <-lines)
+	assert.Equal(t, "b", <-lines)
+
+	// 服务端关闭连接后，写入失败时关闭连接，重试时重新建立；写入失败之前发送的日志可能丢失。
+	require.NoError(t, (<-conns).Close())
+	require.Eventually(t, func() bool {
+		_, _ = w.Write([]byte("c"))
+		if nil != w.Sync() {
+			return false
+		}
+		select {
+		case line := <-lines:
+			return "c" == line
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, time.Millisecond)
+	require.NoError(t, w.Close())
+
+	mu.Lock()
+	assert.Len(t, dialed, 2)
+	mu.Unlock()
+	assert.GreaterOrEqual(t, entries("sent"), float64(3))
+	_, err = w.Write([]byte("after close"))
+	assert.ErrorIs(t, err, ErrWriterClosed)
+}
+
+// TestUDPWriter 测试每条日志作为一个数据报发送，超过上限的日志被丢弃并报告错误。
+func TestUDPWriter(t *testing.T) {
+	entries := entriesSince(t.Name())
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer pc.Close() // nolint:errcheck
+
+	var errs []error
+	w, err := NewUDPWriter(pc.LocalAddr().String(),
+		WithShipperName(t.Name()),
+		WithShipperMaxEntrySize(8),
+		WithShipperErrorHandler(func(err error) { errs = append(errs, err) }),
+	)
+	require.NoError(t, err)
+
+	_, _ = w.Write([]byte("hello\n"))
+	_, _ = w.Write([]byte("too large entry\n"))
+	_, _ = w.Write([]byte("world\n"))
+	require.NoError(t, w.Close())
+
+	buf := make([]byte, 64)
+	var got []string
+	for range 2 {
+		require.NoError(t, pc.SetReadDeadline(time.Now().Add(time.Second)))
+		n, _, err := pc.ReadFrom(buf)
+		require.NoError(t, err)
+		got = append(got, string(buf[:n]))
+	}
+	assert.Equal(t, []string{"hello", "world"}, got)
+	assert.Equal(t, float64(2), entries("sent"))
+	assert.Equal(t, float64(1), entries("failed"))
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "超过上限")
+}
+
+// TestKafkaWriter 测试日志作为消息发送到主题，发送失败时整批重试。
+func TestKafkaWriter(t *testing.T) {
+	entries := entriesSince("kafka")
+	producer := &recordProducer{fails: 1}
+	w, err := NewKafkaWriter("app-logs", producer, fastShipperBackoff())
+	require.NoError(t, err)
+
+	_, _ = w.Write([]byte("a\n"))
+	_, _ = w.Write([]byte("b\n"))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"a", "b"}, producer.values)
+	assert.Equal(t, []string{"app-logs", "app-logs"}, producer.topics)
+	assert.Equal(t, float64(2), entries("sent"))
+}
+
+// TestShipperWriter_Retry 测试只重试尚未发送的日志，重试用尽时丢弃剩余的日志并报告错误，关闭时关闭传输方式。
+func TestShipperWriter_Retry(t *testing.T) {
+	entries := entriesSince(t.Name())
+	partial := func(entries [][]byte) (int, error) {
+		return 1, errors.New("connection reset")
+	}
+	transport := &recordTransport{results: []func(entries [][]byte) (int, error){partial, partial}}
+	var errs []error
+	w, err := NewShipperWriter(transport,
+		WithShipperName(t.Name()),
+		WithShipperErrorHandler(func(err error) { errs = append(errs, err) }),
+		fastShipperBackoff(retry.WithMaxAttempts(2)),
+	)
+	require.NoError(t, err)
+
+	for _, s := range []string{"a", "b", "c"} {
+		_, _ = w.Write([]byte(s))
+	}
+	require.NoError(t, w.Sync())
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"b", "c"}}, transport.snapshot())
+	assert.Equal(t, float64(2), entries("sent"))
+	assert.Equal(t, float64(1), entries("failed"))
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], retry.ErrBudgetExhausted)
+
+	assert.EqualError(t, w.Close(), "close failed")
+	assert.EqualError(t, w.Close(), "close failed")
+	assert.Equal(t, 1, transport.closed)
+}
+
+// TestShipperWriter_Policy 测试发送跟不上时 AsyncDrop 丢弃日志并计入指标，AsyncBlock 等待而不丢失日志。
+func TestShipperWriter_Policy(t *testing.T) {
+	t.Run("drop", func(t *testing.T) {
+		entries := entriesSince(t.Name())
+		transport := &recordTransport{entered: make(chan struct{}), release: make(chan struct{})}
+		entered := transport.entered
+		w, err := NewShipperWriter(transport, WithShipperName(t.Name()), WithShipperBatchSize(1), WithShipperBufferSize(1))
+		require.NoError(t, err)
+
+		_, _ = w.Write([]byte("a"))
+		<-entered
+		for _, s := range []string{"b", "c", "d"} {
+			n, err := w.Write([]byte(s))
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+		}
+		close(transport.release)
+		_ = w.Close()
+
+		assert.Equal(t, float64(2), entries("dropped"))
+		assert.Equal(t, [][]string{{"a"}, {"b"}}, transport.snapshot())
+	})
+
+	t.Run("block", func(t *testing.T) {
+		entries := entriesSince(t.Name())
+		transport := &recordTransport{entered: make(chan struct{}), release: make(chan struct{})}
+		entered := transport.entered
+		w, err := NewShipperWriter(transport, WithShipperName(t.Name()), WithShipperBatchSize(1), WithShipperBufferSize(1),
+			WithShipperPolicy(AsyncBlock))
+		require.NoError(t, err)
+
+		_, _ = w.Write([]byte("a"))
+		<-entered
+		_, _ = w.Write([]byte("b"))
+		written := make(chan struct{})
+		go func() {
+			defer close(written)
+			_, _ = w.Write([]byte("c"))
+		}()
+		select {
+		case <-written:
+			t.Fatal("Write 应当等待缓冲区腾出空间")
+		case <-time.After(20 * time.Millisecond):
+		}
+		close(transport.release)
+		<-written
+		_ = w.Close()
+
+		assert.Zero(t, entries("dropped"))
+		assert.Equal(t, [][]string{{"a"}, {"b"}, {"c"}}, transport.snapshot())
+	})
+}
+
+// TestNewShipperWriter_Invalid 测试无效的地址、主题、生产者与传输方式。
+func TestNewShipperWriter_Invalid(t *testing.T) {
+	for _, address := range []string{"", "localhost", ":5000", "localhost:"} {
+		_, err := NewTCPWriter(address)
+		assert.Error(t, err, address)
+		_, err = NewUDPWriter(address)
+		assert.Error(t, err, address)
+	}
+	_, err := NewKafkaWriter("", &recordProducer{})
+	assert.Error(t, err)
+	_, err = NewKafkaWriter("app-logs", nil)
+	assert.Error(t, err)
+	_, err = NewShipperWriter(nil)
+	assert.Error(t, err)
+
+	// 服务端不可用时创建成功，错误在发送时报告。
+	var buf bytes.Buffer
+	w, err := NewTCPWriter("127.0.0.1:1",
+		WithShipperMetrics(false),
+		WithShipperErrorHandler(func(err error) { buf.WriteString(err.Error()) }),
+		fastShipperBackoff(retry.WithMaxAttempts(1)),
+	)
+	require.NoError(t, err)
+	_, _ = w.Write([]byte("lost"))
+	require.NoError(t, w.Close())
+	assert.True(t, strings.Contains(buf.String(), "丢弃 1 条日志"), buf.String())
+}
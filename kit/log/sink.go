@@ -0,0 +1,112 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+type (
+	// logrusSink 是 Logrus 的一个输出目标。
+	logrusSink struct {
+		// w 是输出目标。
+		w io.Writer
+		// formatter 是该目标的格式化器。
+		formatter logrus.Formatter
+	}
+
+	// logrusSinkHook 是将每条日志按各目标的格式化器分别格式化并写入的 Logrus 钩子。
+	logrusSinkHook struct {
+		// mu 串行化写入，Logrus 调用钩子时不加锁。
+		mu sync.Mutex
+		// sinks 是输出目标列表。
+		sinks []logrusSink
+	}
+
+	// nopFormatter 是不输出任何内容的 Logrus 格式化器，日志由 logrusSinkHook 输出时使用，避免重复格式化。
+	nopFormatter struct{}
+
+	// slogMultiHandler 是将每条日志交给多个处理器的 slog.Handler，每个处理器对应一个输出目标。
+	slogMultiHandler struct {
+		// handlers 是各输出目标的处理器。
+		handlers []slog.Handler
+	}
+)
+
+// Levels 实现了 logrus.Hook 接口，作用于全部日志级别。
+func (h *logrusSinkHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire 实现了 logrus.Hook 接口，按各目标的格式化器格式化日志并写入，返回全部失败的错误。
+func (h *logrusSinkHook) Fire(entry *logrus.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var errs []error
+	for _, s := range h.sinks {
+		b, err := s.formatter.Format(entry)
+		if nil == err {
+			_, err = s.w.Write(b)
+		}
+		if nil != err {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Format 实现了 logrus.Formatter 接口，不输出任何内容。
+func (nopFormatter) Format(*logrus.Entry) ([]byte, error) {
+	return nil, nil
+}
+
+// Enabled 实现了 slog.Handler 接口，任意一个处理器启用该级别时返回 true。
+func (h *slogMultiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle 实现了 slog.Handler 接口，将日志交给启用该级别的每个处理器，返回全部失败的错误。
+func (h *slogMultiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, r.Clone()); nil != err {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs 实现了 slog.Handler 接口。
+func (h *slogMultiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithAttrs(attrs))
+	}
+	return &slogMultiHandler{handlers: handlers}
+}
+
+// WithGroup 实现了 slog.Handler 接口。
+func (h *slogMultiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithGroup(name))
+	}
+	return &slogMultiHandler{handlers: handlers}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewLogger_Sinks 测试同时输出到文件与自定义的写入器，每个目标使用独立的格式，关闭时只关闭打开的文件。
+func TestNewLogger_Sinks(t *testing.T) {
+	for _, logType := range []LogType{LogTypeLogrus, LogTypeSlog} {
+		t.Run(string(logType), func(t *testing.T) {
+			logPath := filepath.Join(t.TempDir(), "app.log")
+			w := &closeRecorder{}
+			logger, err := NewLogger(
+				WithLogType(logType),
+				WithFormatType(JSONFormat),
+				WithEnableRotate(false),
+				WithOutput(filepath.Join(t.TempDir(), "ignored.log")),
+				WithSinks(Sink{Output: logPath}, Sink{Writer: w, FormatType: TextFormat}),
+			)
+			require.NoError(t, err)
+
+			logger.WithField("user", "alice").Info("hello")
+			logger.Debug("hidden")
+			require.NoError(t, logger.Close())
+			assert.Zero(t, w.closed)
+
+			content, err := os.ReadFile(logPath) // nolint:gosec
+			require.NoError(t, err)
+			line := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(bytes.TrimSpace(content), &line), string(content))
+			assert.Equal(t, "hello", line["msg"])
+			assert.Equal(t, "alice", line["user"])
+
+			text := strings.TrimSpace(w.String())
+			assert.NotContains(t, text, "\n")
+			assert.False(t, strings.HasPrefix(text, "{"), text)
+			assert.Contains(t, text, "msg=hello")
+			assert.Contains(t, text, "user=alice")
+		})
+	}
+}
+
+// TestNewLogger_Sinks_Std 测试标准库日志实现以文本格式同时输出到多个目标，开启异步写入时每个目标独立写入。
+func TestNewLogger_Sinks_Std(t *testing.T) {
+	var a, b bytes.Buffer
+	logger, err := NewLogger(
+		WithLogType(LogTypeStd),
+		WithSinks(Sink{Writer: &a}, Sink{Writer: &b, FormatType: JSONFormat}),
+		WithAsync(16, time.Hour),
+	)
+	require.NoError(t, err)
+
+	logger.WithField("user", "alice").Warn("hello")
+	assert.Zero(t, a.Len())
+	require.NoError(t, logger.Close())
+	for _, buf := range []*bytes.Buffer{&a, &b} {
+		assert.Contains(t, buf.String(), "[WARN] [user=alice] hello")
+	}
+}
+
+// TestNewSlogLogger_Sinks_InvalidFormat 测试目标使用不支持的格式时返回错误。
+func TestNewSlogLogger_Sinks_InvalidFormat(t *testing.T) {
+	_, err := NewSlogLogger(WithSlogSinks(Sink{Writer: &bytes.Buffer{}}, Sink{Writer: &bytes.Buffer{}, FormatType: "xml"}))
+	assert.Error(t, err)
+}
+
+// TestNewLogger_Writer 测试各个日志实现写入调用方传入的写入器，忽略 Output，关闭时不关闭写入器。
+func TestNewLogger_Writer(t *testing.T) {
+	dir := t.TempDir()
+	for _, logType := range []LogType{LogTypeStd, LogTypeLogrus, LogTypeSlog} {
+		w := &closeRecorder{}
+		logger, err := NewLogger(WithLogType(logType), WithWriter(w), WithOutput(filepath.Join(dir, "ignored.log")))
+		require.NoError(t, err, logType)
+
+		logger.WithField("user", "alice").Info("hello")
+		require.NoError(t, logger.Close(), logType)
+		assert.Contains(t, w.String(), "hello", logType)
+		assert.Contains(t, w.String(), "alice", logType)
+		assert.Zero(t, w.closed, logType)
+	}
+	_, err := os.Stat(filepath.Join(dir, "ignored.log"))
+	assert.True(t, os.IsNotExist(err))
+
+	var buf bytes.Buffer
+	NewStdLoggerWithWriter(&buf).Warn("std")
+	assert.Contains(t, buf.String(), "[WARN] std")
+}
@@ -0,0 +1,724 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	kitfs "github.com/fsyyft-go/monorepo/kit/fs"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+const (
+	// SlogLevelFatal 是 FatalLevel 对应的 slog 日志级别，slog 没有致命错误级别，输出时显示为 FATAL。
+	SlogLevelFatal = slog.LevelError + 4
+)
+
+var (
+	// slogLevelMap 定义了自定义日志级别到 slog 日志级别的映射。
+	slogLevelMap = map[Level]slog.Level{
+		DebugLevel: slog.LevelDebug,
+		InfoLevel:  slog.LevelInfo,
+		WarnLevel:  slog.LevelWarn,
+		ErrorLevel: slog.LevelError,
+		FatalLevel: SlogLevelFatal,
+	}
+
+	// defaultSlogOptions 定义了默认的 slog 日志选项。
+	defaultSlogOptions = SlogLoggerOptions{
+		FormatType:   JSONFormat,
+		Level:        InfoLevel,
+		FileMode:     defaultFileMode,
+		DirMode:      defaultDirMode,
+		EnableRotate: true,               // 默认启用日志滚动
+		RotateTime:   time.Hour,          // 默认每小时滚动一次
+		MaxAge:       time.Hour * 24 * 7, // 默认保留7天
+	}
+)
+
+type (
+	// SlogLogger 实现了 Logger 接口，使用标准库的 log/slog 作为底层日志库。
+	// 这个实现提供了以下功能：
+	// - 文本（key=value）与 JSON 两种内置格式。
+	// - 接入任意 slog.Handler，例如 OpenTelemetry 等第三方提供的处理器。
+	// - 通过 Slog 方法取得共享处理器与日志级别的 *slog.Logger，逐步迁移到 slog 的代码可以与 Logger 混用。
+	//
+	// WithField 与 WithFields 通过 slog.Handler 的 WithAttrs 派生，字段在派生时交给处理器预先格式化；
+	// 字段值在输出时才经过 RegisterFieldEncoder 注册的编码器转换，函数类型的字段值无法输出，会被忽略。
+	SlogLogger struct {
+		// handler 是输出日志的处理器，已经包含 WithField 与 WithFields 添加的字段。
+		handler slog.Handler
+		// level 是日志级别，与派生的实例共享，支持运行时并发修改。
+		level *slog.LevelVar
+		// output 是内置处理器的输出目标，与派生的实例共享，使用自定义的处理器时为 nil。
+		output *output
+		// hooks 是通过 AddHook 添加的钩子，与派生的实例共享。
+		hooks *hooks
+		// caller 是记录调用位置的配置。
+		caller caller
+	}
+
+	// SlogLoggerOptions 包含了 SlogLogger 的所有配置选项。
+	SlogLoggerOptions struct {
+		// OutputPath 输出文件路径，为空时输出到标准输出。
+		OutputPath string
+		// Writer 日志的输出目标，设置时忽略 OutputPath。
+		Writer io.Writer
+		// FormatType 内置处理器的输出格式，可选值包括 TextFormat、JSONFormat。
+		FormatType LoggerFormatType
+		// TimestampFormat 内置处理器的时间格式，为空时使用 slog 默认的 RFC 3339 格式。
+		TimestampFormat string
+		// Handler 自定义的处理器，设置时忽略输出与格式相关的选项。
+		Handler slog.Handler
+		// Level 日志级别。
+		Level Level
+		// FileMode 文件权限。
+		FileMode os.FileMode
+		// DirMode 目录权限。
+		DirMode os.FileMode
+		// EnableRotate 是否启用日志滚动。
+		EnableRotate bool
+		// RotateTime 日志滚动时间间隔。
+		RotateTime time.Duration
+		// MaxAge 日志保留时间。
+		MaxAge time.Duration
+		// Clock 日志滚动使用的时钟，为 nil 时使用系统时钟。
+		Clock kittime.Clock
+		// FS 是创建日志文件使用的文件系统，为 nil 时使用磁盘。
+		FS kitfs.FS
+		// Sinks 同时输出的多个目标，设置时忽略 OutputPath 与 Writer。
+		Sinks []Sink
+
+		// wrapWriter 不为 nil 时用于包装输出目标，NewLogger 通过它开启异步写入。
+		wrapWriter func(io.Writer) io.Writer
+	}
+
+	// SlogOption 定义了 SlogLogger 的配置选项函数类型。
+	SlogOption func(*SlogLoggerOptions)
+
+	// slogLevelHandler 为自定义的处理器增加 SlogLogger 的日志级别检查，使 SetLevel 对自定义处理器同样生效。
+	slogLevelHandler struct {
+		slog.Handler
+		// level 是日志级别。
+		level *slog.LevelVar
+	}
+
+	// slogFieldValue 是字段值的包装，输出时才经过注册的编码器转换。
+	slogFieldValue struct {
+		// v 是原始的字段值。
+		v interface{}
+	}
+)
+
+// WithSlogOutputPath 设置日志输出路径。
+//
+// 参数：
+//   - path：日志文件的输出路径，为空时输出到标准输出。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogOutputPath(path string) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.OutputPath = path
+	}
+}
+
+// WithSlogWriter 设置日志的输出目标。
+//
+// 参数：
+//   - w：输出目标，设置时忽略 WithSlogOutputPath。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogWriter(w io.Writer) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.Writer = w
+	}
+}
+
+// WithSlogFormatType 设置内置处理器的输出格式。
+//
+// 参数：
+//   - formatType：输出格式，TextFormat 使用 slog.TextHandler，JSONFormat 使用 slog.JSONHandler。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogFormatType(formatType LoggerFormatType) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.FormatType = formatType
+	}
+}
+
+// WithSlogTimestampFormat 设置内置处理器的时间格式。
+//
+// 参数：
+//   - format：时间的格式化模板，例如："2006-01-02 15:04:05.000"，为空时使用 slog 默认的格式。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogTimestampFormat(format string) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.TimestampFormat = format
+	}
+}
+
+// WithSlogSinks 设置同时输出的多个目标，设置后忽略 WithSlogOutputPath 与 WithSlogWriter。
+// 每个目标使用独立的内置处理器，目标的 FormatType 为空时使用 WithSlogFormatType 设置的格式。
+//
+// 参数：
+//   - sinks：输出目标列表。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogSinks(sinks ...Sink) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.Sinks = append([]Sink(nil), sinks...)
+	}
+}
+
+// WithSlogHandler 设置自定义的处理器。
+// 处理器自身的级别过滤仍然生效，SlogLogger 的日志级别在其之前检查。
+//
+// 参数：
+//   - handler：自定义的处理器，设置时忽略输出与格式相关的选项。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogHandler(handler slog.Handler) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.Handler = handler
+	}
+}
+
+// WithSlogLevel 设置日志级别。
+//
+// 参数：
+//   - level：日志输出的级别，可选值包括 DebugLevel、InfoLevel、WarnLevel、ErrorLevel、FatalLevel。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogLevel(level Level) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.Level = level
+	}
+}
+
+// WithSlogEnableRotate 设置是否启用日志滚动。
+//
+// 参数：
+//   - enable：是否启用日志滚动功能，true 表示启用，false 表示禁用。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogEnableRotate(enable bool) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.EnableRotate = enable
+	}
+}
+
+// WithSlogRotateTime 设置日志滚动时间间隔。
+//
+// 参数：
+//   - duration：日志滚动的时间间隔，例如：time.Hour 表示每小时滚动一次。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogRotateTime(duration time.Duration) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.RotateTime = duration
+	}
+}
+
+// WithSlogMaxAge 设置日志保留时间。
+//
+// 参数：
+//   - duration：日志文件的最大保留时间，超过这个时间的日志文件会被自动删除。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogMaxAge(duration time.Duration) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.MaxAge = duration
+	}
+}
+
+// WithSlogClock 设置日志滚动使用的时钟。
+//
+// 参数：
+//   - clock：决定滚动时间点与日志文件名的时钟，为 nil 时使用系统时钟，测试时可以注入 kit/time 的 FakeClock。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogClock(clock kittime.Clock) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.Clock = clock
+	}
+}
+
+// WithSlogFS 设置创建日志文件使用的文件系统。
+// 日志滚动只能作用于磁盘，注入其他文件系统时不滚动，日志始终写入 OutputPath 指定的文件。
+//
+// 参数：
+//   - fsys：文件系统，为 nil 时使用磁盘，测试时可以注入 kit/testing 的 MemFS。
+//
+// 返回值：
+//   - SlogOption：返回一个配置选项函数。
+func WithSlogFS(fsys kitfs.FS) SlogOption {
+	return func(o *SlogLoggerOptions) {
+		o.FS = fsys
+	}
+}
+
+// NewSlogLogger 创建一个新的 SlogLogger 实例。
+//
+// 参数：
+//   - opts：可选的配置选项列表，用于自定义日志记录器的行为。
+//
+// 返回值：
+//   - Logger：返回创建的日志实例。
+//   - error：返回创建过程中可能发生的错误。
+//
+// 示例：
+//
+//	logger, err := log.NewSlogLogger(
+//	    log.WithSlogFormatType(log.TextFormat),
+//	    log.WithSlogLevel(log.DebugLevel),
+//	)
+func NewSlogLogger(opts ...SlogOption) (Logger, error) {
+	// 使用默认选项。
+	options := defaultSlogOptions
+
+	// 应用自定义选项。
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	level := new(slog.LevelVar)
+	if slogLevel, ok := slogLevelMap[options.Level]; ok {
+		level.Set(slogLevel)
+	}
+
+	hooks := newHooks()
+	if nil != options.Handler {
+		return &SlogLogger{
+			handler: &slogHookHandler{Handler: &slogLevelHandler{Handler: options.Handler, level: level}, hooks: hooks},
+			level:   level,
+			hooks:   hooks,
+		}, nil
+	}
+
+	// 调用方传入的写入器不由日志实例关闭。
+	sinks := append([]Sink(nil), options.Sinks...)
+	if 0 == len(sinks) {
+		sinks = []Sink{{Output: options.OutputPath, Writer: options.Writer}}
+	}
+	for i := range sinks {
+		if "" == sinks[i].FormatType {
+			sinks[i].FormatType = options.FormatType
+		}
+		if TextFormat != sinks[i].FormatType && JSONFormat != sinks[i].FormatType {
+			return nil, fmt.Errorf("不支持的日志格式：%s", sinks[i].FormatType)
+		}
+	}
+	outs, err := openSinks(sinks, func(path string) (io.Writer, error) {
+		return openOutputWriter(options.FS, path, options.FileMode, options.DirMode,
+			options.EnableRotate, options.RotateTime, options.MaxAge, options.Clock)
+	}, options.wrapWriter)
+	if nil != err {
+		return nil, err
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: slogReplaceAttr(options.TimestampFormat),
+	}
+	handlers := make([]slog.Handler, 0, len(outs))
+	for i, o := range outs {
+		if TextFormat == sinks[i].FormatType {
+			handlers = append(handlers, slog.NewTextHandler(o.w, handlerOpts))
+		} else {
+			handlers = append(handlers, slog.NewJSONHandler(o.w, handlerOpts))
+		}
+	}
+	handler := handlers[0]
+	if len(handlers) > 1 {
+		handler = &slogMultiHandler{handlers: handlers}
+	}
+	out := newMultiOutput(outs)
+
+	return &SlogLogger{
+		handler: &slogHookHandler{Handler: handler, hooks: hooks},
+		level:   level,
+		output:  out,
+		hooks:   hooks,
+	}, nil
+}
+
+// slogReplaceAttr 返回内置处理器使用的属性替换函数：FATAL 级别输出为 FATAL，时间按 timestampFormat 格式化。
+func slogReplaceAttr(timestampFormat string) func(groups []string, a slog.Attr) slog.Attr {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if 0 != len(groups) {
+			return a
+		}
+		switch a.Key {
+		case slog.LevelKey:
+			if level, ok := a.Value.Any().(slog.Level); ok && level >= SlogLevelFatal {
+				a.Value = slog.StringValue("FATAL")
+			}
+		case slog.TimeKey:
+			if "" != timestampFormat && slog.KindTime == a.Value.Kind() {
+				a.Value = slog.StringValue(a.Value.Time().Format(timestampFormat))
+			}
+		}
+		return a
+	}
+}
+
+// Slog 返回与该实例共享处理器、字段与日志级别的 *slog.Logger。
+//
+// 返回值：
+//   - *slog.Logger：slog 日志实例。
+//
+// 示例：
+//
+//	logger, _ := log.NewSlogLogger()
+//	slog.SetDefault(logger.(*log.SlogLogger).Slog())
+func (l *SlogLogger) Slog() *slog.Logger {
+	return slog.New(l.handler)
+}
+
+// SetLevel 实现 Logger 接口的日志级别设置方法。
+// 通过 WithField 等方法派生的实例共享日志级别，可以在记录日志的同时并发调用。
+//
+// 参数：
+//   - level：要设置的日志级别。
+func (l *SlogLogger) SetLevel(level Level) {
+	if slogLevel, ok := slogLevelMap[level]; ok {
+		l.level.Set(slogLevel)
+	}
+}
+
+// GetLevel 实现 Logger 接口的日志级别获取方法。
+//
+// 返回值：
+//   - Level：返回当前日志记录器的日志级别。
+func (l *SlogLogger) GetLevel() Level {
+	slogLevel := l.level.Level()
+	for level, sLevel := range slogLevelMap {
+		if sLevel == slogLevel {
+			return level
+		}
+	}
+	return InfoLevel
+}
+
+// log 记录指定级别的日志，msg 只在级别启用时生成。
+func (l *SlogLogger) log(level slog.Level, msg func() string) {
+	l.handle(context.Background(), level, msg)
+}
+
+// logContext 记录指定级别的日志，ctx 交给处理器，并添加从 ctx 中提取的字段。
+func (l *SlogLogger) logContext(ctx context.Context, level slog.Level, msg func() string) {
+	l.handle(ctx, level, msg)
+}
+
+// handle 创建日志记录交给处理器，由 log 与 logContext 调用，使两者到日志方法调用方的栈帧数相同。
+// 开启记录调用位置时，记录的 PC 指向调用方，并添加 caller 字段。
+func (l *SlogLogger) handle(ctx context.Context, level slog.Level, msg func() string) {
+	if !l.handler.Enabled(ctx, level) {
+		return
+	}
+	pc := l.caller.pc(3)
+	r := slog.NewRecord(time.Now(), level, msg(), pc)
+	if 0 != pc {
+		r.AddAttrs(slog.String(FieldCaller, formatCaller(pc)))
+	}
+	if fields := ContextFields(ctx); len(fields) > 0 {
+		for _, k := range sortedKeys(fields) {
+			if v := fields[k]; !isFuncValue(v) {
+				r.AddAttrs(slogAttr(k, v))
+			}
+		}
+	}
+	_ = l.handler.Handle(ctx, r)
+}
+
+// Debug 实现 Logger 接口的调试级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SlogLogger) Debug(args ...interface{}) {
+	l.log(slog.LevelDebug, func() string { return fmt.Sprint(args...) })
+}
+
+// Debugf 实现 Logger 接口的格式化调试级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *SlogLogger) Debugf(format string, args ...interface{}) {
+	l.log(slog.LevelDebug, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Info 实现 Logger 接口的信息级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SlogLogger) Info(args ...interface{}) {
+	l.log(slog.LevelInfo, func() string { return fmt.Sprint(args...) })
+}
+
+// Infof 实现 Logger 接口的格式化信息级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *SlogLogger) Infof(format string, args ...interface{}) {
+	l.log(slog.LevelInfo, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Warn 实现 Logger 接口的警告级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SlogLogger) Warn(args ...interface{}) {
+	l.log(slog.LevelWarn, func() string { return fmt.Sprint(args...) })
+}
+
+// Warnf 实现 Logger 接口的格式化警告级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *SlogLogger) Warnf(format string, args ...interface{}) {
+	l.log(slog.LevelWarn, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Error 实现 Logger 接口的错误级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SlogLogger) Error(args ...interface{}) {
+	l.log(slog.LevelError, func() string { return fmt.Sprint(args...) })
+}
+
+// Errorf 实现 Logger 接口的格式化错误级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *SlogLogger) Errorf(format string, args ...interface{}) {
+	l.log(slog.LevelError, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Fatal 实现 Logger 接口的致命错误级别日志记录。
+// 记录日志后会导致程序以状态码 1 退出。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SlogLogger) Fatal(args ...interface{}) {
+	l.log(SlogLevelFatal, func() string { return fmt.Sprint(args...) })
+	_ = l.Sync()
+	os.Exit(1)
+}
+
+// Fatalf 实现 Logger 接口的格式化致命错误级别日志记录。
+// 记录日志后会导致程序以状态码 1 退出。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *SlogLogger) Fatalf(format string, args ...interface{}) {
+	l.log(SlogLevelFatal, func() string { return fmt.Sprintf(format, args...) })
+	_ = l.Sync()
+	os.Exit(1)
+}
+
+// DebugContext 实现 Logger 接口的调试级别日志记录，ctx 交给处理器，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SlogLogger) DebugContext(ctx context.Context, args ...interface{}) {
+	l.logContext(ctx, slog.LevelDebug, func() string { return fmt.Sprint(args...) })
+}
+
+// InfoContext 实现 Logger 接口的信息级别日志记录，ctx 交给处理器，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SlogLogger) InfoContext(ctx context.Context, args ...interface{}) {
+	l.logContext(ctx, slog.LevelInfo, func() string { return fmt.Sprint(args...) })
+}
+
+// WarnContext 实现 Logger 接口的警告级别日志记录，ctx 交给处理器，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SlogLogger) WarnContext(ctx context.Context, args ...interface{}) {
+	l.logContext(ctx, slog.LevelWarn, func() string { return fmt.Sprint(args...) })
+}
+
+// ErrorContext 实现 Logger 接口的错误级别日志记录，ctx 交给处理器，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SlogLogger) ErrorContext(ctx context.Context, args ...interface{}) {
+	l.logContext(ctx, slog.LevelError, func() string { return fmt.Sprint(args...) })
+}
+
+// WithContext 实现 Logger 接口的 context 字段添加方法。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//
+// 返回值：
+//   - Logger：返回一个包含提取的字段的新 Logger 实例，没有可提取的字段时返回原实例。
+func (l *SlogLogger) WithContext(ctx context.Context) Logger {
+	fields := ContextFields(ctx)
+	if 0 == len(fields) {
+		return l
+	}
+	return l.WithFields(fields)
+}
+
+// WithError 实现 Logger 接口的错误字段添加方法。
+//
+// 参数：
+//   - err：要记录的错误。
+//
+// 返回值：
+//   - Logger：返回一个包含错误信息、错误类型与调用堆栈的新 Logger 实例，err 为 nil 时返回原实例。
+func (l *SlogLogger) WithError(err error) Logger {
+	if nil == err {
+		return l
+	}
+	return l.WithFields(errorFields(err))
+}
+
+// WithField 实现 Logger 接口的单字段添加方法。
+// 函数类型的字段值无法输出，会被忽略。
+//
+// 参数：
+//   - key：字段名。
+//   - value：字段值。
+//
+// 返回值：
+//   - Logger：返回一个包含新字段的新 Logger 实例。
+func (l *SlogLogger) WithField(key string, value interface{}) Logger {
+	if isFuncValue(value) {
+		return l
+	}
+	return &SlogLogger{
+		handler: l.handler.WithAttrs([]slog.Attr{slogAttr(key, value)}),
+		level:   l.level,
+		output:  l.output,
+		hooks:   l.hooks,
+		caller:  l.caller,
+	}
+}
+
+// WithFields 实现 Logger 接口的多字段添加方法。
+// 字段按字段名的字典序添加；函数类型的字段值无法输出，会被忽略。
+//
+// 参数：
+//   - fields：要添加的字段映射。
+//
+// 返回值：
+//   - Logger：返回一个包含新字段的新 Logger 实例。
+func (l *SlogLogger) WithFields(fields map[string]interface{}) Logger {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, k := range sortedKeys(fields) {
+		if v := fields[k]; !isFuncValue(v) {
+			attrs = append(attrs, slogAttr(k, v))
+		}
+	}
+	if 0 == len(attrs) {
+		return l
+	}
+	return &SlogLogger{
+		handler: l.handler.WithAttrs(attrs),
+		level:   l.level,
+		output:  l.output,
+		hooks:   l.hooks,
+		caller:  l.caller,
+	}
+}
+
+// AddHook 实现 Logger 接口的钩子添加方法。
+// 钩子在处理器输出日志之后调用，通过 Slog 方法取得的 *slog.Logger 记录的日志同样会调用钩子。
+//
+// 参数：
+//   - levels：触发钩子的日志级别，为空时对全部级别触发。
+//   - fn：钩子函数，为 nil 时不添加。
+func (l *SlogLogger) AddHook(levels []Level, fn func(Entry)) {
+	l.hooks.add(levels, fn)
+}
+
+// withCaller 返回记录调用位置的实例。
+func (l *SlogLogger) withCaller(skip int) Logger {
+	n := *l
+	n.caller = caller{enabled: true, skip: skip}
+	return &n
+}
+
+// withCallerSkip 返回额外跳过 n 个栈帧的实例，没有开启记录调用位置时返回 l 本身。
+func (l *SlogLogger) withCallerSkip(n int) Logger {
+	if !l.caller.enabled {
+		return l
+	}
+	return l.withCaller(l.caller.skip + n)
+}
+
+// Sync 实现 Logger 接口的缓冲日志写入方法。
+//
+// 返回值：
+//   - error：写入输出目标失败时返回错误，使用自定义的处理器、输出到标准输出或已经关闭时返回 nil。
+func (l *SlogLogger) Sync() error {
+	return l.output.sync()
+}
+
+// Close 实现 Logger 接口的资源释放方法，写入缓冲的日志并关闭打开的日志文件与滚动写入器。
+// 通过 WithSlogWriter 传入的写入器与自定义的处理器不由日志实例关闭。
+// 通过 WithField 等方法派生的实例共享输出目标，关闭后它们同样不再输出日志。
+//
+// 返回值：
+//   - error：关闭失败时返回错误，重复调用返回第一次关闭的结果。
+func (l *SlogLogger) Close() error {
+	return l.output.close()
+}
+
+// slogAttr 创建字段对应的属性，字段值在输出时才经过注册的编码器转换。
+func slogAttr(key string, value interface{}) slog.Attr {
+	return slog.Any(key, slogFieldValue{v: value})
+}
+
+// LogValue 实现了 slog.LogValuer 接口，返回经过编码器转换的字段值。
+func (v slogFieldValue) LogValue() slog.Value {
+	return slog.AnyValue(encodeField(v.v))
+}
+
+// Enabled 在处理器的级别检查之前检查 SlogLogger 的日志级别。
+func (h *slogLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.Handler.Enabled(ctx, level)
+}
+
+// WithAttrs 返回添加了属性的处理器，保留级别检查。
+func (h *slogLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &slogLevelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+// WithGroup 返回添加了分组的处理器，保留级别检查。
+func (h *slogLevelHandler) WithGroup(name string) slog.Handler {
+	return &slogLevelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordHandler 是记录日志条目的 slog.Handler，只接受 WarnLevel 及以上的日志。
+type recordHandler struct {
+	records *[]slog.Record
+	attrs   []slog.Attr
+}
+
+func (h *recordHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.LevelWarn
+}
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	r.AddAttrs(h.attrs...)
+	*h.records = append(*h.records, r)
+	return nil
+}
+
+func (h *recordHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &recordHandler{records: h.records, attrs: append(append([]slog.Attr(nil), h.attrs...), attrs...)}
+}
+
+func (h *recordHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+// TestSlogLogger_JSON 测试 JSON 格式的级别、时间格式与字段，派生不影响原 Logger，函数类型的字段值被忽略。
+func TestSlogLogger_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewSlogLogger(WithSlogWriter(&buf), WithSlogTimestampFormat(timestampFormat))
+	require.NoError(t, err)
+
+	logger.Debug("hidden")
+	parent := logger.WithField("request_id", "r1")
+	child := parent.WithFields(map[string]interface{}{"user": "alice", "cost": 1500 * time.Millisecond, "fn": func() {}})
+	logger.Info("base")
+	child.Warnf("child %d", 1)
+	parent.WithField("cb", func() {}).Error("parent")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "INFO", lines[0]["level"])
+	assert.Equal(t, "base", lines[0]["msg"])
+	assert.NotContains(t, lines[0], "request_id")
+	_, err = time.Parse(timestampFormat, lines[0]["time"].(string))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "WARN", lines[1]["level"])
+	assert.Equal(t, "child 1", lines[1]["msg"])
+	assert.Equal(t, "r1", lines[1]["request_id"])
+	assert.Equal(t, "alice", lines[1]["user"])
+	assert.Equal(t, map[string]interface{}{"text": "1.5s", "ms": 1500.0}, lines[1]["cost"])
+	assert.NotContains(t, lines[1], "fn")
+
+	assert.Equal(t, "ERROR", lines[2]["level"])
+	assert.NotContains(t, lines[2], "user")
+	assert.NotContains(t, lines[2], "cb")
+}
+
+// TestSlogLogger_Text 测试文本格式使用编码器转换字段值。
+func TestSlogLogger_Text(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewSlogLogger(WithSlogWriter(&buf), WithSlogFormatType(TextFormat))
+	require.NoError(t, err)
+
+	logger.WithField("err", errors.New("boom")).WithField("cost", time.Second).Error("failed")
+	out := buf.String()
+	assert.Contains(t, out, "level=ERROR")
+	assert.Contains(t, out, `msg=failed`)
+	assert.Contains(t, out, "err=boom")
+	assert.Contains(t, out, "cost=1s")
+}
+
+// TestSlogLogger_Level 测试级别映射，派生的 Logger 与 Slog 返回的实例共享日志级别，FATAL 级别按名称输出。
+func TestSlogLogger_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewSlogLogger(WithSlogWriter(&buf), WithSlogLevel(WarnLevel))
+	require.NoError(t, err)
+	assert.Equal(t, WarnLevel, logger.GetLevel())
+
+	child := logger.WithField("k", "v")
+	std := logger.(*SlogLogger).Slog()
+	child.Info("hidden")
+	std.Info("hidden")
+
+	logger.SetLevel(DebugLevel)
+	assert.Equal(t, DebugLevel, child.GetLevel())
+	child.Debug("shown")
+	std.Debug("shown")
+	logger.SetLevel(FatalLevel)
+	assert.Equal(t, FatalLevel, logger.GetLevel())
+	logger.(*SlogLogger).log(SlogLevelFatal, func() string { return "fatal" })
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "v", lines[0]["k"])
+	assert.Equal(t, "DEBUG", lines[1]["level"])
+	assert.Equal(t, "FATAL", lines[2]["level"])
+}
+
+// TestSlogLogger_Handler 测试自定义处理器：处理器与 SlogLogger 的日志级别同时生效，字段交给处理器。
+func TestSlogLogger_Handler(t *testing.T) {
+	var records []slog.Record
+	logger, err := NewSlogLogger(WithSlogHandler(&recordHandler{records: &records}), WithSlogLevel(ErrorLevel))
+	require.NoError(t, err)
+
+	logger.Warn("hidden by logger level")
+	logger.SetLevel(DebugLevel)
+	logger.Info("hidden by handler")
+	logger.WithField("user", "alice").Warn("shown")
+
+	require.Len(t, records, 1)
+	assert.Equal(t, "shown", records[0].Message)
+	assert.Equal(t, slog.LevelWarn, records[0].Level)
+	records[0].Attrs(func(a slog.Attr) bool {
+		assert.Equal(t, "user", a.Key)
+		assert.Equal(t, "alice", a.Value.Resolve().Any())
+		return true
+	})
+}
+
+// TestNewLogger_Slog 测试通过 NewLogger 创建 slog 日志实例，不支持的格式返回错误。
+func TestNewLogger_Slog(t *testing.T) {
+	logger, err := NewLogger(WithLogType(LogTypeSlog), WithLevel(DebugLevel))
+	require.NoError(t, err)
+	assert.IsType(t, &SlogLogger{}, logger)
+	assert.Equal(t, DebugLevel, logger.GetLevel())
+
+	_, err = NewLogger(WithLogType(LogTypeSlog), WithFormatType("xml"))
+	assert.Error(t, err)
+}
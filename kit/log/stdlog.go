@@ -5,14 +5,22 @@
 package log
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"os"
-	"path/filepath"
+	"slices"
+	"sync/atomic"
+
+	kitfs "github.com/fsyyft-go/monorepo/kit/fs"
+	kitstrings "github.com/fsyyft-go/monorepo/kit/strings"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
 )
 
 const (
+	// outputCallDepth 是调用 log.Logger.Output 时跳过的栈帧数：log、Info 等方法与调用方。
+	outputCallDepth = 3
 	// defaultFilePermission 默认的文件权限模式。
 	defaultFilePermission = 0666
 	// defaultDirPermission 默认的目录权限模式。
@@ -31,8 +39,18 @@ type (
 		logger *log.Logger
 		// fields 存储结构化字段信息。
 		fields map[string]interface{}
-		// level 存储当前的日志级别。
-		level Level
+		// keys 是按字典序排列的字段名，在添加字段时排序，输出时不再分配内存。
+		keys []string
+		// level 存储当前的日志级别，与通过 WithField 等方法派生的实例共享，支持运行时并发修改。
+		level *atomic.Int32
+		// output 是输出目标，与派生的实例共享，为 nil 时 Sync 与 Close 不做任何操作。
+		output *output
+		// hooks 是通过 AddHook 添加的钩子，与派生的实例共享。
+		hooks *hooks
+		// caller 是记录调用位置的配置。
+		caller caller
+		// clock 是生成钩子日志时间使用的时钟；输出内容中的时间由标准库的 log 包生成，不受该时钟影响。
+		clock kittime.Clock
 	}
 )
 
@@ -45,41 +63,58 @@ type (
 //   - Logger：返回创建的日志实例。
 //   - error：返回创建过程中可能发生的错误。
 func NewStdLogger(output string) (Logger, error) {
-	var writer io.Writer = os.Stdout
-
-	// 如果指定了输出目录，配置文件输出。
-	if output != "" {
-		// 确保日志文件所在的目录存在。
-		// 使用 0755 权限确保目录可读可执行，且所有者可写。
-		if err := os.MkdirAll(filepath.Dir(output), defaultDirPermission); nil != err {
-			return nil, err
-		}
+	return newStdLogger(nil, []Sink{{Output: output}}, nil, nil)
+}
 
-		// 打开或创建日志文件。
-		// 使用 0666 权限确保文件可读可写。
-		file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, defaultFilePermission) // nolint:gosec
-		if nil != err {
-			return nil, err
-		}
-		writer = file
+// NewStdLoggerWithWriter 创建一个写入 w 的 StdLogger 实例。
+//
+// 参数：
+//   - w：日志的输出目标，例如网络写入器或测试使用的内存缓冲区，不由日志实例关闭，为 nil 时输出到标准输出。
+//
+// 返回值：
+//   - Logger：返回创建的日志实例。
+func NewStdLoggerWithWriter(w io.Writer) Logger {
+	// 不打开文件，不会失败。
+	l, _ := newStdLogger(nil, []Sink{{Writer: w}}, nil, nil)
+	return l
+}
+
+// newStdLogger 创建同时写入 sinks 的 StdLogger，日志文件在 fsys 中创建，fsys 为 nil 时使用磁盘；
+// wrap 不为 nil 时用于包装每个输出目标；clock 为钩子使用的时钟，为 nil 时使用系统时钟。
+// StdLogger 只输出文本格式，忽略 Sink 的 FormatType。
+func newStdLogger(fsys kitfs.FS, sinks []Sink, wrap func(io.Writer) io.Writer, clock kittime.Clock) (Logger, error) {
+	outs, err := openSinks(sinks, func(path string) (io.Writer, error) {
+		// 打开或创建日志文件，所在目录不存在时一并创建。
+		// 使用 0755 权限确保目录可读可执行，且所有者可写；使用 0666 权限确保文件可读可写。
+		return kitfs.OpenAppendFS(fsys, path, defaultFilePermission, defaultDirPermission)
+	}, wrap)
+	if nil != err {
+		return nil, err
 	}
+	out := newMultiOutput(outs)
 
-	return &StdLogger{
+	l := &StdLogger{
 		// 创建标准库日志实例，启用时间戳。
-		logger: log.New(writer, "", log.LstdFlags),
+		logger: log.New(out.w, "", log.LstdFlags),
 		// 初始化结构化字段映射。
 		fields: make(map[string]interface{}),
-		// 默认使用 InfoLevel。
-		level: InfoLevel,
-	}, nil
+		level:  new(atomic.Int32),
+		output: out,
+		hooks:  newHooks(),
+		clock:  kittime.OrReal(clock),
+	}
+	// 默认使用 InfoLevel。
+	l.level.Store(int32(InfoLevel))
+	return l, nil
 }
 
 // SetLevel 实现 Logger 接口的日志级别设置方法。
+// 通过 WithField 等方法派生的实例共享日志级别，可以在记录日志的同时并发调用。
 //
 // 参数：
 //   - level：要设置的日志级别。
 func (l *StdLogger) SetLevel(level Level) {
-	l.level = level
+	l.level.Store(int32(level))
 }
 
 // GetLevel 实现 Logger 接口的日志级别获取方法。
@@ -87,7 +122,7 @@ func (l *StdLogger) SetLevel(level Level) {
 // 返回值：
 //   - Level：返回当前日志记录器的日志级别。
 func (l *StdLogger) GetLevel() Level {
-	return l.level
+	return Level(l.level.Load())
 }
 
 // shouldLog 检查给定的日志级别是否应该被记录。
@@ -98,25 +133,54 @@ func (l *StdLogger) GetLevel() Level {
 // 返回值：
 //   - bool：如果应该记录该级别的日志，则返回 true，否则返回 false。
 func (l *StdLogger) shouldLog(level Level) bool {
-	return level >= l.level
+	return level >= l.GetLevel()
 }
 
-// formatFields 格式化结构化字段为字符串。
+// appendPrefix 将日志级别与结构化字段追加到 dst，字段按字段名的字典序输出。
+//
+// 参数：
+//   - dst：追加的目标。
+//   - levelStr：日志级别的字符串表示。
+//   - caller：调用位置，不为空时作为 caller 字段输出，覆盖同名的字段。
 //
 // 返回值：
-//   - string：返回格式化后的字段字符串，如果没有字段则返回空字符串。
-func (l *StdLogger) formatFields() string {
-	if len(l.fields) == 0 {
-		return ""
+//   - []byte：追加之后的切片，格式为 "[INFO] [k1=v1 k2=v2] "，没有字段时为 "[INFO] "。
+func (l *StdLogger) appendPrefix(dst []byte, levelStr, caller string) []byte {
+	dst = append(dst, levelStr...)
+	dst = append(dst, ' ')
+	if 0 == len(l.keys) && "" == caller {
+		return dst
 	}
-	fields := "["
-	for k, v := range l.fields {
-		fields += fmt.Sprintf("%s=%v ", k, v)
+	dst = append(dst, '[')
+	n := 0
+	for _, k := range l.keys {
+		if "" != caller && k >= FieldCaller {
+			dst = appendStdField(dst, n, FieldCaller, caller)
+			n++
+			caller = ""
+			if FieldCaller == k {
+				continue
+			}
+		}
+		dst = appendStdField(dst, n, k, encodeField(l.fields[k]))
+		n++
 	}
-	return fields[:len(fields)-1] + "]"
+	if "" != caller {
+		dst = appendStdField(dst, n, FieldCaller, caller)
+	}
+	return append(dst, "] "...)
+}
+
+// appendStdField 将第 i 个字段追加到 dst，字段之间以空格分隔。
+func appendStdField(dst []byte, i int, key string, value interface{}) []byte {
+	if i > 0 {
+		dst = append(dst, ' ')
+	}
+	return kitstrings.AppendField(dst, key, value)
 }
 
 // log 记录指定级别的日志。
+// 日志内容在复用的缓冲区中拼接，通过零复制转换交给标准库输出，Output 返回前会复制内容，缓冲区随后放回池中。
 //
 // 参数：
 //   - logLevel：日志级别。
@@ -126,12 +190,14 @@ func (l *StdLogger) log(logLevel Level, levelStr string, args ...interface{}) {
 	if !l.shouldLog(logLevel) {
 		return
 	}
-	fields := l.formatFields()
-	if fields != "" {
-		l.logger.Printf("%s %s %v", levelStr, fields, fmt.Sprint(args...))
-	} else {
-		l.logger.Printf("%s %v", levelStr, fmt.Sprint(args...))
-	}
+	buf := kitstrings.GetBuffer()
+	defer kitstrings.PutBuffer(buf)
+	caller := l.caller.field(2)
+	buf.B = l.appendPrefix(buf.B, levelStr, caller)
+	start := len(buf.B)
+	buf.B = fmt.Append(buf.B, args...)
+	_ = l.logger.Output(outputCallDepth, kitstrings.FromBytes(buf.B))
+	l.fire(logLevel, buf.B[start:], caller)
 }
 
 // logf 记录指定级别的格式化日志。
@@ -145,12 +211,31 @@ func (l *StdLogger) logf(logLevel Level, levelStr string, format string, args ..
 	if !l.shouldLog(logLevel) {
 		return
 	}
-	fields := l.formatFields()
-	if fields != "" {
-		l.logger.Printf("%s %s "+format, append([]interface{}{levelStr, fields}, args...)...)
-	} else {
-		l.logger.Printf("%s "+format, append([]interface{}{levelStr}, args...)...)
+	buf := kitstrings.GetBuffer()
+	defer kitstrings.PutBuffer(buf)
+	caller := l.caller.field(2)
+	buf.B = l.appendPrefix(buf.B, levelStr, caller)
+	start := len(buf.B)
+	buf.B = fmt.Appendf(buf.B, format, args...)
+	_ = l.logger.Output(outputCallDepth, kitstrings.FromBytes(buf.B))
+	l.fire(logLevel, buf.B[start:], caller)
+}
+
+// fire 调用在 level 级别触发的钩子，没有钩子时不复制日志内容与字段。
+//
+// 参数：
+//   - level：日志级别。
+//   - msg：日志内容，调用返回后不再被引用。
+//   - caller：调用位置，不为空时添加到字段中。
+func (l *StdLogger) fire(level Level, msg []byte, caller string) {
+	if !l.hooks.enabled(level) {
+		return
+	}
+	fields := hookFields(l.fields)
+	if "" != caller {
+		fields[FieldCaller] = caller
 	}
+	l.hooks.fire(Entry{Time: l.clock.Now(), Level: level, Message: string(msg), Fields: fields})
 }
 
 // Debug 实现 Logger 接口的调试级别日志记录。
@@ -228,6 +313,7 @@ func (l *StdLogger) Errorf(format string, args ...interface{}) {
 //   - args：要记录的内容，支持任意类型的值。
 func (l *StdLogger) Fatal(args ...interface{}) {
 	l.log(FatalLevel, "[FATAL]", args...)
+	_ = l.Sync()
 	os.Exit(1)
 }
 
@@ -239,9 +325,87 @@ func (l *StdLogger) Fatal(args ...interface{}) {
 //   - args：格式化参数。
 func (l *StdLogger) Fatalf(format string, args ...interface{}) {
 	l.logf(FatalLevel, "[FATAL]", format, args...)
+	_ = l.Sync()
 	os.Exit(1)
 }
 
+// DebugContext 实现 Logger 接口的调试级别日志记录，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *StdLogger) DebugContext(ctx context.Context, args ...interface{}) {
+	l.withContext(ctx, DebugLevel).log(DebugLevel, "[DEBUG]", args...)
+}
+
+// InfoContext 实现 Logger 接口的信息级别日志记录，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *StdLogger) InfoContext(ctx context.Context, args ...interface{}) {
+	l.withContext(ctx, InfoLevel).log(InfoLevel, "[INFO]", args...)
+}
+
+// WarnContext 实现 Logger 接口的警告级别日志记录，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *StdLogger) WarnContext(ctx context.Context, args ...interface{}) {
+	l.withContext(ctx, WarnLevel).log(WarnLevel, "[WARN]", args...)
+}
+
+// ErrorContext 实现 Logger 接口的错误级别日志记录，并添加从 ctx 中提取的字段。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//   - args：要记录的内容，支持任意类型的值。
+func (l *StdLogger) ErrorContext(ctx context.Context, args ...interface{}) {
+	l.withContext(ctx, ErrorLevel).log(ErrorLevel, "[ERROR]", args...)
+}
+
+// withContext 返回添加了从 ctx 中提取的字段的实例，日志级别未启用或没有字段时返回 l 本身。
+func (l *StdLogger) withContext(ctx context.Context, level Level) *StdLogger {
+	if !l.shouldLog(level) {
+		return l
+	}
+	fields := ContextFields(ctx)
+	if 0 == len(fields) {
+		return l
+	}
+	return l.WithFields(fields).(*StdLogger)
+}
+
+// WithContext 实现 Logger 接口的 context 字段添加方法。
+//
+// 参数：
+//   - ctx：携带链路标识、请求标识等信息的上下文。
+//
+// 返回值：
+//   - Logger：返回一个包含提取的字段的新 Logger 实例，没有可提取的字段时返回原实例。
+func (l *StdLogger) WithContext(ctx context.Context) Logger {
+	fields := ContextFields(ctx)
+	if 0 == len(fields) {
+		return l
+	}
+	return l.WithFields(fields)
+}
+
+// WithError 实现 Logger 接口的错误字段添加方法。
+//
+// 参数：
+//   - err：要记录的错误。
+//
+// 返回值：
+//   - Logger：返回一个包含错误信息、错误类型与调用堆栈的新 Logger 实例，err 为 nil 时返回原实例。
+func (l *StdLogger) WithError(err error) Logger {
+	if nil == err {
+		return l
+	}
+	return l.WithFields(errorFields(err))
+}
+
 // WithField 实现 Logger 接口的单字段添加方法。
 //
 // 参数：
@@ -259,7 +423,12 @@ func (l *StdLogger) WithField(key string, value interface{}) Logger {
 	return &StdLogger{
 		logger: l.logger,
 		fields: newFields,
+		keys:   sortedKeys(newFields),
 		level:  l.level,
+		output: l.output,
+		hooks:  l.hooks,
+		caller: l.caller,
+		clock:  l.clock,
 	}
 }
 
@@ -281,6 +450,68 @@ func (l *StdLogger) WithFields(fields map[string]interface{}) Logger {
 	return &StdLogger{
 		logger: l.logger,
 		fields: newFields,
+		keys:   sortedKeys(newFields),
 		level:  l.level,
+		output: l.output,
+		hooks:  l.hooks,
+		caller: l.caller,
+		clock:  l.clock,
+	}
+}
+
+// AddHook 实现 Logger 接口的钩子添加方法。
+//
+// 参数：
+//   - levels：触发钩子的日志级别，为空时对全部级别触发。
+//   - fn：钩子函数，为 nil 时不添加。
+func (l *StdLogger) AddHook(levels []Level, fn func(Entry)) {
+	l.hooks.add(levels, fn)
+}
+
+// withCaller 返回记录调用位置的实例。
+func (l *StdLogger) withCaller(skip int) Logger {
+	n := *l
+	n.caller = caller{enabled: true, skip: skip}
+	return &n
+}
+
+// withCallerSkip 返回额外跳过 n 个栈帧的实例，没有开启记录调用位置时返回 l 本身。
+func (l *StdLogger) withCallerSkip(n int) Logger {
+	if !l.caller.enabled {
+		return l
+	}
+	return l.withCaller(l.caller.skip + n)
+}
+
+// Sync 实现 Logger 接口的缓冲日志写入方法。
+//
+// 返回值：
+//   - error：写入输出目标失败时返回错误，输出到标准输出或已经关闭时返回 nil。
+func (l *StdLogger) Sync() error {
+	return l.output.sync()
+}
+
+// Close 实现 Logger 接口的资源释放方法，写入缓冲的日志并关闭打开的日志文件。
+// 通过 WithField 等方法派生的实例共享日志文件，关闭后它们同样不再输出日志。
+//
+// 返回值：
+//   - error：关闭失败时返回错误，重复调用返回第一次关闭的结果。
+func (l *StdLogger) Close() error {
+	return l.output.close()
+}
+
+// sortedKeys 返回按字典序排列的字段名。
+//
+// 参数：
+//   - fields：字段映射。
+//
+// 返回值：
+//   - []string：排序后的字段名。
+func sortedKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
 	}
+	slices.Sort(keys)
+	return keys
 }
@@ -0,0 +1,41 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package metrics 提供了标准的 Prometheus 指标注册与指标服务，统一各个服务的指标端点。
+
+主要功能：
+
+  - 一次注册：Register 将 Go 运行时、进程、构建信息与 WithCollectors 传入的指标注册到指定的 Registerer
+  - 不依赖组件：kit 各组件通过各自的 Collectors 函数导出指标，由使用方按实际使用的组件传入
+  - 重复安全：已经注册过的采集器会被跳过，可以与使用方自行注册的 kit 指标共存
+  - 指标服务：Server 在独立的端口上输出指标，实现了 kit/runtime 的 Runner 接口
+  - 处理函数：Handler 可以挂载到已有的路由上，采集部分失败时仍输出其余指标
+
+基本使用：
+
+	err := metrics.Register(prometheus.DefaultRegisterer,
+	    metrics.WithCollectors(kithttp.Collectors()...),
+	    metrics.WithCollectors(cache.Collectors()...),
+	)
+	if nil != err {
+	    return err
+	}
+	srv := metrics.NewServer(metrics.WithAddress(":9090"))
+	if err := srv.Start(ctx); nil != err {
+	    return err
+	}
+	defer srv.Stop(context.Background())
+
+挂载到已有的路由：
+
+	mux.Handle("/metrics", metrics.Handler(prometheus.DefaultGatherer))
+
+使用自定义的 Registry：
+
+	reg := prometheus.NewRegistry()
+	_ = metrics.Register(reg, metrics.WithCollectors(orderCounter))
+	srv := metrics.NewServer(metrics.WithGatherer(reg))
+*/
+package metrics
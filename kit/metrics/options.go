@@ -0,0 +1,154 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+)
+
+// 以下为指标注册与指标服务的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// processDefault 为是否默认注册进程指标。
+	processDefault = true
+	// addressDefault 为指标服务默认监听的地址。
+	addressDefault = ":9090"
+	// pathDefault 为指标服务默认的路径。
+	pathDefault = "/metrics"
+)
+
+type (
+	// Option 定义了指标注册与指标服务的配置选项。
+	Option func(*options)
+
+	// options 包含指标注册与指标服务的配置。
+	options struct {
+		// process 表示是否注册进程指标。
+		process bool
+		// collectors 是额外注册的采集器。
+		collectors []prometheus.Collector
+
+		// address 是指标服务监听的地址。
+		address string
+		// path 是指标服务的路径。
+		path string
+		// gatherer 是指标服务输出的指标来源。
+		gatherer prometheus.Gatherer
+		// logger 是记录指标服务错误使用的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+	}
+)
+
+// WithProcess 设置是否注册进程指标，包括 CPU、内存与文件描述符等，仅对 Register 生效。
+// 进程指标依赖 /proc 文件系统，在不支持的平台上只会输出部分指标。
+//
+// 参数：
+//   - process：是否注册进程指标，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithProcess(process bool) Option {
+	return func(o *options) {
+		o.process = process
+	}
+}
+
+// WithCollectors 追加需要一并注册的采集器，例如 kit 各组件 Collectors 函数返回的指标或服务自身的业务指标，仅对 Register 生效。
+// 可以多次使用，采集器按顺序追加。
+//
+// 参数：
+//   - collectors：需要注册的采集器。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCollectors(collectors ...prometheus.Collector) Option {
+	return func(o *options) {
+		o.collectors = append(o.collectors, collectors...)
+	}
+}
+
+// WithAddress 设置指标服务监听的地址，仅对 NewServer 生效。
+//
+// 参数：
+//   - address：监听地址，默认为 :9090。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithAddress(address string) Option {
+	return func(o *options) {
+		o.address = address
+	}
+}
+
+// WithPath 设置指标服务的路径，仅对 NewServer 生效。
+//
+// 参数：
+//   - path：路径，默认为 /metrics。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPath(path string) Option {
+	return func(o *options) {
+		o.path = path
+	}
+}
+
+// WithGatherer 设置指标服务输出的指标来源，仅对 NewServer 生效。
+// 使用自定义的 Registry 时，应当与 Register 使用同一个 Registry。
+//
+// 参数：
+//   - gatherer：指标来源，默认为 prometheus.DefaultGatherer。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithGatherer(gatherer prometheus.Gatherer) Option {
+	return func(o *options) {
+		o.gatherer = gatherer
+	}
+}
+
+// WithLogger 设置记录指标服务错误使用的日志实例，仅对 NewServer 与 Handler 生效。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		process:  processDefault,
+		address:  addressDefault,
+		path:     pathDefault,
+		gatherer: prometheus.DefaultGatherer,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if "" == o.path || '/' != o.path[0] {
+		o.path = pathDefault
+	}
+	if nil == o.gatherer {
+		o.gatherer = prometheus.DefaultGatherer
+	}
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package metrics
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// Register 将标准的采集器注册到 reg：Go 运行时、进程、构建信息，以及通过 WithCollectors 传入的采集器。
+// kit 各组件通过各自的 Collectors 函数导出指标，由使用方按实际使用的组件传入，本包除记录日志使用的 kit/log 外不依赖其他 kit 组件。
+// 已经注册过的采集器会被跳过，因此可以重复调用，也可以与使用方自行注册的 kit 指标共存。
+//
+// 参数：
+//   - reg：注册的目标，通常为 prometheus.DefaultRegisterer 或自定义的 Registry。
+//   - opts：配置选项，支持 WithProcess 与 WithCollectors。
+//
+// 返回值：
+//   - error：注册失败的采集器的错误，多个错误通过 errors.Join 合并。
+//
+// 示例：
+//
+//	err := metrics.Register(prometheus.DefaultRegisterer,
+//	    metrics.WithCollectors(kithttp.Collectors()...),
+//	    metrics.WithCollectors(cache.Collectors()...),
+//	)
+//	if nil != err {
+//	    return err
+//	}
+func Register(reg prometheus.Registerer, opts ...Option) error {
+	o := newOptions(opts...)
+
+	cs := []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewBuildInfoCollector(),
+	}
+	if o.process {
+		cs = append(cs, collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	}
+	cs = append(cs, o.collectors...)
+
+	var errs []error
+	for _, c := range cs {
+		if err := reg.Register(c); nil != err {
+			var are prometheus.AlreadyRegisteredError
+			if errors.As(err, &are) {
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
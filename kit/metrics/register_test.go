@@ -0,0 +1,81 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// familyNames 返回 g 中全部指标族的名称。
+func familyNames(t *testing.T, g prometheus.Gatherer) []string {
+	t.Helper()
+	mfs, err := g.Gather()
+	require.NoError(t, err)
+	names := make([]string, 0, len(mfs))
+	for _, mf := range mfs {
+		names = append(names, mf.GetName())
+	}
+	return names
+}
+
+// hasPrefix 判断 names 中是否有以 prefix 开头的名称。
+func hasPrefix(names []string, prefix string) bool {
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestRegister 测试默认注册 Go 运行时、进程、构建信息与传入的采集器，并且可以重复调用。
+func TestRegister(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "kit_test_requests_total", Help: "requests."}, []string{"name"})
+	require.NoError(t, Register(reg, WithCollectors(requests)))
+
+	names := familyNames(t, reg)
+	assert.Contains(t, names, "go_goroutines")
+	assert.Contains(t, names, "go_build_info")
+	assert.True(t, hasPrefix(names, "process_"))
+
+	// 传入的采集器已经注册，再次注册返回 AlreadyRegisteredError。
+	err := reg.Register(requests)
+	assert.ErrorAs(t, err, &prometheus.AlreadyRegisteredError{})
+
+	// 重复调用时跳过已经注册的采集器。
+	assert.NoError(t, Register(reg, WithCollectors(requests)))
+}
+
+// TestRegister_Options 测试关闭进程指标，以及多次追加自定义采集器。
+func TestRegister_Options(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	orders := prometheus.NewCounter(prometheus.CounterOpts{Name: "app_orders_total", Help: "orders."})
+	orders.Inc()
+	users := prometheus.NewCounter(prometheus.CounterOpts{Name: "app_users_total", Help: "users."})
+	users.Inc()
+	require.NoError(t, Register(reg, WithProcess(false), WithCollectors(orders), WithCollectors(users)))
+
+	names := familyNames(t, reg)
+	assert.Contains(t, names, "app_orders_total")
+	assert.Contains(t, names, "app_users_total")
+	assert.False(t, hasPrefix(names, "process_"))
+}
+
+// TestRegister_Conflict 测试与已注册指标冲突的采集器返回错误，其余采集器仍然注册。
+func TestRegister_Conflict(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	conflict := prometheus.NewGauge(prometheus.GaugeOpts{Name: "go_goroutines", Help: "conflict."})
+
+	err := Register(reg, WithCollectors(conflict))
+	require.Error(t, err)
+	assert.NotErrorAs(t, err, &prometheus.AlreadyRegisteredError{})
+	assert.Contains(t, familyNames(t, reg), "go_build_info")
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdnet "net"
+	stdhttp "net/http"
+	stdsync "sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+)
+
+const (
+	// readHeaderTimeout 是指标服务读取请求头的超时时间。
+	readHeaderTimeout = 5 * time.Second
+)
+
+type (
+	// Server 是输出 Prometheus 指标的 HTTP 服务。
+	// Server 实现了 kit/runtime 的 Runner 接口：Start 监听地址并在后台提供服务后立即返回，
+	// Stop 停止接受新请求并等待正在处理的请求完成。
+	Server struct {
+		// o 是服务的配置。
+		o *options
+		// srv 是底层的 HTTP 服务。
+		srv *stdhttp.Server
+
+		// mu 保护 listener。
+		mu stdsync.Mutex
+		// listener 是服务的监听器，Start 之前为 nil。
+		listener stdnet.Listener
+		// wg 等待服务协程退出。
+		wg stdsync.WaitGroup
+	}
+
+	// errorLog 将 promhttp 的错误输出到 kit/log。
+	errorLog struct {
+		// logger 是日志实例。
+		logger kitlog.Logger
+	}
+)
+
+// Handler 返回输出 g 中指标的 HTTP 处理函数。
+// 采集单个指标失败时仍输出其余指标，错误通过 kit/log 记录。
+//
+// 参数：
+//   - g：指标来源，通常为 prometheus.DefaultGatherer。
+//   - opts：配置选项，支持 WithLogger。
+//
+// 返回值：
+//   - http.Handler：输出指标的处理函数。
+//
+// 示例：
+//
+//	mux.Handle("/metrics", metrics.Handler(prometheus.DefaultGatherer))
+func Handler(g prometheus.Gatherer, opts ...Option) stdhttp.Handler {
+	o := newOptions(opts...)
+	return promhttp.HandlerFor(g, promhttp.HandlerOpts{
+		ErrorLog:      &errorLog{logger: o.getLogger()},
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// NewServer 创建输出 Prometheus 指标的 HTTP 服务。
+//
+// 参数：
+//   - opts：配置选项，支持 WithAddress、WithPath、WithGatherer 与 WithLogger。
+//
+// 返回值：
+//   - *Server：新的指标服务。
+//
+// 示例：
+//
+//	_ = metrics.Register(prometheus.DefaultRegisterer)
+//	srv := metrics.NewServer(metrics.WithAddress(":9090"))
+//	if err := srv.Start(ctx); nil != err {
+//	    return err
+//	}
+//	defer srv.Stop(context.Background())
+func NewServer(opts ...Option) *Server {
+	o := newOptions(opts...)
+
+	mux := stdhttp.NewServeMux()
+	mux.Handle(o.path, Handler(o.gatherer, opts...))
+
+	return &Server{
+		o: o,
+		srv: &stdhttp.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+// Start 监听地址并在后台提供服务，监听失败时返回错误。
+// ctx 结束时服务立即关闭，优雅停止应当使用 Stop。
+//
+// 参数：
+//   - ctx：服务的生命周期。
+//
+// 返回值：
+//   - error：监听失败的错误。
+func (s *Server) Start(ctx context.Context) error {
+	l, err := stdnet.Listen("tcp", s.o.address)
+	if nil != err {
+		return fmt.Errorf("kit/metrics: 监听 %s 失败：%w", s.o.address, err)
+	}
+	s.mu.Lock()
+	s.listener = l
+	s.mu.Unlock()
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = s.srv.Close()
+	})
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer stop()
+		if err := s.srv.Serve(l); nil != err && !errors.Is(err, stdhttp.ErrServerClosed) {
+			s.o.getLogger().WithField("error", err.Error()).Error("metrics server stopped")
+		}
+	}()
+	return nil
+}
+
+// Stop 停止接受新请求并等待正在处理的请求完成，截止时间到达时返回 ctx 的错误。
+//
+// 参数：
+//   - ctx：停止操作的截止时间。
+//
+// 返回值：
+//   - error：停止过程中的错误。
+func (s *Server) Stop(ctx context.Context) error {
+	err := s.srv.Shutdown(ctx)
+	s.wg.Wait()
+	return err
+}
+
+// Addr 返回服务实际监听的地址，Start 之前返回 nil。
+// 监听 :0 时可以通过该方法得到系统分配的端口。
+//
+// 返回值：
+//   - net.Addr：监听地址。
+func (s *Server) Addr() stdnet.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if nil == s.listener {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Println 实现 promhttp.Logger 接口。
+func (l *errorLog) Println(v ...interface{}) {
+	l.logger.Error(fmt.Sprint(v...))
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	stdsync "sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+)
+
+type (
+	// errorLogger 记录错误级别日志的 kitlog.Logger，只实现指标服务用到的方法。
+	errorLogger struct {
+		kitlog.Logger
+		mu       stdsync.Mutex
+		messages []string
+	}
+)
+
+func (l *errorLogger) Error(args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, fmt.Sprint(args...))
+}
+
+// Messages 返回已记录的日志。
+func (l *errorLogger) Messages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.messages...)
+}
+
+// get 请求 url，返回状态码与响应体。
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := stdhttp.Get(url)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+// TestServer 测试指标服务的启动、输出与停止。
+func TestServer(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	require.NoError(t, Register(reg, WithProcess(false)))
+
+	srv := NewServer(WithAddress("127.0.0.1:0"), WithPath("/internal/metrics"), WithGatherer(reg))
+	assert.Nil(t, srv.Addr())
+	require.NoError(t, srv.Start(context.Background()))
+	base := "http://" + srv.Addr().String()
+
+	code, body := get(t, base+"/internal/metrics")
+	assert.Equal(t, stdhttp.StatusOK, code)
+	assert.Contains(t, body, "go_goroutines")
+	code, _ = get(t, base+"/metrics")
+	assert.Equal(t, stdhttp.StatusNotFound, code)
+
+	require.NoError(t, srv.Stop(context.Background()))
+	_, err := stdhttp.Get(base + "/internal/metrics")
+	assert.Error(t, err)
+}
+
+// TestServer_Defaults 测试默认参数与非法参数的处理。
+func TestServer_Defaults(t *testing.T) {
+	o := newOptions(WithPath("metrics"), WithGatherer(nil))
+	assert.Equal(t, pathDefault, o.path)
+	assert.Equal(t, addressDefault, o.address)
+	assert.Equal(t, prometheus.DefaultGatherer, o.gatherer)
+	assert.NotNil(t, o.getLogger())
+}
+
+// TestServer_ListenError 测试地址被占用时 Start 返回错误。
+func TestServer_ListenError(t *testing.T) {
+	first := NewServer(WithAddress("127.0.0.1:0"))
+	require.NoError(t, first.Start(context.Background()))
+	defer func() {
+		_ = first.Stop(context.Background())
+	}()
+
+	second := NewServer(WithAddress(first.Addr().String()))
+	err := second.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kit/metrics")
+	// 没有启动的服务可以直接停止。
+	assert.NoError(t, second.Stop(context.Background()))
+}
+
+// TestServer_ContextCancel 测试 Start 的上下文结束时服务关闭。
+func TestServer_ContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := NewServer(WithAddress("127.0.0.1:0"))
+	require.NoError(t, srv.Start(ctx))
+	addr := "http://" + srv.Addr().String() + pathDefault
+
+	cancel()
+	require.Eventually(t, func() bool {
+		resp, err := stdhttp.Get(addr)
+		if nil == err {
+			_ = resp.Body.Close()
+		}
+		return nil != err
+	}, time.Second, 5*time.Millisecond)
+	assert.NoError(t, srv.Stop(context.Background()))
+}
+
+// TestHandler_GatherError 测试采集失败时仍输出其余指标，并记录错误日志。
+func TestHandler_GatherError(t *testing.T) {
+	logger := &errorLogger{}
+	reg := prometheus.NewRegistry()
+	require.NoError(t, Register(reg, WithProcess(false)))
+	g := prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		mfs, err := reg.Gather()
+		require.NoError(t, err)
+		return mfs, errors.New("collect failed")
+	})
+
+	rec := httptest.NewRecorder()
+	Handler(g, WithLogger(logger)).ServeHTTP(rec, httptest.NewRequest(stdhttp.MethodGet, "/metrics", nil))
+	assert.Equal(t, stdhttp.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+	require.Len(t, logger.Messages(), 1)
+	assert.Contains(t, logger.Messages()[0], "collect failed")
+}
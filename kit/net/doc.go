@@ -0,0 +1,57 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package net 提供了网络服务常用的辅助工具，使服务可以在停止时优雅地排空连接，并在启动时等待依赖就绪。
+
+主要功能：
+
+  - 优雅关闭的监听器：GracefulListener 跟踪已接受的连接，Shutdown 停止接受新连接并等待连接关闭
+  - 强制关闭：Shutdown 的上下文结束时强制关闭剩余连接，保证停止操作有确定的上限
+  - 生命周期集成：Server 实现了 kit/runtime 的 Runner 接口，可以与其他组件统一启动和停止
+  - 指标：记录打开的连接数、接受的连接总数与被强制关闭的连接总数
+  - 就绪探测：WaitReady 按 kit/runtime/retry 的退避策略探测地址，直到可以建立连接或健康检查返回 200
+
+基本使用：
+
+	ln, err := net.Listen("tcp", ":9000", net.WithName("echo"))
+	if nil != err {
+	    return err
+	}
+	srv := net.NewServer(ln, func(ctx context.Context, conn stdnet.Conn) {
+	    _, _ = io.Copy(conn, conn)
+	})
+	_ = srv.Start(ctx)
+
+	// 收到退出信号后最多等待 10 秒。
+	stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = srv.Stop(stopCtx)
+
+包装已有的监听器，例如交给 http.Server 或 grpc.Server 使用：
+
+	ln := net.NewGracefulListener(inner)
+	go func() { _ = grpcServer.Serve(ln) }()
+	_ = ln.Shutdown(stopCtx)
+
+启动时等待依赖就绪：
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := net.WaitReady(ctx, "tcp", "db:5432"); nil != err {
+	    return err
+	}
+	if err := net.WaitReady(ctx, "tcp", "api:8080", net.WithHealthURL("http://api:8080/healthz")); nil != err {
+	    return err
+	}
+
+由于包名与标准库相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "net"
+
+	    kitnet "github.com/fsyyft-go/monorepo/kit/net"
+	)
+*/
+package net
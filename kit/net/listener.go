@@ -0,0 +1,252 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package net
+
+import (
+	"context"
+	stdnet "net"
+	stdsync "sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// GracefulListener 是跟踪已接受连接的监听器，支持优雅关闭。
+	// 调用 Shutdown 后监听器停止接受新连接，并等待已接受的连接关闭；
+	// 上下文结束时仍未关闭的连接会被强制关闭。
+	// Shutdown 的签名与 kit/runtime 的 Runner.Stop 一致，可以直接在 Runner 的生命周期中使用。
+	// GracefulListener 的所有方法都是并发安全的。
+	GracefulListener struct {
+		// Listener 是被包装的监听器。
+		stdnet.Listener
+
+		// mu 保护 conns 与 closed。
+		mu stdsync.Mutex
+		// conns 是已接受且尚未关闭的连接集合。
+		conns map[*trackedConn]struct{}
+		// closed 表示监听器已停止接受新连接。
+		closed bool
+		// closeErr 是关闭被包装的监听器时返回的错误。
+		closeErr error
+		// closeOnce 保证被包装的监听器只关闭一次。
+		closeOnce stdsync.Once
+
+		// drained 在监听器已关闭且所有连接都关闭后关闭。
+		drained chan struct{}
+		// drainedOnce 保证 drained 只关闭一次。
+		drainedOnce stdsync.Once
+
+		// connections 记录当前打开的连接数，未启用指标时为 nil。
+		connections prometheus.Gauge
+		// accepted 记录接受的连接总数，未启用指标时为 nil。
+		accepted prometheus.Counter
+		// forceClosed 记录被强制关闭的连接总数，未启用指标时为 nil。
+		forceClosed prometheus.Counter
+	}
+
+	// trackedConn 是 GracefulListener 接受的连接，关闭时从监听器的连接集合中移除。
+	trackedConn struct {
+		stdnet.Conn
+
+		// listener 是接受该连接的监听器。
+		listener *GracefulListener
+		// closeOnce 保证连接只从集合中移除一次。
+		closeOnce stdsync.Once
+	}
+)
+
+// Listen 在指定的网络地址上监听，并返回支持优雅关闭的监听器。
+//
+// 参数：
+//   - network：网络类型，例如 tcp、tcp4、unix。
+//   - address：监听地址，例如 :8080。
+//   - opts：配置选项，支持 WithName 与 WithMetrics。
+//
+// 返回值：
+//   - *GracefulListener：支持优雅关闭的监听器。
+//   - error：监听失败时返回错误。
+//
+// 示例：
+//
+//	ln, err := net.Listen("tcp", ":9000", net.WithName("echo"))
+//	if nil != err {
+//	    return err
+//	}
+//	defer ln.Shutdown(context.Background())
+func Listen(network, address string, opts ...Option) (*GracefulListener, error) {
+	l, err := stdnet.Listen(network, address)
+	if nil != err {
+		return nil, err
+	}
+	return NewGracefulListener(l, opts...), nil
+}
+
+// NewGracefulListener 包装一个已有的监听器，使其支持优雅关闭。
+// 包装之后不应再直接调用被包装监听器的 Accept 与 Close。
+//
+// 参数：
+//   - l：被包装的监听器。
+//   - opts：配置选项，支持 WithName 与 WithMetrics。
+//
+// 返回值：
+//   - *GracefulListener：支持优雅关闭的监听器。
+func NewGracefulListener(l stdnet.Listener, opts ...Option) *GracefulListener {
+	o := newOptions(opts...)
+
+	gl := &GracefulListener{
+		Listener: l,
+		conns:    make(map[*trackedConn]struct{}),
+		drained:  make(chan struct{}),
+	}
+	if o.metrics {
+		gl.connections = MetricConnections.WithLabelValues(o.name)
+		gl.accepted = MetricAccepted.WithLabelValues(o.name)
+		gl.forceClosed = MetricForceClosed.WithLabelValues(o.name)
+	}
+	return gl
+}
+
+// Accept 等待并返回下一个连接，返回的连接在关闭时会自动停止跟踪。
+// 监听器关闭后返回 net.ErrClosed。
+//
+// 返回值：
+//   - net.Conn：新接受的连接。
+//   - error：监听器已关闭或接受失败时返回错误。
+func (l *GracefulListener) Accept() (stdnet.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if nil != err {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Accept 与 Close 并发时，关闭后才完成接受的连接不再交给调用方。
+	if l.closed {
+		_ = conn.Close()
+		return nil, stdnet.ErrClosed
+	}
+	tc := &trackedConn{Conn: conn, listener: l}
+	l.conns[tc] = struct{}{}
+	if nil != l.accepted {
+		l.accepted.Inc()
+		l.connections.Inc()
+	}
+	return tc, nil
+}
+
+// Close 停止接受新连接，已接受的连接不受影响。多次调用是安全的。
+//
+// 返回值：
+//   - error：关闭被包装的监听器失败时返回错误，多次调用返回相同的错误。
+func (l *GracefulListener) Close() error {
+	l.closeOnce.Do(func() {
+		l.mu.Lock()
+		l.closed = true
+		empty := 0 == len(l.conns)
+		l.mu.Unlock()
+
+		l.closeErr = l.Listener.Close()
+		if empty {
+			l.closeDrained()
+		}
+	})
+	return l.closeErr
+}
+
+// Shutdown 停止接受新连接，并等待所有已接受的连接关闭。
+// 上下文结束时强制关闭剩余的连接并返回上下文的错误。
+//
+// 参数：
+//   - ctx：上下文，其截止时间是等待连接关闭的最长时间。
+//
+// 返回值：
+//   - error：所有连接在上下文结束前关闭时返回关闭监听器的错误，否则返回上下文的错误。
+//
+// 示例：
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	if err := ln.Shutdown(ctx); errors.Is(err, context.DeadlineExceeded) {
+//	    log.Warn("部分连接被强制关闭")
+//	}
+func (l *GracefulListener) Shutdown(ctx context.Context) error {
+	err := l.Close()
+
+	select {
+	case <-l.drained:
+		return err
+	case <-ctx.Done():
+		// 上下文已结束但连接也已全部关闭时，视为正常完成。
+		select {
+		case <-l.drained:
+			return err
+		default:
+		}
+	}
+
+	l.mu.Lock()
+	conns := make([]*trackedConn, 0, len(l.conns))
+	for c := range l.conns {
+		conns = append(conns, c)
+	}
+	l.mu.Unlock()
+
+	for _, c := range conns {
+		_ = c.Close()
+		if nil != l.forceClosed {
+			l.forceClosed.Inc()
+		}
+	}
+	return ctx.Err()
+}
+
+// ActiveConns 返回已接受且尚未关闭的连接数。
+//
+// 返回值：
+//   - int：连接数。
+func (l *GracefulListener) ActiveConns() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.conns)
+}
+
+// Done 返回一个通道，监听器关闭且所有连接都关闭后该通道被关闭。
+//
+// 返回值：
+//   - <-chan struct{}：连接全部关闭时被关闭的通道。
+func (l *GracefulListener) Done() <-chan struct{} {
+	return l.drained
+}
+
+// remove 停止跟踪连接，监听器已关闭且没有剩余连接时通知等待方。
+func (l *GracefulListener) remove(c *trackedConn) {
+	l.mu.Lock()
+	delete(l.conns, c)
+	empty := l.closed && 0 == len(l.conns)
+	l.mu.Unlock()
+
+	if nil != l.connections {
+		l.connections.Dec()
+	}
+	if empty {
+		l.closeDrained()
+	}
+}
+
+// closeDrained 关闭 drained 通道。
+func (l *GracefulListener) closeDrained() {
+	l.drainedOnce.Do(func() {
+		close(l.drained)
+	})
+}
+
+// Close 关闭连接并停止跟踪。多次调用是安全的。
+func (c *trackedConn) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(func() {
+		c.listener.remove(c)
+	})
+	return err
+}
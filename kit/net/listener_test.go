@@ -0,0 +1,140 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package net
+
+import (
+	"context"
+	stdnet "net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listen 在本地随机端口上创建监听器。
+func listen(t *testing.T, opts ...Option) *GracefulListener {
+	t.Helper()
+	l, err := Listen("tcp", "127.0.0.1:0", opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+	return l
+}
+
+// connect 连接到监听器并返回客户端与服务端的连接。
+func connect(t *testing.T, l *GracefulListener) (stdnet.Conn, stdnet.Conn) {
+	t.Helper()
+	client, err := stdnet.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	server, err := l.Accept()
+	require.NoError(t, err)
+	return client, server
+}
+
+// TestGracefulListener_Track 测试已接受连接的跟踪与关闭后的移除。
+func TestGracefulListener_Track(t *testing.T) {
+	l := listen(t)
+
+	_, s1 := connect(t, l)
+	_, s2 := connect(t, l)
+	assert.Equal(t, 2, l.ActiveConns())
+
+	require.NoError(t, s1.Close())
+	// 重复关闭不会重复计数。
+	_ = s1.Close()
+	assert.Equal(t, 1, l.ActiveConns())
+
+	require.NoError(t, s2.Close())
+	assert.Equal(t, 0, l.ActiveConns())
+
+	select {
+	case <-l.Done():
+		t.Fatal("监听器未关闭时不应通知连接已全部关闭")
+	default:
+	}
+}
+
+// TestGracefulListener_ShutdownDrains 测试 Shutdown 停止接受新连接并等待已接受的连接关闭。
+func TestGracefulListener_ShutdownDrains(t *testing.T) {
+	l := listen(t)
+	_, server := connect(t, l)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- l.Shutdown(context.Background())
+	}()
+
+	// 关闭后不再接受新连接。
+	require.Eventually(t, func() bool {
+		conn, err := stdnet.Dial("tcp", l.Addr().String())
+		if nil == err {
+			_ = conn.Close()
+		}
+		return nil != err
+	}, time.Second, time.Millisecond)
+	_, err := l.Accept()
+	assert.ErrorIs(t, err, stdnet.ErrClosed)
+
+	select {
+	case <-done:
+		t.Fatal("仍有连接时 Shutdown 不应返回")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	require.NoError(t, server.Close())
+	require.NoError(t, <-done)
+	<-l.Done()
+}
+
+// TestGracefulListener_ShutdownEmpty 测试没有连接时 Shutdown 立即返回且可以重复调用。
+func TestGracefulListener_ShutdownEmpty(t *testing.T) {
+	l := listen(t)
+	require.NoError(t, l.Shutdown(context.Background()))
+	require.NoError(t, l.Shutdown(context.Background()))
+	<-l.Done()
+}
+
+// TestGracefulListener_ShutdownForceClose 测试上下文结束时强制关闭剩余连接并记录指标。
+func TestGracefulListener_ShutdownForceClose(t *testing.T) {
+	name := t.Name() + strconv.FormatInt(time.Now().UnixNano(), 10)
+	l := listen(t, WithName(name))
+	client, _ := connect(t, l)
+	_, _ = connect(t, l)
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricAccepted.WithLabelValues(name)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricConnections.WithLabelValues(name)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := l.Shutdown(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, l.ActiveConns())
+	<-l.Done()
+
+	// 服务端强制关闭后客户端读到 EOF。
+	require.NoError(t, client.SetReadDeadline(time.Now().Add(time.Second)))
+	_, err = client.Read(make([]byte, 1))
+	assert.Error(t, err)
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(MetricConnections.WithLabelValues(name)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricForceClosed.WithLabelValues(name)))
+}
+
+// TestGracefulListener_WithoutMetrics 测试关闭指标时的行为，以及连接已全部关闭时忽略已结束的上下文。
+func TestGracefulListener_WithoutMetrics(t *testing.T) {
+	l := listen(t, WithMetrics(false), WithName(""))
+	_, server := connect(t, l)
+	require.NoError(t, server.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, l.Shutdown(ctx))
+}
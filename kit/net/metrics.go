@@ -0,0 +1,61 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package net
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 定义监听器指标相关的常量。
+const (
+	// namespace 定义 prometheus 指标的命名空间。
+	namespace = "kit_net"
+	// subsystem 定义 prometheus 指标的子系统名称。
+	subsystem = "listener"
+)
+
+var (
+	// MetricConnections 用于记录监听器当前打开的连接数。
+	// 该指标包含以下标签：
+	// - name: 监听器的名称。
+	MetricConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "connections",
+		Help:      "listener's open connections.",
+	}, []string{"name"})
+
+	// MetricAccepted 用于记录监听器接受的连接总数。
+	// 该指标包含以下标签：
+	// - name: 监听器的名称。
+	MetricAccepted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "accepted_total",
+		Help:      "listener's accepted connections total.",
+	}, []string{"name"})
+
+	// MetricForceClosed 用于记录关闭监听器时因超过截止时间被强制关闭的连接总数。
+	// 该指标包含以下标签：
+	// - name: 监听器的名称。
+	MetricForceClosed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "force_closed_total",
+		Help:      "listener's connections force closed on shutdown total.",
+	}, []string{"name"})
+)
+
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricConnections,
+		MetricAccepted,
+		MetricForceClosed,
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package net
+
+// 以下为监听器的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// nameDefault 为监听器的默认名称，用于指标标签。
+	nameDefault = "default"
+	// metricsDefault 为是否默认记录指标。
+	metricsDefault = true
+)
+
+type (
+	// Option 定义了监听器的配置选项。
+	Option func(*options)
+
+	// options 包含监听器的配置。
+	options struct {
+		// name 是监听器的名称，用于指标标签。
+		name string
+		// metrics 表示是否记录指标。
+		metrics bool
+	}
+)
+
+// WithName 设置监听器的名称，用于区分不同监听器的指标。
+//
+// 参数：
+//   - name：监听器名称，默认为 default。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithMetrics 设置是否记录指标。
+//
+// 参数：
+//   - metrics：是否记录指标，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMetrics(metrics bool) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		name:    nameDefault,
+		metrics: metricsDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if "" == o.name {
+		o.name = nameDefault
+	}
+	return o
+}
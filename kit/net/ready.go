@@ -0,0 +1,199 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package net
+
+import (
+	"context"
+	"fmt"
+	"io"
+	stdnet "net"
+	"net/http"
+	"time"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+// 以下为 WaitReady 的默认参数配置。
+// 可通过 ReadyOption 机制覆盖。
+var (
+	// dialTimeoutDefault 为单次探测的超时时间。
+	dialTimeoutDefault = time.Second
+	// backoffDefault 为探测之间的退避策略，等待时间从 50 毫秒开始增长，最长 1 秒。
+	backoffDefault = []retry.BackoffOption{
+		retry.WithMin(50 * time.Millisecond),
+		retry.WithMax(time.Second),
+	}
+)
+
+type (
+	// ReadyOption 定义了 WaitReady 的配置选项。
+	ReadyOption func(*readyOptions)
+
+	// readyOptions 包含 WaitReady 的配置。
+	readyOptions struct {
+		// dialTimeout 是单次探测的超时时间。
+		dialTimeout time.Duration
+		// healthURL 是健康检查地址，为空时只探测 TCP 连接。
+		healthURL string
+		// client 是请求健康检查地址使用的客户端。
+		client *http.Client
+		// backoff 是探测之间的退避策略。
+		backoff []retry.BackoffOption
+	}
+)
+
+// WithDialTimeout 设置单次探测的超时时间，包括建立连接与请求健康检查地址。
+//
+// 参数：
+//   - timeout：单次探测的超时时间，默认为 1 秒。
+//
+// 返回值：
+//   - ReadyOption：配置选项函数。
+func WithDialTimeout(timeout time.Duration) ReadyOption {
+	return func(o *readyOptions) {
+		o.dialTimeout = timeout
+	}
+}
+
+// WithHealthURL 设置健康检查地址。
+// 设置后，地址可以建立连接且对该地址的 GET 请求返回 200 时才视为就绪。
+//
+// 参数：
+//   - url：健康检查地址，例如 http://127.0.0.1:8080/healthz。
+//
+// 返回值：
+//   - ReadyOption：配置选项函数。
+func WithHealthURL(url string) ReadyOption {
+	return func(o *readyOptions) {
+		o.healthURL = url
+	}
+}
+
+// WithHTTPClient 设置请求健康检查地址使用的客户端，例如需要 TLS 配置时。
+//
+// 参数：
+//   - client：HTTP 客户端，默认为 http.DefaultClient。
+//
+// 返回值：
+//   - ReadyOption：配置选项函数。
+func WithHTTPClient(client *http.Client) ReadyOption {
+	return func(o *readyOptions) {
+		o.client = client
+	}
+}
+
+// WithBackoff 设置探测之间的退避策略，选项追加在默认策略之后。
+//
+// 参数：
+//   - opts：kit/runtime/retry 的退避选项，默认等待时间从 50 毫秒开始增长，最长 1 秒。
+//
+// 返回值：
+//   - ReadyOption：配置选项函数。
+func WithBackoff(opts ...retry.BackoffOption) ReadyOption {
+	return func(o *readyOptions) {
+		o.backoff = append(o.backoff, opts...)
+	}
+}
+
+// newReadyOptions 创建并应用配置选项，非法的参数使用默认值。
+func newReadyOptions(opts ...ReadyOption) *readyOptions {
+	o := &readyOptions{
+		dialTimeout: dialTimeoutDefault,
+		client:      http.DefaultClient,
+		backoff:     append([]retry.BackoffOption(nil), backoffDefault...),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.dialTimeout <= 0 {
+		o.dialTimeout = dialTimeoutDefault
+	}
+	if nil == o.client {
+		o.client = http.DefaultClient
+	}
+	return o
+}
+
+// WaitReady 按退避策略反复探测地址，直到可以建立连接或上下文结束。
+// 设置 WithHealthURL 时，还要求健康检查地址返回 200。
+// 用于测试中等待被测服务启动，以及服务启动时按依赖顺序等待下游就绪。
+//
+// 参数：
+//   - ctx：上下文，其截止时间是等待就绪的最长时间。
+//   - network：网络类型，例如 tcp、unix。
+//   - address：探测的地址，例如 127.0.0.1:5432。
+//   - opts：配置选项，支持 WithDialTimeout、WithHealthURL、WithHTTPClient 与 WithBackoff。
+//
+// 返回值：
+//   - error：地址就绪时返回 nil；上下文结束时返回的错误同时包含上下文的错误与最后一次探测的错误。
+//
+// 示例：
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	if err := net.WaitReady(ctx, "tcp", "db:5432"); nil != err {
+//	    return err
+//	}
+//	if err := net.WaitReady(ctx, "tcp", "api:8080",
+//	    net.WithHealthURL("http://api:8080/healthz"),
+//	); nil != err {
+//	    return err
+//	}
+func WaitReady(ctx context.Context, network, address string, opts ...ReadyOption) error {
+	o := newReadyOptions(opts...)
+	// 健康检查地址无效时重试没有意义，直接返回错误。
+	if "" != o.healthURL {
+		if _, err := http.NewRequest(http.MethodGet, o.healthURL, nil); nil != err {
+			return err
+		}
+	}
+
+	var lastErr error
+	err := retry.RetryWithContext(ctx, func(ctx context.Context) error {
+		lastErr = o.probe(ctx, network, address)
+		return lastErr
+	}, o.backoff...)
+	if nil == err {
+		return nil
+	}
+	if nil == lastErr {
+		return fmt.Errorf("等待 %s 就绪失败：%w", address, err)
+	}
+	return fmt.Errorf("等待 %s 就绪失败：%w，最后一次探测：%w", address, err, lastErr)
+}
+
+// probe 执行一次探测。
+func (o *readyOptions) probe(ctx context.Context, network, address string) error {
+	ctx, cancel := context.WithTimeout(ctx, o.dialTimeout)
+	defer cancel()
+
+	d := stdnet.Dialer{}
+	conn, err := d.DialContext(ctx, network, address)
+	if nil != err {
+		return err
+	}
+	_ = conn.Close()
+
+	if "" == o.healthURL {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.healthURL, nil)
+	if nil != err {
+		return err
+	}
+	resp, err := o.client.Do(req)
+	if nil != err {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	if http.StatusOK != resp.StatusCode {
+		return fmt.Errorf("健康检查返回 %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package net
+
+import (
+	"context"
+	stdnet "net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+// closedAddr 返回一个当前没有监听的本地地址。
+func closedAddr(t *testing.T) string {
+	t.Helper()
+	l, err := stdnet.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+	return addr
+}
+
+// TestWaitReady_Listening 测试地址已在监听时立即返回。
+func TestWaitReady_Listening(t *testing.T) {
+	l := listen(t, WithMetrics(false))
+	require.NoError(t, WaitReady(context.Background(), "tcp", l.Addr().String()))
+}
+
+// TestWaitReady_BecomesReady 测试地址在等待期间开始监听时返回。
+func TestWaitReady_BecomesReady(t *testing.T) {
+	addr := closedAddr(t)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		l, err := stdnet.Listen("tcp", addr)
+		if nil != err {
+			return
+		}
+		t.Cleanup(func() {
+			_ = l.Close()
+		})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, WaitReady(ctx, "tcp", addr, WithBackoff(retry.WithMin(10*time.Millisecond))))
+}
+
+// TestWaitReady_Timeout 测试上下文超时时返回包含最后一次探测原因的错误。
+func TestWaitReady_Timeout(t *testing.T) {
+	addr := closedAddr(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := WaitReady(ctx, "tcp", addr, WithDialTimeout(-1), WithBackoff(retry.WithMin(10*time.Millisecond)))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), addr)
+	// 错误中包含最后一次探测的原因。
+	assert.Contains(t, err.Error(), "最后一次探测")
+}
+
+// TestWaitReady_Canceled 测试上下文已取消时返回取消错误。
+func TestWaitReady_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := WaitReady(ctx, "tcp", closedAddr(t))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestWaitReady_HealthURL 测试健康检查地址返回 200 之前持续探测。
+func TestWaitReady_HealthURL(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := WaitReady(ctx, "tcp", addr,
+		WithHealthURL(srv.URL+"/healthz"),
+		WithHTTPClient(srv.Client()),
+		WithBackoff(retry.WithMin(time.Millisecond), retry.WithMax(10*time.Millisecond)),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+// TestWaitReady_HealthURLUnhealthy 测试健康检查地址一直不健康时返回包含状态码的错误。
+func TestWaitReady_HealthURLUnhealthy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := WaitReady(ctx, "tcp", strings.TrimPrefix(srv.URL, "http://"),
+		WithHealthURL(srv.URL), WithHTTPClient(nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+}
+
+// TestWaitReady_InvalidHealthURL 测试健康检查地址无效时立即返回错误。
+func TestWaitReady_InvalidHealthURL(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := WaitReady(ctx, "tcp", closedAddr(t), WithHealthURL("://"))
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, context.DeadlineExceeded)
+}
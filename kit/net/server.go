@@ -0,0 +1,148 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package net
+
+import (
+	"context"
+	"errors"
+	stdnet "net"
+	stdsync "sync"
+	"time"
+)
+
+// 以下为接受连接失败时的重试间隔。
+const (
+	// acceptDelayMin 为第一次重试前的等待时间。
+	acceptDelayMin = 5 * time.Millisecond
+	// acceptDelayMax 为重试前的最长等待时间。
+	acceptDelayMax = time.Second
+)
+
+type (
+	// ConnHandler 处理一个已接受的连接。
+	// 处理函数返回后连接会被关闭；ctx 在服务停止并强制关闭连接时被取消。
+	ConnHandler func(ctx context.Context, conn stdnet.Conn)
+
+	// Server 在 GracefulListener 上接受连接，并在独立的协程中处理每个连接。
+	// Server 实现了 kit/runtime 的 Runner 接口：Start 启动接受循环后立即返回，
+	// Stop 停止接受新连接并等待正在处理的连接完成，截止时间到达时强制关闭剩余连接。
+	Server struct {
+		// listener 是接受连接的监听器。
+		listener *GracefulListener
+		// handler 是处理连接的函数。
+		handler ConnHandler
+
+		// mu 保护 cancel。
+		mu stdsync.Mutex
+		// cancel 取消传给处理函数的上下文，Start 之前为 nil。
+		cancel context.CancelFunc
+		// wg 等待接受循环退出。
+		wg stdsync.WaitGroup
+	}
+)
+
+// NewServer 创建在 listener 上接受连接并交给 handler 处理的服务。
+//
+// 参数：
+//   - listener：接受连接的监听器。
+//   - handler：处理连接的函数。
+//
+// 返回值：
+//   - *Server：新的服务。
+//
+// 示例：
+//
+//	ln, _ := net.Listen("tcp", ":9000")
+//	srv := net.NewServer(ln, func(ctx context.Context, conn stdnet.Conn) {
+//	    _, _ = io.Copy(conn, conn)
+//	})
+//	_ = srv.Start(ctx)
+//	defer srv.Stop(shutdownCtx)
+func NewServer(listener *GracefulListener, handler ConnHandler) *Server {
+	return &Server{
+		listener: listener,
+		handler:  handler,
+	}
+}
+
+// Start 在后台协程中启动接受循环后立即返回。
+// ctx 被取消时停止接受新连接，但不会中断正在处理的连接；优雅关闭需要调用 Stop。
+//
+// 参数：
+//   - ctx：提供生命周期控制和取消信号，同时是传给处理函数的上下文的父上下文。
+//
+// 返回值：
+//   - error：总是返回 nil。
+func (s *Server) Start(ctx context.Context) error {
+	connCtx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	s.cancel = cancel
+	s.mu.Unlock()
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = s.listener.Close()
+	})
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer stop()
+		s.serve(connCtx)
+	}()
+	return nil
+}
+
+// Stop 停止接受新连接，并等待正在处理的连接完成。
+// ctx 结束时强制关闭剩余连接，取消传给处理函数的上下文，并返回 ctx 的错误。
+//
+// 参数：
+//   - ctx：提供停止操作的截止时间。
+//
+// 返回值：
+//   - error：所有连接在截止时间前完成时返回 nil，否则返回 ctx 的错误。
+func (s *Server) Stop(ctx context.Context) error {
+	err := s.listener.Shutdown(ctx)
+	s.wg.Wait()
+
+	s.mu.Lock()
+	if nil != s.cancel {
+		s.cancel()
+	}
+	s.mu.Unlock()
+
+	if errors.Is(err, stdnet.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+// serve 循环接受连接，直到监听器被关闭。
+// 接受失败时按指数增长的间隔重试，避免文件描述符耗尽等错误导致忙循环。
+func (s *Server) serve(ctx context.Context) {
+	var delay time.Duration
+	for {
+		conn, err := s.listener.Accept()
+		if nil != err {
+			if errors.Is(err, stdnet.ErrClosed) {
+				return
+			}
+			delay = min(max(2*delay, acceptDelayMin), acceptDelayMax)
+			select {
+			case <-time.After(delay):
+				continue
+			case <-s.listener.Done():
+				return
+			}
+		}
+		delay = 0
+
+		go func() {
+			defer func() {
+				_ = conn.Close()
+			}()
+			s.handler(ctx, conn)
+		}()
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package net
+
+import (
+	"bufio"
+	"context"
+	"io"
+	stdnet "net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echo 回显收到的所有内容。
+func echo(_ context.Context, conn stdnet.Conn) {
+	_, _ = io.Copy(conn, conn)
+}
+
+// TestServer_Echo 测试 Server 接受并处理连接，连接关闭后 Stop 立即完成。
+func TestServer_Echo(t *testing.T) {
+	srv := NewServer(listen(t, WithMetrics(false)), echo)
+	require.NoError(t, srv.Start(context.Background()))
+
+	conn, err := stdnet.Dial("tcp", srv.listener.Addr().String())
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("ping\n"))
+	require.NoError(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "ping\n", line)
+
+	// 客户端关闭后服务端的处理函数返回，Stop 立即完成。
+	require.NoError(t, conn.Close())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, srv.Stop(ctx))
+}
+
+// TestServer_StopForceClose 测试 Stop 超时时强制关闭连接并取消处理函数的上下文。
+func TestServer_StopForceClose(t *testing.T) {
+	handled := make(chan context.Context, 1)
+	srv := NewServer(listen(t, WithMetrics(false)), func(ctx context.Context, conn stdnet.Conn) {
+		handled <- ctx
+		_, _ = io.Copy(io.Discard, conn)
+	})
+	require.NoError(t, srv.Start(context.Background()))
+
+	conn, err := stdnet.Dial("tcp", srv.listener.Addr().String())
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	connCtx := <-handled
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, srv.Stop(ctx), context.DeadlineExceeded)
+	assert.ErrorIs(t, connCtx.Err(), context.Canceled)
+}
+
+// TestServer_StartContextCancel 测试 Start 的上下文被取消时停止接受新连接。
+func TestServer_StartContextCancel(t *testing.T) {
+	srv := NewServer(listen(t, WithMetrics(false)), echo)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, srv.Start(ctx))
+
+	cancel()
+	require.Eventually(t, func() bool {
+		conn, err := stdnet.Dial("tcp", srv.listener.Addr().String())
+		if nil == err {
+			_ = conn.Close()
+		}
+		return nil != err
+	}, time.Second, time.Millisecond)
+	require.NoError(t, srv.Stop(context.Background()))
+}
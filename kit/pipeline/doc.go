@@ -0,0 +1,26 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package pipeline 提供了基于泛型的分阶段流水线，用于 ETL 类的扇出、扇入处理。
+
+主要功能：
+
+  - 分阶段：Source 产生输入，Stage 转换元素，Sink 消费输出，阶段之间通过有界通道连接
+  - 并发：每个阶段以配置的并发数运行在 kit/runtime/goroutine 的协程池中
+  - 顺序：Stage 默认不保持输入顺序，WithOrdered(true) 时按输入顺序输出
+  - 错误传播：任一阶段返回错误时取消整条流水线，Wait 返回第一个错误
+  - 取消：流水线的上下文取消时所有阶段退出
+
+基本使用：
+
+	p := pipeline.New(ctx)
+	ids := pipeline.Source(p, pipeline.FromSlice(userIDs))
+	users := pipeline.Stage(ids, loadUser, pipeline.WithWorkers(8))
+	pipeline.Sink(users, saveUser, pipeline.WithWorkers(2))
+	if err := p.Wait(); nil != err {
+	    return err
+	}
+*/
+package pipeline
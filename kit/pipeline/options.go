@@ -0,0 +1,142 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pipeline
+
+import (
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+// 以下为流水线的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// workersDefault 为每个阶段默认的并发数。
+	workersDefault = 1
+	// bufferDefault 为阶段输出通道默认的缓冲区大小。
+	bufferDefault = 0
+	// orderedDefault 为阶段默认是否保持输入顺序。
+	orderedDefault = false
+)
+
+type (
+	// Option 定义了流水线与阶段的配置选项。
+	// 传给 New 的选项作为所有阶段的默认值，传给 Source、Stage 与 Sink 的选项只对该阶段生效。
+	Option func(*options)
+
+	// options 包含流水线与阶段的配置。
+	options struct {
+		// pool 是运行阶段协程的协程池，为 nil 时使用 kit/runtime/goroutine 的默认协程池。
+		pool goroutine.GoroutinePool
+		// name 是阶段的名称，用于错误信息。
+		name string
+		// workers 是阶段的并发数。
+		workers int
+		// buffer 是阶段输出通道的缓冲区大小。
+		buffer int
+		// ordered 表示阶段是否保持输入顺序。
+		ordered bool
+	}
+)
+
+// WithPool 设置运行阶段协程的协程池，只能传给 New。
+// 每个并发单元在运行期间占用协程池中的一个协程，协程池的容量应不小于所有阶段并发数之和。
+//
+// 参数：
+//   - pool：协程池，默认为 kit/runtime/goroutine 的默认协程池。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPool(pool goroutine.GoroutinePool) Option {
+	return func(o *options) {
+		o.pool = pool
+	}
+}
+
+// WithName 设置阶段的名称，出现在该阶段返回的错误中。
+//
+// 参数：
+//   - name：阶段的名称，默认为 source、stage1、stage2……与 sink1、sink2……。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithWorkers 设置阶段的并发数，对 Source 不生效。
+//
+// 参数：
+//   - workers：并发数，默认为 1，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithWorkers(workers int) Option {
+	return func(o *options) {
+		o.workers = workers
+	}
+}
+
+// WithBuffer 设置阶段输出通道的缓冲区大小，对 Sink 不生效。
+//
+// 参数：
+//   - buffer：缓冲区大小，默认为 0，小于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithBuffer(buffer int) Option {
+	return func(o *options) {
+		o.buffer = buffer
+	}
+}
+
+// WithOrdered 设置阶段是否按输入顺序输出，仅对 Stage 生效。
+// 保持顺序时，处理较慢的元素会阻塞其后已经处理完成的元素的输出。
+//
+// 参数：
+//   - ordered：是否保持输入顺序，默认为 false。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithOrdered(ordered bool) Option {
+	return func(o *options) {
+		o.ordered = ordered
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		workers: workersDefault,
+		buffer:  bufferDefault,
+		ordered: orderedDefault,
+	}
+	return o.with(opts...)
+}
+
+// with 复制当前配置并应用配置选项，非法的参数使用默认值。
+func (o *options) with(opts ...Option) *options {
+	c := *o
+	c.name = ""
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.workers < 1 {
+		c.workers = workersDefault
+	}
+	if c.buffer < 0 {
+		c.buffer = bufferDefault
+	}
+	return &c
+}
+
+// submit 将任务提交到协程池。
+func (o *options) submit(task func()) error {
+	if nil != o.pool {
+		return o.pool.Submit(task)
+	}
+	return goroutine.Submit(task)
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	stdsync "sync"
+	"sync/atomic"
+)
+
+type (
+	// SourceFunc 产生流水线的输入，通过 emit 逐个输出元素。
+	// emit 在流水线被取消时返回错误，SourceFunc 应当直接返回该错误。
+	SourceFunc[T any] func(ctx context.Context, emit func(T) error) error
+
+	// StageFunc 将一个输入元素转换为一个输出元素。
+	StageFunc[T, U any] func(ctx context.Context, in T) (U, error)
+
+	// SinkFunc 消费流水线的输出。
+	SinkFunc[T any] func(ctx context.Context, in T) error
+
+	// Pipeline 是由 Source、若干 Stage 与 Sink 组成的流水线。
+	// 每个阶段在协程池中以配置的并发数运行，阶段之间通过有界通道连接，下游处理不过来时上游自然阻塞。
+	// 任一阶段返回错误时取消整条流水线，Wait 返回第一个错误。
+	Pipeline struct {
+		// ctx 是所有阶段使用的上下文，流水线失败时取消。
+		ctx context.Context
+		// cancel 以失败原因取消 ctx。
+		cancel context.CancelCauseFunc
+		// o 是所有阶段的默认配置。
+		o *options
+		// wg 记录运行中的协程。
+		wg stdsync.WaitGroup
+		// errOnce 保证只记录第一个错误。
+		errOnce stdsync.Once
+		// err 是第一个错误。
+		err error
+		// stages 是已经添加的 Stage 数量，用于生成默认名称。
+		stages atomic.Int32
+		// sinks 是已经添加的 Sink 数量，用于生成默认名称。
+		sinks atomic.Int32
+	}
+
+	// Stream 是阶段的输出，只能被一个 Stage 或 Sink 消费。
+	Stream[T any] struct {
+		// p 是所属的流水线。
+		p *Pipeline
+		// ch 是输出通道，阶段结束时关闭。
+		ch <-chan T
+		// consumed 表示是否已经被消费。
+		consumed atomic.Bool
+	}
+)
+
+// New 创建一个流水线。
+//
+// 参数：
+//   - ctx：流水线的上下文，取消时所有阶段停止。
+//   - opts：配置选项，WithPool 只能在此设置，其他选项作为所有阶段的默认值。
+//
+// 返回值：
+//   - *Pipeline：流水线。
+//
+// 示例：
+//
+//	p := pipeline.New(ctx)
+//	ids := pipeline.Source(p, pipeline.FromSlice(userIDs))
+//	users := pipeline.Stage(ids, loadUser, pipeline.WithWorkers(8))
+//	pipeline.Sink(users, saveUser)
+//	if err := p.Wait(); nil != err {
+//	    return err
+//	}
+func New(ctx context.Context, opts ...Option) *Pipeline {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Pipeline{
+		ctx:    ctx,
+		cancel: cancel,
+		o:      newOptions(opts...),
+	}
+}
+
+// Wait 等待所有阶段结束。
+// 流水线必须以 Sink 结束，否则最后一个阶段的输出无人消费，Wait 不会返回。
+//
+// 返回值：
+//   - error：第一个失败的阶段返回的错误，或流水线上下文被取消的原因。
+func (p *Pipeline) Wait() error {
+	p.wg.Wait()
+	p.cancel(nil)
+	return p.err
+}
+
+// fail 记录第一个错误并取消流水线。
+func (p *Pipeline) fail(err error) {
+	p.errOnce.Do(func() {
+		p.err = err
+		p.cancel(err)
+	})
+}
+
+// abort 在流水线上下文被取消时记录取消的原因。
+func (p *Pipeline) abort() {
+	p.fail(context.Cause(p.ctx))
+}
+
+// run 在协程池中运行 fn，提交失败时使流水线失败。
+func (p *Pipeline) run(o *options, fn func()) {
+	p.wg.Add(1)
+	err := p.o.submit(func() {
+		defer p.wg.Done()
+		fn()
+	})
+	if nil != err {
+		p.wg.Done()
+		p.fail(fmt.Errorf("kit/pipeline: 启动 %s 失败：%w", o.name, err))
+	}
+}
+
+// source 返回流的输入通道，重复消费时 panic。
+func (s *Stream[T]) source() <-chan T {
+	if !s.consumed.CompareAndSwap(false, true) {
+		panic("kit/pipeline: 数据流只能被一个 Stage 或 Sink 消费")
+	}
+	return s.ch
+}
+
+// Source 为流水线添加输入。fn 在单个协程中运行，返回后输出通道关闭。
+//
+// 参数：
+//   - p：流水线。
+//   - fn：产生输入的函数。
+//   - opts：配置选项，支持 WithName 与 WithBuffer。
+//
+// 返回值：
+//   - *Stream[T]：输入的数据流。
+func Source[T any](p *Pipeline, fn SourceFunc[T], opts ...Option) *Stream[T] {
+	o := p.o.with(opts...)
+	if "" == o.name {
+		o.name = "source"
+	}
+	out := make(chan T, o.buffer)
+
+	emit := func(v T) error {
+		select {
+		case out <- v:
+			return nil
+		case <-p.ctx.Done():
+			return context.Cause(p.ctx)
+		}
+	}
+	p.run(o, func() {
+		defer close(out)
+		if err := fn(p.ctx, emit); nil != err {
+			p.failStage(o, err)
+		}
+	})
+	return &Stream[T]{p: p, ch: out}
+}
+
+// FromSlice 返回依次输出 items 中元素的 SourceFunc。
+//
+// 参数：
+//   - items：输入的元素。
+//
+// 返回值：
+//   - SourceFunc[T]：输出 items 的函数。
+func FromSlice[T any](items []T) SourceFunc[T] {
+	return func(_ context.Context, emit func(T) error) error {
+		for _, v := range items {
+			if err := emit(v); nil != err {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// FromChannel 返回依次输出 ch 中元素的 SourceFunc，ch 关闭后输入结束。
+//
+// 参数：
+//   - ch：输入的通道。
+//
+// 返回值：
+//   - SourceFunc[T]：输出 ch 中元素的函数。
+func FromChannel[T any](ch <-chan T) SourceFunc[T] {
+	return func(ctx context.Context, emit func(T) error) error {
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok {
+					return nil
+				}
+				if err := emit(v); nil != err {
+					return err
+				}
+			case <-ctx.Done():
+				return context.Cause(ctx)
+			}
+		}
+	}
+}
+
+// failStage 记录阶段返回的错误并取消流水线。
+// 流水线已经被取消时，阶段返回的错误通常是取消的原因，此时只记录取消的原因。
+func (p *Pipeline) failStage(o *options, err error) {
+	if nil != p.ctx.Err() {
+		p.abort()
+		return
+	}
+	p.fail(fmt.Errorf("kit/pipeline: %s 失败：%w", o.name, err))
+}
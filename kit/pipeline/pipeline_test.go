@@ -0,0 +1,190 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+// numbers 返回 0 到 n-1 的整数。
+func numbers(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+// square 返回输入的平方，并随机等待一小段时间以打乱完成顺序。
+func square(_ context.Context, v int) (int, error) {
+	time.Sleep(time.Duration(rand.IntN(200)) * time.Microsecond)
+	return v * v, nil
+}
+
+// TestPipeline 测试不保持顺序时所有元素都被处理。
+func TestPipeline(t *testing.T) {
+	p := New(context.Background(), WithBuffer(4))
+	squares := Stage(Source(p, FromSlice(numbers(100))), square, WithWorkers(4))
+	texts := Stage(squares, func(_ context.Context, v int) (string, error) {
+		return strconv.Itoa(v), nil
+	}, WithWorkers(2))
+	result := Collect(texts)
+	require.NoError(t, p.Wait())
+
+	got := result()
+	want := make([]string, 0, 100)
+	for _, v := range numbers(100) {
+		want = append(want, strconv.Itoa(v*v))
+	}
+	assert.ElementsMatch(t, want, got)
+}
+
+// TestPipeline_Ordered 测试保持顺序时按输入顺序输出。
+func TestPipeline_Ordered(t *testing.T) {
+	p := New(context.Background())
+	squares := Stage(Source(p, FromSlice(numbers(200))), square, WithWorkers(8), WithOrdered(true), WithBuffer(2))
+	result := Collect(squares)
+	require.NoError(t, p.Wait())
+
+	want := make([]int, 0, 200)
+	for _, v := range numbers(200) {
+		want = append(want, v*v)
+	}
+	assert.Equal(t, want, result())
+}
+
+// TestPipeline_StageError 测试阶段返回错误时取消整条流水线，Wait 返回带有阶段名称的错误。
+func TestPipeline_StageError(t *testing.T) {
+	for _, ordered := range []bool{false, true} {
+		t.Run(strconv.FormatBool(ordered), func(t *testing.T) {
+			errBad := errors.New("bad item")
+			var consumed atomic.Int32
+			p := New(context.Background())
+			// 无限输入，只能因为流水线失败而结束。
+			src := Source(p, func(_ context.Context, emit func(int) error) error {
+				for i := 0; ; i++ {
+					if err := emit(i); nil != err {
+						return err
+					}
+				}
+			})
+			out := Stage(src, func(_ context.Context, v int) (int, error) {
+				if 10 == v {
+					return 0, errBad
+				}
+				return v, nil
+			}, WithWorkers(3), WithOrdered(ordered))
+			Sink(out, func(context.Context, int) error {
+				consumed.Add(1)
+				return nil
+			}, WithWorkers(2))
+
+			err := p.Wait()
+			assert.ErrorIs(t, err, errBad)
+			assert.EqualError(t, err, "kit/pipeline: stage1 失败：bad item")
+			assert.Less(t, consumed.Load(), int32(10000))
+		})
+	}
+}
+
+// TestPipeline_SourceSinkError 测试 Source 与 Sink 返回错误。
+func TestPipeline_SourceSinkError(t *testing.T) {
+	p := New(context.Background())
+	src := Source(p, func(_ context.Context, emit func(int) error) error {
+		if err := emit(1); nil != err {
+			return err
+		}
+		return errors.New("read failed")
+	}, WithName("reader"))
+	Sink(src, func(context.Context, int) error { return nil })
+	assert.EqualError(t, p.Wait(), "kit/pipeline: reader 失败：read failed")
+
+	p = New(context.Background())
+	Sink(Source(p, FromSlice(numbers(10))), func(context.Context, int) error {
+		return errors.New("write failed")
+	})
+	assert.EqualError(t, p.Wait(), "kit/pipeline: sink1 失败：write failed")
+}
+
+// TestPipeline_Cancel 测试上下文取消时所有阶段退出，Wait 返回取消的原因。
+func TestPipeline_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan int)
+	p := New(ctx)
+	out := Stage(Source(p, FromChannel(ch)), square, WithWorkers(2), WithOrdered(true))
+	received := make(chan struct{}, 1)
+	Sink(out, func(context.Context, int) error {
+		received <- struct{}{}
+		return nil
+	})
+
+	ch <- 1
+	<-received
+	cancel()
+	assert.ErrorIs(t, p.Wait(), context.Canceled)
+}
+
+// TestFromChannel 测试通道关闭后输入结束。
+func TestFromChannel(t *testing.T) {
+	ch := make(chan int, 3)
+	for _, v := range []int{3, 1, 2} {
+		ch <- v
+	}
+	close(ch)
+
+	p := New(context.Background())
+	result := Collect(Source(p, FromChannel(ch)))
+	require.NoError(t, p.Wait())
+	assert.Equal(t, []int{3, 1, 2}, result())
+}
+
+// TestStream_ConsumedTwice 测试数据流被重复消费时 panic。
+func TestStream_ConsumedTwice(t *testing.T) {
+	p := New(context.Background())
+	src := Source(p, FromSlice(numbers(3)))
+	result := Collect(src)
+	assert.Panics(t, func() {
+		Sink(src, func(context.Context, int) error { return nil })
+	})
+	require.NoError(t, p.Wait())
+	assert.Equal(t, numbers(3), result())
+}
+
+// TestPipeline_Pool 测试使用自定义的协程池，协程池容量不足时流水线失败。
+func TestPipeline_Pool(t *testing.T) {
+	pool, release, err := goroutine.NewGoroutinePool(goroutine.WithSize(2), goroutine.WithNonBlocking(true), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer release()
+
+	p := New(context.Background(), WithPool(pool))
+	Sink(Stage(Source(p, FromSlice(numbers(10))), square, WithWorkers(4)), func(context.Context, int) error { return nil })
+	err = p.Wait()
+	assert.ErrorContains(t, err, "kit/pipeline: 启动 stage1 失败")
+}
+
+// TestNewOptions 测试非法的参数使用默认值，阶段的名称不继承。
+func TestNewOptions(t *testing.T) {
+	o := newOptions(WithWorkers(0), WithBuffer(-1), WithName("p"))
+	assert.Equal(t, workersDefault, o.workers)
+	assert.Equal(t, bufferDefault, o.buffer)
+	assert.False(t, o.ordered)
+
+	o = newOptions(WithWorkers(4), WithOrdered(true)).with(WithBuffer(8))
+	assert.Equal(t, 4, o.workers)
+	assert.Equal(t, 8, o.buffer)
+	assert.True(t, o.ordered)
+	assert.Empty(t, o.name)
+}
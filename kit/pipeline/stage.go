@@ -0,0 +1,205 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pipeline
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+)
+
+type (
+	// job 是保持顺序时分发给并发单元的元素。
+	job[T, U any] struct {
+		// in 是输入元素。
+		in T
+		// out 接收处理结果，容量为 1。
+		out chan U
+	}
+)
+
+// Stage 为流水线添加一个转换阶段，以配置的并发数并行处理输入。
+// 默认不保持输入顺序，设置 WithOrdered(true) 时按输入顺序输出。
+//
+// 参数：
+//   - in：输入的数据流。
+//   - fn：转换函数，返回错误时流水线失败。
+//   - opts：配置选项，支持 WithName、WithWorkers、WithBuffer 与 WithOrdered。
+//
+// 返回值：
+//   - *Stream[U]：输出的数据流。
+func Stage[T, U any](in *Stream[T], fn StageFunc[T, U], opts ...Option) *Stream[U] {
+	p := in.p
+	o := p.o.with(opts...)
+	if "" == o.name {
+		o.name = "stage" + strconv.Itoa(int(p.stages.Add(1)))
+	}
+	src := in.source()
+	out := make(chan U, o.buffer)
+
+	if o.ordered {
+		stageOrdered(p, o, src, out, fn)
+	} else {
+		stageUnordered(p, o, src, out, fn)
+	}
+	return &Stream[U]{p: p, ch: out}
+}
+
+// stageUnordered 启动不保持顺序的并发单元，最后一个退出的并发单元关闭输出通道。
+func stageUnordered[T, U any](p *Pipeline, o *options, src <-chan T, out chan<- U, fn StageFunc[T, U]) {
+	var running atomic.Int32
+	running.Store(int32(o.workers))
+	for range o.workers {
+		p.run(o, func() {
+			defer func() {
+				if 0 == running.Add(-1) {
+					close(out)
+				}
+			}()
+			for {
+				v, ok := receive(p, src)
+				if !ok {
+					return
+				}
+				u, err := fn(p.ctx, v)
+				if nil != err {
+					p.failStage(o, err)
+					return
+				}
+				if !send(p, out, u) {
+					return
+				}
+			}
+		})
+	}
+}
+
+// stageOrdered 启动保持顺序的阶段：分发协程按输入顺序为每个元素创建结果通道，
+// 并发单元将结果写入对应的通道，收集协程按相同顺序读取结果并输出。
+func stageOrdered[T, U any](p *Pipeline, o *options, src <-chan T, out chan<- U, fn StageFunc[T, U]) {
+	jobs := make(chan job[T, U])
+	// pending 的容量限制了已经分发但尚未输出的元素数量。
+	pending := make(chan chan U, o.workers+o.buffer)
+
+	p.run(o, func() {
+		defer close(jobs)
+		defer close(pending)
+		for {
+			v, ok := receive(p, src)
+			if !ok {
+				return
+			}
+			j := job[T, U]{in: v, out: make(chan U, 1)}
+			if !send(p, pending, j.out) || !send(p, jobs, j) {
+				return
+			}
+		}
+	})
+
+	for range o.workers {
+		p.run(o, func() {
+			for {
+				j, ok := receive(p, jobs)
+				if !ok {
+					return
+				}
+				u, err := fn(p.ctx, j.in)
+				if nil != err {
+					p.failStage(o, err)
+					return
+				}
+				j.out <- u
+			}
+		})
+	}
+
+	p.run(o, func() {
+		defer close(out)
+		for {
+			result, ok := receive(p, pending)
+			if !ok {
+				return
+			}
+			u, ok := receive(p, result)
+			if !ok || !send(p, out, u) {
+				return
+			}
+		}
+	})
+}
+
+// Sink 为流水线添加输出阶段，以配置的并发数消费输入。
+// 并发数为 1 时按输入顺序消费。
+//
+// 参数：
+//   - in：输入的数据流。
+//   - fn：消费函数，返回错误时流水线失败。
+//   - opts：配置选项，支持 WithName 与 WithWorkers。
+func Sink[T any](in *Stream[T], fn SinkFunc[T], opts ...Option) {
+	p := in.p
+	o := p.o.with(opts...)
+	if "" == o.name {
+		o.name = "sink" + strconv.Itoa(int(p.sinks.Add(1)))
+	}
+	src := in.source()
+
+	for range o.workers {
+		p.run(o, func() {
+			for {
+				v, ok := receive(p, src)
+				if !ok {
+					return
+				}
+				if err := fn(p.ctx, v); nil != err {
+					p.failStage(o, err)
+					return
+				}
+			}
+		})
+	}
+}
+
+// Collect 添加一个收集所有输出的 Sink，Wait 返回之后通过返回的函数读取结果。
+// 输出的顺序与输入数据流的顺序一致。
+//
+// 参数：
+//   - in：输入的数据流。
+//
+// 返回值：
+//   - func() []T：返回收集到的结果，应在 Wait 返回之后调用。
+func Collect[T any](in *Stream[T]) func() []T {
+	var items []T
+	Sink(in, func(_ context.Context, v T) error {
+		items = append(items, v)
+		return nil
+	}, WithWorkers(1), WithName("collect"))
+	return func() []T {
+		return items
+	}
+}
+
+// receive 从通道读取元素，通道关闭或流水线被取消时返回 false。
+// 流水线被取消时记录取消的原因。
+func receive[T any](p *Pipeline, ch <-chan T) (T, bool) {
+	select {
+	case v, ok := <-ch:
+		return v, ok
+	case <-p.ctx.Done():
+		p.abort()
+		var zero T
+		return zero, false
+	}
+}
+
+// send 向通道写入元素，流水线被取消时返回 false 并记录取消的原因。
+func send[T any](p *Pipeline, ch chan<- T, v T) bool {
+	select {
+	case ch <- v:
+		return true
+	case <-p.ctx.Done():
+		p.abort()
+		return false
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package pool 提供了类型安全的对象池，在 sync.Pool 的基础上增加构造与重置钩子、可选的空闲对象数量上限以及 Prometheus 指标。
+
+主要功能：
+
+  - 类型安全：Pool[T] 的 Get 与 Put 直接使用 T，不需要类型断言
+  - 钩子：New 传入构造函数与重置函数，重置函数返回 false 时丢弃对象，例如容量过大的缓冲区
+  - 数量上限：WithCapacity 限制保留的空闲对象数量，超过的对象被丢弃
+  - 指标：记录取出、放回、新建与丢弃的次数，通过 WithName 区分不同的对象池
+
+指标需要使用方注册，Collectors 返回本包的全部指标，可以通过 kit/metrics 的 WithCollectors 传入 Register 一次注册。
+
+基本使用：
+
+	entries := pool.New(func() *Entry {
+	    return &Entry{Fields: make(map[string]interface{}, 8)}
+	}, func(e *Entry) bool {
+	    clear(e.Fields)
+	    return true
+	}, pool.WithName("entry"))
+
+	e := entries.Get()
+	defer entries.Put(e)
+*/
+package pool
@@ -0,0 +1,37 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pool
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 定义对象池指标相关的常量。
+const (
+	// namespace 定义 prometheus 指标的命名空间。
+	namespace = "kit_pool"
+)
+
+var (
+	// MetricObjects 用于记录对象池的操作次数。
+	// 该指标包含以下标签：
+	// - name: 对象池的名称。
+	// - op: 操作类型，get 表示取出，put 表示放回，new 表示池中没有对象而新建，drop 表示放回时被丢弃。
+	MetricObjects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "objects_total",
+		Help:      "pool's object operations total.",
+	}, []string{"name", "op"})
+)
+
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricObjects,
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pool
+
+// 以下为对象池的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// capacityDefault 为默认保留的空闲对象数量上限，0 表示不限制。
+	capacityDefault = 0
+	// metricsDefault 为是否默认记录指标。
+	metricsDefault = true
+)
+
+type (
+	// Option 定义了对象池的配置选项。
+	Option func(*options)
+
+	// options 包含对象池的配置。
+	options struct {
+		// name 是对象池的名称，用于指标标签。
+		name string
+		// capacity 是保留的空闲对象数量上限，0 表示不限制。
+		capacity int
+		// metrics 表示是否记录指标。
+		metrics bool
+	}
+)
+
+// WithName 设置对象池的名称，用于区分不同对象池的指标。
+//
+// 参数：
+//   - name：对象池的名称，默认为空。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithCapacity 设置保留的空闲对象数量上限。
+// 不限制时空闲对象保存在 sync.Pool 中，会在垃圾回收时被逐步释放；限制时空闲对象保存在固定大小的空闲列表中，
+// 超过上限的对象被丢弃，空闲列表中的对象不会被垃圾回收释放，适用于创建代价高、需要限制常驻内存的对象。
+//
+// 参数：
+//   - capacity：空闲对象数量上限，默认为 0 表示不限制，小于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCapacity(capacity int) Option {
+	return func(o *options) {
+		o.capacity = capacity
+	}
+}
+
+// WithMetrics 设置是否记录指标。
+//
+// 参数：
+//   - metrics：是否记录指标，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMetrics(metrics bool) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		capacity: capacityDefault,
+		metrics:  metricsDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.capacity < 0 {
+		o.capacity = capacityDefault
+	}
+
+	return o
+}
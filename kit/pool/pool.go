@@ -0,0 +1,140 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pool
+
+import (
+	stdsync "sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// Pool 是类型安全的对象池，在 sync.Pool 的基础上提供构造与重置钩子、可选的空闲对象数量上限以及指标。
+	// T 通常应为指针类型，值类型放入 sync.Pool 时会产生额外的内存分配。
+	// 所有方法都是并发安全的。
+	Pool[T any] struct {
+		// newFn 创建新的对象。
+		newFn func() T
+		// reset 在对象放回前重置对象，返回 false 时丢弃对象，为 nil 时不重置。
+		reset func(T) bool
+		// pool 保存空闲对象，未限制数量时使用。
+		pool stdsync.Pool
+		// free 保存空闲对象，限制数量时使用，否则为 nil。
+		free chan T
+
+		// gets 记录取出的次数，未启用指标时为 nil。
+		gets prometheus.Counter
+		// puts 记录放回的次数，未启用指标时为 nil。
+		puts prometheus.Counter
+		// news 记录新建对象的次数，未启用指标时为 nil。
+		news prometheus.Counter
+		// drops 记录放回时被丢弃的次数，未启用指标时为 nil。
+		drops prometheus.Counter
+	}
+)
+
+// New 创建一个对象池。
+//
+// 参数：
+//   - newFn：创建新对象的函数，池中没有空闲对象时调用。
+//   - reset：放回前重置对象的函数，返回 false 时丢弃对象（例如缓冲区过大时），为 nil 时不重置。
+//   - opts：配置选项，支持 WithName、WithCapacity 与 WithMetrics。
+//
+// 返回值：
+//   - *Pool[T]：对象池实例。
+//
+// 示例：
+//
+//	buffers := pool.New(func() *bytes.Buffer {
+//	    return bytes.NewBuffer(make([]byte, 0, 1024))
+//	}, func(b *bytes.Buffer) bool {
+//	    if b.Cap() > 64<<10 {
+//	        return false
+//	    }
+//	    b.Reset()
+//	    return true
+//	}, pool.WithName("buffer"))
+//
+//	buf := buffers.Get()
+//	defer buffers.Put(buf)
+func New[T any](newFn func() T, reset func(T) bool, opts ...Option) *Pool[T] {
+	o := newOptions(opts...)
+	p := &Pool[T]{
+		newFn: newFn,
+		reset: reset,
+	}
+	if o.capacity > 0 {
+		p.free = make(chan T, o.capacity)
+	} else {
+		p.pool.New = func() interface{} {
+			return p.create()
+		}
+	}
+	if o.metrics {
+		p.gets = MetricObjects.WithLabelValues(o.name, "get")
+		p.puts = MetricObjects.WithLabelValues(o.name, "put")
+		p.news = MetricObjects.WithLabelValues(o.name, "new")
+		p.drops = MetricObjects.WithLabelValues(o.name, "drop")
+	}
+	return p
+}
+
+// Get 从池中取出一个对象，池中没有空闲对象时创建新的对象。
+//
+// 返回值：
+//   - T：对象，使用完毕后应调用 Put 放回。
+func (p *Pool[T]) Get() T {
+	if nil != p.gets {
+		p.gets.Inc()
+	}
+	if nil == p.free {
+		return p.pool.Get().(T)
+	}
+	select {
+	case v := <-p.free:
+		return v
+	default:
+		return p.create()
+	}
+}
+
+// Put 重置对象并放回池中。重置函数返回 false 或空闲对象已经达到上限时，对象被丢弃。
+// 放回之后不能再使用该对象。
+//
+// 参数：
+//   - v：要放回的对象。
+func (p *Pool[T]) Put(v T) {
+	if nil != p.puts {
+		p.puts.Inc()
+	}
+	if nil != p.reset && !p.reset(v) {
+		p.drop()
+		return
+	}
+	if nil == p.free {
+		p.pool.Put(v)
+		return
+	}
+	select {
+	case p.free <- v:
+	default:
+		p.drop()
+	}
+}
+
+// create 创建新的对象。
+func (p *Pool[T]) create() T {
+	if nil != p.news {
+		p.news.Inc()
+	}
+	return p.newFn()
+}
+
+// drop 记录被丢弃的对象。
+func (p *Pool[T]) drop() {
+	if nil != p.drops {
+		p.drops.Inc()
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pool
+
+import (
+	"bytes"
+	stdsync "sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// newBuffer 创建测试使用的缓冲区。
+func newBuffer() *bytes.Buffer {
+	return bytes.NewBuffer(make([]byte, 0, 16))
+}
+
+// resetBuffer 重置缓冲区，容量超过 64 字节时丢弃。
+func resetBuffer(b *bytes.Buffer) bool {
+	if b.Cap() > 64 {
+		return false
+	}
+	b.Reset()
+	return true
+}
+
+// TestPool 测试对象的取出、重置与放回。
+func TestPool(t *testing.T) {
+	p := New(newBuffer, resetBuffer, WithMetrics(false))
+
+	b := p.Get()
+	assert.Equal(t, 0, b.Len())
+	b.WriteString("hello")
+	p.Put(b)
+
+	// 取出的对象已经被重置，无论是否来自池中。
+	b = p.Get()
+	assert.Equal(t, 0, b.Len())
+
+	// 不设置重置函数时对象原样放回。
+	raw := New(newBuffer, nil, WithMetrics(false))
+	raw.Put(bytes.NewBufferString("keep"))
+	assert.NotNil(t, raw.Get())
+}
+
+// TestPool_Capacity 测试限制空闲对象数量。
+func TestPool_Capacity(t *testing.T) {
+	created := 0
+	p := New(func() *bytes.Buffer {
+		created++
+		return newBuffer()
+	}, resetBuffer, WithCapacity(2), WithMetrics(false))
+
+	a, b, c := p.Get(), p.Get(), p.Get()
+	assert.Equal(t, 3, created)
+	p.Put(a)
+	p.Put(b)
+	// 空闲列表已满，第三个对象被丢弃。
+	p.Put(c)
+
+	assert.Same(t, a, p.Get())
+	assert.Same(t, b, p.Get())
+	assert.NotSame(t, c, p.Get())
+	assert.Equal(t, 4, created)
+
+	// 重置函数返回 false 的对象被丢弃。
+	big := p.Get()
+	big.Grow(128)
+	p.Put(big)
+	assert.NotSame(t, big, p.Get())
+}
+
+// TestPool_Metrics 测试对象池的指标。
+func TestPool_Metrics(t *testing.T) {
+	name := t.Name()
+	p := New(newBuffer, resetBuffer, WithName(name), WithCapacity(1))
+
+	a, b := p.Get(), p.Get()
+	p.Put(a)
+	p.Put(b)
+	_ = p.Get()
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(MetricObjects.WithLabelValues(name, "get")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricObjects.WithLabelValues(name, "put")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricObjects.WithLabelValues(name, "new")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricObjects.WithLabelValues(name, "drop")))
+}
+
+// TestPool_Concurrent 测试并发地取出与放回。
+func TestPool_Concurrent(t *testing.T) {
+	for _, capacity := range []int{0, 4} {
+		p := New(newBuffer, resetBuffer, WithCapacity(capacity), WithName(t.Name()))
+		var wg stdsync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 1000 {
+					b := p.Get()
+					assert.Equal(t, 0, b.Len())
+					b.WriteString("x")
+					p.Put(b)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+// TestNewOptions 测试配置选项的默认值与非法参数。
+func TestNewOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, "", o.name)
+	assert.Equal(t, capacityDefault, o.capacity)
+	assert.Equal(t, metricsDefault, o.metrics)
+
+	o = newOptions(WithCapacity(-1))
+	assert.Equal(t, capacityDefault, o.capacity)
+}
+
+// BenchmarkPool 测试取出与放回的性能。
+func BenchmarkPool(b *testing.B) {
+	p := New(newBuffer, resetBuffer, WithMetrics(false))
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buf := p.Get()
+			buf.WriteString("x")
+			p.Put(buf)
+		}
+	})
+}
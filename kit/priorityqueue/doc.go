@@ -0,0 +1,34 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package priorityqueue 提供了基于泛型与二叉堆的优先队列。
+
+与标准库 container/heap 相比，Queue 不需要实现 heap.Interface，元素不经过 interface{} 装箱，
+并且 Push 返回元素的句柄，可以在元素出队前更新其优先级或将其删除，适用于定时任务、按截止时间调度、
+Dijkstra 等需要调整优先级的场景。
+
+基本使用：
+
+	q := priorityqueue.New(func(a, b *Job) bool {
+	    return a.RunAt.Before(b.RunAt)
+	})
+	item := q.Push(job)
+
+	// 推迟执行。
+	job.RunAt = job.RunAt.Add(time.Minute)
+	q.Update(item, job)
+
+	// 取消。
+	q.Remove(item)
+
+	next, ok := q.Pop()
+
+元素为有序类型时可以使用 NewOrdered，值越小越先出队：
+
+	q := priorityqueue.NewOrdered[int]()
+
+队列默认不是并发安全的，WithConcurrent(true) 开启后所有方法通过互斥锁保护。
+*/
+package priorityqueue
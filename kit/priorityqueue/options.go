@@ -0,0 +1,70 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package priorityqueue
+
+// 以下为优先队列的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// capacityDefault 为默认预分配的容量。
+	capacityDefault = 0
+	// concurrentDefault 为默认是否支持并发访问。
+	concurrentDefault = false
+)
+
+type (
+	// Option 定义了优先队列的配置选项。
+	Option func(*options)
+
+	// options 包含优先队列的配置。
+	options struct {
+		// capacity 是预分配的容量。
+		capacity int
+		// concurrent 表示是否支持并发访问。
+		concurrent bool
+	}
+)
+
+// WithCapacity 设置预分配的容量，元素数量超过容量时自动扩容。
+//
+// 参数：
+//   - capacity：容量，默认为 0，小于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCapacity(capacity int) Option {
+	return func(o *options) {
+		o.capacity = capacity
+	}
+}
+
+// WithConcurrent 设置是否支持并发访问，开启后所有方法通过互斥锁保护。
+//
+// 参数：
+//   - concurrent：是否支持并发访问，默认为 false。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithConcurrent(concurrent bool) Option {
+	return func(o *options) {
+		o.concurrent = concurrent
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		capacity:   capacityDefault,
+		concurrent: concurrentDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.capacity < 0 {
+		o.capacity = capacityDefault
+	}
+
+	return o
+}
@@ -0,0 +1,285 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package priorityqueue
+
+import (
+	"cmp"
+	stdsync "sync"
+)
+
+type (
+	// Queue 是基于二叉堆的优先队列，Pop 总是返回优先级最高的元素，即按 less 排序最小的元素。
+	// 优先级相同的元素出队顺序不确定。
+	// 通过 WithConcurrent 开启后所有方法都是并发安全的；否则 Queue 不是并发安全的。
+	Queue[T any] struct {
+		// less 判断 a 的优先级是否高于 b。
+		less func(a, b T) bool
+		// items 是按堆排列的元素。
+		items []*Item[T]
+		// mu 在支持并发访问时保护队列，否则为 nil。
+		mu *stdsync.Mutex
+	}
+
+	// Item 是队列中元素的句柄，由 Push 返回，用于更新元素的优先级或从队列中删除元素。
+	Item[T any] struct {
+		// value 是元素的值。
+		value T
+		// index 是元素在堆中的下标，元素出队或被删除后为 -1。
+		index int
+		// queue 是元素所在的队列。
+		queue *Queue[T]
+	}
+)
+
+// New 创建一个优先队列。
+//
+// 参数：
+//   - less：判断 a 的优先级是否高于 b 的函数，a 先于 b 出队时返回 true。
+//   - opts：配置选项，支持 WithCapacity 与 WithConcurrent。
+//
+// 返回值：
+//   - *Queue[T]：优先队列实例。
+//
+// 示例：
+//
+//	q := priorityqueue.New(func(a, b *Task) bool {
+//	    return a.Deadline.Before(b.Deadline)
+//	})
+//	q.Push(task)
+//	next, ok := q.Pop()
+func New[T any](less func(a, b T) bool, opts ...Option) *Queue[T] {
+	o := newOptions(opts...)
+	q := &Queue[T]{
+		less:  less,
+		items: make([]*Item[T], 0, o.capacity),
+	}
+	if o.concurrent {
+		q.mu = &stdsync.Mutex{}
+	}
+	return q
+}
+
+// NewOrdered 创建一个元素为有序类型的优先队列，值越小越先出队。
+// 需要值越大越先出队时，使用 New 并传入 func(a, b int) bool { return a > b } 这样的比较函数。
+//
+// 参数：
+//   - opts：配置选项，支持 WithCapacity 与 WithConcurrent。
+//
+// 返回值：
+//   - *Queue[T]：优先队列实例。
+func NewOrdered[T cmp.Ordered](opts ...Option) *Queue[T] {
+	return New(cmp.Less[T], opts...)
+}
+
+// Push 添加一个元素。
+//
+// 参数：
+//   - value：元素。
+//
+// 返回值：
+//   - *Item[T]：元素的句柄，不需要更新或删除元素时可以忽略。
+func (q *Queue[T]) Push(value T) *Item[T] {
+	q.lock()
+	defer q.unlock()
+
+	item := &Item[T]{value: value, index: len(q.items), queue: q}
+	q.items = append(q.items, item)
+	q.up(item.index)
+	return item
+}
+
+// Pop 取出优先级最高的元素。
+//
+// 返回值：
+//   - T：优先级最高的元素，队列为空时为零值。
+//   - bool：队列不为空时返回 true。
+func (q *Queue[T]) Pop() (T, bool) {
+	q.lock()
+	defer q.unlock()
+
+	if 0 == len(q.items) {
+		var zero T
+		return zero, false
+	}
+	return q.remove(0).value, true
+}
+
+// Peek 返回优先级最高的元素，但不取出。
+//
+// 返回值：
+//   - T：优先级最高的元素，队列为空时为零值。
+//   - bool：队列不为空时返回 true。
+func (q *Queue[T]) Peek() (T, bool) {
+	q.lock()
+	defer q.unlock()
+
+	if 0 == len(q.items) {
+		var zero T
+		return zero, false
+	}
+	return q.items[0].value, true
+}
+
+// Update 更新元素的值并按新的优先级调整位置。
+//
+// 参数：
+//   - item：Push 返回的句柄。
+//   - value：新的值。
+//
+// 返回值：
+//   - bool：元素仍在队列中并被更新时返回 true；元素已经出队、被删除或属于其他队列时返回 false。
+func (q *Queue[T]) Update(item *Item[T], value T) bool {
+	q.lock()
+	defer q.unlock()
+
+	if !q.owns(item) {
+		return false
+	}
+	item.value = value
+	if !q.down(item.index) {
+		q.up(item.index)
+	}
+	return true
+}
+
+// Remove 从队列中删除元素。
+//
+// 参数：
+//   - item：Push 返回的句柄。
+//
+// 返回值：
+//   - bool：元素仍在队列中并被删除时返回 true。
+func (q *Queue[T]) Remove(item *Item[T]) bool {
+	q.lock()
+	defer q.unlock()
+
+	if !q.owns(item) {
+		return false
+	}
+	q.remove(item.index)
+	return true
+}
+
+// Len 返回元素数量。
+//
+// 返回值：
+//   - int：元素数量。
+func (q *Queue[T]) Len() int {
+	q.lock()
+	defer q.unlock()
+	return len(q.items)
+}
+
+// Clear 删除全部元素，已有的句柄全部失效。
+func (q *Queue[T]) Clear() {
+	q.lock()
+	defer q.unlock()
+
+	for _, item := range q.items {
+		item.index = -1
+	}
+	clear(q.items)
+	q.items = q.items[:0]
+}
+
+// Drain 按优先级从高到低取出全部元素。
+//
+// 返回值：
+//   - []T：全部元素，队列为空时返回空切片。
+func (q *Queue[T]) Drain() []T {
+	q.lock()
+	defer q.unlock()
+
+	values := make([]T, 0, len(q.items))
+	for 0 < len(q.items) {
+		values = append(values, q.remove(0).value)
+	}
+	return values
+}
+
+// Value 返回元素的值，即 Push 或最近一次 Update 设置的值。
+//
+// 返回值：
+//   - T：元素的值。
+func (i *Item[T]) Value() T {
+	i.queue.lock()
+	defer i.queue.unlock()
+	return i.value
+}
+
+// lock 在支持并发访问时加锁。
+func (q *Queue[T]) lock() {
+	if nil != q.mu {
+		q.mu.Lock()
+	}
+}
+
+// unlock 在支持并发访问时解锁。
+func (q *Queue[T]) unlock() {
+	if nil != q.mu {
+		q.mu.Unlock()
+	}
+}
+
+// owns 判断 item 是否仍在当前队列中，调用方需要持有锁。
+func (q *Queue[T]) owns(item *Item[T]) bool {
+	return nil != item && item.queue == q && item.index >= 0
+}
+
+// remove 删除下标为 i 的元素并返回，调用方需要持有锁。
+func (q *Queue[T]) remove(i int) *Item[T] {
+	last := len(q.items) - 1
+	item := q.items[i]
+	if i != last {
+		q.swap(i, last)
+	}
+	q.items[last] = nil
+	q.items = q.items[:last]
+	if i != last && !q.down(i) {
+		q.up(i)
+	}
+	item.index = -1
+	return item
+}
+
+// up 将下标为 i 的元素向上调整到正确的位置。
+func (q *Queue[T]) up(i int) {
+	for 0 < i {
+		parent := (i - 1) / 2
+		if !q.less(q.items[i].value, q.items[parent].value) {
+			break
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+// down 将下标为 i 的元素向下调整到正确的位置，位置发生变化时返回 true。
+func (q *Queue[T]) down(i int) bool {
+	start := i
+	n := len(q.items)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && q.less(q.items[right].value, q.items[child].value) {
+			child = right
+		}
+		if !q.less(q.items[child].value, q.items[i].value) {
+			break
+		}
+		q.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+// swap 交换下标为 i 与 j 的元素。
+func (q *Queue[T]) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
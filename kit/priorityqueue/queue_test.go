@@ -0,0 +1,236 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package priorityqueue
+
+import (
+	"math/rand/v2"
+	"slices"
+	stdsync "sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// task 是测试使用的带优先级的元素。
+	task struct {
+		name     string
+		priority int
+	}
+)
+
+// byPriority 按优先级从高到低排序 task。
+func byPriority(a, b task) bool {
+	return a.priority > b.priority
+}
+
+// TestQueue 测试按优先级出队。
+func TestQueue(t *testing.T) {
+	q := NewOrdered[int]()
+	_, ok := q.Pop()
+	assert.False(t, ok)
+	_, ok = q.Peek()
+	assert.False(t, ok)
+
+	values := rand.New(rand.NewPCG(1, 2)).Perm(1000)
+	for _, v := range values {
+		q.Push(v)
+	}
+	assert.Equal(t, 1000, q.Len())
+	top, ok := q.Peek()
+	require.True(t, ok)
+	assert.Equal(t, 0, top)
+
+	for i := 0; i < 1000; i++ {
+		v, ok := q.Pop()
+		require.True(t, ok)
+		require.Equal(t, i, v)
+	}
+	assert.Equal(t, 0, q.Len())
+}
+
+// TestUpdate 测试更新优先级。
+func TestUpdate(t *testing.T) {
+	q := New(byPriority)
+	a := q.Push(task{name: "a", priority: 1})
+	b := q.Push(task{name: "b", priority: 2})
+	c := q.Push(task{name: "c", priority: 3})
+	assert.Equal(t, "b", b.Value().name)
+
+	// 提高优先级。
+	require.True(t, q.Update(a, task{name: "a", priority: 10}))
+	top, _ := q.Peek()
+	assert.Equal(t, "a", top.name)
+	assert.Equal(t, 10, a.Value().priority)
+
+	// 降低优先级。
+	require.True(t, q.Update(a, task{name: "a", priority: 0}))
+	assert.Equal(t, []string{"c", "b", "a"}, names(q.Drain()))
+
+	// 出队后的句柄失效。
+	assert.False(t, q.Update(c, task{name: "c"}))
+	assert.False(t, q.Remove(c))
+	assert.False(t, q.Update(nil, task{}))
+
+	// 其他队列的句柄。
+	other := New(byPriority)
+	d := other.Push(task{name: "d"})
+	assert.False(t, q.Update(d, task{name: "d"}))
+	assert.False(t, q.Remove(d))
+	assert.Equal(t, 1, other.Len())
+}
+
+// TestRemove 测试删除任意位置的元素。
+func TestRemove(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	q := NewOrdered[int](WithCapacity(100))
+	items := make([]*Item[int], 0, 100)
+	for _, v := range r.Perm(100) {
+		items = append(items, q.Push(v))
+	}
+
+	var want []int
+	for i, item := range items {
+		if 0 == i%3 {
+			require.True(t, q.Remove(item))
+			assert.False(t, q.Remove(item))
+		} else {
+			want = append(want, item.Value())
+		}
+	}
+	slices.Sort(want)
+	assert.Equal(t, want, q.Drain())
+
+	// 删除最后一个元素。
+	last := q.Push(1)
+	require.True(t, q.Remove(last))
+	assert.Empty(t, q.Drain())
+}
+
+// TestRandomized 随机执行各种操作，与排序的结果比较。
+func TestRandomized(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	q := NewOrdered[int]()
+	live := make(map[*Item[int]]struct{})
+	for i := 0; i < 5000; i++ {
+		switch r.IntN(4) {
+		case 0, 1:
+			live[q.Push(r.IntN(1000))] = struct{}{}
+		case 2:
+			for item := range live {
+				require.True(t, q.Update(item, r.IntN(1000)))
+				break
+			}
+		case 3:
+			for item := range live {
+				require.True(t, q.Remove(item))
+				delete(live, item)
+				break
+			}
+		}
+	}
+
+	want := make([]int, 0, len(live))
+	for item := range live {
+		want = append(want, item.Value())
+	}
+	slices.Sort(want)
+	assert.Equal(t, want, q.Drain())
+}
+
+// TestClear 测试清空队列。
+func TestClear(t *testing.T) {
+	q := NewOrdered[int]()
+	item := q.Push(1)
+	q.Push(2)
+	q.Clear()
+	assert.Equal(t, 0, q.Len())
+	assert.False(t, q.Remove(item))
+	assert.Equal(t, 1, item.Value())
+
+	q.Push(3)
+	assert.Equal(t, []int{3}, q.Drain())
+	assert.Equal(t, []int{}, q.Drain())
+}
+
+// TestConcurrent 测试并发访问。
+func TestConcurrent(t *testing.T) {
+	q := NewOrdered[int](WithConcurrent(true))
+
+	var wg stdsync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				item := q.Push(g*1000 + i)
+				if 0 == i%2 {
+					q.Update(item, item.Value()+1)
+				}
+				_, _ = q.Peek()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 4000, q.Len())
+
+	var popped []int
+	var mu stdsync.Mutex
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, ok := q.Pop()
+				if !ok {
+					return
+				}
+				mu.Lock()
+				popped = append(popped, v)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, popped, 4000)
+}
+
+// TestOptions 测试配置选项与默认值。
+func TestOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, capacityDefault, o.capacity)
+	assert.Equal(t, concurrentDefault, o.concurrent)
+
+	o = newOptions(WithCapacity(16), WithConcurrent(true))
+	assert.Equal(t, 16, o.capacity)
+	assert.True(t, o.concurrent)
+
+	o = newOptions(WithCapacity(-1))
+	assert.Equal(t, capacityDefault, o.capacity)
+}
+
+// BenchmarkPushPop 测试入队与出队的性能。
+func BenchmarkPushPop(b *testing.B) {
+	q := NewOrdered[int](WithCapacity(1024))
+	r := rand.New(rand.NewPCG(7, 8))
+	for i := 0; i < 1024; i++ {
+		q.Push(r.IntN(1 << 20))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Push(r.IntN(1 << 20))
+		q.Pop()
+	}
+}
+
+// names 返回 task 的名称。
+func names(tasks []task) []string {
+	result := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		result = append(result, t.name)
+	}
+	return result
+}
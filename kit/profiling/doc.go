@@ -0,0 +1,36 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package profiling 提供了简易的持续 profile 采集，适用于没有 profile 服务的环境。
+
+采集方式：
+
+  - 定期采集：每隔 WithInterval 采集一次，默认为 10 分钟
+  - 条件触发：每隔 WithCheckInterval 检查 WithTrigger 添加的条件，满足时立即采集，同一条件在 WithCooldown 内只触发一次
+  - 手动采集：调用 Capture，例如在收到信号或管理接口请求时
+
+每次采集 WithProfiles 设置的全部 profile，默认为 CPU、heap 与 goroutine。profile 为 gzip 压缩的 protobuf 格式，
+写入 WithDir 目录中名为 <profile>-<时间>-<原因>.pb.gz 的文件，每种 profile 只保留最新的 WithMaxFiles 个文件；
+设置 WithUpload 后改为交给上传函数处理。
+
+基本使用：
+
+	r := profiling.New(
+	    profiling.WithDir("/var/log/app/profiles"),
+	    profiling.WithTrigger("heap_high", profiling.HeapAbove(2<<30)),
+	    profiling.WithTrigger("goroutine_leak", profiling.GoroutinesAbove(10000)),
+	)
+	if err := r.Start(ctx); nil != err {
+	    panic(err)
+	}
+	defer r.Stop(context.Background())
+
+分析采集到的文件：
+
+	go tool pprof -http=:8080 /var/log/app/profiles/heap-20250102T030405.000-heap_high.pb.gz
+
+Runner 实现了 kit/runtime 的 Runner 接口，可以与其他组件一起管理生命周期。
+*/
+package profiling
@@ -0,0 +1,320 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package profiling
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 以下为持续采集的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// profilesDefault 为默认采集的 profile。
+	profilesDefault = []Profile{ProfileCPU, ProfileHeap, ProfileGoroutine}
+	// intervalDefault 为默认的定期采集间隔。
+	intervalDefault = 10 * time.Minute
+	// cpuDurationDefault 为默认的 CPU profile 采样时长。
+	cpuDurationDefault = 10 * time.Second
+	// dirDefault 为默认保存 profile 文件的目录。
+	dirDefault = filepath.Join(os.TempDir(), "profiles")
+	// maxFilesDefault 为每种 profile 默认保留的文件数量。
+	maxFilesDefault = 10
+	// checkIntervalDefault 为默认检查触发条件的间隔。
+	checkIntervalDefault = 10 * time.Second
+	// cooldownDefault 为同一触发条件两次触发采集之间的默认最短间隔。
+	cooldownDefault = 5 * time.Minute
+	// blockProfileRateDefault 为采集 block profile 时默认的采样率，单位为纳秒。
+	blockProfileRateDefault = 10000
+	// mutexProfileFractionDefault 为采集 mutex profile 时默认的采样比例。
+	mutexProfileFractionDefault = 100
+	// clockDefault 为默认使用的时钟。
+	clockDefault = kittime.NewRealClock()
+)
+
+type (
+	// Option 定义了持续采集的配置选项。
+	Option func(*options)
+
+	// options 包含持续采集的配置。
+	options struct {
+		// profiles 是要采集的 profile。
+		profiles []Profile
+		// interval 是定期采集的间隔，小于等于 0 时不定期采集。
+		interval time.Duration
+		// cpuDuration 是 CPU profile 的采样时长。
+		cpuDuration time.Duration
+		// dir 是保存 profile 文件的目录。
+		dir string
+		// maxFiles 是每种 profile 保留的文件数量。
+		maxFiles int
+		// upload 是上传 profile 的函数，设置后不再写入文件。
+		upload UploadFunc
+		// triggers 是触发采集的条件。
+		triggers []trigger
+		// checkInterval 是检查触发条件的间隔。
+		checkInterval time.Duration
+		// cooldown 是同一触发条件两次触发采集之间的最短间隔。
+		cooldown time.Duration
+		// blockProfileRate 是采集 block profile 时的采样率。
+		blockProfileRate int
+		// mutexProfileFraction 是采集 mutex profile 时的采样比例。
+		mutexProfileFraction int
+		// clock 是计时使用的时钟。
+		clock kittime.Clock
+		// logger 是记录采集结果的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+	}
+
+	// trigger 是触发采集的条件。
+	trigger struct {
+		// name 是条件的名称，作为采集原因。
+		name string
+		// cond 判断条件是否满足。
+		cond Condition
+	}
+)
+
+// WithProfiles 设置要采集的 profile。
+//
+// 参数：
+//   - profiles：要采集的 profile，默认为 CPU、heap 与 goroutine，为空时使用默认值，重复与未知的 profile 被忽略。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithProfiles(profiles ...Profile) Option {
+	return func(o *options) {
+		o.profiles = profiles
+	}
+}
+
+// WithInterval 设置定期采集的间隔。
+//
+// 参数：
+//   - interval：采集间隔，默认为 10 分钟，小于等于 0 时不定期采集，只在触发条件满足或调用 Capture 时采集。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.interval = interval
+	}
+}
+
+// WithCPUDuration 设置 CPU profile 的采样时长。
+//
+// 参数：
+//   - d：采样时长，默认为 10 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCPUDuration(d time.Duration) Option {
+	return func(o *options) {
+		o.cpuDuration = d
+	}
+}
+
+// WithDir 设置保存 profile 文件的目录，目录不存在时在 Start 中创建。
+//
+// 参数：
+//   - dir：目录，默认为系统临时目录下的 profiles，为空时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithDir(dir string) Option {
+	return func(o *options) {
+		o.dir = dir
+	}
+}
+
+// WithMaxFiles 设置每种 profile 保留的文件数量，超过时删除最早的文件。
+//
+// 参数：
+//   - n：文件数量，默认为 10，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxFiles(n int) Option {
+	return func(o *options) {
+		o.maxFiles = n
+	}
+}
+
+// WithUpload 设置上传 profile 的函数，设置后 profile 交给该函数处理，不再写入文件。
+//
+// 参数：
+//   - fn：上传函数，为 nil 时写入文件。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithUpload(fn UploadFunc) Option {
+	return func(o *options) {
+		o.upload = fn
+	}
+}
+
+// WithTrigger 添加一个触发采集的条件，可以多次使用添加多个条件。
+// 条件每隔 WithCheckInterval 检查一次，满足时立即采集全部 profile，采集原因为条件的名称。
+//
+// 参数：
+//   - name：条件的名称，用于日志与文件名。
+//   - cond：判断条件是否满足的函数，例如 HeapAbove 与 GoroutinesAbove，为 nil 时忽略。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTrigger(name string, cond Condition) Option {
+	return func(o *options) {
+		if nil != cond {
+			o.triggers = append(o.triggers, trigger{name: name, cond: cond})
+		}
+	}
+}
+
+// WithCheckInterval 设置检查触发条件的间隔。
+//
+// 参数：
+//   - interval：检查间隔，默认为 10 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCheckInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.checkInterval = interval
+	}
+}
+
+// WithCooldown 设置同一触发条件两次触发采集之间的最短间隔，避免条件持续满足时反复采集。
+//
+// 参数：
+//   - cooldown：最短间隔，默认为 5 分钟，小于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCooldown(cooldown time.Duration) Option {
+	return func(o *options) {
+		o.cooldown = cooldown
+	}
+}
+
+// WithBlockProfileRate 设置采集 block profile 时的采样率，仅在采集 block profile 时生效。
+//
+// 参数：
+//   - rate：阻塞每达到 rate 纳秒采样一次，含义与 runtime.SetBlockProfileRate 一致，默认为 10000，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithBlockProfileRate(rate int) Option {
+	return func(o *options) {
+		o.blockProfileRate = rate
+	}
+}
+
+// WithMutexProfileFraction 设置采集 mutex profile 时的采样比例，仅在采集 mutex profile 时生效。
+//
+// 参数：
+//   - fraction：平均每 fraction 次锁竞争采样一次，含义与 runtime.SetMutexProfileFraction 一致，默认为 100，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMutexProfileFraction(fraction int) Option {
+	return func(o *options) {
+		o.mutexProfileFraction = fraction
+	}
+}
+
+// WithClock 设置计时使用的时钟。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithLogger 设置记录采集结果的日志实例。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		interval:             intervalDefault,
+		cpuDuration:          cpuDurationDefault,
+		dir:                  dirDefault,
+		maxFiles:             maxFilesDefault,
+		checkInterval:        checkIntervalDefault,
+		cooldown:             cooldownDefault,
+		blockProfileRate:     blockProfileRateDefault,
+		mutexProfileFraction: mutexProfileFractionDefault,
+		clock:                clockDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// 去掉重复与未知的 profile。
+	profiles := make([]Profile, 0, len(o.profiles))
+	for _, p := range o.profiles {
+		if p.valid() && !containsProfile(profiles, p) {
+			profiles = append(profiles, p)
+		}
+	}
+	if 0 == len(profiles) {
+		profiles = append(profiles, profilesDefault...)
+	}
+	o.profiles = profiles
+
+	if o.cpuDuration <= 0 {
+		o.cpuDuration = cpuDurationDefault
+	}
+	if "" == o.dir {
+		o.dir = dirDefault
+	}
+	if o.maxFiles < 1 {
+		o.maxFiles = maxFilesDefault
+	}
+	if o.checkInterval <= 0 {
+		o.checkInterval = checkIntervalDefault
+	}
+	if o.cooldown < 0 {
+		o.cooldown = cooldownDefault
+	}
+	if o.blockProfileRate < 1 {
+		o.blockProfileRate = blockProfileRateDefault
+	}
+	if o.mutexProfileFraction < 1 {
+		o.mutexProfileFraction = mutexProfileFractionDefault
+	}
+	if nil == o.clock {
+		o.clock = clockDefault
+	}
+
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
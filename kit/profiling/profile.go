@@ -0,0 +1,109 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package profiling
+
+import (
+	"context"
+	"runtime"
+	"runtime/metrics"
+	"slices"
+	"time"
+)
+
+const (
+	// ProfileCPU 是 CPU profile，采样 WithCPUDuration 设置的时长。
+	ProfileCPU Profile = "cpu"
+	// ProfileHeap 是堆内存 profile。
+	ProfileHeap Profile = "heap"
+	// ProfileGoroutine 是全部协程的调用栈。
+	ProfileGoroutine Profile = "goroutine"
+	// ProfileBlock 是阻塞 profile，启用后 Start 会开启阻塞采样。
+	ProfileBlock Profile = "block"
+	// ProfileMutex 是锁竞争 profile，启用后 Start 会开启锁竞争采样。
+	ProfileMutex Profile = "mutex"
+)
+
+const (
+	// ReasonInterval 是定期采集的采集原因。
+	ReasonInterval = "interval"
+	// ReasonManual 是 Capture 未指定原因时的采集原因。
+	ReasonManual = "manual"
+)
+
+const (
+	// heapObjectsMetric 是堆上对象占用字节数的运行时指标。
+	heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+)
+
+type (
+	// Profile 是 profile 的类型，值与 runtime/pprof 中 profile 的名称一致。
+	Profile string
+
+	// Snapshot 是一次采集得到的 profile。
+	Snapshot struct {
+		// Profile 是 profile 的类型。
+		Profile Profile
+		// Reason 是采集原因：ReasonInterval、ReasonManual 或触发条件的名称。
+		Reason string
+		// Time 是开始采集的时间。
+		Time time.Time
+		// Data 是 gzip 压缩的 protobuf 格式的 profile，可以直接使用 go tool pprof 分析。
+		Data []byte
+	}
+
+	// UploadFunc 上传一次采集得到的 profile，返回的错误会被记录到日志。
+	UploadFunc func(ctx context.Context, s *Snapshot) error
+
+	// Condition 判断是否需要触发采集，会被定期调用，应当快速返回。
+	Condition func() bool
+)
+
+// HeapAbove 返回堆上对象占用的内存超过 bytes 时满足的条件。
+//
+// 参数：
+//   - bytes：内存阈值，单位为字节。
+//
+// 返回值：
+//   - Condition：触发条件。
+//
+// 示例：
+//
+//	profiling.WithTrigger("heap_high", profiling.HeapAbove(1<<30))
+func HeapAbove(bytes uint64) Condition {
+	return func() bool {
+		// runtime/metrics 不会暂停程序，比 runtime.ReadMemStats 开销更小。
+		samples := []metrics.Sample{{Name: heapObjectsMetric}}
+		metrics.Read(samples)
+		return metrics.KindUint64 == samples[0].Value.Kind() && samples[0].Value.Uint64() > bytes
+	}
+}
+
+// GoroutinesAbove 返回协程数量超过 n 时满足的条件，可用于捕获协程泄漏。
+//
+// 参数：
+//   - n：协程数量阈值。
+//
+// 返回值：
+//   - Condition：触发条件。
+func GoroutinesAbove(n int) Condition {
+	return func() bool {
+		return runtime.NumGoroutine() > n
+	}
+}
+
+// valid 判断 profile 是否是支持的类型。
+func (p Profile) valid() bool {
+	switch p {
+	case ProfileCPU, ProfileHeap, ProfileGoroutine, ProfileBlock, ProfileMutex:
+		return true
+	default:
+		return false
+	}
+}
+
+// containsProfile 判断 profiles 中是否包含 p。
+func containsProfile(profiles []Profile, p Profile) bool {
+	return slices.Contains(profiles, p)
+}
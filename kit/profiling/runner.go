@@ -0,0 +1,335 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	stdsync "sync"
+	"time"
+)
+
+const (
+	// fileTimeLayout 是文件名中时间的格式，按文件名排序即按时间排序。
+	fileTimeLayout = "20060102T150405.000"
+	// fileExt 是 profile 文件的扩展名。
+	fileExt = ".pb.gz"
+)
+
+var (
+	// ErrRunning 表示 Runner 已经启动。
+	ErrRunning = errors.New("kit/profiling: 已经启动")
+)
+
+type (
+	// Runner 定期采集 profile，并在触发条件满足时立即采集，采集结果写入按数量轮转的文件或交给上传函数。
+	// Runner 实现了 kit/runtime 的 Runner 接口：Start 启动后台采集后立即返回，Stop 停止采集并等待正在进行的采集结束。
+	// 所有方法都是并发安全的。
+	Runner struct {
+		// o 是采集的配置。
+		o *options
+		// captureMu 保证同一时间只有一次采集。
+		captureMu stdsync.Mutex
+
+		// mu 保护以下字段。
+		mu stdsync.Mutex
+		// cancel 停止后台采集，未启动时为 nil。
+		cancel context.CancelFunc
+		// done 在后台采集协程退出时关闭。
+		done chan struct{}
+		// mutexFraction 是启动前的锁竞争采样比例，停止时恢复。
+		mutexFraction int
+	}
+)
+
+// New 创建一个持续采集 profile 的 Runner。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Runner：Runner 实例，需要调用 Start 启动。
+//
+// 示例：
+//
+//	r := profiling.New(
+//	    profiling.WithDir("/var/log/app/profiles"),
+//	    profiling.WithTrigger("heap_high", profiling.HeapAbove(2<<30)),
+//	)
+//	if err := r.Start(ctx); nil != err {
+//	    return err
+//	}
+//	defer r.Stop(context.Background())
+func New(opts ...Option) *Runner {
+	return &Runner{o: newOptions(opts...)}
+}
+
+// Start 启动后台采集后立即返回。采集 block 或 mutex profile 时同时开启对应的运行时采样。
+// ctx 结束时后台采集停止，但运行时采样的恢复需要调用 Stop。
+//
+// 参数：
+//   - ctx：后台采集的生命周期。
+//
+// 返回值：
+//   - error：已经启动时返回 ErrRunning，创建目录失败时返回错误。
+func (r *Runner) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if nil != r.cancel {
+		return ErrRunning
+	}
+	if nil == r.o.upload {
+		if err := os.MkdirAll(r.o.dir, 0o755); nil != err {
+			return fmt.Errorf("kit/profiling: 创建目录 %s 失败：%w", r.o.dir, err)
+		}
+	}
+
+	if containsProfile(r.o.profiles, ProfileBlock) {
+		runtime.SetBlockProfileRate(r.o.blockProfileRate)
+	}
+	if containsProfile(r.o.profiles, ProfileMutex) {
+		r.mutexFraction = runtime.SetMutexProfileFraction(r.o.mutexProfileFraction)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go r.run(ctx, r.done)
+	return nil
+}
+
+// Stop 停止后台采集，等待正在进行的采集结束，并关闭 Start 开启的运行时采样。未启动时不执行任何操作。
+// 正在进行的 CPU 采样会被提前结束并放弃。
+//
+// 参数：
+//   - ctx：停止操作的截止时间。
+//
+// 返回值：
+//   - error：截止时间到达时返回 ctx 的错误。
+func (r *Runner) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+
+	if nil == cancel {
+		return nil
+	}
+	cancel()
+
+	if containsProfile(r.o.profiles, ProfileBlock) {
+		runtime.SetBlockProfileRate(0)
+	}
+	if containsProfile(r.o.profiles, ProfileMutex) {
+		runtime.SetMutexProfileFraction(r.mutexFraction)
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Capture 立即采集全部 profile，可以在未启动时调用，例如在处理信号或管理接口时。
+// 同一时间只有一次采集，正在采集时等待其结束。CPU profile 最后采集，其余 profile 反映调用时的状态。
+//
+// 参数：
+//   - ctx：采集的上下文，结束时放弃正在进行的 CPU 采样。
+//   - reason：采集原因，用于日志与文件名，为空时为 ReasonManual。
+//
+// 返回值：
+//   - error：采集、写入或上传失败的错误，多个错误通过 errors.Join 合并；失败的 profile 不影响其余 profile。
+func (r *Runner) Capture(ctx context.Context, reason string) error {
+	if "" == reason {
+		reason = ReasonManual
+	}
+
+	r.captureMu.Lock()
+	defer r.captureMu.Unlock()
+
+	// CPU 采样需要等待，放在最后，使其余 profile 反映触发时的状态。
+	profiles := make([]Profile, 0, len(r.o.profiles))
+	for _, p := range r.o.profiles {
+		if ProfileCPU != p {
+			profiles = append(profiles, p)
+		}
+	}
+	if containsProfile(r.o.profiles, ProfileCPU) {
+		profiles = append(profiles, ProfileCPU)
+	}
+
+	var errs []error
+	for _, p := range profiles {
+		if err := r.captureOne(ctx, p, reason); nil != err {
+			errs = append(errs, err)
+		}
+		if err := ctx.Err(); nil != err {
+			errs = append(errs, err)
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// run 是后台采集协程，定期采集并检查触发条件。
+func (r *Runner) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	var intervalC, checkC <-chan time.Time
+	if r.o.interval > 0 {
+		ticker := r.o.clock.NewTicker(r.o.interval)
+		defer ticker.Stop()
+		intervalC = ticker.C()
+	}
+	if 0 < len(r.o.triggers) {
+		ticker := r.o.clock.NewTicker(r.o.checkInterval)
+		defer ticker.Stop()
+		checkC = ticker.C()
+	}
+
+	// fired 记录每个触发条件最近一次触发的时间。
+	fired := make(map[string]time.Time)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-intervalC:
+			_ = r.Capture(ctx, ReasonInterval)
+		case <-checkC:
+			r.check(ctx, fired)
+		}
+	}
+}
+
+// check 检查触发条件，满足且不在冷却时间内时采集。
+func (r *Runner) check(ctx context.Context, fired map[string]time.Time) {
+	for _, t := range r.o.triggers {
+		now := r.o.clock.Now()
+		if last, ok := fired[t.name]; ok && now.Sub(last) < r.o.cooldown {
+			continue
+		}
+		if !t.cond() {
+			continue
+		}
+		fired[t.name] = now
+		_ = r.Capture(ctx, t.name)
+	}
+}
+
+// captureOne 采集并保存一个 profile，记录结果到日志。
+func (r *Runner) captureOne(ctx context.Context, p Profile, reason string) error {
+	s := &Snapshot{Profile: p, Reason: reason, Time: r.o.clock.Now()}
+	fields := map[string]interface{}{
+		"profile": string(p),
+		"reason":  reason,
+	}
+	logger := r.o.getLogger()
+
+	data, err := r.collect(ctx, p)
+	if nil == err {
+		s.Data = data
+		err = r.save(ctx, s)
+	}
+	if nil != err {
+		logger.WithFields(fields).Error(err)
+		return err
+	}
+
+	fields["size"] = len(s.Data)
+	logger.WithFields(fields).Info("kit/profiling: profile 已采集")
+	return nil
+}
+
+// collect 采集一个 profile，返回 gzip 压缩的 protobuf 数据。
+func (r *Runner) collect(ctx context.Context, p Profile) ([]byte, error) {
+	var buf bytes.Buffer
+	if ProfileCPU != p {
+		if err := pprof.Lookup(string(p)).WriteTo(&buf, 0); nil != err {
+			return nil, fmt.Errorf("kit/profiling: 采集 %s profile 失败：%w", p, err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	if err := pprof.StartCPUProfile(&buf); nil != err {
+		return nil, fmt.Errorf("kit/profiling: 采集 cpu profile 失败：%w", err)
+	}
+	select {
+	case <-r.o.clock.After(r.o.cpuDuration):
+		pprof.StopCPUProfile()
+		return buf.Bytes(), nil
+	case <-ctx.Done():
+		pprof.StopCPUProfile()
+		return nil, fmt.Errorf("kit/profiling: 采集 cpu profile 失败：%w", ctx.Err())
+	}
+}
+
+// save 上传 profile，或写入文件并删除超出数量的旧文件。
+func (r *Runner) save(ctx context.Context, s *Snapshot) error {
+	if nil != r.o.upload {
+		if err := r.o.upload(ctx, s); nil != err {
+			return fmt.Errorf("kit/profiling: 上传 %s profile 失败：%w", s.Profile, err)
+		}
+		return nil
+	}
+
+	name := fmt.Sprintf("%s-%s-%s%s", s.Profile, s.Time.Format(fileTimeLayout), sanitize(s.Reason), fileExt)
+	file := filepath.Join(r.o.dir, name)
+	if err := os.WriteFile(file, s.Data, 0o644); nil != err {
+		return fmt.Errorf("kit/profiling: 写入 %s 失败：%w", file, err)
+	}
+	return r.rotate(s.Profile)
+}
+
+// rotate 删除 p 的超出保留数量的最早文件。
+func (r *Runner) rotate(p Profile) error {
+	entries, err := os.ReadDir(r.o.dir)
+	if nil != err {
+		return fmt.Errorf("kit/profiling: 清理 %s profile 文件失败：%w", p, err)
+	}
+
+	prefix := string(p) + "-"
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), prefix) && strings.HasSuffix(e.Name(), fileExt) {
+			names = append(names, e.Name())
+		}
+	}
+	if len(names) <= r.o.maxFiles {
+		return nil
+	}
+
+	sort.Strings(names)
+	var errs []error
+	for _, name := range names[:len(names)-r.o.maxFiles] {
+		if err := os.Remove(filepath.Join(r.o.dir, name)); nil != err && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); nil != err {
+		return fmt.Errorf("kit/profiling: 清理 %s profile 文件失败：%w", p, err)
+	}
+	return nil
+}
+
+// sanitize 将采集原因中不适合出现在文件名中的字符替换为下划线。
+func sanitize(reason string) string {
+	return strings.Map(func(c rune) rune {
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || '-' == c || '_' == c {
+			return c
+		}
+		return '_'
+	}, reason)
+}
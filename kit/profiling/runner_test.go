@@ -0,0 +1,366 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package profiling
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	stdsync "sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+type (
+	// recordUploader 记录上传的 profile。
+	recordUploader struct {
+		mu        stdsync.Mutex
+		snapshots []*Snapshot
+		err       error
+	}
+)
+
+// Upload 实现 UploadFunc。
+func (u *recordUploader) Upload(_ context.Context, s *Snapshot) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.snapshots = append(u.snapshots, s)
+	return u.err
+}
+
+// Snapshots 返回已上传的 profile。
+func (u *recordUploader) Snapshots() []*Snapshot {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]*Snapshot(nil), u.snapshots...)
+}
+
+// listFiles 返回 dir 中的文件名，按名称排序。
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// assertProfile 断言 data 是 gzip 压缩的非空 profile。
+func assertProfile(t *testing.T, data []byte) {
+	t.Helper()
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	raw, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.NotEmpty(t, raw)
+}
+
+// TestCapture 测试手动采集并写入文件。
+func TestCapture(t *testing.T) {
+	dir := t.TempDir()
+	clock := kittime.NewFakeClock(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	logger := logtest.NewRecorder()
+	r := New(
+		WithProfiles(ProfileHeap, ProfileGoroutine, ProfileHeap, Profile("unknown")),
+		WithDir(dir),
+		WithClock(clock),
+		WithLogger(logger),
+	)
+
+	require.NoError(t, r.Capture(context.Background(), ""))
+	require.NoError(t, r.Capture(context.Background(), "oom/suspect"))
+	assert.Equal(t, []string{
+		"goroutine-20250102T030405.000-manual.pb.gz",
+		"goroutine-20250102T030405.000-oom_suspect.pb.gz",
+		"heap-20250102T030405.000-manual.pb.gz",
+		"heap-20250102T030405.000-oom_suspect.pb.gz",
+	}, listFiles(t, dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, "heap-20250102T030405.000-manual.pb.gz"))
+	require.NoError(t, err)
+	assertProfile(t, data)
+	assert.Len(t, logger.Messages(), 4)
+	assert.Equal(t, "info kit/profiling: profile 已采集", logger.Messages()[0])
+}
+
+// TestCaptureCPU 测试 CPU 采样等待设置的时长，以及上下文取消时放弃采样。
+func TestCaptureCPU(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Now())
+	uploader := &recordUploader{}
+	r := New(
+		WithProfiles(ProfileCPU, ProfileHeap),
+		WithCPUDuration(time.Second),
+		WithUpload(uploader.Upload),
+		WithClock(clock),
+		WithLogger(logtest.NewRecorder()),
+	)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- r.Capture(context.Background(), "")
+	}()
+	clock.BlockUntil(1)
+	// heap 在 CPU 采样之前完成。
+	require.Len(t, uploader.Snapshots(), 1)
+	assert.Equal(t, ProfileHeap, uploader.Snapshots()[0].Profile)
+	clock.Advance(time.Second)
+	require.NoError(t, <-errCh)
+
+	snapshots := uploader.Snapshots()
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, ProfileCPU, snapshots[1].Profile)
+	assert.Equal(t, ReasonManual, snapshots[1].Reason)
+	assertProfile(t, snapshots[1].Data)
+
+	// 上下文取消时放弃 CPU 采样。
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		errCh <- r.Capture(ctx, "")
+	}()
+	clock.BlockUntil(1)
+	cancel()
+	err := <-errCh
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, uploader.Snapshots(), 3)
+
+	// 已经在进行 CPU 采样时采集失败，其余 profile 不受影响。
+	require.NoError(t, pprof.StartCPUProfile(io.Discard))
+	err = r.Capture(context.Background(), "")
+	pprof.StopCPUProfile()
+	assert.ErrorContains(t, err, "kit/profiling: 采集 cpu profile 失败")
+	assert.Len(t, uploader.Snapshots(), 4)
+}
+
+// TestRotate 测试超出保留数量时删除最早的文件。
+func TestRotate(t *testing.T) {
+	dir := t.TempDir()
+	clock := kittime.NewFakeClock(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	r := New(
+		WithProfiles(ProfileGoroutine),
+		WithDir(dir),
+		WithMaxFiles(2),
+		WithClock(clock),
+		WithLogger(logtest.NewRecorder()),
+	)
+	// 其他文件不受影响。
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "goroutine.txt"), nil, 0o644))
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, r.Capture(context.Background(), ""))
+		clock.Advance(time.Second)
+	}
+	assert.Equal(t, []string{
+		"goroutine-20250102T030407.000-manual.pb.gz",
+		"goroutine-20250102T030408.000-manual.pb.gz",
+		"goroutine.txt",
+	}, listFiles(t, dir))
+
+	// 目录被删除时写入失败。
+	require.NoError(t, os.RemoveAll(dir))
+	assert.ErrorContains(t, r.Capture(context.Background(), ""), "kit/profiling: 写入")
+	assert.ErrorContains(t, r.rotate(ProfileGoroutine), "kit/profiling: 清理 goroutine profile 文件失败")
+}
+
+// TestUploadError 测试上传失败时返回错误并记录日志。
+func TestUploadError(t *testing.T) {
+	logger := logtest.NewRecorder()
+	uploader := &recordUploader{err: errors.New("unavailable")}
+	r := New(
+		WithProfiles(ProfileHeap, ProfileGoroutine),
+		WithUpload(uploader.Upload),
+		WithLogger(logger),
+	)
+
+	err := r.Capture(context.Background(), "")
+	assert.ErrorContains(t, err, "kit/profiling: 上传 heap profile 失败：unavailable")
+	assert.ErrorContains(t, err, "kit/profiling: 上传 goroutine profile 失败：unavailable")
+	assert.Len(t, uploader.Snapshots(), 2)
+	assert.Equal(t, []string{
+		"error kit/profiling: 上传 heap profile 失败：unavailable",
+		"error kit/profiling: 上传 goroutine profile 失败：unavailable",
+	}, logger.Messages())
+}
+
+// TestRunnerInterval 测试定期采集与启动停止。
+func TestRunnerInterval(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Now())
+	uploader := &recordUploader{}
+	r := New(
+		WithProfiles(ProfileHeap),
+		WithInterval(time.Minute),
+		WithUpload(uploader.Upload),
+		WithClock(clock),
+		WithLogger(logtest.NewRecorder()),
+	)
+
+	require.NoError(t, r.Stop(context.Background()))
+	require.NoError(t, r.Start(context.Background()))
+	assert.ErrorIs(t, r.Start(context.Background()), ErrRunning)
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	assert.Eventually(t, func() bool {
+		return 1 == len(uploader.Snapshots())
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, ReasonInterval, uploader.Snapshots()[0].Reason)
+
+	require.NoError(t, r.Stop(context.Background()))
+	assert.Equal(t, 0, clock.Waiters())
+
+	// 停止后可以重新启动。
+	require.NoError(t, r.Start(context.Background()))
+	require.NoError(t, r.Stop(context.Background()))
+}
+
+// TestRunnerTrigger 测试触发条件满足时采集，以及冷却时间。
+func TestRunnerTrigger(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Now())
+	uploader := &recordUploader{}
+	var high atomic.Bool
+	r := New(
+		WithProfiles(ProfileGoroutine),
+		WithInterval(0),
+		WithTrigger("high", high.Load),
+		WithTrigger("ignored", nil),
+		WithCheckInterval(time.Second),
+		WithCooldown(10*time.Second),
+		WithUpload(uploader.Upload),
+		WithClock(clock),
+		WithLogger(logtest.NewRecorder()),
+	)
+	require.NoError(t, r.Start(context.Background()))
+	defer func() {
+		assert.NoError(t, r.Stop(context.Background()))
+	}()
+	// 只有检查触发条件的定时器。
+	clock.BlockUntil(1)
+	assert.Equal(t, 1, clock.Waiters())
+
+	// tick 推进一次检查间隔，并等待检查完成。
+	tick := func() {
+		clock.Advance(time.Second)
+		// 等待后台协程完成本次检查。
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	tick()
+	assert.Empty(t, uploader.Snapshots())
+
+	high.Store(true)
+	tick()
+	assert.Eventually(t, func() bool {
+		return 1 == len(uploader.Snapshots())
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, "high", uploader.Snapshots()[0].Reason)
+
+	// 冷却时间内不再采集。
+	for i := 0; i < 5; i++ {
+		tick()
+	}
+	assert.Len(t, uploader.Snapshots(), 1)
+
+	// 冷却时间结束后再次采集。
+	for i := 0; i < 5; i++ {
+		tick()
+	}
+	assert.Eventually(t, func() bool {
+		return 2 == len(uploader.Snapshots())
+	}, 5*time.Second, time.Millisecond)
+}
+
+// TestRunnerSampling 测试采集 block 与 mutex profile 时开启与恢复运行时采样。
+func TestRunnerSampling(t *testing.T) {
+	prev := runtime.SetMutexProfileFraction(7)
+	defer runtime.SetMutexProfileFraction(prev)
+
+	r := New(
+		WithProfiles(ProfileBlock, ProfileMutex),
+		WithInterval(0),
+		WithMutexProfileFraction(3),
+		WithDir(t.TempDir()),
+	)
+	require.NoError(t, r.Start(context.Background()))
+	assert.Equal(t, 3, runtime.SetMutexProfileFraction(-1))
+	require.NoError(t, r.Stop(context.Background()))
+	assert.Equal(t, 7, runtime.SetMutexProfileFraction(-1))
+}
+
+// TestStartError 测试创建目录失败。
+func TestStartError(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+
+	r := New(WithDir(filepath.Join(file, "profiles")))
+	assert.ErrorContains(t, r.Start(context.Background()), "kit/profiling: 创建目录")
+	require.NoError(t, r.Stop(context.Background()))
+}
+
+// TestConditions 测试内置的触发条件。
+func TestConditions(t *testing.T) {
+	assert.True(t, HeapAbove(0)())
+	assert.False(t, HeapAbove(1<<62)())
+	assert.True(t, GoroutinesAbove(0)())
+	assert.False(t, GoroutinesAbove(1<<30)())
+}
+
+// TestOptions 测试配置选项与默认值。
+func TestOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, profilesDefault, o.profiles)
+	assert.Equal(t, intervalDefault, o.interval)
+	assert.Equal(t, cpuDurationDefault, o.cpuDuration)
+	assert.Equal(t, dirDefault, o.dir)
+	assert.Equal(t, maxFilesDefault, o.maxFiles)
+	assert.Equal(t, checkIntervalDefault, o.checkInterval)
+	assert.Equal(t, cooldownDefault, o.cooldown)
+	assert.Equal(t, blockProfileRateDefault, o.blockProfileRate)
+	assert.Equal(t, mutexProfileFractionDefault, o.mutexProfileFraction)
+	assert.Equal(t, clockDefault, o.clock)
+	assert.Equal(t, kitlog.GetLogger(), o.getLogger())
+
+	o = newOptions(
+		WithProfiles(),
+		WithCPUDuration(0),
+		WithDir(""),
+		WithMaxFiles(0),
+		WithCheckInterval(0),
+		WithCooldown(-1),
+		WithBlockProfileRate(0),
+		WithMutexProfileFraction(0),
+		WithClock(nil),
+	)
+	assert.Equal(t, profilesDefault, o.profiles)
+	assert.Equal(t, cpuDurationDefault, o.cpuDuration)
+	assert.Equal(t, dirDefault, o.dir)
+	assert.Equal(t, maxFilesDefault, o.maxFiles)
+	assert.Equal(t, checkIntervalDefault, o.checkInterval)
+	assert.Equal(t, cooldownDefault, o.cooldown)
+	assert.Equal(t, blockProfileRateDefault, o.blockProfileRate)
+	assert.Equal(t, mutexProfileFractionDefault, o.mutexProfileFraction)
+	assert.Equal(t, clockDefault, o.clock)
+
+	o = newOptions(WithCooldown(0), WithBlockProfileRate(1), WithInterval(-1))
+	assert.Equal(t, time.Duration(0), o.cooldown)
+	assert.Equal(t, 1, o.blockProfileRate)
+	assert.Equal(t, time.Duration(-1), o.interval)
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package queue 提供了有界的多生产者多消费者队列，作为重试队列、批量收集器等组件的基础。
+
+主要功能：
+
+  - 背压：队列满时 Put 阻塞等待，TryPut 立即返回 ErrFull，由生产者选择处理方式
+  - 批量出队：GetBatch 一次取出多个元素，只要有元素就立即返回
+  - 关闭与排空：Close 之后拒绝新的元素，消费者继续取出剩余的元素，Drained 在取完后关闭
+  - 指标：记录队列深度与入队、出队、拒绝的元素数
+
+基本使用：
+
+	q := queue.New[*Task](1024, queue.WithName("task"))
+
+	// 生产者。
+	if err := q.Put(ctx, task); nil != err {
+	    return err
+	}
+
+	// 消费者。
+	for {
+	    tasks, err := q.GetBatch(ctx, 100)
+	    if nil != err {
+	        return
+	    }
+	    handle(tasks)
+	}
+*/
+package queue
@@ -0,0 +1,47 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package queue
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 定义队列指标相关的常量。
+const (
+	// namespace 定义 prometheus 指标的命名空间。
+	namespace = "kit_queue"
+)
+
+var (
+	// MetricDepth 用于记录队列中的元素数量。
+	// 该指标包含以下标签：
+	// - name: 队列的名称。
+	MetricDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "depth",
+		Help:      "queue's items currently queued.",
+	}, []string{"name"})
+
+	// MetricItems 用于记录队列处理的元素数。
+	// 该指标包含以下标签：
+	// - name: 队列的名称。
+	// - result: 处理结果，enqueued 表示入队，dequeued 表示出队，rejected 表示队列已满被拒绝。
+	MetricItems = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "items_total",
+		Help:      "queue's items total.",
+	}, []string{"name", "result"})
+)
+
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricDepth,
+		MetricItems,
+	}
+}
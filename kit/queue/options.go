@@ -0,0 +1,62 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package queue
+
+// 以下为队列的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// metricsDefault 为是否默认记录指标。
+	metricsDefault = true
+)
+
+type (
+	// Option 定义了队列的配置选项。
+	Option func(*options)
+
+	// options 包含队列的配置。
+	options struct {
+		// name 是队列的名称，用于指标标签。
+		name string
+		// metrics 表示是否记录指标。
+		metrics bool
+	}
+)
+
+// WithName 设置队列的名称，用于区分不同队列的指标。
+//
+// 参数：
+//   - name：队列的名称，默认为空。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithMetrics 设置是否记录指标。
+//
+// 参数：
+//   - metrics：是否记录指标，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMetrics(metrics bool) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// newOptions 创建并应用配置选项。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		metrics: metricsDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
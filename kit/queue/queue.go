@@ -0,0 +1,317 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package queue
+
+import (
+	"context"
+	"errors"
+	stdsync "sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// ErrFull 表示队列已满。
+	ErrFull = errors.New("kit/queue: 队列已满")
+	// ErrClosed 表示队列已经关闭；对出队操作而言，表示队列已经关闭且剩余元素已经取完。
+	ErrClosed = errors.New("kit/queue: 队列已关闭")
+)
+
+type (
+	// Queue 是有界的多生产者多消费者队列，队列满时通过阻塞或返回错误向生产者施加背压。
+	// 关闭之后不再接受新的元素，消费者可以继续取出剩余的元素。
+	// 所有方法都是并发安全的。
+	Queue[T any] struct {
+		// mu 保护以下字段。
+		mu stdsync.Mutex
+		// buf 是存放元素的环形缓冲区，长度即队列容量。
+		buf []T
+		// head 是队首元素在 buf 中的位置。
+		head int
+		// size 是队列中的元素数量。
+		size int
+		// closed 表示队列是否已经关闭。
+		closed bool
+		// readable 在有元素入队或队列关闭时关闭并替换，用于唤醒等待出队的调用方。
+		readable chan struct{}
+		// writable 在有元素出队或队列关闭时关闭并替换，用于唤醒等待入队的调用方。
+		writable chan struct{}
+		// drained 在队列关闭且元素取完时关闭。
+		drained chan struct{}
+
+		// depth 记录队列中的元素数量，未启用指标时为 nil。
+		depth prometheus.Gauge
+		// enqueued 记录入队的元素数，未启用指标时为 nil。
+		enqueued prometheus.Counter
+		// dequeued 记录出队的元素数，未启用指标时为 nil。
+		dequeued prometheus.Counter
+		// rejected 记录因队列已满被拒绝的元素数，未启用指标时为 nil。
+		rejected prometheus.Counter
+	}
+)
+
+// New 创建一个有界队列。
+//
+// 参数：
+//   - capacity：队列的容量，小于 1 时按 1 处理。
+//   - opts：配置选项，支持 WithName 与 WithMetrics。
+//
+// 返回值：
+//   - *Queue[T]：队列实例。
+//
+// 示例：
+//
+//	q := queue.New[*Task](1024, queue.WithName("task"))
+//	if err := q.Put(ctx, task); nil != err {
+//	    return err
+//	}
+func New[T any](capacity int, opts ...Option) *Queue[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	o := newOptions(opts...)
+	q := &Queue[T]{
+		buf:      make([]T, capacity),
+		readable: make(chan struct{}),
+		writable: make(chan struct{}),
+		drained:  make(chan struct{}),
+	}
+	if o.metrics {
+		q.depth = MetricDepth.WithLabelValues(o.name)
+		q.enqueued = MetricItems.WithLabelValues(o.name, "enqueued")
+		q.dequeued = MetricItems.WithLabelValues(o.name, "dequeued")
+		q.rejected = MetricItems.WithLabelValues(o.name, "rejected")
+	}
+	return q
+}
+
+// Put 将元素放入队列，队列已满时阻塞等待，直到有空位、队列关闭或上下文被取消。
+//
+// 参数：
+//   - ctx：上下文，用于取消等待。
+//   - v：要放入的元素。
+//
+// 返回值：
+//   - error：队列已关闭时返回 ErrClosed，上下文被取消时返回上下文的错误。
+func (q *Queue[T]) Put(ctx context.Context, v T) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+		if q.size < len(q.buf) {
+			q.push(v)
+			q.mu.Unlock()
+			return nil
+		}
+		writable := q.writable
+		q.mu.Unlock()
+
+		select {
+		case <-writable:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// TryPut 将元素放入队列，队列已满时立即返回 ErrFull，不会等待。
+//
+// 参数：
+//   - v：要放入的元素。
+//
+// 返回值：
+//   - error：队列已满时返回 ErrFull，队列已关闭时返回 ErrClosed。
+func (q *Queue[T]) TryPut(v T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+	if q.size == len(q.buf) {
+		if nil != q.rejected {
+			q.rejected.Inc()
+		}
+		return ErrFull
+	}
+	q.push(v)
+	return nil
+}
+
+// Get 从队列中取出一个元素，队列为空时阻塞等待，直到有元素、队列关闭或上下文被取消。
+//
+// 参数：
+//   - ctx：上下文，用于取消等待。
+//
+// 返回值：
+//   - T：取出的元素。
+//   - error：队列已关闭且元素已经取完时返回 ErrClosed，上下文被取消时返回上下文的错误。
+func (q *Queue[T]) Get(ctx context.Context) (T, error) {
+	var zero T
+	items, err := q.GetBatch(ctx, 1)
+	if nil != err {
+		return zero, err
+	}
+	return items[0], nil
+}
+
+// TryGet 从队列中取出一个元素，队列为空时立即返回，不会等待。
+//
+// 返回值：
+//   - T：取出的元素。
+//   - bool：取出元素时返回 true。
+func (q *Queue[T]) TryGet() (T, bool) {
+	var zero T
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if 0 == q.size {
+		return zero, false
+	}
+	return q.pop(1)[0], true
+}
+
+// GetBatch 从队列中取出最多 max 个元素，队列为空时阻塞等待，直到有元素、队列关闭或上下文被取消。
+// 只要有元素就立即返回，不会等待凑满 max 个。
+//
+// 参数：
+//   - ctx：上下文，用于取消等待。
+//   - max：最多取出的元素数量，小于 1 时按 1 处理。
+//
+// 返回值：
+//   - []T：取出的元素，按入队顺序排列，至少包含一个元素。
+//   - error：队列已关闭且元素已经取完时返回 ErrClosed，上下文被取消时返回上下文的错误。
+func (q *Queue[T]) GetBatch(ctx context.Context, max int) ([]T, error) {
+	if max < 1 {
+		max = 1
+	}
+	for {
+		q.mu.Lock()
+		if q.size > 0 {
+			items := q.pop(max)
+			q.mu.Unlock()
+			return items, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return nil, ErrClosed
+		}
+		readable := q.readable
+		q.mu.Unlock()
+
+		select {
+		case <-readable:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Close 关闭队列。关闭之后 Put 与 TryPut 返回 ErrClosed，等待入队的调用方被唤醒；
+// 消费者可以继续取出剩余的元素，取完之后出队操作返回 ErrClosed。重复调用不执行任何操作。
+func (q *Queue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.signalReadable()
+	q.signalWritable()
+	if 0 == q.size {
+		close(q.drained)
+	}
+}
+
+// Drained 返回一个在队列关闭且剩余元素全部取出后关闭的通道，用于等待消费者处理完剩余的元素。
+//
+// 返回值：
+//   - <-chan struct{}：队列排空时关闭的通道。
+//
+// 示例：
+//
+//	q.Close()
+//	select {
+//	case <-q.Drained():
+//	case <-ctx.Done():
+//	    return ctx.Err()
+//	}
+func (q *Queue[T]) Drained() <-chan struct{} {
+	return q.drained
+}
+
+// Len 返回队列中的元素数量。
+//
+// 返回值：
+//   - int：元素数量。
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// Cap 返回队列的容量。
+//
+// 返回值：
+//   - int：队列的容量。
+func (q *Queue[T]) Cap() int {
+	return len(q.buf)
+}
+
+// IsClosed 返回队列是否已经关闭。
+//
+// 返回值：
+//   - bool：队列已关闭时返回 true。
+func (q *Queue[T]) IsClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+// push 将元素放入队尾，调用方需要持有锁并保证队列未满。
+func (q *Queue[T]) push(v T) {
+	q.buf[(q.head+q.size)%len(q.buf)] = v
+	q.size++
+	q.signalReadable()
+	if nil != q.enqueued {
+		q.enqueued.Inc()
+		q.depth.Set(float64(q.size))
+	}
+}
+
+// pop 从队首取出最多 max 个元素，调用方需要持有锁并保证队列不为空。
+func (q *Queue[T]) pop(max int) []T {
+	n := min(max, q.size)
+	items := make([]T, n)
+	var zero T
+	for i := range n {
+		items[i] = q.buf[q.head]
+		// 清除引用，避免已经出队的元素无法被回收。
+		q.buf[q.head] = zero
+		q.head = (q.head + 1) % len(q.buf)
+	}
+	q.size -= n
+	q.signalWritable()
+	if q.closed && 0 == q.size {
+		close(q.drained)
+	}
+	if nil != q.dequeued {
+		q.dequeued.Add(float64(n))
+		q.depth.Set(float64(q.size))
+	}
+	return items
+}
+
+// signalReadable 唤醒所有等待出队的调用方，调用方需要持有锁。
+func (q *Queue[T]) signalReadable() {
+	close(q.readable)
+	q.readable = make(chan struct{})
+}
+
+// signalWritable 唤醒所有等待入队的调用方，调用方需要持有锁。
+func (q *Queue[T]) signalWritable() {
+	close(q.writable)
+	q.writable = make(chan struct{})
+}
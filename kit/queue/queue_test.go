@@ -0,0 +1,206 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package queue
+
+import (
+	"context"
+	"strconv"
+	stdsync "sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQueue 测试元素按入队顺序出队，以及非阻塞的入队与出队。
+func TestQueue(t *testing.T) {
+	q := New[int](2, WithMetrics(false))
+	assert.Equal(t, 2, q.Cap())
+
+	require.NoError(t, q.TryPut(1))
+	require.NoError(t, q.Put(context.Background(), 2))
+	assert.ErrorIs(t, q.TryPut(3), ErrFull)
+	assert.Equal(t, 2, q.Len())
+
+	v, err := q.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	require.NoError(t, q.TryPut(3))
+	v, ok := q.TryGet()
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	v, ok = q.TryGet()
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	_, ok = q.TryGet()
+	assert.False(t, ok)
+
+	// 容量小于 1 时按 1 处理。
+	assert.Equal(t, 1, New[int](0, WithMetrics(false)).Cap())
+}
+
+// TestQueue_Backpressure 测试队列已满时 Put 阻塞，直到有元素出队或上下文被取消。
+func TestQueue_Backpressure(t *testing.T) {
+	q := New[int](1, WithMetrics(false))
+	require.NoError(t, q.Put(context.Background(), 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, q.Put(ctx, 2), context.DeadlineExceeded)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- q.Put(context.Background(), 2)
+	}()
+	select {
+	case <-done:
+		t.Fatal("队列已满时 Put 不应返回")
+	case <-time.After(20 * time.Millisecond):
+	}
+	v, err := q.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	require.NoError(t, <-done)
+	v, err = q.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = q.Get(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestQueue_GetBatch 测试批量出队不等待凑满，环形缓冲区回绕后顺序不变。
+func TestQueue_GetBatch(t *testing.T) {
+	q := New[int](4, WithMetrics(false))
+	for i := range 3 {
+		require.NoError(t, q.TryPut(i))
+	}
+	items, err := q.GetBatch(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, items)
+
+	for i := 3; i < 6; i++ {
+		require.NoError(t, q.TryPut(i))
+	}
+	items, err = q.GetBatch(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4, 5}, items)
+
+	done := make(chan []int, 1)
+	go func() {
+		items, _ := q.GetBatch(context.Background(), 0)
+		done <- items
+	}()
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, q.TryPut(6))
+	assert.Equal(t, []int{6}, <-done)
+}
+
+// TestQueue_Close 测试关闭后拒绝入队、唤醒等待方，剩余元素取完后出队返回 ErrClosed。
+func TestQueue_Close(t *testing.T) {
+	q := New[int](1, WithMetrics(false))
+	require.NoError(t, q.TryPut(1))
+
+	putDone := make(chan error, 1)
+	go func() {
+		putDone <- q.Put(context.Background(), 2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	q.Close()
+	q.Close()
+	assert.True(t, q.IsClosed())
+	assert.ErrorIs(t, <-putDone, ErrClosed)
+	assert.ErrorIs(t, q.TryPut(3), ErrClosed)
+
+	select {
+	case <-q.Drained():
+		t.Fatal("剩余元素未取完时不应排空")
+	default:
+	}
+	v, err := q.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	<-q.Drained()
+	_, err = q.Get(context.Background())
+	assert.ErrorIs(t, err, ErrClosed)
+
+	// 等待出队的调用方在关闭时被唤醒。
+	empty := New[int](1, WithMetrics(false))
+	getDone := make(chan error, 1)
+	go func() {
+		_, err := empty.Get(context.Background())
+		getDone <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	empty.Close()
+	assert.ErrorIs(t, <-getDone, ErrClosed)
+	<-empty.Drained()
+}
+
+// TestQueue_Concurrent 测试多个生产者与消费者并发使用时不丢失也不重复元素。
+func TestQueue_Concurrent(t *testing.T) {
+	const producers, perProducer = 4, 1000
+	q := New[int](8, WithMetrics(false))
+
+	var wg stdsync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				assert.NoError(t, q.Put(context.Background(), p*perProducer+i))
+			}
+		}()
+	}
+
+	var mu stdsync.Mutex
+	seen := make(map[int]bool)
+	var consumers stdsync.WaitGroup
+	for range 3 {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				items, err := q.GetBatch(context.Background(), 5)
+				if nil != err {
+					return
+				}
+				mu.Lock()
+				for _, v := range items {
+					assert.False(t, seen[v])
+					seen[v] = true
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	q.Close()
+	consumers.Wait()
+	assert.Len(t, seen, producers*perProducer)
+}
+
+// TestQueue_Metrics 测试队列深度与元素数指标。
+func TestQueue_Metrics(t *testing.T) {
+	name := t.Name() + strconv.FormatInt(time.Now().UnixNano(), 10)
+	q := New[int](2, WithName(name))
+	require.NoError(t, q.TryPut(1))
+	require.NoError(t, q.TryPut(2))
+	assert.ErrorIs(t, q.TryPut(3), ErrFull)
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricDepth.WithLabelValues(name)))
+
+	_, err := q.GetBatch(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), testutil.ToFloat64(MetricDepth.WithLabelValues(name)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricItems.WithLabelValues(name, "enqueued")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricItems.WithLabelValues(name, "dequeued")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricItems.WithLabelValues(name, "rejected")))
+}
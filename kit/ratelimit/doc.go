@@ -0,0 +1,43 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package ratelimit 提供了限流功能，用于保护下游服务不被突发流量压垮。
+
+主要功能：
+
+  - 令牌桶：TokenBucket 以固定速率产生令牌，允许不超过容量的突发请求
+  - 滑动窗口：SlidingWindow 保证任意长度为窗口大小的时间段内请求数不超过上限，并提供 Prometheus 指标
+  - 按键限流：KeyedLimiter 为每个键（用户、客户端 IP、下游服务等）维护独立的限流器，长时间未访问的键自动清理
+  - 三种使用方式：Allow 不等待直接判断，Wait 阻塞等待直到获得令牌，Reserve 预约令牌并由调用方决定是否等待
+
+基本使用：
+
+	// 每秒 10 个请求，允许 20 个突发请求。
+	limiter := ratelimit.NewTokenBucket(ratelimit.WithRate(10), ratelimit.WithBurst(20))
+
+	// 超出限制时直接拒绝。
+	if !limiter.Allow() {
+	    return ErrTooManyRequests
+	}
+
+	// 超出限制时等待，上下文被取消或等待时间会超过截止时间时返回错误。
+	if err := limiter.Wait(ctx); nil != err {
+	    return err
+	}
+
+滑动窗口：
+
+	// 任意一分钟内最多 600 个请求。
+	limiter := ratelimit.NewSlidingWindow(ratelimit.WithLimit(600), ratelimit.WithWindow(time.Minute))
+
+按键限流：
+
+	limiter := ratelimit.NewKeyedLimiter[string](ratelimit.WithRate(5), ratelimit.WithKeyExpiry(time.Hour))
+	if !limiter.Allow(clientIP) {
+	    http.Error(w, "请求过于频繁", http.StatusTooManyRequests)
+	    return
+	}
+*/
+package ratelimit
@@ -0,0 +1,171 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+type (
+	// KeyedLimiter 为每个键维护独立的限流器，例如按用户、按客户端 IP 或按下游服务限流。
+	// 键在第一次访问时创建对应的限流器；超过过期时间未被访问的键会在之后的访问中被清理，避免内存无限增长。
+	KeyedLimiter[K comparable] struct {
+		// mu 保护 entries 与 lastSweep。
+		mu sync.Mutex
+		// entries 是各键对应的限流器。
+		entries map[K]*keyedEntry
+		// newLimiter 为新的键创建限流器。
+		newLimiter func() Limiter
+		// expiry 是键在未被访问多久后过期。
+		expiry time.Duration
+		// lastSweep 是最后一次清理过期键的时间。
+		lastSweep time.Time
+		// clock 是读取当前时间使用的时钟。
+		clock kittime.Clock
+	}
+
+	// keyedEntry 是一个键对应的限流器及其最后访问时间。
+	keyedEntry struct {
+		// limiter 是该键的限流器。
+		limiter Limiter
+		// lastAccess 是最后一次访问的时间。
+		lastAccess time.Time
+	}
+)
+
+// NewKeyedLimiter 创建按键限流的限流器，每个键使用由 opts 配置的令牌桶。
+//
+// 参数：
+//   - opts：配置选项，支持 WithRate、WithInterval、WithBurst 与 WithKeyExpiry。
+//
+// 返回值：
+//   - *KeyedLimiter[K]：按键限流的限流器。
+//
+// 示例：
+//
+//	// 每个客户端 IP 每秒 5 个请求。
+//	limiter := ratelimit.NewKeyedLimiter[string](ratelimit.WithRate(5))
+//	if !limiter.Allow(clientIP) {
+//	    http.Error(w, "请求过于频繁", http.StatusTooManyRequests)
+//	    return
+//	}
+func NewKeyedLimiter[K comparable](opts ...Option) *KeyedLimiter[K] {
+	return NewKeyedLimiterFunc[K](func() Limiter {
+		return NewTokenBucket(opts...)
+	}, opts...)
+}
+
+// NewKeyedLimiterFunc 创建按键限流的限流器，每个键的限流器由 newLimiter 创建。
+//
+// 参数：
+//   - newLimiter：为新的键创建限流器的函数。
+//   - opts：配置选项，只有 WithKeyExpiry 生效。
+//
+// 返回值：
+//   - *KeyedLimiter[K]：按键限流的限流器。
+func NewKeyedLimiterFunc[K comparable](newLimiter func() Limiter, opts ...Option) *KeyedLimiter[K] {
+	o := newOptions(opts...)
+	return &KeyedLimiter[K]{
+		entries:    make(map[K]*keyedEntry),
+		newLimiter: newLimiter,
+		expiry:     o.keyExpiry,
+		lastSweep:  o.clock.Now(),
+		clock:      o.clock,
+	}
+}
+
+// Get 返回键对应的限流器，不存在时创建。
+//
+// 参数：
+//   - key：限流的键。
+//
+// 返回值：
+//   - Limiter：键对应的限流器。
+func (l *KeyedLimiter[K]) Get(key K) Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.sweep(now)
+
+	e, ok := l.entries[key]
+	if !ok {
+		e = &keyedEntry{limiter: l.newLimiter()}
+		l.entries[key] = e
+	}
+	e.lastAccess = now
+	return e.limiter
+}
+
+// Allow 判断键当前是否允许一次请求。
+//
+// 参数：
+//   - key：限流的键。
+//
+// 返回值：
+//   - bool：允许时返回 true。
+func (l *KeyedLimiter[K]) Allow(key K) bool {
+	return l.Get(key).Allow()
+}
+
+// Wait 阻塞等待直到键获得一个令牌。
+//
+// 参数：
+//   - ctx：上下文，用于取消等待。
+//   - key：限流的键。
+//
+// 返回值：
+//   - error：上下文被取消或等待时间会超过截止时间时返回错误。
+func (l *KeyedLimiter[K]) Wait(ctx context.Context, key K) error {
+	return l.Get(key).Wait(ctx)
+}
+
+// Reserve 为键预约一个令牌。
+//
+// 参数：
+//   - key：限流的键。
+//
+// 返回值：
+//   - *Reservation：预约结果。
+func (l *KeyedLimiter[K]) Reserve(key K) *Reservation {
+	return l.Get(key).Reserve()
+}
+
+// Remove 删除键对应的限流器，下次访问时重新创建。
+//
+// 参数：
+//   - key：限流的键。
+func (l *KeyedLimiter[K]) Remove(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.entries, key)
+}
+
+// Len 返回当前维护的键的数量。
+//
+// 返回值：
+//   - int：键的数量。
+func (l *KeyedLimiter[K]) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.entries)
+}
+
+// sweep 清理过期的键，每个过期时间内最多执行一次，调用方需要持有锁。
+func (l *KeyedLimiter[K]) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.expiry {
+		return
+	}
+	l.lastSweep = now
+	for k, e := range l.entries {
+		if now.Sub(e.lastAccess) >= l.expiry {
+			delete(l.entries, k)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// TestKeyedLimiter 测试按键独立限流。
+func TestKeyedLimiter(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	l := NewKeyedLimiter[string](WithRate(1), WithBurst(2), WithClock(clock))
+
+	assert.True(t, l.Allow("a"))
+	assert.True(t, l.Allow("a"))
+	assert.False(t, l.Allow("a"))
+	assert.True(t, l.Allow("b"), "不同的键互不影响")
+	assert.Equal(t, 2, l.Len())
+	assert.Same(t, l.Get("a"), l.Get("a"))
+
+	r := l.Reserve("b")
+	require.True(t, r.OK())
+	assert.Equal(t, time.Duration(0), r.Delay())
+	assert.NoError(t, l.Wait(context.Background(), "c"))
+
+	l.Remove("a")
+	assert.Equal(t, 2, l.Len())
+	assert.True(t, l.Allow("a"), "删除后重新创建限流器")
+}
+
+// TestKeyedLimiter_Expiry 测试过期键的清理。
+func TestKeyedLimiter_Expiry(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	l := NewKeyedLimiter[int](WithKeyExpiry(time.Minute), WithClock(clock))
+
+	l.Allow(1)
+	l.Allow(2)
+	clock.Advance(30 * time.Second)
+	l.Allow(2)
+	assert.Equal(t, 2, l.Len())
+
+	clock.Advance(40 * time.Second)
+	l.Allow(3)
+	assert.Equal(t, 2, l.Len(), "键 1 超过一分钟未访问，被清理")
+
+	clock.Advance(2 * time.Minute)
+	l.Allow(4)
+	assert.Equal(t, 1, l.Len())
+}
+
+// TestNewKeyedLimiterFunc 测试自定义限流器工厂。
+func TestNewKeyedLimiterFunc(t *testing.T) {
+	created := 0
+	l := NewKeyedLimiterFunc[string](func() Limiter {
+		created++
+		return NewTokenBucket(WithRate(1), WithBurst(1))
+	})
+	assert.True(t, l.Allow("x"))
+	assert.False(t, l.Allow("x"))
+	assert.True(t, l.Allow("y"))
+	assert.Equal(t, 2, created)
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+var (
+	// ErrExceedsBurst 表示一次请求的令牌数超过了限流器的容量，永远无法满足。
+	ErrExceedsBurst = errors.New("请求的令牌数超过限流器容量")
+	// ErrWouldExceedDeadline 表示等待令牌的时间会超过上下文的截止时间。
+	ErrWouldExceedDeadline = errors.New("等待令牌的时间超过上下文截止时间")
+)
+
+// 以下为限流器的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// rateDefault 为每秒产生的令牌数。
+	rateDefault = float64(100)
+	// keyExpiryDefault 为按键限流时，键在未被访问多久后过期。
+	keyExpiryDefault = 10 * time.Minute
+	// limitDefault 为滑动窗口内允许的最大请求数。
+	limitDefault = 100
+	// windowDefault 为滑动窗口的大小。
+	windowDefault = time.Second
+	// metricsDefault 为是否默认记录指标。
+	metricsDefault = true
+	// clockDefault 为读取当前时间与等待使用的时钟。
+	clockDefault = kittime.NewRealClock()
+)
+
+type (
+	// Limiter 定义了限流器的接口。
+	// 所有方法都是并发安全的。
+	Limiter interface {
+		// Allow 判断当前是否允许一次请求，允许时消耗一个令牌，不会等待。
+		//
+		// 返回值：
+		//   - bool：允许时返回 true。
+		Allow() bool
+
+		// AllowN 判断当前是否允许 n 个请求，允许时消耗 n 个令牌，不会等待。
+		//
+		// 参数：
+		//   - n：请求的令牌数。
+		//
+		// 返回值：
+		//   - bool：允许时返回 true。
+		AllowN(n int) bool
+
+		// Wait 阻塞等待直到获得一个令牌，或者上下文被取消。
+		//
+		// 参数：
+		//   - ctx：上下文，用于取消等待。
+		//
+		// 返回值：
+		//   - error：上下文被取消或等待时间会超过截止时间时返回错误。
+		Wait(ctx context.Context) error
+
+		// WaitN 阻塞等待直到获得 n 个令牌，或者上下文被取消。
+		//
+		// 参数：
+		//   - ctx：上下文，用于取消等待。
+		//   - n：请求的令牌数。
+		//
+		// 返回值：
+		//   - error：n 超过容量、上下文被取消或等待时间会超过截止时间时返回错误。
+		WaitN(ctx context.Context, n int) error
+
+		// Reserve 预约一个令牌，返回需要等待的时间，由调用方决定是否等待。
+		//
+		// 返回值：
+		//   - *Reservation：预约结果。
+		Reserve() *Reservation
+
+		// ReserveN 预约 n 个令牌。
+		//
+		// 参数：
+		//   - n：请求的令牌数。
+		//
+		// 返回值：
+		//   - *Reservation：预约结果，n 超过容量时 OK 返回 false。
+		ReserveN(n int) *Reservation
+	}
+
+	// Reservation 是一次令牌预约的结果。
+	// 预约成功后，调用方应等待 Delay 返回的时间再执行请求；放弃执行时调用 Cancel 归还令牌。
+	Reservation struct {
+		// ok 表示预约是否成功。
+		ok bool
+		// delay 是预约时计算的需要等待的时间。
+		delay time.Duration
+		// cancel 归还预约的令牌，为 nil 时 Cancel 不执行任何操作。
+		cancel func()
+		// cancelOnce 保证令牌只归还一次。
+		cancelOnce sync.Once
+	}
+
+	// Option 定义了限流器的配置选项。
+	Option func(*options)
+
+	// options 包含限流器的配置。
+	options struct {
+		// rate 是每秒产生的令牌数。
+		rate float64
+		// burst 是令牌桶的容量，即允许的最大突发请求数。
+		burst int
+		// keyExpiry 是按键限流时，键在未被访问多久后过期。
+		keyExpiry time.Duration
+		// limit 是滑动窗口内允许的最大请求数。
+		limit int
+		// window 是滑动窗口的大小。
+		window time.Duration
+		// windowMode 是滑动窗口的计数方式。
+		windowMode WindowMode
+		// name 是限流器的名称，用于指标标签。
+		name string
+		// metrics 表示是否记录指标。
+		metrics bool
+		// clock 是读取当前时间与等待使用的时钟。
+		clock kittime.Clock
+	}
+)
+
+// WithRate 设置每秒产生的令牌数，即稳定状态下每秒允许的请求数。
+//
+// 参数：
+//   - rate：每秒产生的令牌数，必须大于 0，默认为 100。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithRate(rate float64) Option {
+	return func(o *options) {
+		o.rate = rate
+	}
+}
+
+// WithInterval 以产生一个令牌的时间间隔设置速率，例如每 200 毫秒一个请求。
+//
+// 参数：
+//   - interval：产生一个令牌的时间间隔，必须大于 0。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		if interval > 0 {
+			o.rate = float64(time.Second) / float64(interval)
+		}
+	}
+}
+
+// WithBurst 设置令牌桶的容量，即允许的最大突发请求数。
+//
+// 参数：
+//   - burst：令牌桶容量，必须大于 0，默认与每秒产生的令牌数相同（至少为 1）。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithBurst(burst int) Option {
+	return func(o *options) {
+		o.burst = burst
+	}
+}
+
+// WithKeyExpiry 设置按键限流时，键在未被访问多久后过期并被清理，仅对 KeyedLimiter 生效。
+//
+// 参数：
+//   - expiry：过期时间，默认为 10 分钟。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithKeyExpiry(expiry time.Duration) Option {
+	return func(o *options) {
+		o.keyExpiry = expiry
+	}
+}
+
+// WithClock 设置读取当前时间与等待令牌使用的时钟。
+// 测试时可以注入 kit/time 的 FakeClock，推进时钟即可补充令牌或滑动窗口。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		rate:      rateDefault,
+		keyExpiry: keyExpiryDefault,
+		limit:     limitDefault,
+		window:    windowDefault,
+		metrics:   metricsDefault,
+		clock:     clockDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.rate <= 0 {
+		o.rate = rateDefault
+	}
+	if o.burst <= 0 {
+		o.burst = int(o.rate)
+		if o.burst < 1 {
+			o.burst = 1
+		}
+	}
+	if o.keyExpiry <= 0 {
+		o.keyExpiry = keyExpiryDefault
+	}
+	if o.limit <= 0 {
+		o.limit = limitDefault
+	}
+	if o.window <= 0 {
+		o.window = windowDefault
+	}
+	o.clock = kittime.OrReal(o.clock)
+	return o
+}
+
+// OK 返回预约是否成功。
+// 请求的令牌数超过容量时预约失败。
+//
+// 返回值：
+//   - bool：预约成功时返回 true。
+func (r *Reservation) OK() bool {
+	return r.ok
+}
+
+// Delay 返回执行请求前需要等待的时间。
+//
+// 返回值：
+//   - time.Duration：需要等待的时间，0 表示可以立即执行。
+func (r *Reservation) Delay() time.Duration {
+	return r.delay
+}
+
+// Cancel 放弃预约并归还令牌，多次调用只生效一次。
+// 预约失败时 Cancel 不执行任何操作。
+func (r *Reservation) Cancel() {
+	if nil != r.cancel {
+		r.cancelOnce.Do(r.cancel)
+	}
+}
+
+// wait 按预约结果等待。
+// 等待时间会超过上下文的截止时间或上下文被取消时，归还令牌并返回错误。
+func wait(ctx context.Context, clock kittime.Clock, r *Reservation) error {
+	if !r.ok {
+		return ErrExceedsBurst
+	}
+	if err := ctx.Err(); nil != err {
+		r.Cancel()
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(clock.Now()) < r.delay {
+		r.Cancel()
+		return ErrWouldExceedDeadline
+	}
+	if r.delay <= 0 {
+		return nil
+	}
+
+	timer := clock.NewTimer(r.delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
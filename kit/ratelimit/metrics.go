@@ -0,0 +1,51 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 定义限流器指标相关的常量。
+const (
+	// namespace 定义 prometheus 指标的命名空间。
+	namespace = "kit_ratelimit"
+	// subsystem 定义 prometheus 指标的子系统名称。
+	subsystem = "sliding_window"
+)
+
+var (
+	// MetricRequests 用于记录限流器处理的请求数。
+	// 该指标包含以下标签：
+	// - name: 限流器的名称。
+	// - result: 处理结果，allowed 表示允许（包括预约成功），rejected 表示拒绝。
+	MetricRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "requests_total",
+		Help:      "rate limiter's requests total.",
+	}, []string{"name", "result"})
+
+	// MetricWindowUsage 用于记录限流器当前窗口内的请求数。
+	// 该指标包含以下标签：
+	// - name: 限流器的名称。
+	MetricWindowUsage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "window_usage",
+		Help:      "rate limiter's requests in current window.",
+	}, []string{"name"})
+)
+
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricRequests,
+		MetricWindowUsage,
+	}
+}
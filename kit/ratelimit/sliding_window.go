@@ -0,0 +1,430 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// WindowMode 定义了滑动窗口的计数方式。
+type WindowMode int
+
+const (
+	// WindowModeLog 记录窗口内每个请求的时间，任意长度为窗口大小的时间段内的请求数都不会超过上限。
+	// 内存占用与上限成正比。
+	WindowModeLog WindowMode = iota
+	// WindowModeCounter 只记录当前与上一个固定窗口的请求数，按时间比例加权估算滑动窗口内的请求数。
+	// 内存占用为常数，但在请求分布不均匀时可能有少量误差。
+	WindowModeCounter
+)
+
+var (
+	// 确保 SlidingWindow 实现了 Limiter 接口。
+	_ Limiter = (*SlidingWindow)(nil)
+)
+
+type (
+	// SlidingWindow 是滑动窗口限流器，保证任意长度为 window 的时间段内最多允许 limit 个请求。
+	// 与令牌桶相比，它精确地实现了“每滚动一分钟最多 N 个请求”的语义，常用于对接有此类配额的外部 API。
+	SlidingWindow struct {
+		// mu 保护 counter 与 last。
+		mu sync.Mutex
+		// limit 是窗口内允许的最大请求数。
+		limit int
+		// window 是窗口大小。
+		window time.Duration
+		// counter 是窗口内请求的计数实现。
+		counter windowCounter
+		// last 是最后一次预约的执行时间，预约按顺序执行。
+		last time.Time
+		// clock 是读取当前时间与等待使用的时钟。
+		clock kittime.Clock
+		// allowed 是允许请求数的指标，未开启指标时为 nil。
+		allowed prometheus.Counter
+		// rejected 是拒绝请求数的指标，未开启指标时为 nil。
+		rejected prometheus.Counter
+		// usage 是当前窗口内请求数的指标，未开启指标时为 nil。
+		usage prometheus.Gauge
+	}
+
+	// windowCounter 定义了滑动窗口内请求的计数方式，调用方需要持有锁。
+	windowCounter interface {
+		// earliest 返回不早于 at 的、可以再容纳 n 个请求的最早时间。
+		earliest(at time.Time, n int) time.Time
+		// add 在时间 at 记录 n 个请求。
+		add(at time.Time, n int)
+		// remove 撤销在时间 at 记录的 n 个请求。
+		remove(at time.Time, n int)
+		// count 返回时间 at 所在窗口内的请求数。
+		count(at time.Time) int
+		// evict 清理在时间 now 已经不再参与计数的记录。
+		evict(now time.Time)
+	}
+
+	// logCounter 记录窗口内每个请求的时间，时间按升序排列。
+	logCounter struct {
+		// window 是窗口大小。
+		window time.Duration
+		// limit 是窗口内允许的最大请求数。
+		limit int
+		// times 是各请求的时间。
+		times []time.Time
+	}
+
+	// weightedCounter 按固定窗口计数，并以上一个窗口的请求数按时间比例加权估算滑动窗口内的请求数。
+	weightedCounter struct {
+		// window 是窗口大小。
+		window time.Duration
+		// limit 是窗口内允许的最大请求数。
+		limit int
+		// counts 是各固定窗口的请求数，键为窗口序号。
+		counts map[int64]int
+	}
+)
+
+// WithLimit 设置滑动窗口内允许的最大请求数，仅对 SlidingWindow 生效。
+//
+// 参数：
+//   - limit：最大请求数，必须大于 0，默认为 100。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLimit(limit int) Option {
+	return func(o *options) {
+		o.limit = limit
+	}
+}
+
+// WithWindow 设置滑动窗口的大小，仅对 SlidingWindow 生效。
+//
+// 参数：
+//   - window：窗口大小，必须大于 0，默认为 1 秒。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithWindow(window time.Duration) Option {
+	return func(o *options) {
+		o.window = window
+	}
+}
+
+// WithWindowMode 设置滑动窗口的计数方式，仅对 SlidingWindow 生效。
+//
+// 参数：
+//   - mode：计数方式，默认为 WindowModeLog。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithWindowMode(mode WindowMode) Option {
+	return func(o *options) {
+		o.windowMode = mode
+	}
+}
+
+// WithName 设置限流器的名称，作为指标的 name 标签。
+//
+// 参数：
+//   - name：限流器名称。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithMetrics 设置是否记录指标，仅对 SlidingWindow 生效。
+//
+// 参数：
+//   - metrics：是否记录指标，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMetrics(metrics bool) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// NewSlidingWindow 创建滑动窗口限流器。
+//
+// 参数：
+//   - opts：配置选项，支持 WithLimit、WithWindow、WithWindowMode、WithName 与 WithMetrics。
+//
+// 返回值：
+//   - *SlidingWindow：滑动窗口限流器。
+//
+// 示例：
+//
+//	// 任意一分钟内最多 600 个请求。
+//	limiter := ratelimit.NewSlidingWindow(
+//	    ratelimit.WithLimit(600),
+//	    ratelimit.WithWindow(time.Minute),
+//	    ratelimit.WithName("github-api"),
+//	)
+func NewSlidingWindow(opts ...Option) *SlidingWindow {
+	o := newOptions(opts...)
+
+	w := &SlidingWindow{
+		limit:  o.limit,
+		window: o.window,
+		clock:  o.clock,
+	}
+	switch o.windowMode {
+	case WindowModeCounter:
+		w.counter = &weightedCounter{window: o.window, limit: o.limit, counts: make(map[int64]int)}
+	default:
+		w.counter = &logCounter{window: o.window, limit: o.limit}
+	}
+	if o.metrics {
+		w.allowed = MetricRequests.WithLabelValues(o.name, "allowed")
+		w.rejected = MetricRequests.WithLabelValues(o.name, "rejected")
+		w.usage = MetricWindowUsage.WithLabelValues(o.name)
+	}
+	return w
+}
+
+// Allow 判断当前是否允许一次请求。
+func (w *SlidingWindow) Allow() bool {
+	return w.AllowN(1)
+}
+
+// AllowN 判断当前是否允许 n 个请求，窗口内的剩余配额不足时不记录请求并返回 false。
+func (w *SlidingWindow) AllowN(n int) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.clock.Now()
+	w.counter.evict(now)
+	ok := n <= w.limit && !w.last.After(now) && !w.counter.earliest(now, n).After(now)
+	if ok {
+		w.counter.add(now, n)
+		w.last = now
+	}
+	w.record(now, ok, n)
+	return ok
+}
+
+// Wait 阻塞等待直到窗口内有剩余配额。
+func (w *SlidingWindow) Wait(ctx context.Context) error {
+	return w.WaitN(ctx, 1)
+}
+
+// WaitN 阻塞等待直到窗口内有 n 个剩余配额。
+func (w *SlidingWindow) WaitN(ctx context.Context, n int) error {
+	return wait(ctx, w.clock, w.ReserveN(n))
+}
+
+// Reserve 预约一次请求。
+func (w *SlidingWindow) Reserve() *Reservation {
+	return w.ReserveN(1)
+}
+
+// ReserveN 预约 n 个请求。
+// 窗口内的剩余配额不足时预约未来的配额，Delay 返回需要等待的时间；n 超过上限时预约失败。
+// 在 Delay 到期前调用 Cancel 会撤销预约。
+func (w *SlidingWindow) ReserveN(n int) *Reservation {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.clock.Now()
+	w.counter.evict(now)
+	if n > w.limit {
+		w.record(now, false, n)
+		return &Reservation{}
+	}
+
+	at := now
+	if w.last.After(at) {
+		at = w.last
+	}
+	at = w.counter.earliest(at, n)
+	w.counter.add(at, n)
+	w.last = at
+	w.record(now, true, n)
+
+	return &Reservation{
+		ok:    true,
+		delay: at.Sub(now),
+		cancel: func() {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+
+			if w.clock.Now().After(at) {
+				// 请求已经执行，无法撤销。
+				return
+			}
+			w.counter.remove(at, n)
+		},
+	}
+}
+
+// Count 返回当前窗口内的请求数。
+//
+// 返回值：
+//   - int：请求数，包含已经预约但尚未到执行时间的请求。
+func (w *SlidingWindow) Count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.clock.Now()
+	w.counter.evict(now)
+	return w.counter.count(now)
+}
+
+// record 记录指标，调用方需要持有锁。
+func (w *SlidingWindow) record(now time.Time, ok bool, n int) {
+	if nil == w.allowed {
+		return
+	}
+	if ok {
+		w.allowed.Add(float64(n))
+	} else {
+		w.rejected.Add(float64(n))
+	}
+	w.usage.Set(float64(w.counter.count(now)))
+}
+
+// earliest 返回不早于 at 的、可以再容纳 n 个请求的最早时间。
+// 由于请求按时间顺序记录，只需保证 (t-window, t] 内已有的请求数加上 n 不超过上限。
+func (c *logCounter) earliest(at time.Time, n int) time.Time {
+	start := c.start(at)
+	// 需要移出窗口的最早的请求数。
+	k := len(c.times) - start + n - c.limit
+	if k <= 0 {
+		return at
+	}
+	t := c.times[start+k-1].Add(c.window)
+	if t.Before(at) {
+		return at
+	}
+	return t
+}
+
+// add 在时间 at 记录 n 个请求，at 不早于已记录的时间。
+func (c *logCounter) add(at time.Time, n int) {
+	for i := 0; i < n; i++ {
+		c.times = append(c.times, at)
+	}
+}
+
+// remove 从后向前撤销在时间 at 记录的 n 个请求。
+func (c *logCounter) remove(at time.Time, n int) {
+	for i := len(c.times) - 1; i >= 0 && n > 0; i-- {
+		if c.times[i].Equal(at) {
+			c.times = append(c.times[:i], c.times[i+1:]...)
+			n--
+		}
+	}
+}
+
+// count 返回 (at-window, at] 内的请求数，包含晚于 at 的预约。
+func (c *logCounter) count(at time.Time) int {
+	return len(c.times) - c.start(at)
+}
+
+// evict 移除在时间 now 已经移出窗口的请求。
+func (c *logCounter) evict(now time.Time) {
+	if i := c.start(now); i > 0 {
+		c.times = append(c.times[:0], c.times[i:]...)
+	}
+}
+
+// start 返回时间 at 的窗口 (at-window, at] 内第一个请求的下标。
+func (c *logCounter) start(at time.Time) int {
+	begin := at.Add(-c.window)
+	i := 0
+	for i < len(c.times) && !c.times[i].After(begin) {
+		i++
+	}
+	return i
+}
+
+// index 返回时间 at 所在的固定窗口的序号。
+func (c *weightedCounter) index(at time.Time) int64 {
+	return at.UnixNano() / int64(c.window)
+}
+
+// estimate 估算时间 at 的滑动窗口内的请求数：
+// 当前固定窗口的请求数，加上上一个固定窗口的请求数乘以滑动窗口与其重叠的比例。
+func (c *weightedCounter) estimate(at time.Time) float64 {
+	idx := c.index(at)
+	elapsed := at.UnixNano() - idx*int64(c.window)
+	weight := float64(int64(c.window)-elapsed) / float64(c.window)
+	return float64(c.counts[idx-1])*weight + float64(c.counts[idx])
+}
+
+// earliest 返回不早于 at 的、可以再容纳 n 个请求的最早时间。
+// 上一个固定窗口的权重随时间线性下降，因此可以直接求解；当前窗口内无法满足时依次尝试之后的窗口。
+func (c *weightedCounter) earliest(at time.Time, n int) time.Time {
+	window := int64(c.window)
+	for idx := c.index(at); ; idx++ {
+		start := time.Unix(0, idx*window)
+		if start.Before(at) {
+			start = at
+		}
+		room := float64(c.limit - c.counts[idx] - n)
+		if room < 0 {
+			continue
+		}
+		if c.estimate(start)+float64(n) <= float64(c.limit) {
+			return start
+		}
+		// prev*(window-elapsed)/window <= room，解出 elapsed。
+		prev := float64(c.counts[idx-1])
+		elapsed := int64(float64(window) * (1 - room/prev))
+		t := time.Unix(0, idx*window+elapsed)
+		if elapsed < window && !t.Before(start) {
+			// 浮点误差可能导致估算值略大于上限，向后取整到下一纳秒。
+			for c.estimate(t)+float64(n) > float64(c.limit) && c.index(t) == idx {
+				t = t.Add(time.Nanosecond)
+			}
+			if c.index(t) == idx {
+				return t
+			}
+		}
+	}
+}
+
+// add 在时间 at 所在的固定窗口记录 n 个请求。
+func (c *weightedCounter) add(at time.Time, n int) {
+	c.counts[c.index(at)] += n
+}
+
+// remove 撤销在时间 at 所在的固定窗口记录的 n 个请求。
+func (c *weightedCounter) remove(at time.Time, n int) {
+	idx := c.index(at)
+	c.counts[idx] -= n
+	if c.counts[idx] <= 0 {
+		delete(c.counts, idx)
+	}
+}
+
+// count 返回时间 at 的滑动窗口内估算的请求数，向上取整。
+func (c *weightedCounter) count(at time.Time) int {
+	e := c.estimate(at)
+	n := int(e)
+	if float64(n) < e {
+		n++
+	}
+	return n
+}
+
+// evict 移除在时间 now 已经不再参与估算的固定窗口。
+func (c *weightedCounter) evict(now time.Time) {
+	idx := c.index(now)
+	for k := range c.counts {
+		if k < idx-1 {
+			delete(c.counts, k)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// TestSlidingWindow_Log 测试精确计数的滑动窗口。
+func TestSlidingWindow_Log(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	w := NewSlidingWindow(WithLimit(3), WithWindow(time.Minute), WithMetrics(false), WithClock(clock))
+
+	assert.True(t, w.Allow())
+	clock.Advance(20 * time.Second)
+	assert.True(t, w.AllowN(2))
+	assert.False(t, w.Allow())
+	assert.Equal(t, 3, w.Count())
+
+	// 第一个请求在 60 秒时移出窗口（窗口为左开右闭区间）。
+	clock.Advance(39 * time.Second)
+	assert.False(t, w.Allow())
+	clock.Advance(time.Second)
+	assert.True(t, w.Allow())
+	assert.False(t, w.Allow())
+
+	// 与固定窗口不同，窗口边界附近不会出现两倍的突发请求。
+	clock.Advance(20 * time.Second)
+	assert.True(t, w.AllowN(2))
+	assert.False(t, w.Allow())
+	assert.False(t, w.AllowN(4), "超过上限的请求永远不被允许")
+}
+
+// TestSlidingWindow_Counter 测试加权估算的滑动窗口。
+func TestSlidingWindow_Counter(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	w := NewSlidingWindow(WithLimit(10), WithWindow(time.Minute), WithWindowMode(WindowModeCounter), WithMetrics(false), WithClock(clock))
+
+	assert.True(t, w.AllowN(10))
+	assert.False(t, w.Allow())
+
+	// 进入下一个固定窗口 15 秒后，上一个窗口的权重为 0.75，估算值为 7.5。
+	clock.Advance(75 * time.Second)
+	assert.Equal(t, 8, w.Count())
+	assert.True(t, w.AllowN(2))
+	assert.False(t, w.Allow(), "估算值 9.5 再加 1 超过上限")
+
+	clock.Advance(6 * time.Second)
+	assert.True(t, w.Allow(), "上一个窗口的权重降为 0.65，估算值为 8.5")
+
+	clock.Advance(2 * time.Minute)
+	assert.Equal(t, 0, w.Count())
+	assert.True(t, w.AllowN(10))
+}
+
+// TestSlidingWindow_Reserve 测试预约与取消。
+func TestSlidingWindow_Reserve(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  WindowMode
+		delay time.Duration
+		reuse time.Duration
+	}{
+		// 精确计数时，最早的请求移出窗口后即可执行。
+		{name: "log", mode: WindowModeLog, delay: time.Second, reuse: time.Second},
+		// 加权估算时，需要等到上一个固定窗口的权重降到 0.5。
+		{name: "counter", mode: WindowModeCounter, delay: 1500 * time.Millisecond, reuse: 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := kittime.NewFakeClock(time.Time{})
+			w := NewSlidingWindow(WithLimit(2), WithWindow(time.Second), WithWindowMode(tt.mode), WithMetrics(false), WithClock(clock))
+
+			assert.False(t, w.ReserveN(3).OK())
+
+			r := w.ReserveN(2)
+			require.True(t, r.OK())
+			assert.Equal(t, time.Duration(0), r.Delay())
+
+			r = w.Reserve()
+			require.True(t, r.OK())
+			assert.Equal(t, tt.delay, r.Delay())
+			assert.False(t, w.Allow(), "存在未执行的预约时按顺序排队")
+
+			r.Cancel()
+			clock.Advance(tt.reuse)
+			assert.True(t, w.AllowN(2), "取消的预约不再占用配额")
+		})
+	}
+}
+
+// TestSlidingWindow_Wait 测试等待配额。
+func TestSlidingWindow_Wait(t *testing.T) {
+	w := NewSlidingWindow(WithLimit(2), WithWindow(50*time.Millisecond), WithMetrics(false))
+
+	ctx := context.Background()
+	require.NoError(t, w.WaitN(ctx, 2))
+	start := time.Now()
+	require.NoError(t, w.Wait(ctx))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	assert.ErrorIs(t, w.WaitN(ctx, 3), ErrExceedsBurst)
+
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.NoError(t, w.Wait(ctx))
+	assert.ErrorIs(t, w.Wait(short), ErrWouldExceedDeadline)
+}
+
+// TestSlidingWindow_Metrics 测试指标记录。
+func TestSlidingWindow_Metrics(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	w := NewSlidingWindow(WithLimit(1), WithName("test-metrics"), WithClock(clock))
+
+	allowed := testutil.ToFloat64(MetricRequests.WithLabelValues("test-metrics", "allowed"))
+	rejected := testutil.ToFloat64(MetricRequests.WithLabelValues("test-metrics", "rejected"))
+
+	w.Allow()
+	w.Allow()
+	w.AllowN(2)
+
+	assert.Equal(t, allowed+1, testutil.ToFloat64(MetricRequests.WithLabelValues("test-metrics", "allowed")))
+	assert.Equal(t, rejected+3, testutil.ToFloat64(MetricRequests.WithLabelValues("test-metrics", "rejected")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricWindowUsage.WithLabelValues("test-metrics")))
+}
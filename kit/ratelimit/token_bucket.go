@@ -0,0 +1,165 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+var (
+	// 确保 TokenBucket 实现了 Limiter 接口。
+	_ Limiter = (*TokenBucket)(nil)
+)
+
+type (
+	// TokenBucket 是令牌桶限流器。
+	// 令牌以固定速率产生并存入容量为 burst 的桶中，每个请求消耗一个令牌；
+	// 桶中有令牌时请求可以立即执行，因此允许不超过 burst 的突发请求，长期平均速率不超过 rate。
+	TokenBucket struct {
+		// mu 保护以下字段。
+		mu sync.Mutex
+		// rate 是每秒产生的令牌数。
+		rate float64
+		// burst 是令牌桶的容量。
+		burst int
+		// tokens 是当前的令牌数，预约未来的令牌时可以为负数。
+		tokens float64
+		// last 是最后一次更新令牌数的时间。
+		last time.Time
+		// clock 是读取当前时间与等待使用的时钟。
+		clock kittime.Clock
+	}
+)
+
+// NewTokenBucket 创建令牌桶限流器，初始时桶是满的。
+//
+// 参数：
+//   - opts：配置选项，支持 WithRate、WithInterval 与 WithBurst。
+//
+// 返回值：
+//   - *TokenBucket：令牌桶限流器。
+//
+// 示例：
+//
+//	// 每秒 10 个请求，允许 20 个突发请求。
+//	limiter := ratelimit.NewTokenBucket(ratelimit.WithRate(10), ratelimit.WithBurst(20))
+//	if !limiter.Allow() {
+//	    return ErrTooManyRequests
+//	}
+func NewTokenBucket(opts ...Option) *TokenBucket {
+	o := newOptions(opts...)
+	return &TokenBucket{
+		rate:   o.rate,
+		burst:  o.burst,
+		tokens: float64(o.burst),
+		last:   o.clock.Now(),
+		clock:  o.clock,
+	}
+}
+
+// Allow 判断当前是否允许一次请求。
+func (b *TokenBucket) Allow() bool {
+	return b.AllowN(1)
+}
+
+// AllowN 判断当前是否允许 n 个请求，桶中的令牌不足时不消耗令牌并返回 false。
+func (b *TokenBucket) AllowN(n int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	b.advance(now)
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// Wait 阻塞等待直到获得一个令牌。
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	return b.WaitN(ctx, 1)
+}
+
+// WaitN 阻塞等待直到获得 n 个令牌。
+func (b *TokenBucket) WaitN(ctx context.Context, n int) error {
+	return wait(ctx, b.clock, b.ReserveN(n))
+}
+
+// Reserve 预约一个令牌。
+func (b *TokenBucket) Reserve() *Reservation {
+	return b.ReserveN(1)
+}
+
+// ReserveN 预约 n 个令牌。
+// 令牌不足时预约未来产生的令牌，Delay 返回需要等待的时间；n 超过容量时预约失败。
+// 在 Delay 到期前调用 Cancel 会归还令牌。
+func (b *TokenBucket) ReserveN(n int) *Reservation {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if n > b.burst {
+		return &Reservation{}
+	}
+
+	now := b.clock.Now()
+	b.advance(now)
+	b.tokens -= float64(n)
+
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	timeToAct := now.Add(delay)
+
+	return &Reservation{
+		ok:    true,
+		delay: delay,
+		cancel: func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			now := b.clock.Now()
+			if now.After(timeToAct) {
+				// 令牌已经被使用，无法归还。
+				return
+			}
+			b.advance(now)
+			b.tokens += float64(n)
+			if b.tokens > float64(b.burst) {
+				b.tokens = float64(b.burst)
+			}
+		},
+	}
+}
+
+// Tokens 返回当前桶中可用的令牌数，预约了未来的令牌时为负数。
+//
+// 返回值：
+//   - float64：可用的令牌数。
+func (b *TokenBucket) Tokens() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(b.clock.Now())
+	return b.tokens
+}
+
+// advance 根据流逝的时间补充令牌，调用方需要持有锁。
+func (b *TokenBucket) advance(now time.Time) {
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 {
+		return
+	}
+	b.last = now
+	b.tokens += elapsed.Seconds() * b.rate
+	if b.tokens > float64(b.burst) {
+		b.tokens = float64(b.burst)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// TestNewTokenBucket_Defaults 测试默认参数与非法参数的处理。
+func TestNewTokenBucket_Defaults(t *testing.T) {
+	b := NewTokenBucket()
+	assert.Equal(t, rateDefault, b.rate)
+	assert.Equal(t, 100, b.burst)
+
+	b = NewTokenBucket(WithRate(-1), WithBurst(-1))
+	assert.Equal(t, rateDefault, b.rate)
+	assert.Equal(t, 100, b.burst)
+
+	b = NewTokenBucket(WithRate(0.5))
+	assert.Equal(t, 1, b.burst, "容量至少为 1")
+
+	b = NewTokenBucket(WithInterval(200 * time.Millisecond))
+	assert.InDelta(t, 5, b.rate, 1e-9)
+}
+
+// TestTokenBucket_Allow 测试令牌的消耗与补充。
+func TestTokenBucket_Allow(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	b := NewTokenBucket(WithRate(10), WithBurst(3), WithClock(clock))
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, b.Allow(), "第 %d 次突发请求", i+1)
+	}
+	assert.False(t, b.Allow())
+
+	clock.Advance(100 * time.Millisecond)
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow())
+
+	// 长时间空闲后令牌数不超过容量。
+	clock.Advance(time.Hour)
+	assert.InDelta(t, 3, b.Tokens(), 1e-9)
+	assert.False(t, b.AllowN(4))
+	assert.True(t, b.AllowN(3))
+}
+
+// TestTokenBucket_Reserve 测试预约与取消。
+func TestTokenBucket_Reserve(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	b := NewTokenBucket(WithRate(10), WithBurst(2), WithClock(clock))
+
+	r := b.ReserveN(3)
+	assert.False(t, r.OK())
+	r.Cancel()
+
+	r = b.ReserveN(2)
+	require.True(t, r.OK())
+	assert.Equal(t, time.Duration(0), r.Delay())
+
+	r = b.Reserve()
+	require.True(t, r.OK())
+	assert.Equal(t, 100*time.Millisecond, r.Delay())
+	assert.InDelta(t, -1, b.Tokens(), 1e-9)
+
+	r.Cancel()
+	r.Cancel()
+	assert.InDelta(t, 0, b.Tokens(), 1e-9, "取消后归还令牌，且只归还一次")
+
+	// 已经到期的预约无法归还令牌。
+	r = b.Reserve()
+	clock.Advance(200 * time.Millisecond)
+	r.Cancel()
+	assert.InDelta(t, 1, b.Tokens(), 1e-9)
+}
+
+// TestTokenBucket_Wait 测试等待令牌。
+func TestTokenBucket_Wait(t *testing.T) {
+	b := NewTokenBucket(WithRate(100), WithBurst(1))
+
+	ctx := context.Background()
+	require.NoError(t, b.Wait(ctx))
+	start := time.Now()
+	require.NoError(t, b.Wait(ctx))
+	assert.GreaterOrEqual(t, time.Since(start), 5*time.Millisecond)
+
+	assert.ErrorIs(t, b.WaitN(ctx, 2), ErrExceedsBurst)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, b.Wait(cancelled), context.Canceled)
+
+	b = NewTokenBucket(WithRate(1), WithBurst(1))
+	require.True(t, b.Allow())
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	assert.ErrorIs(t, b.Wait(short), ErrWouldExceedDeadline)
+	assert.Less(t, time.Since(start), 50*time.Millisecond, "超过截止时间时立即返回")
+	assert.Less(t, b.Tokens(), float64(0.1), "失败的等待归还了令牌")
+	assert.Greater(t, b.Tokens(), float64(-0.1))
+}
+
+// TestTokenBucket_WaitCancel 测试等待过程中取消上下文。
+func TestTokenBucket_WaitCancel(t *testing.T) {
+	b := NewTokenBucket(WithRate(1), WithBurst(1))
+	require.True(t, b.Allow())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	assert.ErrorIs(t, b.Wait(ctx), context.Canceled)
+	assert.Greater(t, b.Tokens(), float64(-0.5), "取消等待后归还令牌")
+}
+
+// TestTokenBucket_WaitClock 测试等待使用注入的时钟，推进时钟即可结束等待。
+func TestTokenBucket_WaitClock(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	b := NewTokenBucket(WithRate(1), WithBurst(1), WithClock(clock))
+	require.True(t, b.Allow())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Wait(context.Background())
+	}()
+
+	clock.BlockUntil(1)
+	select {
+	case <-done:
+		t.Fatal("推进时钟前不应结束等待")
+	default:
+	}
+	clock.Advance(time.Second)
+	assert.NoError(t, <-done)
+}
+
+// TestTokenBucket_Concurrent 测试并发请求不会超过容量。
+func TestTokenBucket_Concurrent(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	b := NewTokenBucket(WithRate(1), WithBurst(50), WithClock(clock))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if b.Allow() {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, allowed)
+}
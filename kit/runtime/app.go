@@ -0,0 +1,419 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	kitconfig "github.com/fsyyft-go/monorepo/kit/config"
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	kitsignal "github.com/fsyyft-go/monorepo/kit/signal"
+)
+
+const (
+	// defaultShutdownTimeout 是默认的停止超时时间。
+	defaultShutdownTimeout = 30 * time.Second
+)
+
+type (
+	// SetupFunc 在配置与日志初始化之后、组件启动之前执行，返回的 Runner 加入组件组，不需要运行的组件可以返回 nil。
+	// 指标、追踪等需要根据配置创建的组件通过 SetupFunc 接入。
+	SetupFunc func(ctx context.Context) (Runner, error)
+
+	// Hook 是应用停止时执行的函数。
+	Hook func(ctx context.Context) error
+
+	// App 将服务启动所需的配置加载、日志初始化、组件组、信号处理与停止钩子组合在一起，
+	// 通过链式调用描述，由 Run 依次执行，取代每个服务 main 函数中重复的启动代码。
+	// App 不是并发安全的，每个实例只能调用一次 Run。
+	App struct {
+		// name 是应用的名称，作为日志字段输出。
+		name string
+		// withConfig 表示是否加载配置。
+		withConfig bool
+		// configTarget 是配置的解析目标，为 nil 时只加载不解析。
+		configTarget interface{}
+		// configOpts 是加载配置的选项。
+		configOpts []kitconfig.Option
+		// withLogger 表示是否初始化全局日志实例。
+		withLogger bool
+		// logOpts 是初始化日志的选项，优先级高于配置中的日志设置。
+		logOpts []kitlog.Option
+		// setups 按添加顺序保存组件的创建函数。
+		setups []SetupFunc
+		// hooks 按添加顺序保存停止钩子。
+		hooks []Hook
+		// signalOpts 是监听退出信号的选项。
+		signalOpts []kitsignal.Option
+		// shutdownTimeout 是停止组件与执行钩子的总超时时间。
+		shutdownTimeout time.Duration
+		// config 是 Run 加载的配置。
+		config kitconfig.Config
+		// logger 是带有应用名称字段的日志实例。
+		logger kitlog.Logger
+	}
+)
+
+// NewApp 创建一个应用。
+//
+// 参数：
+//   - name：应用的名称。
+//
+// 返回值：
+//   - *App：应用实例，通过链式调用继续配置。
+//
+// 示例：
+//
+//	func main() {
+//	    var cfg AppConfig
+//	    app := runtime.NewApp("order").
+//	        WithConfig(&cfg, config.WithFile("conf/app.yaml"), config.WithEnv("ORDER")).
+//	        WithLogger()
+//	    app.Setup(func(ctx context.Context) (runtime.Runner, error) {
+//	        return newServer(cfg.Server, app.Logger()), nil
+//	    })
+//	    if err := app.Run(); nil != err {
+//	        log.Fatal(err)
+//	    }
+//	}
+func NewApp(name string) *App {
+	return &App{
+		name:            name,
+		shutdownTimeout: defaultShutdownTimeout,
+	}
+}
+
+// WithConfig 设置启动时通过 kit/config 加载配置。
+// v 不为 nil 时使用 config.Load 解析并校验到 v，否则使用 config.New 只加载配置。
+//
+// 参数：
+//   - v：配置的解析目标，必须是指向结构体的指针或 nil。
+//   - opts：加载配置的选项。
+//
+// 返回值：
+//   - *App：应用实例本身。
+func (a *App) WithConfig(v interface{}, opts ...kitconfig.Option) *App {
+	a.withConfig = true
+	a.configTarget = v
+	a.configOpts = opts
+	return a
+}
+
+// WithLogger 设置启动时通过 kit/log 初始化全局日志实例。
+// 加载了配置时，依次读取配置中的 log.type、log.level、log.output 与 log.format 作为日志选项，opts 在其后应用。
+//
+// 参数：
+//   - opts：日志选项。
+//
+// 返回值：
+//   - *App：应用实例本身。
+func (a *App) WithLogger(opts ...kitlog.Option) *App {
+	a.withLogger = true
+	a.logOpts = opts
+	return a
+}
+
+// Setup 添加组件的创建函数，创建函数在配置与日志初始化之后按添加顺序执行。
+//
+// 参数：
+//   - fn：组件的创建函数。
+//
+// 返回值：
+//   - *App：应用实例本身。
+//
+// 示例：
+//
+//	app.Setup(func(ctx context.Context) (runtime.Runner, error) {
+//	    return trace.Setup(ctx, trace.WithServiceName("order"))
+//	})
+func (a *App) Setup(fn SetupFunc) *App {
+	a.setups = append(a.setups, fn)
+	return a
+}
+
+// Add 添加已经创建的组件，与 Setup 添加的组件按添加顺序排列。
+//
+// 参数：
+//   - runners：要添加的组件。
+//
+// 返回值：
+//   - *App：应用实例本身。
+func (a *App) Add(runners ...Runner) *App {
+	for _, r := range runners {
+		a.setups = append(a.setups, func(context.Context) (Runner, error) {
+			return r, nil
+		})
+	}
+	return a
+}
+
+// OnShutdown 添加停止钩子，钩子在全部组件停止之后按添加顺序的逆序执行。
+//
+// 参数：
+//   - hook：停止钩子。
+//
+// 返回值：
+//   - *App：应用实例本身。
+func (a *App) OnShutdown(hook Hook) *App {
+	a.hooks = append(a.hooks, hook)
+	return a
+}
+
+// WithSignals 设置监听退出信号的选项，默认监听 SIGINT 与 SIGTERM。
+//
+// 参数：
+//   - opts：kit/signal 的选项。
+//
+// 返回值：
+//   - *App：应用实例本身。
+func (a *App) WithSignals(opts ...kitsignal.Option) *App {
+	a.signalOpts = opts
+	return a
+}
+
+// WithShutdownTimeout 设置停止组件与执行钩子的总超时时间，默认为 30 秒，小于等于 0 时忽略。
+//
+// 参数：
+//   - timeout：停止超时时间。
+//
+// 返回值：
+//   - *App：应用实例本身。
+func (a *App) WithShutdownTimeout(timeout time.Duration) *App {
+	if timeout > 0 {
+		a.shutdownTimeout = timeout
+	}
+	return a
+}
+
+// Config 返回 Run 加载的配置，配置加载之前或没有调用 WithConfig 时返回 nil。
+//
+// 返回值：
+//   - config.Config：加载的配置。
+func (a *App) Config() kitconfig.Config {
+	return a.config
+}
+
+// Logger 返回带有应用名称字段的日志实例，日志初始化之前返回基于当前全局日志实例的实例。
+//
+// 返回值：
+//   - log.Logger：日志实例。
+func (a *App) Logger() kitlog.Logger {
+	if nil != a.logger {
+		return a.logger
+	}
+	return kitlog.GetLogger().WithField("app", a.name)
+}
+
+// Run 使用 context.Background 运行应用，参见 RunContext。
+//
+// 返回值：
+//   - error：运行过程中的错误。
+func (a *App) Run() error {
+	return a.RunContext(context.Background())
+}
+
+// RunContext 运行应用，直到收到退出信号、ctx 结束或者某个组件启动失败。
+// 依次加载配置、初始化日志、按添加顺序创建组件，然后并发调用全部组件的 Start；
+// 退出时按添加顺序的逆序调用组件的 Stop，再按逆序执行停止钩子，二者共享停止超时时间。
+// 开始停止之后，尚未调用 Start 的组件不再启动；创建组件失败时，已经创建的组件同样会被停止。
+//
+// 参数：
+//   - ctx：应用的生命周期。
+//
+// 返回值：
+//   - error：初始化、启动或停止过程中的错误；因收到退出信号或 ctx 结束而正常退出时返回 nil。
+func (a *App) RunContext(ctx context.Context) error {
+	ctx, stop := kitsignal.NotifyContext(ctx, a.signalOpts...)
+	defer stop()
+
+	if err := a.init(); nil != err {
+		return err
+	}
+	logger := a.Logger()
+
+	runners := make([]Runner, 0, len(a.setups))
+	for i, setup := range a.setups {
+		r, err := setup(ctx)
+		if nil != err {
+			err = fmt.Errorf("kit/runtime: 创建第 %d 个组件失败：%w", i+1, err)
+			return errors.Join(err, a.shutdown(ctx, runners, nil))
+		}
+		if nil != r {
+			runners = append(runners, r)
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		startErr error
+		// mu 与 stopping 保证开始停止之后不再调用尚未启动的组件的 Start。
+		mu       sync.Mutex
+		stopping bool
+	)
+	for i, r := range runners {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			skip := stopping
+			mu.Unlock()
+			if skip {
+				return
+			}
+			if err := r.Start(runCtx); nil != err {
+				once.Do(func() {
+					startErr = fmt.Errorf("kit/runtime: 启动第 %d 个组件失败：%w", i+1, err)
+					cancel()
+				})
+			}
+		}()
+	}
+	logger.Info("application started")
+
+	<-runCtx.Done()
+	mu.Lock()
+	stopping = true
+	mu.Unlock()
+	if sig, ok := kitsignal.Received(ctx); ok {
+		logger.WithField("signal", sig.String()).Info("application stopping")
+	} else if nil != startErr {
+		logger.WithField("error", startErr.Error()).Error("application stopping")
+	} else {
+		logger.Info("application stopping")
+	}
+
+	err := errors.Join(startErr, a.shutdown(ctx, runners, &wg))
+	if nil != err {
+		logger.WithField("error", err.Error()).Error("application stopped with error")
+	} else {
+		logger.Info("application stopped")
+	}
+	return err
+}
+
+// init 加载配置并初始化日志。
+//
+// 返回值：
+//   - error：加载配置或初始化日志失败的错误。
+func (a *App) init() error {
+	if a.withConfig {
+		var (
+			cfg kitconfig.Config
+			err error
+		)
+		if nil != a.configTarget {
+			cfg, err = kitconfig.Load(a.configTarget, a.configOpts...)
+		} else {
+			cfg, err = kitconfig.New(a.configOpts...)
+		}
+		if nil != err {
+			return fmt.Errorf("kit/runtime: 加载配置失败：%w", err)
+		}
+		a.config = cfg
+	}
+
+	if a.withLogger {
+		opts, err := logOptions(a.config)
+		if nil != err {
+			return err
+		}
+		if err := kitlog.InitLogger(append(opts, a.logOpts...)...); nil != err {
+			return fmt.Errorf("kit/runtime: %w", err)
+		}
+	}
+	a.logger = kitlog.GetLogger().WithField("app", a.name)
+	return nil
+}
+
+// shutdown 按逆序停止组件并执行停止钩子，然后等待组件的 Start 返回，最后写入缓冲的日志。
+//
+// 参数：
+//   - ctx：应用的生命周期，停止超时时间在其基础上计算，不受其取消的影响。
+//   - runners：要停止的组件。
+//   - wg：等待组件的 Start 返回，为 nil 时不等待。
+//
+// 返回值：
+//   - error：停止组件、执行钩子或等待超时的错误。
+func (a *App) shutdown(ctx context.Context, runners []Runner, wg *sync.WaitGroup) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), a.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(runners) - 1; i >= 0; i-- {
+		if err := runners[i].Stop(ctx); nil != err {
+			errs = append(errs, fmt.Errorf("kit/runtime: 停止第 %d 个组件失败：%w", i+1, err))
+		}
+	}
+	for i := len(a.hooks) - 1; i >= 0; i-- {
+		if err := a.hooks[i](ctx); nil != err {
+			errs = append(errs, fmt.Errorf("kit/runtime: 执行第 %d 个停止钩子失败：%w", i+1, err))
+		}
+	}
+
+	if nil != wg {
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("kit/runtime: 等待组件退出超时：%w", ctx.Err()))
+		}
+	}
+
+	// 组件与钩子停止后写入缓冲的日志，避免异步写入等缓冲中的日志在进程退出时丢失；
+	// 由应用初始化的全局日志实例同时被关闭，否则只写入缓冲的日志。
+	var err error
+	if a.withLogger {
+		err = kitlog.Close()
+	} else {
+		err = kitlog.Sync()
+	}
+	if nil != err {
+		errs = append(errs, fmt.Errorf("kit/runtime: 写入缓冲的日志失败：%w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// logOptions 从配置中读取日志选项。
+//
+// 参数：
+//   - cfg：加载的配置，为 nil 时返回空的选项。
+//
+// 返回值：
+//   - []log.Option：配置中设置了的日志选项。
+//   - error：日志级别无法解析时返回错误。
+func logOptions(cfg kitconfig.Config) ([]kitlog.Option, error) {
+	if nil == cfg {
+		return nil, nil
+	}
+	var opts []kitlog.Option
+	if cfg.IsSet("log.type") {
+		opts = append(opts, kitlog.WithLogType(kitlog.LogType(cfg.GetString("log.type"))))
+	}
+	if cfg.IsSet("log.level") {
+		level, err := kitlog.ParseLevel(cfg.GetString("log.level"))
+		if nil != err {
+			return nil, fmt.Errorf("kit/runtime: 配置 log.level 无效：%w", err)
+		}
+		opts = append(opts, kitlog.WithLevel(level))
+	}
+	if cfg.IsSet("log.output") {
+		opts = append(opts, kitlog.WithOutput(cfg.GetString("log.output")))
+	}
+	if cfg.IsSet("log.format") {
+		opts = append(opts, kitlog.WithFormatType(kitlog.LoggerFormatType(cfg.GetString("log.format"))))
+	}
+	return opts, nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build !windows
+
+package runtime
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitsignal "github.com/fsyyft-go/monorepo/kit/signal"
+)
+
+// TestApp_Signal 测试收到退出信号时应用正常退出。
+func TestApp_Signal(t *testing.T) {
+	rec := &recorder{}
+	a := newFakeRunner("a", rec)
+	a.block = true
+
+	app := NewApp("test").Add(a).WithSignals(kitsignal.WithSignals(syscall.SIGUSR2))
+	done := make(chan error, 1)
+	go func() { done <- app.RunContext(context.Background()) }()
+	<-a.started
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR2))
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"start a", "stop a"}, rec.list())
+}
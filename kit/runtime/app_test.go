@@ -0,0 +1,245 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitconfig "github.com/fsyyft-go/monorepo/kit/config"
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+)
+
+type (
+	// recorder 按发生顺序记录组件与钩子的事件。
+	recorder struct {
+		mu     sync.Mutex
+		events []string
+	}
+
+	// fakeRunner 是记录启动与停止事件的组件。
+	fakeRunner struct {
+		name     string
+		rec      *recorder
+		startErr error
+		// block 为 true 时 Start 阻塞到 ctx 结束。
+		block bool
+		// stopWait 为 true 时 Stop 阻塞到 ctx 结束。
+		stopWait bool
+		started  chan struct{}
+	}
+)
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+// stops 返回除启动之外的事件，组件在开始停止之前是否已经启动是不确定的。
+func (r *recorder) stops() []string {
+	var events []string
+	for _, e := range r.list() {
+		if !strings.HasPrefix(e, "start ") {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+func newFakeRunner(name string, rec *recorder) *fakeRunner {
+	return &fakeRunner{name: name, rec: rec, started: make(chan struct{})}
+}
+
+func (f *fakeRunner) Start(ctx context.Context) error {
+	f.rec.add("start " + f.name)
+	close(f.started)
+	if nil != f.startErr {
+		return f.startErr
+	}
+	if f.block {
+		<-ctx.Done()
+	}
+	return nil
+}
+
+func (f *fakeRunner) Stop(ctx context.Context) error {
+	f.rec.add("stop " + f.name)
+	if f.stopWait {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+// hook 返回记录事件的停止钩子。
+func hook(rec *recorder, name string) Hook {
+	return func(context.Context) error {
+		rec.add("hook " + name)
+		return nil
+	}
+}
+
+// keepLogger 在测试结束后恢复全局日志实例。
+func keepLogger(t *testing.T) {
+	prev := kitlog.GetLogger()
+	t.Cleanup(func() { kitlog.SetLogger(prev) })
+}
+
+// TestApp_Lifecycle 测试应用加载配置、初始化日志、启动组件，ctx 结束后按逆序停止组件并执行钩子。
+func TestApp_Lifecycle(t *testing.T) {
+	keepLogger(t)
+	fsys := fstest.MapFS{
+		"app.yaml": {Data: []byte("name: order\nlog:\n  level: debug\n")},
+	}
+	var cfg struct {
+		Name string `config:"name"`
+		Port int    `config:"port" default:"8080"`
+	}
+	rec := &recorder{}
+	a := newFakeRunner("a", rec)
+	b := newFakeRunner("b", rec)
+	b.block = true
+
+	app := NewApp("test").
+		WithConfig(&cfg, kitconfig.WithFile("app.yaml"), kitconfig.WithFS(fsys)).
+		WithLogger().
+		Add(a).
+		Setup(func(context.Context) (Runner, error) {
+			rec.add("setup " + cfg.Name)
+			return b, nil
+		}).
+		Setup(func(context.Context) (Runner, error) {
+			return nil, nil
+		}).
+		OnShutdown(hook(rec, "1")).
+		OnShutdown(hook(rec, "2"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- app.RunContext(ctx) }()
+	<-a.started
+	<-b.started
+
+	assert.Equal(t, "order", cfg.Name)
+	assert.Equal(t, 8080, cfg.Port)
+	assert.Equal(t, "order", app.Config().GetString("name"))
+	assert.Equal(t, kitlog.DebugLevel, kitlog.GetLevel())
+
+	cancel()
+	require.NoError(t, <-done)
+	events := rec.list()
+	assert.Equal(t, "setup order", events[0])
+	assert.ElementsMatch(t, []string{"start a", "start b"}, events[1:3])
+	assert.Equal(t, []string{"stop b", "stop a", "hook 2", "hook 1"}, events[3:])
+}
+
+// TestApp_StartError 测试组件启动失败时停止全部组件并返回启动错误。
+func TestApp_StartError(t *testing.T) {
+	rec := &recorder{}
+	errBoom := errors.New("boom")
+	a := newFakeRunner("a", rec)
+	a.block = true
+	b := newFakeRunner("b", rec)
+	b.startErr = errBoom
+
+	err := NewApp("test").Add(a, b).OnShutdown(hook(rec, "1")).RunContext(context.Background())
+	require.ErrorIs(t, err, errBoom)
+	assert.Contains(t, err.Error(), "启动第 2 个组件失败")
+	assert.Equal(t, []string{"stop b", "stop a", "hook 1"}, rec.stops())
+}
+
+// TestApp_SetupError 测试创建组件失败时不启动组件，已经创建的组件被停止，钩子被执行。
+func TestApp_SetupError(t *testing.T) {
+	rec := &recorder{}
+	errBoom := errors.New("boom")
+	a := newFakeRunner("a", rec)
+
+	err := NewApp("test").
+		Add(a).
+		Setup(func(context.Context) (Runner, error) { return nil, errBoom }).
+		OnShutdown(hook(rec, "1")).
+		RunContext(context.Background())
+	require.ErrorIs(t, err, errBoom)
+	assert.Equal(t, []string{"stop a", "hook 1"}, rec.list())
+}
+
+// TestApp_ConfigError 测试配置加载失败与日志级别无效时返回错误，不创建组件。
+func TestApp_ConfigError(t *testing.T) {
+	keepLogger(t)
+	called := false
+	setup := func(context.Context) (Runner, error) {
+		called = true
+		return nil, nil
+	}
+
+	err := NewApp("test").
+		WithConfig(nil, kitconfig.WithFile("missing.yaml"), kitconfig.WithFS(fstest.MapFS{})).
+		Setup(setup).
+		RunContext(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "加载配置失败")
+
+	fsys := fstest.MapFS{"app.yaml": {Data: []byte("log:\n  level: verbose\n")}}
+	err = NewApp("test").
+		WithConfig(nil, kitconfig.WithFile("app.yaml"), kitconfig.WithFS(fsys)).
+		WithLogger().
+		Setup(setup).
+		RunContext(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "log.level")
+	assert.False(t, called)
+}
+
+// TestApp_ShutdownTimeout 测试组件停止超时时返回超时错误，之后的组件与钩子仍然执行。
+func TestApp_ShutdownTimeout(t *testing.T) {
+	rec := &recorder{}
+	a := newFakeRunner("a", rec)
+	b := newFakeRunner("b", rec)
+	b.stopWait = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := NewApp("test").
+		Add(a, b).
+		OnShutdown(hook(rec, "1")).
+		WithShutdownTimeout(50 * time.Millisecond).
+		RunContext(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{"stop b", "stop a", "hook 1"}, rec.stops())
+}
+
+// TestApp_ShutdownFlushesLog 测试应用退出时关闭由其初始化的全局日志实例，异步写入缓冲中的日志被写入输出目标。
+func TestApp_ShutdownFlushesLog(t *testing.T) {
+	keepLogger(t)
+	var buf bytes.Buffer
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := NewApp("test").
+		WithLogger(kitlog.WithWriter(&buf), kitlog.WithAsync(16, time.Hour)).
+		OnShutdown(func(context.Context) error {
+			kitlog.Info("shutting down")
+			return nil
+		}).
+		RunContext(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "shutting down")
+}
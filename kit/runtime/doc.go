@@ -12,6 +12,7 @@ Package runtime 提供了运行时工具函数和协程管理功能。
   - 性能优化工具
   - 安全的并发操作
   - 资源使用跟踪
+  - 应用启动框架，组合配置、日志、组件组、信号处理与停止钩子
 
 基本功能：
 
@@ -68,6 +69,21 @@ Package runtime 提供了运行时工具函数和协程管理功能。
 	fmt.Printf("已完成任务：%d\n", stats.Completed)
 	fmt.Printf("失败任务：%d\n", stats.Failed)
 
+5. 应用启动：
+
+	var cfg AppConfig
+	app := runtime.NewApp("order").
+	    WithConfig(&cfg, config.WithFile("conf/app.yaml")).
+	    WithLogger()
+	app.Setup(func(ctx context.Context) (runtime.Runner, error) {
+	    return trace.Setup(ctx, trace.WithServiceName("order"))
+	}).Add(server).OnShutdown(closeDB)
+
+	// 收到退出信号后按逆序停止组件并执行停止钩子
+	if err := app.Run(); nil != err {
+	    log.Fatal(err)
+	}
+
 性能优化：
 
 1. 任务调度：
@@ -0,0 +1,147 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goroutine
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	// dumpBufferSize 获取全部协程堆栈时使用的初始缓冲区大小。
+	dumpBufferSize = 64 << 10
+	// dumpBufferMax 获取全部协程堆栈时缓冲区的上限，避免协程数量极多时无限扩容。
+	dumpBufferMax = 64 << 20
+)
+
+type (
+	// Stack 描述了一个协程在某一时刻的堆栈快照。
+	Stack struct {
+		// ID 是协程 ID。
+		ID int64
+		// State 是协程状态，例如 "running"、"chan receive"、"select"。
+		State string
+		// Function 是堆栈最顶层正在执行的函数全名，例如 "net/http.(*Server).Serve"。
+		Function string
+		// Trace 是该协程完整的堆栈文本，包括首行的协程头信息。
+		Trace string
+	}
+)
+
+// String 返回协程完整的堆栈文本。
+//
+// 返回值：
+//   - string：堆栈文本。
+func (s Stack) String() string {
+	return s.Trace
+}
+
+// Contains 检查堆栈中的任意一帧是否包含指定的函数名片段。
+//
+// 参数：
+//   - fn：要查找的函数名或其片段。
+//
+// 返回值：
+//   - bool：找到时返回 true。
+func (s Stack) Contains(fn string) bool {
+	return strings.Contains(s.Trace, fn)
+}
+
+// Stacks 获取当前进程中所有协程的堆栈快照。
+// 该函数会短暂停止所有协程（STW），不应在热点路径中频繁调用。
+//
+// 返回值：
+//   - []Stack：所有协程的堆栈，第一个元素是调用方所在的协程。
+func Stacks() []Stack {
+	return parseStacks(allStacks())
+}
+
+// Dump 将当前进程中所有协程的堆栈写入 w，格式与 panic 时的输出一致。
+// 常用于信号处理或诊断接口中输出协程信息。
+//
+// 参数：
+//   - w：写入目标。
+//
+// 返回值：
+//   - error：写入过程中发生的错误。
+func Dump(w io.Writer) error {
+	_, err := w.Write(allStacks())
+	return err
+}
+
+// allStacks 获取所有协程的原始堆栈文本，缓冲区不足时自动扩容。
+func allStacks() []byte {
+	size := dumpBufferSize
+	for {
+		buf := make([]byte, size)
+		n := runtime.Stack(buf, true)
+		if n < size || size >= dumpBufferMax {
+			return buf[:n]
+		}
+		size *= 2
+	}
+}
+
+// parseStacks 将 runtime.Stack 输出的文本解析为 Stack 列表。
+//
+// 参数：
+//   - data：runtime.Stack 输出的原始文本，各协程之间以空行分隔。
+//
+// 返回值：
+//   - []Stack：解析结果，无法识别的块会被跳过。
+func parseStacks(data []byte) []Stack {
+	var stacks []Stack
+	for _, block := range bytes.Split(data, []byte("\n\n")) {
+		block = bytes.TrimSpace(block)
+		if len(block) == 0 {
+			continue
+		}
+		if s, ok := parseStack(string(block)); ok {
+			stacks = append(stacks, s)
+		}
+	}
+	return stacks
+}
+
+// parseStack 解析单个协程的堆栈文本。
+// 首行格式为 "goroutine 18 [chan receive, 2 minutes]:"，第二行为最顶层的函数调用。
+func parseStack(block string) (Stack, bool) {
+	header, rest, _ := strings.Cut(block, "\n")
+	if !strings.HasPrefix(header, "goroutine ") {
+		return Stack{}, false
+	}
+
+	// 解析协程 ID。
+	fields := strings.SplitN(header[len("goroutine "):], " ", 2)
+	id, err := strconv.ParseInt(fields[0], 10, 64)
+	if nil != err || len(fields) < 2 {
+		return Stack{}, false
+	}
+
+	// 解析方括号中的状态，去掉逗号后的等待时长等附加信息。
+	state := fields[1]
+	if i := strings.IndexByte(state, '['); i >= 0 {
+		state = state[i+1:]
+	}
+	if i := strings.IndexAny(state, ",]"); i >= 0 {
+		state = state[:i]
+	}
+
+	// 第二行为函数调用，去掉末尾的参数列表。
+	function, _, _ := strings.Cut(rest, "\n")
+	if i := strings.LastIndexByte(function, '('); i > 0 {
+		function = function[:i]
+	}
+
+	return Stack{
+		ID:       id,
+		State:    state,
+		Function: function,
+		Trace:    block,
+	}, true
+}
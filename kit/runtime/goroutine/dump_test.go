@@ -0,0 +1,82 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goroutine
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockedOnChannel 阻塞在通道上，用于在堆栈中留下可识别的函数名。
+func blockedOnChannel(ch chan struct{}) {
+	<-ch
+}
+
+// TestStacks 测试获取所有协程的堆栈快照。
+func TestStacks(t *testing.T) {
+	ch := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		blockedOnChannel(ch)
+	}()
+	<-started
+	defer close(ch)
+
+	stacks := Stacks()
+	require.NotEmpty(t, stacks)
+
+	// 第一个协程应为当前协程。
+	assert.Equal(t, GetGoIDSlow(), stacks[0].ID)
+	assert.Equal(t, "running", stacks[0].State)
+
+	found := false
+	for _, s := range stacks {
+		if s.Contains("goroutine.blockedOnChannel") {
+			found = true
+			assert.Equal(t, "chan receive", s.State)
+		}
+	}
+	assert.True(t, found, "应该能找到阻塞在通道上的协程")
+}
+
+// TestParseStacks 测试堆栈文本的解析。
+func TestParseStacks(t *testing.T) {
+	data := []byte(`goroutine 1 [running]:
+main.main()
+	/app/main.go:10 +0x1d
+
+goroutine 18 [chan receive, 2 minutes]:
+github.com/fsyyft-go/monorepo/kit/runtime/goroutine.(*goroutinePool).Submit(0xc000010000, 0x1)
+	/app/pool.go:20 +0x2a
+created by main.main in goroutine 1
+	/app/main.go:9 +0x1d
+
+not a goroutine block
+`)
+
+	stacks := parseStacks(data)
+	require.Len(t, stacks, 2)
+
+	assert.Equal(t, int64(1), stacks[0].ID)
+	assert.Equal(t, "running", stacks[0].State)
+	assert.Equal(t, "main.main", stacks[0].Function)
+
+	assert.Equal(t, int64(18), stacks[1].ID)
+	assert.Equal(t, "chan receive", stacks[1].State)
+	assert.Equal(t, "github.com/fsyyft-go/monorepo/kit/runtime/goroutine.(*goroutinePool).Submit", stacks[1].Function)
+	assert.True(t, stacks[1].Contains("created by main.main"))
+}
+
+// TestDump 测试输出全部协程堆栈。
+func TestDump(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Dump(&buf))
+	assert.Contains(t, buf.String(), "goroutine ")
+	assert.Contains(t, buf.String(), "TestDump")
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goroutine
+
+import (
+	kitsync "github.com/fsyyft-go/monorepo/kit/sync"
+)
+
+// 本文件向外部测试包 goroutine_test 导出内部实现。
+// kit/testing 依赖本包，导入 kit/testing 的测试必须放在外部测试包中，否则会形成导入循环。
+
+const (
+	// StatTickTime 是协程池指标采集的时间间隔。
+	StatTickTime = statTickTime
+)
+
+// IsWorker 检查调用方所在的协程是否被协程池记录为执行任务的协程。
+func IsWorker(p GoroutinePool) bool {
+	_, ok := p.(*goroutinePool).workers.Load(GetGoID())
+	return ok
+}
+
+// Shards 返回协程池的分片数量。
+func Shards(p GoroutinePool) int {
+	return len(p.(*goroutinePool).shards)
+}
+
+// ShardSlots 返回协程池第 i 个分片的信号量。
+func ShardSlots(p GoroutinePool, i int) *kitsync.Semaphore {
+	return p.(*goroutinePool).shards[i].slots
+}
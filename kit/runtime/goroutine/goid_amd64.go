@@ -7,10 +7,22 @@
 package goroutine
 
 // GetGoID 获取当前协程的 ID。
-// 此函数在 amd64 架构下使用汇编实现，以获取更高效的性能。
+// 此函数在 amd64 架构下使用汇编实现，以获取更高效的性能；
+// 当前 Go 版本的 goid 偏移量未知，或者按偏移量读取的结果与解析堆栈的结果不一致时，回退到解析堆栈，保证结果正确。
 //
 // 已废弃：请考虑使用其他替代方法获取协程 ID。
 //
 // 返回值：
 //   - int64：返回当前协程的 ID。
-func GetGoID() int64
+func GetGoID() int64 {
+	if !fastGoID {
+		return getGoIDSlow()
+	}
+	return getGoIDFast()
+}
+
+// fastGoID 表示是否可以按偏移量读取协程 ID，在包初始化时与解析堆栈的结果比较。
+var fastGoID = 0 != offset && verifyGoID(getGoIDFast)
+
+// getGoIDFast 按 goid 偏移量从当前协程的 G 结构体中读取协程 ID，使用汇编实现。
+func getGoIDFast() int64
@@ -7,8 +7,8 @@
 #include "textflag.h"
 #include "go_tls.h"
 
-// func GetGoID() int64
-TEXT ·GetGoID(SB), NOSPLIT, $0-8
+// func getGoIDFast() int64
+TEXT ·getGoIDFast(SB), NOSPLIT, $0-8
 	get_tls(CX)
 	MOVQ g(CX), AX
 	MOVQ ·offset(SB), BX
@@ -0,0 +1,33 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build amd64
+
+package goroutine
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOffset 测试当前 Go 版本的 goid 偏移量已知时，GetGoID 使用按偏移量读取的快速方法。
+func TestOffset(t *testing.T) {
+	if 0 == Offset() {
+		t.Skipf("%s 的 goid 偏移量未经核实，GetGoID 使用解析堆栈的方法", runtime.Version())
+	}
+	assert.NotZero(t, Offset(), "缺少当前 Go 版本的 goid 偏移量")
+	assert.True(t, fastGoID, "按偏移量读取的协程 ID 与解析堆栈的结果不一致")
+	assert.Equal(t, GetGoIDSlow(), getGoIDFast())
+}
+
+// TestVerifyGoID 测试只在当前协程中读到正确结果的方法不能通过检查。
+func TestVerifyGoID(t *testing.T) {
+	assert.True(t, verifyGoID(getGoIDSlow))
+
+	id := getGoIDSlow()
+	assert.False(t, verifyGoID(func() int64 { return id }))
+	assert.False(t, verifyGoID(func() int64 { return 0 }))
+}
@@ -11,12 +11,19 @@ package goroutine
 func getg() *g
 
 // GetGoID 获取当前协程的 ID。
-// 此函数在非 Windows 的 arm64 架构下通过 G 结构体获取协程 ID。
+// 此函数在非 Windows 的 arm64 架构下通过 G 结构体获取协程 ID；
+// G 结构体的定义与当前 Go 版本的运行时不一致时回退到解析堆栈，保证结果正确。
 //
 // 已废弃：请考虑使用其他替代方法获取协程 ID。
 //
 // 返回值：
 //   - int64：返回当前协程的 ID。
 func GetGoID() int64 {
+	if !fastGoID {
+		return getGoIDSlow()
+	}
 	return getg().goid
 }
+
+// fastGoID 表示是否可以从 G 结构体读取协程 ID，在包初始化时与解析堆栈的结果比较。
+var fastGoID = verifyGoID(func() int64 { return getg().goid })
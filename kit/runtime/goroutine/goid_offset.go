@@ -14,6 +14,7 @@ import (
 var (
 	// offsetDict 存储不同 Go 版本中 goid 在 G 结构体中的偏移量。
 	// 这些偏移量是固定的，不同的 Go 版本可能会有不同的偏移量。
+	// 只收录对照该版本 runtime/runtime2.go 中 G 结构体的定义核实过的偏移量，未收录的版本使用解析堆栈的方法。
 	offsetDict = map[string]int64{
 		"go1.4":  128,
 		"go1.5":  184,
@@ -37,6 +38,7 @@ var (
 		"go1.23": 160, // 多了 syscallbp 8 个字节。
 		"go1.24": 160,
 		"go1.25": 152, // 少了 gobuf.ret 8 个字节。
+		"go1.27": 152, // 与 go1.25 相同。
 	}
 
 	// offset 存储当前 Go 运行时版本的 goid 偏移量。
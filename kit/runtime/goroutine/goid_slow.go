@@ -50,6 +50,25 @@ func extractGID(s []byte) int64 {
 	return gid
 }
 
+// verifyGoID 检查 fast 在当前协程与一个新协程中读取的协程 ID 是否都与解析堆栈的结果一致。
+// 只比较一个协程时，错误的偏移量可能恰好读到与协程 ID 相同的值，两个协程的 ID 不同，可以排除这种巧合。
+//
+// 参数：
+//   - fast：读取当前协程 ID 的快速方法。
+//
+// 返回值：
+//   - bool：两个协程的结果都一致时返回 true。
+func verifyGoID(fast func() int64) bool {
+	if fast() != getGoIDSlow() {
+		return false
+	}
+	ok := make(chan bool)
+	go func() {
+		ok <- fast() == getGoIDSlow()
+	}()
+	return <-ok
+}
+
 // GetGoIDSlow 获取当前协程的 ID，当无法从 GetGoID 获取协程 ID 时使用此方法。
 // 该方法通过获取协程的堆栈信息，然后解析堆栈信息来提取协程 ID。
 //
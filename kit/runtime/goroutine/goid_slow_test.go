@@ -2,7 +2,7 @@
 //
 // Licensed under the MIT License. See LICENSE file in the project root for full license information.
 
-package goroutine
+package goroutine_test
 
 import (
 	"sync"
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
 	kittesting "github.com/fsyyft-go/monorepo/kit/testing"
 )
 
@@ -20,19 +21,19 @@ func TestGetGoIDSlow(t *testing.T) {
 
 	t.Run("测试获取 getGoIDSlow 是非零整数", func(t *testing.T) {
 		// 调用被测函数。
-		gid := getGoIDSlow()
+		gid := goroutine.GetGoIDSlow()
 
 		// 验证返回值是否是非零整数。
-		a.NotEqual(int64(0), gid, "getGoIDSlow() 得到的是一个非零的整数。")
+		a.NotEqual(int64(0), gid, "goroutine.GetGoIDSlow() 得到的是一个非零的整数。")
 	})
 
 	t.Run("测试获取 getGoIDSlow 内部 ID 比外部大", func(t *testing.T) {
 		var wg sync.WaitGroup
 		var idOuter, idInternal int64
 		wg.Add(1)
-		idOuter = getGoIDSlow()
+		idOuter = goroutine.GetGoIDSlow()
 		go func() {
-			idInternal = getGoIDSlow()
+			idInternal = goroutine.GetGoIDSlow()
 			wg.Done()
 		}()
 		wg.Wait()
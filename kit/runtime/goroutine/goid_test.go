@@ -2,7 +2,7 @@
 //
 // Licensed under the MIT License. See LICENSE file in the project root for full license information.
 
-package goroutine
+package goroutine_test
 
 import (
 	"runtime"
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
 	kittesting "github.com/fsyyft-go/monorepo/kit/testing"
 )
 
@@ -24,9 +25,9 @@ func TestGetGoID(t *testing.T) {
 			var wg sync.WaitGroup
 			var idOuter, idInternal int64
 			wg.Add(1)
-			idOuter = GetGoID()
+			idOuter = goroutine.GetGoID()
 			go func() {
-				idInternal = GetGoID()
+				idInternal = goroutine.GetGoID()
 				wg.Done()
 			}()
 			wg.Wait()
@@ -45,9 +46,9 @@ func TestGetGoID_Equal(t *testing.T) {
 		a := assert.New(t)
 
 		// 获取快速版本的 goroutine ID。
-		id := GetGoID()
+		id := goroutine.GetGoID()
 		// 获取慢速版本的 goroutine ID。
-		idSlow := GetGoIDSlow()
+		idSlow := goroutine.GetGoIDSlow()
 
 		a.Equal(id, idSlow, "GetGoID GetGoIDSlow 需要返回相同的值")
 	})
@@ -56,14 +57,14 @@ func TestGetGoID_Equal(t *testing.T) {
 func BenchmarkGetGoID(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		go func() { GetGoID() }()
+		go func() { goroutine.GetGoID() }()
 	}
 }
 
 func BenchmarkGetGoIDSlow(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		go func() { GetGoIDSlow() }()
+		go func() { goroutine.GetGoIDSlow() }()
 	}
 }
 
@@ -5,13 +5,21 @@
 package goroutine
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"math/rand/v2"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/panjf2000/ants/v2"
 
 	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	kitsync "github.com/fsyyft-go/monorepo/kit/sync"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
 )
 
 // 默认配置值。
@@ -30,11 +38,29 @@ var (
 	panicHandlerDefault = func(r interface{}) {}
 	// metricsDefault 定义了是否默认提供指标信息，默认为 true。
 	metricsDefault = true
+	// clockDefault 定义了指标采集默认使用的时钟，默认为系统时钟。
+	clockDefault = kittime.NewRealClock()
+	// deadlockDetectionDefault 定义了是否默认检测任务中同步提交导致的死锁，默认为 true。
+	// 检测只对限制了大小的协程池生效，不限制大小的协程池提交不会等待，也就不会发生死锁。
+	deadlockDetectionDefault = true
+	// shardsDefault 定义了默认的分片数量，默认为 1，即不分片。
+	shardsDefault = 1
+
+	// closedChan 是已关闭的通道，UniqueDone 在没有对应的任务时返回。
+	closedChan = func() chan struct{} {
+		ch := make(chan struct{})
+		close(ch)
+		return ch
+	}()
+
+	// poolDefault 是默认的协程池实例，第一次提交任务时创建，创建失败时下一次提交重新创建。
+	poolDefault = kitsync.NewOnceValue[GoroutinePool](kitsync.WithRetryOnFailure(true))
+)
 
-	// poolDefault 是默认的协程池实例。
-	poolDefault *goroutinePool
-	// poolDefaultLocker 用于保护默认协程池的并发访问。
-	poolDefaultLocker sync.RWMutex
+var (
+	// ErrSelfSubmitDeadlock 表示协程池已满，且占用协程池的全部任务都在等待向同一个协程池提交任务，继续等待会导致死锁。
+	// 常见于任务中同步地向同一个协程池（例如默认协程池）提交子任务并等待其完成。
+	ErrSelfSubmitDeadlock = errors.New("kit/runtime/goroutine: 协程池中的任务向已满的同一个协程池提交任务，等待会导致死锁")
 )
 
 type (
@@ -52,6 +78,35 @@ type (
 		//   - error：如果提交失败则返回错误。
 		Submit(task func()) error
 
+		// SubmitContext 提交一个任务到协程池中执行，没有空闲协程时排队等待，直到有空闲协程或上下文被取消。
+		// 与 Submit 不同，SubmitContext 在非阻塞模式下也会等待，等待的任务数仍受最大阻塞数量限制。
+		// 参数：
+		//   - ctx：上下文，用于取消等待。
+		//   - task：要执行的任务函数。
+		//
+		// 返回值：
+		//   - error：如果提交失败或上下文被取消则返回错误。
+		SubmitContext(ctx context.Context, task func()) error
+
+		// SubmitUnique 按键去重地提交任务：同一个键的任务正在排队或执行时不再提交，用于合并重复的刷新、失效等任务。
+		// 任务结束（包括 panic）或提交失败后，同一个键的任务可以再次提交。
+		// 参数：
+		//   - key：任务键。
+		//   - task：要执行的任务函数。
+		//
+		// 返回值：
+		//   - bool：任务被提交时返回 true，同一个键的任务正在排队或执行时返回 false。
+		//   - error：如果提交失败则返回错误，此时返回的 bool 为 false。
+		SubmitUnique(key string, task func()) (accepted bool, err error)
+
+		// UniqueDone 返回在指定键当前的任务结束时关闭的通道，用于等待被合并的任务完成。
+		// 参数：
+		//   - key：任务键。
+		//
+		// 返回值：
+		//   - <-chan struct{}：任务结束时关闭的通道；没有该键的任务正在排队或执行时返回已关闭的通道。
+		UniqueDone(key string) <-chan struct{}
+
 		// Tune 调整协程池的大小。
 		// 参数：
 		//   - size：新的协程池大小。
@@ -84,10 +139,20 @@ type (
 	}
 )
 
-// goroutinePool 实现了 GoroutinePool 接口，是协程池的具体实现。
-type goroutinePool struct {
+// shard 是协程池的一个分片，拥有独立的 ants.Pool 与信号量，不同分片上的提交互不竞争锁。
+type shard struct {
 	// pool 是底层的 ants.Pool 实例，用于实际的任务调度和执行。
 	pool *ants.Pool
+	// slots 是容量与分片大小相同的信号量，每个执行中的任务持有一个权重。
+	// 只有一个分片时，等待权重的调用方即阻塞中的任务，最大阻塞数量由信号量的最大等待者数量限制。
+	// 协程池不限制大小时提交不会等待，不经过信号量。
+	slots *kitsync.Semaphore
+}
+
+// goroutinePool 实现了 GoroutinePool 接口，是协程池的具体实现。
+type goroutinePool struct {
+	// shards 是协程池的分片，协程池的容量平均分配到各个分片。
+	shards []*shard
 
 	// size 定义了协程池的大小（默认为 int 最大值）。
 	size int
@@ -106,9 +171,36 @@ type goroutinePool struct {
 	name string
 	// metrics 定义了是否提供指标信息（默认为 true）。
 	metrics bool
+	// clock 定义了指标采集使用的时钟（默认为系统时钟）。
+	clock kittime.Clock
+	// deadlockDetection 定义了是否检测任务中同步提交导致的死锁（默认为 true，只对限制了大小的协程池生效）。
+	deadlockDetection bool
+	// unbounded 在协程池不限制大小时为 true，随 Tune 更新；此时提交直接交给底层协程池，不经过信号量。
+	unbounded atomic.Bool
+	// shardCount 定义了分片数量（默认为 1）。
+	shardCount int
+
+	// waiters 是多个分片时等待空闲协程的提交数量，最大阻塞数量据此限制。
+	waiters atomic.Int64
+	// wake 在多个分片时用于唤醒一个等待空闲协程的提交，被唤醒的提交获得协程后继续唤醒下一个。
+	wake chan struct{}
+
+	// workers 记录正在执行任务的协程 ID，用于识别任务中的提交，仅在检测死锁时使用。
+	workers sync.Map
+	// blockedWorkers 是正在等待提交任务的任务协程数量。
+	blockedWorkers atomic.Int64
+
+	// uniqueMu 保护 unique。
+	uniqueMu sync.Mutex
+	// unique 记录通过 SubmitUnique 提交、尚未结束的任务，值为任务结束时关闭的通道。
+	unique map[string]chan struct{}
 
 	// closed 用于通知子协程退出的通道。
 	closed chan struct{}
+	// done 在协程池关闭时取消，用于唤醒阻塞中的任务提交。
+	done context.Context
+	// cancel 取消 done。
+	cancel context.CancelFunc
 }
 
 // WithSize 设置协程池的大小。
@@ -124,6 +216,8 @@ func WithSize(size int) Option {
 }
 
 // WithExpiry 设置协程池中协程的过期时间。
+// 过期清理由 ants 使用系统时钟完成，不受 WithClock 设置的时钟影响。
+//
 // 参数：
 //   - expiry：协程的过期时间。
 //
@@ -207,6 +301,53 @@ func WithMetrics(metrics bool) Option {
 	}
 }
 
+// WithClock 设置指标采集使用的时钟。
+// 测试时可以注入 kit/time 的 FakeClock，推进时钟即可触发指标采集。
+// 该时钟只用于指标采集：空闲协程的过期清理（WithExpiry）由 ants 内部使用系统时钟完成，无法注入时钟，
+// 推进 FakeClock 不会使空闲协程过期，依赖过期清理的测试需要真实等待。
+//
+// 参数：
+//   - clock：时钟，为 nil 时使用系统时钟。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(p *goroutinePool) {
+		p.clock = clock
+	}
+}
+
+// WithDeadlockDetection 设置是否检测任务中同步提交导致的死锁。
+// 启用时，协程池已满且占用协程池的全部任务都在等待向同一个协程池提交任务时，提交返回 ErrSelfSubmitDeadlock 并记录警告日志，而不是永久阻塞。
+// 检测需要在每个任务开始时记录协程 ID，只对限制了大小的协程池生效；不限制大小的协程池（默认）提交不会等待，没有额外开销。
+// 对任务数量极大且任务极短的有界协程池可以关闭。
+// 参数：
+//   - enabled：是否检测。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithDeadlockDetection(enabled bool) Option {
+	return func(p *goroutinePool) {
+		p.deadlockDetection = enabled
+	}
+}
+
+// WithShards 设置分片数量。
+// 每个分片拥有独立的底层协程池与信号量，提交时随机选择分片，
+// 分片已满时依次尝试其他分片，因此只要任意分片有空闲协程，提交就不会等待。
+// 每秒百万级别的并发提交时，分片可以显著减少锁竞争；协程池的大小平均分配到各个分片，向上取整，最大阻塞数量对全部分片统一限制。
+// 多个分片时，等待中的提交不保证先到先得，新的提交可能先于等待中的提交获得空闲协程。
+// 参数：
+//   - shards：分片数量，小于等于 0 时使用 runtime.GOMAXPROCS(0)。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithShards(shards int) Option {
+	return func(p *goroutinePool) {
+		p.shardCount = shards
+	}
+}
+
 // NewGoroutinePool 创建一个新的协程池实例。
 // 参数：
 //   - opts：配置选项。
@@ -225,40 +366,75 @@ func NewGoroutinePool(opts ...Option) (GoroutinePool, func(), error) {
 		maxBlocking:  maxBlockingDefault,
 		panicHandler: panicHandlerDefault,
 		metrics:      metricsDefault,
+		clock:        clockDefault,
 		closed:       make(chan struct{}, 1),
+		unique:       make(map[string]chan struct{}),
+		wake:         make(chan struct{}, 1),
+
+		deadlockDetection: deadlockDetectionDefault,
+		shardCount:        shardsDefault,
 	}
+	p.done, p.cancel = context.WithCancel(context.Background())
 
 	// 应用用户提供的配置选项。
 	for _, opt := range opts {
 		opt(p)
 	}
+	p.clock = kittime.OrReal(p.clock)
+	if p.shardCount <= 0 {
+		p.shardCount = runtime.GOMAXPROCS(0)
+	}
+	p.unbounded.Store(isUnbounded(p.size))
 
 	// 定义清理函数，用于释放协程池资源。
 	cleanup := func() {
-		// 通知协程池关闭。
+		// 通知协程池关闭，并唤醒阻塞中的任务提交。
 		p.closed <- struct{}{}
-		// 如果底层池已创建，则释放资源。
-		if p.pool != nil {
-			errRelease := p.pool.ReleaseTimeout(10 * time.Second)
-			if errRelease != nil {
-				return
-			}
+		p.cancel()
+		// 释放各个分片的底层池，分片并发释放，总的等待时间不超过 10 秒。
+		var wg sync.WaitGroup
+		for _, s := range p.shards {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = s.pool.ReleaseTimeout(10 * time.Second)
+			}()
 		}
+		wg.Wait()
 	}
 
-	// 创建底层的 ants.Pool 实例。
-	pool, errNewPool := ants.NewPool(
-		p.size,
-		ants.WithExpiryDuration(p.expiry),
-		ants.WithPreAlloc(p.preAlloc),
-		ants.WithNonblocking(p.nonBlocking),
-		ants.WithMaxBlockingTasks(p.maxBlocking),
-		ants.WithPanicHandler(p.panicHandler),
-	)
-	if errNewPool != nil {
-		return nil, nil, errNewPool
+	// 创建各个分片底层的 ants.Pool 实例。
+	// 阻塞与最大阻塞数量由 slots 控制，提交到 ants.Pool 的任务数不会超过分片大小。
+	for i := 0; i < p.shardCount; i++ {
+		pool, errNewPool := ants.NewPool(
+			shardSize(p.size, p.shardCount),
+			ants.WithExpiryDuration(p.expiry),
+			ants.WithPreAlloc(p.preAlloc),
+			ants.WithPanicHandler(p.panicHandler),
+		)
+		if errNewPool != nil {
+			for _, s := range p.shards {
+				s.pool.Release()
+			}
+			return nil, nil, errNewPool
+		}
+
+		name := p.name
+		maxWaiters := p.maxBlocking
+		if p.shardCount > 1 {
+			// 多个分片时，阻塞由协程池统一管理，信号量不会有等待者。
+			name = fmt.Sprintf("%s/%d", p.name, i)
+			maxWaiters = 0
+		}
+		p.shards = append(p.shards, &shard{
+			pool: pool,
+			slots: kitsync.NewSemaphore(slotsSize(pool.Cap()),
+				kitsync.WithMaxWaiters(maxWaiters),
+				kitsync.WithName(name),
+				kitsync.WithMetrics(p.metrics),
+			),
+		})
 	}
-	p.pool = pool
 
 	if p.metrics {
 		go stat(p)
@@ -274,49 +450,329 @@ func NewGoroutinePool(opts ...Option) (GoroutinePool, func(), error) {
 // 返回值：
 //   - error：如果提交失败则返回错误。
 func (p *goroutinePool) Submit(task func()) error {
-	return p.pool.Submit(task)
+	if p.unbounded.Load() {
+		return p.submitDirect(task)
+	}
+	if p.nonBlocking {
+		if p.IsClosed() {
+			return ants.ErrPoolClosed
+		}
+		s := p.tryAcquire()
+		if nil == s {
+			return ants.ErrPoolOverload
+		}
+		return p.submit(s, task)
+	}
+	return p.SubmitContext(context.Background(), task)
+}
+
+// SubmitContext 提交一个任务到协程池中执行，没有空闲协程时排队等待，直到有空闲协程或上下文被取消。
+// 参数：
+//   - ctx：上下文，用于取消等待。
+//   - task：要执行的任务函数。
+//
+// 返回值：
+//   - error：如果提交失败或上下文被取消则返回错误。
+func (p *goroutinePool) SubmitContext(ctx context.Context, task func()) error {
+	if p.unbounded.Load() {
+		return p.submitDirect(task)
+	}
+	if p.IsClosed() {
+		return ants.ErrPoolClosed
+	}
+	if s := p.tryAcquire(); nil != s {
+		return p.submit(s, task)
+	}
+
+	// 任务中向已满的同一个协程池提交时，检查是否所有任务都在等待。
+	if p.deadlockDetection {
+		if _, ok := p.workers.Load(GetGoID()); ok {
+			defer p.blockedWorkers.Add(-1)
+			if blocked := p.blockedWorkers.Add(1); blocked >= p.slotsSize() {
+				return p.deadlock(blocked)
+			}
+		}
+	}
+
+	// 协程池关闭时同样结束等待。
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(p.done, cancel)
+	defer stop()
+
+	s, err := p.acquire(ctx)
+	if nil != err {
+		switch {
+		case errors.Is(err, kitsync.ErrMaxWaiters):
+			return ants.ErrPoolOverload
+		case nil != p.done.Err():
+			return ants.ErrPoolClosed
+		default:
+			return err
+		}
+	}
+	return p.submit(s, task)
+}
+
+// tryAcquire 不等待地获取一个空闲协程的权重。
+// 从随机的分片开始依次尝试各个分片，返回获得权重的分片；全部分片已满时返回 nil。
+func (p *goroutinePool) tryAcquire() *shard {
+	n := len(p.shards)
+	if 1 == n {
+		if p.shards[0].slots.TryAcquire(1) {
+			return p.shards[0]
+		}
+		return nil
+	}
+
+	// 从随机的分片开始，并发的提交分散到不同分片；rand.IntN 使用每个线程独立的随机源，本身没有锁竞争。
+	start := rand.IntN(n)
+	for i := 0; i < n; i++ {
+		s := p.shards[(start+i)%n]
+		if s.slots.TryAcquire(1) {
+			return s
+		}
+	}
+	return nil
+}
+
+// acquire 等待空闲协程的权重，直到获得权重或上下文被取消。
+// 只有一个分片时在信号量上排队；多个分片时等待任意分片有任务结束后重新尝试全部分片。
+func (p *goroutinePool) acquire(ctx context.Context) (*shard, error) {
+	if 1 == len(p.shards) {
+		s := p.shards[0]
+		if err := s.slots.Acquire(ctx, 1); nil != err {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	waiters := p.waiters.Add(1)
+	defer p.waiters.Add(-1)
+	if p.maxBlocking > 0 && waiters > int64(p.maxBlocking) {
+		return nil, kitsync.ErrMaxWaiters
+	}
+	for {
+		if s := p.tryAcquire(); nil != s {
+			// 同时结束的任务可能只留下一个唤醒信号，获得权重后继续唤醒下一个等待者。
+			p.signal()
+			return s, nil
+		}
+		select {
+		case <-p.wake:
+		case <-ctx.Done():
+			// 已经取走的唤醒信号交给下一个等待者。
+			p.signal()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// signal 在多个分片且有提交等待时唤醒一个等待者。
+func (p *goroutinePool) signal() {
+	if p.waiters.Load() > 0 {
+		select {
+		case p.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// release 归还分片的权重，并唤醒等待空闲协程的提交。
+func (p *goroutinePool) release(s *shard) {
+	s.slots.Release(1)
+	if len(p.shards) > 1 {
+		p.signal()
+	}
+}
+
+// SubmitUnique 按键去重地提交任务：同一个键的任务正在排队或执行时不再提交，用于合并重复的刷新、失效等任务。
+// 任务在排队期间即占用该键，阻塞模式下等待空闲协程时重复的提交同样被跳过。
+// 注意：任务开始执行后到达的提交也会被跳过，如果任务需要观察到这些提交对应的变化，应在任务结束后重新提交。
+// 参数：
+//   - key：任务键。
+//   - task：要执行的任务函数。
+//
+// 返回值：
+//   - bool：任务被提交时返回 true，同一个键的任务正在排队或执行时返回 false。
+//   - error：如果提交失败则返回错误，此时返回的 bool 为 false。
+func (p *goroutinePool) SubmitUnique(key string, task func()) (bool, error) {
+	p.uniqueMu.Lock()
+	if _, ok := p.unique[key]; ok {
+		p.uniqueMu.Unlock()
+		return false, nil
+	}
+	done := make(chan struct{})
+	p.unique[key] = done
+	p.uniqueMu.Unlock()
+
+	if err := p.Submit(func() {
+		defer p.finishUnique(key, done)
+		task()
+	}); nil != err {
+		p.finishUnique(key, done)
+		return false, err
+	}
+	return true, nil
+}
+
+// UniqueDone 返回在指定键当前的任务结束时关闭的通道，用于等待被合并的任务完成。
+// 参数：
+//   - key：任务键。
+//
+// 返回值：
+//   - <-chan struct{}：任务结束时关闭的通道；没有该键的任务正在排队或执行时返回已关闭的通道。
+func (p *goroutinePool) UniqueDone(key string) <-chan struct{} {
+	p.uniqueMu.Lock()
+	defer p.uniqueMu.Unlock()
+	if done, ok := p.unique[key]; ok {
+		return done
+	}
+	return closedChan
+}
+
+// finishUnique 释放任务键并通知等待任务结束的调用方。
+func (p *goroutinePool) finishUnique(key string, done chan struct{}) {
+	p.uniqueMu.Lock()
+	delete(p.unique, key)
+	p.uniqueMu.Unlock()
+	close(done)
+}
+
+// submit 将已获得权重的任务提交到分片的底层协程池，任务结束或提交失败时归还权重。
+func (p *goroutinePool) submit(s *shard, task func()) error {
+	err := s.pool.Submit(func() {
+		defer p.release(s)
+		if p.deadlockDetection {
+			id := GetGoID()
+			p.workers.Store(id, struct{}{})
+			defer p.workers.Delete(id)
+		}
+		task()
+	})
+	if nil != err {
+		p.release(s)
+	}
+	return err
+}
+
+// submitDirect 将任务直接提交到底层协程池，不经过信号量，用于不限制大小的协程池：提交不会等待，也不会发生死锁。
+// 多个分片时提交到随机的分片。
+func (p *goroutinePool) submitDirect(task func()) error {
+	s := p.shards[0]
+	if n := len(p.shards); n > 1 {
+		s = p.shards[rand.IntN(n)]
+	}
+	return s.pool.Submit(task)
+}
+
+// deadlock 记录警告日志并返回描述死锁的错误。
+func (p *goroutinePool) deadlock(blocked int64) error {
+	capacity := p.slotsSize()
+	err := fmt.Errorf("%w：协程池 %q 的容量为 %d，%d 个任务正在等待提交", ErrSelfSubmitDeadlock, p.name, capacity, blocked)
+	kitlog.GetLogger().WithFields(map[string]interface{}{
+		"pool": p.name,
+		"cap":  capacity,
+	}).Warn(err.Error())
+	return err
+}
+
+// slotsSize 返回各个分片信号量的容量之和，超过 int64 的范围时返回 math.MaxInt64。
+func (p *goroutinePool) slotsSize() int64 {
+	var total int64
+	for _, s := range p.shards {
+		size := s.slots.Size()
+		if total > math.MaxInt64-size {
+			return math.MaxInt64
+		}
+		total += size
+	}
+	return total
+}
+
+// slotsSize 根据底层协程池的容量计算信号量的容量，容量不大于 0 表示不限制。
+func slotsSize(capacity int) int64 {
+	if capacity <= 0 {
+		return math.MaxInt64
+	}
+	return int64(capacity)
+}
+
+// isUnbounded 判断协程池的大小是否表示不限制，不大于 0 或者不小于默认大小时不限制。
+func isUnbounded(size int) bool {
+	return size <= 0 || size >= math.MaxInt32
+}
+
+// shardSize 将协程池的大小平均分配到 shards 个分片，向上取整；大小不大于 0 表示不限制，原样返回。
+func shardSize(size, shards int) int {
+	if size <= 0 || shards <= 1 {
+		return size
+	}
+	return (size-1)/shards + 1
 }
 
 // Tune 调整协程池的大小。
+// 多个分片时，新的大小平均分配到各个分片，向上取整。
 // 参数：
 //   - size：新的协程池大小。
 func (p *goroutinePool) Tune(size int) {
-	p.pool.Tune(size)
+	p.unbounded.Store(isUnbounded(size))
+	for _, s := range p.shards {
+		s.pool.Tune(shardSize(size, len(p.shards)))
+		s.slots.Resize(slotsSize(s.pool.Cap()))
+	}
+	// 扩容后唤醒等待空闲协程的提交。
+	if len(p.shards) > 1 {
+		p.signal()
+	}
 }
 
 // Cap 获取协程池的容量大小。
 // 返回值：
-//   - int：协程池的容量。
+//   - int：协程池的容量，多个分片时为各分片容量之和。
 func (p *goroutinePool) Cap() int {
-	return p.pool.Cap()
+	return p.sum((*ants.Pool).Cap)
 }
 
 // Running 获取协程池中正在运行的协程数量。
 // 返回值：
 //   - int：正在运行的协程数量。
 func (p *goroutinePool) Running() int {
-	return p.pool.Running()
+	return p.sum((*ants.Pool).Running)
 }
 
 // Free 获取协程池中空闲的协程数量。
 // 返回值：
 //   - int：空闲的协程数量。
 func (p *goroutinePool) Free() int {
-	return p.pool.Free()
+	return p.sum((*ants.Pool).Free)
 }
 
 // Waiting 获取协程池中等待执行的任务数量。
 // 返回值：
 //   - int：等待执行的任务数量。
 func (p *goroutinePool) Waiting() int {
-	return p.pool.Waiting()
+	waiting := p.sum((*ants.Pool).Waiting) + int(p.waiters.Load())
+	for _, s := range p.shards {
+		waiting += s.slots.Waiters()
+	}
+	return waiting
 }
 
 // IsClosed 检查协程池是否已经关闭。
 // 返回值：
 //   - bool：如果协程池已关闭则返回 true。
 func (p *goroutinePool) IsClosed() bool {
-	return p.pool.IsClosed()
+	return p.shards[0].pool.IsClosed()
+}
+
+// sum 返回各个分片底层协程池的某项统计之和。
+func (p *goroutinePool) sum(stat func(*ants.Pool) int) int {
+	total := 0
+	for _, s := range p.shards {
+		total += stat(s.pool)
+	}
+	return total
 }
 
 // Submit 提交一个任务到协程池中执行。
@@ -326,25 +782,65 @@ func (p *goroutinePool) IsClosed() bool {
 // 返回值：
 //   - error：如果提交失败则返回错误。
 func Submit(task func()) error {
-	if nil == poolDefault {
-		poolDefaultLocker.Lock()
-		defer poolDefaultLocker.Unlock()
-		if nil == poolDefault {
-			if p, cleanup, err := NewGoroutinePool(WithName("default")); nil == err {
-				poolDefault = p.(*goroutinePool) // nolint: errcheck
-			} else {
-				cleanup()
-				return err
-			}
-		}
+	pool, err := defaultPool()
+	if nil != err {
+		return err
+	}
+
+	return pool.Submit(recovered(task))
+}
+
+// SubmitUnique 按键去重地提交任务到默认协程池，同一个键的任务正在排队或执行时不再提交。
+// 参数：
+//   - key：任务键。
+//   - task：要执行的任务函数。
+//
+// 返回值：
+//   - bool：任务被提交时返回 true，同一个键的任务正在排队或执行时返回 false。
+//   - error：如果提交失败则返回错误，此时返回的 bool 为 false。
+//
+// 示例：
+//
+//	// 配置变化频繁时，同一时间只有一个刷新任务在排队或执行。
+//	_, err := goroutine.SubmitUnique("refresh:config", refreshConfig)
+func SubmitUnique(key string, task func()) (bool, error) {
+	pool, err := defaultPool()
+	if nil != err {
+		return false, err
 	}
+	return pool.SubmitUnique(key, recovered(task))
+}
 
-	return poolDefault.Submit(func() {
+// UniqueDone 返回在默认协程池中指定键当前的任务结束时关闭的通道。
+// 参数：
+//   - key：任务键。
+//
+// 返回值：
+//   - <-chan struct{}：任务结束时关闭的通道；没有该键的任务正在排队或执行时返回已关闭的通道。
+func UniqueDone(key string) <-chan struct{} {
+	pool, err := defaultPool()
+	if nil != err {
+		return closedChan
+	}
+	return pool.UniqueDone(key)
+}
+
+// defaultPool 返回默认协程池，第一次调用时创建。
+func defaultPool() (GoroutinePool, error) {
+	return poolDefault.Do(func() (GoroutinePool, error) {
+		p, _, err := NewGoroutinePool(WithName("default"))
+		return p, err
+	})
+}
+
+// recovered 包装任务，记录任务中的 panic 而不是交给协程池的 panic 处理函数。
+func recovered(task func()) func() {
+	return func() {
 		defer func() {
 			if r := recover(); nil != r {
 				kitlog.Error("goroutine panic", r)
 			}
 		}()
 		task()
-	})
+	}
 }
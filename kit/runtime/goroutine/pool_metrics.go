@@ -7,13 +7,18 @@ package goroutine
 import (
 	"time"
 
+	"github.com/panjf2000/ants/v2"
 	"github.com/prometheus/client_golang/prometheus"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
 )
 
 // 定义协程池指标统计相关的常量。
 const (
 	// statTickTime 定义指标采集的时间间隔，默认为 10 秒。
 	statTickTime = 10 * time.Second
+	// statJitter 定义指标采集间隔的抖动比例，错开多个协程池与多个副本的采集时间。
+	statJitter = 0.1
 	// namespace 定义 prometheus 指标的命名空间。
 	namespace = "kit_goroutine"
 	// subsystem 定义 prometheus 指标的子系统名称。
@@ -33,8 +38,18 @@ var (
 	}, []string{"name", "state"})
 )
 
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricWorkerCurrent,
+	}
+}
+
 // stat 定期采集协程池的运行状态指标。
-// 该函数会启动一个定时器，每 10 秒采集一次协程池的状态信息。
+// 该函数会使用协程池的时钟启动一个带抖动的定时器，平均每 10 秒采集一次协程池的状态信息。
 // 采集的指标包括：
 // - 协程池的总容量。
 // - 当前正在运行的协程数量。
@@ -42,20 +57,20 @@ var (
 // - 当前等待任务的协程数量。
 // 当协程池关闭时，该函数会自动退出。
 func stat(p *goroutinePool) {
-	// 创建定时器，每 10 秒触发一次。
-	ticker := time.NewTicker(statTickTime)
+	// 创建定时器，平均每 10 秒触发一次，每次间隔在 ±10% 内随机。
+	ticker := kittime.NewJitteredTicker(statTickTime, statJitter, kittime.WithClock(p.clock))
 	defer ticker.Stop()
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.C():
 			// 更新协程池的容量指标。
-			MetricWorkerCurrent.WithLabelValues(p.name, "cap").Set(float64(p.pool.Cap()))
+			MetricWorkerCurrent.WithLabelValues(p.name, "cap").Set(float64(p.Cap()))
 			// 更新正在运行的协程数量指标。
-			MetricWorkerCurrent.WithLabelValues(p.name, "running").Set(float64(p.pool.Running()))
+			MetricWorkerCurrent.WithLabelValues(p.name, "running").Set(float64(p.Running()))
 			// 更新空闲协程数量指标。
-			MetricWorkerCurrent.WithLabelValues(p.name, "free").Set(float64(p.pool.Free()))
+			MetricWorkerCurrent.WithLabelValues(p.name, "free").Set(float64(p.Free()))
 			// 更新等待任务的协程数量指标。
-			MetricWorkerCurrent.WithLabelValues(p.name, "waiting").Set(float64(p.pool.Waiting()))
+			MetricWorkerCurrent.WithLabelValues(p.name, "waiting").Set(float64(p.sum((*ants.Pool).Waiting)))
 		case <-p.closed:
 			// 当协程池关闭时退出循环。
 			return
@@ -8,23 +8,33 @@
 // 测试覆盖了协程池的主要功能点，包括创建、任务提交、容量调整和状态查询等。
 // 每个测试用例都包含详细的注释说明，便于理解测试目的和预期结果。
 
-package goroutine
+package goroutine_test
 
 import (
+	"context"
+	"math"
+	"runtime"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/panjf2000/ants/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+	kittesting "github.com/fsyyft-go/monorepo/kit/testing"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
 )
 
 // TestNewGoroutinePool 测试创建新的协程池。
 func TestNewGoroutinePool(t *testing.T) {
 	tests := []struct {
 		name    string
-		opts    []Option
+		opts    []goroutine.Option
 		wantErr bool
 	}{
 		{
@@ -34,26 +44,26 @@ func TestNewGoroutinePool(t *testing.T) {
 		},
 		{
 			name: "使用自定义配置创建协程池",
-			opts: []Option{
-				WithSize(10),
-				WithExpiry(time.Second),
-				WithPreAlloc(true),
-				WithNonBlocking(true),
-				WithMaxBlocking(100),
-				WithName("test-pool"),
-				WithMetrics(true),
+			opts: []goroutine.Option{
+				goroutine.WithSize(10),
+				goroutine.WithExpiry(time.Second),
+				goroutine.WithPreAlloc(true),
+				goroutine.WithNonBlocking(true),
+				goroutine.WithMaxBlocking(100),
+				goroutine.WithName("test-pool"),
+				goroutine.WithMetrics(true),
 			},
 			wantErr: false,
 		},
 		{
 			name: "使用最小配置创建协程池",
-			opts: []Option{
-				WithSize(1),
-				WithExpiry(time.Millisecond),
-				WithPreAlloc(false),
-				WithNonBlocking(false),
-				WithMaxBlocking(0),
-				WithMetrics(false),
+			opts: []goroutine.Option{
+				goroutine.WithSize(1),
+				goroutine.WithExpiry(time.Millisecond),
+				goroutine.WithPreAlloc(false),
+				goroutine.WithNonBlocking(false),
+				goroutine.WithMaxBlocking(0),
+				goroutine.WithMetrics(false),
 			},
 			wantErr: false,
 		},
@@ -61,7 +71,7 @@ func TestNewGoroutinePool(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			pool, cleanup, err := NewGoroutinePool(tt.opts...)
+			pool, cleanup, err := goroutine.NewGoroutinePool(tt.opts...)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -76,7 +86,7 @@ func TestNewGoroutinePool(t *testing.T) {
 
 // TestGoroutinePool_Submit 测试提交任务到协程池。
 func TestGoroutinePool_Submit(t *testing.T) {
-	pool, cleanup, err := NewGoroutinePool(WithSize(2))
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(2))
 	require.NoError(t, err)
 	defer cleanup()
 
@@ -120,7 +130,7 @@ func TestGoroutinePool_Submit(t *testing.T) {
 
 // TestGoroutinePool_SubmitAfterClose 测试关闭后提交任务。
 func TestGoroutinePool_SubmitAfterClose(t *testing.T) {
-	pool, cleanup, err := NewGoroutinePool()
+	pool, cleanup, err := goroutine.NewGoroutinePool()
 	require.NoError(t, err)
 	cleanup()
 
@@ -130,9 +140,9 @@ func TestGoroutinePool_SubmitAfterClose(t *testing.T) {
 
 // TestGoroutinePool_NonBlocking 测试非阻塞模式。
 func TestGoroutinePool_NonBlocking(t *testing.T) {
-	pool, cleanup, err := NewGoroutinePool(
-		WithSize(1),
-		WithNonBlocking(true),
+	pool, cleanup, err := goroutine.NewGoroutinePool(
+		goroutine.WithSize(1),
+		goroutine.WithNonBlocking(true),
 	)
 	require.NoError(t, err)
 	defer cleanup()
@@ -150,9 +160,9 @@ func TestGoroutinePool_NonBlocking(t *testing.T) {
 
 // TestGoroutinePool_MaxBlocking 测试最大阻塞数限制。
 func TestGoroutinePool_MaxBlocking(t *testing.T) {
-	pool, cleanup, err := NewGoroutinePool(
-		WithSize(1),
-		WithMaxBlocking(1),
+	pool, cleanup, err := goroutine.NewGoroutinePool(
+		goroutine.WithSize(1),
+		goroutine.WithMaxBlocking(1),
 	)
 	require.NoError(t, err)
 	defer cleanup()
@@ -173,18 +183,223 @@ func TestGoroutinePool_MaxBlocking(t *testing.T) {
 	}()
 
 	// 提交第三个任务，应该返回错误
-	time.Sleep(10 * time.Millisecond) // 等待第二个任务被阻塞
+	// 等待第二个任务被阻塞。
+	kittesting.Eventually(t, func() bool { return pool.Waiting() == 1 }, time.Second, time.Millisecond)
 	err = pool.Submit(func() {})
 	assert.Error(t, err, "超过最大阻塞数时应该返回错误")
 
 	wg.Wait()
 }
 
+// TestGoroutinePool_SubmitContext 测试带上下文的任务提交。
+func TestGoroutinePool_SubmitContext(t *testing.T) {
+	// 非阻塞模式下 SubmitContext 同样排队等待。
+	pool, cleanup, err := goroutine.NewGoroutinePool(
+		goroutine.WithSize(1),
+		goroutine.WithNonBlocking(true),
+		goroutine.WithMetrics(false),
+	)
+	require.NoError(t, err)
+	defer cleanup()
+
+	release := make(chan struct{})
+	require.NoError(t, pool.SubmitContext(context.Background(), func() { <-release }))
+
+	// 等待超时，任务不会被执行。
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = pool.SubmitContext(ctx, func() { t.Error("等待超时的任务不应该被执行") })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// 有空闲协程后等待中的任务被执行。
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, pool.SubmitContext(context.Background(), func() { close(done) }))
+	}()
+	kittesting.Eventually(t, func() bool { return 1 == pool.Waiting() }, time.Second, time.Millisecond)
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("有空闲协程后等待中的任务应该被执行")
+	}
+}
+
+// TestGoroutinePool_SubmitContextClose 测试关闭协程池时唤醒阻塞中的任务提交。
+func TestGoroutinePool_SubmitContextClose(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(1), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	require.NoError(t, pool.Submit(func() { <-release }))
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- pool.Submit(func() {})
+	}()
+	kittesting.Eventually(t, func() bool { return 1 == pool.Waiting() }, time.Second, time.Millisecond)
+
+	close(release)
+	cleanup()
+	err = <-errs
+	if nil != err {
+		assert.ErrorIs(t, err, ants.ErrPoolClosed)
+	}
+}
+
+// TestGoroutinePool_SelfSubmitDeadlock 测试全部任务都在等待向已满的同一个协程池提交时返回错误，而不是死锁。
+func TestGoroutinePool_SelfSubmitDeadlock(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(2), goroutine.WithName("nested"), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer cleanup()
+
+	// 两个任务都开始执行后再提交子任务并等待，使协程池已满。
+	var started sync.WaitGroup
+	started.Add(2)
+	gate := make(chan struct{})
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, pool.Submit(func() {
+			started.Done()
+			<-gate
+			done := make(chan struct{})
+			err := pool.Submit(func() { close(done) })
+			if nil == err {
+				<-done
+			}
+			errs <- err
+		}))
+	}
+	started.Wait()
+	close(gate)
+
+	// 第二个等待的任务返回错误并结束，第一个任务随之获得空闲协程。
+	var failed int
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			if nil != err {
+				failed++
+				assert.ErrorIs(t, err, goroutine.ErrSelfSubmitDeadlock)
+				assert.Contains(t, err.Error(), `"nested"`)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("任务中的同步提交不应该死锁")
+		}
+	}
+	assert.Equal(t, 1, failed)
+}
+
+// TestGoroutinePool_SelfSubmitWait 测试仍有任务可以结束时，任务中的提交等待空闲协程而不是返回错误。
+func TestGoroutinePool_SelfSubmitWait(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(2), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer cleanup()
+
+	release := make(chan struct{})
+	require.NoError(t, pool.Submit(func() { <-release }))
+
+	errs := make(chan error, 1)
+	require.NoError(t, pool.Submit(func() {
+		done := make(chan struct{})
+		err := pool.Submit(func() { close(done) })
+		if nil == err {
+			<-done
+		}
+		errs <- err
+	}))
+	kittesting.Eventually(t, func() bool { return 1 == pool.Waiting() }, time.Second, time.Millisecond)
+
+	close(release)
+	select {
+	case err := <-errs:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("有空闲协程后任务中的提交应该完成")
+	}
+}
+
+// TestGoroutinePool_DeadlockDetectionDisabled 测试关闭死锁检测后不记录执行任务的协程。
+func TestGoroutinePool_DeadlockDetectionDisabled(t *testing.T) {
+	p, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(1), goroutine.WithDeadlockDetection(false), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer cleanup()
+
+	recorded := make(chan bool, 1)
+	require.NoError(t, p.Submit(func() {
+		ok := goroutine.IsWorker(p)
+		recorded <- ok
+	}))
+	assert.False(t, <-recorded)
+}
+
+// TestGoroutinePool_DeadlockDetectionUnbounded 测试不限制大小的协程池默认不记录执行任务的协程，调小后开始记录。
+func TestGoroutinePool_DeadlockDetectionUnbounded(t *testing.T) {
+	p, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer cleanup()
+
+	recorded := make(chan bool, 1)
+	task := func() {
+		ok := goroutine.IsWorker(p)
+		recorded <- ok
+	}
+	require.NoError(t, p.Submit(task))
+	assert.False(t, <-recorded)
+
+	p.Tune(4)
+	require.NoError(t, p.Submit(task))
+	assert.True(t, <-recorded)
+}
+
+// TestGoroutinePool_UnboundedDirect 测试不限制大小的协程池提交不经过信号量，调小后提交重新经过信号量。
+func TestGoroutinePool_UnboundedDirect(t *testing.T) {
+	p, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer cleanup()
+	slots := goroutine.ShardSlots(p, 0)
+
+	held := make(chan int64, 1)
+	task := func() { held <- slots.Held() }
+	require.NoError(t, p.Submit(task))
+	assert.Equal(t, int64(0), <-held)
+	require.NoError(t, p.SubmitContext(context.Background(), task))
+	assert.Equal(t, int64(0), <-held)
+
+	p.Tune(4)
+	require.NoError(t, p.Submit(task))
+	assert.Equal(t, int64(1), <-held)
+}
+
+// TestGoroutinePool_TuneWakesWaiting 测试扩大协程池后等待中的任务被执行。
+func TestGoroutinePool_TuneWakesWaiting(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(1), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer cleanup()
+
+	release := make(chan struct{})
+	defer close(release)
+	require.NoError(t, pool.Submit(func() { <-release }))
+
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, pool.Submit(func() { close(done) }))
+	}()
+	kittesting.Eventually(t, func() bool { return 1 == pool.Waiting() }, time.Second, time.Millisecond)
+
+	pool.Tune(2)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("扩大协程池后等待中的任务应该被执行")
+	}
+}
+
 // TestGoroutinePool_PanicHandler 测试 panic 处理器。
 func TestGoroutinePool_PanicHandler(t *testing.T) {
 	var panicCount int32
-	pool, cleanup, err := NewGoroutinePool(
-		WithPanicHandler(func(i interface{}) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(
+		goroutine.WithPanicHandler(func(i interface{}) {
 			atomic.AddInt32(&panicCount, 1)
 		}),
 	)
@@ -197,15 +412,16 @@ func TestGoroutinePool_PanicHandler(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	time.Sleep(10 * time.Millisecond) // 等待 panic 处理器执行
+	// 等待 panic 处理器执行。
+	kittesting.Eventually(t, func() bool { return atomic.LoadInt32(&panicCount) == 1 }, time.Second, time.Millisecond)
 	assert.Equal(t, int32(1), atomic.LoadInt32(&panicCount), "panic 处理器应该被调用一次")
 }
 
 // TestGoroutinePool_Expiry 测试协程过期。
 func TestGoroutinePool_Expiry(t *testing.T) {
-	pool, cleanup, err := NewGoroutinePool(
-		WithSize(1),
-		WithExpiry(50*time.Millisecond),
+	pool, cleanup, err := goroutine.NewGoroutinePool(
+		goroutine.WithSize(1),
+		goroutine.WithExpiry(50*time.Millisecond),
 	)
 	require.NoError(t, err)
 	defer cleanup()
@@ -224,11 +440,34 @@ func TestGoroutinePool_Expiry(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TestGoroutinePool_Clock 测试指标采集使用注入的时钟。
+func TestGoroutinePool_Clock(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	name := t.Name() + strconv.FormatInt(time.Now().UnixNano(), 10)
+	_, cleanup, err := goroutine.NewGoroutinePool(
+		goroutine.WithSize(3),
+		goroutine.WithName(name),
+		goroutine.WithClock(clock),
+	)
+	require.NoError(t, err)
+	defer cleanup()
+
+	gauge := goroutine.MetricWorkerCurrent.WithLabelValues(name, "cap")
+	clock.BlockUntil(1)
+	assert.Equal(t, float64(0), testutil.ToFloat64(gauge), "时钟推进前不采集指标")
+
+	// 采集间隔带有抖动，推进两倍的平均间隔保证触发一次。
+	clock.Advance(2 * goroutine.StatTickTime)
+	assert.Eventually(t, func() bool {
+		return 3 == testutil.ToFloat64(gauge)
+	}, time.Second, time.Millisecond, "推进时钟后应采集指标")
+}
+
 // TestGoroutinePool_PreAlloc 测试预分配。
 func TestGoroutinePool_PreAlloc(t *testing.T) {
-	pool, cleanup, err := NewGoroutinePool(
-		WithSize(5),
-		WithPreAlloc(true),
+	pool, cleanup, err := goroutine.NewGoroutinePool(
+		goroutine.WithSize(5),
+		goroutine.WithPreAlloc(true),
 	)
 	require.NoError(t, err)
 	defer cleanup()
@@ -239,7 +478,7 @@ func TestGoroutinePool_PreAlloc(t *testing.T) {
 
 // TestGoroutinePool_Tune 测试调整协程池大小。
 func TestGoroutinePool_Tune(t *testing.T) {
-	pool, cleanup, err := NewGoroutinePool(WithSize(2))
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(2))
 	require.NoError(t, err)
 	defer cleanup()
 
@@ -279,7 +518,7 @@ func TestGoroutinePool_Tune(t *testing.T) {
 
 // TestGoroutinePool_Status 测试协程池状态查询。
 func TestGoroutinePool_Status(t *testing.T) {
-	pool, cleanup, err := NewGoroutinePool(WithSize(2))
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(2))
 	require.NoError(t, err)
 	defer cleanup()
 
@@ -337,7 +576,7 @@ func TestSubmit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := Submit(tt.task)
+			err := goroutine.Submit(tt.task)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -347,9 +586,132 @@ func TestSubmit(t *testing.T) {
 	}
 }
 
+// TestGoroutinePool_SubmitUnique 测试按键去重地提交任务。
+func TestGoroutinePool_SubmitUnique(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool()
+	require.NoError(t, err)
+	defer cleanup()
+
+	// 没有任务时返回已关闭的通道。
+	select {
+	case <-pool.UniqueDone("refresh"):
+	default:
+		t.Fatal("没有任务时应返回已关闭的通道")
+	}
+
+	release := make(chan struct{})
+	var runs atomic.Int32
+	task := func() {
+		runs.Add(1)
+		<-release
+	}
+	accepted, err := pool.SubmitUnique("refresh", task)
+	require.NoError(t, err)
+	assert.True(t, accepted)
+	done := pool.UniqueDone("refresh")
+
+	// 同一个键的任务执行中时跳过，不同的键不受影响。
+	for i := 0; i < 10; i++ {
+		accepted, err = pool.SubmitUnique("refresh", task)
+		require.NoError(t, err)
+		assert.False(t, accepted, "同一个键的任务执行中时应跳过提交")
+	}
+	accepted, err = pool.SubmitUnique("invalidate", func() {})
+	require.NoError(t, err)
+	assert.True(t, accepted)
+
+	select {
+	case <-done:
+		t.Fatal("任务结束前通道不应关闭")
+	default:
+	}
+	close(release)
+	<-done
+	assert.Equal(t, int32(1), runs.Load(), "被跳过的任务不应执行")
+
+	// 任务结束后可以再次提交。
+	accepted, err = pool.SubmitUnique("refresh", task)
+	require.NoError(t, err)
+	assert.True(t, accepted)
+	<-pool.UniqueDone("refresh")
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+// TestGoroutinePool_SubmitUniqueQueued 测试任务排队等待空闲协程期间，同一个键的提交同样被跳过。
+func TestGoroutinePool_SubmitUniqueQueued(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(1))
+	require.NoError(t, err)
+	defer cleanup()
+
+	release := make(chan struct{})
+	require.NoError(t, pool.Submit(func() { <-release }))
+
+	var runs atomic.Int32
+	go func() {
+		accepted, err := pool.SubmitUnique("refresh", func() { runs.Add(1) })
+		assert.NoError(t, err)
+		assert.True(t, accepted)
+	}()
+	kittesting.Eventually(t, func() bool { return 1 == pool.Waiting() }, time.Second, time.Millisecond)
+
+	accepted, err := pool.SubmitUnique("refresh", func() { runs.Add(1) })
+	require.NoError(t, err)
+	assert.False(t, accepted, "同一个键的任务排队时应跳过提交")
+
+	done := pool.UniqueDone("refresh")
+	close(release)
+	<-done
+	assert.Equal(t, int32(1), runs.Load())
+}
+
+// TestGoroutinePool_SubmitUniqueRelease 测试提交失败或任务 panic 后释放任务键。
+func TestGoroutinePool_SubmitUniqueRelease(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(1), goroutine.WithNonBlocking(true))
+	require.NoError(t, err)
+	defer cleanup()
+
+	release := make(chan struct{})
+	require.NoError(t, pool.Submit(func() { <-release }))
+
+	accepted, err := pool.SubmitUnique("refresh", func() {})
+	assert.ErrorIs(t, err, ants.ErrPoolOverload)
+	assert.False(t, accepted)
+	<-pool.UniqueDone("refresh")
+
+	// 非阻塞模式下协程归还之前提交会失败，重试直到提交成功。
+	submit := func(task func()) bool {
+		accepted, err := pool.SubmitUnique("refresh", task)
+		return nil == err && accepted
+	}
+	close(release)
+	kittesting.Eventually(t, func() bool { return submit(func() { panic("test panic") }) }, time.Second, time.Millisecond)
+	<-pool.UniqueDone("refresh")
+	// 任务 panic 后应释放任务键。
+	kittesting.Eventually(t, func() bool { return submit(func() {}) }, time.Second, time.Millisecond)
+}
+
+// TestSubmitUnique 测试向默认池按键去重地提交任务。
+func TestSubmitUnique(t *testing.T) {
+	release := make(chan struct{})
+	accepted, err := goroutine.SubmitUnique("test:submit-unique", func() {
+		<-release
+		panic("test panic")
+	})
+	require.NoError(t, err)
+	assert.True(t, accepted)
+
+	accepted, err = goroutine.SubmitUnique("test:submit-unique", func() {})
+	require.NoError(t, err)
+	assert.False(t, accepted)
+
+	close(release)
+	<-goroutine.UniqueDone("test:submit-unique")
+	<-goroutine.UniqueDone("test:missing")
+}
+
 // TestGoroutinePool_Concurrent 测试协程池的并发操作。
 func TestGoroutinePool_Concurrent(t *testing.T) {
-	pool, cleanup, err := NewGoroutinePool(WithSize(5))
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(5))
 	require.NoError(t, err)
 	defer cleanup()
 
@@ -383,7 +745,7 @@ func TestGoroutinePool_Concurrent(t *testing.T) {
 
 // TestGoroutinePool_ConcurrentTune 测试并发调整池大小。
 func TestGoroutinePool_ConcurrentTune(t *testing.T) {
-	pool, cleanup, err := NewGoroutinePool(WithSize(5))
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(5))
 	require.NoError(t, err)
 	defer cleanup()
 
@@ -416,7 +778,7 @@ func TestGoroutinePool_ConcurrentTune(t *testing.T) {
 
 // TestGoroutinePool_Cleanup 测试清理函数。
 func TestGoroutinePool_Cleanup(t *testing.T) {
-	pool, cleanup, err := NewGoroutinePool()
+	pool, cleanup, err := goroutine.NewGoroutinePool()
 	require.NoError(t, err)
 
 	// 提交一些任务
@@ -435,3 +797,169 @@ func TestGoroutinePool_Cleanup(t *testing.T) {
 	err = pool.Submit(func() {})
 	assert.Error(t, err, "向已清理的池提交任务应该返回错误")
 }
+
+// TestGoroutinePool_Shards 测试分片后的容量统计与跨分片提交。
+func TestGoroutinePool_Shards(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(10), goroutine.WithShards(4), goroutine.WithNonBlocking(true), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer cleanup()
+
+	// 每个分片的大小向上取整为 3。
+	assert.Equal(t, 12, pool.Cap())
+	assert.Equal(t, 4, goroutine.Shards(pool))
+
+	// 分片已满后使用其他分片的空闲协程，全部分片已满时才拒绝提交。
+	release := make(chan struct{})
+	for i := 0; i < 12; i++ {
+		require.NoError(t, pool.Submit(func() { <-release }))
+	}
+	assert.Equal(t, 12, pool.Running())
+	assert.ErrorIs(t, pool.Submit(func() {}), ants.ErrPoolOverload)
+
+	close(release)
+	kittesting.Eventually(t, func() bool { return nil == pool.Submit(func() {}) }, time.Second, time.Millisecond)
+}
+
+// TestGoroutinePool_ShardsDefault 测试分片数量不大于 0 时使用 GOMAXPROCS。
+func TestGoroutinePool_ShardsDefault(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithShards(0), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer cleanup()
+
+	assert.Equal(t, runtime.GOMAXPROCS(0), goroutine.Shards(pool))
+}
+
+// TestGoroutinePool_ShardsBlocking 测试分片后等待中的提交在任意分片的任务结束时被唤醒。
+func TestGoroutinePool_ShardsBlocking(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(2), goroutine.WithShards(2), goroutine.WithMaxBlocking(2), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer cleanup()
+
+	releases := []chan struct{}{make(chan struct{}), make(chan struct{})}
+	for _, release := range releases {
+		require.NoError(t, pool.Submit(func() { <-release }))
+	}
+
+	var executed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, pool.Submit(func() { executed.Add(1) }))
+		}()
+	}
+	kittesting.Eventually(t, func() bool { return 2 == pool.Waiting() }, time.Second, time.Millisecond)
+
+	// 超过最大阻塞数量时返回错误。
+	assert.ErrorIs(t, pool.Submit(func() {}), ants.ErrPoolOverload)
+
+	// 只结束一个分片上的任务，两个等待中的提交依次使用该分片的空闲协程。
+	close(releases[1])
+	wg.Wait()
+	kittesting.Eventually(t, func() bool { return 2 == executed.Load() }, time.Second, time.Millisecond)
+	close(releases[0])
+}
+
+// TestGoroutinePool_ShardsTune 测试分片后调整大小唤醒等待中的提交。
+func TestGoroutinePool_ShardsTune(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(2), goroutine.WithShards(2), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer cleanup()
+
+	release := make(chan struct{})
+	defer close(release)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, pool.Submit(func() { <-release }))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, pool.Submit(func() { close(done) }))
+	}()
+	kittesting.Eventually(t, func() bool { return 1 == pool.Waiting() }, time.Second, time.Millisecond)
+
+	pool.Tune(4)
+	assert.Equal(t, 4, pool.Cap())
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("扩大协程池后等待中的任务应该被执行")
+	}
+}
+
+// TestGoroutinePool_ShardsClose 测试分片后关闭协程池结束等待中的提交。
+func TestGoroutinePool_ShardsClose(t *testing.T) {
+	pool, cleanup, err := goroutine.NewGoroutinePool(goroutine.WithSize(2), goroutine.WithShards(2), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	defer close(release)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, pool.Submit(func() { <-release }))
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- pool.Submit(func() {})
+	}()
+	kittesting.Eventually(t, func() bool { return 1 == pool.Waiting() }, time.Second, time.Millisecond)
+
+	go cleanup()
+	select {
+	case err := <-errCh:
+		assert.ErrorIs(t, err, ants.ErrPoolClosed)
+	case <-time.After(time.Second):
+		t.Fatal("关闭协程池后等待中的提交应该返回")
+	}
+}
+
+// benchmarkSubmit 在 GOMAXPROCS 个协程中并发提交空任务，并等待全部任务结束。
+func benchmarkSubmit(b *testing.B, opts ...goroutine.Option) {
+	p, cleanup, err := goroutine.NewGoroutinePool(append([]goroutine.Option{goroutine.WithMetrics(false)}, opts...)...)
+	require.NoError(b, err)
+	defer cleanup()
+	benchmarkSubmitTo(b, p.Submit)
+}
+
+// benchmarkSubmitTo 在 GOMAXPROCS 个协程中通过 submit 并发提交空任务，并等待全部任务结束。
+func benchmarkSubmitTo(b *testing.B, submit func(func()) error) {
+	var wg sync.WaitGroup
+	task := func() { wg.Done() }
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			wg.Add(1)
+			if err := submit(task); nil != err {
+				wg.Done()
+			}
+		}
+	})
+	wg.Wait()
+}
+
+// BenchmarkSubmit 对比默认配置、有界、关闭死锁检测与分片时的提交吞吐量，ants 为直接使用 ants.Pool 的基线。
+func BenchmarkSubmit(b *testing.B) {
+	b.Run("ants", func(b *testing.B) {
+		p, err := ants.NewPool(math.MaxInt32)
+		require.NoError(b, err)
+		defer p.Release()
+		benchmarkSubmitTo(b, p.Submit)
+	})
+	b.Run("single", func(b *testing.B) {
+		benchmarkSubmit(b)
+	})
+	b.Run("single-bounded", func(b *testing.B) {
+		benchmarkSubmit(b, goroutine.WithSize(1024))
+	})
+	b.Run("single-bounded-nodetect", func(b *testing.B) {
+		benchmarkSubmit(b, goroutine.WithSize(1024), goroutine.WithDeadlockDetection(false))
+	})
+	b.Run("sharded", func(b *testing.B) {
+		benchmarkSubmit(b, goroutine.WithShards(0))
+	})
+	b.Run("sharded-bounded", func(b *testing.B) {
+		benchmarkSubmit(b, goroutine.WithSize(1024), goroutine.WithShards(0))
+	})
+}
@@ -0,0 +1,278 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package retry
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 以下为 Adaptive 的默认参数配置。
+// 可通过 AdaptiveOption 机制覆盖。
+var (
+	// adaptiveWindowDefault 为统计成功率的滑动窗口大小。
+	adaptiveWindowDefault = 10 * time.Second
+	// adaptiveMinSamplesDefault 为开始调整退避时间所需的最少尝试次数。
+	adaptiveMinSamplesDefault = 20
+	// adaptiveMaxScaleDefault 为全部尝试失败时等待时间的放大倍数。
+	adaptiveMaxScaleDefault = float64(8)
+	// adaptiveSuspendRateDefault 为暂停重试的失败率。
+	adaptiveSuspendRateDefault = 0.9
+)
+
+var (
+	// ErrSuspended 表示操作最近的失败率过高，重试已被暂停。
+	// RetryWithContext 返回的错误同时包装了最后一次尝试的错误。
+	ErrSuspended = errors.New("kit/runtime/retry: 失败率过高，已暂停重试")
+)
+
+type (
+	// AdaptiveOption 定义了 Adaptive 的配置选项。
+	AdaptiveOption func(*adaptiveOptions)
+
+	// adaptiveOptions 包含 Adaptive 的配置。
+	adaptiveOptions struct {
+		// window 是统计成功率的滑动窗口大小。
+		window time.Duration
+		// minSamples 是开始调整退避时间所需的最少尝试次数，样本不足时不调整。
+		minSamples int
+		// maxScale 是全部尝试失败时等待时间的放大倍数。
+		maxScale float64
+		// suspendRate 是暂停重试的失败率，大于 1 时不暂停。
+		suspendRate float64
+		// clock 是统计使用的时钟。
+		clock kittime.Clock
+	}
+
+	// Adaptive 按操作统计最近一段时间内尝试的成功率，并据此自动调整退避时间：
+	// 失败率越高，等待时间放大得越多；失败率达到暂停阈值时不再重试，只保留每次调用的首次尝试。
+	// 依赖恢复后，首次尝试的成功使失败率下降，等待时间随之收窄，重试自动恢复。
+	// 同一依赖的所有调用方共享一个 Adaptive，使它们在依赖降级期间一起退让，无需手动调整参数。
+	// 所有方法都是并发安全的。
+	Adaptive struct {
+		// o 是 Adaptive 的配置。
+		o *adaptiveOptions
+
+		// mu 保护 windows。
+		mu sync.Mutex
+		// windows 是每个操作的滑动窗口。
+		windows map[string]*window
+	}
+)
+
+// WithAdaptiveWindow 设置统计成功率的滑动窗口大小。
+//
+// 参数：
+//   - size：窗口大小，默认为 10 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - AdaptiveOption：配置选项函数。
+func WithAdaptiveWindow(size time.Duration) AdaptiveOption {
+	return func(o *adaptiveOptions) {
+		o.window = size
+	}
+}
+
+// WithAdaptiveMinSamples 设置开始调整退避时间所需的最少尝试次数，避免少量样本造成误判。
+//
+// 参数：
+//   - n：最少尝试次数，默认为 20，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - AdaptiveOption：配置选项函数。
+func WithAdaptiveMinSamples(n int) AdaptiveOption {
+	return func(o *adaptiveOptions) {
+		o.minSamples = n
+	}
+}
+
+// WithAdaptiveMaxScale 设置全部尝试失败时等待时间的放大倍数。
+// 等待时间的放大倍数为 1 + 失败率 × (maxScale - 1)，可以超过 Backoff 的最大等待时间。
+//
+// 参数：
+//   - scale：放大倍数，默认为 8，小于 1 时使用默认值，为 1 时不放大。
+//
+// 返回值：
+//   - AdaptiveOption：配置选项函数。
+func WithAdaptiveMaxScale(scale float64) AdaptiveOption {
+	return func(o *adaptiveOptions) {
+		o.maxScale = scale
+	}
+}
+
+// WithAdaptiveSuspendRate 设置暂停重试的失败率，失败率达到该值时不再重试。
+//
+// 参数：
+//   - rate：失败率，取值范围为 (0, 1]，默认为 0.9，大于 1 时从不暂停，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - AdaptiveOption：配置选项函数。
+func WithAdaptiveSuspendRate(rate float64) AdaptiveOption {
+	return func(o *adaptiveOptions) {
+		o.suspendRate = rate
+	}
+}
+
+// WithAdaptiveClock 设置统计使用的时钟。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟，为 nil 时使用默认值。
+//
+// 返回值：
+//   - AdaptiveOption：配置选项函数。
+func WithAdaptiveClock(clock kittime.Clock) AdaptiveOption {
+	return func(o *adaptiveOptions) {
+		o.clock = clock
+	}
+}
+
+// NewAdaptive 创建按成功率自动调整退避时间的 Adaptive。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Adaptive：Adaptive 实例，通过 WithAdaptive 用于重试。
+//
+// 示例：
+//
+//	var userAdaptive = retry.NewAdaptive()
+//
+//	err := retry.RetryWithContext(ctx, callUserService,
+//	    retry.WithMax(2*time.Second),
+//	    retry.WithAdaptive(userAdaptive, "user.get"),
+//	)
+//	if errors.Is(err, retry.ErrSuspended) {
+//	    // 依赖降级中，使用缓存或快速失败。
+//	}
+func NewAdaptive(opts ...AdaptiveOption) *Adaptive {
+	o := &adaptiveOptions{
+		window:      adaptiveWindowDefault,
+		minSamples:  adaptiveMinSamplesDefault,
+		maxScale:    adaptiveMaxScaleDefault,
+		suspendRate: adaptiveSuspendRateDefault,
+		clock:       clockDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.window <= 0 {
+		o.window = adaptiveWindowDefault
+	}
+	if o.minSamples <= 0 {
+		o.minSamples = adaptiveMinSamplesDefault
+	}
+	if o.maxScale < 1 {
+		o.maxScale = adaptiveMaxScaleDefault
+	}
+	if o.suspendRate <= 0 {
+		o.suspendRate = adaptiveSuspendRateDefault
+	}
+	o.clock = kittime.OrReal(o.clock)
+
+	return &Adaptive{o: o, windows: make(map[string]*window)}
+}
+
+// Record 记录操作的一次尝试结果。使用 WithAdaptive 时 RetryWithContext 会自动记录每次尝试。
+//
+// 参数：
+//   - operation：操作名称。
+//   - err：尝试的错误，为 nil 时表示成功。
+func (a *Adaptive) Record(operation string, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.o.clock.Now()
+	w, ok := a.windows[operation]
+	if !ok {
+		w = newWindow(a.o.window, now)
+		a.windows[operation] = w
+	}
+	w.add(now, nil != err)
+}
+
+// FailureRate 返回操作在滑动窗口内的失败率。
+//
+// 参数：
+//   - operation：操作名称。
+//
+// 返回值：
+//   - float64：失败率，取值范围为 [0, 1]，尝试次数少于最少尝试次数时为 0。
+func (a *Adaptive) FailureRate(operation string) float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	w, ok := a.windows[operation]
+	if !ok {
+		return 0
+	}
+	total, failures := w.counts(a.o.clock.Now())
+	if total < a.o.minSamples {
+		return 0
+	}
+	return float64(failures) / float64(total)
+}
+
+// Scale 返回操作当前的等待时间放大倍数，为 1 + 失败率 × (最大放大倍数 - 1)。
+//
+// 参数：
+//   - operation：操作名称。
+//
+// 返回值：
+//   - float64：放大倍数，不小于 1。
+func (a *Adaptive) Scale(operation string) float64 {
+	return 1 + a.FailureRate(operation)*(a.o.maxScale-1)
+}
+
+// Suspended 返回操作的重试当前是否被暂停，即失败率是否达到暂停阈值。
+//
+// 参数：
+//   - operation：操作名称。
+//
+// 返回值：
+//   - bool：重试被暂停时返回 true。
+func (a *Adaptive) Suspended(operation string) bool {
+	return a.FailureRate(operation) >= a.o.suspendRate
+}
+
+// WithAdaptive 设置 Retry 与 RetryWithContext 使用 Adaptive 按操作的成功率调整退避时间。
+// 每次尝试的结果都会记录到 a 中；失败后等待时间乘以 a.Scale(operation)，
+// 失败率达到暂停阈值时不再重试，返回同时包装 ErrSuspended 与最后一次尝试错误的错误。
+// 参数：
+//   - a *Adaptive：共享的 Adaptive 实例，为 nil 时不调整。
+//   - operation string：操作名称，同一依赖的调用使用相同的名称。
+//
+// 返回值：
+//   - BackoffOption：用于启用自适应退避的选项函数。
+func WithAdaptive(a *Adaptive, operation string) BackoffOption {
+	return func(b *Backoff) {
+		b.adaptive = a
+		b.operation = operation
+	}
+}
+
+// adapt 记录一次尝试的结果，失败时返回放大后的等待时间与是否暂停重试。
+// 未启用自适应退避时直接返回 delay。
+func (b *Backoff) adapt(err error, delay time.Duration) (time.Duration, bool) {
+	if nil == b.adaptive {
+		return delay, false
+	}
+	b.adaptive.Record(b.operation, err)
+	if nil == err {
+		return delay, false
+	}
+	if b.adaptive.Suspended(b.operation) {
+		return 0, true
+	}
+	scaled := float64(delay) * b.adaptive.Scale(b.operation)
+	if scaled > maxInt64 {
+		return time.Duration(maxInt64), false
+	}
+	return time.Duration(scaled), false
+}
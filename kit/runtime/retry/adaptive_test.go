@@ -0,0 +1,133 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// record 为 operation 记录 successes 次成功与 failures 次失败。
+func record(a *Adaptive, operation string, successes, failures int) {
+	for i := 0; i < successes; i++ {
+		a.Record(operation, nil)
+	}
+	for i := 0; i < failures; i++ {
+		a.Record(operation, errors.New("fail"))
+	}
+}
+
+// 测试 Adaptive 按操作统计失败率，样本不足时不调整，滑出窗口的尝试不再计入。
+func TestAdaptive(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Now())
+	a := NewAdaptive(
+		WithAdaptiveWindow(10*time.Second),
+		WithAdaptiveMinSamples(4),
+		WithAdaptiveMaxScale(5),
+		WithAdaptiveSuspendRate(0.8),
+		WithAdaptiveClock(clock),
+	)
+
+	assert.Zero(t, a.FailureRate("db"))
+	record(a, "db", 0, 3)
+	assert.Zero(t, a.FailureRate("db"), "样本不足时失败率应为 0")
+	assert.Equal(t, float64(1), a.Scale("db"))
+
+	record(a, "db", 1, 0)
+	assert.Equal(t, 0.75, a.FailureRate("db"))
+	assert.Equal(t, float64(4), a.Scale("db"))
+	assert.False(t, a.Suspended("db"))
+	assert.Zero(t, a.FailureRate("cache"), "不同操作独立统计")
+
+	record(a, "db", 0, 1)
+	assert.True(t, a.Suspended("db"))
+
+	clock.Advance(10 * time.Second)
+	assert.Zero(t, a.FailureRate("db"), "滑出窗口的尝试不再计入")
+	assert.False(t, a.Suspended("db"))
+}
+
+// 测试非法的参数使用默认值，暂停阈值大于 1 时从不暂停。
+func TestNewAdaptive_Options(t *testing.T) {
+	a := NewAdaptive(
+		WithAdaptiveWindow(0),
+		WithAdaptiveMinSamples(-1),
+		WithAdaptiveMaxScale(0.5),
+		WithAdaptiveSuspendRate(0),
+		WithAdaptiveClock(nil),
+	)
+	assert.Equal(t, adaptiveWindowDefault, a.o.window)
+	assert.Equal(t, adaptiveMinSamplesDefault, a.o.minSamples)
+	assert.Equal(t, adaptiveMaxScaleDefault, a.o.maxScale)
+	assert.Equal(t, adaptiveSuspendRateDefault, a.o.suspendRate)
+	assert.NotNil(t, a.o.clock)
+
+	a = NewAdaptive(WithAdaptiveMinSamples(1), WithAdaptiveSuspendRate(1.1))
+	record(a, "db", 0, 10)
+	assert.False(t, a.Suspended("db"))
+}
+
+// 测试 RetryWithContext 按失败率放大等待时间，失败率过高时暂停重试，依赖恢复后重试自动恢复。
+func TestRetryWithContext_Adaptive(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	statsClock := kittime.NewFakeClock(time.Now())
+	a := NewAdaptive(
+		WithAdaptiveMinSamples(10),
+		WithAdaptiveMaxScale(3),
+		WithAdaptiveClock(statsClock),
+	)
+	errFail := errors.New("fail")
+	opts := []BackoffOption{WithClock(clock), WithMin(time.Hour), WithMax(time.Hour), WithAdaptive(a, "db")}
+
+	// 失败率为 0.5，等待时间放大为 2 倍。
+	record(a, "db", 10, 9)
+	count := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- RetryWithContext(context.Background(), func(ctx context.Context) error {
+			count++
+			if count < 2 {
+				return errFail
+			}
+			return nil
+		}, opts...)
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(2*time.Hour - time.Nanosecond)
+	assert.Equal(t, 1, clock.Waiters(), "等待时间应放大为 2 倍")
+	clock.Advance(time.Nanosecond)
+	require.NoError(t, <-done)
+	assert.Equal(t, 2, count)
+	assert.InDelta(t, 10.0/21, a.FailureRate("db"), 1e-9, "每次尝试都应记录")
+
+	// 失败率达到暂停阈值，首次尝试失败后不再重试。
+	record(a, "db", 0, 100)
+	count = 0
+	err := RetryWithContext(context.Background(), func(ctx context.Context) error {
+		count++
+		return errFail
+	}, opts...)
+	assert.ErrorIs(t, err, ErrSuspended)
+	assert.ErrorIs(t, err, errFail)
+	assert.Equal(t, 1, count)
+	assert.Zero(t, clock.Waiters())
+
+	// 依赖恢复后，重试自动恢复。
+	statsClock.Advance(adaptiveWindowDefault)
+	assert.NoError(t, Retry(func() error { return nil }, opts...))
+	assert.False(t, a.Suspended("db"))
+
+	// Copy 保留自适应退避配置。
+	b := NewBackoff(opts...).Copy()
+	assert.Same(t, a, b.adaptive)
+	assert.Equal(t, "db", b.operation)
+}
@@ -9,6 +9,10 @@ import (
 	"math/rand"
 	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
 )
 
 type (
@@ -34,6 +38,35 @@ type (
 		// max 表示等待时间的最大值。
 		// 默认为 10 秒。
 		max time.Duration
+
+		// clock 是等待重试时使用的时钟。
+		// 默认为系统时钟。
+		clock kittime.Clock
+
+		// tracing 表示重试时是否记录追踪信息。
+		// 默认为 false。
+		tracing bool
+
+		// tracer 是为每次尝试创建子 Span 的 Tracer，为 nil 时在当前 Span 上记录事件。
+		tracer trace.Tracer
+
+		// adaptive 是按成功率调整退避时间的 Adaptive，为 nil 时不调整。
+		adaptive *Adaptive
+
+		// operation 是记录到 adaptive 中的操作名称。
+		operation string
+
+		// maxAttempts 是 RetryWithContext 最多尝试的次数。
+		// 默认为 0，表示不限制。
+		maxAttempts int
+
+		// maxElapsed 是 RetryWithContext 从第一次尝试开始允许经过的最长时间。
+		// 默认为 0，表示不限制。
+		maxElapsed time.Duration
+
+		// cooperative 表示是否为每次尝试创建在预算用尽时取消的子上下文。
+		// 默认为 false。
+		cooperative bool
 	}
 )
 
@@ -49,10 +82,18 @@ const (
 //   - *Backoff：新建的 Backoff 实例，参数与当前实例一致。
 func (b *Backoff) Copy() *Backoff {
 	return &Backoff{
-		factor: b.factor,
-		jitter: b.jitter,
-		min:    b.min,
-		max:    b.max,
+		factor:      b.factor,
+		jitter:      b.jitter,
+		min:         b.min,
+		max:         b.max,
+		clock:       b.clock,
+		tracing:     b.tracing,
+		tracer:      b.tracer,
+		adaptive:    b.adaptive,
+		operation:   b.operation,
+		maxAttempts: b.maxAttempts,
+		maxElapsed:  b.maxElapsed,
+		cooperative: b.cooperative,
 	}
 }
 
@@ -143,9 +184,11 @@ func NewBackoff(opts ...BackoffOption) *Backoff {
 		jitter: jitterDefault,
 		min:    minDefault,
 		max:    maxDefault,
+		clock:  clockDefault,
 	}
 	for _, opt := range opts {
 		opt(b)
 	}
+	b.clock = kittime.OrReal(b.clock)
 	return b
 }
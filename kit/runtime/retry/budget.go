@@ -0,0 +1,142 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+var (
+	// ErrBudgetExhausted 表示重试次数或重试时间已经用尽。
+	// RetryWithContext 返回的错误同时包装了最后一次尝试的错误。
+	ErrBudgetExhausted = errors.New("kit/runtime/retry: 重试次数或时间已用尽")
+)
+
+type (
+	// budget 记录一次 RetryWithContext 调用的重试预算。
+	budget struct {
+		// maxAttempts 是最多尝试的次数，为 0 时不限制。
+		maxAttempts int
+		// maxElapsed 是从第一次尝试开始允许经过的最长时间，为 0 时不限制。
+		maxElapsed time.Duration
+		// clock 是计时使用的时钟。
+		clock kittime.Clock
+		// start 是第一次尝试开始的时间。
+		start time.Time
+		// cooperative 表示是否为每次尝试创建可以取消的子上下文。
+		cooperative bool
+
+		// ctx 在协作取消模式下为每次尝试的上下文的父上下文，重试时间用尽时以 ErrBudgetExhausted 为原因取消；
+		// 未启用协作取消或不限制重试时间时为 nil。
+		ctx context.Context
+		// cancel 取消 ctx。
+		cancel context.CancelCauseFunc
+		// timer 在重试时间用尽时触发。
+		timer kittime.Timer
+	}
+)
+
+// WithMaxAttempts 设置 RetryWithContext 最多尝试的次数，包括第一次尝试。
+// 次数用尽后返回同时包装 ErrBudgetExhausted 与最后一次错误的错误。
+// 参数：
+//   - n int：最多尝试的次数，小于等于 0 时不限制。
+//
+// 返回值：
+//   - BackoffOption：用于设置最多尝试次数的选项函数。
+func WithMaxAttempts(n int) BackoffOption {
+	return func(b *Backoff) {
+		b.maxAttempts = max(n, 0)
+	}
+}
+
+// WithMaxElapsed 设置 RetryWithContext 从第一次尝试开始允许经过的最长时间。
+// 失败后如果等待下一次重试会超出该时间，不再等待，直接返回同时包装 ErrBudgetExhausted 与最后一次错误的错误。
+// 默认只在尝试之间检查，正在进行的尝试不受影响；需要限制最后一次尝试时同时使用 WithCooperativeCancel。
+// 参数：
+//   - d time.Duration：最长时间，小于等于 0 时不限制。
+//
+// 返回值：
+//   - BackoffOption：用于设置最长重试时间的选项函数。
+func WithMaxElapsed(d time.Duration) BackoffOption {
+	return func(b *Backoff) {
+		b.maxElapsed = max(d, 0)
+	}
+}
+
+// WithCooperativeCancel 设置是否协作取消正在进行的尝试。
+// 启用后，每次尝试使用独立的子上下文，父上下文结束、WithMaxElapsed 设置的时间用尽或尝试结束时取消，
+// 重试函数响应上下文的取消即可保证耗时较长的最后一次尝试不会超出调用方的整体期限。
+// 因时间用尽而取消时，context.Cause 返回 ErrBudgetExhausted。
+// 参数：
+//   - enabled bool：是否启用。
+//
+// 返回值：
+//   - BackoffOption：用于设置协作取消的选项函数。
+func WithCooperativeCancel(enabled bool) BackoffOption {
+	return func(b *Backoff) {
+		b.cooperative = enabled
+	}
+}
+
+// newBudget 开始计算一次重试的预算，调用方在重试结束后调用 stop。
+func (b *Backoff) newBudget(ctx context.Context) *budget {
+	bg := &budget{
+		maxAttempts: b.maxAttempts,
+		maxElapsed:  b.maxElapsed,
+		clock:       b.clock,
+		start:       b.clock.Now(),
+		cooperative: b.cooperative,
+	}
+	if !b.cooperative || 0 == b.maxElapsed {
+		return bg
+	}
+
+	bg.ctx, bg.cancel = context.WithCancelCause(ctx)
+	bg.timer = b.clock.NewTimer(b.maxElapsed)
+	go func() {
+		select {
+		case <-bg.timer.C():
+			bg.cancel(ErrBudgetExhausted)
+		case <-bg.ctx.Done():
+		}
+	}()
+	return bg
+}
+
+// attempt 返回一次尝试使用的上下文与尝试结束时调用的函数。
+// 未启用协作取消时直接返回 ctx。
+func (bg *budget) attempt(ctx context.Context) (context.Context, context.CancelFunc) {
+	if !bg.cooperative {
+		return ctx, func() {}
+	}
+	if nil != bg.ctx {
+		ctx = bg.ctx
+	}
+	return context.WithCancel(ctx)
+}
+
+// exhausted 判断第 attempt 次尝试失败、需要等待 delay 后重试时，预算是否已经用尽。
+func (bg *budget) exhausted(attempt int, delay time.Duration) bool {
+	if 0 != bg.maxAttempts && attempt >= bg.maxAttempts {
+		return true
+	}
+	if 0 != bg.maxElapsed && bg.clock.Since(bg.start)+delay >= bg.maxElapsed {
+		return true
+	}
+	return nil != bg.ctx && errors.Is(context.Cause(bg.ctx), ErrBudgetExhausted)
+}
+
+// stop 释放预算使用的定时器与上下文。
+func (bg *budget) stop() {
+	if nil == bg.ctx {
+		return
+	}
+	bg.timer.Stop()
+	bg.cancel(nil)
+}
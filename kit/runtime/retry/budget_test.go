@@ -0,0 +1,144 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 测试 WithMaxAttempts 限制尝试次数，用尽后返回同时包装 ErrBudgetExhausted 与最后一次错误的错误。
+func TestRetry_MaxAttempts(t *testing.T) {
+	errFail := errors.New("fail")
+	count := 0
+	err := Retry(func() error {
+		count++
+		return errFail
+	}, WithMaxAttempts(3), WithMin(time.Millisecond), WithMax(time.Millisecond))
+
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+	assert.ErrorIs(t, err, errFail)
+	assert.Equal(t, 3, count)
+
+	// 小于等于 0 时不限制。
+	count = 0
+	err = Retry(func() error {
+		count++
+		if count < 5 {
+			return errFail
+		}
+		return nil
+	}, WithMaxAttempts(-1), WithMin(time.Millisecond), WithMax(time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, count)
+}
+
+// 测试 WithMaxElapsed 在等待下一次重试会超出重试时间时不再等待。
+func TestRetryWithContext_MaxElapsed(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	errFail := errors.New("fail")
+
+	count := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- RetryWithContext(context.Background(), func(ctx context.Context) error {
+			count++
+			return errFail
+		}, WithClock(clock), WithMin(time.Hour), WithMax(time.Hour), WithMaxElapsed(150*time.Minute))
+	}()
+
+	// 第 1、2 次失败后等待一小时；第 3 次失败时已经过 2 小时，再等待一小时会超出 2.5 小时。
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Hour)
+	}
+	err := <-done
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+	assert.ErrorIs(t, err, errFail)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, 0, clock.Waiters())
+}
+
+// 测试 WithCooperativeCancel 在重试时间用尽时取消正在进行的尝试。
+func TestRetryWithContext_CooperativeCancel(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	errFail := errors.New("fail")
+
+	count := 0
+	started := make(chan struct{})
+	causes := make(chan error, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- RetryWithContext(context.Background(), func(ctx context.Context) error {
+			count++
+			if 1 == count {
+				return errFail
+			}
+			// 第 2 次尝试一直执行到上下文被取消。
+			close(started)
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return ctx.Err()
+		}, WithClock(clock), WithMin(10*time.Minute), WithMax(10*time.Minute),
+			WithMaxElapsed(time.Hour), WithCooperativeCancel(true))
+	}()
+
+	// 等待预算定时器与重试定时器。
+	clock.BlockUntil(2)
+	clock.Advance(10 * time.Minute)
+	<-started
+	clock.Advance(50 * time.Minute)
+
+	err := <-done
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, <-causes, ErrBudgetExhausted)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 0, clock.Waiters(), "结束后应停止定时器")
+}
+
+// 测试协作取消模式下，每次尝试的上下文在尝试结束时取消，父上下文的取消同样传递给正在进行的尝试。
+func TestRetryWithContext_CooperativeAttemptContext(t *testing.T) {
+	var attemptCtx context.Context
+	err := RetryWithContext(context.Background(), func(ctx context.Context) error {
+		attemptCtx = ctx
+		return nil
+	}, WithCooperativeCancel(true))
+	assert.NoError(t, err)
+	assert.ErrorIs(t, attemptCtx.Err(), context.Canceled, "尝试结束后应取消上下文")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- RetryWithContext(ctx, func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		}, WithCooperativeCancel(true), WithMaxElapsed(time.Hour))
+	}()
+	<-started
+	cancel()
+	err = <-done
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, ErrBudgetExhausted)
+}
+
+// 测试 Copy 复制预算相关的参数。
+func TestBackoff_CopyBudget(t *testing.T) {
+	b := NewBackoff(WithMaxAttempts(3), WithMaxElapsed(time.Minute), WithCooperativeCancel(true))
+	c := b.Copy()
+	assert.Equal(t, 3, c.maxAttempts)
+	assert.Equal(t, time.Minute, c.maxElapsed)
+	assert.True(t, c.cooperative)
+
+	assert.Equal(t, time.Duration(0), NewBackoff(WithMaxElapsed(-time.Second)).maxElapsed)
+}
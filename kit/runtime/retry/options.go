@@ -6,6 +6,10 @@ package retry
 
 import (
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
 )
 
 // 以下为 Backoff 的默认参数配置。
@@ -19,6 +23,8 @@ var (
 	factorDefault = float64(2)
 	// jitterDefault 为 Backoff 是否启用抖动。
 	jitterDefault = false
+	// clockDefault 为 Backoff 等待重试时使用的时钟。
+	clockDefault = kittime.NewRealClock()
 )
 
 // BackoffOption 类型用于配置 Backoff 实例的参数。
@@ -72,3 +78,32 @@ func WithJitter(jitter bool) BackoffOption {
 		b.jitter = jitter
 	}
 }
+
+// WithClock 设置等待重试时使用的时钟。
+// 测试时可以注入 kit/time 的 FakeClock，无需真实等待即可验证重试过程。
+// 参数：
+//   - clock kittime.Clock：时钟，为 nil 时使用系统时钟。
+//
+// 返回值：
+//   - BackoffOption：用于设置 clock 字段的选项函数。
+func WithClock(clock kittime.Clock) BackoffOption {
+	return func(b *Backoff) {
+		b.clock = clock
+	}
+}
+
+// WithTracing 设置 Retry 与 RetryWithContext 在追踪中记录每次尝试。
+// tracer 为 nil 时，在上下文中当前的 Span 上为每次尝试记录一个 retry.attempt 事件；
+// 否则为每次尝试创建名为 retry.attempt 的子 Span，传给重试函数的上下文包含该 Span。
+// 事件与 Span 包含尝试次数（retry.attempt），失败时还包含错误（retry.error）与下一次重试前的等待时间（retry.delay_ms）。
+// 参数：
+//   - tracer trace.Tracer：创建子 Span 的 Tracer，为 nil 时只记录事件。
+//
+// 返回值：
+//   - BackoffOption：用于启用追踪的选项函数。
+func WithTracing(tracer trace.Tracer) BackoffOption {
+	return func(b *Backoff) {
+		b.tracing = true
+		b.tracer = tracer
+	}
+}
@@ -7,7 +7,7 @@ package retry
 
 import (
 	"context"
-	"time"
+	"fmt"
 )
 
 type (
@@ -60,31 +60,50 @@ func Retry(fn RetryableFunc, opts ...BackoffOption) error {
 //
 // 返回值：
 //   - error：如果所有重试均失败，则返回最后一次的错误；否则返回 nil。
+//     使用 WithAdaptive 且失败率过高时，返回同时包装 ErrSuspended 与最后一次错误的错误；
+//     使用 WithMaxAttempts 或 WithMaxElapsed 且预算用尽时，返回同时包装 ErrBudgetExhausted 与最后一次错误的错误。
 //
 // 当前实现仅为占位，实际重试逻辑需后续补充。
 func RetryWithContext(ctx context.Context, fn RetryableFuncWithContext, opts ...BackoffOption) error {
 	var err error
 
 	b := NewBackoff(opts...)
+	bg := b.newBudget(ctx)
+	defer bg.stop()
 
-	for {
+	for attempt := 1; ; attempt++ {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			err = fn(ctx)
+			attemptCtx, endAttempt := bg.attempt(ctx)
+			attemptCtx, endTrace := b.traceAttempt(attemptCtx, attempt)
+			err = fn(attemptCtx)
+			endAttempt()
 			if err == nil {
 				// 执行成功，返回 nil，退出重试。
+				b.adapt(nil, 0)
+				endTrace(nil, 0)
 				return nil
 			}
 
-			// 执行失败，等待下一次重试。
-			delay := b.Duration()
+			// 执行失败，等待下一次重试；失败率过高时暂停重试。
+			delay, suspended := b.adapt(err, b.Duration())
+			endTrace(err, delay)
+			if suspended {
+				return fmt.Errorf("%w：%w", ErrSuspended, err)
+			}
+			// 尝试次数用尽，或等待后重试会超出重试时间时，不再等待。
+			if bg.exhausted(attempt, delay) {
+				return fmt.Errorf("%w：%w", ErrBudgetExhausted, err)
+			}
+			timer := b.clock.NewTimer(delay)
 			select {
 			case <-ctx.Done():
-				// 上下文已取消，返回错误。
+				// 上下文已取消，停止定时器并返回错误。
+				timer.Stop()
 				return ctx.Err()
-			case <-time.After(delay):
+			case <-timer.C():
 				// 等待下一次重试。
 				continue
 			}
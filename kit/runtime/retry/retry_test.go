@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
 )
 
 // 测试 Retry 的基本功能，覆盖成功、失败、重试多次等场景。
@@ -245,6 +247,8 @@ func TestBackoffOptionsAndNewBackoff(t *testing.T) {
 	assert.Equal(t, 10*time.Second, b2.max, "默认 max 应为 10s")
 	assert.Equal(t, 2.0, b2.factor, "默认 factor 应为 2")
 	assert.Equal(t, false, b2.jitter, "默认 jitter 应为 false")
+	assert.Equal(t, kittime.NewRealClock(), b2.clock, "默认 clock 应为系统时钟")
+	assert.Equal(t, kittime.NewRealClock(), NewBackoff(WithClock(nil)).clock, "clock 为 nil 时应使用系统时钟")
 
 	// 测试极端参数分支
 	b3 := NewBackoff(WithMin(10*time.Second), WithMax(1*time.Second))
@@ -265,3 +269,40 @@ func TestBackoffOptionsAndNewBackoff(t *testing.T) {
 	assert.GreaterOrEqual(t, v, 100*time.Millisecond, "jitter 场景下返回值不应小于 min")
 	assert.LessOrEqual(t, v, 200*time.Millisecond, "jitter 场景下返回值不应大于理论最大")
 }
+
+// 测试 WithClock 注入的时钟控制重试等待，验证无需真实等待即可完成重试，且取消时停止定时器。
+func TestRetryWithContext_Clock(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Time{})
+	errFail := errors.New("fail")
+
+	count := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- RetryWithContext(context.Background(), func(ctx context.Context) error {
+			count++
+			if count < 3 {
+				return errFail
+			}
+			return nil
+		}, WithClock(clock), WithMin(time.Hour), WithMax(time.Hour))
+	}()
+
+	// 每次失败后等待一小时，推进时钟触发下一次重试。
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Hour)
+	}
+	assert.NoError(t, <-done)
+	assert.Equal(t, 3, count, "应在第 3 次调用时成功")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		done <- RetryWithContext(ctx, func(ctx context.Context) error {
+			return errFail
+		}, WithClock(clock), WithMin(time.Hour), WithMax(time.Hour))
+	}()
+	clock.BlockUntil(1)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, 0, clock.Waiters(), "取消后应停止定时器")
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package retry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 以下为追踪中使用的名称。
+const (
+	// spanNameAttempt 为每次尝试的事件与子 Span 的名称。
+	spanNameAttempt = "retry.attempt"
+	// attrAttempt 为尝试次数的属性名，从 1 开始。
+	attrAttempt = "retry.attempt"
+	// attrError 为尝试失败时错误信息的属性名。
+	attrError = "retry.error"
+	// attrDelay 为尝试失败后下一次重试前的等待时间的属性名，单位为毫秒。
+	attrDelay = "retry.delay_ms"
+)
+
+// traceAttempt 开始记录第 attempt 次尝试，返回传给重试函数的上下文与结束记录的函数。
+// 未启用追踪时直接返回 ctx；结束记录的函数在尝试成功时传入 nil 错误与 0 等待时间。
+func (b *Backoff) traceAttempt(ctx context.Context, attempt int) (context.Context, func(err error, delay time.Duration)) {
+	if !b.tracing {
+		return ctx, func(error, time.Duration) {}
+	}
+
+	if nil == b.tracer {
+		span := trace.SpanFromContext(ctx)
+		return ctx, func(err error, delay time.Duration) {
+			attrs := []attribute.KeyValue{attribute.Int(attrAttempt, attempt)}
+			if nil != err {
+				attrs = append(attrs, attribute.String(attrError, err.Error()), attribute.Int64(attrDelay, delay.Milliseconds()))
+			}
+			span.AddEvent(spanNameAttempt, trace.WithAttributes(attrs...))
+		}
+	}
+
+	ctx, span := b.tracer.Start(ctx, spanNameAttempt, trace.WithAttributes(attribute.Int(attrAttempt, attempt)))
+	return ctx, func(err error, delay time.Duration) {
+		if nil != err {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			span.SetAttributes(attribute.String(attrError, err.Error()), attribute.Int64(attrDelay, delay.Milliseconds()))
+		}
+		span.End()
+	}
+}
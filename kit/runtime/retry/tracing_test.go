@@ -0,0 +1,141 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// attrsOf 将属性列表转换为以属性名为键的映射。
+func attrsOf(kvs []attribute.KeyValue) map[string]interface{} {
+	m := make(map[string]interface{}, len(kvs))
+	for _, kv := range kvs {
+		m[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	return m
+}
+
+// failTimes 返回前 n 次调用失败、之后成功的重试函数，并记录每次调用收到的上下文中的 Span。
+func failTimes(n int, spans *[]trace.SpanContext) RetryableFuncWithContext {
+	calls := 0
+	return func(ctx context.Context) error {
+		*spans = append(*spans, trace.SpanContextFromContext(ctx))
+		calls++
+		if calls <= n {
+			return errors.New("unavailable")
+		}
+		return nil
+	}
+}
+
+// TestWithTracing_Events 测试未指定 Tracer 时在当前 Span 上为每次尝试记录事件。
+func TestWithTracing_Events(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+
+	var received []trace.SpanContext
+	err := RetryWithContext(ctx, failTimes(2, &received), WithTracing(nil), WithMin(time.Millisecond), WithMax(2*time.Millisecond))
+	require.NoError(t, err)
+	parent.End()
+
+	// 重试函数收到的上下文仍然是父 Span。
+	require.Len(t, received, 3)
+	for _, sc := range received {
+		assert.Equal(t, parent.SpanContext().SpanID(), sc.SpanID())
+	}
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	events := ended[0].Events()
+	require.Len(t, events, 3)
+	for i, event := range events {
+		assert.Equal(t, "retry.attempt", event.Name)
+		attrs := attrsOf(event.Attributes)
+		assert.Equal(t, int64(i+1), attrs[attrAttempt])
+		if i < 2 {
+			assert.Equal(t, "unavailable", attrs[attrError])
+			assert.Contains(t, attrs, attrDelay)
+		} else {
+			assert.NotContains(t, attrs, attrError)
+		}
+	}
+	assert.Equal(t, int64(1), attrsOf(events[0].Attributes)[attrDelay])
+	assert.Equal(t, int64(2), attrsOf(events[1].Attributes)[attrDelay])
+}
+
+// TestWithTracing_Spans 测试指定 Tracer 时为每次尝试创建子 Span。
+func TestWithTracing_Spans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := provider.Tracer("test")
+	ctx, parent := tracer.Start(context.Background(), "parent")
+
+	var received []trace.SpanContext
+	err := Retry(func() error {
+		return nil
+	}, WithTracing(tracer))
+	require.NoError(t, err)
+
+	err = RetryWithContext(ctx, failTimes(1, &received), WithTracing(tracer), WithMin(time.Millisecond))
+	require.NoError(t, err)
+	parent.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 4)
+
+	// Retry 没有父 Span，尝试的 Span 为根 Span。
+	assert.Equal(t, "retry.attempt", ended[0].Name())
+	assert.False(t, ended[0].Parent().IsValid())
+
+	failed, succeeded := ended[1], ended[2]
+	for i, span := range []sdktrace.ReadOnlySpan{failed, succeeded} {
+		assert.Equal(t, "retry.attempt", span.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Equal(t, int64(i+1), attrsOf(span.Attributes())[attrAttempt])
+		// 重试函数收到的上下文包含该次尝试的 Span。
+		assert.Equal(t, span.SpanContext().SpanID(), received[i].SpanID())
+	}
+
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	assert.Equal(t, "unavailable", attrsOf(failed.Attributes())[attrError])
+	assert.Equal(t, int64(1), attrsOf(failed.Attributes())[attrDelay])
+	require.Len(t, failed.Events(), 1)
+	assert.Equal(t, "exception", failed.Events()[0].Name)
+
+	assert.Equal(t, codes.Unset, succeeded.Status().Code)
+	assert.NotContains(t, attrsOf(succeeded.Attributes()), attrError)
+}
+
+// TestWithTracing_Disabled 测试未启用追踪时不记录事件。
+func TestWithTracing_Disabled(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+
+	var received []trace.SpanContext
+	require.NoError(t, RetryWithContext(ctx, failTimes(1, &received), WithMin(time.Millisecond)))
+	parent.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	assert.Empty(t, ended[0].Events())
+
+	// Copy 保留追踪的配置。
+	b := NewBackoff(WithTracing(provider.Tracer("test"))).Copy()
+	assert.True(t, b.tracing)
+	assert.NotNil(t, b.tracer)
+}
@@ -0,0 +1,16 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build !unix
+
+package watchdog
+
+import (
+	"time"
+)
+
+// processCPUTime 在不支持读取进程 CPU 时间的平台上始终返回 false。
+func processCPUTime() (time.Duration, bool) {
+	return 0, false
+}
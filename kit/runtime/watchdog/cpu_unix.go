@@ -0,0 +1,21 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build unix
+
+package watchdog
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime 返回进程累计占用的用户态与内核态 CPU 时间。
+func processCPUTime() (time.Duration, bool) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); nil != err {
+		return 0, false
+	}
+	return time.Duration(ru.Utime.Nano() + ru.Stime.Nano()), true
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package watchdog 提供了资源看门狗，将悄然发生的性能退化转化为可供分析的现场。
+
+主要特性：
+
+  - 定期检查堆内存、协程数量与 CPU 使用率
+  - 超过阈值时记录日志，通过 kit/profiling 采集协程堆栈与堆内存 profile
+  - 支持触发时调用回调函数，例如发送告警
+  - 同一资源在冷却时间内不重复触发
+  - 实现 kit/runtime 的 Runner 接口
+
+基本使用：
+
+	p := profiling.New(profiling.WithProfiles(profiling.ProfileHeap, profiling.ProfileGoroutine))
+	w := watchdog.New(
+	    watchdog.WithHeapThreshold(2<<30),
+	    watchdog.WithGoroutineThreshold(10000),
+	    watchdog.WithCPUThreshold(0.9),
+	    watchdog.WithCapturer(p),
+	    watchdog.WithCallback(func(ctx context.Context, e watchdog.Event) {
+	        alert.Send(ctx, fmt.Sprintf("%s 超过阈值：%v", e.Resource, e.Value))
+	    }),
+	)
+	if err := w.Start(ctx); nil != err {
+	    panic(err)
+	}
+	defer w.Stop(context.Background())
+
+更多信息请参考 README.md。
+*/
+package watchdog
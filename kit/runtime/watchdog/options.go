@@ -0,0 +1,219 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package watchdog
+
+import (
+	"time"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 以下为资源看门狗的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// intervalDefault 为默认的检查间隔。
+	intervalDefault = 10 * time.Second
+	// cooldownDefault 为同一资源两次触发之间的默认最短间隔。
+	cooldownDefault = 5 * time.Minute
+	// clockDefault 为默认使用的时钟。
+	clockDefault = kittime.NewRealClock()
+)
+
+type (
+	// Option 定义了资源看门狗的配置选项。
+	Option func(*options)
+
+	// options 包含资源看门狗的配置。
+	options struct {
+		// interval 是检查资源使用情况的间隔。
+		interval time.Duration
+		// cooldown 是同一资源两次触发之间的最短间隔。
+		cooldown time.Duration
+		// heapBytes 是堆内存的阈值，为 0 时不检查。
+		heapBytes uint64
+		// goroutines 是协程数量的阈值，为 0 时不检查。
+		goroutines int
+		// cpu 是 CPU 使用率的阈值，为 0 时不检查。
+		cpu float64
+		// capturer 是触发时采集诊断数据的采集器，为 nil 时不采集。
+		capturer Capturer
+		// callbacks 是触发时调用的回调函数。
+		callbacks []Callback
+		// clock 是计时使用的时钟。
+		clock kittime.Clock
+		// logger 是记录触发事件的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+		// sample 读取当前的资源使用情况，测试时可以替换。
+		sample func() Usage
+	}
+)
+
+// WithInterval 设置检查资源使用情况的间隔。
+//
+// 参数：
+//   - interval：检查间隔，默认为 10 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.interval = interval
+	}
+}
+
+// WithCooldown 设置同一资源两次触发之间的最短间隔，避免资源持续超过阈值时反复采集。
+//
+// 参数：
+//   - cooldown：最短间隔，默认为 5 分钟，小于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCooldown(cooldown time.Duration) Option {
+	return func(o *options) {
+		o.cooldown = cooldown
+	}
+}
+
+// WithHeapThreshold 设置堆内存的阈值，堆上对象占用的内存超过阈值时触发。
+//
+// 参数：
+//   - bytes：阈值，单位为字节，默认为 0，即不检查。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithHeapThreshold(bytes uint64) Option {
+	return func(o *options) {
+		o.heapBytes = bytes
+	}
+}
+
+// WithGoroutineThreshold 设置协程数量的阈值，协程数量超过阈值时触发，可用于发现协程泄漏。
+//
+// 参数：
+//   - n：阈值，默认为 0，即不检查，小于 0 时视为 0。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithGoroutineThreshold(n int) Option {
+	return func(o *options) {
+		o.goroutines = n
+	}
+}
+
+// WithCPUThreshold 设置 CPU 使用率的阈值，两次检查之间进程的 CPU 使用率超过阈值时触发。
+// 使用率为进程占用的 CPU 时间与 GOMAXPROCS 个 CPU 可用时间之比，不支持读取进程 CPU 时间的平台上不检查。
+//
+// 参数：
+//   - ratio：阈值，取值范围为 (0, 1]，例如 0.9 表示 90%，默认为 0，即不检查，超出范围时视为 0。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCPUThreshold(ratio float64) Option {
+	return func(o *options) {
+		o.cpu = ratio
+	}
+}
+
+// WithCapturer 设置触发时采集诊断数据的采集器，通常为 kit/profiling 的 Runner。
+// 采集在检查协程中同步进行，采集期间不检查资源使用情况。
+//
+// 参数：
+//   - c：采集器，默认为 nil，即不采集。
+//
+// 返回值：
+//   - Option：配置选项函数。
+//
+// 示例：
+//
+//	p := profiling.New(profiling.WithProfiles(profiling.ProfileHeap, profiling.ProfileGoroutine))
+//	w := watchdog.New(watchdog.WithCapturer(p), watchdog.WithHeapThreshold(2<<30))
+func WithCapturer(c Capturer) Option {
+	return func(o *options) {
+		o.capturer = c
+	}
+}
+
+// WithCallback 添加触发时调用的回调函数，可以多次使用添加多个回调函数，按添加的顺序在采集之后调用。
+//
+// 参数：
+//   - fn：回调函数，为 nil 时忽略。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCallback(fn Callback) Option {
+	return func(o *options) {
+		if nil != fn {
+			o.callbacks = append(o.callbacks, fn)
+		}
+	}
+}
+
+// WithClock 设置计时使用的时钟。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithLogger 设置记录触发事件的日志实例。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		interval: intervalDefault,
+		cooldown: cooldownDefault,
+		clock:    clockDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.interval <= 0 {
+		o.interval = intervalDefault
+	}
+	if o.cooldown < 0 {
+		o.cooldown = cooldownDefault
+	}
+	if o.goroutines < 0 {
+		o.goroutines = 0
+	}
+	if o.cpu <= 0 || o.cpu > 1 {
+		o.cpu = 0
+	}
+	if nil == o.clock {
+		o.clock = clockDefault
+	}
+	if nil == o.sample {
+		o.sample = newSampler().sample
+	}
+
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package watchdog
+
+import (
+	"runtime"
+	"runtime/metrics"
+	"time"
+)
+
+const (
+	// heapObjectsMetric 是堆上对象占用字节数的运行时指标，与 kit/profiling 的 HeapAbove 一致。
+	heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+)
+
+type (
+	// Usage 是一次检查时读取的资源使用情况。
+	Usage struct {
+		// HeapBytes 是堆上对象占用的字节数。
+		HeapBytes uint64
+		// Goroutines 是协程数量。
+		Goroutines int
+		// CPU 是自上次检查以来进程的 CPU 使用率，为占用的 CPU 时间与 GOMAXPROCS 个 CPU 可用时间之比。
+		// 首次检查或平台不支持时为 0。
+		CPU float64
+	}
+
+	// sampler 读取资源使用情况，并记录上次读取的 CPU 时间用于计算 CPU 使用率。
+	// 只在检查协程中使用，不需要加锁。
+	sampler struct {
+		// cpuTime 是上次读取的进程 CPU 时间。
+		cpuTime time.Duration
+		// at 是上次读取 CPU 时间的时刻，为零值时表示尚未读取。
+		at time.Time
+	}
+)
+
+// newSampler 创建读取资源使用情况的 sampler。
+func newSampler() *sampler {
+	return &sampler{}
+}
+
+// sample 读取当前的资源使用情况。
+// CPU 使用率需要与进程 CPU 时间对应的真实时间计算，因此使用 time.Now 而不是可注入的时钟。
+func (s *sampler) sample() Usage {
+	// runtime/metrics 不会暂停程序，比 runtime.ReadMemStats 开销更小。
+	samples := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(samples)
+
+	u := Usage{Goroutines: runtime.NumGoroutine()}
+	if metrics.KindUint64 == samples[0].Value.Kind() {
+		u.HeapBytes = samples[0].Value.Uint64()
+	}
+
+	cpuTime, ok := processCPUTime()
+	if !ok {
+		return u
+	}
+	now := time.Now()
+	if !s.at.IsZero() {
+		if wall := now.Sub(s.at); wall > 0 {
+			u.CPU = float64(cpuTime-s.cpuTime) / (float64(wall) * float64(runtime.GOMAXPROCS(0)))
+		}
+	}
+	s.cpuTime, s.at = cpuTime, now
+	return u
+}
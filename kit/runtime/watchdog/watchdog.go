@@ -0,0 +1,233 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// ResourceHeap 表示堆内存，值为堆上对象占用的字节数。
+	ResourceHeap Resource = "heap"
+	// ResourceGoroutines 表示协程数量。
+	ResourceGoroutines Resource = "goroutines"
+	// ResourceCPU 表示 CPU 使用率。
+	ResourceCPU Resource = "cpu"
+)
+
+const (
+	// reasonPrefix 是采集原因的前缀，采集原因为前缀加资源名称，例如 watchdog_heap。
+	reasonPrefix = "watchdog_"
+)
+
+var (
+	// ErrRunning 表示看门狗已经启动。
+	ErrRunning = errors.New("kit/runtime/watchdog: 已经启动")
+)
+
+type (
+	// Resource 是看门狗监控的资源。
+	Resource string
+
+	// Capturer 在资源超过阈值时采集诊断数据，kit/profiling 的 *Runner 实现了该接口。
+	Capturer interface {
+		// Capture 立即采集诊断数据。
+		//
+		// 参数：
+		//   - ctx：采集的上下文。
+		//   - reason：采集原因，为 "watchdog_" 加资源名称，例如 watchdog_heap。
+		//
+		// 返回值：
+		//   - error：采集失败的错误，会被记录到日志。
+		Capture(ctx context.Context, reason string) error
+	}
+
+	// Event 是一次资源超过阈值的触发事件。
+	Event struct {
+		// Resource 是超过阈值的资源。
+		Resource Resource
+		// Value 是资源的当前值。
+		Value float64
+		// Threshold 是资源的阈值。
+		Threshold float64
+		// Usage 是触发时的全部资源使用情况。
+		Usage Usage
+		// Time 是触发的时间。
+		Time time.Time
+		// CaptureErr 是采集诊断数据的错误，未设置采集器或采集成功时为 nil。
+		CaptureErr error
+	}
+
+	// Callback 是资源超过阈值时调用的回调函数，在检查协程中同步调用，应当快速返回。
+	Callback func(ctx context.Context, e Event)
+
+	// Watchdog 定期检查堆内存、协程数量与 CPU 使用率，超过阈值时记录日志、通过 Capturer 采集诊断数据并调用回调函数，
+	// 使悄然发生的性能退化留下可供分析的现场。
+	// Watchdog 实现了 kit/runtime 的 Runner 接口：Start 启动后台检查后立即返回，Stop 停止检查并等待正在进行的采集结束。
+	// 所有方法都是并发安全的。
+	Watchdog struct {
+		// o 是看门狗的配置。
+		o *options
+
+		// mu 保护以下字段。
+		mu sync.Mutex
+		// cancel 停止后台检查，未启动时为 nil。
+		cancel context.CancelFunc
+		// done 在后台检查协程退出时关闭。
+		done chan struct{}
+	}
+)
+
+// New 创建资源看门狗。未设置任何阈值时看门狗启动后不会触发。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Watchdog：看门狗实例，需要调用 Start 启动。
+//
+// 示例：
+//
+//	p := profiling.New(profiling.WithProfiles(profiling.ProfileHeap, profiling.ProfileGoroutine))
+//	w := watchdog.New(
+//	    watchdog.WithHeapThreshold(2<<30),
+//	    watchdog.WithGoroutineThreshold(10000),
+//	    watchdog.WithCPUThreshold(0.9),
+//	    watchdog.WithCapturer(p),
+//	)
+//	if err := w.Start(ctx); nil != err {
+//	    return err
+//	}
+//	defer w.Stop(context.Background())
+func New(opts ...Option) *Watchdog {
+	return &Watchdog{o: newOptions(opts...)}
+}
+
+// Start 启动后台检查后立即返回，ctx 结束时后台检查停止。
+//
+// 参数：
+//   - ctx：后台检查的生命周期。
+//
+// 返回值：
+//   - error：已经启动时返回 ErrRunning。
+func (w *Watchdog) Start(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if nil != w.cancel {
+		return ErrRunning
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+	w.done = make(chan struct{})
+	go w.run(ctx, w.done)
+	return nil
+}
+
+// Stop 停止后台检查，并等待正在进行的采集与回调结束。未启动时不执行任何操作。
+//
+// 参数：
+//   - ctx：停止操作的截止时间。
+//
+// 返回值：
+//   - error：截止时间到达时返回 ctx 的错误。
+func (w *Watchdog) Stop(ctx context.Context) error {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+
+	if nil == cancel {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 是后台检查协程，定期检查资源使用情况。
+func (w *Watchdog) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := w.o.clock.NewTicker(w.o.interval)
+	defer ticker.Stop()
+
+	// 首次读取作为 CPU 使用率的基准。
+	w.o.sample()
+
+	// fired 记录每种资源最近一次触发的时间。
+	fired := make(map[Resource]time.Time)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			w.check(ctx, fired)
+		}
+	}
+}
+
+// check 读取资源使用情况，对超过阈值且不在冷却时间内的资源触发。
+func (w *Watchdog) check(ctx context.Context, fired map[Resource]time.Time) {
+	u := w.o.sample()
+	for _, e := range w.exceeded(u) {
+		now := w.o.clock.Now()
+		if last, ok := fired[e.Resource]; ok && now.Sub(last) < w.o.cooldown {
+			continue
+		}
+		fired[e.Resource] = now
+		e.Time = now
+		w.trigger(ctx, e)
+	}
+}
+
+// exceeded 返回超过阈值的资源对应的事件，按堆内存、协程数量、CPU 使用率的顺序排列。
+func (w *Watchdog) exceeded(u Usage) []Event {
+	var events []Event
+	if 0 < w.o.heapBytes && u.HeapBytes > w.o.heapBytes {
+		events = append(events, Event{Resource: ResourceHeap, Value: float64(u.HeapBytes), Threshold: float64(w.o.heapBytes), Usage: u})
+	}
+	if 0 < w.o.goroutines && u.Goroutines > w.o.goroutines {
+		events = append(events, Event{Resource: ResourceGoroutines, Value: float64(u.Goroutines), Threshold: float64(w.o.goroutines), Usage: u})
+	}
+	if 0 < w.o.cpu && u.CPU > w.o.cpu {
+		events = append(events, Event{Resource: ResourceCPU, Value: u.CPU, Threshold: w.o.cpu, Usage: u})
+	}
+	return events
+}
+
+// trigger 记录日志、采集诊断数据并调用回调函数。
+func (w *Watchdog) trigger(ctx context.Context, e Event) {
+	logger := w.o.getLogger().WithFields(map[string]interface{}{
+		"resource":   string(e.Resource),
+		"value":      e.Value,
+		"threshold":  e.Threshold,
+		"heap_bytes": e.Usage.HeapBytes,
+		"goroutines": e.Usage.Goroutines,
+		"cpu":        e.Usage.CPU,
+	})
+	logger.Warn(fmt.Sprintf("kit/runtime/watchdog: %s 超过阈值", e.Resource))
+
+	if nil != w.o.capturer {
+		if err := w.o.capturer.Capture(ctx, reasonPrefix+string(e.Resource)); nil != err {
+			e.CaptureErr = err
+			logger.Error(fmt.Sprintf("kit/runtime/watchdog: 采集诊断数据失败：%v", err))
+		}
+	}
+
+	for _, fn := range w.o.callbacks {
+		fn(ctx, e)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+type (
+	// recordCapturer 记录采集原因的 Capturer，fail 中的原因返回错误。
+	recordCapturer struct {
+		mu      sync.Mutex
+		reasons []string
+		fail    string
+	}
+)
+
+func (c *recordCapturer) Capture(_ context.Context, reason string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reasons = append(c.reasons, reason)
+	if c.fail == reason {
+		return errors.New("disk full")
+	}
+	return nil
+}
+
+// Reasons 返回记录的全部采集原因。
+func (c *recordCapturer) Reasons() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.reasons...)
+}
+
+// TestWatchdog 测试资源超过阈值时记录日志、采集诊断数据并调用回调函数，冷却时间内不重复触发。
+func TestWatchdog(t *testing.T) {
+	clock := kittime.NewFakeClock(time.Now())
+	logger := logtest.NewRecorder()
+	capturer := &recordCapturer{fail: "watchdog_cpu"}
+	events := make(chan Event, 10)
+	w := New(
+		WithInterval(time.Second),
+		WithCooldown(2*time.Second),
+		WithHeapThreshold(100),
+		WithGoroutineThreshold(10),
+		WithCPUThreshold(0.9),
+		WithCapturer(capturer),
+		WithCallback(func(_ context.Context, e Event) { events <- e }),
+		WithCallback(nil),
+		WithClock(clock),
+		WithLogger(logger),
+	)
+	usages := make(chan Usage)
+	w.o.sample = func() Usage { return <-usages }
+
+	require.NoError(t, w.Start(context.Background()))
+	assert.ErrorIs(t, w.Start(context.Background()), ErrRunning)
+	// 首次读取作为基准，不触发。
+	usages <- Usage{HeapBytes: 1000, Goroutines: 1000, CPU: 1}
+	clock.BlockUntil(1)
+
+	// 每次读取之前，上一次检查已经结束。
+	check := func(u Usage) {
+		clock.Advance(time.Second)
+		usages <- u
+	}
+
+	high := Usage{HeapBytes: 200, Goroutines: 5, CPU: 0.95}
+	check(high)
+	e := <-events
+	assert.Equal(t, ResourceHeap, e.Resource)
+	assert.Equal(t, float64(200), e.Value)
+	assert.Equal(t, float64(100), e.Threshold)
+	assert.Equal(t, high, e.Usage)
+	assert.Equal(t, clock.Now(), e.Time)
+	assert.NoError(t, e.CaptureErr)
+	e = <-events
+	assert.Equal(t, ResourceCPU, e.Resource)
+	assert.Equal(t, 0.95, e.Value)
+	assert.EqualError(t, e.CaptureErr, "disk full")
+
+	// 冷却时间内不重复触发，其他资源不受影响。
+	check(Usage{HeapBytes: 200, Goroutines: 11})
+	e = <-events
+	assert.Equal(t, ResourceGoroutines, e.Resource)
+
+	check(high)
+	assert.Equal(t, ResourceHeap, (<-events).Resource)
+	assert.Equal(t, ResourceCPU, (<-events).Resource)
+
+	require.NoError(t, w.Stop(context.Background()))
+	assert.Empty(t, events)
+	assert.Equal(t, []string{"watchdog_heap", "watchdog_cpu", "watchdog_goroutines", "watchdog_heap", "watchdog_cpu"}, capturer.Reasons())
+
+	messages := logger.Messages()
+	require.Len(t, messages, 7)
+	assert.Equal(t, "warn kit/runtime/watchdog: heap 超过阈值", messages[0])
+	assert.Equal(t, "warn kit/runtime/watchdog: cpu 超过阈值", messages[1])
+	assert.Equal(t, "error kit/runtime/watchdog: 采集诊断数据失败：disk full", messages[2])
+	assert.Equal(t, "warn kit/runtime/watchdog: goroutines 超过阈值", messages[3])
+	entries := logger.Entries()
+	for i, resource := range []Resource{ResourceHeap, ResourceCPU, ResourceCPU, ResourceGoroutines} {
+		assert.Equal(t, string(resource), entries[i].Fields["resource"])
+	}
+}
+
+// TestWatchdog_NoThreshold 测试未设置阈值时不触发。
+func TestWatchdog_NoThreshold(t *testing.T) {
+	w := New(WithCallback(func(context.Context, Event) {
+		t.Error("unexpected event")
+	}))
+	assert.Empty(t, w.exceeded(Usage{HeapBytes: 1 << 40, Goroutines: 1 << 20, CPU: 1}))
+}
+
+// TestWatchdog_Stop 测试未启动时的停止，以及回调阻塞时停止的截止时间。
+func TestWatchdog_Stop(t *testing.T) {
+	assert.NoError(t, New().Stop(context.Background()))
+
+	clock := kittime.NewFakeClock(time.Now())
+	release := make(chan struct{})
+	called := make(chan struct{})
+	w := New(
+		WithGoroutineThreshold(1),
+		WithClock(clock),
+		WithCallback(func(context.Context, Event) {
+			close(called)
+			<-release
+		}),
+	)
+	w.o.sample = func() Usage { return Usage{Goroutines: 2} }
+	require.NoError(t, w.Start(context.Background()))
+	clock.BlockUntil(1)
+	clock.Advance(intervalDefault)
+	<-called
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, w.Stop(ctx), context.DeadlineExceeded)
+	close(release)
+
+	// 停止后可以重新启动。
+	require.NoError(t, w.Start(context.Background()))
+	require.NoError(t, w.Stop(context.Background()))
+}
+
+// TestNewOptions 测试非法的参数使用默认值。
+func TestNewOptions(t *testing.T) {
+	o := newOptions(
+		WithInterval(0),
+		WithCooldown(-1),
+		WithGoroutineThreshold(-1),
+		WithCPUThreshold(1.5),
+		WithClock(nil),
+	)
+	assert.Equal(t, intervalDefault, o.interval)
+	assert.Equal(t, cooldownDefault, o.cooldown)
+	assert.Equal(t, 0, o.goroutines)
+	assert.Equal(t, float64(0), o.cpu)
+	assert.Equal(t, clockDefault, o.clock)
+	assert.Equal(t, kitlog.GetLogger(), o.getLogger())
+}
+
+// TestSampler 测试读取资源使用情况，首次读取时 CPU 使用率为 0。
+func TestSampler(t *testing.T) {
+	s := newSampler()
+	u := s.sample()
+	assert.Positive(t, u.HeapBytes)
+	assert.Positive(t, u.Goroutines)
+	assert.Zero(t, u.CPU)
+
+	// 消耗一些 CPU 时间。
+	deadline := time.Now().Add(20 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		_ = strings.Repeat("x", 1024)
+	}
+	u = s.sample()
+	if _, ok := processCPUTime(); ok {
+		assert.Positive(t, u.CPU)
+	}
+	assert.LessOrEqual(t, u.CPU, 1.5)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package signal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	stdsignal "os/signal"
+	stdsync "sync"
+)
+
+type (
+	// ReceivedError 是 NotifyContext 返回的上下文因收到退出信号而取消时的原因。
+	ReceivedError struct {
+		// Signal 是收到的退出信号。
+		Signal os.Signal
+	}
+)
+
+// Error 返回错误信息。
+func (e *ReceivedError) Error() string {
+	return fmt.Sprintf("kit/signal: 收到信号 %s", e.Signal)
+}
+
+// NotifyContext 返回一个在收到退出信号时取消的上下文。
+// 与标准库的 signal.NotifyContext 不同，收到第一个退出信号后继续监听，
+// 再次收到退出信号时执行强制退出函数，避免优雅退出卡住时进程无法结束。
+//
+// 参数：
+//   - parent：父上下文。
+//   - opts：配置选项，支持 WithSignals、WithForceExit 与 WithLogger。
+//
+// 返回值：
+//   - context.Context：收到退出信号时取消的上下文，取消原因为 *ReceivedError。
+//   - context.CancelFunc：停止监听信号并取消上下文，应在不再需要时调用。
+//
+// 示例：
+//
+//	ctx, stop := signal.NotifyContext(context.Background())
+//	defer stop()
+//
+//	<-ctx.Done()
+//	// 执行优雅退出，期间再次按下 Ctrl+C 会强制退出。
+func NotifyContext(parent context.Context, opts ...Option) (context.Context, context.CancelFunc) {
+	o := newOptions(opts...)
+	ctx, cancel := context.WithCancelCause(parent)
+
+	// 缓冲两个信号，保证第二个信号在处理第一个信号期间到达时不会丢失。
+	ch := make(chan os.Signal, 2)
+	stdsignal.Notify(ch, o.signals...)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-ch:
+			o.getLogger().WithField("signal", sig.String()).Info("received signal, shutting down")
+			cancel(&ReceivedError{Signal: sig})
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case sig := <-ch:
+			o.getLogger().WithField("signal", sig.String()).Warn("received second signal, forcing exit")
+			o.forceExit(sig)
+		case <-done:
+		}
+	}()
+
+	var once stdsync.Once
+	stop := func() {
+		once.Do(func() {
+			stdsignal.Stop(ch)
+			close(done)
+			cancel(nil)
+		})
+	}
+	return ctx, stop
+}
+
+// Received 返回上下文是否因收到退出信号而取消，以及收到的信号。
+//
+// 参数：
+//   - ctx：NotifyContext 返回的上下文或其派生的上下文。
+//
+// 返回值：
+//   - os.Signal：收到的退出信号。
+//   - bool：上下文因收到退出信号而取消时返回 true。
+func Received(ctx context.Context) (os.Signal, bool) {
+	var received *ReceivedError
+	if errors.As(context.Cause(ctx), &received) {
+		return received.Signal, true
+	}
+	return nil, false
+}
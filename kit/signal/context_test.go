@@ -0,0 +1,84 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build !windows
+
+package signal
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// raise 向当前进程发送信号。
+func raise(t *testing.T, sig syscall.Signal) {
+	t.Helper()
+	require.NoError(t, syscall.Kill(os.Getpid(), sig))
+}
+
+// TestNotifyContext 测试第一个退出信号取消上下文，第二个退出信号执行强制退出函数。
+func TestNotifyContext(t *testing.T) {
+	exited := make(chan os.Signal, 1)
+	ctx, stop := NotifyContext(context.Background(),
+		WithSignals(syscall.SIGUSR2),
+		WithForceExit(func(sig os.Signal) { exited <- sig }),
+	)
+	defer stop()
+
+	raise(t, syscall.SIGUSR2)
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("上下文未取消")
+	}
+	sig, ok := Received(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, syscall.SIGUSR2, sig)
+	assert.EqualError(t, context.Cause(ctx), "kit/signal: 收到信号 user defined signal 2")
+
+	raise(t, syscall.SIGUSR2)
+	select {
+	case sig = <-exited:
+		assert.Equal(t, syscall.SIGUSR2, sig)
+	case <-time.After(5 * time.Second):
+		t.Fatal("未执行强制退出函数")
+	}
+}
+
+// TestNotifyContext_Stop 测试调用 stop 取消上下文，且不视为收到信号。
+func TestNotifyContext_Stop(t *testing.T) {
+	ctx, stop := NotifyContext(context.Background(), WithSignals(syscall.SIGUSR2))
+	stop()
+	stop()
+
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	_, ok := Received(ctx)
+	assert.False(t, ok)
+
+	// 父上下文取消时同样取消。
+	parent, cancel := context.WithCancel(context.Background())
+	ctx, stop = NotifyContext(parent)
+	defer stop()
+	cancel()
+	<-ctx.Done()
+	_, ok = Received(ctx)
+	assert.False(t, ok)
+	_, ok = Received(context.Background())
+	assert.False(t, ok)
+}
+
+// TestNewOptions 测试非法的参数使用默认值。
+func TestNewOptions(t *testing.T) {
+	o := newOptions(WithSignals(), WithForceExit(nil), WithLogger(nil))
+	assert.Equal(t, signalsDefault, o.signals)
+	assert.NotNil(t, o.forceExit)
+	assert.NotNil(t, o.getLogger())
+}
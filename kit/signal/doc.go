@@ -0,0 +1,40 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package signal 提供了进程信号的处理工具，替代散落在各个服务中的 signal.Notify 代码。
+
+主要功能：
+
+  - 退出信号：NotifyContext 返回在收到退出信号时取消的上下文，再次收到退出信号时强制退出
+  - 信号回调：Handler 将重新加载配置、输出诊断信息等非退出信号分发给注册的回调函数
+  - 约定信号：SignalReload（SIGHUP）重新加载配置，SignalDump（SIGUSR1）输出所有协程的堆栈
+  - 生命周期：Handler 实现了 kit/runtime 的 Runner 接口
+
+基本使用：
+
+	ctx, stop := signal.NotifyContext(context.Background())
+	defer stop()
+
+	h := signal.NewHandler()
+	h.OnReload(func(ctx context.Context) error {
+	    return cfg.Reload(ctx)
+	})
+	h.OnDump(os.Stderr)
+	if err := h.Start(ctx); nil != err {
+	    return err
+	}
+	defer h.Stop(context.Background())
+
+	<-ctx.Done()
+
+由于包名与 os/signal 相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "os/signal"
+
+	    kitsignal "github.com/fsyyft-go/monorepo/kit/signal"
+	)
+*/
+package signal
@@ -0,0 +1,187 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package signal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	stdsignal "os/signal"
+	stdsync "sync"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+var (
+	// ErrStarted 表示 Handler 已经启动。
+	ErrStarted = errors.New("kit/signal: 信号处理已启动")
+)
+
+type (
+	// HandlerFunc 是处理信号的回调函数。
+	// 参数 ctx 在 Handler 停止时取消，参数 sig 是收到的信号。
+	HandlerFunc func(ctx context.Context, sig os.Signal)
+
+	// Handler 将信号分发给注册的回调函数，用于集中处理重新加载配置、输出诊断信息等非退出信号。
+	// 回调函数在同一个协程中按收到信号的顺序依次执行。
+	// Handler 实现了 kit/runtime 的 Runner 接口。
+	Handler struct {
+		// o 是信号处理的配置。
+		o *options
+		// mu 保护以下字段。
+		mu stdsync.Mutex
+		// handlers 是各个信号注册的回调函数。
+		handlers map[os.Signal][]HandlerFunc
+		// ch 是接收信号的通道，启动后不为 nil。
+		ch chan os.Signal
+		// cancel 取消传递给回调函数的上下文。
+		cancel context.CancelFunc
+		// done 在分发协程退出时关闭。
+		done chan struct{}
+	}
+)
+
+// NewHandler 创建一个信号处理器。
+//
+// 参数：
+//   - opts：配置选项，支持 WithLogger。
+//
+// 返回值：
+//   - *Handler：信号处理器，需要调用 Start 后才开始接收信号。
+func NewHandler(opts ...Option) *Handler {
+	return &Handler{
+		o:        newOptions(opts...),
+		handlers: make(map[os.Signal][]HandlerFunc),
+	}
+}
+
+// Handle 为信号注册回调函数，同一信号的多个回调函数按注册顺序执行。
+// 启动之后注册的信号立即开始接收。sig 为 nil 时忽略，便于处理当前平台不支持的信号。
+//
+// 参数：
+//   - sig：要处理的信号。
+//   - fn：回调函数。
+func (h *Handler) Handle(sig os.Signal, fn HandlerFunc) {
+	if nil == sig || nil == fn {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[sig] = append(h.handlers[sig], fn)
+	if nil != h.ch {
+		stdsignal.Notify(h.ch, sig)
+	}
+}
+
+// OnReload 在收到 SignalReload 信号时调用 fn 重新加载配置，并记录重新加载的结果。
+//
+// 参数：
+//   - fn：重新加载配置的函数。
+func (h *Handler) OnReload(fn func(ctx context.Context) error) {
+	h.Handle(SignalReload, func(ctx context.Context, sig os.Signal) {
+		logger := h.o.getLogger().WithField("signal", sig.String())
+		if err := fn(ctx); nil != err {
+			logger.WithField("error", err.Error()).Error("reload failed")
+			return
+		}
+		logger.Info("reloaded")
+	})
+}
+
+// OnDump 在收到 SignalDump 信号时将所有协程的堆栈写入 w，当前平台不支持该信号时不做任何处理。
+//
+// 参数：
+//   - w：写入目标，为 nil 时写入 os.Stderr。
+func (h *Handler) OnDump(w io.Writer) {
+	if nil == w {
+		w = os.Stderr
+	}
+	h.Handle(SignalDump, func(_ context.Context, sig os.Signal) {
+		if err := goroutine.Dump(w); nil != err {
+			h.o.getLogger().WithField("signal", sig.String()).WithField("error", err.Error()).Error("dump goroutines failed")
+		}
+	})
+}
+
+// Start 开始接收已注册的信号并分发给回调函数，该方法不会阻塞。
+// ctx 取消时停止接收信号。
+//
+// 参数：
+//   - ctx：控制信号处理的生命周期。
+//
+// 返回值：
+//   - error：重复启动时返回 ErrStarted。
+func (h *Handler) Start(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if nil != h.ch {
+		return ErrStarted
+	}
+
+	h.ch = make(chan os.Signal, 1)
+	for sig := range h.handlers {
+		stdsignal.Notify(h.ch, sig)
+	}
+	ctx, h.cancel = context.WithCancel(ctx)
+	h.done = make(chan struct{})
+	go h.loop(ctx, h.ch, h.done)
+	return nil
+}
+
+// Stop 停止接收信号，并等待正在执行的回调函数返回。
+//
+// 参数：
+//   - ctx：等待回调函数返回的截止时间。
+//
+// 返回值：
+//   - error：截止时间到达时返回上下文的错误。
+func (h *Handler) Stop(ctx context.Context) error {
+	h.mu.Lock()
+	if nil == h.ch {
+		h.mu.Unlock()
+		return nil
+	}
+	stdsignal.Stop(h.ch)
+	h.cancel()
+	done := h.done
+	h.mu.Unlock()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// loop 依次将收到的信号分发给回调函数，直到 ctx 取消。
+func (h *Handler) loop(ctx context.Context, ch <-chan os.Signal, done chan<- struct{}) {
+	defer close(done)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-ch:
+			h.mu.Lock()
+			handlers := h.handlers[sig]
+			h.mu.Unlock()
+			for _, fn := range handlers {
+				h.call(ctx, sig, fn)
+			}
+		}
+	}
+}
+
+// call 执行回调函数，回调函数发生 panic 时记录日志，不影响后续信号的处理。
+func (h *Handler) call(ctx context.Context, sig os.Signal, fn HandlerFunc) {
+	defer func() {
+		if r := recover(); nil != r {
+			h.o.getLogger().WithField("signal", sig.String()).WithField("panic", r).Error("signal handler panic")
+		}
+	}()
+	fn(ctx, sig)
+}
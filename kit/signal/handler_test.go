@@ -0,0 +1,136 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build !windows
+
+package signal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	stdsync "sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// syncBuffer 是并发安全的 bytes.Buffer。
+	syncBuffer struct {
+		mu  stdsync.Mutex
+		buf bytes.Buffer
+	}
+)
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// String 返回已写入的内容。
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestHandler 测试信号被分发给注册的回调函数，回调函数的 panic 不影响后续信号的处理。
+func TestHandler(t *testing.T) {
+	h := NewHandler()
+	received := make(chan os.Signal, 4)
+	h.Handle(syscall.SIGUSR2, func(context.Context, os.Signal) { panic("boom") })
+	h.Handle(syscall.SIGUSR2, func(_ context.Context, sig os.Signal) { received <- sig })
+	h.Handle(nil, func(context.Context, os.Signal) {})
+	h.Handle(syscall.SIGUSR2, nil)
+
+	require.NoError(t, h.Start(context.Background()))
+	assert.ErrorIs(t, h.Start(context.Background()), ErrStarted)
+	defer func() {
+		assert.NoError(t, h.Stop(context.Background()))
+	}()
+
+	for range 2 {
+		raise(t, syscall.SIGUSR2)
+		select {
+		case sig := <-received:
+			assert.Equal(t, syscall.SIGUSR2, sig)
+		case <-time.After(5 * time.Second):
+			t.Fatal("回调函数未执行")
+		}
+	}
+}
+
+// TestHandler_Reload 测试启动之后注册的重新加载回调函数。
+func TestHandler_Reload(t *testing.T) {
+	h := NewHandler()
+	require.NoError(t, h.Start(context.Background()))
+	defer func() {
+		assert.NoError(t, h.Stop(context.Background()))
+	}()
+
+	reloaded := make(chan error, 2)
+	results := []error{errors.New("bad config"), nil}
+	h.OnReload(func(context.Context) error {
+		err := results[0]
+		results = results[1:]
+		reloaded <- err
+		return err
+	})
+
+	for _, want := range []error{errors.New("bad config"), nil} {
+		raise(t, syscall.SIGHUP)
+		select {
+		case err := <-reloaded:
+			assert.Equal(t, want, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("未重新加载")
+		}
+	}
+}
+
+// TestHandler_Dump 测试收到 SignalDump 时输出所有协程的堆栈。
+func TestHandler_Dump(t *testing.T) {
+	buf := &syncBuffer{}
+	h := NewHandler()
+	h.OnDump(buf)
+	NewHandler().OnDump(nil)
+	require.NoError(t, h.Start(context.Background()))
+
+	raise(t, syscall.SIGUSR1)
+	assert.Eventually(t, func() bool {
+		return bytes.Contains([]byte(buf.String()), []byte("goroutine "))
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, h.Stop(context.Background()))
+	// 未启动或重复停止时直接返回。
+	assert.NoError(t, NewHandler().Stop(context.Background()))
+}
+
+// TestHandler_StopTimeout 测试回调函数未返回时 Stop 在截止时间到达后返回，且 Start 的上下文取消时停止处理。
+func TestHandler_StopTimeout(t *testing.T) {
+	release := make(chan struct{})
+	entered := make(chan struct{})
+	h := NewHandler()
+	h.Handle(syscall.SIGUSR2, func(context.Context, os.Signal) {
+		close(entered)
+		<-release
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, h.Start(ctx))
+
+	raise(t, syscall.SIGUSR2)
+	<-entered
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer stopCancel()
+	assert.ErrorIs(t, h.Stop(stopCtx), context.DeadlineExceeded)
+
+	close(release)
+	cancel()
+	assert.NoError(t, h.Stop(context.Background()))
+}
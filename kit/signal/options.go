@@ -0,0 +1,104 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package signal
+
+import (
+	"os"
+	"syscall"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+)
+
+// 以下为信号处理的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// signalsDefault 为 NotifyContext 默认监听的退出信号。
+	signalsDefault = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	// forceExitDefault 为收到第二个退出信号时默认执行的强制退出函数。
+	forceExitDefault = func(os.Signal) {
+		os.Exit(1)
+	}
+)
+
+type (
+	// Option 定义了信号处理的配置选项。
+	Option func(*options)
+
+	// options 包含信号处理的配置。
+	options struct {
+		// signals 是 NotifyContext 监听的退出信号。
+		signals []os.Signal
+		// forceExit 是收到第二个退出信号时执行的函数。
+		forceExit func(os.Signal)
+		// logger 是记录信号处理过程使用的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+	}
+)
+
+// WithSignals 设置 NotifyContext 监听的退出信号，仅对 NotifyContext 生效。
+//
+// 参数：
+//   - signals：退出信号，默认为 os.Interrupt 与 syscall.SIGTERM，为空时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithSignals(signals ...os.Signal) Option {
+	return func(o *options) {
+		o.signals = signals
+	}
+}
+
+// WithForceExit 设置收到第二个退出信号时执行的函数，仅对 NotifyContext 生效。
+//
+// 参数：
+//   - forceExit：强制退出函数，参数为收到的信号，默认调用 os.Exit(1)，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithForceExit(forceExit func(os.Signal)) Option {
+	return func(o *options) {
+		o.forceExit = forceExit
+	}
+}
+
+// WithLogger 设置记录信号处理过程使用的日志实例。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		signals:   signalsDefault,
+		forceExit: forceExitDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if 0 == len(o.signals) {
+		o.signals = signalsDefault
+	}
+	if nil == o.forceExit {
+		o.forceExit = forceExitDefault
+	}
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
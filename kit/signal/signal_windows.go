@@ -0,0 +1,19 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build windows
+
+package signal
+
+import (
+	"os"
+	"syscall"
+)
+
+var (
+	// SignalReload 是约定的重新加载配置的信号。
+	SignalReload os.Signal = syscall.SIGHUP
+	// SignalDump 是约定的输出所有协程堆栈的信号，Windows 不支持 SIGUSR1，因此为 nil。
+	SignalDump os.Signal
+)
@@ -0,0 +1,35 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package stream 提供了基于 Go 1.23 迭代器（iter.Seq）的惰性序列操作。
+
+所有操作都返回 iter.Seq，可以直接用于 for range，也可以与标准库 slices、maps 的迭代器函数组合。
+操作是惰性的：构造序列时不会读取任何元素，遍历时才逐个读取，停止遍历后上游也随之停止，
+因此可以处理无限序列或体积很大的数据源。
+
+基本使用：
+
+	ids := stream.Filter(stream.FromChan(events), func(e Event) bool {
+	    return e.Kind == "order"
+	})
+	for batch := range stream.Batch(stream.Map(ids, Event.OrderID), 100) {
+	    process(batch)
+	}
+
+	first := stream.Collect(stream.Take(seq, 10))
+
+ParallelMap 将转换函数提交到协程池中并行执行，默认保持输入的顺序：
+
+	results := stream.ParallelMap(stream.FromSlice(urls), fetch,
+	    stream.WithConcurrency(16),
+	)
+	for r := range results {
+	    handle(r)
+	}
+
+协程池默认使用 kit/runtime/goroutine 的默认协程池，可以通过 WithPool 指定；
+协程池不可用时在新的协程中执行。停止遍历 ParallelMap 时会等待正在执行的转换函数返回。
+*/
+package stream
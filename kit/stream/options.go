@@ -0,0 +1,103 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package stream
+
+import (
+	"runtime"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+// 以下为并行处理的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// orderedDefault 为默认是否保持输入顺序。
+	orderedDefault = true
+)
+
+type (
+	// Option 定义了并行处理的配置选项。
+	Option func(*options)
+
+	// options 包含并行处理的配置。
+	options struct {
+		// concurrency 是同时执行的数量。
+		concurrency int
+		// ordered 表示输出是否保持输入的顺序。
+		ordered bool
+		// pool 是执行函数的协程池，为 nil 时使用 kit/runtime/goroutine 的默认协程池。
+		pool goroutine.GoroutinePool
+	}
+)
+
+// WithConcurrency 设置同时执行的数量。
+//
+// 参数：
+//   - n：并发数，默认为 runtime.GOMAXPROCS(0)，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		o.concurrency = n
+	}
+}
+
+// WithOrdered 设置输出是否保持输入的顺序。
+// 不保持顺序时先完成的元素先输出，耗时差异较大时延迟更低。
+//
+// 参数：
+//   - ordered：是否保持顺序，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithOrdered(ordered bool) Option {
+	return func(o *options) {
+		o.ordered = ordered
+	}
+}
+
+// WithPool 设置执行函数的协程池。
+//
+// 参数：
+//   - pool：协程池，默认为 kit/runtime/goroutine 的默认协程池。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPool(pool goroutine.GoroutinePool) Option {
+	return func(o *options) {
+		o.pool = pool
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		concurrency: runtime.GOMAXPROCS(0),
+		ordered:     orderedDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.concurrency < 1 {
+		o.concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	return o
+}
+
+// submit 将任务提交到协程池，提交失败时（例如协程池已关闭）在新的协程中执行。
+func (o *options) submit(task func()) {
+	var err error
+	if nil != o.pool {
+		err = o.pool.Submit(task)
+	} else {
+		err = goroutine.Submit(task)
+	}
+	if nil != err {
+		go task()
+	}
+}
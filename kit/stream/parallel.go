@@ -0,0 +1,173 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package stream
+
+import (
+	"iter"
+	stdsync "sync"
+)
+
+type (
+	// job 是等待并行处理的元素。
+	job[T any] struct {
+		// index 是元素在输入序列中的位置。
+		index int
+		// value 是元素。
+		value T
+	}
+
+	// result 是并行处理的结果。
+	result[U any] struct {
+		// index 是元素在输入序列中的位置。
+		index int
+		// value 是处理的结果。
+		value U
+		// panicked 表示处理时是否发生了 panic。
+		panicked bool
+		// panicValue 是 panic 的值。
+		panicValue interface{}
+	}
+)
+
+// ParallelMap 返回并行地对每个元素调用 fn 后的序列，fn 在协程池中执行。
+// 与 Map 一样是惰性的：开始遍历时才读取输入序列并启动处理，停止遍历时放弃尚未处理的元素，
+// 并等待正在执行的 fn 返回后才结束遍历，因此遍历结束后不会再有 fn 在执行。
+// 输入序列在单独的协程中读取，同时处理与等待输出的元素不超过并发数的 2 倍。
+// fn 或输入序列 panic 时，panic 在遍历的协程中重新抛出。
+//
+// 参数：
+//   - seq：输入序列。
+//   - fn：转换函数，会被并发调用。
+//   - opts：配置选项，支持 WithConcurrency、WithOrdered 与 WithPool。
+//
+// 返回值：
+//   - iter.Seq[U]：转换后的序列，默认保持输入的顺序。
+//
+// 示例：
+//
+//	thumbs := stream.ParallelMap(stream.FromSlice(images), makeThumbnail, stream.WithConcurrency(8))
+//	for thumb := range thumbs {
+//	    save(thumb)
+//	}
+func ParallelMap[T, U any](seq iter.Seq[T], fn func(T) U, opts ...Option) iter.Seq[U] {
+	o := newOptions(opts...)
+	return func(yield func(U) bool) {
+		jobs := make(chan job[T])
+		results := make(chan result[U], o.concurrency)
+		// tokens 限制已经读取但尚未输出的元素数量，避免保持顺序时结果无限堆积。
+		tokens := make(chan struct{}, 2*o.concurrency)
+		done := make(chan struct{})
+		// seqPanic 记录读取输入序列时的 panic，在 results 关闭后读取。
+		var seqPanic *result[U]
+
+		go func() {
+			defer close(jobs)
+			defer func() {
+				if r := recover(); nil != r {
+					seqPanic = &result[U]{panicked: true, panicValue: r}
+				}
+			}()
+			index := 0
+			for v := range seq {
+				select {
+				case tokens <- struct{}{}:
+				case <-done:
+					return
+				}
+				select {
+				case jobs <- job[T]{index: index, value: v}:
+				case <-done:
+					return
+				}
+				index++
+			}
+		}()
+
+		// 在单独的协程中提交，协程池已满时不阻塞遍历的协程。
+		go func() {
+			var wg stdsync.WaitGroup
+			for i := 0; i < o.concurrency; i++ {
+				wg.Add(1)
+				o.submit(func() {
+					defer wg.Done()
+					work(fn, jobs, results, done)
+				})
+			}
+			wg.Wait()
+			close(results)
+		}()
+
+		defer func() {
+			close(done)
+			// 等待正在执行的 fn 返回。
+			for range results {
+			}
+		}()
+
+		pending := make(map[int]result[U])
+		next := 0
+		for r := range results {
+			if r.panicked {
+				panic(r.panicValue)
+			}
+			if !o.ordered {
+				<-tokens
+				if !yield(r.value) {
+					return
+				}
+				continue
+			}
+			pending[r.index] = r
+			for {
+				pr, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				<-tokens
+				if !yield(pr.value) {
+					return
+				}
+			}
+		}
+		if nil != seqPanic {
+			panic(seqPanic.panicValue)
+		}
+	}
+}
+
+// work 从 jobs 中读取元素并处理，直到 jobs 关闭或 done 关闭。
+func work[T, U any](fn func(T) U, jobs <-chan job[T], results chan<- result[U], done <-chan struct{}) {
+	for {
+		var j job[T]
+		var ok bool
+		select {
+		case j, ok = <-jobs:
+			if !ok {
+				return
+			}
+		case <-done:
+			return
+		}
+
+		r := result[U]{index: j.index}
+		func() {
+			defer func() {
+				if p := recover(); nil != p {
+					r.panicked = true
+					r.panicValue = p
+				}
+			}()
+			r.value = fn(j.value)
+		}()
+
+		select {
+		case results <- r:
+		case <-done:
+			return
+		}
+	}
+}
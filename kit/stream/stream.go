@@ -0,0 +1,166 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package stream
+
+import (
+	"iter"
+)
+
+// FromSlice 返回按顺序遍历切片元素的序列。
+//
+// 参数：
+//   - s：切片，遍历时读取，不会被复制。
+//
+// 返回值：
+//   - iter.Seq[T]：元素序列。
+//
+// 示例：
+//
+//	for v := range stream.FromSlice([]int{1, 2, 3}) {
+//	    fmt.Println(v)
+//	}
+func FromSlice[T any](s []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FromChan 返回从通道接收元素的序列，通道关闭时序列结束。
+// 提前停止遍历时通道中剩余的元素不会被接收，通道只能被遍历一次。
+//
+// 参数：
+//   - ch：通道。
+//
+// 返回值：
+//   - iter.Seq[T]：元素序列。
+func FromChan[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Map 返回对每个元素调用 fn 后的序列，fn 在遍历时才被调用。
+//
+// 参数：
+//   - seq：输入序列。
+//   - fn：转换函数。
+//
+// 返回值：
+//   - iter.Seq[U]：转换后的序列。
+func Map[T, U any](seq iter.Seq[T], fn func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter 返回只包含满足条件的元素的序列。
+//
+// 参数：
+//   - seq：输入序列。
+//   - pred：判断元素是否保留的函数。
+//
+// 返回值：
+//   - iter.Seq[T]：过滤后的序列。
+func Filter[T any](seq iter.Seq[T], pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if pred(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Take 返回只包含前 n 个元素的序列，取到 n 个元素后立即停止遍历输入序列。
+//
+// 参数：
+//   - seq：输入序列。
+//   - n：元素数量，小于等于 0 时返回空序列。
+//
+// 返回值：
+//   - iter.Seq[T]：前 n 个元素的序列。
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		count := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			count++
+			if count >= n {
+				return
+			}
+		}
+	}
+}
+
+// Batch 返回将元素按 size 个一组分批的序列，最后一批可能不足 size 个。
+// 每一批都是新分配的切片，可以被保留。
+//
+// 参数：
+//   - seq：输入序列。
+//   - size：每批的元素数量，小于 1 时 panic。
+//
+// 返回值：
+//   - iter.Seq[[]T]：批次序列。
+//
+// 示例：
+//
+//	for batch := range stream.Batch(rows, 500) {
+//	    if err := db.InsertBatch(ctx, batch); nil != err {
+//	        return err
+//	    }
+//	}
+func Batch[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("kit/stream: Batch 的 size 必须大于 0")
+	}
+	return func(yield func([]T) bool) {
+		batch := make([]T, 0, size)
+		for v := range seq {
+			batch = append(batch, v)
+			if len(batch) < size {
+				continue
+			}
+			if !yield(batch) {
+				return
+			}
+			batch = make([]T, 0, size)
+		}
+		if 0 < len(batch) {
+			yield(batch)
+		}
+	}
+}
+
+// Collect 遍历序列并将全部元素收集到切片中，与 slices.Collect 相同。
+//
+// 参数：
+//   - seq：序列，必须是有限的。
+//
+// 返回值：
+//   - []T：全部元素，序列为空时返回 nil。
+func Collect[T any](seq iter.Seq[T]) []T {
+	var s []T
+	for v := range seq {
+		s = append(s, v)
+	}
+	return s
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package stream
+
+import (
+	"iter"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+// naturals 返回从 0 开始的无限整数序列，并记录读取的元素数量。
+func naturals(read *atomic.Int64) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if nil != read {
+				read.Add(1)
+			}
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// TestOperators 测试序列操作的组合。
+func TestOperators(t *testing.T) {
+	var read atomic.Int64
+	evens := Filter(naturals(&read), func(v int) bool { return 0 == v%2 })
+	squares := Map(evens, func(v int) string { return strconv.Itoa(v * v) })
+	assert.Zero(t, read.Load())
+
+	assert.Equal(t, []string{"0", "4", "16"}, Collect(Take(squares, 3)))
+	// Take 取够元素后立即停止读取。
+	assert.Equal(t, int64(5), read.Load())
+
+	assert.Nil(t, Collect(Take(naturals(nil), 0)))
+	assert.Nil(t, Collect(FromSlice([]int(nil))))
+	assert.Equal(t, []int{1, 2}, Collect(Take(FromSlice([]int{1, 2}), 5)))
+}
+
+// TestEarlyStop 测试提前停止遍历。
+func TestEarlyStop(t *testing.T) {
+	for name, seq := range map[string]iter.Seq[int]{
+		"FromSlice": FromSlice([]int{1, 2, 3}),
+		"Map":       Map(FromSlice([]int{1, 2, 3}), func(v int) int { return v }),
+		"Filter":    Filter(FromSlice([]int{1, 2, 3}), func(int) bool { return true }),
+		"Take":      Take(FromSlice([]int{1, 2, 3}), 3),
+	} {
+		var got []int
+		for v := range seq {
+			got = append(got, v)
+			if 2 == len(got) {
+				break
+			}
+		}
+		assert.Equal(t, []int{1, 2}, got, name)
+	}
+
+	var batches [][]int
+	for b := range Batch(naturals(nil), 2) {
+		batches = append(batches, b)
+		if 2 == len(batches) {
+			break
+		}
+	}
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}}, batches)
+}
+
+// TestFromChan 测试从通道读取。
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	assert.Equal(t, []int{1, 2, 3}, Collect(FromChan(ch)))
+
+	ch = make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	for range FromChan(ch) {
+		break
+	}
+	assert.Equal(t, 1, len(ch))
+}
+
+// TestBatch 测试分批。
+func TestBatch(t *testing.T) {
+	batches := Collect(Batch(FromSlice([]int{1, 2, 3, 4, 5}), 2))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, batches)
+	// 每一批使用独立的底层数组。
+	batches[0][0] = 100
+	assert.Equal(t, 3, batches[1][0])
+
+	assert.Equal(t, [][]int{{1, 2}}, Collect(Batch(FromSlice([]int{1, 2}), 2)))
+	assert.Nil(t, Collect(Batch(FromSlice([]int{}), 2)))
+	assert.PanicsWithValue(t, "kit/stream: Batch 的 size 必须大于 0", func() {
+		Batch(FromSlice([]int{1}), 0)
+	})
+}
+
+// TestParallelMap 测试并行处理保持顺序与并发数。
+func TestParallelMap(t *testing.T) {
+	var running, peak atomic.Int64
+	square := func(v int) int {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		// 逆序的耗时，使后面的元素先完成。
+		time.Sleep(time.Duration(20-v%20) * 100 * time.Microsecond)
+		running.Add(-1)
+		return v * v
+	}
+
+	input := make([]int, 200)
+	for i := range input {
+		input[i] = i
+	}
+	got := Collect(ParallelMap(FromSlice(input), square, WithConcurrency(4)))
+	want := Collect(Map(FromSlice(input), func(v int) int { return v * v }))
+	assert.Equal(t, want, got)
+	assert.LessOrEqual(t, peak.Load(), int64(4))
+	assert.Greater(t, peak.Load(), int64(1))
+
+	// 不保持顺序时元素不变。
+	got = Collect(ParallelMap(FromSlice(input), square, WithConcurrency(4), WithOrdered(false)))
+	slices.Sort(got)
+	assert.Equal(t, want, got)
+
+	assert.Nil(t, Collect(ParallelMap(FromSlice([]int{}), square)))
+}
+
+// TestParallelMapEarlyStop 测试提前停止遍历后不再执行 fn。
+func TestParallelMapEarlyStop(t *testing.T) {
+	for _, ordered := range []bool{true, false} {
+		var read, running atomic.Int64
+		seq := ParallelMap(naturals(&read), func(v int) int {
+			running.Add(1)
+			defer running.Add(-1)
+			time.Sleep(time.Millisecond)
+			return v
+		}, WithConcurrency(4), WithOrdered(ordered))
+
+		count := 0
+		for range seq {
+			count++
+			if 10 == count {
+				break
+			}
+		}
+		assert.Zero(t, running.Load())
+		// 已读取的元素不超过输出的元素加上窗口大小与两个正在传递的元素。
+		assert.LessOrEqual(t, read.Load(), int64(10+8+2))
+	}
+}
+
+// TestParallelMapPanic 测试 fn 与输入序列的 panic 在遍历的协程中重新抛出。
+func TestParallelMapPanic(t *testing.T) {
+	seq := ParallelMap(FromSlice([]int{1, 2, 3}), func(v int) int {
+		if 2 == v {
+			panic("boom")
+		}
+		return v
+	}, WithConcurrency(2))
+	assert.PanicsWithValue(t, "boom", func() {
+		Collect(seq)
+	})
+
+	broken := func(yield func(int) bool) {
+		if !yield(1) {
+			return
+		}
+		panic("broken input")
+	}
+	assert.PanicsWithValue(t, "broken input", func() {
+		Collect(ParallelMap(broken, func(v int) int { return v }))
+	})
+}
+
+// TestParallelMapPool 测试使用指定的协程池，以及协程池不可用时在新的协程中执行。
+func TestParallelMapPool(t *testing.T) {
+	pool, release, err := goroutine.NewGoroutinePool(goroutine.WithSize(2), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+
+	double := func(v int) int { return 2 * v }
+	// 协程池的容量小于并发数。
+	got := Collect(ParallelMap(FromSlice([]int{1, 2, 3, 4, 5}), double, WithPool(pool), WithConcurrency(4)))
+	assert.Equal(t, []int{2, 4, 6, 8, 10}, got)
+
+	release()
+	got = Collect(ParallelMap(FromSlice([]int{1, 2, 3}), double, WithPool(pool)))
+	assert.Equal(t, []int{2, 4, 6}, got)
+}
+
+// TestOptions 测试配置选项与默认值。
+func TestOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, runtime.GOMAXPROCS(0), o.concurrency)
+	assert.Equal(t, orderedDefault, o.ordered)
+	assert.Nil(t, o.pool)
+
+	o = newOptions(WithConcurrency(3), WithOrdered(false))
+	assert.Equal(t, 3, o.concurrency)
+	assert.False(t, o.ordered)
+
+	o = newOptions(WithConcurrency(0))
+	assert.Equal(t, runtime.GOMAXPROCS(0), o.concurrency)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package strings
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// AppendValue 将 v 的文本形式追加到 dst，结果与 fmt.Sprint(v) 一致。
+// 常见类型直接使用 strconv 格式化，避免 fmt 的反射与装箱开销。
+//
+// 参数：
+//   - dst：追加的目标。
+//   - v：要格式化的值。
+//
+// 返回值：
+//   - []byte：追加之后的切片。
+func AppendValue(dst []byte, v interface{}) []byte {
+	switch x := v.(type) {
+	case string:
+		return append(dst, x...)
+	case int:
+		return strconv.AppendInt(dst, int64(x), 10)
+	case int8:
+		return strconv.AppendInt(dst, int64(x), 10)
+	case int16:
+		return strconv.AppendInt(dst, int64(x), 10)
+	case int32:
+		return strconv.AppendInt(dst, int64(x), 10)
+	case int64:
+		return strconv.AppendInt(dst, x, 10)
+	case uint:
+		return strconv.AppendUint(dst, uint64(x), 10)
+	case uint8:
+		return strconv.AppendUint(dst, uint64(x), 10)
+	case uint16:
+		return strconv.AppendUint(dst, uint64(x), 10)
+	case uint32:
+		return strconv.AppendUint(dst, uint64(x), 10)
+	case uint64:
+		return strconv.AppendUint(dst, x, 10)
+	case float32:
+		return strconv.AppendFloat(dst, float64(x), 'g', -1, 32)
+	case float64:
+		return strconv.AppendFloat(dst, x, 'g', -1, 64)
+	case bool:
+		return strconv.AppendBool(dst, x)
+	case time.Duration:
+		return append(dst, x.String()...)
+	default:
+		// error 与 fmt.Stringer 交给 fmt 处理，由其处理 nil 指针接收者导致的 panic。
+		return fmt.Append(dst, v)
+	}
+}
+
+// AppendField 将 "key=value" 追加到 dst，value 的格式化规则与 AppendValue 相同。
+//
+// 参数：
+//   - dst：追加的目标。
+//   - key：字段名。
+//   - value：字段值。
+//
+// 返回值：
+//   - []byte：追加之后的切片。
+func AppendField(dst []byte, key string, value interface{}) []byte {
+	dst = append(dst, key...)
+	dst = append(dst, '=')
+	return AppendValue(dst, value)
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package strings
+
+import (
+	stdsync "sync"
+)
+
+const (
+	// bufferSize 是新建缓冲区的初始容量。
+	bufferSize = 256
+	// bufferMax 是放回池中的缓冲区的最大容量，超过的缓冲区直接丢弃，避免偶尔的大日志长期占用内存。
+	bufferMax = 64 << 10
+)
+
+var (
+	// bufferPool 是缓冲区的对象池。
+	bufferPool = stdsync.Pool{
+		New: func() interface{} {
+			return &Buffer{B: make([]byte, 0, bufferSize)}
+		},
+	}
+)
+
+type (
+	// Buffer 是可复用的字节缓冲区，用于在热点路径中拼接字符串。
+	Buffer struct {
+		// B 是缓冲区的内容，可以直接传给 append 系列函数。
+		B []byte
+	}
+)
+
+// GetBuffer 从池中获取一个空的缓冲区，使用完毕后应调用 PutBuffer 放回。
+//
+// 返回值：
+//   - *Buffer：空的缓冲区。
+//
+// 示例：
+//
+//	buf := strings.GetBuffer()
+//	defer strings.PutBuffer(buf)
+//	buf.B = strings.AppendField(buf.B, "user", id)
+//	logger.Output(2, strings.FromBytes(buf.B))
+func GetBuffer() *Buffer {
+	return bufferPool.Get().(*Buffer)
+}
+
+// PutBuffer 将缓冲区放回池中。放回之后不能再使用该缓冲区，也不能再使用由其内容通过 FromBytes 得到的字符串。
+//
+// 参数：
+//   - b：要放回的缓冲区，为 nil 时不做任何处理。
+func PutBuffer(b *Buffer) {
+	if nil == b || cap(b.B) > bufferMax {
+		return
+	}
+	b.B = b.B[:0]
+	bufferPool.Put(b)
+}
+
+// WriteString 将 s 追加到缓冲区。
+//
+// 参数：
+//   - s：要追加的字符串。
+func (b *Buffer) WriteString(s string) {
+	b.B = append(b.B, s...)
+}
+
+// WriteByte 将 c 追加到缓冲区。
+//
+// 参数：
+//   - c：要追加的字节。
+//
+// 返回值：
+//   - error：始终返回 nil，用于满足 io.ByteWriter 接口。
+func (b *Buffer) WriteByte(c byte) error {
+	b.B = append(b.B, c)
+	return nil
+}
+
+// Write 将 p 追加到缓冲区，实现 io.Writer 接口。
+//
+// 参数：
+//   - p：要追加的字节。
+//
+// 返回值：
+//   - int：追加的字节数。
+//   - error：始终返回 nil。
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.B = append(b.B, p...)
+	return len(p), nil
+}
+
+// Len 返回缓冲区的长度。
+//
+// 返回值：
+//   - int：缓冲区的长度。
+func (b *Buffer) Len() int {
+	return len(b.B)
+}
+
+// String 返回缓冲区内容的副本。
+//
+// 返回值：
+//   - string：缓冲区的内容。
+func (b *Buffer) String() string {
+	return string(b.B)
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package strings
+
+import (
+	"unsafe"
+)
+
+// ToBytes 返回与 s 共享底层内存的字节切片，不复制数据。
+//
+// 约定：返回的切片只读，任何写入都会破坏字符串不可变的保证，可能导致程序崩溃；
+// 不能对其 append，也不能传给会修改入参的函数。需要修改时使用 []byte(s)。
+//
+// 参数：
+//   - s：源字符串。
+//
+// 返回值：
+//   - []byte：与 s 共享内存的只读字节切片，s 为空时返回 nil。
+func ToBytes(s string) []byte {
+	if "" == s {
+		return nil
+	}
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+// FromBytes 返回与 b 共享底层内存的字符串，不复制数据。
+//
+// 约定：返回的字符串存活期间 b 不能再被修改，包括复用 b 所在的缓冲区；
+// 典型用法是在同步调用中把临时缓冲区作为字符串传给只读取、不保留该字符串的函数。
+// 不满足约定时使用 string(b)。
+//
+// 参数：
+//   - b：源字节切片。
+//
+// 返回值：
+//   - string：与 b 共享内存的字符串，b 为空时返回空字符串。
+func FromBytes(b []byte) string {
+	if 0 == len(b) {
+		return ""
+	}
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package strings 提供了字符串与字节切片的零复制转换，以及在热路径上拼接文本的工具。
+
+主要功能：
+
+  - 零复制转换：ToBytes、FromBytes 在 string 与 []byte 之间转换而不复制数据，调用方需要遵守各自的约定
+  - 追加格式化：AppendValue、AppendField 将值以 fmt.Sprint 相同的格式追加到字节切片，常见类型不分配内存
+  - 缓冲区复用：GetBuffer、PutBuffer 通过对象池复用拼接使用的缓冲区
+
+kit/log 的 StdLogger 使用本包拼接日志行，每条日志不再为格式化字段分配中间字符串。
+
+基本使用：
+
+	buf := strings.GetBuffer()
+	defer strings.PutBuffer(buf)
+
+	buf.B = append(buf.B, "[INFO] "...)
+	buf.B = strings.AppendField(buf.B, "user_id", 42)
+	buf.B = append(buf.B, ' ')
+	buf.B = strings.AppendField(buf.B, "cost", 15*time.Millisecond)
+	logger.Output(2, strings.FromBytes(buf.B))
+
+零复制转换的约定：
+
+ToBytes 返回的切片只读；FromBytes 返回的字符串存活期间源切片不能被修改。
+无法确认满足约定时使用 []byte(s) 与 string(b)。
+
+由于包名与标准库 strings 相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "strings"
+
+	    kitstrings "github.com/fsyyft-go/monorepo/kit/strings"
+	)
+*/
+package strings
@@ -0,0 +1,107 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package strings
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// nilError 是指针接收者实现的 error，nil 指针调用 Error 会 panic。
+	nilError struct {
+		msg string
+	}
+)
+
+func (e *nilError) Error() string {
+	return e.msg
+}
+
+// TestToBytes 测试字符串与字节切片的零复制转换。
+func TestToBytes(t *testing.T) {
+	s := "hello"
+	b := ToBytes(s)
+	assert.Equal(t, []byte("hello"), b)
+	assert.Equal(t, len(s), cap(b))
+	assert.Nil(t, ToBytes(""))
+
+	buf := []byte("world")
+	assert.Equal(t, "world", FromBytes(buf))
+	assert.Equal(t, "", FromBytes(nil))
+	assert.Equal(t, "", FromBytes([]byte{}))
+	assert.Equal(t, "hello", FromBytes(ToBytes(s)))
+}
+
+// TestAppendValue 测试常见类型的格式化结果与 fmt.Sprint 一致。
+func TestAppendValue(t *testing.T) {
+	var typedNil *nilError
+	values := []interface{}{
+		"s", int(-1), int8(-8), int16(-16), int32(-32), int64(-64),
+		uint(1), uint8(8), uint16(16), uint32(32), uint64(64),
+		float32(1.5), 3.14159, 1e21, true, false,
+		1500 * time.Millisecond, errors.New("boom"), typedNil, nil,
+		[]byte("ab"), struct{ A int }{A: 1}, map[string]int{"a": 1},
+	}
+	for _, v := range values {
+		assert.Equal(t, fmt.Sprint(v), string(AppendValue(nil, v)), "%T", v)
+	}
+}
+
+// TestAppendField 测试字段的格式化。
+func TestAppendField(t *testing.T) {
+	dst := AppendField([]byte("["), "user", 42)
+	dst = append(dst, ' ')
+	dst = AppendField(dst, "name", "alice")
+	assert.Equal(t, "[user=42 name=alice", string(dst))
+}
+
+// TestBuffer 测试缓冲区的写入与复用。
+func TestBuffer(t *testing.T) {
+	buf := GetBuffer()
+	var _ io.Writer = buf
+	var _ io.ByteWriter = buf
+	buf.WriteString("a")
+	_ = buf.WriteByte('=')
+	n, err := buf.Write([]byte("1"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 3, buf.Len())
+	assert.Equal(t, "a=1", buf.String())
+	PutBuffer(buf)
+	PutBuffer(nil)
+
+	buf = GetBuffer()
+	assert.Equal(t, 0, buf.Len())
+	// 过大的缓冲区不放回池中。
+	buf.B = make([]byte, 0, bufferMax+1)
+	PutBuffer(buf)
+}
+
+// BenchmarkAppendField 测试格式化字段的性能。
+func BenchmarkAppendField(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		buf := GetBuffer()
+		buf.B = AppendField(buf.B, "user", 42)
+		buf.B = AppendField(buf.B, "name", "alice")
+		buf.B = AppendField(buf.B, "elapsed", 1500*time.Millisecond)
+		PutBuffer(buf)
+	}
+}
+
+// BenchmarkSprintf 测试使用 fmt.Sprintf 格式化字段的性能，作为对比。
+func BenchmarkSprintf(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = fmt.Sprintf("%s=%v ", "user", 42) + fmt.Sprintf("%s=%v ", "name", "alice") +
+			fmt.Sprintf("%s=%v", "elapsed", 1500*time.Millisecond)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package sync 提供了标准库 sync 之外的同步原语。
+
+主要功能：
+
+  - 按键加锁：KeyedMutex 与 KeyedRWMutex 按字符串键加锁，不同键之间互不阻塞，键的锁按引用计数自动释放
+  - 带权重的信号量：Semaphore 支持上下文取消、先进先出的等待顺序、等待者数量上限与运行时调整容量，并提供 Prometheus 指标
+  - 延迟初始化：OnceError 与 OnceValue 保存初始化的错误，可选失败后重试；Resettable 可以重置后重新初始化
+
+基本使用：
+
+	var mu sync.KeyedMutex
+
+	func UpdateBalance(userID string, delta int64) {
+	    mu.Lock(userID)
+	    defer mu.Unlock(userID)
+	    // 同一个用户的余额更新串行执行，不同用户之间并发执行。
+	}
+
+带权重的信号量：
+
+	sem := sync.NewSemaphore(10, sync.WithName("download"))
+	if err := sem.Acquire(ctx, 1); nil != err {
+	    return err
+	}
+	defer sem.Release(1)
+
+延迟初始化，失败后下一次调用重试：
+
+	var client = sync.NewOnceValue[*Client](sync.WithRetryOnFailure(true))
+
+	func GetClient() (*Client, error) {
+	    return client.Do(func() (*Client, error) {
+	        return Dial(addr)
+	    })
+	}
+
+由于包名与标准库相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "sync"
+
+	    kitsync "github.com/fsyyft-go/monorepo/kit/sync"
+	)
+*/
+package sync
@@ -0,0 +1,225 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sync
+
+import (
+	stdsync "sync"
+)
+
+type (
+	// KeyedMutex 是按键加锁的互斥锁，不同键之间互不阻塞，相同键的临界区互斥执行。
+	// 每个键的锁按引用计数管理，没有协程持有或等待时自动释放，不需要为每个资源预先创建互斥锁。
+	// KeyedMutex 的零值即可使用，使用后不能复制。
+	KeyedMutex struct {
+		// locks 是按键管理的锁。
+		locks keyedLocks
+	}
+
+	// KeyedRWMutex 是按键加锁的读写锁，相同键的读锁可以并发持有，写锁与其他锁互斥。
+	// KeyedRWMutex 的零值即可使用，使用后不能复制。
+	KeyedRWMutex struct {
+		// locks 是按键管理的锁。
+		locks keyedLocks
+	}
+
+	// keyedLocks 按键管理带引用计数的读写锁。
+	keyedLocks struct {
+		// mu 保护 locks。
+		mu stdsync.Mutex
+		// locks 是键到锁的映射，只包含被持有或等待中的键。
+		locks map[string]*refLock
+	}
+
+	// refLock 是带引用计数的读写锁。
+	refLock struct {
+		// mu 是实际加锁的读写锁。
+		mu stdsync.RWMutex
+		// refs 是持有或等待该锁的协程数量，由 keyedLocks.mu 保护。
+		refs int
+	}
+)
+
+// Lock 对键加锁，键已被加锁时阻塞等待。
+//
+// 参数：
+//   - key：要加锁的键。
+//
+// 示例：
+//
+//	var mu sync.KeyedMutex
+//
+//	func UpdateBalance(userID string, delta int64) {
+//	    mu.Lock(userID)
+//	    defer mu.Unlock(userID)
+//	    // 同一个用户的余额更新串行执行，不同用户之间并发执行。
+//	}
+func (m *KeyedMutex) Lock(key string) {
+	m.locks.acquire(key).mu.Lock()
+}
+
+// TryLock 尝试对键加锁，不会阻塞。
+//
+// 参数：
+//   - key：要加锁的键。
+//
+// 返回值：
+//   - bool：加锁成功时返回 true。
+func (m *KeyedMutex) TryLock(key string) bool {
+	l := m.locks.acquire(key)
+	if l.mu.TryLock() {
+		return true
+	}
+	m.locks.release(key)
+	return false
+}
+
+// Unlock 解锁键。
+// 解锁未加锁的键会引发 panic。
+//
+// 参数：
+//   - key：要解锁的键。
+func (m *KeyedMutex) Unlock(key string) {
+	m.locks.get(key).mu.Unlock()
+	m.locks.release(key)
+}
+
+// Len 返回被持有或等待中的键的数量。
+//
+// 返回值：
+//   - int：键的数量。
+func (m *KeyedMutex) Len() int {
+	return m.locks.len()
+}
+
+// Lock 对键加写锁，键已被加读锁或写锁时阻塞等待。
+//
+// 参数：
+//   - key：要加锁的键。
+func (m *KeyedRWMutex) Lock(key string) {
+	m.locks.acquire(key).mu.Lock()
+}
+
+// TryLock 尝试对键加写锁，不会阻塞。
+//
+// 参数：
+//   - key：要加锁的键。
+//
+// 返回值：
+//   - bool：加锁成功时返回 true。
+func (m *KeyedRWMutex) TryLock(key string) bool {
+	l := m.locks.acquire(key)
+	if l.mu.TryLock() {
+		return true
+	}
+	m.locks.release(key)
+	return false
+}
+
+// Unlock 解除键的写锁。
+// 解锁未加写锁的键会引发 panic。
+//
+// 参数：
+//   - key：要解锁的键。
+func (m *KeyedRWMutex) Unlock(key string) {
+	m.locks.get(key).mu.Unlock()
+	m.locks.release(key)
+}
+
+// RLock 对键加读锁，键已被加写锁时阻塞等待。
+//
+// 参数：
+//   - key：要加锁的键。
+//
+// 示例：
+//
+//	var mu sync.KeyedRWMutex
+//
+//	func ReadFile(path string) ([]byte, error) {
+//	    mu.RLock(path)
+//	    defer mu.RUnlock(path)
+//	    return os.ReadFile(path)
+//	}
+func (m *KeyedRWMutex) RLock(key string) {
+	m.locks.acquire(key).mu.RLock()
+}
+
+// TryRLock 尝试对键加读锁，不会阻塞。
+//
+// 参数：
+//   - key：要加锁的键。
+//
+// 返回值：
+//   - bool：加锁成功时返回 true。
+func (m *KeyedRWMutex) TryRLock(key string) bool {
+	l := m.locks.acquire(key)
+	if l.mu.TryRLock() {
+		return true
+	}
+	m.locks.release(key)
+	return false
+}
+
+// RUnlock 解除键的读锁。
+// 解锁未加读锁的键会引发 panic。
+//
+// 参数：
+//   - key：要解锁的键。
+func (m *KeyedRWMutex) RUnlock(key string) {
+	m.locks.get(key).mu.RUnlock()
+	m.locks.release(key)
+}
+
+// Len 返回被持有或等待中的键的数量。
+//
+// 返回值：
+//   - int：键的数量。
+func (m *KeyedRWMutex) Len() int {
+	return m.locks.len()
+}
+
+// acquire 增加键的引用计数并返回键的锁，键不存在时创建。
+func (k *keyedLocks) acquire(key string) *refLock {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if nil == k.locks {
+		k.locks = make(map[string]*refLock)
+	}
+	l, ok := k.locks[key]
+	if !ok {
+		l = &refLock{}
+		k.locks[key] = l
+	}
+	l.refs++
+	return l
+}
+
+// get 返回键的锁，键不存在时说明解锁了未加锁的键，引发 panic。
+func (k *keyedLocks) get(key string) *refLock {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	l, ok := k.locks[key]
+	if !ok {
+		panic("kit/sync: 解锁未加锁的键 " + key)
+	}
+	return l
+}
+
+// release 减少键的引用计数，计数归零时删除键的锁。
+func (k *keyedLocks) release(key string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	l := k.locks[key]
+	l.refs--
+	if 0 == l.refs {
+		delete(k.locks, key)
+	}
+}
+
+// len 返回被持有或等待中的键的数量。
+func (k *keyedLocks) len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.locks)
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sync
+
+import (
+	"strconv"
+	stdsync "sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestKeyedMutex_Exclusive 测试相同键的临界区互斥执行。
+func TestKeyedMutex_Exclusive(t *testing.T) {
+	var (
+		mu      KeyedMutex
+		wg      stdsync.WaitGroup
+		active  int32
+		counter int
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock("k")
+			defer mu.Unlock("k")
+			assert.Equal(t, int32(1), atomic.AddInt32(&active, 1))
+			counter++
+			atomic.AddInt32(&active, -1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 100, counter)
+	assert.Equal(t, 0, mu.Len(), "没有协程持有或等待时释放键的锁")
+}
+
+// TestKeyedMutex_IndependentKeys 测试不同键之间互不阻塞。
+func TestKeyedMutex_IndependentKeys(t *testing.T) {
+	var mu KeyedMutex
+	mu.Lock("a")
+	defer mu.Unlock("a")
+
+	done := make(chan struct{})
+	go func() {
+		mu.Lock("b")
+		mu.Unlock("b")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("不同的键不应互相阻塞")
+	}
+	assert.Equal(t, 1, mu.Len())
+}
+
+// TestKeyedMutex_TryLock 测试尝试加锁。
+func TestKeyedMutex_TryLock(t *testing.T) {
+	var mu KeyedMutex
+	assert.True(t, mu.TryLock("k"))
+	assert.False(t, mu.TryLock("k"))
+	assert.True(t, mu.TryLock("other"))
+	assert.Equal(t, 2, mu.Len())
+
+	mu.Unlock("k")
+	mu.Unlock("other")
+	assert.Equal(t, 0, mu.Len())
+}
+
+// TestKeyedMutex_UnlockUnlocked 测试解锁未加锁的键引发 panic。
+func TestKeyedMutex_UnlockUnlocked(t *testing.T) {
+	var mu KeyedMutex
+	assert.Panics(t, func() { mu.Unlock("k") })
+
+	var rw KeyedRWMutex
+	assert.Panics(t, func() { rw.RUnlock("k") })
+}
+
+// TestKeyedRWMutex 测试读锁并发持有、写锁互斥。
+func TestKeyedRWMutex(t *testing.T) {
+	var mu KeyedRWMutex
+	mu.RLock("k")
+	assert.True(t, mu.TryRLock("k"), "读锁可以并发持有")
+	assert.False(t, mu.TryLock("k"), "持有读锁时不能加写锁")
+
+	locked := make(chan struct{})
+	go func() {
+		mu.Lock("k")
+		close(locked)
+	}()
+
+	mu.RUnlock("k")
+	select {
+	case <-locked:
+		t.Fatal("仍有读锁时不应获得写锁")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	mu.RUnlock("k")
+	<-locked
+	assert.False(t, mu.TryRLock("k"), "持有写锁时不能加读锁")
+	mu.Unlock("k")
+	assert.Equal(t, 0, mu.Len())
+}
+
+// TestKeyedRWMutex_Concurrent 测试多个键的并发读写。
+func TestKeyedRWMutex_Concurrent(t *testing.T) {
+	var (
+		mu     KeyedRWMutex
+		wg     stdsync.WaitGroup
+		values [10]int
+	)
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			idx := i % len(values)
+			key := strconv.Itoa(idx)
+			if 0 == i%4 {
+				mu.Lock(key)
+				values[idx]++
+				mu.Unlock(key)
+				return
+			}
+			mu.RLock(key)
+			_ = values[idx]
+			mu.RUnlock(key)
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	assert.Equal(t, 50, total)
+	assert.Equal(t, 0, mu.Len())
+}
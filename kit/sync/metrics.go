@@ -0,0 +1,50 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sync
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 定义同步原语指标相关的常量。
+const (
+	// namespace 定义 prometheus 指标的命名空间。
+	namespace = "kit_sync"
+	// subsystemSemaphore 定义信号量指标的子系统名称。
+	subsystemSemaphore = "semaphore"
+)
+
+var (
+	// MetricSemaphoreWaiters 用于记录信号量当前等待获取的协程数。
+	// 该指标包含以下标签：
+	// - name: 信号量的名称。
+	MetricSemaphoreWaiters = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystemSemaphore,
+		Name:      "waiters",
+		Help:      "semaphore's current waiters.",
+	}, []string{"name"})
+
+	// MetricSemaphoreHeld 用于记录信号量当前被持有的权重。
+	// 该指标包含以下标签：
+	// - name: 信号量的名称。
+	MetricSemaphoreHeld = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystemSemaphore,
+		Name:      "held",
+		Help:      "semaphore's current held weight.",
+	}, []string{"name"})
+)
+
+// Collectors 返回本包导出的全部指标，供使用方注册，例如通过 kit/metrics 的 WithCollectors 一次注册。
+//
+// 返回值：
+//   - []prometheus.Collector：本包的全部指标。
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		MetricSemaphoreWaiters,
+		MetricSemaphoreHeld,
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sync
+
+import (
+	stdsync "sync"
+	"sync/atomic"
+)
+
+type (
+	// OnceValue 只执行一次初始化函数，并保存其返回的值与错误。
+	// 与标准库的 sync.OnceValues 不同，开启 WithRetryOnFailure 后，初始化失败不会被保存，下一次调用会重新执行。
+	// OnceValue 的零值即可使用（失败不重试），使用后不能复制。
+	OnceValue[T any] struct {
+		// result 是保存的初始化结果，为 nil 表示初始化未完成。
+		result atomic.Pointer[onceResult[T]]
+		// mu 保证同一时间只有一个协程执行初始化。
+		mu stdsync.Mutex
+		// retryOnFailure 表示初始化失败时是否允许重新执行。
+		retryOnFailure bool
+	}
+
+	// onceResult 是初始化的结果，保存后不再修改。
+	onceResult[T any] struct {
+		// value 是初始化函数返回的值。
+		value T
+		// err 是初始化函数返回的错误。
+		err error
+	}
+
+	// OnceError 只执行一次初始化函数，并保存其返回的错误。
+	// OnceError 的零值即可使用（失败不重试），使用后不能复制。
+	OnceError struct {
+		// once 是实际执行初始化的 OnceValue。
+		once OnceValue[struct{}]
+	}
+
+	// Resettable 是可以重置的 OnceValue，重置后下一次调用重新执行初始化。
+	// 常用于延迟建立的连接：连接断开后调用 Reset，下一次使用时重新建立。
+	// Resettable 的零值即可使用（失败不重试），使用后不能复制。
+	Resettable[T any] struct {
+		// OnceValue 提供 Do 与 Done。
+		OnceValue[T]
+	}
+)
+
+// NewOnceValue 创建 OnceValue。
+//
+// 参数：
+//   - opts：配置选项，支持 WithRetryOnFailure。
+//
+// 返回值：
+//   - *OnceValue[T]：OnceValue 实例。
+func NewOnceValue[T any](opts ...Option) *OnceValue[T] {
+	return &OnceValue[T]{retryOnFailure: newOptions(opts...).retryOnFailure}
+}
+
+// Do 在初始化未完成时执行 fn，并返回保存的值与错误；并发调用会等待正在执行的初始化。
+// fn 发生 panic 时视为没有执行，panic 会传递给调用方。
+//
+// 参数：
+//   - fn：初始化函数。
+//
+// 返回值：
+//   - T：初始化函数返回的值。
+//   - error：初始化函数返回的错误。
+//
+// 示例：
+//
+//	var client = sync.NewOnceValue[*redis.Client](sync.WithRetryOnFailure(true))
+//
+//	func Client() (*redis.Client, error) {
+//	    return client.Do(func() (*redis.Client, error) {
+//	        return redis.Dial(addr)
+//	    })
+//	}
+func (o *OnceValue[T]) Do(fn func() (T, error)) (T, error) {
+	if r := o.result.Load(); nil != r {
+		return r.value, r.err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if r := o.result.Load(); nil != r {
+		return r.value, r.err
+	}
+
+	value, err := fn()
+	if nil != err && o.retryOnFailure {
+		return value, err
+	}
+	o.result.Store(&onceResult[T]{value: value, err: err})
+	return value, err
+}
+
+// Done 返回初始化是否已完成。
+// 开启 WithRetryOnFailure 时，只有初始化成功后才返回 true。
+//
+// 返回值：
+//   - bool：初始化已完成时返回 true。
+func (o *OnceValue[T]) Done() bool {
+	return nil != o.result.Load()
+}
+
+// NewOnceError 创建 OnceError。
+//
+// 参数：
+//   - opts：配置选项，支持 WithRetryOnFailure。
+//
+// 返回值：
+//   - *OnceError：OnceError 实例。
+func NewOnceError(opts ...Option) *OnceError {
+	return &OnceError{once: OnceValue[struct{}]{retryOnFailure: newOptions(opts...).retryOnFailure}}
+}
+
+// Do 在初始化未完成时执行 fn，并返回保存的错误；并发调用会等待正在执行的初始化。
+// fn 发生 panic 时视为没有执行，panic 会传递给调用方。
+//
+// 参数：
+//   - fn：初始化函数。
+//
+// 返回值：
+//   - error：初始化函数返回的错误。
+//
+// 示例：
+//
+//	var initOnce = sync.NewOnceError(sync.WithRetryOnFailure(true))
+//
+//	func ensureSchema(ctx context.Context) error {
+//	    return initOnce.Do(func() error {
+//	        return migrate(ctx)
+//	    })
+//	}
+func (o *OnceError) Do(fn func() error) error {
+	_, err := o.once.Do(func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// Done 返回初始化是否已完成。
+// 开启 WithRetryOnFailure 时，只有初始化成功后才返回 true。
+//
+// 返回值：
+//   - bool：初始化已完成时返回 true。
+func (o *OnceError) Done() bool {
+	return o.once.Done()
+}
+
+// NewResettable 创建 Resettable。
+//
+// 参数：
+//   - opts：配置选项，支持 WithRetryOnFailure。
+//
+// 返回值：
+//   - *Resettable[T]：Resettable 实例。
+func NewResettable[T any](opts ...Option) *Resettable[T] {
+	return &Resettable[T]{OnceValue: OnceValue[T]{retryOnFailure: newOptions(opts...).retryOnFailure}}
+}
+
+// Reset 清除保存的值与错误，下一次 Do 重新执行初始化；正在执行的初始化完成后才会重置。
+//
+// 返回值：
+//   - T：重置前保存的值，初始化未完成时返回零值，便于调用方释放资源。
+//
+// 示例：
+//
+//	conn, err := r.Do(dial)
+//	if nil != err {
+//	    return err
+//	}
+//	if err := conn.Ping(); nil != err {
+//	    if old := r.Reset(); nil != old {
+//	        _ = old.Close()
+//	    }
+//	}
+func (r *Resettable[T]) Reset() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var old T
+	if res := r.result.Swap(nil); nil != res {
+		old = res.value
+	}
+	return old
+}
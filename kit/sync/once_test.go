@@ -0,0 +1,147 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sync
+
+import (
+	"errors"
+	stdsync "sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOnceError 测试只执行一次并保存错误。
+func TestOnceError(t *testing.T) {
+	errInit := errors.New("init failed")
+	tests := []struct {
+		name      string
+		once      *OnceError
+		wantCalls int32
+		wantDone  bool
+	}{
+		{name: "零值保存错误", once: &OnceError{}, wantCalls: 1, wantDone: true},
+		{name: "失败不重试", once: NewOnceError(), wantCalls: 1, wantDone: true},
+		{name: "失败后重试", once: NewOnceError(WithRetryOnFailure(true)), wantCalls: 3, wantDone: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			for i := 0; i < 3; i++ {
+				err := tt.once.Do(func() error {
+					atomic.AddInt32(&calls, 1)
+					return errInit
+				})
+				assert.ErrorIs(t, err, errInit)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantDone, tt.once.Done())
+		})
+	}
+}
+
+// TestOnceError_RetryThenSucceed 测试失败重试直到成功，成功后不再执行。
+func TestOnceError_RetryThenSucceed(t *testing.T) {
+	once := NewOnceError(WithRetryOnFailure(true))
+	var calls int32
+	fn := func() error {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return errors.New("not ready")
+		}
+		return nil
+	}
+
+	assert.Error(t, once.Do(fn))
+	assert.Error(t, once.Do(fn))
+	assert.NoError(t, once.Do(fn))
+	assert.NoError(t, once.Do(fn))
+	assert.Equal(t, int32(3), calls)
+	assert.True(t, once.Done())
+}
+
+// TestOnceError_Panic 测试初始化函数 panic 时视为没有执行。
+func TestOnceError_Panic(t *testing.T) {
+	var once OnceError
+	assert.Panics(t, func() {
+		_ = once.Do(func() error { panic("boom") })
+	})
+	assert.False(t, once.Done())
+	assert.NoError(t, once.Do(func() error { return nil }))
+}
+
+// TestOnceValue_Concurrent 测试并发调用只执行一次，所有调用得到相同的值。
+func TestOnceValue_Concurrent(t *testing.T) {
+	var (
+		once  OnceValue[*int]
+		calls int32
+		wg    stdsync.WaitGroup
+	)
+	results := make([]*int, 50)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := once.Do(func() (*int, error) {
+				atomic.AddInt32(&calls, 1)
+				n := 42
+				return &n, nil
+			})
+			assert.NoError(t, err)
+			results[i] = v
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls)
+	for _, v := range results {
+		assert.Same(t, results[0], v)
+	}
+}
+
+// TestResettable 测试重置后重新初始化。
+func TestResettable(t *testing.T) {
+	r := NewResettable[int](WithRetryOnFailure(true))
+	var calls int32
+	fn := func() (int, error) {
+		return int(atomic.AddInt32(&calls, 1)), nil
+	}
+
+	assert.Equal(t, 0, r.Reset(), "未初始化时重置返回零值")
+
+	v, err := r.Do(fn)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, _ = r.Do(fn)
+	assert.Equal(t, 1, v)
+
+	assert.Equal(t, 1, r.Reset(), "重置返回之前保存的值")
+	assert.False(t, r.Done())
+	v, _ = r.Do(fn)
+	assert.Equal(t, 2, v)
+	assert.True(t, r.Done())
+}
+
+// TestResettable_Concurrent 测试并发调用与重置。
+func TestResettable_Concurrent(t *testing.T) {
+	var (
+		r  Resettable[int]
+		wg stdsync.WaitGroup
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if 0 == i%10 {
+				r.Reset()
+				return
+			}
+			v, err := r.Do(func() (int, error) { return 1, nil })
+			assert.NoError(t, err)
+			assert.Equal(t, 1, v)
+		}(i)
+	}
+	wg.Wait()
+}
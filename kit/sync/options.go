@@ -0,0 +1,92 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sync
+
+// 以下为同步原语的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// metricsDefault 为是否默认记录指标。
+	metricsDefault = true
+)
+
+type (
+	// Option 定义了同步原语的配置选项。
+	Option func(*options)
+
+	// options 包含同步原语的配置。
+	options struct {
+		// name 是同步原语的名称，用于指标标签。
+		name string
+		// metrics 表示是否记录指标。
+		metrics bool
+		// maxWaiters 是信号量允许的最大等待者数量，0 表示不限制。
+		maxWaiters int
+		// retryOnFailure 表示初始化失败时是否允许重新执行。
+		retryOnFailure bool
+	}
+)
+
+// WithName 设置名称，作为指标的 name 标签。
+//
+// 参数：
+//   - name：名称。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithMetrics 设置是否记录指标。
+//
+// 参数：
+//   - metrics：是否记录指标，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMetrics(metrics bool) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// WithMaxWaiters 设置信号量允许的最大等待者数量，等待者已满时 Acquire 立即返回 ErrMaxWaiters。
+//
+// 参数：
+//   - maxWaiters：最大等待者数量，0 表示不限制（默认）。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxWaiters(maxWaiters int) Option {
+	return func(o *options) {
+		o.maxWaiters = maxWaiters
+	}
+}
+
+// WithRetryOnFailure 设置初始化失败时是否允许重新执行，仅对 OnceValue、OnceError 与 Resettable 生效。
+//
+// 参数：
+//   - retry：为 true 时初始化失败不保存结果，下一次调用重新执行，默认为 false。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithRetryOnFailure(retry bool) Option {
+	return func(o *options) {
+		o.retryOnFailure = retry
+	}
+}
+
+// newOptions 创建并应用配置选项。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		metrics: metricsDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
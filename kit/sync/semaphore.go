@@ -0,0 +1,243 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sync
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	stdsync "sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// ErrExceedsSize 表示请求的权重超过了信号量的容量，永远无法满足。
+	ErrExceedsSize = errors.New("请求的权重超过信号量容量")
+	// ErrMaxWaiters 表示等待获取信号量的协程数已达到 WithMaxWaiters 设置的上限。
+	ErrMaxWaiters = errors.New("等待获取信号量的协程数已达上限")
+)
+
+type (
+	// Semaphore 是带权重的信号量，用于限制同时使用某种资源的总量，例如并发请求数、内存或连接数。
+	// 等待的协程按先进先出的顺序获取，较大的请求不会被后来的较小请求饿死。
+	// Semaphore 的所有方法都是并发安全的。
+	Semaphore struct {
+		// maxWaiters 是允许的最大等待者数量，0 表示不限制。
+		maxWaiters int
+		// mu 保护 size、cur 与 waiters。
+		mu stdsync.Mutex
+		// size 是信号量的容量。
+		size int64
+		// cur 是当前被持有的权重。
+		cur int64
+		// waiters 是按到达顺序排列的等待者。
+		waiters list.List
+		// waitersGauge 是等待者数量的指标，未开启指标时为 nil。
+		waitersGauge prometheus.Gauge
+		// heldGauge 是被持有权重的指标，未开启指标时为 nil。
+		heldGauge prometheus.Gauge
+	}
+
+	// waiter 是等待获取信号量的协程。
+	waiter struct {
+		// n 是请求的权重。
+		n int64
+		// ready 在获取成功后关闭。
+		ready chan struct{}
+	}
+)
+
+// NewSemaphore 创建带权重的信号量。
+//
+// 参数：
+//   - size：信号量的容量，即可以同时持有的最大权重。
+//   - opts：配置选项，支持 WithName、WithMetrics 与 WithMaxWaiters。
+//
+// 返回值：
+//   - *Semaphore：信号量实例。
+//
+// 示例：
+//
+//	// 同时最多使用 64MB 内存处理图片。
+//	sem := sync.NewSemaphore(64<<20, sync.WithName("image"))
+//	if err := sem.Acquire(ctx, size); nil != err {
+//	    return err
+//	}
+//	defer sem.Release(size)
+func NewSemaphore(size int64, opts ...Option) *Semaphore {
+	o := newOptions(opts...)
+	s := &Semaphore{size: size, maxWaiters: o.maxWaiters}
+	if o.metrics {
+		s.waitersGauge = MetricSemaphoreWaiters.WithLabelValues(o.name)
+		s.heldGauge = MetricSemaphoreHeld.WithLabelValues(o.name)
+	}
+	return s
+}
+
+// Acquire 获取权重为 n 的信号量，容量不足时阻塞等待，直到获取成功或上下文被取消。
+//
+// 参数：
+//   - ctx：上下文，用于取消等待。
+//   - n：请求的权重。
+//
+// 返回值：
+//   - error：n 超过容量时返回 ErrExceedsSize；等待者已满时返回 ErrMaxWaiters；
+//     上下文被取消时返回上下文的错误，此时不持有信号量。
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	if err := ctx.Err(); nil != err {
+		return err
+	}
+
+	s.mu.Lock()
+	if n > s.size {
+		s.mu.Unlock()
+		return ErrExceedsSize
+	}
+	if s.size-s.cur >= n && 0 == s.waiters.Len() {
+		s.cur += n
+		s.changed()
+		s.mu.Unlock()
+		return nil
+	}
+	if s.maxWaiters > 0 && s.waiters.Len() >= s.maxWaiters {
+		s.mu.Unlock()
+		return ErrMaxWaiters
+	}
+
+	ready := make(chan struct{})
+	elem := s.waiters.PushBack(waiter{n: n, ready: ready})
+	s.changed()
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-ready:
+			// 取消的同时获取成功，归还权重后按取消处理。
+			s.cur -= n
+			s.notifyWaiters()
+		default:
+			isFront := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			// 排在最前面的等待者离开后，后面的等待者可能已经可以获取。
+			if isFront && s.size > s.cur {
+				s.notifyWaiters()
+			}
+		}
+		s.changed()
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire 尝试获取权重为 n 的信号量，不会阻塞。
+// 有其他协程在等待时，即使容量足够也会失败，以保证等待者的先后顺序。
+//
+// 参数：
+//   - n：请求的权重。
+//
+// 返回值：
+//   - bool：获取成功时返回 true。
+func (s *Semaphore) TryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size-s.cur < n || 0 != s.waiters.Len() {
+		return false
+	}
+	s.cur += n
+	s.changed()
+	return true
+}
+
+// Release 释放权重为 n 的信号量，并唤醒可以获取的等待者。
+// 释放的权重超过已持有的权重会引发 panic。
+//
+// 参数：
+//   - n：释放的权重。
+func (s *Semaphore) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cur -= n
+	if s.cur < 0 {
+		panic("kit/sync: 释放的权重超过已持有的权重")
+	}
+	s.notifyWaiters()
+	s.changed()
+}
+
+// Resize 调整信号量的容量，已持有的权重不受影响。
+// 扩大容量时唤醒可以获取的等待者；缩小容量后，持有的权重可能暂时超过容量，释放到容量以下后才能再次获取。
+// 请求的权重超过新容量的等待者会一直等待，直到容量再次扩大或上下文被取消。
+//
+// 参数：
+//   - size：新的容量。
+func (s *Semaphore) Resize(size int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.size = size
+	s.notifyWaiters()
+	s.changed()
+}
+
+// Size 返回信号量的容量。
+//
+// 返回值：
+//   - int64：容量。
+func (s *Semaphore) Size() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+// Held 返回当前被持有的权重。
+//
+// 返回值：
+//   - int64：被持有的权重。
+func (s *Semaphore) Held() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cur
+}
+
+// Waiters 返回当前等待获取的协程数。
+//
+// 返回值：
+//   - int：等待者数量。
+func (s *Semaphore) Waiters() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.waiters.Len()
+}
+
+// notifyWaiters 按顺序唤醒可以获取的等待者，调用方需要持有锁。
+func (s *Semaphore) notifyWaiters() {
+	for {
+		front := s.waiters.Front()
+		if nil == front {
+			return
+		}
+		w := front.Value.(waiter)
+		if s.size-s.cur < w.n {
+			// 容量不足时不跳过队首的等待者，避免较大的请求被饿死。
+			return
+		}
+		s.cur += w.n
+		s.waiters.Remove(front)
+		close(w.ready)
+	}
+}
+
+// changed 在等待者或持有的权重变化后更新指标，调用方需要持有锁。
+func (s *Semaphore) changed() {
+	if nil == s.heldGauge {
+		return
+	}
+	s.waitersGauge.Set(float64(s.waiters.Len()))
+	s.heldGauge.Set(float64(s.cur))
+}
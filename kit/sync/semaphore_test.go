@@ -0,0 +1,206 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sync
+
+import (
+	"context"
+	"strconv"
+	stdsync "sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSemaphore_AcquireRelease 测试获取与释放。
+func TestSemaphore_AcquireRelease(t *testing.T) {
+	s := NewSemaphore(3, WithMetrics(false))
+	ctx := context.Background()
+
+	require.NoError(t, s.Acquire(ctx, 2))
+	assert.True(t, s.TryAcquire(1))
+	assert.False(t, s.TryAcquire(1))
+
+	s.Release(3)
+	assert.True(t, s.TryAcquire(3))
+	s.Release(3)
+
+	assert.ErrorIs(t, s.Acquire(ctx, 4), ErrExceedsSize)
+	assert.Panics(t, func() { s.Release(1) })
+}
+
+// TestSemaphore_Context 测试等待时上下文被取消。
+func TestSemaphore_Context(t *testing.T) {
+	s := NewSemaphore(1, WithMetrics(false))
+	require.NoError(t, s.Acquire(context.Background(), 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.Acquire(ctx, 1), context.DeadlineExceeded)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, s.Acquire(canceled, 1), context.Canceled, "上下文已取消时直接返回")
+
+	s.Release(1)
+	assert.True(t, s.TryAcquire(1), "取消的等待者不占用权重")
+}
+
+// TestSemaphore_FIFO 测试等待者按先后顺序获取，较大的请求不会被饿死。
+func TestSemaphore_FIFO(t *testing.T) {
+	s := NewSemaphore(2, WithMetrics(false))
+	ctx := context.Background()
+	require.NoError(t, s.Acquire(ctx, 1))
+
+	acquired := make(chan int64, 2)
+	go func() {
+		_ = s.Acquire(ctx, 2)
+		acquired <- 2
+	}()
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return 1 == s.waiters.Len()
+	}, time.Second, time.Millisecond)
+
+	assert.False(t, s.TryAcquire(1), "有等待者时不插队")
+	go func() {
+		_ = s.Acquire(ctx, 1)
+		acquired <- 1
+	}()
+
+	s.Release(1)
+	assert.Equal(t, int64(2), <-acquired)
+	s.Release(2)
+	assert.Equal(t, int64(1), <-acquired)
+	s.Release(1)
+}
+
+// TestSemaphore_CancelFront 测试队首的等待者取消后唤醒后面的等待者。
+func TestSemaphore_CancelFront(t *testing.T) {
+	s := NewSemaphore(2, WithMetrics(false))
+	require.NoError(t, s.Acquire(context.Background(), 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.Acquire(ctx, 2)
+	}()
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return 1 == s.waiters.Len()
+	}, time.Second, time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		_ = s.Acquire(context.Background(), 1)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return 2 == s.waiters.Len()
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("队首取消后，后面的等待者应被唤醒")
+	}
+}
+
+// TestSemaphore_Concurrent 测试并发持有的权重不超过容量。
+func TestSemaphore_Concurrent(t *testing.T) {
+	s := NewSemaphore(5, WithMetrics(false))
+	var (
+		wg   stdsync.WaitGroup
+		held int64
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(n int64) {
+			defer wg.Done()
+			assert.NoError(t, s.Acquire(context.Background(), n))
+			assert.LessOrEqual(t, atomic.AddInt64(&held, n), int64(5))
+			time.Sleep(time.Millisecond)
+			atomic.AddInt64(&held, -n)
+			s.Release(n)
+		}(int64(i%3 + 1))
+	}
+	wg.Wait()
+	assert.True(t, s.TryAcquire(5))
+}
+
+// TestSemaphore_Metrics 测试等待者与持有权重的指标。
+func TestSemaphore_Metrics(t *testing.T) {
+	name := t.Name() + strconv.FormatInt(time.Now().UnixNano(), 10)
+	s := NewSemaphore(2, WithName(name))
+
+	require.NoError(t, s.Acquire(context.Background(), 2))
+	assert.Equal(t, float64(2), testutil.ToFloat64(MetricSemaphoreHeld.WithLabelValues(name)))
+
+	done := make(chan struct{})
+	go func() {
+		_ = s.Acquire(context.Background(), 1)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		return 1 == testutil.ToFloat64(MetricSemaphoreWaiters.WithLabelValues(name))
+	}, time.Second, time.Millisecond)
+
+	s.Release(2)
+	<-done
+	assert.Equal(t, float64(0), testutil.ToFloat64(MetricSemaphoreWaiters.WithLabelValues(name)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MetricSemaphoreHeld.WithLabelValues(name)))
+}
+
+// TestSemaphore_MaxWaiters 测试等待者已满时立即返回错误。
+func TestSemaphore_MaxWaiters(t *testing.T) {
+	s := NewSemaphore(1, WithMetrics(false), WithMaxWaiters(1))
+	require.NoError(t, s.Acquire(context.Background(), 1))
+
+	done := make(chan struct{})
+	go func() {
+		_ = s.Acquire(context.Background(), 1)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return 1 == s.Waiters() }, time.Second, time.Millisecond)
+
+	assert.ErrorIs(t, s.Acquire(context.Background(), 1), ErrMaxWaiters)
+
+	s.Release(1)
+	<-done
+	assert.Equal(t, int64(1), s.Held())
+}
+
+// TestSemaphore_Resize 测试调整容量。
+func TestSemaphore_Resize(t *testing.T) {
+	s := NewSemaphore(1, WithMetrics(false))
+	require.NoError(t, s.Acquire(context.Background(), 1))
+
+	done := make(chan struct{})
+	go func() {
+		_ = s.Acquire(context.Background(), 1)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return 1 == s.Waiters() }, time.Second, time.Millisecond)
+
+	s.Resize(2)
+	<-done
+	assert.Equal(t, int64(2), s.Size())
+	assert.Equal(t, int64(2), s.Held())
+
+	s.Resize(1)
+	s.Release(1)
+	assert.False(t, s.TryAcquire(1), "持有的权重未降到容量以下时不能获取")
+	s.Release(1)
+	assert.True(t, s.TryAcquire(1))
+}
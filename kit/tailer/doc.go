@@ -0,0 +1,36 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package tailer 提供了持续跟踪文件新写入行的 Tailer，行为与 tail -F 一致。
+
+Tailer 定期检查文件，将新写入的完整行发送到通道。文件被重命名轮转、通过符号链接切换
+（例如 kit/log 按时间轮转时的 WithLinkName）或被删除后重新创建时，Tailer 读完原文件的剩余内容后
+切换到新文件；文件被截断时从开头重新读取。
+
+基本使用：
+
+	t := tailer.New("/var/log/app/app.log")
+	if err := t.Start(ctx); nil != err {
+	    return err
+	}
+	defer t.Stop(context.Background())
+
+	for line := range t.Lines() {
+	    fmt.Println(line.Text)
+	}
+
+每一行都带有该行之后的位置（文件的 inode 与偏移量）。处理完一行后保存其位置，
+重启时通过 WithPosition 从下一行继续读取；停止期间文件已被轮转时，Tailer 在同一目录中
+按 inode 找到原文件，读完剩余内容后再读取当前文件：
+
+	t := tailer.New(path, tailer.WithPosition(checkpoint.Load()))
+	for line := range t.Lines() {
+	    handle(line.Text)
+	    checkpoint.Save(line.Position)
+	}
+
+在没有 inode 的平台（如 Windows）上，运行期间的轮转检测不受影响，恢复读取时只能根据文件大小判断。
+*/
+package tailer
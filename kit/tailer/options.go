@@ -0,0 +1,178 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package tailer
+
+import (
+	"time"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// 以下为文件跟踪的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// pollIntervalDefault 为默认检查文件变化的间隔。
+	pollIntervalDefault = 250 * time.Millisecond
+	// bufferSizeDefault 为默认的行通道缓冲大小。
+	bufferSizeDefault = 64
+	// maxLineSizeDefault 为默认的单行最大字节数。
+	maxLineSizeDefault = 1 << 20
+	// clockDefault 为默认使用的时钟。
+	clockDefault = kittime.NewRealClock()
+)
+
+type (
+	// Option 定义了文件跟踪的配置选项。
+	Option func(*options)
+
+	// options 包含文件跟踪的配置。
+	options struct {
+		// position 是恢复读取的位置，为 nil 时从文件开头或末尾开始。
+		position *Position
+		// fromEnd 表示没有可恢复的位置时是否从文件末尾开始。
+		fromEnd bool
+		// pollInterval 是检查文件变化的间隔。
+		pollInterval time.Duration
+		// bufferSize 是行通道的缓冲大小。
+		bufferSize int
+		// maxLineSize 是单行的最大字节数。
+		maxLineSize int
+		// clock 是计时使用的时钟。
+		clock kittime.Clock
+		// logger 是记录文件轮转与读取错误的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+	}
+)
+
+// WithPosition 设置恢复读取的位置，通常是上次处理的最后一行的 Line.Position。
+// 文件标识与当前文件一致时从 Offset 继续读取；不一致时在同一目录中查找该标识的文件（已被轮转），
+// 找到时先读完该文件的剩余内容再读取当前文件，找不到时从当前文件的开头读取。
+//
+// 参数：
+//   - pos：恢复读取的位置，默认不恢复。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPosition(pos Position) Option {
+	return func(o *options) {
+		o.position = &pos
+	}
+}
+
+// WithFromEnd 设置没有可恢复的位置时是否从文件末尾开始，只读取启动后新写入的行，与 tail -f 一致。
+// 启动后才创建的文件与轮转后的新文件总是从开头读取。
+//
+// 参数：
+//   - fromEnd：是否从文件末尾开始，默认为 false，即从文件开头开始。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithFromEnd(fromEnd bool) Option {
+	return func(o *options) {
+		o.fromEnd = fromEnd
+	}
+}
+
+// WithPollInterval 设置检查文件变化的间隔。
+//
+// 参数：
+//   - interval：检查间隔，默认为 250 毫秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.pollInterval = interval
+	}
+}
+
+// WithBufferSize 设置行通道的缓冲大小。
+//
+// 参数：
+//   - size：缓冲大小，默认为 64，小于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithBufferSize(size int) Option {
+	return func(o *options) {
+		o.bufferSize = size
+	}
+}
+
+// WithMaxLineSize 设置单行的最大字节数，超过时按最大字节数拆分为多行。
+//
+// 参数：
+//   - size：最大字节数，默认为 1MiB，小于 1 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxLineSize(size int) Option {
+	return func(o *options) {
+		o.maxLineSize = size
+	}
+}
+
+// WithClock 设置计时使用的时钟。
+//
+// 参数：
+//   - clock：时钟，默认为系统时钟，为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock kittime.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithLogger 设置记录文件轮转与读取错误的日志实例。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		pollInterval: pollIntervalDefault,
+		bufferSize:   bufferSizeDefault,
+		maxLineSize:  maxLineSizeDefault,
+		clock:        clockDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.pollInterval <= 0 {
+		o.pollInterval = pollIntervalDefault
+	}
+	if o.bufferSize < 0 {
+		o.bufferSize = bufferSizeDefault
+	}
+	if o.maxLineSize < 1 {
+		o.maxLineSize = maxLineSizeDefault
+	}
+	if nil == o.clock {
+		o.clock = clockDefault
+	}
+
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
@@ -0,0 +1,415 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package tailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	stdsync "sync"
+)
+
+const (
+	// readChunkSize 是每次从文件读取的字节数。
+	readChunkSize = 32 * 1024
+)
+
+var (
+	// ErrStarted 表示 Tailer 已经启动过，Tailer 只能启动一次。
+	ErrStarted = errors.New("kit/tailer: 已经启动")
+)
+
+type (
+	// Position 是文件中的读取位置，用于在重启后恢复读取。
+	Position struct {
+		// Inode 是文件的标识，在 unix 上为 inode，在其他平台上为 0。
+		Inode uint64
+		// Offset 是下一次读取的偏移量。
+		Offset int64
+	}
+
+	// Line 是从文件中读取的一行。
+	Line struct {
+		// Text 是行的内容，不包含行尾的 \n 与 \r\n。
+		Text string
+		// Position 是该行之后的位置，处理完该行后保存，重启时通过 WithPosition 从下一行继续读取。
+		Position Position
+	}
+
+	// Tailer 持续跟踪一个文件新写入的行，与 tail -F 一致。
+	// 文件被重命名轮转、通过符号链接切换（例如 kit/log 的按时间轮转）或被删除后重新创建时，
+	// 读完原文件的剩余内容后切换到新文件；文件被截断时从开头重新读取。
+	// Tailer 实现了 kit/runtime 的 Runner 接口，Start 与 Stop 是并发安全的。
+	Tailer struct {
+		// path 是跟踪的文件路径。
+		path string
+		// o 是跟踪的配置。
+		o *options
+		// lines 是输出行的通道，后台协程退出时关闭。
+		lines chan Line
+
+		// mu 保护以下字段。
+		mu stdsync.Mutex
+		// started 表示是否已经启动过。
+		started bool
+		// cancel 停止后台协程，未启动或已停止时为 nil。
+		cancel context.CancelFunc
+		// done 在后台协程退出时关闭。
+		done chan struct{}
+
+		// 以下字段只在后台协程中访问。
+
+		// file 是正在读取的文件，尚未打开时为 nil。
+		file *os.File
+		// info 是正在读取的文件的信息，用于判断路径是否指向了新的文件。
+		info os.FileInfo
+		// id 是正在读取的文件的标识。
+		id uint64
+		// offset 是 pending 第一个字节在文件中的偏移量。
+		offset int64
+		// pending 是已经读取但尚未组成完整行的内容。
+		pending []byte
+		// chunk 是读取文件的缓冲区。
+		chunk []byte
+		// opened 表示是否已经打开过文件，之后打开的文件都是轮转后的新文件，从开头读取。
+		opened bool
+	}
+)
+
+// New 创建一个跟踪文件的 Tailer，文件可以暂不存在。
+//
+// 参数：
+//   - path：文件路径，可以是指向当前文件的符号链接。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Tailer：Tailer 实例，需要调用 Start 启动。
+//
+// 示例：
+//
+//	t := tailer.New("/var/log/app/app.log", tailer.WithPosition(saved))
+//	if err := t.Start(ctx); nil != err {
+//	    return err
+//	}
+//	defer t.Stop(context.Background())
+//
+//	for line := range t.Lines() {
+//	    handle(line.Text)
+//	    saved = line.Position
+//	}
+func New(path string, opts ...Option) *Tailer {
+	o := newOptions(opts...)
+	return &Tailer{
+		path:  path,
+		o:     o,
+		lines: make(chan Line, o.bufferSize),
+	}
+}
+
+// Lines 返回输出行的通道，Tailer 停止后通道被关闭。
+//
+// 返回值：
+//   - <-chan Line：输出行的通道。
+func (t *Tailer) Lines() <-chan Line {
+	return t.lines
+}
+
+// Start 启动后台跟踪后立即返回。文件不存在时等待其被创建。
+//
+// 参数：
+//   - ctx：后台跟踪的生命周期，结束时跟踪停止并关闭 Lines 返回的通道。
+//
+// 返回值：
+//   - error：已经启动过时返回 ErrStarted。
+func (t *Tailer) Start(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.started {
+		return ErrStarted
+	}
+	t.started = true
+
+	ctx, cancel := context.WithCancel(ctx)
+	t.cancel = cancel
+	t.done = make(chan struct{})
+	go t.run(ctx, t.done)
+	return nil
+}
+
+// Stop 停止跟踪并等待后台协程退出，之后 Lines 返回的通道被关闭。未启动时不执行任何操作。
+// 通道中尚未被接收的行仍然可以读取。
+//
+// 参数：
+//   - ctx：停止操作的截止时间。
+//
+// 返回值：
+//   - error：截止时间到达时返回 ctx 的错误。
+func (t *Tailer) Stop(ctx context.Context) error {
+	t.mu.Lock()
+	cancel, done := t.cancel, t.done
+	t.cancel, t.done = nil, nil
+	t.mu.Unlock()
+
+	if nil == cancel {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 是后台跟踪协程，定期读取新写入的内容并检查文件的变化。
+func (t *Tailer) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	defer close(t.lines)
+	defer func() {
+		if nil != t.file {
+			_ = t.file.Close()
+		}
+	}()
+
+	t.chunk = make([]byte, readChunkSize)
+	for {
+		if !t.poll(ctx) {
+			return
+		}
+		select {
+		case <-t.o.clock.After(t.o.pollInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// poll 读取新写入的内容，并处理文件的截断与轮转。
+// 返回 false 表示 ctx 已结束。
+func (t *Tailer) poll(ctx context.Context) bool {
+	if nil == t.file && !t.open() {
+		return true
+	}
+	if !t.read(ctx) {
+		return false
+	}
+
+	if fi, err := t.file.Stat(); nil == err && fi.Size() < t.offset+int64(len(t.pending)) {
+		t.o.getLogger().WithField("path", t.path).Info("kit/tailer: 文件被截断，从开头读取")
+		if _, err := t.file.Seek(0, io.SeekStart); nil != err {
+			t.o.getLogger().WithField("path", t.path).Error("kit/tailer: 读取文件失败：", err)
+			return true
+		}
+		t.offset = 0
+		t.pending = t.pending[:0]
+		if !t.read(ctx) {
+			return false
+		}
+	}
+
+	fi, err := os.Stat(t.path)
+	if nil != err || os.SameFile(t.info, fi) {
+		// 轮转过程中路径可能暂时不存在，继续读取原文件。
+		return true
+	}
+
+	// 路径已经指向新的文件，读完原文件后切换。
+	if !t.read(ctx) || !t.flush(ctx) {
+		return false
+	}
+	_ = t.file.Close()
+	t.file = nil
+	t.o.getLogger().WithField("path", t.path).Info("kit/tailer: 文件已轮转，切换到新文件")
+
+	if !t.open() {
+		return true
+	}
+	return t.read(ctx)
+}
+
+// open 打开路径指向的文件。第一次打开时按配置恢复读取位置，之后从开头读取。
+// 返回 false 表示文件暂时无法打开。
+func (t *Tailer) open() bool {
+	f, fi, err := openFile(t.path)
+	if nil != err {
+		if errors.Is(err, os.ErrNotExist) {
+			// 启动时文件不存在，之后创建的文件从开头读取。
+			t.opened = true
+		} else {
+			t.o.getLogger().WithField("path", t.path).Error("kit/tailer: 打开文件失败：", err)
+		}
+		return false
+	}
+
+	if t.opened {
+		t.use(f, fi, 0)
+		return true
+	}
+	t.opened = true
+
+	pos := t.o.position
+	switch {
+	case nil != pos:
+		id := fileID(fi)
+		if (0 == pos.Inode || pos.Inode == id) && pos.Offset <= fi.Size() {
+			t.use(f, fi, pos.Offset)
+			return true
+		}
+		if 0 != pos.Inode && pos.Inode != id {
+			if rf, rfi, ok := t.findRotated(*pos); ok {
+				_ = f.Close()
+				t.o.getLogger().WithFields(map[string]interface{}{
+					"path":    t.path,
+					"rotated": rf.Name(),
+				}).Info("kit/tailer: 从已轮转的文件恢复读取")
+				t.use(rf, rfi, pos.Offset)
+				return true
+			}
+		}
+		t.use(f, fi, 0)
+	case t.o.fromEnd:
+		t.use(f, fi, fi.Size())
+	default:
+		t.use(f, fi, 0)
+	}
+	return true
+}
+
+// use 从 offset 开始读取文件。
+func (t *Tailer) use(f *os.File, fi os.FileInfo, offset int64) {
+	if 0 < offset {
+		if _, err := f.Seek(offset, io.SeekStart); nil != err {
+			offset = 0
+		}
+	}
+	t.file = f
+	t.info = fi
+	t.id = fileID(fi)
+	t.offset = offset
+	t.pending = t.pending[:0]
+}
+
+// findRotated 在文件所在的目录中查找标识为 pos.Inode 且不小于 pos.Offset 的文件。
+func (t *Tailer) findRotated(pos Position) (*os.File, os.FileInfo, bool) {
+	path, err := filepath.EvalSymlinks(t.path)
+	if nil != err {
+		path = t.path
+	}
+	dir := filepath.Dir(path)
+	entries, err := os.ReadDir(dir)
+	if nil != err {
+		return nil, nil, false
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if nil != err || !info.Mode().IsRegular() || pos.Inode != fileID(info) || info.Size() < pos.Offset {
+			continue
+		}
+		f, fi, err := openFile(filepath.Join(dir, entry.Name()))
+		if nil != err {
+			continue
+		}
+		if pos.Inode == fileID(fi) {
+			return f, fi, true
+		}
+		_ = f.Close()
+	}
+	return nil, nil, false
+}
+
+// read 读取到文件末尾，并输出其中的完整行。返回 false 表示 ctx 已结束。
+func (t *Tailer) read(ctx context.Context) bool {
+	for {
+		n, err := t.file.Read(t.chunk)
+		if 0 < n {
+			t.pending = append(t.pending, t.chunk[:n]...)
+			if !t.emit(ctx) {
+				return false
+			}
+		}
+		if nil != err {
+			if !errors.Is(err, io.EOF) {
+				t.o.getLogger().WithField("path", t.path).Error("kit/tailer: 读取文件失败：", err)
+			}
+			return true
+		}
+		if 0 == n {
+			return true
+		}
+	}
+}
+
+// emit 输出 pending 中的完整行，超过最大字节数的行被拆分。返回 false 表示 ctx 已结束。
+func (t *Tailer) emit(ctx context.Context) bool {
+	rest := t.pending
+	limit := t.o.maxLineSize
+	for {
+		i := bytes.IndexByte(rest, '\n')
+		if (i < 0 && len(rest) > limit) || i > limit {
+			if !t.send(ctx, rest[:limit], int64(limit)) {
+				return false
+			}
+			rest = rest[limit:]
+			continue
+		}
+		if i < 0 {
+			break
+		}
+		if !t.send(ctx, bytes.TrimSuffix(rest[:i], []byte{'\r'}), int64(i+1)) {
+			return false
+		}
+		rest = rest[i+1:]
+	}
+	t.pending = append(t.pending[:0], rest...)
+	return true
+}
+
+// flush 将 pending 中剩余的内容作为最后一行输出。返回 false 表示 ctx 已结束。
+func (t *Tailer) flush(ctx context.Context) bool {
+	if 0 == len(t.pending) {
+		return true
+	}
+	if !t.send(ctx, t.pending, int64(len(t.pending))) {
+		return false
+	}
+	t.pending = t.pending[:0]
+	return true
+}
+
+// send 输出一行，n 是该行在文件中占用的字节数。返回 false 表示 ctx 已结束。
+func (t *Tailer) send(ctx context.Context, text []byte, n int64) bool {
+	line := Line{
+		Text:     string(text),
+		Position: Position{Inode: t.id, Offset: t.offset + n},
+	}
+	select {
+	case t.lines <- line:
+		t.offset += n
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// openFile 打开文件并返回文件信息。
+func openFile(path string) (*os.File, os.FileInfo, error) {
+	f, err := os.Open(path)
+	if nil != err {
+		return nil, nil, err
+	}
+	fi, err := f.Stat()
+	if nil != err {
+		_ = f.Close()
+		return nil, nil, err
+	}
+	return f, fi, nil
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build !unix
+
+package tailer
+
+import (
+	"os"
+)
+
+// fileID 在没有 inode 的平台上返回 0，表示文件标识未知。
+// 运行期间的轮转通过 os.SameFile 判断，不受影响；恢复读取时只能根据文件大小判断。
+func fileID(_ os.FileInfo) uint64 {
+	return 0
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package tailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// startTailer 创建并启动 Tailer，返回其使用的时钟与日志实例。
+func startTailer(t *testing.T, path string, opts ...Option) (*Tailer, *kittime.FakeClock, *logtest.Recorder) {
+	t.Helper()
+	clock := kittime.NewFakeClock(time.Now())
+	logger := logtest.NewRecorder()
+	opts = append([]Option{WithClock(clock), WithLogger(logger), WithPollInterval(time.Second)}, opts...)
+	tl := New(path, opts...)
+	require.NoError(t, tl.Start(context.Background()))
+	t.Cleanup(func() {
+		_ = tl.Stop(context.Background())
+	})
+	return tl, clock, logger
+}
+
+// poll 触发一次检查。
+func poll(clock *kittime.FakeClock) {
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+}
+
+// nextLines 接收 n 行并返回其内容。
+func nextLines(t *testing.T, tl *Tailer, n int) []Line {
+	t.Helper()
+	lines := make([]Line, 0, n)
+	for len(lines) < n {
+		select {
+		case line, ok := <-tl.Lines():
+			require.True(t, ok, "通道已关闭")
+			lines = append(lines, line)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("等待第 %d 行超时", len(lines)+1)
+		}
+	}
+	return lines
+}
+
+// texts 返回行的内容。
+func texts(lines []Line) []string {
+	s := make([]string, 0, len(lines))
+	for _, line := range lines {
+		s = append(s, line.Text)
+	}
+	return s
+}
+
+// appendFile 向文件追加内容。
+func appendFile(t *testing.T, path, s string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(s)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+// inode 返回文件的标识。
+func inode(t *testing.T, path string) uint64 {
+	t.Helper()
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	return fileID(fi)
+}
+
+// TestTailer 测试读取已有内容与新写入的行。
+func TestTailer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "a\nb\r\nc")
+
+	tl, clock, _ := startTailer(t, path)
+	lines := nextLines(t, tl, 2)
+	assert.Equal(t, []string{"a", "b"}, texts(lines))
+	id := inode(t, path)
+	assert.Equal(t, Position{Inode: id, Offset: 2}, lines[0].Position)
+	assert.Equal(t, Position{Inode: id, Offset: 5}, lines[1].Position)
+
+	// 不完整的行等待换行符。
+	appendFile(t, path, "d\ne\n")
+	poll(clock)
+	lines = nextLines(t, tl, 2)
+	assert.Equal(t, []string{"cd", "e"}, texts(lines))
+	assert.Equal(t, int64(10), lines[1].Position.Offset)
+
+	require.NoError(t, tl.Stop(context.Background()))
+	_, ok := <-tl.Lines()
+	assert.False(t, ok)
+	assert.ErrorIs(t, tl.Start(context.Background()), ErrStarted)
+	assert.NoError(t, tl.Stop(context.Background()))
+}
+
+// TestTailerWaitFile 测试等待文件被创建。
+func TestTailerWaitFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	tl, clock, logger := startTailer(t, path, WithFromEnd(true))
+	clock.BlockUntil(1)
+	appendFile(t, path, "first\n")
+	poll(clock)
+	// 文件在启动后才被创建，从开头读取。
+	assert.Equal(t, []string{"first"}, texts(nextLines(t, tl, 1)))
+	assert.False(t, logger.Contains("error"))
+}
+
+// TestTailerFromEnd 测试从文件末尾开始读取。
+func TestTailerFromEnd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "old\n")
+
+	tl, clock, _ := startTailer(t, path, WithFromEnd(true))
+	clock.BlockUntil(1)
+	appendFile(t, path, "new\n")
+	poll(clock)
+	assert.Equal(t, []string{"new"}, texts(nextLines(t, tl, 1)))
+}
+
+// TestTailerRename 测试文件被重命名轮转。
+func TestTailerRename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendFile(t, path, "1\n")
+
+	tl, clock, logger := startTailer(t, path)
+	assert.Equal(t, []string{"1"}, texts(nextLines(t, tl, 1)))
+
+	// 轮转后原文件仍有写入，之后创建新文件。
+	rotated := filepath.Join(dir, "app.log.1")
+	require.NoError(t, os.Rename(path, rotated))
+	appendFile(t, rotated, "2\npartial")
+	poll(clock)
+	appendFile(t, path, "3\n")
+	poll(clock)
+
+	lines := nextLines(t, tl, 3)
+	assert.Equal(t, []string{"2", "partial"}, texts(lines[:2]))
+	assert.Equal(t, "3", lines[2].Text)
+	assert.Equal(t, Position{Inode: inode(t, path), Offset: 2}, lines[2].Position)
+	assert.True(t, logger.Contains("文件已轮转"))
+}
+
+// TestTailerSymlink 测试通过符号链接切换文件，与 kit/log 的按时间轮转一致。
+func TestTailerSymlink(t *testing.T) {
+	if "windows" == runtime.GOOS {
+		t.Skip("创建符号链接需要权限")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	first := filepath.Join(dir, "app-2025010108.log")
+	second := filepath.Join(dir, "app-2025010109.log")
+	appendFile(t, first, "1\n")
+	require.NoError(t, os.Symlink(first, path))
+
+	tl, clock, _ := startTailer(t, path)
+	assert.Equal(t, []string{"1"}, texts(nextLines(t, tl, 1)))
+
+	appendFile(t, second, "2\n")
+	appendFile(t, first, "last\n")
+	tmp := path + ".tmp"
+	require.NoError(t, os.Symlink(second, tmp))
+	require.NoError(t, os.Rename(tmp, path))
+	poll(clock)
+	assert.Equal(t, []string{"last", "2"}, texts(nextLines(t, tl, 2)))
+}
+
+// TestTailerTruncate 测试文件被截断。
+func TestTailerTruncate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "aaaa\nbbbb\n")
+
+	tl, clock, logger := startTailer(t, path)
+	assert.Equal(t, []string{"aaaa", "bbbb"}, texts(nextLines(t, tl, 2)))
+
+	require.NoError(t, os.Truncate(path, 0))
+	appendFile(t, path, "c\n")
+	poll(clock)
+	lines := nextLines(t, tl, 1)
+	assert.Equal(t, "c", lines[0].Text)
+	assert.Equal(t, int64(2), lines[0].Position.Offset)
+	assert.True(t, logger.Contains("文件被截断"))
+}
+
+// TestTailerResume 测试从保存的位置恢复读取。
+func TestTailerResume(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendFile(t, path, "1\n2\n")
+
+	tl, _, _ := startTailer(t, path)
+	saved := nextLines(t, tl, 1)[0].Position
+	require.NoError(t, tl.Stop(context.Background()))
+
+	// 文件未轮转，从下一行继续。
+	appendFile(t, path, "3\n")
+	tl, _, _ = startTailer(t, path, WithPosition(saved))
+	assert.Equal(t, []string{"2", "3"}, texts(nextLines(t, tl, 2)))
+	require.NoError(t, tl.Stop(context.Background()))
+
+	// 停止期间文件被轮转，先读完已轮转的文件。
+	if "windows" != runtime.GOOS {
+		require.NoError(t, os.Rename(path, filepath.Join(dir, "app.log.1")))
+		appendFile(t, path, "4\n")
+		tl, _, logger := startTailer(t, path, WithPosition(saved))
+		assert.Equal(t, []string{"2", "3", "4"}, texts(nextLines(t, tl, 3)))
+		assert.True(t, logger.Contains("从已轮转的文件恢复读取"))
+		require.NoError(t, tl.Stop(context.Background()))
+	}
+
+	// 找不到对应的文件或位置超出文件大小时从开头读取。
+	tl, _, _ = startTailer(t, path, WithPosition(Position{Inode: saved.Inode + 1000, Offset: 100}))
+	assert.Equal(t, "4", nextLines(t, tl, 1)[0].Text)
+	require.NoError(t, tl.Stop(context.Background()))
+	tl, _, _ = startTailer(t, path, WithPosition(Position{Offset: 100}))
+	assert.Equal(t, "4", nextLines(t, tl, 1)[0].Text)
+}
+
+// TestTailerMaxLineSize 测试超长的行被拆分。
+func TestTailerMaxLineSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "abcdefg\nxyz\n")
+
+	tl, _, _ := startTailer(t, path, WithMaxLineSize(3))
+	lines := nextLines(t, tl, 4)
+	assert.Equal(t, []string{"abc", "def", "g", "xyz"}, texts(lines))
+	assert.Equal(t, []int64{3, 6, 8, 12}, []int64{
+		lines[0].Position.Offset, lines[1].Position.Offset, lines[2].Position.Offset, lines[3].Position.Offset,
+	})
+}
+
+// TestTailerStopBlocked 测试接收方不再读取时停止。
+func TestTailerStopBlocked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, strings.Repeat("line\n", 10))
+
+	tl, _, _ := startTailer(t, path, WithBufferSize(0))
+	nextLines(t, tl, 1)
+	require.NoError(t, tl.Stop(context.Background()))
+	for range tl.Lines() {
+	}
+}
+
+// TestTailerReadError 测试读取失败时记录日志。
+func TestTailerReadError(t *testing.T) {
+	if "windows" == runtime.GOOS {
+		t.Skip("目录的读取行为不同")
+	}
+	tl, _, logger := startTailer(t, t.TempDir())
+	require.Eventually(t, func() bool {
+		return logger.Contains("读取文件失败")
+	}, 5*time.Second, time.Millisecond)
+	require.NoError(t, tl.Stop(context.Background()))
+}
+
+// TestOptions 测试配置选项与默认值。
+func TestOptions(t *testing.T) {
+	o := newOptions()
+	assert.Nil(t, o.position)
+	assert.False(t, o.fromEnd)
+	assert.Equal(t, pollIntervalDefault, o.pollInterval)
+	assert.Equal(t, bufferSizeDefault, o.bufferSize)
+	assert.Equal(t, maxLineSizeDefault, o.maxLineSize)
+	assert.Equal(t, clockDefault, o.clock)
+	assert.Equal(t, kitlog.GetLogger(), o.getLogger())
+
+	o = newOptions(WithPollInterval(0), WithBufferSize(-1), WithMaxLineSize(0), WithClock(nil))
+	assert.Equal(t, pollIntervalDefault, o.pollInterval)
+	assert.Equal(t, bufferSizeDefault, o.bufferSize)
+	assert.Equal(t, maxLineSizeDefault, o.maxLineSize)
+	assert.Equal(t, clockDefault, o.clock)
+}
@@ -0,0 +1,20 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build unix
+
+package tailer
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileID 返回文件的 inode。
+func fileID(fi os.FileInfo) uint64 {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		return uint64(st.Ino)
+	}
+	return 0
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"text/tabwriter"
+)
+
+type (
+	// BenchCase 定义了一个参与对比的基准测试。
+	BenchCase struct {
+		// Name 是基准测试的名称。
+		Name string
+		// Fn 是基准测试函数。
+		Fn func(b *testing.B)
+	}
+
+	// BenchResult 是一个基准测试的运行结果。
+	BenchResult struct {
+		// Name 是基准测试的名称。
+		Name string
+		// BenchmarkResult 是 testing.Benchmark 返回的原始结果，Extra 中包含通过 ReportMetric 上报的自定义指标。
+		testing.BenchmarkResult
+	}
+)
+
+// Bench 以统一的方式运行基准测试循环：开启内存分配统计、重置计时器，并执行 b.N 次 fn。
+//
+// 参数：
+//   - b *testing.B：当前基准测试实例。
+//   - fn func()：每次迭代执行的函数。
+//
+// 示例：
+//
+//	func BenchmarkEncode(b *testing.B) {
+//	    testing.Bench(b, func() { encode(data) })
+//	}
+func Bench(b *testing.B, fn func()) {
+	b.Helper()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fn()
+	}
+}
+
+// BenchParallel 与 Bench 相同，但通过 b.RunParallel 在多个协程中并发执行 fn。
+//
+// 参数：
+//   - b *testing.B：当前基准测试实例。
+//   - fn func()：每次迭代执行的函数，需要是并发安全的。
+func BenchParallel(b *testing.B, fn func()) {
+	b.Helper()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			fn()
+		}
+	})
+}
+
+// ReportThroughput 上报每秒处理量指标，例如协程池的 tasks/s 或日志的 entries/s。
+// 应在计时结束后（基准测试函数返回前）调用，吞吐量按 b.Elapsed 计算。
+//
+// 参数：
+//   - b *testing.B：当前基准测试实例。
+//   - count int：计时期间处理的总数量。
+//   - unit string：处理对象的单位，例如 "tasks"，上报的指标名为 "tasks/s"。
+func ReportThroughput(b *testing.B, count int, unit string) {
+	b.Helper()
+
+	if elapsed := b.Elapsed(); elapsed > 0 {
+		b.ReportMetric(float64(count)/elapsed.Seconds(), unit+"/s")
+	}
+}
+
+// RunBenchmarks 依次运行多个基准测试并返回结果，通常与 PrintComparison 一起在普通测试中使用，
+// 用于对比同一功能不同实现的性能。
+//
+// 参数：
+//   - cases ...BenchCase：要运行的基准测试。
+//
+// 返回值：
+//   - []BenchResult：与 cases 顺序一致的运行结果。
+func RunBenchmarks(cases ...BenchCase) []BenchResult {
+	results := make([]BenchResult, 0, len(cases))
+	for _, c := range cases {
+		results = append(results, BenchResult{
+			Name:            c.Name,
+			BenchmarkResult: testing.Benchmark(c.Fn),
+		})
+	}
+	return results
+}
+
+// PrintComparison 通过默认输出器以表格形式输出基准测试结果，并以第一个结果为基准计算相对变化。
+//
+// 参数：
+//   - results []BenchResult：要对比的结果。
+//
+// 示例：
+//
+//	testing.PrintComparison(testing.RunBenchmarks(
+//	    testing.BenchCase{Name: "mutex", Fn: benchMutex},
+//	    testing.BenchCase{Name: "atomic", Fn: benchAtomic},
+//	))
+func PrintComparison(results []BenchResult) {
+	var buf bytes.Buffer
+	WriteComparison(&buf, results)
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		Println(line)
+	}
+}
+
+// WriteComparison 将基准测试结果以表格形式写入 w。
+// 每行包含 ns/op、B/op、allocs/op 以及所有自定义指标，除第一行外，ns/op 之后附带相对第一行的变化百分比。
+//
+// 参数：
+//   - w io.Writer：输出目标。
+//   - results []BenchResult：要对比的结果。
+func WriteComparison(w io.Writer, results []BenchResult) {
+	if 0 == len(results) {
+		return
+	}
+
+	// 汇总所有结果中出现过的自定义指标，按名称排序保证列顺序稳定。
+	extraSet := make(map[string]struct{})
+	for _, r := range results {
+		for name := range r.Extra {
+			extraSet[name] = struct{}{}
+		}
+	}
+	extras := make([]string, 0, len(extraSet))
+	for name := range extraSet {
+		extras = append(extras, name)
+	}
+	sort.Strings(extras)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := []string{"name", "ns/op", "delta", "B/op", "allocs/op"}
+	header = append(header, extras...)
+	_, _ = fmt.Fprintln(tw, strings.Join(header, "\t")+"\t")
+
+	base := results[0]
+	for i, r := range results {
+		delta := "-"
+		if i > 0 && base.NsPerOp() > 0 {
+			delta = fmt.Sprintf("%+.2f%%", (float64(r.NsPerOp())/float64(base.NsPerOp())-1)*100)
+		}
+		row := []string{
+			r.Name,
+			fmt.Sprintf("%d", r.NsPerOp()),
+			delta,
+			fmt.Sprintf("%d", r.AllocedBytesPerOp()),
+			fmt.Sprintf("%d", r.AllocsPerOp()),
+		}
+		for _, name := range extras {
+			if v, ok := r.Extra[name]; ok {
+				row = append(row, fmt.Sprintf("%.2f", v))
+			} else {
+				row = append(row, "-")
+			}
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(row, "\t")+"\t")
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteComparison(t *testing.T) {
+	results := []BenchResult{
+		{Name: "base", BenchmarkResult: testing.BenchmarkResult{
+			N: 100, T: 100 * time.Microsecond, MemAllocs: 200, MemBytes: 1600,
+			Extra: map[string]float64{"tasks/s": 1000},
+		}},
+		{Name: "fast", BenchmarkResult: testing.BenchmarkResult{
+			N: 100, T: 50 * time.Microsecond,
+		}},
+	}
+
+	var buf bytes.Buffer
+	WriteComparison(&buf, results)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines: %q", len(lines), buf.String())
+	}
+
+	tests := []struct {
+		line   string
+		fields []string
+	}{
+		{lines[0], []string{"name", "ns/op", "delta", "B/op", "allocs/op", "tasks/s"}},
+		{lines[1], []string{"base", "1000", "-", "16", "2", "1000.00"}},
+		{lines[2], []string{"fast", "500", "-50.00%", "0", "0", "-"}},
+	}
+	for _, tt := range tests {
+		if got := strings.Fields(tt.line); strings.Join(got, " ") != strings.Join(tt.fields, " ") {
+			t.Errorf("line = %q, want fields %q", tt.line, tt.fields)
+		}
+	}
+
+	buf.Reset()
+	WriteComparison(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("empty results should write nothing, got %q", buf.String())
+	}
+}
+
+func TestRunBenchmarks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping benchmark run in short mode")
+	}
+
+	results := RunBenchmarks(BenchCase{Name: "count", Fn: func(b *testing.B) {
+		count := 0
+		Bench(b, func() { count++ })
+		ReportThroughput(b, count, "items")
+	}})
+
+	if len(results) != 1 || results[0].Name != "count" {
+		t.Fatalf("results = %+v", results)
+	}
+	if results[0].N == 0 {
+		t.Error("benchmark should run at least once")
+	}
+	if results[0].Extra["items/s"] <= 0 {
+		t.Errorf("Extra = %v, want items/s", results[0].Extra)
+	}
+}
+
+func BenchmarkBenchParallel(b *testing.B) {
+	BenchParallel(b, func() { _ = strings.Repeat("x", 8) })
+}
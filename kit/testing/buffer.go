@@ -0,0 +1,117 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+var (
+	// testBuffers 保存开启了缓冲输出的协程，键为协程 ID，值为 *testBuffer。
+	testBuffers sync.Map
+	// testBufferCount 是已注册的缓冲数量，为 0 时输出无需查询协程 ID。
+	// 协程 ID 通过解析堆栈获取，不依赖 GetGoID 的运行时结构体偏移量，在任意 Go 版本下都可靠，
+	// 其开销只在存在缓冲时产生。
+	testBufferCount atomic.Int64
+)
+
+type (
+	// testBuffer 缓存单个测试的输出。
+	testBuffer struct {
+		// mu 保护 chunks。
+		mu sync.Mutex
+		// name 是测试名，刷新时作为每行的前缀。
+		name string
+		// chunks 是按顺序缓存的输出。
+		chunks []bufferedChunk
+	}
+
+	// bufferedChunk 是一次被缓存的输出。
+	bufferedChunk struct {
+		// out 是原本的输出目标。
+		out io.Writer
+		// data 是输出内容。
+		data string
+		// raw 表示刷新时不添加测试名前缀，用于 JSON 输出（JSON 中已包含测试名）。
+		raw bool
+	}
+)
+
+// BufferOutput 为当前测试开启缓冲输出：测试所在协程通过 Println、Printf 等函数产生的输出先被缓存，
+// 测试结束时以 "[测试名] " 为前缀一次性写出，使并行测试的输出不再交错、可以区分来源。
+// 缓冲以协程为单位，测试中启动的其他协程的输出仍会直接写出。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//
+// 示例：
+//
+//	func TestWorker(t *testing.T) {
+//	    t.Parallel()
+//	    testing.BufferOutput(t)
+//	    testing.Println("开始处理")
+//	}
+func BufferOutput(t testing.TB) {
+	t.Helper()
+
+	id := goroutine.GetGoIDSlow()
+	buf := &testBuffer{name: t.Name()}
+	if _, loaded := testBuffers.LoadOrStore(id, buf); loaded {
+		// 同一测试重复调用时沿用已有的缓冲。
+		return
+	}
+	testBufferCount.Add(1)
+
+	t.Cleanup(func() {
+		testBuffers.Delete(id)
+		testBufferCount.Add(-1)
+		buf.flush()
+	})
+}
+
+// bufferOutput 在当前协程开启了缓冲输出时缓存 data 并返回 true，否则返回 false。
+func bufferOutput(out io.Writer, data string, raw bool) bool {
+	if 0 == testBufferCount.Load() {
+		return false
+	}
+	v, ok := testBuffers.Load(goroutine.GetGoIDSlow())
+	if !ok {
+		return false
+	}
+
+	buf := v.(*testBuffer)
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+	buf.chunks = append(buf.chunks, bufferedChunk{out: out, data: data, raw: raw})
+	return true
+}
+
+// flush 将缓存的输出写到各自的输出目标，非 JSON 输出的每一行都会加上测试名前缀。
+func (b *testBuffer) flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	prefix := "[" + b.name + "] "
+	for _, c := range b.chunks {
+		data := c.data
+		if !c.raw {
+			lines := strings.SplitAfter(data, "\n")
+			for i, line := range lines {
+				if "" != line {
+					lines[i] = prefix + line
+				}
+			}
+			data = strings.Join(lines, "")
+		}
+		_, _ = io.WriteString(c.out, data)
+	}
+	b.chunks = nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// syncBuffer 是并发安全的 bytes.Buffer。
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+func TestBufferOutput(t *testing.T) {
+	var out syncBuffer
+	p := NewPrinter(WithWriter(&out), WithPrefix("> "), WithColor(ColorNever))
+
+	t.Run("sub", func(t *testing.T) {
+		BufferOutput(t)
+		BufferOutput(t)
+		p.Println("first")
+		p.Printf("a\nb\n")
+
+		// 其他协程的输出不经过缓冲。
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.Println("direct")
+		}()
+		wg.Wait()
+
+		if got := out.String(); got != "> direct\n" {
+			t.Errorf("output before test end = %q, want only direct output", got)
+		}
+	})
+
+	want := "> direct\n[TestBufferOutput/sub] > first\n[TestBufferOutput/sub] > a\n[TestBufferOutput/sub] b\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	// 测试结束后不再缓冲。
+	p.Println("after")
+	if got := out.String(); !strings.HasSuffix(got, "> after\n") {
+		t.Errorf("output after cleanup = %q", got)
+	}
+}
+
+func TestBufferOutput_JSON(t *testing.T) {
+	var out syncBuffer
+	p := NewPrinter(WithWriter(&out), WithFormat(FormatJSON))
+
+	t.Run("sub", func(t *testing.T) {
+		BufferOutput(t)
+		p.Println("x")
+		if "" != out.String() {
+			t.Errorf("output should be buffered, got %q", out.String())
+		}
+	})
+
+	if got := out.String(); !strings.HasPrefix(got, "{") || !strings.Contains(got, `"message":"x"`) {
+		t.Errorf("JSON output should be flushed without prefix, got %q", got)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"time"
+
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+var (
+	// clockStartDefault 定义了 NewClock 未指定起始时间时使用的固定时间。
+	// 使用固定值保证测试输出可复现。
+	clockStartDefault = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type (
+	// Clock 是一个可控的时钟测试替身。
+	// 时间只会在调用 Advance 或 Set 时前进，Sleep、After、Timer、Ticker
+	// 都在时间前进到期望点时才被触发，使依赖时间的代码可以被即时、确定地测试。
+	// Clock 的所有方法都是并发安全的。
+	//
+	// Clock 基于 kit/time 的 FakeClock 实现，Timer 与 Ticker 保留了与标准库一致的 C 字段。
+	// 向通过 WithClock 选项接收 kit/time.Clock 的组件注入时，使用其中的 FakeClock。
+	Clock struct {
+		// FakeClock 是实际的可控时钟，实现了 kit/time 的 Clock 接口。
+		*kittime.FakeClock
+	}
+
+	// Timer 是 Clock 创建的定时器，语义与 time.Timer 一致。
+	Timer struct {
+		// C 是定时器触发时接收时间的通道。
+		C <-chan time.Time
+
+		timer kittime.Timer
+	}
+
+	// Ticker 是 Clock 创建的周期定时器，语义与 time.Ticker 一致。
+	// 与 time.Ticker 相同，接收方来不及读取时多余的触发会被丢弃。
+	Ticker struct {
+		// C 是每次触发时接收时间的通道。
+		C <-chan time.Time
+
+		ticker kittime.Ticker
+	}
+)
+
+// NewClock 创建一个新的可控时钟。
+//
+// 参数：
+//   - start time.Time：时钟的起始时间，零值表示使用固定的默认时间 2025-01-01 00:00:00 UTC。
+//
+// 返回值：
+//   - *Clock：新的时钟实例。
+//
+// 示例：
+//
+//	clock := testing.NewClock(time.Time{})
+//	ch := clock.After(time.Second)
+//	clock.Advance(time.Second)
+//	<-ch
+//
+//	// 注入到接收 kit/time.Clock 的组件。
+//	err := retry.Retry(fn, retry.WithClock(clock.FakeClock))
+func NewClock(start time.Time) *Clock {
+	if start.IsZero() {
+		start = clockStartDefault
+	}
+	return &Clock{FakeClock: kittime.NewFakeClock(start)}
+}
+
+// NewTimer 创建一个在时钟前进 d 之后触发一次的定时器。
+//
+// 参数：
+//   - d time.Duration：触发时长。
+//
+// 返回值：
+//   - *Timer：新的定时器。
+func (c *Clock) NewTimer(d time.Duration) *Timer {
+	t := c.FakeClock.NewTimer(d)
+	return &Timer{C: t.C(), timer: t}
+}
+
+// NewTicker 创建一个每当时钟前进 d 就触发一次的周期定时器。
+// 与 time.NewTicker 一致，d 必须大于 0，否则会 panic。
+//
+// 参数：
+//   - d time.Duration：触发间隔。
+//
+// 返回值：
+//   - *Ticker：新的周期定时器。
+func (c *Clock) NewTicker(d time.Duration) *Ticker {
+	t := c.FakeClock.NewTicker(d)
+	return &Ticker{C: t.C(), ticker: t}
+}
+
+// Stop 停止定时器。
+//
+// 返回值：
+//   - bool：定时器在触发前被停止时返回 true，已触发或已停止时返回 false。
+func (t *Timer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// Reset 将定时器重置为在时钟前进 d 之后触发。
+//
+// 参数：
+//   - d time.Duration：新的触发时长。
+//
+// 返回值：
+//   - bool：定时器在重置前仍处于活动状态时返回 true。
+func (t *Timer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
+
+// Stop 停止周期定时器，之后不会再有新的触发。
+func (t *Ticker) Stop() {
+	t.ticker.Stop()
+}
+
+// Reset 停止周期定时器并将触发间隔重置为 d，下一次触发在时钟前进 d 之后。
+// 与 time.Ticker 一致，d 必须大于 0，否则会 panic。
+//
+// 参数：
+//   - d time.Duration：新的触发间隔。
+func (t *Ticker) Reset(d time.Duration) {
+	t.ticker.Reset(d)
+}
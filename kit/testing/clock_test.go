@@ -0,0 +1,166 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"testing"
+	"time"
+)
+
+// received 非阻塞地检查通道是否已有值。
+func received(c <-chan time.Time) (time.Time, bool) {
+	select {
+	case v := <-c:
+		return v, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestClock_NowAndSet(t *testing.T) {
+	clock := NewClock(time.Time{})
+	if !clock.Now().Equal(clockStartDefault) {
+		t.Fatalf("Now = %v, want %v", clock.Now(), clockStartDefault)
+	}
+
+	start := clock.Now()
+	clock.Advance(3 * time.Second)
+	if got := clock.Since(start); got != 3*time.Second {
+		t.Errorf("Since = %v, want %v", got, 3*time.Second)
+	}
+
+	target := start.Add(-time.Hour)
+	clock.Set(target)
+	if !clock.Now().Equal(target) {
+		t.Errorf("Set backwards: Now = %v, want %v", clock.Now(), target)
+	}
+}
+
+func TestClock_After(t *testing.T) {
+	clock := NewClock(time.Time{})
+	start := clock.Now()
+
+	ch := clock.After(time.Second)
+	if _, ok := received(ch); ok {
+		t.Fatal("After fired before Advance")
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if _, ok := received(ch); ok {
+		t.Fatal("After fired too early")
+	}
+
+	clock.Advance(time.Second)
+	v, ok := received(ch)
+	if !ok {
+		t.Fatal("After did not fire")
+	}
+	// 触发时间应为到期时间点，而不是推进后的时间。
+	if want := start.Add(time.Second); !v.Equal(want) {
+		t.Errorf("fired at %v, want %v", v, want)
+	}
+	if clock.Waiters() != 0 {
+		t.Errorf("Waiters = %d, want 0", clock.Waiters())
+	}
+
+	if _, ok := received(clock.After(0)); !ok {
+		t.Error("After(0) should fire immediately")
+	}
+}
+
+func TestClock_Sleep(t *testing.T) {
+	clock := NewClock(time.Time{})
+	done := make(chan struct{})
+
+	go func() {
+		clock.Sleep(time.Minute)
+		close(done)
+	}()
+
+	clock.BlockUntil(1)
+	select {
+	case <-done:
+		t.Fatal("Sleep returned before Advance")
+	default:
+	}
+
+	clock.Advance(time.Minute)
+	<-done
+}
+
+func TestClock_Timer(t *testing.T) {
+	clock := NewClock(time.Time{})
+
+	timer := clock.NewTimer(time.Second)
+	if !timer.Stop() {
+		t.Error("Stop on active timer should return true")
+	}
+	clock.Advance(2 * time.Second)
+	if _, ok := received(timer.C); ok {
+		t.Error("stopped timer fired")
+	}
+	if timer.Stop() {
+		t.Error("Stop on stopped timer should return false")
+	}
+
+	if timer.Reset(time.Second) {
+		t.Error("Reset on stopped timer should return false")
+	}
+	clock.Advance(time.Second)
+	if _, ok := received(timer.C); !ok {
+		t.Error("reset timer did not fire")
+	}
+}
+
+func TestClock_Ticker(t *testing.T) {
+	clock := NewClock(time.Time{})
+	start := clock.Now()
+
+	ticker := clock.NewTicker(time.Second)
+	for i := 1; i <= 3; i++ {
+		clock.Advance(time.Second)
+		v, ok := received(ticker.C)
+		if !ok {
+			t.Fatalf("tick %d not received", i)
+		}
+		if want := start.Add(time.Duration(i) * time.Second); !v.Equal(want) {
+			t.Errorf("tick %d at %v, want %v", i, v, want)
+		}
+	}
+
+	// 一次推进跨越多个周期时，未读取的触发会被丢弃，只保留一个。
+	clock.Advance(5 * time.Second)
+	if _, ok := received(ticker.C); !ok {
+		t.Fatal("tick not received after large advance")
+	}
+	if _, ok := received(ticker.C); ok {
+		t.Error("ticker should drop ticks for slow receivers")
+	}
+
+	ticker.Reset(10 * time.Second)
+	clock.Advance(5 * time.Second)
+	if _, ok := received(ticker.C); ok {
+		t.Error("ticker fired before reset interval")
+	}
+	clock.Advance(5 * time.Second)
+	if _, ok := received(ticker.C); !ok {
+		t.Error("ticker did not fire after reset interval")
+	}
+
+	ticker.Stop()
+	clock.Advance(time.Minute)
+	if _, ok := received(ticker.C); ok {
+		t.Error("stopped ticker fired")
+	}
+}
+
+func TestClock_NewTickerPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); nil == r {
+			t.Error("NewTicker(0) should panic")
+		}
+	}()
+	NewClock(time.Time{}).NewTicker(0)
+}
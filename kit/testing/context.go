@@ -0,0 +1,115 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+var (
+	// contextMarginDefault 定义了上下文截止时间相对测试截止时间的默认提前量。
+	// 提前结束的上下文让被测代码先于测试框架超时返回，测试因此能够输出有意义的失败信息，
+	// 而不是被 go test -timeout 直接终止并打印全部协程堆栈。
+	contextMarginDefault = 5 * time.Second
+)
+
+type (
+	// ContextOption 定义了测试上下文的配置选项。
+	ContextOption func(*contextOptions)
+
+	// contextOptions 包含测试上下文的配置。
+	contextOptions struct {
+		// margin 是上下文截止时间相对测试截止时间的提前量。
+		margin time.Duration
+		// timeout 是上下文的最长存活时间，为 0 表示不限制。
+		timeout time.Duration
+	}
+
+	// deadliner 是提供测试截止时间的测试实例，*testing.T 和 *testing.F 实现了该接口。
+	deadliner interface {
+		Deadline() (time.Time, bool)
+	}
+)
+
+// WithDeadlineMargin 设置上下文截止时间相对测试截止时间的提前量。
+//
+// 参数：
+//   - margin：提前量。
+//
+// 返回值：
+//   - ContextOption：配置选项函数。
+func WithDeadlineMargin(margin time.Duration) ContextOption {
+	return func(o *contextOptions) {
+		o.margin = margin
+	}
+}
+
+// WithContextTimeout 设置上下文的最长存活时间，与测试截止时间共同生效，以先到者为准。
+//
+// 参数：
+//   - timeout：最长存活时间。
+//
+// 返回值：
+//   - ContextOption：配置选项函数。
+func WithContextTimeout(timeout time.Duration) ContextOption {
+	return func(o *contextOptions) {
+		o.timeout = timeout
+	}
+}
+
+// Context 返回与当前测试绑定的上下文。
+// 上下文在测试结束（t.Cleanup）时取消；如果测试设置了截止时间（go test -timeout），
+// 上下文会在截止时间前提前一段时间（默认 5 秒）超时。剩余时间不足两倍提前量时，提前量缩减为剩余时间的一半。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - opts ...ContextOption：可选的配置选项。
+//
+// 返回值：
+//   - context.Context：与测试绑定的上下文。
+//
+// 示例：
+//
+//	ctx := testing.Context(t, testing.WithContextTimeout(3*time.Second))
+//	err := retry.RetryWithContext(ctx, fn)
+func Context(t testing.TB, opts ...ContextOption) context.Context {
+	t.Helper()
+
+	o := &contextOptions{
+		margin: contextMarginDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var deadline time.Time
+	if d, ok := t.(deadliner); ok {
+		if testDeadline, ok := d.Deadline(); ok {
+			margin := o.margin
+			if remaining := time.Until(testDeadline); remaining < 2*margin {
+				margin = remaining / 2
+			}
+			deadline = testDeadline.Add(-margin)
+		}
+	}
+	if o.timeout > 0 {
+		if timeoutDeadline := time.Now().Add(o.timeout); deadline.IsZero() || timeoutDeadline.Before(deadline) {
+			deadline = timeoutDeadline
+		}
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if deadline.IsZero() {
+		ctx, cancel = context.WithCancel(context.Background())
+	} else {
+		ctx, cancel = context.WithDeadline(context.Background(), deadline)
+	}
+	t.Cleanup(cancel)
+
+	return ctx
+}
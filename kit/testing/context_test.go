@@ -0,0 +1,78 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// deadlineTB 提供可控的测试截止时间。
+type deadlineTB struct {
+	*cleanupTB
+	deadline time.Time
+}
+
+func (d *deadlineTB) Deadline() (time.Time, bool) {
+	return d.deadline, !d.deadline.IsZero()
+}
+
+func TestContext_CancelledOnCleanup(t *testing.T) {
+	var ctx context.Context
+	t.Run("sub", func(t *testing.T) {
+		ctx = Context(t)
+		if nil != ctx.Err() {
+			t.Fatalf("ctx.Err() = %v, want nil", ctx.Err())
+		}
+	})
+	if ctx.Err() != context.Canceled {
+		t.Errorf("ctx.Err() = %v, want Canceled", ctx.Err())
+	}
+}
+
+func TestContext_Deadline(t *testing.T) {
+	tests := []struct {
+		name      string
+		remaining time.Duration
+		opts      []ContextOption
+		want      time.Duration
+	}{
+		{name: "没有测试截止时间", remaining: 0, want: 0},
+		{name: "默认提前量", remaining: time.Minute, want: time.Minute - contextMarginDefault},
+		{name: "自定义提前量", remaining: time.Minute, opts: []ContextOption{WithDeadlineMargin(time.Second)}, want: time.Minute - time.Second},
+		{name: "剩余时间不足", remaining: 4 * time.Second, want: 2 * time.Second},
+		{name: "超时早于截止时间", remaining: time.Minute, opts: []ContextOption{WithContextTimeout(time.Second)}, want: time.Second},
+		{name: "仅超时", remaining: 0, opts: []ContextOption{WithContextTimeout(time.Second)}, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cleanups []func()
+			tb := &deadlineTB{cleanupTB: &cleanupTB{recordTB: newRecordTB(t), cleanups: &cleanups}}
+			now := time.Now()
+			if tt.remaining > 0 {
+				tb.deadline = now.Add(tt.remaining)
+			}
+
+			ctx := Context(tb, tt.opts...)
+			deadline, ok := ctx.Deadline()
+			if tt.want == 0 {
+				if ok {
+					t.Errorf("unexpected deadline %v", deadline)
+				}
+			} else if diff := deadline.Sub(now) - tt.want; diff < -100*time.Millisecond || diff > 100*time.Millisecond {
+				t.Errorf("deadline in %v, want %v", deadline.Sub(now), tt.want)
+			}
+
+			for _, fn := range cleanups {
+				fn()
+			}
+			if nil == ctx.Err() {
+				t.Error("ctx should be cancelled by cleanup")
+			}
+		})
+	}
+}
@@ -3,8 +3,188 @@
 // Licensed under the MIT License. See LICENSE file in the project root for full license information.
 
 /*
-Package testing 提供了一组用于测试时输出日志的辅助函数。
-这个包封装了标准库 fmt 包的功能，并在输出内容前添加统一的日志前缀，
+Package testing 提供了一组编写测试时使用的辅助函数。
+
+日志输出：
+
+Println 与 Printf 封装了标准库 fmt 包的功能，并在输出内容前添加统一的日志前缀，
 使测试输出更加清晰和易于识别。
+
+文件辅助：
+
+TempDir、TempFile 创建预置内容的临时目录和文件，并通过 t.TempDir 在测试结束时自动清理；
+WriteFile、ReadFile 在失败时直接终止测试；AssertFileContent、AssertFileExists 用于断言文件状态。
+
+	dir := testing.TempDir(t, map[string]string{"conf/app.yaml": "level: debug"})
+	testing.AssertFileContent(t, filepath.Join(dir, "conf/app.yaml"), "level: debug")
+
+内存文件系统：
+
+MemFS 是内存中的可写文件系统，实现了 kit/fs 的 FS 接口以及 io/fs.FS，
+通过 WithFS 等选项注入日志、配置等组件后，依赖文件路径的行为不需要读写真实的磁盘即可测试。
+
+	fsys := testing.NewMemFS()
+	logger, _ := log.NewLogger(log.WithOutput("/var/log/app.log"), log.WithFS(fsys))
+	logger.Info("started")
+	data, _ := fsys.ReadFile("/var/log/app.log")
+
+可控时钟：
+
+Clock 是一个只在调用 Advance 或 Set 时前进的时钟测试替身，提供 Now、Sleep、After、
+NewTimer、NewTicker 等与标准库对应的方法，使依赖时间的代码无需真实等待即可被确定地测试。
+Clock 基于 kit/time 的 FakeClock 实现，向提供 WithClock 选项的组件注入时使用 clock.FakeClock。
+
+	clock := testing.NewClock(time.Time{})
+	go worker(clock)        // worker 内部调用 clock.Sleep(time.Minute)
+	clock.BlockUntil(1)     // 等待 worker 进入 Sleep
+	clock.Advance(time.Minute)
+
+异步断言：
+
+Eventually 基于 kit/runtime/retry 的退避策略轮询条件，直到条件成立或超时；
+Consistently 以固定间隔检查条件在一段时间内始终成立。二者用于替代 "time.Sleep 后断言" 的写法。
+
+	testing.Eventually(t, func() bool { return pool.Waiting() == 1 }, time.Second, time.Millisecond)
+
+协程泄漏检查：
+
+VerifyNoLeaks 在测试开始时记录现有协程，测试结束时报告新增且未退出的协程堆栈；
+VerifyTestMain 在 TestMain 中对整个包做同样的检查。协程池指标采集等已知的后台协程默认被忽略，
+也可以通过 IgnoreFunction 追加忽略项。
+
+	func TestWorker(t *testing.T) {
+	    testing.VerifyNoLeaks(t)
+	    ...
+	}
+
+网络测试辅助：
+
+FreePort 分配空闲端口，Listen 创建随测试关闭的监听器，WaitForAddr 等待地址可以建立连接；
+NewHTTPServer 与 StartTCPServer 启动测试服务器，并在测试结束时自动关闭。
+
+	srv := testing.NewHTTPServer(t, promhttp.Handler())
+	resp, err := http.Get(srv.URL + "/metrics")
+
+外部服务：
+
+RequireService 准备集成测试依赖的外部服务并等待其就绪。设置了 KIT_TESTING_<服务名称>_ADDR 时连接已有实例，
+否则通过 docker 启动容器并在测试结束时删除；短模式或无法使用 docker 时跳过测试。
+PostgresService、RedisService 与 KafkaService 返回预定义的服务。
+
+	pg := testing.RequireService(t, testing.PostgresService())
+	db, err := sql.Open("pgx", pg.URL)
+
+前缀与着色：
+
+SetPrefix 修改包级别输出的前缀，Info、Warn、Error 等函数输出带级别标签的内容，并在终端下按级别着色；
+输出目标不是终端或设置了 NO_COLOR 环境变量时自动禁用颜色。需要独立配置时可以通过 NewPrinter 创建输出器。
+
+	testing.SetPrefix("[mypkg] ")
+	testing.Warnf("重试第 %d 次", 3)
+
+JSON 输出：
+
+设置环境变量 KIT_TESTING_FORMAT=json，或调用 SetFormat(FormatJSON)，所有输出都会变为每行一个 JSON 对象，
+包含 time、level、test、message 字段。测试名优先取自 ForTest 关联的测试，否则从调用栈中识别顶层测试函数。
+
+	{"time":"2025-01-01T00:00:00Z","level":"info","test":"TestWorker","message":"开始执行"}
+
+段落与步骤：
+
+Section 开始一个段落并返回结束函数，段落内的所有输出增加一级缩进，结束时输出段落耗时；
+Step 在当前段落中输出一个步骤。段落可以嵌套，使多阶段集成测试的输出更易阅读。
+
+	defer testing.Section("启动服务")()
+	testing.Step("监听端口 %d", port)
+
+作用域：
+
+Scope 返回关联到测试并处于指定作用域的输出器，每行输出都带有测试名与作用域路径，
+Printer.Scope 在当前作用域下创建嵌套的作用域，使较长的集成测试输出可以按阶段定位。
+
+	setup := testing.Scope(t, "setup")
+	setup.Scope("db").Println("迁移完成") // =-=       [TestOrder/setup/db] 迁移完成
+
+随机数据：
+
+NewRand 创建带种子的随机数据生成器，可以生成字符串、整数、时长、字节切片，或通过 Fill 填充结构体。
+测试失败时会输出所用种子，设置环境变量 KIT_TESTING_SEED 即可复现同一组数据。
+
+	r := testing.NewRand(t)
+	var user User
+	_ = r.Fill(&user)
+
+环境变量：
+
+SetEnv、UnsetEnv 与 SetEnvs 修改环境变量，并在测试结束时恢复为原来的值；
+由于环境变量是进程级别的状态，在调用了 t.Parallel 的测试中使用会直接导致测试失败。
+
+	testing.SetEnv(t, "APP_LOG_LEVEL", "debug")
+	testing.UnsetEnv(t, "APP_CONFIG")
+
+测试上下文：
+
+Context 返回在测试结束时取消的上下文；测试设置了截止时间时，上下文会提前一段时间超时，
+使 RetryWithContext、协程池 Drain 等接受上下文的接口在真实的截止时间下被测试。
+
+	ctx := testing.Context(t, testing.WithContextTimeout(3*time.Second))
+	err := retry.RetryWithContext(ctx, fn)
+
+基准测试：
+
+Bench 与 BenchParallel 统一基准测试循环（开启内存分配统计并重置计时器），ReportThroughput 上报每秒处理量等自定义指标；
+RunBenchmarks 与 PrintComparison 运行多个实现并以第一个为基准输出对比表格。
+
+	func BenchmarkSubmit(b *testing.B) {
+	    testing.Bench(b, func() { _ = pool.Submit(task) })
+	    testing.ReportThroughput(b, b.N, "tasks")
+	}
+
+快照测试：
+
+AssertSnapshot 将值序列化后与 testdata/snapshots 下保存的快照比较，不一致时输出逐行差异；
+快照不存在时自动创建，使用 -kit.update-snapshots 参数或环境变量 KIT_TESTING_UPDATE_SNAPSHOTS=1 可以覆盖已有快照。
+
+	testing.AssertSnapshot(t, "default-options", NewOptions(), testing.WithSnapshotFormat(testing.SnapshotGo))
+
+表格驱动测试：
+
+RunTable 以子测试的方式运行 Case 列表，自动处理子测试命名、可选的并行执行、用例级别的 Setup/Teardown，
+并将测试函数中的 panic 转换为带堆栈的测试失败。
+
+	testing.RunTable(t, []testing.Case[string]{
+	    {Name: "空字符串", Data: ""},
+	    {Name: "中文", Data: "你好"},
+	}, func(t *testing.T, s string) { ... }, testing.WithParallel())
+
+测试数据：
+
+LoadBytes、LoadJSON 与 LoadYAML 读取被测包 testdata 目录下的文件并解析，读取或解析失败时以包含文件路径的信息终止测试。
+
+	var want Response
+	testing.LoadJSON(t, "responses/ok.json", &want)
+
+缓冲输出：
+
+BufferOutput 使当前测试所在协程的输出先被缓存，测试结束时以 "[测试名] " 为前缀一次性写出，
+并行测试的输出因此不再交错。缓冲按协程区分（通过 kit/runtime/goroutine 获取协程 ID），测试中启动的其他协程的输出仍直接写出。
+
+	t.Parallel()
+	testing.BufferOutput(t)
+
+指标断言：
+
+MetricValue、AssertMetric 与 AssertMetricLabels 从 prometheus.Gatherer 中采集指标，按部分标签匹配序列并断言其值或全部标签组合；
+CollectorValue 无需注册即可直接读取收集器（例如 goroutine.MetricWorkerCurrent）中的指标值。
+
+	testing.AssertMetric(t, reg, "http_requests_total", prometheus.Labels{"code": "200"}, 3)
+
+输出详细程度：
+
+输出分为 quiet、normal、debug 三个详细程度：quiet 只输出警告与错误，normal 输出除调试外的全部内容，debug 额外输出 Debug、Debugf 的内容。
+详细程度优先取自命令行参数 -kit.verbosity，其次为环境变量 KIT_TESTING_VERBOSITY，默认为 normal；也可以通过 SetVerbosity 在代码中指定。
+
+	KIT_TESTING_VERBOSITY=quiet go test ./...
+	go test ./... -kit.verbosity=debug
 */
 package testing
@@ -0,0 +1,73 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"os"
+	"testing"
+)
+
+// SetEnv 设置环境变量，并在测试结束时恢复为原来的值（原来未设置则删除）。
+// 环境变量是进程级别的状态，因此 SetEnv 不能用于调用了 t.Parallel 的测试或其父测试，否则测试立即失败。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - key string：环境变量名。
+//   - value string：要设置的值。
+//
+// 示例：
+//
+//	testing.SetEnv(t, "APP_LOG_LEVEL", "debug")
+func SetEnv(t testing.TB, key, value string) {
+	t.Helper()
+
+	setEnv(t, key, value)
+}
+
+// UnsetEnv 删除环境变量，并在测试结束时恢复为原来的值。
+// 与 SetEnv 相同，UnsetEnv 不能用于并行测试。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - key string：环境变量名。
+func UnsetEnv(t testing.TB, key string) {
+	t.Helper()
+
+	// 先通过 t.Setenv 完成并行检查并登记恢复动作，再删除环境变量。
+	if !setEnv(t, key, "") {
+		return
+	}
+	if err := os.Unsetenv(key); nil != err {
+		t.Fatalf("删除环境变量 %s 失败：%v", key, err)
+	}
+}
+
+// SetEnvs 批量设置环境变量，语义与逐个调用 SetEnv 相同。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - envs map[string]string：环境变量名到值的映射。
+func SetEnvs(t testing.TB, envs map[string]string) {
+	t.Helper()
+
+	for key, value := range envs {
+		SetEnv(t, key, value)
+	}
+}
+
+// setEnv 调用 t.Setenv 设置环境变量，t.Setenv 会记录原值并在测试结束时恢复。
+// t.Setenv 在并行测试中会 panic，这里将其转换为测试失败，返回是否设置成功。
+func setEnv(t testing.TB, key, value string) (ok bool) {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); nil != r {
+			t.Fatalf("不能在并行测试中修改环境变量 %s：%v", key, r)
+		}
+	}()
+
+	t.Setenv(key, value)
+	return true
+}
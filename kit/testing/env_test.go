@@ -0,0 +1,71 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+const envTestKey = "KIT_TESTING_ENV_TEST"
+
+func TestSetEnv_Restore(t *testing.T) {
+	_ = os.Setenv(envTestKey, "origin")
+	defer os.Unsetenv(envTestKey) // nolint: errcheck
+
+	t.Run("set", func(t *testing.T) {
+		SetEnv(t, envTestKey, "changed")
+		if got := os.Getenv(envTestKey); got != "changed" {
+			t.Errorf("Getenv = %q, want changed", got)
+		}
+	})
+	if got := os.Getenv(envTestKey); got != "origin" {
+		t.Errorf("after SetEnv, Getenv = %q, want origin", got)
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		UnsetEnv(t, envTestKey)
+		if _, ok := os.LookupEnv(envTestKey); ok {
+			t.Error("UnsetEnv should remove the variable")
+		}
+	})
+	if got := os.Getenv(envTestKey); got != "origin" {
+		t.Errorf("after UnsetEnv, Getenv = %q, want origin", got)
+	}
+}
+
+func TestSetEnvs_RestoreUnset(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		SetEnvs(t, map[string]string{envTestKey: "a"})
+		if got := os.Getenv(envTestKey); got != "a" {
+			t.Errorf("Getenv = %q, want a", got)
+		}
+	})
+	if _, ok := os.LookupEnv(envTestKey); ok {
+		t.Error("variable not set before should be removed after test")
+	}
+}
+
+func TestSetEnv_ParallelGuard(t *testing.T) {
+	t.Run("parallel", func(t *testing.T) {
+		t.Parallel()
+
+		rec := newRecordTB(t)
+		rec.run(func(tb testing.TB) {
+			SetEnv(tb, envTestKey, "x")
+		})
+
+		if !rec.fatal {
+			t.Fatal("SetEnv in parallel test should fail")
+		}
+		if !strings.Contains(rec.output(), envTestKey) {
+			t.Errorf("output = %q", rec.output())
+		}
+		if _, ok := os.LookupEnv(envTestKey); ok {
+			t.Error("variable should not be set")
+		}
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+const (
+	// eventuallyMaxIntervalDivisor 定义了 Eventually 轮询间隔的上限相对于超时时间的比例。
+	// 轮询间隔从 interval 开始指数增长，但不会超过 timeout 的十分之一，保证超时前至少有足够的检查次数。
+	eventuallyMaxIntervalDivisor = 10
+)
+
+var (
+	// errConditionNotMet 表示条件尚未满足，用于驱动 retry 进入下一次尝试。
+	errConditionNotMet = errors.New("condition not met")
+)
+
+// Eventually 断言 cond 在 timeout 内最终返回 true。
+// 轮询基于 kit/runtime/retry 的退避策略：首次立即检查，之后的间隔从 interval 开始指数增长，
+// 上限为 interval 与 timeout/10 中的较大者。用于替代 "time.Sleep 后断言" 这类依赖固定等待时间的写法。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - cond func() bool：要检查的条件。
+//   - timeout time.Duration：等待条件满足的最长时间。
+//   - interval time.Duration：初始轮询间隔。
+//
+// 返回值：
+//   - bool：条件在超时前满足时返回 true；否则记录错误并返回 false。
+//
+// 示例：
+//
+//	testing.Eventually(t, func() bool {
+//	    return atomic.LoadInt32(&count) == 1
+//	}, time.Second, 5*time.Millisecond)
+func Eventually(t testing.TB, cond func() bool, timeout time.Duration, interval time.Duration) bool {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	maxInterval := timeout / eventuallyMaxIntervalDivisor
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+
+	err := retry.RetryWithContext(ctx, func(_ context.Context) error {
+		if cond() {
+			return nil
+		}
+		return errConditionNotMet
+	}, retry.WithMin(interval), retry.WithMax(maxInterval))
+	if nil != err {
+		t.Errorf("条件在 %v 内未满足", timeout)
+		return false
+	}
+
+	return true
+}
+
+// Consistently 断言 cond 在 duration 时间内每次检查都返回 true。
+// 检查以固定的 interval 间隔进行，首次检查立即执行；任意一次返回 false 即判定失败。
+// 适用于验证某个状态 "不会发生变化"，例如任务被拒绝后计数器保持不变。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - cond func() bool：要检查的条件。
+//   - duration time.Duration：条件需要保持成立的时长。
+//   - interval time.Duration：检查间隔。
+//
+// 返回值：
+//   - bool：条件始终成立时返回 true；否则记录错误并返回 false。
+func Consistently(t testing.TB, cond func() bool, duration time.Duration, interval time.Duration) bool {
+	t.Helper()
+
+	// min 与 max 相同时退避策略退化为固定间隔。
+	b := retry.NewBackoff(retry.WithMin(interval), retry.WithMax(interval))
+	deadline := time.NewTimer(duration)
+	defer deadline.Stop()
+
+	for checks := 1; ; checks++ {
+		if !cond() {
+			t.Errorf("条件在第 %d 次检查时不再成立", checks)
+			return false
+		}
+
+		wait := time.NewTimer(b.Duration())
+		select {
+		case <-deadline.C:
+			wait.Stop()
+			return true
+		case <-wait.C:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventually(t *testing.T) {
+	var count int32
+	go func() {
+		for i := 0; i < 3; i++ {
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&count, 1)
+		}
+	}()
+
+	if !Eventually(t, func() bool {
+		return atomic.LoadInt32(&count) == 3
+	}, time.Second, time.Millisecond) {
+		t.Error("Eventually should succeed")
+	}
+}
+
+func TestEventually_Timeout(t *testing.T) {
+	rec := newRecordTB(t)
+	var calls int32
+	var result bool
+
+	start := time.Now()
+	rec.run(func(tb testing.TB) {
+		result = Eventually(tb, func() bool {
+			atomic.AddInt32(&calls, 1)
+			return false
+		}, 50*time.Millisecond, time.Millisecond)
+	})
+
+	if result || !rec.Failed() {
+		t.Fatal("Eventually should fail when condition never holds")
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Eventually returned after %v, before timeout", elapsed)
+	}
+	if atomic.LoadInt32(&calls) < 2 {
+		t.Errorf("condition checked %d times, want at least 2", calls)
+	}
+	if !strings.Contains(rec.output(), "未满足") {
+		t.Errorf("output = %q", rec.output())
+	}
+}
+
+func TestConsistently(t *testing.T) {
+	var calls int32
+	if !Consistently(t, func() bool {
+		atomic.AddInt32(&calls, 1)
+		return true
+	}, 30*time.Millisecond, 5*time.Millisecond) {
+		t.Error("Consistently should succeed")
+	}
+	if atomic.LoadInt32(&calls) < 2 {
+		t.Errorf("condition checked %d times, want at least 2", calls)
+	}
+}
+
+func TestConsistently_Failure(t *testing.T) {
+	rec := newRecordTB(t)
+	var calls int32
+	var result bool
+
+	rec.run(func(tb testing.TB) {
+		result = Consistently(tb, func() bool {
+			return atomic.AddInt32(&calls, 1) < 3
+		}, time.Second, time.Millisecond)
+	})
+
+	if result || !rec.Failed() {
+		t.Fatal("Consistently should fail when condition stops holding")
+	}
+	if !strings.Contains(rec.output(), "第 3 次") {
+		t.Errorf("output = %q", rec.output())
+	}
+}
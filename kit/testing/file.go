@@ -0,0 +1,155 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	// defaultFileMode 定义了测试辅助函数创建文件时使用的默认权限。
+	defaultFileMode = 0644
+	// defaultDirMode 定义了测试辅助函数创建目录时使用的默认权限。
+	defaultDirMode = 0755
+)
+
+// TempDir 创建一个临时目录，并按照 files 预先写入文件内容。
+// 目录由 t.TempDir 创建，测试结束时会被自动清理，无需手动 defer 删除。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - files map[string]string：相对路径到文件内容的映射，可以为 nil；路径中的子目录会被自动创建。
+//
+// 返回值：
+//   - string：创建的临时目录路径。
+//
+// 示例：
+//
+//	dir := testing.TempDir(t, map[string]string{
+//	    "conf/app.yaml": "level: debug",
+//	})
+func TempDir(t testing.TB, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		WriteFile(t, filepath.Join(dir, name), content)
+	}
+
+	return dir
+}
+
+// TempFile 在独立的临时目录中创建一个文件并写入内容。
+// 文件所在目录由 t.TempDir 创建，测试结束时会被自动清理。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - pattern string：文件名模式，与 os.CreateTemp 的 pattern 规则一致，例如 "app-*.log"。
+//   - content string：要写入的文件内容。
+//
+// 返回值：
+//   - string：创建的临时文件路径。
+func TempFile(t testing.TB, pattern string, content string) string {
+	t.Helper()
+
+	file, err := os.CreateTemp(t.TempDir(), pattern)
+	if nil != err {
+		t.Fatalf("创建临时文件失败：%v", err)
+	}
+	// 写入失败和关闭失败都需要中断测试，避免后续断言读到不完整的内容。
+	if _, err := file.WriteString(content); nil != err {
+		_ = file.Close()
+		t.Fatalf("写入临时文件 %s 失败：%v", file.Name(), err)
+	}
+	if err := file.Close(); nil != err {
+		t.Fatalf("关闭临时文件 %s 失败：%v", file.Name(), err)
+	}
+
+	return file.Name()
+}
+
+// WriteFile 将内容写入指定文件，失败时立即终止测试。
+// 文件所在目录不存在时会被自动创建。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - path string：要写入的文件路径。
+//   - content string：要写入的文件内容。
+func WriteFile(t testing.TB, path string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), defaultDirMode); nil != err {
+		t.Fatalf("创建目录 %s 失败：%v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), defaultFileMode); nil != err {
+		t.Fatalf("写入文件 %s 失败：%v", path, err)
+	}
+}
+
+// ReadFile 读取指定文件的全部内容，失败时立即终止测试。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - path string：要读取的文件路径。
+//
+// 返回值：
+//   - string：文件内容。
+func ReadFile(t testing.TB, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if nil != err {
+		t.Fatalf("读取文件 %s 失败：%v", path, err)
+	}
+
+	return string(data)
+}
+
+// AssertFileContent 断言指定文件的内容与期望值完全一致。
+// 文件不存在或读取失败时终止测试，内容不一致时记录错误但不终止测试。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - path string：要检查的文件路径。
+//   - want string：期望的文件内容。
+//
+// 返回值：
+//   - bool：内容一致时返回 true。
+func AssertFileContent(t testing.TB, path string, want string) bool {
+	t.Helper()
+
+	if got := ReadFile(t, path); got != want {
+		t.Errorf("文件 %s 内容 = %q，期望 %q", path, got, want)
+		return false
+	}
+
+	return true
+}
+
+// AssertFileExists 断言指定路径存在且是一个普通文件。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - path string：要检查的文件路径。
+//
+// 返回值：
+//   - bool：文件存在时返回 true。
+func AssertFileExists(t testing.TB, path string) bool {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	if nil != err {
+		t.Errorf("文件 %s 不存在：%v", path, err)
+		return false
+	}
+	if info.IsDir() {
+		t.Errorf("路径 %s 是目录，期望是文件", path)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordTB 是用于验证失败路径的 testing.TB 替身。
+// 它记录 Errorf/Fatalf 的输出，Fatalf 通过 runtime.Goexit 终止当前协程，
+// 因此被测函数需要通过 run 方法在独立协程中执行。
+type recordTB struct {
+	testing.TB
+
+	mu     sync.Mutex
+	failed bool
+	fatal  bool
+	logs   []string
+}
+
+// newRecordTB 创建一个基于真实测试实例的 recordTB，未覆盖的方法委托给 t。
+func newRecordTB(t *testing.T) *recordTB {
+	return &recordTB{TB: t}
+}
+
+func (r *recordTB) Helper() {}
+
+func (r *recordTB) Errorf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failed = true
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+func (r *recordTB) Fatalf(format string, args ...interface{}) {
+	r.mu.Lock()
+	r.failed = true
+	r.fatal = true
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+	r.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (r *recordTB) Failed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failed
+}
+
+// output 返回所有记录的失败信息。
+func (r *recordTB) output() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.Join(r.logs, "\n")
+}
+
+// run 在独立协程中执行 fn，并等待其结束（包括因 Fatalf 提前退出的情况）。
+func (r *recordTB) run(fn func(tb testing.TB)) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+	<-done
+}
+
+func TestTempDir(t *testing.T) {
+	dir := TempDir(t, map[string]string{
+		"a.txt":          "alpha",
+		"conf/app.yaml":  "level: debug",
+		"deep/x/y/z.log": "",
+	})
+
+	AssertFileContent(t, filepath.Join(dir, "a.txt"), "alpha")
+	AssertFileContent(t, filepath.Join(dir, "conf", "app.yaml"), "level: debug")
+	AssertFileExists(t, filepath.Join(dir, "deep", "x", "y", "z.log"))
+}
+
+func TestTempFile(t *testing.T) {
+	path := TempFile(t, "app-*.log", "hello")
+
+	if !strings.HasPrefix(filepath.Base(path), "app-") || !strings.HasSuffix(path, ".log") {
+		t.Errorf("TempFile path = %q, want pattern app-*.log", path)
+	}
+	AssertFileContent(t, path, "hello")
+}
+
+func TestWriteReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "file.txt")
+	WriteFile(t, path, "content")
+
+	if got := ReadFile(t, path); got != "content" {
+		t.Errorf("ReadFile = %q, want %q", got, "content")
+	}
+}
+
+func TestFileAssertions_Failure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(path, []byte("actual"), defaultFileMode); nil != err {
+		t.Fatalf("os.WriteFile error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		fn        func(tb testing.TB)
+		wantFatal bool
+		wantMsg   string
+	}{
+		{
+			name:    "内容不一致",
+			fn:      func(tb testing.TB) { AssertFileContent(tb, path, "expected") },
+			wantMsg: `"actual"`,
+		},
+		{
+			name:    "路径是目录",
+			fn:      func(tb testing.TB) { AssertFileExists(tb, dir) },
+			wantMsg: "是目录",
+		},
+		{
+			name:    "文件不存在",
+			fn:      func(tb testing.TB) { AssertFileExists(tb, filepath.Join(dir, "missing")) },
+			wantMsg: "不存在",
+		},
+		{
+			name:      "读取不存在的文件",
+			fn:        func(tb testing.TB) { ReadFile(tb, filepath.Join(dir, "missing")) },
+			wantFatal: true,
+			wantMsg:   "读取文件",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := newRecordTB(t)
+			rec.run(tt.fn)
+
+			if !rec.Failed() {
+				t.Fatalf("expected failure")
+			}
+			if rec.fatal != tt.wantFatal {
+				t.Errorf("fatal = %v, want %v", rec.fatal, tt.wantFatal)
+			}
+			if !strings.Contains(rec.output(), tt.wantMsg) {
+				t.Errorf("output = %q, want contains %q", rec.output(), tt.wantMsg)
+			}
+		})
+	}
+}
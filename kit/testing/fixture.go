@@ -0,0 +1,75 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// testdataDir 是 Go 约定的测试数据目录，go 命令会忽略其中的源码。
+	testdataDir = "testdata"
+)
+
+// LoadBytes 读取被测包 testdata 目录下的文件内容，读取失败时立即终止测试。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - name string：相对于 testdata 目录的文件路径，使用 / 分隔。
+//
+// 返回值：
+//   - []byte：文件内容。
+//
+// 示例：
+//
+//	raw := testing.LoadBytes(t, "requests/create.http")
+func LoadBytes(t testing.TB, name string) []byte {
+	t.Helper()
+
+	path := filepath.Join(testdataDir, filepath.FromSlash(name))
+	data, err := os.ReadFile(path)
+	if nil != err {
+		t.Fatalf("读取测试数据 %s 失败：%v", path, err)
+	}
+	return data
+}
+
+// LoadJSON 读取 testdata 目录下的 JSON 文件并解析到 v，读取或解析失败时立即终止测试。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - name string：相对于 testdata 目录的文件路径。
+//   - v interface{}：解析目标，必须是指针。
+//
+// 示例：
+//
+//	var want Response
+//	testing.LoadJSON(t, "response.json", &want)
+func LoadJSON(t testing.TB, name string, v interface{}) {
+	t.Helper()
+
+	if err := json.Unmarshal(LoadBytes(t, name), v); nil != err {
+		t.Fatalf("解析 JSON 测试数据 %s 失败：%v", filepath.Join(testdataDir, name), err)
+	}
+}
+
+// LoadYAML 读取 testdata 目录下的 YAML 文件并解析到 v，读取或解析失败时立即终止测试。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - name string：相对于 testdata 目录的文件路径。
+//   - v interface{}：解析目标，必须是指针。
+func LoadYAML(t testing.TB, name string, v interface{}) {
+	t.Helper()
+
+	if err := yaml.Unmarshal(LoadBytes(t, name), v); nil != err {
+		t.Fatalf("解析 YAML 测试数据 %s 失败：%v", filepath.Join(testdataDir, name), err)
+	}
+}
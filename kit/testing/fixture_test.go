@@ -0,0 +1,60 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"strings"
+	"testing"
+)
+
+type fixtureConfig struct {
+	Name string `json:"name" yaml:"name"`
+	Port int    `json:"port" yaml:"port"`
+}
+
+func TestLoadFixtures(t *testing.T) {
+	want := fixtureConfig{Name: "app", Port: 8080}
+
+	var fromJSON fixtureConfig
+	LoadJSON(t, "fixtures/config.json", &fromJSON)
+	if fromJSON != want {
+		t.Errorf("LoadJSON = %+v, want %+v", fromJSON, want)
+	}
+
+	var fromYAML fixtureConfig
+	LoadYAML(t, "fixtures/config.yaml", &fromYAML)
+	if fromYAML != want {
+		t.Errorf("LoadYAML = %+v, want %+v", fromYAML, want)
+	}
+
+	if got := string(LoadBytes(t, "fixtures/config.yaml")); got != "name: app\nport: 8080\n" {
+		t.Errorf("LoadBytes = %q", got)
+	}
+}
+
+func TestLoadFixtures_Failure(t *testing.T) {
+	tests := []struct {
+		name string
+		load func(tb testing.TB)
+		want string
+	}{
+		{"文件不存在", func(tb testing.TB) { LoadBytes(tb, "fixtures/missing.json") }, "missing.json"},
+		{"JSON 格式错误", func(tb testing.TB) { LoadJSON(tb, "fixtures/broken.json", &fixtureConfig{}) }, "broken.json"},
+		{"YAML 格式错误", func(tb testing.TB) { LoadYAML(tb, "fixtures/broken.json", &fixtureConfig{}) }, "broken.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := newRecordTB(t)
+			rec.run(tt.load)
+			if !rec.fatal {
+				t.Fatal("load should fail fatally")
+			}
+			if !strings.Contains(rec.output(), tt.want) {
+				t.Errorf("output = %q, want mention of %q", rec.output(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+var (
+	// leakTimeoutDefault 定义了等待协程退出的默认最长时间。
+	leakTimeoutDefault = time.Second
+
+	// leakIgnoreDefault 定义了默认忽略的后台协程函数名片段。
+	// 这些协程由运行时或 kit 组件在包级别启动，生命周期与进程一致，不属于测试泄漏。
+	leakIgnoreDefault = []string{
+		// 协程池的指标采集协程，默认协程池不会被释放。
+		"github.com/fsyyft-go/monorepo/kit/runtime/goroutine.stat",
+		// signal.Notify 启动的信号接收协程。
+		"os/signal.signal_recv",
+		"os/signal.loop",
+	}
+)
+
+type (
+	// LeakOption 定义了协程泄漏检查的配置选项。
+	LeakOption func(*leakOptions)
+
+	// leakOptions 包含协程泄漏检查的配置。
+	leakOptions struct {
+		// timeout 是等待协程退出的最长时间。
+		timeout time.Duration
+		// ignores 是需要忽略的堆栈函数名片段。
+		ignores []string
+	}
+
+	// leakReporter 是输出泄漏信息的函数，测试中为 t.Errorf，TestMain 中输出到标准错误。
+	leakReporter func(format string, args ...interface{})
+)
+
+// WithLeakTimeout 设置等待协程退出的最长时间。
+// 协程退出通常是异步的，检查会在超时前反复进行，超时后仍存在的协程才会被判定为泄漏。
+//
+// 参数：
+//   - timeout：等待的最长时间。
+//
+// 返回值：
+//   - LeakOption：配置选项函数。
+func WithLeakTimeout(timeout time.Duration) LeakOption {
+	return func(o *leakOptions) {
+		o.timeout = timeout
+	}
+}
+
+// IgnoreFunction 忽略堆栈中包含指定函数名片段的协程。
+// 可以多次调用，所有片段都会生效。
+//
+// 参数：
+//   - fns：函数全名或其片段，例如 "github.com/panjf2000/ants/v2.(*poolCommon).purgeStaleWorkers"。
+//
+// 返回值：
+//   - LeakOption：配置选项函数。
+func IgnoreFunction(fns ...string) LeakOption {
+	return func(o *leakOptions) {
+		o.ignores = append(o.ignores, fns...)
+	}
+}
+
+// VerifyNoLeaks 在测试开始时记录现有协程，并在测试结束时检查是否有新增且未退出的协程。
+// 检查通过 t.Cleanup 注册，因此只需在测试开头调用一次；发现泄漏时通过 t.Errorf 输出泄漏协程的完整堆栈。
+// 并行测试启动的协程会被误判为泄漏，因此不应在 t.Parallel 的测试中使用。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - opts ...LeakOption：可选的配置选项。
+//
+// 示例：
+//
+//	func TestWorker(t *testing.T) {
+//	    testing.VerifyNoLeaks(t)
+//	    ...
+//	}
+func VerifyNoLeaks(t testing.TB, opts ...LeakOption) {
+	t.Helper()
+
+	o := newLeakOptions(opts...)
+
+	// 记录测试开始时已存在的协程，这些协程不属于本测试。
+	baseline := make(map[int64]struct{})
+	for _, s := range goroutine.Stacks() {
+		baseline[s.ID] = struct{}{}
+	}
+
+	t.Cleanup(func() {
+		t.Helper()
+		reportLeaks(t.Errorf, o, baseline)
+	})
+}
+
+// VerifyTestMain 运行包内的全部测试，并在测试结束后检查是否有遗留的协程。
+// 发现泄漏时输出泄漏协程的堆栈，并以非零状态码退出。
+//
+// 参数：
+//   - m *testing.M：TestMain 接收的参数。
+//   - opts ...LeakOption：可选的配置选项。
+//
+// 示例：
+//
+//	func TestMain(m *testing.M) {
+//	    testing.VerifyTestMain(m)
+//	}
+func VerifyTestMain(m *testing.M, opts ...LeakOption) {
+	code := m.Run()
+	if 0 == code {
+		o := newLeakOptions(opts...)
+		// 只保留当前协程作为基线，其余协程都应在测试结束前退出。
+		baseline := map[int64]struct{}{goroutine.GetGoIDSlow(): {}}
+		if reportLeaks(func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		}, o, baseline) {
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+// newLeakOptions 创建带默认值的配置，并应用所有选项。
+func newLeakOptions(opts ...LeakOption) *leakOptions {
+	o := &leakOptions{
+		timeout: leakTimeoutDefault,
+		ignores: append([]string(nil), leakIgnoreDefault...),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// reportLeaks 在超时前反复检查泄漏的协程，超时后仍存在时通过 report 输出。
+//
+// 返回值：
+//   - bool：存在泄漏时返回 true。
+func reportLeaks(report leakReporter, o *leakOptions, baseline map[int64]struct{}) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), o.timeout)
+	defer cancel()
+
+	var leaked []goroutine.Stack
+	_ = retry.RetryWithContext(ctx, func(_ context.Context) error {
+		leaked = findLeaks(o, baseline)
+		if len(leaked) == 0 {
+			return nil
+		}
+		return fmt.Errorf("found %d leaked goroutines", len(leaked))
+	}, retry.WithMin(time.Millisecond), retry.WithMax(50*time.Millisecond))
+
+	// 超时后最后检查一次，避免最后一次等待期间协程已退出却仍被报告。
+	if len(leaked) > 0 {
+		leaked = findLeaks(o, baseline)
+	}
+	if len(leaked) == 0 {
+		return false
+	}
+
+	traces := make([]string, 0, len(leaked))
+	for _, s := range leaked {
+		traces = append(traces, s.Trace)
+	}
+	report("发现 %d 个泄漏的协程：\n\n%s", len(leaked), strings.Join(traces, "\n\n"))
+	return true
+}
+
+// findLeaks 返回不在基线中、不是当前协程且未被忽略的协程。
+func findLeaks(o *leakOptions, baseline map[int64]struct{}) []goroutine.Stack {
+	var leaked []goroutine.Stack
+	stacks := goroutine.Stacks()
+	for i, s := range stacks {
+		// 第一个堆栈是调用方所在的协程。
+		if 0 == i {
+			continue
+		}
+		if _, ok := baseline[s.ID]; ok {
+			continue
+		}
+		if isIgnored(o, s) {
+			continue
+		}
+		leaked = append(leaked, s)
+	}
+	return leaked
+}
+
+// isIgnored 检查协程是否命中忽略列表。
+func isIgnored(o *leakOptions, s goroutine.Stack) bool {
+	for _, fn := range o.ignores {
+		if s.Contains(fn) {
+			return true
+		}
+	}
+	return false
+}
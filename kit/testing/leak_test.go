@@ -0,0 +1,83 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// leakyWorker 阻塞在通道上，用于在堆栈中留下可识别的函数名。
+func leakyWorker(ch chan struct{}) {
+	<-ch
+}
+
+func TestVerifyNoLeaks(t *testing.T) {
+	VerifyNoLeaks(t)
+
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(done)
+	}()
+	<-done
+}
+
+func TestVerifyNoLeaks_Leaked(t *testing.T) {
+	ch := make(chan struct{})
+	defer close(ch)
+
+	rec := newRecordTB(t)
+	var cleanups []func()
+	rec.run(func(tb testing.TB) {
+		VerifyNoLeaks(&cleanupTB{recordTB: rec, cleanups: &cleanups}, WithLeakTimeout(20*time.Millisecond))
+	})
+	go leakyWorker(ch)
+
+	for _, fn := range cleanups {
+		fn()
+	}
+
+	if !rec.Failed() {
+		t.Fatal("VerifyNoLeaks should report the leaked goroutine")
+	}
+	if !strings.Contains(rec.output(), "leakyWorker") {
+		t.Errorf("output should contain leaked stack, got %q", rec.output())
+	}
+}
+
+func TestVerifyNoLeaks_Ignore(t *testing.T) {
+	ch := make(chan struct{})
+	defer close(ch)
+
+	rec := newRecordTB(t)
+	var cleanups []func()
+	rec.run(func(tb testing.TB) {
+		VerifyNoLeaks(&cleanupTB{recordTB: rec, cleanups: &cleanups},
+			WithLeakTimeout(20*time.Millisecond),
+			IgnoreFunction("kit/testing.leakyWorker"),
+		)
+	})
+	go leakyWorker(ch)
+
+	for _, fn := range cleanups {
+		fn()
+	}
+
+	if rec.Failed() {
+		t.Errorf("ignored goroutine should not be reported: %s", rec.output())
+	}
+}
+
+// cleanupTB 记录 Cleanup 注册的函数，由测试手动触发。
+type cleanupTB struct {
+	*recordTB
+	cleanups *[]func()
+}
+
+func (c *cleanupTB) Cleanup(fn func()) {
+	*c.cleanups = append(*c.cleanups, fn)
+}
@@ -0,0 +1,510 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"errors"
+	"io"
+	iofs "io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	kitfs "github.com/fsyyft-go/monorepo/kit/fs"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+var (
+	// errIsDir 表示以写入方式打开目录。
+	errIsDir = errors.New("is a directory")
+	// errNotDir 表示路径中的上级不是目录。
+	errNotDir = errors.New("not a directory")
+	// errNotEmpty 表示删除或替换的目录不为空。
+	errNotEmpty = errors.New("directory not empty")
+	// errBadFlag 表示文件的打开方式不支持该操作。
+	errBadFlag = errors.New("bad file descriptor")
+)
+
+type (
+	// MemFS 是内存中的可写文件系统，实现了 kit/fs 的 FS 接口以及 io/fs 的 FS、StatFS、ReadFileFS，
+	// 注入日志、配置等组件后，依赖文件路径的行为不需要读写真实的磁盘即可测试。
+	//
+	// 路径统一转换为以 "/" 分隔并清理，绝对路径与相对路径指向同一棵目录树，
+	// 例如 "/var/log/app.log" 与 "var/log/app.log" 是同一个文件；根目录始终存在。
+	// 文件权限只被记录，不做访问控制。MemFS 的所有方法都是并发安全的。
+	MemFS struct {
+		// mu 保护整棵目录树与全部文件的内容。
+		mu sync.Mutex
+		// root 是根目录。
+		root *memNode
+		// clock 是记录修改时间使用的时钟。
+		clock kittime.Clock
+	}
+
+	// MemFSOption 定义了 MemFS 的配置选项。
+	MemFSOption func(*MemFS)
+
+	// memNode 是 MemFS 中的文件或目录。
+	memNode struct {
+		// name 是文件名，不包含上级目录。
+		name string
+		// mode 是文件的类型与权限。
+		mode iofs.FileMode
+		// modTime 是最后修改的时间。
+		modTime time.Time
+		// data 是文件的内容。
+		data []byte
+		// children 是目录中的文件，文件为 nil。
+		children map[string]*memNode
+	}
+
+	// memFile 是 MemFS 中打开的文件或目录。
+	memFile struct {
+		// fsys 是文件所属的文件系统。
+		fsys *MemFS
+		// node 是打开的文件或目录。
+		node *memNode
+		// name 是打开时使用的路径，用于错误信息。
+		name string
+		// flag 是打开方式。
+		flag int
+		// offset 是读写的位置，目录为 ReadDir 已经返回的数量。
+		offset int64
+		// closed 表示文件已经关闭。
+		closed bool
+	}
+
+	// memInfo 是 memNode 在某一时刻的信息。
+	memInfo struct {
+		// name 是文件名。
+		name string
+		// size 是文件的长度。
+		size int64
+		// mode 是文件的类型与权限。
+		mode iofs.FileMode
+		// modTime 是最后修改的时间。
+		modTime time.Time
+	}
+)
+
+// WithMemFSClock 设置 MemFS 记录修改时间使用的时钟。
+//
+// 参数：
+//   - clock kittime.Clock：时钟，为 nil 时使用系统时钟，测试与修改时间相关的行为时可以注入 FakeClock。
+//
+// 返回值：
+//   - MemFSOption：配置选项。
+func WithMemFSClock(clock kittime.Clock) MemFSOption {
+	return func(m *MemFS) {
+		m.clock = kittime.OrReal(clock)
+	}
+}
+
+// NewMemFS 创建一个只包含根目录的内存文件系统。
+//
+// 参数：
+//   - opts ...MemFSOption：配置选项。
+//
+// 返回值：
+//   - *MemFS：创建的文件系统。
+//
+// 示例：
+//
+//	fsys := testing.NewMemFS()
+//	logger, err := log.NewLogger(log.WithOutput("/var/log/app.log"), log.WithFS(fsys))
+//	...
+//	data, _ := fsys.ReadFile("/var/log/app.log")
+func NewMemFS(opts ...MemFSOption) *MemFS {
+	m := &MemFS{clock: kittime.NewRealClock()}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.root = &memNode{name: ".", mode: iofs.ModeDir | defaultDirMode, modTime: m.clock.Now(), children: map[string]*memNode{}}
+	return m
+}
+
+// Open 实现了 io/fs.FS 接口，以只读方式打开文件或目录。
+func (m *MemFS) Open(name string) (iofs.File, error) {
+	return m.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// Stat 实现了 io/fs.StatFS 接口。
+func (m *MemFS) Stat(name string) (iofs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n, err := m.lookup(cleanPath(name))
+	if nil != err {
+		return nil, &iofs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return n.info(), nil
+}
+
+// ReadFile 实现了 io/fs.ReadFileFS 接口，返回文件内容的副本。
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n, err := m.lookup(cleanPath(name))
+	if nil == err && n.isDir() {
+		err = errIsDir
+	}
+	if nil != err {
+		return nil, &iofs.PathError{Op: "read", Path: name, Err: err}
+	}
+	return slices.Clone(n.data), nil
+}
+
+// WriteFile 按 os.WriteFile 的语义写入文件，所在目录必须已经存在。
+func (m *MemFS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	f, err := m.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if nil != err {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); nil == err {
+		err = closeErr
+	}
+	return err
+}
+
+// OpenFile 实现了 kit/fs 的 FS 接口，按 os.OpenFile 的语义打开文件。
+func (m *MemFS) OpenFile(name string, flag int, perm os.FileMode) (kitfs.File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := cleanPath(name)
+	writable := 0 != flag&(os.O_WRONLY|os.O_RDWR)
+	n, err := m.lookup(p)
+	switch {
+	case nil == err && 0 != flag&os.O_CREATE && 0 != flag&os.O_EXCL:
+		return nil, &iofs.PathError{Op: "open", Path: name, Err: iofs.ErrExist}
+	case nil == err && n.isDir() && (writable || 0 != flag&os.O_TRUNC):
+		return nil, &iofs.PathError{Op: "open", Path: name, Err: errIsDir}
+	case nil == err:
+		if writable && 0 != flag&os.O_TRUNC {
+			n.data = nil
+			n.modTime = m.clock.Now()
+		}
+	case errors.Is(err, iofs.ErrNotExist) && 0 != flag&os.O_CREATE:
+		parent, base, perr := m.parent(p)
+		if nil != perr {
+			return nil, &iofs.PathError{Op: "open", Path: name, Err: perr}
+		}
+		n = &memNode{name: base, mode: perm.Perm(), modTime: m.clock.Now()}
+		parent.children[base] = n
+	default:
+		return nil, &iofs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &memFile{fsys: m, node: n, name: name, flag: flag}, nil
+}
+
+// MkdirAll 实现了 kit/fs 的 FS 接口，连同不存在的上级目录一起创建目录。
+func (m *MemFS) MkdirAll(name string, perm os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := m.root
+	for _, elem := range splitPath(cleanPath(name)) {
+		child, ok := n.children[elem]
+		if !ok {
+			child = &memNode{name: elem, mode: iofs.ModeDir | perm.Perm(), modTime: m.clock.Now(), children: map[string]*memNode{}}
+			n.children[elem] = child
+		} else if !child.isDir() {
+			return &iofs.PathError{Op: "mkdir", Path: name, Err: errNotDir}
+		}
+		n = child
+	}
+	return nil
+}
+
+// Rename 实现了 kit/fs 的 FS 接口，newpath 是已经存在的文件或空目录时替换它。
+func (m *MemFS) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	op, np := cleanPath(oldpath), cleanPath(newpath)
+	fail := func(err error) error {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: err}
+	}
+	n, err := m.lookup(op)
+	if nil != err {
+		return fail(err)
+	}
+	if np == op {
+		return nil
+	}
+	if "." == op || "." == np {
+		return fail(iofs.ErrInvalid)
+	}
+	if n.isDir() && strings.HasPrefix(np, op+"/") {
+		return fail(iofs.ErrInvalid)
+	}
+	newParent, newBase, err := m.parent(np)
+	if nil != err {
+		return fail(err)
+	}
+	if existing, ok := newParent.children[newBase]; ok {
+		if existing.isDir() != n.isDir() {
+			return fail(iofs.ErrExist)
+		}
+		if existing.isDir() && 0 != len(existing.children) {
+			return fail(errNotEmpty)
+		}
+	}
+	oldParent, oldBase, _ := m.parent(op)
+	delete(oldParent.children, oldBase)
+	n.name = newBase
+	newParent.children[newBase] = n
+	return nil
+}
+
+// Remove 实现了 kit/fs 的 FS 接口，删除文件或空目录。
+func (m *MemFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := cleanPath(name)
+	n, err := m.lookup(p)
+	switch {
+	case nil != err:
+	case "." == p:
+		err = iofs.ErrInvalid
+	case n.isDir() && 0 != len(n.children):
+		err = errNotEmpty
+	}
+	if nil != err {
+		return &iofs.PathError{Op: "remove", Path: name, Err: err}
+	}
+	parent, base, _ := m.parent(p)
+	delete(parent.children, base)
+	return nil
+}
+
+// Chmod 实现了 kit/fs 的 FS 接口，修改文件的权限。
+func (m *MemFS) Chmod(name string, mode os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n, err := m.lookup(cleanPath(name))
+	if nil != err {
+		return &iofs.PathError{Op: "chmod", Path: name, Err: err}
+	}
+	n.mode = n.mode.Type() | mode.Perm()
+	return nil
+}
+
+// lookup 查找清理后的路径对应的文件或目录，调用方持有 mu。
+func (m *MemFS) lookup(p string) (*memNode, error) {
+	n := m.root
+	for _, elem := range splitPath(p) {
+		if !n.isDir() {
+			return nil, errNotDir
+		}
+		child, ok := n.children[elem]
+		if !ok {
+			return nil, iofs.ErrNotExist
+		}
+		n = child
+	}
+	return n, nil
+}
+
+// parent 返回清理后的路径的上级目录与文件名，上级目录必须已经存在，调用方持有 mu。
+func (m *MemFS) parent(p string) (*memNode, string, error) {
+	dir, base := path.Split(p)
+	parent, err := m.lookup(cleanPath(dir))
+	if nil != err {
+		return nil, "", err
+	}
+	if !parent.isDir() {
+		return nil, "", errNotDir
+	}
+	return parent, base, nil
+}
+
+// cleanPath 将操作系统格式的路径转换为相对根目录、以 "/" 分隔的路径，根目录为 "."。
+func cleanPath(name string) string {
+	p := path.Clean("/" + filepath.ToSlash(name))[1:]
+	if "" == p {
+		return "."
+	}
+	return p
+}
+
+// splitPath 将清理后的路径拆分为各级的文件名，根目录返回 nil。
+func splitPath(p string) []string {
+	if "." == p {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// isDir 判断是否为目录。
+func (n *memNode) isDir() bool {
+	return n.mode.IsDir()
+}
+
+// info 返回当前的文件信息，调用方持有 mu。
+func (n *memNode) info() *memInfo {
+	return &memInfo{name: n.name, size: int64(len(n.data)), mode: n.mode, modTime: n.modTime}
+}
+
+// Stat 实现了 io/fs.File 接口。
+func (f *memFile) Stat() (iofs.FileInfo, error) {
+	f.fsys.mu.Lock()
+	defer f.fsys.mu.Unlock()
+
+	if f.closed {
+		return nil, f.error("stat", iofs.ErrClosed)
+	}
+	return f.node.info(), nil
+}
+
+// Read 实现了 io/fs.File 接口。
+func (f *memFile) Read(b []byte) (int, error) {
+	f.fsys.mu.Lock()
+	defer f.fsys.mu.Unlock()
+
+	switch {
+	case f.closed:
+		return 0, f.error("read", iofs.ErrClosed)
+	case f.node.isDir():
+		return 0, f.error("read", errIsDir)
+	case os.O_WRONLY == f.flag&(os.O_WRONLY|os.O_RDWR):
+		return 0, f.error("read", errBadFlag)
+	case f.offset >= int64(len(f.node.data)):
+		return 0, io.EOF
+	}
+	n := copy(b, f.node.data[f.offset:])
+	f.offset += int64(n)
+	return n, nil
+}
+
+// Write 实现了 kit/fs 的 File 接口，以追加方式打开时总是写入到文件末尾。
+func (f *memFile) Write(b []byte) (int, error) {
+	f.fsys.mu.Lock()
+	defer f.fsys.mu.Unlock()
+
+	switch {
+	case f.closed:
+		return 0, f.error("write", iofs.ErrClosed)
+	case 0 == f.flag&(os.O_WRONLY|os.O_RDWR):
+		return 0, f.error("write", errBadFlag)
+	}
+	if 0 != f.flag&os.O_APPEND {
+		f.offset = int64(len(f.node.data))
+	}
+	if end := f.offset + int64(len(b)); end > int64(len(f.node.data)) {
+		f.node.data = slices.Grow(f.node.data, int(end)-len(f.node.data))[:end]
+	}
+	copy(f.node.data[f.offset:], b)
+	f.offset += int64(len(b))
+	f.node.modTime = f.fsys.clock.Now()
+	return len(b), nil
+}
+
+// Seek 实现了 io.Seeker 接口。
+func (f *memFile) Seek(offset int64, whence int) (int64, error) {
+	f.fsys.mu.Lock()
+	defer f.fsys.mu.Unlock()
+
+	if f.closed {
+		return 0, f.error("seek", iofs.ErrClosed)
+	}
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += int64(len(f.node.data))
+	}
+	if offset < 0 {
+		return 0, f.error("seek", iofs.ErrInvalid)
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+// ReadDir 实现了 io/fs.ReadDirFile 接口，按文件名排序返回目录中的文件。
+func (f *memFile) ReadDir(count int) ([]iofs.DirEntry, error) {
+	f.fsys.mu.Lock()
+	defer f.fsys.mu.Unlock()
+
+	switch {
+	case f.closed:
+		return nil, f.error("readdir", iofs.ErrClosed)
+	case !f.node.isDir():
+		return nil, f.error("readdir", errNotDir)
+	}
+	names := make([]string, 0, len(f.node.children))
+	for name := range f.node.children {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	rest := names[min(f.offset, int64(len(names))):]
+	if count > 0 && len(rest) > count {
+		rest = rest[:count]
+	}
+	if count > 0 && 0 == len(rest) {
+		return nil, io.EOF
+	}
+	entries := make([]iofs.DirEntry, 0, len(rest))
+	for _, name := range rest {
+		entries = append(entries, iofs.FileInfoToDirEntry(f.node.children[name].info()))
+	}
+	f.offset += int64(len(rest))
+	return entries, nil
+}
+
+// Sync 实现了 kit/fs 的 File 接口，内存中的文件不需要同步。
+func (f *memFile) Sync() error {
+	f.fsys.mu.Lock()
+	defer f.fsys.mu.Unlock()
+
+	if f.closed {
+		return f.error("sync", iofs.ErrClosed)
+	}
+	return nil
+}
+
+// Close 实现了 io/fs.File 接口。
+func (f *memFile) Close() error {
+	f.fsys.mu.Lock()
+	defer f.fsys.mu.Unlock()
+
+	if f.closed {
+		return f.error("close", iofs.ErrClosed)
+	}
+	f.closed = true
+	return nil
+}
+
+// error 返回包含操作与路径的错误。
+func (f *memFile) error(op string, err error) error {
+	return &iofs.PathError{Op: op, Path: f.name, Err: err}
+}
+
+// Name 实现了 io/fs.FileInfo 接口。
+func (i *memInfo) Name() string { return i.name }
+
+// Size 实现了 io/fs.FileInfo 接口。
+func (i *memInfo) Size() int64 { return i.size }
+
+// Mode 实现了 io/fs.FileInfo 接口。
+func (i *memInfo) Mode() iofs.FileMode { return i.mode }
+
+// ModTime 实现了 io/fs.FileInfo 接口。
+func (i *memInfo) ModTime() time.Time { return i.modTime }
+
+// IsDir 实现了 io/fs.FileInfo 接口。
+func (i *memInfo) IsDir() bool { return i.mode.IsDir() }
+
+// Sys 实现了 io/fs.FileInfo 接口。
+func (i *memInfo) Sys() interface{} { return nil }
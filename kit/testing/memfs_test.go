@@ -0,0 +1,269 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"errors"
+	"io"
+	iofs "io/fs"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	kitfs "github.com/fsyyft-go/monorepo/kit/fs"
+	kittime "github.com/fsyyft-go/monorepo/kit/time"
+)
+
+// TestMemFS_FSCompat 测试 MemFS 满足 io/fs 的约定。
+func TestMemFS_FSCompat(t *testing.T) {
+	m := NewMemFS()
+	if err := m.MkdirAll("root/conf/empty", 0755); nil != err {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	for name, content := range map[string]string{
+		"root/conf/app.yaml": "level: debug",
+		"root/README":        "readme",
+		"root/conf/b.json":   "{}",
+	} {
+		if err := m.WriteFile(name, []byte(content), 0644); nil != err {
+			t.Fatalf("WriteFile %s: %v", name, err)
+		}
+	}
+
+	// fs.Sub 按 io/fs 的规则校验路径后再交给 MemFS。
+	sub, err := iofs.Sub(m, "root")
+	if nil != err {
+		t.Fatalf("Sub: %v", err)
+	}
+	if err := fstest.TestFS(sub, "conf/app.yaml", "README", "conf/b.json", "conf/empty"); nil != err {
+		t.Fatal(err)
+	}
+}
+
+// TestMemFS_Paths 测试绝对路径、相对路径与操作系统格式的路径指向同一个文件。
+func TestMemFS_Paths(t *testing.T) {
+	m := NewMemFS()
+	if err := m.MkdirAll("/var/log", 0755); nil != err {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := m.WriteFile("/var/log/app.log", []byte("x"), 0644); nil != err {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	for _, name := range []string{"var/log/app.log", "/var//log/./app.log", "/var/tmp/../log/app.log"} {
+		if data, err := m.ReadFile(name); nil != err || "x" != string(data) {
+			t.Errorf("ReadFile(%q) = %q, %v", name, data, err)
+		}
+	}
+	if info, err := m.Stat("/"); nil != err || !info.IsDir() {
+		t.Errorf("Stat(/) = %v, %v, want root dir", info, err)
+	}
+}
+
+// TestMemFS_OpenFile 测试 OpenFile 的打开方式：创建、独占创建、截断、追加与读写权限。
+func TestMemFS_OpenFile(t *testing.T) {
+	m := NewMemFS()
+
+	if _, err := m.OpenFile("missing/a.txt", os.O_CREATE|os.O_WRONLY, 0644); !errors.Is(err, iofs.ErrNotExist) {
+		t.Errorf("上级目录不存在时 err = %v, want ErrNotExist", err)
+	}
+	if _, err := m.Open("a.txt"); !errors.Is(err, iofs.ErrNotExist) {
+		t.Errorf("Open 不存在的文件 err = %v, want ErrNotExist", err)
+	}
+
+	f, err := m.OpenFile("a.txt", os.O_CREATE|os.O_EXCL|os.O_RDWR, 0600)
+	if nil != err {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	if _, err := f.Write([]byte("hello world")); nil != err {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := f.(io.Seeker).Seek(0, io.SeekStart); nil != err {
+		t.Fatalf("Seek: %v", err)
+	}
+	if data, err := io.ReadAll(f); nil != err || "hello world" != string(data) {
+		t.Errorf("ReadAll = %q, %v", data, err)
+	}
+	if err := f.Close(); nil != err {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := f.Write([]byte("x")); !errors.Is(err, iofs.ErrClosed) {
+		t.Errorf("关闭后 Write err = %v, want ErrClosed", err)
+	}
+	if err := f.Close(); !errors.Is(err, iofs.ErrClosed) {
+		t.Errorf("重复 Close err = %v, want ErrClosed", err)
+	}
+
+	if _, err := m.OpenFile("a.txt", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600); !errors.Is(err, iofs.ErrExist) {
+		t.Errorf("O_EXCL err = %v, want ErrExist", err)
+	}
+
+	// 追加方式打开时，每次写入都在文件末尾。
+	a1, _ := m.OpenFile("a.txt", os.O_WRONLY|os.O_APPEND, 0)
+	a2, _ := m.OpenFile("a.txt", os.O_WRONLY|os.O_APPEND, 0)
+	_, _ = a1.Write([]byte("!"))
+	_, _ = a2.Write([]byte("?"))
+	if data, _ := m.ReadFile("a.txt"); "hello world!?" != string(data) {
+		t.Errorf("追加后内容 = %q", data)
+	}
+	if _, err := a1.Read(make([]byte, 1)); nil == err {
+		t.Error("只写方式打开时 Read 应失败")
+	}
+
+	r, _ := m.Open("a.txt")
+	if _, err := r.(kitfs.File).Write([]byte("x")); nil == err {
+		t.Error("只读方式打开时 Write 应失败")
+	}
+
+	// 截断。
+	tr, err := m.OpenFile("a.txt", os.O_WRONLY|os.O_TRUNC, 0)
+	if nil != err {
+		t.Fatalf("OpenFile O_TRUNC: %v", err)
+	}
+	_ = tr.Close()
+	if info, _ := m.Stat("a.txt"); 0 != info.Size() || 0600 != info.Mode().Perm() {
+		t.Errorf("截断后 size = %d, mode = %v", info.Size(), info.Mode())
+	}
+
+	if _, err := m.OpenFile(".", os.O_WRONLY, 0); nil == err {
+		t.Error("以写入方式打开目录应失败")
+	}
+	if _, err := m.OpenFile("a.txt/b", os.O_CREATE|os.O_WRONLY, 0644); nil == err {
+		t.Error("上级不是目录时创建文件应失败")
+	}
+}
+
+// TestMemFS_RenameRemove 测试重命名、删除与修改权限。
+func TestMemFS_RenameRemove(t *testing.T) {
+	m := NewMemFS()
+	_ = m.MkdirAll("dir/sub", 0755)
+	_ = m.WriteFile("dir/sub/a", []byte("a"), 0644)
+	_ = m.WriteFile("b", []byte("b"), 0644)
+
+	if err := m.Rename("b", "dir/sub/a"); nil != err {
+		t.Fatalf("Rename 替换文件: %v", err)
+	}
+	if data, _ := m.ReadFile("dir/sub/a"); "b" != string(data) {
+		t.Errorf("替换后内容 = %q", data)
+	}
+	if _, err := m.Stat("b"); !errors.Is(err, iofs.ErrNotExist) {
+		t.Errorf("重命名后原文件 err = %v", err)
+	}
+
+	if err := m.Rename("dir", "moved"); nil != err {
+		t.Fatalf("Rename 目录: %v", err)
+	}
+	if info, err := m.Stat("moved/sub"); nil != err || "sub" != info.Name() {
+		t.Errorf("Stat(moved/sub) = %v, %v", info, err)
+	}
+	if err := m.Rename("moved", "moved/sub/x"); nil == err {
+		t.Error("将目录移动到自身之下应失败")
+	}
+	if err := m.Rename("missing", "x"); !errors.Is(err, iofs.ErrNotExist) {
+		t.Errorf("Rename 不存在的文件 err = %v", err)
+	}
+	_ = m.MkdirAll("other", 0755)
+	if err := m.Rename("moved/sub/a", "other"); nil == err {
+		t.Error("用文件替换目录应失败")
+	}
+
+	if err := m.Remove("moved/sub"); nil == err {
+		t.Error("删除非空目录应失败")
+	}
+	if err := m.Remove("moved/sub/a"); nil != err {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := m.Remove("moved/sub"); nil != err {
+		t.Fatalf("Remove 空目录: %v", err)
+	}
+	if err := m.Remove("/"); nil == err {
+		t.Error("删除根目录应失败")
+	}
+
+	if err := m.Chmod("other", 0700); nil != err {
+		t.Fatalf("Chmod: %v", err)
+	}
+	if info, _ := m.Stat("other"); !info.IsDir() || 0700 != info.Mode().Perm() {
+		t.Errorf("Chmod 后 mode = %v", info.Mode())
+	}
+	if err := m.MkdirAll("moved/sub/a", 0755); nil != err {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	_ = m.WriteFile("f", nil, 0644)
+	if err := m.MkdirAll("f/g", 0755); nil == err {
+		t.Error("上级是文件时 MkdirAll 应失败")
+	}
+}
+
+// TestMemFS_ModTime 测试修改时间由注入的时钟决定。
+func TestMemFS_ModTime(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := kittime.NewFakeClock(start)
+	m := NewMemFS(WithMemFSClock(clock))
+
+	_ = m.WriteFile("a", []byte("1"), 0644)
+	clock.Advance(time.Hour)
+	f, _ := m.OpenFile("a", os.O_WRONLY|os.O_APPEND, 0)
+	_, _ = f.Write([]byte("2"))
+
+	info, _ := m.Stat("a")
+	if want := start.Add(time.Hour); !info.ModTime().Equal(want) {
+		t.Errorf("ModTime = %v, want %v", info.ModTime(), want)
+	}
+}
+
+// TestMemFS_Concurrent 测试并发追加写入不会丢失内容。
+func TestMemFS_Concurrent(t *testing.T) {
+	m := NewMemFS()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f, err := m.OpenFile("log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			if nil != err {
+				t.Errorf("OpenFile: %v", err)
+				return
+			}
+			defer f.Close() // nolint:errcheck
+			for j := 0; j < 100; j++ {
+				_, _ = f.Write([]byte("x\n"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if data, _ := m.ReadFile("log"); 800 != strings.Count(string(data), "\n") {
+		t.Errorf("行数 = %d, want 800", strings.Count(string(data), "\n"))
+	}
+}
+
+// TestMemFS_Components 测试向 kit/fs 的辅助函数注入 MemFS。
+func TestMemFS_Components(t *testing.T) {
+	m := NewMemFS()
+
+	if err := kitfs.EnsureDirFS(m, "/etc/app", 0755); nil != err {
+		t.Fatalf("EnsureDirFS: %v", err)
+	}
+	if err := kitfs.WriteFileAtomicFS(m, "/etc/app/app.yaml", []byte("v1"), 0640); nil != err {
+		t.Fatalf("WriteFileAtomicFS: %v", err)
+	}
+	if err := kitfs.WriteFileAtomicFS(m, "/etc/app/app.yaml", []byte("v2"), 0640); nil != err {
+		t.Fatalf("WriteFileAtomicFS: %v", err)
+	}
+	entries, _ := iofs.ReadDir(m, "etc/app")
+	if 1 != len(entries) || "app.yaml" != entries[0].Name() {
+		t.Errorf("目录内容 = %v，不应残留临时文件", entries)
+	}
+	if data, _ := m.ReadFile("/etc/app/app.yaml"); "v2" != string(data) {
+		t.Errorf("内容 = %q", data)
+	}
+	if err := kitfs.EnsureDirFS(m, "/etc/app/app.yaml", 0755); !errors.Is(err, kitfs.ErrNotDir) {
+		t.Errorf("EnsureDirFS 文件 err = %v, want ErrNotDir", err)
+	}
+
+}
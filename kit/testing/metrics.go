@@ -0,0 +1,228 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// MetricValue 从 g 中采集名为 name、标签包含 labels 的指标值，找不到或匹配到多个时立即终止测试。
+// labels 只需包含用于区分的部分标签；计数器、仪表盘与无类型指标返回其值，直方图与摘要返回样本数量。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - g prometheus.Gatherer：指标来源，例如 prometheus.DefaultGatherer 或 *prometheus.Registry。
+//   - name string：指标全名，例如 "kit_goroutine_worker_current"。
+//   - labels prometheus.Labels：需要匹配的标签，可以为 nil。
+//
+// 返回值：
+//   - float64：指标值。
+//
+// 示例：
+//
+//	running := testing.MetricValue(t, prometheus.DefaultGatherer, "kit_goroutine_worker_current",
+//	    prometheus.Labels{"name": "default", "state": "running"})
+func MetricValue(t testing.TB, g prometheus.Gatherer, name string, labels prometheus.Labels) float64 {
+	t.Helper()
+
+	value, err := gatherValue(g, name, labels)
+	if nil != err {
+		t.Fatalf("%v", err)
+	}
+	return value
+}
+
+// AssertMetric 断言 g 中名为 name、标签包含 labels 的指标值等于 want。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - g prometheus.Gatherer：指标来源。
+//   - name string：指标全名。
+//   - labels prometheus.Labels：需要匹配的标签，可以为 nil。
+//   - want float64：期望的值。
+//
+// 返回值：
+//   - bool：断言成功时返回 true。
+func AssertMetric(t testing.TB, g prometheus.Gatherer, name string, labels prometheus.Labels, want float64) bool {
+	t.Helper()
+
+	got, err := gatherValue(g, name, labels)
+	if nil != err {
+		t.Errorf("%v", err)
+		return false
+	}
+	if got != want {
+		t.Errorf("指标 %s%s = %v，期望 %v", name, formatLabels(labels), got, want)
+		return false
+	}
+	return true
+}
+
+// AssertMetricLabels 断言 g 中名为 name 的指标恰好包含 want 中的标签组合（与顺序无关）。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - g prometheus.Gatherer：指标来源。
+//   - name string：指标全名。
+//   - want []prometheus.Labels：期望的全部标签组合。
+//
+// 返回值：
+//   - bool：断言成功时返回 true。
+func AssertMetricLabels(t testing.TB, g prometheus.Gatherer, name string, want []prometheus.Labels) bool {
+	t.Helper()
+
+	mf, err := gatherFamily(g, name)
+	if nil != err {
+		t.Errorf("%v", err)
+		return false
+	}
+
+	got := make([]string, 0, len(mf.GetMetric()))
+	for _, m := range mf.GetMetric() {
+		got = append(got, formatLabels(metricLabels(m)))
+	}
+	wantStrs := make([]string, 0, len(want))
+	for _, l := range want {
+		wantStrs = append(wantStrs, formatLabels(l))
+	}
+	sort.Strings(got)
+	sort.Strings(wantStrs)
+
+	if strings.Join(got, ",") != strings.Join(wantStrs, ",") {
+		t.Errorf("指标 %s 的标签组合为 %v，期望 %v", name, got, wantStrs)
+		return false
+	}
+	return true
+}
+
+// CollectorValue 直接从收集器（例如 goroutine.MetricWorkerCurrent）中读取标签包含 labels 的指标值，
+// 无需注册到 Registry；找不到或匹配到多个时立即终止测试。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - c prometheus.Collector：收集器。
+//   - labels prometheus.Labels：需要匹配的标签，可以为 nil。
+//
+// 返回值：
+//   - float64：指标值。
+func CollectorValue(t testing.TB, c prometheus.Collector, labels prometheus.Labels) float64 {
+	t.Helper()
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); nil != err {
+		t.Fatalf("注册收集器失败：%v", err)
+	}
+	mfs, err := reg.Gather()
+	if nil != err {
+		t.Fatalf("采集指标失败：%v", err)
+	}
+	if 1 != len(mfs) {
+		t.Fatalf("收集器产生了 %d 个指标族，期望 1 个", len(mfs))
+	}
+
+	value, err := familyValue(mfs[0], labels)
+	if nil != err {
+		t.Fatalf("%v", err)
+	}
+	return value
+}
+
+// gatherValue 采集并返回匹配的单个指标值。
+func gatherValue(g prometheus.Gatherer, name string, labels prometheus.Labels) (float64, error) {
+	mf, err := gatherFamily(g, name)
+	if nil != err {
+		return 0, err
+	}
+	return familyValue(mf, labels)
+}
+
+// gatherFamily 采集并返回名为 name 的指标族。
+func gatherFamily(g prometheus.Gatherer, name string) (*dto.MetricFamily, error) {
+	mfs, err := g.Gather()
+	if nil != err {
+		return nil, fmt.Errorf("采集指标失败：%w", err)
+	}
+	for _, mf := range mfs {
+		if mf.GetName() == name {
+			return mf, nil
+		}
+	}
+	return nil, fmt.Errorf("未找到指标 %s", name)
+}
+
+// familyValue 返回指标族中标签包含 labels 的唯一指标的值。
+func familyValue(mf *dto.MetricFamily, labels prometheus.Labels) (float64, error) {
+	var matched []*dto.Metric
+	available := make([]string, 0, len(mf.GetMetric()))
+	for _, m := range mf.GetMetric() {
+		ml := metricLabels(m)
+		available = append(available, formatLabels(ml))
+		if labelsMatch(ml, labels) {
+			matched = append(matched, m)
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return 0, fmt.Errorf("指标 %s 中没有标签匹配 %s 的序列，现有序列：%v", mf.GetName(), formatLabels(labels), available)
+	case 1:
+	default:
+		return 0, fmt.Errorf("指标 %s 中有 %d 个序列匹配 %s，请提供更多标签", mf.GetName(), len(matched), formatLabels(labels))
+	}
+
+	m := matched[0]
+	switch mf.GetType() {
+	case dto.MetricType_COUNTER:
+		return m.GetCounter().GetValue(), nil
+	case dto.MetricType_GAUGE:
+		return m.GetGauge().GetValue(), nil
+	case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+		return float64(m.GetHistogram().GetSampleCount()), nil
+	case dto.MetricType_SUMMARY:
+		return float64(m.GetSummary().GetSampleCount()), nil
+	default:
+		return m.GetUntyped().GetValue(), nil
+	}
+}
+
+// metricLabels 将指标的标签对转换为映射。
+func metricLabels(m *dto.Metric) prometheus.Labels {
+	labels := make(prometheus.Labels, len(m.GetLabel()))
+	for _, lp := range m.GetLabel() {
+		labels[lp.GetName()] = lp.GetValue()
+	}
+	return labels
+}
+
+// labelsMatch 判断 have 是否包含 want 中的全部标签。
+func labelsMatch(have, want prometheus.Labels) bool {
+	for k, v := range want {
+		if have[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// formatLabels 以 {k="v",...} 的形式按键排序输出标签。
+func formatLabels(labels prometheus.Labels) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, labels[k]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func newTestRegistry() (*prometheus.Registry, *prometheus.CounterVec, *prometheus.GaugeVec) {
+	reg := prometheus.NewRegistry()
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_requests_total"}, []string{"method", "code"})
+	workers := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_workers"}, []string{"name", "state"})
+	reg.MustRegister(requests, workers)
+
+	requests.WithLabelValues("GET", "200").Add(3)
+	requests.WithLabelValues("POST", "500").Inc()
+	workers.WithLabelValues("default", "running").Set(2)
+	return reg, requests, workers
+}
+
+func TestMetricAssertions(t *testing.T) {
+	reg, _, workers := newTestRegistry()
+
+	if got := MetricValue(t, reg, "test_requests_total", prometheus.Labels{"method": "GET"}); got != 3 {
+		t.Errorf("MetricValue = %v, want 3", got)
+	}
+	AssertMetric(t, reg, "test_workers", prometheus.Labels{"state": "running"}, 2)
+	AssertMetricLabels(t, reg, "test_requests_total", []prometheus.Labels{
+		{"method": "POST", "code": "500"},
+		{"method": "GET", "code": "200"},
+	})
+
+	if got := CollectorValue(t, workers, prometheus.Labels{"name": "default"}); got != 2 {
+		t.Errorf("CollectorValue = %v, want 2", got)
+	}
+}
+
+func TestMetricAssertions_Failure(t *testing.T) {
+	reg, _, _ := newTestRegistry()
+
+	tests := []struct {
+		name  string
+		fn    func(tb testing.TB)
+		fatal bool
+		want  string
+	}{
+		{"值不一致", func(tb testing.TB) {
+			AssertMetric(tb, reg, "test_workers", nil, 5)
+		}, false, "期望 5"},
+		{"指标不存在", func(tb testing.TB) {
+			MetricValue(tb, reg, "missing_metric", nil)
+		}, true, "missing_metric"},
+		{"匹配多个序列", func(tb testing.TB) {
+			MetricValue(tb, reg, "test_requests_total", nil)
+		}, true, "2 个序列"},
+		{"标签不匹配", func(tb testing.TB) {
+			AssertMetric(tb, reg, "test_requests_total", prometheus.Labels{"method": "PUT"}, 1)
+		}, false, `code="200"`},
+		{"标签组合不一致", func(tb testing.TB) {
+			AssertMetricLabels(tb, reg, "test_requests_total", []prometheus.Labels{{"method": "GET", "code": "200"}})
+		}, false, "标签组合"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := newRecordTB(t)
+			rec.run(tt.fn)
+			if !rec.failed || rec.fatal != tt.fatal {
+				t.Fatalf("failed = %v, fatal = %v, want fatal = %v", rec.failed, rec.fatal, tt.fatal)
+			}
+			if !strings.Contains(rec.output(), tt.want) {
+				t.Errorf("output = %q, want %q", rec.output(), tt.want)
+			}
+		})
+	}
+}
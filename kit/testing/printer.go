@@ -0,0 +1,667 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// ColorAuto 表示根据输出目标自动决定是否着色：输出为终端且未设置 NO_COLOR 环境变量时着色。
+	ColorAuto ColorMode = iota
+	// ColorAlways 表示始终输出 ANSI 颜色。
+	ColorAlways
+	// ColorNever 表示从不输出 ANSI 颜色。
+	ColorNever
+)
+
+const (
+	// FormatText 表示带前缀的纯文本输出格式。
+	FormatText OutputFormat = "text"
+	// FormatJSON 表示每行一个 JSON 对象的输出格式，便于 CI 系统解析。
+	FormatJSON OutputFormat = "json"
+)
+
+const (
+	// levelNone 表示不带级别的普通输出，对应 Println 与 Printf。
+	levelNone printLevel = iota
+	// levelInfo 表示信息级别的输出。
+	levelInfo
+	// levelWarn 表示警告级别的输出。
+	levelWarn
+	// levelError 表示错误级别的输出。
+	levelError
+	// levelDebug 表示调试级别的输出，只在 VerbosityDebug 下输出。
+	levelDebug
+)
+
+const (
+	// colorReset 是重置 ANSI 颜色的控制序列。
+	colorReset = "\033[0m"
+	// noColorEnv 是约定俗成的禁用颜色环境变量，参考 https://no-color.org。
+	noColorEnv = "NO_COLOR"
+	// formatEnv 是设置默认输出格式的环境变量，取值为 text 或 json。
+	formatEnv = "KIT_TESTING_FORMAT"
+	// callerDepth 是查找测试函数名时最多回溯的调用栈深度。
+	callerDepth = 32
+	// indentUnit 是每层段落的缩进。
+	indentUnit = "  "
+)
+
+var (
+	// levelTags 定义了各级别在输出中的标签。
+	levelTags = map[printLevel]string{
+		levelInfo:  "[INFO] ",
+		levelWarn:  "[WARN] ",
+		levelError: "[ERROR] ",
+		levelDebug: "[DEBUG] ",
+	}
+
+	// levelNames 定义了各级别在 JSON 输出中的名称。
+	levelNames = map[printLevel]string{
+		levelNone:  "print",
+		levelInfo:  "info",
+		levelWarn:  "warn",
+		levelError: "error",
+		levelDebug: "debug",
+	}
+
+	// testFuncPrefixes 定义了 go test 会执行的顶层函数名前缀，用于从调用栈中识别测试名。
+	testFuncPrefixes = []string{"Test", "Benchmark", "Example", "Fuzz"}
+
+	// levelColors 定义了各级别使用的 ANSI 颜色控制序列。
+	levelColors = map[printLevel]string{
+		levelInfo:  "\033[32m",
+		levelWarn:  "\033[33m",
+		levelError: "\033[31m",
+		levelDebug: "\033[90m",
+	}
+
+	// defaultPrinter 是包级别输出函数使用的默认输出器。
+	defaultPrinter = NewPrinter()
+)
+
+type (
+	// ColorMode 定义了输出着色的模式。
+	ColorMode int
+
+	// OutputFormat 定义了输出的格式。
+	OutputFormat string
+
+	// printLevel 定义了输出的级别，用于决定标签和颜色。
+	printLevel int
+
+	// PrinterOption 定义了 Printer 的配置选项。
+	PrinterOption func(*Printer)
+
+	// Printer 是带统一前缀的测试输出器。
+	// 包级别的 Println、Printf 等函数使用默认的 Printer；需要不同前缀或输出目标时可以创建独立实例。
+	// Printer 的所有方法都是并发安全的。
+	Printer struct {
+		// mu 保护配置字段，并保证单条输出不会与其他输出交错。
+		mu sync.Mutex
+		// out 是输出目标，为 nil 时在每次输出时使用当前的 os.Stdout。
+		out io.Writer
+		// prefix 是每行输出的前缀。
+		prefix string
+		// color 是着色模式。
+		color ColorMode
+		// format 是输出格式。
+		format OutputFormat
+		// verbosity 是输出详细程度，为 VerbosityDefault 时取自命令行参数或环境变量。
+		verbosity Verbosity
+		// test 是关联的测试名，为空时 JSON 输出会尝试从调用栈中识别。
+		test string
+		// scope 是作用域路径，以 "/" 分隔，为空时不输出作用域标签。
+		scope string
+		// sections 是当前打开的段落栈，决定输出的缩进层级。
+		sections []section
+	}
+
+	// section 记录一个打开的段落。
+	section struct {
+		// name 是段落名称。
+		name string
+		// start 是段落开始的时间。
+		start time.Time
+	}
+
+	// jsonLine 是 JSON 输出格式下的一行记录。
+	jsonLine struct {
+		// Time 是输出时间。
+		Time time.Time `json:"time"`
+		// Level 是输出级别。
+		Level string `json:"level"`
+		// Test 是测试名，无法识别时省略。
+		Test string `json:"test,omitempty"`
+		// Scope 是输出所在的作用域路径，以 "/" 分隔，不在作用域中时省略。
+		Scope string `json:"scope,omitempty"`
+		// Section 是输出所在的段落路径，以 "/" 分隔，不在段落中时省略。
+		Section string `json:"section,omitempty"`
+		// Message 是输出内容，不包含前缀和末尾换行。
+		Message string `json:"message"`
+	}
+)
+
+// WithPrefix 设置输出前缀。
+//
+// 参数：
+//   - prefix：每行输出的前缀，默认为 "=-=       "。
+//
+// 返回值：
+//   - PrinterOption：配置选项函数。
+func WithPrefix(prefix string) PrinterOption {
+	return func(p *Printer) {
+		p.prefix = prefix
+	}
+}
+
+// WithColor 设置着色模式。
+//
+// 参数：
+//   - mode：着色模式，默认为 ColorAuto。
+//
+// 返回值：
+//   - PrinterOption：配置选项函数。
+func WithColor(mode ColorMode) PrinterOption {
+	return func(p *Printer) {
+		p.color = mode
+	}
+}
+
+// WithWriter 设置输出目标。
+//
+// 参数：
+//   - w：输出目标，为 nil 时输出到 os.Stdout。
+//
+// 返回值：
+//   - PrinterOption：配置选项函数。
+func WithWriter(w io.Writer) PrinterOption {
+	return func(p *Printer) {
+		p.out = w
+	}
+}
+
+// WithFormat 设置输出格式。
+//
+// 参数：
+//   - format：输出格式，默认取自环境变量 KIT_TESTING_FORMAT，未设置时为 FormatText。
+//
+// 返回值：
+//   - PrinterOption：配置选项函数。
+func WithFormat(format OutputFormat) PrinterOption {
+	return func(p *Printer) {
+		p.format = format
+	}
+}
+
+// NewPrinter 创建一个新的输出器。
+//
+// 参数：
+//   - opts：可选的配置选项。
+//
+// 返回值：
+//   - *Printer：新的输出器。
+func NewPrinter(opts ...PrinterOption) *Printer {
+	p := &Printer{
+		prefix: logHeader,
+		color:  ColorAuto,
+		format: formatFromEnv(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// SetPrefix 设置包级别输出函数使用的前缀。
+// 通常在包的 TestMain 或 init 中调用，用于区分不同包的测试输出。
+//
+// 参数：
+//   - prefix：新的前缀。
+func SetPrefix(prefix string) {
+	defaultPrinter.SetPrefix(prefix)
+}
+
+// SetColor 设置包级别输出函数使用的着色模式。
+//
+// 参数：
+//   - mode：着色模式。
+func SetColor(mode ColorMode) {
+	defaultPrinter.SetColor(mode)
+}
+
+// SetFormat 设置包级别输出函数使用的输出格式。
+//
+// 参数：
+//   - format：输出格式。
+func SetFormat(format OutputFormat) {
+	defaultPrinter.SetFormat(format)
+}
+
+// ForTest 返回一个基于默认输出器、关联到指定测试的输出器。
+//
+// 参数：
+//   - t：要关联的测试实例。
+//
+// 返回值：
+//   - *Printer：新的输出器。
+func ForTest(t testing.TB) *Printer {
+	return defaultPrinter.ForTest(t)
+}
+
+// Section 使用默认输出器开始一个段落，返回结束段落的函数。
+//
+// 参数：
+//   - name：段落名称。
+//
+// 返回值：
+//   - func()：结束段落的函数，通常配合 defer 使用。
+func Section(name string) func() {
+	return defaultPrinter.Section(name)
+}
+
+// Step 使用默认输出器在当前段落中输出一个步骤。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - a：格式化参数。
+func Step(format string, a ...interface{}) {
+	defaultPrinter.Step(format, a...)
+}
+
+// Info 使用默认输出器输出信息级别的内容，着色时显示为绿色。
+//
+// 参数：
+//   - a ...interface{}：要输出的内容。
+func Info(a ...interface{}) {
+	defaultPrinter.Info(a...)
+}
+
+// Infof 使用默认输出器输出格式化的信息级别内容。
+//
+// 参数：
+//   - format string：格式化字符串。
+//   - a ...interface{}：格式化参数。
+func Infof(format string, a ...interface{}) {
+	defaultPrinter.Infof(format, a...)
+}
+
+// Warn 使用默认输出器输出警告级别的内容，着色时显示为黄色。
+//
+// 参数：
+//   - a ...interface{}：要输出的内容。
+func Warn(a ...interface{}) {
+	defaultPrinter.Warn(a...)
+}
+
+// Warnf 使用默认输出器输出格式化的警告级别内容。
+//
+// 参数：
+//   - format string：格式化字符串。
+//   - a ...interface{}：格式化参数。
+func Warnf(format string, a ...interface{}) {
+	defaultPrinter.Warnf(format, a...)
+}
+
+// Error 使用默认输出器输出错误级别的内容，着色时显示为红色。
+//
+// 参数：
+//   - a ...interface{}：要输出的内容。
+func Error(a ...interface{}) {
+	defaultPrinter.Error(a...)
+}
+
+// Errorf 使用默认输出器输出格式化的错误级别内容。
+//
+// 参数：
+//   - format string：格式化字符串。
+//   - a ...interface{}：格式化参数。
+func Errorf(format string, a ...interface{}) {
+	defaultPrinter.Errorf(format, a...)
+}
+
+// SetPrefix 设置输出前缀。
+//
+// 参数：
+//   - prefix：新的前缀。
+func (p *Printer) SetPrefix(prefix string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prefix = prefix
+}
+
+// SetColor 设置着色模式。
+//
+// 参数：
+//   - mode：着色模式。
+func (p *Printer) SetColor(mode ColorMode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.color = mode
+}
+
+// SetFormat 设置输出格式。
+//
+// 参数：
+//   - format：输出格式。
+func (p *Printer) SetFormat(format OutputFormat) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.format = format
+}
+
+// ForTest 返回一个关联到指定测试的新输出器，其余配置与当前输出器相同。
+// JSON 格式下每行记录都会带上 t.Name()，包括子测试的完整名称。
+//
+// 参数：
+//   - t：要关联的测试实例。
+//
+// 返回值：
+//   - *Printer：新的输出器。
+func (p *Printer) ForTest(t testing.TB) *Printer {
+	c := p.clone()
+	c.test = t.Name()
+	return c
+}
+
+// clone 返回配置与当前输出器相同的新输出器，不复制打开的段落。
+func (p *Printer) clone() *Printer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &Printer{
+		out:       p.out,
+		prefix:    p.prefix,
+		color:     p.color,
+		format:    p.format,
+		verbosity: p.verbosity,
+		test:      p.test,
+		scope:     p.scope,
+	}
+}
+
+// Section 开始一个段落：输出段落开始标记，并使之后的所有输出增加一级缩进。
+// 返回的函数用于结束段落，会输出结束标记和段落耗时；段落可以嵌套，结束函数重复调用时只生效一次。
+//
+// 参数：
+//   - name：段落名称。
+//
+// 返回值：
+//   - func()：结束段落的函数，通常配合 defer 使用。
+//
+// 示例：
+//
+//	defer p.Section("启动服务")()
+//	p.Step("监听端口 %d", port)
+func (p *Printer) Section(name string) func() {
+	p.print(levelNone, "▶ "+name+"\n")
+
+	p.mu.Lock()
+	p.sections = append(p.sections, section{name: name, start: time.Now()})
+	depth := len(p.sections)
+	p.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			// 外层段落已先结束时本段落已被一并关闭；内层段落未结束时则随本段落一起关闭，保证缩进层级正确。
+			if len(p.sections) < depth {
+				p.mu.Unlock()
+				return
+			}
+			sec := p.sections[depth-1]
+			p.sections = p.sections[:depth-1]
+			p.mu.Unlock()
+
+			p.print(levelNone, fmt.Sprintf("◀ %s (%v)\n", sec.name, time.Since(sec.start).Round(time.Microsecond)))
+		})
+	}
+}
+
+// Step 在当前段落中输出一个步骤，末尾没有换行时自动补充。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - a：格式化参数。
+func (p *Printer) Step(format string, a ...interface{}) {
+	p.print(levelNone, "- "+ensureNewline(fmt.Sprintf(format, a...)))
+}
+
+// Println 输出带前缀的内容并换行，参数之间以空格分隔。
+//
+// 参数：
+//   - a ...interface{}：要输出的内容。
+func (p *Printer) Println(a ...interface{}) {
+	p.print(levelNone, fmt.Sprintln(a...))
+}
+
+// Printf 输出带前缀的格式化内容，不会自动换行。
+//
+// 参数：
+//   - format string：格式化字符串。
+//   - a ...interface{}：格式化参数。
+func (p *Printer) Printf(format string, a ...interface{}) {
+	p.print(levelNone, fmt.Sprintf(format, a...))
+}
+
+// Info 输出信息级别的内容并换行。
+//
+// 参数：
+//   - a ...interface{}：要输出的内容。
+func (p *Printer) Info(a ...interface{}) {
+	p.print(levelInfo, fmt.Sprintln(a...))
+}
+
+// Infof 输出格式化的信息级别内容，末尾没有换行时自动补充。
+//
+// 参数：
+//   - format string：格式化字符串。
+//   - a ...interface{}：格式化参数。
+func (p *Printer) Infof(format string, a ...interface{}) {
+	p.print(levelInfo, ensureNewline(fmt.Sprintf(format, a...)))
+}
+
+// Warn 输出警告级别的内容并换行。
+//
+// 参数：
+//   - a ...interface{}：要输出的内容。
+func (p *Printer) Warn(a ...interface{}) {
+	p.print(levelWarn, fmt.Sprintln(a...))
+}
+
+// Warnf 输出格式化的警告级别内容，末尾没有换行时自动补充。
+//
+// 参数：
+//   - format string：格式化字符串。
+//   - a ...interface{}：格式化参数。
+func (p *Printer) Warnf(format string, a ...interface{}) {
+	p.print(levelWarn, ensureNewline(fmt.Sprintf(format, a...)))
+}
+
+// Error 输出错误级别的内容并换行。
+//
+// 参数：
+//   - a ...interface{}：要输出的内容。
+func (p *Printer) Error(a ...interface{}) {
+	p.print(levelError, fmt.Sprintln(a...))
+}
+
+// Errorf 输出格式化的错误级别内容，末尾没有换行时自动补充。
+//
+// 参数：
+//   - format string：格式化字符串。
+//   - a ...interface{}：格式化参数。
+func (p *Printer) Errorf(format string, a ...interface{}) {
+	p.print(levelError, ensureNewline(fmt.Sprintf(format, a...)))
+}
+
+// print 组装前缀、级别标签与颜色后一次性写出，避免并发输出交错。
+func (p *Printer) print(level printLevel, msg string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.enabled(level) {
+		return
+	}
+
+	out := p.writer()
+
+	if FormatJSON == p.format {
+		p.printJSON(out, level, msg)
+		return
+	}
+
+	var b strings.Builder
+	colored := level != levelNone && p.colorEnabled(out)
+	if colored {
+		b.WriteString(levelColors[level])
+	}
+	b.WriteString(p.prefix)
+	b.WriteString(p.scopeTag())
+	b.WriteString(strings.Repeat(indentUnit, len(p.sections)))
+	b.WriteString(levelTags[level])
+	if colored {
+		// 颜色在换行符之前结束，避免影响下一行的输出。
+		b.WriteString(strings.TrimSuffix(msg, "\n"))
+		b.WriteString(colorReset)
+		if strings.HasSuffix(msg, "\n") {
+			b.WriteString("\n")
+		}
+	} else {
+		b.WriteString(msg)
+	}
+
+	if bufferOutput(out, b.String(), false) {
+		return
+	}
+	_, _ = io.WriteString(out, b.String())
+}
+
+// printJSON 以 JSON 行的形式写出一条记录，调用方必须持有锁。
+func (p *Printer) printJSON(out io.Writer, level printLevel, msg string) {
+	test := p.test
+	if "" == test {
+		test = callerTestName()
+	}
+	names := make([]string, 0, len(p.sections))
+	for _, sec := range p.sections {
+		names = append(names, sec.name)
+	}
+	line, err := json.Marshal(jsonLine{
+		Time:    time.Now(),
+		Level:   levelNames[level],
+		Test:    test,
+		Scope:   p.scope,
+		Section: strings.Join(names, "/"),
+		Message: strings.TrimSuffix(msg, "\n"),
+	})
+	if nil != err {
+		// 所有字段都是可序列化的基础类型，这里只做兜底。
+		return
+	}
+	if bufferOutput(out, string(line)+"\n", true) {
+		return
+	}
+	_, _ = out.Write(append(line, '\n'))
+}
+
+// writer 返回当前的输出目标，调用方必须持有锁。
+func (p *Printer) writer() io.Writer {
+	if nil == p.out {
+		return os.Stdout
+	}
+	return p.out
+}
+
+// colorEnabled 判断是否需要着色，调用方必须持有锁。
+func (p *Printer) colorEnabled(out io.Writer) bool {
+	switch p.color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		if _, ok := os.LookupEnv(noColorEnv); ok {
+			return false
+		}
+		return isTerminal(out)
+	}
+}
+
+// isTerminal 判断输出目标是否为终端。
+// 只识别 *os.File 类型的字符设备，其他 io.Writer 一律视为非终端。
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if nil != err {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// formatFromEnv 从环境变量读取默认输出格式，未设置或取值无法识别时使用 FormatText。
+func formatFromEnv() OutputFormat {
+	if FormatJSON == OutputFormat(strings.ToLower(os.Getenv(formatEnv))) {
+		return FormatJSON
+	}
+	return FormatText
+}
+
+// callerTestName 从调用栈中查找最近的测试函数，返回其函数名。
+// 子测试与测试中启动的协程无法通过调用栈识别，需要使用 ForTest 显式关联。
+func callerTestName() string {
+	pcs := make([]uintptr, callerDepth)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if name := testFuncName(frame.Function); "" != name {
+			return name
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// testFuncName 在函数全名为测试函数时返回其短名称，否则返回空字符串。
+// 函数全名形如 "github.com/x/pkg.TestFoo" 或 "github.com/x/pkg.TestFoo.func1"。
+func testFuncName(function string) string {
+	if i := strings.LastIndexByte(function, '/'); i >= 0 {
+		function = function[i+1:]
+	}
+	// 去掉包名。
+	_, name, ok := strings.Cut(function, ".")
+	if !ok {
+		return ""
+	}
+	name, _, _ = strings.Cut(name, ".")
+	for _, prefix := range testFuncPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return name
+		}
+	}
+	return ""
+}
+
+// ensureNewline 确保字符串以换行符结尾。
+func ensureNewline(s string) string {
+	if strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
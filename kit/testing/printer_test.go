@@ -0,0 +1,196 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrinter_Prefix(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter(WithWriter(&buf), WithPrefix(">> "))
+
+	p.Println("a", 1)
+	p.Printf("b=%d\n", 2)
+	p.SetPrefix("## ")
+	p.Println("c")
+
+	want := ">> a 1\n>> b=2\n## c\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrinter_Levels(t *testing.T) {
+	type levelCase struct {
+		color ColorMode
+		print func(p *Printer)
+		want  string
+	}
+
+	RunTable(t, []Case[levelCase]{
+		{Name: "Info 不着色", Data: levelCase{
+			color: ColorNever,
+			print: func(p *Printer) { p.Info("ok") },
+			want:  "=-=       [INFO] ok\n",
+		}},
+		{Name: "Warnf 自动补充换行", Data: levelCase{
+			color: ColorNever,
+			print: func(p *Printer) { p.Warnf("slow %dms", 10) },
+			want:  "=-=       [WARN] slow 10ms\n",
+		}},
+		{Name: "Error 着色", Data: levelCase{
+			color: ColorAlways,
+			print: func(p *Printer) { p.Error("boom") },
+			want:  "\033[31m=-=       [ERROR] boom\033[0m\n",
+		}},
+		{Name: "Infof 着色", Data: levelCase{
+			color: ColorAlways,
+			print: func(p *Printer) { p.Infof("done\n") },
+			want:  "\033[32m=-=       [INFO] done\033[0m\n",
+		}},
+		{Name: "Println 不受着色影响", Data: levelCase{
+			color: ColorAlways,
+			print: func(p *Printer) { p.Println("plain") },
+			want:  "=-=       plain\n",
+		}},
+		{Name: "自动模式下非终端不着色", Data: levelCase{
+			color: ColorAuto,
+			print: func(p *Printer) { p.Error("boom") },
+			want:  "=-=       [ERROR] boom\n",
+		}},
+	}, func(t *testing.T, tt levelCase) {
+		var buf bytes.Buffer
+		p := NewPrinter(WithWriter(&buf), WithColor(tt.color))
+		tt.print(p)
+		if buf.String() != tt.want {
+			t.Errorf("output = %q, want %q", buf.String(), tt.want)
+		}
+	})
+}
+
+func TestSetPrefix(t *testing.T) {
+	var buf bytes.Buffer
+	old := defaultPrinter
+	defaultPrinter = NewPrinter(WithWriter(&buf))
+	defer func() { defaultPrinter = old }()
+
+	SetPrefix("[pkg] ")
+	SetColor(ColorNever)
+	Println("x")
+	Warn("y")
+
+	want := "[pkg] x\n[pkg] [WARN] y\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrinter_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter(WithWriter(&buf), WithFormat(FormatJSON))
+
+	p.Println("hello", 1)
+	p.Warnf("slow %dms", 5)
+	p.ForTest(t).Printf("sub\n")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines: %q", len(lines), buf.String())
+	}
+
+	want := []jsonLine{
+		{Level: "print", Test: "TestPrinter_JSON", Message: "hello 1"},
+		{Level: "warn", Test: "TestPrinter_JSON", Message: "slow 5ms"},
+		{Level: "print", Test: t.Name(), Message: "sub"},
+	}
+	for i, line := range lines {
+		var got jsonLine
+		if err := json.Unmarshal([]byte(line), &got); nil != err {
+			t.Fatalf("line %d is not JSON: %q", i, line)
+		}
+		if got.Time.IsZero() {
+			t.Errorf("line %d: missing time", i)
+		}
+		got.Time = time.Time{}
+		if got != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestTestFuncName(t *testing.T) {
+	tests := []struct {
+		function string
+		want     string
+	}{
+		{"github.com/fsyyft-go/monorepo/kit/testing.TestPrinter_JSON", "TestPrinter_JSON"},
+		{"github.com/fsyyft-go/monorepo/kit/testing.TestPrinter_JSON.func1", "TestPrinter_JSON"},
+		{"github.com/fsyyft-go/monorepo/kit/testing.BenchmarkX", "BenchmarkX"},
+		{"github.com/fsyyft-go/monorepo/kit/testing.(*Printer).Println", ""},
+		{"testing.tRunner", ""},
+		{"main", ""},
+	}
+	for _, tt := range tests {
+		if got := testFuncName(tt.function); got != tt.want {
+			t.Errorf("testFuncName(%q) = %q, want %q", tt.function, got, tt.want)
+		}
+	}
+}
+
+func TestPrinter_Section(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter(WithWriter(&buf), WithPrefix(""))
+
+	endOuter := p.Section("outer")
+	p.Step("step %d", 1)
+	endInner := p.Section("inner")
+	p.Println("detail")
+	endInner()
+	endInner()
+	endOuter()
+	p.Println("after")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	wantPrefixes := []string{
+		"▶ outer",
+		"  - step 1",
+		"  ▶ inner",
+		"    detail",
+		"  ◀ inner (",
+		"◀ outer (",
+		"after",
+	}
+	if len(lines) != len(wantPrefixes) {
+		t.Fatalf("got %d lines: %q", len(lines), buf.String())
+	}
+	for i, want := range wantPrefixes {
+		if !strings.HasPrefix(lines[i], want) {
+			t.Errorf("line %d = %q, want prefix %q", i, lines[i], want)
+		}
+	}
+}
+
+func TestPrinter_SectionJSON(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter(WithWriter(&buf), WithFormat(FormatJSON))
+
+	end := p.Section("setup")
+	p.Step("connect")
+	end()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	var got jsonLine
+	if err := json.Unmarshal([]byte(lines[1]), &got); nil != err {
+		t.Fatalf("invalid JSON: %q", lines[1])
+	}
+	if got.Section != "setup" || got.Message != "- connect" {
+		t.Errorf("got %+v", got)
+	}
+}
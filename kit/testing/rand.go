@@ -0,0 +1,270 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	kitenv "github.com/fsyyft-go/monorepo/kit/env"
+)
+
+const (
+	// seedEnv 是指定随机种子的环境变量，用于复现失败的测试。
+	seedEnv = "KIT_TESTING_SEED"
+	// alphanumeric 是 String 默认使用的字符集。
+	alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// fillMaxDepth 是 Fill 递归填充嵌套类型的最大深度，避免自引用类型无限递归。
+	fillMaxDepth = 8
+	// fillMaxLen 是 Fill 为切片、映射和字符串生成的最大长度。
+	fillMaxLen = 8
+)
+
+var (
+	// timeType 是 time.Time 的反射类型，Fill 对其做特殊处理。
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+type (
+	// Rand 是带固定种子的随机数据生成器。
+	// 相同种子生成的数据序列完全一致，测试失败时会输出种子，便于通过环境变量 KIT_TESTING_SEED 复现。
+	// Rand 不是并发安全的，并发测试应为每个协程创建独立的实例。
+	Rand struct {
+		*rand.Rand
+
+		// seed 是生成器使用的种子。
+		seed int64
+	}
+)
+
+// NewRand 为当前测试创建随机数据生成器。
+// 种子优先取自环境变量 KIT_TESTING_SEED，未设置时使用当前时间；测试失败时会通过 t.Logf 输出种子。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//
+// 返回值：
+//   - *Rand：随机数据生成器。
+//
+// 示例：
+//
+//	r := testing.NewRand(t)
+//	name := r.String(8)
+//
+// 复现失败：
+//
+//	KIT_TESTING_SEED=1735689600000000000 go test -run TestX ./...
+func NewRand(t testing.TB) *Rand {
+	t.Helper()
+
+	seed, err := kitenv.Lookup(seedEnv, time.Now().UnixNano())
+	if nil != err {
+		t.Fatalf("随机数种子不合法：%v", err)
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("随机种子：%d，设置环境变量 %s=%d 可复现本次测试", seed, seedEnv, seed)
+		}
+	})
+
+	return NewRandWithSeed(seed)
+}
+
+// NewRandWithSeed 使用指定种子创建随机数据生成器。
+//
+// 参数：
+//   - seed int64：随机种子。
+//
+// 返回值：
+//   - *Rand：随机数据生成器。
+func NewRandWithSeed(seed int64) *Rand {
+	return &Rand{
+		Rand: rand.New(rand.NewSource(seed)), // nolint: gosec
+		seed: seed,
+	}
+}
+
+// Seed 返回生成器使用的种子。
+//
+// 返回值：
+//   - int64：随机种子。
+func (r *Rand) Seed() int64 {
+	return r.seed
+}
+
+// String 生成由大小写字母和数字组成的随机字符串。
+//
+// 参数：
+//   - n int：字符串长度。
+//
+// 返回值：
+//   - string：随机字符串。
+func (r *Rand) String(n int) string {
+	return r.StringFrom(n, alphanumeric)
+}
+
+// StringFrom 生成由指定字符集中的字符组成的随机字符串。
+//
+// 参数：
+//   - n int：字符串长度（以字符计）。
+//   - charset string：字符集，不能为空。
+//
+// 返回值：
+//   - string：随机字符串。
+func (r *Rand) StringFrom(n int, charset string) string {
+	chars := []rune(charset)
+	out := make([]rune, n)
+	for i := range out {
+		out[i] = chars[r.Intn(len(chars))]
+	}
+	return string(out)
+}
+
+// IntRange 生成 [min, max) 区间内的随机整数。
+// max 小于等于 min 时返回 min。
+//
+// 参数：
+//   - min int：下界（包含）。
+//   - max int：上界（不包含）。
+//
+// 返回值：
+//   - int：随机整数。
+func (r *Rand) IntRange(min, max int) int {
+	if max <= min {
+		return min
+	}
+	return min + r.Intn(max-min)
+}
+
+// Duration 生成 [min, max) 区间内的随机时长。
+// max 小于等于 min 时返回 min。
+//
+// 参数：
+//   - min time.Duration：下界（包含）。
+//   - max time.Duration：上界（不包含）。
+//
+// 返回值：
+//   - time.Duration：随机时长。
+func (r *Rand) Duration(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(r.Int63n(int64(max-min)))
+}
+
+// Bytes 生成指定长度的随机字节切片。
+//
+// 参数：
+//   - n int：字节数。
+//
+// 返回值：
+//   - []byte：随机字节。
+func (r *Rand) Bytes(n int) []byte {
+	b := make([]byte, n)
+	// math/rand.Rand.Read 总是返回 len(b) 且不会出错。
+	_, _ = r.Read(b)
+	return b
+}
+
+// Bool 生成随机布尔值。
+//
+// 返回值：
+//   - bool：随机布尔值。
+func (r *Rand) Bool() bool {
+	return r.Intn(2) == 1
+}
+
+// Pick 从候选值中随机选择一个。
+//
+// 参数：
+//   - r *Rand：随机数据生成器。
+//   - items []T：候选值，不能为空。
+//
+// 返回值：
+//   - T：选中的值。
+func Pick[T any](r *Rand, items []T) T {
+	return items[r.Intn(len(items))]
+}
+
+// Fill 使用随机数据填充 ptr 指向的值。
+// 支持布尔、整数、浮点数、字符串、切片、数组、映射、指针、time.Time 以及由它们组成的结构体；
+// 结构体的未导出字段、函数、通道和接口字段会被跳过。
+//
+// 参数：
+//   - ptr interface{}：指向待填充值的非 nil 指针。
+//
+// 返回值：
+//   - error：ptr 不是非 nil 指针时返回错误。
+func (r *Rand) Fill(ptr interface{}) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("Fill 需要非 nil 指针，实际为 %T", ptr)
+	}
+	r.fill(v.Elem(), 0)
+	return nil
+}
+
+// fill 递归填充可设置的值。
+func (r *Rand) fill(v reflect.Value, depth int) {
+	if depth > fillMaxDepth || !v.CanSet() {
+		return
+	}
+
+	if v.Type() == timeType {
+		// 生成 2000 年至 2100 年之间精确到秒的 UTC 时间。
+		sec := r.Int63n(100*365*24*3600) + time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+		v.Set(reflect.ValueOf(time.Unix(sec, 0).UTC()))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// 按类型位数截断，避免溢出。
+		v.SetInt(r.Int63() >> (64 - v.Type().Bits()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(r.Uint64() >> (64 - v.Type().Bits()))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(r.Float64())
+	case reflect.String:
+		v.SetString(r.String(r.IntRange(1, fillMaxLen+1)))
+	case reflect.Slice:
+		n := r.IntRange(1, fillMaxLen+1)
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			r.fill(s.Index(i), depth+1)
+		}
+		v.Set(s)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			r.fill(v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		for i, n := 0, r.IntRange(1, fillMaxLen+1); i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			val := reflect.New(v.Type().Elem()).Elem()
+			r.fill(key, depth+1)
+			r.fill(val, depth+1)
+			m.SetMapIndex(key, val)
+		}
+		v.Set(m)
+	case reflect.Ptr:
+		p := reflect.New(v.Type().Elem())
+		r.fill(p.Elem(), depth+1)
+		v.Set(p)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			r.fill(v.Field(i), depth+1)
+		}
+	default:
+		// 函数、通道、接口等类型无法生成有意义的随机值，保持零值。
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRand_Deterministic(t *testing.T) {
+	a := NewRandWithSeed(42)
+	b := NewRandWithSeed(42)
+
+	if a.String(16) != b.String(16) {
+		t.Error("same seed should generate same strings")
+	}
+	if a.IntRange(0, 1000) != b.IntRange(0, 1000) {
+		t.Error("same seed should generate same ints")
+	}
+	if string(a.Bytes(8)) != string(b.Bytes(8)) {
+		t.Error("same seed should generate same bytes")
+	}
+	if a.Seed() != 42 {
+		t.Errorf("Seed = %d, want 42", a.Seed())
+	}
+}
+
+func TestRand_Ranges(t *testing.T) {
+	r := NewRand(t)
+
+	for i := 0; i < 100; i++ {
+		if v := r.IntRange(-5, 5); v < -5 || v >= 5 {
+			t.Fatalf("IntRange = %d, out of [-5, 5)", v)
+		}
+		if d := r.Duration(time.Millisecond, time.Second); d < time.Millisecond || d >= time.Second {
+			t.Fatalf("Duration = %v, out of range", d)
+		}
+	}
+	if r.IntRange(3, 3) != 3 || r.Duration(time.Second, 0) != time.Second {
+		t.Error("empty range should return min")
+	}
+
+	s := r.StringFrom(10, "ab")
+	if len(s) != 10 || strings.Trim(s, "ab") != "" {
+		t.Errorf("StringFrom = %q", s)
+	}
+	if got := Pick(r, []string{"x"}); got != "x" {
+		t.Errorf("Pick = %q", got)
+	}
+}
+
+func TestRand_SeedFromEnv(t *testing.T) {
+	t.Setenv(seedEnv, "7")
+	if got := NewRand(t).Seed(); got != 7 {
+		t.Errorf("Seed = %d, want 7", got)
+	}
+}
+
+func TestRand_SeedPrintedOnFailure(t *testing.T) {
+	rec := newRecordTB(t)
+	var cleanups []func()
+	tb := &cleanupTB{recordTB: rec, cleanups: &cleanups}
+	var logs []string
+	logTB := &logRecordTB{cleanupTB: tb, logs: &logs}
+
+	t.Setenv(seedEnv, "99")
+	NewRand(logTB)
+	rec.run(func(testing.TB) { rec.Errorf("boom") })
+	for _, fn := range cleanups {
+		fn()
+	}
+
+	if len(logs) != 1 || !strings.Contains(logs[0], "99") {
+		t.Errorf("logs = %q, want seed printed", logs)
+	}
+}
+
+func TestRand_Fill(t *testing.T) {
+	type inner struct {
+		Name string
+		Tags []string
+	}
+	type sample struct {
+		Enabled  bool
+		Count    int8
+		Size     uint16
+		Ratio    float64
+		Timeout  time.Duration
+		At       time.Time
+		Inner    inner
+		Ptr      *inner
+		Labels   map[string]int
+		Fixed    [2]int
+		private  int
+		Callback func()
+	}
+
+	r := NewRandWithSeed(1)
+	var v sample
+	if err := r.Fill(&v); nil != err {
+		t.Fatalf("Fill: %v", err)
+	}
+
+	if v.Inner.Name == "" || len(v.Inner.Tags) == 0 || v.Ptr == nil || len(v.Labels) == 0 {
+		t.Errorf("Fill left fields empty: %+v", v)
+	}
+	if v.At.Year() < 2000 || v.At.Year() > 2100 {
+		t.Errorf("Fill time out of range: %v", v.At)
+	}
+	if v.private != 0 || v.Callback != nil {
+		t.Error("Fill should skip unexported and func fields")
+	}
+
+	// 相同种子填充结果一致。
+	var v2 sample
+	_ = NewRandWithSeed(1).Fill(&v2)
+	v.Callback, v2.Callback = nil, nil
+	if !reflect.DeepEqual(v, v2) {
+		t.Error("same seed should fill same values")
+	}
+
+	if err := r.Fill(v); nil == err {
+		t.Error("Fill with non-pointer should return error")
+	}
+}
+
+// logRecordTB 记录 Logf 的输出。
+type logRecordTB struct {
+	*cleanupTB
+	logs *[]string
+}
+
+func (l *logRecordTB) Logf(format string, args ...interface{}) {
+	*l.logs = append(*l.logs, fmt.Sprintf(format, args...))
+}
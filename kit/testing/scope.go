@@ -0,0 +1,63 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"strings"
+	"testing"
+)
+
+// Scope 返回一个基于默认输出器、关联到指定测试并处于指定作用域的输出器。
+// 文本格式下每行输出都带有 "[测试名/作用域路径] " 标签，JSON 格式下记录 test 与 scope 字段，
+// 便于在较长的集成测试输出中定位每一行来自哪个测试的哪个阶段。
+//
+// 参数：
+//   - t：要关联的测试实例。
+//   - name：作用域名称，例如 setup、run、teardown。
+//
+// 返回值：
+//   - *Printer：新的输出器，可以继续通过 Printer.Scope 创建嵌套的作用域。
+//
+// 示例：
+//
+//	setup := testing.Scope(t, "setup")
+//	setup.Info("启动数据库")       // =-=       [TestOrder/setup] [INFO] 启动数据库
+//	db := setup.Scope("db")
+//	db.Printf("迁移完成\n")        // =-=       [TestOrder/setup/db] 迁移完成
+func Scope(t testing.TB, name string) *Printer {
+	return defaultPrinter.ForTest(t).Scope(name)
+}
+
+// Scope 返回一个处于当前作用域的下一级作用域中的新输出器，其余配置与当前输出器相同。
+// 新输出器的段落与当前输出器相互独立。
+//
+// 参数：
+//   - name：作用域名称，首尾的 "/" 会被去掉。
+//
+// 返回值：
+//   - *Printer：新的输出器。
+func (p *Printer) Scope(name string) *Printer {
+	c := p.clone()
+	name = strings.Trim(name, "/")
+	switch {
+	case "" == name:
+	case "" == c.scope:
+		c.scope = name
+	default:
+		c.scope = c.scope + "/" + name
+	}
+	return c
+}
+
+// scopeTag 返回文本格式下的作用域标签，不在作用域中时返回空字符串，调用方必须持有锁。
+func (p *Printer) scopeTag() string {
+	if "" == p.scope {
+		return ""
+	}
+	if "" == p.test {
+		return "[" + p.scope + "] "
+	}
+	return "[" + p.test + "/" + p.scope + "] "
+}
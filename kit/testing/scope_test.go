@@ -0,0 +1,68 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestScope(t *testing.T) {
+	var buf bytes.Buffer
+	old := defaultPrinter
+	defaultPrinter = NewPrinter(WithWriter(&buf), WithPrefix(""), WithColor(ColorNever), WithFormat(FormatText))
+	defer func() { defaultPrinter = old }()
+
+	setup := Scope(t, "setup")
+	setup.Info("start")
+	db := setup.Scope("/db/")
+	end := db.Section("migrate")
+	db.Step("v1")
+	end()
+	setup.Scope("").Println("same")
+	t.Run("sub", func(t *testing.T) {
+		Scope(t, "run").Println("sub")
+	})
+	NewPrinter(WithWriter(&buf), WithPrefix("")).Scope("bare").Println("bare")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	wantPrefixes := []string{
+		"[TestScope/setup] [INFO] start",
+		"[TestScope/setup/db] ▶ migrate",
+		"[TestScope/setup/db]   - v1",
+		"[TestScope/setup/db] ◀ migrate (",
+		"[TestScope/setup] same",
+		"[TestScope/sub/run] sub",
+		"[bare] bare",
+	}
+	if len(lines) != len(wantPrefixes) {
+		t.Fatalf("got %d lines: %q", len(lines), buf.String())
+	}
+	for i, want := range wantPrefixes {
+		if !strings.HasPrefix(lines[i], want) {
+			t.Errorf("line %d = %q, want prefix %q", i, lines[i], want)
+		}
+	}
+}
+
+func TestScope_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter(WithWriter(&buf), WithFormat(FormatJSON)).ForTest(t).Scope("setup").Scope("db")
+
+	end := p.Section("migrate")
+	p.Println("v1")
+	end()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	var got jsonLine
+	if err := json.Unmarshal([]byte(lines[1]), &got); nil != err {
+		t.Fatalf("invalid JSON: %q", lines[1])
+	}
+	if got.Test != "TestScope_JSON" || got.Scope != "setup/db" || got.Section != "migrate" || got.Message != "v1" {
+		t.Errorf("got %+v", got)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/retry"
+)
+
+const (
+	// loopbackAddr 是测试辅助函数监听的默认地址，只绑定回环网卡避免暴露到外部网络。
+	loopbackAddr = "127.0.0.1:0"
+	// dialTimeout 是 WaitForAddr 单次拨号的超时时间。
+	dialTimeout = 100 * time.Millisecond
+)
+
+// FreePort 获取一个当前可用的本地 TCP 端口。
+// 端口通过监听 127.0.0.1:0 由系统分配后立即释放，因此在极少数情况下可能被其他进程抢占；
+// 能直接使用 net.Listener 的场景应优先使用 Listen。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//
+// 返回值：
+//   - int：可用的端口号。
+func FreePort(t testing.TB) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", loopbackAddr)
+	if nil != err {
+		t.Fatalf("分配空闲端口失败：%v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port // nolint: errcheck
+	if err := l.Close(); nil != err {
+		t.Fatalf("释放端口 %d 失败：%v", port, err)
+	}
+
+	return port
+}
+
+// Listen 在本地回环地址的随机端口上创建一个 TCP 监听器，测试结束时自动关闭。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//
+// 返回值：
+//   - net.Listener：创建的监听器。
+func Listen(t testing.TB) net.Listener {
+	t.Helper()
+
+	l, err := net.Listen("tcp", loopbackAddr)
+	if nil != err {
+		t.Fatalf("创建监听器失败：%v", err)
+	}
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+
+	return l
+}
+
+// WaitForAddr 等待指定地址可以建立 TCP 连接，超时后终止测试。
+// 轮询间隔基于 kit/runtime/retry 的退避策略逐步增长。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - addr string：要等待的地址，例如 "127.0.0.1:8080"。
+//   - timeout time.Duration：等待的最长时间。
+func WaitForAddr(t testing.TB, addr string, timeout time.Duration) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var lastErr error
+	err := retry.RetryWithContext(ctx, func(ctx context.Context) error {
+		d := net.Dialer{Timeout: dialTimeout}
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if nil != err {
+			lastErr = err
+			return err
+		}
+		return conn.Close()
+	}, retry.WithMin(5*time.Millisecond), retry.WithMax(100*time.Millisecond))
+	if nil != err {
+		t.Fatalf("等待地址 %s 可连接超时（%v）：%v", addr, timeout, lastErr)
+	}
+}
+
+// NewHTTPServer 启动一个 httptest.Server，测试结束时自动关闭。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - handler http.Handler：处理请求的 handler。
+//
+// 返回值：
+//   - *httptest.Server：已启动的服务器，可通过 URL 字段获取访问地址。
+//
+// 示例：
+//
+//	srv := testing.NewHTTPServer(t, promhttp.Handler())
+//	resp, err := http.Get(srv.URL + "/metrics")
+func NewHTTPServer(t testing.TB, handler http.Handler) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// StartTCPServer 在本地随机端口启动一个 TCP 服务器，每个连接在独立的协程中交给 handle 处理。
+// 测试结束时关闭监听器并等待所有连接处理函数返回；handle 返回后连接会被自动关闭。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - handle func(net.Conn)：连接处理函数。
+//
+// 返回值：
+//   - string：服务器的监听地址。
+func StartTCPServer(t testing.TB, handle func(net.Conn)) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", loopbackAddr)
+	if nil != err {
+		t.Fatalf("创建监听器失败：%v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			conn, err := l.Accept()
+			if nil != err {
+				// 监听器关闭后退出；其他错误同样无法继续接受连接。
+				if !errors.Is(err, net.ErrClosed) {
+					t.Logf("接受连接失败：%v", err)
+				}
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer conn.Close() // nolint: errcheck
+				handle(conn)
+			}()
+		}
+	}()
+
+	t.Cleanup(func() {
+		_ = l.Close()
+		wg.Wait()
+	})
+
+	return l.Addr().String()
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFreePort(t *testing.T) {
+	port := FreePort(t)
+	if port <= 0 {
+		t.Fatalf("FreePort = %d", port)
+	}
+
+	// 端口应可以被重新监听。
+	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if nil != err {
+		t.Fatalf("listen on free port: %v", err)
+	}
+	_ = l.Close()
+}
+
+func TestListenAndWaitForAddr(t *testing.T) {
+	l := Listen(t)
+	WaitForAddr(t, l.Addr().String(), time.Second)
+}
+
+func TestWaitForAddr_Timeout(t *testing.T) {
+	addr := fmt.Sprintf("127.0.0.1:%d", FreePort(t))
+
+	rec := newRecordTB(t)
+	rec.run(func(tb testing.TB) {
+		WaitForAddr(tb, addr, 50*time.Millisecond)
+	})
+
+	if !rec.fatal {
+		t.Fatal("WaitForAddr should fail on closed port")
+	}
+	if !strings.Contains(rec.output(), addr) {
+		t.Errorf("output = %q", rec.output())
+	}
+}
+
+func TestNewHTTPServer(t *testing.T) {
+	srv := NewHTTPServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "pong")
+	}))
+
+	resp, err := http.Get(srv.URL)
+	if nil != err {
+		t.Fatalf("http.Get: %v", err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "pong" {
+		t.Errorf("body = %q, want pong", body)
+	}
+}
+
+func TestStartTCPServer(t *testing.T) {
+	addr := StartTCPServer(t, func(conn net.Conn) {
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		_, _ = io.WriteString(conn, strings.ToUpper(line))
+	})
+
+	conn, err := net.Dial("tcp", addr)
+	if nil != err {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close() // nolint: errcheck
+
+	_, _ = io.WriteString(conn, "hello\n")
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if nil != err {
+		t.Fatalf("read reply: %v", err)
+	}
+	if reply != "HELLO\n" {
+		t.Errorf("reply = %q, want HELLO", reply)
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// requireServiceSkipped 在子测试中执行 RequireService，返回子测试是否被跳过。
+func requireServiceSkipped(t *testing.T, svc Service) bool {
+	var skipped bool
+	t.Run(svc.Name, func(st *testing.T) {
+		defer func() {
+			skipped = st.Skipped()
+		}()
+		RequireService(st, svc)
+	})
+	return skipped
+}
+
+// fakeDocker 将 dockerCommand 替换为执行 script 的脚本，测试结束时恢复。
+func fakeDocker(t *testing.T, script string) {
+	if "windows" == runtime.GOOS {
+		t.Skip("模拟的 docker 命令依赖 /bin/sh")
+	}
+	path := filepath.Join(t.TempDir(), "docker")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); nil != err {
+		t.Fatalf("write fake docker: %v", err)
+	}
+	origin := dockerCommand
+	dockerCommand = path
+	t.Cleanup(func() {
+		dockerCommand = origin
+	})
+}
+
+func TestRequireService_Attach(t *testing.T) {
+	l := Listen(t)
+	SetEnv(t, "KIT_TESTING_POSTGRES_ADDR", l.Addr().String())
+	UnsetEnv(t, "KIT_TESTING_POSTGRES_URL")
+
+	pg := RequireService(t, PostgresService())
+	if l.Addr().String() != pg.Addr || "" != pg.ContainerID {
+		t.Fatalf("instance = %+v", pg)
+	}
+	if want := "postgres://postgres:postgres@" + pg.Addr + "/test?sslmode=disable"; want != pg.URL {
+		t.Fatalf("URL = %q, want %q", pg.URL, want)
+	}
+
+	// 显式设置的 URL 优先于模板。
+	SetEnv(t, "KIT_TESTING_POSTGRES_URL", "postgres://app:secret@db/app")
+	if pg = RequireService(t, PostgresService()); "postgres://app:secret@db/app" != pg.URL {
+		t.Fatalf("URL = %q", pg.URL)
+	}
+}
+
+func TestRequireServices_Attach(t *testing.T) {
+	pg, redis := Listen(t), Listen(t)
+	SetEnvs(t, map[string]string{
+		"KIT_TESTING_POSTGRES_ADDR": pg.Addr().String(),
+		"KIT_TESTING_REDIS_ADDR":    redis.Addr().String(),
+	})
+
+	instances := RequireServices(t, PostgresService(), RedisService())
+	if 2 != len(instances) || redis.Addr().String() != instances["redis"].Addr {
+		t.Fatalf("instances = %+v", instances)
+	}
+	if want := "redis://" + redis.Addr().String() + "/0"; want != instances["redis"].URL {
+		t.Fatalf("URL = %q, want %q", instances["redis"].URL, want)
+	}
+}
+
+func TestRequireService_Attach_NotReady(t *testing.T) {
+	svc := RedisService()
+	svc.StartTimeout = 200 * time.Millisecond
+	SetEnv(t, "KIT_TESTING_REDIS_ADDR", "127.0.0.1:"+strconv.Itoa(FreePort(t)))
+
+	rec := newRecordTB(t)
+	rec.run(func(tb testing.TB) {
+		RequireService(tb, svc)
+	})
+	if !rec.fatal || !strings.Contains(rec.output(), "redis") {
+		t.Fatalf("fatal = %v, output = %q", rec.fatal, rec.output())
+	}
+}
+
+func TestRequireService_Skip(t *testing.T) {
+	UnsetEnv(t, "KIT_TESTING_KAFKA_ADDR")
+
+	SetEnv(t, "KIT_TESTING_DOCKER", "off")
+	if !requireServiceSkipped(t, KafkaService()) {
+		t.Fatal("docker disabled: want skipped")
+	}
+
+	UnsetEnv(t, "KIT_TESTING_DOCKER")
+	origin := dockerCommand
+	dockerCommand = filepath.Join(t.TempDir(), "no-such-docker")
+	defer func() {
+		dockerCommand = origin
+	}()
+	if !requireServiceSkipped(t, KafkaService()) {
+		t.Fatal("docker missing: want skipped")
+	}
+}
+
+func TestRequireService_RunFailed(t *testing.T) {
+	UnsetEnv(t, "KIT_TESTING_REDIS_ADDR")
+	UnsetEnv(t, "KIT_TESTING_DOCKER")
+	fakeDocker(t, "echo 'pull access denied' >&2\nexit 1\n")
+
+	rec := newRecordTB(t)
+	rec.run(func(tb testing.TB) {
+		RequireService(tb, RedisService())
+	})
+	if !rec.fatal || !strings.Contains(rec.output(), "pull access denied") {
+		t.Fatalf("fatal = %v, output = %q", rec.fatal, rec.output())
+	}
+}
+
+func TestRequireService_DockerPort(t *testing.T) {
+	UnsetEnv(t, "KIT_TESTING_REDIS_ADDR")
+	UnsetEnv(t, "KIT_TESTING_DOCKER")
+	l := Listen(t)
+	_, port, _ := strings.Cut(l.Addr().String(), ":")
+	argsFile := filepath.Join(t.TempDir(), "args")
+	fakeDocker(t, `case "$1" in
+run) echo "$@" >`+argsFile+`; echo cid ;;
+port) echo "127.0.0.1:`+port+`"; echo "[::1]:`+port+`" ;;
+esac
+`)
+
+	svc := RedisService()
+	svc.ReadyCmd = nil
+	redis := RequireService(t, svc)
+	if l.Addr().String() != redis.Addr || "cid" != redis.ContainerID {
+		t.Fatalf("instance = %+v", redis)
+	}
+	// 没有使用 HostPortPlaceholder 时由 docker 分配宿主机端口。
+	args, err := os.ReadFile(argsFile)
+	if nil != err || !strings.Contains(string(args), "-p 127.0.0.1::6379 ") {
+		t.Fatalf("docker run args = %q, err = %v", args, err)
+	}
+}
+
+func TestRunContainer_PortAllocated(t *testing.T) {
+	countFile := filepath.Join(t.TempDir(), "count")
+	fakeDocker(t, `echo x >>`+countFile+`
+if [ "$(wc -l <`+countFile+`)" -lt 2 ]; then
+  echo 'Bind for 127.0.0.1:1 failed: port is already allocated' >&2
+  exit 125
+fi
+echo cid
+`)
+
+	id, port, err := runContainer(Context(t), t, KafkaService())
+	if nil != err || "cid" != id || port <= 0 {
+		t.Fatalf("runContainer = %q, %d, %v", id, port, err)
+	}
+	if count, _ := os.ReadFile(countFile); 2 != strings.Count(string(count), "x") {
+		t.Fatalf("docker run attempts = %d, want 2", strings.Count(string(count), "x"))
+	}
+
+	// 其他错误不重试。
+	_ = os.Remove(countFile)
+	fakeDocker(t, "echo x >>"+countFile+"\necho 'pull access denied' >&2\nexit 1\n")
+	if _, _, err = runContainer(Context(t), t, KafkaService()); nil == err {
+		t.Fatal("runContainer: want error")
+	}
+	if count, _ := os.ReadFile(countFile); 1 != strings.Count(string(count), "x") {
+		t.Fatalf("docker run attempts = %d, want 1", strings.Count(string(count), "x"))
+	}
+}
+
+func TestParseDockerPort(t *testing.T) {
+	for _, tc := range []struct {
+		out  string
+		want int
+	}{
+		{out: "127.0.0.1:49153\n", want: 49153},
+		{out: "0.0.0.0:32768\n[::]:32768\n", want: 32768},
+		{out: "[::1]:5432", want: 5432},
+	} {
+		if got, err := parseDockerPort([]byte(tc.out)); nil != err || tc.want != got {
+			t.Fatalf("parseDockerPort(%q) = %d, %v, want %d", tc.out, got, err, tc.want)
+		}
+	}
+	for _, out := range []string{"", "Error: No public port '6379/tcp' published", "127.0.0.1:0", "127.0.0.1:x"} {
+		if _, err := parseDockerPort([]byte(out)); nil == err {
+			t.Fatalf("parseDockerPort(%q): want error", out)
+		}
+	}
+}
+
+func TestDockerRunArgs(t *testing.T) {
+	svc := Service{
+		Name:  "kafka",
+		Image: "apache/kafka:3.8.0",
+		Port:  9092,
+		Env: map[string]string{
+			"B": "PLAINTEXT://127.0.0.1:" + HostPortPlaceholder,
+			"A": "1",
+		},
+		Args: []string{"--port", HostPortPlaceholder},
+	}
+
+	got := dockerRunArgs(svc, 32768)
+	want := []string{
+		"run", "-d", "--rm",
+		"--label", "kit.testing=kafka",
+		"-p", "127.0.0.1:32768:9092",
+		"-e", "A=1",
+		"-e", "B=PLAINTEXT://127.0.0.1:32768",
+		"apache/kafka:3.8.0",
+		"--port", "32768",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("dockerRunArgs = %q, want %q", got, want)
+	}
+}
+
+func TestDockerRunArgs_DynamicPort(t *testing.T) {
+	got := dockerRunArgs(RedisService(), 0)
+	want := []string{
+		"run", "-d", "--rm",
+		"--label", "kit.testing=redis",
+		"-p", "127.0.0.1::6379",
+		"redis:7-alpine",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("dockerRunArgs = %q, want %q", got, want)
+	}
+}
+
+func TestUsesHostPort(t *testing.T) {
+	if !usesHostPort(KafkaService()) {
+		t.Fatal("kafka: want true")
+	}
+	if usesHostPort(PostgresService()) || usesHostPort(RedisService()) {
+		t.Fatal("postgres/redis: want false")
+	}
+	if !usesHostPort(Service{Args: []string{"--port=" + HostPortPlaceholder}}) {
+		t.Fatal("args: want true")
+	}
+}
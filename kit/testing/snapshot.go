@@ -0,0 +1,348 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	kitenv "github.com/fsyyft-go/monorepo/kit/env"
+)
+
+const (
+	// SnapshotJSON 以缩进的 JSON 格式序列化快照，只包含导出字段。
+	SnapshotJSON SnapshotFormat = "json"
+	// SnapshotGo 以类似 Go 语法的格式序列化快照，包含未导出字段，指针会被解引用输出。
+	SnapshotGo SnapshotFormat = "go"
+
+	// updateSnapshotsEnv 是开启快照更新的环境变量。
+	updateSnapshotsEnv = "KIT_TESTING_UPDATE_SNAPSHOTS"
+	// snapshotExt 是快照文件的扩展名。
+	snapshotExt = ".snap"
+	// goFormatMaxDepth 是 Go 语法格式化的最大递归深度，避免循环引用导致无限递归。
+	goFormatMaxDepth = 32
+)
+
+var (
+	// snapshotDirDefault 定义了快照文件的默认根目录，相对于被测包所在目录。
+	snapshotDirDefault = filepath.Join("testdata", "snapshots")
+
+	// updateSnapshots 是开启快照更新的命令行参数：go test ./... -kit.update-snapshots。
+	updateSnapshots = flag.Bool("kit.update-snapshots", false, "用当前结果覆盖 kit/testing 快照文件")
+)
+
+type (
+	// SnapshotFormat 定义了快照的序列化格式。
+	SnapshotFormat string
+
+	// SnapshotOption 定义了快照断言的配置选项。
+	SnapshotOption func(*snapshotOptions)
+
+	// snapshotOptions 包含快照断言的配置。
+	snapshotOptions struct {
+		// dir 是快照文件的根目录。
+		dir string
+		// format 是序列化格式。
+		format SnapshotFormat
+	}
+)
+
+// WithSnapshotDir 设置快照文件的根目录。
+//
+// 参数：
+//   - dir：快照根目录，默认为 testdata/snapshots。
+//
+// 返回值：
+//   - SnapshotOption：配置选项函数。
+func WithSnapshotDir(dir string) SnapshotOption {
+	return func(o *snapshotOptions) {
+		o.dir = dir
+	}
+}
+
+// WithSnapshotFormat 设置快照的序列化格式。
+//
+// 参数：
+//   - format：序列化格式，默认为 SnapshotJSON。
+//
+// 返回值：
+//   - SnapshotOption：配置选项函数。
+func WithSnapshotFormat(format SnapshotFormat) SnapshotOption {
+	return func(o *snapshotOptions) {
+		o.format = format
+	}
+}
+
+// AssertSnapshot 将 value 序列化后与已保存的快照比较，不一致时输出逐行差异并使测试失败。
+// 快照保存在 <dir>/<测试名>/<name>.snap；快照不存在时会自动创建。
+// 使用 -kit.update-snapshots 参数或设置环境变量 KIT_TESTING_UPDATE_SNAPSHOTS=1 运行测试，可以用当前结果覆盖快照。
+//
+// 参数：
+//   - t testing.TB：当前测试实例。
+//   - name string：快照名称，同一测试内唯一。
+//   - value interface{}：要比较的值；[]byte 和 string 按原样保存。
+//   - opts ...SnapshotOption：可选的配置选项。
+//
+// 返回值：
+//   - bool：与快照一致（或快照被创建、更新）时返回 true。
+//
+// 示例：
+//
+//	testing.AssertSnapshot(t, "default-options", NewOptions())
+func AssertSnapshot(t testing.TB, name string, value interface{}, opts ...SnapshotOption) bool {
+	t.Helper()
+
+	o := &snapshotOptions{
+		dir:    snapshotDirDefault,
+		format: SnapshotJSON,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	got, err := serializeSnapshot(value, o.format)
+	if nil != err {
+		t.Errorf("序列化快照 %s 失败：%v", name, err)
+		return false
+	}
+
+	path := filepath.Join(o.dir, sanitizePath(t.Name()), sanitizePath(name)+snapshotExt)
+	want, err := os.ReadFile(path)
+	switch {
+	case shouldUpdateSnapshots():
+		WriteFile(t, path, got)
+		t.Logf("已更新快照 %s", path)
+		return true
+	case os.IsNotExist(err):
+		WriteFile(t, path, got)
+		t.Logf("已创建快照 %s", path)
+		return true
+	case nil != err:
+		t.Errorf("读取快照 %s 失败：%v", path, err)
+		return false
+	}
+
+	if string(want) != got {
+		t.Errorf("快照 %s 不一致（- 快照，+ 当前）：\n%s\n使用 -kit.update-snapshots 或 %s=1 更新快照",
+			path, diffLines(string(want), got), updateSnapshotsEnv)
+		return false
+	}
+	return true
+}
+
+// shouldUpdateSnapshots 判断是否需要用当前结果覆盖快照。
+func shouldUpdateSnapshots() bool {
+	if nil != updateSnapshots && *updateSnapshots {
+		return true
+	}
+	return kitenv.Get(updateSnapshotsEnv, false)
+}
+
+// sanitizePath 将测试名或快照名中不适合作为文件名的字符替换为下划线，子测试的 / 保留为目录分隔。
+func sanitizePath(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// serializeSnapshot 按指定格式序列化值，结果总以换行结尾。
+func serializeSnapshot(value interface{}, format SnapshotFormat) (string, error) {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		switch format {
+		case SnapshotJSON:
+			data, err := json.MarshalIndent(value, "", "  ")
+			if nil != err {
+				return "", err
+			}
+			s = string(data)
+		case SnapshotGo:
+			var sb strings.Builder
+			formatGo(&sb, reflect.ValueOf(value), 0)
+			s = sb.String()
+		default:
+			return "", fmt.Errorf("不支持的快照格式 %q", format)
+		}
+	}
+	return ensureNewline(s), nil
+}
+
+// formatGo 以类似 Go 语法、每个字段一行的格式输出值，指针解引用并以 & 标记，映射按键排序。
+func formatGo(sb *strings.Builder, v reflect.Value, depth int) {
+	if depth > goFormatMaxDepth {
+		sb.WriteString("...")
+		return
+	}
+	indent := strings.Repeat("\t", depth+1)
+	closing := strings.Repeat("\t", depth)
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		sb.WriteString("nil")
+	case reflect.Ptr:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		sb.WriteString("&")
+		formatGo(sb, v.Elem(), depth)
+	case reflect.Interface:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		formatGo(sb, v.Elem(), depth)
+	case reflect.Struct:
+		sb.WriteString(v.Type().String())
+		if 0 == v.NumField() {
+			sb.WriteString("{}")
+			return
+		}
+		sb.WriteString("{\n")
+		for i := 0; i < v.NumField(); i++ {
+			sb.WriteString(indent + v.Type().Field(i).Name + ": ")
+			formatGo(sb, v.Field(i), depth+1)
+			sb.WriteString(",\n")
+		}
+		sb.WriteString(closing + "}")
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		sb.WriteString(v.Type().String())
+		if 0 == v.Len() {
+			sb.WriteString("{}")
+			return
+		}
+		sb.WriteString("{\n")
+		for i := 0; i < v.Len(); i++ {
+			sb.WriteString(indent)
+			formatGo(sb, v.Index(i), depth+1)
+			sb.WriteString(",\n")
+		}
+		sb.WriteString(closing + "}")
+	case reflect.Map:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		sb.WriteString(v.Type().String())
+		if 0 == v.Len() {
+			sb.WriteString("{}")
+			return
+		}
+		type entry struct {
+			key string
+			val reflect.Value
+		}
+		entries := make([]entry, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			var kb strings.Builder
+			formatGo(&kb, iter.Key(), depth+1)
+			entries = append(entries, entry{key: kb.String(), val: iter.Value()})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+		sb.WriteString("{\n")
+		for _, e := range entries {
+			sb.WriteString(indent + e.key + ": ")
+			formatGo(sb, e.val, depth+1)
+			sb.WriteString(",\n")
+		}
+		sb.WriteString(closing + "}")
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		// 函数和通道的地址每次运行都不同，只输出是否为 nil。
+		if v.IsNil() {
+			sb.WriteString("nil")
+		} else {
+			sb.WriteString(v.Type().String() + "{...}")
+		}
+	case reflect.String:
+		sb.WriteString(strconv.Quote(v.String()))
+	case reflect.Bool:
+		sb.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// time.Duration 等带 String 方法的类型输出更易读。
+		if s, ok := stringer(v); ok {
+			sb.WriteString(s)
+			return
+		}
+		sb.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sb.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		sb.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	default:
+		sb.WriteString(fmt.Sprintf("%v", v))
+	}
+}
+
+// stringer 在值可导出且实现了 fmt.Stringer 时返回其字符串表示。
+func stringer(v reflect.Value) (string, bool) {
+	if !v.CanInterface() {
+		return "", false
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String(), true
+	}
+	return "", false
+}
+
+// diffLines 基于最长公共子序列输出两段文本的逐行差异，相同的行以两个空格开头，删除的行以 "- " 开头，新增的行以 "+ " 开头。
+func diffLines(want, got string) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// lcs[i][j] 是 a[i:] 与 b[j:] 的最长公共子序列长度。
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			sb.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			sb.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type snapshotConfig struct {
+	Name    string
+	Timeout time.Duration
+	Tags    []string
+	Limits  map[string]int
+	Next    *snapshotConfig
+	hook    func()
+}
+
+func TestAssertSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	cfg := snapshotConfig{Name: "app", Timeout: time.Second, Tags: []string{"a"}}
+
+	// 首次运行创建快照。
+	if !AssertSnapshot(t, "config", cfg, WithSnapshotDir(dir)) {
+		t.Fatal("first run should create snapshot")
+	}
+	path := filepath.Join(dir, "TestAssertSnapshot", "config.snap")
+	if _, err := os.Stat(path); nil != err {
+		t.Fatalf("snapshot not created: %v", err)
+	}
+
+	// 相同的值通过。
+	if !AssertSnapshot(t, "config", cfg, WithSnapshotDir(dir)) {
+		t.Error("same value should match snapshot")
+	}
+
+	// 不同的值失败并输出差异。
+	cfg.Name = "other"
+	rec := newRecordTB(t)
+	rec.run(func(tb testing.TB) {
+		AssertSnapshot(tb, "config", cfg, WithSnapshotDir(dir))
+	})
+	if !rec.failed {
+		t.Fatal("changed value should fail")
+	}
+	for _, want := range []string{`-   "Name": "app",`, `+   "Name": "other",`} {
+		if !strings.Contains(rec.output(), want) {
+			t.Errorf("output missing %q:\n%s", want, rec.output())
+		}
+	}
+
+	// 开启更新后覆盖快照。
+	t.Setenv(updateSnapshotsEnv, "1")
+	if !AssertSnapshot(t, "config", cfg, WithSnapshotDir(dir)) {
+		t.Error("update mode should pass")
+	}
+	AssertFileContent(t, path, "{\n  \"Name\": \"other\",\n  \"Timeout\": 1000000000,\n  \"Tags\": [\n    \"a\"\n  ],\n  \"Limits\": null,\n  \"Next\": null\n}\n")
+}
+
+func TestSerializeSnapshot_Go(t *testing.T) {
+	cfg := &snapshotConfig{
+		Name:    "app",
+		Timeout: 3 * time.Second,
+		Limits:  map[string]int{"b": 2, "a": 1},
+		Next:    &snapshotConfig{Name: "child"},
+		hook:    func() {},
+	}
+
+	got, err := serializeSnapshot(cfg, SnapshotGo)
+	if nil != err {
+		t.Fatalf("serializeSnapshot: %v", err)
+	}
+	want := `&testing.snapshotConfig{
+	Name: "app",
+	Timeout: 3s,
+	Tags: nil,
+	Limits: map[string]int{
+		"a": 1,
+		"b": 2,
+	},
+	Next: &testing.snapshotConfig{
+		Name: "child",
+		Timeout: 0s,
+		Tags: nil,
+		Limits: nil,
+		Next: nil,
+		hook: nil,
+	},
+	hook: func(){...},
+}
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if _, err := serializeSnapshot(1, "xml"); nil == err {
+		t.Error("unknown format should return error")
+	}
+	if got, _ := serializeSnapshot("raw", SnapshotJSON); got != "raw\n" {
+		t.Errorf("string snapshot = %q", got)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	got := diffLines("a\nb\nc\n", "a\nx\nc\nd\n")
+	want := "  a\n- b\n+ x\n  c\n+ d"
+	if got != want {
+		t.Errorf("diffLines =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"fmt"
+	"runtime/debug"
+	"testing"
+)
+
+type (
+	// Case 定义了表格驱动测试中的一个用例。
+	Case[T any] struct {
+		// Name 是子测试名称，为空时使用 "case-<序号>"。
+		Name string
+		// Data 是传递给测试函数的用例数据。
+		Data T
+		// Skip 不为空时跳过该用例，内容为跳过原因。
+		Skip string
+		// Setup 在测试函数之前执行，可以为 nil。
+		Setup func(t *testing.T)
+		// Teardown 在测试函数之后执行（测试函数 panic 或失败时同样执行），可以为 nil。
+		Teardown func(t *testing.T)
+	}
+
+	// TableOption 定义了表格驱动测试的配置选项。
+	TableOption func(*tableOptions)
+
+	// tableOptions 包含表格驱动测试的配置。
+	tableOptions struct {
+		// parallel 表示子测试是否并行执行。
+		parallel bool
+	}
+)
+
+// WithParallel 使所有用例以 t.Parallel 并行执行。
+//
+// 返回值：
+//   - TableOption：配置选项函数。
+func WithParallel() TableOption {
+	return func(o *tableOptions) {
+		o.parallel = true
+	}
+}
+
+// RunTable 以子测试的方式逐个运行表格中的用例。
+// 每个用例依次执行 Setup、fn 与 Teardown；fn 中的 panic 会被转换为带堆栈的测试失败，不会中断其他用例。
+//
+// 参数：
+//   - t *testing.T：当前测试实例。
+//   - cases []Case[T]：用例列表。
+//   - fn func(t *testing.T, data T)：对每个用例执行的测试函数。
+//   - opts ...TableOption：可选的配置选项。
+//
+// 示例：
+//
+//	testing.RunTable(t, []testing.Case[int]{
+//	    {Name: "正数", Data: 1},
+//	    {Name: "负数", Data: -1},
+//	}, func(t *testing.T, n int) {
+//	    if abs(n) != 1 {
+//	        t.Errorf("abs(%d) != 1", n)
+//	    }
+//	}, testing.WithParallel())
+func RunTable[T any](t *testing.T, cases []Case[T], fn func(t *testing.T, data T), opts ...TableOption) {
+	t.Helper()
+
+	o := &tableOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	for i, c := range cases {
+		name := c.Name
+		if "" == name {
+			name = fmt.Sprintf("case-%d", i)
+		}
+
+		t.Run(name, func(t *testing.T) {
+			if o.parallel {
+				t.Parallel()
+			}
+			if "" != c.Skip {
+				t.Skip(c.Skip)
+			}
+			if nil != c.Teardown {
+				defer c.Teardown(t)
+			}
+			if nil != c.Setup {
+				c.Setup(t)
+			}
+
+			runCase(t, func() { fn(t, c.Data) })
+		})
+	}
+}
+
+// runCase 执行单个用例，将 panic 转换为测试失败。
+func runCase(t testing.TB, fn func()) {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); nil != r {
+			t.Errorf("用例 panic：%v\n%s", r, debug.Stack())
+		}
+	}()
+
+	fn()
+}
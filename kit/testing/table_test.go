@@ -0,0 +1,70 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunTable(t *testing.T) {
+	var setups, teardowns, runs atomic.Int32
+	var names []string
+
+	t.Run("table", func(t *testing.T) {
+		RunTable(t, []Case[int]{
+			{Name: "one", Data: 1},
+			{Data: 2},
+			{Name: "skipped", Data: 3, Skip: "not ready"},
+		}, func(t *testing.T, n int) {
+			runs.Add(1)
+			names = append(names, t.Name())
+			if n <= 0 {
+				t.Errorf("n = %d", n)
+			}
+		})
+
+		RunTable(t, []Case[string]{
+			{
+				Name:     "hooks",
+				Data:     "x",
+				Setup:    func(t *testing.T) { setups.Add(1) },
+				Teardown: func(t *testing.T) { teardowns.Add(1) },
+			},
+			{
+				Name:     "hooks-parallel",
+				Data:     "y",
+				Setup:    func(t *testing.T) { setups.Add(1) },
+				Teardown: func(t *testing.T) { teardowns.Add(1) },
+			},
+		}, func(t *testing.T, s string) {}, WithParallel())
+	})
+
+	if runs.Load() != 2 {
+		t.Errorf("runs = %d, want 2 (skipped case excluded)", runs.Load())
+	}
+	wantNames := []string{"TestRunTable/table/one", "TestRunTable/table/case-1"}
+	if strings.Join(names, ",") != strings.Join(wantNames, ",") {
+		t.Errorf("names = %v, want %v", names, wantNames)
+	}
+	if setups.Load() != 2 || teardowns.Load() != 2 {
+		t.Errorf("setups = %d, teardowns = %d, want 2", setups.Load(), teardowns.Load())
+	}
+}
+
+func TestRunCase_Panic(t *testing.T) {
+	rec := newRecordTB(t)
+	rec.run(func(tb testing.TB) {
+		runCase(tb, func() { panic("boom") })
+	})
+
+	if !rec.failed || rec.fatal {
+		t.Fatalf("panic should be reported as non-fatal failure, failed=%v fatal=%v", rec.failed, rec.fatal)
+	}
+	if !strings.Contains(rec.output(), "boom") || !strings.Contains(rec.output(), "goroutine") {
+		t.Errorf("output should contain panic value and stack: %q", rec.output())
+	}
+}
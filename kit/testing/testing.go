@@ -4,18 +4,14 @@
 
 package testing
 
-import (
-	"fmt"
-)
-
 const (
-	// logHeader 定义了日志输出的统一前缀，用于在测试输出中快速识别来自测试包的日志信息。
-	// 前缀格式为 "=-=       "，包含特殊标识符和空格，使输出更加醒目。
+	// logHeader 定义了日志输出的默认前缀，用于在测试输出中快速识别来自测试包的日志信息。
+	// 前缀格式为 "=-=       "，包含特殊标识符和空格，使输出更加醒目；可通过 SetPrefix 修改。
 	logHeader = "=-=       "
 )
 
 // Println 输出带有统一前缀的日志信息，并在末尾自动添加换行符。
-// 该函数会在实际内容前添加 logHeader 前缀，并使用空格分隔多个参数。
+// 该函数会在实际内容前添加当前设置的前缀（默认为 logHeader），并使用空格分隔多个参数。
 //
 // 参数：
 //   - a ...interface{}：要输出的任意类型参数列表。
@@ -25,14 +21,11 @@ const (
 //	testing.Println("测试信息")
 //	testing.Println("值：", 100, "状态：", "成功")
 func Println(a ...interface{}) {
-	// 先输出统一的日志前缀。
-	fmt.Print(logHeader)
-	// 使用 fmt.Println 输出参数，参数之间会自动添加空格。
-	fmt.Println(a...)
+	defaultPrinter.Println(a...)
 }
 
 // Printf 输出带有统一前缀的格式化日志信息。
-// 该函数会在实际内容前添加 logHeader 前缀，并根据提供的格式字符串格式化输出内容。
+// 该函数会在实际内容前添加当前设置的前缀（默认为 logHeader），并根据提供的格式字符串格式化输出内容。
 //
 // 参数：
 //   - format string：格式化字符串，支持所有 fmt.Printf 的格式化指令。
@@ -43,8 +36,5 @@ func Println(a ...interface{}) {
 //	testing.Printf("当前进度：%d%%\n", 50)
 //	testing.Printf("用户：%s，年龄：%d\n", "张三", 25)
 func Printf(format string, a ...interface{}) {
-	// 先输出统一的日志前缀。
-	fmt.Print(logHeader)
-	// 使用 fmt.Printf 按照指定格式输出内容。
-	fmt.Printf(format, a...)
+	defaultPrinter.Printf(format, a...)
 }
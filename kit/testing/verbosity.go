@@ -0,0 +1,170 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// VerbosityDefault 表示未显式设置，取自命令行参数 -kit.verbosity 或环境变量 KIT_TESTING_VERBOSITY，均未设置时为 VerbosityNormal。
+	VerbosityDefault Verbosity = iota
+	// VerbosityQuiet 只输出警告与错误级别的内容。
+	VerbosityQuiet
+	// VerbosityNormal 输出除调试级别外的全部内容。
+	VerbosityNormal
+	// VerbosityDebug 输出包括调试级别在内的全部内容。
+	VerbosityDebug
+)
+
+const (
+	// verbosityEnv 是设置输出详细程度的环境变量，取值为 quiet、normal 或 debug。
+	verbosityEnv = "KIT_TESTING_VERBOSITY"
+)
+
+var (
+	// verbosityNames 定义了各详细程度的名称。
+	verbosityNames = map[Verbosity]string{
+		VerbosityQuiet:  "quiet",
+		VerbosityNormal: "normal",
+		VerbosityDebug:  "debug",
+	}
+
+	// verbosityFlag 是设置输出详细程度的命令行参数：go test ./... -kit.verbosity=debug，优先于环境变量。
+	verbosityFlag = flag.String("kit.verbosity", "", "kit/testing 输出的详细程度：quiet、normal 或 debug")
+)
+
+type (
+	// Verbosity 定义了输出的详细程度。
+	Verbosity int
+)
+
+// String 返回详细程度的名称。
+//
+// 返回值：
+//   - string：quiet、normal、debug 或 default。
+func (v Verbosity) String() string {
+	if name, ok := verbosityNames[v]; ok {
+		return name
+	}
+	return "default"
+}
+
+// ParseVerbosity 解析详细程度的名称，忽略大小写。
+//
+// 参数：
+//   - s string：quiet、normal 或 debug。
+//
+// 返回值：
+//   - Verbosity：解析得到的详细程度。
+//   - error：名称无法识别时返回错误。
+func ParseVerbosity(s string) (Verbosity, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for v, n := range verbosityNames {
+		if n == name {
+			return v, nil
+		}
+	}
+	return VerbosityDefault, fmt.Errorf("无法识别的输出详细程度 %q，可选值为 quiet、normal、debug", s)
+}
+
+// WithVerbosity 设置输出的详细程度。
+//
+// 参数：
+//   - v：详细程度，默认为 VerbosityDefault。
+//
+// 返回值：
+//   - PrinterOption：配置选项函数。
+func WithVerbosity(v Verbosity) PrinterOption {
+	return func(p *Printer) {
+		p.verbosity = v
+	}
+}
+
+// SetVerbosity 设置包级别输出函数使用的详细程度。
+//
+// 参数：
+//   - v：详细程度，设置为 VerbosityDefault 时恢复为跟随命令行参数与环境变量。
+func SetVerbosity(v Verbosity) {
+	defaultPrinter.SetVerbosity(v)
+}
+
+// Debug 使用默认输出器输出调试级别的内容，只在 VerbosityDebug 下输出。
+//
+// 参数：
+//   - a ...interface{}：要输出的内容。
+func Debug(a ...interface{}) {
+	defaultPrinter.Debug(a...)
+}
+
+// Debugf 使用默认输出器输出格式化的调试级别内容，只在 VerbosityDebug 下输出。
+//
+// 参数：
+//   - format string：格式化字符串。
+//   - a ...interface{}：格式化参数。
+func Debugf(format string, a ...interface{}) {
+	defaultPrinter.Debugf(format, a...)
+}
+
+// SetVerbosity 设置输出的详细程度。
+//
+// 参数：
+//   - v：详细程度。
+func (p *Printer) SetVerbosity(v Verbosity) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.verbosity = v
+}
+
+// Debug 输出调试级别的内容并换行，只在 VerbosityDebug 下输出。
+//
+// 参数：
+//   - a ...interface{}：要输出的内容。
+func (p *Printer) Debug(a ...interface{}) {
+	p.print(levelDebug, fmt.Sprintln(a...))
+}
+
+// Debugf 输出格式化的调试级别内容，末尾没有换行时自动补充，只在 VerbosityDebug 下输出。
+//
+// 参数：
+//   - format string：格式化字符串。
+//   - a ...interface{}：格式化参数。
+func (p *Printer) Debugf(format string, a ...interface{}) {
+	p.print(levelDebug, ensureNewline(fmt.Sprintf(format, a...)))
+}
+
+// enabled 判断指定级别的输出在当前详细程度下是否需要输出，调用方必须持有锁。
+func (p *Printer) enabled(level printLevel) bool {
+	v := p.verbosity
+	if VerbosityDefault == v {
+		v = verbosityFromEnv()
+	}
+
+	switch level {
+	case levelWarn, levelError:
+		return true
+	case levelDebug:
+		return v >= VerbosityDebug
+	default:
+		return v >= VerbosityNormal
+	}
+}
+
+// verbosityFromEnv 依次从命令行参数与环境变量读取详细程度，均未设置或无法识别时为 VerbosityNormal。
+// 命令行参数在测试开始前才被解析，因此每次输出时读取而不是在初始化时缓存。
+func verbosityFromEnv() Verbosity {
+	for _, s := range []string{*verbosityFlag, os.Getenv(verbosityEnv)} {
+		if "" == s {
+			continue
+		}
+		if v, err := ParseVerbosity(s); nil == err {
+			return v
+		}
+	}
+	return VerbosityNormal
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrinter_Verbosity(t *testing.T) {
+	print := func(p *Printer) {
+		p.Println("print")
+		p.Info("info")
+		p.Debug("debug")
+		p.Warn("warn")
+		p.Errorf("error")
+	}
+
+	tests := []struct {
+		name string
+		v    Verbosity
+		env  string
+		want string
+	}{
+		{"quiet", VerbosityQuiet, "", "[WARN] warn\n[ERROR] error\n"},
+		{"normal", VerbosityNormal, "debug", "print\n[INFO] info\n[WARN] warn\n[ERROR] error\n"},
+		{"debug", VerbosityDebug, "", "print\n[INFO] info\n[DEBUG] debug\n[WARN] warn\n[ERROR] error\n"},
+		{"默认取自环境变量", VerbosityDefault, "QUIET", "[WARN] warn\n[ERROR] error\n"},
+		{"环境变量无法识别", VerbosityDefault, "loud", "print\n[INFO] info\n[WARN] warn\n[ERROR] error\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetEnv(t, verbosityEnv, tt.env)
+			var buf bytes.Buffer
+			p := NewPrinter(WithWriter(&buf), WithPrefix(""), WithColor(ColorNever), WithVerbosity(tt.v))
+			print(p)
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestVerbosity_FlagOverridesEnv(t *testing.T) {
+	SetEnv(t, verbosityEnv, "quiet")
+	old := *verbosityFlag
+	*verbosityFlag = "debug"
+	defer func() { *verbosityFlag = old }()
+
+	if got := verbosityFromEnv(); got != VerbosityDebug {
+		t.Errorf("verbosityFromEnv = %v, want debug", got)
+	}
+}
+
+func TestParseVerbosity(t *testing.T) {
+	for _, v := range []Verbosity{VerbosityQuiet, VerbosityNormal, VerbosityDebug} {
+		got, err := ParseVerbosity(v.String())
+		if nil != err || got != v {
+			t.Errorf("ParseVerbosity(%q) = %v, %v", v.String(), got, err)
+		}
+	}
+	if _, err := ParseVerbosity("verbose"); nil == err {
+		t.Error("unknown verbosity should return error")
+	}
+	if VerbosityDefault.String() != "default" {
+		t.Errorf("VerbosityDefault.String() = %q", VerbosityDefault.String())
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	stdtime "time"
+)
+
+var (
+	// clockDefault 是 NewRealClock 返回的系统时钟。
+	clockDefault Clock = realClock{}
+)
+
+type (
+	// Clock 抽象了读取当前时间与等待一段时间的能力。
+	// 组件通过 Clock 而不是直接调用标准库的 time 包使用时间，测试时可以注入 FakeClock，使依赖时间的逻辑可以被即时、确定地测试。
+	// Clock 的实现必须是并发安全的。
+	Clock interface {
+		// Now 返回当前时间。
+		Now() stdtime.Time
+		// Since 返回从 t 到当前时间经过的时长。
+		Since(t stdtime.Time) stdtime.Duration
+		// After 返回一个通道，等待 d 之后通道会收到当前时间。
+		After(d stdtime.Duration) <-chan stdtime.Time
+		// Sleep 阻塞当前协程 d。
+		Sleep(d stdtime.Duration)
+		// NewTimer 创建一个在 d 之后触发一次的定时器。
+		NewTimer(d stdtime.Duration) Timer
+		// AfterFunc 创建一个在 d 之后于新的协程中调用 f 的定时器，定时器的 C 返回 nil。
+		AfterFunc(d stdtime.Duration, f func()) Timer
+		// NewTicker 创建一个每隔 d 触发一次的周期定时器，d 必须大于 0。
+		NewTicker(d stdtime.Duration) Ticker
+	}
+
+	// Timer 是 Clock 创建的定时器，语义与 time.Timer 一致。
+	Timer interface {
+		// C 返回定时器触发时接收时间的通道。
+		C() <-chan stdtime.Time
+		// Stop 停止定时器，定时器在触发前被停止时返回 true。
+		Stop() bool
+		// Reset 将定时器重置为在 d 之后触发，定时器在重置前仍处于活动状态时返回 true。
+		Reset(d stdtime.Duration) bool
+	}
+
+	// Ticker 是 Clock 创建的周期定时器，语义与 time.Ticker 一致。
+	Ticker interface {
+		// C 返回每次触发时接收时间的通道。
+		C() <-chan stdtime.Time
+		// Stop 停止周期定时器，之后不会再有新的触发。
+		Stop()
+		// Reset 将触发间隔重置为 d，d 必须大于 0。
+		Reset(d stdtime.Duration)
+	}
+
+	// realClock 是基于标准库 time 包的系统时钟。
+	realClock struct{}
+
+	// realTimer 包装了标准库的 time.Timer。
+	realTimer struct {
+		// timer 是标准库的定时器。
+		timer *stdtime.Timer
+	}
+
+	// realTicker 包装了标准库的 time.Ticker。
+	realTicker struct {
+		// ticker 是标准库的周期定时器。
+		ticker *stdtime.Ticker
+	}
+)
+
+// NewRealClock 返回基于标准库 time 包的系统时钟。
+// 各组件的 WithClock 选项未设置时都使用该时钟。
+//
+// 返回值：
+//   - Clock：系统时钟。
+func NewRealClock() Clock {
+	return clockDefault
+}
+
+// OrReal 在 clock 为 nil 时返回系统时钟，否则返回 clock，供组件处理未设置的 Clock 选项。
+//
+// 参数：
+//   - clock：组件配置的时钟，可以为 nil。
+//
+// 返回值：
+//   - Clock：可以直接使用的时钟。
+func OrReal(clock Clock) Clock {
+	if nil == clock {
+		return clockDefault
+	}
+	return clock
+}
+
+// Now 返回当前时间。
+func (realClock) Now() stdtime.Time {
+	return stdtime.Now()
+}
+
+// Since 返回从 t 到当前时间经过的时长。
+func (realClock) Since(t stdtime.Time) stdtime.Duration {
+	return stdtime.Since(t)
+}
+
+// After 返回一个通道，等待 d 之后通道会收到当前时间。
+func (realClock) After(d stdtime.Duration) <-chan stdtime.Time {
+	return stdtime.After(d)
+}
+
+// Sleep 阻塞当前协程 d。
+func (realClock) Sleep(d stdtime.Duration) {
+	stdtime.Sleep(d)
+}
+
+// NewTimer 创建一个在 d 之后触发一次的定时器。
+func (realClock) NewTimer(d stdtime.Duration) Timer {
+	return realTimer{timer: stdtime.NewTimer(d)}
+}
+
+// AfterFunc 创建一个在 d 之后于新的协程中调用 f 的定时器。
+func (realClock) AfterFunc(d stdtime.Duration, f func()) Timer {
+	return realTimer{timer: stdtime.AfterFunc(d, f)}
+}
+
+// NewTicker 创建一个每隔 d 触发一次的周期定时器。
+func (realClock) NewTicker(d stdtime.Duration) Ticker {
+	return realTicker{ticker: stdtime.NewTicker(d)}
+}
+
+// C 返回定时器触发时接收时间的通道。
+func (t realTimer) C() <-chan stdtime.Time {
+	return t.timer.C
+}
+
+// Stop 停止定时器。
+func (t realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// Reset 将定时器重置为在 d 之后触发。
+func (t realTimer) Reset(d stdtime.Duration) bool {
+	return t.timer.Reset(d)
+}
+
+// C 返回每次触发时接收时间的通道。
+func (t realTicker) C() <-chan stdtime.Time {
+	return t.ticker.C
+}
+
+// Stop 停止周期定时器。
+func (t realTicker) Stop() {
+	t.ticker.Stop()
+}
+
+// Reset 将触发间隔重置为 d。
+func (t realTicker) Reset(d stdtime.Duration) {
+	t.ticker.Reset(d)
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRealClock 测试系统时钟。
+func TestRealClock(t *testing.T) {
+	clock := NewRealClock()
+
+	start := clock.Now()
+	clock.Sleep(stdtime.Millisecond)
+	assert.GreaterOrEqual(t, clock.Since(start), stdtime.Millisecond)
+	<-clock.After(stdtime.Millisecond)
+
+	timer := clock.NewTimer(stdtime.Hour)
+	assert.True(t, timer.Reset(stdtime.Millisecond))
+	<-timer.C()
+	assert.False(t, timer.Stop())
+
+	fired := make(chan struct{})
+	timer = clock.AfterFunc(stdtime.Millisecond, func() { close(fired) })
+	assert.Nil(t, timer.C())
+	<-fired
+
+	ticker := clock.NewTicker(stdtime.Hour)
+	ticker.Reset(stdtime.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+}
+
+// TestOrReal 测试未设置时钟时使用系统时钟。
+func TestOrReal(t *testing.T) {
+	assert.Equal(t, NewRealClock(), OrReal(nil))
+
+	fake := NewFakeClock(stdtime.Time{})
+	assert.Same(t, fake, OrReal(fake))
+
+	var _ Clock = fake
+}
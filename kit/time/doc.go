@@ -0,0 +1,68 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package time 提供了可注入的时钟抽象，使各组件中与时间相关的行为可以在测试中被控制。
+
+主要功能：
+
+  - 时钟接口：Clock 提供 Now、Since、After、Sleep、NewTimer、NewTicker，语义与标准库 time 包一致
+  - 系统时钟：NewRealClock 返回基于标准库的实现，是各组件 WithClock 选项的默认值
+  - 可控时钟：FakeClock 只在调用 Advance 或 Set 时前进，到期的等待者按时间顺序触发
+  - 抖动定时器：NewJitteredTicker 创建触发间隔带随机抖动的周期定时器，错开多个副本的周期任务
+  - 阶段计时：Stopwatch 记录多步骤操作中各阶段的耗时，输出为日志字段或写入直方图
+
+kit 中使用时间的组件都提供 WithClock 选项，包括 kit/runtime/retry 的重试等待、kit/runtime/goroutine 的指标采集、
+kit/log 的日志滚动、kit/cache 的过期与清理以及 kit/ratelimit 的令牌补充与等待。
+
+基本使用：
+
+	func NewWorker(clock time.Clock) *Worker {
+	    return &Worker{clock: time.OrReal(clock)}
+	}
+
+	func (w *Worker) Run(ctx context.Context) {
+	    ticker := w.clock.NewTicker(stdtime.Minute)
+	    defer ticker.Stop()
+	    for {
+	        select {
+	        case <-ticker.C():
+	            w.flush()
+	        case <-ctx.Done():
+	            return
+	        }
+	    }
+	}
+
+在测试中推进时钟：
+
+	clock := time.NewFakeClock(stdtime.Time{})
+	go worker.Run(ctx)
+	clock.BlockUntil(1)           // 等待 Run 创建周期定时器
+	clock.Advance(stdtime.Minute) // 立即触发一次 flush
+
+带抖动的周期任务：
+
+	ticker := time.NewJitteredTicker(10*stdtime.Second, 0.1, time.WithClock(clock))
+	defer ticker.Stop()
+
+统计阶段耗时：
+
+	sw := time.StartStopwatch("checkout")
+	validate(order)
+	sw.Lap("validate")
+	charge(order)
+	sw.Lap("charge")
+	sw.Stop()
+	logger.WithFields(sw.Fields()).Info("下单完成")
+
+由于包名与标准库相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "time"
+
+	    kittime "github.com/fsyyft-go/monorepo/kit/time"
+	)
+*/
+package time
@@ -0,0 +1,364 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"sort"
+	stdsync "sync"
+	stdtime "time"
+)
+
+var (
+	// fakeStartDefault 定义了 NewFakeClock 未指定起始时间时使用的固定时间。
+	// 使用固定值保证测试输出可复现。
+	fakeStartDefault = stdtime.Date(2025, 1, 1, 0, 0, 0, 0, stdtime.UTC)
+)
+
+type (
+	// FakeClock 是一个可控的时钟，实现了 Clock 接口，用于测试。
+	// 时间只会在调用 Advance 或 Set 时前进，Sleep、After、Timer、AfterFunc、Ticker
+	// 都在时间前进到期望点时才被触发，使依赖时间的代码可以被即时、确定地测试。
+	// FakeClock 的所有方法都是并发安全的。
+	FakeClock struct {
+		// mu 保护以下所有字段。
+		mu stdsync.Mutex
+		// cond 在等待者数量变化时广播，供 BlockUntil 使用。
+		cond *stdsync.Cond
+		// now 是时钟的当前时间。
+		now stdtime.Time
+		// waiters 是尚未触发的等待者列表，按触发时间排序。
+		waiters []*fakeWaiter
+	}
+
+	// fakeWaiter 表示一个等待时钟前进到指定时间点的等待者。
+	fakeWaiter struct {
+		// deadline 是触发时间点。
+		deadline stdtime.Time
+		// period 是周期触发的间隔，为 0 表示只触发一次。
+		period stdtime.Duration
+		// c 是触发时发送当前时间的通道，容量为 1，AfterFunc 创建的等待者为 nil。
+		c chan stdtime.Time
+		// fn 是 AfterFunc 创建的等待者触发时在新的协程中调用的函数。
+		fn func()
+	}
+
+	// fakeTimer 是 FakeClock 创建的定时器。
+	fakeTimer struct {
+		// clock 是创建定时器的时钟。
+		clock *FakeClock
+		// waiter 是定时器对应的等待者。
+		waiter *fakeWaiter
+	}
+
+	// fakeTicker 是 FakeClock 创建的周期定时器。
+	// 与 time.Ticker 相同，接收方来不及读取时多余的触发会被丢弃。
+	fakeTicker struct {
+		// clock 是创建周期定时器的时钟。
+		clock *FakeClock
+		// waiter 是周期定时器对应的等待者。
+		waiter *fakeWaiter
+	}
+)
+
+// NewFakeClock 创建一个新的可控时钟。
+//
+// 参数：
+//   - start：时钟的起始时间，零值表示使用固定的默认时间 2025-01-01 00:00:00 UTC。
+//
+// 返回值：
+//   - *FakeClock：新的时钟实例。
+//
+// 示例：
+//
+//	clock := time.NewFakeClock(stdtime.Time{})
+//	ch := clock.After(stdtime.Second)
+//	clock.Advance(stdtime.Second)
+//	<-ch
+func NewFakeClock(start stdtime.Time) *FakeClock {
+	if start.IsZero() {
+		start = fakeStartDefault
+	}
+	c := &FakeClock{now: start}
+	c.cond = stdsync.NewCond(&c.mu)
+	return c
+}
+
+// Now 返回时钟的当前时间。
+//
+// 返回值：
+//   - time.Time：当前时间。
+func (c *FakeClock) Now() stdtime.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since 返回从 t 到时钟当前时间经过的时长。
+//
+// 参数：
+//   - t：起始时间。
+//
+// 返回值：
+//   - time.Duration：经过的时长。
+func (c *FakeClock) Since(t stdtime.Time) stdtime.Duration {
+	return c.Now().Sub(t)
+}
+
+// After 返回一个通道，时钟前进 d 之后通道会收到当前时间。
+// d 小于等于 0 时通道立即可读。
+//
+// 参数：
+//   - d：等待时长。
+//
+// 返回值：
+//   - <-chan time.Time：触发时接收时间的通道。
+func (c *FakeClock) After(d stdtime.Duration) <-chan stdtime.Time {
+	return c.addWaiter(d, 0, nil).c
+}
+
+// Sleep 阻塞当前协程，直到时钟被推进 d。
+// 通常在被测协程中调用，由测试协程通过 BlockUntil 与 Advance 配合推进。
+//
+// 参数：
+//   - d：休眠时长。
+func (c *FakeClock) Sleep(d stdtime.Duration) {
+	<-c.After(d)
+}
+
+// NewTimer 创建一个在时钟前进 d 之后触发一次的定时器。
+//
+// 参数：
+//   - d：触发时长。
+//
+// 返回值：
+//   - Timer：新的定时器。
+func (c *FakeClock) NewTimer(d stdtime.Duration) Timer {
+	return &fakeTimer{clock: c, waiter: c.addWaiter(d, 0, nil)}
+}
+
+// AfterFunc 创建一个在时钟前进 d 之后于新的协程中调用 f 的定时器。
+// 与 time.AfterFunc 一致，定时器的 C 返回 nil，d 小于等于 0 时立即调用 f。
+//
+// 参数：
+//   - d：触发时长。
+//   - f：触发时调用的函数。
+//
+// 返回值：
+//   - Timer：新的定时器，Stop 在触发前调用时 f 不会被调用。
+func (c *FakeClock) AfterFunc(d stdtime.Duration, f func()) Timer {
+	return &fakeTimer{clock: c, waiter: c.addWaiter(d, 0, f)}
+}
+
+// NewTicker 创建一个每当时钟前进 d 就触发一次的周期定时器。
+// 与 time.NewTicker 一致，d 必须大于 0，否则会 panic。
+//
+// 参数：
+//   - d：触发间隔。
+//
+// 返回值：
+//   - Ticker：新的周期定时器。
+func (c *FakeClock) NewTicker(d stdtime.Duration) Ticker {
+	if d <= 0 {
+		panic("kit/time: non-positive interval for FakeClock.NewTicker")
+	}
+	return &fakeTicker{clock: c, waiter: c.addWaiter(d, d, nil)}
+}
+
+// Advance 将时钟推进 d，并按时间顺序触发期间到期的所有等待者。
+// 周期定时器在一次推进中可能被多次调度，但由于通道容量为 1，未被读取的触发会被丢弃。
+//
+// 参数：
+//   - d：推进的时长，小于等于 0 时只触发已到期的等待者。
+func (c *FakeClock) Advance(d stdtime.Duration) {
+	c.mu.Lock()
+	target := c.now
+	if d > 0 {
+		target = c.now.Add(d)
+	}
+	c.fireLocked(target)
+	c.mu.Unlock()
+}
+
+// Set 将时钟设置到指定时间，并触发期间到期的所有等待者。
+// t 早于当前时间时，时钟会回拨但不会触发任何等待者。
+//
+// 参数：
+//   - t：目标时间。
+func (c *FakeClock) Set(t stdtime.Time) {
+	c.mu.Lock()
+	if t.Before(c.now) {
+		c.now = t
+	} else {
+		c.fireLocked(t)
+	}
+	c.mu.Unlock()
+}
+
+// Waiters 返回当前尚未触发的等待者数量，包括 Sleep、After、Timer、AfterFunc 和 Ticker。
+//
+// 返回值：
+//   - int：等待者数量。
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil 阻塞直到等待者数量达到 n。
+// 用于在调用 Advance 前确认被测协程已经进入 Sleep 或已创建定时器，避免竞态。
+//
+// 参数：
+//   - n：期望的等待者数量。
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// addWaiter 注册一个新的等待者，fn 不为 nil 时触发时调用 fn 而不是发送到通道。
+func (c *FakeClock) addWaiter(d stdtime.Duration, period stdtime.Duration, fn func()) *fakeWaiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &fakeWaiter{
+		deadline: c.now.Add(d),
+		period:   period,
+		fn:       fn,
+	}
+	if nil == fn {
+		w.c = make(chan stdtime.Time, 1)
+	}
+	// 一次性等待且已到期时直接触发，不进入等待列表。
+	if d <= 0 && 0 == period {
+		w.fire(c.now)
+		return w
+	}
+	c.insertLocked(w)
+	return w
+}
+
+// insertLocked 将等待者按触发时间顺序插入列表，调用方必须持有锁。
+func (c *FakeClock) insertLocked(w *fakeWaiter) {
+	i := sort.Search(len(c.waiters), func(i int) bool {
+		return c.waiters[i].deadline.After(w.deadline)
+	})
+	c.waiters = append(c.waiters, nil)
+	copy(c.waiters[i+1:], c.waiters[i:])
+	c.waiters[i] = w
+	c.cond.Broadcast()
+}
+
+// removeLocked 从列表中移除等待者，调用方必须持有锁。
+//
+// 返回值：
+//   - bool：等待者存在并被移除时返回 true。
+func (c *FakeClock) removeLocked(w *fakeWaiter) bool {
+	for i, v := range c.waiters {
+		if v == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.cond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+// fireLocked 将时间推进到 target，并依次触发到期的等待者，调用方必须持有锁。
+func (c *FakeClock) fireLocked(target stdtime.Time) {
+	for len(c.waiters) > 0 && !c.waiters[0].deadline.After(target) {
+		w := c.waiters[0]
+		c.waiters = c.waiters[1:]
+		c.now = w.deadline
+		w.fire(c.now)
+
+		if w.period > 0 {
+			w.deadline = w.deadline.Add(w.period)
+			c.insertLocked(w)
+		}
+	}
+	c.now = target
+	c.cond.Broadcast()
+}
+
+// fire 触发等待者：AfterFunc 创建的等待者在新的协程中调用函数，其他等待者向通道发送 now。
+// 通道已满说明上一次触发尚未被读取，与标准库一致直接丢弃。
+func (w *fakeWaiter) fire(now stdtime.Time) {
+	if nil != w.fn {
+		go w.fn()
+		return
+	}
+	select {
+	case w.c <- now:
+	default:
+	}
+}
+
+// C 返回定时器触发时接收时间的通道。
+func (t *fakeTimer) C() <-chan stdtime.Time {
+	return t.waiter.c
+}
+
+// Stop 停止定时器。
+//
+// 返回值：
+//   - bool：定时器在触发前被停止时返回 true，已触发或已停止时返回 false。
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.removeLocked(t.waiter)
+}
+
+// Reset 将定时器重置为在时钟前进 d 之后触发。
+//
+// 参数：
+//   - d：新的触发时长。
+//
+// 返回值：
+//   - bool：定时器在重置前仍处于活动状态时返回 true。
+func (t *fakeTimer) Reset(d stdtime.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	active := t.clock.removeLocked(t.waiter)
+	t.waiter.deadline = t.clock.now.Add(d)
+	if d <= 0 {
+		t.waiter.fire(t.clock.now)
+		return active
+	}
+	t.clock.insertLocked(t.waiter)
+	return active
+}
+
+// C 返回每次触发时接收时间的通道。
+func (t *fakeTicker) C() <-chan stdtime.Time {
+	return t.waiter.c
+}
+
+// Stop 停止周期定时器，之后不会再有新的触发。
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.clock.removeLocked(t.waiter)
+}
+
+// Reset 停止周期定时器并将触发间隔重置为 d，下一次触发在时钟前进 d 之后。
+// 与 time.Ticker 一致，d 必须大于 0，否则会 panic。
+//
+// 参数：
+//   - d：新的触发间隔。
+func (t *fakeTicker) Reset(d stdtime.Duration) {
+	if d <= 0 {
+		panic("kit/time: non-positive interval for Ticker.Reset")
+	}
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	t.clock.removeLocked(t.waiter)
+	t.waiter.period = d
+	t.waiter.deadline = t.clock.now.Add(d)
+	t.clock.insertLocked(t.waiter)
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// received 非阻塞地检查通道是否已有值。
+func received(c <-chan stdtime.Time) (stdtime.Time, bool) {
+	select {
+	case v := <-c:
+		return v, true
+	default:
+		return stdtime.Time{}, false
+	}
+}
+
+// TestFakeClock_NowAndSet 测试读取、推进与回拨时间。
+func TestFakeClock_NowAndSet(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+	assert.True(t, clock.Now().Equal(fakeStartDefault))
+
+	start := clock.Now()
+	clock.Advance(3 * stdtime.Second)
+	assert.Equal(t, 3*stdtime.Second, clock.Since(start))
+
+	target := start.Add(-stdtime.Hour)
+	clock.Set(target)
+	assert.True(t, clock.Now().Equal(target), "Set 可以回拨时间")
+}
+
+// TestFakeClock_After 测试 After 在到期时间点触发。
+func TestFakeClock_After(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+	start := clock.Now()
+
+	ch := clock.After(stdtime.Second)
+	_, ok := received(ch)
+	assert.False(t, ok)
+
+	clock.Advance(500 * stdtime.Millisecond)
+	_, ok = received(ch)
+	assert.False(t, ok, "未到期不触发")
+
+	clock.Advance(stdtime.Second)
+	v, ok := received(ch)
+	require.True(t, ok)
+	assert.True(t, v.Equal(start.Add(stdtime.Second)), "触发时间为到期时间点，而不是推进后的时间")
+	assert.Equal(t, 0, clock.Waiters())
+
+	_, ok = received(clock.After(0))
+	assert.True(t, ok, "After(0) 立即触发")
+}
+
+// TestFakeClock_Sleep 测试 Sleep 与 BlockUntil 配合使用。
+func TestFakeClock_Sleep(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+	done := make(chan struct{})
+
+	go func() {
+		clock.Sleep(stdtime.Minute)
+		close(done)
+	}()
+
+	clock.BlockUntil(1)
+	select {
+	case <-done:
+		t.Fatal("Advance 之前 Sleep 不应返回")
+	default:
+	}
+
+	clock.Advance(stdtime.Minute)
+	<-done
+}
+
+// TestFakeClock_Timer 测试定时器的停止与重置。
+func TestFakeClock_Timer(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+
+	timer := clock.NewTimer(stdtime.Second)
+	assert.True(t, timer.Stop(), "停止活动的定时器返回 true")
+	clock.Advance(2 * stdtime.Second)
+	_, ok := received(timer.C())
+	assert.False(t, ok, "已停止的定时器不触发")
+	assert.False(t, timer.Stop())
+
+	assert.False(t, timer.Reset(stdtime.Second), "重置已停止的定时器返回 false")
+	clock.Advance(stdtime.Second)
+	_, ok = received(timer.C())
+	assert.True(t, ok)
+
+	assert.False(t, timer.Reset(0))
+	_, ok = received(timer.C())
+	assert.True(t, ok, "Reset(0) 立即触发")
+}
+
+// TestFakeClock_AfterFunc 测试定时器到期时在新的协程中调用函数。
+func TestFakeClock_AfterFunc(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+	fired := make(chan struct{}, 2)
+	fn := func() { fired <- struct{}{} }
+
+	timer := clock.AfterFunc(stdtime.Second, fn)
+	assert.Nil(t, timer.C())
+	assert.Equal(t, 1, clock.Waiters())
+	clock.Advance(stdtime.Second)
+	<-fired
+	assert.Zero(t, clock.Waiters())
+
+	assert.False(t, timer.Reset(stdtime.Second), "重置已触发的定时器返回 false")
+	assert.True(t, timer.Stop())
+	clock.Advance(stdtime.Second)
+	select {
+	case <-fired:
+		t.Fatal("已停止的定时器不应调用函数")
+	case <-stdtime.After(10 * stdtime.Millisecond):
+	}
+
+	clock.AfterFunc(0, fn)
+	<-fired
+}
+
+// TestFakeClock_Ticker 测试周期定时器的触发、丢弃、重置与停止。
+func TestFakeClock_Ticker(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+	start := clock.Now()
+
+	ticker := clock.NewTicker(stdtime.Second)
+	for i := 1; i <= 3; i++ {
+		clock.Advance(stdtime.Second)
+		v, ok := received(ticker.C())
+		require.True(t, ok, "第 %d 次触发", i)
+		assert.True(t, v.Equal(start.Add(stdtime.Duration(i)*stdtime.Second)))
+	}
+
+	// 一次推进跨越多个周期时，未读取的触发会被丢弃，只保留一个。
+	clock.Advance(5 * stdtime.Second)
+	_, ok := received(ticker.C())
+	require.True(t, ok)
+	_, ok = received(ticker.C())
+	assert.False(t, ok)
+
+	ticker.Reset(10 * stdtime.Second)
+	clock.Advance(5 * stdtime.Second)
+	_, ok = received(ticker.C())
+	assert.False(t, ok)
+	clock.Advance(5 * stdtime.Second)
+	_, ok = received(ticker.C())
+	assert.True(t, ok)
+
+	ticker.Stop()
+	clock.Advance(stdtime.Minute)
+	_, ok = received(ticker.C())
+	assert.False(t, ok, "已停止的周期定时器不触发")
+
+	assert.Panics(t, func() { clock.NewTicker(0) })
+	assert.Panics(t, func() { ticker.Reset(0) })
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+type (
+	// Option 定义了本包组件的配置选项函数类型。
+	Option func(*options)
+
+	// options 定义了本包组件的配置选项。
+	options struct {
+		// clock 是组件使用的时钟。
+		clock Clock
+	}
+)
+
+// WithClock 设置组件使用的时钟。
+//
+// 参数：
+//   - clock：时钟，为 nil 时使用系统时钟。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClock(clock Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// newOptions 创建并应用配置选项。
+func newOptions(opts ...Option) *options {
+	o := &options{clock: clockDefault}
+	for _, opt := range opts {
+		opt(o)
+	}
+	o.clock = OrReal(o.clock)
+	return o
+}
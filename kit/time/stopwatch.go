@@ -0,0 +1,199 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	stdsync "sync"
+	stdtime "time"
+)
+
+const (
+	// PhaseTotal 是 Stopwatch 输出的总耗时使用的阶段名称。
+	PhaseTotal = "total"
+)
+
+type (
+	// Observer 接收一次观测值，与 prometheus.Observer 的方法一致，Histogram 与 Summary 都可以直接使用。
+	Observer interface {
+		// Observe 记录一次观测值。
+		Observe(value float64)
+	}
+
+	// Lap 是 Stopwatch 记录的一个阶段。
+	Lap struct {
+		// Name 是阶段名称。
+		Name string
+		// Duration 是阶段的耗时。
+		Duration stdtime.Duration
+	}
+
+	// Stopwatch 记录一个多步骤操作中各个阶段的耗时。
+	// 每次调用 Lap 结束一个阶段，阶段的耗时为距离上一次 Lap（或开始）的时长；Stop 结束计时。
+	// 记录的耗时可以通过 Fields 作为结构化日志的字段输出，或者通过 Observe 一次写入直方图。
+	// Stopwatch 是并发安全的。
+	Stopwatch struct {
+		// name 是操作名称，非空时作为 Fields 中键的前缀。
+		name string
+		// clock 是计时使用的时钟。
+		clock Clock
+
+		// mu 保护以下字段。
+		mu stdsync.Mutex
+		// start 是开始计时的时间。
+		start stdtime.Time
+		// last 是上一个阶段结束的时间。
+		last stdtime.Time
+		// laps 是按结束顺序记录的阶段。
+		laps []Lap
+		// total 是 Stop 时的总耗时。
+		total stdtime.Duration
+		// stopped 表示是否已经调用 Stop。
+		stopped bool
+	}
+)
+
+// StartStopwatch 创建并立即开始计时一个 Stopwatch。
+//
+// 参数：
+//   - name：操作名称，非空时 Fields 的键为 "name.阶段"，为空时直接使用阶段名称。
+//   - opts：配置选项，支持 WithClock。
+//
+// 返回值：
+//   - *Stopwatch：已经开始计时的 Stopwatch。
+//
+// 示例：
+//
+//	sw := time.StartStopwatch("checkout")
+//	validate(order)
+//	sw.Lap("validate")
+//	charge(order)
+//	sw.Lap("charge")
+//	sw.Stop()
+//	logger.WithFields(sw.Fields()).Info("下单完成")
+func StartStopwatch(name string, opts ...Option) *Stopwatch {
+	o := newOptions(opts...)
+	now := o.clock.Now()
+	return &Stopwatch{
+		name:  name,
+		clock: o.clock,
+		start: now,
+		last:  now,
+	}
+}
+
+// Lap 结束当前阶段并记录其耗时，下一个阶段从此刻开始。
+// 同名的阶段可以多次记录，Fields 中输出其耗时之和。调用 Stop 之后 Lap 不再记录，返回 0。
+//
+// 参数：
+//   - phase：刚刚结束的阶段的名称。
+//
+// 返回值：
+//   - stdtime.Duration：该阶段的耗时。
+func (s *Stopwatch) Lap(phase string) stdtime.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return 0
+	}
+	now := s.clock.Now()
+	d := now.Sub(s.last)
+	s.last = now
+	s.laps = append(s.laps, Lap{Name: phase, Duration: d})
+	return d
+}
+
+// Stop 结束计时，多次调用返回第一次调用时的总耗时。
+// 最后一次 Lap 之后的时间只计入总耗时，不属于任何阶段。
+//
+// 返回值：
+//   - stdtime.Duration：从开始到 Stop 的总耗时。
+func (s *Stopwatch) Stop() stdtime.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.stopped {
+		s.stopped = true
+		s.total = s.clock.Since(s.start)
+	}
+	return s.total
+}
+
+// Elapsed 返回总耗时，未调用 Stop 时返回从开始到当前的时长。
+//
+// 返回值：
+//   - stdtime.Duration：总耗时。
+func (s *Stopwatch) Elapsed() stdtime.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return s.total
+	}
+	return s.clock.Since(s.start)
+}
+
+// Laps 返回按结束顺序记录的阶段的副本。
+//
+// 返回值：
+//   - []Lap：已经记录的阶段。
+func (s *Stopwatch) Laps() []Lap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Lap(nil), s.laps...)
+}
+
+// Fields 返回各阶段与总耗时组成的日志字段，值为 stdtime.Duration，可以直接传给 kit/log 的 WithFields。
+// 键为阶段名称，总耗时的键为 PhaseTotal；操作名称非空时键以 "name." 为前缀。
+//
+// 返回值：
+//   - map[string]interface{}：日志字段。
+//
+// 示例：
+//
+//	// 输出 checkout.validate、checkout.charge 与 checkout.total 三个字段。
+//	logger.WithFields(sw.Fields()).Info("下单完成")
+func (s *Stopwatch) Fields() map[string]interface{} {
+	laps := s.Laps()
+	fields := make(map[string]interface{}, len(laps)+1)
+	for _, lap := range laps {
+		key := s.key(lap.Name)
+		d, _ := fields[key].(stdtime.Duration)
+		fields[key] = d + lap.Duration
+	}
+	fields[s.key(PhaseTotal)] = s.Elapsed()
+	return fields
+}
+
+// Observe 将每个阶段与总耗时以秒为单位写入 observer 返回的观测器。
+// 总耗时使用 PhaseTotal 作为阶段名称；observer 返回 nil 时跳过该阶段。
+//
+// 参数：
+//   - observer：根据阶段名称返回观测器，通常从以阶段为标签的 HistogramVec 中获取。
+//
+// 示例：
+//
+//	sw.Observe(func(phase string) time.Observer {
+//	    return checkoutDuration.WithLabelValues(phase)
+//	})
+func (s *Stopwatch) Observe(observer func(phase string) Observer) {
+	for _, lap := range s.Laps() {
+		if o := observer(lap.Name); nil != o {
+			o.Observe(lap.Duration.Seconds())
+		}
+	}
+	if o := observer(PhaseTotal); nil != o {
+		o.Observe(s.Elapsed().Seconds())
+	}
+}
+
+// key 返回阶段在 Fields 中的键。
+func (s *Stopwatch) key(phase string) string {
+	if "" == s.name {
+		return phase
+	}
+	return s.name + "." + phase
+}
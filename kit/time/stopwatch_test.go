@@ -0,0 +1,102 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// recordObserver 记录收到的观测值。
+	recordObserver struct {
+		// values 是收到的观测值。
+		values *[]float64
+	}
+)
+
+// Observe 记录一次观测值。
+func (o recordObserver) Observe(value float64) {
+	*o.values = append(*o.values, value)
+}
+
+// TestStopwatch 测试阶段耗时、总耗时与 Stop 之后的行为。
+func TestStopwatch(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+	sw := StartStopwatch("checkout", WithClock(clock))
+
+	clock.Advance(100 * stdtime.Millisecond)
+	assert.Equal(t, 100*stdtime.Millisecond, sw.Lap("validate"))
+	clock.Advance(300 * stdtime.Millisecond)
+	assert.Equal(t, 300*stdtime.Millisecond, sw.Lap("charge"))
+	assert.Equal(t, 400*stdtime.Millisecond, sw.Elapsed())
+
+	clock.Advance(50 * stdtime.Millisecond)
+	assert.Equal(t, 450*stdtime.Millisecond, sw.Stop())
+
+	// Stop 之后不再计时。
+	clock.Advance(stdtime.Second)
+	assert.Equal(t, 450*stdtime.Millisecond, sw.Stop())
+	assert.Equal(t, 450*stdtime.Millisecond, sw.Elapsed())
+	assert.Zero(t, sw.Lap("late"))
+	assert.Equal(t, []Lap{
+		{Name: "validate", Duration: 100 * stdtime.Millisecond},
+		{Name: "charge", Duration: 300 * stdtime.Millisecond},
+	}, sw.Laps())
+}
+
+// TestStopwatch_Fields 测试日志字段的键与同名阶段的累加。
+func TestStopwatch_Fields(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+	sw := StartStopwatch("sync", WithClock(clock))
+	for i := 0; i < 3; i++ {
+		clock.Advance(stdtime.Second)
+		sw.Lap("fetch")
+	}
+	clock.Advance(2 * stdtime.Second)
+	sw.Lap("write")
+	sw.Stop()
+
+	assert.Equal(t, map[string]interface{}{
+		"sync.fetch": 3 * stdtime.Second,
+		"sync.write": 2 * stdtime.Second,
+		"sync.total": 5 * stdtime.Second,
+	}, sw.Fields())
+
+	unnamed := StartStopwatch("", WithClock(clock))
+	clock.Advance(stdtime.Second)
+	unnamed.Lap("load")
+	assert.Equal(t, map[string]interface{}{
+		"load":     stdtime.Second,
+		PhaseTotal: stdtime.Second,
+	}, unnamed.Fields())
+}
+
+// TestStopwatch_Observe 测试按阶段写入观测器，返回 nil 的阶段被跳过。
+func TestStopwatch_Observe(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+	sw := StartStopwatch("checkout", WithClock(clock))
+	clock.Advance(500 * stdtime.Millisecond)
+	sw.Lap("validate")
+	clock.Advance(stdtime.Second)
+	sw.Lap("skipped")
+	sw.Stop()
+
+	observed := make(map[string]*[]float64)
+	sw.Observe(func(phase string) Observer {
+		if "skipped" == phase {
+			return nil
+		}
+		values := &[]float64{}
+		observed[phase] = values
+		return recordObserver{values: values}
+	})
+
+	assert.Len(t, observed, 2)
+	assert.Equal(t, []float64{0.5}, *observed["validate"])
+	assert.Equal(t, []float64{1.5}, *observed[PhaseTotal])
+}
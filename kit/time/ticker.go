@@ -0,0 +1,151 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"math/rand/v2"
+	stdsync "sync"
+	stdtime "time"
+)
+
+type (
+	// JitteredTicker 是触发间隔带随机抖动的周期定时器，实现了 Ticker 接口。
+	// 每次触发的间隔在 [d×(1-jitterFraction), d×(1+jitterFraction)] 内均匀分布，平均间隔仍为 d。
+	// 多个副本以相同的周期执行采集、刷新等任务时，抖动可以错开各副本的触发时间，避免同时对下游造成压力。
+	// 与 time.Ticker 相同，接收方来不及读取时多余的触发会被丢弃。
+	JitteredTicker struct {
+		// c 是每次触发时发送时间的通道，容量为 1。
+		c chan stdtime.Time
+		// clock 是创建底层定时器的时钟。
+		clock Clock
+
+		// mu 保护 d 与 jitter。
+		mu stdsync.Mutex
+		// d 是平均触发间隔。
+		d stdtime.Duration
+		// jitter 是抖动比例，取值范围为 [0, 1]。
+		jitter float64
+
+		// reset 通知后台协程按新的间隔重新计时。
+		reset chan struct{}
+		// stop 在 Stop 时关闭，通知后台协程退出。
+		stop chan struct{}
+		// stopOnce 保证 stop 只关闭一次。
+		stopOnce stdsync.Once
+	}
+)
+
+// NewJitteredTicker 创建触发间隔带随机抖动的周期定时器。
+// 与 time.NewTicker 一致，d 必须大于 0，否则会 panic；不再使用时必须调用 Stop 释放后台协程。
+//
+// 参数：
+//   - d：平均触发间隔。
+//   - jitterFraction：抖动比例，0 表示不抖动，0.1 表示每次间隔在 d 的 ±10% 内随机，超出 [0, 1] 时取边界值。
+//   - opts：配置选项，支持 WithClock。
+//
+// 返回值：
+//   - *JitteredTicker：新的周期定时器。
+//
+// 示例：
+//
+//	ticker := time.NewJitteredTicker(10*stdtime.Second, 0.1)
+//	defer ticker.Stop()
+//	for {
+//	    select {
+//	    case <-ticker.C():
+//	        collect()
+//	    case <-ctx.Done():
+//	        return
+//	    }
+//	}
+func NewJitteredTicker(d stdtime.Duration, jitterFraction float64, opts ...Option) *JitteredTicker {
+	if d <= 0 {
+		panic("kit/time: non-positive interval for NewJitteredTicker")
+	}
+	t := &JitteredTicker{
+		c:      make(chan stdtime.Time, 1),
+		clock:  newOptions(opts...).clock,
+		d:      d,
+		jitter: min(max(jitterFraction, 0), 1),
+		reset:  make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+	timer := t.clock.NewTimer(t.next())
+	go t.run(timer)
+	return t
+}
+
+// C 返回每次触发时接收时间的通道。
+//
+// 返回值：
+//   - <-chan time.Time：触发时接收时间的通道。
+func (t *JitteredTicker) C() <-chan stdtime.Time {
+	return t.c
+}
+
+// Stop 停止周期定时器并释放后台协程，之后不会再有新的触发。多次调用是安全的。
+func (t *JitteredTicker) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.stop)
+	})
+}
+
+// Reset 将平均触发间隔重置为 d，并从当前时间重新计时。
+// 与 time.Ticker 一致，d 必须大于 0，否则会 panic。Stop 之后调用没有效果。
+//
+// 参数：
+//   - d：新的平均触发间隔。
+func (t *JitteredTicker) Reset(d stdtime.Duration) {
+	if d <= 0 {
+		panic("kit/time: non-positive interval for JitteredTicker.Reset")
+	}
+	t.mu.Lock()
+	t.d = d
+	t.mu.Unlock()
+
+	select {
+	case t.reset <- struct{}{}:
+	default:
+	}
+}
+
+// next 返回下一次触发的间隔。
+func (t *JitteredTicker) next() stdtime.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if 0 == t.jitter {
+		return t.d
+	}
+	// 在 [1-jitter, 1+jitter) 内均匀取值。
+	factor := 1 + t.jitter*(2*rand.Float64()-1)
+	return stdtime.Duration(float64(t.d) * factor)
+}
+
+// run 在后台等待定时器触发，转发触发时间并按新的随机间隔重新计时。
+func (t *JitteredTicker) run(timer Timer) {
+	defer timer.Stop()
+	for {
+		select {
+		case now := <-timer.C():
+			// 通道已满说明上一次触发尚未被读取，与标准库一致直接丢弃。
+			select {
+			case t.c <- now:
+			default:
+			}
+			timer.Reset(t.next())
+		case <-t.reset:
+			if !timer.Stop() {
+				// 定时器已经触发但尚未读取时，丢弃这次触发。
+				select {
+				case <-timer.C():
+				default:
+				}
+			}
+			timer.Reset(t.next())
+		case <-t.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nextTick 等待周期定时器开始计时后推进时钟，返回触发时间与本次间隔。
+func nextTick(t *testing.T, clock *FakeClock, ticker *JitteredTicker, advance stdtime.Duration) stdtime.Duration {
+	t.Helper()
+	clock.BlockUntil(1)
+	start := clock.Now()
+	clock.Advance(advance)
+	select {
+	case v := <-ticker.C():
+		return v.Sub(start)
+	case <-stdtime.After(stdtime.Second):
+		require.FailNow(t, "周期定时器没有触发")
+		return 0
+	}
+}
+
+// TestJitteredTicker 测试触发间隔在抖动范围内且不完全相同。
+func TestJitteredTicker(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+	ticker := NewJitteredTicker(10*stdtime.Second, 0.5, WithClock(clock))
+	defer ticker.Stop()
+
+	seen := make(map[stdtime.Duration]struct{})
+	for i := 0; i < 20; i++ {
+		gap := nextTick(t, clock, ticker, 15*stdtime.Second)
+		assert.GreaterOrEqual(t, gap, 5*stdtime.Second)
+		assert.LessOrEqual(t, gap, 15*stdtime.Second)
+		seen[gap] = struct{}{}
+	}
+	assert.Greater(t, len(seen), 1, "触发间隔应当是随机的")
+}
+
+// TestJitteredTicker_NoJitter 测试抖动比例为 0 时与普通周期定时器一致，超出范围的比例取边界值。
+func TestJitteredTicker_NoJitter(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+	ticker := NewJitteredTicker(stdtime.Second, -1, WithClock(clock))
+	defer ticker.Stop()
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, stdtime.Second, nextTick(t, clock, ticker, stdtime.Second))
+	}
+
+	wide := NewJitteredTicker(stdtime.Second, 2)
+	defer wide.Stop()
+	assert.Equal(t, float64(1), wide.jitter)
+}
+
+// TestJitteredTicker_ResetStop 测试重置间隔与停止。
+func TestJitteredTicker_ResetStop(t *testing.T) {
+	clock := NewFakeClock(stdtime.Time{})
+	ticker := NewJitteredTicker(stdtime.Second, 0, WithClock(clock))
+
+	clock.BlockUntil(1)
+	ticker.Reset(stdtime.Minute)
+	// 等待后台协程按新的间隔重新计时。
+	require.Eventually(t, func() bool {
+		clock.mu.Lock()
+		defer clock.mu.Unlock()
+		return 1 == len(clock.waiters) && clock.waiters[0].deadline.Equal(fakeStartDefault.Add(stdtime.Minute))
+	}, stdtime.Second, stdtime.Millisecond)
+	assert.Equal(t, stdtime.Minute, nextTick(t, clock, ticker, stdtime.Minute))
+
+	ticker.Stop()
+	ticker.Stop()
+	require.Eventually(t, func() bool {
+		return 0 == clock.Waiters()
+	}, stdtime.Second, stdtime.Millisecond, "Stop 后应释放定时器")
+	clock.Advance(stdtime.Hour)
+	_, ok := received(ticker.C())
+	assert.False(t, ok)
+	ticker.Reset(stdtime.Second)
+
+	assert.Panics(t, func() { NewJitteredTicker(0, 0) })
+	assert.Panics(t, func() { ticker.Reset(0) })
+}
+
+// TestJitteredTicker_Real 测试使用系统时钟时正常触发。
+func TestJitteredTicker_Real(t *testing.T) {
+	ticker := NewJitteredTicker(stdtime.Millisecond, 0.5)
+	defer ticker.Stop()
+	for i := 0; i < 3; i++ {
+		<-ticker.C()
+	}
+}
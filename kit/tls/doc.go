@@ -0,0 +1,56 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package tls 提供了服务端与客户端 tls.Config 的构建功能，并支持证书文件的热更新。
+
+默认配置：
+
+  - 最低版本为 TLS 1.2，可通过 WithMinVersion 提高到 TLS 1.3
+  - TLS 1.2 只使用支持前向保密的 ECDHE AEAD 密码套件，TLS 1.3 的密码套件由标准库决定
+  - 设置了 WithCA 时，服务端默认要求并校验客户端证书（mTLS），客户端使用该 CA 代替系统根证书
+
+基本使用：
+
+	p, err := tls.New(
+	    tls.WithCertificate("server.crt", "server.key"),
+	    tls.WithCA("ca.crt"),
+	)
+	if nil != err {
+	    panic(err)
+	}
+	cfg, err := p.ServerConfig()
+	if nil != err {
+	    panic(err)
+	}
+	srv := &http.Server{Addr: ":8443", TLSConfig: cfg}
+	_ = srv.ListenAndServeTLS("", "")
+
+热更新：
+
+Watch 监听证书、私钥与 CA 文件所在的目录，文件变化后重新加载。生成的 tls.Config 在每次握手时读取当前的证书与 CA，
+因此新的连接立即使用新的证书，无需重启监听，已经建立的连接不受影响。
+重新加载成功或失败都会记录到 WithLogger 设置的日志实例中；重新加载失败时继续使用原有的证书。
+
+	p, err := tls.Watch(
+	    tls.WithCertificate("/etc/tls/tls.crt", "/etc/tls/tls.key"),
+	    tls.WithCA("/etc/tls/ca.crt"),
+	    tls.WithLogger(logger),
+	)
+	if nil != err {
+	    panic(err)
+	}
+	defer p.Close()
+
+	conn, err := tls.Dial("tcp", "backend:8443", p.ClientConfig())
+
+由于包名与标准库相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "crypto/tls"
+
+	    kittls "github.com/fsyyft-go/monorepo/kit/tls"
+	)
+*/
+package tls
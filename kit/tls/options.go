@@ -0,0 +1,187 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package tls
+
+import (
+	stdtls "crypto/tls"
+	"time"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+)
+
+// 以下为 TLS 配置的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// minVersionDefault 为默认的最低 TLS 版本。
+	minVersionDefault = uint16(stdtls.VersionTLS12)
+	// reloadDebounceDefault 为文件变化后重新加载证书前的默认等待时间。
+	// 证书与私钥通常先后写入，等待一段时间可以将其合并为一次重新加载。
+	reloadDebounceDefault = 100 * time.Millisecond
+
+	// cipherSuitesDefault 为 TLS 1.2 使用的密码套件，只包含支持前向保密的 AEAD 套件。
+	// TLS 1.3 的密码套件不可配置，由标准库决定。
+	cipherSuitesDefault = []uint16{
+		stdtls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		stdtls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		stdtls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		stdtls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		stdtls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+		stdtls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+	}
+)
+
+type (
+	// Option 定义了 TLS 配置的选项。
+	Option func(*options)
+
+	// options 包含 TLS 配置。
+	options struct {
+		// certFile 是证书文件的路径。
+		certFile string
+		// keyFile 是私钥文件的路径。
+		keyFile string
+		// caFile 是 CA 证书文件的路径。
+		caFile string
+		// clientAuth 是服务端校验客户端证书的策略。
+		clientAuth stdtls.ClientAuthType
+		// clientAuthSet 表示是否通过 WithClientAuth 设置了 clientAuth。
+		clientAuthSet bool
+		// serverName 是客户端校验的服务端名称。
+		serverName string
+		// minVersion 是最低的 TLS 版本。
+		minVersion uint16
+		// reloadDebounce 是文件变化后重新加载证书前的等待时间。
+		reloadDebounce time.Duration
+		// logger 是记录重新加载事件的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+	}
+)
+
+// WithCertificate 设置证书与私钥文件，PEM 格式。
+// 服务端配置使用该证书向客户端证明身份；客户端配置在服务端要求时使用该证书（mTLS）。
+//
+// 参数：
+//   - certFile：证书文件的路径，可以包含中间证书。
+//   - keyFile：私钥文件的路径。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCertificate(certFile, keyFile string) Option {
+	return func(o *options) {
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+// WithCA 设置 CA 证书文件，PEM 格式，可以包含多个证书。
+// 服务端配置使用它校验客户端证书，客户端配置使用它代替系统根证书校验服务端证书。
+//
+// 参数：
+//   - caFile：CA 证书文件的路径。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithCA(caFile string) Option {
+	return func(o *options) {
+		o.caFile = caFile
+	}
+}
+
+// WithClientAuth 设置服务端校验客户端证书的策略，仅对服务端配置生效。
+//
+// 参数：
+//   - clientAuth：校验策略，设置了 WithCA 时默认为 RequireAndVerifyClientCert，否则默认为 NoClientCert。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithClientAuth(clientAuth stdtls.ClientAuthType) Option {
+	return func(o *options) {
+		o.clientAuth = clientAuth
+		o.clientAuthSet = true
+	}
+}
+
+// WithServerName 设置客户端校验的服务端名称，仅对客户端配置生效。
+//
+// 参数：
+//   - serverName：服务端名称，默认为空，此时使用连接的主机名。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithServerName(serverName string) Option {
+	return func(o *options) {
+		o.serverName = serverName
+	}
+}
+
+// WithMinVersion 设置最低的 TLS 版本。
+//
+// 参数：
+//   - version：tls.VersionTLS12 或 tls.VersionTLS13，默认为 tls.VersionTLS12，其他值使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMinVersion(version uint16) Option {
+	return func(o *options) {
+		o.minVersion = version
+	}
+}
+
+// WithReloadDebounce 设置文件变化后重新加载证书前的等待时间，仅对 Watch 生效。
+//
+// 参数：
+//   - d：等待时间，默认为 100 毫秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithReloadDebounce(d time.Duration) Option {
+	return func(o *options) {
+		o.reloadDebounce = d
+	}
+}
+
+// WithLogger 设置记录证书重新加载事件的日志实例。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		minVersion:     minVersionDefault,
+		reloadDebounce: reloadDebounceDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if stdtls.VersionTLS12 != o.minVersion && stdtls.VersionTLS13 != o.minVersion {
+		o.minVersion = minVersionDefault
+	}
+	if o.reloadDebounce <= 0 {
+		o.reloadDebounce = reloadDebounceDefault
+	}
+	if !o.clientAuthSet && "" != o.caFile {
+		o.clientAuth = stdtls.RequireAndVerifyClientCert
+	}
+
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
@@ -0,0 +1,335 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package tls
+
+import (
+	stdtls "crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	stdsync "sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+var (
+	// ErrNoCertificate 表示创建服务端配置时没有设置证书。
+	ErrNoCertificate = errors.New("kit/tls: 未设置证书")
+)
+
+type (
+	// Provider 从文件加载证书与 CA，并生成使用最新证书的服务端与客户端 tls.Config。
+	// 生成的 tls.Config 在每次握手时读取当前的证书与 CA，因此重新加载后新的连接立即生效，无需重启监听。
+	// 所有方法都是并发安全的。
+	Provider struct {
+		// opts 是 TLS 配置。
+		opts *options
+		// cert 是当前的证书，未设置证书时为 nil。
+		cert atomic.Pointer[stdtls.Certificate]
+		// roots 是当前的 CA 证书池，未设置 CA 时为 nil。
+		roots atomic.Pointer[x509.CertPool]
+		// reloadMu 保证重新加载按顺序执行。
+		reloadMu stdsync.Mutex
+
+		// fsw 是文件系统监听器，未监听时为 nil。
+		fsw *fsnotify.Watcher
+		// done 在 Close 时关闭，通知监听协程退出。
+		done chan struct{}
+		// wg 等待监听协程退出。
+		wg stdsync.WaitGroup
+		// closeOnce 保证 Close 只执行一次。
+		closeOnce stdsync.Once
+	}
+)
+
+// New 加载证书与 CA，创建不监听文件变化的 Provider，需要时可以调用 Reload 手动重新加载。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Provider：Provider 实例。
+//   - error：文件不存在或格式错误时返回错误。
+//
+// 示例：
+//
+//	p, err := tls.New(tls.WithCertificate("server.crt", "server.key"))
+//	if nil != err {
+//	    return err
+//	}
+//	cfg, err := p.ServerConfig()
+func New(opts ...Option) (*Provider, error) {
+	p := &Provider{
+		opts: newOptions(opts...),
+		done: make(chan struct{}),
+	}
+	if err := p.load(); nil != err {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Watch 加载证书与 CA，并监听文件的变化，变化后自动重新加载。
+// 监听的是文件所在的目录，因此通过重命名原子替换文件，以及 Kubernetes Secret 的符号链接切换都能被感知。
+// 重新加载的结果记录到 WithLogger 设置的日志实例中；重新加载失败时继续使用原有的证书。
+//
+// 参数：
+//   - opts：配置选项，与 New 相同。
+//
+// 返回值：
+//   - *Provider：Provider 实例，不再使用时需要调用 Close。
+//   - error：首次加载或创建监听失败时返回错误。
+//
+// 示例：
+//
+//	p, err := tls.Watch(
+//	    tls.WithCertificate("/etc/tls/tls.crt", "/etc/tls/tls.key"),
+//	    tls.WithCA("/etc/tls/ca.crt"),
+//	)
+//	if nil != err {
+//	    return err
+//	}
+//	defer p.Close()
+//
+//	cfg, _ := p.ServerConfig()
+//	ln, err := tls.Listen("tcp", ":8443", cfg)
+func Watch(opts ...Option) (*Provider, error) {
+	p, err := New(opts...)
+	if nil != err {
+		return nil, err
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if nil != err {
+		return nil, fmt.Errorf("kit/tls: 创建证书文件监听失败：%w", err)
+	}
+	dirs := make(map[string]struct{})
+	for _, f := range []string{p.opts.certFile, p.opts.keyFile, p.opts.caFile} {
+		if "" == f {
+			continue
+		}
+		dir := filepath.Dir(f)
+		if _, ok := dirs[dir]; ok {
+			continue
+		}
+		dirs[dir] = struct{}{}
+		if err := fsw.Add(dir); nil != err {
+			_ = fsw.Close()
+			return nil, fmt.Errorf("kit/tls: 监听证书目录 %s 失败：%w", dir, err)
+		}
+	}
+	p.fsw = fsw
+
+	p.wg.Add(1)
+	go p.watch()
+
+	return p, nil
+}
+
+// ServerConfig 返回服务端的 tls.Config。
+// 握手时使用当前的证书；设置了 WithCA 时使用当前的 CA 校验客户端证书。
+// 返回的配置可以修改，例如设置 NextProtos，但不应修改 GetCertificate 与 GetConfigForClient。
+//
+// 返回值：
+//   - *tls.Config：服务端配置。
+//   - error：没有设置证书时返回 ErrNoCertificate。
+func (p *Provider) ServerConfig() (*stdtls.Config, error) {
+	if nil == p.cert.Load() {
+		return nil, ErrNoCertificate
+	}
+
+	cfg := p.baseConfig()
+	cfg.ClientAuth = p.opts.clientAuth
+	cfg.GetCertificate = func(*stdtls.ClientHelloInfo) (*stdtls.Certificate, error) {
+		return p.cert.Load(), nil
+	}
+	if nil != p.roots.Load() {
+		// 每次握手复制一份配置并填入当前的 CA，使重新加载的 CA 立即生效。
+		cfg.GetConfigForClient = func(*stdtls.ClientHelloInfo) (*stdtls.Config, error) {
+			c := cfg.Clone()
+			c.GetConfigForClient = nil
+			c.ClientCAs = p.roots.Load()
+			return c, nil
+		}
+	}
+	return cfg, nil
+}
+
+// ClientConfig 返回客户端的 tls.Config。
+// 设置了证书时在服务端要求时提供当前的证书；设置了 WithCA 时使用当前的 CA 代替系统根证书校验服务端证书。
+//
+// 返回值：
+//   - *tls.Config：客户端配置。
+func (p *Provider) ClientConfig() *stdtls.Config {
+	cfg := p.baseConfig()
+	cfg.ServerName = p.opts.serverName
+	if nil != p.cert.Load() {
+		cfg.GetClientCertificate = func(*stdtls.CertificateRequestInfo) (*stdtls.Certificate, error) {
+			return p.cert.Load(), nil
+		}
+	}
+	if nil != p.roots.Load() {
+		// 标准库只能使用配置中固定的 RootCAs 校验，为了使重新加载的 CA 生效，跳过标准库的校验，
+		// 改为在 VerifyConnection 中使用当前的 CA 完成同样的校验。
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyConnection = p.verifyServer
+	}
+	return cfg
+}
+
+// Certificate 返回当前证书的叶子证书，可用于检查证书的有效期。
+//
+// 返回值：
+//   - *x509.Certificate：叶子证书，未设置证书时返回 nil。
+func (p *Provider) Certificate() *x509.Certificate {
+	if cert := p.cert.Load(); nil != cert {
+		return cert.Leaf
+	}
+	return nil
+}
+
+// Reload 立即重新加载证书与 CA，并记录重新加载的结果。
+//
+// 返回值：
+//   - error：加载失败时返回错误，此时继续使用原有的证书与 CA。
+func (p *Provider) Reload() error {
+	p.reloadMu.Lock()
+	defer p.reloadMu.Unlock()
+
+	logger := p.opts.getLogger()
+	if err := p.load(); nil != err {
+		logger.Error("kit/tls: 重新加载证书失败：", err)
+		return err
+	}
+
+	fields := map[string]interface{}{
+		"cert": p.opts.certFile,
+		"ca":   p.opts.caFile,
+	}
+	if leaf := p.Certificate(); nil != leaf {
+		fields["serial"] = leaf.SerialNumber.String()
+		fields["not_after"] = leaf.NotAfter.Format(time.RFC3339)
+	}
+	logger.WithFields(fields).Info("kit/tls: 证书已重新加载")
+	return nil
+}
+
+// Close 停止监听文件的变化，对 New 创建的 Provider 不执行任何操作。
+//
+// 返回值：
+//   - error：关闭监听失败时返回错误。
+func (p *Provider) Close() error {
+	var err error
+	p.closeOnce.Do(func() {
+		close(p.done)
+		if nil != p.fsw {
+			err = p.fsw.Close()
+		}
+		p.wg.Wait()
+	})
+	return err
+}
+
+// load 加载证书与 CA，全部成功后才替换当前的证书与 CA。
+func (p *Provider) load() error {
+	var cert *stdtls.Certificate
+	if "" != p.opts.certFile || "" != p.opts.keyFile {
+		c, err := stdtls.LoadX509KeyPair(p.opts.certFile, p.opts.keyFile)
+		if nil != err {
+			return fmt.Errorf("kit/tls: 加载证书 %s 失败：%w", p.opts.certFile, err)
+		}
+		cert = &c
+	}
+
+	var roots *x509.CertPool
+	if "" != p.opts.caFile {
+		data, err := os.ReadFile(p.opts.caFile)
+		if nil != err {
+			return fmt.Errorf("kit/tls: 读取 CA 证书 %s 失败：%w", p.opts.caFile, err)
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(data) {
+			return fmt.Errorf("kit/tls: CA 证书 %s 中没有有效的证书", p.opts.caFile)
+		}
+	}
+
+	if nil != cert {
+		p.cert.Store(cert)
+	}
+	if nil != roots {
+		p.roots.Store(roots)
+	}
+	return nil
+}
+
+// baseConfig 返回服务端与客户端共用的配置。
+func (p *Provider) baseConfig() *stdtls.Config {
+	return &stdtls.Config{
+		MinVersion:   p.opts.minVersion,
+		CipherSuites: append([]uint16(nil), cipherSuitesDefault...),
+	}
+}
+
+// verifyServer 使用当前的 CA 校验服务端的证书链与名称。
+func (p *Provider) verifyServer(cs stdtls.ConnectionState) error {
+	if 0 == len(cs.PeerCertificates) {
+		return errors.New("kit/tls: 服务端没有提供证书")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         p.roots.Load(),
+		DNSName:       cs.ServerName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err := cs.PeerCertificates[0].Verify(opts); nil != err {
+		return fmt.Errorf("kit/tls: 校验服务端证书失败：%w", err)
+	}
+	return nil
+}
+
+// watch 处理文件系统事件，合并短时间内的连续事件后重新加载证书。
+func (p *Provider) watch() {
+	defer p.wg.Done()
+
+	var timer *time.Timer
+	var timerC <-chan time.Time
+	defer func() {
+		if nil != timer {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case _, ok := <-p.fsw.Events:
+			if !ok {
+				return
+			}
+			// 目录中的任何变化都可能影响证书文件（例如符号链接切换），统一触发重新加载。
+			if nil == timer {
+				timer = time.NewTimer(p.opts.reloadDebounce)
+			} else {
+				timer.Reset(p.opts.reloadDebounce)
+			}
+			timerC = timer.C
+		case err, ok := <-p.fsw.Errors:
+			if !ok {
+				return
+			}
+			p.opts.getLogger().Error("kit/tls: 监听证书文件失败：", err)
+		case <-timerC:
+			timerC = nil
+			_ = p.Reload()
+		}
+	}
+}
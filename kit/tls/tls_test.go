@@ -0,0 +1,309 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	stdtls "crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/log/logtest"
+)
+
+type (
+	// testCA 是测试使用的 CA。
+	testCA struct {
+		cert *x509.Certificate
+		key  *ecdsa.PrivateKey
+		pem  []byte
+	}
+)
+
+// newTestCA 创建测试使用的 CA。
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kit test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue 签发 localhost 的证书，返回证书与私钥的 PEM。
+func (ca *testCA) issue(t *testing.T, serial int64) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeFiles 在 dir 中写入证书、私钥与 CA 文件，返回它们的路径。
+func (ca *testCA) writeFiles(t *testing.T, dir string, serial int64) (string, string, string) {
+	t.Helper()
+	certPEM, keyPEM := ca.issue(t, serial)
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+	require.NoError(t, os.WriteFile(caFile, ca.pem, 0o600))
+	return certFile, keyFile, caFile
+}
+
+// handshake 使用给定的配置建立一次 TLS 连接，返回客户端与服务端的握手错误。
+func handshake(t *testing.T, serverCfg, clientCfg *stdtls.Config) (error, error) {
+	t.Helper()
+	ln, err := stdtls.Listen("tcp", "127.0.0.1:0", serverCfg)
+	require.NoError(t, err)
+	defer func() {
+		_ = ln.Close()
+	}()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if nil != err {
+			serverErr <- err
+			return
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		serverErr <- conn.(*stdtls.Conn).Handshake()
+	}()
+
+	conn, err := stdtls.Dial("tcp", ln.Addr().String(), clientCfg)
+	if nil == err {
+		_ = conn.Close()
+	}
+	return err, <-serverErr
+}
+
+// TestMutualTLS 测试使用同一 CA 签发的证书完成双向认证。
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile, caFile := ca.writeFiles(t, t.TempDir(), 2)
+
+	server, err := New(WithCertificate(certFile, keyFile), WithCA(caFile))
+	require.NoError(t, err)
+	client, err := New(WithCertificate(certFile, keyFile), WithCA(caFile), WithServerName("localhost"))
+	require.NoError(t, err)
+
+	serverCfg, err := server.ServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, stdtls.RequireAndVerifyClientCert, serverCfg.ClientAuth)
+	assert.Equal(t, uint16(stdtls.VersionTLS12), serverCfg.MinVersion)
+
+	clientErr, serverErr := handshake(t, serverCfg, client.ClientConfig())
+	assert.NoError(t, clientErr)
+	assert.NoError(t, serverErr)
+
+	// 客户端没有证书时，服务端拒绝连接。
+	anonymous, err := New(WithCA(caFile), WithServerName("localhost"))
+	require.NoError(t, err)
+	_, serverErr = handshake(t, serverCfg, anonymous.ClientConfig())
+	assert.Error(t, serverErr)
+}
+
+// TestVerifyServer 测试客户端使用 CA 校验服务端证书。
+func TestVerifyServer(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile, _ := ca.writeFiles(t, t.TempDir(), 2)
+	_, _, otherCAFile := newTestCA(t).writeFiles(t, t.TempDir(), 2)
+
+	server, err := New(WithCertificate(certFile, keyFile))
+	require.NoError(t, err)
+	serverCfg, err := server.ServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, stdtls.NoClientCert, serverCfg.ClientAuth)
+
+	// 不受信任的 CA。
+	client, err := New(WithCA(otherCAFile), WithServerName("localhost"))
+	require.NoError(t, err)
+	clientErr, _ := handshake(t, serverCfg, client.ClientConfig())
+	assert.ErrorContains(t, clientErr, "kit/tls: 校验服务端证书失败")
+
+	// 名称不匹配。
+	client, err = New(WithCA(filepath.Join(filepath.Dir(certFile), "ca.crt")), WithServerName("example.com"))
+	require.NoError(t, err)
+	clientErr, _ = handshake(t, serverCfg, client.ClientConfig())
+	assert.ErrorContains(t, clientErr, "kit/tls: 校验服务端证书失败")
+
+	// 服务端没有提供证书。
+	assert.ErrorContains(t, client.verifyServer(stdtls.ConnectionState{}), "kit/tls: 服务端没有提供证书")
+}
+
+// TestWatch 测试文件变化后重新加载证书，以及加载失败时继续使用原有的证书。
+func TestWatch(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile, caFile := ca.writeFiles(t, dir, 2)
+	logger := logtest.NewRecorder()
+
+	p, err := Watch(
+		WithCertificate(certFile, keyFile),
+		WithCA(caFile),
+		WithReloadDebounce(10*time.Millisecond),
+		WithLogger(logger),
+	)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, p.Close())
+	}()
+	assert.Equal(t, int64(2), p.Certificate().SerialNumber.Int64())
+
+	ca.writeFiles(t, dir, 3)
+	assert.Eventually(t, func() bool {
+		return 0 == big.NewInt(3).Cmp(p.Certificate().SerialNumber)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, logger.Messages(), "info kit/tls: 证书已重新加载")
+
+	// 握手使用新的证书。
+	serverCfg, err := p.ServerConfig()
+	require.NoError(t, err)
+	clientErr, serverErr := handshake(t, serverCfg, p.ClientConfig())
+	assert.NoError(t, clientErr)
+	assert.NoError(t, serverErr)
+
+	// 写入无效的证书，继续使用原有的证书。
+	require.NoError(t, os.WriteFile(certFile, []byte("invalid"), 0o600))
+	assert.Eventually(t, func() bool {
+		for _, msg := range logger.Messages() {
+			if strings.HasPrefix(msg, "error kit/tls: 重新加载证书失败：") {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(3), p.Certificate().SerialNumber.Int64())
+
+	// 重复关闭。
+	assert.NoError(t, p.Close())
+}
+
+// TestReload 测试手动重新加载。
+func TestReload(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile, caFile := ca.writeFiles(t, dir, 2)
+	logger := logtest.NewRecorder()
+
+	p, err := New(WithCertificate(certFile, keyFile), WithCA(caFile), WithLogger(logger))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, p.Close())
+	}()
+
+	ca.writeFiles(t, dir, 4)
+	require.NoError(t, p.Reload())
+	assert.Equal(t, int64(4), p.Certificate().SerialNumber.Int64())
+
+	require.NoError(t, os.WriteFile(caFile, []byte("invalid"), 0o600))
+	assert.ErrorContains(t, p.Reload(), "中没有有效的证书")
+	assert.Equal(t, int64(4), p.Certificate().SerialNumber.Int64())
+	assert.Equal(t, []string{"info kit/tls: 证书已重新加载"}, logger.Messages()[:1])
+	assert.Len(t, logger.Messages(), 2)
+}
+
+// TestNewErrors 测试加载失败的情况。
+func TestNewErrors(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile, caFile := ca.writeFiles(t, dir, 2)
+	missing := filepath.Join(dir, "missing.pem")
+
+	_, err := New(WithCertificate(missing, keyFile))
+	assert.ErrorContains(t, err, "kit/tls: 加载证书")
+	_, err = New(WithCertificate(certFile, caFile))
+	assert.ErrorContains(t, err, "kit/tls: 加载证书")
+	_, err = New(WithCA(missing))
+	assert.ErrorContains(t, err, "kit/tls: 读取 CA 证书")
+	_, err = Watch(WithCA(missing))
+	assert.ErrorContains(t, err, "kit/tls: 读取 CA 证书")
+
+	// 没有证书时不能创建服务端配置，客户端配置使用系统根证书。
+	p, err := New()
+	require.NoError(t, err)
+	_, err = p.ServerConfig()
+	assert.ErrorIs(t, err, ErrNoCertificate)
+	assert.Nil(t, p.Certificate())
+	cfg := p.ClientConfig()
+	assert.False(t, cfg.InsecureSkipVerify)
+	assert.Nil(t, cfg.GetClientCertificate)
+	assert.Nil(t, cfg.VerifyConnection)
+}
+
+// TestOptions 测试配置选项与默认值。
+func TestOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, minVersionDefault, o.minVersion)
+	assert.Equal(t, reloadDebounceDefault, o.reloadDebounce)
+	assert.Equal(t, stdtls.NoClientCert, o.clientAuth)
+	assert.Equal(t, kitlog.GetLogger(), o.getLogger())
+
+	o = newOptions(
+		WithCA("ca.crt"),
+		WithMinVersion(stdtls.VersionTLS13),
+		WithReloadDebounce(time.Second),
+		WithServerName("example.com"),
+	)
+	assert.Equal(t, uint16(stdtls.VersionTLS13), o.minVersion)
+	assert.Equal(t, time.Second, o.reloadDebounce)
+	assert.Equal(t, "example.com", o.serverName)
+	assert.Equal(t, stdtls.RequireAndVerifyClientCert, o.clientAuth)
+
+	o = newOptions(
+		WithCA("ca.crt"),
+		WithClientAuth(stdtls.VerifyClientCertIfGiven),
+		WithMinVersion(stdtls.VersionTLS10),
+		WithReloadDebounce(-1),
+	)
+	assert.Equal(t, stdtls.VerifyClientCertIfGiven, o.clientAuth)
+	assert.Equal(t, minVersionDefault, o.minVersion)
+	assert.Equal(t, reloadDebounceDefault, o.reloadDebounce)
+}
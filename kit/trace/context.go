@@ -0,0 +1,97 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package trace
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// LogFieldTraceID 是日志中追踪标识的字段名。
+	LogFieldTraceID = "trace_id"
+	// LogFieldSpanID 是日志中 Span 标识的字段名。
+	LogFieldSpanID = "span_id"
+
+	// instrumentationName 是 Start 使用的 Tracer 的名称。
+	instrumentationName = "github.com/fsyyft-go/monorepo/kit/trace"
+)
+
+// Start 使用全局的 TracerProvider 创建一个 Span，返回携带该 Span 的上下文。
+// 调用方必须在操作结束时调用 span.End()。
+//
+// 参数：
+//   - ctx：父上下文，其中的 Span 作为新 Span 的父 Span。
+//   - spanName：Span 的名称。
+//   - opts：Span 的选项。
+//
+// 返回值：
+//   - context.Context：携带新 Span 的上下文。
+//   - trace.Span：新的 Span。
+//
+// 示例：
+//
+//	ctx, span := trace.Start(ctx, "LoadOrder")
+//	defer span.End()
+func Start(ctx context.Context, spanName string, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, spanName, opts...)
+}
+
+// TraceIDFromContext 返回上下文中 Span 的追踪标识，格式为 32 位十六进制字符串。
+//
+// 参数：
+//   - ctx：上下文。
+//
+// 返回值：
+//   - string：追踪标识。
+//   - bool：上下文中是否存在有效的追踪标识。
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	sc := oteltrace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return "", false
+	}
+	return sc.TraceID().String(), true
+}
+
+// SpanIDFromContext 返回上下文中 Span 的标识，格式为 16 位十六进制字符串。
+//
+// 参数：
+//   - ctx：上下文。
+//
+// 返回值：
+//   - string：Span 标识。
+//   - bool：上下文中是否存在有效的 Span 标识。
+func SpanIDFromContext(ctx context.Context) (string, bool) {
+	sc := oteltrace.SpanContextFromContext(ctx)
+	if !sc.HasSpanID() {
+		return "", false
+	}
+	return sc.SpanID().String(), true
+}
+
+// LogFields 返回上下文中 Span 的追踪标识与 Span 标识组成的日志字段，用于将日志与追踪关联。
+// 上下文中没有有效的 Span 时返回 nil。
+//
+// 参数：
+//   - ctx：上下文。
+//
+// 返回值：
+//   - map[string]interface{}：以 LogFieldTraceID 与 LogFieldSpanID 为键的日志字段。
+//
+// 示例：
+//
+//	logger.WithFields(trace.LogFields(ctx)).Info("order created")
+func LogFields(ctx context.Context) map[string]interface{} {
+	sc := oteltrace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return map[string]interface{}{
+		LogFieldTraceID: sc.TraceID().String(),
+		LogFieldSpanID:  sc.SpanID().String(),
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// spanContext 返回携带固定追踪标识与 Span 标识的上下文。
+func spanContext() context.Context {
+	sc := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+		SpanID:     oteltrace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		TraceFlags: oteltrace.FlagsSampled,
+	})
+	return oteltrace.ContextWithSpanContext(context.Background(), sc)
+}
+
+// TestTraceIDFromContext 测试从上下文中读取追踪标识与 Span 标识。
+func TestTraceIDFromContext(t *testing.T) {
+	traceID, ok := TraceIDFromContext(spanContext())
+	assert.True(t, ok)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", traceID)
+	spanID, ok := SpanIDFromContext(spanContext())
+	assert.True(t, ok)
+	assert.Equal(t, "0102030405060708", spanID)
+
+	_, ok = TraceIDFromContext(context.Background())
+	assert.False(t, ok)
+	_, ok = SpanIDFromContext(context.Background())
+	assert.False(t, ok)
+}
+
+// TestLogFields 测试日志字段的生成，没有有效的 Span 时返回 nil。
+func TestLogFields(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		LogFieldTraceID: "0102030405060708090a0b0c0d0e0f10",
+		LogFieldSpanID:  "0102030405060708",
+	}, LogFields(spanContext()))
+	assert.Nil(t, LogFields(context.Background()))
+}
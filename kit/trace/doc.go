@@ -0,0 +1,40 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package trace 提供了 OpenTelemetry 追踪的初始化与上下文工具，统一各个服务的追踪配置。
+
+主要功能：
+
+  - 一次初始化：Setup 创建通过 OTLP gRPC 批量导出 Span 的 TracerProvider，并设置全局实例与 W3C 传播器
+  - 资源属性：服务名称与版本默认取自构建信息，可以通过选项覆盖并追加其他属性
+  - 采样：根 Span 按比例采样，子 Span 跟随父 Span 的采样结果
+  - 生命周期：Provider 实现了 kit/runtime 的 Runner 接口，Stop 导出剩余的 Span 并关闭导出器
+  - 上下文工具：TraceIDFromContext、SpanIDFromContext 与 LogFields 从上下文中读取追踪标识，用于关联日志
+
+基本使用：
+
+	provider, err := trace.Setup(ctx,
+	    trace.WithServiceName("order"),
+	    trace.WithEndpoint("otel-collector:4317"),
+	    trace.WithInsecure(true),
+	)
+	if nil != err {
+	    return err
+	}
+	defer provider.Stop(context.Background())
+
+	ctx, span := trace.Start(ctx, "CreateOrder")
+	defer span.End()
+	logger.WithFields(trace.LogFields(ctx)).Info("order created")
+
+由于包名与 go.opentelemetry.io/otel/trace 相同，同时使用时建议为其中之一指定别名：
+
+	import (
+	    "go.opentelemetry.io/otel/trace"
+
+	    kittrace "github.com/fsyyft-go/monorepo/kit/trace"
+	)
+*/
+package trace
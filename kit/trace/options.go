@@ -0,0 +1,184 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package trace
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// 以下为追踪初始化的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// sampleRatioDefault 为根 Span 的默认采样比例。
+	sampleRatioDefault = 1.0
+	// globalDefault 为是否默认设置为全局的 TracerProvider 与传播器。
+	globalDefault = true
+)
+
+type (
+	// Option 定义了追踪初始化的配置选项。
+	Option func(*options)
+
+	// options 包含追踪初始化的配置。
+	options struct {
+		// serviceName 是服务名称，为空时使用主模块路径的最后一段。
+		serviceName string
+		// serviceVersion 是服务版本，为空时使用主模块的版本。
+		serviceVersion string
+		// attributes 是额外的资源属性。
+		attributes []attribute.KeyValue
+
+		// sampleRatio 是根 Span 的采样比例。
+		sampleRatio float64
+		// sampler 是自定义的采样器，设置后 sampleRatio 不再生效。
+		sampler sdktrace.Sampler
+
+		// endpoint 是 OTLP gRPC 接收端的地址，为空时使用环境变量或 OTLP 的默认地址。
+		endpoint string
+		// insecure 表示是否使用明文连接接收端。
+		insecure bool
+		// exporter 是自定义的导出器，设置后 OTLP 相关的选项不再生效。
+		exporter sdktrace.SpanExporter
+
+		// global 表示是否设置为全局的 TracerProvider 与传播器。
+		global bool
+	}
+)
+
+// WithServiceName 设置资源属性中的服务名称。
+//
+// 参数：
+//   - name：服务名称，默认为主模块路径的最后一段。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithServiceName(name string) Option {
+	return func(o *options) {
+		o.serviceName = name
+	}
+}
+
+// WithServiceVersion 设置资源属性中的服务版本。
+//
+// 参数：
+//   - version：服务版本，默认为主模块的版本。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithServiceVersion(version string) Option {
+	return func(o *options) {
+		o.serviceVersion = version
+	}
+}
+
+// WithAttributes 追加资源属性，例如部署环境与实例标识。
+//
+// 参数：
+//   - attrs：资源属性。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return func(o *options) {
+		o.attributes = append(o.attributes, attrs...)
+	}
+}
+
+// WithSampleRatio 设置根 Span 的采样比例，子 Span 跟随父 Span 的采样结果。
+//
+// 参数：
+//   - ratio：采样比例，取值范围为 [0, 1]，默认为 1，超出范围时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithSampleRatio(ratio float64) Option {
+	return func(o *options) {
+		o.sampleRatio = ratio
+	}
+}
+
+// WithSampler 设置自定义的采样器，设置后 WithSampleRatio 不再生效。
+//
+// 参数：
+//   - sampler：采样器。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithSampler(sampler sdktrace.Sampler) Option {
+	return func(o *options) {
+		o.sampler = sampler
+	}
+}
+
+// WithEndpoint 设置 OTLP gRPC 接收端的地址。
+// 未设置时使用 OTEL_EXPORTER_OTLP_TRACES_ENDPOINT 或 OTEL_EXPORTER_OTLP_ENDPOINT 环境变量，都未设置时为 localhost:4317。
+//
+// 参数：
+//   - endpoint：接收端地址，格式为 host:port。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithEndpoint(endpoint string) Option {
+	return func(o *options) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithInsecure 设置是否使用明文连接接收端，通常用于连接同一节点上的 Collector。
+//
+// 参数：
+//   - insecure：是否使用明文连接，默认为 false。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithInsecure(insecure bool) Option {
+	return func(o *options) {
+		o.insecure = insecure
+	}
+}
+
+// WithExporter 设置自定义的导出器，设置后 WithEndpoint 与 WithInsecure 不再生效。
+// 测试时可以使用 tracetest.NewInMemoryExporter 检查产生的 Span。
+//
+// 参数：
+//   - exporter：导出器。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithExporter(exporter sdktrace.SpanExporter) Option {
+	return func(o *options) {
+		o.exporter = exporter
+	}
+}
+
+// WithGlobal 设置是否将创建的 TracerProvider 与 W3C 传播器设置为全局实例。
+//
+// 参数：
+//   - global：是否设置为全局实例，默认为 true。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithGlobal(global bool) Option {
+	return func(o *options) {
+		o.global = global
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		sampleRatio: sampleRatioDefault,
+		global:      globalDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.sampleRatio < 0 || o.sampleRatio > 1 {
+		o.sampleRatio = sampleRatioDefault
+	}
+	return o
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package trace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// Provider 包装了初始化完成的 TracerProvider。
+	// Provider 实现了 kit/runtime 的 Runner 接口：TracerProvider 在 Setup 时已经开始工作，Start 不做任何事情；
+	// Stop 导出缓冲中剩余的 Span 并关闭导出器，应当在服务退出前调用。
+	Provider struct {
+		// tp 是底层的 TracerProvider。
+		tp *sdktrace.TracerProvider
+	}
+)
+
+// Setup 创建 TracerProvider：Span 通过 OTLP gRPC 批量导出，资源属性包含服务名称与版本，
+// 根 Span 按比例采样而子 Span 跟随父 Span 的采样结果。默认同时设置为全局的 TracerProvider，
+// 并设置 W3C Trace Context 与 Baggage 传播器。
+//
+// 参数：
+//   - ctx：创建导出器使用的上下文，连接接收端在后台进行，不会阻塞。
+//   - opts：配置选项，支持 WithServiceName、WithServiceVersion、WithAttributes、WithSampleRatio、WithSampler、
+//     WithEndpoint、WithInsecure、WithExporter 与 WithGlobal。
+//
+// 返回值：
+//   - *Provider：初始化完成的 Provider。
+//   - error：创建导出器或资源失败时返回的错误。
+//
+// 示例：
+//
+//	provider, err := trace.Setup(ctx,
+//	    trace.WithServiceName("order"),
+//	    trace.WithEndpoint("otel-collector:4317"),
+//	    trace.WithInsecure(true),
+//	    trace.WithSampleRatio(0.1),
+//	)
+//	if nil != err {
+//	    return err
+//	}
+//	defer provider.Stop(context.Background())
+func Setup(ctx context.Context, opts ...Option) (*Provider, error) {
+	o := newOptions(opts...)
+
+	exporter := o.exporter
+	if nil == exporter {
+		var exporterOpts []otlptracegrpc.Option
+		if "" != o.endpoint {
+			exporterOpts = append(exporterOpts, otlptracegrpc.WithEndpoint(o.endpoint))
+		}
+		if o.insecure {
+			exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+		}
+		var err error
+		if exporter, err = otlptracegrpc.New(ctx, exporterOpts...); nil != err {
+			return nil, fmt.Errorf("kit/trace: 创建 OTLP 导出器失败：%w", err)
+		}
+	}
+
+	res, err := newResource(o)
+	if nil != err {
+		_ = exporter.Shutdown(ctx)
+		return nil, fmt.Errorf("kit/trace: 创建资源失败：%w", err)
+	}
+
+	sampler := o.sampler
+	if nil == sampler {
+		sampler = sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.sampleRatio))
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sampler),
+	)
+	if o.global {
+		otel.SetTracerProvider(tp)
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	}
+	return &Provider{tp: tp}, nil
+}
+
+// newResource 创建包含服务名称、版本与额外属性的资源，并合并 SDK 的默认资源与环境变量中的资源属性。
+func newResource(o *options) (*resource.Resource, error) {
+	name, version := o.serviceName, o.serviceVersion
+	defaultName, defaultVersion := buildInfo()
+	if "" == name {
+		name = defaultName
+	}
+	if "" == version {
+		version = defaultVersion
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceName(name)}
+	if "" != version {
+		attrs = append(attrs, semconv.ServiceVersion(version))
+	}
+	attrs = append(attrs, o.attributes...)
+	return resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, attrs...))
+}
+
+// buildInfo 从构建信息中返回默认的服务名称与版本。
+// 服务名称为主模块路径的最后一段，没有构建信息时为可执行文件的名称；
+// 版本为主模块的版本，本地构建的 (devel) 版本视为没有版本。
+func buildInfo() (string, string) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || "" == info.Main.Path {
+		return filepath.Base(os.Args[0]), ""
+	}
+	version := info.Main.Version
+	if "(devel)" == version {
+		version = ""
+	}
+	return path.Base(info.Main.Path), version
+}
+
+// Start 实现 kit/runtime 的 Runner 接口，TracerProvider 在 Setup 时已经开始工作，因此直接返回。
+//
+// 参数：
+//   - ctx：未使用。
+//
+// 返回值：
+//   - error：总是返回 nil。
+func (p *Provider) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop 导出缓冲中剩余的 Span 并关闭导出器，之后创建的 Span 不再被导出。
+//
+// 参数：
+//   - ctx：停止操作的截止时间。
+//
+// 返回值：
+//   - error：导出或关闭失败的错误。
+func (p *Provider) Stop(ctx context.Context) error {
+	return p.tp.Shutdown(ctx)
+}
+
+// ForceFlush 立即导出缓冲中的 Span，常用于短生命周期的任务在退出前确保 Span 已经发送。
+//
+// 参数：
+//   - ctx：导出操作的截止时间。
+//
+// 返回值：
+//   - error：导出失败的错误。
+func (p *Provider) ForceFlush(ctx context.Context) error {
+	return p.tp.ForceFlush(ctx)
+}
+
+// Tracer 返回指定名称的 Tracer，名称通常为调用方的包路径。
+//
+// 参数：
+//   - name：Tracer 的名称。
+//   - opts：Tracer 的选项。
+//
+// 返回值：
+//   - trace.Tracer：Tracer。
+func (p *Provider) Tracer(name string, opts ...oteltrace.TracerOption) oteltrace.Tracer {
+	return p.tp.Tracer(name, opts...)
+}
+
+// TracerProvider 返回底层的 TracerProvider，用于不使用全局实例的组件，例如 otelhttp 与 otelgrpc 的选项。
+//
+// 返回值：
+//   - *sdktrace.TracerProvider：底层的 TracerProvider。
+func (p *Provider) TracerProvider() *sdktrace.TracerProvider {
+	return p.tp
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package trace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+// restoreGlobal 在测试结束时恢复全局的 TracerProvider 与传播器。
+func restoreGlobal(t *testing.T) {
+	tp, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tp)
+		otel.SetTextMapPropagator(propagator)
+	})
+}
+
+// TestSetup 测试 Span 被导出，并携带服务名称、版本与额外的资源属性。
+func TestSetup(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider, err := Setup(context.Background(),
+		WithExporter(exporter),
+		WithGlobal(false),
+		WithServiceName("order"),
+		WithServiceVersion("v1.2.3"),
+		WithAttributes(attribute.String("deployment.environment.name", "test")),
+	)
+	require.NoError(t, err)
+	require.NoError(t, provider.Start(context.Background()))
+
+	_, span := provider.Tracer("test").Start(context.Background(), "op")
+	span.End()
+	require.NoError(t, provider.ForceFlush(context.Background()))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "op", spans[0].Name)
+	attrs := spans[0].Resource.Set()
+	name, _ := attrs.Value(semconv.ServiceNameKey)
+	assert.Equal(t, "order", name.AsString())
+	version, _ := attrs.Value(semconv.ServiceVersionKey)
+	assert.Equal(t, "v1.2.3", version.AsString())
+	env, _ := attrs.Value("deployment.environment.name")
+	assert.Equal(t, "test", env.AsString())
+	assert.NotNil(t, provider.TracerProvider())
+
+	// 停止之后创建的 Span 不再被记录。
+	require.NoError(t, provider.Stop(context.Background()))
+	_, span = provider.Tracer("test").Start(context.Background(), "after")
+	assert.False(t, span.IsRecording())
+	span.End()
+}
+
+// TestSetup_Global 测试默认设置全局的 TracerProvider 与传播器。
+func TestSetup_Global(t *testing.T) {
+	restoreGlobal(t)
+	exporter := tracetest.NewInMemoryExporter()
+	provider, err := Setup(context.Background(), WithExporter(exporter))
+	require.NoError(t, err)
+	defer func() {
+		_ = provider.Stop(context.Background())
+	}()
+
+	ctx, span := Start(context.Background(), "global")
+	assert.True(t, span.SpanContext().IsSampled())
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	assert.NotEmpty(t, carrier.Get("traceparent"))
+	span.End()
+
+	require.NoError(t, provider.ForceFlush(context.Background()))
+	require.Len(t, exporter.GetSpans(), 1)
+	assert.Equal(t, instrumentationName, exporter.GetSpans()[0].InstrumentationScope.Name)
+}
+
+// TestSetup_Sampler 测试采样比例与自定义采样器。
+func TestSetup_Sampler(t *testing.T) {
+	provider, err := Setup(context.Background(), WithExporter(tracetest.NewInMemoryExporter()), WithGlobal(false), WithSampleRatio(0))
+	require.NoError(t, err)
+	ctx, root := provider.Tracer("test").Start(context.Background(), "root")
+	assert.False(t, root.SpanContext().IsSampled())
+	// 子 Span 跟随父 Span 的采样结果。
+	_, child := provider.Tracer("test").Start(ctx, "child")
+	assert.False(t, child.SpanContext().IsSampled())
+
+	provider, err = Setup(context.Background(), WithExporter(tracetest.NewInMemoryExporter()), WithGlobal(false),
+		WithSampleRatio(0), WithSampler(sdktrace.AlwaysSample()))
+	require.NoError(t, err)
+	_, root = provider.Tracer("test").Start(context.Background(), "root")
+	assert.True(t, root.SpanContext().IsSampled())
+
+	// 超出范围的采样比例使用默认值。
+	assert.Equal(t, sampleRatioDefault, newOptions(WithSampleRatio(2)).sampleRatio)
+	assert.Equal(t, sampleRatioDefault, newOptions(WithSampleRatio(-1)).sampleRatio)
+}
+
+// TestSetup_OTLP 测试未设置导出器时创建 OTLP 导出器，接收端不可用不会阻塞初始化。
+func TestSetup_OTLP(t *testing.T) {
+	provider, err := Setup(context.Background(), WithEndpoint("127.0.0.1:1"), WithInsecure(true), WithGlobal(false))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, provider.Stop(ctx))
+}
+
+// TestBuildInfo 测试默认的服务名称不为空，未设置服务名称时使用默认值。
+func TestBuildInfo(t *testing.T) {
+	name, _ := buildInfo()
+	assert.NotEmpty(t, name)
+
+	res, err := newResource(newOptions())
+	require.NoError(t, err)
+	value, ok := res.Set().Value(semconv.ServiceNameKey)
+	require.True(t, ok)
+	assert.Equal(t, name, value.AsString())
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package validator 提供了基于结构体标签的校验功能，校验结果与 kit/errors 集成。
+
+主要功能：
+
+  - 内置规则：required、omitempty、min、max、len、oneof、pattern，time.Duration 字段的参数按时长解析
+  - 递归校验：嵌套的结构体以及切片、数组、映射中的结构体，字段路径形如 server.port、items[0].name
+  - 自定义规则：RegisterRule 注册按名称引用的规则
+  - 自定义校验：实现 Validatable 的结构体在字段规则之后调用 Validate 方法，用于校验字段之间的关系
+  - 结构化结果：一次返回全部未通过的字段，错误码为 kiterrors.CodeInvalidArgument，FieldErrors 逐个读取
+
+kit/config 的 Load 使用该包校验配置结构体，字段路径与配置键一致。
+
+基本使用：
+
+	type CreateUserRequest struct {
+	    Name  string `json:"name" validate:"required,max=32"`
+	    Level string `json:"level" validate:"oneof=basic pro"`
+	}
+
+	if err := validator.Struct(&req); nil != err {
+	    for _, fe := range validator.FieldErrors(err) {
+	        fmt.Println(fe.Field, fe.Message)
+	    }
+	}
+
+规则本身不合法（未注册的规则、无法解析的参数、不支持的字段类型）属于编程错误，返回包装 ErrInvalidRule 的错误，
+不带 CodeInvalidArgument 错误码，避免被当作用户输入错误处理。
+*/
+package validator
@@ -0,0 +1,80 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package validator
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidRule 表示校验规则本身不合法，例如未注册的规则或无法解析的参数，属于编程错误。
+	ErrInvalidRule = errors.New("kit/validator: 校验规则不合法")
+)
+
+type (
+	// FieldError 描述单个字段未通过校验的原因。
+	FieldError struct {
+		// Field 是字段的路径，例如 "server.port" 或 "items[0].name"，校验单个值时为空。
+		Field string
+		// Rule 是未通过的规则名称，例如 "required"；由 Validate 方法返回的错误为 "validate"。
+		Rule string
+		// Param 是规则的参数，例如 "min=1" 中的 "1"。
+		Param string
+		// Message 是未通过校验的原因。
+		Message string
+		// cause 是 Validate 方法或自定义规则返回的原始错误。
+		cause error
+	}
+)
+
+// Error 返回 "字段路径: 原因" 格式的错误信息，字段路径为空时只返回原因。
+func (e *FieldError) Error() string {
+	if "" == e.Field {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// Unwrap 返回原始错误。
+func (e *FieldError) Unwrap() error {
+	return e.cause
+}
+
+// FieldErrors 返回错误链中全部的 FieldError，用于向调用方逐个展示未通过校验的字段。
+//
+// 参数：
+//   - err：Struct 或 Var 返回的错误。
+//
+// 返回值：
+//   - []*FieldError：按字段顺序排列的校验错误，err 中没有 FieldError 时返回 nil。
+//
+// 示例：
+//
+//	if err := validator.Struct(&req); nil != err {
+//	    for _, fe := range validator.FieldErrors(err) {
+//	        resp.Errors[fe.Field] = fe.Message
+//	    }
+//	}
+func FieldErrors(err error) []*FieldError {
+	var out []*FieldError
+	var walk func(err error)
+	walk = func(err error) {
+		for nil != err {
+			if fe, ok := err.(*FieldError); ok {
+				out = append(out, fe)
+				return
+			}
+			if multi, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, e := range multi.Unwrap() {
+					walk(e)
+				}
+				return
+			}
+			err = errors.Unwrap(err)
+		}
+	}
+	walk(err)
+	return out
+}
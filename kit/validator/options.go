@@ -0,0 +1,91 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+// 以下为校验的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// tagNameDefault 为声明校验规则的结构体标签。
+	tagNameDefault = "validate"
+	// fieldNameDefault 为生成字段路径的默认函数。
+	fieldNameDefault = jsonFieldName
+)
+
+type (
+	// Option 定义了校验的配置选项。
+	Option func(*options)
+
+	// options 包含校验的配置。
+	options struct {
+		// tagName 是声明校验规则的结构体标签。
+		tagName string
+		// fieldName 根据结构体字段生成字段路径中的名称。
+		fieldName func(field reflect.StructField) string
+	}
+)
+
+// WithTagName 设置声明校验规则的结构体标签。
+//
+// 参数：
+//   - tagName：标签名，默认为 "validate"，为空时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithTagName(tagName string) Option {
+	return func(o *options) {
+		o.tagName = tagName
+	}
+}
+
+// WithFieldName 设置生成字段路径的函数，使错误中的字段路径与配置键、请求参数等外部名称一致。
+// 函数返回空字符串时，字段的子字段直接挂在上一级路径下，用于嵌入的结构体。
+//
+// 参数：
+//   - fn：根据结构体字段返回名称的函数，默认使用 json 标签中的名称，没有时使用字段名，嵌入的结构体返回空字符串；
+//     为 nil 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithFieldName(fn func(field reflect.StructField) string) Option {
+	return func(o *options) {
+		o.fieldName = fn
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		tagName:   tagNameDefault,
+		fieldName: fieldNameDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if "" == o.tagName {
+		o.tagName = tagNameDefault
+	}
+	if nil == o.fieldName {
+		o.fieldName = fieldNameDefault
+	}
+	return o
+}
+
+// jsonFieldName 返回 json 标签中的名称，没有时返回字段名，嵌入的结构体返回空字符串。
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if "" != name && "-" != name {
+		return name
+	}
+	if field.Anonymous {
+		return ""
+	}
+	return field.Name
+}
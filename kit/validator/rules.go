@@ -0,0 +1,277 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package validator
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	stdsync "sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// ruleRequired 要求字段非空，指针字段要求非 nil。
+	ruleRequired = "required"
+	// ruleOmitEmpty 在字段为空时跳过之后的全部规则。
+	ruleOmitEmpty = "omitempty"
+	// rulePattern 要求字符串匹配正则表达式，必须是最后一条规则，表达式中可以包含逗号。
+	rulePattern = "pattern"
+	// ruleSkip 表示不校验该字段，也不递归校验其子字段。
+	ruleSkip = "-"
+)
+
+var (
+	// durationType 是 time.Duration 的反射类型，min、max 的参数按时长解析。
+	durationType = reflect.TypeOf(time.Duration(0))
+
+	// rulesMu 保护 rules。
+	rulesMu stdsync.RWMutex
+	// rules 是已注册的规则，包括内置规则与 RegisterRule 注册的自定义规则。
+	rules = map[string]RuleFunc{
+		"min":       ruleMin,
+		"max":       ruleMax,
+		"len":       ruleLen,
+		"oneof":     ruleOneOf,
+		rulePattern: rulePatternMatch,
+	}
+
+	// specCache 缓存解析后的结构体标签，键为标签内容。
+	specCache stdsync.Map
+	// patternCache 缓存编译后的正则表达式，键为表达式。
+	patternCache stdsync.Map
+)
+
+type (
+	// RuleFunc 定义了校验规则。
+	// v 是字段的值，指针字段已经解引用；param 是规则的参数，例如 "min=1" 中的 "1"。
+	// 返回的错误信息作为 FieldError 的 Message；参数不合法时应返回包装 ErrInvalidRule 的错误，校验随即中止。
+	RuleFunc func(v reflect.Value, param string) error
+
+	// ruleSpec 是解析后的单条规则。
+	ruleSpec struct {
+		// name 是规则名称。
+		name string
+		// param 是规则的参数。
+		param string
+	}
+)
+
+// RegisterRule 注册自定义规则，同名的规则会被覆盖，包括内置规则。
+// 通常在 init 函数中调用，注册后对全部校验生效。
+//
+// 参数：
+//   - name：规则名称，不能为空，也不能是 required、omitempty 或 "-"，否则会 panic。
+//   - fn：规则的实现，为 nil 时会 panic。
+//
+// 示例：
+//
+//	validator.RegisterRule("port", func(v reflect.Value, _ string) error {
+//	    if v.Int() < 1 || v.Int() > 65535 {
+//	        return errors.New("必须是合法的端口号")
+//	    }
+//	    return nil
+//	})
+func RegisterRule(name string, fn RuleFunc) {
+	if "" == name || ruleRequired == name || ruleOmitEmpty == name || ruleSkip == name || strings.ContainsAny(name, ",=") {
+		panic(fmt.Sprintf("kit/validator: 规则名称 %q 不可用", name))
+	}
+	if nil == fn {
+		panic("kit/validator: 规则 " + name + " 的实现不能为 nil")
+	}
+
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[name] = fn
+}
+
+// lookupRule 返回已注册的规则。
+func lookupRule(name string) (RuleFunc, bool) {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	fn, ok := rules[name]
+	return fn, ok
+}
+
+// parseRules 解析结构体标签中的规则，结果按标签内容缓存。
+func parseRules(tag string) []ruleSpec {
+	if cached, ok := specCache.Load(tag); ok {
+		return cached.([]ruleSpec)
+	}
+
+	var specs []ruleSpec
+	rest := tag
+	for "" != rest {
+		var part string
+		if strings.HasPrefix(rest, rulePattern+"=") {
+			// 正则表达式中可能包含逗号，pattern 之后的内容全部作为参数。
+			part, rest = rest, ""
+		} else {
+			part, rest, _ = strings.Cut(rest, ",")
+		}
+		part = strings.TrimSpace(part)
+		if "" == part {
+			continue
+		}
+		name, param, _ := strings.Cut(part, "=")
+		specs = append(specs, ruleSpec{name: name, param: param})
+	}
+
+	specCache.Store(tag, specs)
+	return specs
+}
+
+// isEmpty 返回值是否为空：字符串、切片、映射与通道长度为 0，其他类型为零值。
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Chan:
+		return 0 == v.Len()
+	default:
+		return v.IsZero()
+	}
+}
+
+// ruleMin 要求数字不小于参数，字符串、切片与映射的长度不小于参数；time.Duration 的参数按时长解析，例如 "min=1s"。
+func ruleMin(v reflect.Value, param string) error {
+	c, subject, err := compare(v, "min", param)
+	if nil != err {
+		return err
+	}
+	if c < 0 {
+		return fmt.Errorf("%s必须大于等于 %s", subject, param)
+	}
+	return nil
+}
+
+// ruleMax 要求数字不大于参数，字符串、切片与映射的长度不大于参数。
+func ruleMax(v reflect.Value, param string) error {
+	c, subject, err := compare(v, "max", param)
+	if nil != err {
+		return err
+	}
+	if c > 0 {
+		return fmt.Errorf("%s必须小于等于 %s", subject, param)
+	}
+	return nil
+}
+
+// ruleLen 要求字符串、切片与映射的长度等于参数，数字等于参数。
+func ruleLen(v reflect.Value, param string) error {
+	c, subject, err := compare(v, "len", param)
+	if nil != err {
+		return err
+	}
+	if 0 != c {
+		return fmt.Errorf("%s必须等于 %s", subject, param)
+	}
+	return nil
+}
+
+// compare 比较 v 与参数，返回比较结果与比较的对象，比较长度时对象为 "长度"。
+// 字符串的长度按字符计算。
+func compare(v reflect.Value, rule, param string) (int, string, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var p int64
+		var err error
+		if durationType == v.Type() {
+			var d time.Duration
+			d, err = time.ParseDuration(param)
+			p = int64(d)
+		} else {
+			p, err = strconv.ParseInt(param, 10, 64)
+		}
+		if nil != err {
+			return 0, "", invalidParam(rule, param, err)
+		}
+		return cmp.Compare(v.Int(), p), "", nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		p, err := strconv.ParseUint(param, 10, 64)
+		if nil != err {
+			return 0, "", invalidParam(rule, param, err)
+		}
+		return cmp.Compare(v.Uint(), p), "", nil
+	case reflect.Float32, reflect.Float64:
+		p, err := strconv.ParseFloat(param, 64)
+		if nil != err {
+			return 0, "", invalidParam(rule, param, err)
+		}
+		return cmp.Compare(v.Float(), p), "", nil
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+		p, err := strconv.Atoi(param)
+		if nil != err {
+			return 0, "", invalidParam(rule, param, err)
+		}
+		n := v.Len()
+		if reflect.String == v.Kind() {
+			n = utf8.RuneCountInString(v.String())
+		}
+		return cmp.Compare(n, p), "长度", nil
+	default:
+		return 0, "", unsupported(rule, v)
+	}
+}
+
+// ruleOneOf 要求值是参数中以空格分隔的选项之一，支持字符串与整数。
+func ruleOneOf(v reflect.Value, param string) error {
+	var s string
+	switch v.Kind() {
+	case reflect.String:
+		s = v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s = strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s = strconv.FormatUint(v.Uint(), 10)
+	default:
+		return unsupported("oneof", v)
+	}
+
+	options := strings.Fields(param)
+	for _, option := range options {
+		if s == option {
+			return nil
+		}
+	}
+	return fmt.Errorf("必须是 %s 之一", strings.Join(options, "、"))
+}
+
+// rulePatternMatch 要求字符串匹配参数中的正则表达式。
+func rulePatternMatch(v reflect.Value, param string) error {
+	if reflect.String != v.Kind() {
+		return unsupported(rulePattern, v)
+	}
+
+	var re *regexp.Regexp
+	if cached, ok := patternCache.Load(param); ok {
+		re = cached.(*regexp.Regexp)
+	} else {
+		compiled, err := regexp.Compile(param)
+		if nil != err {
+			return invalidParam(rulePattern, param, err)
+		}
+		patternCache.Store(param, compiled)
+		re = compiled
+	}
+
+	if !re.MatchString(v.String()) {
+		return errors.New("格式不正确，应匹配 " + param)
+	}
+	return nil
+}
+
+// invalidParam 返回参数无法解析的错误。
+func invalidParam(rule, param string, err error) error {
+	return fmt.Errorf("%w：规则 %s 的参数 %q 无法解析：%v", ErrInvalidRule, rule, param, err)
+}
+
+// unsupported 返回规则不支持该类型的错误。
+func unsupported(rule string, v reflect.Value) error {
+	return fmt.Errorf("%w：规则 %s 不支持 %s 类型", ErrInvalidRule, rule, v.Type())
+}
@@ -0,0 +1,291 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package validator
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+
+	kiterrors "github.com/fsyyft-go/monorepo/kit/errors"
+)
+
+type (
+	// Validatable 定义了自定义校验的接口，与 kit/config 的 Validator 接口相同。
+	// 结构体及其嵌套的结构体实现该接口时，在字段规则校验之后调用 Validate 方法，用于校验字段之间的关系。
+	// Validate 方法中不要对自身调用 Struct，否则会无限递归。
+	Validatable interface {
+		// Validate 校验结构体是否合法。
+		//
+		// 返回值：
+		//   - error：不合法时返回错误，可以返回 Var 的结果以复用内置规则。
+		Validate() error
+	}
+
+	// walker 遍历值并收集校验错误。
+	walker struct {
+		// opts 是校验的配置。
+		opts *options
+		// errs 是收集到的校验错误。
+		errs kiterrors.MultiError
+	}
+)
+
+// Struct 按结构体标签校验结构体，递归校验嵌套的结构体以及切片、数组、映射中的结构体。
+// 标签中的规则以逗号分隔，例如 `validate:"required,min=1,max=65535"`，内置规则包括：
+//
+//   - required：非空，指针要求非 nil
+//   - omitempty：为空时跳过之后的规则
+//   - min、max、len：数字的大小，或字符串（按字符计算）、切片、映射的长度；time.Duration 的参数为时长，例如 min=1s
+//   - oneof：以空格分隔的选项之一，例如 oneof=debug info warn
+//   - pattern：匹配正则表达式，必须是最后一条规则，表达式中可以包含逗号
+//   - "-"：不校验该字段及其子字段
+//
+// 每个字段在第一条未通过的规则处停止，全部字段的错误一并返回。
+//
+// 参数：
+//   - v：结构体或指向结构体的指针。
+//   - opts：配置选项，参见 WithTagName 与 WithFieldName。
+//
+// 返回值：
+//   - error：校验通过时返回 nil；否则返回错误码为 kit/errors 的 CodeInvalidArgument、包含全部 FieldError 的错误，
+//     可以通过 FieldErrors 逐个读取；规则本身不合法时返回包装 ErrInvalidRule 的错误。
+//
+// 示例：
+//
+//	type CreateUserRequest struct {
+//	    Name  string `json:"name" validate:"required,max=32"`
+//	    Age   int    `json:"age" validate:"min=0,max=150"`
+//	    Email string `json:"email" validate:"omitempty,pattern=^[^@]+@[^@]+$"`
+//	}
+//
+//	if err := validator.Struct(&req); nil != err {
+//	    return err
+//	}
+func Struct(v interface{}, opts ...Option) error {
+	rv := reflect.ValueOf(v)
+	target := rv
+	for reflect.Ptr == target.Kind() && !target.IsNil() {
+		target = target.Elem()
+	}
+	if reflect.Struct != target.Kind() {
+		return fmt.Errorf("%w：校验目标必须是结构体或指向结构体的指针，实际为 %T", ErrInvalidRule, v)
+	}
+
+	w := &walker{opts: newOptions(opts...)}
+	if err := w.value(rv, ""); nil != err {
+		return err
+	}
+	return w.result()
+}
+
+// Var 按规则校验单个值，规则的格式与结构体标签相同。
+//
+// 参数：
+//   - v：要校验的值，为结构体时同时按结构体标签校验。
+//   - rules：以逗号分隔的规则，例如 "required,min=1"。
+//   - opts：配置选项。
+//
+// 返回值：
+//   - error：与 Struct 相同，FieldError 的 Field 为空。
+//
+// 示例：
+//
+//	func (c *Config) Validate() error {
+//	    if c.TLS.Enabled {
+//	        return validator.Var(c.TLS.CertFile, "required")
+//	    }
+//	    return nil
+//	}
+func Var(v interface{}, rules string, opts ...Option) error {
+	w := &walker{opts: newOptions(opts...)}
+	if err := w.field(reflect.ValueOf(v), "", parseRules(rules)); nil != err {
+		return err
+	}
+	return w.result()
+}
+
+// result 返回收集到的校验错误。
+func (w *walker) result() error {
+	return kiterrors.WithCode(w.errs.ErrorOrNil(), kiterrors.CodeInvalidArgument)
+}
+
+// field 对字段依次应用规则，全部通过后递归校验字段的值。
+func (w *walker) field(v reflect.Value, path string, specs []ruleSpec) error {
+	target := v
+	for (reflect.Ptr == target.Kind() || reflect.Interface == target.Kind()) && !target.IsNil() {
+		target = target.Elem()
+	}
+	isNil := !target.IsValid() || (reflect.Ptr == target.Kind() || reflect.Interface == target.Kind()) && target.IsNil()
+	isPtr := v.IsValid() && reflect.Ptr == v.Kind()
+
+	for _, spec := range specs {
+		switch spec.name {
+		case ruleSkip:
+			return nil
+		case ruleOmitEmpty:
+			if isNil || isEmpty(target) {
+				return nil
+			}
+			continue
+		case ruleRequired:
+			// 指针只要求非 nil，允许指向零值，用于区分“未设置”与“设置为零值”。
+			if isNil || (!isPtr && isEmpty(target)) {
+				w.errs.Append(&FieldError{Field: path, Rule: ruleRequired, Message: "不能为空"})
+				return nil
+			}
+			continue
+		}
+
+		if isNil {
+			continue
+		}
+		fn, ok := lookupRule(spec.name)
+		if !ok {
+			return fmt.Errorf("%w：字段 %s 使用了未注册的规则 %s", ErrInvalidRule, path, spec.name)
+		}
+		if err := fn(target, spec.param); nil != err {
+			if errors.Is(err, ErrInvalidRule) {
+				return fmt.Errorf("%w（字段 %s）", err, path)
+			}
+			w.errs.Append(&FieldError{Field: path, Rule: spec.name, Param: spec.param, Message: err.Error(), cause: err})
+			return nil
+		}
+	}
+
+	if isNil {
+		return nil
+	}
+	return w.value(v, path)
+}
+
+// value 递归校验值中的结构体。
+func (w *walker) value(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return w.value(v.Elem(), path)
+	case reflect.Struct:
+		if err := w.structFields(v, path); nil != err {
+			return err
+		}
+		if v.CanAddr() {
+			w.validate(v.Addr(), path)
+		} else {
+			w.validate(v, path)
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		if !mayContainStruct(v.Type().Elem()) {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := w.value(v.Index(i), path+"["+strconv.Itoa(i)+"]"); nil != err {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if !mayContainStruct(v.Type().Elem()) {
+			return nil
+		}
+		// 按键排序，保证错误的顺序稳定。
+		keys := v.MapKeys()
+		names := make(map[reflect.Value]string, len(keys))
+		for _, k := range keys {
+			names[k] = fmt.Sprint(k)
+		}
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return cmp.Compare(names[a], names[b])
+		})
+		for _, k := range keys {
+			if err := w.value(v.MapIndex(k), path+"["+names[k]+"]"); nil != err {
+				return err
+			}
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// structFields 按标签校验结构体的导出字段。
+func (w *walker) structFields(v reflect.Value, path string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		// 嵌入的非导出结构体中的导出字段同样需要校验，与 encoding/json 的处理一致。
+		if !field.IsExported() && !(field.Anonymous && reflect.Struct == indirectType(field.Type).Kind()) {
+			continue
+		}
+		if err := w.field(v.Field(i), joinPath(path, w.opts.fieldName(field)), parseRules(field.Tag.Get(w.opts.tagName))); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate 在 v 实现了 Validatable 时调用其 Validate 方法。
+// Validate 返回的 FieldError 的字段路径加上当前路径作为前缀，其他错误作为当前路径的 FieldError。
+func (w *walker) validate(v reflect.Value, path string) {
+	// 嵌入的非导出结构体无法通过反射调用方法，由外层结构体通过方法提升调用。
+	if !v.CanInterface() {
+		return
+	}
+	validatable, ok := v.Interface().(Validatable)
+	if !ok {
+		return
+	}
+	err := validatable.Validate()
+	if nil == err {
+		return
+	}
+
+	fieldErrs := FieldErrors(err)
+	if 0 == len(fieldErrs) {
+		w.errs.Append(&FieldError{Field: path, Rule: "validate", Message: err.Error(), cause: err})
+		return
+	}
+	for _, fe := range fieldErrs {
+		copied := *fe
+		copied.Field = joinPath(path, fe.Field)
+		w.errs.Append(&copied)
+	}
+}
+
+// mayContainStruct 返回类型的值中是否可能包含需要递归校验的结构体。
+func mayContainStruct(t reflect.Type) bool {
+	switch indirectType(t).Kind() {
+	case reflect.Struct, reflect.Interface, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// indirectType 返回指针最终指向的类型。
+func indirectType(t reflect.Type) reflect.Type {
+	for reflect.Ptr == t.Kind() {
+		t = t.Elem()
+	}
+	return t
+}
+
+// joinPath 拼接字段路径。
+func joinPath(path, name string) string {
+	switch {
+	case "" == path:
+		return name
+	case "" == name:
+		return path
+	default:
+		return path + "." + name
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kiterrors "github.com/fsyyft-go/monorepo/kit/errors"
+)
+
+type (
+	// testServer 是嵌套校验使用的结构体。
+	testServer struct {
+		Host    string        `json:"host" validate:"required"`
+		Port    int           `json:"port" validate:"min=1,max=65535"`
+		Timeout time.Duration `json:"timeout" validate:"min=100ms,max=1m"`
+	}
+
+	// testConfig 是覆盖各类规则的结构体。
+	testConfig struct {
+		Name    string            `json:"name" validate:"required,len=3"`
+		Level   string            `json:"level" validate:"oneof=debug info warn"`
+		Email   string            `json:"email" validate:"omitempty,pattern=^[a-z]{1,3}@x\\.com$"`
+		Tags    []string          `json:"tags" validate:"min=1"`
+		Ratio   float64           `json:"ratio" validate:"max=1"`
+		Retries uint              `json:"retries" validate:"max=3"`
+		Code    int               `json:"code" validate:"oneof=1 2"`
+		Server  testServer        `json:"server"`
+		Backup  *testServer       `json:"backup"`
+		Peers   []testServer      `json:"peers"`
+		Routes  map[string]*route `json:"routes"`
+		Limit   *int              `json:"limit" validate:"required"`
+		Skipped testServer        `json:"skipped" validate:"-"`
+		Any     interface{}       `json:"any"`
+		secret  string            `validate:"required"`
+	}
+
+	// route 实现了 Validatable，校验字段之间的关系。
+	route struct {
+		From int `validate:"min=0"`
+		To   int
+	}
+
+	// embedded 测试嵌入结构体的字段路径。
+	embedded struct {
+		testServer
+		Extra string `validate:"required"`
+	}
+
+	// valueValidatable 以值接收者实现 Validatable。
+	valueValidatable struct {
+		OK bool
+	}
+
+	// nestedValidate 的 Validate 返回 Var 的结果。
+	nestedValidate struct {
+		Name string
+	}
+)
+
+func (r *route) Validate() error {
+	if r.To < r.From {
+		return errors.New("to 不能小于 from")
+	}
+	return nil
+}
+
+func (v valueValidatable) Validate() error {
+	if !v.OK {
+		return errors.New("not ok")
+	}
+	return nil
+}
+
+func (n *nestedValidate) Validate() error {
+	return Var(n.Name, "max=2")
+}
+
+// validConfig 返回通过校验的配置。
+func validConfig() *testConfig {
+	limit := 0
+	return &testConfig{
+		Name:    "abc",
+		Level:   "info",
+		Tags:    []string{"a"},
+		Ratio:   0.5,
+		Retries: 3,
+		Code:    2,
+		Server:  testServer{Host: "h", Port: 80, Timeout: time.Second},
+		Limit:   &limit,
+	}
+}
+
+// fieldMessages 返回字段路径到错误原因的映射。
+func fieldMessages(err error) map[string]string {
+	out := make(map[string]string)
+	for _, fe := range FieldErrors(err) {
+		out[fe.Field] = fe.Rule + ":" + fe.Message
+	}
+	return out
+}
+
+// TestStruct 测试内置规则与嵌套结构体的校验。
+func TestStruct(t *testing.T) {
+	require.NoError(t, Struct(validConfig()))
+	require.NoError(t, Struct(*validConfig()))
+
+	c := validConfig()
+	c.Name = "中文名"
+	c.Email = "ab@x.com"
+	require.NoError(t, Struct(c), "长度按字符计算")
+
+	c = &testConfig{
+		Name:    "abcd",
+		Level:   "trace",
+		Email:   "bad",
+		Ratio:   1.5,
+		Retries: 4,
+		Code:    3,
+		Server:  testServer{Port: 0, Timeout: time.Millisecond},
+		Backup:  &testServer{Host: "b", Port: 70000, Timeout: time.Second},
+		Peers:   []testServer{{Host: "p", Port: 1, Timeout: time.Hour}},
+		Routes:  map[string]*route{"b": {From: 2, To: 1}, "a": {From: -1}, "c": nil},
+		Skipped: testServer{},
+		Any:     &testServer{Port: 1, Timeout: time.Second},
+		secret:  "",
+	}
+	err := Struct(c)
+	require.Error(t, err)
+	assert.True(t, kiterrors.IsCode(err, kiterrors.CodeInvalidArgument))
+
+	assert.Equal(t, map[string]string{
+		"name":             "len:长度必须等于 3",
+		"level":            "oneof:必须是 debug、info、warn 之一",
+		"email":            "pattern:格式不正确，应匹配 ^[a-z]{1,3}@x\\.com$",
+		"tags":             "min:长度必须大于等于 1",
+		"ratio":            "max:必须小于等于 1",
+		"retries":          "max:必须小于等于 3",
+		"code":             "oneof:必须是 1、2 之一",
+		"server.host":      "required:不能为空",
+		"server.port":      "min:必须大于等于 1",
+		"server.timeout":   "min:必须大于等于 100ms",
+		"backup.port":      "max:必须小于等于 65535",
+		"peers[0].timeout": "max:必须小于等于 1m",
+		"routes[a].From":   "min:必须大于等于 0",
+		"routes[b]":        "validate:to 不能小于 from",
+		"limit":            "required:不能为空",
+		"any.host":         "required:不能为空",
+	}, fieldMessages(err))
+
+	fes := FieldErrors(err)
+	assert.Equal(t, "name", fes[0].Field, "错误按字段顺序排列")
+	assert.Equal(t, "3", fes[0].Param)
+	assert.Equal(t, "name: 长度必须等于 3", fes[0].Error())
+}
+
+// TestStructOptions 测试标签名与字段路径的配置。
+func TestStructOptions(t *testing.T) {
+	type conf struct {
+		Port int `config:"port" check:"min=1"`
+	}
+	err := Struct(&conf{}, WithTagName("check"), WithFieldName(func(f reflect.StructField) string {
+		return strings.ToUpper(f.Tag.Get("config"))
+	}))
+	assert.Equal(t, map[string]string{"PORT": "min:必须大于等于 1"}, fieldMessages(err))
+
+	// 空值与 nil 使用默认配置。
+	err = Struct(&embedded{}, WithTagName(""), WithFieldName(nil))
+	assert.Equal(t, map[string]string{
+		"host":    "required:不能为空",
+		"port":    "min:必须大于等于 1",
+		"timeout": "min:必须大于等于 100ms",
+		"Extra":   "required:不能为空",
+	}, fieldMessages(err))
+}
+
+// TestValidatable 测试 Validate 方法的调用。
+func TestValidatable(t *testing.T) {
+	err := Struct(valueValidatable{})
+	assert.Equal(t, "not ok", err.Error())
+	assert.Equal(t, map[string]string{"": "validate:not ok"}, fieldMessages(err))
+	assert.NoError(t, Struct(&valueValidatable{OK: true}))
+
+	err = Struct(&struct {
+		Items []interface{} `json:"items"`
+	}{Items: []interface{}{valueValidatable{}, &nestedValidate{Name: "abc"}}})
+	assert.Equal(t, map[string]string{
+		"items[0]": "validate:not ok",
+		"items[1]": "max:长度必须小于等于 2",
+	}, fieldMessages(err))
+}
+
+// TestVar 测试单个值的校验。
+func TestVar(t *testing.T) {
+	assert.NoError(t, Var("abc", "required,max=3"))
+	assert.NoError(t, Var(nil, "omitempty,min=1"))
+	assert.NoError(t, Var([3]int{}, "len=3"))
+	assert.NoError(t, Var(uint8(3), "oneof=1 3"))
+
+	err := Var("", "required,min=1")
+	assert.Equal(t, "不能为空", err.Error())
+	assert.Equal(t, map[string]string{"": "required:不能为空"}, fieldMessages(err))
+
+	assert.Error(t, Var(nil, "required"))
+	assert.Error(t, Var(map[string]int{}, "min=1"))
+	assert.Error(t, Var(make(chan int), "len=1"))
+	assert.Error(t, Var(&testServer{}, "required"), "按结构体标签校验")
+
+	n := 5
+	assert.NoError(t, Var(&n, "min=5"))
+	zero := 0
+	assert.NoError(t, Var(&zero, "required"), "指针只要求非 nil")
+	var nilPtr *int
+	assert.NoError(t, Var(nilPtr, "min=1"), "nil 指针跳过其他规则")
+}
+
+// TestInvalidRule 测试规则本身不合法时的错误。
+func TestInvalidRule(t *testing.T) {
+	cases := []struct {
+		v     interface{}
+		rules string
+	}{
+		{1, "unknown"},
+		{1, "min=x"},
+		{uint(1), "max=x"},
+		{1.5, "min=x"},
+		{"s", "len=x"},
+		{time.Second, "min=1"},
+		{true, "min=1"},
+		{1.5, "oneof=1"},
+		{1, "pattern=x"},
+		{"s", "pattern=("},
+	}
+	for _, c := range cases {
+		err := Var(c.v, c.rules)
+		assert.ErrorIs(t, err, ErrInvalidRule, "%v %s", c.v, c.rules)
+		assert.False(t, kiterrors.IsCode(err, kiterrors.CodeInvalidArgument))
+	}
+
+	assert.ErrorIs(t, Struct(nil), ErrInvalidRule)
+	assert.ErrorIs(t, Struct(1), ErrInvalidRule)
+	assert.ErrorIs(t, Struct((*testServer)(nil)), ErrInvalidRule)
+
+	type badNested struct {
+		A struct {
+			B int `validate:"min=x"`
+		}
+	}
+	type badSlice struct {
+		C []struct {
+			D int `validate:"nope"`
+		}
+	}
+	type badMap struct {
+		E map[string]struct {
+			F int `validate:"nope"`
+		}
+	}
+	assert.ErrorContains(t, Struct(&badNested{}), "A.B")
+	assert.ErrorContains(t, Struct(&badSlice{C: make([]struct {
+		D int `validate:"nope"`
+	}, 1)}), "C[0].D")
+	assert.ErrorContains(t, Struct(&badMap{E: map[string]struct {
+		F int `validate:"nope"`
+	}{"k": {}}}), "E[k].F")
+}
+
+// TestRegisterRule 测试自定义规则的注册。
+func TestRegisterRule(t *testing.T) {
+	RegisterRule("even", func(v reflect.Value, _ string) error {
+		if 0 != v.Int()%2 {
+			return errors.New("必须是偶数")
+		}
+		return nil
+	})
+	assert.NoError(t, Var(2, "even"))
+	err := Var(3, "min=1 , even")
+	assert.Equal(t, map[string]string{"": "even:必须是偶数"}, fieldMessages(err))
+
+	for _, name := range []string{"", "required", "omitempty", "-", "a,b", "a=b"} {
+		assert.Panics(t, func() { RegisterRule(name, ruleMin) }, name)
+	}
+	assert.Panics(t, func() { RegisterRule("nil", nil) })
+}
+
+// TestFieldErrors 测试从错误链中读取 FieldError。
+func TestFieldErrors(t *testing.T) {
+	assert.Nil(t, FieldErrors(nil))
+	assert.Nil(t, FieldErrors(errors.New("x")))
+
+	inner := &FieldError{Field: "a", Rule: "custom", Message: "bad", cause: errors.ErrUnsupported}
+	err := kiterrors.Wrap(inner, "wrapped")
+	assert.Equal(t, []*FieldError{inner}, FieldErrors(err))
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+}
@@ -0,0 +1,338 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	stdsync "sync"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+)
+
+type (
+	// Conn 是连接管理器中的一个 WebSocket 连接。
+	// 写入全部在写协程中完成，Send 只将消息放入发送缓冲区，因此可以在任意协程中并发调用。
+	Conn struct {
+		// m 是连接所属的连接管理器。
+		m *Manager
+		// ws 是底层的 WebSocket 连接。
+		ws *gorillaws.Conn
+		// id 是连接在连接管理器内的编号。
+		id uint64
+		// req 是升级前的 HTTP 请求。
+		req *http.Request
+		// ctx 在连接开始关闭时取消。
+		ctx context.Context
+		// cancel 取消 ctx。
+		cancel context.CancelFunc
+		// send 是发送缓冲区，只由写协程读取，从不关闭。
+		send chan outbound
+
+		// closeOnce 保证关闭只被请求一次。
+		closeOnce stdsync.Once
+		// closing 在请求关闭时关闭。
+		closing chan struct{}
+		// closeFrame 是本端主动关闭时发送的关闭帧，对端关闭或出错时为 nil，在关闭 closing 之前设置。
+		closeFrame []byte
+		// readDone 在读协程退出时关闭。
+		readDone chan struct{}
+		// readErr 是读协程退出的原因，在关闭 readDone 之前设置。
+		readErr error
+		// writeErr 是写协程写入失败的错误，只由写协程访问。
+		writeErr error
+		// done 在连接结束、OnClose 返回后关闭。
+		done chan struct{}
+
+		// groups 是连接加入的组，由连接管理器的锁保护。
+		groups map[string]struct{}
+	}
+
+	// outbound 是等待发送的一条消息。
+	outbound struct {
+		// messageType 是消息类型。
+		messageType int
+		// data 是消息内容。
+		data []byte
+	}
+)
+
+// newConn 创建连接。
+func newConn(m *Manager, ws *gorillaws.Conn, r *http.Request) *Conn {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(r.Context()))
+	return &Conn{
+		m:        m,
+		ws:       ws,
+		id:       m.nextID.Add(1),
+		req:      r,
+		ctx:      ctx,
+		cancel:   cancel,
+		send:     make(chan outbound, m.o.sendBuffer),
+		closing:  make(chan struct{}),
+		readDone: make(chan struct{}),
+		done:     make(chan struct{}),
+		groups:   make(map[string]struct{}),
+	}
+}
+
+// ID 返回连接在连接管理器内的编号，从 1 开始递增。
+//
+// 返回值：
+//   - uint64：连接编号。
+func (c *Conn) ID() uint64 {
+	return c.id
+}
+
+// Request 返回升级前的 HTTP 请求，可以从中读取鉴权信息与查询参数。
+//
+// 返回值：
+//   - *http.Request：升级请求。
+func (c *Conn) Request() *http.Request {
+	return c.req
+}
+
+// Subprotocol 返回协商的子协议。
+//
+// 返回值：
+//   - string：子协议，没有协商时为空字符串。
+func (c *Conn) Subprotocol() string {
+	return c.ws.Subprotocol()
+}
+
+// Context 返回连接的上下文，包含升级请求上下文中的值，在连接开始关闭时取消。
+//
+// 返回值：
+//   - context.Context：连接的上下文。
+func (c *Conn) Context() context.Context {
+	return c.ctx
+}
+
+// Done 返回在连接结束、WithOnClose 设置的函数返回后关闭的通道。
+//
+// 返回值：
+//   - <-chan struct{}：连接结束后关闭的通道。
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Send 将消息放入发送缓冲区，由写协程发送，不等待发送完成。
+//
+// 参数：
+//   - messageType：消息类型，TextMessage 或 BinaryMessage。
+//   - data：消息内容，发送完成前不能修改。
+//
+// 返回值：
+//   - error：连接已经关闭或正在关闭时返回 ErrClosed，发送缓冲区已满时返回 ErrSendBufferFull。
+func (c *Conn) Send(messageType int, data []byte) error {
+	select {
+	case <-c.closing:
+		return ErrClosed
+	default:
+	}
+	select {
+	case c.send <- outbound{messageType: messageType, data: data}:
+		return nil
+	default:
+		return ErrSendBufferFull
+	}
+}
+
+// SendText 将文本消息放入发送缓冲区。
+//
+// 参数：
+//   - text：消息内容。
+//
+// 返回值：
+//   - error：与 Send 相同。
+func (c *Conn) SendText(text string) error {
+	return c.Send(TextMessage, []byte(text))
+}
+
+// Join 将连接加入组，之后 BroadcastGroup 向该组发送的消息会发送到该连接。连接结束时自动离开全部组。
+//
+// 参数：
+//   - group：组名。
+//
+// 返回值：
+//   - error：连接已经结束时返回 ErrClosed。
+func (c *Conn) Join(group string) error {
+	return c.m.join(c, group)
+}
+
+// Leave 将连接移出组，连接不在组内时不执行任何操作。
+//
+// 参数：
+//   - group：组名。
+func (c *Conn) Leave(group string) {
+	c.m.mu.Lock()
+	defer c.m.mu.Unlock()
+	c.m.leave(c, group)
+}
+
+// Groups 返回连接加入的全部组，按组名排序。
+//
+// 返回值：
+//   - []string：组名。
+func (c *Conn) Groups() []string {
+	c.m.mu.RLock()
+	defer c.m.mu.RUnlock()
+	groups := make([]string, 0, len(c.groups))
+	for g := range c.groups {
+		groups = append(groups, g)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// Close 优雅地关闭连接：先发送缓冲区中已有的消息，再发送 1000（normal closure）关闭帧，
+// 等待对端回应关闭帧或写超时后关闭底层连接。Close 不等待连接结束，需要时等待 Done 返回的通道。
+//
+// 返回值：
+//   - error：始终为 nil，重复调用不执行任何操作。
+func (c *Conn) Close() error {
+	c.requestClose(gorillaws.FormatCloseMessage(gorillaws.CloseNormalClosure, ""))
+	return nil
+}
+
+// requestClose 请求关闭连接，frame 为本端主动关闭时发送的关闭帧，对端关闭或出错时为 nil。只有第一次请求生效。
+func (c *Conn) requestClose(frame []byte) {
+	c.closeOnce.Do(func() {
+		c.closeFrame = frame
+		close(c.closing)
+		c.cancel()
+	})
+}
+
+// deadline 返回当前写入操作的截止时间。
+func (c *Conn) deadline() time.Time {
+	return time.Now().Add(c.m.o.writeTimeout)
+}
+
+// readPump 是读协程，读取消息并调用 WithOnMessage 设置的函数，收到任何数据都会延长读超时。
+func (c *Conn) readPump() {
+	defer close(c.readDone)
+
+	o := c.m.o
+	c.ws.SetReadLimit(o.maxMessageSize)
+	extend := func(string) error {
+		return c.ws.SetReadDeadline(time.Now().Add(o.pongWait))
+	}
+	_ = extend("")
+	c.ws.SetPongHandler(extend)
+
+	for {
+		messageType, data, err := c.ws.ReadMessage()
+		if nil != err {
+			c.readErr = err
+			c.requestClose(nil)
+			return
+		}
+		_ = extend("")
+		if nil != o.onMessage {
+			o.onMessage(c, messageType, data)
+		}
+	}
+}
+
+// writePump 是写协程，发送缓冲区中的消息并定期发送 Ping，请求关闭后完成关闭流程并结束连接。
+func (c *Conn) writePump() {
+	ticker := time.NewTicker(c.m.o.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg := <-c.send:
+			c.write(msg)
+		case <-ticker.C:
+			if err := c.ws.WriteControl(gorillaws.PingMessage, nil, c.deadline()); nil != err {
+				c.fail(err)
+			}
+		case <-c.closing:
+			c.shutdown()
+			return
+		}
+	}
+}
+
+// write 发送一条消息，失败时请求关闭连接。
+func (c *Conn) write(msg outbound) {
+	_ = c.ws.SetWriteDeadline(c.deadline())
+	if err := c.ws.WriteMessage(msg.messageType, msg.data); nil != err {
+		c.fail(err)
+	}
+}
+
+// fail 记录写入失败的错误并请求关闭连接。
+func (c *Conn) fail(err error) {
+	if nil == c.writeErr {
+		c.writeErr = err
+	}
+	c.requestClose(nil)
+}
+
+// shutdown 完成关闭流程：本端主动关闭时先发送缓冲区中的消息与关闭帧，并等待对端回应；然后关闭底层连接并结束连接。
+func (c *Conn) shutdown() {
+	if nil != c.closeFrame && nil == c.writeErr {
+		c.flush()
+		if nil == c.writeErr && nil == c.ws.WriteControl(gorillaws.CloseMessage, c.closeFrame, c.deadline()) {
+			timer := time.NewTimer(c.m.o.writeTimeout)
+			select {
+			case <-c.readDone:
+			case <-timer.C:
+			}
+			timer.Stop()
+		}
+	}
+	_ = c.ws.Close()
+	<-c.readDone
+	c.finish()
+}
+
+// flush 发送缓冲区中已有的消息。
+func (c *Conn) flush() {
+	for nil == c.writeErr {
+		select {
+		case msg := <-c.send:
+			c.write(msg)
+		default:
+			return
+		}
+	}
+}
+
+// abort 在写协程无法启动时结束连接。
+func (c *Conn) abort(err error) {
+	c.readErr = err
+	close(c.readDone)
+	c.requestClose(nil)
+	_ = c.ws.Close()
+	c.finish()
+}
+
+// finish 注销连接，调用 WithOnClose 设置的函数并关闭 done。
+func (c *Conn) finish() {
+	c.m.remove(c)
+	if nil != c.m.o.onClose {
+		c.m.o.onClose(c, c.closeErr())
+	}
+	close(c.done)
+	c.m.wg.Done()
+}
+
+// closeErr 返回连接结束的原因，本端主动关闭或对端正常关闭时返回 nil。
+func (c *Conn) closeErr() error {
+	switch {
+	case nil != c.closeFrame:
+		return nil
+	case nil != c.writeErr:
+		return c.writeErr
+	case gorillaws.IsCloseError(c.readErr, gorillaws.CloseNormalClosure, gorillaws.CloseGoingAway, gorillaws.CloseNoStatusReceived):
+		return nil
+	default:
+		return c.readErr
+	}
+}
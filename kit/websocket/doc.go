@@ -0,0 +1,32 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+/*
+Package websocket 提供了 WebSocket 连接管理器，为实时接口提供统一的连接管理。
+
+主要功能：
+
+  - 升级：Manager 实现了 http.Handler，也可以通过 Upgrade 在鉴权之后手动升级
+  - 读写协程：每个连接的读写协程运行在 kit/runtime/goroutine 的协程池中，写入全部在写协程中完成，Send 可以并发调用
+  - 心跳：定期发送 Ping，超过 WithPongWait 没有收到任何数据时关闭连接
+  - 广播：连接通过 Join 加入组，Broadcast 与 BroadcastGroup 向全部连接或组内连接发送消息，跳过读取过慢的连接
+  - 优雅关闭：Conn.Close 与 Manager.Shutdown 先发送缓冲区中的消息，再发送关闭帧并等待对端回应
+
+基本使用：
+
+	var m *websocket.Manager
+	m = websocket.New(
+	    websocket.WithOnConnect(func(c *websocket.Conn) {
+	        _ = c.Join("lobby")
+	    }),
+	    websocket.WithOnMessage(func(c *websocket.Conn, typ int, data []byte) {
+	        m.BroadcastGroup("lobby", typ, data)
+	    }),
+	)
+	mux.Handle("GET /ws", m)
+
+	// 停止 HTTP 服务之前关闭全部连接。
+	_ = m.Shutdown(ctx)
+*/
+package websocket
@@ -0,0 +1,322 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	stdsync "sync"
+	"sync/atomic"
+
+	gorillaws "github.com/gorilla/websocket"
+)
+
+const (
+	// TextMessage 表示 UTF-8 编码的文本消息。
+	TextMessage = gorillaws.TextMessage
+	// BinaryMessage 表示二进制消息。
+	BinaryMessage = gorillaws.BinaryMessage
+
+	// shutdownReason 是连接管理器关闭时发送给对端的关闭原因。
+	shutdownReason = "server shutdown"
+)
+
+var (
+	// ErrShutdown 表示连接管理器已经关闭，不再接受新的连接。
+	ErrShutdown = errors.New("kit/websocket: 连接管理器已关闭")
+	// ErrClosed 表示连接已经关闭或正在关闭。
+	ErrClosed = errors.New("kit/websocket: 连接已关闭")
+	// ErrSendBufferFull 表示连接的发送缓冲区已满，通常是对端读取过慢。
+	ErrSendBufferFull = errors.New("kit/websocket: 发送缓冲区已满")
+)
+
+type (
+	// Manager 管理 WebSocket 连接：将 HTTP 请求升级为连接，在协程池中为每个连接运行读写协程，
+	// 定期发送 Ping 并在对端失联时关闭连接，按组广播消息，并在关闭时向全部连接发送关闭帧、等待连接结束。
+	// Manager 实现了 http.Handler，可以直接注册到路由上。所有方法都是并发安全的。
+	Manager struct {
+		// o 是连接管理器的配置。
+		o *options
+		// upgrader 将 HTTP 请求升级为 WebSocket 连接。
+		upgrader gorillaws.Upgrader
+		// nextID 是下一个连接的编号。
+		nextID atomic.Uint64
+		// wg 等待全部连接结束。
+		wg stdsync.WaitGroup
+
+		// mu 保护以下字段以及每个连接的 groups。
+		mu stdsync.RWMutex
+		// conns 是全部活动的连接。
+		conns map[*Conn]struct{}
+		// groups 是按组名索引的连接。
+		groups map[string]map[*Conn]struct{}
+		// closed 表示 Shutdown 已经被调用。
+		closed bool
+	}
+)
+
+// New 创建连接管理器。
+//
+// 参数：
+//   - opts：配置选项。
+//
+// 返回值：
+//   - *Manager：连接管理器，可以直接作为 http.Handler 注册到路由上。
+//
+// 示例：
+//
+//	m := websocket.New(
+//	    websocket.WithOnConnect(func(c *websocket.Conn) {
+//	        _ = c.Join("room:" + c.Request().URL.Query().Get("room"))
+//	    }),
+//	    websocket.WithOnMessage(func(c *websocket.Conn, typ int, data []byte) {
+//	        m.BroadcastGroup("room:1", typ, data)
+//	    }),
+//	)
+//	mux.Handle("GET /ws", m)
+//	defer m.Shutdown(shutdownCtx)
+func New(opts ...Option) *Manager {
+	o := newOptions(opts...)
+	return &Manager{
+		o: o,
+		upgrader: gorillaws.Upgrader{
+			HandshakeTimeout: o.writeTimeout,
+			CheckOrigin:      o.checkOrigin,
+			Subprotocols:     o.subprotocols,
+		},
+		conns:  make(map[*Conn]struct{}),
+		groups: make(map[string]map[*Conn]struct{}),
+	}
+}
+
+// ServeHTTP 将请求升级为 WebSocket 连接，实现了 http.Handler 接口。
+// 升级失败时已经向客户端返回了错误响应；连接管理器关闭后返回 503。
+//
+// 参数：
+//   - w：响应。
+//   - r：请求。
+func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_, _ = m.Upgrade(w, r, nil)
+}
+
+// Upgrade 将请求升级为 WebSocket 连接，调用 WithOnConnect 设置的函数后启动读写协程。
+// 适用于需要在升级前完成鉴权、或需要设置响应头的场景。
+//
+// 参数：
+//   - w：响应。
+//   - r：请求，连接的 Request 返回该请求。
+//   - responseHeader：升级响应中附加的响应头，可以为 nil。
+//
+// 返回值：
+//   - *Conn：建立的连接。
+//   - error：连接管理器已经关闭时返回 ErrShutdown，升级失败时返回对应的错误，此时已经向客户端返回了错误响应。
+func (m *Manager) Upgrade(w http.ResponseWriter, r *http.Request, responseHeader http.Header) (*Conn, error) {
+	m.mu.RLock()
+	closed := m.closed
+	m.mu.RUnlock()
+	if closed {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return nil, ErrShutdown
+	}
+
+	ws, err := m.upgrader.Upgrade(w, r, responseHeader)
+	if nil != err {
+		m.o.getLogger().Debug("kit/websocket: 升级连接失败：", err)
+		return nil, err
+	}
+
+	c := newConn(m, ws, r)
+	if !m.add(c) {
+		_ = ws.WriteControl(gorillaws.CloseMessage, gorillaws.FormatCloseMessage(gorillaws.CloseGoingAway, shutdownReason), c.deadline())
+		_ = ws.Close()
+		return nil, ErrShutdown
+	}
+
+	if nil != m.o.onConnect {
+		m.o.onConnect(c)
+	}
+
+	if err := m.o.submit(c.writePump); nil != err {
+		m.o.getLogger().Error("kit/websocket: 启动写协程失败：", err)
+		c.abort(fmt.Errorf("kit/websocket: 启动写协程失败：%w", err))
+		return nil, err
+	}
+	if err := m.o.submit(c.readPump); nil != err {
+		m.o.getLogger().Error("kit/websocket: 启动读协程失败：", err)
+		c.readErr = fmt.Errorf("kit/websocket: 启动读协程失败：%w", err)
+		close(c.readDone)
+		c.requestClose(nil)
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Broadcast 向全部连接发送消息。发送缓冲区已满或正在关闭的连接会被跳过。
+//
+// 参数：
+//   - messageType：消息类型，TextMessage 或 BinaryMessage。
+//   - data：消息内容，发送完成前不能修改。
+//
+// 返回值：
+//   - int：成功放入发送缓冲区的连接数。
+func (m *Manager) Broadcast(messageType int, data []byte) int {
+	m.mu.RLock()
+	conns := make([]*Conn, 0, len(m.conns))
+	for c := range m.conns {
+		conns = append(conns, c)
+	}
+	m.mu.RUnlock()
+
+	return broadcast(conns, messageType, data)
+}
+
+// BroadcastGroup 向组内的全部连接发送消息。发送缓冲区已满或正在关闭的连接会被跳过。
+//
+// 参数：
+//   - group：组名。
+//   - messageType：消息类型，TextMessage 或 BinaryMessage。
+//   - data：消息内容，发送完成前不能修改。
+//
+// 返回值：
+//   - int：成功放入发送缓冲区的连接数。
+func (m *Manager) BroadcastGroup(group string, messageType int, data []byte) int {
+	m.mu.RLock()
+	members := m.groups[group]
+	conns := make([]*Conn, 0, len(members))
+	for c := range members {
+		conns = append(conns, c)
+	}
+	m.mu.RUnlock()
+
+	return broadcast(conns, messageType, data)
+}
+
+// Count 返回活动的连接数。
+//
+// 返回值：
+//   - int：连接数。
+func (m *Manager) Count() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.conns)
+}
+
+// GroupSize 返回组内的连接数。
+//
+// 参数：
+//   - group：组名。
+//
+// 返回值：
+//   - int：连接数，组不存在时为 0。
+func (m *Manager) GroupSize(group string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.groups[group])
+}
+
+// Shutdown 停止接受新的连接，向全部连接发送 1001（going away）关闭帧，并等待连接结束。
+// 关闭前已经放入发送缓冲区的消息会先被发送。ctx 结束时强制关闭剩余的连接并返回 ctx 的错误。
+// 通常在 HTTP 服务停止之前调用，因为升级后的连接不受 http.Server.Shutdown 管理。
+//
+// 参数：
+//   - ctx：提供关闭操作的截止时间。
+//
+// 返回值：
+//   - error：全部连接在截止时间前结束时返回 nil，否则返回 ctx 的错误。
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.closed = true
+	conns := make([]*Conn, 0, len(m.conns))
+	for c := range m.conns {
+		conns = append(conns, c)
+	}
+	m.mu.Unlock()
+
+	frame := gorillaws.FormatCloseMessage(gorillaws.CloseGoingAway, shutdownReason)
+	for _, c := range conns {
+		c.requestClose(frame)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		for _, c := range conns {
+			_ = c.ws.Close()
+		}
+		return ctx.Err()
+	}
+}
+
+// add 登记新的连接，连接管理器已经关闭时返回 false。
+func (m *Manager) add(c *Conn) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return false
+	}
+	m.conns[c] = struct{}{}
+	m.wg.Add(1)
+	return true
+}
+
+// remove 注销连接，并将连接移出全部组。
+func (m *Manager) remove(c *Conn) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.conns, c)
+	for group := range c.groups {
+		m.leave(c, group)
+	}
+}
+
+// join 将连接加入组，连接已经注销时返回 ErrClosed。
+func (m *Manager) join(c *Conn, group string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.conns[c]; !ok {
+		return ErrClosed
+	}
+	members, ok := m.groups[group]
+	if !ok {
+		members = make(map[*Conn]struct{})
+		m.groups[group] = members
+	}
+	members[c] = struct{}{}
+	c.groups[group] = struct{}{}
+	return nil
+}
+
+// leave 将连接移出组，组为空时删除组，调用方负责加锁。
+func (m *Manager) leave(c *Conn, group string) {
+	delete(c.groups, group)
+	members, ok := m.groups[group]
+	if !ok {
+		return
+	}
+	delete(members, c)
+	if 0 == len(members) {
+		delete(m.groups, group)
+	}
+}
+
+// broadcast 向 conns 发送消息，返回成功放入发送缓冲区的连接数。
+func broadcast(conns []*Conn, messageType int, data []byte) int {
+	n := 0
+	for _, c := range conns {
+		if nil == c.Send(messageType, data) {
+			n++
+		}
+	}
+	return n
+}
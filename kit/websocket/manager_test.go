@@ -0,0 +1,304 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+type (
+	// closeEvent 记录一次 OnClose 调用。
+	closeEvent struct {
+		conn *Conn
+		err  error
+	}
+)
+
+// startServer 启动使用 m 处理请求的测试服务，返回 WebSocket 地址。
+func startServer(t *testing.T, m *Manager) string {
+	srv := httptest.NewServer(m)
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+// dial 连接测试服务。
+func dial(t *testing.T, url string) *gorillaws.Conn {
+	ws, resp, err := gorillaws.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	t.Cleanup(func() { _ = ws.Close() })
+	return ws
+}
+
+// readText 读取一条文本消息。
+func readText(t *testing.T, ws *gorillaws.Conn) string {
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+	typ, data, err := ws.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, TextMessage, typ)
+	return string(data)
+}
+
+// TestManager_Echo 测试连接建立、收发消息、加入组，以及对端正常关闭后注销连接。
+func TestManager_Echo(t *testing.T) {
+	closed := make(chan closeEvent, 1)
+	m := New(
+		WithOnConnect(func(c *Conn) {
+			assert.NoError(t, c.Join("room"))
+			assert.NoError(t, c.SendText("welcome"))
+		}),
+		WithOnMessage(func(c *Conn, typ int, data []byte) {
+			assert.NoError(t, c.Send(typ, append([]byte("echo:"), data...)))
+		}),
+		WithOnClose(func(c *Conn, err error) {
+			closed <- closeEvent{c, err}
+		}),
+		WithSubprotocols("chat"),
+	)
+	url := startServer(t, m)
+
+	dialer := gorillaws.Dialer{Subprotocols: []string{"chat"}}
+	ws, resp, err := dialer.Dial(url+"?user=alice", nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, "welcome", readText(t, ws))
+
+	require.NoError(t, ws.WriteMessage(TextMessage, []byte("hi")))
+	assert.Equal(t, "echo:hi", readText(t, ws))
+	assert.Equal(t, 1, m.Count())
+	assert.Equal(t, 1, m.GroupSize("room"))
+
+	require.NoError(t, ws.WriteMessage(gorillaws.CloseMessage, gorillaws.FormatCloseMessage(gorillaws.CloseNormalClosure, "")))
+	e := <-closed
+	assert.NoError(t, e.err)
+	assert.Equal(t, uint64(1), e.conn.ID())
+	assert.Equal(t, "alice", e.conn.Request().URL.Query().Get("user"))
+	assert.Equal(t, "chat", e.conn.Subprotocol())
+	assert.Error(t, e.conn.Context().Err())
+	assert.Empty(t, e.conn.Groups())
+	<-e.conn.Done()
+	assert.Equal(t, 0, m.Count())
+	assert.Equal(t, 0, m.GroupSize("room"))
+	assert.ErrorIs(t, e.conn.SendText("late"), ErrClosed)
+	assert.ErrorIs(t, e.conn.Join("room"), ErrClosed)
+}
+
+// TestManager_Broadcast 测试向全部连接与组内连接广播，以及离开组。
+func TestManager_Broadcast(t *testing.T) {
+	conns := make(chan *Conn, 3)
+	m := New(WithOnConnect(func(c *Conn) { conns <- c }))
+	url := startServer(t, m)
+
+	clients := []*gorillaws.Conn{dial(t, url), dial(t, url), dial(t, url)}
+	var server []*Conn
+	for range clients {
+		server = append(server, <-conns)
+	}
+	// 连接按建立顺序编号。
+	for i, c := range server {
+		assert.Equal(t, uint64(i+1), c.ID())
+	}
+	require.NoError(t, server[0].Join("a"))
+	require.NoError(t, server[1].Join("a"))
+	require.NoError(t, server[1].Join("b"))
+	assert.Equal(t, []string{"a", "b"}, server[1].Groups())
+
+	assert.Equal(t, 2, m.BroadcastGroup("a", TextMessage, []byte("to-a")))
+	assert.Equal(t, 0, m.BroadcastGroup("none", TextMessage, []byte("none")))
+	server[1].Leave("a")
+	server[1].Leave("missing")
+	assert.Equal(t, 1, m.GroupSize("a"))
+	assert.Equal(t, 3, m.Broadcast(TextMessage, []byte("to-all")))
+
+	assert.Equal(t, "to-a", readText(t, clients[0]))
+	assert.Equal(t, "to-all", readText(t, clients[0]))
+	assert.Equal(t, "to-a", readText(t, clients[1]))
+	assert.Equal(t, "to-all", readText(t, clients[1]))
+	assert.Equal(t, "to-all", readText(t, clients[2]))
+}
+
+// TestManager_Heartbeat 测试读取中的客户端自动回应 Ping 保持连接，不回应的客户端超时后被关闭。
+func TestManager_Heartbeat(t *testing.T) {
+	closed := make(chan closeEvent, 2)
+	m := New(
+		WithPongWait(300*time.Millisecond),
+		WithPingInterval(50*time.Millisecond),
+		WithOnClose(func(c *Conn, err error) { closed <- closeEvent{c, err} }),
+	)
+	url := startServer(t, m)
+
+	// 客户端只有在读取时才会回应 Ping。
+	alive := dial(t, url)
+	pings := make(chan struct{}, 100)
+	alive.SetPingHandler(func(data string) error {
+		pings <- struct{}{}
+		return alive.WriteControl(gorillaws.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	go func() {
+		for {
+			if _, _, err := alive.ReadMessage(); nil != err {
+				return
+			}
+		}
+	}()
+	dial(t, url)
+
+	e := <-closed
+	assert.Error(t, e.err, "不回应 Ping 的连接应因读超时被关闭")
+	assert.Equal(t, uint64(2), e.conn.ID())
+	assert.GreaterOrEqual(t, len(pings), 3)
+	assert.Equal(t, 1, m.Count())
+}
+
+// TestManager_Shutdown 测试关闭时先发送缓冲区中的消息，再发送 1001 关闭帧，之后拒绝新的连接。
+func TestManager_Shutdown(t *testing.T) {
+	conns := make(chan *Conn, 1)
+	closed := make(chan closeEvent, 1)
+	m := New(
+		WithOnConnect(func(c *Conn) { conns <- c }),
+		WithOnClose(func(c *Conn, err error) { closed <- closeEvent{c, err} }),
+	)
+	url := startServer(t, m)
+
+	ws := dial(t, url)
+	c := <-conns
+	require.NoError(t, c.SendText("last"))
+
+	result := make(chan error, 1)
+	go func() { result <- m.Shutdown(context.Background()) }()
+
+	assert.Equal(t, "last", readText(t, ws))
+	_, _, err := ws.ReadMessage()
+	assert.True(t, gorillaws.IsCloseError(err, gorillaws.CloseGoingAway), "%v", err)
+	require.NoError(t, <-result)
+	assert.NoError(t, (<-closed).err)
+
+	_, resp, err := gorillaws.DefaultDialer.Dial(url, nil)
+	assert.ErrorIs(t, err, gorillaws.ErrBadHandshake)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	_ = resp.Body.Close()
+}
+
+// TestManager_ShutdownDeadline 测试对端不回应关闭帧时，截止时间到达后强制关闭连接。
+func TestManager_ShutdownDeadline(t *testing.T) {
+	conns := make(chan *Conn, 1)
+	m := New(WithWriteTimeout(5*time.Second), WithOnConnect(func(c *Conn) { conns <- c }))
+	url := startServer(t, m)
+
+	dial(t, url)
+	c := <-conns
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, m.Shutdown(ctx), context.DeadlineExceeded)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("强制关闭后连接应结束")
+	}
+}
+
+// TestConn_Close 测试本端主动关闭连接。
+func TestConn_Close(t *testing.T) {
+	closed := make(chan closeEvent, 1)
+	m := New(
+		WithOnConnect(func(c *Conn) { _ = c.Close() }),
+		WithOnClose(func(c *Conn, err error) { closed <- closeEvent{c, err} }),
+	)
+	ws := dial(t, startServer(t, m))
+
+	_, _, err := ws.ReadMessage()
+	assert.True(t, gorillaws.IsCloseError(err, gorillaws.CloseNormalClosure), "%v", err)
+	assert.NoError(t, (<-closed).err)
+}
+
+// TestConn_Send 测试发送缓冲区已满与连接关闭后的发送。
+func TestConn_Send(t *testing.T) {
+	m := New(WithSendBuffer(1))
+	c := newConn(m, nil, httptest.NewRequest(http.MethodGet, "/ws", nil))
+
+	assert.NoError(t, c.SendText("a"))
+	assert.ErrorIs(t, c.SendText("b"), ErrSendBufferFull)
+	assert.ErrorIs(t, c.Join("a"), ErrClosed, "未登记的连接不能加入组")
+
+	c.requestClose(nil)
+	assert.ErrorIs(t, c.SendText("c"), ErrClosed)
+}
+
+// TestManager_UpgradeErrors 测试升级失败的情况：不是 WebSocket 请求、Origin 校验失败。
+func TestManager_UpgradeErrors(t *testing.T) {
+	m := New(WithCheckOrigin(func(r *http.Request) bool {
+		return "https://app.example.com" == r.Header.Get("Origin")
+	}))
+	url := startServer(t, m)
+
+	resp, err := http.Get("http" + strings.TrimPrefix(url, "ws"))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	_, resp, err = gorillaws.DefaultDialer.Dial(url, http.Header{"Origin": []string{"https://evil.example.com"}})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	ws, resp, err := gorillaws.DefaultDialer.Dial(url, http.Header{"Origin": []string{"https://app.example.com"}})
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	_ = ws.Close()
+}
+
+// TestManager_Pool 测试读写协程运行在指定的协程池中，协程池无法运行读写协程时结束连接。
+func TestManager_Pool(t *testing.T) {
+	pool, release, err := goroutine.NewGoroutinePool(goroutine.WithSize(1), goroutine.WithNonBlocking(true), goroutine.WithMetrics(false))
+	require.NoError(t, err)
+	defer release()
+
+	closed := make(chan closeEvent, 2)
+	m := New(WithPool(pool), WithOnClose(func(c *Conn, err error) { closed <- closeEvent{c, err} }))
+	url := startServer(t, m)
+
+	// 写协程占用唯一的协程，读协程无法启动。
+	dial(t, url)
+	e := <-closed
+	assert.ErrorContains(t, e.err, "启动读协程失败")
+
+	// 协程池被占满时写协程无法启动。
+	block := make(chan struct{})
+	defer close(block)
+	require.Eventually(t, func() bool { return nil == pool.Submit(func() { <-block }) }, time.Second, 10*time.Millisecond)
+	dial(t, url)
+	e = <-closed
+	assert.ErrorContains(t, e.err, "启动写协程失败")
+	assert.Equal(t, 0, m.Count())
+}
+
+// TestNewOptions 测试非法的参数使用默认值。
+func TestNewOptions(t *testing.T) {
+	o := newOptions(
+		WithPongWait(0),
+		WithPingInterval(time.Hour),
+		WithWriteTimeout(-1),
+		WithMaxMessageSize(0),
+		WithSendBuffer(0),
+	)
+	assert.Equal(t, pongWaitDefault, o.pongWait)
+	assert.Equal(t, pongWaitDefault*9/10, o.pingInterval)
+	assert.Equal(t, writeTimeoutDefault, o.writeTimeout)
+	assert.Equal(t, maxMessageSizeDefault, o.maxMessageSize)
+	assert.Equal(t, sendBufferDefault, o.sendBuffer)
+	assert.NotNil(t, o.getLogger())
+}
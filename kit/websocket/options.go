@@ -0,0 +1,268 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package websocket
+
+import (
+	"net/http"
+	"time"
+
+	kitlog "github.com/fsyyft-go/monorepo/kit/log"
+	"github.com/fsyyft-go/monorepo/kit/runtime/goroutine"
+)
+
+// 以下为连接管理器的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// pongWaitDefault 为等待对端消息或 Pong 的默认最长时间。
+	pongWaitDefault = 60 * time.Second
+	// writeTimeoutDefault 为写入一条消息的默认超时时间。
+	writeTimeoutDefault = 10 * time.Second
+	// maxMessageSizeDefault 为默认允许读取的最大消息字节数。
+	maxMessageSizeDefault = int64(64 << 10)
+	// sendBufferDefault 为每个连接默认的发送缓冲区大小。
+	sendBufferDefault = 256
+)
+
+type (
+	// Option 定义了连接管理器的配置选项。
+	Option func(*options)
+
+	// options 包含连接管理器的配置。
+	options struct {
+		// pingInterval 是发送 Ping 的间隔，为 0 时为 pongWait 的 9/10。
+		pingInterval time.Duration
+		// pongWait 是等待对端消息或 Pong 的最长时间，超时后关闭连接。
+		pongWait time.Duration
+		// writeTimeout 是写入一条消息的超时时间。
+		writeTimeout time.Duration
+		// maxMessageSize 是允许读取的最大消息字节数。
+		maxMessageSize int64
+		// sendBuffer 是每个连接的发送缓冲区大小。
+		sendBuffer int
+		// checkOrigin 校验升级请求的 Origin，为 nil 时只允许同源请求。
+		checkOrigin func(r *http.Request) bool
+		// subprotocols 是服务端支持的子协议，按优先级排列。
+		subprotocols []string
+		// onConnect 在连接建立后、开始读写之前调用。
+		onConnect func(c *Conn)
+		// onMessage 在收到消息时调用。
+		onMessage func(c *Conn, messageType int, data []byte)
+		// onClose 在连接关闭、读写协程都退出后调用。
+		onClose func(c *Conn, err error)
+		// pool 是运行读写协程的协程池，为 nil 时使用 kit/runtime/goroutine 的默认协程池。
+		pool goroutine.GoroutinePool
+		// logger 是记录连接错误的日志实例，为 nil 时使用 kit/log 的全局日志实例。
+		logger kitlog.Logger
+	}
+)
+
+// WithPingInterval 设置向对端发送 Ping 的间隔。
+//
+// 参数：
+//   - interval：发送间隔，默认为 pongWait 的 9/10，小于等于 0 或不小于 pongWait 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPingInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.pingInterval = interval
+	}
+}
+
+// WithPongWait 设置等待对端消息或 Pong 的最长时间，超过该时间没有收到任何数据时认为对端已经失联并关闭连接。
+//
+// 参数：
+//   - wait：等待时间，默认为 60 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPongWait(wait time.Duration) Option {
+	return func(o *options) {
+		o.pongWait = wait
+	}
+}
+
+// WithWriteTimeout 设置写入一条消息（包括 Ping 与关闭帧）的超时时间，超时后关闭连接。
+//
+// 参数：
+//   - timeout：超时时间，默认为 10 秒，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.writeTimeout = timeout
+	}
+}
+
+// WithMaxMessageSize 设置允许读取的最大消息字节数，超过时关闭连接。
+//
+// 参数：
+//   - size：最大字节数，默认为 64 KiB，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithMaxMessageSize(size int64) Option {
+	return func(o *options) {
+		o.maxMessageSize = size
+	}
+}
+
+// WithSendBuffer 设置每个连接的发送缓冲区大小，缓冲区满时 Send 返回 ErrSendBufferFull。
+//
+// 参数：
+//   - size：可以缓冲的消息数，默认为 256，小于等于 0 时使用默认值。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithSendBuffer(size int) Option {
+	return func(o *options) {
+		o.sendBuffer = size
+	}
+}
+
+// WithCheckOrigin 设置校验升级请求 Origin 的函数，返回 false 时拒绝升级。
+//
+// 参数：
+//   - fn：校验函数，默认只允许没有 Origin 或 Origin 与 Host 相同的请求。
+//
+// 返回值：
+//   - Option：配置选项函数。
+//
+// 示例：
+//
+//	websocket.WithCheckOrigin(func(r *http.Request) bool {
+//	    return r.Header.Get("Origin") == "https://app.example.com"
+//	})
+func WithCheckOrigin(fn func(r *http.Request) bool) Option {
+	return func(o *options) {
+		o.checkOrigin = fn
+	}
+}
+
+// WithSubprotocols 设置服务端支持的子协议，按优先级排列，升级时选择客户端请求的第一个受支持的子协议。
+//
+// 参数：
+//   - protocols：子协议列表。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithSubprotocols(protocols ...string) Option {
+	return func(o *options) {
+		o.subprotocols = protocols
+	}
+}
+
+// WithOnConnect 设置连接建立后调用的函数，在开始读写之前同步调用，可以在其中加入广播组或发送欢迎消息。
+//
+// 参数：
+//   - fn：回调函数。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithOnConnect(fn func(c *Conn)) Option {
+	return func(o *options) {
+		o.onConnect = fn
+	}
+}
+
+// WithOnMessage 设置收到消息时调用的函数。同一连接的消息在读协程中按顺序同步调用，回调返回之前不会读取下一条消息。
+//
+// 参数：
+//   - fn：回调函数，messageType 为 TextMessage 或 BinaryMessage。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithOnMessage(fn func(c *Conn, messageType int, data []byte)) Option {
+	return func(o *options) {
+		o.onMessage = fn
+	}
+}
+
+// WithOnClose 设置连接关闭后调用的函数，调用时连接已经离开全部广播组，读写协程都已退出。
+//
+// 参数：
+//   - fn：回调函数，本端主动关闭或对端正常关闭时 err 为 nil，否则为导致关闭的错误。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithOnClose(fn func(c *Conn, err error)) Option {
+	return func(o *options) {
+		o.onClose = fn
+	}
+}
+
+// WithPool 设置运行读写协程的协程池。每个连接在整个生命周期内占用两个协程，协程池的大小应不小于最大连接数的两倍。
+//
+// 参数：
+//   - pool：协程池，默认为 kit/runtime/goroutine 的默认协程池。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithPool(pool goroutine.GoroutinePool) Option {
+	return func(o *options) {
+		o.pool = pool
+	}
+}
+
+// WithLogger 设置记录连接错误的日志实例。
+//
+// 参数：
+//   - logger：日志实例，默认为 kit/log 的全局日志实例。
+//
+// 返回值：
+//   - Option：配置选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions 创建并应用配置选项，非法的参数使用默认值。
+func newOptions(opts ...Option) *options {
+	o := &options{
+		pongWait:       pongWaitDefault,
+		writeTimeout:   writeTimeoutDefault,
+		maxMessageSize: maxMessageSizeDefault,
+		sendBuffer:     sendBufferDefault,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.pongWait <= 0 {
+		o.pongWait = pongWaitDefault
+	}
+	if o.pingInterval <= 0 || o.pingInterval >= o.pongWait {
+		o.pingInterval = o.pongWait * 9 / 10
+	}
+	if o.writeTimeout <= 0 {
+		o.writeTimeout = writeTimeoutDefault
+	}
+	if o.maxMessageSize <= 0 {
+		o.maxMessageSize = maxMessageSizeDefault
+	}
+	if o.sendBuffer <= 0 {
+		o.sendBuffer = sendBufferDefault
+	}
+
+	return o
+}
+
+// getLogger 返回记录日志使用的日志实例。
+func (o *options) getLogger() kitlog.Logger {
+	if nil != o.logger {
+		return o.logger
+	}
+	return kitlog.GetLogger()
+}
+
+// submit 在协程池中运行 task。
+func (o *options) submit(task func()) error {
+	if nil != o.pool {
+		return o.pool.Submit(task)
+	}
+	return goroutine.Submit(task)
+}